@@ -20,6 +20,7 @@ type AggregateOutput struct {
 	LicensesAggregated []string                `json:"licenses_aggregated,omitempty"` // Detected licenses (unique names only)
 	Dependencies       [][]string              `json:"dependencies,omitempty"`        // All dependencies [type, name, version]
 	CodeStats          interface{}             `json:"code_stats,omitempty"`          // Code statistics (if enabled)
+	Analysis           interface{}             `json:"analysis,omitempty"`            // Project-level analysis results (if enabled)
 }
 
 // Aggregator handles aggregation of scan results
@@ -82,6 +83,9 @@ func (a *Aggregator) Aggregate(payload *types.Payload) *AggregateOutput {
 	// Copy primary_languages from root payload (already extracted from code_stats)
 	output.PrimaryLanguages = payload.PrimaryLanguages
 
+	// Include analysis results if present
+	output.Analysis = payload.Analysis
+
 	return output
 }
 
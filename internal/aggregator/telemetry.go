@@ -0,0 +1,151 @@
+package aggregator
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// TelemetryFormat is the metadata format of the anonymized telemetry summary
+const TelemetryFormat = "telemetry"
+
+// telemetryTopDependencies is the number of most used dependencies in the summary
+const telemetryTopDependencies = 25
+
+// TelemetrySummary is an anonymized, aggregate-only summary of a scan for fleet-wide analytics.
+// It contains no project names, paths, versions, git information, or dependency names.
+type TelemetrySummary struct {
+	Metadata           TelemetryMetadata `json:"metadata"`
+	Components         int               `json:"components"`          // Number of components
+	Dependencies       int               `json:"dependencies"`        // Unique dependencies by ecosystem and name
+	DirectDependencies int               `json:"direct_dependencies"` // Unique dependencies declared directly by a component
+	Ecosystems         map[string]int    `json:"ecosystems"`          // Unique dependencies per ecosystem
+	Licenses           map[string]int    `json:"licenses"`            // Unique dependencies per license ("unknown" when undeclared)
+	TopDependencies    []HashedCount     `json:"top_dependencies"`    // Dependencies used by the most components
+}
+
+// TelemetryMetadata describes the summary without identifying the scan
+type TelemetryMetadata struct {
+	Format      string `json:"format"`
+	Source      string `json:"source"`
+	SpecVersion string `json:"specVersion,omitempty"`
+	Date        string `json:"date"`   // Scan date (YYYY-MM-DD)
+	Salted      bool   `json:"salted"` // Hashes are keyed with a fleet salt
+}
+
+// HashedCount is a dependency identified only by the hash of its ecosystem and name
+type HashedCount struct {
+	Hash       string `json:"hash"`
+	Type       string `json:"type"`
+	Components int    `json:"components"` // Number of components declaring the dependency
+}
+
+// BuildTelemetry summarizes the payload tree for telemetry. Dependency names are hashed with
+// HMAC-SHA256 keyed by the salt, or SHA-256 without a salt; the same dependency has the same
+// hash in every repository scanned with the same salt.
+func BuildTelemetry(payload *types.Payload, salt string, now time.Time) *TelemetrySummary {
+	summary := &TelemetrySummary{
+		Metadata: TelemetryMetadata{
+			Format: TelemetryFormat,
+			Source: "tech-stack-scanner",
+			Date:   now.UTC().Format("2006-01-02"),
+			Salted: salt != "",
+		},
+		Ecosystems:      make(map[string]int),
+		Licenses:        make(map[string]int),
+		TopDependencies: []HashedCount{},
+	}
+	if meta, ok := payload.Metadata.(*metadata.ScanMetadata); ok {
+		summary.Metadata.SpecVersion = meta.SpecVersion
+	}
+
+	type usage struct {
+		depType    string
+		components int
+		direct     bool
+		license    string
+	}
+	usages := make(map[string]*usage)
+	var walk func(p *types.Payload)
+	walk = func(p *types.Payload) {
+		summary.Components++
+		declared := make(map[string]bool)
+		for _, dep := range p.Dependencies {
+			key := dep.Type + ":" + dep.Name
+			u, ok := usages[key]
+			if !ok {
+				u = &usage{depType: dep.Type}
+				usages[key] = u
+			}
+			if !declared[key] {
+				declared[key] = true
+				u.components++
+			}
+			u.direct = u.direct || dep.Direct
+			if u.license == "" {
+				u.license = telemetryLicense(dep)
+			}
+		}
+		for _, child := range p.Children {
+			walk(child)
+		}
+	}
+	walk(payload)
+
+	for key, u := range usages {
+		summary.Dependencies++
+		if u.direct {
+			summary.DirectDependencies++
+		}
+		summary.Ecosystems[u.depType]++
+		license := u.license
+		if license == "" {
+			license = "unknown"
+		}
+		summary.Licenses[license]++
+		summary.TopDependencies = append(summary.TopDependencies, HashedCount{
+			Hash: hashName(key, salt), Type: u.depType, Components: u.components,
+		})
+	}
+
+	sort.Slice(summary.TopDependencies, func(i, j int) bool {
+		a, b := summary.TopDependencies[i], summary.TopDependencies[j]
+		if a.Components != b.Components {
+			return a.Components > b.Components
+		}
+		return a.Hash < b.Hash
+	})
+	if len(summary.TopDependencies) > telemetryTopDependencies {
+		summary.TopDependencies = summary.TopDependencies[:telemetryTopDependencies]
+	}
+	return summary
+}
+
+// telemetryLicense returns the concluded license of a dependency, else the declared one
+func telemetryLicense(dep types.Dependency) string {
+	if license, _ := dep.Metadata[parsers.MetadataLicenseConcluded].(string); license != "" {
+		return license
+	}
+	license, _ := dep.Metadata[parsers.MetadataLicenseDeclared].(string)
+	return license
+}
+
+// hashName returns the first 16 bytes of the keyed or plain SHA-256 of the name as hex
+func hashName(name, salt string) string {
+	var sum []byte
+	if salt != "" {
+		mac := hmac.New(sha256.New, []byte(salt))
+		mac.Write([]byte(name))
+		sum = mac.Sum(nil)
+	} else {
+		digest := sha256.Sum256([]byte(name))
+		sum = digest[:]
+	}
+	return hex.EncodeToString(sum[:16])
+}
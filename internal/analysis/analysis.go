@@ -0,0 +1,68 @@
+// Package analysis provides project-level analyses that run on the completed
+// payload tree after scanning (e.g., upgrade advisories, update tool coverage,
+// machine-learning and GraphQL summaries, version pinning hygiene, pre-release
+// usage, dependency complexity, license rollups, copyleft exposure, supply chain
+// and bus factor risk, base image recommendations, platform support, npm install
+// conflicts, npm patches and overrides, accepted risks). Results are collected in
+// a Report that is attached to the root payload's "analysis" field.
+package analysis
+
+import (
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Report holds the results of all enabled post-scan analyses
+type Report struct {
+	UpgradeAdvisory []UpgradeAdvice        `json:"upgrade_advisory,omitempty"`
+	UpdateCoverage  *UpdateCoverage        `json:"update_coverage,omitempty"`
+	MLStack         *MLStack               `json:"ml_stack,omitempty"`
+	GraphQL         *GraphQLSurface        `json:"graphql,omitempty"`
+	PinningHygiene  *PinningHygiene        `json:"pinning_hygiene,omitempty"`
+	Prereleases     []PrereleaseDependency `json:"prerelease_usage,omitempty"`
+	Complexity      *DependencyComplexity  `json:"complexity,omitempty"`
+	LicenseRollup   *LicenseRollup         `json:"license_rollup,omitempty"`
+	Copyleft        *CopyleftExposure      `json:"copyleft_exposure,omitempty"`
+	SupplyChain     *SupplyChainRisk       `json:"supply_chain_risk,omitempty"`
+	BusFactor       *BusFactorRisk         `json:"bus_factor_risk,omitempty"`
+	BaseImages      []BaseImageAdvice      `json:"base_image_advice,omitempty"`
+	Platforms       *PlatformMatrix        `json:"platform_matrix,omitempty"`
+	Install         *InstallConflicts      `json:"install_conflicts,omitempty"`
+	Patches         *PatchInventory        `json:"patch_inventory,omitempty"`
+	AcceptedRisks   []AcceptedRisk         `json:"accepted_risks,omitempty"`
+}
+
+// IsEmpty returns true if no analysis produced any result
+func (r *Report) IsEmpty() bool {
+	return r == nil || (len(r.UpgradeAdvisory) == 0 && r.UpdateCoverage == nil && r.MLStack == nil && r.GraphQL == nil && r.PinningHygiene == nil && len(r.Prereleases) == 0 && r.Complexity == nil && r.LicenseRollup == nil && r.Copyleft == nil && r.SupplyChain == nil && r.BusFactor == nil && len(r.BaseImages) == 0 && r.Platforms == nil && r.Install == nil && r.Patches == nil && len(r.AcceptedRisks) == 0)
+}
+
+// ReportFor returns the analysis report attached to the payload, creating it if needed
+func ReportFor(payload *types.Payload) *Report {
+	if report, ok := payload.Analysis.(*Report); ok && report != nil {
+		return report
+	}
+	report := &Report{}
+	payload.Analysis = report
+	return report
+}
+
+// walkComponents calls fn for the payload and every descendant
+func walkComponents(payload *types.Payload, fn func(*types.Payload)) {
+	fn(payload)
+	for _, child := range payload.Children {
+		walkComponents(child, fn)
+	}
+}
+
+// recordRegistryOrigin appends a value looked up in the package registry to the origin chain
+// of a dependency metadata map (a copy owned by the caller). A dependency read from a single
+// file gets the file its version came from first.
+func recordRegistryOrigin(metadata map[string]interface{}, dep types.Dependency, field, value string) {
+	if _, ok := metadata[parsers.MetadataOrigin]; !ok {
+		if source := parsers.DependencySource(dep); source != "" {
+			parsers.AppendOrigin(metadata, source, parsers.OriginFieldVersion, dep.Version)
+		}
+	}
+	parsers.AppendOrigin(metadata, parsers.OriginSourceRegistry, field, value)
+}
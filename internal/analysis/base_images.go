@@ -0,0 +1,462 @@
+package analysis
+
+import (
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/registry"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Stages of a base image reported in BaseImageAdvice
+const (
+	StageRuntime = "runtime" // Final stage of the Dockerfile, shipped as the image
+	StageBuild   = "build"   // Earlier stage of a multi-stage build
+)
+
+// Reasons of base image recommendations reported in BaseImageAdvice
+const (
+	BaseImageEOLDistribution = "end-of-life distribution release"
+	BaseImageEOLRuntime      = "end-of-life runtime version"
+	BaseImageDeprecated      = "deprecated image"
+	BaseImageFullVariant     = "full variant in runtime stage"
+	BaseImageToolchain       = "build toolchain in runtime stage"
+)
+
+// Current releases recommended for end-of-life ones
+const (
+	currentDebian       = "trixie"
+	currentDebianNumber = "13"
+	currentUbuntu       = "noble"
+	currentUbuntuNumber = "24.04"
+	currentPython       = "3.13"
+	currentNode         = "24"
+	currentRuby         = "3.4"
+	currentJava         = "21"
+)
+
+// eolDebianReleases and eolUbuntuReleases are the distribution releases without (LTS) support,
+// by codename and version
+var (
+	eolDebianReleases = map[string]bool{
+		"jessie": true, "stretch": true, "buster": true, "bullseye": true,
+		"8": true, "9": true, "10": true, "11": true,
+	}
+	eolUbuntuReleases = map[string]bool{
+		"trusty": true, "xenial": true, "bionic": true, "focal": true,
+		"14.04": true, "16.04": true, "18.04": true, "20.04": true,
+	}
+)
+
+// Distroless runtime images of compiled binaries and of the runtimes available as distroless image
+const (
+	distrolessStatic = "gcr.io/distroless/static-debian12"
+	distrolessCC     = "gcr.io/distroless/cc-debian12"
+)
+
+var (
+	distrolessNode = map[string]string{
+		"20": "gcr.io/distroless/nodejs20-debian12",
+		"22": "gcr.io/distroless/nodejs22-debian12",
+		"24": "gcr.io/distroless/nodejs24-debian12",
+	}
+	distrolessJava = map[string]string{
+		"17": "gcr.io/distroless/java17-debian12",
+		"21": "gcr.io/distroless/java21-debian12",
+	}
+)
+
+// imageVersionRegex splits an image tag into its version and variant (3.12-slim-bookworm)
+var imageVersionRegex = regexp.MustCompile(`^(\d+(?:\.\d+)*)(?:-(.+))?$`)
+
+// temurinVersionRegex finds the Java version of Maven and Gradle image tags (3.9-eclipse-temurin-17)
+var temurinVersionRegex = regexp.MustCompile(`(?:temurin|jdk)-?(\d+)`)
+
+// BaseImageAdvice recommends a slimmer or more current alternative to a base image of the
+// Dockerfiles. Sizes are set when registry enrichment reports them for both images; the delta
+// is recommended minus current (negative is an improvement).
+type BaseImageAdvice struct {
+	Image           string   `json:"image"`                      // Base image as written in FROM (python:3.8)
+	Stage           string   `json:"stage"`                      // "runtime" (final stage) or "build"
+	Recommended     string   `json:"recommended"`                // Recommended image (python:3.13-slim)
+	Alternatives    []string `json:"alternatives,omitempty"`     // Further alternatives (distroless images)
+	Reasons         []string `json:"reasons"`                    // Why the base image should change
+	CurrentSize     int64    `json:"current_size,omitempty"`     // Compressed size of the image in bytes
+	RecommendedSize int64    `json:"recommended_size,omitempty"` // Compressed size of the recommended image in bytes
+	SizeDelta       *int64   `json:"size_delta,omitempty"`       // Size change in bytes
+	Files           []string `json:"files"`                      // Dockerfiles using the image
+	Components      []string `json:"components"`                 // IDs of components with these Dockerfiles
+}
+
+// baseImageRef is a base image split into its parts: the official image name (python), the
+// version and variant of its tag (3.12, slim-bookworm)
+type baseImageRef struct {
+	name    string
+	version string
+	variant string
+}
+
+// String returns the image reference (name:version-variant)
+func (r baseImageRef) String() string {
+	tag := r.version
+	if r.variant != "" {
+		if tag != "" {
+			tag += "-"
+		}
+		tag += r.variant
+	}
+	if tag == "" {
+		return r.name
+	}
+	return r.name + ":" + tag
+}
+
+// tag returns the tag of the image reference ("latest" if it has none)
+func (r baseImageRef) tag() string {
+	if _, tag, ok := strings.Cut(r.String(), ":"); ok {
+		return tag
+	}
+	return "latest"
+}
+
+// BuildBaseImageAdvice compares the base images of the Dockerfiles (properties of the docker
+// detector) against slimmer or more current alternatives of the official images: end-of-life
+// distribution releases (debian, ubuntu, centos, and the distribution variants of language
+// images), end-of-life Python, Node.js, and Ruby versions, the deprecated openjdk image, full
+// language images in the runtime stage (slim variant), and build toolchains in the runtime
+// stage (distroless or JRE images). Distroless images are listed as alternatives of runtime
+// stages. With a lookup (registry enrichment), the compressed sizes of both images are
+// compared. Returns nil if no base image has a recommendation.
+func BuildBaseImageAdvice(payload *types.Payload, lookup PackageLookup, logger *slog.Logger) []BaseImageAdvice {
+	if payload == nil {
+		return nil
+	}
+
+	advice := make(map[string]*BaseImageAdvice)
+	walkComponents(payload, func(component *types.Payload) {
+		dockerfiles, _ := component.Properties["docker"].([]interface{})
+		for _, entry := range dockerfiles {
+			info, ok := entry.(*parsers.DockerfileInfo)
+			if !ok {
+				continue
+			}
+			stages := make(map[string]bool, len(info.Stages))
+			for _, stage := range info.Stages {
+				stages[strings.ToLower(stage)] = true
+			}
+			for i, image := range info.BaseImages {
+				if stages[strings.ToLower(image)] {
+					continue // FROM an earlier stage
+				}
+				stage := StageBuild
+				if i == len(info.BaseImages)-1 {
+					stage = StageRuntime
+				}
+				key := image + "|" + stage
+				if existing, ok := advice[key]; ok {
+					existing.Files = appendUnique(existing.Files, info.File)
+					existing.Components = appendUnique(existing.Components, component.ID)
+					continue
+				}
+				entry := adviseBaseImage(image, stage)
+				if entry == nil {
+					continue
+				}
+				entry.Files = []string{info.File}
+				entry.Components = []string{component.ID}
+				advice[key] = entry
+			}
+		}
+	})
+
+	if len(advice) == 0 {
+		return nil
+	}
+	result := make([]BaseImageAdvice, 0, len(advice))
+	for _, entry := range advice {
+		if lookup != nil && lookup.Supports(parsers.DependencyTypeDocker) {
+			compareBaseImages(entry, lookup, logger)
+		}
+		result = append(result, *entry)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Image != result[j].Image {
+			return result[i].Image < result[j].Image
+		}
+		return result[i].Stage < result[j].Stage
+	})
+	return result
+}
+
+// adviseBaseImage returns the recommendation for a base image in a stage, or nil if it has none
+// (current, not an official image, or built from a build argument)
+func adviseBaseImage(image, stage string) *BaseImageAdvice {
+	current, ok := parseBaseImage(image)
+	if !ok {
+		return nil
+	}
+	runtime := stage == StageRuntime
+	recommended := current
+	var alternatives, reasons []string
+	addReason := func(reason string) {
+		reasons = appendUnique(reasons, reason)
+	}
+
+	switch current.name {
+	case "debian", "ubuntu":
+		if release, suffix := splitDistroTag(current); recommendDistroRelease(current.name, release) != "" {
+			recommended = baseImageRef{name: current.name, variant: joinVariant(recommendDistroRelease(current.name, release), suffix)}
+			addReason(BaseImageEOLDistribution)
+		}
+	case "centos":
+		if !strings.HasPrefix(current.variant, "stream") || current.variant == "stream8" {
+			recommended = baseImageRef{name: "almalinux", version: "9"}
+			addReason(BaseImageEOLDistribution)
+		}
+	case "openjdk":
+		javaVersion := current.version
+		if javaVersion == "" {
+			javaVersion = currentJava
+		}
+		major, _, _ := strings.Cut(javaVersion, ".")
+		variant := "jdk"
+		if runtime {
+			variant = "jre"
+		}
+		recommended = baseImageRef{name: "eclipse-temurin", version: major, variant: variant}
+		addReason(BaseImageDeprecated)
+	case "golang":
+		if runtime {
+			recommended = baseImageRef{name: distrolessStatic}
+			addReason(BaseImageToolchain)
+		}
+	case "rust":
+		if runtime {
+			recommended = baseImageRef{name: distrolessCC}
+			addReason(BaseImageToolchain)
+		}
+	case "maven", "gradle":
+		if runtime {
+			javaVersion := currentJava
+			if match := temurinVersionRegex.FindStringSubmatch(current.variant); match != nil {
+				javaVersion = match[1]
+			}
+			recommended = baseImageRef{name: "eclipse-temurin", version: javaVersion, variant: "jre"}
+			addReason(BaseImageToolchain)
+		}
+	case "eclipse-temurin":
+		if runtime && strings.Contains(current.variant, "jdk") {
+			recommended.variant = strings.Replace(current.variant, "jdk", "jre", 1)
+			addReason(BaseImageToolchain)
+		}
+	case "python", "node", "ruby":
+		if eol, version := eolRuntime(current.name, current.version); eol {
+			recommended.version = version
+			addReason(BaseImageEOLRuntime)
+		}
+		if runtime && !slimVariant(recommended.variant) {
+			recommended.variant = joinVariant("slim", recommended.variant)
+			addReason(BaseImageFullVariant)
+		}
+	default:
+		return nil
+	}
+
+	// Distribution release of language image variants (3.11-slim-buster)
+	if recommended.name == current.name {
+		if variant := replaceEOLCodename(recommended.variant); variant != recommended.variant {
+			recommended.variant = variant
+			addReason(BaseImageEOLDistribution)
+		}
+	}
+
+	// Distroless alternatives of runtime stages
+	if runtime {
+		major, _, _ := strings.Cut(recommended.version, ".")
+		switch {
+		case recommended.name == "node" && distrolessNode[major] != "":
+			alternatives = append(alternatives, distrolessNode[major])
+		case recommended.name == "eclipse-temurin" && distrolessJava[major] != "":
+			alternatives = append(alternatives, distrolessJava[major])
+		}
+	}
+
+	if len(reasons) == 0 {
+		return nil
+	}
+	return &BaseImageAdvice{
+		Image:        image,
+		Stage:        stage,
+		Recommended:  recommended.String(),
+		Alternatives: alternatives,
+		Reasons:      reasons,
+	}
+}
+
+// parseBaseImage splits a base image into the official image name and the version and variant
+// of its tag. Returns false for images of other namespaces and registries, scratch, and
+// references using build arguments.
+func parseBaseImage(image string) (baseImageRef, bool) {
+	if strings.Contains(image, "$") {
+		return baseImageRef{}, false
+	}
+	image, _, _ = strings.Cut(image, "@") // Digest
+	name, tag := image, ""
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		name, tag = image[:i], image[i+1:]
+	}
+	name = strings.TrimPrefix(strings.TrimPrefix(name, "docker.io/"), "library/")
+	if strings.Contains(name, "/") || name == "scratch" {
+		return baseImageRef{}, false
+	}
+
+	ref := baseImageRef{name: name}
+	if match := imageVersionRegex.FindStringSubmatch(tag); match != nil {
+		ref.version, ref.variant = match[1], match[2]
+	} else if tag != "latest" {
+		ref.variant = tag
+	}
+	return ref, true
+}
+
+// BaseImageName returns the name of an official Docker Hub image reference (python for
+// docker.io/library/python:3.12-slim); false for images of other namespaces and registries
+func BaseImageName(image string) (string, bool) {
+	ref, ok := parseBaseImage(image)
+	return ref.name, ok
+}
+
+// splitDistroTag returns the release of a debian or ubuntu image tag and its suffix
+// (buster-slim: buster, slim)
+func splitDistroTag(ref baseImageRef) (string, string) {
+	if ref.version != "" {
+		return ref.version, ref.variant
+	}
+	release, suffix, _ := strings.Cut(ref.variant, "-")
+	return release, suffix
+}
+
+// recommendDistroRelease returns the current release replacing an end-of-life debian or ubuntu
+// release (codename for codenames, version for versions), or empty if the release is supported
+func recommendDistroRelease(distro, release string) string {
+	_, numeric := strconv.Atoi(strings.ReplaceAll(release, ".", ""))
+	switch {
+	case distro == "debian" && eolDebianReleases[release]:
+		if numeric == nil {
+			return currentDebianNumber
+		}
+		return currentDebian
+	case distro == "ubuntu" && eolUbuntuReleases[release]:
+		if numeric == nil {
+			return currentUbuntuNumber
+		}
+		return currentUbuntu
+	}
+	return ""
+}
+
+// replaceEOLCodename replaces the end-of-life debian and ubuntu codenames of an image variant
+// (slim-buster, jre-focal) by the current release
+func replaceEOLCodename(variant string) string {
+	parts := strings.Split(variant, "-")
+	for i, part := range parts {
+		if _, err := strconv.Atoi(part); err == nil {
+			continue // Numbered releases are versions of the language (jdk-11)
+		}
+		switch {
+		case eolDebianReleases[part]:
+			parts[i] = currentDebian
+		case eolUbuntuReleases[part]:
+			parts[i] = currentUbuntu
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// eolRuntime reports whether a Python, Node.js, or Ruby version is end-of-life, with the
+// version to move to. Versions are compared by major (Node.js) or major.minor.
+func eolRuntime(name, version string) (bool, string) {
+	if version == "" {
+		return false, ""
+	}
+	parts := strings.Split(version, ".")
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false, ""
+	}
+	minor := -1
+	if len(parts) > 1 {
+		minor, _ = strconv.Atoi(parts[1])
+	}
+	switch name {
+	case "python":
+		if major < 3 || (major == 3 && minor >= 0 && minor < 10) {
+			return true, currentPython
+		}
+	case "node":
+		if major < 22 || (major < 25 && major%2 == 1) {
+			return true, currentNode
+		}
+	case "ruby":
+		if major < 3 || (major == 3 && minor >= 0 && minor < 3) {
+			return true, currentRuby
+		}
+	}
+	return false, ""
+}
+
+// slimVariant reports whether an image variant is a slim or Alpine image
+func slimVariant(variant string) bool {
+	for _, part := range strings.Split(variant, "-") {
+		if part == "slim" || strings.HasPrefix(part, "alpine") {
+			return true
+		}
+	}
+	return false
+}
+
+// joinVariant joins the parts of an image variant, skipping empty parts
+func joinVariant(parts ...string) string {
+	var nonEmpty []string
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, "-")
+}
+
+// compareBaseImages sets the sizes of the current and recommended images reported by the
+// lookup, with their delta when both are known
+func compareBaseImages(entry *BaseImageAdvice, lookup PackageLookup, logger *slog.Logger) {
+	current, _ := parseBaseImage(entry.Image)
+	recommended, _ := parseBaseImage(entry.Recommended)
+	currentInfo := lookupImage(current.name, lookup, logger)
+	recommendedInfo := lookupImage(recommended.name, lookup, logger)
+	if currentInfo == nil || recommendedInfo == nil {
+		return
+	}
+
+	entry.CurrentSize = currentInfo.VersionSizes[current.tag()]
+	entry.RecommendedSize = recommendedInfo.VersionSizes[recommended.tag()]
+	if entry.CurrentSize > 0 && entry.RecommendedSize > 0 {
+		delta := entry.RecommendedSize - entry.CurrentSize
+		entry.SizeDelta = &delta
+	}
+}
+
+// lookupImage returns the registry metadata of an image, or nil if the lookup fails
+func lookupImage(name string, lookup PackageLookup, logger *slog.Logger) *registry.PackageInfo {
+	info, err := lookup.Lookup(parsers.DependencyTypeDocker, name)
+	if err != nil {
+		if logger != nil {
+			logger.Debug("Registry lookup failed", "type", parsers.DependencyTypeDocker, "name", name, "error", err)
+		}
+		return nil
+	}
+	return info
+}
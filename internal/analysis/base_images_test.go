@@ -0,0 +1,128 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/registry"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdviseBaseImage(t *testing.T) {
+	tests := []struct {
+		image        string
+		stage        string
+		recommended  string
+		alternatives []string
+		reasons      []string
+	}{
+		{"debian:buster-slim", StageRuntime, "debian:trixie-slim", nil, []string{BaseImageEOLDistribution}},
+		{"debian:10", StageBuild, "debian:13", nil, []string{BaseImageEOLDistribution}},
+		{"ubuntu:18.04", StageRuntime, "ubuntu:24.04", nil, []string{BaseImageEOLDistribution}},
+		{"ubuntu:focal", StageRuntime, "ubuntu:noble", nil, []string{BaseImageEOLDistribution}},
+		{"centos:7", StageRuntime, "almalinux:9", nil, []string{BaseImageEOLDistribution}},
+		{"python:3.8", StageRuntime, "python:3.13-slim", nil, []string{BaseImageEOLRuntime, BaseImageFullVariant}},
+		{"python:3.8", StageBuild, "python:3.13", nil, []string{BaseImageEOLRuntime}},
+		{"python:3.12-slim-buster", StageRuntime, "python:3.12-slim-trixie", nil, []string{BaseImageEOLDistribution}},
+		{"node:18-alpine", StageRuntime, "node:24-alpine", []string{"gcr.io/distroless/nodejs24-debian12"}, []string{BaseImageEOLRuntime}},
+		{"node:22", StageRuntime, "node:22-slim", []string{"gcr.io/distroless/nodejs22-debian12"}, []string{BaseImageFullVariant}},
+		{"ruby:2.7", StageBuild, "ruby:3.4", nil, []string{BaseImageEOLRuntime}},
+		{"openjdk:17-jdk-slim", StageRuntime, "eclipse-temurin:17-jre", []string{"gcr.io/distroless/java17-debian12"}, []string{BaseImageDeprecated}},
+		{"openjdk:11", StageBuild, "eclipse-temurin:11-jdk", nil, []string{BaseImageDeprecated}},
+		{"golang:1.22", StageRuntime, "gcr.io/distroless/static-debian12", nil, []string{BaseImageToolchain}},
+		{"rust:1.79", StageRuntime, "gcr.io/distroless/cc-debian12", nil, []string{BaseImageToolchain}},
+		{"maven:3.9-eclipse-temurin-17", StageRuntime, "eclipse-temurin:17-jre", []string{"gcr.io/distroless/java17-debian12"}, []string{BaseImageToolchain}},
+		{"eclipse-temurin:21-jdk-jammy", StageRuntime, "eclipse-temurin:21-jre-jammy", []string{"gcr.io/distroless/java21-debian12"}, []string{BaseImageToolchain}},
+		{"docker.io/library/python:3.8-slim@sha256:abc", StageRuntime, "python:3.13-slim", nil, []string{BaseImageEOLRuntime}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image+"/"+tt.stage, func(t *testing.T) {
+			advice := adviseBaseImage(tt.image, tt.stage)
+			require.NotNil(t, advice)
+			assert.Equal(t, tt.recommended, advice.Recommended)
+			assert.Equal(t, tt.alternatives, advice.Alternatives)
+			assert.Equal(t, tt.reasons, advice.Reasons)
+		})
+	}
+
+	// Current images, images of other namespaces, and build arguments have no recommendation
+	for _, image := range []string{"python:3.13-slim", "golang:1.22", "debian:bookworm", "node:24-alpine", "scratch", "bitnami/python:3.8", "python:${PY_VERSION}", "alpine:3.20"} {
+		stage := StageRuntime
+		if image == "golang:1.22" {
+			stage = StageBuild
+		}
+		assert.Nil(t, adviseBaseImage(image, stage), image)
+	}
+}
+
+func TestBuildBaseImageAdvice(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	api := types.NewPayloadWithPath("api", "/api")
+	api.Properties["docker"] = []interface{}{&parsers.DockerfileInfo{
+		File:       "/api/Dockerfile",
+		BaseImages: []string{"golang:1.22", "builder", "debian:buster-slim"},
+		Stages:     []string{"builder"},
+		MultiStage: true,
+	}}
+	worker := types.NewPayloadWithPath("worker", "/worker")
+	worker.Properties["docker"] = []interface{}{&parsers.DockerfileInfo{
+		File:       "/worker/Dockerfile",
+		BaseImages: []string{"debian:buster-slim"},
+	}}
+	root.AddChild(api)
+	root.AddChild(worker)
+
+	advice := BuildBaseImageAdvice(root, nil, nil)
+	require.Len(t, advice, 1, "golang in the build stage and the stage reference are fine")
+	assert.Equal(t, "debian:buster-slim", advice[0].Image)
+	assert.Equal(t, StageRuntime, advice[0].Stage)
+	assert.Equal(t, []string{"/api/Dockerfile", "/worker/Dockerfile"}, advice[0].Files)
+	assert.Equal(t, []string{api.ID, worker.ID}, advice[0].Components)
+	assert.Zero(t, advice[0].CurrentSize)
+	assert.Nil(t, advice[0].SizeDelta, "no deltas without enrichment")
+
+	assert.Nil(t, BuildBaseImageAdvice(types.NewPayloadWithPath("main", "/"), nil, nil))
+}
+
+func TestBuildBaseImageAdvice_Deltas(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	root.Properties["docker"] = []interface{}{
+		&parsers.DockerfileInfo{File: "/Dockerfile", BaseImages: []string{"python:3.8"}},
+		&parsers.DockerfileInfo{File: "/web/Dockerfile", BaseImages: []string{"node"}},
+	}
+	lookup := &fakeLookup{packages: map[string]*registry.PackageInfo{
+		"docker:python": {VersionSizes: map[string]int64{"3.8": 357000000, "3.13-slim": 43000000}},
+		"docker:node":   {VersionSizes: map[string]int64{"latest": 400000000}},
+	}}
+
+	advice := BuildBaseImageAdvice(root, lookup, nil)
+	require.Len(t, advice, 2)
+
+	node := advice[0]
+	assert.Equal(t, "node", node.Image)
+	assert.Equal(t, "node:slim", node.Recommended)
+	assert.Equal(t, int64(400000000), node.CurrentSize)
+	assert.Nil(t, node.SizeDelta, "size of the recommended tag unknown")
+
+	python := advice[1]
+	assert.Equal(t, "python:3.13-slim", python.Recommended)
+	assert.Equal(t, int64(43000000), python.RecommendedSize)
+	require.NotNil(t, python.SizeDelta)
+	assert.Equal(t, int64(-314000000), *python.SizeDelta)
+}
+
+func TestBaseImageName(t *testing.T) {
+	name, ok := BaseImageName("docker.io/library/python:3.12-slim")
+	assert.True(t, ok)
+	assert.Equal(t, "python", name)
+	name, ok = BaseImageName("node@sha256:abc")
+	assert.True(t, ok)
+	assert.Equal(t, "node", name)
+
+	_, ok = BaseImageName("ghcr.io/acme/app:1.0")
+	assert.False(t, ok)
+	_, ok = BaseImageName("scratch")
+	assert.False(t, ok)
+}
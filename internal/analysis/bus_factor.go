@@ -0,0 +1,152 @@
+package analysis
+
+import (
+	"log/slog"
+	"sort"
+	"strconv"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// BusFactorRisk lists the central dependencies maintained by a single person: a dependency
+// is central when at least half of the components with dependencies declare it directly
+type BusFactorRisk struct {
+	Components int                `json:"components"` // Components with direct dependencies
+	Packages   []BusFactorPackage `json:"packages"`
+}
+
+// BusFactorPackage is a central single-maintainer dependency
+type BusFactorPackage struct {
+	Type        string   `json:"type"`
+	Name        string   `json:"name"`
+	Versions    []string `json:"versions"`
+	Maintainers int      `json:"maintainers"`
+	Publisher   string   `json:"publisher,omitempty"`
+	Repository  string   `json:"repository,omitempty"`
+	Dependents  int      `json:"dependents"` // Components declaring the dependency directly
+	Components  []string `json:"components"` // IDs of components declaring the dependency
+}
+
+// ConcludeMaintainers records the maintainer count, publisher, and repository URL the
+// registry reports for each direct dependency. Returns the number of dependencies updated.
+func ConcludeMaintainers(payload *types.Payload, lookup PackageLookup, logger *slog.Logger) int {
+	if payload == nil || lookup == nil {
+		return 0
+	}
+
+	updated := 0
+	walkComponents(payload, func(component *types.Payload) {
+		for i, dep := range component.Dependencies {
+			if !dep.Direct || !lookup.Supports(dep.Type) {
+				continue
+			}
+			info, err := lookup.Lookup(dep.Type, dep.Name)
+			if err != nil {
+				if logger != nil {
+					logger.Debug("Registry lookup failed", "type", dep.Type, "name", dep.Name, "error", err)
+				}
+				continue
+			}
+			if info.Maintainers == 0 && info.Publisher == "" && info.RepositoryURL == "" {
+				continue
+			}
+
+			// Copy the metadata: dependencies of a manifest may share a single metadata map
+			metadata := make(map[string]interface{}, len(dep.Metadata)+3)
+			for key, value := range dep.Metadata {
+				metadata[key] = value
+			}
+			if info.Maintainers > 0 {
+				metadata[parsers.MetadataMaintainers] = info.Maintainers
+				recordRegistryOrigin(metadata, dep, parsers.MetadataMaintainers, strconv.Itoa(info.Maintainers))
+			}
+			if info.Publisher != "" {
+				metadata[parsers.MetadataPublisher] = info.Publisher
+				recordRegistryOrigin(metadata, dep, parsers.MetadataPublisher, info.Publisher)
+			}
+			if info.RepositoryURL != "" {
+				metadata[parsers.MetadataRepository] = info.RepositoryURL
+				recordRegistryOrigin(metadata, dep, parsers.MetadataRepository, info.RepositoryURL)
+			}
+			component.Dependencies[i].Metadata = metadata
+			updated++
+		}
+	})
+	return updated
+}
+
+// BuildBusFactorRisk collects the central distributed dependencies with a single maintainer,
+// sorted by descending dependents and name. Requires the maintainer counts of registry
+// enrichment; returns nil if no dependency qualifies.
+func BuildBusFactorRisk(payload *types.Payload) *BusFactorRisk {
+	if payload == nil {
+		return nil
+	}
+
+	components := 0
+	packages := make(map[string]*BusFactorPackage)
+	walkComponents(payload, func(component *types.Payload) {
+		hasDirect := false
+		for _, dep := range component.Dependencies {
+			if !dep.Direct {
+				continue
+			}
+			hasDirect = true
+			if dependencyExposure(dep) != ExposureDistributed || metadataInt(dep.Metadata[parsers.MetadataMaintainers]) != 1 {
+				continue
+			}
+			key := dep.Type + ":" + dep.Name
+			pkg, ok := packages[key]
+			if !ok {
+				pkg = &BusFactorPackage{Type: dep.Type, Name: dep.Name, Maintainers: 1}
+				packages[key] = pkg
+			}
+			pkg.Versions = appendUnique(pkg.Versions, dep.Version)
+			if publisher, _ := dep.Metadata[parsers.MetadataPublisher].(string); publisher != "" {
+				pkg.Publisher = publisher
+			}
+			if repository, _ := dep.Metadata[parsers.MetadataRepository].(string); repository != "" {
+				pkg.Repository = repository
+			}
+			pkg.Components = appendUnique(pkg.Components, component.ID)
+			pkg.Dependents = len(pkg.Components)
+		}
+		if hasDirect {
+			components++
+		}
+	})
+
+	risk := &BusFactorRisk{Components: components}
+	for _, pkg := range packages {
+		if pkg.Dependents*2 >= components {
+			sort.Strings(pkg.Versions)
+			risk.Packages = append(risk.Packages, *pkg)
+		}
+	}
+	if len(risk.Packages) == 0 {
+		return nil
+	}
+	sort.Slice(risk.Packages, func(i, j int) bool {
+		a, b := risk.Packages[i], risk.Packages[j]
+		if a.Dependents != b.Dependents {
+			return a.Dependents > b.Dependents
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Name < b.Name
+	})
+	return risk
+}
+
+// metadataInt returns an integer metadata value (int or decoded float64)
+func metadataInt(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return 0
+}
@@ -0,0 +1,89 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/registry"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcludeMaintainers(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	shared := types.NewMetadata(parsers.MetadataSourcePackageJSON)
+	root.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "left-pad", Version: "1.3.0", Scope: types.ScopeProd, Direct: true, Metadata: shared},
+		{Type: "npm", Name: "react", Version: "18.2.0", Scope: types.ScopeProd, Direct: true, Metadata: shared},
+		{Type: "npm", Name: "core-js", Version: "3.33.0", Scope: types.ScopeProd, Direct: false, Metadata: shared},
+		{Type: "maven", Name: "junit:junit", Version: "4.13.2", Scope: types.ScopeTest, Direct: true},
+	}
+	lookup := &fakeLookup{packages: map[string]*registry.PackageInfo{
+		"npm:left-pad": {LatestVersion: "1.3.0", Maintainers: 1, Publisher: "stevemao", RepositoryURL: "https://github.com/stevemao/left-pad"},
+		"npm:react":    {LatestVersion: "18.3.1"},
+		"npm:core-js":  {LatestVersion: "3.33.0", Maintainers: 1},
+	}}
+
+	assert.Equal(t, 1, ConcludeMaintainers(root, lookup, nil))
+
+	deps := root.Dependencies
+	assert.Equal(t, 1, deps[0].Metadata[parsers.MetadataMaintainers])
+	assert.Equal(t, "stevemao", deps[0].Metadata[parsers.MetadataPublisher])
+	assert.Equal(t, "https://github.com/stevemao/left-pad", deps[0].Metadata[parsers.MetadataRepository])
+	assert.Equal(t, parsers.MetadataSourcePackageJSON, deps[0].Metadata["source"])
+	assert.NotContains(t, deps[1].Metadata, parsers.MetadataMaintainers, "nothing known")
+	assert.NotContains(t, deps[2].Metadata, parsers.MetadataMaintainers, "transitive dependencies are not looked up")
+	assert.NotContains(t, shared, parsers.MetadataMaintainers, "shared manifest metadata is not modified")
+}
+
+func TestBuildBusFactorRisk(t *testing.T) {
+	single := func(extra map[string]interface{}) map[string]interface{} {
+		metadata := map[string]interface{}{parsers.MetadataMaintainers: 1}
+		for key, value := range extra {
+			metadata[key] = value
+		}
+		return metadata
+	}
+	root := types.NewPayloadWithPath("main", "/")
+	root.ID = "root"
+	api := types.NewPayloadWithPath("api", "/api/package.json")
+	api.ID = "api"
+	api.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "left-pad", Version: "1.3.0", Scope: types.ScopeProd, Direct: true,
+			Metadata: single(map[string]interface{}{parsers.MetadataPublisher: "stevemao"})},
+		{Type: "npm", Name: "tiny-lib", Version: "0.1.0", Scope: types.ScopeProd, Direct: true, Metadata: single(nil)},
+		{Type: "npm", Name: "express", Version: "4.18.2", Scope: types.ScopeProd, Direct: true,
+			Metadata: map[string]interface{}{parsers.MetadataMaintainers: 5}},
+	}
+	web := types.NewPayloadWithPath("web", "/web/package.json")
+	web.ID = "web"
+	web.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "left-pad", Version: "1.2.0", Scope: types.ScopeProd, Direct: true, Metadata: single(nil)},
+		{Type: "npm", Name: "dev-tool", Version: "1.0.0", Scope: types.ScopeDev, Direct: true, Metadata: single(nil)},
+		{Type: "npm", Name: "express", Version: "4.18.2", Scope: types.ScopeProd, Direct: true},
+	}
+	docs := types.NewPayloadWithPath("docs", "/docs/package.json")
+	docs.ID = "docs"
+	docs.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "express", Version: "4.18.2", Scope: types.ScopeProd, Direct: true},
+		{Type: "npm", Name: "left-pad", Version: "1.3.0", Scope: types.ScopeProd, Direct: false, Metadata: single(nil)},
+	}
+	root.Children = []*types.Payload{api, web, docs}
+
+	risk := BuildBusFactorRisk(root)
+	require.NotNil(t, risk)
+	assert.Equal(t, 3, risk.Components)
+	assert.Equal(t, []BusFactorPackage{
+		{Type: "npm", Name: "left-pad", Versions: []string{"1.2.0", "1.3.0"}, Maintainers: 1, Publisher: "stevemao", Dependents: 2, Components: []string{"api", "web"}},
+	}, risk.Packages, "tiny-lib is declared by 1 of 3 components, dev-tool is not distributed")
+
+	assert.Nil(t, BuildBusFactorRisk(types.NewPayloadWithPath("main", "/")))
+}
+
+func TestMetadataInt(t *testing.T) {
+	assert.Equal(t, 2, metadataInt(2))
+	assert.Equal(t, 2, metadataInt(float64(2)), "decoded JSON numbers")
+	assert.Equal(t, 0, metadataInt("2"))
+	assert.Equal(t, 0, metadataInt(nil))
+}
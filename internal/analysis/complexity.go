@@ -0,0 +1,130 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Complexity score weights: every direct dependency counts 1, every transitive dependency
+// counts transitiveWeight, and every ecosystem beyond the first counts ecosystemWeight
+const (
+	transitiveWeight = 0.1
+	ecosystemWeight  = 5
+)
+
+// Threshold names reported in ComponentComplexity.Exceeded
+const (
+	ThresholdDirectDependencies     = "max_direct_dependencies"
+	ThresholdTransitiveDependencies = "max_transitive_dependencies"
+	ThresholdEcosystems             = "max_ecosystems"
+	ThresholdScore                  = "max_score"
+)
+
+// DependencyComplexity reports the dependency complexity of every component and the components
+// exceeding the configured thresholds
+type DependencyComplexity struct {
+	Thresholds *config.ComplexityThresholds `json:"thresholds,omitempty"`
+	Flagged    int                          `json:"flagged"`
+	Components []ComponentComplexity        `json:"components"`
+}
+
+// ComponentComplexity is the dependency complexity of a single component
+type ComponentComplexity struct {
+	Name       string   `json:"name"`
+	Path       string   `json:"path"`
+	Direct     int      `json:"direct"`
+	Transitive int      `json:"transitive"` // Indirect dependencies resolved from lock files
+	Ecosystems []string `json:"ecosystems"`
+	Score      int      `json:"score"`
+	Exceeded   []string `json:"exceeded,omitempty"` // Names of the thresholds the component exceeds
+}
+
+// BuildDependencyComplexity computes a complexity score for every component with dependencies
+// and flags the components exceeding the thresholds (nil thresholds only report scores).
+// Components are sorted by descending score. Returns nil if no component has dependencies.
+func BuildDependencyComplexity(payload *types.Payload, thresholds *config.ComplexityThresholds) *DependencyComplexity {
+	if payload == nil {
+		return nil
+	}
+
+	result := &DependencyComplexity{Thresholds: thresholds}
+	walkComponents(payload, func(component *types.Payload) {
+		if len(component.Dependencies) == 0 {
+			return
+		}
+		complexity := componentComplexity(component)
+		complexity.Exceeded = exceededThresholds(complexity, thresholds)
+		if len(complexity.Exceeded) > 0 {
+			result.Flagged++
+		}
+		result.Components = append(result.Components, complexity)
+	})
+
+	if len(result.Components) == 0 {
+		return nil
+	}
+	sort.SliceStable(result.Components, func(i, j int) bool {
+		return result.Components[i].Score > result.Components[j].Score
+	})
+	return result
+}
+
+// componentComplexity counts the unique direct and transitive dependencies and the ecosystems
+// of a component. A dependency declared both directly and transitively counts as direct.
+func componentComplexity(component *types.Payload) ComponentComplexity {
+	direct := make(map[string]bool)
+	ecosystems := make(map[string]bool)
+	for _, dep := range component.Dependencies {
+		key := dep.Type + ":" + dep.Name
+		direct[key] = direct[key] || dep.Direct
+		ecosystems[dep.Type] = true
+	}
+
+	complexity := ComponentComplexity{
+		Name:       component.Name,
+		Path:       componentDirs(component)[0],
+		Ecosystems: make([]string, 0, len(ecosystems)),
+	}
+	for _, isDirect := range direct {
+		if isDirect {
+			complexity.Direct++
+		} else {
+			complexity.Transitive++
+		}
+	}
+	for ecosystem := range ecosystems {
+		complexity.Ecosystems = append(complexity.Ecosystems, ecosystem)
+	}
+	sort.Strings(complexity.Ecosystems)
+
+	score := float64(complexity.Direct) + transitiveWeight*float64(complexity.Transitive) + ecosystemWeight*float64(len(ecosystems)-1)
+	complexity.Score = int(math.Round(score))
+	return complexity
+}
+
+// exceededThresholds returns the names of the thresholds a component exceeds
+func exceededThresholds(complexity ComponentComplexity, thresholds *config.ComplexityThresholds) []string {
+	if thresholds == nil {
+		return nil
+	}
+	var exceeded []string
+	checks := []struct {
+		name  string
+		value int
+		limit int
+	}{
+		{ThresholdDirectDependencies, complexity.Direct, thresholds.MaxDirectDependencies},
+		{ThresholdTransitiveDependencies, complexity.Transitive, thresholds.MaxTransitiveDependencies},
+		{ThresholdEcosystems, len(complexity.Ecosystems), thresholds.MaxEcosystems},
+		{ThresholdScore, complexity.Score, thresholds.MaxScore},
+	}
+	for _, check := range checks {
+		if check.limit > 0 && check.value > check.limit {
+			exceeded = append(exceeded, check.name)
+		}
+	}
+	return exceeded
+}
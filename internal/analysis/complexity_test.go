@@ -0,0 +1,87 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func complexityTree() *types.Payload {
+	root := types.NewPayloadWithPath("main", "/")
+	root.Dependencies = []types.Dependency{
+		{Type: "githubAction", Name: "actions/checkout", Version: "v4", Direct: true},
+	}
+
+	web := types.NewPayloadWithPath("web", "/web/package.json")
+	for _, name := range []string{"react", "react-dom", "vite", "typescript"} {
+		web.Dependencies = append(web.Dependencies, types.Dependency{Type: "npm", Name: name, Version: "1.0.0", Direct: true})
+	}
+	for _, name := range []string{"scheduler", "loose-envify", "js-tokens", "esbuild", "rollup", "postcss", "nanoid", "picocolors", "source-map-js", "fsevents", "resolve"} {
+		web.Dependencies = append(web.Dependencies, types.Dependency{Type: "npm", Name: name, Version: "1.0.0"})
+	}
+	// Lock file entry of a direct dependency counts once, as direct
+	web.Dependencies = append(web.Dependencies, types.Dependency{Type: "npm", Name: "react", Version: "18.2.0"})
+	web.Dependencies = append(web.Dependencies, types.Dependency{Type: "docker", Name: "node", Version: "20", Direct: true})
+
+	api := types.NewPayloadWithPath("api", "/api/pyproject.toml")
+	api.Dependencies = []types.Dependency{
+		{Type: "python", Name: "fastapi", Version: "0.110.0", Direct: true},
+		{Type: "python", Name: "uvicorn", Version: "0.29.0", Direct: true},
+	}
+	docs := types.NewPayloadWithPath("docs", "/docs")
+
+	root.AddChild(web)
+	root.AddChild(api)
+	root.AddChild(docs)
+	return root
+}
+
+func TestBuildDependencyComplexity(t *testing.T) {
+	complexity := BuildDependencyComplexity(complexityTree(), nil)
+	require.NotNil(t, complexity)
+
+	assert.Nil(t, complexity.Thresholds)
+	assert.Equal(t, 0, complexity.Flagged)
+	require.Len(t, complexity.Components, 3)
+
+	// 5 direct + 11 transitive * 0.1 + 1 additional ecosystem * 5 = 11.1
+	assert.Equal(t, ComponentComplexity{
+		Name:       "web",
+		Path:       "/web",
+		Direct:     5,
+		Transitive: 11,
+		Ecosystems: []string{"docker", "npm"},
+		Score:      11,
+	}, complexity.Components[0])
+	assert.Equal(t, "api", complexity.Components[1].Name)
+	assert.Equal(t, 2, complexity.Components[1].Score)
+	assert.Equal(t, "main", complexity.Components[2].Name)
+	assert.Equal(t, "/", complexity.Components[2].Path)
+}
+
+func TestBuildDependencyComplexity_Thresholds(t *testing.T) {
+	thresholds := &config.ComplexityThresholds{
+		MaxDirectDependencies:     4,
+		MaxTransitiveDependencies: 20,
+		MaxEcosystems:             1,
+	}
+	complexity := BuildDependencyComplexity(complexityTree(), thresholds)
+	require.NotNil(t, complexity)
+
+	assert.Same(t, thresholds, complexity.Thresholds)
+	assert.Equal(t, 1, complexity.Flagged)
+	assert.Equal(t, []string{ThresholdDirectDependencies, ThresholdEcosystems}, complexity.Components[0].Exceeded)
+	assert.Empty(t, complexity.Components[1].Exceeded)
+
+	complexity = BuildDependencyComplexity(complexityTree(), &config.ComplexityThresholds{MaxScore: 1})
+	assert.Equal(t, 2, complexity.Flagged)
+	assert.Equal(t, []string{ThresholdScore}, complexity.Components[1].Exceeded)
+}
+
+func TestBuildDependencyComplexity_NoDependencies(t *testing.T) {
+	assert.Nil(t, BuildDependencyComplexity(types.NewPayloadWithPath("main", "/"), nil))
+	assert.Nil(t, BuildDependencyComplexity(nil, nil))
+}
@@ -0,0 +1,237 @@
+package analysis
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// License classes by copyleft strength, from least to most restrictive
+const (
+	LicensePermissive      = "permissive"
+	LicenseWeakCopyleft    = "weak_copyleft"    // File or library scoped (LGPL, MPL, EPL, CDDL)
+	LicenseStrongCopyleft  = "strong_copyleft"  // Derived works (GPL)
+	LicenseNetworkCopyleft = "network_copyleft" // Derived works including network use (AGPL, SSPL)
+)
+
+// Exposure contexts derived from the dependency scope
+const (
+	ExposureDistributed = "distributed" // Shipped or linked with the product (prod, peer, optional, ...)
+	ExposureDevelopment = "development" // Development and test only (dev, test)
+	ExposureBuildOnly   = "build_only"  // Build and CI tooling, not linked (build scope, GitHub Actions)
+)
+
+// Copyleft risk levels
+const (
+	RiskHigh   = "high"
+	RiskMedium = "medium"
+	RiskLow    = "low"
+	RiskNone   = "none"
+)
+
+// copyleftPrefixes classify SPDX identifiers by prefix; the first matching prefix wins
+var copyleftPrefixes = []struct {
+	prefix string
+	class  string
+}{
+	{"agpl-", LicenseNetworkCopyleft},
+	{"sspl-", LicenseNetworkCopyleft},
+	{"lgpl-", LicenseWeakCopyleft},
+	{"gpl-", LicenseStrongCopyleft},
+	{"osl-", LicenseStrongCopyleft},
+	{"eupl-", LicenseStrongCopyleft},
+	{"cecill-2", LicenseStrongCopyleft},
+	{"mpl-", LicenseWeakCopyleft},
+	{"epl-", LicenseWeakCopyleft},
+	{"cddl-", LicenseWeakCopyleft},
+	{"cpl-", LicenseWeakCopyleft},
+	{"ms-rl", LicenseWeakCopyleft},
+	{"cecill-c", LicenseWeakCopyleft},
+}
+
+// classRank orders license classes by restrictiveness
+var classRank = map[string]int{
+	LicensePermissive:      0,
+	LicenseWeakCopyleft:    1,
+	LicenseStrongCopyleft:  2,
+	LicenseNetworkCopyleft: 3,
+}
+
+// buildOnlyTypes are dependency types that are never linked into the product
+var buildOnlyTypes = map[string]bool{
+	parsers.DependencyTypeGitHubAction: true,
+}
+
+// CopyleftExposure summarizes copyleft licensed dependencies by how they are used
+type CopyleftExposure struct {
+	Risk     string            `json:"risk"`    // Highest risk of all findings
+	Summary  map[string]int    `json:"summary"` // Risk level -> number of findings
+	Findings []CopyleftFinding `json:"findings"`
+}
+
+// CopyleftFinding is a copyleft licensed dependency with its exposure and risk
+type CopyleftFinding struct {
+	Type       string      `json:"type"`
+	Name       string      `json:"name"`
+	Version    string      `json:"version"`
+	License    string      `json:"license"`
+	Class      string      `json:"class"`
+	Scope      types.Scope `json:"scope,omitempty"`
+	Exposure   string      `json:"exposure"`
+	Risk       string      `json:"risk"`
+	Components []string    `json:"components"` // IDs of components declaring the dependency
+}
+
+// BuildCopyleftExposure classifies the copyleft licensed dependencies of the payload tree by
+// exposure (distributed, development, build-only) and assigns a risk level: copyleft that
+// reaches derived works when distributed is high, weak copyleft when distributed is medium,
+// and copyleft limited to development or build tooling is low. Returns nil if no dependency
+// has a copyleft license.
+func BuildCopyleftExposure(payload *types.Payload) *CopyleftExposure {
+	if payload == nil {
+		return nil
+	}
+
+	findings := make(map[string]*CopyleftFinding)
+	walkComponents(payload, func(component *types.Payload) {
+		for _, dep := range component.Dependencies {
+			depLicense := dependencyLicense(dep)
+			class := ClassifyLicense(depLicense)
+			if class == "" || class == LicensePermissive {
+				continue
+			}
+			exposure := dependencyExposure(dep)
+			key := dep.Type + ":" + dep.Name + "@" + dep.Version + ":" + exposure
+			if existing, ok := findings[key]; ok {
+				existing.Components = appendUnique(existing.Components, component.ID)
+				continue
+			}
+			findings[key] = &CopyleftFinding{
+				Type:       dep.Type,
+				Name:       dep.Name,
+				Version:    dep.Version,
+				License:    depLicense,
+				Class:      class,
+				Scope:      dep.Scope,
+				Exposure:   exposure,
+				Risk:       copyleftRisk(class, exposure),
+				Components: []string{component.ID},
+			}
+		}
+	})
+
+	if len(findings) == 0 {
+		return nil
+	}
+
+	exposure := &CopyleftExposure{
+		Risk:     RiskNone,
+		Summary:  make(map[string]int),
+		Findings: make([]CopyleftFinding, 0, len(findings)),
+	}
+	riskRank := map[string]int{RiskNone: 0, RiskLow: 1, RiskMedium: 2, RiskHigh: 3}
+	for _, finding := range findings {
+		exposure.Findings = append(exposure.Findings, *finding)
+		exposure.Summary[finding.Risk]++
+		if riskRank[finding.Risk] > riskRank[exposure.Risk] {
+			exposure.Risk = finding.Risk
+		}
+	}
+	sort.Slice(exposure.Findings, func(i, j int) bool {
+		a, b := exposure.Findings[i], exposure.Findings[j]
+		if riskRank[a.Risk] != riskRank[b.Risk] {
+			return riskRank[a.Risk] > riskRank[b.Risk]
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Version < b.Version
+	})
+	return exposure
+}
+
+// ClassifyLicense returns the copyleft class of an SPDX license or expression. A choice
+// ("GPL-2.0-only OR MIT") takes the least restrictive alternative, a combination
+// ("MIT AND GPL-3.0-only") the most restrictive part. Returns "" for an empty license.
+func ClassifyLicense(expression string) string {
+	expr := strings.TrimSpace(strings.Trim(strings.TrimSpace(expression), "()"))
+	if expr == "" {
+		return ""
+	}
+
+	if alternatives := splitExpression(expr, " or "); len(alternatives) > 1 {
+		class := ""
+		for _, alternative := range alternatives {
+			if c := ClassifyLicense(alternative); class == "" || classRank[c] < classRank[class] {
+				class = c
+			}
+		}
+		return class
+	}
+	if parts := splitExpression(expr, " and "); len(parts) > 1 {
+		class := LicensePermissive
+		for _, part := range parts {
+			if c := ClassifyLicense(part); classRank[c] > classRank[class] {
+				class = c
+			}
+		}
+		return class
+	}
+
+	id := strings.ToLower(expr)
+	if idx := strings.Index(id, " with "); idx >= 0 { // "GPL-2.0-only WITH Classpath-exception-2.0"
+		if strings.HasPrefix(id, "gpl-") && strings.Contains(id[idx:], "classpath-exception") {
+			return LicenseWeakCopyleft
+		}
+		id = id[:idx]
+	}
+	for _, entry := range copyleftPrefixes {
+		if strings.HasPrefix(id, entry.prefix) {
+			return entry.class
+		}
+	}
+	return LicensePermissive
+}
+
+// splitExpression splits a license expression by a case-insensitive operator
+func splitExpression(expr, operator string) []string {
+	lower := strings.ToLower(expr)
+	var parts []string
+	for {
+		idx := strings.Index(lower, operator)
+		if idx < 0 {
+			break
+		}
+		parts = append(parts, strings.TrimSpace(expr[:idx]))
+		expr, lower = expr[idx+len(operator):], lower[idx+len(operator):]
+	}
+	return append(parts, strings.TrimSpace(expr))
+}
+
+// dependencyExposure derives the exposure context of a dependency from its type and scope
+func dependencyExposure(dep types.Dependency) string {
+	switch {
+	case buildOnlyTypes[dep.Type] || dep.Scope == types.ScopeBuild:
+		return ExposureBuildOnly
+	case dep.Scope == types.ScopeDev || dep.Scope == types.ScopeTest:
+		return ExposureDevelopment
+	default:
+		return ExposureDistributed
+	}
+}
+
+// copyleftRisk returns the risk level of a copyleft class in an exposure context
+func copyleftRisk(class, exposure string) string {
+	if exposure != ExposureDistributed {
+		return RiskLow
+	}
+	if class == LicenseWeakCopyleft {
+		return RiskMedium
+	}
+	return RiskHigh
+}
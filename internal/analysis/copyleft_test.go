@@ -0,0 +1,108 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyLicense(t *testing.T) {
+	tests := []struct {
+		license  string
+		expected string
+	}{
+		{"", ""},
+		{"MIT", LicensePermissive},
+		{"Apache-2.0", LicensePermissive},
+		{"GPL-3.0-only", LicenseStrongCopyleft},
+		{"gpl-2.0-or-later", LicenseStrongCopyleft},
+		{"AGPL-3.0-or-later", LicenseNetworkCopyleft},
+		{"SSPL-1.0", LicenseNetworkCopyleft},
+		{"LGPL-2.1-only", LicenseWeakCopyleft},
+		{"MPL-2.0", LicenseWeakCopyleft},
+		{"EPL-2.0", LicenseWeakCopyleft},
+		{"GPL-2.0-only WITH Classpath-exception-2.0", LicenseWeakCopyleft},
+		{"GPL-2.0-only OR MIT", LicensePermissive},
+		{"(LGPL-3.0-only OR GPL-3.0-only)", LicenseWeakCopyleft},
+		{"MIT AND GPL-3.0-only", LicenseStrongCopyleft},
+		{"MIT and BSD-3-Clause", LicensePermissive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.license, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ClassifyLicense(tt.license))
+		})
+	}
+}
+
+func copyleftDep(depType, name, version string, scope types.Scope, license string) types.Dependency {
+	return types.Dependency{Type: depType, Name: name, Version: version, Scope: scope, Metadata: map[string]interface{}{parsers.MetadataLicenseDeclared: license}}
+}
+
+func TestBuildCopyleftExposure(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	root.ID = "root"
+
+	app := types.NewPayloadWithPath("app", "/app/pyproject.toml")
+	app.ID = "app"
+	app.Dependencies = []types.Dependency{
+		copyleftDep("python", "pyqt5", "5.15.9", types.ScopeProd, "GPL-3.0-only"),
+		copyleftDep("python", "chardet", "5.2.0", types.ScopeProd, "LGPL-2.1-only"),
+		copyleftDep("python", "requests", "2.31.0", types.ScopeProd, "Apache-2.0"),
+		copyleftDep("python", "pylint", "3.0.0", types.ScopeDev, "GPL-2.0-or-later"),
+		{Type: "python", Name: "unknown-lib", Version: "1.0.0", Scope: types.ScopeProd},
+	}
+	app.Dependencies[0].Metadata[parsers.MetadataLicenseConcluded] = "GPL-3.0-only"
+
+	web := types.NewPayloadWithPath("web", "/web/package.json")
+	web.ID = "web"
+	web.Dependencies = []types.Dependency{
+		copyleftDep("npm", "dual-licensed", "1.0.0", types.ScopeProd, "GPL-2.0-only OR MIT"),
+		copyleftDep("githubAction", "some/gpl-action", "v1", "", "GPL-3.0-only"),
+		copyleftDep("npm", "gpl-codegen", "2.0.0", types.ScopeBuild, "GPL-3.0-only"),
+	}
+	// A second component shipping the same package is reported once with both components
+	other := types.NewPayloadWithPath("other", "/other/pyproject.toml")
+	other.ID = "other"
+	other.Dependencies = []types.Dependency{copyleftDep("python", "pyqt5", "5.15.9", types.ScopeProd, "GPL-3.0-only")}
+
+	root.AddChild(app)
+	root.AddChild(web)
+	root.AddChild(other)
+
+	exposure := BuildCopyleftExposure(root)
+	require.NotNil(t, exposure)
+
+	assert.Equal(t, RiskHigh, exposure.Risk)
+	assert.Equal(t, map[string]int{RiskHigh: 1, RiskMedium: 1, RiskLow: 3}, exposure.Summary)
+	require.Len(t, exposure.Findings, 5)
+
+	assert.Equal(t, CopyleftFinding{
+		Type: "python", Name: "pyqt5", Version: "5.15.9", License: "GPL-3.0-only",
+		Class: LicenseStrongCopyleft, Scope: types.ScopeProd, Exposure: ExposureDistributed, Risk: RiskHigh,
+		Components: []string{"app", "other"},
+	}, exposure.Findings[0])
+	assert.Equal(t, "chardet", exposure.Findings[1].Name)
+	assert.Equal(t, RiskMedium, exposure.Findings[1].Risk)
+
+	exposures := make(map[string]string)
+	for _, finding := range exposure.Findings[2:] {
+		assert.Equal(t, RiskLow, finding.Risk)
+		exposures[finding.Name] = finding.Exposure
+	}
+	assert.Equal(t, map[string]string{
+		"some/gpl-action": ExposureBuildOnly,
+		"gpl-codegen":     ExposureBuildOnly,
+		"pylint":          ExposureDevelopment,
+	}, exposures)
+}
+
+func TestBuildCopyleftExposure_NoCopyleft(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	root.Dependencies = []types.Dependency{copyleftDep("npm", "react", "18.2.0", types.ScopeProd, "MIT")}
+
+	assert.Nil(t, BuildCopyleftExposure(root))
+	assert.Nil(t, BuildCopyleftExposure(nil))
+}
@@ -0,0 +1,140 @@
+package analysis
+
+import (
+	"path"
+	"sort"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// updatableEcosystems are the dependency types supported by Dependabot or Renovate
+var updatableEcosystems = map[string]bool{
+	parsers.DependencyTypeNpm:          true,
+	parsers.DependencyTypePython:       true,
+	parsers.DependencyTypeRuby:         true,
+	parsers.DependencyTypeGolang:       true,
+	parsers.DependencyTypeRust:         true,
+	parsers.DependencyTypeMaven:        true,
+	parsers.DependencyTypeGradle:       true,
+	parsers.DependencyTypePHP:          true,
+	parsers.DependencyTypeDotnet:       true,
+	parsers.DependencyTypeDocker:       true,
+	parsers.DependencyTypeGitHubAction: true,
+	parsers.DependencyTypeTerraform:    true,
+	parsers.DependencyTypeCocoapods:    true,
+	parsers.DependencyTypeConan:        true,
+}
+
+// UpdateCoverage reports which dependency ecosystems are kept up to date by automated update tools
+type UpdateCoverage struct {
+	Tools   []string      `json:"tools"`          // Configuration files of detected tools (e.g., "dependabot: /.github/dependabot.yml")
+	Covered []string      `json:"covered"`        // Ecosystems where every manifest directory is covered
+	Gaps    []CoverageGap `json:"gaps,omitempty"` // Ecosystems present in the repository but not (fully) covered
+}
+
+// CoverageGap lists the directories of an ecosystem not covered by any update tool
+type CoverageGap struct {
+	Ecosystem   string   `json:"ecosystem"`
+	Directories []string `json:"directories"`
+}
+
+// BuildUpdateCoverage compares the ecosystems found in the payload tree against the
+// Dependabot and Renovate configurations detected during the scan.
+// Returns nil if the tree has no dependencies of an updatable ecosystem.
+func BuildUpdateCoverage(payload *types.Payload) *UpdateCoverage {
+	if payload == nil {
+		return nil
+	}
+
+	configs := collectUpdateToolConfigs(payload)
+
+	// ecosystem -> directory -> covered
+	ecosystems := make(map[string]map[string]bool)
+	walkComponents(payload, func(component *types.Payload) {
+		dirs := componentDirs(component)
+		for _, dep := range component.Dependencies {
+			if !updatableEcosystems[dep.Type] {
+				continue
+			}
+			if ecosystems[dep.Type] == nil {
+				ecosystems[dep.Type] = make(map[string]bool)
+			}
+			covered := isCovered(configs, dep.Type, dirs)
+			ecosystems[dep.Type][dirs[0]] = ecosystems[dep.Type][dirs[0]] || covered
+		}
+	})
+
+	if len(ecosystems) == 0 {
+		return nil
+	}
+
+	coverage := &UpdateCoverage{Tools: []string{}, Covered: []string{}}
+	for _, config := range configs {
+		coverage.Tools = append(coverage.Tools, config.Tool+": "+config.File)
+	}
+	sort.Strings(coverage.Tools)
+
+	for ecosystem, dirs := range ecosystems {
+		var uncovered []string
+		for dir, covered := range dirs {
+			if !covered {
+				uncovered = append(uncovered, dir)
+			}
+		}
+		if len(uncovered) == 0 {
+			coverage.Covered = append(coverage.Covered, ecosystem)
+			continue
+		}
+		sort.Strings(uncovered)
+		coverage.Gaps = append(coverage.Gaps, CoverageGap{Ecosystem: ecosystem, Directories: uncovered})
+	}
+	sort.Strings(coverage.Covered)
+	sort.Slice(coverage.Gaps, func(i, j int) bool {
+		return coverage.Gaps[i].Ecosystem < coverage.Gaps[j].Ecosystem
+	})
+
+	return coverage
+}
+
+// collectUpdateToolConfigs gathers Dependabot and Renovate configurations from component properties
+func collectUpdateToolConfigs(payload *types.Payload) []*parsers.UpdateToolConfig {
+	var configs []*parsers.UpdateToolConfig
+	walkComponents(payload, func(component *types.Payload) {
+		for _, key := range []string{parsers.UpdateToolDependabot, parsers.UpdateToolRenovate} {
+			if config, ok := component.Properties[key].(*parsers.UpdateToolConfig); ok {
+				configs = append(configs, config)
+			}
+		}
+	})
+	return configs
+}
+
+// isCovered reports whether any tool covers the ecosystem in one of the directories
+func isCovered(configs []*parsers.UpdateToolConfig, ecosystem string, dirs []string) bool {
+	for _, config := range configs {
+		for _, dir := range dirs {
+			if config.Covers(ecosystem, dir) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// componentDirs returns the directories of a component's manifest paths (first entry is the primary directory).
+// Paths are file paths such as "/web/package.json"; the root payload uses "/".
+func componentDirs(component *types.Payload) []string {
+	dirs := make([]string, 0, len(component.Path))
+	for _, p := range component.Path {
+		dir := "/"
+		if p != "/" {
+			dir = path.Dir(p)
+		}
+		dirs = append(dirs, dir)
+	}
+	if len(dirs) == 0 {
+		dirs = append(dirs, "/")
+	}
+	return dirs
+}
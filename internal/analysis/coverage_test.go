@@ -0,0 +1,83 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildUpdateCoverage(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	root.Dependencies = []types.Dependency{
+		{Type: "githubAction", Name: "actions/checkout", Version: "v4"},
+	}
+
+	dependabot, err := parsers.NewUpdateToolsParser().ParseDependabot(`version: 2
+updates:
+  - package-ecosystem: npm
+    directory: /web
+  - package-ecosystem: github-actions
+    directory: /
+`)
+	require.NoError(t, err)
+	dependabot.File = "/.github/dependabot.yml"
+	root.Properties[parsers.UpdateToolDependabot] = dependabot
+
+	web := types.NewPayloadWithPath("web", "/web/package.json")
+	web.Dependencies = []types.Dependency{{Type: "npm", Name: "react", Version: "18.0.0"}}
+	admin := types.NewPayloadWithPath("admin", "/admin/package.json")
+	admin.Dependencies = []types.Dependency{{Type: "npm", Name: "vue", Version: "3.0.0"}}
+	api := types.NewPayloadWithPath("api", "/api/pyproject.toml")
+	api.Dependencies = []types.Dependency{
+		{Type: "python", Name: "fastapi", Version: "0.110.0"},
+		{Type: "unknown", Name: "ignored", Version: "1.0"},
+	}
+	root.AddChild(web)
+	root.AddChild(admin)
+	root.AddChild(api)
+
+	coverage := BuildUpdateCoverage(root)
+	require.NotNil(t, coverage)
+
+	assert.Equal(t, []string{"dependabot: /.github/dependabot.yml"}, coverage.Tools)
+	assert.Equal(t, []string{"githubAction"}, coverage.Covered)
+	assert.Equal(t, []CoverageGap{
+		{Ecosystem: "npm", Directories: []string{"/admin"}},
+		{Ecosystem: "python", Directories: []string{"/api"}},
+	}, coverage.Gaps)
+}
+
+func TestBuildUpdateCoverage_RenovateCoversAll(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	renovate := parsers.NewUpdateToolsParser().ParseRenovate(`{}`)
+	renovate.File = "/renovate.json"
+	root.Properties[parsers.UpdateToolRenovate] = renovate
+
+	app := types.NewPayloadWithPath("app", "/app/Cargo.toml")
+	app.Dependencies = []types.Dependency{{Type: "cargo", Name: "serde", Version: "1.0"}}
+	root.AddChild(app)
+
+	coverage := BuildUpdateCoverage(root)
+	require.NotNil(t, coverage)
+	assert.Equal(t, []string{"cargo"}, coverage.Covered)
+	assert.Empty(t, coverage.Gaps)
+}
+
+func TestBuildUpdateCoverage_NoTools(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	root.Dependencies = []types.Dependency{{Type: "golang", Name: "github.com/spf13/cobra", Version: "v1.8.0"}}
+
+	coverage := BuildUpdateCoverage(root)
+	require.NotNil(t, coverage)
+	assert.Empty(t, coverage.Tools)
+	assert.Empty(t, coverage.Covered)
+	assert.Equal(t, []CoverageGap{{Ecosystem: "golang", Directories: []string{"/"}}}, coverage.Gaps)
+}
+
+func TestBuildUpdateCoverage_NoEcosystems(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	assert.Nil(t, BuildUpdateCoverage(root))
+}
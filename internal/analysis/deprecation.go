@@ -0,0 +1,57 @@
+package analysis
+
+import (
+	"log/slog"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// ConcludeDeprecations flags the direct dependencies the registry reports as deprecated or
+// yanked: the whole package when its latest version is (npm deprecated, PyPI and crates.io
+// yanked), else the version used (npm deprecated, PyPI, crates.io, and RubyGems yanked). The
+// deprecation message is stored with the flag. Returns the number of dependencies flagged.
+func ConcludeDeprecations(payload *types.Payload, lookup PackageLookup, logger *slog.Logger) int {
+	if payload == nil || lookup == nil {
+		return 0
+	}
+
+	flagged := 0
+	walkComponents(payload, func(component *types.Payload) {
+		for i, dep := range component.Dependencies {
+			if !dep.Direct || !lookup.Supports(dep.Type) {
+				continue
+			}
+			version := baseVersion(dep.Version)
+			if version == "" {
+				continue
+			}
+			info, err := lookup.Lookup(dep.Type, dep.Name)
+			if err != nil {
+				if logger != nil {
+					logger.Debug("Registry lookup failed", "type", dep.Type, "name", dep.Name, "error", err)
+				}
+				continue
+			}
+			deprecated, message := parsers.DeprecatedPackage, info.Deprecated
+			if message == "" {
+				deprecated, message = parsers.DeprecatedVersion, info.DeprecationFor(version)
+			}
+			if message == "" {
+				continue
+			}
+
+			// Copy the metadata: dependencies of a manifest may share a single metadata map
+			metadata := make(map[string]interface{}, len(dep.Metadata)+2)
+			for key, value := range dep.Metadata {
+				metadata[key] = value
+			}
+			metadata[parsers.MetadataDeprecated] = deprecated
+			metadata[parsers.MetadataDeprecationMessage] = message
+			recordRegistryOrigin(metadata, dep, parsers.MetadataDeprecated, deprecated)
+			component.Dependencies[i].Metadata = metadata
+			flagged++
+		}
+	})
+	return flagged
+}
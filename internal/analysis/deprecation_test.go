@@ -0,0 +1,43 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/registry"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcludeDeprecations(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	shared := types.NewMetadata(parsers.MetadataSourcePackageJSON)
+	root.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "request", Version: "^2.88.0", Scope: types.ScopeProd, Direct: true, Metadata: shared},
+		{Type: "npm", Name: "react", Version: "18.2.0", Scope: types.ScopeProd, Direct: true, Metadata: shared},
+		{Type: "python", Name: "urllib3", Version: "==2.0.0", Scope: types.ScopeProd, Direct: true},
+		{Type: "npm", Name: "querystring", Version: "0.2.0", Scope: types.ScopeProd, Direct: false, Metadata: shared},
+		{Type: "npm", Name: "react-dom", Version: "latest", Scope: types.ScopeProd, Direct: true, Metadata: shared},
+	}
+	lookup := &fakeLookup{packages: map[string]*registry.PackageInfo{
+		"npm:request": {LatestVersion: "2.88.2", Deprecated: "request has been deprecated",
+			VersionDeprecations: map[string]string{"2.88.0": "request has been deprecated (version)"}},
+		"npm:react":       {LatestVersion: "18.3.1", VersionDeprecations: map[string]string{"18.0.0": "use 18.2.0"}},
+		"python:urllib3":  {LatestVersion: "2.2.3", VersionDeprecations: map[string]string{"2.0.0": "yanked: broken wheel"}},
+		"npm:querystring": {LatestVersion: "0.2.1", Deprecated: "use URLSearchParams"},
+		"npm:react-dom":   {LatestVersion: "18.3.1", Deprecated: "not really"},
+	}}
+
+	assert.Equal(t, 2, ConcludeDeprecations(root, lookup, nil))
+
+	deps := root.Dependencies
+	assert.Equal(t, parsers.DeprecatedPackage, deps[0].Metadata[parsers.MetadataDeprecated], "the package deprecation takes precedence")
+	assert.Equal(t, "request has been deprecated", deps[0].Metadata[parsers.MetadataDeprecationMessage])
+	assert.Equal(t, parsers.MetadataSourcePackageJSON, deps[0].Metadata["source"])
+	assert.NotContains(t, deps[1].Metadata, parsers.MetadataDeprecated)
+	assert.Equal(t, parsers.DeprecatedVersion, deps[2].Metadata[parsers.MetadataDeprecated])
+	assert.Equal(t, "yanked: broken wheel", deps[2].Metadata[parsers.MetadataDeprecationMessage])
+	assert.NotContains(t, deps[3].Metadata, parsers.MetadataDeprecated, "transitive dependencies are not looked up")
+	assert.NotContains(t, deps[4].Metadata, parsers.MetadataDeprecated, "no concrete version")
+	assert.NotContains(t, shared, parsers.MetadataDeprecated, "shared manifest metadata is not modified")
+}
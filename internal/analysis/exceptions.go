@@ -0,0 +1,159 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+// exceptionDateLayout is the layout of exception expiry dates
+const exceptionDateLayout = "2006-01-02"
+
+// Exception approves the findings of a package version despite policy, e.g. a copyleft
+// dependency that legal review cleared for its linkage
+type Exception struct {
+	Package  string   `yaml:"package" json:"package"`                 // Dependency type and name ("npm:some-lgpl")
+	Version  string   `yaml:"version" json:"version"`                 // Exact version, or "*" for every version
+	Rules    []string `yaml:"rules,omitempty" json:"rules,omitempty"` // Rule IDs approved; empty = all rules
+	Reviewer string   `yaml:"reviewer" json:"reviewer"`
+	Reason   string   `yaml:"reason" json:"reason"`
+	Expires  string   `yaml:"expires" json:"expires"` // Last day the exception applies (YYYY-MM-DD)
+}
+
+// exceptionsFile is the layout of the exceptions file
+type exceptionsFile struct {
+	Exceptions []Exception `yaml:"exceptions"`
+}
+
+// AcceptedRisk is a finding approved by an exception; it is reported instead of failing the
+// scan or being published as a violation
+type AcceptedRisk struct {
+	RuleID    string `json:"rule_id"`
+	Level     string `json:"level"`
+	Component string `json:"component"`
+	File      string `json:"file"`
+	Subject   string `json:"subject"`
+	Version   string `json:"version"`
+	Message   string `json:"message"`
+	Reviewer  string `json:"reviewer"`
+	Reason    string `json:"reason"`
+	Expires   string `json:"expires"`
+}
+
+// ParseExceptions parses an exceptions file (YAML or JSON) with an "exceptions" list. Every
+// exception needs a package ("type:name"), a version, a reviewer, a reason, and an expiry
+// date; listed rules must be known rule IDs.
+func ParseExceptions(data []byte) ([]Exception, error) {
+	var file exceptionsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid exceptions file: %w", err)
+	}
+	for i, exception := range file.Exceptions {
+		name := fmt.Sprintf("exception %d", i+1)
+		if exception.Package != "" {
+			name += " (" + exception.Package + ")"
+		}
+		switch {
+		case !strings.Contains(exception.Package, ":"):
+			return nil, fmt.Errorf("%s: package must be \"type:name\"", name)
+		case exception.Version == "":
+			return nil, fmt.Errorf("%s: version is required (\"*\" for every version)", name)
+		case exception.Reviewer == "" || exception.Reason == "":
+			return nil, fmt.Errorf("%s: reviewer and reason are required", name)
+		}
+		if _, err := time.Parse(exceptionDateLayout, exception.Expires); err != nil {
+			return nil, fmt.Errorf("%s: expires must be a date (YYYY-MM-DD)", name)
+		}
+		for _, rule := range exception.Rules {
+			if !isRuleID(rule) {
+				return nil, fmt.Errorf("%s: unknown rule '%s'. Valid: %s", name, rule, strings.Join(RuleIDs, ", "))
+			}
+		}
+	}
+	return file.Exceptions, nil
+}
+
+// Expired returns true if the exception no longer applies on the day of now
+func (e Exception) Expired(now time.Time) bool {
+	return now.Format(exceptionDateLayout) > e.Expires
+}
+
+// matches returns true if the exception approves a finding of a dependency version
+func (e Exception) matches(finding Finding) bool {
+	if e.Package != finding.Subject || (e.Version != "*" && e.Version != finding.Version) {
+		return false
+	}
+	if len(e.Rules) == 0 {
+		return true
+	}
+	for _, rule := range e.Rules {
+		if rule == finding.RuleID {
+			return true
+		}
+	}
+	return false
+}
+
+// AcceptRisks records the findings of the payload tree approved by an exception as accepted
+// risks of the report, so BuildFindings no longer returns them. Expired exceptions do not
+// apply and are returned, so callers can warn about them. Analyses must have run on the
+// payload before.
+func AcceptRisks(payload *types.Payload, exceptions []Exception, now time.Time) []Exception {
+	if payload == nil || len(exceptions) == 0 {
+		return nil
+	}
+
+	var active, expired []Exception
+	for _, exception := range exceptions {
+		if exception.Expired(now) {
+			expired = append(expired, exception)
+		} else {
+			active = append(active, exception)
+		}
+	}
+
+	report := ReportFor(payload)
+	report.AcceptedRisks = nil
+	for _, finding := range BuildFindings(payload) {
+		for _, exception := range active {
+			if !exception.matches(finding) {
+				continue
+			}
+			report.AcceptedRisks = append(report.AcceptedRisks, AcceptedRisk{
+				RuleID: finding.RuleID, Level: finding.Level, Component: finding.Component, File: finding.File,
+				Subject: finding.Subject, Version: finding.Version, Message: finding.Message,
+				Reviewer: exception.Reviewer, Reason: exception.Reason, Expires: exception.Expires,
+			})
+			break
+		}
+	}
+	sort.SliceStable(report.AcceptedRisks, func(i, j int) bool {
+		a, b := report.AcceptedRisks[i], report.AcceptedRisks[j]
+		if a.Subject != b.Subject {
+			return a.Subject < b.Subject
+		}
+		return a.RuleID < b.RuleID
+	})
+	return expired
+}
+
+// acceptedIndex indexes the accepted risks of the report by finding key
+func acceptedIndex(report *Report) map[string]bool {
+	index := make(map[string]bool)
+	if report == nil {
+		return index
+	}
+	for _, risk := range report.AcceptedRisks {
+		index[findingKey(risk.RuleID, risk.File, risk.Message)] = true
+	}
+	return index
+}
+
+// findingKey identifies a finding within a scan
+func findingKey(ruleID, file, message string) string {
+	return ruleID + "|" + file + "|" + message
+}
@@ -0,0 +1,67 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExceptions(t *testing.T) {
+	exceptions, err := ParseExceptions([]byte(`
+exceptions:
+  - package: php:some/lgpl
+    version: "^2.0"
+    rules: [copyleft-distributed]
+    reviewer: legal@acme.com
+    reason: Dynamically linked, cleared by legal review
+    expires: 2027-06-30
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []Exception{{
+		Package: "php:some/lgpl", Version: "^2.0", Rules: []string{RuleCopyleftDistributed},
+		Reviewer: "legal@acme.com", Reason: "Dynamically linked, cleared by legal review", Expires: "2027-06-30",
+	}}, exceptions)
+
+	invalid := map[string]string{
+		"package without type": `{"exceptions": [{"package": "lodash", "version": "*", "reviewer": "a", "reason": "b", "expires": "2027-01-01"}]}`,
+		"missing version":      `{"exceptions": [{"package": "npm:lodash", "reviewer": "a", "reason": "b", "expires": "2027-01-01"}]}`,
+		"missing reviewer":     `{"exceptions": [{"package": "npm:lodash", "version": "*", "reason": "b", "expires": "2027-01-01"}]}`,
+		"invalid expiry":       `{"exceptions": [{"package": "npm:lodash", "version": "*", "reviewer": "a", "reason": "b", "expires": "soon"}]}`,
+		"unknown rule":         `{"exceptions": [{"package": "npm:lodash", "version": "*", "rules": ["gpl"], "reviewer": "a", "reason": "b", "expires": "2027-01-01"}]}`,
+		"not a list":           `exceptions: yes`,
+	}
+	for name, data := range invalid {
+		_, err := ParseExceptions([]byte(data))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestAcceptRisks(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	approved := Exception{Package: "php:some/lgpl", Version: "^2.0", Rules: []string{RuleCopyleftDistributed},
+		Reviewer: "legal@acme.com", Reason: "Dynamically linked", Expires: "2026-10-16"}
+	otherVersion := Exception{Package: "php:some/lgpl", Version: "^3.0", Reviewer: "a", Reason: "b", Expires: "2027-01-01"}
+	expired := Exception{Package: "php:some/lgpl", Version: "*", Reviewer: "a", Reason: "b", Expires: "2026-10-15"}
+
+	root := sarifTree()
+	all := BuildFindings(root)
+
+	assert.Equal(t, []Exception{expired}, AcceptRisks(root, []Exception{approved, otherVersion, expired}, now))
+	report := ReportFor(root)
+	require.Len(t, report.AcceptedRisks, 1)
+	assert.Equal(t, AcceptedRisk{
+		RuleID: RuleCopyleftDistributed, Level: LevelWarning, Component: "api", File: "api/composer.json",
+		Subject: "php:some/lgpl", Version: "^2.0", Message: all[1].Message,
+		Reviewer: "legal@acme.com", Reason: "Dynamically linked", Expires: "2026-10-16",
+	}, report.AcceptedRisks[0])
+
+	findings := BuildFindings(root)
+	assert.Len(t, findings, len(all)-1, "accepted risks are not findings")
+	assert.NotContains(t, findings, all[1])
+
+	AcceptRisks(root, []Exception{approved}, now.AddDate(0, 0, 1))
+	assert.Empty(t, ReportFor(root).AcceptedRisks, "the exception expired")
+	assert.Len(t, BuildFindings(root), len(all))
+}
@@ -0,0 +1,67 @@
+package analysis
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RuleIDs lists the rule IDs of all findings
+var RuleIDs = []string{RuleComplexityThreshold, RuleCopyleftDistributed, RuleDeprecatedDependency, RuleEOLRuntime, RuleInstallScript, RuleLicenseMismatch, RuleOutdatedDependency, RulePrereleaseDependency, RuleSingleMaintainer, RuleUnpinnedDependency}
+
+// levelRank orders finding levels by severity
+var levelRank = map[string]int{LevelNote: 1, LevelWarning: 2, LevelError: 3}
+
+// FailOn decides which findings fail a scan. A finding fails when it reaches the level or
+// its rule is listed.
+type FailOn struct {
+	Level string          // Minimum level; empty = levels do not fail
+	Rules map[string]bool // Rule IDs that fail at any level
+}
+
+// ParseFailOn parses a comma-separated list of finding levels (note, warning, error) and rule
+// IDs (copyleft-distributed, ...). The lowest listed level applies. Returns nil for an empty list.
+func ParseFailOn(spec string) (*FailOn, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+
+	failOn := &FailOn{Rules: make(map[string]bool)}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		switch {
+		case item == "":
+		case levelRank[item] > 0:
+			if failOn.Level == "" || levelRank[item] < levelRank[failOn.Level] {
+				failOn.Level = item
+			}
+		case isRuleID(item):
+			failOn.Rules[item] = true
+		default:
+			return nil, fmt.Errorf("invalid fail-on condition '%s'. Valid: note, warning, error, %s", item, strings.Join(RuleIDs, ", "))
+		}
+	}
+	return failOn, nil
+}
+
+// Failing returns the findings that fail the scan
+func (f *FailOn) Failing(findings []Finding) []Finding {
+	if f == nil {
+		return nil
+	}
+	var failing []Finding
+	for _, finding := range findings {
+		if f.Rules[finding.RuleID] || (f.Level != "" && levelRank[finding.Level] >= levelRank[f.Level]) {
+			failing = append(failing, finding)
+		}
+	}
+	return failing
+}
+
+func isRuleID(id string) bool {
+	for _, rule := range RuleIDs {
+		if rule == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFailOn(t *testing.T) {
+	failOn, err := ParseFailOn("")
+	require.NoError(t, err)
+	assert.Nil(t, failOn)
+
+	failOn, err = ParseFailOn("error, Warning,copyleft-distributed,")
+	require.NoError(t, err)
+	assert.Equal(t, &FailOn{Level: LevelWarning, Rules: map[string]bool{RuleCopyleftDistributed: true}}, failOn, "the lowest level applies")
+
+	failOn, err = ParseFailOn("eol-runtime")
+	require.NoError(t, err)
+	assert.Equal(t, &FailOn{Rules: map[string]bool{RuleEOLRuntime: true}}, failOn)
+
+	_, err = ParseFailOn("critical")
+	assert.ErrorContains(t, err, "invalid fail-on condition 'critical'")
+}
+
+func TestFailOnFailing(t *testing.T) {
+	findings := []Finding{
+		{RuleID: RuleCopyleftDistributed, Level: LevelError, Subject: "npm:gpl-lib"},
+		{RuleID: RuleUnpinnedDependency, Level: LevelWarning, Subject: "npm:left-pad"},
+		{RuleID: RuleLicenseMismatch, Level: LevelNote, Subject: "npm:react"},
+	}
+	failing := func(spec string) []string {
+		failOn, err := ParseFailOn(spec)
+		require.NoError(t, err)
+		var subjects []string
+		for _, finding := range failOn.Failing(findings) {
+			subjects = append(subjects, finding.Subject)
+		}
+		return subjects
+	}
+
+	assert.Equal(t, []string{"npm:gpl-lib"}, failing("error"))
+	assert.Equal(t, []string{"npm:gpl-lib", "npm:left-pad"}, failing("warning"))
+	assert.Equal(t, []string{"npm:gpl-lib", "npm:left-pad", "npm:react"}, failing("note"))
+	assert.Equal(t, []string{"npm:react"}, failing("license-mismatch"), "rules fail at any level")
+	assert.Equal(t, []string{"npm:gpl-lib", "npm:react"}, failing("error,license-mismatch"))
+	assert.Empty(t, failing("outdated-dependency"))
+	assert.Empty(t, failing(""))
+}
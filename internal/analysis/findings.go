@@ -0,0 +1,242 @@
+package analysis
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Rule IDs of the reported findings
+const (
+	RuleCopyleftDistributed  = "copyleft-distributed"
+	RuleLicenseMismatch      = "license-mismatch"
+	RuleUnpinnedDependency   = "unpinned-dependency"
+	RuleOutdatedDependency   = "outdated-dependency"
+	RuleComplexityThreshold  = "complexity-threshold"
+	RuleInstallScript        = "install-script"
+	RuleSingleMaintainer     = "single-maintainer"
+	RuleDeprecatedDependency = "deprecated-dependency"
+	RulePrereleaseDependency = "prerelease-dependency"
+	RuleEOLRuntime           = "eol-runtime"
+)
+
+// Finding levels (SARIF result levels)
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+	LevelNote    = "note"
+)
+
+// Finding is an actionable result of the post-scan analyses
+type Finding struct {
+	RuleID    string
+	Level     string
+	Component string // Name of the component the finding belongs to
+	File      string // Manifest path relative to the scanned directory (no leading slash)
+	Line      int    // Line of the dependency declaration in File, 0 if unknown
+	Subject   string // Dependency ("npm:react") or component name the finding is about
+	Version   string // Version of the dependency, empty for component findings
+	Message   string
+}
+
+// Fingerprint identifies a finding across scans by rule, manifest file, and subject; versions
+// and counts in the message do not change it
+func (f Finding) Fingerprint() string {
+	sum := sha256.Sum256([]byte(f.RuleID + "|" + f.File + "|" + f.Subject))
+	return hex.EncodeToString(sum[:8])
+}
+
+// BuildFindings collects the findings of the payload tree: distributed copyleft dependencies
+// (high and medium risk), license mismatches, unpinned direct dependencies, outdated
+// dependencies of the upgrade advisory, components exceeding the complexity thresholds,
+// dependencies running install scripts, central single-maintainer dependencies, deprecated
+// or yanked dependencies, distributed direct dependencies on pre-release versions, and
+// deprecated Lambda runtimes and out-of-support .NET frameworks and SDKs.
+// Findings accepted by exceptions (see AcceptRisks) are left out. Findings are sorted by rule and file. Analyses must have run on the payload before.
+func BuildFindings(payload *types.Payload) []Finding {
+	if payload == nil {
+		return nil
+	}
+
+	report, _ := payload.Analysis.(*Report)
+	collector := &findingCollector{seen: make(map[string]bool), outdated: outdatedIndex(report), busFactor: busFactorIndex(report), accepted: acceptedIndex(report)}
+	if report != nil && report.Complexity != nil {
+		collector.thresholds = report.Complexity
+	}
+	if report != nil && report.BusFactor != nil {
+		collector.components = report.BusFactor.Components
+	}
+	walkComponents(payload, collector.collect)
+
+	sort.SliceStable(collector.findings, func(i, j int) bool {
+		a, b := collector.findings[i], collector.findings[j]
+		if a.RuleID != b.RuleID {
+			return a.RuleID < b.RuleID
+		}
+		return a.File < b.File
+	})
+	return collector.findings
+}
+
+// outdatedIndex indexes the upgrade advisory by dependency type, name, and current version
+func outdatedIndex(report *Report) map[string]UpgradeAdvice {
+	index := make(map[string]UpgradeAdvice)
+	if report == nil {
+		return index
+	}
+	for _, advice := range report.UpgradeAdvisory {
+		index[advice.Type+":"+advice.Name+"@"+advice.CurrentVersion] = advice
+	}
+	return index
+}
+
+// busFactorIndex indexes the central single-maintainer dependencies by type and name
+func busFactorIndex(report *Report) map[string]BusFactorPackage {
+	index := make(map[string]BusFactorPackage)
+	if report == nil || report.BusFactor == nil {
+		return index
+	}
+	for _, pkg := range report.BusFactor.Packages {
+		index[pkg.Type+":"+pkg.Name] = pkg
+	}
+	return index
+}
+
+// findingCollector collects the findings of every component, skipping duplicates
+type findingCollector struct {
+	findings   []Finding
+	seen       map[string]bool
+	outdated   map[string]UpgradeAdvice
+	busFactor  map[string]BusFactorPackage
+	accepted   map[string]bool // Keys of the findings accepted by exceptions
+	components int             // Components with direct dependencies, of the bus factor risk
+	thresholds *DependencyComplexity
+}
+
+func (c *findingCollector) collect(component *types.Payload) {
+	for _, dep := range component.Dependencies {
+		file, line := declarationLocation(component, dep)
+		subject := dep.Type + ":" + dep.Name
+		label := fmt.Sprintf("%s %s (%s)", dep.Name, dep.Version, dep.Type)
+
+		depLicense := dependencyLicense(dep)
+		if class := ClassifyLicense(depLicense); class != "" && class != LicensePermissive {
+			level := map[string]string{RiskHigh: LevelError, RiskMedium: LevelWarning}[copyleftRisk(class, dependencyExposure(dep))]
+			if level != "" {
+				c.add(component, RuleCopyleftDistributed, level, file, line, subject, dep.Version, fmt.Sprintf("%s is licensed %s (%s) and distributed with the product", label, depLicense, class))
+			}
+		}
+
+		if mismatch, _ := dep.Metadata[parsers.MetadataLicenseMismatch].(bool); mismatch {
+			c.add(component, RuleLicenseMismatch, LevelWarning, file, line, subject, dep.Version, fmt.Sprintf("%s declares %s, the registry concludes %s", label,
+				metadataLicense(dep, parsers.MetadataLicenseDeclared), metadataLicense(dep, parsers.MetadataLicenseConcluded)))
+		}
+
+		if flagged, _ := dep.Metadata[parsers.MetadataInstallScript].(bool); flagged {
+			message := label + " runs scripts when installed"
+			if hooks := metadataStrings(dep.Metadata[parsers.MetadataInstallHooks]); len(hooks) > 0 {
+				message += " (" + strings.Join(hooks, ", ") + ")"
+			}
+			c.add(component, RuleInstallScript, LevelNote, file, line, subject, dep.Version, message)
+		}
+
+		if message, _ := dep.Metadata[parsers.MetadataDeprecationMessage].(string); message != "" {
+			what := "is deprecated"
+			if dep.Metadata[parsers.MetadataDeprecated] == parsers.DeprecatedVersion {
+				what = "uses a deprecated version"
+			}
+			c.add(component, RuleDeprecatedDependency, LevelWarning, file, line, subject, dep.Version, fmt.Sprintf("%s %s: %s", label, what, message))
+		}
+
+		if !dep.Direct {
+			continue
+		}
+		if style := ClassifyConstraint(dep); style == PinWildcard || style == PinGitBranch {
+			c.add(component, RuleUnpinnedDependency, LevelWarning, file, line, subject, dep.Version, fmt.Sprintf("%s is not pinned (%s)", label, style))
+		}
+		if dependencyExposure(dep) == ExposureDistributed {
+			if _, stage := prereleaseVersion(dep); stage != "" {
+				c.add(component, RulePrereleaseDependency, LevelWarning, file, line, subject, dep.Version, fmt.Sprintf("%s is a pre-release version (%s)", label, stage))
+			}
+		}
+		if advice, ok := c.outdated[dep.Type+":"+dep.Name+"@"+baseVersion(dep.Version)]; ok {
+			level := LevelNote
+			if advice.Deprecated != "" {
+				level = LevelWarning
+			}
+			c.add(component, RuleOutdatedDependency, level, file, line, subject, dep.Version, fmt.Sprintf("%s is outdated, latest is %s (%s update)", label, advice.LatestVersion, advice.UpdateType))
+		}
+		if pkg, ok := c.busFactor[subject]; ok {
+			c.add(component, RuleSingleMaintainer, LevelNote, file, line, subject, dep.Version, fmt.Sprintf("%s has a single maintainer and is declared by %d of %d components", label, pkg.Dependents, c.components))
+		}
+	}
+
+	c.collectRuntimes(component)
+
+	if c.thresholds != nil && c.thresholds.Thresholds != nil && len(component.Dependencies) > 0 {
+		complexity := componentComplexity(component)
+		if exceeded := exceededThresholds(complexity, c.thresholds.Thresholds); len(exceeded) > 0 {
+			file := componentDirs(component)[0]
+			if len(component.Path) > 0 {
+				file = component.Path[0]
+			}
+			c.add(component, RuleComplexityThreshold, LevelWarning, file, 0, component.Name, "", fmt.Sprintf("Component %s exceeds %s (direct %d, transitive %d, ecosystems %d, score %d)",
+				component.Name, strings.Join(exceeded, ", "), complexity.Direct, complexity.Transitive, len(complexity.Ecosystems), complexity.Score))
+		}
+	}
+}
+
+// collectRuntimes reports the end-of-life runtimes of a component: deprecated Lambda runtimes
+// of serverless applications, out-of-support .NET target frameworks, and SDK pins of global.json
+// whose runtime is out of support
+func (c *findingCollector) collectRuntimes(component *types.Payload) {
+	apps, _ := component.Properties["serverless"].([]interface{})
+	for _, entry := range apps {
+		app, ok := entry.(*parsers.ServerlessInfo)
+		if !ok {
+			continue
+		}
+		for _, runtime := range app.DeprecatedRuntimes() {
+			c.add(component, RuleEOLRuntime, LevelWarning, app.File, 0, runtime.Runtime, "", fmt.Sprintf("Lambda runtime %s is deprecated since %s", runtime.Runtime, runtime.Deprecation))
+		}
+	}
+
+	if dotnet, ok := component.Properties["dotnet"].(map[string]interface{}); ok {
+		support, _ := dotnet["framework_support"].([]*parsers.DotNetFrameworkSupport)
+		for _, framework := range support {
+			if framework.Supported || len(component.Path) == 0 {
+				continue
+			}
+			c.add(component, RuleEOLRuntime, LevelWarning, component.Path[0], 0, framework.Framework, "", fmt.Sprintf("Target framework %s (%s) is out of support since %s", framework.Framework, framework.Product, framework.EndOfSupport))
+		}
+	}
+	if sdk, ok := component.Properties["dotnet_sdk"].(*parsers.DotNetSDKInfo); ok && sdk.Support != nil && !sdk.Support.Supported {
+		c.add(component, RuleEOLRuntime, LevelWarning, sdk.File, 0, sdk.Support.Framework, "", fmt.Sprintf(".NET SDK %s ships %s, out of support since %s", sdk.Version, sdk.Support.Product, sdk.Support.EndOfSupport))
+	}
+}
+
+func (c *findingCollector) add(component *types.Payload, ruleID, level, file string, line int, subject, version, message string) {
+	file = strings.TrimPrefix(file, "/")
+	key := findingKey(ruleID, file, message)
+	if c.seen[key] || c.accepted[key] {
+		return
+	}
+	c.seen[key] = true
+	c.findings = append(c.findings, Finding{RuleID: ruleID, Level: level, Component: component.Name, File: file, Line: line, Subject: subject, Version: version, Message: message})
+}
+
+// declarationLocation returns the manifest and line declaring a dependency when the scan
+// located its declaration, else the file it was read from and line 0
+func declarationLocation(component *types.Payload, dep types.Dependency) (string, int) {
+	file, _ := dep.Metadata[parsers.MetadataFile].(string)
+	line := metadataInt(dep.Metadata[parsers.MetadataLine])
+	if file == "" || line <= 0 {
+		return dependencyFile(component, dep), 0
+	}
+	return file, line
+}
@@ -0,0 +1,117 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildFindings(t *testing.T) {
+	findings := BuildFindings(sarifTree())
+	require.Len(t, findings, 9)
+
+	assert.Equal(t, Finding{
+		RuleID:    RuleCopyleftDistributed,
+		Level:     LevelWarning,
+		Component: "api",
+		File:      "api/composer.json",
+		Subject:   "php:some/lgpl",
+		Version:   "^2.0",
+		Message:   "some/lgpl ^2.0 (php) is licensed LGPL-3.0-only (weak_copyleft) and distributed with the product",
+	}, findings[1])
+	assert.Equal(t, "web", findings[0].Subject, "complexity findings are about the component")
+	assert.Equal(t, Finding{
+		RuleID:    RuleEOLRuntime,
+		Level:     LevelWarning,
+		Component: "functions",
+		File:      "functions/serverless.yml",
+		Subject:   "nodejs16.x",
+		Message:   "Lambda runtime nodejs16.x is deprecated since 2024-06-12",
+	}, findings[3], "supported runtimes are not reported")
+
+	assert.Nil(t, BuildFindings(nil))
+	assert.Empty(t, BuildFindings(types.NewPayloadWithPath("main", "/")))
+}
+
+func TestBuildFindings_InstallScript(t *testing.T) {
+	root := types.NewPayloadWithPath("web", "/package-lock.json")
+	root.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "esbuild", Version: "0.20.0", Scope: types.ScopeDev, Direct: true,
+			Metadata: map[string]interface{}{"source": "package-lock.json", parsers.MetadataInstallScript: true, parsers.MetadataInstallHooks: []string{"postinstall"}}},
+		{Type: "npm", Name: "core-js", Version: "3.33.0", Scope: types.ScopeProd, Direct: false,
+			Metadata: map[string]interface{}{"source": "package-lock.json", parsers.MetadataInstallScript: true}},
+	}
+
+	findings := BuildFindings(root)
+	require.Len(t, findings, 2)
+	assert.Equal(t, RuleInstallScript, findings[0].RuleID)
+	assert.Equal(t, LevelNote, findings[0].Level)
+	assert.Equal(t, "package-lock.json", findings[0].File)
+	assert.Equal(t, "esbuild 0.20.0 (npm) runs scripts when installed (postinstall)", findings[0].Message)
+	assert.Equal(t, "core-js 3.33.0 (npm) runs scripts when installed", findings[1].Message, "transitive dependencies are reported")
+}
+
+func TestBuildFindings_SingleMaintainer(t *testing.T) {
+	root := types.NewPayloadWithPath("api", "/api/package.json")
+	root.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "left-pad", Version: "1.3.0", Scope: types.ScopeProd, Direct: true,
+			Metadata: map[string]interface{}{"source": "api/package.json", parsers.MetadataMaintainers: 1}},
+	}
+	report := ReportFor(root)
+	report.BusFactor = BuildBusFactorRisk(root)
+
+	findings := BuildFindings(root)
+	require.Len(t, findings, 1)
+	assert.Equal(t, RuleSingleMaintainer, findings[0].RuleID)
+	assert.Equal(t, LevelNote, findings[0].Level)
+	assert.Equal(t, "npm:left-pad", findings[0].Subject)
+	assert.Equal(t, "left-pad 1.3.0 (npm) has a single maintainer and is declared by 1 of 1 components", findings[0].Message)
+}
+
+func TestBuildFindings_Deprecated(t *testing.T) {
+	root := types.NewPayloadWithPath("api", "/requirements.txt")
+	root.Dependencies = []types.Dependency{
+		{Type: "python", Name: "urllib3", Version: "2.0.0", Scope: types.ScopeProd, Direct: true,
+			Metadata: map[string]interface{}{"source": "requirements.txt", parsers.MetadataDeprecated: parsers.DeprecatedVersion, parsers.MetadataDeprecationMessage: "yanked"}},
+		{Type: "npm", Name: "request", Version: "2.88.2", Scope: types.ScopeProd, Direct: true,
+			Metadata: map[string]interface{}{"source": "package.json", parsers.MetadataDeprecated: parsers.DeprecatedPackage, parsers.MetadataDeprecationMessage: "request has been deprecated"}},
+	}
+
+	findings := BuildFindings(root)
+	require.Len(t, findings, 2)
+	assert.Equal(t, RuleDeprecatedDependency, findings[0].RuleID)
+	assert.Equal(t, LevelWarning, findings[0].Level)
+	assert.Equal(t, "request 2.88.2 (npm) is deprecated: request has been deprecated", findings[0].Message)
+	assert.Equal(t, "urllib3 2.0.0 (python) uses a deprecated version: yanked", findings[1].Message)
+}
+
+func TestBuildFindings_Prerelease(t *testing.T) {
+	root := types.NewPayloadWithPath("web", "/package.json")
+	root.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "react", Version: "19.0.0-rc.1", Scope: types.ScopeProd, Direct: true, Metadata: map[string]interface{}{"source": "package.json"}},
+		{Type: "npm", Name: "vitest", Version: "2.0.0-beta.3", Scope: types.ScopeDev, Direct: true, Metadata: map[string]interface{}{"source": "package.json"}},
+		{Type: "npm", Name: "scheduler", Version: "0.25.0-rc.1", Scope: types.ScopeProd, Direct: false, Metadata: map[string]interface{}{"source": "package.json"}},
+	}
+
+	findings := BuildFindings(root)
+	require.Len(t, findings, 1, "development and transitive dependencies are not reported")
+	assert.Equal(t, RulePrereleaseDependency, findings[0].RuleID)
+	assert.Equal(t, LevelWarning, findings[0].Level)
+	assert.Equal(t, "react 19.0.0-rc.1 (npm) is a pre-release version (rc)", findings[0].Message)
+}
+
+func TestFindingFingerprint(t *testing.T) {
+	finding := Finding{RuleID: RuleCopyleftDistributed, File: "web/package.json", Subject: "npm:gpl-lib", Message: "gpl-lib 1.0.0 ..."}
+	bumped := finding
+	bumped.Message = "gpl-lib 1.1.0 ..."
+	bumped.Level = LevelWarning
+	other := finding
+	other.File = "admin/package.json"
+
+	assert.Len(t, finding.Fingerprint(), 16)
+	assert.Equal(t, finding.Fingerprint(), bumped.Fingerprint(), "versions and levels do not change the fingerprint")
+	assert.NotEqual(t, finding.Fingerprint(), other.Fingerprint())
+}
@@ -0,0 +1,93 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// GraphQLSurface summarizes the GraphQL schema surface, tooling, and libraries of the repository
+type GraphQLSurface struct {
+	Configs       []string         `json:"configs,omitempty"`    // Tool configurations as "tool: file"
+	SchemaFiles   int              `json:"schema_files"`         // Documents defining types
+	Types         int              `json:"types"`                // Named type definitions
+	Queries       int              `json:"queries"`              // Query root fields
+	Mutations     int              `json:"mutations"`            // Mutation root fields
+	Subscriptions int              `json:"subscriptions"`        // Subscription root fields
+	Operations    int              `json:"operations,omitempty"` // Executable definitions in operation documents
+	Libraries     []GraphQLLibrary `json:"libraries,omitempty"`  // GraphQL client, server, and codegen dependencies
+}
+
+// GraphQLLibrary is a GraphQL library dependency with its declared version
+type GraphQLLibrary struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`    // Dependency type
+	Version string `json:"version"` // Declared version or constraint
+	Role    string `json:"role"`    // client, server, codegen, or core
+}
+
+// BuildGraphQLSurface aggregates GraphQL configurations, documents, and library dependencies
+// from the payload tree. Returns nil if the repository uses no GraphQL.
+func BuildGraphQLSurface(payload *types.Payload) *GraphQLSurface {
+	if payload == nil {
+		return nil
+	}
+
+	surface := &GraphQLSurface{}
+	libraries := make(map[GraphQLLibrary]bool)
+
+	walkComponents(payload, func(component *types.Payload) {
+		for _, dep := range component.Dependencies {
+			if role := parsers.GraphQLLibraryRole(dep.Type, dep.Name); role != "" {
+				libraries[GraphQLLibrary{Name: dep.Name, Type: dep.Type, Version: dep.Version, Role: role}] = true
+			}
+		}
+		if configs, ok := component.Properties["graphql_configs"].([]interface{}); ok {
+			for _, c := range configs {
+				if config, ok := c.(*parsers.GraphQLConfig); ok {
+					surface.Configs = append(surface.Configs, config.Tool+": "+config.File)
+				}
+			}
+		}
+		if documents, ok := component.Properties["graphql_documents"].([]interface{}); ok {
+			for _, d := range documents {
+				if doc, ok := d.(parsers.GraphQLDocument); ok {
+					surface.addDocument(doc)
+				}
+			}
+		}
+	})
+
+	if len(surface.Configs) == 0 && surface.SchemaFiles == 0 && surface.Operations == 0 && len(libraries) == 0 {
+		return nil
+	}
+
+	sort.Strings(surface.Configs)
+	for lib := range libraries {
+		surface.Libraries = append(surface.Libraries, lib)
+	}
+	sort.Slice(surface.Libraries, func(i, j int) bool {
+		a, b := surface.Libraries[i], surface.Libraries[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Version < b.Version
+	})
+	return surface
+}
+
+// addDocument adds the definitions of a GraphQL document to the totals
+func (s *GraphQLSurface) addDocument(doc parsers.GraphQLDocument) {
+	if doc.Types > 0 || doc.Queries > 0 || doc.Mutations > 0 || doc.Subscriptions > 0 {
+		s.SchemaFiles++
+	}
+	s.Types += doc.Types
+	s.Queries += doc.Queries
+	s.Mutations += doc.Mutations
+	s.Subscriptions += doc.Subscriptions
+	s.Operations += doc.Operations
+}
@@ -0,0 +1,58 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildGraphQLSurface(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+
+	web := types.NewPayloadWithPath("web", "/web/package.json")
+	web.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "@apollo/client", Version: "^3.10.0"},
+		{Type: "npm", Name: "@graphql-codegen/cli", Version: "5.0.2"},
+		{Type: "npm", Name: "graphql", Version: "^16.8.1"},
+		{Type: "npm", Name: "react", Version: "^18.0.0"},
+	}
+	web.Properties["graphql_configs"] = []interface{}{&parsers.GraphQLConfig{File: "/web/codegen.ts", Tool: "codegen"}}
+	web.Properties["graphql_documents"] = []interface{}{parsers.GraphQLDocument{File: "/web/src/queries.graphql", Operations: 5}}
+
+	api := types.NewPayloadWithPath("api", "/api/pom.xml")
+	api.Dependencies = []types.Dependency{{Type: "maven", Name: "com.graphql-java:graphql-java", Version: "22.1"}}
+	api.Properties["graphql_documents"] = []interface{}{
+		parsers.GraphQLDocument{File: "/api/schema/schema.graphqls", Types: 12, Queries: 4, Mutations: 2},
+		parsers.GraphQLDocument{File: "/api/schema/events.graphqls", Types: 1, Subscriptions: 1},
+	}
+	root.AddChild(web)
+	root.AddChild(api)
+
+	surface := BuildGraphQLSurface(root)
+	require.NotNil(t, surface)
+
+	assert.Equal(t, []string{"codegen: /web/codegen.ts"}, surface.Configs)
+	assert.Equal(t, 2, surface.SchemaFiles)
+	assert.Equal(t, 13, surface.Types)
+	assert.Equal(t, 4, surface.Queries)
+	assert.Equal(t, 2, surface.Mutations)
+	assert.Equal(t, 1, surface.Subscriptions)
+	assert.Equal(t, 5, surface.Operations)
+	assert.Equal(t, []GraphQLLibrary{
+		{Name: "@apollo/client", Type: "npm", Version: "^3.10.0", Role: "client"},
+		{Name: "@graphql-codegen/cli", Type: "npm", Version: "5.0.2", Role: "codegen"},
+		{Name: "com.graphql-java:graphql-java", Type: "maven", Version: "22.1", Role: "server"},
+		{Name: "graphql", Type: "npm", Version: "^16.8.1", Role: "core"},
+	}, surface.Libraries)
+}
+
+func TestBuildGraphQLSurface_NoGraphQL(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	root.Dependencies = []types.Dependency{{Type: "npm", Name: "react", Version: "18.0.0"}}
+
+	assert.Nil(t, BuildGraphQLSurface(root))
+	assert.Nil(t, BuildGraphQLSurface(nil))
+}
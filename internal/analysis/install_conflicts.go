@@ -0,0 +1,57 @@
+package analysis
+
+import (
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// InstallConflicts reports the npm peer dependency and engine conflicts of the components
+type InstallConflicts struct {
+	Failing    int                         `json:"failing"` // Conflicts failing npm install
+	Warning    int                         `json:"warning"` // Conflicts npm install warns about
+	Components []ComponentInstallConflicts `json:"components"`
+}
+
+// ComponentInstallConflicts holds the install conflicts of the package-lock.json of a component
+type ComponentInstallConflicts struct {
+	Name        string                       `json:"name"`
+	Path        string                       `json:"path"`
+	NodeVersion string                       `json:"node_version,omitempty"` // Node version engines are checked against
+	Conflicts   []parsers.NPMInstallConflict `json:"conflicts"`
+}
+
+// BuildInstallConflicts collects the conflicts the Node.js detector found between the
+// peerDependencies and engines.node ranges of locked packages and the locked versions and Node
+// version (nodejs install_conflicts property). Returns nil if no component has conflicts.
+func BuildInstallConflicts(payload *types.Payload) *InstallConflicts {
+	if payload == nil {
+		return nil
+	}
+
+	result := &InstallConflicts{}
+	walkComponents(payload, func(component *types.Payload) {
+		properties, _ := component.Properties["nodejs"].(map[string]interface{})
+		conflicts, _ := properties["install_conflicts"].([]parsers.NPMInstallConflict)
+		if len(conflicts) == 0 {
+			return
+		}
+		nodeVersion, _ := properties["node_version"].(string)
+		result.Components = append(result.Components, ComponentInstallConflicts{
+			Name:        component.Name,
+			Path:        componentDirs(component)[0],
+			NodeVersion: nodeVersion,
+			Conflicts:   conflicts,
+		})
+		for _, conflict := range conflicts {
+			if conflict.Outcome == parsers.NPMOutcomeFail {
+				result.Failing++
+			} else {
+				result.Warning++
+			}
+		}
+	})
+	if len(result.Components) == 0 {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,36 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildInstallConflicts(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	web := types.NewPayloadWithPath("web", "/web/package.json")
+	web.SetComponentProperty("nodejs", "node_version", "16.20.2")
+	web.SetComponentProperty("nodejs", "install_conflicts", []parsers.NPMInstallConflict{
+		{Kind: parsers.NPMConflictPeer, Package: "react-dom", Requires: "react", Range: "^18.2.0", Resolved: "17.0.2", Outcome: parsers.NPMOutcomeFail},
+		{Kind: parsers.NPMConflictEngine, Package: "vite", Requires: "node", Range: ">=18", Resolved: "16.20.2", Outcome: parsers.NPMOutcomeWarn},
+	})
+	api := types.NewPayloadWithPath("api", "/api/package.json")
+	api.SetComponentProperty("nodejs", "node_version", "20.11.1")
+	root.Children = []*types.Payload{web, api}
+
+	result := BuildInstallConflicts(root)
+	require.NotNil(t, result)
+	assert.Equal(t, 1, result.Failing)
+	assert.Equal(t, 1, result.Warning)
+	require.Len(t, result.Components, 1, "components without conflicts are left out")
+	assert.Equal(t, "web", result.Components[0].Name)
+	assert.Equal(t, "/web", result.Components[0].Path)
+	assert.Equal(t, "16.20.2", result.Components[0].NodeVersion)
+	assert.Len(t, result.Components[0].Conflicts, 2)
+
+	assert.Nil(t, BuildInstallConflicts(api))
+	assert.Nil(t, BuildInstallConflicts(nil))
+}
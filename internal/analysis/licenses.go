@@ -0,0 +1,354 @@
+package analysis
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/license"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// UnknownLicense is the attribution group of packages without license information
+const UnknownLicense = "Unknown"
+
+// licenseNormalizer maps license names to SPDX identifiers
+var licenseNormalizer = license.NewNormalizer()
+
+// undistributedScopes are dependency scopes that do not ship with the product
+var undistributedScopes = map[types.Scope]bool{
+	types.ScopeDev:   true,
+	types.ScopeTest:  true,
+	types.ScopeBuild: true,
+}
+
+// LicenseRollup summarizes the licenses of distributed dependencies overall and per component
+type LicenseRollup struct {
+	Licenses   map[string]int      `json:"licenses"` // License -> number of unique packages
+	Unknown    int                 `json:"unknown"`  // Packages without license information
+	Components []ComponentLicenses `json:"components"`
+	Mismatches []LicenseMismatch   `json:"mismatches,omitempty"` // Dependencies whose declared and concluded licenses differ
+}
+
+// ComponentLicenses is the license rollup of a single component
+type ComponentLicenses struct {
+	Name         string         `json:"name"`
+	Path         string         `json:"path"`
+	Declared     []string       `json:"declared,omitempty"`  // Licenses declared by the component's manifests
+	Concluded    []string       `json:"concluded,omitempty"` // Licenses detected from the component's LICENSE files
+	Mismatch     bool           `json:"mismatch,omitempty"`  // Declared and concluded licenses differ
+	Dependencies map[string]int `json:"dependencies"`        // License -> number of packages
+	Unknown      int            `json:"unknown"`
+}
+
+// LicenseMismatch is a dependency whose declared license differs from the concluded one
+type LicenseMismatch struct {
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Declared  string `json:"declared"`
+	Concluded string `json:"concluded"`
+}
+
+// AttributionGroup lists the packages distributed under a license
+type AttributionGroup struct {
+	License  string
+	Packages []AttributedPackage
+}
+
+// AttributedPackage is a distributed package with its copyright holders where known
+type AttributedPackage struct {
+	Type      string
+	Name      string
+	Version   string
+	Copyright []string
+}
+
+// BuildLicenseRollup aggregates the licenses of distributed dependencies (all scopes except
+// dev, test, and build) per component and across the payload tree. The concluded license of a
+// dependency takes precedence over the declared one (package-lock.json, composer.lock).
+// Returns nil if no component has distributed dependencies.
+func BuildLicenseRollup(payload *types.Payload) *LicenseRollup {
+	if payload == nil {
+		return nil
+	}
+
+	rollup := &LicenseRollup{Licenses: make(map[string]int)}
+	seen := make(map[string]bool)
+	walkComponents(payload, func(component *types.Payload) {
+		entry := ComponentLicenses{
+			Name:         component.Name,
+			Path:         componentDirs(component)[0],
+			Dependencies: make(map[string]int),
+		}
+		counted := make(map[string]bool)
+		forEachDistributed(component, func(dep types.Dependency, depLicense string) {
+			key := dep.Type + ":" + dep.Name + "@" + dep.Version
+			if !counted[key] {
+				counted[key] = true
+				countLicense(entry.Dependencies, &entry.Unknown, depLicense)
+			}
+			if !seen[key] {
+				seen[key] = true
+				countLicense(rollup.Licenses, &rollup.Unknown, depLicense)
+				if mismatch, ok := dep.Metadata[parsers.MetadataLicenseMismatch].(bool); ok && mismatch {
+					rollup.Mismatches = append(rollup.Mismatches, LicenseMismatch{
+						Type:      dep.Type,
+						Name:      dep.Name,
+						Version:   dep.Version,
+						Declared:  metadataLicense(dep, parsers.MetadataLicenseDeclared),
+						Concluded: metadataLicense(dep, parsers.MetadataLicenseConcluded),
+					})
+				}
+			}
+		})
+		if len(counted) == 0 {
+			return
+		}
+		for _, l := range component.Licenses {
+			if l.DetectionType == "file_based" {
+				entry.Concluded = append(entry.Concluded, l.LicenseName)
+			} else {
+				entry.Declared = append(entry.Declared, l.LicenseName)
+			}
+		}
+		entry.Mismatch = len(entry.Declared) > 0 && len(entry.Concluded) > 0 && !sameLicenses(entry.Declared, entry.Concluded)
+		rollup.Components = append(rollup.Components, entry)
+	})
+
+	if len(rollup.Components) == 0 {
+		return nil
+	}
+	sort.Slice(rollup.Mismatches, func(i, j int) bool {
+		if rollup.Mismatches[i].Type != rollup.Mismatches[j].Type {
+			return rollup.Mismatches[i].Type < rollup.Mismatches[j].Type
+		}
+		return rollup.Mismatches[i].Name < rollup.Mismatches[j].Name
+	})
+	return rollup
+}
+
+// ConcludeLicenses looks up the registry license of every direct dependency version and stores
+// it as the concluded license in the dependency metadata, flagging mismatches with the declared
+// license. Dependencies without a concrete version or unsupported by the lookup are skipped;
+// lookup failures are logged. Returns the number of dependencies with a concluded license.
+func ConcludeLicenses(payload *types.Payload, lookup PackageLookup, logger *slog.Logger) int {
+	if payload == nil || lookup == nil {
+		return 0
+	}
+
+	concluded := 0
+	walkComponents(payload, func(component *types.Payload) {
+		for i, dep := range component.Dependencies {
+			if !dep.Direct || !lookup.Supports(dep.Type) {
+				continue
+			}
+			version := baseVersion(dep.Version)
+			if version == "" {
+				continue
+			}
+			info, err := lookup.Lookup(dep.Type, dep.Name)
+			if err != nil {
+				if logger != nil {
+					logger.Debug("Registry lookup failed", "type", dep.Type, "name", dep.Name, "error", err)
+				}
+				continue
+			}
+			conclusion := normalizeLicense(info.LicenseFor(version))
+			if conclusion == "" {
+				continue
+			}
+
+			// Copy the metadata: dependencies of a manifest may share a single metadata map
+			metadata := make(map[string]interface{}, len(dep.Metadata)+2)
+			for key, value := range dep.Metadata {
+				metadata[key] = value
+			}
+			metadata[parsers.MetadataLicenseConcluded] = conclusion
+			recordRegistryOrigin(metadata, dep, parsers.MetadataLicenseConcluded, conclusion)
+			if declared := metadataLicense(dep, parsers.MetadataLicenseDeclared); declared != "" && !sameLicenses([]string{declared}, []string{conclusion}) {
+				metadata[parsers.MetadataLicenseMismatch] = true
+			}
+			component.Dependencies[i].Metadata = metadata
+			concluded++
+		}
+	})
+	return concluded
+}
+
+// BuildAttributions groups the distributed dependencies of the payload tree by license.
+// Groups are sorted by license with UnknownLicense last; packages are sorted by name.
+func BuildAttributions(payload *types.Payload) []AttributionGroup {
+	if payload == nil {
+		return nil
+	}
+
+	groups := make(map[string]map[string]*AttributedPackage)
+	walkComponents(payload, func(component *types.Payload) {
+		forEachDistributed(component, func(dep types.Dependency, depLicense string) {
+			if depLicense == "" {
+				depLicense = UnknownLicense
+			}
+			if groups[depLicense] == nil {
+				groups[depLicense] = make(map[string]*AttributedPackage)
+			}
+			key := dep.Type + ":" + dep.Name + "@" + dep.Version
+			pkg, ok := groups[depLicense][key]
+			if !ok {
+				pkg = &AttributedPackage{Type: dep.Type, Name: dep.Name, Version: dep.Version}
+				groups[depLicense][key] = pkg
+			}
+			for _, holder := range metadataStrings(dep.Metadata[parsers.MetadataAuthors]) {
+				pkg.Copyright = appendUnique(pkg.Copyright, holder)
+			}
+		})
+	})
+
+	result := make([]AttributionGroup, 0, len(groups))
+	for name, packages := range groups {
+		group := AttributionGroup{License: name, Packages: make([]AttributedPackage, 0, len(packages))}
+		for _, pkg := range packages {
+			group.Packages = append(group.Packages, *pkg)
+		}
+		sort.Slice(group.Packages, func(i, j int) bool {
+			a, b := group.Packages[i], group.Packages[j]
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			if a.Type != b.Type {
+				return a.Type < b.Type
+			}
+			return a.Version < b.Version
+		})
+		result = append(result, group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if (result[i].License == UnknownLicense) != (result[j].License == UnknownLicense) {
+			return result[j].License == UnknownLicense
+		}
+		return result[i].License < result[j].License
+	})
+	return result
+}
+
+// WriteAttributions writes a NOTICE-style Markdown document listing the packages of every
+// attribution group with their copyright holders where known
+func WriteAttributions(w io.Writer, groups []AttributionGroup) error {
+	var b strings.Builder
+	b.WriteString("# Third-Party Notices\n\n")
+	b.WriteString("This project includes the following third-party packages, grouped by license.\n")
+	for _, group := range groups {
+		noun := "packages"
+		if len(group.Packages) == 1 {
+			noun = "package"
+		}
+		fmt.Fprintf(&b, "\n## %s (%d %s)\n\n", group.License, len(group.Packages), noun)
+		for _, pkg := range group.Packages {
+			fmt.Fprintf(&b, "- %s", pkg.Name)
+			if pkg.Version != "" {
+				fmt.Fprintf(&b, " %s", pkg.Version)
+			}
+			fmt.Fprintf(&b, " (%s)", pkg.Type)
+			if len(pkg.Copyright) > 0 {
+				fmt.Fprintf(&b, " - Copyright %s", strings.Join(pkg.Copyright, ", "))
+			}
+			b.WriteString("\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// forEachDistributed calls fn for every distributed dependency of a component with its
+// normalized license, concluded before declared ("" if unknown)
+func forEachDistributed(component *types.Payload, fn func(types.Dependency, string)) {
+	for _, dep := range component.Dependencies {
+		if undistributedScopes[dep.Scope] {
+			continue
+		}
+		fn(dep, dependencyLicense(dep))
+	}
+}
+
+// dependencyLicense returns the normalized license of a dependency, concluded before declared
+func dependencyLicense(dep types.Dependency) string {
+	if concluded := metadataLicense(dep, parsers.MetadataLicenseConcluded); concluded != "" {
+		return concluded
+	}
+	return metadataLicense(dep, parsers.MetadataLicenseDeclared)
+}
+
+// metadataLicense returns the normalized license stored under a dependency metadata key
+func metadataLicense(dep types.Dependency, key string) string {
+	raw, _ := dep.Metadata[key].(string)
+	return normalizeLicense(raw)
+}
+
+// sameLicenses reports whether two license lists name the same licenses, ignoring order,
+// case, and how expressions are split ("MIT OR Apache-2.0" equals "Apache-2.0 OR MIT")
+func sameLicenses(a, b []string) bool {
+	set := func(licenses []string) map[string]bool {
+		result := make(map[string]bool)
+		for _, l := range licenses {
+			for _, part := range licenseNormalizer.ParseLicenseExpression(strings.Trim(l, "()")) {
+				result[strings.ToLower(strings.Trim(part, "() "))] = true
+			}
+		}
+		return result
+	}
+	setA, setB := set(a), set(b)
+	if len(setA) != len(setB) {
+		return false
+	}
+	for l := range setA {
+		if !setB[l] {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeLicense normalizes a single license to its SPDX identifier; expressions such as
+// "(MIT OR Apache-2.0)" are kept without the enclosing parentheses
+func normalizeLicense(raw string) string {
+	expr := strings.TrimSpace(raw)
+	if strings.HasPrefix(expr, "(") && strings.HasSuffix(expr, ")") {
+		expr = strings.TrimSpace(expr[1 : len(expr)-1])
+	}
+	if expr == "" {
+		return ""
+	}
+	if parts := licenseNormalizer.ParseLicenseExpression(expr); len(parts) == 1 {
+		return parts[0]
+	}
+	return expr
+}
+
+// countLicense increments the license counter, or the unknown counter for an empty license
+func countLicense(counts map[string]int, unknown *int, depLicense string) {
+	if depLicense == "" {
+		*unknown++
+		return
+	}
+	counts[depLicense]++
+}
+
+// metadataStrings returns a string list metadata value ([]string or decoded []interface{})
+func metadataStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		var result []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
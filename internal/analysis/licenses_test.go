@@ -0,0 +1,151 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/registry"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func licenseTree() *types.Payload {
+	root := types.NewPayloadWithPath("shop", "/")
+
+	web := types.NewPayloadWithPath("web", "/web/package.json")
+	web.AddLicense(types.License{LicenseName: "Apache-2.0", DetectionType: "direct", SourceFile: "package.json"})
+	web.AddLicense(types.License{LicenseName: "MIT", DetectionType: "file_based", SourceFile: "LICENSE"})
+	web.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "react", Version: "18.2.0", Scope: types.ScopeProd, Metadata: map[string]interface{}{parsers.MetadataLicenseDeclared: "MIT"}},
+		{Type: "npm", Name: "scheduler", Version: "0.23.0", Scope: types.ScopeProd, Metadata: map[string]interface{}{parsers.MetadataLicenseDeclared: "MIT"}},
+		{Type: "npm", Name: "tslib", Version: "2.6.2", Scope: types.ScopeProd, Metadata: map[string]interface{}{parsers.MetadataLicenseDeclared: "0BSD"}},
+		{Type: "npm", Name: "rxjs-compat", Version: "6.6.7", Scope: types.ScopeProd, Metadata: map[string]interface{}{parsers.MetadataLicenseDeclared: "(MIT OR Apache-2.0)"}},
+		{Type: "npm", Name: "internal-ui", Version: "1.0.0", Scope: types.ScopeProd},
+		{Type: "npm", Name: "vitest", Version: "1.6.0", Scope: types.ScopeDev, Metadata: map[string]interface{}{parsers.MetadataLicenseDeclared: "MIT"}},
+	}
+
+	api := types.NewPayloadWithPath("api", "/api/composer.json")
+	api.Dependencies = []types.Dependency{
+		{Type: "php", Name: "monolog/monolog", Version: "^3.0", Scope: types.ScopeProd, Metadata: map[string]interface{}{parsers.MetadataLicenseDeclared: "MIT", parsers.MetadataAuthors: []string{"Jordi Boggiano"}}},
+		{Type: "php", Name: "phpunit/phpunit", Version: "^10.5", Scope: types.ScopeDev, Metadata: map[string]interface{}{parsers.MetadataLicenseDeclared: "BSD-3-Clause"}},
+	}
+	// A second component shipping the same package is counted once overall
+	admin := types.NewPayloadWithPath("admin", "/admin/package.json")
+	admin.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "react", Version: "18.2.0", Scope: types.ScopeProd, Metadata: map[string]interface{}{parsers.MetadataLicenseDeclared: "MIT"}},
+	}
+	tools := types.NewPayloadWithPath("tools", "/tools/package.json")
+	tools.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "eslint", Version: "9.0.0", Scope: types.ScopeDev, Metadata: map[string]interface{}{parsers.MetadataLicenseDeclared: "MIT"}},
+	}
+
+	root.AddChild(web)
+	root.AddChild(api)
+	root.AddChild(admin)
+	root.AddChild(tools)
+	return root
+}
+
+func TestBuildLicenseRollup(t *testing.T) {
+	rollup := BuildLicenseRollup(licenseTree())
+	require.NotNil(t, rollup)
+
+	assert.Equal(t, map[string]int{"MIT": 3, "0BSD": 1, "MIT OR Apache-2.0": 1}, rollup.Licenses)
+	assert.Equal(t, 1, rollup.Unknown)
+
+	require.Len(t, rollup.Components, 3, "components without distributed dependencies are omitted")
+	assert.Equal(t, ComponentLicenses{
+		Name:         "web",
+		Path:         "/web",
+		Declared:     []string{"Apache-2.0"},
+		Concluded:    []string{"MIT"},
+		Mismatch:     true,
+		Dependencies: map[string]int{"MIT": 2, "0BSD": 1, "MIT OR Apache-2.0": 1},
+		Unknown:      1,
+	}, rollup.Components[0])
+	assert.Equal(t, map[string]int{"MIT": 1}, rollup.Components[1].Dependencies)
+	assert.False(t, rollup.Components[1].Mismatch)
+	assert.Equal(t, "admin", rollup.Components[2].Name)
+	assert.Empty(t, rollup.Mismatches)
+}
+
+func TestConcludeLicenses(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	shared := types.NewMetadata(parsers.MetadataSourcePackageJSON)
+	root.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "react", Version: "^18.2.0", Scope: types.ScopeProd, Direct: true, Metadata: map[string]interface{}{parsers.MetadataLicenseDeclared: "MIT"}},
+		{Type: "npm", Name: "relicensed", Version: "2.0.0", Scope: types.ScopeProd, Direct: true, Metadata: map[string]interface{}{parsers.MetadataLicenseDeclared: "MIT"}},
+		{Type: "npm", Name: "dual", Version: "1.0.0", Scope: types.ScopeProd, Direct: true, Metadata: map[string]interface{}{parsers.MetadataLicenseDeclared: "(Apache-2.0 OR MIT)"}},
+		{Type: "python", Name: "requests", Version: "==2.32.3", Scope: types.ScopeProd, Direct: true, Metadata: shared},
+		{Type: "python", Name: "urllib3", Version: "2.2.1", Scope: types.ScopeProd, Direct: false, Metadata: shared},
+		{Type: "python", Name: "unknown", Version: "1.0", Scope: types.ScopeProd, Direct: true, Metadata: shared},
+		{Type: "cargo", Name: "serde", Version: "1.0", Scope: types.ScopeProd, Direct: true},
+	}
+	lookup := &fakeLookup{packages: map[string]*registry.PackageInfo{
+		"npm:react":       {LatestVersion: "18.3.1", VersionLicenses: map[string]string{"18.2.0": "MIT", "18.3.1": "MIT"}},
+		"npm:relicensed":  {LatestVersion: "2.0.0", VersionLicenses: map[string]string{"2.0.0": "BUSL-1.1"}},
+		"npm:dual":        {LatestVersion: "1.0.0", License: "MIT OR Apache-2.0"},
+		"python:requests": {LatestVersion: "2.32.3", License: "Apache License 2.0"},
+	}}
+
+	assert.Equal(t, 4, ConcludeLicenses(root, lookup, nil))
+
+	deps := root.Dependencies
+	assert.Equal(t, "MIT", deps[0].Metadata[parsers.MetadataLicenseConcluded])
+	assert.NotContains(t, deps[0].Metadata, parsers.MetadataLicenseMismatch)
+	assert.Equal(t, "BUSL-1.1", deps[1].Metadata[parsers.MetadataLicenseConcluded])
+	assert.Equal(t, true, deps[1].Metadata[parsers.MetadataLicenseMismatch])
+	assert.NotContains(t, deps[2].Metadata, parsers.MetadataLicenseMismatch, "expression order does not matter")
+	assert.Equal(t, "Apache-2.0", deps[3].Metadata[parsers.MetadataLicenseConcluded], "registry licenses are normalized to SPDX")
+	assert.NotContains(t, deps[3].Metadata, parsers.MetadataLicenseMismatch, "no declared license to compare with")
+	assert.Equal(t, parsers.MetadataSourcePackageJSON, deps[3].Metadata["source"])
+	assert.NotContains(t, shared, parsers.MetadataLicenseConcluded, "shared manifest metadata is not modified")
+	assert.NotContains(t, deps[4].Metadata, parsers.MetadataLicenseConcluded, "transitive dependencies are not looked up")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"source": parsers.MetadataSourcePackageJSON, "field": parsers.OriginFieldVersion, "value": "==2.32.3"},
+		map[string]interface{}{"source": parsers.OriginSourceRegistry, "field": parsers.MetadataLicenseConcluded, "value": "Apache-2.0"},
+	}, deps[3].Metadata[parsers.MetadataOrigin], "the origin chain starts with the file of the version")
+
+	rollup := BuildLicenseRollup(root)
+	require.NotNil(t, rollup)
+	assert.Equal(t, map[string]int{"MIT": 1, "BUSL-1.1": 1, "MIT OR Apache-2.0": 1, "Apache-2.0": 1}, rollup.Licenses, "concluded licenses take precedence")
+	assert.Equal(t, []LicenseMismatch{
+		{Type: "npm", Name: "relicensed", Version: "2.0.0", Declared: "MIT", Concluded: "BUSL-1.1"},
+	}, rollup.Mismatches)
+}
+
+func TestBuildLicenseRollup_NoDistributedDependencies(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	root.Dependencies = []types.Dependency{{Type: "npm", Name: "eslint", Scope: types.ScopeDev}}
+	assert.Nil(t, BuildLicenseRollup(root))
+	assert.Nil(t, BuildLicenseRollup(nil))
+}
+
+func TestBuildAttributions(t *testing.T) {
+	groups := BuildAttributions(licenseTree())
+	require.Len(t, groups, 4)
+
+	assert.Equal(t, "0BSD", groups[0].License)
+	assert.Equal(t, "MIT", groups[1].License)
+	assert.Equal(t, []AttributedPackage{
+		{Type: "php", Name: "monolog/monolog", Version: "^3.0", Copyright: []string{"Jordi Boggiano"}},
+		{Type: "npm", Name: "react", Version: "18.2.0"},
+		{Type: "npm", Name: "scheduler", Version: "0.23.0"},
+	}, groups[1].Packages)
+	assert.Equal(t, "MIT OR Apache-2.0", groups[2].License)
+	assert.Equal(t, UnknownLicense, groups[3].License, "packages without license information are listed last")
+}
+
+func TestWriteAttributions(t *testing.T) {
+	var out strings.Builder
+	require.NoError(t, WriteAttributions(&out, BuildAttributions(licenseTree())))
+
+	notice := out.String()
+	assert.True(t, strings.HasPrefix(notice, "# Third-Party Notices\n\nThis project includes the following third-party packages, grouped by license.\n"))
+	assert.Contains(t, notice, "\n## 0BSD (1 package)\n\n- tslib 2.6.2 (npm)\n")
+	assert.Contains(t, notice, "\n## MIT (3 packages)\n\n- monolog/monolog ^3.0 (php) - Copyright Jordi Boggiano\n- react 18.2.0 (npm)\n")
+	assert.Contains(t, notice, "\n## Unknown (1 package)\n\n- internal-ui 1.0.0 (npm)\n")
+	assert.NotContains(t, notice, "vitest")
+}
@@ -0,0 +1,92 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// mlFrameworkTechs are the technologies reported as machine-learning frameworks
+var mlFrameworkTechs = map[string]bool{
+	"pytorch":      true,
+	"tensorflow":   true,
+	"sklearn":      true,
+	"transformers": true,
+	"onnx":         true,
+}
+
+// MLStack summarizes the machine-learning frameworks and artifacts of the repository
+type MLStack struct {
+	Frameworks []string                  `json:"frameworks"`            // ML framework techs found in any component
+	Models     []parsers.MLModel         `json:"models,omitempty"`      // Model files with their sizes
+	ModelsSize int64                     `json:"models_size,omitempty"` // Total size of all model files in bytes
+	Notebooks  int                       `json:"notebooks,omitempty"`   // Number of Jupyter notebooks
+	CUDA       []parsers.CUDARequirement `json:"cuda,omitempty"`        // CUDA versions required by dependencies
+	Components []string                  `json:"components,omitempty"`  // Components using an ML framework
+}
+
+// BuildMLStack collects ML framework techs, model files, notebooks, and CUDA requirements
+// from the payload tree. Returns nil if the repository has no ML framework and no artifacts.
+func BuildMLStack(payload *types.Payload) *MLStack {
+	if payload == nil {
+		return nil
+	}
+
+	stack := &MLStack{Frameworks: []string{}}
+	frameworks := make(map[string]bool)
+	components := make(map[string]bool)
+	cuda := make(map[parsers.CUDARequirement]bool)
+
+	walkComponents(payload, func(component *types.Payload) {
+		for _, tech := range component.Techs {
+			if mlFrameworkTechs[tech] {
+				frameworks[tech] = true
+				components[component.Name] = true
+			}
+		}
+		for _, dep := range component.Dependencies {
+			if req := parsers.CUDARequirementFor(dep); req != nil {
+				cuda[*req] = true
+			}
+		}
+		if models, ok := component.Properties["ml_models"].([]interface{}); ok {
+			for _, m := range models {
+				if model, ok := m.(parsers.MLModel); ok {
+					stack.Models = append(stack.Models, model)
+					stack.ModelsSize += model.Size
+				}
+			}
+		}
+		if notebooks, ok := component.Properties["ml_notebooks"].([]interface{}); ok {
+			stack.Notebooks += len(notebooks)
+		}
+	})
+
+	if len(frameworks) == 0 && len(stack.Models) == 0 && stack.Notebooks == 0 {
+		return nil
+	}
+
+	for tech := range frameworks {
+		stack.Frameworks = append(stack.Frameworks, tech)
+	}
+	sort.Strings(stack.Frameworks)
+	for name := range components {
+		stack.Components = append(stack.Components, name)
+	}
+	sort.Strings(stack.Components)
+	for req := range cuda {
+		stack.CUDA = append(stack.CUDA, req)
+	}
+	sort.Slice(stack.CUDA, func(i, j int) bool {
+		if stack.CUDA[i].Version != stack.CUDA[j].Version {
+			return stack.CUDA[i].Version < stack.CUDA[j].Version
+		}
+		return stack.CUDA[i].Dependency < stack.CUDA[j].Dependency
+	})
+	sort.Slice(stack.Models, func(i, j int) bool {
+		return stack.Models[i].File < stack.Models[j].File
+	})
+
+	return stack
+}
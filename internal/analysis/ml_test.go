@@ -0,0 +1,58 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMLStack(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	root.Dependencies = []types.Dependency{{Type: "docker", Name: "nvidia/cuda", Version: "12.1.0-runtime-ubuntu22.04"}}
+	root.Properties["ml_notebooks"] = []interface{}{"/notebooks/eda.ipynb", "/notebooks/train.ipynb"}
+
+	training := types.NewPayloadWithPath("training", "/training/pyproject.toml")
+	training.Techs = []string{"python", "pytorch", "transformers"}
+	training.Dependencies = []types.Dependency{
+		{Type: "python", Name: "torch", Version: "2.1.0+cu121"},
+		{Type: "python", Name: "transformers", Version: "4.40.0"},
+	}
+	training.Properties["ml_models"] = []interface{}{
+		parsers.MLModel{File: "/training/models/model.safetensors", Format: "safetensors", Size: 400},
+		parsers.MLModel{File: "/training/models/export.onnx", Format: "onnx", Size: 100},
+	}
+	training.Properties["ml_notebooks"] = []interface{}{"/training/eval.ipynb"}
+
+	api := types.NewPayloadWithPath("api", "/api/requirements.txt")
+	api.Techs = []string{"python", "sklearn"}
+	root.AddChild(training)
+	root.AddChild(api)
+
+	stack := BuildMLStack(root)
+	require.NotNil(t, stack)
+
+	assert.Equal(t, []string{"pytorch", "sklearn", "transformers"}, stack.Frameworks)
+	assert.Equal(t, []string{"api", "training"}, stack.Components)
+	assert.Equal(t, []parsers.MLModel{
+		{File: "/training/models/export.onnx", Format: "onnx", Size: 100},
+		{File: "/training/models/model.safetensors", Format: "safetensors", Size: 400},
+	}, stack.Models)
+	assert.Equal(t, int64(500), stack.ModelsSize)
+	assert.Equal(t, 3, stack.Notebooks)
+	assert.Equal(t, []parsers.CUDARequirement{
+		{Version: "12.1", Type: "python", Dependency: "torch"},
+		{Version: "12.1.0", Type: "docker", Dependency: "nvidia/cuda"},
+	}, stack.CUDA)
+}
+
+func TestBuildMLStack_NoML(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	root.Techs = []string{"nodejs"}
+	root.Dependencies = []types.Dependency{{Type: "npm", Name: "react", Version: "18.0.0"}}
+
+	assert.Nil(t, BuildMLStack(root))
+	assert.Nil(t, BuildMLStack(nil))
+}
@@ -0,0 +1,54 @@
+package analysis
+
+import (
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// PatchInventory reports the upstream npm packages the components patch locally and the
+// versions they override
+type PatchInventory struct {
+	Patched    int                `json:"patched"`    // Locally patched packages
+	Overridden int                `json:"overridden"` // Version overrides
+	Components []ComponentPatches `json:"components"`
+}
+
+// ComponentPatches holds the patches and overrides of the package.json of a component
+type ComponentPatches struct {
+	Name      string                `json:"name"`
+	Path      string                `json:"path"`
+	Patches   []parsers.NPMPatch    `json:"patches,omitempty"`
+	Overrides []parsers.NPMOverride `json:"overrides,omitempty"`
+}
+
+// BuildPatchInventory collects the patches (yarn patch: protocol and .yarn/patches,
+// pnpm.patchedDependencies, patch-package patches/) and version overrides (overrides,
+// resolutions, pnpm.overrides) the Node.js detector found (nodejs patches and overrides
+// properties). Returns nil if no component patches or overrides packages.
+func BuildPatchInventory(payload *types.Payload) *PatchInventory {
+	if payload == nil {
+		return nil
+	}
+
+	result := &PatchInventory{}
+	walkComponents(payload, func(component *types.Payload) {
+		properties, _ := component.Properties["nodejs"].(map[string]interface{})
+		patches, _ := properties["patches"].([]parsers.NPMPatch)
+		overrides, _ := properties["overrides"].([]parsers.NPMOverride)
+		if len(patches) == 0 && len(overrides) == 0 {
+			return
+		}
+		result.Components = append(result.Components, ComponentPatches{
+			Name:      component.Name,
+			Path:      componentDirs(component)[0],
+			Patches:   patches,
+			Overrides: overrides,
+		})
+		result.Patched += len(patches)
+		result.Overridden += len(overrides)
+	})
+	if len(result.Components) == 0 {
+		return nil
+	}
+	return result
+}
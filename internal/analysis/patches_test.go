@@ -0,0 +1,41 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildPatchInventory(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	web := types.NewPayloadWithPath("web", "/web/package.json")
+	web.SetComponentProperty("nodejs", "patches", []parsers.NPMPatch{
+		{Package: "lodash", Version: "4.17.21", Tool: parsers.NPMPatchToolPatchPackage, File: "patches/lodash+4.17.21.patch"},
+		{Package: "express", Version: "4.18.2", Tool: parsers.NPMPatchToolPnpm, File: "patches/express@4.18.2.patch"},
+	})
+	api := types.NewPayloadWithPath("api", "/api/package.json")
+	api.SetComponentProperty("nodejs", "overrides", []parsers.NPMOverride{
+		{Package: "semver", Version: "7.5.4", Field: parsers.NPMOverrideFieldNPM},
+	})
+	docs := types.NewPayloadWithPath("docs", "/docs/package.json")
+	docs.SetComponentProperty("nodejs", "package_name", "docs")
+	root.Children = []*types.Payload{web, api, docs}
+
+	result := BuildPatchInventory(root)
+	require.NotNil(t, result)
+	assert.Equal(t, 2, result.Patched)
+	assert.Equal(t, 1, result.Overridden)
+	require.Len(t, result.Components, 2, "components without patches or overrides are left out")
+	assert.Equal(t, "web", result.Components[0].Name)
+	assert.Equal(t, "/web", result.Components[0].Path)
+	assert.Len(t, result.Components[0].Patches, 2)
+	assert.Empty(t, result.Components[0].Overrides)
+	assert.Equal(t, "api", result.Components[1].Name)
+	assert.Len(t, result.Components[1].Overrides, 1)
+
+	assert.Nil(t, BuildPatchInventory(docs))
+	assert.Nil(t, BuildPatchInventory(nil))
+}
@@ -0,0 +1,365 @@
+package analysis
+
+import (
+	"math"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Constraint styles of declared dependency versions
+const (
+	PinExact     = "exact"      // A single version (1.2.3, ==1.2.3, [1.2.3], image tag, commit SHA, content hash)
+	PinLocked    = "locked"     // Resolved from a lock file; the declared constraint is not known
+	PinTilde     = "tilde"      // Patch-level updates (~1.2.3, ~> 1.2.3, ~=1.2.3)
+	PinCaret     = "caret"      // Minor-level updates (^1.2.3, Cargo bare versions, floating major tags)
+	PinRange     = "range"      // Comparison ranges and wildcards within a version (>=1.0, 1.x, [1.0,2.0))
+	PinWildcard  = "wildcard"   // Any version (*, latest, no constraint)
+	PinGitBranch = "git_branch" // Tracks a git branch (or the default branch)
+	PinGitRef    = "git_ref"    // Git dependency pinned to a tag or commit
+	PinLocal     = "local"      // Path, file, link, workspace, or included build reference (not scored)
+	PinUnknown   = "unknown"    // Unresolved placeholders such as ${version} (not scored)
+)
+
+// pinWeights are the hygiene weights of the constraint styles; styles without a weight are not scored
+var pinWeights = map[string]float64{
+	PinExact:     1,
+	PinLocked:    1,
+	PinGitRef:    1,
+	PinTilde:     0.75,
+	PinCaret:     0.5,
+	PinRange:     0.5,
+	PinWildcard:  0,
+	PinGitBranch: 0,
+}
+
+// lockFileSources are dependency sources holding resolved versions instead of declared constraints
+var lockFileSources = map[string]bool{
+	parsers.MetadataSourcePackageLock:   true,
+	parsers.MetadataSourceYarnLock:      true,
+	parsers.MetadataSourcePnpmLock:      true,
+	parsers.MetadataSourceDenoLock:      true,
+	parsers.MetadataSourcePoetryLock:    true,
+	parsers.MetadataSourceGemfileLock:   true,
+	parsers.MetadataSourceCargoLock:     true,
+	parsers.MetadataSourceComposerLock:  true,
+	parsers.MetadataSourcePodfileLock:   true,
+	parsers.MetadataSourceBufLock:       true,
+	parsers.MetadataSourceRebarLock:     true,
+	parsers.MetadataSourceShardLock:     true,
+	parsers.MetadataSourceLuarocksLock:  true,
+	parsers.MetadataSourceDubSelections: true,
+	parsers.MetadataSourceFoundryLock:   true,
+}
+
+var (
+	commitSHARegex   = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+	versionTagRegex  = regexp.MustCompile(`^v?\d+(\.\d+)*([-+.]\w+)*$`)
+	fullVersionRegex = regexp.MustCompile(`^v?\d+\.\d+\.\d+`)
+	wildcardSegment  = regexp.MustCompile(`(^|\.)[*xX](\.|$)`)
+)
+
+// PinningHygiene reports how strictly direct dependencies pin their versions
+type PinningHygiene struct {
+	Score      int              `json:"score"` // 0-100: share of scored dependencies weighted by constraint style
+	Total      int              `json:"total"`
+	Styles     map[string]int   `json:"styles"`
+	Ecosystems []PinningSummary `json:"ecosystems"`
+	Files      []PinningSummary `json:"files"`
+}
+
+// PinningSummary is the pinning breakdown of an ecosystem or a manifest file
+type PinningSummary struct {
+	Ecosystem string         `json:"ecosystem,omitempty"`
+	File      string         `json:"file,omitempty"`
+	Score     int            `json:"score"`
+	Total     int            `json:"total"`
+	Styles    map[string]int `json:"styles"`
+	Unpinned  []string       `json:"unpinned,omitempty"` // Wildcard and branch-tracking dependencies (supply-chain risk)
+}
+
+// BuildPinningHygiene classifies the constraint style of every direct dependency in the
+// payload tree and computes a hygiene score overall, per ecosystem, and per manifest file.
+// Returns nil if the tree has no direct dependencies.
+func BuildPinningHygiene(payload *types.Payload) *PinningHygiene {
+	if payload == nil {
+		return nil
+	}
+
+	overall := newPinningTally()
+	ecosystems := make(map[string]*pinningTally)
+	files := make(map[string]*pinningTally)
+
+	walkComponents(payload, func(component *types.Payload) {
+		for _, dep := range component.Dependencies {
+			if !dep.Direct {
+				continue
+			}
+			style := ClassifyConstraint(dep)
+			file := dependencyFile(component, dep)
+			if ecosystems[dep.Type] == nil {
+				ecosystems[dep.Type] = newPinningTally()
+			}
+			if files[file] == nil {
+				files[file] = newPinningTally()
+			}
+			overall.add(dep, style)
+			ecosystems[dep.Type].add(dep, style)
+			files[file].add(dep, style)
+		}
+	})
+
+	if overall.total == 0 {
+		return nil
+	}
+
+	hygiene := &PinningHygiene{
+		Score:      overall.score(),
+		Total:      overall.total,
+		Styles:     overall.styles,
+		Ecosystems: make([]PinningSummary, 0, len(ecosystems)),
+		Files:      make([]PinningSummary, 0, len(files)),
+	}
+	for ecosystem, tally := range ecosystems {
+		summary := tally.summary()
+		summary.Ecosystem = ecosystem
+		hygiene.Ecosystems = append(hygiene.Ecosystems, summary)
+	}
+	for file, tally := range files {
+		summary := tally.summary()
+		summary.File = file
+		hygiene.Files = append(hygiene.Files, summary)
+	}
+	sort.Slice(hygiene.Ecosystems, func(i, j int) bool { return hygiene.Ecosystems[i].Ecosystem < hygiene.Ecosystems[j].Ecosystem })
+	sort.Slice(hygiene.Files, func(i, j int) bool { return hygiene.Files[i].File < hygiene.Files[j].File })
+	return hygiene
+}
+
+// ClassifyConstraint returns the constraint style of a dependency's declared version
+func ClassifyConstraint(dep types.Dependency) string {
+	source, _ := dep.Metadata["source"].(string)
+	if lockFileSources[source] {
+		return PinLocked
+	}
+	if _, ok := dep.Metadata[parsers.MetadataArtifact]; ok { // Resolved versions embedded in binaries
+		return PinLocked
+	}
+	if _, ok := dep.Metadata["path"]; ok {
+		return PinLocal
+	}
+	if _, ok := dep.Metadata[parsers.MetadataIncludedBuild]; ok { // Gradle composite builds
+		return PinLocal
+	}
+	if _, ok := dep.Metadata["hash"]; ok && dep.Type == parsers.DependencyTypeZig { // Content hash of build.zig.zon
+		return PinExact
+	}
+	if _, ok := dep.Metadata["submodule"]; ok { // Git submodules are pinned to a commit
+		return PinGitRef
+	}
+	if _, ok := dep.Metadata["git"]; ok { // Gemfile git sources
+		if _, ok := dep.Metadata["branch"]; ok {
+			return PinGitBranch
+		}
+		return gitRefStyle(dep.Version)
+	}
+
+	v := strings.TrimSpace(dep.Version)
+	lower := strings.ToLower(v)
+	switch {
+	case v == "" || v == "*" || lower == "latest" || lower == "x":
+		return PinWildcard
+	case strings.Contains(v, "${") || strings.Contains(v, "$("):
+		return PinUnknown
+	case hasAnyPrefix(lower, "file:", "link:", "workspace:", "portal:", "path:", "./", "../", "/"):
+		return PinLocal
+	case isGitReference(lower):
+		return gitRefFromURL(v)
+	case strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://"):
+		return PinExact // Archive URL
+	}
+
+	switch dep.Type {
+	case parsers.DependencyTypeGitHubAction:
+		return actionRefStyle(v)
+	case parsers.DependencyTypeDocker:
+		return PinExact // Specific tag or digest ("latest" is handled above)
+	case parsers.DependencyTypeNimble:
+		if strings.HasPrefix(v, "#") { // VCS ref (#head, #v1.2.0, #<commit>)
+			return gitRefStyle(strings.TrimPrefix(v, "#"))
+		}
+	case parsers.DependencyTypeDub:
+		if strings.HasPrefix(v, "~") && !strings.HasPrefix(v, "~>") { // Branch (~master)
+			return PinGitBranch
+		}
+	case parsers.DependencyTypeLuarocks:
+		if strings.HasPrefix(v, "~=") { // Lua inequality, not a tilde requirement
+			return PinRange
+		}
+	case parsers.DependencyTypePHP:
+		if strings.HasPrefix(lower, "dev-") || strings.HasSuffix(lower, "-dev") {
+			return PinGitBranch
+		}
+	}
+	return operatorStyle(v, dep.Type)
+}
+
+// operatorStyle classifies a version constraint by its operators
+func operatorStyle(v, depType string) string {
+	switch {
+	case strings.HasPrefix(v, "^"):
+		return PinCaret
+	case strings.HasPrefix(v, "~"): // ~1.2, ~> 1.2 (Ruby, Terraform), ~=1.2 (Python)
+		return PinTilde
+	case strings.Contains(v, "||") || strings.Contains(v, " - ") || strings.Contains(v, ","):
+		return PinRange
+	case strings.HasPrefix(v, "[") && strings.HasSuffix(v, "]"):
+		return PinExact // Maven/NuGet exact version [1.2.3]
+	case strings.HasPrefix(v, "(") || strings.HasPrefix(v, "["):
+		return PinRange
+	case hasAnyPrefix(v, ">", "<", "!="):
+		return PinRange
+	case wildcardSegment.MatchString(strings.TrimLeft(v, "=v")):
+		return PinRange
+	case hasAnyPrefix(v, "===", "==", "="):
+		return PinExact
+	case depType == parsers.DependencyTypeRust:
+		return PinCaret // Cargo treats a bare version as a caret requirement
+	default:
+		return PinExact
+	}
+}
+
+// actionRefStyle classifies a GitHub Action reference: commit SHAs and full versions are
+// exact, major/minor tags float like caret ranges, anything else is a branch
+func actionRefStyle(ref string) string {
+	switch {
+	case commitSHARegex.MatchString(ref):
+		return PinExact
+	case fullVersionRegex.MatchString(ref):
+		return PinExact
+	case versionTagRegex.MatchString(ref):
+		return PinCaret
+	default:
+		return PinGitBranch
+	}
+}
+
+// isGitReference reports whether a version is a git source (git+https://, github:user/repo, *.git)
+func isGitReference(v string) bool {
+	return hasAnyPrefix(v, "git+", "git://", "git@", "github:", "gitlab:", "bitbucket:") || strings.Contains(v, ".git#") || strings.HasSuffix(v, ".git")
+}
+
+// gitRefFromURL classifies a git URL by its ref (npm "#ref", pip "@ref")
+func gitRefFromURL(v string) string {
+	if _, ref, ok := strings.Cut(v, "#"); ok {
+		return gitRefStyle(strings.TrimPrefix(ref, "semver:"))
+	}
+	if idx := strings.LastIndex(v, "@"); idx > strings.LastIndex(v, "/") {
+		return gitRefStyle(v[idx+1:])
+	}
+	return PinGitBranch
+}
+
+// gitRefStyle classifies a git ref: tags and commits are pinned, other refs are branches
+func gitRefStyle(ref string) string {
+	if commitSHARegex.MatchString(ref) || versionTagRegex.MatchString(ref) {
+		return PinGitRef
+	}
+	return PinGitBranch
+}
+
+// dependencyFile returns the manifest path a dependency was declared in, using the component's
+// paths and the dependency's metadata source
+func dependencyFile(component *types.Payload, dep types.Dependency) string {
+	source, _ := dep.Metadata["source"].(string)
+	if source == "" {
+		source = dep.SourceFile
+	}
+	for _, p := range component.Path {
+		if source == "" || path.Base(p) == source || (strings.HasPrefix(source, ".") && strings.Contains(p, source)) {
+			return p
+		}
+	}
+	dirs := componentDirs(component)
+	return path.Join(dirs[0], source)
+}
+
+// SourceFiles returns the manifests and lock files the payload tree was derived from: the
+// component paths and the files the dependencies were read from, relative to the scanned
+// directory (no leading slash), sorted. Paths may name files that no longer exist.
+func SourceFiles(payload *types.Payload) []string {
+	if payload == nil {
+		return nil
+	}
+
+	var files []string
+	seen := make(map[string]bool)
+	add := func(file string) {
+		file = strings.TrimPrefix(file, "/")
+		if file != "" && !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+	walkComponents(payload, func(component *types.Payload) {
+		for _, p := range component.Path {
+			add(p)
+		}
+		for _, dep := range component.Dependencies {
+			add(dependencyFile(component, dep))
+		}
+	})
+	sort.Strings(files)
+	return files
+}
+
+func hasAnyPrefix(s string, prefixes ...string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// pinningTally counts constraint styles and collects unpinned dependencies
+type pinningTally struct {
+	total    int
+	scored   int
+	weight   float64
+	styles   map[string]int
+	unpinned []string
+}
+
+func newPinningTally() *pinningTally {
+	return &pinningTally{styles: make(map[string]int)}
+}
+
+func (t *pinningTally) add(dep types.Dependency, style string) {
+	t.total++
+	t.styles[style]++
+	if weight, ok := pinWeights[style]; ok {
+		t.scored++
+		t.weight += weight
+	}
+	if style == PinWildcard || style == PinGitBranch {
+		t.unpinned = append(t.unpinned, dep.Name)
+	}
+}
+
+// score returns the weighted share of scored dependencies (100 when nothing is scored)
+func (t *pinningTally) score() int {
+	if t.scored == 0 {
+		return 100
+	}
+	return int(math.Round(100 * t.weight / float64(t.scored)))
+}
+
+func (t *pinningTally) summary() PinningSummary {
+	sort.Strings(t.unpinned)
+	return PinningSummary{Score: t.score(), Total: t.total, Styles: t.styles, Unpinned: t.unpinned}
+}
@@ -0,0 +1,155 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyConstraint(t *testing.T) {
+	tests := []struct {
+		depType  string
+		version  string
+		metadata map[string]interface{}
+		expected string
+	}{
+		{"npm", "18.2.0", nil, PinExact},
+		{"npm", "^18.2.0", nil, PinCaret},
+		{"npm", "~4.17.21", nil, PinTilde},
+		{"npm", ">=1.0.0 <2.0.0", nil, PinRange},
+		{"npm", "1.x", nil, PinRange},
+		{"npm", "*", nil, PinWildcard},
+		{"npm", "latest", nil, PinWildcard},
+		{"npm", "workspace:*", nil, PinLocal},
+		{"npm", "file:../shared", nil, PinLocal},
+		{"npm", "github:user/repo#main", nil, PinGitBranch},
+		{"npm", "git+https://github.com/user/repo.git#v1.2.0", nil, PinGitRef},
+		{"npm", "git+https://github.com/user/repo.git", nil, PinGitBranch},
+		{"npm", "18.2.0", types.NewMetadata(parsers.MetadataSourcePackageLock), PinLocked},
+		{"python", "==2.31.0", nil, PinExact},
+		{"python", "~=2.31", nil, PinTilde},
+		{"python", ">=2.0,<3", nil, PinRange},
+		{"python", "==2.*", nil, PinRange},
+		{"python", "git+https://github.com/org/lib@3f1c2a9", nil, PinGitRef},
+		{"ruby", "~> 7.1", nil, PinTilde},
+		{"ruby", "latest", map[string]interface{}{"git": "https://github.com/org/gem", "branch": "main"}, PinGitBranch},
+		{"ruby", "latest", map[string]interface{}{"path": "../engine"}, PinLocal},
+		{"cargo", "1.0", nil, PinCaret},
+		{"cargo", "=1.0.2", nil, PinExact},
+		{"golang", "v1.9.1", nil, PinExact},
+		{"maven", "[1.0,2.0)", nil, PinRange},
+		{"maven", "[1.2.3]", nil, PinExact},
+		{"maven", "${spring.version}", nil, PinUnknown},
+		{"gradle", "1.0.0", map[string]interface{}{parsers.MetadataIncludedBuild: "/libs/core"}, PinLocal},
+		{"dotnet", "8.0.1", nil, PinExact},
+		{"php", "dev-main", nil, PinGitBranch},
+		{"php", "^10.0", nil, PinCaret},
+		{"docker", "3.12-slim", nil, PinExact},
+		{"docker", "latest", nil, PinWildcard},
+		{"githubAction", "v4", nil, PinCaret},
+		{"githubAction", "v4.1.1", nil, PinExact},
+		{"githubAction", "b4ffde65f46336ab88eb53be808477a3936bae11", nil, PinExact},
+		{"githubAction", "main", nil, PinGitBranch},
+		{"zig", "latest", map[string]interface{}{"hash": "1220abcd"}, PinExact},
+		{"zig", "latest", nil, PinWildcard},
+		{"nimble", "#head", nil, PinGitBranch},
+		{"nimble", "#v0.6.0", nil, PinGitRef},
+		{"nimble", "^= 0.6", nil, PinCaret},
+		{"nimble", ">= 1.0 & < 2.0", nil, PinRange},
+		{"dub", "~>0.9.5", nil, PinTilde},
+		{"dub", "~master", nil, PinGitBranch},
+		{"dub", "1.2.3", nil, PinExact},
+		{"luarocks", "~> 2.1", nil, PinTilde},
+		{"luarocks", "~= 1.0", nil, PinRange},
+		{"luarocks", "== 1.13.1", nil, PinExact},
+		{"fpm", "main", map[string]interface{}{"git": "https://github.com/org/lib", "branch": "main"}, PinGitBranch},
+		{"fpm", "v0.4.2", map[string]interface{}{"git": "https://github.com/org/lib"}, PinGitRef},
+		{"cmake", ">=1.10", nil, PinRange},
+		{"cmake", "latest", nil, PinWildcard},
+		{"foundry", "latest", map[string]interface{}{"git": "https://github.com/foundry-rs/forge-std", "submodule": "lib/forge-std"}, PinGitRef},
+		{"foundry", "v1.9.4", types.NewMetadata(parsers.MetadataSourceFoundryLock), PinLocked},
+		{"foundry", "5.0.2", types.NewMetadata(parsers.MetadataSourceFoundryToml), PinExact},
+		{"gitSubmodule", "b514bdc898e2951020cbdca1304b75f5950d1f59", map[string]interface{}{"source": ".gitmodules", "git": "https://github.com/google/googletest", "submodule": "third_party/googletest"}, PinGitRef},
+		{"gitSubmodule", "main", map[string]interface{}{"source": ".gitmodules", "submodule": "vendor/theme", "branch": "main"}, PinGitRef},
+		{"vendored", "1.3.1", map[string]interface{}{"source": "zlib.h", "vendored-fork": true, "path": "third_party/zlib"}, PinLocal},
+		{"golang", "v1.9.1", map[string]interface{}{"source": "server", "artifact": "/bin/server"}, PinLocked},
+		{"maven", "6.1.4", map[string]interface{}{"source": "app.war", "artifact": "/dist/app.war"}, PinLocked},
+		{"shards", "1.4.0", types.NewMetadata(parsers.MetadataSourceShardLock), PinLocked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.depType+" "+tt.version, func(t *testing.T) {
+			dep := types.Dependency{Type: tt.depType, Name: "pkg", Version: tt.version, Metadata: tt.metadata}
+			assert.Equal(t, tt.expected, ClassifyConstraint(dep))
+		})
+	}
+}
+
+func TestBuildPinningHygiene(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	root.Dependencies = []types.Dependency{
+		{Type: "githubAction", Name: "actions/checkout", Version: "v4", Direct: true, Metadata: types.NewMetadata(parsers.MetadataSourceGitHubWorkflow)},
+	}
+	root.AddPath("/.github/workflows/ci.yml")
+
+	web := types.NewPayloadWithPath("web", "/web/package.json")
+	web.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "react", Version: "18.2.0", Direct: true, Metadata: types.NewMetadata(parsers.MetadataSourcePackageJSON)},
+		{Type: "npm", Name: "vite", Version: "^5.0.0", Direct: true, Metadata: types.NewMetadata(parsers.MetadataSourcePackageJSON)},
+		{Type: "npm", Name: "left-pad", Version: "*", Direct: true, Metadata: types.NewMetadata(parsers.MetadataSourcePackageJSON)},
+		{Type: "npm", Name: "shared", Version: "workspace:*", Direct: true, Metadata: types.NewMetadata(parsers.MetadataSourcePackageJSON)},
+		{Type: "npm", Name: "scheduler", Version: "0.23.0", Direct: false},
+	}
+	api := types.NewPayloadWithPath("api", "/api/requirements.txt")
+	api.Dependencies = []types.Dependency{
+		{Type: "python", Name: "fastapi", Version: "==0.110.0", Direct: true, Metadata: types.NewMetadata(parsers.MetadataSourceRequirementsTxt)},
+	}
+	root.AddChild(web)
+	root.AddChild(api)
+
+	hygiene := BuildPinningHygiene(root)
+	require.NotNil(t, hygiene)
+
+	// Scored: caret (0.5) + exact (1) + caret (0.5) + wildcard (0) + exact (1) = 3 of 5; workspace link is not scored
+	assert.Equal(t, 60, hygiene.Score)
+	assert.Equal(t, 6, hygiene.Total)
+	assert.Equal(t, map[string]int{PinExact: 2, PinCaret: 2, PinWildcard: 1, PinLocal: 1}, hygiene.Styles)
+
+	require.Len(t, hygiene.Ecosystems, 3)
+	assert.Equal(t, "npm", hygiene.Ecosystems[1].Ecosystem)
+	assert.Equal(t, 50, hygiene.Ecosystems[1].Score)
+	assert.Equal(t, []string{"left-pad"}, hygiene.Ecosystems[1].Unpinned)
+
+	require.Len(t, hygiene.Files, 3)
+	assert.Equal(t, "/.github/workflows/ci.yml", hygiene.Files[0].File)
+	assert.Equal(t, "/api/requirements.txt", hygiene.Files[1].File)
+	assert.Equal(t, 100, hygiene.Files[1].Score)
+	assert.Equal(t, "/web/package.json", hygiene.Files[2].File)
+	assert.Equal(t, 4, hygiene.Files[2].Total)
+}
+
+func TestBuildPinningHygiene_NoDirectDependencies(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	root.Dependencies = []types.Dependency{{Type: "npm", Name: "scheduler", Version: "0.23.0"}}
+	assert.Nil(t, BuildPinningHygiene(root))
+	assert.Nil(t, BuildPinningHygiene(nil))
+}
+
+func TestSourceFiles(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	web := types.NewPayloadWithPath("web", "/web/package.json")
+	web.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "react", Version: "18.2.0", Metadata: map[string]interface{}{"source": "package-lock.json"}},
+		{Type: "npm", Name: "lodash", Version: "4.17.21", Metadata: map[string]interface{}{"source": "package-lock.json"}},
+	}
+	api := types.NewPayloadWithPath("api", "/api/pom.xml")
+	api.Dependencies = []types.Dependency{{Type: "maven", Name: "junit:junit", Version: "4.13.2", SourceFile: "pom.xml"}}
+	root.Children = []*types.Payload{web, api}
+
+	assert.Equal(t, []string{"api/pom.xml", "web/package-lock.json", "web/package.json"}, SourceFiles(root))
+	assert.Empty(t, SourceFiles(types.NewPayloadWithPath("main", "/")))
+	assert.Nil(t, SourceFiles(nil))
+}
@@ -0,0 +1,193 @@
+package analysis
+
+import (
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// MatrixPlatforms are the operating system and architecture combinations (GOOS/GOARCH) of the
+// platform support matrix
+var MatrixPlatforms = []string{
+	"linux/amd64", "linux/arm64", "darwin/amd64", "darwin/arm64", "windows/amd64", "windows/arm64",
+}
+
+// PlatformMatrix reports which platforms the locked dependency sets of the components support
+type PlatformMatrix struct {
+	Platforms  []string             `json:"platforms"`  // Platforms evaluated (MatrixPlatforms)
+	Components []ComponentPlatforms `json:"components"` // Components with platform specific dependencies
+}
+
+// ComponentPlatforms is the platform support of a single component
+type ComponentPlatforms struct {
+	Name        string        `json:"name"`
+	Path        string        `json:"path"`
+	Supported   []string      `json:"supported"`             // Platforms all dependencies install on
+	Unsupported []PlatformGap `json:"unsupported,omitempty"` // Platforms some dependencies do not install on
+}
+
+// PlatformGap explains why a component does not support a platform
+type PlatformGap struct {
+	Platform  string   `json:"platform"`
+	Packages  []string `json:"packages,omitempty"`   // Dependencies without artifact or support for the platform (type:name)
+	LockFiles []string `json:"lock_files,omitempty"` // Lock files not resolved for the platform (Gemfile.lock PLATFORMS)
+}
+
+// platformPackage collects the platform data of the records of a package in a component
+// (Gemfile.lock records platform variants of a gem separately)
+type platformPackage struct {
+	key       string
+	portable  bool            // A record installs on every platform
+	platforms map[string]bool // Platforms of the platform specific artifacts
+	records   []types.Dependency
+}
+
+// BuildPlatformMatrix evaluates the platform support of every component with platform specific
+// dependencies: packages whose locked artifacts only exist for some platforms (platform wheels
+// without source distribution, gem platform variants), npm packages restricted by os and cpu,
+// and Gemfile.lock files resolved for some platforms only. Dependencies not installed on a
+// platform (environment markers, optional npm packages of other platforms) do not count against
+// it. Returns nil if no component has platform specific dependencies.
+func BuildPlatformMatrix(payload *types.Payload) *PlatformMatrix {
+	if payload == nil {
+		return nil
+	}
+
+	result := &PlatformMatrix{Platforms: MatrixPlatforms}
+	walkComponents(payload, func(component *types.Payload) {
+		if entry := componentPlatforms(component); entry != nil {
+			result.Components = append(result.Components, *entry)
+		}
+	})
+	if len(result.Components) == 0 {
+		return nil
+	}
+	return result
+}
+
+// componentPlatforms returns the platform support of a component, or nil if none of its
+// dependencies is platform specific
+func componentPlatforms(component *types.Payload) *ComponentPlatforms {
+	packages := platformPackages(component.Dependencies)
+	lockPlatforms := rubyLockPlatforms(component)
+	if len(packages) == 0 && lockPlatforms == nil {
+		return nil
+	}
+
+	entry := &ComponentPlatforms{Name: component.Name, Path: componentDirs(component)[0], Supported: []string{}}
+	for _, platform := range MatrixPlatforms {
+		gap := PlatformGap{Platform: platform}
+		goos, goarch, _ := strings.Cut(platform, "/")
+		env := &parsers.TargetEnvironment{OS: goos, Arch: goarch}
+		for _, pkg := range packages {
+			if !pkg.supports(env, platform) {
+				gap.Packages = append(gap.Packages, pkg.key)
+			}
+		}
+		if lockPlatforms != nil && !lockPlatforms[platform] {
+			gap.LockFiles = append(gap.LockFiles, path.Join(entry.Path, "Gemfile.lock"))
+		}
+		if len(gap.Packages) == 0 && len(gap.LockFiles) == 0 {
+			entry.Supported = append(entry.Supported, platform)
+			continue
+		}
+		sort.Strings(gap.Packages)
+		entry.Unsupported = append(entry.Unsupported, gap)
+	}
+	return entry
+}
+
+// platformPackages groups the platform specific dependencies by package: dependencies with
+// artifact platforms, and npm dependencies restricted by os or cpu
+func platformPackages(dependencies []types.Dependency) []*platformPackage {
+	byKey := make(map[string]*platformPackage)
+	var keys []string
+	for _, dep := range dependencies {
+		key := dep.Type + ":" + dep.Name
+		pkg := byKey[key]
+		if pkg == nil {
+			pkg = &platformPackage{key: key, platforms: make(map[string]bool)}
+			byKey[key] = pkg
+			keys = append(keys, key)
+		}
+		pkg.records = append(pkg.records, dep)
+		platforms := metadataStrings(dep.Metadata[parsers.MetadataArtifactPlatforms])
+		if len(platforms) == 0 {
+			pkg.portable = true
+		}
+		for _, platform := range platforms {
+			pkg.platforms[platform] = true
+		}
+	}
+
+	var result []*platformPackage
+	for _, key := range keys {
+		pkg := byKey[key]
+		if !pkg.portable || slices.ContainsFunc(pkg.records, requiredNpmPlatform) {
+			result = append(result, pkg)
+		}
+	}
+	return result
+}
+
+// supports reports whether a package installs on a platform: it is not installed there, it has
+// a portable record or an artifact for the platform, and npm's os and cpu fields of required
+// (non-optional) records allow the platform
+func (pkg *platformPackage) supports(env *parsers.TargetEnvironment, platform string) bool {
+	installed := false
+	for _, dep := range pkg.records {
+		if requiredNpmPlatform(dep) && !env.Active(dep) {
+			return false // npm refuses to install the package (EBADPLATFORM)
+		}
+		installed = installed || env.Active(dep)
+	}
+	if !installed {
+		return true
+	}
+	return pkg.portable || pkg.platforms[platform]
+}
+
+// requiredNpmPlatform reports whether a dependency is a non-optional npm package restricted by
+// its os or cpu fields
+func requiredNpmPlatform(dep types.Dependency) bool {
+	if dep.Type != parsers.DependencyTypeNpm {
+		return false
+	}
+	restricted := false
+	entries, _ := dep.Metadata[parsers.MetadataActivation].([]interface{})
+	for _, item := range entries {
+		entry, _ := item.(map[string]interface{})
+		switch entry[parsers.ActivationFieldKind] {
+		case parsers.ActivationOptional:
+			return false
+		case parsers.ActivationOS, parsers.ActivationCPU:
+			restricted = true
+		}
+	}
+	return restricted
+}
+
+// rubyLockPlatforms returns the platforms the Gemfile.lock of a component is resolved for, or
+// nil if it has none or a portable platform (ruby)
+func rubyLockPlatforms(component *types.Payload) map[string]bool {
+	properties, _ := component.Properties["ruby"].(map[string]interface{})
+	lockPlatforms := metadataStrings(properties["lock_platforms"])
+	if len(lockPlatforms) == 0 {
+		return nil
+	}
+	supported := make(map[string]bool)
+	for _, lockPlatform := range lockPlatforms {
+		platforms, portable := parsers.GemPlatforms(lockPlatform)
+		if portable {
+			return nil
+		}
+		for _, platform := range platforms {
+			supported[platform] = true
+		}
+	}
+	return supported
+}
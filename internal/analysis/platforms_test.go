@@ -0,0 +1,71 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildPlatformMatrix(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+
+	api := types.NewPayloadWithPath("api", "/api/pyproject.toml")
+	torch := types.Dependency{Type: "python", Name: "torch", Version: "2.4.0", Direct: true}
+	parsers.SetArtifactPlatforms(&torch, []string{"linux/amd64", "darwin/arm64", "windows/amd64"})
+	pywin32 := types.Dependency{Type: "python", Name: "pywin32", Version: "306", Direct: true}
+	parsers.SetArtifactPlatforms(&pywin32, []string{"windows/amd64"})
+	parsers.AddPythonMarkerActivation(&pywin32, `sys_platform == "win32"`)
+	api.Dependencies = []types.Dependency{
+		torch, pywin32,
+		{Type: "python", Name: "requests", Version: "2.31.0", Direct: true},
+	}
+
+	web := types.NewPayloadWithPath("web", "/web/Gemfile")
+	nokogiri := types.Dependency{Type: "ruby", Name: "nokogiri", Version: "1.16.0", Direct: true}
+	nokogiriLinux := types.Dependency{Type: "ruby", Name: "nokogiri", Version: "1.16.0-x86_64-linux", Direct: true}
+	parsers.SetArtifactPlatforms(&nokogiriLinux, []string{"linux/amd64"})
+	web.Dependencies = []types.Dependency{nokogiri, nokogiriLinux}
+	web.SetComponentProperty("ruby", "lock_platforms", []string{"x86_64-linux", "arm64-darwin"})
+
+	ui := types.NewPayloadWithPath("ui", "/ui/package.json")
+	fsevents := types.Dependency{Type: "npm", Name: "fsevents", Version: "2.3.3", Direct: true}
+	parsers.AddEnvironmentActivation(&fsevents, parsers.ActivationOS, []string{"darwin"})
+	rollup := types.Dependency{Type: "npm", Name: "@rollup/rollup-linux-x64-gnu", Version: "4.18.0", Scope: types.ScopeOptional, Direct: true}
+	parsers.AddActivation(&rollup, parsers.ActivationOptional, nil, true)
+	parsers.AddEnvironmentActivation(&rollup, parsers.ActivationOS, []string{"linux"})
+	parsers.AddEnvironmentActivation(&rollup, parsers.ActivationCPU, []string{"x64"})
+	ui.Dependencies = []types.Dependency{fsevents, rollup}
+
+	plain := types.NewPayloadWithPath("plain", "/plain/package.json")
+	plain.Dependencies = []types.Dependency{{Type: "npm", Name: "react", Version: "18.3.1", Direct: true}}
+
+	root.AddChild(api)
+	root.AddChild(web)
+	root.AddChild(ui)
+	root.AddChild(plain)
+
+	matrix := BuildPlatformMatrix(root)
+	require.NotNil(t, matrix)
+	assert.Equal(t, MatrixPlatforms, matrix.Platforms)
+	require.Len(t, matrix.Components, 3, "components without platform specific dependencies are skipped")
+
+	apiPlatforms := matrix.Components[0]
+	assert.Equal(t, "/api", apiPlatforms.Path)
+	assert.Equal(t, []string{"linux/amd64", "darwin/arm64", "windows/amd64"}, apiPlatforms.Supported, "pywin32 is not installed outside Windows")
+	require.Len(t, apiPlatforms.Unsupported, 3)
+	assert.Equal(t, PlatformGap{Platform: "linux/arm64", Packages: []string{"python:torch"}}, apiPlatforms.Unsupported[0])
+	assert.Equal(t, PlatformGap{Platform: "windows/arm64", Packages: []string{"python:pywin32", "python:torch"}}, apiPlatforms.Unsupported[2])
+
+	webPlatforms := matrix.Components[1]
+	assert.Equal(t, []string{"linux/amd64", "darwin/arm64"}, webPlatforms.Supported, "portable nokogiri record")
+	assert.Equal(t, PlatformGap{Platform: "linux/arm64", LockFiles: []string{"/web/Gemfile.lock"}}, webPlatforms.Unsupported[0])
+
+	uiPlatforms := matrix.Components[2]
+	assert.Equal(t, []string{"darwin/amd64", "darwin/arm64"}, uiPlatforms.Supported, "fsevents is required, optional rollup binaries are skipped")
+	assert.Equal(t, []string{"npm:fsevents"}, uiPlatforms.Unsupported[0].Packages)
+
+	assert.Nil(t, BuildPlatformMatrix(plain))
+}
@@ -0,0 +1,145 @@
+package analysis
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Pre-release stages reported in PrereleaseDependency
+const (
+	StageSnapshot  = "snapshot"
+	StageDev       = "dev"
+	StageAlpha     = "alpha"
+	StageBeta      = "beta"
+	StageMilestone = "milestone"
+	StageRC        = "rc"
+	StagePre       = "pre" // Other pre-release identifiers (next, canary, preview)
+)
+
+// prereleaseStages classifies pre-release versions by their earliest identifier
+var prereleaseStages = []struct {
+	stage string
+	regex *regexp.Regexp
+}{
+	{StageSnapshot, regexp.MustCompile(`snapshot|\d{8}\.\d{6}-\d+$`)},
+	{StageDev, regexp.MustCompile(`(^|[^a-z])dev`)},
+	{StageRC, regexp.MustCompile(`(^|[^a-z])(rc|cr|c)(\d|[.-]|$)`)},
+	{StageBeta, regexp.MustCompile(`(^|[^a-z])(beta|b)(\d|[.-]|$)`)},
+	{StageAlpha, regexp.MustCompile(`(^|[^a-z])(alpha|a)(\d|[.-]|$)`)},
+	{StageMilestone, regexp.MustCompile(`(^|[^a-z])(milestone|m)(\d|[.-]|$)`)},
+}
+
+// goPseudoVersionRegex matches Go pseudo-versions (untagged commits), which are not pre-releases
+var goPseudoVersionRegex = regexp.MustCompile(`\d{14}-[0-9a-f]{12}$`)
+
+// PrereleaseDependency is a distributed dependency using a pre-release version
+type PrereleaseDependency struct {
+	Type       string   `json:"type"`
+	Name       string   `json:"name"`
+	Version    string   `json:"version"`
+	Stage      string   `json:"stage"` // alpha, beta, rc, milestone, dev, snapshot, or pre
+	Direct     bool     `json:"direct"`
+	Components []string `json:"components"` // IDs of components declaring the dependency
+}
+
+// BuildPrereleaseUsage lists the distributed dependencies (all scopes except dev, test, and
+// build) whose version is a pre-release, sorted by type, name, and version. Versions are read
+// with the versioning system of the registry (semver, PEP 440, Maven); Go pseudo-versions are
+// not pre-releases. Returns nil if there are none.
+func BuildPrereleaseUsage(payload *types.Payload) []PrereleaseDependency {
+	if payload == nil {
+		return nil
+	}
+
+	packages := make(map[string]*PrereleaseDependency)
+	walkComponents(payload, func(component *types.Payload) {
+		for _, dep := range component.Dependencies {
+			if dependencyExposure(dep) != ExposureDistributed {
+				continue
+			}
+			version, stage := prereleaseVersion(dep)
+			if stage == "" {
+				continue
+			}
+			key := dep.Type + ":" + dep.Name + "@" + version
+			pkg, ok := packages[key]
+			if !ok {
+				pkg = &PrereleaseDependency{Type: dep.Type, Name: dep.Name, Version: version, Stage: stage}
+				packages[key] = pkg
+			}
+			pkg.Direct = pkg.Direct || dep.Direct
+			pkg.Components = appendUnique(pkg.Components, component.ID)
+		}
+	})
+
+	if len(packages) == 0 {
+		return nil
+	}
+
+	result := make([]PrereleaseDependency, 0, len(packages))
+	for _, pkg := range packages {
+		result = append(result, *pkg)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Version < b.Version
+	})
+	return result
+}
+
+// prereleaseVersion returns the version of a dependency and its pre-release stage, or an empty
+// stage if the version is a release or cannot be read
+func prereleaseVersion(dep types.Dependency) (string, string) {
+	version := baseVersion(dep.Version)
+	if version == "" {
+		return "", ""
+	}
+	if dep.Type == "golang" && goPseudoVersionRegex.MatchString(version) {
+		return version, ""
+	}
+
+	if dep.Type == "ruby" {
+		// RubyGems versions with a letter are pre-releases (7.1.0.rc1); platform suffixes are not
+		number, _, _ := strings.Cut(version, "-")
+		if !strings.ContainsFunc(number, isLetter) {
+			return version, ""
+		}
+	} else {
+		system := parsers.VersionSystem(dep.Type)
+		if system == nil {
+			return version, ""
+		}
+		parsed, err := system.Parse(version)
+		if err != nil || !parsed.IsPrerelease() {
+			return version, ""
+		}
+	}
+	return version, prereleaseStage(version)
+}
+
+// prereleaseStage classifies a pre-release version by its earliest stage identifier
+// ("1.0.0-alpha.beta" is an alpha), or "pre" for other identifiers
+func prereleaseStage(version string) string {
+	lower := strings.ToLower(version)
+	stage, first := StagePre, len(lower)
+	for _, candidate := range prereleaseStages {
+		if loc := candidate.regex.FindStringIndex(lower); loc != nil && loc[0] < first {
+			stage, first = candidate.stage, loc[0]
+		}
+	}
+	return stage
+}
+
+func isLetter(r rune) bool {
+	return ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+}
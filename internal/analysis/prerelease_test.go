@@ -0,0 +1,56 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildPrereleaseUsage(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	root.ID = "root"
+	root.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "react", Version: "^19.0.0-rc.1", Scope: types.ScopeProd, Direct: true},
+		{Type: "npm", Name: "vitest", Version: "2.0.0-beta.3", Scope: types.ScopeDev, Direct: true},
+		{Type: "npm", Name: "lodash", Version: "4.17.21", Scope: types.ScopeProd, Direct: true},
+		{Type: "golang", Name: "golang.org/x/sys", Version: "v0.0.0-20240101000000-abcdefabcdef", Scope: types.ScopeProd, Direct: true},
+	}
+	child := types.NewPayloadWithPath("api", "/api/pom.xml")
+	child.ID = "api"
+	child.Dependencies = []types.Dependency{
+		{Type: "maven", Name: "com.example:client", Version: "2.0.0-SNAPSHOT", Scope: types.ScopeProd, Direct: true},
+		{Type: "python", Name: "pydantic", Version: "==2.0b3", Scope: types.ScopeProd, Direct: true},
+		{Type: "ruby", Name: "rails", Version: "7.2.0.rc1", Direct: false},
+		{Type: "ruby", Name: "nokogiri", Version: "1.16.7-x86_64-linux", Direct: false},
+		{Type: "npm", Name: "react", Version: "19.0.0-rc.1", Scope: types.ScopeProd, Direct: false},
+	}
+	root.Children = []*types.Payload{child}
+
+	assert.Equal(t, []PrereleaseDependency{
+		{Type: "maven", Name: "com.example:client", Version: "2.0.0-SNAPSHOT", Stage: StageSnapshot, Direct: true, Components: []string{"api"}},
+		{Type: "npm", Name: "react", Version: "19.0.0-rc.1", Stage: StageRC, Direct: true, Components: []string{"root", "api"}},
+		{Type: "python", Name: "pydantic", Version: "2.0b3", Stage: StageBeta, Direct: true, Components: []string{"api"}},
+		{Type: "ruby", Name: "rails", Version: "7.2.0.rc1", Stage: StageRC, Direct: false, Components: []string{"api"}},
+	}, BuildPrereleaseUsage(root))
+
+	assert.Nil(t, BuildPrereleaseUsage(types.NewPayloadWithPath("main", "/")))
+}
+
+func TestPrereleaseStage(t *testing.T) {
+	tests := map[string]string{
+		"1.0.0-alpha.beta.1":      StageAlpha,
+		"1.0.0a1.dev1":            StageAlpha,
+		"2.0rc1":                  StageRC,
+		"7.0.0.CR1":               StageRC,
+		"1.0.dev3":                StageDev,
+		"3.0.0-M1":                StageMilestone,
+		"1.0.0-20131201.121010-1": StageSnapshot,
+		"1.0.0-SNAPSHOT":          StageSnapshot,
+		"15.0.0-canary.12":        StagePre,
+		"3.0.0-next.4":            StagePre,
+	}
+	for version, want := range tests {
+		assert.Equal(t, want, prereleaseStage(version), version)
+	}
+}
@@ -0,0 +1,143 @@
+package analysis
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// SARIF format constants
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolURI = "https://github.com/petrarca/tech-stack-analyzer"
+
+	sarifFingerprintKey = "stackAnalyzerFinding/v1"
+)
+
+// sarifRules describes the rules in the order they are listed in the tool driver
+var sarifRules = []SARIFRule{
+	newSARIFRule(RuleCopyleftDistributed, "CopyleftDistributed", LevelError,
+		"Copyleft licensed dependency is distributed with the product", "copyleft-exposure"),
+	newSARIFRule(RuleLicenseMismatch, "LicenseMismatch", LevelWarning,
+		"Declared license of a dependency differs from the license concluded from registry data", "license-rollup-and-attributions"),
+	newSARIFRule(RuleUnpinnedDependency, "UnpinnedDependency", LevelWarning,
+		"Direct dependency accepts any version or tracks a git branch", "version-pinning-hygiene"),
+	newSARIFRule(RuleOutdatedDependency, "OutdatedDependency", LevelNote,
+		"Direct dependency is behind the latest registry version", "upgrade-advisory"),
+	newSARIFRule(RuleComplexityThreshold, "ComplexityThreshold", LevelWarning,
+		"Component exceeds the configured dependency complexity thresholds", "dependency-complexity"),
+	newSARIFRule(RuleInstallScript, "InstallScript", LevelNote,
+		"Dependency runs scripts when it is installed", "supply-chain-risk"),
+	newSARIFRule(RuleSingleMaintainer, "SingleMaintainer", LevelNote,
+		"Dependency declared by most components has a single maintainer", "bus-factor-risk"),
+	newSARIFRule(RuleDeprecatedDependency, "DeprecatedDependency", LevelWarning,
+		"Direct dependency or the version used is deprecated or yanked in its registry", "deprecated-dependencies"),
+	newSARIFRule(RulePrereleaseDependency, "PrereleaseDependency", LevelWarning,
+		"Distributed direct dependency uses a pre-release version", "pre-release-usage"),
+	newSARIFRule(RuleEOLRuntime, "EOLRuntime", LevelWarning,
+		"Lambda runtime, .NET target framework, or .NET SDK is past its end of life", "properties-field"),
+}
+
+// SARIFLog is a SARIF 2.1.0 log with a single run
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun is the run of the analyzer and its results
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+// SARIFTool describes the analyzer and the rules it reports
+type SARIFTool struct {
+	Driver struct {
+		Name           string      `json:"name"`
+		Version        string      `json:"version,omitempty"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []SARIFRule `json:"rules"`
+	} `json:"driver"`
+}
+
+// SARIFRule is a reporting descriptor
+type SARIFRule struct {
+	ID                   string       `json:"id"`
+	Name                 string       `json:"name"`
+	ShortDescription     SARIFMessage `json:"shortDescription"`
+	HelpURI              string       `json:"helpUri"`
+	DefaultConfiguration struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+// SARIFMessage is a plain text message
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFResult is a single finding located in a manifest file
+type SARIFResult struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             SARIFMessage      `json:"message"`
+	Locations           []SARIFLocation   `json:"locations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+}
+
+// SARIFLocation is the physical location of a result (the line of the dependency declaration,
+// else the first line of the manifest)
+type SARIFLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region struct {
+			StartLine int `json:"startLine"`
+		} `json:"region"`
+	} `json:"physicalLocation"`
+}
+
+func newSARIFRule(id, name, level, description, readmeAnchor string) SARIFRule {
+	rule := SARIFRule{ID: id, Name: name, ShortDescription: SARIFMessage{Text: description}, HelpURI: sarifToolURI + "#" + readmeAnchor}
+	rule.DefaultConfiguration.Level = level
+	return rule
+}
+
+// BuildSARIF converts the findings of the payload tree (see BuildFindings) into a SARIF log.
+// Results are located at the manifest declaring the dependency, relative to the scanned
+// directory, and carry the finding fingerprint for deduplication across scans.
+func BuildSARIF(payload *types.Payload, toolVersion string) *SARIFLog {
+	run := SARIFRun{Results: []SARIFResult{}}
+	run.Tool.Driver.Name = "stack-analyzer"
+	run.Tool.Driver.Version = toolVersion
+	run.Tool.Driver.InformationURI = sarifToolURI
+	run.Tool.Driver.Rules = sarifRules
+
+	for _, finding := range BuildFindings(payload) {
+		result := SARIFResult{
+			RuleID:              finding.RuleID,
+			Level:               finding.Level,
+			Message:             SARIFMessage{Text: finding.Message},
+			PartialFingerprints: map[string]string{sarifFingerprintKey: finding.Fingerprint()},
+		}
+		if finding.File != "" {
+			var location SARIFLocation
+			location.PhysicalLocation.ArtifactLocation.URI = finding.File
+			location.PhysicalLocation.Region.StartLine = max(finding.Line, 1)
+			result.Locations = []SARIFLocation{location}
+		}
+		run.Results = append(run.Results, result)
+	}
+	return &SARIFLog{Schema: sarifSchema, Version: sarifVersion, Runs: []SARIFRun{run}}
+}
+
+// WriteSARIF writes a SARIF log as indented JSON
+func WriteSARIF(w io.Writer, log *SARIFLog) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
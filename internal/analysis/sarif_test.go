@@ -0,0 +1,105 @@
+package analysis
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sarifTree() *types.Payload {
+	root := types.NewPayloadWithPath("main", "/")
+
+	web := types.NewPayloadWithPath("web", "/web/package.json")
+	web.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "react", Version: "17.0.2", Scope: types.ScopeProd, Direct: true, Metadata: map[string]interface{}{
+			"source": "package-lock.json", parsers.MetadataFile: "/web/package.json", parsers.MetadataLine: 7,
+		}},
+		{Type: "npm", Name: "left-pad", Version: "latest", Scope: types.ScopeProd, Direct: true, Metadata: map[string]interface{}{"source": "package.json"}},
+		{Type: "npm", Name: "gpl-lib", Version: "1.0.0", Scope: types.ScopeProd, Direct: true, Metadata: map[string]interface{}{
+			"source": "package.json", parsers.MetadataLicenseDeclared: "MIT", parsers.MetadataLicenseConcluded: "GPL-3.0-only", parsers.MetadataLicenseMismatch: true,
+		}},
+		{Type: "npm", Name: "gpl-devtool", Version: "1.0.0", Scope: types.ScopeDev, Direct: true, Metadata: map[string]interface{}{
+			"source": "package.json", parsers.MetadataLicenseDeclared: "GPL-3.0-only",
+		}},
+	}
+	api := types.NewPayloadWithPath("api", "/api/composer.json")
+	api.Dependencies = []types.Dependency{
+		{Type: "php", Name: "some/lgpl", Version: "^2.0", Scope: types.ScopeProd, Direct: true, Metadata: map[string]interface{}{
+			"source": "composer.json", parsers.MetadataLicenseDeclared: "LGPL-3.0-only",
+		}},
+	}
+	functions := types.NewPayloadWithPath("functions", "/functions/serverless.yml")
+	functions.Properties["serverless"] = []interface{}{&parsers.ServerlessInfo{
+		File:           "/functions/serverless.yml",
+		RuntimeSupport: []parsers.LambdaRuntimeSupport{{Runtime: "nodejs16.x", Deprecation: "2024-06-12", Deprecated: true}, {Runtime: "nodejs20.x"}},
+	}}
+	legacy := types.NewPayloadWithPath("Legacy", "/legacy/Legacy.csproj")
+	legacy.Properties["dotnet"] = map[string]interface{}{"framework_support": []*parsers.DotNetFrameworkSupport{
+		{Framework: "net6.0", Product: ".NET 6", EndOfSupport: "2024-11-12"},
+		{Framework: "net8.0", Product: ".NET 8", EndOfSupport: "2026-11-10", Supported: true},
+	}}
+	legacy.Properties["dotnet_sdk"] = &parsers.DotNetSDKInfo{File: "/legacy/global.json", Version: "6.0.100",
+		Support: &parsers.DotNetFrameworkSupport{Framework: "net6.0", Product: ".NET 6", EndOfSupport: "2024-11-12"}}
+	root.AddChild(web)
+	root.AddChild(api)
+	root.AddChild(functions)
+	root.AddChild(legacy)
+
+	ReportFor(root).UpgradeAdvisory = []UpgradeAdvice{
+		{Type: "npm", Name: "react", CurrentVersion: "17.0.2", LatestVersion: "18.3.1", UpdateType: UpdateMajor},
+	}
+	ReportFor(root).Complexity = BuildDependencyComplexity(root, &config.ComplexityThresholds{MaxDirectDependencies: 3})
+	return root
+}
+
+func TestBuildSARIF(t *testing.T) {
+	log := BuildSARIF(sarifTree(), "v1.2.3")
+	require.Len(t, log.Runs, 1)
+	assert.Equal(t, "2.1.0", log.Version)
+	assert.Equal(t, "stack-analyzer", log.Runs[0].Tool.Driver.Name)
+	assert.Equal(t, "v1.2.3", log.Runs[0].Tool.Driver.Version)
+	assert.Len(t, log.Runs[0].Tool.Driver.Rules, 10)
+
+	type finding struct {
+		rule, level, uri string
+		line             int
+		message          string
+	}
+	var findings []finding
+	for _, result := range log.Runs[0].Results {
+		require.Len(t, result.Locations, 1)
+		assert.Len(t, result.PartialFingerprints[sarifFingerprintKey], 16)
+		location := result.Locations[0].PhysicalLocation
+		findings = append(findings, finding{result.RuleID, result.Level, location.ArtifactLocation.URI, location.Region.StartLine, result.Message.Text})
+	}
+	assert.Equal(t, []finding{
+		{RuleComplexityThreshold, LevelWarning, "web/package.json", 1, "Component web exceeds max_direct_dependencies (direct 4, transitive 0, ecosystems 1, score 4)"},
+		{RuleCopyleftDistributed, LevelWarning, "api/composer.json", 1, "some/lgpl ^2.0 (php) is licensed LGPL-3.0-only (weak_copyleft) and distributed with the product"},
+		{RuleCopyleftDistributed, LevelError, "web/package.json", 1, "gpl-lib 1.0.0 (npm) is licensed GPL-3.0-only (strong_copyleft) and distributed with the product"},
+		{RuleEOLRuntime, LevelWarning, "functions/serverless.yml", 1, "Lambda runtime nodejs16.x is deprecated since 2024-06-12"},
+		{RuleEOLRuntime, LevelWarning, "legacy/Legacy.csproj", 1, "Target framework net6.0 (.NET 6) is out of support since 2024-11-12"},
+		{RuleEOLRuntime, LevelWarning, "legacy/global.json", 1, ".NET SDK 6.0.100 ships .NET 6, out of support since 2024-11-12"},
+		{RuleLicenseMismatch, LevelWarning, "web/package.json", 1, "gpl-lib 1.0.0 (npm) declares MIT, the registry concludes GPL-3.0-only"},
+		{RuleOutdatedDependency, LevelNote, "web/package.json", 7, "react 17.0.2 (npm) is outdated, latest is 18.3.1 (major update)"},
+		{RuleUnpinnedDependency, LevelWarning, "web/package.json", 1, "left-pad latest (npm) is not pinned (wildcard)"},
+	}, findings)
+}
+
+func TestBuildSARIF_NoFindings(t *testing.T) {
+	log := BuildSARIF(types.NewPayloadWithPath("main", "/"), "dev")
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSARIF(&buf, log))
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, sarifSchema, decoded["$schema"])
+	run := decoded["runs"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{}, run["results"], "results must be an empty array, not null")
+}
@@ -0,0 +1,221 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Kinds of suggestions
+const (
+	SuggestionPinVersion        = "pin-version"        // Replace a wildcard constraint with the version resolved by the lock file
+	SuggestionReplaceDeprecated = "replace-deprecated" // Replace a deprecated package with the package its deprecation message names
+	SuggestionSPDXLicense       = "spdx-license"       // Replace a license string with its SPDX identifier
+)
+
+// suggestionLicenseSources are the manifests whose license field is a quoted string
+var suggestionLicenseSources = map[string]bool{
+	"package.json": true, "composer.json": true, "Cargo.toml": true, "pyproject.toml": true,
+}
+
+// deprecationReplacementRegex matches the replacement package named by a deprecation message
+// ("use @scope/pkg instead", "renamed to pkg", "replaced by pkg")
+var deprecationReplacementRegex = regexp.MustCompile("(?:(?i:\\buse)\\s+[`'\"]?(@?[a-z0-9][a-z0-9._/-]*)[`'\"]?\\s+(?i:instead)|(?i:renamed to|replaced by|moved to|superseded by)\\s+[`'\"]?(@?[a-z0-9][a-z0-9._/-]*))")
+
+// deprecationStopWords are words following "use" that do not name a package
+var deprecationStopWords = map[string]bool{"the": true, "a": true, "an": true, "it": true, "this": true, "version": true, "at": true, "with": true}
+
+// Suggestion is a machine-applicable edit of a manifest: replace OldText with NewText on Line
+// of File, or at the first occurrence in File when Line is 0. Safe edits do not change which
+// packages are installed (pinning the resolved version, normalizing license strings); others,
+// like replacing a deprecated package, need review.
+type Suggestion struct {
+	Kind        string `json:"kind"`
+	RuleID      string `json:"rule_id,omitempty"` // Rule of the findings the edit resolves
+	Subject     string `json:"subject"`           // Dependency ("npm:react") or component name
+	File        string `json:"file"`              // Manifest path relative to the scanned directory (no leading slash)
+	Line        int    `json:"line,omitempty"`
+	OldText     string `json:"old_text"`
+	NewText     string `json:"new_text"`
+	Description string `json:"description"`
+	Safe        bool   `json:"safe"`
+}
+
+// BuildSuggestions returns the edits of the manifests of the payload tree resolving
+// constraint issues: wildcard constraints of package.json pinned to the version resolved by
+// the lock file, deprecated packages replaced by the package their deprecation message names,
+// and license strings replaced by their SPDX identifier. Dependency edits require the
+// declaration location of the dependency. Suggestions are sorted by file, line, and kind.
+func BuildSuggestions(payload *types.Payload) []Suggestion {
+	if payload == nil {
+		return nil
+	}
+
+	var suggestions []Suggestion
+	seen := make(map[string]bool)
+	add := func(suggestion Suggestion) {
+		key := suggestion.File + "|" + strconv.Itoa(suggestion.Line) + "|" + suggestion.OldText + "|" + suggestion.NewText
+		if !seen[key] {
+			seen[key] = true
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+	walkComponents(payload, func(component *types.Payload) {
+		for _, dep := range component.Dependencies {
+			if suggestion, ok := pinSuggestion(dep); ok {
+				add(suggestion)
+			}
+			if suggestion, ok := deprecationSuggestion(dep); ok {
+				add(suggestion)
+			}
+		}
+		for _, license := range component.Licenses {
+			if suggestion, ok := licenseSuggestion(component, license); ok {
+				add(suggestion)
+			}
+		}
+	})
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Kind < b.Kind
+	})
+	return suggestions
+}
+
+// WriteSuggestions writes suggestions as an indented JSON array
+func WriteSuggestions(w io.Writer, suggestions []Suggestion) error {
+	if suggestions == nil {
+		suggestions = []Suggestion{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(suggestions)
+}
+
+// pinSuggestion pins a direct npm dependency declared with a wildcard range in package.json
+// to the version resolved by the lock file
+func pinSuggestion(dep types.Dependency) (Suggestion, bool) {
+	file, line := declaredLocation(dep)
+	declared := originValue(dep, parsers.OriginFieldRange)
+	if line == 0 || path.Base(file) != parsers.MetadataSourcePackageJSON || dep.Version == declared {
+		return Suggestion{}, false
+	}
+	if ClassifyConstraint(types.Dependency{Type: dep.Type, Name: dep.Name, Version: declared}) != PinWildcard {
+		return Suggestion{}, false
+	}
+	if ClassifyConstraint(types.Dependency{Type: dep.Type, Name: dep.Name, Version: dep.Version}) != PinExact {
+		return Suggestion{}, false
+	}
+	return Suggestion{
+		Kind:        SuggestionPinVersion,
+		RuleID:      RuleUnpinnedDependency,
+		Subject:     dep.Type + ":" + dep.Name,
+		File:        file,
+		Line:        line,
+		OldText:     strconv.Quote(declared),
+		NewText:     strconv.Quote(dep.Version),
+		Description: fmt.Sprintf("Pin %s to %s, the version resolved by the lock file", dep.Name, dep.Version),
+		Safe:        true,
+	}, true
+}
+
+// deprecationSuggestion replaces a deprecated package with the package its deprecation
+// message names
+func deprecationSuggestion(dep types.Dependency) (Suggestion, bool) {
+	file, line := declaredLocation(dep)
+	message, _ := dep.Metadata[parsers.MetadataDeprecationMessage].(string)
+	if line == 0 || dep.Metadata[parsers.MetadataDeprecated] != parsers.DeprecatedPackage {
+		return Suggestion{}, false
+	}
+	replacement := deprecationReplacement(message)
+	if replacement == "" || replacement == dep.Name {
+		return Suggestion{}, false
+	}
+	return Suggestion{
+		Kind:        SuggestionReplaceDeprecated,
+		RuleID:      RuleDeprecatedDependency,
+		Subject:     dep.Type + ":" + dep.Name,
+		File:        file,
+		Line:        line,
+		OldText:     dep.Name,
+		NewText:     replacement,
+		Description: fmt.Sprintf("Replace deprecated %s with %s (check the version constraint)", dep.Name, replacement),
+	}, true
+}
+
+// licenseSuggestion replaces a license string of a manifest normalized by the scan with its
+// SPDX identifier
+func licenseSuggestion(component *types.Payload, license types.License) (Suggestion, bool) {
+	if license.DetectionType != "normalized" || license.OriginalLicense == "" || !suggestionLicenseSources[license.SourceFile] {
+		return Suggestion{}, false
+	}
+	file := ""
+	for _, p := range component.Path {
+		if path.Base(p) == license.SourceFile {
+			file = strings.TrimPrefix(p, "/")
+		}
+	}
+	if file == "" {
+		return Suggestion{}, false
+	}
+	return Suggestion{
+		Kind:        SuggestionSPDXLicense,
+		Subject:     component.Name,
+		File:        file,
+		OldText:     strconv.Quote(license.OriginalLicense),
+		NewText:     strconv.Quote(license.LicenseName),
+		Description: fmt.Sprintf("Use the SPDX identifier %s for the license %q", license.LicenseName, license.OriginalLicense),
+		Safe:        true,
+	}, true
+}
+
+// deprecationReplacement returns the package named as replacement by a deprecation message,
+// or "" if it names none
+func deprecationReplacement(message string) string {
+	for _, match := range deprecationReplacementRegex.FindAllStringSubmatch(message, -1) {
+		name := strings.TrimRight(match[1]+match[2], "./")
+		if name != "" && !deprecationStopWords[name] {
+			return name
+		}
+	}
+	return ""
+}
+
+// declaredLocation returns the manifest (without leading slash) and line declaring a
+// dependency, or line 0 if the scan did not locate its declaration
+func declaredLocation(dep types.Dependency) (string, int) {
+	file, _ := dep.Metadata[parsers.MetadataFile].(string)
+	line := metadataInt(dep.Metadata[parsers.MetadataLine])
+	if file == "" || line <= 0 {
+		return "", 0
+	}
+	return strings.TrimPrefix(file, "/"), line
+}
+
+// originValue returns the value of a field of the origin chain of a dependency, or "" if the
+// chain has none
+func originValue(dep types.Dependency, field string) string {
+	chain, _ := dep.Metadata[parsers.MetadataOrigin].([]interface{})
+	for _, entry := range chain {
+		if values, ok := entry.(map[string]interface{}); ok && values["field"] == field {
+			value, _ := values["value"].(string)
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,102 @@
+package analysis
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSuggestions(t *testing.T) {
+	located := func(line int, declared string, extra map[string]interface{}) map[string]interface{} {
+		metadata := map[string]interface{}{
+			"source":             "package-lock.json",
+			parsers.MetadataFile: "/web/package.json",
+			parsers.MetadataLine: line,
+		}
+		parsers.AppendOrigin(metadata, parsers.MetadataSourcePackageJSON, parsers.OriginFieldRange, declared)
+		for key, value := range extra {
+			metadata[key] = value
+		}
+		return metadata
+	}
+
+	root := types.NewPayloadWithPath("main", "/")
+	web := types.NewPayloadWithPath("web", "/web/package.json")
+	web.Licenses = []types.License{
+		{LicenseName: "Apache-2.0", DetectionType: "normalized", SourceFile: "package.json", OriginalLicense: "apache-2.0"},
+		{LicenseName: "MIT", DetectionType: "direct", SourceFile: "package.json"},
+	}
+	web.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "lodash", Version: "4.17.21", Direct: true, Metadata: located(5, "*", nil)},
+		{Type: "npm", Name: "react", Version: "18.2.0", Direct: true, Metadata: located(6, "^18.0.0", nil)},
+		{Type: "npm", Name: "request", Version: "2.88.2", Direct: true, Metadata: located(7, "^2.88.0", map[string]interface{}{
+			parsers.MetadataDeprecated:         parsers.DeprecatedPackage,
+			parsers.MetadataDeprecationMessage: "request has been deprecated, use `got` instead.",
+		})},
+		{Type: "npm", Name: "left-pad", Version: "1.3.0", Direct: true, Metadata: located(8, "^1.3.0", map[string]interface{}{
+			parsers.MetadataDeprecated:         parsers.DeprecatedPackage,
+			parsers.MetadataDeprecationMessage: "use String.prototype.padStart()",
+		})},
+		{Type: "npm", Name: "chalk", Version: "5.3.0", Direct: true,
+			Metadata: map[string]interface{}{parsers.MetadataOrigin: []interface{}{map[string]interface{}{"field": parsers.OriginFieldRange, "value": "latest"}}}},
+	}
+	root.Children = []*types.Payload{web}
+
+	suggestions := BuildSuggestions(root)
+	require.Len(t, suggestions, 3)
+	assert.Equal(t, Suggestion{
+		Kind:        SuggestionSPDXLicense,
+		Subject:     "web",
+		File:        "web/package.json",
+		OldText:     `"apache-2.0"`,
+		NewText:     `"Apache-2.0"`,
+		Description: `Use the SPDX identifier Apache-2.0 for the license "apache-2.0"`,
+		Safe:        true,
+	}, suggestions[0])
+	assert.Equal(t, Suggestion{
+		Kind:        SuggestionPinVersion,
+		RuleID:      RuleUnpinnedDependency,
+		Subject:     "npm:lodash",
+		File:        "web/package.json",
+		Line:        5,
+		OldText:     `"*"`,
+		NewText:     `"4.17.21"`,
+		Description: "Pin lodash to 4.17.21, the version resolved by the lock file",
+		Safe:        true,
+	}, suggestions[1])
+	assert.Equal(t, SuggestionReplaceDeprecated, suggestions[2].Kind)
+	assert.Equal(t, 7, suggestions[2].Line)
+	assert.Equal(t, "request", suggestions[2].OldText)
+	assert.Equal(t, "got", suggestions[2].NewText)
+	assert.False(t, suggestions[2].Safe, "replacing a package needs review")
+
+	assert.Nil(t, BuildSuggestions(nil))
+	assert.Empty(t, BuildSuggestions(types.NewPayloadWithPath("main", "/")))
+}
+
+func TestDeprecationReplacement(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{"Package renamed to @scope/new-name.", "@scope/new-name"},
+		{"This package has been replaced by 'uuid'", "uuid"},
+		{"Use node-fetch instead", "node-fetch"},
+		{"Please use the native API instead", ""},
+		{"use String.prototype.padStart()", ""},
+		{"no longer maintained", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, deprecationReplacement(tt.message), tt.message)
+	}
+}
+
+func TestWriteSuggestions(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteSuggestions(&buf, nil))
+	assert.Equal(t, "[]\n", buf.String())
+}
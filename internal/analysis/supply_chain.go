@@ -0,0 +1,124 @@
+package analysis
+
+import (
+	"log/slog"
+	"sort"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// SupplyChainRisk lists the dependencies carrying supply chain risk indicators
+type SupplyChainRisk struct {
+	InstallScripts []InstallScriptPackage `json:"install_scripts,omitempty"`
+}
+
+// InstallScriptPackage is a dependency running scripts when it is installed, a common vector
+// of supply chain attacks
+type InstallScriptPackage struct {
+	Type       string      `json:"type"`
+	Name       string      `json:"name"`
+	Version    string      `json:"version"`
+	Hooks      []string    `json:"hooks,omitempty"`  // Install lifecycle hooks, when known from registry data
+	Native     bool        `json:"native,omitempty"` // The scripts build native code
+	Scope      types.Scope `json:"scope,omitempty"`
+	Direct     bool        `json:"direct"`
+	Components []string    `json:"components"` // IDs of components declaring the dependency
+}
+
+// ConcludeInstallScripts records the install lifecycle hooks (preinstall, install,
+// postinstall) the registry reports for the version of each direct dependency, flagging
+// the dependency with install_script. Dependencies without hooks are left unchanged.
+// Returns the number of dependencies flagged.
+func ConcludeInstallScripts(payload *types.Payload, lookup PackageLookup, logger *slog.Logger) int {
+	if payload == nil || lookup == nil {
+		return 0
+	}
+
+	flagged := 0
+	walkComponents(payload, func(component *types.Payload) {
+		for i, dep := range component.Dependencies {
+			if !dep.Direct || dep.Type != "npm" || !lookup.Supports(dep.Type) {
+				continue
+			}
+			version := baseVersion(dep.Version)
+			if version == "" {
+				continue
+			}
+			info, err := lookup.Lookup(dep.Type, dep.Name)
+			if err != nil {
+				if logger != nil {
+					logger.Debug("Registry lookup failed", "type", dep.Type, "name", dep.Name, "error", err)
+				}
+				continue
+			}
+			hooks, _ := info.InstallHooksFor(version)
+			if len(hooks) == 0 {
+				continue
+			}
+
+			// Copy the metadata: dependencies of a manifest may share a single metadata map
+			metadata := make(map[string]interface{}, len(dep.Metadata)+2)
+			for key, value := range dep.Metadata {
+				metadata[key] = value
+			}
+			metadata[parsers.MetadataInstallScript] = true
+			metadata[parsers.MetadataInstallHooks] = hooks
+			recordRegistryOrigin(metadata, dep, parsers.MetadataInstallHooks, "")
+			component.Dependencies[i].Metadata = metadata
+			flagged++
+		}
+	})
+	return flagged
+}
+
+// BuildSupplyChainRisk collects the dependencies of the payload tree flagged with
+// install_script (from lock files or registry data), sorted by type, name, and version.
+// Returns nil if no dependency carries a risk indicator.
+func BuildSupplyChainRisk(payload *types.Payload) *SupplyChainRisk {
+	if payload == nil {
+		return nil
+	}
+
+	packages := make(map[string]*InstallScriptPackage)
+	walkComponents(payload, func(component *types.Payload) {
+		for _, dep := range component.Dependencies {
+			if flagged, _ := dep.Metadata[parsers.MetadataInstallScript].(bool); !flagged {
+				continue
+			}
+			key := dep.Type + ":" + dep.Name + "@" + dep.Version
+			pkg, ok := packages[key]
+			if !ok {
+				pkg = &InstallScriptPackage{Type: dep.Type, Name: dep.Name, Version: dep.Version, Scope: dep.Scope}
+				packages[key] = pkg
+			}
+			for _, hook := range metadataStrings(dep.Metadata[parsers.MetadataInstallHooks]) {
+				pkg.Hooks = appendUnique(pkg.Hooks, hook)
+			}
+			native, _ := dep.Metadata[parsers.MetadataNative].(bool)
+			pkg.Native = pkg.Native || native
+			pkg.Direct = pkg.Direct || dep.Direct
+			pkg.Components = appendUnique(pkg.Components, component.ID)
+		}
+	})
+
+	if len(packages) == 0 {
+		return nil
+	}
+
+	risk := &SupplyChainRisk{InstallScripts: make([]InstallScriptPackage, 0, len(packages))}
+	for _, pkg := range packages {
+		risk.InstallScripts = append(risk.InstallScripts, *pkg)
+	}
+	sort.Slice(risk.InstallScripts, func(i, j int) bool {
+		a, b := risk.InstallScripts[i], risk.InstallScripts[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Version < b.Version
+	})
+	return risk
+}
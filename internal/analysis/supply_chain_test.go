@@ -0,0 +1,65 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/registry"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcludeInstallScripts(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	shared := types.NewMetadata(parsers.MetadataSourcePackageJSON)
+	root.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "esbuild", Version: "^0.20.0", Scope: types.ScopeDev, Direct: true, Metadata: shared},
+		{Type: "npm", Name: "react", Version: "18.2.0", Scope: types.ScopeProd, Direct: true, Metadata: shared},
+		{Type: "npm", Name: "core-js", Version: "3.33.0", Scope: types.ScopeProd, Direct: false, Metadata: shared},
+		{Type: "python", Name: "numpy", Version: "1.26.4", Scope: types.ScopeProd, Direct: true},
+	}
+	lookup := &fakeLookup{packages: map[string]*registry.PackageInfo{
+		"npm:esbuild": {LatestVersion: "0.20.2", VersionInstallHooks: map[string][]string{"0.20.0": {"postinstall"}}},
+		"npm:react":   {LatestVersion: "18.3.1", VersionInstallHooks: map[string][]string{"18.2.0": {}}},
+		"npm:core-js": {LatestVersion: "3.33.0", VersionInstallHooks: map[string][]string{"3.33.0": {"postinstall"}}},
+	}}
+
+	assert.Equal(t, 1, ConcludeInstallScripts(root, lookup, nil))
+
+	deps := root.Dependencies
+	assert.Equal(t, true, deps[0].Metadata[parsers.MetadataInstallScript])
+	assert.Equal(t, []string{"postinstall"}, deps[0].Metadata[parsers.MetadataInstallHooks])
+	assert.Equal(t, parsers.MetadataSourcePackageJSON, deps[0].Metadata["source"])
+	assert.NotContains(t, deps[1].Metadata, parsers.MetadataInstallScript)
+	assert.NotContains(t, deps[2].Metadata, parsers.MetadataInstallScript, "transitive dependencies are not looked up")
+	assert.NotContains(t, shared, parsers.MetadataInstallScript, "shared manifest metadata is not modified")
+}
+
+func TestBuildSupplyChainRisk(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	root.ID = "root"
+	root.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "react", Version: "18.2.0", Scope: types.ScopeProd, Direct: true},
+		{Type: "npm", Name: "esbuild", Version: "0.20.0", Scope: types.ScopeDev, Direct: true,
+			Metadata: map[string]interface{}{parsers.MetadataInstallScript: true, parsers.MetadataInstallHooks: []interface{}{"postinstall"}}},
+	}
+	child := types.NewPayloadWithPath("web", "/web/package.json")
+	child.ID = "web"
+	child.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "bcrypt", Version: "5.1.1", Scope: types.ScopeProd, Direct: false,
+			Metadata: map[string]interface{}{parsers.MetadataInstallScript: true, parsers.MetadataNative: true}},
+		{Type: "npm", Name: "esbuild", Version: "0.20.0", Scope: types.ScopeDev, Direct: false,
+			Metadata: map[string]interface{}{parsers.MetadataInstallScript: true}},
+	}
+	root.Children = []*types.Payload{child}
+
+	risk := BuildSupplyChainRisk(root)
+	require.NotNil(t, risk)
+	assert.Equal(t, []InstallScriptPackage{
+		{Type: "npm", Name: "bcrypt", Version: "5.1.1", Native: true, Scope: types.ScopeProd, Direct: false, Components: []string{"web"}},
+		{Type: "npm", Name: "esbuild", Version: "0.20.0", Hooks: []string{"postinstall"}, Scope: types.ScopeDev, Direct: true, Components: []string{"root", "web"}},
+	}, risk.InstallScripts)
+
+	assert.Nil(t, BuildSupplyChainRisk(types.NewPayloadWithPath("main", "/")))
+}
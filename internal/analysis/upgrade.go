@@ -0,0 +1,151 @@
+package analysis
+
+import (
+	"log/slog"
+	"sort"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/registry"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Update types reported in UpgradeAdvice
+const (
+	UpdateMajor = "major"
+	UpdateMinor = "minor"
+	UpdatePatch = "patch"
+)
+
+// Breaking-change reasons reported in UpgradeAdvice
+const (
+	BreakingMajorBump  = "major version bump"
+	BreakingZeroMinor  = "minor bump on 0.x version"
+	BreakingDeprecated = "latest version is deprecated"
+)
+
+// UpgradeAdvice describes an outdated direct dependency and what to review before upgrading
+type UpgradeAdvice struct {
+	Type            string   `json:"type"`                        // Dependency type (npm, python, cargo, ruby)
+	Name            string   `json:"name"`                        // Package name
+	CurrentVersion  string   `json:"current_version"`             // Version derived from the declared constraint
+	LatestVersion   string   `json:"latest_version"`              // Latest version published in the registry
+	UpdateType      string   `json:"update_type"`                 // "major", "minor", or "patch"
+	Breaking        bool     `json:"breaking"`                    // True if any breaking-change heuristic matched
+	BreakingReasons []string `json:"breaking_reasons,omitempty"`  // Which heuristics matched
+	Deprecated      string   `json:"deprecated,omitempty"`        // Registry deprecation message
+	ReleaseNotesURL string   `json:"release_notes_url,omitempty"` // GitHub releases page (if repository is on GitHub)
+	ChangelogURL    string   `json:"changelog_url,omitempty"`     // Changelog URL from registry metadata
+	RepositoryURL   string   `json:"repository_url,omitempty"`    // Source repository URL
+	Components      []string `json:"components"`                  // IDs of components declaring the dependency
+}
+
+// PackageLookup retrieves registry metadata for a package (implemented by registry.Client)
+type PackageLookup interface {
+	Supports(depType string) bool
+	Lookup(depType, name string) (*registry.PackageInfo, error)
+}
+
+// BuildUpgradeAdvisory collects outdated direct dependencies across the payload tree.
+// Dependencies without a concrete version or unsupported by the lookup are skipped;
+// lookup failures are logged and do not abort the analysis.
+func BuildUpgradeAdvisory(payload *types.Payload, lookup PackageLookup, logger *slog.Logger) []UpgradeAdvice {
+	if payload == nil || lookup == nil {
+		return nil
+	}
+
+	advice := make(map[string]*UpgradeAdvice)
+	walkComponents(payload, func(component *types.Payload) {
+		for _, dep := range component.Dependencies {
+			entry := adviseDependency(dep, lookup, logger)
+			if entry == nil {
+				continue
+			}
+			key := entry.Type + ":" + entry.Name + "@" + entry.CurrentVersion
+			if existing, ok := advice[key]; ok {
+				existing.Components = appendUnique(existing.Components, component.ID)
+				continue
+			}
+			entry.Components = []string{component.ID}
+			advice[key] = entry
+		}
+	})
+
+	result := make([]UpgradeAdvice, 0, len(advice))
+	for _, entry := range advice {
+		result = append(result, *entry)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Type != result[j].Type {
+			return result[i].Type < result[j].Type
+		}
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].CurrentVersion < result[j].CurrentVersion
+	})
+	return result
+}
+
+// adviseDependency returns advice for a single dependency, or nil if it is up to date or cannot be checked
+func adviseDependency(dep types.Dependency, lookup PackageLookup, logger *slog.Logger) *UpgradeAdvice {
+	if !dep.Direct || !lookup.Supports(dep.Type) {
+		return nil
+	}
+	current := baseVersion(dep.Version)
+	if current == "" {
+		return nil
+	}
+
+	info, err := lookup.Lookup(dep.Type, dep.Name)
+	if err != nil {
+		if logger != nil {
+			logger.Debug("Registry lookup failed", "type", dep.Type, "name", dep.Name, "error", err)
+		}
+		return nil
+	}
+	if info == nil || info.LatestVersion == "" || compareVersions(current, info.LatestVersion) >= 0 {
+		return nil
+	}
+
+	entry := &UpgradeAdvice{
+		Type:            dep.Type,
+		Name:            dep.Name,
+		CurrentVersion:  current,
+		LatestVersion:   info.LatestVersion,
+		UpdateType:      updateType(current, info.LatestVersion),
+		Deprecated:      info.Deprecated,
+		ReleaseNotesURL: registry.GitHubReleasesURL(info.RepositoryURL),
+		ChangelogURL:    info.ChangelogURL,
+		RepositoryURL:   info.RepositoryURL,
+	}
+	entry.BreakingReasons = breakingReasons(current, entry.UpdateType, info.Deprecated)
+	entry.Breaking = len(entry.BreakingReasons) > 0
+	return entry
+}
+
+// breakingReasons applies the breaking-change heuristics.
+// Under semver, a minor bump on a 0.x version may contain breaking changes.
+func breakingReasons(current, update, deprecated string) []string {
+	var reasons []string
+	switch update {
+	case UpdateMajor:
+		reasons = append(reasons, BreakingMajorBump)
+	case UpdateMinor:
+		if parts, _ := versionParts(current); len(parts) > 0 && parts[0] == 0 {
+			reasons = append(reasons, BreakingZeroMinor)
+		}
+	}
+	if deprecated != "" {
+		reasons = append(reasons, BreakingDeprecated)
+	}
+	return reasons
+}
+
+// appendUnique appends value to list if not already present
+func appendUnique(list []string, value string) []string {
+	for _, v := range list {
+		if v == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
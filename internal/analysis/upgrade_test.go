@@ -0,0 +1,152 @@
+package analysis
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/registry"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLookup serves registry metadata from a map keyed by "type:name"
+type fakeLookup struct {
+	packages map[string]*registry.PackageInfo
+}
+
+func (f *fakeLookup) Supports(depType string) bool {
+	return depType == "npm" || depType == "python" || depType == "docker"
+}
+
+func (f *fakeLookup) Lookup(depType, name string) (*registry.PackageInfo, error) {
+	if info, ok := f.packages[depType+":"+name]; ok {
+		return info, nil
+	}
+	return nil, fmt.Errorf("not found: %s", name)
+}
+
+func TestBaseVersion(t *testing.T) {
+	tests := []struct {
+		constraint string
+		expected   string
+	}{
+		{"1.2.3", "1.2.3"},
+		{"^1.2.3", "1.2.3"},
+		{"~1.2", "1.2"},
+		{">=2.0,<3", "2.0"},
+		{"==2.31.0", "2.31.0"},
+		{"~> 7.0.4", "7.0.4"},
+		{">= 1.0 < 2.0", "1.0"},
+		{"v1.4.0", "1.4.0"},
+		{"^1.0.0 || ^2.0.0", "1.0.0"},
+		{"", ""},
+		{"*", ""},
+		{"latest", ""},
+		{"git+https://github.com/a/b.git", ""},
+		{"workspace:*", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			assert.Equal(t, tt.expected, baseVersion(tt.constraint))
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, -1, compareVersions("1.2.3", "1.2.4"))
+	assert.Equal(t, -1, compareVersions("1.9", "1.10.0"))
+	assert.Equal(t, 0, compareVersions("1.2", "1.2.0"))
+	assert.Equal(t, 1, compareVersions("2.0.0", "1.99.99"))
+	assert.Equal(t, -1, compareVersions("2.0.0-beta.1", "2.0.0"))
+	assert.Equal(t, 1, compareVersions("2.0.0", "2.0.0rc1"))
+}
+
+func TestUpdateType(t *testing.T) {
+	assert.Equal(t, UpdateMajor, updateType("1.2.3", "2.0.0"))
+	assert.Equal(t, UpdateMinor, updateType("1.2.3", "1.3.0"))
+	assert.Equal(t, UpdatePatch, updateType("1.2.3", "1.2.9"))
+}
+
+func TestBuildUpgradeAdvisory(t *testing.T) {
+	lookup := &fakeLookup{packages: map[string]*registry.PackageInfo{
+		"npm:react":        {LatestVersion: "18.3.1", RepositoryURL: "https://github.com/facebook/react"},
+		"npm:lodash":       {LatestVersion: "4.17.21"},
+		"npm:request":      {LatestVersion: "2.88.2", Deprecated: "request has been deprecated"},
+		"npm:zero":         {LatestVersion: "0.5.0"},
+		"python:requests":  {LatestVersion: "2.32.3", ChangelogURL: "https://example.com/HISTORY.md"},
+		"npm:not-outdated": {LatestVersion: "1.0.0"},
+	}}
+
+	root := types.NewPayloadWithPath("main", "/")
+	root.ID = "root"
+	web := types.NewPayloadWithPath("web", "/web")
+	web.ID = "web"
+	api := types.NewPayloadWithPath("api", "/api")
+	api.ID = "api"
+	root.AddChild(web)
+	root.AddChild(api)
+
+	web.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "react", Version: "^17.0.2", Direct: true},
+		{Type: "npm", Name: "lodash", Version: "4.17.21", Direct: true},
+		{Type: "npm", Name: "request", Version: "2.88.0", Direct: true},
+		{Type: "npm", Name: "zero", Version: "^0.4.1", Direct: true},
+		{Type: "npm", Name: "transitive", Version: "1.0.0", Direct: false},
+		{Type: "npm", Name: "unversioned", Version: "latest", Direct: true},
+		{Type: "maven", Name: "org.example:lib", Version: "1.0.0", Direct: true},
+		{Type: "npm", Name: "unknown", Version: "1.0.0", Direct: true},
+	}
+	api.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "react", Version: "17.0.2", Direct: true},
+		{Type: "python", Name: "requests", Version: ">=2.31.0", Direct: true},
+	}
+
+	advisory := BuildUpgradeAdvisory(root, lookup, nil)
+	require.Len(t, advisory, 4)
+
+	// Sorted by type, then name
+	assert.Equal(t, "react", advisory[0].Name)
+	assert.Equal(t, "request", advisory[1].Name)
+	assert.Equal(t, "zero", advisory[2].Name)
+	assert.Equal(t, "requests", advisory[3].Name)
+
+	react := advisory[0]
+	assert.Equal(t, "17.0.2", react.CurrentVersion)
+	assert.Equal(t, "18.3.1", react.LatestVersion)
+	assert.Equal(t, UpdateMajor, react.UpdateType)
+	assert.True(t, react.Breaking)
+	assert.Equal(t, []string{BreakingMajorBump}, react.BreakingReasons)
+	assert.Equal(t, "https://github.com/facebook/react/releases", react.ReleaseNotesURL)
+	assert.Equal(t, []string{"web", "api"}, react.Components, "same package and version should be merged across components")
+
+	request := advisory[1]
+	assert.Equal(t, UpdatePatch, request.UpdateType)
+	assert.Equal(t, "request has been deprecated", request.Deprecated)
+	assert.Equal(t, []string{BreakingDeprecated}, request.BreakingReasons)
+
+	zero := advisory[2]
+	assert.Equal(t, UpdateMinor, zero.UpdateType)
+	assert.Equal(t, []string{BreakingZeroMinor}, zero.BreakingReasons)
+
+	requests := advisory[3]
+	assert.Equal(t, UpdateMinor, requests.UpdateType)
+	assert.False(t, requests.Breaking)
+	assert.Empty(t, requests.BreakingReasons)
+	assert.Equal(t, "https://example.com/HISTORY.md", requests.ChangelogURL)
+	assert.Equal(t, []string{"api"}, requests.Components)
+}
+
+func TestReportFor(t *testing.T) {
+	payload := types.NewPayloadWithPath("main", "/")
+	assert.Nil(t, payload.Analysis)
+
+	report := ReportFor(payload)
+	require.NotNil(t, report)
+	assert.True(t, report.IsEmpty())
+	assert.Same(t, report, ReportFor(payload), "should return the existing report")
+
+	report.UpgradeAdvisory = []UpgradeAdvice{{Name: "react"}}
+	assert.False(t, report.IsEmpty())
+}
@@ -0,0 +1,122 @@
+package analysis
+
+import (
+	"strconv"
+	"strings"
+)
+
+// rangeOperators are stripped from declared constraints to get the base version
+var rangeOperators = []string{"~>", "==", ">=", "<=", "~=", "!=", "^", "~", ">", "<", "=", "v"}
+
+// baseVersion extracts the concrete version from a declared constraint.
+// Returns empty string for constraints that do not name a version (e.g., "latest", "*", URLs).
+// Examples: "^1.2.3" -> "1.2.3", ">=2.0,<3" -> "2.0", "~> 7.0.4" -> "7.0.4"
+func baseVersion(constraint string) string {
+	v := strings.TrimSpace(constraint)
+	if v == "" || v == "*" || v == "latest" || strings.Contains(v, ":") {
+		return ""
+	}
+
+	// Strip leading operators (possibly separated from the version by spaces)
+	for stripped := true; stripped; {
+		stripped = false
+		for _, op := range rangeOperators {
+			if strings.HasPrefix(v, op) {
+				v = strings.TrimSpace(strings.TrimPrefix(v, op))
+				stripped = true
+			}
+		}
+	}
+
+	// Keep only the first constraint of a compound expression
+	if idx := strings.IndexAny(v, ", |"); idx >= 0 {
+		v = v[:idx]
+	}
+
+	if v == "" || !isDigitByte(v[0]) {
+		return ""
+	}
+	return v
+}
+
+// versionParts splits a version into numeric release parts and a pre-release flag.
+// Non-numeric suffixes (e.g., "1.2.3-beta.1", "2.0.0rc1") mark the version as a pre-release.
+func versionParts(version string) ([]int, bool) {
+	var parts []int
+	prerelease := false
+	for _, segment := range strings.Split(version, ".") {
+		digits := segment
+		for i := 0; i < len(segment); i++ {
+			if !isDigitByte(segment[i]) {
+				digits = segment[:i]
+				prerelease = true
+				break
+			}
+		}
+		if digits == "" {
+			break
+		}
+		n, err := strconv.Atoi(digits)
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+		if prerelease {
+			break
+		}
+	}
+	return parts, prerelease
+}
+
+// compareVersions compares two dotted versions numerically (missing parts count as zero).
+// Returns -1, 0, or 1. A pre-release sorts before the same release version.
+func compareVersions(a, b string) int {
+	pa, preA := versionParts(a)
+	pb, preB := versionParts(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case preA && !preB:
+		return -1
+	case !preA && preB:
+		return 1
+	}
+	return 0
+}
+
+// updateType classifies the difference between two versions as "major", "minor", or "patch"
+func updateType(current, latest string) string {
+	pc, _ := versionParts(current)
+	pl, _ := versionParts(latest)
+	get := func(p []int, i int) int {
+		if i < len(p) {
+			return p[i]
+		}
+		return 0
+	}
+	switch {
+	case get(pc, 0) != get(pl, 0):
+		return UpdateMajor
+	case get(pc, 1) != get(pl, 1):
+		return UpdateMinor
+	default:
+		return UpdatePatch
+	}
+}
+
+func isDigitByte(b byte) bool {
+	return b >= '0' && b <= '9'
+}
@@ -0,0 +1,104 @@
+// Package attest wraps scan results in in-toto attestation statements: the subjects are the
+// digests of the scanned tree (git commit and the manifests and lock files the result was
+// derived from), the predicate holds the scan result, so supply-chain pipelines can verify
+// which repository state an analysis artifact describes. Statements are not signed; sign
+// them with the tooling of the pipeline (e.g. cosign, in-toto-attestation signers).
+package attest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/git"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// in-toto statement and predicate types
+const (
+	StatementType = "https://in-toto.io/Statement/v1"
+	PredicateType = "https://github.com/petrarca/tech-stack-analyzer/attestation/scan/v1"
+	MediaType     = "application/vnd.in-toto+json"
+)
+
+// ScannerURI identifies the analyzer in predicates
+const ScannerURI = "https://github.com/petrarca/tech-stack-analyzer"
+
+// Subject is an artifact the statement is about, identified by its digests
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"` // Algorithm -> hex digest (sha256, gitCommit)
+}
+
+// Statement is an in-toto v1 attestation statement
+type Statement struct {
+	Type          string    `json:"_type"`
+	Subject       []Subject `json:"subject"`
+	PredicateType string    `json:"predicateType"`
+	Predicate     Predicate `json:"predicate"`
+}
+
+// Predicate describes the scan that produced the result, SLSA provenance style
+type Predicate struct {
+	Scanner      Scanner           `json:"scanner"`
+	ScannedAt    string            `json:"scannedAt"`    // RFC 3339
+	ResultDigest map[string]string `json:"resultDigest"` // Digest of the result as written to the output file
+	Result       json.RawMessage   `json:"result"`       // Scan result (the JSON output of the scan)
+}
+
+// Scanner identifies the analyzer version that produced the result
+type Scanner struct {
+	URI     string `json:"uri"`
+	Version string `json:"version"`
+}
+
+// Subjects returns the subjects of the scanned tree: the full git commit hash of the
+// repository (named by its remote URL, without credentials) when known, and the sha256 digest
+// of each file read through the provider. Files that cannot be read (deleted since the scan,
+// directories) are skipped.
+func Subjects(remoteURL, commit string, provider types.Provider, files []string) []Subject {
+	var subjects []Subject
+	if commit != "" {
+		name := git.SanitizeRemoteURL(remoteURL)
+		if name == "" {
+			name = "git"
+		}
+		subjects = append(subjects, Subject{Name: name, Digest: map[string]string{"gitCommit": commit}})
+	}
+	for _, file := range files {
+		if exists, _ := provider.Exists(file); !exists {
+			continue
+		}
+		content, err := provider.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		subjects = append(subjects, Subject{Name: file, Digest: map[string]string{"sha256": SHA256(content)}})
+	}
+	return subjects
+}
+
+// NewStatement wraps a scan result (the JSON output) in a statement about the subjects
+func NewStatement(subjects []Subject, result []byte, scannerVersion string, scannedAt time.Time) *Statement {
+	if subjects == nil {
+		subjects = []Subject{}
+	}
+	return &Statement{
+		Type:          StatementType,
+		Subject:       subjects,
+		PredicateType: PredicateType,
+		Predicate: Predicate{
+			Scanner:      Scanner{URI: ScannerURI, Version: scannerVersion},
+			ScannedAt:    scannedAt.UTC().Format(time.RFC3339),
+			ResultDigest: map[string]string{"sha256": SHA256(result)},
+			Result:       json.RawMessage(result),
+		},
+	}
+}
+
+// SHA256 returns the hex sha256 digest of content
+func SHA256(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/fleet"
+	"github.com/spf13/cobra"
+)
+
+var aggregateFormat string
+var aggregateOutput string
+var aggregateTop int
+var aggregateDirect bool
+var aggregateMinAdoption float64
+var aggregateMinRepos int
+
+var aggregateCmd = &cobra.Command{
+	Use:   "aggregate <results.json|directory>...",
+	Short: "Merge many scan results into a fleet report",
+	Long: `Aggregate merges the scan results of many repositories (the full or aggregated JSON
+output of "scan") into a fleet-level report:
+
+  - the most common dependencies with their version spread across repositories
+    (e.g., 14 repositories on rails 6.x, 3 on 7.x)
+  - the license distribution of the dependencies
+  - technologies by number of repositories, and orphaned technologies used by a single repository
+  - convergence recommendations: direct dependencies where most repositories have standardized
+    on one version series, or on one of several alternative libraries (e.g., axios over
+    node-fetch and got), with the outlier repositories
+
+Directories are read for *.json files (not recursively). Repositories are named after their
+git remote, else the project name, else the file name.
+
+Examples:
+  stack-analyzer aggregate results/
+  stack-analyzer aggregate shop.json billing.json --format text
+  stack-analyzer aggregate results/ --direct --top 20 -o fleet-report.json`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runAggregate,
+
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"json"}, cobra.ShellCompDirectiveFilterFileExt
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(aggregateCmd)
+	setupOutputFlags(aggregateCmd, &aggregateFormat, &aggregateOutput)
+	aggregateCmd.Flags().IntVar(&aggregateTop, "top", fleet.DefaultTop, "Number of most common dependencies in the report (0 = all)")
+	aggregateCmd.Flags().BoolVar(&aggregateDirect, "direct", false, "Count only dependencies declared directly in a manifest")
+	aggregateCmd.Flags().Float64Var(&aggregateMinAdoption, "min-adoption", fleet.DefaultMinAdoption, "Share of repositories (0-1) a version or library needs to be recommended as standard")
+	aggregateCmd.Flags().IntVar(&aggregateMinRepos, "min-repos", fleet.DefaultMinRepositories, "Repositories a dependency or library group needs for a recommendation")
+}
+
+// FleetReportResult is the output for the aggregate command
+type FleetReportResult struct {
+	*fleet.Report
+}
+
+func (r *FleetReportResult) ToJSON() interface{} {
+	return r.Report
+}
+
+func (r *FleetReportResult) ToText(w io.Writer) {
+	fmt.Fprintf(w, "Repositories: %d\n", len(r.Repositories))
+
+	fmt.Fprintf(w, "\nMost common dependencies:\n")
+	for _, dep := range r.Dependencies {
+		series := make([]string, 0, len(dep.Versions))
+		for _, version := range dep.Versions {
+			series = append(series, fmt.Sprintf("%s: %d", version.Series, version.Repositories))
+		}
+		fmt.Fprintf(w, "  %-40s %3d repos  (%s)\n", dep.Type+":"+dep.Name, dep.Repositories, strings.Join(series, ", "))
+	}
+
+	fmt.Fprintf(w, "\nLicenses:\n")
+	for _, license := range r.Licenses {
+		fmt.Fprintf(w, "  %-40s %3d repos  %4d dependencies\n", license.License, license.Repositories, license.Dependencies)
+	}
+
+	fmt.Fprintf(w, "\nTechnologies:\n")
+	for _, tech := range r.Technologies {
+		fmt.Fprintf(w, "  %-40s %3d repos\n", tech.Tech, tech.Repositories)
+	}
+
+	fmt.Fprintf(w, "\nOrphaned technologies (single repository):\n")
+	if len(r.OrphanedTechnologies) == 0 {
+		fmt.Fprintf(w, "  none\n")
+	}
+	for _, tech := range r.OrphanedTechnologies {
+		fmt.Fprintf(w, "  %-40s %s\n", tech.Tech, strings.Join(tech.RepositoryNames, ", "))
+	}
+
+	fmt.Fprintf(w, "\nConvergence recommendations:\n")
+	if len(r.Recommendations) == 0 {
+		fmt.Fprintf(w, "  none\n")
+	}
+	for _, rec := range r.Recommendations {
+		subject := rec.Type + ":" + rec.Name
+		if rec.Kind == fleet.KindLibrary {
+			subject = rec.Type + " " + rec.Name
+		}
+		fmt.Fprintf(w, "  %s: %s in %d of %d repos (%.0f%%)\n", subject, rec.Standard,
+			rec.Repositories-len(rec.Outliers), rec.Repositories, rec.Adoption*100)
+		for _, outlier := range rec.Outliers {
+			fmt.Fprintf(w, "    %-38s uses %s\n", outlier.Repository, strings.Join(outlier.Uses, ", "))
+		}
+	}
+}
+
+func runAggregate(cmd *cobra.Command, args []string) {
+	if aggregateMinAdoption <= 0 || aggregateMinAdoption > 1 {
+		exitErrorf("Invalid --min-adoption %v: must be greater than 0 and at most 1", aggregateMinAdoption)
+	}
+
+	files, err := resultFiles(args)
+	if err != nil {
+		exitErrorf("Failed to read scan results: %v", err)
+	}
+	if len(files) == 0 {
+		exitErrorf("No scan results found in %s", strings.Join(args, ", "))
+	}
+
+	repos := make([]*fleet.Repository, 0, len(files))
+	for _, path := range files {
+		file, err := os.Open(path)
+		if err != nil {
+			exitErrorf("Failed to open scan results: %v", err)
+		}
+		repo, err := fleet.Load(path, file)
+		_ = file.Close()
+		if err != nil {
+			exitErrorf("Failed to load %s: %v", path, err)
+		}
+		repos = append(repos, repo)
+	}
+
+	report := fleet.BuildReport(repos, fleet.Options{
+		Top:             aggregateTop,
+		DirectOnly:      aggregateDirect,
+		MinAdoption:     aggregateMinAdoption,
+		MinRepositories: aggregateMinRepos,
+	})
+	OutputToFile(&FleetReportResult{Report: report}, aggregateFormat, aggregateOutput)
+}
+
+// resultFiles expands directories to the *.json files they contain
+func resultFiles(args []string) ([]string, error) {
+	var files []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+
+		matches, err := filepath.Glob(filepath.Join(arg, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+	return files, nil
+}
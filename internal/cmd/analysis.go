@@ -0,0 +1,533 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/analysis"
+	"github.com/petrarca/tech-stack-analyzer/internal/attest"
+	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/fix"
+	"github.com/petrarca/tech-stack-analyzer/internal/git"
+	"github.com/petrarca/tech-stack-analyzer/internal/notify"
+	"github.com/petrarca/tech-stack-analyzer/internal/provider"
+	"github.com/petrarca/tech-stack-analyzer/internal/registry"
+	"github.com/petrarca/tech-stack-analyzer/internal/repro"
+	"github.com/petrarca/tech-stack-analyzer/internal/tickets"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/petrarca/tech-stack-analyzer/internal/version"
+)
+
+// runAnalyses runs the enabled post-scan analyses and attaches the report to the root payload
+func runAnalyses(payload interface{}, logger *slog.Logger) {
+	p, ok := payload.(*types.Payload)
+	if !ok {
+		return
+	}
+
+	// Dependency update tool coverage (offline, always enabled)
+	if coverage := analysis.BuildUpdateCoverage(p); coverage != nil {
+		analysis.ReportFor(p).UpdateCoverage = coverage
+	}
+
+	// Machine-learning stack summary (offline, always enabled)
+	if mlStack := analysis.BuildMLStack(p); mlStack != nil {
+		analysis.ReportFor(p).MLStack = mlStack
+	}
+
+	// GraphQL surface and tooling summary (offline, always enabled)
+	if graphQL := analysis.BuildGraphQLSurface(p); graphQL != nil {
+		analysis.ReportFor(p).GraphQL = graphQL
+	}
+
+	// Version pinning hygiene of direct dependencies (offline, always enabled)
+	if pinning := analysis.BuildPinningHygiene(p); pinning != nil {
+		analysis.ReportFor(p).PinningHygiene = pinning
+	}
+
+	// Distributed dependencies on pre-release versions (offline, always enabled)
+	if prereleases := analysis.BuildPrereleaseUsage(p); len(prereleases) > 0 {
+		analysis.ReportFor(p).Prereleases = prereleases
+	}
+
+	// Platform support matrix of components with platform specific dependencies (offline, always enabled)
+	if platforms := analysis.BuildPlatformMatrix(p); platforms != nil {
+		analysis.ReportFor(p).Platforms = platforms
+	}
+
+	// npm peer dependency and engine conflicts of locked packages (offline, always enabled)
+	if conflicts := analysis.BuildInstallConflicts(p); conflicts != nil {
+		analysis.ReportFor(p).Install = conflicts
+		logger.Info("npm install conflicts found", "failing", conflicts.Failing, "warning", conflicts.Warning)
+	}
+
+	// Locally patched and overridden npm packages (offline, always enabled)
+	if patches := analysis.BuildPatchInventory(p); patches != nil {
+		analysis.ReportFor(p).Patches = patches
+		logger.Debug("npm patches and overrides found", "patched", patches.Patched, "overridden", patches.Overridden)
+	}
+
+	// Dependency complexity per component, flagged against configured thresholds (offline, always enabled)
+	if complexity := analysis.BuildDependencyComplexity(p, settings.ComplexityThresholds); complexity != nil {
+		analysis.ReportFor(p).Complexity = complexity
+		if complexity.Flagged > 0 {
+			logger.Info("Components exceed complexity thresholds", "flagged", complexity.Flagged)
+		}
+	}
+
+	var lookup analysis.PackageLookup
+	if settings.EnrichRegistry || settings.DataBundle != "" {
+		client := registryLookup(logger)
+		lookup = client
+		advisory := analysis.BuildUpgradeAdvisory(p, client, logger)
+		if len(advisory) > 0 {
+			analysis.ReportFor(p).UpgradeAdvisory = advisory
+		}
+		logger.Debug("Upgrade advisory complete", "outdated", len(advisory))
+		concluded := analysis.ConcludeLicenses(p, client, logger)
+		logger.Debug("License conclusion complete", "concluded", concluded)
+		flagged := analysis.ConcludeInstallScripts(p, client, logger)
+		logger.Debug("Install script conclusion complete", "flagged", flagged)
+		maintained := analysis.ConcludeMaintainers(p, client, logger)
+		logger.Debug("Maintainer conclusion complete", "updated", maintained)
+		deprecated := analysis.ConcludeDeprecations(p, client, logger)
+		logger.Debug("Deprecation conclusion complete", "flagged", deprecated)
+	}
+
+	// Base image recommendations of Dockerfiles (offline, size deltas when enriched)
+	if baseImages := analysis.BuildBaseImageAdvice(p, lookup, logger); len(baseImages) > 0 {
+		analysis.ReportFor(p).BaseImages = baseImages
+		logger.Info("Base images have recommended alternatives", "images", len(baseImages))
+	}
+
+	// License rollup of distributed dependencies (offline, uses concluded licenses when enriched)
+	if rollup := analysis.BuildLicenseRollup(p); rollup != nil {
+		analysis.ReportFor(p).LicenseRollup = rollup
+	}
+
+	// Copyleft exposure by scope (offline, uses concluded licenses when enriched)
+	if copyleft := analysis.BuildCopyleftExposure(p); copyleft != nil {
+		analysis.ReportFor(p).Copyleft = copyleft
+		if copyleft.Risk == analysis.RiskHigh {
+			logger.Info("Copyleft licensed dependencies are distributed", "high", copyleft.Summary[analysis.RiskHigh])
+		}
+	}
+
+	// Supply chain risk indicators (offline, uses registry install hooks when enriched)
+	if supplyChain := analysis.BuildSupplyChainRisk(p); supplyChain != nil {
+		analysis.ReportFor(p).SupplyChain = supplyChain
+	}
+
+	// Central single-maintainer dependencies (requires the maintainer counts of registry enrichment)
+	if busFactor := analysis.BuildBusFactorRisk(p); busFactor != nil {
+		analysis.ReportFor(p).BusFactor = busFactor
+		logger.Info("Central dependencies have a single maintainer", "packages", len(busFactor.Packages))
+	}
+
+	// Findings approved by the exceptions file (after all analyses producing findings)
+	if settings.ExceptionsFile != "" {
+		acceptRisks(p, logger)
+	}
+}
+
+// acceptRisks records the findings approved by the exceptions file as accepted risks
+func acceptRisks(p *types.Payload, logger *slog.Logger) {
+	data, err := os.ReadFile(settings.ExceptionsFile)
+	if err != nil {
+		logger.Error("Failed to read exceptions file", "error", err)
+		os.Exit(exitError)
+	}
+	exceptions, err := analysis.ParseExceptions(data)
+	if err != nil {
+		logger.Error("Failed to parse exceptions file", "file", settings.ExceptionsFile, "error", err)
+		os.Exit(exitError)
+	}
+	for _, exception := range analysis.AcceptRisks(p, exceptions, time.Now()) {
+		fmt.Fprintf(os.Stderr, "Exception for %s@%s expired on %s (reviewer %s), its findings are violations again\n",
+			exception.Package, exception.Version, exception.Expires, exception.Reviewer)
+	}
+	if accepted := len(analysis.ReportFor(p).AcceptedRisks); accepted > 0 {
+		logger.Info("Findings accepted by exceptions", "accepted", accepted)
+	}
+}
+
+// registryLookup returns the package lookup of the registry analyses: the data bundle when
+// --data-bundle is set (offline), else the package registries, with the npm registries and
+// credentials of the user's and the scanned directories' .npmrc and .yarnrc.yml files (the
+// latter without credentials unless --trust-npmrc is set)
+func registryLookup(logger *slog.Logger) analysis.PackageLookup {
+	if settings.DataBundle == "" {
+		fmt.Fprintf(os.Stderr, "Querying package registries for upgrade advisory, licenses, install scripts, maintainers, deprecations, and base images...\n")
+		return newRegistryClient(scanRoots, settings.TrustNpmrc)
+	}
+
+	file, err := os.Open(settings.DataBundle)
+	if err != nil {
+		logger.Error("Failed to open data bundle", "error", err)
+		os.Exit(exitError)
+	}
+	defer file.Close()
+	bundle, err := registry.ReadBundle(file)
+	if err != nil {
+		logger.Error("Failed to read data bundle", "file", settings.DataBundle, "error", err)
+		os.Exit(exitError)
+	}
+	fmt.Fprintf(os.Stderr, "Reading registry data from %s (%d packages, created %s)...\n",
+		settings.DataBundle, len(bundle.Packages), bundle.Manifest.Created)
+	return bundle
+}
+
+// newRegistryClient creates a registry client using the npm registries and credentials of the
+// user's .npmrc and .yarnrc.yml files and those of the given directories; the credentials and
+// environment variables of the directories' files are only used when they are trusted
+func newRegistryClient(dirs []string, trustDirs bool) *registry.Client {
+	client := registry.NewClient(registry.DefaultTimeout)
+	home, _ := os.UserHomeDir()
+	if config := registry.LoadNpmConfig(home, dirs, trustDirs); !config.IsEmpty() {
+		client.SetNpmConfig(config)
+	}
+	return client
+}
+
+// writeAttributions writes the third-party notices of the distributed dependencies to the
+// attributions file
+func writeAttributions(payload interface{}, logger *slog.Logger) {
+	p, ok := payload.(*types.Payload)
+	if !ok {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := analysis.WriteAttributions(&buf, analysis.BuildAttributions(p)); err != nil {
+		logger.Error("Failed to write attributions file", "error", err)
+		os.Exit(exitError)
+	}
+	data := buf.Bytes()
+	if settings.Redact != "" {
+		data = []byte(newRedactor().String(buf.String()))
+	}
+	if err := os.WriteFile(settings.AttributionsFile, data, 0644); err != nil {
+		logger.Error("Failed to write attributions file", "error", err)
+		os.Exit(exitError)
+	}
+	fmt.Fprintf(os.Stderr, "Attributions written to %s\n", settings.AttributionsFile)
+}
+
+// writeSARIF writes the findings of the post-scan analyses to the SARIF file
+func writeSARIF(payload interface{}, logger *slog.Logger) {
+	p, ok := payload.(*types.Payload)
+	if !ok {
+		return
+	}
+
+	var buf bytes.Buffer
+	sarifLog := analysis.BuildSARIF(p, version.Version)
+	if err := analysis.WriteSARIF(&buf, sarifLog); err != nil {
+		logger.Error("Failed to write SARIF file", "error", err)
+		os.Exit(exitError)
+	}
+	data := buf.Bytes()
+	if settings.Redact != "" {
+		data = newRedactor().JSON(data)
+	}
+	if err := os.WriteFile(settings.SARIFFile, data, 0644); err != nil {
+		logger.Error("Failed to write SARIF file", "error", err)
+		os.Exit(exitError)
+	}
+	fmt.Fprintf(os.Stderr, "SARIF written to %s (%d results)\n", settings.SARIFFile, len(sarifLog.Runs[0].Results))
+}
+
+// writeSuggestions writes the manifest edits resolving findings to the suggestions file
+func writeSuggestions(payload interface{}, logger *slog.Logger) {
+	p, ok := payload.(*types.Payload)
+	if !ok {
+		return
+	}
+
+	var buf bytes.Buffer
+	suggestions := analysis.BuildSuggestions(p)
+	if err := analysis.WriteSuggestions(&buf, suggestions); err != nil {
+		logger.Error("Failed to write suggestions file", "error", err)
+		os.Exit(exitError)
+	}
+	data := buf.Bytes()
+	if settings.Redact != "" {
+		data = newRedactor().JSON(data)
+	}
+	if err := os.WriteFile(settings.SuggestionsFile, data, 0644); err != nil {
+		logger.Error("Failed to write suggestions file", "error", err)
+		os.Exit(exitError)
+	}
+	fmt.Fprintf(os.Stderr, "Suggestions written to %s (%d edits)\n", settings.SuggestionsFile, len(suggestions))
+}
+
+// applyFixes prints the diff of the safe manifest edits and writes them to the scanned
+// directory unless --fix-dry-run is set. The diff goes to stdout unless the JSON output does.
+func applyFixes(payload interface{}, logger *slog.Logger) {
+	p, ok := payload.(*types.Payload)
+	if !ok {
+		return
+	}
+	if len(scanRoots) != 1 {
+		logger.Error("--fix requires a single scanned directory")
+		os.Exit(exitError)
+	}
+
+	root := scanRoots[0]
+	changes, err := fix.Plan(p, provider.NewFSProvider(root))
+	if err != nil {
+		logger.Error("Failed to plan fixes", "error", err)
+		os.Exit(exitError)
+	}
+	preview := os.Stdout
+	if settings.OutputFile == "" {
+		preview = os.Stderr
+	}
+	var edited []fix.Change
+	for _, change := range changes {
+		for _, skipped := range change.Skipped {
+			logger.Warn("Fix skipped", "file", change.File, "edit", skipped)
+		}
+		if !bytes.Equal(change.Before, change.After) {
+			fmt.Fprint(preview, fix.UnifiedDiff(change))
+			edited = append(edited, change)
+		}
+	}
+	if settings.FixDryRun {
+		fmt.Fprintf(os.Stderr, "Fix dry run: %d manifests would change\n", len(edited))
+		return
+	}
+
+	for _, change := range edited {
+		path, err := fix.Target(root, change.File)
+		if err != nil {
+			logger.Error("Failed to write fix", "file", change.File, "error", err)
+			os.Exit(exitError)
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			logger.Error("Failed to write fix", "file", change.File, "error", err)
+			os.Exit(exitError)
+		}
+		if err := os.WriteFile(path, change.After, info.Mode().Perm()); err != nil {
+			logger.Error("Failed to write fix", "file", change.File, "error", err)
+			os.Exit(exitError)
+		}
+		logger.Debug("Fix applied", "file", change.File, "edits", change.Edits)
+	}
+	fmt.Fprintf(os.Stderr, "Fixes applied to %d manifests\n", len(edited))
+}
+
+// writeAttestation writes an in-toto statement wrapping the output with the digests of the
+// scanned tree: the git commit and the manifests and lock files of the result
+func writeAttestation(payload interface{}, output []byte, logger *slog.Logger) {
+	p, ok := payload.(*types.Payload)
+	if !ok {
+		return
+	}
+	if len(scanRoots) != 1 {
+		logger.Error("--attestation requires a single scanned directory")
+		os.Exit(exitError)
+	}
+
+	root := scanRoots[0]
+	var remoteURL string
+	if info := git.GetGitInfo(root); info != nil {
+		remoteURL = info.RemoteURL
+	}
+	subjects := attest.Subjects(remoteURL, git.HeadCommit(root), provider.NewFSProvider(root), analysis.SourceFiles(p))
+	if settings.Redact != "" {
+		redactor := newRedactor()
+		for i := range subjects {
+			subjects[i].Name = redactor.String(subjects[i].Name)
+		}
+	}
+	data, err := json.MarshalIndent(attest.NewStatement(subjects, output, version.Version, time.Now()), "", "  ")
+	if err != nil {
+		logger.Error("Failed to write attestation file", "error", err)
+		os.Exit(exitError)
+	}
+	if err := os.WriteFile(settings.AttestationFile, append(data, '\n'), 0644); err != nil {
+		logger.Error("Failed to write attestation file", "error", err)
+		os.Exit(exitError)
+	}
+	fmt.Fprintf(os.Stderr, "Attestation written to %s (%d subjects)\n", settings.AttestationFile, len(subjects))
+}
+
+// reproConfig holds the settings that shape the scan result, digested in reproducibility
+// manifests (output file paths and logging do not change the result and are left out)
+type reproConfig struct {
+	Exclude                  []string                     `json:"exclude,omitempty"`
+	FilterRules              []string                     `json:"filter_rules,omitempty"`
+	NoCodeStats              bool                         `json:"no_code_stats"`
+	CodeStatsPerComponent    bool                         `json:"component_code_stats"`
+	PrimaryLanguageThreshold float64                      `json:"primary_language_threshold"`
+	UseLockFiles             bool                         `json:"use_lock_files"`
+	IncludeTransitive        bool                         `json:"include_transitive"`
+	RootID                   string                       `json:"root_id,omitempty"`
+	MavenProfiles            []string                     `json:"maven_profiles,omitempty"`
+	EnrichRegistry           bool                         `json:"enrich_registry"`
+	DataBundle               string                       `json:"data_bundle,omitempty"` // Digest of the bundle file
+	Exceptions               string                       `json:"exceptions,omitempty"`  // Digest of the exceptions file
+	ComplexityThresholds     *config.ComplexityThresholds `json:"complexity_thresholds,omitempty"`
+	Aggregate                string                       `json:"aggregate,omitempty"`
+	Query                    string                       `json:"query,omitempty"`
+	Redact                   string                       `json:"redact,omitempty"`
+	Telemetry                bool                         `json:"telemetry"`
+	PrettyPrint              bool                         `json:"pretty"`
+	Project                  *config.ScanConfig           `json:"project,omitempty"` // Merged .stack-analyzer.yml and --config
+}
+
+// writeReproManifest writes the reproducibility manifest of the output: the digests of the
+// files the scanner read, the analyzer version, and the digest of the result-shaping settings
+func writeReproManifest(output []byte, logger *slog.Logger) {
+	if len(scanRoots) != 1 {
+		logger.Error("--repro-manifest requires a single scanned directory")
+		os.Exit(exitError)
+	}
+
+	root := scanRoots[0]
+	inputs := make(map[string]string, len(scanInputs)+1)
+	for path, digest := range scanInputs {
+		inputs[path] = digest
+	}
+	if content, err := provider.NewFSProvider(root).ReadFile(".stack-analyzer.yml"); err == nil {
+		inputs[".stack-analyzer.yml"] = repro.SHA256(content)
+	}
+
+	configDigest, err := repro.ConfigDigest(reproConfig{
+		Exclude:                  settings.ExcludePatterns,
+		FilterRules:              settings.FilterRules,
+		NoCodeStats:              settings.NoCodeStats,
+		CodeStatsPerComponent:    settings.CodeStatsPerComponent,
+		PrimaryLanguageThreshold: settings.PrimaryLanguageThreshold,
+		UseLockFiles:             settings.UseLockFiles,
+		IncludeTransitive:        settings.IncludeTransitive,
+		RootID:                   settings.RootID,
+		MavenProfiles:            settings.MavenProfiles,
+		EnrichRegistry:           settings.EnrichRegistry,
+		DataBundle:               fileDigest(settings.DataBundle),
+		Exceptions:               fileDigest(settings.ExceptionsFile),
+		ComplexityThresholds:     settings.ComplexityThresholds,
+		Aggregate:                settings.Aggregate,
+		Query:                    settings.Query,
+		Redact:                   settings.Redact,
+		Telemetry:                settings.Telemetry,
+		PrettyPrint:              settings.PrettyPrint,
+		Project:                  scanMerged,
+	})
+	if err != nil {
+		logger.Error("Failed to write reproducibility manifest", "error", err)
+		os.Exit(exitError)
+	}
+
+	manifest := repro.New(inputs, configDigest, output, time.Now())
+	manifest.AnalyzerVersion = version.Version
+	if version.Commit != "none" {
+		manifest.AnalyzerCommit = version.Commit
+	}
+	manifest.GitCommit = git.HeadCommit(root)
+	manifest.NetworkData = settings.EnrichRegistry && settings.DataBundle == ""
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		logger.Error("Failed to write reproducibility manifest", "error", err)
+		os.Exit(exitError)
+	}
+	if err := os.WriteFile(settings.ReproManifestFile, append(data, '\n'), 0644); err != nil {
+		logger.Error("Failed to write reproducibility manifest", "error", err)
+		os.Exit(exitError)
+	}
+	fmt.Fprintf(os.Stderr, "Reproducibility manifest written to %s (%d inputs)\n", settings.ReproManifestFile, len(manifest.Inputs))
+}
+
+// fileDigest returns the sha256 digest of a file, or "" if no file is set or it cannot be read
+func fileDigest(path string) string {
+	if path == "" {
+		return ""
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return repro.SHA256(content)
+}
+
+// postNotification posts the scan summary to the notification webhook if the findings reach
+// the configured level. Failures are logged and do not fail the scan.
+func postNotification(payload interface{}, logger *slog.Logger) {
+	p, ok := payload.(*types.Payload)
+	if !ok {
+		return
+	}
+
+	summary := notify.BuildSummary(p)
+	if !notify.ShouldNotify(summary, settings.NotifyOn) {
+		logger.Debug("Notification skipped, no findings at or above level", "notify_on", settings.NotifyOn)
+		return
+	}
+	client := &http.Client{Timeout: notify.DefaultTimeout}
+	if err := notify.Post(client, settings.NotifyWebhook, summary); err != nil {
+		logger.Error("Failed to post notification", "error", err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Notification posted (%s)\n", notify.DetectFormat(settings.NotifyWebhook))
+}
+
+// syncTickets opens Jira tickets for findings at or above the configured level. Failures are
+// logged and do not fail the scan.
+func syncTickets(payload interface{}, logger *slog.Logger) {
+	p, ok := payload.(*types.Payload)
+	if !ok {
+		return
+	}
+	if settings.JiraToken == "" {
+		logger.Error("Jira tickets skipped, STACK_ANALYZER_JIRA_TOKEN is not set")
+		return
+	}
+
+	level := settings.TicketsOn
+	if level == "" {
+		level = analysis.LevelError
+	}
+	client := tickets.NewJiraClient(tickets.JiraConfig{
+		URL:       settings.JiraURL,
+		Project:   settings.JiraProject,
+		IssueType: settings.JiraIssueType,
+		User:      settings.JiraUser,
+		Token:     settings.JiraToken,
+	}, tickets.DefaultTimeout)
+	result := tickets.Sync(client, analysis.BuildFindings(p), level, logger)
+	fmt.Fprintf(os.Stderr, "Jira tickets: %d created, %d updated, %d unchanged, %d failed\n",
+		len(result.Created), len(result.Updated), len(result.Unchanged), result.Failed)
+}
+
+// failOnFindings exits with code 1 when findings match --fail-on. Called after the output is
+// written, so the results are available to the pipeline either way.
+func failOnFindings(payload interface{}, logger *slog.Logger) {
+	p, ok := payload.(*types.Payload)
+	if !ok {
+		return
+	}
+
+	failOn, err := analysis.ParseFailOn(settings.FailOn)
+	if err != nil {
+		logger.Error("Invalid settings", "error", err)
+		os.Exit(exitError)
+	}
+	failing := failOn.Failing(analysis.BuildFindings(p))
+	if len(failing) == 0 {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "%d findings match --fail-on %s:\n", len(failing), settings.FailOn)
+	for _, finding := range failing {
+		fmt.Fprintf(os.Stderr, "  [%s] %s: %s\n", finding.Level, finding.RuleID, finding.Message)
+	}
+	os.Exit(exitFindings)
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var browseCmd = &cobra.Command{
+	Use:   "browse [results.json]",
+	Short: "Browse scan results in an interactive terminal UI",
+	Long: `Browse opens scan results (the full JSON output of "scan") in an interactive terminal UI
+with an ecosystem tree, a searchable dependency list, and a detail pane showing metadata,
+licenses, and the components declaring each dependency.
+
+Defaults to stack-analysis.json in the current directory.
+
+Examples:
+  stack-analyzer browse
+  stack-analyzer browse build/scan-results.json`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runBrowse,
+
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return []string{"json"}, cobra.ShellCompDirectiveFilterFileExt
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(browseCmd)
+}
+
+func runBrowse(cmd *cobra.Command, args []string) {
+	path := "stack-analysis.json"
+	if len(args) == 1 {
+		path = args[0]
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		exitErrorf("Failed to open scan results: %v", err)
+	}
+	results, err := tui.Load(file)
+	_ = file.Close()
+	if err != nil {
+		exitErrorf("Failed to load %s: %v", path, err)
+	}
+
+	if err := tui.Run(results); err != nil {
+		exitErrorf("Browser failed: %v", err)
+	}
+}
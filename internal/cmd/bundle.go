@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/analysis"
+	"github.com/petrarca/tech-stack-analyzer/internal/fleet"
+	"github.com/petrarca/tech-stack-analyzer/internal/registry"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/petrarca/tech-stack-analyzer/internal/version"
+	"github.com/spf13/cobra"
+)
+
+var bundleOutput string
+var bundleTrustNpmrc bool
+
+var bundleCmd = &cobra.Command{
+	Use:   "bundle <results.json|directory>...",
+	Short: "Package registry data for offline scans",
+	Long: `Bundle packages the network-sourced data the analyzer uses into a single archive, so scans
+in air-gapped environments can use it with "scan --data-bundle" instead of network access.
+
+The bundle holds the package registry metadata (npm, PyPI, crates.io, RubyGems) of the direct
+dependencies of stored scan results (the full or aggregated JSON output of "scan"): latest
+versions, licenses, install hooks, maintainers, and deprecations. It also holds the Docker Hub
+tag sizes of the current and recommended images of their base image advice.
+
+The bundle holds no other data: the SPDX license list, the end-of-life tables of runtimes and
+distribution releases, and the technology rules are embedded in the binary, and the analyzer
+uses no vulnerability database (such as an OSV snapshot) or popular-package lists.
+
+Directories are read for *.json files (not recursively). Requires network access.
+
+Examples:
+  stack-analyzer scan -o app.json /path/to/app
+  stack-analyzer bundle app.json -o stack-analyzer-data.tar.gz
+  stack-analyzer scan --data-bundle stack-analyzer-data.tar.gz /path/to/app`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runBundle,
+
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"json"}, cobra.ShellCompDirectiveFilterFileExt
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(bundleCmd)
+	bundleCmd.Flags().StringVarP(&bundleOutput, "output", "o", "stack-analyzer-data.tar.gz", "Output file path of the data bundle")
+	bundleCmd.Flags().BoolVar(&bundleTrustNpmrc, "trust-npmrc", false, "Expand the environment variables and use the credentials of the .npmrc and .yarnrc.yml files of the working directory (only the user's files are trusted by default)")
+	_ = bundleCmd.MarkFlagFilename("output", "gz", "tgz")
+}
+
+func runBundle(cmd *cobra.Command, args []string) {
+	files, err := resultFiles(args)
+	if err != nil {
+		exitErrorf("Failed to read scan results: %v", err)
+	}
+	if len(files) == 0 {
+		exitErrorf("No scan results found in %s", strings.Join(args, ", "))
+	}
+
+	// npm registries and credentials of the user's and the working directory's .npmrc and .yarnrc.yml
+	workDir, _ := os.Getwd()
+	client := newRegistryClient([]string{workDir}, bundleTrustNpmrc)
+	packages := make(map[string]types.Dependency)
+	for _, path := range files {
+		file, err := os.Open(path)
+		if err != nil {
+			exitErrorf("Failed to open scan results: %v", err)
+		}
+		repo, err := fleet.Load(path, file)
+		_ = file.Close()
+		if err != nil {
+			exitErrorf("Failed to load %s: %v", path, err)
+		}
+		for _, dep := range repo.Dependencies {
+			if dep.Direct && client.Supports(dep.Type) {
+				packages[dep.Type+":"+dep.Name] = dep
+			}
+		}
+		for _, image := range repo.BaseImages {
+			if name, ok := analysis.BaseImageName(image); ok {
+				packages[parsers.DependencyTypeDocker+":"+name] = types.Dependency{Type: parsers.DependencyTypeDocker, Name: name}
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(packages))
+	for key := range packages {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fmt.Fprintf(os.Stderr, "Querying package registries for %d packages...\n", len(keys))
+	failed := 0
+	for _, key := range keys {
+		if _, err := client.Lookup(packages[key].Type, packages[key].Name); err != nil {
+			failed++
+		}
+	}
+
+	var buf bytes.Buffer
+	bundle := registry.NewBundle(client, version.Version, time.Now())
+	if err := registry.WriteBundle(&buf, bundle); err != nil {
+		exitErrorf("Failed to write data bundle: %v", err)
+	}
+	if err := os.WriteFile(bundleOutput, buf.Bytes(), 0644); err != nil {
+		exitErrorf("Failed to write output file: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "Data bundle written to %s (%d packages, %d lookups failed)\n", bundleOutput, len(bundle.Packages), failed)
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/rules"
+	"github.com/spf13/cobra"
+)
+
+// Values offered by shell completion for flags with a fixed set of values
+var (
+	aggregateFieldValues = []string{"tech", "techs", "reason", "languages", "licenses", "dependencies", "git", "all"}
+	outputFormatValues   = []string{"json", "yaml", "text"}
+	logLevelValues       = []string{"debug", "info", "warn", "error"}
+	logFormatValues      = []string{"text", "json"}
+	findingLevelValues   = []string{"note", "warning", "error"}
+	queryValues          = []string{"deps[", "components[", "licenses["}
+	targetEnvValues      = []string{"os=linux", "os=darwin", "os=windows", "arch=amd64", "arch=arm64", "python=", "node_env=production"}
+)
+
+// completeTechNames completes a single technology name argument from the embedded rules,
+// with the rule name and category as description
+func completeTechNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return techCompletions(toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTechList completes the last element of a comma-separated list of technology names
+func completeTechList(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	prefix, last := splitListPrefix(toComplete)
+	completions := techCompletions(last)
+	for i := range completions {
+		completions[i] = prefix + completions[i]
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+func techCompletions(toComplete string) []string {
+	allRules, err := rules.LoadEmbeddedRules()
+	if err != nil {
+		return nil
+	}
+
+	var completions []string
+	for _, rule := range allRules {
+		if strings.HasPrefix(rule.Tech, toComplete) {
+			completions = append(completions, rule.Tech+"\t"+rule.Name+" ("+rule.Type+")")
+		}
+	}
+	sort.Strings(completions)
+	return completions
+}
+
+// completeValueList completes the last element of a comma-separated list from fixed values,
+// skipping values already in the list
+func completeValueList(values []string) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		prefix, last := splitListPrefix(toComplete)
+		used := make(map[string]bool)
+		for _, value := range strings.Split(prefix, ",") {
+			used[value] = true
+		}
+
+		var completions []string
+		for _, value := range values {
+			if !used[value] && strings.HasPrefix(value, last) {
+				completions = append(completions, prefix+value)
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+}
+
+// splitListPrefix splits "a,b,c" into the completed part "a,b," and the element being typed "c"
+func splitListPrefix(toComplete string) (prefix, last string) {
+	i := strings.LastIndex(toComplete, ",")
+	return toComplete[:i+1], toComplete[i+1:]
+}
+
+// registerFlagCompletion attaches a completion function to a flag of the command; flags are
+// defined in the same init, so registration cannot fail
+func registerFlagCompletion(cmd *cobra.Command, flag string, fn cobra.CompletionFunc) {
+	_ = cmd.RegisterFlagCompletionFunc(flag, fn)
+}
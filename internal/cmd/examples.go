@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// exampleTopic groups the commands of a common workflow
+type exampleTopic struct {
+	Name     string
+	Title    string
+	Examples []example
+}
+
+// example is a command with a one-line description
+type example struct {
+	Description string
+	Command     string
+}
+
+var exampleTopics = []exampleTopic{
+	{Name: "scan", Title: "Scanning", Examples: []example{
+		{"Scan the current directory", "stack-analyzer scan"},
+		{"Scan several projects into one result", "stack-analyzer scan ./frontend ./backend -o results.json"},
+		{"Skip generated and vendored code", `stack-analyzer scan --exclude vendor --exclude "**/__generated__/**" .`},
+		{"Deterministic IDs for comparing scans", "stack-analyzer scan --root-id my-project ."},
+		{"Only technologies and languages", "stack-analyzer scan --aggregate techs,languages ."},
+	}},
+	{Name: "query", Title: "Extracting results", Examples: []example{
+		{"Production npm dependencies under a GPL-family license", "stack-analyzer scan --query 'deps[type=npm][scope=prod][license~GPL]' ."},
+		{"Names of all direct dependencies", "stack-analyzer scan --query 'deps[direct=true].name' ."},
+		{"Components using React", "stack-analyzer scan --query 'components[techs=react]' ."},
+		{"Browse earlier results interactively", "stack-analyzer browse stack-analysis.json"},
+	}},
+	{Name: "licenses", Title: "License compliance", Examples: []example{
+		{"Third-party notices grouped by license", "stack-analyzer scan --attributions THIRD_PARTY_NOTICES.md ."},
+		{"Concluded licenses and upgrade advisory from package registries", "stack-analyzer scan --enrich-registry ."},
+		{"Registry analyses offline from a data bundle", "stack-analyzer scan --data-bundle stack-analyzer-data.tar.gz ."},
+		{"Copyleft dependencies distributed with the product", "stack-analyzer scan --query 'deps[scope=prod][license~GPL]' ."},
+	}},
+	{Name: "ci", Title: "CI pipelines", Examples: []example{
+		{"Findings for code scanning", "stack-analyzer scan --sarif results.sarif ."},
+		{"Inline configuration with build properties", `stack-analyzer scan --config '{"scan":{"output":{"file":"scan.json"},"properties":{"build":"'$BUILD_NUMBER'"}}}' .`},
+		{"Post a summary to Slack when warnings exist", "STACK_ANALYZER_NOTIFY_WEBHOOK=https://hooks.slack.com/... stack-analyzer scan --notify-on warning ."},
+		{"Open Jira tickets for errors", "stack-analyzer scan --jira-url https://acme.atlassian.net --jira-project SEC ."},
+	}},
+	{Name: "fleet", Title: "Fleet reports", Examples: []example{
+		{"Most common dependencies and version spread across repositories", "stack-analyzer aggregate results/ --format text"},
+		{"Direct dependencies only, top 20", "stack-analyzer aggregate results/ --direct --top 20 -o fleet-report.json"},
+		{"Dependency and license trends of stored scans as CSV", "stack-analyzer trends scans/ --format csv -o trends.csv"},
+		{"Anonymized summary for fleet analytics", "STACK_ANALYZER_TELEMETRY_SALT=$FLEET_SALT stack-analyzer scan --telemetry ."},
+	}},
+	{Name: "rules", Title: "Rules and technologies", Examples: []example{
+		{"List all technologies", "stack-analyzer info techs"},
+		{"Show the rule of a technology", "stack-analyzer info rule postgresql"},
+		{"Debug a single rule", "stack-analyzer scan --rules nodejs,react --debug --trace-rules ."},
+	}},
+	{Name: "completion", Title: "Shell completion", Examples: []example{
+		{"Bash (current shell)", "source <(stack-analyzer completion bash)"},
+		{"Zsh (permanently)", `stack-analyzer completion zsh > "${fpath[1]}/_stack-analyzer"`},
+		{"Fish", "stack-analyzer completion fish > ~/.config/fish/completions/stack-analyzer.fish"},
+		{"PowerShell", "stack-analyzer completion powershell | Out-String | Invoke-Expression"},
+	}},
+}
+
+var examplesCmd = &cobra.Command{
+	Use:   "examples [topic]",
+	Short: "Show example commands for common workflows",
+	Long: `Show example commands for common workflows, optionally limited to one topic:
+scan, query, licenses, ci, fleet, rules, completion.`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: exampleTopicNames(),
+	Run:       runExamples,
+}
+
+func init() {
+	rootCmd.AddCommand(examplesCmd)
+}
+
+func exampleTopicNames() []string {
+	names := make([]string, len(exampleTopics))
+	for i, topic := range exampleTopics {
+		names[i] = topic.Name + "\t" + topic.Title
+	}
+	return names
+}
+
+func runExamples(cmd *cobra.Command, args []string) {
+	topics := exampleTopics
+	if len(args) == 1 {
+		topics = nil
+		for _, topic := range exampleTopics {
+			if topic.Name == args[0] {
+				topics = append(topics, topic)
+			}
+		}
+		if topics == nil {
+			names := make([]string, len(exampleTopics))
+			for i, topic := range exampleTopics {
+				names[i] = topic.Name
+			}
+			exitErrorf("Unknown topic: %s (available: %s)", args[0], strings.Join(names, ", "))
+		}
+	}
+	writeExamples(os.Stdout, topics)
+}
+
+func writeExamples(w io.Writer, topics []exampleTopic) {
+	for i, topic := range topics {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s (%s)\n", topic.Title, topic.Name)
+		for _, ex := range topic.Examples {
+			fmt.Fprintf(w, "\n  # %s\n  %s\n", ex.Description, ex.Command)
+		}
+	}
+}
@@ -20,6 +20,8 @@ var ruleCmd = &cobra.Command{
 	Long:  `Display the complete rule definition for a given technology name.`,
 	Args:  cobra.ExactArgs(1),
 	Run:   runRule,
+
+	ValidArgsFunction: completeTechNames,
 }
 
 func init() {
@@ -69,6 +69,7 @@ func OutputToFile(o Outputter, format string, outputFile string) {
 // setupFormatFlag configures format flag and validation for a command
 func setupFormatFlag(cmd *cobra.Command, formatPtr *string) {
 	cmd.Flags().StringVarP(formatPtr, "format", "f", "json", "Output format: json, yaml, or text")
+	registerFlagCompletion(cmd, "format", cobra.FixedCompletions(outputFormatValues, cobra.ShellCompDirectiveNoFileComp))
 	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
 		*formatPtr = util.NormalizeFormat(*formatPtr)
 		return util.ValidateOutputFormat(*formatPtr)
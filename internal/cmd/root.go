@@ -19,11 +19,24 @@ frameworks, tools, cloud services, and more.`,
 	Version: version.Full(),
 }
 
+// Exit codes of the scan command (0 = no findings match --fail-on)
+const (
+	exitFindings = 1 // Findings match --fail-on
+	exitError    = 2 // Invalid usage, configuration, or scan error
+)
+
+// exitErrorf prints an error to stderr and exits with exitError, so scripts can tell errors
+// apart from findings (log.Fatalf exits with exitFindings)
+func exitErrorf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(exitError)
+}
+
 // Execute runs the root command
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 }
 
@@ -5,13 +5,23 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"log/slog"
 
 	"github.com/petrarca/tech-stack-analyzer/internal/aggregator"
+	"github.com/petrarca/tech-stack-analyzer/internal/analysis"
 	"github.com/petrarca/tech-stack-analyzer/internal/codestats"
 	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/git"
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/profiling"
+	"github.com/petrarca/tech-stack-analyzer/internal/query"
+	"github.com/petrarca/tech-stack-analyzer/internal/redact"
 	"github.com/petrarca/tech-stack-analyzer/internal/scanner"
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 	"github.com/spf13/cobra"
@@ -82,6 +92,12 @@ var (
 	settings       *config.Settings
 	scanConfig     *config.ScanConfigFile
 	scanConfigPath string
+	scanRoots      []string           // Absolute paths of the scanned directories (for --redact)
+	scanInputs     map[string]string  // Digests of the files the scanner read (for --repro-manifest)
+	scanMerged     *config.ScanConfig // Merged project and scan configuration (for --repro-manifest)
+	scanStarted    time.Time          // Start of the scan command (for the phase timings)
+	scanPhases     *profiling.Phases  // Time per scan phase (with --verbose, nil otherwise)
+	profileSession *profiling.Session // Running --profile capture
 )
 
 var scanCmd = &cobra.Command{
@@ -127,12 +143,19 @@ func init() {
 	// Set up flags with defaults from environment variables
 	scanCmd.Flags().StringVarP(&settings.OutputFile, "output", "o", outputFile, "Output file path (default: stack-analysis.json)")
 	scanCmd.Flags().StringVar(&settings.Aggregate, "aggregate", aggregate, "Aggregate fields: tech,techs,languages,licenses,dependencies,git,all")
+	scanCmd.Flags().StringVar(&settings.Redact, "redact", settings.Redact, "Redact environment details from the output: paths,usernames,registries,emails,all")
+	scanCmd.Flags().BoolVar(&settings.Telemetry, "telemetry", settings.Telemetry, "Write only an anonymized, aggregate-only summary for fleet analytics (hash salt from STACK_ANALYZER_TELEMETRY_SALT)")
+	scanCmd.Flags().StringVar(&settings.Query, "query", "", "Write only the records matching a query, e.g. deps[type=npm][scope=prod][license~GPL] (see README)")
 	scanCmd.Flags().BoolVar(&settings.PrettyPrint, "pretty", prettyPrint, "Pretty print JSON output")
 	scanCmd.Flags().BoolVarP(&settings.Verbose, "verbose", "v", verbose, "Show progress with simple output")
 	scanCmd.Flags().BoolVarP(&settings.Debug, "debug", "d", debug, "Show progress with tree structure (cannot be used with --verbose)")
 	scanCmd.Flags().BoolVar(&settings.TraceTimings, "trace-timings", traceTimings, "Show timing information for each directory (requires --verbose or --debug)")
 	scanCmd.Flags().BoolVar(&settings.TraceRules, "trace-rules", traceRules, "Show detailed rule matching information (requires --verbose or --debug)")
 
+	// Profiling flags (runtime profiles for performance reports)
+	scanCmd.Flags().StringSliceVar(&settings.Profiles, "profile", settings.Profiles, "Capture runtime profiles: cpu, mem, trace (pprof and execution trace files, see --profile-dir)")
+	scanCmd.Flags().StringVar(&settings.ProfileDir, "profile-dir", ".", "Directory the --profile files are written to")
+
 	// Exclude patterns - support multiple flags or comma-separated values
 	scanCmd.Flags().StringSliceVar(&settings.ExcludePatterns, "exclude", settings.ExcludePatterns, "Patterns to exclude (supports glob patterns, can be specified multiple times)")
 
@@ -145,6 +168,75 @@ func init() {
 	// Per-component code statistics flag (disabled by default)
 	scanCmd.Flags().BoolVar(&settings.CodeStatsPerComponent, "component-code-stats", settings.CodeStatsPerComponent, "Enable per-component code statistics (lines of code, comments, blanks, complexity per component)")
 
+	// Registry enrichment flag (disabled by default, requires network access)
+	scanCmd.Flags().BoolVar(&settings.EnrichRegistry, "enrich-registry", settings.EnrichRegistry, "Query package registries (npm, PyPI, crates.io, RubyGems) for an upgrade advisory of outdated direct dependencies, their concluded licenses, npm install hooks, maintainers, and deprecations")
+
+	// Offline registry data flag (air-gapped environments)
+	scanCmd.Flags().StringVar(&settings.DataBundle, "data-bundle", settings.DataBundle, "Read registry data from a data bundle of the bundle command instead of the network (enables the --enrich-registry analyses offline)")
+
+	// Trust flag of project npm configuration (credentials of the scanned tree)
+	scanCmd.Flags().BoolVar(&settings.TrustNpmrc, "trust-npmrc", settings.TrustNpmrc, "Expand the environment variables and use the credentials of the .npmrc and .yarnrc.yml files of the scanned directories for registry enrichment (only the user's files are trusted by default)")
+
+	// Transitive lock file dependencies flag (direct dependencies only by default)
+	scanCmd.Flags().BoolVar(&settings.IncludeTransitive, "include-transitive", settings.IncludeTransitive, "Add the transitive dependencies of lock files listing the full package graph (Cargo.lock, go.sum); direct dependencies only by default")
+
+	// Maven profile selection flag (like mvn -P)
+	scanCmd.Flags().StringSliceVar(&settings.MavenProfiles, "maven-profiles", settings.MavenProfiles, "Maven profiles to consider like mvn -P (\"!id\" deselects a profile, \"*\" selects all profiles)")
+
+	// Detector cache flag (results of expensive detectors between scans)
+	scanCmd.Flags().StringVar(&settings.DetectorCacheDir, "detector-cache", settings.DetectorCacheDir, "Cache the results of expensive detectors (e.g. Go source walks) in this directory; entries are reused while the files they read are unchanged")
+
+	// Scan boundary flags (nested git repositories, links to directories)
+	scanCmd.Flags().StringVar(&settings.NestedRepos, "nested-repos", settings.NestedRepos, "Nested git repositories (submodules, vendored clones): component scans each as a component with its own git information, skip leaves them out (default: component)")
+	scanCmd.Flags().StringVar(&settings.Symlinks, "symlinks", settings.Symlinks, "Links to directories inside the scan root: skip does not follow them, component records each as a component referencing its target (scanned if the walk did not reach it); links leaving the root are never followed (default: skip)")
+
+	// Target environment flag (dependencies installed on one platform)
+	scanCmd.Flags().StringVar(&settings.TargetEnv, "target-env", settings.TargetEnv, "Report the dependencies installed in this environment: comma-separated os, arch, python, node_env (e.g. os=linux,arch=amd64,python=3.11,node_env=production); conditional dependencies of other platforms, features not enabled by default, and dev dependencies of production npm installs are left out")
+
+	// Production-only inventory flag (runtime dependencies of all ecosystems)
+	scanCmd.Flags().BoolVar(&settings.ProdOnly, "prod-only", settings.ProdOnly, "Report the runtime dependencies only: leave out dev, test, and build dependencies of all ecosystems (devDependencies, requirements-dev.txt, test groups, build plugins)")
+
+	// Image archive flag (layer attribution of container root file systems)
+	scanCmd.Flags().StringVar(&settings.ImageArchive, "image-archive", settings.ImageArchive, "Attribute the artifacts and dependencies of a scanned container root file system to the image layer (and Dockerfile instruction) that introduced their file; the directory of an extracted docker save archive of the image")
+
+	// Attribution file flag (third-party notices grouped by license)
+	scanCmd.Flags().StringVar(&settings.AttributionsFile, "attributions", settings.AttributionsFile, "Write third-party notices (packages grouped by license) to this Markdown file")
+
+	// SARIF output flag (findings for code scanning integrations)
+	scanCmd.Flags().StringVar(&settings.SARIFFile, "sarif", settings.SARIFFile, "Write findings (copyleft, license mismatches, unpinned and outdated dependencies, complexity) to this SARIF file")
+
+	// Autofix suggestions flag (manifest edits resolving findings)
+	scanCmd.Flags().StringVar(&settings.SuggestionsFile, "suggestions", settings.SuggestionsFile, "Write machine-applicable manifest edits (pin wildcard versions, replace deprecated packages, SPDX license strings) to this JSON file")
+
+	// Attestation flag (in-toto statement for supply-chain pipelines)
+	scanCmd.Flags().StringVar(&settings.AttestationFile, "attestation", settings.AttestationFile, "Write an in-toto attestation statement wrapping the output with the digests of the scanned tree to this file")
+
+	// Reproducibility manifest flag (digests of the scan inputs)
+	scanCmd.Flags().StringVar(&settings.ReproManifestFile, "repro-manifest", settings.ReproManifestFile, "Write a reproducibility manifest (digests of every file the scan read, analyzer version, config digest) to this file; check it later with the verify command")
+
+	// Fix mode flags (safe manifest edits, opt-in)
+	scanCmd.Flags().BoolVar(&settings.Fix, "fix", settings.Fix, "Apply safe manifest edits (pin wildcard versions to the lock file, SPDX license strings, sorted package.json dependencies) and print their diff")
+	scanCmd.Flags().BoolVar(&settings.FixDryRun, "fix-dry-run", settings.FixDryRun, "Print the diff of the --fix edits without writing the manifests")
+
+	// Exceptions flag (approved findings reported as accepted risks)
+	scanCmd.Flags().StringVar(&settings.ExceptionsFile, "exceptions", settings.ExceptionsFile, "Exceptions file of package versions approved despite policy (reviewer, reason, expiry); their findings are reported as accepted risks")
+
+	// CI gate flag (exit code 1 for matching findings)
+	scanCmd.Flags().StringVar(&settings.FailOn, "fail-on", settings.FailOn, "Exit with code 1 when findings reach a level (note, warning, error) or match a rule ID (e.g. copyleft-distributed); comma-separated")
+
+	// Webhook notification flags (scan summary to Slack or Teams)
+	scanCmd.Flags().StringVar(&settings.NotifyWebhook, "notify-webhook", settings.NotifyWebhook, "Post a scan summary to this Slack or Microsoft Teams webhook URL")
+	scanCmd.Flags().StringVar(&settings.NotifyOn, "notify-on", settings.NotifyOn, "Minimum finding level that triggers the notification: always, note, warning, error (default: always)")
+
+	// Issue tracker flags (Jira tickets for findings)
+	scanCmd.Flags().StringVar(&settings.JiraURL, "jira-url", settings.JiraURL, "Open Jira tickets for findings on this site (requires --jira-project and STACK_ANALYZER_JIRA_TOKEN)")
+	scanCmd.Flags().StringVar(&settings.JiraProject, "jira-project", settings.JiraProject, "Jira project key of the tickets")
+	scanCmd.Flags().StringVar(&settings.TicketsOn, "tickets-on", settings.TicketsOn, "Minimum finding level that opens a ticket: note, warning, error (default: error)")
+
+	// Incremental scan flags (rescan changed manifests, merge into a base result)
+	scanCmd.Flags().StringVar(&settings.ChangedSince, "changed-since", settings.ChangedSince, "Rescan only the directories whose manifests or lock files changed since this git ref (branch, tag, commit) and merge them into --base-result")
+	scanCmd.Flags().StringVar(&settings.BaseResult, "base-result", settings.BaseResult, "Full scan result of the --changed-since ref that the rescan is merged into")
+
 	// Root ID override flag for deterministic scans
 	scanCmd.Flags().StringVar(&settings.RootID, "root-id", "", "Override random root ID for deterministic scans (e.g., 'my-project-2024')")
 
@@ -155,6 +247,33 @@ func init() {
 
 	// Scan configuration flag
 	scanCmd.Flags().StringVar(&scanConfigPath, "config", "", "Scan configuration file path or inline JSON")
+
+	// Shell completion of flag values
+	registerFlagCompletion(scanCmd, "aggregate", completeValueList(aggregateFieldValues))
+	registerFlagCompletion(scanCmd, "rules", completeTechList)
+	registerFlagCompletion(scanCmd, "redact", completeValueList(append(append([]string{}, redact.Categories...), redact.All)))
+	registerFlagCompletion(scanCmd, "query", cobra.FixedCompletions(queryValues, cobra.ShellCompDirectiveNoFileComp|cobra.ShellCompDirectiveNoSpace))
+	registerFlagCompletion(scanCmd, "fail-on", completeValueList(append(append([]string{}, findingLevelValues...), analysis.RuleIDs...)))
+	registerFlagCompletion(scanCmd, "notify-on", cobra.FixedCompletions(append([]string{"always"}, findingLevelValues...), cobra.ShellCompDirectiveNoFileComp))
+	registerFlagCompletion(scanCmd, "tickets-on", cobra.FixedCompletions(findingLevelValues, cobra.ShellCompDirectiveNoFileComp))
+	registerFlagCompletion(scanCmd, "log-level", cobra.FixedCompletions(logLevelValues, cobra.ShellCompDirectiveNoFileComp))
+	registerFlagCompletion(scanCmd, "profile", completeValueList(profiling.Profiles))
+	registerFlagCompletion(scanCmd, "nested-repos", cobra.FixedCompletions(scanner.BoundaryModes, cobra.ShellCompDirectiveNoFileComp))
+	registerFlagCompletion(scanCmd, "symlinks", cobra.FixedCompletions(scanner.BoundaryModes, cobra.ShellCompDirectiveNoFileComp))
+	registerFlagCompletion(scanCmd, "target-env", completeValueList(targetEnvValues))
+	_ = scanCmd.MarkFlagDirname("profile-dir")
+	registerFlagCompletion(scanCmd, "log-format", cobra.FixedCompletions(logFormatValues, cobra.ShellCompDirectiveNoFileComp))
+	_ = scanCmd.MarkFlagFilename("output", "json")
+	_ = scanCmd.MarkFlagFilename("sarif", "sarif", "json")
+	_ = scanCmd.MarkFlagFilename("suggestions", "json")
+	_ = scanCmd.MarkFlagFilename("attestation", "json")
+	_ = scanCmd.MarkFlagFilename("repro-manifest", "json")
+	_ = scanCmd.MarkFlagFilename("base-result", "json")
+	_ = scanCmd.MarkFlagFilename("attributions", "md")
+	_ = scanCmd.MarkFlagFilename("exceptions", "yml", "yaml", "json")
+	_ = scanCmd.MarkFlagFilename("data-bundle", "gz", "tgz")
+	_ = scanCmd.MarkFlagDirname("detector-cache")
+	_ = scanCmd.MarkFlagFilename("config", "yml", "yaml", "json")
 }
 
 // configureLogging sets up logging based on command flags
@@ -178,13 +297,18 @@ func resolveScanPath(path string, logger *slog.Logger) (absPath string, isFile b
 	absPath, err := filepath.Abs(path)
 	if err != nil {
 		logger.Error("Invalid path", "error", err)
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 
 	fileInfo, err := os.Stat(absPath)
 	if os.IsNotExist(err) {
 		logger.Error("Path does not exist", "path", absPath)
-		os.Exit(1)
+		os.Exit(exitError)
+	}
+	if fileInfo.IsDir() {
+		scanRoots = append(scanRoots, absPath)
+	} else {
+		scanRoots = append(scanRoots, filepath.Dir(absPath))
 	}
 	return absPath, !fileInfo.IsDir()
 }
@@ -200,6 +324,7 @@ func configureExcludePatterns(cmd *cobra.Command) {
 
 func runScan(cmd *cobra.Command, args []string) {
 	logger := configureLogging(cmd)
+	startProfiling(logger)
 
 	// Load and merge scan configuration
 	scanConfig = loadAndMergeScanConfig(logger)
@@ -243,6 +368,10 @@ func runSinglePathScan(path string, cmd *cobra.Command, logger *slog.Logger) {
 func runMultiPathScan(paths []string, cmd *cobra.Command, logger *slog.Logger) {
 	configureExcludePatterns(cmd)
 	setupScanSettings(logger)
+	if settings.ChangedSince != "" {
+		logger.Error("--changed-since requires a single scanned directory")
+		os.Exit(exitError)
+	}
 
 	// Create a root payload that will contain all scan results
 	rootPayload := types.NewPayloadWithPath("main", "/")
@@ -286,7 +415,7 @@ func loadAndMergeScanConfig(logger *slog.Logger) *config.ScanConfigFile {
 	scanConfig, err := config.LoadScanConfig(scanConfigPath)
 	if err != nil {
 		logger.Error("Failed to load scan configuration", "error", err)
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 
 	// Merge config with settings (CLI flags take precedence)
@@ -303,7 +432,7 @@ func setupScanSettings(logger *slog.Logger) {
 	// Check for mutually exclusive flags
 	if settings.Verbose && settings.Debug {
 		logger.Error("Cannot use --verbose and --debug together. Choose one.")
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 
 	// Validate trace flags require explicit mode selection
@@ -321,13 +450,17 @@ func setupScanSettings(logger *slog.Logger) {
 		fmt.Fprintf(os.Stderr, "  stack-analyzer scan . --verbose %s  # Human-readable output\n", strings.Join(flags, " "))
 		fmt.Fprintf(os.Stderr, "  stack-analyzer scan . --debug %s    # Machine-readable CSV output\n", strings.Join(flags, " "))
 		fmt.Fprintf(os.Stderr, "\nSee --help for more information.\n")
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 
 	// Validate settings
 	if err := settings.Validate(); err != nil {
 		logger.Error("Invalid settings", "error", err)
-		os.Exit(1)
+		os.Exit(exitError)
+	}
+	if _, err := analysis.ParseFailOn(settings.FailOn); err != nil {
+		logger.Error("Invalid settings", "error", err)
+		os.Exit(exitError)
 	}
 }
 
@@ -337,7 +470,7 @@ func loadAndMergeProjectConfig(absPath string, logger *slog.Logger) (*config.Sca
 	projectConfig, err := config.LoadConfig(absPath)
 	if err != nil {
 		logger.Error("Failed to load project configuration", "error", err)
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 
 	// Merge scan config with project config
@@ -361,6 +494,11 @@ func loadAndMergeProjectConfig(absPath string, logger *slog.Logger) (*config.Sca
 
 // runScanner creates and runs the scanner
 func runScanner(absPath string, isFile bool, mergedConfig *config.ScanConfig, logger *slog.Logger) interface{} {
+	if isFile && settings.ChangedSince != "" {
+		logger.Error("--changed-since requires a directory, not a file")
+		os.Exit(exitError)
+	}
+
 	// Initialize scanner
 	scannerPath := absPath
 	if isFile {
@@ -392,29 +530,71 @@ func runScanner(absPath string, isFile bool, mergedConfig *config.ScanConfig, lo
 		5, // maxPrimaryLangs - could be configurable in the future
 	)
 
+	endInit := scanPhases.Start(profiling.PhaseInit)
 	s, err := scanner.NewScannerWithOptionsAndLogger(scannerPath, settings.ExcludePatterns, settings.Verbose, settings.Debug, settings.TraceTimings, settings.TraceRules, codeStatsAnalyzer, logger, settings.RootID, mergedConfig)
+	endInit()
 	if err != nil {
 		logger.Error("Failed to create scanner", "error", err)
-		os.Exit(1)
+		os.Exit(exitError)
+	}
+	s.SetMavenProfiles(settings.MavenProfiles)
+	s.SetIncludeTransitive(settings.IncludeTransitive)
+	s.SetNestedRepos(settings.NestedRepos)
+	s.SetSymlinks(settings.Symlinks)
+	s.SetProdOnly(settings.ProdOnly)
+	if err := s.SetTargetEnvironment(settings.TargetEnv); err != nil {
+		logger.Error("Invalid target environment", "error", err)
+		os.Exit(exitError)
+	}
+	if err := s.SetImageArchive(settings.ImageArchive); err != nil {
+		logger.Error("Failed to read image archive", "error", err)
+		os.Exit(exitError)
+	}
+	s.MeasurePhases(scanPhases)
+	if settings.DetectorCacheDir != "" {
+		if err := s.EnableDetectorCache(settings.DetectorCacheDir); err != nil {
+			logger.Error("Failed to enable detector cache", "error", err)
+			os.Exit(exitError)
+		}
+	}
+	if settings.ReproManifestFile != "" {
+		s.RecordReads()
 	}
 
 	// Scan project or file
 	var payload interface{}
-	if isFile {
+	partial := false // Code stats of a partial rescan cover only the rescanned directories
+	switch {
+	case isFile:
 		logger.Debug("Scanning file", "file", absPath)
 		payload, err = s.ScanFile(filepath.Base(absPath))
-	} else {
+	case settings.ChangedSince != "":
+		logger.Debug("Rescanning changed directories", "directory", absPath, "since", settings.ChangedSince)
+		var p *types.Payload
+		if p, err = rescanChanged(s, absPath); err == nil {
+			payload = p
+			partial = !isFullRescan(p)
+		}
+	default:
 		logger.Debug("Scanning directory", "directory", absPath)
 		payload, err = s.Scan()
 	}
 
 	if err != nil {
 		logger.Error("Failed to scan", "error", err)
-		os.Exit(1)
+		os.Exit(exitError)
+	}
+	reportPathWarnings(s.PathWarnings(), logger)
+	if settings.ReproManifestFile != "" {
+		scanInputs, scanMerged = s.ReadDigests(), mergedConfig
+	}
+	if settings.DetectorCacheDir != "" {
+		hits, stored := s.DetectorCacheStats()
+		logger.Debug("Detector cache", "dir", settings.DetectorCacheDir, "hits", hits, "stored", stored)
 	}
 
-	// Attach code stats to payload if enabled
-	if codeStatsAnalyzer.IsEnabled() {
+	// Attach code stats to payload if enabled (a partial rescan keeps those of the base result)
+	if codeStatsAnalyzer.IsEnabled() && !partial {
 		if p, ok := payload.(*types.Payload); ok {
 			stats := codeStatsAnalyzer.GetStats()
 			p.CodeStats = stats
@@ -436,6 +616,107 @@ func runScanner(absPath string, isFile bool, mergedConfig *config.ScanConfig, lo
 	return payload
 }
 
+// startProfiling starts the --profile capture, and the phase timings shown with --verbose
+func startProfiling(logger *slog.Logger) {
+	scanStarted = time.Now()
+	if settings.Verbose {
+		scanPhases = profiling.NewPhases()
+	}
+	if len(settings.Profiles) == 0 {
+		return
+	}
+	session, err := profiling.Start(settings.Profiles, settings.ProfileDir)
+	if err != nil {
+		logger.Error("Failed to start profiling", "error", err)
+		os.Exit(exitError)
+	}
+	profileSession = session
+}
+
+// stopProfiling writes the --profile files and shows the phase timings
+func stopProfiling(logger *slog.Logger) {
+	if scanPhases != nil {
+		scanPhases.Report(os.Stderr, time.Since(scanStarted))
+	}
+	if profileSession == nil {
+		return
+	}
+	files, err := profileSession.Stop()
+	if err != nil {
+		logger.Error("Failed to write profiles", "error", err)
+	}
+	for _, file := range files {
+		fmt.Fprintf(os.Stderr, "Profile written to %s\n", file)
+	}
+}
+
+// rescanChanged rescans the directories affected by the files changed since --changed-since and
+// merges them into --base-result
+func rescanChanged(s *scanner.Scanner, absPath string) (*types.Payload, error) {
+	file, err := os.Open(settings.BaseResult)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	base, err := scanner.ReadBaseResult(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", settings.BaseResult, err)
+	}
+
+	changed, err := git.ChangedFiles(absPath, settings.ChangedSince)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := s.Rescan(base, changed)
+	if err != nil {
+		return nil, err
+	}
+
+	info := payload.Metadata.(*metadata.ScanMetadata).Incremental
+	info.ChangedSince = settings.ChangedSince
+	rescanned := "none"
+	if len(info.Rescanned) > 0 {
+		rescanned = strings.Join(info.Rescanned, ", ")
+	}
+	fmt.Fprintf(os.Stderr, "Changed since %s: %d files, rescanned: %s\n", settings.ChangedSince, len(changed), rescanned)
+	return payload, nil
+}
+
+// maxPathWarnings bounds the paths listed in the path warning summary
+const maxPathWarnings = 10
+
+// reportPathWarnings lists the paths the scan could not process on stderr, all of them in the
+// debug log
+func reportPathWarnings(warnings []scanner.PathWarning, logger *slog.Logger) {
+	if len(warnings) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Warning: %d paths could not be processed completely:\n", len(warnings))
+	for i, warning := range warnings {
+		logger.Debug("Path could not be processed", "path", warning.Path, "reason", warning.Reason)
+		if i < maxPathWarnings {
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", printablePath(warning.Path), warning.Reason)
+		}
+	}
+	if len(warnings) > maxPathWarnings {
+		fmt.Fprintf(os.Stderr, "  %d more (see --log-level debug)\n", len(warnings)-maxPathWarnings)
+	}
+}
+
+// printablePath quotes paths with invalid UTF-8 or control characters, so they show on one line
+func printablePath(path string) string {
+	if !utf8.ValidString(path) || strings.IndexFunc(path, unicode.IsControl) >= 0 {
+		return strconv.Quote(path)
+	}
+	return path
+}
+
+// isFullRescan reports whether an incremental scan fell back to scanning the whole tree
+func isFullRescan(payload *types.Payload) bool {
+	info := payload.Metadata.(*metadata.ScanMetadata).Incremental
+	return len(info.Rescanned) == 1 && info.Rescanned[0] == "/"
+}
+
 // enhanceSinglePayload adds configuration data to the payload
 func enhanceSinglePayload(payload interface{}, mergedConfig *config.ScanConfig) {
 	// Add merged config properties to payload metadata
@@ -482,19 +763,70 @@ func enhanceSinglePayload(payload interface{}, mergedConfig *config.ScanConfig)
 
 // generateAndWriteOutput generates output and writes to file or stdout
 func generateAndWriteOutput(payload interface{}, logger *slog.Logger) {
+	// Run post-scan analyses on the complete payload tree
+	endEnrich := scanPhases.Start(profiling.PhaseEnrich)
+	runAnalyses(payload, logger)
+	endEnrich()
+
+	endRender := scanPhases.Start(profiling.PhaseRender)
+	if settings.AttributionsFile != "" {
+		writeAttributions(payload, logger)
+	}
+	if settings.SARIFFile != "" {
+		writeSARIF(payload, logger)
+	}
+	if settings.SuggestionsFile != "" {
+		writeSuggestions(payload, logger)
+	}
+	if settings.Fix || settings.FixDryRun {
+		applyFixes(payload, logger)
+	}
+	if settings.NotifyWebhook != "" {
+		postNotification(payload, logger)
+	}
+	if settings.JiraURL != "" {
+		syncTickets(payload, logger)
+	}
+
 	// Generate output (aggregated or full payload)
 	logger.Debug("Generating output",
 		"aggregate", settings.Aggregate,
 		"pretty_print", settings.PrettyPrint)
 
-	jsonData, err := generateOutput(payload, settings.Aggregate, settings.PrettyPrint)
+	var jsonData []byte
+	var err error
+	switch {
+	case settings.Telemetry:
+		jsonData, err = generateTelemetryOutput(payload, settings.PrettyPrint)
+	case settings.Query != "":
+		jsonData, err = generateQueryOutput(payload, settings.Query, settings.PrettyPrint)
+	default:
+		jsonData, err = generateOutput(payload, settings.Aggregate, settings.PrettyPrint)
+	}
 	if err != nil {
 		logger.Error("Failed to marshal JSON", "error", err)
-		os.Exit(1)
+		os.Exit(exitError)
+	}
+
+	if settings.Redact != "" {
+		jsonData = newRedactor().JSON(jsonData)
 	}
 
 	// Write output
 	writeOutput(jsonData)
+	if settings.AttestationFile != "" {
+		writeAttestation(payload, jsonData, logger)
+	}
+	if settings.ReproManifestFile != "" {
+		writeReproManifest(jsonData, logger)
+	}
+
+	endRender()
+
+	stopProfiling(logger)
+	if settings.FailOn != "" {
+		failOnFindings(payload, logger)
+	}
 }
 
 func generateOutput(payload interface{}, aggregateFields string, prettyPrint bool) ([]byte, error) {
@@ -534,13 +866,50 @@ func generateOutput(payload interface{}, aggregateFields string, prettyPrint boo
 	return json.Marshal(result)
 }
 
+// generateQueryOutput evaluates a query over the payload tree and marshals the matches as a
+// JSON array
+func generateQueryOutput(payload interface{}, expr string, prettyPrint bool) ([]byte, error) {
+	q, err := query.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	root, _ := payload.(*types.Payload)
+	result := q.Evaluate(root)
+
+	if prettyPrint {
+		return json.MarshalIndent(result, "", "  ")
+	}
+	return json.Marshal(result)
+}
+
+// generateTelemetryOutput marshals the anonymized telemetry summary of the payload tree
+func generateTelemetryOutput(payload interface{}, prettyPrint bool) ([]byte, error) {
+	root, ok := payload.(*types.Payload)
+	if !ok {
+		return nil, fmt.Errorf("telemetry requires a scan payload")
+	}
+	summary := aggregator.BuildTelemetry(root, settings.TelemetrySalt, time.Now())
+
+	if prettyPrint {
+		return json.MarshalIndent(summary, "", "  ")
+	}
+	return json.Marshal(summary)
+}
+
+// newRedactor creates the redactor of the --redact categories (validated with the settings)
+func newRedactor() *redact.Redactor {
+	categories, _ := redact.ParseCategories(settings.Redact)
+	home, _ := os.UserHomeDir()
+	return redact.New(categories, scanRoots, home)
+}
+
 // writeOutput writes the JSON data to file or stdout
 func writeOutput(jsonData []byte) {
 	if settings.OutputFile != "" {
 		err := os.WriteFile(settings.OutputFile, jsonData, 0644)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to write output file: %v\n", err)
-			os.Exit(1)
+			os.Exit(exitError)
 		}
 		fmt.Fprintf(os.Stderr, "Results written to %s\n", settings.OutputFile)
 	} else {
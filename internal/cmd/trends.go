@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/fleet"
+	"github.com/spf13/cobra"
+)
+
+var trendsFormat string
+var trendsOutput string
+
+// trendsFormatValues are the output formats of the trends command
+var trendsFormatValues = []string{"json", "csv"}
+
+var trendsCmd = &cobra.Command{
+	Use:   "trends <results.json|directory>...",
+	Short: "Report trends over stored scan results",
+	Long: `Trends reads stored scan results (the full or aggregated JSON output of "scan", e.g. kept
+as CI artifacts per build) and produces a time series per repository, ordered by scan time:
+
+  - unique and direct dependency counts
+  - outdated direct dependencies and their share (only for scans run with --enrich-registry)
+  - license mix (unique dependencies per license)
+
+Directories are read for *.json files (not recursively). Scans of the same repository are
+grouped by name (git remote, else project name, else file name).
+
+Examples:
+  stack-analyzer trends scans/
+  stack-analyzer trends scans/ --format csv -o trends.csv`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runTrends,
+
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"json"}, cobra.ShellCompDirectiveFilterFileExt
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(trendsCmd)
+	trendsCmd.Flags().StringVarP(&trendsFormat, "format", "f", "json", "Output format: json or csv")
+	trendsCmd.Flags().StringVarP(&trendsOutput, "output", "o", "", "Output file path (default: stdout)")
+	registerFlagCompletion(trendsCmd, "format", cobra.FixedCompletions(trendsFormatValues, cobra.ShellCompDirectiveNoFileComp))
+}
+
+func runTrends(cmd *cobra.Command, args []string) {
+	format := strings.ToLower(trendsFormat)
+	if format != "json" && format != "csv" {
+		exitErrorf("Invalid format: %s. Valid formats are: %s", trendsFormat, strings.Join(trendsFormatValues, ", "))
+	}
+
+	files, err := resultFiles(args)
+	if err != nil {
+		exitErrorf("Failed to read scan results: %v", err)
+	}
+	if len(files) == 0 {
+		exitErrorf("No scan results found in %s", strings.Join(args, ", "))
+	}
+
+	repos := make([]*fleet.Repository, 0, len(files))
+	for _, path := range files {
+		file, err := os.Open(path)
+		if err != nil {
+			exitErrorf("Failed to open scan results: %v", err)
+		}
+		repo, err := fleet.Load(path, file)
+		_ = file.Close()
+		if err != nil {
+			exitErrorf("Failed to load %s: %v", path, err)
+		}
+		repos = append(repos, repo)
+	}
+	trends := fleet.BuildTrends(repos)
+
+	var data []byte
+	if format == "csv" {
+		var buf bytes.Buffer
+		if err := fleet.WriteTrendsCSV(&buf, trends); err != nil {
+			exitErrorf("Failed to write CSV: %v", err)
+		}
+		data = buf.Bytes()
+	} else {
+		data, err = json.MarshalIndent(map[string]interface{}{"repositories": trends}, "", "  ")
+		if err != nil {
+			exitErrorf("Failed to marshal JSON: %v", err)
+		}
+		data = append(data, '\n')
+	}
+
+	if trendsOutput == "" {
+		fmt.Print(string(data))
+		return
+	}
+	if err := os.WriteFile(trendsOutput, data, 0644); err != nil {
+		exitErrorf("Failed to write output file: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "Results written to %s\n", trendsOutput)
+}
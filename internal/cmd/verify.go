@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/git"
+	"github.com/petrarca/tech-stack-analyzer/internal/provider"
+	"github.com/petrarca/tech-stack-analyzer/internal/repro"
+	"github.com/spf13/cobra"
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify <manifest.json> [path]",
+	Short: "Verify a reproducibility manifest against a repository state",
+	Long: `Verify checks that the files a scan read (recorded by "scan --repro-manifest") are unchanged
+in a directory (default: current directory), so a stored result still describes the repository.
+
+Changed and missing files are listed and the command exits with code 1; it exits with code 2
+when the manifest or the directory cannot be read. A different git commit
+is reported but does not fail the check when all inputs match. Files added since the scan are
+not detected; re-scan with the same configuration and compare the results for that (the scan
+timestamp in the metadata differs between scans).
+
+Examples:
+  stack-analyzer scan --repro-manifest stack-analysis.manifest.json .
+  stack-analyzer verify stack-analysis.manifest.json .`,
+	Args: cobra.RangeArgs(1, 2),
+	Run:  runVerify,
+
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return []string{"json"}, cobra.ShellCompDirectiveFilterFileExt
+		}
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+}
+
+func runVerify(cmd *cobra.Command, args []string) {
+	file, err := os.Open(args[0])
+	if err != nil {
+		exitErrorf("Failed to open manifest: %v", err)
+	}
+	manifest, err := repro.Read(file)
+	_ = file.Close()
+	if err != nil {
+		exitErrorf("Failed to read %s: %v", args[0], err)
+	}
+
+	dir := "."
+	if len(args) == 2 {
+		dir = args[1]
+	}
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		exitErrorf("Invalid path: %v", err)
+	}
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		exitErrorf("Not a directory: %s", dir)
+	}
+
+	if manifest.GitCommit != "" {
+		commit := git.HeadCommit(root)
+		if commit == "" {
+			commit = "none"
+		}
+		if commit != manifest.GitCommit {
+			fmt.Fprintf(os.Stderr, "Git commit differs: scanned %s, now %s\n", manifest.GitCommit, commit)
+		}
+	}
+
+	mismatches := repro.Verify(manifest, provider.NewFSProvider(root))
+	for _, m := range mismatches {
+		fmt.Printf("%-8s %s\n", m.Status, m.Path)
+	}
+	if len(mismatches) > 0 {
+		fmt.Fprintf(os.Stderr, "%d of %d inputs differ from the manifest\n", len(mismatches), len(manifest.Inputs))
+		os.Exit(exitFindings)
+	}
+	fmt.Fprintf(os.Stderr, "All %d inputs match the manifest (analyzer %s, config %s)\n",
+		len(manifest.Inputs), manifest.AnalyzerVersion, shortDigest(manifest.ConfigDigest))
+}
+
+// shortDigest returns the first 12 characters of a hex digest
+func shortDigest(digest string) string {
+	if len(digest) > 12 {
+		return digest[:12]
+	}
+	return digest
+}
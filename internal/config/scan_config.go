@@ -18,6 +18,8 @@ type ScanOptions struct {
 	OutputFile  string `yaml:"output_file,omitempty" json:"output_file,omitempty" default:"stack-analysis.json"`
 	PrettyPrint bool   `yaml:"pretty,omitempty" json:"pretty,omitempty" default:"true"`
 	Aggregate   string `yaml:"aggregate,omitempty" json:"aggregate,omitempty" default:""`
+	Redact      string `yaml:"redact,omitempty" json:"redact,omitempty" default:""`
+	Telemetry   bool   `yaml:"telemetry,omitempty" json:"telemetry,omitempty" default:"false"`
 
 	// Scan behavior
 	ExcludePatterns          []string `yaml:"exclude_patterns,omitempty" json:"exclude_patterns,omitempty"`
@@ -30,6 +32,40 @@ type ScanOptions struct {
 	CodeStatsPerComponent    bool     `yaml:"component_code_stats,omitempty" json:"component_code_stats,omitempty" default:"false"`
 	PrimaryLanguageThreshold float64  `yaml:"primary_language_threshold,omitempty" json:"primary_language_threshold,omitempty" default:"0.05"`
 	UseLockFiles             *bool    `yaml:"use_lock_files,omitempty" json:"use_lock_files,omitempty"` // nil = default (true), explicit false disables
+	IncludeTransitive        bool     `yaml:"include_transitive,omitempty" json:"include_transitive,omitempty" default:"false"`
+	EnrichRegistry           bool     `yaml:"enrich_registry,omitempty" json:"enrich_registry,omitempty" default:"false"`
+	DataBundle               string   `yaml:"data_bundle,omitempty" json:"data_bundle,omitempty" default:""`
+	MavenProfiles            []string `yaml:"maven_profiles,omitempty" json:"maven_profiles,omitempty"`
+	DetectorCacheDir         string   `yaml:"detector_cache_dir,omitempty" json:"detector_cache_dir,omitempty" default:""`
+	NestedRepos              string   `yaml:"nested_repos,omitempty" json:"nested_repos,omitempty" default:"component"`
+	Symlinks                 string   `yaml:"symlinks,omitempty" json:"symlinks,omitempty" default:"skip"`
+	TargetEnv                string   `yaml:"target_env,omitempty" json:"target_env,omitempty" default:""`
+	ProdOnly                 bool     `yaml:"prod_only,omitempty" json:"prod_only,omitempty" default:"false"`
+	ImageArchive             string   `yaml:"image_archive,omitempty" json:"image_archive,omitempty" default:""`
+
+	// Analysis settings
+	ComplexityThresholds *ComplexityThresholds `yaml:"complexity_thresholds,omitempty" json:"complexity_thresholds,omitempty"`
+	AttributionsFile     string                `yaml:"attributions_file,omitempty" json:"attributions_file,omitempty" default:""`
+	SARIFFile            string                `yaml:"sarif_file,omitempty" json:"sarif_file,omitempty" default:""`
+	SuggestionsFile      string                `yaml:"suggestions_file,omitempty" json:"suggestions_file,omitempty" default:""`
+	AttestationFile      string                `yaml:"attestation_file,omitempty" json:"attestation_file,omitempty" default:""`
+	ReproManifestFile    string                `yaml:"repro_manifest_file,omitempty" json:"repro_manifest_file,omitempty" default:""`
+	ExceptionsFile       string                `yaml:"exceptions_file,omitempty" json:"exceptions_file,omitempty" default:""`
+	FailOn               string                `yaml:"fail_on,omitempty" json:"fail_on,omitempty" default:""`
+	NotifyOn             string                `yaml:"notify_on,omitempty" json:"notify_on,omitempty" default:"always"`
+	JiraURL              string                `yaml:"jira_url,omitempty" json:"jira_url,omitempty" default:""`
+	JiraProject          string                `yaml:"jira_project,omitempty" json:"jira_project,omitempty" default:""`
+	JiraIssueType        string                `yaml:"jira_issue_type,omitempty" json:"jira_issue_type,omitempty" default:"Task"`
+	TicketsOn            string                `yaml:"tickets_on,omitempty" json:"tickets_on,omitempty" default:"error"`
+}
+
+// ComplexityThresholds are the limits above which the dependency complexity analysis flags a
+// component (0 = no limit)
+type ComplexityThresholds struct {
+	MaxDirectDependencies     int `yaml:"max_direct_dependencies,omitempty" json:"max_direct_dependencies,omitempty"`
+	MaxTransitiveDependencies int `yaml:"max_transitive_dependencies,omitempty" json:"max_transitive_dependencies,omitempty"`
+	MaxEcosystems             int `yaml:"max_ecosystems,omitempty" json:"max_ecosystems,omitempty"`
+	MaxScore                  int `yaml:"max_score,omitempty" json:"max_score,omitempty"`
 }
 
 // ScanConfigFile represents the external scan configuration file
@@ -7,15 +7,23 @@ import (
 	"strings"
 
 	"log/slog"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/query"
+	"github.com/petrarca/tech-stack-analyzer/internal/redact"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
 )
 
 // Settings holds all scanner configuration
 // Field names match ScanOptions for reflection-based merging
 type Settings struct {
 	// Output settings
-	OutputFile  string
-	PrettyPrint bool
-	Aggregate   string
+	OutputFile    string
+	PrettyPrint   bool
+	Aggregate     string
+	Query         string // Optional: write only the records matching a query (deps[type=npm][scope=prod])
+	Redact        string // Optional: redaction categories for shared reports (paths, usernames, registries, emails, all)
+	Telemetry     bool   // Write only an anonymized, aggregate-only summary for fleet analytics (opt-in)
+	TelemetrySalt string // Key of the dependency name hashes in the telemetry summary (environment only)
 
 	// Scan behavior
 	ExcludePatterns          []string
@@ -23,12 +31,51 @@ type Settings struct {
 	Debug                    bool
 	TraceTimings             bool
 	TraceRules               bool
+	Profiles                 []string // Runtime profiles to capture: cpu, mem, trace (flag only)
+	ProfileDir               string   // Directory the profiles are written to (flag only)
 	FilterRules              []string // Only use these rules (for debugging)
 	NoCodeStats              bool     // Disable code statistics (enabled by default)
 	CodeStatsPerComponent    bool     // Enable per-component code statistics (disabled by default)
 	RootID                   string   // Override random root ID for deterministic scans
 	PrimaryLanguageThreshold float64  // Minimum percentage for primary languages (default 0.05 = 5%)
 	UseLockFiles             bool     // Use lock files for dependency resolution (default true)
+	IncludeTransitive        bool     // Add the transitive dependencies of Cargo.lock and go.sum (default false: direct only)
+	EnrichRegistry           bool     // Query public package registries for upgrade advisories (disabled by default)
+	DataBundle               string   // Optional: read registry data from a data bundle instead of the network (implies EnrichRegistry)
+	TrustNpmrc               bool     // Use the credentials and environment variables of the scanned directories' .npmrc and .yarnrc.yml (flag only)
+	MavenProfiles            []string // Maven profiles to consider like "mvn -P" ("!id" deselects, "*" selects all)
+	DetectorCacheDir         string   // Optional: cache the results of expensive detectors in this directory between scans
+	NestedRepos              string   // Nested git repositories: component or skip (empty = component)
+	Symlinks                 string   // Links to directories inside the scan root: skip or component (empty = skip)
+	TargetEnv                string   // Environment the dependencies are evaluated for ("os=linux,arch=amd64"; empty = all dependencies)
+	ProdOnly                 bool     // Leave out dev, test, and build dependencies (runtime inventory)
+	ImageArchive             string   // Extracted docker save archive of the scanned root file system (layer attribution)
+	ChangedSince             string   // Rescan only what changed since this git ref (flag only, requires BaseResult)
+	BaseResult               string   // Full scan result the incremental rescan is merged into (flag only)
+
+	// Analysis
+	ComplexityThresholds *ComplexityThresholds // Limits that flag components in the complexity analysis (nil = report only)
+	AttributionsFile     string                // Optional: write third-party notices grouped by license
+	SARIFFile            string                // Optional: write findings as SARIF for code scanning
+	SuggestionsFile      string                // Optional: write machine-applicable manifest edits resolving findings
+	AttestationFile      string                // Optional: write an in-toto statement wrapping the output with the digests of the scanned tree
+	ReproManifestFile    string                // Optional: write a reproducibility manifest with the digests of the files the scan read
+	Fix                  bool                  // Apply the safe manifest edits to the scanned directory (flag only)
+	FixDryRun            bool                  // Print the diff of the safe manifest edits without writing them (flag only)
+	ExceptionsFile       string                // Optional: approved package versions whose findings are accepted risks
+	FailOn               string                // Finding levels and rule IDs that make the scan exit with code 1 (empty = never)
+
+	// Notifications
+	NotifyWebhook string // Optional: Slack or Teams webhook URL for a scan summary (flag or environment only)
+	NotifyOn      string // Minimum finding level that triggers the notification (always, note, warning, error; empty = always)
+
+	// Issue tracker tickets (Jira)
+	JiraURL       string // Optional: Jira site URL; tickets are opened when URL and project are set
+	JiraProject   string // Jira project key
+	JiraIssueType string // Issue type of new tickets (empty = Task)
+	JiraUser      string // Account email for Jira Cloud API tokens (environment only)
+	JiraToken     string // API token or personal access token (environment only)
+	TicketsOn     string // Minimum finding level that opens a ticket (note, warning, error; empty = error)
 
 	// Logging
 	LogLevel  slog.Level
@@ -55,6 +102,7 @@ func DefaultSettings() *Settings {
 		LogFile:                  "",
 		PrimaryLanguageThreshold: 0.05, // 5% threshold for primary languages
 		UseLockFiles:             true, // Lock files enabled by default
+		EnrichRegistry:           false,
 	}
 }
 
@@ -75,6 +123,18 @@ func LoadSettingsFromEnvironment() *Settings {
 		settings.Aggregate = aggregate
 	}
 
+	if redactCategories := os.Getenv("STACK_ANALYZER_REDACT"); redactCategories != "" {
+		settings.Redact = redactCategories
+	}
+
+	if telemetry := os.Getenv("STACK_ANALYZER_TELEMETRY"); telemetry != "" {
+		settings.Telemetry = strings.ToLower(telemetry) == "true"
+	}
+
+	if telemetrySalt := os.Getenv("STACK_ANALYZER_TELEMETRY_SALT"); telemetrySalt != "" {
+		settings.TelemetrySalt = telemetrySalt
+	}
+
 	if verbose := os.Getenv("STACK_ANALYZER_VERBOSE"); verbose != "" {
 		settings.Verbose = strings.ToLower(verbose) == "true"
 	}
@@ -131,6 +191,44 @@ func LoadSettingsFromEnvironment() *Settings {
 		settings.UseLockFiles = strings.ToLower(useLockFiles) != "false"
 	}
 
+	if includeTransitive := os.Getenv("STACK_ANALYZER_INCLUDE_TRANSITIVE"); includeTransitive != "" {
+		settings.IncludeTransitive = strings.ToLower(includeTransitive) == "true"
+	}
+
+	if enrichRegistry := os.Getenv("STACK_ANALYZER_ENRICH_REGISTRY"); enrichRegistry != "" {
+		settings.EnrichRegistry = strings.ToLower(enrichRegistry) == "true"
+	}
+
+	if mavenProfiles := os.Getenv("STACK_ANALYZER_MAVEN_PROFILES"); mavenProfiles != "" {
+		settings.MavenProfiles = strings.Split(mavenProfiles, ",")
+		for i, profile := range settings.MavenProfiles {
+			settings.MavenProfiles[i] = strings.TrimSpace(profile)
+		}
+	}
+
+	if failOn := os.Getenv("STACK_ANALYZER_FAIL_ON"); failOn != "" {
+		settings.FailOn = failOn
+	}
+
+	if notifyWebhook := os.Getenv("STACK_ANALYZER_NOTIFY_WEBHOOK"); notifyWebhook != "" {
+		settings.NotifyWebhook = notifyWebhook
+	}
+
+	if notifyOn := os.Getenv("STACK_ANALYZER_NOTIFY_ON"); notifyOn != "" {
+		settings.NotifyOn = strings.ToLower(notifyOn)
+	}
+
+	if jiraURL := os.Getenv("STACK_ANALYZER_JIRA_URL"); jiraURL != "" {
+		settings.JiraURL = jiraURL
+	}
+
+	if jiraProject := os.Getenv("STACK_ANALYZER_JIRA_PROJECT"); jiraProject != "" {
+		settings.JiraProject = jiraProject
+	}
+
+	settings.JiraUser = os.Getenv("STACK_ANALYZER_JIRA_USER")
+	settings.JiraToken = os.Getenv("STACK_ANALYZER_JIRA_TOKEN")
+
 	return settings
 }
 
@@ -210,5 +308,56 @@ func (s *Settings) Validate() error {
 		}
 	}
 
+	if s.Telemetry && (s.Aggregate != "" || s.Query != "") {
+		return fmt.Errorf("cannot use --telemetry with --aggregate or --query")
+	}
+
+	if s.Query != "" {
+		if s.Aggregate != "" {
+			return fmt.Errorf("cannot use both --query and --aggregate")
+		}
+		if _, err := query.Parse(s.Query); err != nil {
+			return fmt.Errorf("invalid query: %w", err)
+		}
+	}
+
+	if _, err := redact.ParseCategories(s.Redact); err != nil {
+		return err
+	}
+
+	switch s.NestedRepos {
+	case "", "component", "skip":
+	default:
+		return fmt.Errorf("invalid nested repository handling '%s'. Valid values: component, skip", s.NestedRepos)
+	}
+
+	switch s.Symlinks {
+	case "", "skip", "component":
+	default:
+		return fmt.Errorf("invalid symlink handling '%s'. Valid values: skip, component", s.Symlinks)
+	}
+
+	if _, err := parsers.ParseTargetEnvironment(s.TargetEnv); err != nil {
+		return err
+	}
+
+	switch s.NotifyOn {
+	case "", "always", "note", "warning", "error":
+	default:
+		return fmt.Errorf("invalid notify level '%s'. Valid levels: always, note, warning, error", s.NotifyOn)
+	}
+
+	switch s.TicketsOn {
+	case "", "note", "warning", "error":
+	default:
+		return fmt.Errorf("invalid tickets level '%s'. Valid levels: note, warning, error", s.TicketsOn)
+	}
+	if s.JiraURL != "" && s.JiraProject == "" {
+		return fmt.Errorf("--jira-url requires --jira-project")
+	}
+	if (s.ChangedSince == "") != (s.BaseResult == "") {
+		return fmt.Errorf("--changed-since and --base-result must be used together")
+	}
+
 	return nil
 }
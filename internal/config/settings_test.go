@@ -164,6 +164,96 @@ func TestValidate_AlwaysReturnsNil(t *testing.T) {
 	assert.NoError(t, err, "Validate should always return nil for now")
 }
 
+func TestValidate_NotifyOn(t *testing.T) {
+	settings := DefaultSettings()
+	for _, level := range []string{"", "always", "note", "warning", "error"} {
+		settings.NotifyOn = level
+		assert.NoError(t, settings.Validate(), level)
+	}
+
+	settings.NotifyOn = "critical"
+	assert.Error(t, settings.Validate())
+}
+
+func TestValidate_Tickets(t *testing.T) {
+	settings := DefaultSettings()
+	settings.TicketsOn = "warning"
+	assert.NoError(t, settings.Validate())
+
+	settings.TicketsOn = "always"
+	assert.Error(t, settings.Validate(), "tickets need a finding level")
+
+	settings.TicketsOn = ""
+	settings.JiraURL = "https://acme.atlassian.net"
+	assert.Error(t, settings.Validate(), "jira url without project")
+
+	settings.JiraProject = "SEC"
+	assert.NoError(t, settings.Validate())
+}
+
+func TestValidate_ChangedSince(t *testing.T) {
+	settings := DefaultSettings()
+	settings.ChangedSince = "origin/main"
+	assert.ErrorContains(t, settings.Validate(), "must be used together")
+
+	settings.BaseResult = "base.json"
+	assert.NoError(t, settings.Validate())
+
+	settings.ChangedSince = ""
+	assert.Error(t, settings.Validate(), "base result without ref")
+}
+
+func TestValidate_Query(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Query = "deps[type=npm][license~GPL]"
+	assert.NoError(t, settings.Validate())
+
+	settings.Query = "deps[type=npm"
+	assert.ErrorContains(t, settings.Validate(), "invalid query")
+
+	settings.Query = "deps"
+	settings.Aggregate = "techs"
+	assert.Error(t, settings.Validate(), "query and aggregate are exclusive")
+}
+
+func TestValidate_Redact(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Redact = "paths,emails"
+	assert.NoError(t, settings.Validate())
+
+	settings.Redact = "paths,tokens"
+	assert.Error(t, settings.Validate())
+}
+
+func TestValidate_Telemetry(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Telemetry = true
+	assert.NoError(t, settings.Validate())
+
+	settings.Aggregate = "techs"
+	assert.Error(t, settings.Validate(), "telemetry and aggregate are exclusive")
+
+	settings.Aggregate = ""
+	settings.Query = "deps"
+	assert.Error(t, settings.Validate(), "telemetry and query are exclusive")
+}
+
+func TestLoadSettingsFromEnvironment_Telemetry(t *testing.T) {
+	t.Setenv("STACK_ANALYZER_TELEMETRY", "true")
+	t.Setenv("STACK_ANALYZER_TELEMETRY_SALT", "fleet-salt")
+
+	settings := LoadSettingsFromEnvironment()
+	assert.True(t, settings.Telemetry)
+	assert.Equal(t, "fleet-salt", settings.TelemetrySalt)
+}
+
+func TestLoadSettingsFromEnvironment_MavenProfiles(t *testing.T) {
+	t.Setenv("STACK_ANALYZER_MAVEN_PROFILES", "release, !integration-tests")
+
+	settings := LoadSettingsFromEnvironment()
+	assert.Equal(t, []string{"release", "!integration-tests"}, settings.MavenProfiles)
+}
+
 // Helper function to clear environment variables
 func clearEnvVars() {
 	envVars := []string{
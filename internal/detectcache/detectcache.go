@@ -0,0 +1,275 @@
+// Package detectcache persists the components found by expensive component detectors between
+// scans. Entries are keyed by the detector name and version, the detection context (analyzer
+// build, rules, and detection settings), the directory, and its file listing. An entry holds
+// the digests of the inputs the detector used (file contents, directory listings, existence
+// checks) and is used only while all of them are unchanged.
+package detectcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Kinds of recorded inputs
+const (
+	InputFile   = "file"   // File content
+	InputDir    = "dir"    // Directory listing (names and types)
+	InputExists = "exists" // Existence of a path
+	InputIsDir  = "isdir"  // Whether a path is a directory
+)
+
+// absent is the digest of an input that could not be read
+const absent = "-"
+
+// Input is an input of a detector and the digest of its state
+type Input struct {
+	Kind   string `json:"kind"`
+	Path   string `json:"path"`
+	Digest string `json:"digest"`
+}
+
+type entry struct {
+	Inputs     []Input          `json:"inputs"`
+	Components []*types.Payload `json:"components"`
+}
+
+// Cache stores detector results in a directory, one JSON file per entry
+type Cache struct {
+	dir     string
+	context string
+
+	mu     sync.Mutex
+	hits   int
+	stored int
+}
+
+// New creates a cache in dir (created if missing) for the detection context, a digest of
+// everything besides the files that shapes detector results (see Context)
+func New(dir, context string) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create detector cache: %w", err)
+	}
+	return &Cache{dir: dir, context: context}, nil
+}
+
+// Context returns the sha256 digest of the JSON encoding of values shaping detector results
+func Context(values ...interface{}) (string, error) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return digest(data), nil
+}
+
+// Key returns the key of the results of a detector version for a directory and its files
+func (c *Cache) Key(detector string, version int, dir string, files []types.File) string {
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		names = append(names, fmt.Sprintf("%s\x00%s\x00%d", file.Name, file.Type, file.Size))
+	}
+	sort.Strings(names)
+	return digest([]byte(fmt.Sprintf("%s\n%d\n%s\n%s\n%s", detector, version, c.context, dir, strings.Join(names, "\n"))))
+}
+
+// Get returns the cached components of key if all their inputs are unchanged. Files are read
+// through the provider, so a recording provider sees the same reads as without the cache.
+func (c *Cache) Get(key string, provider types.Provider) ([]*types.Payload, bool) {
+	cached, ok := c.read(key)
+	if ok {
+		for _, input := range cached.Inputs {
+			if state(provider, input.Kind, input.Path) != input.Digest {
+				ok = false
+				break
+			}
+		}
+	}
+
+	if !ok {
+		return nil, false
+	}
+	c.mu.Lock()
+	c.hits++
+	c.mu.Unlock()
+	return cached.Components, true
+}
+
+// Put stores the components detected for key with the inputs recorded while detecting them.
+// Components are stored before the scanner modifies them.
+func (c *Cache) Put(key string, recorder *Recorder, components []*types.Payload) error {
+	data, err := json.Marshal(entry{Inputs: recorder.Inputs(), Components: components})
+	if err != nil {
+		return err
+	}
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	// Write to a temporary file first, so concurrent scans never read a partial entry
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.stored++
+	c.mu.Unlock()
+	return nil
+}
+
+// Stats returns the numbers of results read from the cache and stored in it
+func (c *Cache) Stats() (hits, stored int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.stored
+}
+
+func (c *Cache) read(key string) (*entry, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var cached entry
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, false
+	}
+	return &cached, true
+}
+
+// path returns the file of an entry, in subdirectories by the first two key characters
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key[:2], key+".json")
+}
+
+// Recorder wraps a provider and records the inputs read through it
+type Recorder struct {
+	types.Provider
+	inputs map[string]Input
+}
+
+// NewRecorder creates a recorder of the inputs read through p
+func NewRecorder(p types.Provider) *Recorder {
+	return &Recorder{Provider: p, inputs: make(map[string]Input)}
+}
+
+// ListDir returns the contents of a directory and records its listing
+func (r *Recorder) ListDir(path string) ([]types.File, error) {
+	files, err := r.Provider.ListDir(path)
+	r.record(InputDir, path, listingDigest(files, err))
+	return files, err
+}
+
+// Open returns the content of a file and records its digest
+func (r *Recorder) Open(path string) (string, error) {
+	content, err := r.Provider.Open(path)
+	r.record(InputFile, path, contentDigest([]byte(content), err))
+	return content, err
+}
+
+// Exists checks if a path exists and records the result
+func (r *Recorder) Exists(path string) (bool, error) {
+	exists, err := r.Provider.Exists(path)
+	r.record(InputExists, path, boolDigest(exists, err))
+	return exists, err
+}
+
+// IsDir checks if a path is a directory and records the result
+func (r *Recorder) IsDir(path string) (bool, error) {
+	isDir, err := r.Provider.IsDir(path)
+	r.record(InputIsDir, path, boolDigest(isDir, err))
+	return isDir, err
+}
+
+// ReadFile reads file content as bytes and records its digest
+func (r *Recorder) ReadFile(path string) ([]byte, error) {
+	content, err := r.Provider.ReadFile(path)
+	r.record(InputFile, path, contentDigest(content, err))
+	return content, err
+}
+
+// Inputs returns the recorded inputs sorted by path and kind
+func (r *Recorder) Inputs() []Input {
+	inputs := make([]Input, 0, len(r.inputs))
+	for _, input := range r.inputs {
+		inputs = append(inputs, input)
+	}
+	sort.Slice(inputs, func(i, j int) bool {
+		if inputs[i].Path != inputs[j].Path {
+			return inputs[i].Path < inputs[j].Path
+		}
+		return inputs[i].Kind < inputs[j].Kind
+	})
+	return inputs
+}
+
+func (r *Recorder) record(kind, path, digest string) {
+	r.inputs[kind+"\x00"+path] = Input{Kind: kind, Path: path, Digest: digest}
+}
+
+// state returns the current digest of an input
+func state(provider types.Provider, kind, path string) string {
+	switch kind {
+	case InputFile:
+		content, err := provider.ReadFile(path)
+		return contentDigest(content, err)
+	case InputDir:
+		files, err := provider.ListDir(path)
+		return listingDigest(files, err)
+	case InputExists:
+		return boolDigest(provider.Exists(path))
+	case InputIsDir:
+		return boolDigest(provider.IsDir(path))
+	default:
+		return absent
+	}
+}
+
+func contentDigest(content []byte, err error) string {
+	if err != nil {
+		return absent
+	}
+	return digest(content)
+}
+
+func listingDigest(files []types.File, err error) string {
+	if err != nil {
+		return absent
+	}
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		names = append(names, file.Name+"\x00"+file.Type)
+	}
+	sort.Strings(names)
+	return digest([]byte(strings.Join(names, "\n")))
+}
+
+func boolDigest(value bool, err error) string {
+	if err != nil {
+		return absent
+	}
+	return fmt.Sprint(value)
+}
+
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,87 @@
+package detectcache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/provider"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_GetPut(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	write("go.mod", "module app\n")
+	write("cmd/main.go", "package main\n")
+
+	cache, err := New(t.TempDir(), "ctx")
+	require.NoError(t, err)
+	p := provider.NewFSProvider(dir)
+	files, err := p.ListDir(dir)
+	require.NoError(t, err)
+	key := cache.Key("golang", 1, dir, files)
+
+	_, ok := cache.Get(key, p)
+	assert.False(t, ok)
+
+	// Detect: read go.mod, list cmd, check for a missing go.work
+	recorder := NewRecorder(p)
+	_, err = recorder.ReadFile(filepath.Join(dir, "go.mod"))
+	require.NoError(t, err)
+	_, err = recorder.ListDir(filepath.Join(dir, "cmd"))
+	require.NoError(t, err)
+	_, err = recorder.ReadFile(filepath.Join(dir, "go.work"))
+	require.Error(t, err)
+	component := types.NewPayloadWithPath("app", "/go.mod")
+	component.AddTech("golang", "matched file: go.mod")
+	require.NoError(t, cache.Put(key, recorder, []*types.Payload{component}))
+
+	cached, ok := cache.Get(key, p)
+	require.True(t, ok)
+	require.Len(t, cached, 1)
+	assert.Equal(t, "app", cached[0].Name)
+	assert.Equal(t, []string{"golang"}, cached[0].Techs)
+
+	write("cmd/util.go", "package main\n")
+	_, ok = cache.Get(key, p)
+	assert.False(t, ok, "listed directory changed")
+	require.NoError(t, os.Remove(filepath.Join(dir, "cmd", "util.go")))
+	_, ok = cache.Get(key, p)
+	assert.True(t, ok)
+
+	write("go.work", "go 1.22\n")
+	_, ok = cache.Get(key, p)
+	assert.False(t, ok, "missing file created")
+	require.NoError(t, os.Remove(filepath.Join(dir, "go.work")))
+
+	write("go.mod", "module app2\n")
+	_, ok = cache.Get(key, p)
+	assert.False(t, ok, "file content changed")
+
+	hits, stored := cache.Stats()
+	assert.Equal(t, 2, hits)
+	assert.Equal(t, 1, stored)
+}
+
+func TestCache_Key(t *testing.T) {
+	cache, err := New(t.TempDir(), "ctx")
+	require.NoError(t, err)
+	files := []types.File{{Name: "go.mod", Type: "file", Size: 10}, {Name: "cmd", Type: "dir"}}
+	key := cache.Key("golang", 1, "/src", files)
+
+	reordered := []types.File{files[1], files[0]}
+	assert.Equal(t, key, cache.Key("golang", 1, "/src", reordered), "file order does not matter")
+	assert.NotEqual(t, key, cache.Key("golang", 2, "/src", files), "detector version")
+	assert.NotEqual(t, key, cache.Key("golang", 1, "/other", files), "directory")
+	assert.NotEqual(t, key, cache.Key("golang", 1, "/src", files[:1]), "file listing")
+
+	other, err := New(t.TempDir(), "other")
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other.Key("golang", 1, "/src", files), "context")
+}
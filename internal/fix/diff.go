@@ -0,0 +1,153 @@
+package fix
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines around the changes of a hunk
+const diffContext = 3
+
+// maxDiffCells limits the line comparison table; larger changes are shown as a replacement
+// of the whole changed range
+const maxDiffCells = 4_000_000
+
+// diffLine is a line of a diff: ' ' unchanged, '-' removed, '+' added
+type diffLine struct {
+	kind byte
+	text string
+}
+
+// UnifiedDiff returns the unified diff of a change (git style, a/ and b/ prefixes), or ""
+// if the content is unchanged
+func UnifiedDiff(change Change) string {
+	before, after := splitLines(string(change.Before)), splitLines(string(change.After))
+	lines := diffLines(before, after)
+
+	var b strings.Builder
+	for i := 0; i < len(lines); {
+		if lines[i].kind == ' ' {
+			i++
+			continue
+		}
+		if b.Len() == 0 {
+			fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", change.File, change.File)
+		}
+
+		// Extend the hunk while the unchanged lines between changes fit into its context
+		start, last := max(i-diffContext, 0), i
+		for j := i; j < len(lines) && j-last <= 2*diffContext; j++ {
+			if lines[j].kind != ' ' {
+				last = j
+			}
+		}
+		stop := min(last+diffContext+1, len(lines))
+
+		beforeStart, afterStart := 1, 1
+		for _, line := range lines[:start] {
+			if line.kind != '+' {
+				beforeStart++
+			}
+			if line.kind != '-' {
+				afterStart++
+			}
+		}
+		beforeCount, afterCount := 0, 0
+		for _, line := range lines[start:stop] {
+			if line.kind != '+' {
+				beforeCount++
+			}
+			if line.kind != '-' {
+				afterCount++
+			}
+		}
+		if beforeCount == 0 {
+			beforeStart--
+		}
+		if afterCount == 0 {
+			afterStart--
+		}
+
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", beforeStart, beforeCount, afterStart, afterCount)
+		for _, line := range lines[start:stop] {
+			b.WriteByte(line.kind)
+			b.WriteString(line.text)
+			if !strings.HasSuffix(line.text, "\n") {
+				b.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = stop
+	}
+	return b.String()
+}
+
+// diffLines returns the lines of before and after as unchanged, removed, and added lines,
+// based on their longest common subsequence
+func diffLines(before, after []string) []diffLine {
+	// Common prefix and suffix are unchanged
+	prefix := 0
+	for prefix < len(before) && prefix < len(after) && before[prefix] == after[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(before)-prefix && suffix < len(after)-prefix && before[len(before)-1-suffix] == after[len(after)-1-suffix] {
+		suffix++
+	}
+
+	var lines []diffLine
+	for _, text := range before[:prefix] {
+		lines = append(lines, diffLine{' ', text})
+	}
+	a, b := before[prefix:len(before)-suffix], after[prefix:len(after)-suffix]
+	if len(a)*len(b) > maxDiffCells {
+		for _, text := range a {
+			lines = append(lines, diffLine{'-', text})
+		}
+		for _, text := range b {
+			lines = append(lines, diffLine{'+', text})
+		}
+	} else {
+		// common[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+		common := make([][]int, len(a)+1)
+		for i := range common {
+			common[i] = make([]int, len(b)+1)
+		}
+		for i := len(a) - 1; i >= 0; i-- {
+			for j := len(b) - 1; j >= 0; j-- {
+				if a[i] == b[j] {
+					common[i][j] = common[i+1][j+1] + 1
+				} else {
+					common[i][j] = max(common[i+1][j], common[i][j+1])
+				}
+			}
+		}
+		i, j := 0, 0
+		for i < len(a) || j < len(b) {
+			switch {
+			case i < len(a) && j < len(b) && a[i] == b[j]:
+				lines = append(lines, diffLine{' ', a[i]})
+				i++
+				j++
+			case j == len(b) || (i < len(a) && common[i+1][j] >= common[i][j+1]):
+				lines = append(lines, diffLine{'-', a[i]})
+				i++
+			default:
+				lines = append(lines, diffLine{'+', b[j]})
+				j++
+			}
+		}
+	}
+	for _, text := range before[len(before)-suffix:] {
+		lines = append(lines, diffLine{' ', text})
+	}
+	return lines
+}
+
+// splitLines splits content into lines keeping their line breaks
+func splitLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,34 @@
+package fix
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	before := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n16\n"
+	after := strings.Replace(strings.Replace(before, "2\n", "two\n", 1), "15\n", "", 1)
+
+	assert.Equal(t, `--- a/web/package.json
++++ b/web/package.json
+@@ -1,5 +1,5 @@
+ 1
+-2
++two
+ 3
+ 4
+ 5
+@@ -12,5 +12,4 @@
+ 12
+ 13
+ 14
+-15
+ 16
+`, UnifiedDiff(Change{File: "web/package.json", Before: []byte(before), After: []byte(after)}))
+
+	assert.Equal(t, "--- a/a.json\n+++ b/a.json\n@@ -1,1 +1,1 @@\n-{}\n\\ No newline at end of file\n+{}\n",
+		UnifiedDiff(Change{File: "a.json", Before: []byte("{}"), After: []byte("{}\n")}))
+	assert.Empty(t, UnifiedDiff(Change{File: "a.json", Before: []byte("{}\n"), After: []byte("{}\n")}))
+}
@@ -0,0 +1,268 @@
+// Package fix plans safe mechanical edits of the manifests of a scanned directory: wildcard
+// constraints pinned to the version resolved by the lock file, license strings replaced by
+// their SPDX identifier, and sorted dependency blocks. Edits are computed from the scan
+// payload and the manifest contents read through a provider; writing them is left to the
+// caller, so they can be previewed as a diff first.
+package fix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/analysis"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// sortedSections are the dependency sections of package.json whose entries are sorted
+var sortedSections = map[string]bool{
+	"dependencies": true, "devDependencies": true, "peerDependencies": true, "optionalDependencies": true,
+}
+
+// licenseFieldRegex matches the line of a license field of package.json, composer.json,
+// Cargo.toml, or pyproject.toml, capturing the quoted value
+var licenseFieldRegex = regexp.MustCompile(`^\s*"?license"?\s*[:=]\s*("(?:[^"\\]|\\.)*")\s*,?\s*$`)
+
+// entryRegex matches a single-line "name": "constraint" entry of a dependency section
+var entryRegex = regexp.MustCompile(`^(\s*)("(?:[^"\\]|\\.)+"\s*:\s*"(?:[^"\\]|\\.)*")\s*,?\s*$`)
+
+// Change is the edit of one manifest
+type Change struct {
+	File    string   // Manifest path relative to the scanned directory
+	Before  []byte   // Content read from the provider
+	After   []byte   // Content with the edits applied
+	Edits   []string // Descriptions of the applied edits
+	Skipped []string // Edits left out, with the reason
+}
+
+// Plan returns the changes of the manifests of the payload tree: the safe suggestions of
+// analysis.BuildSuggestions, and the dependency sections of package.json sorted by name.
+// Manifests are read through the provider with paths relative to the scanned directory.
+// Suggestions whose old text is no longer found on their line are skipped; suggestions
+// without a line are located on the line of the manifest field they edit. Manifests with
+// CRLF line endings are not sorted, which the change reports in Skipped. Changes are sorted
+// by file.
+func Plan(payload *types.Payload, provider types.Provider) ([]Change, error) {
+	if payload == nil {
+		return nil, nil
+	}
+
+	suggestions := make(map[string][]analysis.Suggestion)
+	for _, suggestion := range analysis.BuildSuggestions(payload) {
+		if suggestion.Safe {
+			suggestions[suggestion.File] = append(suggestions[suggestion.File], suggestion)
+		}
+	}
+	files := make(map[string]bool)
+	for file := range suggestions {
+		files[file] = true
+	}
+	collectPackageJSON(payload, files)
+
+	var changes []Change
+	for file := range files {
+		if !filepath.IsLocal(filepath.FromSlash(file)) {
+			continue // Manifests are edited inside the scanned directory only
+		}
+		content, err := provider.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		change := Change{File: file, Before: content, After: content}
+		for _, suggestion := range suggestions[file] {
+			if suggestion.Line <= 0 {
+				suggestion.Line = fieldLine(change.After, suggestion)
+			}
+			if edited, ok := applySuggestion(change.After, suggestion); ok {
+				change.After = edited
+				change.Edits = append(change.Edits, suggestion.Description)
+			}
+		}
+		if path.Base(file) == "package.json" && bytes.Contains(change.After, []byte("\r")) {
+			change.Skipped = append(change.Skipped, "Sort dependency sections: CRLF line endings are not supported")
+		} else if path.Base(file) == "package.json" {
+			sorted, sections := SortDependencySections(change.After)
+			change.After = sorted
+			for _, section := range sections {
+				change.Edits = append(change.Edits, "Sort "+section+" by name")
+			}
+		}
+		if !bytes.Equal(change.Before, change.After) || len(change.Skipped) > 0 {
+			changes = append(changes, change)
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].File < changes[j].File })
+	return changes, nil
+}
+
+// SortDependencySections sorts the entries of the dependency sections of package.json by
+// name, keeping the indentation of each entry. Sections are only sorted when every entry is
+// a single "name": "constraint" line, so comments or unusual formatting are never rewritten.
+// Returns the content and the names of the sorted sections.
+func SortDependencySections(content []byte) ([]byte, []string) {
+	if bytes.Contains(content, []byte("\r")) {
+		return content, nil
+	}
+
+	type section struct {
+		name       string
+		start, end int64
+	}
+	var sections []section
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return content, nil
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return content, nil
+		}
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return content, nil
+		}
+		if name, _ := token.(string); sortedSections[name] {
+			end := decoder.InputOffset()
+			sections = append(sections, section{name: name, start: end - int64(len(raw)), end: end})
+		}
+	}
+
+	// Replace from the end, so the offsets of earlier sections stay valid
+	var sorted []string
+	for i := len(sections) - 1; i >= 0; i-- {
+		s := sections[i]
+		replacement, ok := sortSection(string(content[s.start:s.end]))
+		if !ok {
+			continue
+		}
+		edited := make([]byte, 0, len(content))
+		edited = append(edited, content[:s.start]...)
+		edited = append(edited, replacement...)
+		content = append(edited, content[s.end:]...)
+		sorted = append([]string{s.name}, sorted...)
+	}
+	return content, sorted
+}
+
+// sortSection sorts the entry lines of a dependency section object. Returns false if the
+// object is not one entry per line or is already sorted.
+func sortSection(text string) (string, bool) {
+	open := strings.Index(text, "\n")
+	closing := strings.LastIndex(text, "\n")
+	if open < 0 || open == closing || strings.TrimSpace(text[:open]) != "{" || strings.TrimSpace(text[closing:]) != "}" {
+		return "", false
+	}
+
+	type entry struct{ indent, name, body string }
+	var entries []entry
+	for _, line := range strings.Split(text[open+1:closing], "\n") {
+		match := entryRegex.FindStringSubmatch(line)
+		if match == nil {
+			return "", false
+		}
+		name := match[2][:strings.Index(match[2][1:], "\"")+2]
+		entries = append(entries, entry{indent: match[1], name: name, body: match[2]})
+	}
+	if sort.SliceIsSorted(entries, func(i, j int) bool { return entries[i].name < entries[j].name }) {
+		return "", false
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+
+	var b strings.Builder
+	b.WriteString(text[:open+1])
+	for i, e := range entries {
+		b.WriteString(e.indent + e.body)
+		if i < len(entries)-1 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(text[closing+1:])
+	return b.String(), true
+}
+
+// applySuggestion replaces the old text of a suggestion on its line. Suggestions without a
+// line are refused, so text matching elsewhere in the manifest is never edited.
+func applySuggestion(content []byte, suggestion analysis.Suggestion) ([]byte, bool) {
+	old, replacement := []byte(suggestion.OldText), []byte(suggestion.NewText)
+	lines := bytes.SplitAfter(content, []byte("\n"))
+	if suggestion.Line <= 0 || suggestion.Line > len(lines) || !bytes.Contains(lines[suggestion.Line-1], old) {
+		return content, false
+	}
+	lines[suggestion.Line-1] = bytes.Replace(lines[suggestion.Line-1], old, replacement, 1)
+	return bytes.Join(lines, nil), true
+}
+
+// fieldLine returns the line of the manifest field a suggestion without line edits: the only
+// line holding its old text as the value of the field ("license": "MIT", license = "MIT").
+// Returns 0 for other suggestions, or if no line or several lines match.
+func fieldLine(content []byte, suggestion analysis.Suggestion) int {
+	if suggestion.Kind != analysis.SuggestionSPDXLicense {
+		return 0
+	}
+	found := 0
+	for i, line := range strings.Split(string(content), "\n") {
+		match := licenseFieldRegex.FindStringSubmatch(line)
+		if match == nil || match[1] != suggestion.OldText {
+			continue
+		}
+		if found > 0 {
+			return 0
+		}
+		found = i + 1
+	}
+	return found
+}
+
+// Target returns the path of the manifest of a change inside the scanned directory root.
+// Returns an error if the manifest is a symbolic link or resolves outside the root, so
+// writing a fix never follows links out of the scanned tree.
+func Target(root, file string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(file)) {
+		return "", fmt.Errorf("%s is outside the scanned directory", file)
+	}
+	target := filepath.Join(root, filepath.FromSlash(file))
+	info, err := os.Lstat(target)
+	if err != nil {
+		return "", err
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		return "", fmt.Errorf("%s is a symbolic link", file)
+	}
+	if !info.Mode().IsRegular() {
+		return "", fmt.Errorf("%s is not a regular file", file)
+	}
+	resolvedRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", err
+	}
+	resolved, err := filepath.EvalSymlinks(target)
+	if err != nil {
+		return "", err
+	}
+	if rel, err := filepath.Rel(resolvedRoot, resolved); err != nil || !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("%s resolves outside the scanned directory", file)
+	}
+	return target, nil
+}
+
+// collectPackageJSON adds the package.json manifests of the payload tree to files
+func collectPackageJSON(payload *types.Payload, files map[string]bool) {
+	for _, p := range payload.Path {
+		if path.Base(p) == "package.json" {
+			files[strings.TrimPrefix(p, "/")] = true
+		}
+	}
+	for _, child := range payload.Children {
+		collectPackageJSON(child, files)
+	}
+}
@@ -0,0 +1,187 @@
+package fix
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/analysis"
+	"github.com/petrarca/tech-stack-analyzer/internal/provider"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const packageJSON = `{
+  "name": "web",
+  "license": "apache-2.0",
+  "dependencies": {
+    "react": "^18.2.0",
+    "lodash": "*"
+  },
+  "devDependencies": {
+    "eslint": "^8.0.0",
+    "jest": "^29.0.0"
+  }
+}
+`
+
+func TestPlan(t *testing.T) {
+	metadata := map[string]interface{}{parsers.MetadataFile: "/web/package.json", parsers.MetadataLine: 6}
+	parsers.AppendOrigin(metadata, parsers.MetadataSourcePackageJSON, parsers.OriginFieldRange, "*")
+
+	root := types.NewPayloadWithPath("main", "/")
+	web := types.NewPayloadWithPath("web", "/web/package.json")
+	web.Licenses = []types.License{{LicenseName: "Apache-2.0", DetectionType: "normalized", SourceFile: "package.json", OriginalLicense: "apache-2.0"}}
+	web.Dependencies = []types.Dependency{{Type: "npm", Name: "lodash", Version: "4.17.21", Direct: true, Metadata: metadata}}
+	root.Children = []*types.Payload{web}
+
+	fs := provider.NewFakeProvider()
+	fs.AddFile("web/package.json", packageJSON)
+
+	changes, err := Plan(root, fs)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "web/package.json", changes[0].File)
+	assert.Equal(t, `{
+  "name": "web",
+  "license": "Apache-2.0",
+  "dependencies": {
+    "lodash": "4.17.21",
+    "react": "^18.2.0"
+  },
+  "devDependencies": {
+    "eslint": "^8.0.0",
+    "jest": "^29.0.0"
+  }
+}
+`, string(changes[0].After))
+	assert.Equal(t, []string{
+		`Use the SPDX identifier Apache-2.0 for the license "apache-2.0"`,
+		"Pin lodash to 4.17.21, the version resolved by the lock file",
+		"Sort dependencies by name",
+	}, changes[0].Edits)
+
+	changes, err = Plan(root, fs)
+	require.NoError(t, err)
+	assert.Len(t, changes, 1, "planning does not write")
+
+	changes, err = Plan(nil, fs)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestPlan_OutsideScannedDirectory(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/../package.json")
+	fs := provider.NewFakeProvider()
+	fs.AddFile("../package.json", packageJSON)
+
+	changes, err := Plan(root, fs)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestPlan_CRLF(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/package.json")
+	fs := provider.NewFakeProvider()
+	fs.AddFile("package.json", "{\r\n  \"dependencies\": {\r\n    \"b\": \"1\",\r\n    \"a\": \"2\"\r\n  }\r\n}\r\n")
+
+	changes, err := Plan(root, fs)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, changes[0].Before, changes[0].After, "CRLF manifests are not sorted")
+	assert.Equal(t, []string{"Sort dependency sections: CRLF line endings are not supported"}, changes[0].Skipped)
+	assert.Empty(t, UnifiedDiff(changes[0]))
+}
+
+func TestApplySuggestion(t *testing.T) {
+	content := []byte("{\n  \"files\": [\"*\"],\n  \"lodash\": \"*\"\n}\n")
+	pin := analysis.Suggestion{OldText: `"*"`, NewText: `"4.17.21"`, Line: 3}
+
+	edited, ok := applySuggestion(content, pin)
+	assert.True(t, ok)
+	assert.Equal(t, "{\n  \"files\": [\"*\"],\n  \"lodash\": \"4.17.21\"\n}\n", string(edited))
+
+	for _, line := range []int{0, -1, 9} {
+		pin.Line = line
+		edited, ok = applySuggestion(content, pin)
+		assert.False(t, ok, "line %d", line)
+		assert.Equal(t, content, edited)
+	}
+}
+
+func TestFieldLine(t *testing.T) {
+	license := analysis.Suggestion{Kind: analysis.SuggestionSPDXLicense, OldText: `"mit"`}
+	assert.Equal(t, 3, fieldLine([]byte("{\n  \"keywords\": [\"mit\"],\n  \"license\": \"mit\"\n}\n"), license))
+	assert.Equal(t, 2, fieldLine([]byte("[package]\nlicense = \"mit\"\n"), license))
+	assert.Zero(t, fieldLine([]byte("{\n  \"license\": \"mit\",\n  \"x\": {\n    \"license\": \"mit\"\n  }\n}\n"), license), "ambiguous")
+	assert.Zero(t, fieldLine([]byte("{\"license\": \"mit\", \"name\": \"a\"}"), license))
+	assert.Zero(t, fieldLine([]byte("{\n  \"license\": \"mit\"\n}\n"), analysis.Suggestion{OldText: `"mit"`}))
+}
+
+func TestTarget(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "package.json"), []byte("{}"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(outside, "package.json"), []byte("{}"), 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(root, "web"), 0755))
+	require.NoError(t, os.Symlink(filepath.Join(outside, "package.json"), filepath.Join(root, "web", "package.json")))
+	require.NoError(t, os.Symlink(outside, filepath.Join(root, "linked")))
+
+	target, err := Target(root, "package.json")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "package.json"), target)
+
+	_, err = Target(root, "web/package.json")
+	assert.ErrorContains(t, err, "symbolic link")
+	_, err = Target(root, "linked/package.json")
+	assert.ErrorContains(t, err, "outside the scanned directory")
+	_, err = Target(root, "../package.json")
+	assert.ErrorContains(t, err, "outside the scanned directory")
+	_, err = Target(root, "missing/package.json")
+	assert.Error(t, err)
+}
+
+func TestSortDependencySections(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		want     string
+		sections []string
+	}{
+		{
+			name:     "sorted by name keeping indentation",
+			content:  "{\n\t\"devDependencies\": {\n\t\t\"b\": \"1\",\n\t\t\"@a/x\": \"2\"\n\t}\n}\n",
+			want:     "{\n\t\"devDependencies\": {\n\t\t\"@a/x\": \"2\",\n\t\t\"b\": \"1\"\n\t}\n}\n",
+			sections: []string{"devDependencies"},
+		},
+		{
+			name:    "already sorted",
+			content: "{\n  \"dependencies\": {\n    \"a\": \"1\",\n    \"b\": \"2\"\n  }\n}\n",
+			want:    "{\n  \"dependencies\": {\n    \"a\": \"1\",\n    \"b\": \"2\"\n  }\n}\n",
+		},
+		{
+			name:    "single line section is kept",
+			content: `{"dependencies": {"b": "1", "a": "2"}}`,
+			want:    `{"dependencies": {"b": "1", "a": "2"}}`,
+		},
+		{
+			name:    "nested value is kept",
+			content: "{\n  \"dependencies\": {\n    \"b\": \"1\",\n    \"a\": {\"version\": \"2\"}\n  }\n}\n",
+			want:    "{\n  \"dependencies\": {\n    \"b\": \"1\",\n    \"a\": {\"version\": \"2\"}\n  }\n}\n",
+		},
+		{
+			name:    "other sections are kept",
+			content: "{\n  \"scripts\": {\n    \"test\": \"jest\",\n    \"build\": \"tsc\"\n  }\n}\n",
+			want:    "{\n  \"scripts\": {\n    \"test\": \"jest\",\n    \"build\": \"tsc\"\n  }\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, sections := SortDependencySections([]byte(tt.content))
+			assert.Equal(t, tt.want, string(got))
+			assert.Equal(t, tt.sections, sections)
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package fleet
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// Recommendation kinds
+const (
+	KindVersion = "version" // Outliers should move to the version series most repositories use
+	KindLibrary = "library" // Outliers should replace an alternative library with the one most repositories use
+)
+
+// Defaults of the convergence thresholds
+const (
+	DefaultMinAdoption     = 0.6
+	DefaultMinRepositories = 3
+)
+
+// AlternativeGroup is a set of libraries of one ecosystem serving the same purpose
+type AlternativeGroup struct {
+	Type    string
+	Purpose string
+	Names   []string
+}
+
+// Alternatives are the library groups checked for library convergence
+var Alternatives = []AlternativeGroup{
+	{Type: "npm", Purpose: "HTTP client", Names: []string{"axios", "node-fetch", "got", "superagent", "request", "ky"}},
+	{Type: "npm", Purpose: "date handling", Names: []string{"moment", "dayjs", "date-fns", "luxon"}},
+	{Type: "npm", Purpose: "test runner", Names: []string{"jest", "vitest", "mocha", "jasmine", "ava"}},
+	{Type: "npm", Purpose: "utility library", Names: []string{"lodash", "underscore", "ramda"}},
+	{Type: "npm", Purpose: "schema validation", Names: []string{"zod", "yup", "joi", "valibot", "superstruct"}},
+	{Type: "npm", Purpose: "ORM", Names: []string{"@prisma/client", "typeorm", "sequelize", "drizzle-orm", "@mikro-orm/core", "objection"}},
+	{Type: "npm", Purpose: "logging", Names: []string{"winston", "pino", "bunyan", "log4js"}},
+	{Type: "npm", Purpose: "state management", Names: []string{"redux", "@reduxjs/toolkit", "mobx", "zustand", "recoil", "jotai"}},
+	{Type: "python", Purpose: "HTTP client", Names: []string{"requests", "httpx", "aiohttp"}},
+	{Type: "python", Purpose: "ORM", Names: []string{"sqlalchemy", "peewee", "pony", "tortoise-orm"}},
+	{Type: "python", Purpose: "test runner", Names: []string{"pytest", "nose", "nose2"}},
+	{Type: "python", Purpose: "date handling", Names: []string{"arrow", "pendulum"}},
+	{Type: "maven", Purpose: "logging", Names: []string{"ch.qos.logback:logback-classic", "org.apache.logging.log4j:log4j-core", "org.slf4j:slf4j-simple"}},
+	{Type: "maven", Purpose: "JSON", Names: []string{"com.fasterxml.jackson.core:jackson-databind", "com.google.code.gson:gson", "org.json:json"}},
+	{Type: "maven", Purpose: "test framework", Names: []string{"org.junit.jupiter:junit-jupiter", "junit:junit", "org.testng:testng"}},
+	{Type: "golang", Purpose: "logging", Names: []string{"github.com/sirupsen/logrus", "go.uber.org/zap", "github.com/rs/zerolog"}},
+	{Type: "golang", Purpose: "HTTP router", Names: []string{"github.com/gin-gonic/gin", "github.com/labstack/echo", "github.com/gofiber/fiber", "github.com/go-chi/chi", "github.com/gorilla/mux"}},
+	{Type: "ruby", Purpose: "HTTP client", Names: []string{"faraday", "httparty", "rest-client"}},
+	{Type: "ruby", Purpose: "test framework", Names: []string{"rspec", "minitest"}},
+}
+
+// Recommendation is a package or library group most repositories have standardized on,
+// with the repositories that have not
+type Recommendation struct {
+	Kind         string    `json:"kind"`         // version or library
+	Type         string    `json:"type"`         // Ecosystem
+	Name         string    `json:"name"`         // Dependency (version) or purpose of the alternatives (library)
+	Standard     string    `json:"standard"`     // Version series or library most repositories use
+	Adoption     float64   `json:"adoption"`     // Share of the repositories using the standard (0-1)
+	Repositories int       `json:"repositories"` // Repositories using the dependency or any of the alternatives
+	Outliers     []Outlier `json:"outliers"`
+}
+
+// Outlier is a repository not using the standard
+type Outlier struct {
+	Repository string   `json:"repository"`
+	Uses       []string `json:"uses"` // Version series or alternative libraries
+}
+
+// recommend returns the convergence recommendations for the direct dependencies of the
+// repositories, most adopted standard first
+func recommend(repos []*Repository, opts Options) []Recommendation {
+	minAdoption, minRepos := opts.MinAdoption, opts.MinRepositories
+	if minAdoption <= 0 {
+		minAdoption = DefaultMinAdoption
+	}
+	if minRepos <= 0 {
+		minRepos = DefaultMinRepositories
+	}
+
+	alternatives := make(map[string]int) // type:name -> index in Alternatives
+	for i, group := range Alternatives {
+		for _, name := range group.Names {
+			alternatives[group.Type+":"+name] = i
+		}
+	}
+
+	versions := make(map[string]map[int]map[string]bool) // type:name -> repository index -> series
+	libraries := make(map[int]map[int]map[string]bool)   // group -> repository index -> libraries
+	names := make([]string, len(repos))
+	for i, repo := range repos {
+		names[i] = repo.Name
+		for _, dep := range repo.Dependencies {
+			if !dep.Direct {
+				continue
+			}
+			name := packageName(dep)
+			key := dep.Type + ":" + name
+			if series := VersionSeries(dep.Version); series != UnknownVersion {
+				addUse(versions, key, i, series)
+			}
+			if group, ok := alternatives[key]; ok {
+				addUse(libraries, group, i, name)
+			}
+		}
+	}
+
+	recommendations := []Recommendation{}
+	for key, uses := range versions {
+		typ, name, _ := strings.Cut(key, ":")
+		if rec, ok := converge(uses, names, minAdoption, minRepos); ok {
+			rec.Kind, rec.Type, rec.Name = KindVersion, typ, name
+			recommendations = append(recommendations, rec)
+		}
+	}
+	for group, uses := range libraries {
+		if rec, ok := converge(uses, names, minAdoption, minRepos); ok {
+			rec.Kind, rec.Type, rec.Name = KindLibrary, Alternatives[group].Type, Alternatives[group].Purpose
+			recommendations = append(recommendations, rec)
+		}
+	}
+
+	sort.Slice(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		if a.Adoption != b.Adoption {
+			return a.Adoption > b.Adoption
+		}
+		if a.Repositories != b.Repositories {
+			return a.Repositories > b.Repositories
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Name < b.Name
+	})
+	return recommendations
+}
+
+// converge finds the standard of one dependency or library group: the value used by the most
+// repositories, if at least minAdoption of minRepos or more repositories use it and some do not
+func converge(uses map[int]map[string]bool, names []string, minAdoption float64, minRepos int) (Recommendation, bool) {
+	if len(uses) < minRepos {
+		return Recommendation{}, false
+	}
+
+	counts := make(map[string]int)
+	for _, values := range uses {
+		for value := range values {
+			counts[value]++
+		}
+	}
+	var standard string
+	for value, count := range counts {
+		if count > counts[standard] || (count == counts[standard] && value < standard) {
+			standard = value
+		}
+	}
+
+	adoption := float64(counts[standard]) / float64(len(uses))
+	if adoption < minAdoption || counts[standard] == len(uses) {
+		return Recommendation{}, false
+	}
+
+	rec := Recommendation{
+		Standard:     standard,
+		Adoption:     math.Round(adoption*100) / 100,
+		Repositories: len(uses),
+		Outliers:     []Outlier{},
+	}
+	for i, name := range names {
+		values, ok := uses[i]
+		if !ok || values[standard] {
+			continue
+		}
+		outlier := Outlier{Repository: name}
+		for value := range values {
+			outlier.Uses = append(outlier.Uses, value)
+		}
+		sort.Strings(outlier.Uses)
+		rec.Outliers = append(rec.Outliers, outlier)
+	}
+	return rec, true
+}
+
+// addUse records that a repository uses a value (version series or library) of a key
+func addUse[K comparable](uses map[K]map[int]map[string]bool, key K, repo int, value string) {
+	if uses[key] == nil {
+		uses[key] = make(map[int]map[string]bool)
+	}
+	if uses[key][repo] == nil {
+		uses[key][repo] = make(map[string]bool)
+	}
+	uses[key][repo][value] = true
+}
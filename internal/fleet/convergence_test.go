@@ -0,0 +1,85 @@
+package fleet
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func convergenceFleet() []*Repository {
+	repo := func(name string, deps ...types.Dependency) *Repository {
+		return &Repository{Name: name, File: name + ".json", Dependencies: deps}
+	}
+	return []*Repository{
+		repo("shop", dep("npm", "react", "^18.2.0", true, ""), dep("npm", "axios", "1.6.0", true, "")),
+		repo("billing", dep("npm", "react", "18.3.1", true, ""), dep("npm", "axios", "1.4.0", true, "")),
+		repo("admin", dep("npm", "react", "18.2.0", true, ""), dep("npm", "axios", "0.27.2", true, "")),
+		repo("portal", dep("npm", "react", "17.0.2", true, ""), dep("npm", "node-fetch", "2.7.0", true, "")),
+		repo("legacy", dep("npm", "react", "16.14.0", false, ""), dep("npm", "got", "11.8.6", true, ""), dep("npm", "superagent", "8.1.2", true, "")),
+	}
+}
+
+func TestRecommendVersions(t *testing.T) {
+	recommendations := recommend(convergenceFleet(), Options{})
+
+	react := findRecommendation(recommendations, KindVersion, "react")
+	require.NotNil(t, react)
+	assert.Equal(t, Recommendation{
+		Kind: KindVersion, Type: "npm", Name: "react", Standard: "18.x", Adoption: 0.75, Repositories: 4,
+		Outliers: []Outlier{{Repository: "portal", Uses: []string{"17.x"}}},
+	}, *react, "transitive dependencies are not recommendations")
+
+	axios := findRecommendation(recommendations, KindVersion, "axios")
+	require.NotNil(t, axios)
+	assert.Equal(t, "1.x", axios.Standard)
+	assert.Equal(t, 0.67, axios.Adoption)
+	assert.Equal(t, []Outlier{{Repository: "admin", Uses: []string{"0.27.x"}}}, axios.Outliers)
+
+	assert.Nil(t, findRecommendation(recommendations, KindVersion, "got"), "used by a single repository")
+}
+
+func TestRecommendLibraries(t *testing.T) {
+	recommendations := recommend(convergenceFleet(), Options{})
+
+	http := findRecommendation(recommendations, KindLibrary, "HTTP client")
+	require.NotNil(t, http)
+	assert.Equal(t, Recommendation{
+		Kind: KindLibrary, Type: "npm", Name: "HTTP client", Standard: "axios", Adoption: 0.6, Repositories: 5,
+		Outliers: []Outlier{
+			{Repository: "portal", Uses: []string{"node-fetch"}},
+			{Repository: "legacy", Uses: []string{"got", "superagent"}},
+		},
+	}, *http)
+}
+
+func TestRecommendThresholds(t *testing.T) {
+	recommendations := recommend(convergenceFleet(), Options{MinAdoption: 0.7})
+	assert.Nil(t, findRecommendation(recommendations, KindLibrary, "HTTP client"), "axios is used by 60% of the repositories")
+	assert.NotNil(t, findRecommendation(recommendations, KindVersion, "react"))
+
+	recommendations = recommend(convergenceFleet(), Options{MinRepositories: 5})
+	assert.Nil(t, findRecommendation(recommendations, KindVersion, "react"), "react is declared directly by 4 repositories")
+	assert.NotNil(t, findRecommendation(recommendations, KindLibrary, "HTTP client"))
+
+	recommendations = recommend(convergenceFleet()[:3], Options{})
+	assert.Nil(t, findRecommendation(recommendations, KindVersion, "react"), "no recommendation without outliers")
+}
+
+func TestBuildReportRecommendations(t *testing.T) {
+	report := BuildReport(convergenceFleet(), Options{})
+	require.Len(t, report.Recommendations, 3)
+	assert.Equal(t, "react", report.Recommendations[0].Name, "the most adopted standard comes first")
+	assert.Equal(t, "axios", report.Recommendations[1].Name)
+	assert.Equal(t, "HTTP client", report.Recommendations[2].Name)
+}
+
+func findRecommendation(recommendations []Recommendation, kind, name string) *Recommendation {
+	for i := range recommendations {
+		if recommendations[i].Kind == kind && recommendations[i].Name == name {
+			return &recommendations[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package fleet
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// DefaultTop is the default number of most common dependencies in the report
+const DefaultTop = 50
+
+// Placeholders for dependencies without a license or version
+const (
+	UnknownLicense = "unknown"
+	UnknownVersion = "unknown"
+)
+
+// Options controls which dependencies are counted
+type Options struct {
+	Top             int     // Number of most common dependencies in the report (0 = all)
+	DirectOnly      bool    // Count only dependencies declared directly in a manifest
+	MinAdoption     float64 // Share of repositories a standard needs for a recommendation (0 = DefaultMinAdoption)
+	MinRepositories int     // Repositories a dependency needs for a recommendation (0 = DefaultMinRepositories)
+}
+
+// Report is the fleet-level summary of many scan results
+type Report struct {
+	Repositories         []RepositorySummary `json:"repositories"`
+	Dependencies         []DependencyUsage   `json:"dependencies"`          // Most common first
+	Licenses             []LicenseUsage      `json:"licenses"`              // Most common first
+	Technologies         []TechUsage         `json:"technologies"`          // Most common first
+	OrphanedTechnologies []TechUsage         `json:"orphaned_technologies"` // Used by a single repository
+	Recommendations      []Recommendation    `json:"recommendations"`       // Convergence on versions and libraries
+}
+
+// RepositorySummary identifies a scanned repository in the report
+type RepositorySummary struct {
+	Name         string `json:"name"`
+	File         string `json:"file"`
+	Dependencies int    `json:"dependencies"` // Unique dependencies by ecosystem and name
+	Techs        int    `json:"techs"`
+}
+
+// DependencyUsage is a dependency with the number of repositories using it
+type DependencyUsage struct {
+	Type         string         `json:"type"`
+	Name         string         `json:"name"`
+	Repositories int            `json:"repositories"`
+	Versions     []VersionUsage `json:"versions"` // Version series, most used first
+}
+
+// VersionUsage is a version series (major version, "0.<minor>" below 1.0) of a dependency
+type VersionUsage struct {
+	Series       string `json:"series"` // e.g., "6.x", "0.4.x", or the version as declared ("latest")
+	Repositories int    `json:"repositories"`
+}
+
+// LicenseUsage is a license with the number of dependencies and repositories using it
+type LicenseUsage struct {
+	License      string `json:"license"`
+	Dependencies int    `json:"dependencies"` // Unique dependencies by ecosystem and name
+	Repositories int    `json:"repositories"`
+}
+
+// TechUsage is a technology with the repositories using it
+type TechUsage struct {
+	Tech            string   `json:"tech"`
+	Repositories    int      `json:"repositories"`
+	RepositoryNames []string `json:"repository_names,omitempty"` // Only for orphaned technologies
+}
+
+// versionPattern matches the major and minor version after range operators ("^6.1.0", ">=0.4")
+var versionPattern = regexp.MustCompile(`^[\^~>=<\s]*v?(\d+)(?:\.(\d+))?`)
+
+// BuildReport merges the repositories into a fleet report
+func BuildReport(repos []*Repository, opts Options) *Report {
+	report := &Report{
+		Repositories:         []RepositorySummary{},
+		Dependencies:         []DependencyUsage{},
+		Licenses:             []LicenseUsage{},
+		Technologies:         []TechUsage{},
+		OrphanedTechnologies: []TechUsage{},
+	}
+
+	type depUsage struct {
+		usage  *DependencyUsage
+		series map[string]int
+		repos  int
+	}
+	deps := make(map[string]*depUsage)
+	licenses := make(map[string]*LicenseUsage)
+	depLicenses := make(map[string]map[string]bool) // dependency key -> licenses in any repository
+	techRepos := make(map[string][]string)
+
+	for _, repo := range repos {
+		seen := make(map[string]map[string]bool)         // dependency key -> version series
+		seenLicenses := make(map[string]map[string]bool) // dependency key -> licenses
+		for _, dep := range repo.Dependencies {
+			if opts.DirectOnly && !dep.Direct {
+				continue
+			}
+			key := dep.Type + ":" + packageName(dep)
+			if seen[key] == nil {
+				seen[key] = make(map[string]bool)
+			}
+			seen[key][VersionSeries(dep.Version)] = true
+			if seenLicenses[key] == nil {
+				seenLicenses[key] = make(map[string]bool)
+			}
+			if license := dependencyLicense(dep); license != "" {
+				seenLicenses[key][license] = true
+			}
+		}
+
+		repoLicenses := make(map[string]bool)
+		for key, found := range seenLicenses {
+			if len(found) == 0 {
+				repoLicenses[UnknownLicense] = true
+			}
+			if depLicenses[key] == nil {
+				depLicenses[key] = make(map[string]bool)
+			}
+			for license := range found {
+				repoLicenses[license] = true
+				depLicenses[key][license] = true
+			}
+		}
+
+		for key, series := range seen {
+			d, ok := deps[key]
+			if !ok {
+				typ, name, _ := strings.Cut(key, ":")
+				d = &depUsage{usage: &DependencyUsage{Type: typ, Name: name}, series: make(map[string]int)}
+				deps[key] = d
+			}
+			d.repos++
+			for s := range series {
+				d.series[s]++
+			}
+		}
+		for license := range repoLicenses {
+			if licenses[license] == nil {
+				licenses[license] = &LicenseUsage{License: license}
+			}
+			licenses[license].Repositories++
+		}
+		for _, tech := range repo.Techs {
+			techRepos[tech] = append(techRepos[tech], repo.Name)
+		}
+
+		report.Repositories = append(report.Repositories, RepositorySummary{
+			Name: repo.Name, File: repo.File, Dependencies: len(seen), Techs: len(repo.Techs),
+		})
+	}
+
+	for key, d := range deps {
+		d.usage.Repositories = d.repos
+		for series, count := range d.series {
+			d.usage.Versions = append(d.usage.Versions, VersionUsage{Series: series, Repositories: count})
+		}
+		sort.Slice(d.usage.Versions, func(i, j int) bool {
+			a, b := d.usage.Versions[i], d.usage.Versions[j]
+			if a.Repositories != b.Repositories {
+				return a.Repositories > b.Repositories
+			}
+			return a.Series < b.Series
+		})
+		report.Dependencies = append(report.Dependencies, *d.usage)
+
+		if len(depLicenses[key]) == 0 {
+			depLicenses[key] = map[string]bool{UnknownLicense: true}
+		}
+		for name := range depLicenses[key] {
+			if licenses[name] == nil {
+				licenses[name] = &LicenseUsage{License: name}
+			}
+			licenses[name].Dependencies++
+		}
+	}
+	sort.Slice(report.Dependencies, func(i, j int) bool {
+		a, b := report.Dependencies[i], report.Dependencies[j]
+		if a.Repositories != b.Repositories {
+			return a.Repositories > b.Repositories
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Name < b.Name
+	})
+	if opts.Top > 0 && len(report.Dependencies) > opts.Top {
+		report.Dependencies = report.Dependencies[:opts.Top]
+	}
+
+	for _, license := range licenses {
+		report.Licenses = append(report.Licenses, *license)
+	}
+	sort.Slice(report.Licenses, func(i, j int) bool {
+		a, b := report.Licenses[i], report.Licenses[j]
+		if a.Repositories != b.Repositories {
+			return a.Repositories > b.Repositories
+		}
+		if a.Dependencies != b.Dependencies {
+			return a.Dependencies > b.Dependencies
+		}
+		return a.License < b.License
+	})
+
+	for tech, names := range techRepos {
+		report.Technologies = append(report.Technologies, TechUsage{Tech: tech, Repositories: len(names)})
+		if len(names) == 1 && len(repos) > 1 {
+			report.OrphanedTechnologies = append(report.OrphanedTechnologies, TechUsage{Tech: tech, Repositories: 1, RepositoryNames: names})
+		}
+	}
+	sort.Slice(report.Technologies, func(i, j int) bool {
+		a, b := report.Technologies[i], report.Technologies[j]
+		if a.Repositories != b.Repositories {
+			return a.Repositories > b.Repositories
+		}
+		return a.Tech < b.Tech
+	})
+	sort.Slice(report.OrphanedTechnologies, func(i, j int) bool {
+		return report.OrphanedTechnologies[i].Tech < report.OrphanedTechnologies[j].Tech
+	})
+
+	report.Recommendations = recommend(repos, opts)
+	return report
+}
+
+// VersionSeries returns the version series used for the version spread: the major version
+// ("6.x"), the minor version below 1.0 ("0.4.x"), "unknown" without a version, or the
+// version as declared when it is not numeric ("latest", git references)
+func VersionSeries(version string) string {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return UnknownVersion
+	}
+	match := versionPattern.FindStringSubmatch(version)
+	if match == nil {
+		return version
+	}
+	major := strings.TrimLeft(match[1], "0")
+	if major == "" && match[2] != "" {
+		minor := strings.TrimLeft(match[2], "0")
+		if minor == "" {
+			minor = "0"
+		}
+		return "0." + minor + ".x"
+	}
+	if major == "" {
+		major = "0"
+	}
+	return major + ".x"
+}
+
+// packageName returns the name dependencies are grouped by: Go modules without their major
+// version suffix, so github.com/foo/bar and github.com/foo/bar/v2 are majors of one package
+func packageName(dep types.Dependency) string {
+	if dep.Type == parsers.DependencyTypeGolang {
+		name, _ := parsers.SplitGoModulePath(dep.Name)
+		return name
+	}
+	return dep.Name
+}
+
+// dependencyLicense returns the concluded license of a dependency, else the declared one
+func dependencyLicense(dep types.Dependency) string {
+	if license, _ := dep.Metadata[parsers.MetadataLicenseConcluded].(string); license != "" {
+		return license
+	}
+	license, _ := dep.Metadata[parsers.MetadataLicenseDeclared].(string)
+	return license
+}
@@ -0,0 +1,142 @@
+package fleet
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dep(typ, name, version string, direct bool, license string) types.Dependency {
+	d := types.Dependency{Type: typ, Name: name, Version: version, Direct: direct}
+	if license != "" {
+		d.Metadata = map[string]interface{}{"license_declared": license}
+	}
+	return d
+}
+
+func testFleet() []*Repository {
+	return []*Repository{
+		{Name: "shop", File: "shop.json", Techs: []string{"docker", "rails"}, Dependencies: []types.Dependency{
+			dep("ruby", "rails", "6.1.7", true, "MIT"),
+			dep("ruby", "rack", "2.2.8", false, "MIT"),
+			dep("ruby", "rack", "3.0.0", false, "MIT"),
+		}},
+		{Name: "billing", File: "billing.json", Techs: []string{"rails"}, Dependencies: []types.Dependency{
+			dep("ruby", "rails", "~> 6.0", true, ""),
+			dep("ruby", "rack", "2.2.8", false, ""),
+			dep("npm", "gpl-lib", "0.4.2", true, "GPL-3.0-only"),
+		}},
+		{Name: "admin", File: "admin.json", Techs: []string{"rails", "terraform"}, Dependencies: []types.Dependency{
+			dep("ruby", "rails", "7.1.2", true, "MIT"),
+		}},
+	}
+}
+
+func TestBuildReportDependencies(t *testing.T) {
+	report := BuildReport(testFleet(), Options{})
+
+	require.Len(t, report.Dependencies, 3)
+	assert.Equal(t, DependencyUsage{
+		Type: "ruby", Name: "rails", Repositories: 3,
+		Versions: []VersionUsage{{Series: "6.x", Repositories: 2}, {Series: "7.x", Repositories: 1}},
+	}, report.Dependencies[0])
+	assert.Equal(t, DependencyUsage{
+		Type: "ruby", Name: "rack", Repositories: 2,
+		Versions: []VersionUsage{{Series: "2.x", Repositories: 2}, {Series: "3.x", Repositories: 1}},
+	}, report.Dependencies[1], "a repository is counted once per dependency and once per version series")
+	assert.Equal(t, "gpl-lib", report.Dependencies[2].Name)
+
+	assert.Equal(t, []RepositorySummary{
+		{Name: "shop", File: "shop.json", Dependencies: 2, Techs: 2},
+		{Name: "billing", File: "billing.json", Dependencies: 3, Techs: 1},
+		{Name: "admin", File: "admin.json", Dependencies: 1, Techs: 2},
+	}, report.Repositories)
+}
+
+func TestBuildReportOptions(t *testing.T) {
+	report := BuildReport(testFleet(), Options{DirectOnly: true})
+	require.Len(t, report.Dependencies, 2)
+	assert.Equal(t, "rails", report.Dependencies[0].Name)
+	assert.Equal(t, "gpl-lib", report.Dependencies[1].Name)
+
+	report = BuildReport(testFleet(), Options{Top: 1})
+	require.Len(t, report.Dependencies, 1)
+	assert.Equal(t, "rails", report.Dependencies[0].Name)
+}
+
+func TestBuildReportLicenses(t *testing.T) {
+	report := BuildReport(testFleet(), Options{})
+
+	assert.Equal(t, []LicenseUsage{
+		{License: "MIT", Dependencies: 2, Repositories: 2},
+		{License: "GPL-3.0-only", Dependencies: 1, Repositories: 1},
+		{License: UnknownLicense, Dependencies: 0, Repositories: 1},
+	}, report.Licenses, "dependencies without a license in one repository take the license of the others")
+
+	repos := testFleet()
+	repos[2].Dependencies = append(repos[2].Dependencies, dep("ruby", "rack", "3.0.0", false, "BSD-3-Clause"))
+	report = BuildReport(repos, Options{})
+	assert.Contains(t, report.Licenses, LicenseUsage{License: "MIT", Dependencies: 2, Repositories: 2})
+	assert.Contains(t, report.Licenses, LicenseUsage{License: "BSD-3-Clause", Dependencies: 1, Repositories: 1}, "a dependency counts for every license it is declared with")
+}
+
+func TestBuildReportTechnologies(t *testing.T) {
+	report := BuildReport(testFleet(), Options{})
+
+	assert.Equal(t, []TechUsage{
+		{Tech: "rails", Repositories: 3},
+		{Tech: "docker", Repositories: 1},
+		{Tech: "terraform", Repositories: 1},
+	}, report.Technologies)
+	assert.Equal(t, []TechUsage{
+		{Tech: "docker", Repositories: 1, RepositoryNames: []string{"shop"}},
+		{Tech: "terraform", Repositories: 1, RepositoryNames: []string{"admin"}},
+	}, report.OrphanedTechnologies)
+
+	single := BuildReport(testFleet()[:1], Options{})
+	assert.Empty(t, single.OrphanedTechnologies, "a single repository has no orphans")
+}
+
+func TestVersionSeries(t *testing.T) {
+	tests := map[string]string{
+		"6.1.7":      "6.x",
+		"^18.2.0":    "18.x",
+		"~> 6.0":     "6.x",
+		">=2.0,<3.0": "2.x",
+		"v1.22.0":    "1.x",
+		"0.4.2":      "0.4.x",
+		"0.04":       "0.4.x",
+		"0":          "0.x",
+		"":           UnknownVersion,
+		"latest":     "latest",
+		"*":          "*",
+	}
+	for version, expected := range tests {
+		assert.Equal(t, expected, VersionSeries(version), version)
+	}
+}
+
+func TestBuildReportGoMajorVersions(t *testing.T) {
+	repos := []*Repository{
+		{Name: "api", Dependencies: []types.Dependency{dep("golang", "github.com/foo/bar/v2", "v2.1.0", true, "")}},
+		{Name: "worker", Dependencies: []types.Dependency{dep("golang", "github.com/foo/bar", "v1.4.0", true, "")}},
+		{Name: "cli", Dependencies: []types.Dependency{
+			dep("golang", "github.com/foo/bar/v2", "v2.0.3", true, ""),
+			dep("golang", "gopkg.in/yaml.v3", "v3.0.1", true, ""),
+		}},
+	}
+	report := BuildReport(repos, Options{})
+
+	require.Len(t, report.Dependencies, 2)
+	assert.Equal(t, DependencyUsage{
+		Type: "golang", Name: "github.com/foo/bar", Repositories: 3,
+		Versions: []VersionUsage{{Series: "2.x", Repositories: 2}, {Series: "1.x", Repositories: 1}},
+	}, report.Dependencies[0], "major version paths are one package")
+	assert.Equal(t, "gopkg.in/yaml", report.Dependencies[1].Name)
+
+	require.Len(t, report.Recommendations, 1)
+	assert.Equal(t, "2.x", report.Recommendations[0].Standard)
+	assert.Equal(t, []Outlier{{Repository: "worker", Uses: []string{"1.x"}}}, report.Recommendations[0].Outliers)
+}
@@ -0,0 +1,123 @@
+// Package fleet merges the results of many scans into a fleet-level report: the most common
+// dependencies with their version spread across repositories, the license distribution, and
+// technologies used by a single repository only.
+package fleet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/git"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Repository is the part of one scan result used for the fleet report
+type Repository struct {
+	Name         string             // Repository name from the git remote, else the project name
+	File         string             // Scan result file
+	Techs        []string           // Unique technologies of all components, sorted
+	Dependencies []types.Dependency // Dependencies of all components
+	Timestamp    time.Time          // Scan time (zero if the result has no timestamp)
+	Outdated     int                // Direct dependencies in the upgrade advisory (--enrich-registry)
+	BaseImages   []string           // Current and recommended images of the base image advice
+}
+
+// scanOutput is the subset of the full and aggregated scan output formats read for the report
+type scanOutput struct {
+	Metadata struct {
+		Format    string `json:"format"`
+		Timestamp string `json:"timestamp"`
+	} `json:"metadata"`
+	Analysis struct {
+		UpgradeAdvisory []json.RawMessage `json:"upgrade_advisory"`
+		BaseImageAdvice []struct {
+			Image       string `json:"image"`
+			Recommended string `json:"recommended"`
+		} `json:"base_image_advice"`
+	} `json:"analysis"`
+	Name         string             `json:"name"`
+	Git          json.RawMessage    `json:"git"` // Object in the full format, array in the aggregated format
+	Tech         []string           `json:"tech"`
+	Techs        []string           `json:"techs"`
+	Dependencies []types.Dependency `json:"dependencies"`
+	Children     []*scanOutput      `json:"children"`
+}
+
+// Load reads one scan result in the full or aggregated JSON output format. The file name is
+// used as repository name when the result has neither a git remote nor a project name.
+func Load(file string, r io.Reader) (*Repository, error) {
+	var output scanOutput
+	if err := json.NewDecoder(r).Decode(&output); err != nil {
+		return nil, fmt.Errorf("failed to parse scan results: %w", err)
+	}
+	if output.Metadata.Format == "telemetry" {
+		return nil, errors.New("telemetry summaries have no dependency names: use the full or aggregated scan output")
+	}
+
+	repo := &Repository{File: file, Name: repositoryName(&output), Outdated: len(output.Analysis.UpgradeAdvisory)}
+	if output.Metadata.Timestamp != "" {
+		timestamp, err := time.Parse(time.RFC3339, output.Metadata.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scan timestamp: %w", err)
+		}
+		repo.Timestamp = timestamp
+	}
+	if repo.Name == "" {
+		repo.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	for _, advice := range output.Analysis.BaseImageAdvice {
+		repo.BaseImages = append(repo.BaseImages, advice.Image, advice.Recommended)
+	}
+
+	techs := make(map[string]bool)
+	collect(&output, repo, techs)
+	for tech := range techs {
+		repo.Techs = append(repo.Techs, tech)
+	}
+	sort.Strings(repo.Techs)
+	return repo, nil
+}
+
+// collect adds the technologies and dependencies of the component tree to the repository
+func collect(output *scanOutput, repo *Repository, techs map[string]bool) {
+	for _, tech := range append(append([]string{}, output.Tech...), output.Techs...) {
+		techs[tech] = true
+	}
+	repo.Dependencies = append(repo.Dependencies, output.Dependencies...)
+	for _, child := range output.Children {
+		collect(child, repo, techs)
+	}
+}
+
+// repositoryName returns the repository name of the git remote, else the project name
+func repositoryName(output *scanOutput) string {
+	var remote string
+	if trimmed := bytes.TrimSpace(output.Git); len(trimmed) > 0 && trimmed[0] == '[' {
+		var infos []*git.GitInfo
+		if json.Unmarshal(trimmed, &infos) == nil && len(infos) > 0 && infos[0] != nil {
+			remote = infos[0].RemoteURL
+		}
+	} else if len(trimmed) > 0 {
+		var info git.GitInfo
+		if json.Unmarshal(trimmed, &info) == nil {
+			remote = info.RemoteURL
+		}
+	}
+
+	if remote = strings.TrimSuffix(strings.TrimRight(remote, "/"), ".git"); remote != "" {
+		if i := strings.LastIndexAny(remote, "/:"); i >= 0 {
+			remote = remote[i+1:]
+		}
+		if remote != "" {
+			return remote
+		}
+	}
+	return output.Name
+}
@@ -0,0 +1,79 @@
+package fleet
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFullOutput(t *testing.T) {
+	input := `{
+  "metadata": {"format": "full", "timestamp": "2026-03-02T08:15:00Z"},
+  "analysis": {
+    "upgrade_advisory": [{"type": "npm", "name": "react"}],
+    "base_image_advice": [{"image": "node:18", "stage": "runtime", "recommended": "node:22-slim"}]
+  },
+  "id": "root", "name": "shop",
+  "git": {"branch": "main", "remote_url": "git@github.com:acme/shop-api.git"},
+  "tech": ["nodejs"], "techs": ["nodejs", "docker"],
+  "dependencies": [["npm", "react", "^18.2.0", "prod", true, {"license_declared": "MIT"}]],
+  "children": [{
+    "id": "web", "name": "web", "techs": ["react"],
+    "dependencies": [["npm", "left-pad", "1.3.0", "prod", false, {}]],
+    "children": []
+  }]
+}`
+	repo, err := Load("results/shop.json", strings.NewReader(input))
+	require.NoError(t, err)
+
+	assert.Equal(t, "shop-api", repo.Name, "named after the git remote")
+	assert.Equal(t, "results/shop.json", repo.File)
+	assert.Equal(t, time.Date(2026, 3, 2, 8, 15, 0, 0, time.UTC), repo.Timestamp)
+	assert.Equal(t, 1, repo.Outdated)
+	assert.Equal(t, []string{"node:18", "node:22-slim"}, repo.BaseImages)
+	assert.Equal(t, []string{"docker", "nodejs", "react"}, repo.Techs)
+	require.Len(t, repo.Dependencies, 2)
+	assert.Equal(t, "react", repo.Dependencies[0].Name)
+	assert.True(t, repo.Dependencies[0].Direct)
+	assert.Equal(t, "left-pad", repo.Dependencies[1].Name)
+}
+
+func TestLoadAggregatedOutput(t *testing.T) {
+	input := `{
+  "metadata": {"format": "aggregated"},
+  "git": [{"remote_url": "https://gitlab.com/acme/billing/"}],
+  "techs": ["python"],
+  "dependencies": [["python", "django", "4.2.1"]]
+}`
+	repo, err := Load("billing.json", strings.NewReader(input))
+	require.NoError(t, err)
+
+	assert.Equal(t, "billing", repo.Name)
+	assert.Equal(t, []string{"python"}, repo.Techs)
+	require.Len(t, repo.Dependencies, 1)
+	assert.Equal(t, "4.2.1", repo.Dependencies[0].Version)
+}
+
+func TestLoadNames(t *testing.T) {
+	repo, err := Load("out/x.json", strings.NewReader(`{"id": "root", "name": "inventory"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "inventory", repo.Name, "project name without git remote")
+
+	repo, err = Load("out/payments.json", strings.NewReader(`{"metadata": {"format": "aggregated"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "payments", repo.Name, "file name without git remote and project name")
+}
+
+func TestLoadRejectsInvalidInput(t *testing.T) {
+	_, err := Load("t.json", strings.NewReader(`{"metadata": {"format": "telemetry"}}`))
+	assert.ErrorContains(t, err, "telemetry summaries")
+
+	_, err = Load("s.json", strings.NewReader(`{"metadata": {"timestamp": "yesterday"}}`))
+	assert.ErrorContains(t, err, "invalid scan timestamp")
+
+	_, err = Load("q.json", strings.NewReader(`[{"name": "react"}]`))
+	assert.ErrorContains(t, err, "failed to parse scan results")
+}
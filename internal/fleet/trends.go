@@ -0,0 +1,140 @@
+package fleet
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+)
+
+// RepositoryTrend is the time series of the stored scans of one repository
+type RepositoryTrend struct {
+	Repository string       `json:"repository"`
+	Points     []TrendPoint `json:"points"` // Oldest scan first
+}
+
+// TrendPoint summarizes one stored scan
+type TrendPoint struct {
+	Timestamp          time.Time      `json:"timestamp"`
+	File               string         `json:"file"`
+	Dependencies       int            `json:"dependencies"`           // Unique dependencies by ecosystem and name
+	DirectDependencies int            `json:"direct_dependencies"`    // Unique dependencies declared in a manifest
+	Outdated           *int           `json:"outdated,omitempty"`     // Direct dependencies with a newer release (enriched scans only)
+	OutdatedPct        *float64       `json:"outdated_pct,omitempty"` // Outdated share of the direct dependencies in percent (enriched scans only)
+	Licenses           map[string]int `json:"licenses"`               // Unique dependencies per license ("unknown" when undeclared)
+}
+
+// BuildTrends groups stored scans by repository, ordered by scan time. Outdated counts are only
+// reported for scans enriched with registry data (--enrich-registry), recognized by an upgrade
+// advisory or concluded licenses; other scans cannot tell up-to-date from unchecked dependencies.
+func BuildTrends(repos []*Repository) []RepositoryTrend {
+	byName := make(map[string][]TrendPoint)
+	for _, repo := range repos {
+		byName[repo.Name] = append(byName[repo.Name], trendPoint(repo))
+	}
+
+	trends := make([]RepositoryTrend, 0, len(byName))
+	for name, points := range byName {
+		sort.SliceStable(points, func(i, j int) bool { return points[i].Timestamp.Before(points[j].Timestamp) })
+		trends = append(trends, RepositoryTrend{Repository: name, Points: points})
+	}
+	sort.Slice(trends, func(i, j int) bool { return trends[i].Repository < trends[j].Repository })
+	return trends
+}
+
+// trendPoint summarizes the dependencies of one scan
+func trendPoint(repo *Repository) TrendPoint {
+	point := TrendPoint{Timestamp: repo.Timestamp, File: repo.File, Licenses: make(map[string]int)}
+
+	licenses := make(map[string]string)
+	direct := make(map[string]bool)
+	enriched := repo.Outdated > 0
+	for _, dep := range repo.Dependencies {
+		key := dep.Type + ":" + dep.Name
+		if dep.Direct {
+			direct[key] = true
+		}
+		if _, ok := dep.Metadata[parsers.MetadataLicenseConcluded]; ok {
+			enriched = true
+		}
+		if licenses[key] == "" || licenses[key] == UnknownLicense {
+			licenses[key] = dependencyLicense(dep)
+			if licenses[key] == "" {
+				licenses[key] = UnknownLicense
+			}
+		}
+	}
+
+	point.Dependencies = len(licenses)
+	point.DirectDependencies = len(direct)
+	for _, license := range licenses {
+		point.Licenses[license]++
+	}
+	if enriched {
+		outdated := repo.Outdated
+		point.Outdated = &outdated
+		if len(direct) > 0 {
+			pct := math.Round(float64(outdated)*1000/float64(len(direct))) / 10
+			point.OutdatedPct = &pct
+		}
+	}
+	return point
+}
+
+// WriteTrendsCSV writes one row per repository and scan. The license mix is spread over one
+// "license:<name>" column per license found in any scan.
+func WriteTrendsCSV(w io.Writer, trends []RepositoryTrend) error {
+	licenseSet := make(map[string]bool)
+	for _, trend := range trends {
+		for _, point := range trend.Points {
+			for license := range point.Licenses {
+				licenseSet[license] = true
+			}
+		}
+	}
+	licenses := make([]string, 0, len(licenseSet))
+	for license := range licenseSet {
+		licenses = append(licenses, license)
+	}
+	sort.Strings(licenses)
+
+	writer := csv.NewWriter(w)
+	header := []string{"repository", "timestamp", "file", "dependencies", "direct_dependencies", "outdated", "outdated_pct"}
+	for _, license := range licenses {
+		header = append(header, "license:"+license)
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, trend := range trends {
+		for _, point := range trend.Points {
+			timestamp := ""
+			if !point.Timestamp.IsZero() {
+				timestamp = point.Timestamp.UTC().Format(time.RFC3339)
+			}
+			outdated, outdatedPct := "", ""
+			if point.Outdated != nil {
+				outdated = strconv.Itoa(*point.Outdated)
+			}
+			if point.OutdatedPct != nil {
+				outdatedPct = strconv.FormatFloat(*point.OutdatedPct, 'f', -1, 64)
+			}
+
+			row := []string{trend.Repository, timestamp, point.File,
+				strconv.Itoa(point.Dependencies), strconv.Itoa(point.DirectDependencies), outdated, outdatedPct}
+			for _, license := range licenses {
+				row = append(row, strconv.Itoa(point.Licenses[license]))
+			}
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
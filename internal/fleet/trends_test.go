@@ -0,0 +1,79 @@
+package fleet
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func trendScans() []*Repository {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 8, 0, 0, 0, time.UTC) }
+	concluded := func(typ, name, license string) types.Dependency {
+		return types.Dependency{Type: typ, Name: name, Version: "1.0.0", Direct: true, Metadata: map[string]interface{}{"license_concluded": license}}
+	}
+	return []*Repository{
+		{Name: "shop", File: "shop-2.json", Timestamp: day(2), Outdated: 1, Dependencies: []types.Dependency{
+			concluded("npm", "react", "MIT"),
+			concluded("npm", "gpl-lib", "GPL-3.0-only"),
+			dep("npm", "loose-envify", "1.4.0", false, ""),
+		}},
+		{Name: "shop", File: "shop-1.json", Timestamp: day(1), Dependencies: []types.Dependency{
+			dep("npm", "react", "18.2.0", true, "MIT"),
+			dep("npm", "react", "18.2.0", false, ""),
+		}},
+		{Name: "billing", File: "billing-1.json", Timestamp: day(1), Dependencies: []types.Dependency{
+			dep("python", "django", "4.2", true, "BSD-3-Clause"),
+		}},
+	}
+}
+
+func TestBuildTrends(t *testing.T) {
+	trends := BuildTrends(trendScans())
+	require.Len(t, trends, 2)
+
+	assert.Equal(t, "billing", trends[0].Repository)
+	shop := trends[1]
+	assert.Equal(t, "shop", shop.Repository)
+	require.Len(t, shop.Points, 2)
+
+	first := shop.Points[0]
+	assert.Equal(t, "shop-1.json", first.File, "oldest scan first")
+	assert.Equal(t, 1, first.Dependencies)
+	assert.Equal(t, 1, first.DirectDependencies)
+	assert.Nil(t, first.Outdated, "not enriched with registry data")
+	assert.Nil(t, first.OutdatedPct)
+	assert.Equal(t, map[string]int{"MIT": 1}, first.Licenses)
+
+	second := shop.Points[1]
+	assert.Equal(t, 3, second.Dependencies)
+	assert.Equal(t, 2, second.DirectDependencies)
+	require.NotNil(t, second.Outdated)
+	assert.Equal(t, 1, *second.Outdated)
+	require.NotNil(t, second.OutdatedPct)
+	assert.Equal(t, 50.0, *second.OutdatedPct)
+	assert.Equal(t, map[string]int{"MIT": 1, "GPL-3.0-only": 1, UnknownLicense: 1}, second.Licenses)
+}
+
+func TestTrendsEnrichedWithoutOutdated(t *testing.T) {
+	scan := &Repository{Name: "shop", Dependencies: []types.Dependency{
+		{Type: "npm", Name: "react", Direct: true, Metadata: map[string]interface{}{"license_concluded": "MIT"}},
+	}}
+	point := BuildTrends([]*Repository{scan})[0].Points[0]
+
+	require.NotNil(t, point.OutdatedPct, "concluded licenses mark an enriched scan")
+	assert.Equal(t, 0.0, *point.OutdatedPct)
+}
+
+func TestWriteTrendsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteTrendsCSV(&buf, BuildTrends(trendScans())))
+
+	assert.Equal(t, "repository,timestamp,file,dependencies,direct_dependencies,outdated,outdated_pct,license:BSD-3-Clause,license:GPL-3.0-only,license:MIT,license:unknown\n"+
+		"billing,2026-03-01T08:00:00Z,billing-1.json,1,1,,,1,0,0,0\n"+
+		"shop,2026-03-01T08:00:00Z,shop-1.json,1,1,,,0,0,1,0\n"+
+		"shop,2026-03-02T08:00:00Z,shop-2.json,3,2,1,50,0,1,1,1\n", buf.String())
+}
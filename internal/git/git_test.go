@@ -1,6 +1,88 @@
 package git
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadCommit(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "web"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "web", "package.json"), []byte("{}\n"), 0644))
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = worktree.Add("web/package.json")
+	require.NoError(t, err)
+	hash, err := worktree.Commit("Initial commit", &git.CommitOptions{Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Unix(0, 0)}})
+	require.NoError(t, err)
+
+	assert.Equal(t, hash.String(), HeadCommit(dir))
+	assert.Equal(t, hash.String(), HeadCommit(filepath.Join(dir, "web")), "subdirectories of the repository")
+	assert.Empty(t, HeadCommit(t.TempDir()))
+}
+
+func TestChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	commit := func(files map[string]string) {
+		for name, content := range files {
+			path := filepath.Join(dir, filepath.FromSlash(name))
+			if content == "" {
+				_, err := worktree.Remove(name)
+				require.NoError(t, err)
+				continue
+			}
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+			require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+			_, err := worktree.Add(name)
+			require.NoError(t, err)
+		}
+		_, err := worktree.Commit("Change", &git.CommitOptions{Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Unix(0, 0)}})
+		require.NoError(t, err)
+	}
+
+	commit(map[string]string{"package.json": "{}\n", "web/package.json": "{}\n", "api/go.mod": "module api\n"})
+	_, err = repo.CreateTag("v1", mustHead(t, repo), nil)
+	require.NoError(t, err)
+	commit(map[string]string{"web/package.json": `{"name":"web"}` + "\n", "api/go.mod": "", "web/yarn.lock": "# lock\n"})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), []byte("uncommitted\n"), 0644))
+
+	files, err := ChangedFiles(dir, "v1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api/go.mod", "web/package.json", "web/yarn.lock"}, files, "uncommitted changes are not included")
+
+	files, err = ChangedFiles(filepath.Join(dir, "web"), "HEAD~1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"package.json", "yarn.lock"}, files, "relative to the scanned subdirectory")
+
+	files, err = ChangedFiles(dir, "HEAD")
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	_, err = ChangedFiles(dir, "no-such-branch")
+	assert.ErrorContains(t, err, "cannot resolve no-such-branch")
+	_, err = ChangedFiles(t.TempDir(), "HEAD")
+	assert.ErrorContains(t, err, "not in a git repository")
+}
+
+func mustHead(t *testing.T, repo *git.Repository) plumbing.Hash {
+	head, err := repo.Head()
+	require.NoError(t, err)
+	return head.Hash()
+}
 
 func TestSanitizeRemoteURL(t *testing.T) {
 	tests := []struct {
@@ -57,9 +139,9 @@ func TestSanitizeRemoteURL(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := sanitizeRemoteURL(tt.input)
+			got := SanitizeRemoteURL(tt.input)
 			if got != tt.expected {
-				t.Errorf("sanitizeRemoteURL(%q) = %q, want %q", tt.input, got, tt.expected)
+				t.Errorf("SanitizeRemoteURL(%q) = %q, want %q", tt.input, got, tt.expected)
 			}
 		})
 	}
@@ -2,7 +2,11 @@ package git
 
 import (
 	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -19,9 +23,13 @@ func loadPatternsFromGitignore(gitignorePath string) ([]string, error) {
 		return nil, fmt.Errorf("failed to read .gitignore: %w", err)
 	}
 	defer file.Close()
+	return parseGitignorePatterns(file)
+}
 
+// parseGitignorePatterns reads the patterns of .gitignore content
+func parseGitignorePatterns(r io.Reader) ([]string, error) {
 	var patterns []string
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 
@@ -149,6 +157,8 @@ type StackBasedLoader struct {
 	logger   *slog.Logger
 	stack    *GitignoreStack
 	basePath string // Store base path for .git/info/exclude
+
+	readFile func(path string) ([]byte, error) // Reads .gitignore files (nil = os.ReadFile)
 }
 
 // NewStackBasedLoader creates a new stack-based gitignore loader
@@ -215,18 +225,33 @@ func (l *StackBasedLoader) InitializeWithTopLevelExcludes(basePath string, exclu
 	return nil
 }
 
+// SetFileReader sets the function reading .gitignore files, e.g. to read them through the file
+// provider of a scan
+func (l *StackBasedLoader) SetFileReader(readFile func(path string) ([]byte, error)) {
+	l.readFile = readFile
+}
+
 // LoadAndPushGitignore loads .gitignore for directory and pushes to stack if found
 // Returns true if .gitignore was found and loaded successfully
 func (l *StackBasedLoader) LoadAndPushGitignore(directory string) bool {
 	gitignorePath := filepath.Join(directory, ".gitignore")
 
-	// Check if .gitignore exists in this directory
-	if _, err := os.Stat(gitignorePath); os.IsNotExist(err) {
+	readFile := l.readFile
+	if readFile == nil {
+		readFile = os.ReadFile
+	}
+	content, err := readFile(gitignorePath)
+	if errors.Is(err, fs.ErrNotExist) {
 		return false // No .gitignore in this directory
 	}
 
 	// Load patterns from .gitignore
-	patterns, err := loadPatternsFromGitignore(gitignorePath)
+	var patterns []string
+	if err != nil {
+		err = fmt.Errorf("failed to read .gitignore: %w", err)
+	} else {
+		patterns, err = parseGitignorePatterns(bytes.NewReader(content))
+	}
 	if err != nil {
 		l.log().logError(gitignorePath, err)
 		return false
@@ -190,3 +190,20 @@ Thumbs.db
 	require.NoError(t, err)
 	assert.Equal(t, expected, patterns)
 }
+
+func TestStackBasedLoader_SetFileReader(t *testing.T) {
+	files := map[string]string{filepath.Join("/repo", "app", ".gitignore"): "dist/\n*.log\n"}
+	loader := NewStackBasedLoader()
+	loader.SetFileReader(func(path string) ([]byte, error) {
+		if content, ok := files[path]; ok {
+			return []byte(content), nil
+		}
+		return nil, os.ErrNotExist
+	})
+
+	assert.False(t, loader.LoadAndPushGitignore("/repo"))
+	require.True(t, loader.LoadAndPushGitignore(filepath.Join("/repo", "app")))
+	assert.True(t, loader.ShouldExclude("debug.log", "app/debug.log"))
+	assert.True(t, loader.ShouldExclude("dist", "app/dist"))
+	assert.False(t, loader.ShouldExclude("main.go", "app/main.go"))
+}
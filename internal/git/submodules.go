@@ -0,0 +1,53 @@
+package git
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// SubmoduleCommits returns the commits the repository at repoPath pins its submodules to, by
+// submodule path (relative to the repository root). The commits are read from the index, or
+// from the HEAD tree when the repository has no index. Returns nil if repoPath is not the root
+// of a git repository.
+func SubmoduleCommits(repoPath string) map[string]string {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil
+	}
+
+	commits := make(map[string]string)
+	if idx, err := repo.Storer.Index(); err == nil && len(idx.Entries) > 0 {
+		for _, entry := range idx.Entries {
+			if entry.Mode == filemode.Submodule {
+				commits[entry.Name] = entry.Hash.String()
+			}
+		}
+		return commits
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return commits
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return commits
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return commits
+	}
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+	for {
+		name, entry, err := walker.Next()
+		if err != nil {
+			break
+		}
+		if entry.Mode == filemode.Submodule {
+			commits[name] = entry.Hash.String()
+		}
+	}
+	return commits
+}
@@ -0,0 +1,71 @@
+package git
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSubmoduleCommit = "1eea5bae12ae557d589f9f0f0edae2faa47cb262"
+	testStagedCommit    = "dbb6104ce834628e473d2173bbc9d47f81a9eec3"
+)
+
+// commitSubmoduleTree commits a tree with a submodule entry at lib/forge-std and points HEAD at it
+func commitSubmoduleTree(t *testing.T, repo *git.Repository) {
+	t.Helper()
+	store := func(obj interface {
+		Encode(plumbing.EncodedObject) error
+	}) plumbing.Hash {
+		encoded := repo.Storer.NewEncodedObject()
+		require.NoError(t, obj.Encode(encoded))
+		hash, err := repo.Storer.SetEncodedObject(encoded)
+		require.NoError(t, err)
+		return hash
+	}
+	lib := store(&object.Tree{Entries: []object.TreeEntry{
+		{Name: "forge-std", Mode: filemode.Submodule, Hash: plumbing.NewHash(testSubmoduleCommit)},
+	}})
+	root := store(&object.Tree{Entries: []object.TreeEntry{{Name: "lib", Mode: filemode.Dir, Hash: lib}}})
+	signature := object.Signature{Name: "test", Email: "test@example.com", When: time.Unix(0, 0)}
+	commit := store(&object.Commit{Author: signature, Committer: signature, Message: "init", TreeHash: root})
+	require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("master"), commit)))
+}
+
+func TestSubmoduleCommits_FromHeadTree(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	commitSubmoduleTree(t, repo)
+
+	assert.Equal(t, map[string]string{"lib/forge-std": testSubmoduleCommit}, SubmoduleCommits(dir))
+}
+
+func TestSubmoduleCommits_FromIndex(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	commitSubmoduleTree(t, repo)
+
+	// The index takes precedence over HEAD (a submodule update staged but not committed)
+	idx := &index.Index{Version: 2}
+	entry := idx.Add("lib/forge-std")
+	entry.Mode = filemode.Submodule
+	entry.Hash = plumbing.NewHash(testStagedCommit)
+	readme := idx.Add("README.md")
+	readme.Mode = filemode.Regular
+	require.NoError(t, repo.Storer.SetIndex(idx))
+
+	assert.Equal(t, map[string]string{"lib/forge-std": testStagedCommit}, SubmoduleCommits(dir))
+}
+
+func TestSubmoduleCommits_NotARepository(t *testing.T) {
+	assert.Nil(t, SubmoduleCommits(t.TempDir()))
+}
@@ -0,0 +1,66 @@
+package git
+
+import (
+	"bufio"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// maxSubtreeCommits bounds the number of commits read when looking for git subtree merges
+const maxSubtreeCommits = 5000
+
+// SubtreeSplits returns the directories added with git subtree in the repository at repoPath,
+// mapped to the upstream commit of their latest merge. They are read from the
+// "git-subtree-dir" and "git-subtree-split" trailers of the commit messages, newest first.
+// Returns nil if repoPath is not the root of a git repository.
+func SubtreeSplits(repoPath string) map[string]string {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil
+	}
+	commits, err := repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return nil
+	}
+	defer commits.Close()
+
+	splits := make(map[string]string)
+	count := 0
+	_ = commits.ForEach(func(commit *object.Commit) error {
+		if count++; count > maxSubtreeCommits {
+			return storer.ErrStop
+		}
+		dir, split := subtreeTrailers(commit.Message)
+		if _, seen := splits[dir]; dir != "" && !seen {
+			splits[dir] = split
+		}
+		return nil
+	})
+	return splits
+}
+
+// subtreeTrailers returns the git-subtree-dir and git-subtree-split trailers of a commit message
+func subtreeTrailers(message string) (string, string) {
+	var dir, split string
+	scanner := bufio.NewScanner(strings.NewReader(message))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "git-subtree-dir":
+			dir = strings.Trim(strings.TrimSpace(value), "/")
+		case "git-subtree-split":
+			split = strings.TrimSpace(value)
+		}
+	}
+	return dir, split
+}
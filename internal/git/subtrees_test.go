@@ -0,0 +1,45 @@
+package git
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubtreeSplits(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+
+	// Commits a chain of empty trees with the given messages, oldest first
+	emptyTree := repo.Storer.NewEncodedObject()
+	require.NoError(t, (&object.Tree{}).Encode(emptyTree))
+	treeHash, err := repo.Storer.SetEncodedObject(emptyTree)
+	require.NoError(t, err)
+	var parent []plumbing.Hash
+	for i, message := range []string{
+		"Add 'third_party/zlib/' from commit '04f42ceca40f73e2978b50e93806c2a18c1281fc'\n\ngit-subtree-dir: third_party/zlib\ngit-subtree-mainline: 5a1c3b2\ngit-subtree-split: 04f42ceca40f73e2978b50e93806c2a18c1281fc\n",
+		"Fix build",
+		"Squashed 'third_party/zlib/' changes from 04f42ce..51b7f2a\n\ngit-subtree-dir: third_party/zlib\ngit-subtree-split: 51b7f2abdade71cd9bb0e7a373ef2610ec6f9daf\n",
+	} {
+		signature := object.Signature{Name: "test", Email: "test@example.com", When: time.Unix(int64(i), 0)}
+		encoded := repo.Storer.NewEncodedObject()
+		require.NoError(t, (&object.Commit{Author: signature, Committer: signature, Message: message, TreeHash: treeHash, ParentHashes: parent}).Encode(encoded))
+		hash, err := repo.Storer.SetEncodedObject(encoded)
+		require.NoError(t, err)
+		parent = []plumbing.Hash{hash}
+	}
+	require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("master"), parent[0])))
+
+	// The latest merge wins
+	assert.Equal(t, map[string]string{"third_party/zlib": "51b7f2abdade71cd9bb0e7a373ef2610ec6f9daf"}, SubtreeSplits(dir))
+}
+
+func TestSubtreeSplits_NotARepository(t *testing.T) {
+	assert.Nil(t, SubtreeSplits(t.TempDir()))
+}
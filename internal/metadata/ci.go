@@ -0,0 +1,160 @@
+package metadata
+
+import (
+	"strings"
+)
+
+// CI providers detected from the environment
+const (
+	CIGitHubActions = "github_actions"
+	CIGitLab        = "gitlab"
+	CIJenkins       = "jenkins"
+	CIAzure         = "azure_pipelines"
+	CICircleCI      = "circleci"
+	CIBitbucket     = "bitbucket"
+	CIGeneric       = "generic" // CI=true without a known provider
+)
+
+// CIInfo identifies the CI build that produced a scan
+type CIInfo struct {
+	Provider    string `json:"provider"`
+	Repository  string `json:"repository,omitempty"`
+	Commit      string `json:"commit,omitempty"`
+	Branch      string `json:"branch,omitempty"`
+	Tag         string `json:"tag,omitempty"`
+	PullRequest string `json:"pull_request,omitempty"` // Pull or merge request number
+	PipelineID  string `json:"pipeline_id,omitempty"`  // Workflow run, pipeline, or build ID
+	JobID       string `json:"job_id,omitempty"`
+	Runner      string `json:"runner,omitempty"` // Runner, agent, or node name
+	URL         string `json:"url,omitempty"`    // Link to the pipeline or build
+}
+
+// DetectCI reads the build metadata of the CI provider from standard environment variables
+// (GITHUB_*, CI_*, ...). Returns nil when not running in CI.
+func DetectCI(getenv func(string) string) *CIInfo {
+	switch {
+	case getenv("GITHUB_ACTIONS") == "true":
+		return githubActionsInfo(getenv)
+	case getenv("GITLAB_CI") == "true":
+		return &CIInfo{
+			Provider:    CIGitLab,
+			Repository:  getenv("CI_PROJECT_PATH"),
+			Commit:      getenv("CI_COMMIT_SHA"),
+			Branch:      firstNonEmpty(getenv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME"), getenv("CI_COMMIT_BRANCH")),
+			Tag:         getenv("CI_COMMIT_TAG"),
+			PullRequest: getenv("CI_MERGE_REQUEST_IID"),
+			PipelineID:  getenv("CI_PIPELINE_ID"),
+			JobID:       getenv("CI_JOB_ID"),
+			Runner:      firstNonEmpty(getenv("CI_RUNNER_DESCRIPTION"), getenv("CI_RUNNER_ID")),
+			URL:         firstNonEmpty(getenv("CI_JOB_URL"), getenv("CI_PIPELINE_URL")),
+		}
+	case getenv("JENKINS_URL") != "":
+		return &CIInfo{
+			Provider:    CIJenkins,
+			Repository:  getenv("JOB_NAME"),
+			Commit:      getenv("GIT_COMMIT"),
+			Branch:      firstNonEmpty(getenv("CHANGE_BRANCH"), getenv("BRANCH_NAME"), strings.TrimPrefix(getenv("GIT_BRANCH"), "origin/")),
+			Tag:         getenv("TAG_NAME"),
+			PullRequest: getenv("CHANGE_ID"),
+			PipelineID:  firstNonEmpty(getenv("BUILD_ID"), getenv("BUILD_NUMBER")),
+			Runner:      getenv("NODE_NAME"),
+			URL:         getenv("BUILD_URL"),
+		}
+	case strings.EqualFold(getenv("TF_BUILD"), "true"):
+		info := &CIInfo{
+			Provider:    CIAzure,
+			Repository:  getenv("BUILD_REPOSITORY_NAME"),
+			Commit:      getenv("BUILD_SOURCEVERSION"),
+			PullRequest: getenv("SYSTEM_PULLREQUEST_PULLREQUESTNUMBER"),
+			PipelineID:  getenv("BUILD_BUILDID"),
+			JobID:       getenv("SYSTEM_JOBID"),
+			Runner:      getenv("AGENT_NAME"),
+		}
+		ref := firstNonEmpty(getenv("SYSTEM_PULLREQUEST_SOURCEBRANCH"), getenv("BUILD_SOURCEBRANCH"))
+		info.Branch, info.Tag = splitGitRef(ref)
+		if collection, project := getenv("SYSTEM_COLLECTIONURI"), getenv("SYSTEM_TEAMPROJECT"); collection != "" && project != "" && info.PipelineID != "" {
+			info.URL = strings.TrimSuffix(collection, "/") + "/" + project + "/_build/results?buildId=" + info.PipelineID
+		}
+		return info
+	case getenv("CIRCLECI") == "true":
+		return &CIInfo{
+			Provider:    CICircleCI,
+			Repository:  joinNonEmpty("/", getenv("CIRCLE_PROJECT_USERNAME"), getenv("CIRCLE_PROJECT_REPONAME")),
+			Commit:      getenv("CIRCLE_SHA1"),
+			Branch:      getenv("CIRCLE_BRANCH"),
+			Tag:         getenv("CIRCLE_TAG"),
+			PullRequest: getenv("CIRCLE_PR_NUMBER"),
+			PipelineID:  getenv("CIRCLE_WORKFLOW_ID"),
+			JobID:       getenv("CIRCLE_BUILD_NUM"),
+			URL:         getenv("CIRCLE_BUILD_URL"),
+		}
+	case getenv("BITBUCKET_BUILD_NUMBER") != "":
+		return &CIInfo{
+			Provider:    CIBitbucket,
+			Repository:  getenv("BITBUCKET_REPO_FULL_NAME"),
+			Commit:      getenv("BITBUCKET_COMMIT"),
+			Branch:      getenv("BITBUCKET_BRANCH"),
+			Tag:         getenv("BITBUCKET_TAG"),
+			PullRequest: getenv("BITBUCKET_PR_ID"),
+			PipelineID:  firstNonEmpty(getenv("BITBUCKET_PIPELINE_UUID"), getenv("BITBUCKET_BUILD_NUMBER")),
+			JobID:       getenv("BITBUCKET_STEP_UUID"),
+		}
+	case strings.EqualFold(getenv("CI"), "true") || getenv("CI") == "1":
+		return &CIInfo{Provider: CIGeneric}
+	}
+	return nil
+}
+
+// githubActionsInfo reads the GitHub Actions run metadata
+func githubActionsInfo(getenv func(string) string) *CIInfo {
+	info := &CIInfo{
+		Provider:   CIGitHubActions,
+		Repository: getenv("GITHUB_REPOSITORY"),
+		Commit:     getenv("GITHUB_SHA"),
+		PipelineID: getenv("GITHUB_RUN_ID"),
+		JobID:      getenv("GITHUB_JOB"),
+		Runner:     getenv("RUNNER_NAME"),
+	}
+	ref := getenv("GITHUB_REF")
+	if strings.HasPrefix(ref, "refs/pull/") { // refs/pull/42/merge
+		info.PullRequest = strings.SplitN(strings.TrimPrefix(ref, "refs/pull/"), "/", 2)[0]
+		info.Branch = getenv("GITHUB_HEAD_REF")
+	} else {
+		info.Branch, info.Tag = splitGitRef(ref)
+	}
+	if server := getenv("GITHUB_SERVER_URL"); server != "" && info.Repository != "" && info.PipelineID != "" {
+		info.URL = server + "/" + info.Repository + "/actions/runs/" + info.PipelineID
+	}
+	return info
+}
+
+// splitGitRef returns the branch or tag name of a full git ref (refs/heads/main, refs/tags/v1)
+func splitGitRef(ref string) (branch, tag string) {
+	switch {
+	case strings.HasPrefix(ref, "refs/heads/"):
+		return strings.TrimPrefix(ref, "refs/heads/"), ""
+	case strings.HasPrefix(ref, "refs/tags/"):
+		return "", strings.TrimPrefix(ref, "refs/tags/")
+	default:
+		return ref, ""
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func joinNonEmpty(sep string, values ...string) string {
+	var parts []string
+	for _, value := range values {
+		if value != "" {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, sep)
+}
@@ -0,0 +1,70 @@
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func envFrom(values map[string]string) func(string) string {
+	return func(key string) string { return values[key] }
+}
+
+func TestDetectCI(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected *CIInfo
+	}{
+		{"not in CI", map[string]string{"HOME": "/root"}, nil},
+		{"github actions push", map[string]string{
+			"GITHUB_ACTIONS": "true", "GITHUB_REPOSITORY": "acme/shop", "GITHUB_SHA": "4f1c2a9", "GITHUB_REF": "refs/heads/main",
+			"GITHUB_RUN_ID": "987", "GITHUB_JOB": "scan", "RUNNER_NAME": "runner-1", "GITHUB_SERVER_URL": "https://github.com",
+		}, &CIInfo{
+			Provider: CIGitHubActions, Repository: "acme/shop", Commit: "4f1c2a9", Branch: "main",
+			PipelineID: "987", JobID: "scan", Runner: "runner-1", URL: "https://github.com/acme/shop/actions/runs/987",
+		}},
+		{"github actions pull request", map[string]string{
+			"GITHUB_ACTIONS": "true", "GITHUB_REF": "refs/pull/42/merge", "GITHUB_HEAD_REF": "feature/x",
+		}, &CIInfo{Provider: CIGitHubActions, Branch: "feature/x", PullRequest: "42"}},
+		{"github actions tag", map[string]string{
+			"GITHUB_ACTIONS": "true", "GITHUB_REF": "refs/tags/v1.2.0",
+		}, &CIInfo{Provider: CIGitHubActions, Tag: "v1.2.0"}},
+		{"gitlab merge request", map[string]string{
+			"GITLAB_CI": "true", "CI": "true", "CI_PROJECT_PATH": "acme/shop", "CI_COMMIT_SHA": "abc", "CI_MERGE_REQUEST_SOURCE_BRANCH_NAME": "feature/y",
+			"CI_MERGE_REQUEST_IID": "7", "CI_PIPELINE_ID": "100", "CI_JOB_ID": "200", "CI_RUNNER_DESCRIPTION": "shared",
+			"CI_PIPELINE_URL": "https://gitlab.com/acme/shop/-/pipelines/100", "CI_JOB_URL": "https://gitlab.com/acme/shop/-/jobs/200",
+		}, &CIInfo{
+			Provider: CIGitLab, Repository: "acme/shop", Commit: "abc", Branch: "feature/y", PullRequest: "7",
+			PipelineID: "100", JobID: "200", Runner: "shared", URL: "https://gitlab.com/acme/shop/-/jobs/200",
+		}},
+		{"jenkins", map[string]string{
+			"JENKINS_URL": "https://ci.acme.com/", "JOB_NAME": "shop", "GIT_COMMIT": "def", "GIT_BRANCH": "origin/main",
+			"BUILD_ID": "55", "NODE_NAME": "agent-2", "BUILD_URL": "https://ci.acme.com/job/shop/55/",
+		}, &CIInfo{
+			Provider: CIJenkins, Repository: "shop", Commit: "def", Branch: "main",
+			PipelineID: "55", Runner: "agent-2", URL: "https://ci.acme.com/job/shop/55/",
+		}},
+		{"azure pipelines", map[string]string{
+			"TF_BUILD": "True", "BUILD_REPOSITORY_NAME": "shop", "BUILD_SOURCEVERSION": "123", "BUILD_SOURCEBRANCH": "refs/heads/release",
+			"BUILD_BUILDID": "9", "AGENT_NAME": "Hosted Agent", "SYSTEM_COLLECTIONURI": "https://dev.azure.com/acme/", "SYSTEM_TEAMPROJECT": "Shop",
+		}, &CIInfo{
+			Provider: CIAzure, Repository: "shop", Commit: "123", Branch: "release", PipelineID: "9", Runner: "Hosted Agent",
+			URL: "https://dev.azure.com/acme/Shop/_build/results?buildId=9",
+		}},
+		{"circleci", map[string]string{
+			"CIRCLECI": "true", "CI": "true", "CIRCLE_PROJECT_USERNAME": "acme", "CIRCLE_PROJECT_REPONAME": "shop", "CIRCLE_SHA1": "789",
+			"CIRCLE_BRANCH": "main", "CIRCLE_WORKFLOW_ID": "wf-1", "CIRCLE_BUILD_NUM": "12",
+		}, &CIInfo{Provider: CICircleCI, Repository: "acme/shop", Commit: "789", Branch: "main", PipelineID: "wf-1", JobID: "12"}},
+		{"bitbucket", map[string]string{
+			"BITBUCKET_BUILD_NUMBER": "3", "BITBUCKET_REPO_FULL_NAME": "acme/shop", "BITBUCKET_COMMIT": "aaa", "BITBUCKET_BRANCH": "main",
+		}, &CIInfo{Provider: CIBitbucket, Repository: "acme/shop", Commit: "aaa", Branch: "main", PipelineID: "3"}},
+		{"generic", map[string]string{"CI": "true"}, &CIInfo{Provider: CIGeneric}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DetectCI(envFrom(tt.env)))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package metadata
 
 import (
+	"os"
 	"path/filepath"
 	"time"
 )
@@ -19,9 +20,42 @@ type ScanMetadata struct {
 	TechCount      int                    `json:"tech_count,omitempty"`     // Number of primary technologies
 	TechsCount     int                    `json:"techs_count,omitempty"`    // Number of all detected technologies
 	Properties     map[string]interface{} `json:"properties,omitempty"`
+	CI             *CIInfo                `json:"ci,omitempty"`            // Build that produced the scan (when running in CI)
+	Incremental    *IncrementalInfo       `json:"incremental,omitempty"`   // Set when the result merges a rescan into a base result
+	TargetEnv      *TargetEnvInfo         `json:"target_env,omitempty"`    // Set when the dependencies are evaluated for a target environment
+	ProdOnly       *ProdOnlyInfo          `json:"prod_only,omitempty"`     // Set when the dev, test, and build dependencies are left out
+	ImageArchive   *ImageArchiveInfo      `json:"image_archive,omitempty"` // Set when the findings are attributed to the layers of a container image
 }
 
-// NewScanMetadata creates a new scan metadata instance
+// TargetEnvInfo describes the environment the dependencies of the scan are evaluated for
+type TargetEnvInfo struct {
+	Spec                 string `json:"spec"`                  // Target environment ("os=linux,arch=amd64")
+	ExcludedDependencies int    `json:"excluded_dependencies"` // Dependencies not installed in the environment
+}
+
+// IncrementalInfo describes an incremental scan: the directories rescanned because their
+// manifests or lock files changed, merged into a base result
+type IncrementalInfo struct {
+	ChangedSince  string   `json:"changed_since"`            // Git ref the changes were computed against
+	BaseTimestamp string   `json:"base_timestamp,omitempty"` // Scan time of the base result
+	ChangedFiles  int      `json:"changed_files"`            // Files changed since the ref
+	Rescanned     []string `json:"rescanned"`                // Rescanned directories ("/" = full scan)
+}
+
+// ProdOnlyInfo describes a production-only inventory (--prod-only)
+type ProdOnlyInfo struct {
+	ExcludedDependencies int `json:"excluded_dependencies"` // Dev, test, and build dependencies left out
+}
+
+// ImageArchiveInfo describes the container image the findings are attributed to (--image-archive)
+type ImageArchiveInfo struct {
+	Path                   string `json:"path"`                    // Extracted docker save archive
+	Layers                 int    `json:"layers"`                  // Layers of the image
+	AttributedDependencies int    `json:"attributed_dependencies"` // Dependencies attributed to a layer
+}
+
+// NewScanMetadata creates a new scan metadata instance, including the CI build metadata when
+// running in CI
 func NewScanMetadata(scanPath string, version string) *ScanMetadata {
 	absPath, _ := filepath.Abs(scanPath)
 
@@ -30,6 +64,7 @@ func NewScanMetadata(scanPath string, version string) *ScanMetadata {
 		Timestamp:   time.Now().UTC().Format(time.RFC3339),
 		ScanPath:    absPath,
 		SpecVersion: version,
+		CI:          DetectCI(os.Getenv),
 	}
 }
 
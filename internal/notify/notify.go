@@ -0,0 +1,214 @@
+// Package notify posts a summary of a completed scan to chat webhooks (Slack, Microsoft
+// Teams) so CI pipelines and scheduled scans can report findings without extra tooling.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/analysis"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// DefaultTimeout is the timeout for posting a notification
+const DefaultTimeout = 10 * time.Second
+
+// Webhook payload formats
+const (
+	FormatSlack = "slack" // Slack incoming webhooks and compatible services (Mattermost, Rocket.Chat)
+	FormatTeams = "teams" // Microsoft Teams workflows and connectors (Adaptive Card)
+)
+
+// Notification thresholds: a notification is posted if the scan has a finding at or above the level
+const (
+	NotifyAlways  = "always"
+	NotifyNote    = "note"
+	NotifyWarning = "warning"
+	NotifyError   = "error"
+)
+
+// maxListedFindings limits the findings quoted in a notification
+const maxListedFindings = 5
+
+// levelRank orders finding levels by severity
+var levelRank = map[string]int{
+	analysis.LevelNote:    1,
+	analysis.LevelWarning: 2,
+	analysis.LevelError:   3,
+}
+
+// Summary is the content of a scan notification
+type Summary struct {
+	Project      string
+	Branch       string
+	Commit       string
+	Components   int
+	Dependencies int            // Unique dependencies by type and name
+	Technologies int            // Unique technologies
+	Findings     map[string]int // Finding level -> count
+	CopyleftRisk string         // Empty if no copyleft dependency was found
+	Top          []string       // Most severe findings, at most maxListedFindings
+}
+
+// BuildSummary summarizes the payload tree and the findings of its analyses. Analyses must
+// have run on the payload before.
+func BuildSummary(payload *types.Payload) *Summary {
+	summary := &Summary{Project: payload.Name, Findings: make(map[string]int)}
+	if payload.Git != nil {
+		summary.Branch = payload.Git.Branch
+		summary.Commit = payload.Git.Commit
+		if name := strings.TrimSuffix(path.Base(strings.TrimSuffix(payload.Git.RemoteURL, "/")), ".git"); name != "" && name != "." {
+			summary.Project = name
+		}
+	}
+
+	dependencies := make(map[string]bool)
+	techs := make(map[string]bool)
+	var walk func(*types.Payload)
+	walk = func(component *types.Payload) {
+		if component != payload {
+			summary.Components++
+		}
+		for _, dep := range component.Dependencies {
+			dependencies[dep.Type+":"+dep.Name] = true
+		}
+		for _, tech := range component.Techs {
+			techs[tech] = true
+		}
+		for _, child := range component.Children {
+			walk(child)
+		}
+	}
+	walk(payload)
+	summary.Dependencies = len(dependencies)
+	summary.Technologies = len(techs)
+
+	if report, ok := payload.Analysis.(*analysis.Report); ok && report != nil && report.Copyleft != nil {
+		summary.CopyleftRisk = report.Copyleft.Risk
+	}
+
+	findings := analysis.BuildFindings(payload)
+	for _, finding := range findings {
+		summary.Findings[finding.Level]++
+	}
+	for _, level := range []string{analysis.LevelError, analysis.LevelWarning, analysis.LevelNote} {
+		for _, finding := range findings {
+			if finding.Level == level && len(summary.Top) < maxListedFindings {
+				summary.Top = append(summary.Top, fmt.Sprintf("[%s] %s", finding.Level, finding.Message))
+			}
+		}
+	}
+	return summary
+}
+
+// ShouldNotify reports whether the summary reaches the notification threshold
+func ShouldNotify(summary *Summary, threshold string) bool {
+	if threshold == "" || threshold == NotifyAlways {
+		return true
+	}
+	for level, count := range summary.Findings {
+		if count > 0 && levelRank[level] >= levelRank[threshold] {
+			return true
+		}
+	}
+	return false
+}
+
+// DetectFormat returns the payload format for a webhook URL: Teams for Microsoft hosts,
+// Slack for everything else
+func DetectFormat(webhookURL string) string {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil {
+		return FormatSlack
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if strings.HasSuffix(host, ".office.com") || strings.HasSuffix(host, ".office365.com") || strings.HasSuffix(host, ".logic.azure.com") {
+		return FormatTeams
+	}
+	return FormatSlack
+}
+
+// Lines renders the summary as plain text lines (title first)
+func (s *Summary) Lines() []string {
+	title := "Stack analysis: " + s.Project
+	if s.Branch != "" {
+		title += " (" + s.Branch
+		if s.Commit != "" {
+			title += " @ " + shortCommit(s.Commit)
+		}
+		title += ")"
+	}
+
+	lines := []string{
+		title,
+		fmt.Sprintf("Components: %d | Dependencies: %d | Technologies: %d", s.Components, s.Dependencies, s.Technologies),
+		fmt.Sprintf("Findings: %d errors, %d warnings, %d notes", s.Findings[analysis.LevelError], s.Findings[analysis.LevelWarning], s.Findings[analysis.LevelNote]),
+	}
+	if s.CopyleftRisk != "" {
+		lines = append(lines, "Copyleft risk: "+s.CopyleftRisk)
+	}
+	return append(lines, s.Top...)
+}
+
+// Message builds the webhook payload of a summary in the given format
+func Message(summary *Summary, format string) interface{} {
+	lines := summary.Lines()
+	if format == FormatTeams {
+		body := []map[string]interface{}{
+			{"type": "TextBlock", "text": lines[0], "weight": "Bolder", "size": "Medium", "wrap": true},
+		}
+		for _, line := range lines[1:] {
+			body = append(body, map[string]interface{}{"type": "TextBlock", "text": line, "wrap": true, "spacing": "Small"})
+		}
+		return map[string]interface{}{
+			"type": "message",
+			"attachments": []map[string]interface{}{{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]interface{}{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body":    body,
+				},
+			}},
+		}
+	}
+
+	return map[string]interface{}{"text": "*" + lines[0] + "*\n" + strings.Join(lines[1:], "\n")}
+}
+
+// Post sends the summary to the webhook in the format detected from its URL. Errors do not
+// include the URL, which contains the webhook secret.
+func Post(client *http.Client, webhookURL string, summary *Summary) error {
+	body, err := json.Marshal(Message(summary, DetectFormat(webhookURL)))
+	if err != nil {
+		return err
+	}
+	resp, err := client.Post(webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook request failed: status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 8 {
+		return commit[:8]
+	}
+	return commit
+}
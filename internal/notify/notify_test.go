@@ -0,0 +1,117 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/analysis"
+	"github.com/petrarca/tech-stack-analyzer/internal/git"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scannedTree() *types.Payload {
+	root := types.NewPayloadWithPath("main", "/")
+	root.Git = &git.GitInfo{Branch: "main", Commit: "0123456789abcdef", RemoteURL: "https://github.com/acme/shop.git"}
+
+	web := types.NewPayloadWithPath("web", "/web/package.json")
+	web.Techs = []string{"nodejs", "react"}
+	web.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "react", Version: "18.2.0", Scope: types.ScopeProd, Direct: true},
+		{Type: "npm", Name: "left-pad", Version: "*", Scope: types.ScopeProd, Direct: true},
+		{Type: "npm", Name: "gpl-lib", Version: "1.0.0", Scope: types.ScopeProd, Direct: true, Metadata: map[string]interface{}{parsers.MetadataLicenseDeclared: "GPL-3.0-only"}},
+	}
+	api := types.NewPayloadWithPath("api", "/api/go.mod")
+	api.Techs = []string{"golang"}
+	api.Dependencies = []types.Dependency{{Type: "golang", Name: "github.com/spf13/cobra", Version: "v1.8.0", Scope: types.ScopeProd, Direct: true}}
+	root.AddChild(web)
+	root.AddChild(api)
+
+	analysis.ReportFor(root).Copyleft = analysis.BuildCopyleftExposure(root)
+	return root
+}
+
+func TestBuildSummary(t *testing.T) {
+	summary := BuildSummary(scannedTree())
+
+	assert.Equal(t, "shop", summary.Project)
+	assert.Equal(t, "main", summary.Branch)
+	assert.Equal(t, 2, summary.Components)
+	assert.Equal(t, 4, summary.Dependencies)
+	assert.Equal(t, 3, summary.Technologies)
+	assert.Equal(t, map[string]int{analysis.LevelError: 1, analysis.LevelWarning: 1}, summary.Findings)
+	assert.Equal(t, analysis.RiskHigh, summary.CopyleftRisk)
+	assert.Equal(t, []string{
+		"[error] gpl-lib 1.0.0 (npm) is licensed GPL-3.0-only (strong_copyleft) and distributed with the product",
+		"[warning] left-pad * (npm) is not pinned (wildcard)",
+	}, summary.Top)
+
+	assert.Equal(t, []string{
+		"Stack analysis: shop (main @ 01234567)",
+		"Components: 2 | Dependencies: 4 | Technologies: 3",
+		"Findings: 1 errors, 1 warnings, 0 notes",
+		"Copyleft risk: high",
+	}, summary.Lines()[:4])
+}
+
+func TestShouldNotify(t *testing.T) {
+	summary := &Summary{Findings: map[string]int{analysis.LevelWarning: 2}}
+
+	assert.True(t, ShouldNotify(summary, ""))
+	assert.True(t, ShouldNotify(summary, NotifyAlways))
+	assert.True(t, ShouldNotify(summary, NotifyNote))
+	assert.True(t, ShouldNotify(summary, NotifyWarning))
+	assert.False(t, ShouldNotify(summary, NotifyError))
+	assert.False(t, ShouldNotify(&Summary{Findings: map[string]int{}}, NotifyNote))
+}
+
+func TestDetectFormat(t *testing.T) {
+	assert.Equal(t, FormatSlack, DetectFormat("https://hooks.slack.com/services/T0/B0/XXXX"))
+	assert.Equal(t, FormatTeams, DetectFormat("https://acme.webhook.office.com/webhookb2/abc"))
+	assert.Equal(t, FormatTeams, DetectFormat("https://prod-01.westus.logic.azure.com/workflows/abc"))
+	assert.Equal(t, FormatSlack, DetectFormat("https://chat.example.com/hooks/abc"))
+}
+
+func TestPost(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(body, &received))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	summary := &Summary{Project: "shop", Findings: map[string]int{}}
+	require.NoError(t, Post(server.Client(), server.URL, summary))
+	assert.Equal(t, "*Stack analysis: shop*\nComponents: 0 | Dependencies: 0 | Technologies: 0\nFindings: 0 errors, 0 warnings, 0 notes", received["text"])
+}
+
+func TestPost_Failure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	err := Post(server.Client(), server.URL+"/secret-token", &Summary{Findings: map[string]int{}})
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret-token")
+}
+
+func TestMessage_Teams(t *testing.T) {
+	message := Message(&Summary{Project: "shop", Findings: map[string]int{}}, FormatTeams).(map[string]interface{})
+
+	assert.Equal(t, "message", message["type"])
+	attachments := message["attachments"].([]map[string]interface{})
+	require.Len(t, attachments, 1)
+	assert.Equal(t, "application/vnd.microsoft.card.adaptive", attachments[0]["contentType"])
+	body := attachments[0]["content"].(map[string]interface{})["body"].([]map[string]interface{})
+	assert.Len(t, body, 3)
+	assert.Equal(t, "Stack analysis: shop", body[0]["text"])
+}
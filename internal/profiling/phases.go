@@ -0,0 +1,147 @@
+package profiling
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Phases of a scan. Phases with a "/" are parts of the phase before it (e.g. "parse/nodejs").
+const (
+	PhaseInit      = "init"      // Rule loading and scanner setup
+	PhaseWalk      = "walk"      // Directory listing, .gitignore handling, and exclusion
+	PhaseParse     = "parse"     // Component detectors (manifests and lock files), by detector
+	PhaseDetect    = "detect"    // Rule matching on file names, extensions, content, and licenses
+	PhaseLanguages = "languages" // Language detection and code statistics of files
+	PhaseGit       = "git"       // Git repository information
+	PhaseResolve   = "resolve"   // Post-processing of the component tree (IDs, references)
+	PhaseEnrich    = "enrich"    // Analyses, including package registry lookups
+	PhaseRender    = "render"    // Output and report files
+)
+
+// maxParts bounds the parts listed per phase in the report
+const maxParts = 10
+
+// Phases accumulates the time spent per phase. A nil *Phases measures nothing, so callers do not
+// need to check whether timing is enabled.
+type Phases struct {
+	mu        sync.Mutex
+	durations map[string]time.Duration
+	order     []string // Phases in order of first measurement
+}
+
+// NewPhases creates an empty phase timer
+func NewPhases() *Phases {
+	return &Phases{durations: make(map[string]time.Duration)}
+}
+
+// noop is returned by Start on a nil *Phases
+func noop() {}
+
+// Start starts measuring a phase and returns the function ending the measurement
+func (p *Phases) Start(phase string) func() {
+	if p == nil {
+		return noop
+	}
+	start := time.Now()
+	return func() { p.Add(phase, time.Since(start)) }
+}
+
+// Add adds time spent in a phase
+func (p *Phases) Add(phase string, d time.Duration) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.durations[phase]; !ok {
+		p.order = append(p.order, phase)
+	}
+	p.durations[phase] += d
+}
+
+// Duration returns the time spent in a phase, including its parts
+func (p *Phases) Duration(phase string) time.Duration {
+	if p == nil {
+		return 0
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	total := p.durations[phase]
+	for name, d := range p.durations {
+		if strings.HasPrefix(name, phase+"/") {
+			total += d
+		}
+	}
+	return total
+}
+
+// Report writes the time per phase in order of first measurement with its share of total, and
+// the slowest parts of each phase
+func (p *Phases) Report(w io.Writer, total time.Duration) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	var phases []string
+	parts := make(map[string][]string)
+	for _, name := range p.order {
+		phase, _, isPart := strings.Cut(name, "/")
+		if !containsString(phases, phase) {
+			phases = append(phases, phase)
+		}
+		if isPart {
+			parts[phase] = append(parts[phase], name)
+		}
+	}
+	durations := make(map[string]time.Duration, len(p.durations))
+	for name, d := range p.durations {
+		durations[name] = d
+	}
+	p.mu.Unlock()
+
+	fmt.Fprintf(w, "Phase timings (total %s):\n", round(total))
+	for _, phase := range phases {
+		d := durations[phase]
+		for _, part := range parts[phase] {
+			d += durations[part]
+		}
+		fmt.Fprintf(w, "  %-16s %10s %6s\n", phase, round(d), share(d, total))
+
+		names := parts[phase]
+		sort.SliceStable(names, func(i, j int) bool { return durations[names[i]] > durations[names[j]] })
+		for i, name := range names {
+			if i == maxParts {
+				fmt.Fprintf(w, "    %d more\n", len(names)-maxParts)
+				break
+			}
+			fmt.Fprintf(w, "    %-14s %10s %6s\n", strings.TrimPrefix(name, phase+"/"), round(durations[name]), share(durations[name], total))
+		}
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func round(d time.Duration) time.Duration {
+	if d >= time.Second {
+		return d.Round(time.Millisecond)
+	}
+	return d.Round(time.Microsecond)
+}
+
+func share(d, total time.Duration) string {
+	if total <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.1f%%", float64(d)*100/float64(total))
+}
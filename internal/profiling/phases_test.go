@@ -0,0 +1,55 @@
+package profiling
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhases_Report(t *testing.T) {
+	phases := NewPhases()
+	phases.Add(PhaseWalk, 100*time.Millisecond)
+	phases.Add(PhaseParse+"/nodejs", 300*time.Millisecond)
+	phases.Add(PhaseParse+"/golang", 500*time.Millisecond)
+	phases.Add(PhaseWalk, 100*time.Millisecond)
+	phases.Add(PhaseRender, 200*time.Millisecond)
+
+	assert.Equal(t, 800*time.Millisecond, phases.Duration(PhaseParse), "phases include their parts")
+	assert.Equal(t, 200*time.Millisecond, phases.Duration(PhaseWalk))
+
+	var out bytes.Buffer
+	phases.Report(&out, 2*time.Second)
+	assert.Equal(t, `Phase timings (total 2s):
+  walk                  200ms  10.0%
+  parse                 800ms  40.0%
+    golang              500ms  25.0%
+    nodejs              300ms  15.0%
+  render                200ms  10.0%
+`, out.String())
+}
+
+func TestPhases_ReportLimitsParts(t *testing.T) {
+	phases := NewPhases()
+	for i := 0; i < maxParts+3; i++ {
+		phases.Add(fmt.Sprintf("%s/detector%02d", PhaseParse, i), time.Duration(i+1)*time.Millisecond)
+	}
+	var out bytes.Buffer
+	phases.Report(&out, time.Second)
+	assert.Contains(t, out.String(), "detector12")
+	assert.NotContains(t, out.String(), "detector00")
+	assert.Contains(t, out.String(), "    3 more\n")
+}
+
+func TestPhases_Nil(t *testing.T) {
+	var phases *Phases
+	phases.Start(PhaseWalk)()
+	phases.Add(PhaseWalk, time.Second)
+	assert.Zero(t, phases.Duration(PhaseWalk))
+
+	var out bytes.Buffer
+	phases.Report(&out, time.Second)
+	assert.Empty(t, out.String())
+}
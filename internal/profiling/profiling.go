@@ -0,0 +1,118 @@
+// Package profiling captures Go runtime profiles of a run (CPU and heap profiles, execution
+// trace) and measures the time spent in the phases of a scan, so performance issues can be
+// reported with actionable data.
+package profiling
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// Profile kinds
+const (
+	ProfileCPU   = "cpu"   // CPU profile (pprof)
+	ProfileMem   = "mem"   // Heap profile at the end of the run (pprof)
+	ProfileTrace = "trace" // Execution trace (go tool trace)
+)
+
+// Profiles lists the supported profile kinds
+var Profiles = []string{ProfileCPU, ProfileMem, ProfileTrace}
+
+// profileFiles are the file names of the profile kinds
+var profileFiles = map[string]string{
+	ProfileCPU:   "stack-analyzer-cpu.pprof",
+	ProfileMem:   "stack-analyzer-mem.pprof",
+	ProfileTrace: "stack-analyzer-trace.out",
+}
+
+// Session is a running capture of profiles
+type Session struct {
+	dir   string
+	mem   bool
+	cpu   *os.File
+	trace *os.File
+	files []string
+}
+
+// Start starts capturing the profile kinds, written to dir when the session is stopped
+func Start(kinds []string, dir string) (*Session, error) {
+	s := &Session{dir: dir}
+	for _, kind := range kinds {
+		if _, ok := profileFiles[kind]; !ok {
+			return nil, fmt.Errorf("unknown profile %q (supported: cpu, mem, trace)", kind)
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	for _, kind := range kinds {
+		var err error
+		switch kind {
+		case ProfileCPU:
+			if s.cpu == nil {
+				if s.cpu, err = s.create(kind); err == nil {
+					err = pprof.StartCPUProfile(s.cpu)
+				}
+			}
+		case ProfileTrace:
+			if s.trace == nil {
+				if s.trace, err = s.create(kind); err == nil {
+					err = trace.Start(s.trace)
+				}
+			}
+		case ProfileMem:
+			s.mem = true
+		}
+		if err != nil {
+			s.Stop()
+			return nil, fmt.Errorf("failed to start %s profile: %w", kind, err)
+		}
+	}
+	return s, nil
+}
+
+// Stop stops the capture, writes the heap profile, and returns the files written
+func (s *Session) Stop() ([]string, error) {
+	var firstErr error
+	keep := func(err error) {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if s.cpu != nil {
+		pprof.StopCPUProfile()
+		keep(s.cpu.Close())
+		s.cpu = nil
+	}
+	if s.trace != nil {
+		trace.Stop()
+		keep(s.trace.Close())
+		s.trace = nil
+	}
+	if s.mem {
+		s.mem = false
+		file, err := s.create(ProfileMem)
+		if err == nil {
+			runtime.GC() // Up-to-date statistics of the live heap
+			keep(pprof.WriteHeapProfile(file))
+			keep(file.Close())
+		}
+		keep(err)
+	}
+	return s.files, firstErr
+}
+
+func (s *Session) create(kind string) (*os.File, error) {
+	path := filepath.Join(s.dir, profileFiles[kind])
+	file, err := os.Create(path)
+	if err == nil {
+		s.files = append(s.files, path)
+	}
+	return file, err
+}
@@ -0,0 +1,42 @@
+package profiling
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "profiles")
+	session, err := Start([]string{ProfileCPU, ProfileMem, ProfileTrace}, dir)
+	require.NoError(t, err)
+
+	files, err := session.Stop()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "stack-analyzer-cpu.pprof"),
+		filepath.Join(dir, "stack-analyzer-trace.out"),
+		filepath.Join(dir, "stack-analyzer-mem.pprof"),
+	}, files)
+	for _, file := range files {
+		info, err := os.Stat(file)
+		require.NoError(t, err)
+		assert.NotZero(t, info.Size(), file)
+	}
+
+	files, err = session.Stop()
+	require.NoError(t, err)
+	assert.Len(t, files, 3, "stopping twice writes nothing new")
+}
+
+func TestStart_UnknownProfile(t *testing.T) {
+	dir := t.TempDir()
+	_, err := Start([]string{ProfileCPU, "block"}, dir)
+	assert.ErrorContains(t, err, `unknown profile "block"`)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "nothing is started for invalid kinds")
+}
@@ -88,3 +88,8 @@ func (p *FakeProvider) IsDir(path string) (bool, error) {
 	_, exists := p.files[path]
 	return exists, nil
 }
+
+// GetBasePath returns the base path for this provider
+func (p *FakeProvider) GetBasePath() string {
+	return "/"
+}
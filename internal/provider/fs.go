@@ -1,30 +1,61 @@
 package provider
 
 import (
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 )
 
-// FSProvider implements the Provider interface for local file systems
+// FSProvider implements the Provider interface for local file systems.
+//
+// Paths inside the root directory are resolved relative to it, one component at a time: symbolic
+// links leaving the root are not followed (their targets cannot be read), and paths beyond the
+// platform path length limit (PATH_MAX) can be read. Paths outside the root (e.g. explicit parent
+// references) are accessed directly.
 type FSProvider struct {
 	rootPath string
+
+	rootOnce sync.Once
+	root     *os.Root // nil if the root directory cannot be opened
+	realRoot string   // Root directory with symbolic links resolved
 }
 
 // NewFSProvider creates a new file system provider
 func NewFSProvider(rootPath string) *FSProvider {
 	return &FSProvider{
-		rootPath: strings.TrimSuffix(rootPath, "/"),
+		rootPath: strings.TrimRight(rootPath, `/`+string(filepath.Separator)),
 	}
 }
 
-// ListDir returns the contents of a directory
+// ListDir returns the contents of a directory. Symbolic links are listed with the details of
+// their target. Entries whose details cannot be read (dangling links, links leaving the root) are
+// listed as files without size and modification time, so reading them reports the error.
 func (p *FSProvider) ListDir(path string) ([]types.File, error) {
 	fullPath := p.getFullPath(path)
 
-	entries, err := os.ReadDir(fullPath)
+	var entries []os.DirEntry
+	var err error
+	if root, rel, ok := p.inRoot(fullPath); ok {
+		var dir *os.File
+		if dir, err = root.Open(rel); err == nil {
+			entries, err = dir.ReadDir(-1)
+			dir.Close()
+			sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+		}
+		if err != nil {
+			err = p.retryResolved(fullPath, err, func(real string) (err error) {
+				entries, err = os.ReadDir(real)
+				return err
+			})
+		}
+	} else {
+		entries, err = os.ReadDir(fullPath)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -32,23 +63,29 @@ func (p *FSProvider) ListDir(path string) ([]types.File, error) {
 	files := make([]types.File, 0, len(entries))
 
 	for _, entry := range entries {
-		info, err := entry.Info()
-		if err != nil {
-			continue // Skip entries we can't get info for
-		}
-
 		fileType := "file"
 		if entry.IsDir() {
 			fileType = "dir"
 		}
 
-		files = append(files, types.File{
-			Name:     entry.Name(),
-			Path:     filepath.Join(path, entry.Name()),
-			Type:     fileType,
-			Size:     info.Size(),
-			Modified: info.ModTime().Unix(),
-		})
+		file := types.File{
+			Name:    entry.Name(),
+			Path:    filepath.Join(path, entry.Name()),
+			Type:    fileType,
+			Symlink: entry.Type()&fs.ModeSymlink != 0,
+		}
+		info, err := entry.Info()
+		if file.Symlink {
+			info, err = p.stat(filepath.Join(fullPath, entry.Name()))
+		}
+		if err == nil {
+			if info.IsDir() {
+				file.Type = "dir"
+			}
+			file.Size = info.Size()
+			file.Modified = info.ModTime().Unix()
+		}
+		files = append(files, file)
 	}
 
 	return files, nil
@@ -56,9 +93,7 @@ func (p *FSProvider) ListDir(path string) ([]types.File, error) {
 
 // Open returns the content of a file
 func (p *FSProvider) Open(path string) (string, error) {
-	fullPath := p.getFullPath(path)
-
-	content, err := os.ReadFile(fullPath)
+	content, err := p.ReadFile(path)
 	if err != nil {
 		return "", err
 	}
@@ -69,13 +104,22 @@ func (p *FSProvider) Open(path string) (string, error) {
 // ReadFile reads file content as bytes
 func (p *FSProvider) ReadFile(path string) ([]byte, error) {
 	fullPath := p.getFullPath(path)
+	if root, rel, ok := p.inRoot(fullPath); ok {
+		content, err := root.ReadFile(rel)
+		if err != nil {
+			err = p.retryResolved(fullPath, err, func(real string) (err error) {
+				content, err = os.ReadFile(real)
+				return err
+			})
+		}
+		return content, err
+	}
 	return os.ReadFile(fullPath)
 }
 
 // Exists checks if a file or directory exists
 func (p *FSProvider) Exists(path string) (bool, error) {
-	fullPath := p.getFullPath(path)
-	_, err := os.Stat(fullPath)
+	_, err := p.stat(p.getFullPath(path))
 	if err == nil {
 		return true, nil
 	}
@@ -87,17 +131,31 @@ func (p *FSProvider) Exists(path string) (bool, error) {
 
 // IsDir checks if a path is a directory
 func (p *FSProvider) IsDir(path string) (bool, error) {
-	fullPath := p.getFullPath(path)
-	info, err := os.Stat(fullPath)
+	info, err := p.stat(p.getFullPath(path))
 	if err != nil {
 		return false, err
 	}
 	return info.IsDir(), nil
 }
 
+// stat returns the details of a file, following symbolic links inside the root
+func (p *FSProvider) stat(fullPath string) (fs.FileInfo, error) {
+	if root, rel, ok := p.inRoot(fullPath); ok {
+		info, err := root.Stat(rel)
+		if err != nil {
+			err = p.retryResolved(fullPath, err, func(real string) (err error) {
+				info, err = os.Stat(real)
+				return err
+			})
+		}
+		return info, err
+	}
+	return os.Stat(fullPath)
+}
+
 // getFullPath converts a relative path to an absolute path
 func (p *FSProvider) getFullPath(path string) string {
-	if strings.HasPrefix(path, "/") {
+	if filepath.IsAbs(path) || strings.HasPrefix(path, "/") {
 		return path
 	}
 
@@ -112,3 +170,39 @@ func (p *FSProvider) getFullPath(path string) string {
 func (p *FSProvider) GetBasePath() string {
 	return p.rootPath
 }
+
+// inRoot returns the root directory and the path relative to it, if the path is inside the root
+func (p *FSProvider) inRoot(fullPath string) (*os.Root, string, bool) {
+	p.rootOnce.Do(func() {
+		p.root, _ = os.OpenRoot(p.rootPath)
+		p.realRoot = p.rootPath
+		if real, err := filepath.EvalSymlinks(p.rootPath); err == nil {
+			p.realRoot = real
+		}
+	})
+	if p.root == nil {
+		return nil, "", false
+	}
+	rel, err := filepath.Rel(p.rootPath, fullPath)
+	if err != nil || !filepath.IsLocal(rel) && rel != "." {
+		return nil, "", false
+	}
+	return p.root, rel, true
+}
+
+// retryResolved retries a failed access inside the root with the resolved path, if the path is
+// a link whose real location is inside the root (e.g. an absolute link the root refuses). Missing
+// paths, and links that cannot be resolved or leave the root, keep their error.
+func (p *FSProvider) retryResolved(fullPath string, err error, access func(real string) error) error {
+	if os.IsNotExist(err) {
+		return err
+	}
+	real, evalErr := filepath.EvalSymlinks(fullPath)
+	if evalErr != nil {
+		return evalErr
+	}
+	if rel, relErr := filepath.Rel(p.realRoot, real); relErr != nil || !filepath.IsLocal(rel) && rel != "." {
+		return err
+	}
+	return access(real)
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"sync"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// RecordingProvider wraps a provider and records the sha256 digest of every file read through
+// it, so the inputs of a scan can be listed after the scan. Files outside the base path are not
+// recorded.
+type RecordingProvider struct {
+	types.Provider
+	mu      sync.Mutex
+	digests map[string]string
+}
+
+// NewRecordingProvider creates a provider recording the files read through p
+func NewRecordingProvider(p types.Provider) *RecordingProvider {
+	return &RecordingProvider{Provider: p, digests: make(map[string]string)}
+}
+
+// Open returns the content of a file and records its digest
+func (p *RecordingProvider) Open(path string) (string, error) {
+	content, err := p.Provider.Open(path)
+	if err == nil {
+		p.record(path, []byte(content))
+	}
+	return content, err
+}
+
+// ReadFile reads file content as bytes and records its digest
+func (p *RecordingProvider) ReadFile(path string) ([]byte, error) {
+	content, err := p.Provider.ReadFile(path)
+	if err == nil {
+		p.record(path, content)
+	}
+	return content, err
+}
+
+// Digests returns the hex sha256 digests of the files read so far by path relative to the
+// base path (slash-separated)
+func (p *RecordingProvider) Digests() map[string]string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	digests := make(map[string]string, len(p.digests))
+	for path, digest := range p.digests {
+		digests[path] = digest
+	}
+	return digests
+}
+
+func (p *RecordingProvider) record(path string, content []byte) {
+	if filepath.IsAbs(path) {
+		if rel, err := filepath.Rel(p.GetBasePath(), path); err == nil {
+			path = rel
+		}
+	}
+	path = filepath.Clean(path)
+	if !filepath.IsLocal(path) {
+		return
+	}
+	path = filepath.ToSlash(path)
+	sum := sha256.Sum256(content)
+	p.mu.Lock()
+	p.digests[path] = hex.EncodeToString(sum[:])
+	p.mu.Unlock()
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/binary"
+	"unicode/utf16"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Byte order marks
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// TextProvider wraps a provider and decodes files starting with a byte order mark, so parsers
+// see plain UTF-8: the UTF-8 BOM is removed and UTF-16 content (common in .NET and Windows
+// tooling) is converted. Other content, including binaries, is returned unchanged.
+type TextProvider struct {
+	types.Provider
+}
+
+// NewTextProvider creates a provider decoding the files read through p
+func NewTextProvider(p types.Provider) *TextProvider {
+	return &TextProvider{Provider: p}
+}
+
+// Open returns the decoded content of a file
+func (p *TextProvider) Open(path string) (string, error) {
+	content, err := p.Provider.Open(path)
+	if err != nil {
+		return content, err
+	}
+	return string(DecodeText([]byte(content))), nil
+}
+
+// ReadFile reads the decoded content of a file
+func (p *TextProvider) ReadFile(path string) ([]byte, error) {
+	content, err := p.Provider.ReadFile(path)
+	if err != nil {
+		return content, err
+	}
+	return DecodeText(content), nil
+}
+
+// DecodeText converts content with a byte order mark to UTF-8 without BOM. UTF-16 in either
+// byte order is decoded (a trailing odd byte is dropped); content without a BOM is returned as is.
+func DecodeText(content []byte) []byte {
+	switch {
+	case bytes.HasPrefix(content, bomUTF8):
+		return content[len(bomUTF8):]
+	case bytes.HasPrefix(content, bomUTF16LE):
+		return decodeUTF16(content[len(bomUTF16LE):], binary.LittleEndian)
+	case bytes.HasPrefix(content, bomUTF16BE):
+		return decodeUTF16(content[len(bomUTF16BE):], binary.BigEndian)
+	default:
+		return content
+	}
+}
+
+func decodeUTF16(content []byte, order binary.ByteOrder) []byte {
+	units := make([]uint16, len(content)/2)
+	for i := range units {
+		units[i] = order.Uint16(content[2*i:])
+	}
+	return []byte(string(utf16.Decode(units)))
+}
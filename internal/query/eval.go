@@ -0,0 +1,211 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Dependency is a dependency with its declaring component
+type Dependency struct {
+	Type              string                 `json:"type"`
+	Name              string                 `json:"name"`
+	Version           string                 `json:"version"`
+	NormalizedVersion string                 `json:"normalized_version,omitempty"` // Canonical form of the version, when it differs
+	Scope             string                 `json:"scope"`
+	Direct            bool                   `json:"direct"`
+	License           string                 `json:"license,omitempty"` // Concluded license, else declared
+	Component         string                 `json:"component"`
+	Path              string                 `json:"path,omitempty"` // Manifest path of the component
+	Metadata          map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Component is a component of the scanned tree
+type Component struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Type      string   `json:"type,omitempty"`
+	Path      []string `json:"path"`
+	Tech      []string `json:"tech"`
+	Techs     []string `json:"techs"`
+	Languages []string `json:"languages"`
+}
+
+// License is a license detected for a component
+type License struct {
+	License       string `json:"license"`
+	Component     string `json:"component"`
+	SourceFile    string `json:"source_file"`
+	DetectionType string `json:"detection_type"`
+}
+
+// Evaluate returns the records of the collection that match all filters, in tree order.
+// With a projected field the non-empty values of that field are returned instead of records.
+func (q *Query) Evaluate(root *types.Payload) []interface{} {
+	results := []interface{}{}
+	if root == nil {
+		return results
+	}
+
+	walkComponents(root, func(p *types.Payload) {
+		for _, record := range records(q.Collection, p) {
+			if !q.match(record) {
+				continue
+			}
+			if q.Field == "" {
+				results = append(results, record)
+				continue
+			}
+			for _, value := range fieldValues(record, q.Field) {
+				if value != "" {
+					results = append(results, value)
+				}
+			}
+		}
+	})
+	return results
+}
+
+// walkComponents calls fn for the payload and all descendants, parents first
+func walkComponents(payload *types.Payload, fn func(*types.Payload)) {
+	fn(payload)
+	for _, child := range payload.Children {
+		walkComponents(child, fn)
+	}
+}
+
+func (q *Query) match(record interface{}) bool {
+	for _, filter := range q.Filters {
+		if !filter.matches(fieldValues(record, filter.Field)) {
+			return false
+		}
+	}
+	return true
+}
+
+// records converts the component to the records of a collection
+func records(collection string, p *types.Payload) []interface{} {
+	path := ""
+	if len(p.Path) > 0 {
+		path = p.Path[0]
+	}
+
+	var result []interface{}
+	switch collection {
+	case CollectionDependencies:
+		for _, dep := range p.Dependencies {
+			license, _ := dep.Metadata[parsers.MetadataLicenseConcluded].(string)
+			if license == "" {
+				license, _ = dep.Metadata[parsers.MetadataLicenseDeclared].(string)
+			}
+			result = append(result, &Dependency{
+				Type: dep.Type, Name: dep.Name, Version: dep.Version, NormalizedVersion: dep.NormalizedVersion, Scope: string(dep.Scope), Direct: dep.Direct,
+				License: license, Component: p.Name, Path: path, Metadata: dep.Metadata,
+			})
+		}
+	case CollectionComponents:
+		languages := make([]string, 0, len(p.Languages))
+		for language := range p.Languages {
+			languages = append(languages, language)
+		}
+		sort.Strings(languages)
+		result = append(result, &Component{
+			ID: p.ID, Name: p.Name, Type: p.ComponentType, Path: p.Path, Tech: p.Tech, Techs: p.Techs, Languages: languages,
+		})
+	case CollectionLicenses:
+		for _, license := range p.Licenses {
+			result = append(result, &License{
+				License: license.LicenseName, Component: p.Name, SourceFile: license.SourceFile, DetectionType: license.DetectionType,
+			})
+		}
+	}
+	return result
+}
+
+// fieldValues returns the values of a record field; list fields return every element and
+// missing fields an empty string, so [scope=] finds dependencies without a scope
+func fieldValues(record interface{}, field string) []string {
+	switch r := record.(type) {
+	case *Dependency:
+		switch field {
+		case "type":
+			return []string{r.Type}
+		case "name":
+			return []string{r.Name}
+		case "version":
+			return []string{r.Version}
+		case "normalized_version":
+			return []string{r.NormalizedVersion}
+		case "scope":
+			return []string{r.Scope}
+		case "direct":
+			return []string{strconv.FormatBool(r.Direct)}
+		case "license":
+			return []string{r.License}
+		case "component":
+			return []string{r.Component}
+		case "path":
+			return []string{r.Path}
+		}
+		return metadataValues(r.Metadata[field])
+	case *Component:
+		switch field {
+		case "id":
+			return []string{r.ID}
+		case "name":
+			return []string{r.Name}
+		case "type":
+			return []string{r.Type}
+		case "path":
+			return orEmpty(r.Path)
+		case "tech":
+			return orEmpty(r.Tech)
+		case "techs":
+			return orEmpty(r.Techs)
+		case "languages":
+			return orEmpty(r.Languages)
+		}
+	case *License:
+		switch field {
+		case "license":
+			return []string{r.License}
+		case "component":
+			return []string{r.Component}
+		case "source_file":
+			return []string{r.SourceFile}
+		case "detection_type":
+			return []string{r.DetectionType}
+		}
+	}
+	return []string{""}
+}
+
+// metadataValues converts a metadata value to strings; lists yield one value per element
+func metadataValues(value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return []string{""}
+	case string:
+		return []string{v}
+	case []string:
+		return orEmpty(v)
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+		return orEmpty(values)
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
+
+func orEmpty(values []string) []string {
+	if len(values) == 0 {
+		return []string{""}
+	}
+	return values
+}
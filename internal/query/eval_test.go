@@ -0,0 +1,79 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func queryTree() *types.Payload {
+	root := types.NewPayloadWithPath("main", "/")
+
+	web := types.NewPayloadWithPath("web", "/web/package.json")
+	web.ComponentType = "nodejs"
+	web.AddPrimaryTech("nodejs")
+	web.AddTech("react", "matched dependency: react")
+	web.AddLanguageWithCount("TypeScript", 10)
+	web.AddLicense(types.License{LicenseName: "MIT", SourceFile: "package.json", DetectionType: "direct"})
+	web.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "react", Version: "18.2.0", Scope: types.ScopeProd, Direct: true, Metadata: map[string]interface{}{"license_declared": "MIT", "source": "package-lock.json"}},
+		{Type: "npm", Name: "gpl-lib", Version: "1.0.0", Scope: types.ScopeProd, Direct: false, Metadata: map[string]interface{}{"license_declared": "MIT", "license_concluded": "GPL-3.0-only"}},
+		{Type: "npm", Name: "vitest", Version: "1.6.0", Scope: types.ScopeDev, Direct: true, Metadata: map[string]interface{}{"license_declared": "LGPL-2.1-only", "groups": []interface{}{"test", "lint"}}},
+	}
+
+	api := types.NewPayloadWithPath("api", "/api/pom.xml")
+	api.AddPrimaryTech("java")
+	api.Dependencies = []types.Dependency{
+		{Type: "maven", Name: "junit:junit", Version: "4.13", NormalizedVersion: "4.13.0", Scope: types.ScopeTest, Direct: true},
+		{Type: "docker", Name: "postgres", Version: "16"},
+	}
+
+	root.AddChild(web)
+	root.AddChild(api)
+	return root
+}
+
+func evaluate(t *testing.T, expr string) []interface{} {
+	t.Helper()
+	q, err := Parse(expr)
+	require.NoError(t, err)
+	return q.Evaluate(queryTree())
+}
+
+func TestEvaluate_Dependencies(t *testing.T) {
+	results := evaluate(t, "deps[type=npm][scope=prod][license~GPL]")
+	require.Len(t, results, 1)
+	assert.Equal(t, &Dependency{
+		Type: "npm", Name: "gpl-lib", Version: "1.0.0", Scope: "prod", Direct: false, License: "GPL-3.0-only",
+		Component: "web", Path: "/web/package.json",
+		Metadata: map[string]interface{}{"license_declared": "MIT", "license_concluded": "GPL-3.0-only"},
+	}, results[0], "the concluded license takes precedence")
+
+	assert.Equal(t, []interface{}{"react", "vitest", "junit:junit"}, evaluate(t, "deps[direct=true].name"))
+	assert.Equal(t, []interface{}{"postgres"}, evaluate(t, "deps[scope=].name"), "missing fields are empty")
+	assert.Equal(t, []interface{}{"react"}, evaluate(t, "deps[source=package-lock.json].name"), "metadata keys are fields")
+	assert.Equal(t, []interface{}{"vitest"}, evaluate(t, "deps[groups=lint].name"), "list metadata matches any element")
+	assert.Equal(t, []interface{}{"package-lock.json"}, evaluate(t, "deps.source"), "projections skip empty values")
+	assert.Len(t, evaluate(t, "deps[component!=web]"), 2)
+	assert.Equal(t, []interface{}{"junit:junit"}, evaluate(t, "deps[normalized_version=4.13.0].name"))
+}
+
+func TestEvaluate_ComponentsAndLicenses(t *testing.T) {
+	assert.Equal(t, []interface{}{"web"}, evaluate(t, "components[tech=nodejs].name"))
+	assert.Equal(t, []interface{}{"main", "api"}, evaluate(t, "components[techs!=react].name"))
+	assert.Equal(t, []interface{}{"TypeScript"}, evaluate(t, "components.languages"))
+
+	results := evaluate(t, "licenses[license=MIT]")
+	require.Len(t, results, 1)
+	assert.Equal(t, &License{License: "MIT", Component: "web", SourceFile: "package.json", DetectionType: "direct"}, results[0])
+}
+
+func TestEvaluate_NoMatches(t *testing.T) {
+	assert.Equal(t, []interface{}{}, evaluate(t, "deps[type=cargo]"))
+
+	q, err := Parse("deps")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{}, q.Evaluate(nil))
+}
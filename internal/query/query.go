@@ -0,0 +1,235 @@
+// Package query evaluates filter expressions such as deps[type=npm][scope=prod][license~GPL]
+// over scan results. Matches are returned as named records, so users do not need to know the
+// positional array format of dependencies in the JSON output.
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Collections that a query can select
+const (
+	CollectionDependencies = "deps"
+	CollectionComponents   = "components"
+	CollectionLicenses     = "licenses"
+)
+
+// Filter operators
+const (
+	OpEqual    = "="
+	OpNotEqual = "!="
+	OpMatch    = "~"  // Regular expression match
+	OpNotMatch = "!~" // No regular expression match
+)
+
+// identLetter lists the characters of collection and field names
+const identLetter = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_-"
+
+// collectionAliases maps accepted collection names to their canonical name
+var collectionAliases = map[string]string{
+	"deps":         CollectionDependencies,
+	"dependencies": CollectionDependencies,
+	"components":   CollectionComponents,
+	"licenses":     CollectionLicenses,
+}
+
+// collectionFields lists the fields of each collection. Dependencies also accept any
+// metadata key (source, license_declared, ...).
+var collectionFields = map[string][]string{
+	CollectionDependencies: {"type", "name", "version", "scope", "direct", "license", "component", "path"},
+	CollectionComponents:   {"id", "name", "type", "path", "tech", "techs", "languages"},
+	CollectionLicenses:     {"license", "component", "source_file", "detection_type"},
+}
+
+// Query is a parsed expression: a collection, filters that must all match, and an optional
+// field to project
+type Query struct {
+	Collection string
+	Filters    []Filter
+	Field      string // Projected field ("deps[type=npm].name"); empty returns whole records
+}
+
+// Filter compares a record field with a value
+type Filter struct {
+	Field   string
+	Op      string
+	Value   string
+	pattern *regexp.Regexp
+}
+
+// Parse parses a query expression:
+//
+//	collection [field op value]... [.field]
+//
+// where collection is deps (dependencies), components, or licenses and op is =, !=, ~
+// (regular expression), or !~. Values containing ] or spaces can be double-quoted.
+func Parse(expr string) (*Query, error) {
+	p := &parser{input: strings.TrimSpace(expr)}
+
+	name := p.ident()
+	collection, ok := collectionAliases[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown collection %q: use deps, components, or licenses", name)
+	}
+	q := &Query{Collection: collection}
+
+	for p.peek() == '[' {
+		filter, err := p.filter()
+		if err != nil {
+			return nil, err
+		}
+		if err := checkField(collection, filter.Field); err != nil {
+			return nil, err
+		}
+		q.Filters = append(q.Filters, filter)
+	}
+
+	if p.peek() == '.' {
+		p.pos++
+		q.Field = p.ident()
+		if q.Field == "" {
+			return nil, fmt.Errorf("missing field name after '.' at position %d", p.pos)
+		}
+		if err := checkField(collection, q.Field); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.pos < len(p.input) {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.input[p.pos:], p.pos)
+	}
+	return q, nil
+}
+
+// checkField rejects unknown fields; dependency fields may name metadata keys
+func checkField(collection, field string) error {
+	if collection == CollectionDependencies {
+		return nil
+	}
+	for _, known := range collectionFields[collection] {
+		if field == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown %s field %q: use %s", collection, field, strings.Join(collectionFields[collection], ", "))
+}
+
+// parser is a cursor over the expression
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) peek() byte {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *parser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *parser) ident() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.input) && strings.IndexByte(identLetter, p.input[p.pos]) >= 0 {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+// filter parses "[field op value]"
+func (p *parser) filter() (Filter, error) {
+	start := p.pos
+	p.pos++ // [
+
+	filter := Filter{Field: p.ident()}
+	if filter.Field == "" {
+		return filter, fmt.Errorf("missing field name at position %d", p.pos)
+	}
+
+	p.skipSpaces()
+	for _, op := range []string{OpNotEqual, OpNotMatch, OpEqual, OpMatch} {
+		if strings.HasPrefix(p.input[p.pos:], op) {
+			filter.Op = op
+			p.pos += len(op)
+			break
+		}
+	}
+	if filter.Op == "" {
+		return filter, fmt.Errorf("missing operator (=, !=, ~, !~) after %q at position %d", filter.Field, p.pos)
+	}
+
+	value, err := p.value()
+	if err != nil {
+		return filter, err
+	}
+	filter.Value = value
+
+	if p.peek() != ']' {
+		return filter, fmt.Errorf("unterminated filter starting at position %d", start)
+	}
+	p.pos++
+
+	if filter.Op == OpMatch || filter.Op == OpNotMatch {
+		if filter.pattern, err = regexp.Compile(filter.Value); err != nil {
+			return filter, fmt.Errorf("invalid pattern %q: %w", filter.Value, err)
+		}
+	}
+	return filter, nil
+}
+
+// value parses a double-quoted string or the raw text up to the closing bracket
+func (p *parser) value() (string, error) {
+	p.skipSpaces()
+	if p.pos < len(p.input) && p.input[p.pos] == '"' {
+		for end := p.pos + 1; end < len(p.input); end++ {
+			switch p.input[end] {
+			case '\\':
+				end++
+			case '"':
+				value, err := strconv.Unquote(p.input[p.pos : end+1])
+				if err != nil {
+					return "", fmt.Errorf("invalid quoted value at position %d: %w", p.pos, err)
+				}
+				p.pos = end + 1
+				return value, nil
+			}
+		}
+		return "", fmt.Errorf("unterminated quoted value at position %d", p.pos)
+	}
+
+	end := strings.IndexByte(p.input[p.pos:], ']')
+	if end < 0 {
+		return "", fmt.Errorf("unterminated filter at position %d", p.pos)
+	}
+	value := strings.TrimSpace(p.input[p.pos : p.pos+end])
+	p.pos += end
+	return value, nil
+}
+
+// matches reports whether any of the field values satisfies the filter; negated operators
+// require that none does
+func (f Filter) matches(values []string) bool {
+	for _, value := range values {
+		var hit bool
+		switch f.Op {
+		case OpEqual, OpNotEqual:
+			hit = value == f.Value
+		case OpMatch, OpNotMatch:
+			hit = f.pattern.MatchString(value)
+		}
+		if hit {
+			return f.Op == OpEqual || f.Op == OpMatch
+		}
+	}
+	return f.Op == OpNotEqual || f.Op == OpNotMatch
+}
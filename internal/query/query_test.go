@@ -0,0 +1,76 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	q, err := Parse("deps[type=npm][scope != dev][license~GPL].name")
+	require.NoError(t, err)
+	assert.Equal(t, CollectionDependencies, q.Collection)
+	assert.Equal(t, "name", q.Field)
+	require.Len(t, q.Filters, 3)
+	assert.Equal(t, Filter{Field: "type", Op: OpEqual, Value: "npm"}, q.Filters[0])
+	assert.Equal(t, Filter{Field: "scope", Op: OpNotEqual, Value: "dev"}, q.Filters[1])
+	assert.Equal(t, OpMatch, q.Filters[2].Op)
+	assert.NotNil(t, q.Filters[2].pattern)
+
+	q, err = Parse(`dependencies[name="@types/node"][version="1.0 [beta]"]`)
+	require.NoError(t, err)
+	assert.Equal(t, CollectionDependencies, q.Collection)
+	assert.Equal(t, "@types/node", q.Filters[0].Value)
+	assert.Equal(t, "1.0 [beta]", q.Filters[1].Value)
+
+	q, err = Parse("components[tech=react]")
+	require.NoError(t, err)
+	assert.Equal(t, CollectionComponents, q.Collection)
+	assert.Empty(t, q.Field)
+
+	q, err = Parse("deps[scope=]")
+	require.NoError(t, err)
+	assert.Equal(t, "", q.Filters[0].Value)
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{"", "unknown collection"},
+		{"packages[type=npm]", "unknown collection"},
+		{"deps[type=npm", "unterminated filter"},
+		{"deps[type npm]", "missing operator"},
+		{"deps[=npm]", "missing field name"},
+		{`deps[name="react]`, "unterminated quoted value"},
+		{"deps[name~(]", "invalid pattern"},
+		{"deps[type=npm] extra", "unexpected"},
+		{"deps.", "missing field name after '.'"},
+		{"components[version=1]", "unknown components field"},
+		{"licenses.name", "unknown licenses field"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+func TestFilterMatches(t *testing.T) {
+	parse := func(expr string) Filter {
+		q, err := Parse("deps" + expr)
+		require.NoError(t, err)
+		return q.Filters[0]
+	}
+
+	assert.True(t, parse("[tech=react]").matches([]string{"nodejs", "react"}))
+	assert.False(t, parse("[tech!=react]").matches([]string{"nodejs", "react"}), "negation requires that no value matches")
+	assert.True(t, parse("[tech!=react]").matches([]string{"nodejs"}))
+	assert.True(t, parse("[license~^(A|L)GPL]").matches([]string{"LGPL-3.0-only"}))
+	assert.False(t, parse("[license!~GPL]").matches([]string{"GPL-2.0-only"}))
+	assert.True(t, parse("[scope=]").matches([]string{""}))
+}
@@ -0,0 +1,155 @@
+package registry
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// BundleFormatVersion is the version of the data bundle layout
+const BundleFormatVersion = 1
+
+// Files of the data bundle archive
+const (
+	bundleManifestFile = "manifest.json"
+	bundlePackagesFile = "registry/packages.json"
+)
+
+// maxBundleFileSize limits the decompressed size of a data bundle file
+const maxBundleFileSize = 1 << 30
+
+// BundleManifest describes the contents of a data bundle
+type BundleManifest struct {
+	FormatVersion   int            `json:"format_version"`
+	Created         string         `json:"created"` // RFC 3339
+	AnalyzerVersion string         `json:"analyzer_version"`
+	Types           []string       `json:"types"`    // Dependency types of the registry data (npm, python, cargo, ruby)
+	Contents        map[string]int `json:"contents"` // Entries per data set ("registry": packages)
+}
+
+// Bundle is a snapshot of the network-sourced data of the analyzer, so scans in air-gapped
+// environments can use it offline. It implements the package lookup of the registry analyses.
+type Bundle struct {
+	Manifest BundleManifest
+	Packages map[string]*PackageInfo // Registry metadata by dependency type and name ("npm:react")
+}
+
+// NewBundle creates a data bundle of the packages looked up by a client
+func NewBundle(client *Client, analyzerVersion string, created time.Time) *Bundle {
+	packages := client.Snapshot()
+	types := make([]string, 0, len(client.fetchers))
+	for depType := range client.fetchers {
+		types = append(types, depType)
+	}
+	sort.Strings(types)
+	return &Bundle{
+		Manifest: BundleManifest{
+			FormatVersion:   BundleFormatVersion,
+			Created:         created.UTC().Format(time.RFC3339),
+			AnalyzerVersion: analyzerVersion,
+			Types:           types,
+			Contents:        map[string]int{"registry": len(packages)},
+		},
+		Packages: packages,
+	}
+}
+
+// Supports reports whether the bundle holds registry data for the dependency type
+func (b *Bundle) Supports(depType string) bool {
+	for _, t := range b.Manifest.Types {
+		if t == depType {
+			return true
+		}
+	}
+	return false
+}
+
+// Lookup returns the registry metadata of a package from the bundle
+func (b *Bundle) Lookup(depType, name string) (*PackageInfo, error) {
+	if info, ok := b.Packages[depType+":"+name]; ok {
+		return info, nil
+	}
+	return nil, fmt.Errorf("package %s:%s is not in the data bundle", depType, name)
+}
+
+// WriteBundle writes a data bundle as gzip-compressed tar archive with the manifest and the
+// registry data
+func WriteBundle(w io.Writer, bundle *Bundle) error {
+	gz := gzip.NewWriter(w)
+	archive := tar.NewWriter(gz)
+	modTime, _ := time.Parse(time.RFC3339, bundle.Manifest.Created)
+
+	files := []struct {
+		name    string
+		content interface{}
+	}{
+		{bundleManifestFile, bundle.Manifest},
+		{bundlePackagesFile, bundle.Packages},
+	}
+	for _, file := range files {
+		data, err := json.MarshalIndent(file.content, "", "  ")
+		if err != nil {
+			return err
+		}
+		header := &tar.Header{Name: file.name, Mode: 0644, Size: int64(len(data)), ModTime: modTime, Typeflag: tar.TypeReg}
+		if err := archive.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := archive.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// ReadBundle reads a data bundle written by WriteBundle. Unknown files are ignored, so newer
+// bundles with additional data sets stay readable.
+func ReadBundle(r io.Reader) (*Bundle, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data bundle: %w", err)
+	}
+	defer gz.Close()
+
+	bundle := &Bundle{Packages: make(map[string]*PackageInfo)}
+	manifest := false
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid data bundle: %w", err)
+		}
+
+		var target interface{}
+		switch header.Name {
+		case bundleManifestFile:
+			target, manifest = &bundle.Manifest, true
+		case bundlePackagesFile:
+			target = &bundle.Packages
+		default:
+			continue
+		}
+		if err := json.NewDecoder(io.LimitReader(archive, maxBundleFileSize)).Decode(target); err != nil {
+			return nil, fmt.Errorf("invalid data bundle file %s: %w", header.Name, err)
+		}
+	}
+
+	if !manifest {
+		return nil, errors.New("invalid data bundle: manifest.json is missing")
+	}
+	if bundle.Manifest.FormatVersion > BundleFormatVersion {
+		return nil, fmt.Errorf("data bundle format %d is newer than the supported format %d", bundle.Manifest.FormatVersion, BundleFormatVersion)
+	}
+	return bundle, nil
+}
@@ -0,0 +1,61 @@
+package registry
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle(t *testing.T) {
+	server, _ := newTestServer(t, map[string]string{
+		"/react": `{
+			"name": "react",
+			"dist-tags": {"latest": "18.3.1"},
+			"maintainers": [{"name": "fb"}],
+			"versions": {"18.3.1": {"license": "MIT", "scripts": {"postinstall": "node x.js"}}}
+		}`,
+	})
+	client := NewClient(0)
+	client.SetBaseURL("npm", server.URL)
+	_, err := client.Lookup("npm", "react")
+	require.NoError(t, err)
+	_, err = client.Lookup("npm", "missing")
+	require.Error(t, err)
+
+	created := time.Date(2026, 10, 16, 8, 30, 0, 0, time.UTC)
+	bundle := NewBundle(client, "v1.2.3", created)
+	assert.Equal(t, BundleManifest{
+		FormatVersion: BundleFormatVersion, Created: "2026-10-16T08:30:00Z", AnalyzerVersion: "v1.2.3",
+		Types: []string{"cargo", "docker", "npm", "python", "ruby"}, Contents: map[string]int{"registry": 1},
+	}, bundle.Manifest, "failed lookups are not bundled")
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteBundle(&buf, bundle))
+	read, err := ReadBundle(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, bundle.Manifest, read.Manifest)
+
+	info, err := read.Lookup("npm", "react")
+	require.NoError(t, err)
+	assert.Equal(t, "18.3.1", info.LatestVersion)
+	assert.Equal(t, "MIT", info.LicenseFor("18.3.1"))
+	assert.Equal(t, 1, info.Maintainers)
+	assert.True(t, read.Supports("cargo"))
+	assert.False(t, read.Supports("maven"))
+	_, err = read.Lookup("npm", "vue")
+	assert.ErrorContains(t, err, "not in the data bundle")
+}
+
+func TestReadBundle_Invalid(t *testing.T) {
+	_, err := ReadBundle(bytes.NewReader([]byte("not a bundle")))
+	assert.Error(t, err)
+
+	var buf bytes.Buffer
+	bundle := &Bundle{Manifest: BundleManifest{FormatVersion: BundleFormatVersion + 1}}
+	require.NoError(t, WriteBundle(&buf, bundle))
+	_, err = ReadBundle(&buf)
+	assert.ErrorContains(t, err, "newer than the supported format")
+}
@@ -0,0 +1,395 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// npmPackument is the subset of the npm registry document we use
+type npmPackument struct {
+	Name        string            `json:"name"`
+	DistTags    map[string]string `json:"dist-tags"`
+	Homepage    string            `json:"homepage"`
+	Repository  json.RawMessage   `json:"repository"`
+	Maintainers []struct {
+		Name string `json:"name"`
+	} `json:"maintainers"`
+	Versions map[string]struct {
+		Deprecated string            `json:"deprecated"`
+		License    json.RawMessage   `json:"license"`
+		Scripts    map[string]string `json:"scripts"`
+		Gypfile    bool              `json:"gypfile"`
+	} `json:"versions"`
+}
+
+// npmInstallHooks are the lifecycle scripts npm runs when installing a package
+var npmInstallHooks = []string{"preinstall", "install", "postinstall"}
+
+// fetchNpm retrieves package metadata from the npm registry
+func fetchNpm(c *Client, name string) (*PackageInfo, error) {
+	// Scoped packages keep the "@" but the slash must be escaped
+	escaped := strings.Replace(url.PathEscape(name), "%40", "@", 1)
+
+	baseURL, authorization := c.baseURLs["npm"], ""
+	if c.npmConfig != nil {
+		registry, trusted := c.npmConfig.registryURL(name)
+		if registry != "" {
+			baseURL = registry
+		}
+		if trusted {
+			authorization = c.npmConfig.authorization(baseURL + "/" + escaped)
+		}
+	}
+
+	var doc npmPackument
+	if err := c.getJSONWithAuth(baseURL+"/"+escaped, authorization, &doc); err != nil {
+		return nil, err
+	}
+
+	info := &PackageInfo{
+		Name:          name,
+		LatestVersion: doc.DistTags["latest"],
+		HomepageURL:   doc.Homepage,
+		RepositoryURL: NormalizeRepositoryURL(npmRepositoryURL(doc.Repository)),
+		Maintainers:   len(doc.Maintainers),
+	}
+	if scope, _, ok := strings.Cut(name, "/"); ok && strings.HasPrefix(scope, "@") {
+		info.Publisher = strings.TrimPrefix(scope, "@")
+	} else {
+		info.Publisher = RepositoryOwner(info.RepositoryURL)
+	}
+	if v, ok := doc.Versions[info.LatestVersion]; ok {
+		info.Deprecated = v.Deprecated
+	}
+	for version, v := range doc.Versions {
+		if license := npmLicense(v.License); license != "" {
+			if info.VersionLicenses == nil {
+				info.VersionLicenses = make(map[string]string)
+			}
+			info.VersionLicenses[version] = license
+		}
+	}
+	info.License = info.VersionLicenses[info.LatestVersion]
+	for version, v := range doc.Versions {
+		if v.Deprecated != "" {
+			if info.VersionDeprecations == nil {
+				info.VersionDeprecations = make(map[string]string)
+			}
+			info.VersionDeprecations[version] = v.Deprecated
+		}
+	}
+	info.VersionInstallHooks = make(map[string][]string, len(doc.Versions))
+	for version, v := range doc.Versions {
+		hooks := []string{}
+		for _, hook := range npmInstallHooks {
+			if v.Scripts[hook] != "" {
+				hooks = append(hooks, hook)
+			}
+		}
+		if v.Gypfile && v.Scripts["install"] == "" && v.Scripts["preinstall"] == "" { // npm runs "node-gyp rebuild"
+			hooks = append(hooks, "install")
+		}
+		info.VersionInstallHooks[version] = hooks
+	}
+	return info, nil
+}
+
+// npmLicense handles the SPDX string and legacy {"type": ...} forms of the license field
+func npmLicense(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return strings.TrimSpace(s)
+	}
+	var obj struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(raw, &obj); err == nil {
+		return strings.TrimSpace(obj.Type)
+	}
+	return ""
+}
+
+// npmRepositoryURL handles both the string and object forms of the repository field
+func npmRepositoryURL(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var obj struct {
+		URL string `json:"url"`
+	}
+	if err := json.Unmarshal(raw, &obj); err == nil {
+		return obj.URL
+	}
+	return ""
+}
+
+// pypiDocument is the subset of the PyPI JSON API response we use
+type pypiDocument struct {
+	Info struct {
+		Name         string            `json:"name"`
+		Version      string            `json:"version"`
+		HomePage     string            `json:"home_page"`
+		ProjectURLs  map[string]string `json:"project_urls"`
+		Yanked       bool              `json:"yanked"`
+		YankedReason string            `json:"yanked_reason"`
+		License      string            `json:"license"`
+		LicenseExpr  string            `json:"license_expression"`
+		Classifiers  []string          `json:"classifiers"`
+	} `json:"info"`
+	Releases map[string][]struct {
+		Yanked       bool   `json:"yanked"`
+		YankedReason string `json:"yanked_reason"`
+	} `json:"releases"`
+}
+
+// maxPyPILicenseLength limits the free-form license field: longer values hold the full license text
+const maxPyPILicenseLength = 100
+
+// fetchPyPI retrieves package metadata from the PyPI JSON API
+func fetchPyPI(c *Client, name string) (*PackageInfo, error) {
+	var doc pypiDocument
+	if err := c.getJSON(c.baseURLs["python"]+"/pypi/"+url.PathEscape(name)+"/json", &doc); err != nil {
+		return nil, err
+	}
+
+	info := &PackageInfo{
+		Name:          name,
+		LatestVersion: doc.Info.Version,
+		HomepageURL:   doc.Info.HomePage,
+	}
+
+	// project_urls keys are free-form; match the common labels case-insensitively
+	for label, link := range doc.Info.ProjectURLs {
+		switch strings.ToLower(strings.TrimSpace(label)) {
+		case "source", "source code", "repository", "code", "github":
+			info.RepositoryURL = NormalizeRepositoryURL(link)
+		case "changelog", "change log", "changes", "release notes", "releases", "history":
+			info.ChangelogURL = link
+		case "homepage":
+			if info.HomepageURL == "" {
+				info.HomepageURL = link
+			}
+		}
+	}
+
+	info.License = pypiLicense(doc.Info.LicenseExpr, doc.Info.License, doc.Info.Classifiers)
+	info.Publisher = RepositoryOwner(info.RepositoryURL) // The JSON API does not list maintainers
+
+	if doc.Info.Yanked {
+		info.Deprecated = yankedMessage(doc.Info.YankedReason)
+	}
+
+	// A release is yanked when all of its files are
+	for version, files := range doc.Releases {
+		yanked := len(files) > 0
+		reason := ""
+		for _, file := range files {
+			yanked = yanked && file.Yanked
+			if file.YankedReason != "" {
+				reason = file.YankedReason
+			}
+		}
+		if yanked {
+			if info.VersionDeprecations == nil {
+				info.VersionDeprecations = make(map[string]string)
+			}
+			info.VersionDeprecations[version] = yankedMessage(reason)
+		}
+	}
+	return info, nil
+}
+
+// yankedMessage returns the deprecation message of a yanked release
+func yankedMessage(reason string) string {
+	if reason == "" {
+		return "yanked"
+	}
+	return "yanked: " + reason
+}
+
+// pypiLicense returns the license of a PyPI release from its SPDX license expression, a short
+// license field, or the "License ::" trove classifiers (joined with " OR ")
+func pypiLicense(expression, license string, classifiers []string) string {
+	if expression = strings.TrimSpace(expression); expression != "" {
+		return expression
+	}
+	if license = strings.TrimSpace(license); license != "" && len(license) <= maxPyPILicenseLength && !strings.Contains(license, "\n") {
+		return license
+	}
+	var licenses []string
+	for _, classifier := range classifiers {
+		if !strings.HasPrefix(classifier, "License :: ") {
+			continue
+		}
+		parts := strings.Split(classifier, " :: ")
+		licenses = append(licenses, strings.TrimSpace(parts[len(parts)-1]))
+	}
+	return strings.Join(licenses, " OR ")
+}
+
+// cratesDocument is the subset of the crates.io API response we use
+type cratesDocument struct {
+	Crate struct {
+		Name             string `json:"name"`
+		MaxStableVersion string `json:"max_stable_version"`
+		NewestVersion    string `json:"newest_version"`
+		Repository       string `json:"repository"`
+		Homepage         string `json:"homepage"`
+	} `json:"crate"`
+	Versions []struct {
+		Num     string `json:"num"`
+		License string `json:"license"`
+		Yanked  bool   `json:"yanked"`
+	} `json:"versions"`
+}
+
+// fetchCrates retrieves crate metadata from crates.io
+func fetchCrates(c *Client, name string) (*PackageInfo, error) {
+	var doc cratesDocument
+	if err := c.getJSON(c.baseURLs["cargo"]+"/api/v1/crates/"+url.PathEscape(name), &doc); err != nil {
+		return nil, err
+	}
+
+	latest := doc.Crate.MaxStableVersion
+	if latest == "" {
+		latest = doc.Crate.NewestVersion
+	}
+
+	info := &PackageInfo{
+		Name:          name,
+		LatestVersion: latest,
+		RepositoryURL: NormalizeRepositoryURL(doc.Crate.Repository),
+		HomepageURL:   doc.Crate.Homepage,
+	}
+	for _, version := range doc.Versions {
+		if version.Yanked {
+			if info.VersionDeprecations == nil {
+				info.VersionDeprecations = make(map[string]string)
+			}
+			info.VersionDeprecations[version.Num] = yankedMessage("")
+		}
+		if version.License == "" {
+			continue
+		}
+		if info.VersionLicenses == nil {
+			info.VersionLicenses = make(map[string]string)
+		}
+		info.VersionLicenses[version.Num] = version.License
+	}
+	info.License = info.VersionLicenses[latest]
+	info.Deprecated = info.VersionDeprecations[latest] // All versions are yanked
+	info.Publisher = RepositoryOwner(info.RepositoryURL)
+
+	// Owners are users and teams ("github:rust-lang:libs"); a team names the organization
+	var owners cratesOwners
+	if err := c.getJSON(c.baseURLs["cargo"]+"/api/v1/crates/"+url.PathEscape(name)+"/owners", &owners); err == nil {
+		info.Maintainers = len(owners.Users)
+		for _, owner := range owners.Users {
+			if parts := strings.Split(owner.Login, ":"); owner.Kind == "team" && len(parts) == 3 {
+				info.Publisher = parts[1]
+				break
+			}
+		}
+	}
+	return info, nil
+}
+
+// cratesOwners is the crates.io owners response
+type cratesOwners struct {
+	Users []struct {
+		Login string `json:"login"`
+		Kind  string `json:"kind"` // user or team
+	} `json:"users"`
+}
+
+// rubyGemsDocument is the subset of the RubyGems API response we use
+type rubyGemsDocument struct {
+	Name          string   `json:"name"`
+	Version       string   `json:"version"`
+	HomepageURI   string   `json:"homepage_uri"`
+	SourceCodeURI string   `json:"source_code_uri"`
+	ChangelogURI  string   `json:"changelog_uri"`
+	Licenses      []string `json:"licenses"`
+}
+
+// fetchRubyGems retrieves gem metadata from rubygems.org
+func fetchRubyGems(c *Client, name string) (*PackageInfo, error) {
+	var doc rubyGemsDocument
+	if err := c.getJSON(c.baseURLs["ruby"]+"/api/v1/gems/"+url.PathEscape(name)+".json", &doc); err != nil {
+		return nil, err
+	}
+
+	info := &PackageInfo{
+		Name:          name,
+		LatestVersion: doc.Version,
+		RepositoryURL: NormalizeRepositoryURL(doc.SourceCodeURI),
+		HomepageURL:   doc.HomepageURI,
+		ChangelogURL:  doc.ChangelogURI,
+		License:       strings.Join(doc.Licenses, " OR "), // Multiple gem licenses are alternatives
+	}
+	info.Publisher = RepositoryOwner(info.RepositoryURL)
+
+	var owners []struct {
+		Handle string `json:"handle"`
+	}
+	if err := c.getJSON(c.baseURLs["ruby"]+"/api/v1/gems/"+url.PathEscape(name)+"/owners.json", &owners); err == nil {
+		info.Maintainers = len(owners)
+	}
+
+	// The versions API omits yanked versions
+	var versions []struct {
+		Number string `json:"number"`
+	}
+	if err := c.getJSON(c.baseURLs["ruby"]+"/api/v1/versions/"+url.PathEscape(name)+".json", &versions); err == nil && len(versions) > 0 {
+		info.IndexedVersions = make(map[string]bool, len(versions))
+		for _, version := range versions {
+			info.IndexedVersions[version.Number] = true
+		}
+	}
+	return info, nil
+}
+
+// dockerHubTags is the subset of the Docker Hub tag list we use
+type dockerHubTags struct {
+	Results []struct {
+		Name     string `json:"name"`
+		FullSize int64  `json:"full_size"`
+	} `json:"results"`
+}
+
+// dockerHubPageSize is the number of recently updated tags read per image
+const dockerHubPageSize = 100
+
+// fetchDockerHub retrieves the compressed sizes of the recently updated tags of an image from
+// Docker Hub (official images are in the library namespace). Images of other registries (a
+// host in the name) are not supported.
+func fetchDockerHub(c *Client, name string) (*PackageInfo, error) {
+	repository := strings.TrimPrefix(name, "docker.io/")
+	if host, _, ok := strings.Cut(repository, "/"); ok && (strings.ContainsAny(host, ".:") || host == "localhost") {
+		return nil, fmt.Errorf("image %s is not hosted on Docker Hub", name)
+	}
+	if !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+
+	var doc dockerHubTags
+	if err := c.getJSON(fmt.Sprintf("%s/v2/repositories/%s/tags?page_size=%d", c.baseURLs["docker"], repository, dockerHubPageSize), &doc); err != nil {
+		return nil, err
+	}
+	info := &PackageInfo{Name: name, VersionSizes: make(map[string]int64, len(doc.Results))}
+	for _, tag := range doc.Results {
+		if tag.FullSize > 0 {
+			info.VersionSizes[tag.Name] = tag.FullSize
+		}
+	}
+	return info, nil
+}
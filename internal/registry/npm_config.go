@@ -0,0 +1,258 @@
+package registry
+
+import (
+	"encoding/base64"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NpmConfig holds the registries and credentials of .npmrc and .yarnrc.yml files: the default
+// registry, the registries of package scopes, and the credentials of registries
+type NpmConfig struct {
+	Registry  string              // Default registry URL; empty for the client's npm base URL
+	Scopes    map[string]string   // Registry URL by package scope ("@acme")
+	Auth      map[string]*NpmAuth // Credentials by registry, as a URL without scheme ("//npm.pkg.github.com/")
+	Untrusted map[string]bool     // Default registry ("") and scopes set by untrusted project files; their requests carry no credentials
+}
+
+// NpmAuth holds the credentials of a registry
+type NpmAuth struct {
+	Token string // Bearer token (_authToken, npmAuthToken)
+	Basic string // Base64 encoded user:password (_auth, username and _password, npmAuthIdent)
+}
+
+// npmEnvRegex matches the environment variable references of npm and yarn configuration
+// values (${NPM_TOKEN}, ${NPM_TOKEN:-default}, ${NPM_TOKEN?})
+var npmEnvRegex = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::?-([^}]*)|\?)?\}`)
+
+// NewNpmConfig returns an empty npm configuration
+func NewNpmConfig() *NpmConfig {
+	return &NpmConfig{Scopes: make(map[string]string), Auth: make(map[string]*NpmAuth), Untrusted: make(map[string]bool)}
+}
+
+// ParseNpmrc adds the registries and credentials of an .npmrc to the configuration: registry,
+// @scope:registry, and the //host/path/:_authToken, :_auth, :username, and :_password settings
+// of registries. Environment variable references are expanded with getenv.
+func (c *NpmConfig) ParseNpmrc(content string, getenv func(string) string) {
+	passwords := make(map[string]string)
+	usernames := make(map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = expandNpmEnv(strings.Trim(strings.TrimSpace(value), `"'`), getenv)
+
+		switch {
+		case key == "registry":
+			c.Registry = strings.TrimSuffix(value, "/")
+		case strings.HasPrefix(key, "@") && strings.HasSuffix(key, ":registry"):
+			c.Scopes[strings.TrimSuffix(key, ":registry")] = strings.TrimSuffix(value, "/")
+		case strings.HasPrefix(key, "//"):
+			sep := strings.LastIndex(key, ":") // Registries may hold a port (//localhost:4873/:_authToken)
+			if sep < 0 || value == "" {
+				continue
+			}
+			registry, setting := npmRegistryKey(key[:sep]), key[sep+1:]
+			switch setting {
+			case "_authToken":
+				c.auth(registry).Token = value
+			case "_auth":
+				c.auth(registry).Basic = value
+			case "username":
+				usernames[registry] = value
+			case "_password":
+				passwords[registry] = value
+			}
+		}
+	}
+	for registry, username := range usernames {
+		if password, err := base64.StdEncoding.DecodeString(passwords[registry]); err == nil && len(password) > 0 {
+			c.auth(registry).Basic = base64.StdEncoding.EncodeToString([]byte(username + ":" + string(password)))
+		}
+	}
+}
+
+// ParseYarnrc adds the registries and credentials of a .yarnrc.yml (Yarn 2+) to the
+// configuration: npmRegistryServer, npmAuthToken, and npmAuthIdent at the top level, for
+// package scopes (npmScopes), and for registries (npmRegistries). Environment variable
+// references are expanded with getenv.
+func (c *NpmConfig) ParseYarnrc(content string, getenv func(string) string) {
+	type yarnRegistry struct {
+		Server    string `yaml:"npmRegistryServer"`
+		AuthToken string `yaml:"npmAuthToken"`
+		AuthIdent string `yaml:"npmAuthIdent"`
+	}
+	var yarnrc struct {
+		yarnRegistry `yaml:",inline"`
+		Scopes       map[string]yarnRegistry `yaml:"npmScopes"`
+		Registries   map[string]yarnRegistry `yaml:"npmRegistries"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &yarnrc); err != nil {
+		return
+	}
+
+	setAuth := func(registry string, settings yarnRegistry) {
+		if token := expandNpmEnv(settings.AuthToken, getenv); token != "" {
+			c.auth(npmRegistryKey(registry)).Token = token
+		}
+		if ident := expandNpmEnv(settings.AuthIdent, getenv); ident != "" {
+			if strings.Contains(ident, ":") {
+				ident = base64.StdEncoding.EncodeToString([]byte(ident))
+			}
+			c.auth(npmRegistryKey(registry)).Basic = ident
+		}
+	}
+
+	if server := expandNpmEnv(yarnrc.Server, getenv); server != "" {
+		c.Registry = strings.TrimSuffix(server, "/")
+	}
+	registry, _ := c.registryURL("")
+	setAuth(registry, yarnrc.yarnRegistry)
+	for scope, settings := range yarnrc.Scopes {
+		scope = "@" + strings.TrimPrefix(scope, "@")
+		if server := expandNpmEnv(settings.Server, getenv); server != "" {
+			c.Scopes[scope] = strings.TrimSuffix(server, "/")
+		}
+		if registry, _ := c.registryURL(scope + "/"); registry != "" {
+			setAuth(registry, settings)
+		}
+	}
+	for registry, settings := range yarnrc.Registries {
+		setAuth(expandNpmEnv(registry, getenv), settings)
+	}
+}
+
+// LoadNpmConfig reads the npm and yarn configuration of the user (~/.npmrc, ~/.yarnrc.yml) and
+// of the scanned directories (.npmrc, .yarnrc.yml), later files overriding earlier ones. Files
+// that cannot be read are skipped. The files of the scanned directories are part of the scanned
+// tree: unless trustDirs is set, their environment variable references are not expanded, their
+// credentials are ignored, and requests to the registries they set carry no credentials.
+func LoadNpmConfig(home string, dirs []string, trustDirs bool) *NpmConfig {
+	config := NewNpmConfig()
+	if home != "" {
+		config.load(home, os.Getenv)
+	}
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		if trustDirs {
+			config.load(dir, os.Getenv)
+			continue
+		}
+		project := NewNpmConfig()
+		project.load(dir, func(string) string { return "" })
+		if project.Registry != "" {
+			config.Registry = project.Registry
+			config.Untrusted[""] = true
+		}
+		for scope, registry := range project.Scopes {
+			config.Scopes[scope] = registry
+			config.Untrusted[scope] = true
+		}
+	}
+	return config
+}
+
+// load adds the .npmrc and .yarnrc.yml files of a directory to the configuration
+func (c *NpmConfig) load(dir string, getenv func(string) string) {
+	if content, err := os.ReadFile(filepath.Join(dir, ".npmrc")); err == nil {
+		c.ParseNpmrc(string(content), getenv)
+	}
+	if content, err := os.ReadFile(filepath.Join(dir, ".yarnrc.yml")); err == nil {
+		c.ParseYarnrc(string(content), getenv)
+	}
+}
+
+// IsEmpty reports whether the configuration sets no registry and no credentials
+func (c *NpmConfig) IsEmpty() bool {
+	return c == nil || c.Registry == "" && len(c.Scopes) == 0 && len(c.Auth) == 0
+}
+
+// registryURL returns the registry of a package: the registry of its scope, else the default
+// registry; empty when the configuration sets neither. The flag reports whether requests to the
+// registry may carry credentials (false for registries of untrusted project files).
+func (c *NpmConfig) registryURL(name string) (string, bool) {
+	if scope, _, ok := strings.Cut(name, "/"); ok && strings.HasPrefix(scope, "@") {
+		if registry, ok := c.Scopes[scope]; ok {
+			return registry, !c.Untrusted[scope]
+		}
+	}
+	return c.Registry, c.Registry == "" || !c.Untrusted[""]
+}
+
+// authorization returns the Authorization header for a request URL: the credentials of the
+// registry with the longest URL prefix of the request, like npm; empty without credentials
+func (c *NpmConfig) authorization(requestURL string) string {
+	key := npmRegistryKey(requestURL)
+	var match *NpmAuth
+	longest := 0
+	for registry, auth := range c.Auth {
+		if strings.HasPrefix(key, registry) && len(registry) > longest {
+			match, longest = auth, len(registry)
+		}
+	}
+	switch {
+	case match == nil:
+		return ""
+	case match.Token != "":
+		return "Bearer " + match.Token
+	case match.Basic != "":
+		return "Basic " + match.Basic
+	}
+	return ""
+}
+
+// auth returns the credentials of a registry, creating them if needed
+func (c *NpmConfig) auth(registry string) *NpmAuth {
+	auth, ok := c.Auth[registry]
+	if !ok {
+		auth = &NpmAuth{}
+		c.Auth[registry] = auth
+	}
+	return auth
+}
+
+// npmRegistryKey returns the key of a registry URL in the credentials: the URL without scheme,
+// query, and default port, ending with a slash ("//npm.pkg.github.com/")
+func npmRegistryKey(registry string) string {
+	if strings.HasPrefix(registry, "//") {
+		registry = "https:" + registry
+	}
+	parsed, err := url.Parse(registry)
+	if err != nil || parsed.Host == "" {
+		return registry
+	}
+	host := parsed.Host
+	if port := parsed.Port(); (parsed.Scheme == "https" && port == "443") || (parsed.Scheme == "http" && port == "80") {
+		host = parsed.Hostname()
+	}
+	key := "//" + host + parsed.EscapedPath()
+	if !strings.HasSuffix(key, "/") {
+		key += "/"
+	}
+	return key
+}
+
+// expandNpmEnv expands the environment variable references of a configuration value; unset
+// variables expand to their default, else to nothing
+func expandNpmEnv(value string, getenv func(string) string) string {
+	return npmEnvRegex.ReplaceAllStringFunc(value, func(ref string) string {
+		match := npmEnvRegex.FindStringSubmatch(ref)
+		if env := getenv(match[1]); env != "" {
+			return env
+		}
+		return match[2]
+	})
+}
@@ -0,0 +1,174 @@
+package registry
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEnv(env map[string]string) func(string) string {
+	return func(name string) string { return env[name] }
+}
+
+func TestParseNpmrc(t *testing.T) {
+	content := `# company registries
+registry=https://npm.example.com/repository/npm/
+@acme:registry=https://npm.pkg.github.com/
+@internal:registry = "https://npm.example.com/repository/internal"
+//npm.pkg.github.com/:_authToken=${GITHUB_TOKEN}
+//npm.example.com/repository/:username=ci
+//npm.example.com/repository/:_password=c2VjcmV0
+; legacy setting
+//legacy.example.com/:_auth=dXNlcjpwYXNz
+//empty.example.com/:_authToken=${UNSET_TOKEN}
+always-auth=true
+`
+	config := NewNpmConfig()
+	config.ParseNpmrc(content, testEnv(map[string]string{"GITHUB_TOKEN": "ghp_test"}))
+
+	assert.Equal(t, "https://npm.example.com/repository/npm", config.Registry)
+	assert.Equal(t, map[string]string{
+		"@acme":     "https://npm.pkg.github.com",
+		"@internal": "https://npm.example.com/repository/internal",
+	}, config.Scopes)
+	assert.Equal(t, map[string]*NpmAuth{
+		"//npm.pkg.github.com/":         {Token: "ghp_test"},
+		"//npm.example.com/repository/": {Basic: base64.StdEncoding.EncodeToString([]byte("ci:secret"))},
+		"//legacy.example.com/":         {Basic: "dXNlcjpwYXNz"},
+	}, config.Auth, "unset variables leave no credentials")
+}
+
+func TestParseYarnrc(t *testing.T) {
+	content := `nodeLinker: node-modules
+npmRegistryServer: "https://npm.example.com/"
+npmAuthToken: "${NPM_TOKEN}"
+npmScopes:
+  acme:
+    npmRegistryServer: "https://npm.pkg.github.com"
+    npmAuthToken: "${GITHUB_TOKEN:-fallback}"
+  tools:
+    npmAuthIdent: "user:pass"
+npmRegistries:
+  "//registry.internal.example.com":
+    npmAuthToken: internal-token
+`
+	config := NewNpmConfig()
+	config.ParseYarnrc(content, testEnv(map[string]string{"NPM_TOKEN": "npm_test"}))
+
+	assert.Equal(t, "https://npm.example.com", config.Registry)
+	assert.Equal(t, map[string]string{"@acme": "https://npm.pkg.github.com"}, config.Scopes)
+	assert.Equal(t, map[string]*NpmAuth{
+		"//npm.example.com/":               {Token: "npm_test", Basic: base64.StdEncoding.EncodeToString([]byte("user:pass"))},
+		"//npm.pkg.github.com/":            {Token: "fallback"},
+		"//registry.internal.example.com/": {Token: "internal-token"},
+	}, config.Auth, "scopes without a registry authenticate against the default registry")
+
+	invalid := NewNpmConfig()
+	invalid.ParseYarnrc(":\tinvalid", testEnv(nil))
+	assert.True(t, invalid.IsEmpty())
+}
+
+func TestLoadNpmConfig(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".npmrc"),
+		[]byte("@acme:registry=https://user.example.com/\n//user.example.com/:_authToken=user-token\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(project, ".yarnrc.yml"),
+		[]byte("npmScopes:\n  acme:\n    npmRegistryServer: https://project.example.com\n"), 0644))
+
+	config := LoadNpmConfig(home, []string{project, filepath.Join(project, "missing")}, true)
+	assert.Equal(t, "https://project.example.com", config.Scopes["@acme"], "project settings override user settings")
+	assert.Equal(t, "user-token", config.Auth["//user.example.com/"].Token)
+	assert.Empty(t, config.Untrusted)
+	assert.True(t, LoadNpmConfig("", nil, false).IsEmpty())
+}
+
+func TestLoadNpmConfig_UntrustedProject(t *testing.T) {
+	t.Setenv("NPM_TEST_SECRET", "ci-secret")
+	home := t.TempDir()
+	project := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".npmrc"),
+		[]byte("@acme:registry=https://user.example.com/\n//user.example.com/:_authToken=${NPM_TEST_SECRET}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(project, ".npmrc"),
+		[]byte("registry=https://evil.example.com/\n@acme:registry=https://evil.example.com/\n//evil.example.com/:_authToken=${NPM_TEST_SECRET}\n"), 0644))
+
+	config := LoadNpmConfig(home, []string{project}, false)
+	assert.Equal(t, "https://evil.example.com", config.Registry)
+	assert.Equal(t, "https://evil.example.com", config.Scopes["@acme"])
+	assert.Equal(t, map[string]*NpmAuth{"//user.example.com/": {Token: "ci-secret"}}, config.Auth,
+		"the user's files expand the environment, project credentials are ignored")
+	assert.Equal(t, map[string]bool{"": true, "@acme": true}, config.Untrusted)
+
+	trusted := LoadNpmConfig(home, []string{project}, true)
+	assert.Equal(t, "ci-secret", trusted.Auth["//evil.example.com/"].Token)
+}
+
+func TestLookupNpmWithConfig(t *testing.T) {
+	authorizations := make(map[string]string)
+	handler := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			authorizations[name+r.URL.EscapedPath()] = r.Header.Get("Authorization")
+			_, _ = w.Write([]byte(`{"dist-tags": {"latest": "1.0.0"}}`))
+		}
+	}
+	public := httptest.NewServer(handler("public"))
+	t.Cleanup(public.Close)
+	private := httptest.NewServer(handler("private"))
+	t.Cleanup(private.Close)
+
+	config := NewNpmConfig()
+	config.ParseNpmrc("@acme:registry="+private.URL+"/npm/\n"+
+		"//"+private.Listener.Addr().String()+"/npm/:_authToken=secret\n", testEnv(nil))
+	client := NewClient(0)
+	client.SetBaseURL("npm", public.URL)
+	client.SetNpmConfig(config)
+
+	_, err := client.Lookup("npm", "@acme/ui")
+	require.NoError(t, err)
+	_, err = client.Lookup("npm", "react")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"private/npm/@acme%2Fui": "Bearer secret",
+		"public/react":           "",
+	}, authorizations, "scoped packages go to their registry, credentials only to the registry they belong to")
+}
+
+func TestLookupNpmUntrustedRegistry(t *testing.T) {
+	authorizations := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations[r.URL.EscapedPath()] = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"dist-tags": {"latest": "1.0.0"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	// The user's credentials for the host; the scope registry comes from an untrusted project
+	config := NewNpmConfig()
+	config.ParseNpmrc("//"+server.Listener.Addr().String()+"/:_authToken=secret\n", testEnv(nil))
+	config.Scopes["@acme"] = server.URL + "/project"
+	config.Untrusted["@acme"] = true
+	client := NewClient(0)
+	client.SetBaseURL("npm", server.URL)
+	client.SetNpmConfig(config)
+
+	_, err := client.Lookup("npm", "@acme/ui")
+	require.NoError(t, err)
+	_, err = client.Lookup("npm", "react")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"/project/@acme%2Fui": "",
+		"/react":              "Bearer secret",
+	}, authorizations, "requests redirected by untrusted project files carry no credentials")
+}
+
+func TestNpmRegistryKey(t *testing.T) {
+	assert.Equal(t, "//npm.pkg.github.com/", npmRegistryKey("https://npm.pkg.github.com"))
+	assert.Equal(t, "//npm.example.com/repo/npm/", npmRegistryKey("https://npm.example.com:443/repo/npm"))
+	assert.Equal(t, "//localhost:4873/", npmRegistryKey("http://localhost:4873/"))
+	assert.Equal(t, "//npm.pkg.github.com/", npmRegistryKey("//npm.pkg.github.com/"))
+}
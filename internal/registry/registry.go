@@ -0,0 +1,266 @@
+// Package registry provides opt-in lookups against public package registries
+// (npm, PyPI, crates.io, RubyGems, Docker Hub) used to enrich scan results with data that
+// cannot be derived from the scanned files, such as the latest published version. npm lookups
+// follow the scope registries and credentials of .npmrc and .yarnrc.yml files, so private
+// packages are looked up in their internal registry.
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout is the per-request timeout for registry lookups
+const DefaultTimeout = 10 * time.Second
+
+// Default registry base URLs
+const (
+	DefaultNpmURL       = "https://registry.npmjs.org"
+	DefaultPyPIURL      = "https://pypi.org"
+	DefaultCratesURL    = "https://crates.io"
+	DefaultRubyGemsURL  = "https://rubygems.org"
+	DefaultDockerHubURL = "https://hub.docker.com"
+)
+
+// PackageInfo holds registry metadata for a single package
+type PackageInfo struct {
+	Name          string `json:"name"`                     // Package name as known by the registry
+	LatestVersion string `json:"latest_version,omitempty"` // Latest stable version published
+	RepositoryURL string `json:"repository_url,omitempty"` // Source repository URL (normalized to https when possible)
+	HomepageURL   string `json:"homepage_url,omitempty"`   // Project homepage
+	ChangelogURL  string `json:"changelog_url,omitempty"`  // Changelog or release notes URL from registry metadata
+	Deprecated    string `json:"deprecated,omitempty"`     // Deprecation message for the latest version (empty if not deprecated)
+	License       string `json:"license,omitempty"`        // License of the latest version as reported by the registry
+	Maintainers   int    `json:"maintainers,omitempty"`    // Number of maintainers (npm maintainers, crates.io and RubyGems owners), 0 if unknown
+	Publisher     string `json:"publisher,omitempty"`      // Publishing organization: npm scope, crates.io team organization, else owner of the repository
+	// VersionLicenses maps published versions to their license (npm, crates.io; other
+	// registries only report the latest version)
+	VersionLicenses map[string]string `json:"version_licenses,omitempty"`
+	// VersionInstallHooks maps published versions to the install lifecycle hooks they declare
+	// (preinstall, install, postinstall); npm only
+	VersionInstallHooks map[string][]string `json:"version_install_hooks,omitempty"`
+	// VersionDeprecations maps deprecated or yanked versions to their deprecation message (npm
+	// deprecations, PyPI and crates.io yanked releases)
+	VersionDeprecations map[string]string `json:"version_deprecations,omitempty"`
+	// IndexedVersions lists the installable versions of registries omitting yanked versions
+	// (RubyGems); nil if the registry reports yanked versions
+	IndexedVersions map[string]bool `json:"indexed_versions,omitempty"`
+	// VersionSizes maps image tags to their compressed size in bytes (Docker Hub, recently
+	// updated tags only)
+	VersionSizes map[string]int64 `json:"version_sizes,omitempty"`
+}
+
+// LicenseFor returns the license the registry reports for a version, or empty if unknown
+func (i *PackageInfo) LicenseFor(version string) string {
+	if license, ok := i.VersionLicenses[version]; ok {
+		return license
+	}
+	if version == i.LatestVersion {
+		return i.License
+	}
+	return ""
+}
+
+// InstallHooksFor returns the install hooks the registry reports for a version, and whether
+// the registry reports the version at all
+func (i *PackageInfo) InstallHooksFor(version string) ([]string, bool) {
+	hooks, ok := i.VersionInstallHooks[version]
+	return hooks, ok
+}
+
+// DeprecationFor returns the deprecation message of a version ("yanked" with the reason, if any,
+// for yanked releases), or empty if the version is not known to be deprecated
+func (i *PackageInfo) DeprecationFor(version string) string {
+	if message, ok := i.VersionDeprecations[version]; ok {
+		return message
+	}
+	if i.IndexedVersions != nil && version != "" {
+		number, _, _ := strings.Cut(version, "-") // Platform gems (1.15.4-x86_64-linux)
+		if !i.IndexedVersions[version] && !i.IndexedVersions[number] {
+			return "yanked"
+		}
+	}
+	return ""
+}
+
+// fetcher retrieves package metadata from a specific registry
+type fetcher func(c *Client, name string) (*PackageInfo, error)
+
+// Client performs registry lookups with a per-package cache.
+// Lookups are sequential; the cache ensures each package is fetched at most once per scan.
+type Client struct {
+	httpClient *http.Client
+	userAgent  string
+	baseURLs   map[string]string
+	npmConfig  *NpmConfig
+	fetchers   map[string]fetcher
+	cache      map[string]*PackageInfo
+	errors     map[string]error
+}
+
+// NewClient creates a registry client using the default public registries
+func NewClient(timeout time.Duration) *Client {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Client{
+		httpClient: &http.Client{Timeout: timeout},
+		userAgent:  "tech-stack-analyzer (https://github.com/petrarca/tech-stack-analyzer)",
+		baseURLs: map[string]string{
+			"npm":    DefaultNpmURL,
+			"python": DefaultPyPIURL,
+			"cargo":  DefaultCratesURL,
+			"ruby":   DefaultRubyGemsURL,
+			"docker": DefaultDockerHubURL,
+		},
+		fetchers: map[string]fetcher{
+			"npm":    fetchNpm,
+			"python": fetchPyPI,
+			"cargo":  fetchCrates,
+			"ruby":   fetchRubyGems,
+			"docker": fetchDockerHub,
+		},
+		cache:  make(map[string]*PackageInfo),
+		errors: make(map[string]error),
+	}
+}
+
+// SetBaseURL overrides the registry base URL for a dependency type (e.g., for mirrors or tests)
+func (c *Client) SetBaseURL(depType, baseURL string) {
+	c.baseURLs[depType] = strings.TrimSuffix(baseURL, "/")
+}
+
+// SetNpmConfig sets the registries and credentials of .npmrc and .yarnrc.yml files for npm
+// lookups: scoped packages are looked up in the registry of their scope, other packages in the
+// configured default registry, and requests carry only the credentials of the registry they go to
+func (c *Client) SetNpmConfig(config *NpmConfig) {
+	c.npmConfig = config
+}
+
+// Supports reports whether the client can look up packages of the given dependency type
+func (c *Client) Supports(depType string) bool {
+	_, ok := c.fetchers[depType]
+	return ok
+}
+
+// Snapshot returns the metadata of the packages looked up so far, keyed by dependency type
+// and name ("npm:react"); failed lookups are not included
+func (c *Client) Snapshot() map[string]*PackageInfo {
+	packages := make(map[string]*PackageInfo, len(c.cache))
+	for key, info := range c.cache {
+		packages[key] = info
+	}
+	return packages
+}
+
+// Lookup returns registry metadata for a package, using the cache when possible
+func (c *Client) Lookup(depType, name string) (*PackageInfo, error) {
+	fetch, ok := c.fetchers[depType]
+	if !ok {
+		return nil, fmt.Errorf("registry lookup not supported for dependency type %q", depType)
+	}
+
+	key := depType + ":" + name
+	if info, ok := c.cache[key]; ok {
+		return info, nil
+	}
+	if err, ok := c.errors[key]; ok {
+		return nil, err
+	}
+
+	info, err := fetch(c, name)
+	if err != nil {
+		c.errors[key] = err
+		return nil, err
+	}
+	c.cache[key] = info
+	return info, nil
+}
+
+// getJSON performs a GET request and decodes the JSON response into target
+func (c *Client) getJSON(url string, target interface{}) error {
+	return c.getJSONWithAuth(url, "", target)
+}
+
+// getJSONWithAuth performs a GET request with an Authorization header (none if empty) and
+// decodes the JSON response into target
+func (c *Client) getJSONWithAuth(url, authorization string, target interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("registry request failed: %s returned %d", url, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+// NormalizeRepositoryURL converts common repository URL forms to browsable https URLs
+// Examples: "git+https://github.com/a/b.git" -> "https://github.com/a/b",
+// "github:a/b" -> "https://github.com/a/b", "git@github.com:a/b.git" -> "https://github.com/a/b"
+func NormalizeRepositoryURL(raw string) string {
+	url := strings.TrimSpace(raw)
+	if url == "" {
+		return ""
+	}
+
+	url = strings.TrimPrefix(url, "git+")
+	if strings.HasPrefix(url, "github:") {
+		url = "https://github.com/" + strings.TrimPrefix(url, "github:")
+	}
+	if strings.HasPrefix(url, "git@") {
+		url = "https://" + strings.Replace(strings.TrimPrefix(url, "git@"), ":", "/", 1)
+	}
+	url = strings.Replace(url, "git://", "https://", 1)
+	url = strings.Replace(url, "ssh://git@", "https://", 1)
+	url = strings.TrimSuffix(url, "/")
+	url = strings.TrimSuffix(url, ".git")
+
+	// Drop fragments such as "#readme" or "#main"
+	if idx := strings.Index(url, "#"); idx >= 0 {
+		url = url[:idx]
+	}
+
+	return url
+}
+
+// RepositoryOwner returns the owner (user or organization) of a GitHub, GitLab, or Bitbucket
+// repository URL, or empty for other hosts
+func RepositoryOwner(repoURL string) string {
+	for _, host := range []string{"https://github.com/", "https://gitlab.com/", "https://bitbucket.org/"} {
+		if rest, ok := strings.CutPrefix(repoURL, host); ok {
+			owner, _, _ := strings.Cut(rest, "/")
+			return owner
+		}
+	}
+	return ""
+}
+
+// GitHubReleasesURL returns the GitHub releases page for a repository URL, or empty if not a GitHub repository
+func GitHubReleasesURL(repoURL string) string {
+	const prefix = "https://github.com/"
+	if !strings.HasPrefix(repoURL, prefix) {
+		return ""
+	}
+	parts := strings.Split(strings.TrimPrefix(repoURL, prefix), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return ""
+	}
+	return prefix + parts[0] + "/" + parts[1] + "/releases"
+}
@@ -0,0 +1,331 @@
+package registry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer serves fixed JSON responses keyed by request path and counts requests
+func newTestServer(t *testing.T, responses map[string]string) (*httptest.Server, *int) {
+	t.Helper()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, ok := responses[r.URL.EscapedPath()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestLookupNpm(t *testing.T) {
+	server, _ := newTestServer(t, map[string]string{
+		"/react": `{
+			"name": "react",
+			"dist-tags": {"latest": "18.3.1"},
+			"homepage": "https://react.dev",
+			"repository": {"type": "git", "url": "git+https://github.com/facebook/react.git"},
+			"maintainers": [{"name": "fb"}, {"name": "react-bot"}],
+			"versions": {"0.14.0": {"license": "BSD-3-Clause"}, "16.0.0": {"license": {"type": "MIT"}}, "18.3.1": {"license": "MIT"}}
+		}`,
+		"/@types%2Fnode": `{
+			"name": "@types/node",
+			"dist-tags": {"latest": "20.1.0"},
+			"repository": "github:DefinitelyTyped/DefinitelyTyped",
+			"versions": {"20.1.0": {"deprecated": "use something else"}}
+		}`,
+	})
+
+	client := NewClient(0)
+	client.SetBaseURL("npm", server.URL+"/")
+
+	info, err := client.Lookup("npm", "react")
+	require.NoError(t, err)
+	assert.Equal(t, "18.3.1", info.LatestVersion)
+	assert.Equal(t, "https://github.com/facebook/react", info.RepositoryURL)
+	assert.Equal(t, "https://react.dev", info.HomepageURL)
+	assert.Empty(t, info.Deprecated)
+	assert.Equal(t, "MIT", info.License)
+	assert.Equal(t, "BSD-3-Clause", info.LicenseFor("0.14.0"))
+	assert.Equal(t, "MIT", info.LicenseFor("16.0.0"), "legacy license objects")
+	assert.Empty(t, info.LicenseFor("17.0.0"))
+	assert.Equal(t, 2, info.Maintainers)
+	assert.Equal(t, "facebook", info.Publisher, "owner of the repository")
+
+	info, err = client.Lookup("npm", "@types/node")
+	require.NoError(t, err)
+	assert.Equal(t, "20.1.0", info.LatestVersion)
+	assert.Equal(t, "https://github.com/DefinitelyTyped/DefinitelyTyped", info.RepositoryURL)
+	assert.Equal(t, "use something else", info.Deprecated)
+	assert.Equal(t, "types", info.Publisher, "scope of the package")
+	assert.Zero(t, info.Maintainers)
+}
+
+func TestLookupNpm_InstallHooks(t *testing.T) {
+	server, _ := newTestServer(t, map[string]string{
+		"/bcrypt": `{
+			"name": "bcrypt",
+			"dist-tags": {"latest": "5.1.1"},
+			"versions": {
+				"5.1.1": {"scripts": {"install": "node-pre-gyp install --fallback-to-build", "test": "jest"}},
+				"3.0.0": {"gypfile": true, "scripts": {"test": "nodeunit test"}},
+				"1.0.0": {"scripts": {"preinstall": "node check.js", "postinstall": "node setup.js"}},
+				"0.1.0": {}
+			}
+		}`,
+	})
+
+	client := NewClient(0)
+	client.SetBaseURL("npm", server.URL)
+
+	info, err := client.Lookup("npm", "bcrypt")
+	require.NoError(t, err)
+	hooks, ok := info.InstallHooksFor("5.1.1")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"install"}, hooks)
+	hooks, _ = info.InstallHooksFor("3.0.0")
+	assert.Equal(t, []string{"install"}, hooks, "binding.gyp runs node-gyp rebuild")
+	hooks, _ = info.InstallHooksFor("1.0.0")
+	assert.Equal(t, []string{"preinstall", "postinstall"}, hooks)
+	hooks, ok = info.InstallHooksFor("0.1.0")
+	assert.True(t, ok)
+	assert.Empty(t, hooks)
+	_, ok = info.InstallHooksFor("9.9.9")
+	assert.False(t, ok)
+}
+
+func TestLookupPyPI(t *testing.T) {
+	server, _ := newTestServer(t, map[string]string{
+		"/pypi/requests/json": `{
+			"info": {
+				"name": "requests",
+				"version": "2.32.3",
+				"project_urls": {
+					"Source": "https://github.com/psf/requests",
+					"Changelog": "https://github.com/psf/requests/blob/main/HISTORY.md",
+					"Homepage": "https://requests.readthedocs.io"
+				},
+				"license": "Apache 2.0"
+			}
+		}`,
+		"/pypi/classified/json": `{
+			"info": {
+				"name": "classified",
+				"version": "1.0.0",
+				"license": "Copyright (c) 2024 Example\nPermission is hereby granted...",
+				"classifiers": ["Programming Language :: Python :: 3", "License :: OSI Approved :: MIT License"]
+			}
+		}`,
+	})
+
+	client := NewClient(0)
+	client.SetBaseURL("python", server.URL)
+
+	info, err := client.Lookup("python", "requests")
+	require.NoError(t, err)
+	assert.Equal(t, "2.32.3", info.LatestVersion)
+	assert.Equal(t, "https://github.com/psf/requests", info.RepositoryURL)
+	assert.Equal(t, "https://github.com/psf/requests/blob/main/HISTORY.md", info.ChangelogURL)
+	assert.Equal(t, "https://requests.readthedocs.io", info.HomepageURL)
+	assert.Equal(t, "Apache 2.0", info.LicenseFor("2.32.3"))
+	assert.Empty(t, info.LicenseFor("2.31.0"), "PyPI only reports the license of the latest version")
+
+	info, err = client.Lookup("python", "classified")
+	require.NoError(t, err)
+	assert.Equal(t, "MIT License", info.License, "license text falls back to the trove classifiers")
+}
+
+func TestLookupCrates(t *testing.T) {
+	server, _ := newTestServer(t, map[string]string{
+		"/api/v1/crates/serde": `{"crate": {"name": "serde", "max_stable_version": "1.0.210", "newest_version": "1.0.211-rc.1", "repository": "https://github.com/serde-rs/serde"},
+			"versions": [{"num": "1.0.210", "license": "MIT OR Apache-2.0"}, {"num": "0.1.0", "license": ""}]}`,
+		"/api/v1/crates/serde/owners": `{"users": [{"login": "dtolnay", "kind": "user"}]}`,
+		"/api/v1/crates/beta":         `{"crate": {"name": "beta", "newest_version": "0.1.0-alpha", "repository": "https://github.com/someone/beta"}}`,
+		"/api/v1/crates/beta/owners":  `{"users": [{"login": "someone", "kind": "user"}, {"login": "github:beta-org:maintainers", "kind": "team"}]}`,
+	})
+
+	client := NewClient(0)
+	client.SetBaseURL("cargo", server.URL)
+
+	info, err := client.Lookup("cargo", "serde")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.210", info.LatestVersion, "should prefer max stable version")
+	assert.Equal(t, "https://github.com/serde-rs/serde", info.RepositoryURL)
+	assert.Equal(t, "MIT OR Apache-2.0", info.License)
+	assert.Empty(t, info.LicenseFor("0.1.0"))
+	assert.Equal(t, 1, info.Maintainers)
+	assert.Equal(t, "serde-rs", info.Publisher)
+
+	info, err = client.Lookup("cargo", "beta")
+	require.NoError(t, err)
+	assert.Equal(t, "0.1.0-alpha", info.LatestVersion, "should fall back to newest version")
+	assert.Equal(t, 2, info.Maintainers)
+	assert.Equal(t, "beta-org", info.Publisher, "organization of the owning team")
+}
+
+func TestLookupRubyGems(t *testing.T) {
+	server, _ := newTestServer(t, map[string]string{
+		"/api/v1/gems/rails.json": `{
+			"name": "rails",
+			"version": "7.2.1",
+			"source_code_uri": "https://github.com/rails/rails/tree/v7.2.1",
+			"changelog_uri": "https://github.com/rails/rails/releases/tag/v7.2.1",
+			"licenses": ["MIT"]
+		}`,
+		"/api/v1/gems/rails/owners.json": `[{"id": 1, "handle": "dhh"}, {"id": 2, "handle": "rafaelfranca"}]`,
+	})
+
+	client := NewClient(0)
+	client.SetBaseURL("ruby", server.URL)
+
+	info, err := client.Lookup("ruby", "rails")
+	require.NoError(t, err)
+	assert.Equal(t, "7.2.1", info.LatestVersion)
+	assert.Equal(t, "https://github.com/rails/rails/releases/tag/v7.2.1", info.ChangelogURL)
+	assert.Equal(t, "MIT", info.License)
+	assert.Equal(t, 2, info.Maintainers)
+	assert.Equal(t, "rails", info.Publisher)
+}
+
+func TestLookupDockerHub(t *testing.T) {
+	server, _ := newTestServer(t, map[string]string{
+		"/v2/repositories/library/python/tags": `{"results": [
+			{"name": "3.13-slim", "full_size": 43315270},
+			{"name": "3.8", "full_size": 357251394},
+			{"name": "3.13-windowsservercore", "full_size": 0}
+		]}`,
+		"/v2/repositories/bitnami/redis/tags": `{"results": [{"name": "7.4", "full_size": 57000000}]}`,
+	})
+
+	client := NewClient(0)
+	client.SetBaseURL("docker", server.URL)
+
+	info, err := client.Lookup("docker", "python")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"3.13-slim": 43315270, "3.8": 357251394}, info.VersionSizes)
+
+	info, err = client.Lookup("docker", "docker.io/bitnami/redis")
+	require.NoError(t, err)
+	assert.Equal(t, int64(57000000), info.VersionSizes["7.4"])
+
+	_, err = client.Lookup("docker", "gcr.io/distroless/static-debian12")
+	assert.Error(t, err, "other registries")
+}
+
+func TestLookup_VersionDeprecations(t *testing.T) {
+	server, _ := newTestServer(t, map[string]string{
+		"/request": `{"name": "request", "dist-tags": {"latest": "2.88.2"},
+			"versions": {"2.88.2": {"deprecated": "request has been deprecated"}, "2.88.0": {"deprecated": "request has been deprecated"}, "2.87.0": {}}}`,
+		"/pypi/urllib3/json": `{"info": {"name": "urllib3", "version": "2.2.3"},
+			"releases": {"2.2.3": [{"yanked": false}], "2.0.0": [{"yanked": true, "yanked_reason": "broken wheel"}, {"yanked": true}], "1.26.0": [{"yanked": true}, {"yanked": false}], "0.1": []}}`,
+		"/api/v1/crates/gone":            `{"crate": {"name": "gone", "newest_version": "0.2.0"}, "versions": [{"num": "0.2.0", "yanked": true}, {"num": "0.1.0", "yanked": false}]}`,
+		"/api/v1/gems/nokogiri.json":     `{"name": "nokogiri", "version": "1.16.7"}`,
+		"/api/v1/versions/nokogiri.json": `[{"number": "1.16.7", "platform": "ruby"}, {"number": "1.15.4", "platform": "x86_64-linux"}]`,
+	})
+
+	client := NewClient(0)
+	client.SetBaseURL("npm", server.URL)
+	client.SetBaseURL("python", server.URL)
+	client.SetBaseURL("cargo", server.URL)
+	client.SetBaseURL("ruby", server.URL)
+
+	info, err := client.Lookup("npm", "request")
+	require.NoError(t, err)
+	assert.Equal(t, "request has been deprecated", info.Deprecated)
+	assert.Equal(t, "request has been deprecated", info.DeprecationFor("2.88.0"))
+	assert.Empty(t, info.DeprecationFor("2.87.0"))
+
+	info, err = client.Lookup("python", "urllib3")
+	require.NoError(t, err)
+	assert.Empty(t, info.Deprecated)
+	assert.Equal(t, "yanked: broken wheel", info.DeprecationFor("2.0.0"))
+	assert.Empty(t, info.DeprecationFor("1.26.0"), "a release is yanked when all of its files are")
+	assert.Empty(t, info.DeprecationFor("0.1"))
+
+	info, err = client.Lookup("cargo", "gone")
+	require.NoError(t, err)
+	assert.Equal(t, "yanked", info.Deprecated, "the latest version is yanked")
+	assert.Empty(t, info.DeprecationFor("0.1.0"))
+
+	info, err = client.Lookup("ruby", "nokogiri")
+	require.NoError(t, err)
+	assert.Empty(t, info.DeprecationFor("1.16.7"))
+	assert.Empty(t, info.DeprecationFor("1.15.4-x86_64-linux"), "platform gems")
+	assert.Equal(t, "yanked", info.DeprecationFor("1.14.0"), "versions missing from the index are yanked")
+}
+
+func TestLookupCachesResultsAndErrors(t *testing.T) {
+	server, requests := newTestServer(t, map[string]string{
+		"/lodash": `{"name": "lodash", "dist-tags": {"latest": "4.17.21"}}`,
+	})
+
+	client := NewClient(0)
+	client.SetBaseURL("npm", server.URL)
+
+	for i := 0; i < 3; i++ {
+		_, err := client.Lookup("npm", "lodash")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, *requests, "successful lookups should be cached")
+
+	for i := 0; i < 3; i++ {
+		_, err := client.Lookup("npm", "missing")
+		assert.Error(t, err)
+	}
+	assert.Equal(t, 2, *requests, "failed lookups should be cached")
+}
+
+func TestLookupUnsupportedType(t *testing.T) {
+	client := NewClient(0)
+	assert.False(t, client.Supports("maven"))
+	assert.True(t, client.Supports("npm"))
+
+	_, err := client.Lookup("maven", "org.example:lib")
+	assert.Error(t, err)
+}
+
+func TestNormalizeRepositoryURL(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", ""},
+		{"git+https://github.com/a/b.git", "https://github.com/a/b"},
+		{"github:a/b", "https://github.com/a/b"},
+		{"git@github.com:a/b.git", "https://github.com/a/b"},
+		{"git://github.com/a/b.git", "https://github.com/a/b"},
+		{"git+ssh://git@github.com/a/b.git", "https://github.com/a/b"},
+		{"https://github.com/a/b#readme", "https://github.com/a/b"},
+		{"https://gitlab.com/a/b/", "https://gitlab.com/a/b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeRepositoryURL(tt.input))
+		})
+	}
+}
+
+func TestGitHubReleasesURL(t *testing.T) {
+	assert.Equal(t, "https://github.com/a/b/releases", GitHubReleasesURL("https://github.com/a/b"))
+	assert.Equal(t, "https://github.com/a/b/releases", GitHubReleasesURL("https://github.com/a/b/tree/main/packages/c"))
+	assert.Empty(t, GitHubReleasesURL("https://gitlab.com/a/b"))
+	assert.Empty(t, GitHubReleasesURL("https://github.com/a"))
+	assert.Empty(t, GitHubReleasesURL(""))
+}
+
+func TestRepositoryOwner(t *testing.T) {
+	assert.Equal(t, "facebook", RepositoryOwner("https://github.com/facebook/react"))
+	assert.Equal(t, "gitlab-org", RepositoryOwner("https://gitlab.com/gitlab-org/gitlab"))
+	assert.Empty(t, RepositoryOwner("https://example.com/repo"))
+	assert.Empty(t, RepositoryOwner(""))
+}
@@ -0,0 +1,138 @@
+// Package repro records reproducibility manifests of scans: the sha256 digests of every file
+// the scanner read, the analyzer version, and a digest of the configuration that shapes the
+// result, so a stored result can be re-verified against a repository state later.
+package repro
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// FormatVersion is the version of the manifest layout
+const FormatVersion = 1
+
+// Verification statuses of inputs
+const (
+	StatusChanged = "changed" // The file content differs from the recorded digest
+	StatusMissing = "missing" // The file no longer exists or cannot be read
+)
+
+// Manifest describes the inputs and the configuration a scan result was derived from
+type Manifest struct {
+	FormatVersion   int     `json:"format_version"`
+	AnalyzerVersion string  `json:"analyzer_version"`
+	AnalyzerCommit  string  `json:"analyzer_commit,omitempty"`
+	ScannedAt       string  `json:"scanned_at"`           // RFC 3339
+	GitCommit       string  `json:"git_commit,omitempty"` // Full HEAD commit hash of the scanned repository
+	ConfigDigest    string  `json:"config_digest"`        // sha256 of the result-shaping settings
+	ResultDigest    string  `json:"result_digest"`        // sha256 of the result as written to the output file (identifies the stored result)
+	InputsDigest    string  `json:"inputs_digest"`        // sha256 over the sorted inputs (see InputsDigest)
+	NetworkData     bool    `json:"network_data"`         // The result includes live package registry data (not reproducible offline)
+	Inputs          []Input `json:"inputs"`
+}
+
+// Input is a file the scan read, relative to the scanned directory
+type Input struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
+
+// Mismatch is an input whose current state differs from the manifest
+type Mismatch struct {
+	Path     string `json:"path"`
+	Status   string `json:"status"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+// New creates a manifest of the inputs (digests by path), the configuration digest, and the
+// result; the caller sets the analyzer, git, and network fields
+func New(inputs map[string]string, configDigest string, result []byte, scannedAt time.Time) *Manifest {
+	list := make([]Input, 0, len(inputs))
+	for path, digest := range inputs {
+		list = append(list, Input{Path: path, SHA256: digest})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Path < list[j].Path })
+	return &Manifest{
+		FormatVersion: FormatVersion,
+		ScannedAt:     scannedAt.UTC().Format(time.RFC3339),
+		ConfigDigest:  configDigest,
+		ResultDigest:  SHA256(result),
+		InputsDigest:  InputsDigest(list),
+		Inputs:        list,
+	}
+}
+
+// ConfigDigest returns the sha256 digest of the JSON encoding of a configuration value
+func ConfigDigest(config interface{}) (string, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return SHA256(data), nil
+}
+
+// InputsDigest returns the sha256 digest over the inputs in sha256sum format ("<digest>  <path>"
+// lines), so it can be reproduced with "sha256sum" over the same files
+func InputsDigest(inputs []Input) string {
+	var b strings.Builder
+	for _, input := range inputs {
+		fmt.Fprintf(&b, "%s  %s\n", input.SHA256, input.Path)
+	}
+	return SHA256([]byte(b.String()))
+}
+
+// Read reads a manifest written as JSON
+func Read(r io.Reader) (*Manifest, error) {
+	var m Manifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if m.FormatVersion == 0 {
+		return nil, fmt.Errorf("invalid manifest: format_version is missing")
+	}
+	if m.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("manifest format %d is newer than the supported format %d", m.FormatVersion, FormatVersion)
+	}
+	return &m, nil
+}
+
+// Verify compares the inputs of the manifest with the files read through the provider and
+// returns the inputs that changed or are missing, in manifest order. Paths outside the
+// directory are reported as missing without reading them. Files the scan did not read (e.g.
+// added since) are not detected; re-scan with the same configuration and compare the results
+// for that.
+func Verify(m *Manifest, provider types.Provider) []Mismatch {
+	var mismatches []Mismatch
+	for _, input := range m.Inputs {
+		missing := Mismatch{Path: input.Path, Status: StatusMissing, Expected: input.SHA256}
+		if !filepath.IsLocal(filepath.FromSlash(input.Path)) {
+			mismatches = append(mismatches, missing)
+			continue
+		}
+		content, err := provider.ReadFile(input.Path)
+		if err != nil {
+			mismatches = append(mismatches, missing)
+			continue
+		}
+		if digest := SHA256(content); digest != input.SHA256 {
+			mismatches = append(mismatches, Mismatch{Path: input.Path, Status: StatusChanged, Expected: input.SHA256, Actual: digest})
+		}
+	}
+	return mismatches
+}
+
+// SHA256 returns the hex sha256 digest of content
+func SHA256(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
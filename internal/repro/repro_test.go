@@ -0,0 +1,93 @@
+package repro
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	inputs := map[string]string{
+		"src/app.js":   SHA256([]byte("console.log(1)\n")),
+		"package.json": SHA256([]byte("{}\n")),
+	}
+	scannedAt := time.Date(2026, 10, 16, 10, 0, 0, 0, time.FixedZone("CEST", 2*3600))
+	m := New(inputs, "cfg", []byte(`{"id":"root"}`), scannedAt)
+
+	assert.Equal(t, FormatVersion, m.FormatVersion)
+	assert.Equal(t, "2026-10-16T08:00:00Z", m.ScannedAt)
+	assert.Equal(t, "cfg", m.ConfigDigest)
+	assert.Equal(t, SHA256([]byte(`{"id":"root"}`)), m.ResultDigest)
+	require.Len(t, m.Inputs, 2)
+	assert.Equal(t, "package.json", m.Inputs[0].Path, "inputs are sorted by path")
+	assert.Equal(t, "src/app.js", m.Inputs[1].Path)
+
+	sums := m.Inputs[0].SHA256 + "  package.json\n" + m.Inputs[1].SHA256 + "  src/app.js\n"
+	assert.Equal(t, SHA256([]byte(sums)), m.InputsDigest, "inputs digest is the digest of the sha256sum listing")
+}
+
+func TestConfigDigest(t *testing.T) {
+	type cfg struct {
+		Exclude []string `json:"exclude"`
+	}
+	a, err := ConfigDigest(cfg{Exclude: []string{"vendor"}})
+	require.NoError(t, err)
+	b, err := ConfigDigest(cfg{Exclude: []string{"vendor"}})
+	require.NoError(t, err)
+	c, err := ConfigDigest(cfg{Exclude: []string{"node_modules"}})
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+}
+
+func TestVerify(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	write("package.json", `{"name":"app"}`)
+	write("src/app.js", "console.log(1)\n")
+	write("go.mod", "module app\n")
+
+	p := provider.NewFSProvider(dir)
+	m := New(map[string]string{
+		"package.json": SHA256([]byte(`{"name":"app"}`)),
+		"src/app.js":   SHA256([]byte("console.log(1)\n")),
+		"go.mod":       SHA256([]byte("module app\n")),
+		"../secret":    SHA256([]byte("")),
+	}, "", nil, time.Now())
+	assert.Equal(t, []Mismatch{{Path: "../secret", Status: StatusMissing, Expected: SHA256([]byte(""))}}, Verify(m, p), "paths outside the directory are not read")
+	m.Inputs = m.Inputs[1:]
+	assert.Empty(t, Verify(m, p))
+
+	write("src/app.js", "console.log(2)\n")
+	require.NoError(t, os.Remove(filepath.Join(dir, "go.mod")))
+	assert.Equal(t, []Mismatch{
+		{Path: "go.mod", Status: StatusMissing, Expected: SHA256([]byte("module app\n"))},
+		{Path: "src/app.js", Status: StatusChanged, Expected: SHA256([]byte("console.log(1)\n")), Actual: SHA256([]byte("console.log(2)\n"))},
+	}, Verify(m, p))
+}
+
+func TestRead(t *testing.T) {
+	m := New(map[string]string{"a": "b"}, "cfg", nil, time.Now())
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	read, err := Read(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, m, read)
+
+	_, err = Read(bytes.NewReader([]byte(`{}`)))
+	assert.ErrorContains(t, err, "format_version is missing")
+	_, err = Read(bytes.NewReader([]byte(`{"format_version": 2}`)))
+	assert.ErrorContains(t, err, "newer than the supported format")
+	_, err = Read(bytes.NewReader([]byte(`not json`)))
+	assert.Error(t, err)
+}
@@ -0,0 +1,150 @@
+package scanner
+
+import (
+	"log/slog"
+	"path"
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Handling of nested git repositories and symbolic links to directories (scan boundaries)
+const (
+	BoundaryComponent = "component" // Scan as a separate component
+	BoundarySkip      = "skip"      // Do not scan
+)
+
+// BoundaryModes lists the supported handling of scan boundaries
+var BoundaryModes = []string{BoundaryComponent, BoundarySkip}
+
+// Component types of scan boundaries without a detected component
+const (
+	componentTypeRepository = "repository"
+	componentTypeLink       = "link"
+)
+
+// dirLink is a symbolic link to a directory inside the scan root, followed after the walk
+type dirLink struct {
+	ctx    *types.Payload // Component of the directory containing the link
+	path   string         // Full path of the link
+	target string         // Target relative to the scan root ("/lib")
+}
+
+// SetNestedRepos sets how git repositories below the scan root are handled: BoundaryComponent
+// (default) scans each as a component with its own git information, BoundarySkip does not scan them
+func (s *Scanner) SetNestedRepos(mode string) {
+	s.nestedRepos = mode
+}
+
+// SetSymlinks sets how symbolic links to directories inside the scan root are handled:
+// BoundarySkip (default) does not follow them, BoundaryComponent records each as a component
+// referencing its target, scanned if the walk did not reach the target. Links leaving the scan
+// root are never followed.
+func (s *Scanner) SetSymlinks(mode string) {
+	s.symlinks = mode
+}
+
+// isNestedRepo reports whether a directory listing below the scan root is a git repository
+// (a .git directory, or a .git file of submodules and worktrees)
+func (s *Scanner) isNestedRepo(dirPath string, files []types.File) bool {
+	if dirPath == s.provider.GetBasePath() {
+		return false
+	}
+	for _, file := range files {
+		if file.Name == ".git" {
+			return true
+		}
+	}
+	return false
+}
+
+// nestedRepoComponent returns the component of a nested repository without a detected component
+func (s *Scanner) nestedRepoComponent(payload *types.Payload, dirPath string) *types.Payload {
+	component := types.NewPayloadWithPath(filepath.Base(dirPath), s.relativePath(dirPath))
+	component.SetComponentType(componentTypeRepository)
+	payload.AddChild(component)
+	return component
+}
+
+// linkTarget resolves a symbolic link of a directory listing to its target relative to the scan
+// root ("/lib"). Links leaving the scan root are reported as not ok (debug log); dangling links
+// are ok without target, reading them reports the error.
+func (s *Scanner) linkTarget(dirPath string, file types.File) (string, bool) {
+	fullPath := filepath.Join(dirPath, file.Name)
+	target, err := filepath.EvalSymlinks(fullPath)
+	if err != nil {
+		return "", true
+	}
+	if s.realBase == "" {
+		s.realBase = s.provider.GetBasePath()
+		if real, err := filepath.EvalSymlinks(s.realBase); err == nil {
+			s.realBase = real
+		}
+	}
+	rel, err := filepath.Rel(s.realBase, target)
+	if err != nil || !filepath.IsLocal(rel) && rel != "." {
+		slog.Debug("Skipping link leaving the scan root", "path", fullPath, "target", target)
+		return "", false
+	}
+	return path.Join("/", filepath.ToSlash(rel)), true
+}
+
+// markWalked records a directory as scanned by its location relative to the scan root, resolving
+// the link followed to reach it
+func (s *Scanner) markWalked(dirPath string) {
+	if s.symlinks != BoundaryComponent {
+		return
+	}
+	if s.walked == nil {
+		s.walked = make(map[string]bool)
+	}
+	rel := s.relativePath(dirPath)
+	if s.following != nil {
+		if linkRel, err := filepath.Rel(s.following.path, dirPath); err == nil {
+			rel = path.Join(s.following.target, filepath.ToSlash(linkRel))
+		}
+	}
+	s.walked[rel] = true
+}
+
+// followLinks adds the components of the symbolic links to directories found by the walk. Targets
+// the walk did not reach (e.g. ignored directories) are scanned at the location of the link, if
+// walk is true; incremental scans only know the rescanned directories and do not scan targets.
+func (s *Scanner) followLinks(walk bool) {
+	// Links found while following a link are appended and handled in turn
+	for i := 0; i < len(s.links); i++ {
+		link := s.links[i]
+		component := types.NewPayloadWithPath(filepath.Base(link.path), s.relativePath(link.path))
+		component.SetComponentType(componentTypeLink)
+		component.SetComponentProperty(componentTypeLink, "target", link.target)
+		link.ctx.AddChild(component)
+
+		if walk && !s.walked[link.target] {
+			s.followLink(component, &link)
+		}
+	}
+	s.links = nil
+}
+
+// followLink scans the target of a link at the location of the link, with the .gitignore files
+// of the directories containing the link
+func (s *Scanner) followLink(component *types.Payload, link *dirLink) {
+	slog.Debug("Following link", "path", link.path, "target", link.target)
+	pushed := 0
+	for _, ancestor := range ancestorDirs(s.provider.GetBasePath(), link.path) {
+		if s.gitignoreStack.LoadAndPushGitignore(ancestor) {
+			pushed++
+		}
+	}
+	s.following = link
+	_ = s.recurse(component, link.path) // Errors are reported as path warnings
+	s.following = nil
+	for i := 0; i < pushed; i++ {
+		s.gitignoreStack.PopGitignore()
+	}
+}
+
+// relativePath returns the slash-separated path of a directory relative to the scan root ("/src")
+func (s *Scanner) relativePath(dirPath string) string {
+	return types.CalculateRelativePath("", dirPath, s.provider.GetBasePath())
+}
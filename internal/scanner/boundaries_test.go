@@ -0,0 +1,116 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// boundaryTree creates a scan root with a nested repository, links inside and outside the root,
+// and a link to an ignored directory
+func boundaryTree(t *testing.T) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symbolic links needs privileges on Windows")
+	}
+	outside := t.TempDir()
+	writeTree(t, outside, map[string]string{
+		"requirements.txt":  "django==4.2\n",
+		"sdk/package.json":  `{"name": "sdk", "dependencies": {"express": "4.18.0"}}`,
+		"sdk/src/client.ts": "export const client = 1\n",
+	})
+
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		"package.json":                `{"name": "root"}`,
+		".gitignore":                  "generated/\n",
+		"packages/a/package.json":     `{"name": "a", "dependencies": {"react": "18.2.0"}}`,
+		"packages/a/index.js":         "module.exports = 1\n",
+		"generated/tool/package.json": `{"name": "tool", "dependencies": {"vue": "3.4.0"}}`,
+		"vendor/lib/.git":             "gitdir: ../../.git/modules/lib\n",
+		"vendor/lib/README.md":        "# lib\n",
+		"vendor/app/.git/HEAD":        "ref: refs/heads/main\n",
+		"vendor/app/go.mod":           "module example.com/app\n\ngo 1.21\n",
+		"apps/web/package.json":       `{"name": "web"}`,
+	})
+	for link, target := range map[string]string{
+		"apps/web/shared":  "../../packages/a",
+		"apps/web/main.js": "../../packages/a/index.js",
+		"tools":            "generated/tool",
+		"loop":             ".",
+		"sdk":              filepath.Join(outside, "sdk"),
+		"requirements.txt": filepath.Join(outside, "requirements.txt"),
+	} {
+		require.NoError(t, os.Symlink(target, filepath.Join(dir, link)))
+	}
+	return dir
+}
+
+func scanBoundaries(t *testing.T, dir, nestedRepos, symlinks string) (*Scanner, map[string]*types.Payload) {
+	s, err := NewScannerWithOptionsAndRootID(dir, nil, false, false, false, false, nil, "test-root")
+	require.NoError(t, err)
+	s.SetNestedRepos(nestedRepos)
+	s.SetSymlinks(symlinks)
+	payload, err := s.Scan()
+	require.NoError(t, err)
+
+	components := make(map[string]*types.Payload)
+	walkPayloads(payload, func(p *types.Payload) { components[p.Path[0]] = p })
+	return s, components
+}
+
+func TestScanner_Boundaries_Default(t *testing.T) {
+	dir := boundaryTree(t)
+	s, components := scanBoundaries(t, dir, "", "")
+
+	paths := make([]string, 0, len(components))
+	for path := range components {
+		paths = append(paths, path)
+	}
+	assert.ElementsMatch(t, []string{"/", "/package.json", "/packages/a/package.json", "/apps/web/package.json", "/vendor/lib", "/vendor/app/go.mod"}, paths)
+
+	repo := components["/vendor/lib"]
+	assert.Equal(t, "lib", repo.Name)
+	assert.Equal(t, componentTypeRepository, repo.ComponentType)
+	assert.Contains(t, repo.Languages, "Markdown", "files of the repository are attributed to it")
+
+	assert.Equal(t, map[string]int{"JSON": 1}, components["/apps/web/package.json"].Languages, "linked file counted at its target")
+	assert.NotContains(t, components["/package.json"].Techs, "python", "link leaving the root is not read")
+	assert.Empty(t, s.PathWarnings())
+}
+
+func TestScanner_Boundaries_SkipNestedRepos(t *testing.T) {
+	_, components := scanBoundaries(t, boundaryTree(t), BoundarySkip, "")
+
+	assert.NotContains(t, components, "/vendor/lib")
+	assert.NotContains(t, components, "/vendor/app/go.mod")
+	assert.Contains(t, components, "/packages/a/package.json")
+}
+
+func TestScanner_Boundaries_LinkComponents(t *testing.T) {
+	_, components := scanBoundaries(t, boundaryTree(t), "", BoundaryComponent)
+
+	shared := components["/apps/web/shared"]
+	require.NotNil(t, shared)
+	assert.Equal(t, componentTypeLink, shared.ComponentType)
+	assert.Equal(t, map[string]interface{}{"target": "/packages/a"}, shared.Properties[componentTypeLink])
+	assert.Empty(t, shared.Children, "target already scanned")
+
+	loop := components["/loop"]
+	require.NotNil(t, loop)
+	assert.Equal(t, map[string]interface{}{"target": "/"}, loop.Properties[componentTypeLink])
+	assert.Empty(t, loop.Children)
+
+	tools := components["/tools"]
+	require.NotNil(t, tools)
+	assert.Equal(t, map[string]interface{}{"target": "/generated/tool"}, tools.Properties[componentTypeLink])
+	require.Len(t, tools.Children, 1, "ignored target scanned at the link")
+	assert.Equal(t, []string{"/tools/package.json"}, tools.Children[0].Path)
+
+	assert.NotContains(t, components, "/sdk", "link leaving the root")
+	assert.NotContains(t, components, "/generated/tool/package.json")
+}
@@ -71,7 +71,7 @@ func (s *Scanner) resolveComponentRefsRecursive(payload *types.Payload, registry
 				TargetID:    targetComponent.ID,
 				PackageName: dep.Name,
 			}
-			payload.ComponentRefs = append(payload.ComponentRefs, compRef)
+			addComponentRef(payload, compRef)
 		}
 	}
 
@@ -81,6 +81,16 @@ func (s *Scanner) resolveComponentRefsRecursive(payload *types.Payload, registry
 	}
 }
 
+// addComponentRef adds a component reference unless the component already has it
+func addComponentRef(payload *types.Payload, ref types.ComponentRef) {
+	for _, existing := range payload.ComponentRefs {
+		if existing == ref {
+			return
+		}
+	}
+	payload.ComponentRefs = append(payload.ComponentRefs, ref)
+}
+
 // findMatchingComponent tries to find a component that provides the given dependency
 func (s *Scanner) findMatchingComponent(dep types.Dependency, registry *ComponentRegistry) *types.Payload {
 	provider := providers.Get(dep.Type)
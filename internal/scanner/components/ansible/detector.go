@@ -0,0 +1,86 @@
+// Package ansible implements detection of Ansible role and collection dependencies declared
+// in requirements.yml and collection galaxy.yml files.
+package ansible
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Detector implements Ansible requirements detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "ansible"
+}
+
+// Detect scans for requirements.yml and galaxy.yml. Roles and collections are added as
+// "ansible" dependencies of a virtual component (merged into parent).
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	parser := parsers.NewAnsibleParser()
+	var payloads []*types.Payload
+
+	for _, file := range files {
+		if file.Type == "dir" {
+			continue
+		}
+		var requirements []parsers.AnsibleRequirement
+		var collection *parsers.AnsibleCollectionInfo
+		switch file.Name {
+		case "requirements.yml", "requirements.yaml":
+			content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+			if err != nil {
+				continue
+			}
+			if requirements, err = parser.ParseRequirementsYAML(string(content)); err != nil {
+				continue
+			}
+		case "galaxy.yml", "galaxy.yaml":
+			content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+			if err != nil {
+				continue
+			}
+			if collection, err = parser.ParseGalaxyYAML(string(content)); err != nil || collection.Name == "" {
+				continue
+			}
+			requirements = collection.Dependencies
+		default:
+			continue
+		}
+
+		if len(requirements) == 0 && collection == nil {
+			continue
+		}
+
+		relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, file.Name))
+		relativeFilePath = "/" + filepath.ToSlash(relativeFilePath)
+
+		payload := types.NewPayloadWithPath("virtual", relativeFilePath)
+		payload.AddTech("ansible", "matched file: "+file.Name)
+		if collection != nil {
+			payload.Properties["ansible_collection"] = collection
+		}
+		payload.Dependencies = parsers.CreateAnsibleDependencies(requirements, file.Name)
+
+		depNames := make([]string, 0, len(payload.Dependencies))
+		for _, dep := range payload.Dependencies {
+			depNames = append(depNames, dep.Name)
+		}
+		for tech, reasons := range depDetector.MatchDependencies(depNames, parsers.DependencyTypeAnsible) {
+			for _, reason := range reasons {
+				payload.AddTech(tech, reason)
+			}
+		}
+		payloads = append(payloads, payload)
+	}
+
+	return payloads
+}
+
+func init() {
+	components.Register(&Detector{})
+}
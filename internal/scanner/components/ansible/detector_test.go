@@ -0,0 +1,106 @@
+package ansible
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "ansible", (&Detector{}).Name())
+}
+
+func TestDetect_Requirements(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/project/roles/requirements.yml": "roles:\n  - name: geerlingguy.java\n    version: 2.3.1\ncollections:\n  - community.general\n",
+	}}
+	files := []types.File{{Name: "requirements.yml", Type: "file"}}
+
+	payloads := (&Detector{}).Detect(files, "/project/roles", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Equal(t, "virtual", payload.Name)
+	assert.Equal(t, []string{"/roles/requirements.yml"}, payload.Path)
+	assert.Contains(t, payload.Techs, "ansible")
+	require.Len(t, payload.Dependencies, 2)
+	assert.Equal(t, "geerlingguy.java", payload.Dependencies[0].Name)
+	assert.Equal(t, "ansible", payload.Dependencies[0].Type)
+	assert.Equal(t, "community.general", payload.Dependencies[1].Name)
+	assert.Equal(t, "latest", payload.Dependencies[1].Version)
+}
+
+func TestDetect_GalaxyYAML(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/project/galaxy.yml": "namespace: acme\nname: platform\nversion: 1.4.0\ndependencies:\n  ansible.utils: '>=2.0.0'\n",
+	}}
+	files := []types.File{{Name: "galaxy.yml", Type: "file"}}
+
+	payloads := (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+
+	collection, ok := payloads[0].Properties["ansible_collection"].(*parsers.AnsibleCollectionInfo)
+	require.True(t, ok)
+	assert.Equal(t, "acme.platform", collection.Name)
+	require.Len(t, payloads[0].Dependencies, 1)
+	assert.Equal(t, ">=2.0.0", payloads[0].Dependencies[0].Version)
+	assert.Equal(t, "galaxy.yml", payloads[0].Dependencies[0].Metadata["source"])
+}
+
+func TestDetect_UnrelatedRequirementsYAML(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/project/requirements.yml": "packages:\n  - numpy\n",
+	}}
+	files := []types.File{{Name: "requirements.yml", Type: "file"}}
+
+	assert.Empty(t, (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{}))
+}
@@ -0,0 +1,199 @@
+// Package appframework implements detection of desktop and mobile application frameworks
+// (Electron, Tauri, React Native) and the platforms they target.
+package appframework
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Configuration files identifying each framework
+var (
+	electronConfigFiles    = []string{"electron-builder.yml", "electron-builder.yaml", "electron-builder.json", "electron-builder.json5", "forge.config.js", "forge.config.ts"}
+	tauriConfigFiles       = []string{"tauri.conf.json"}
+	reactNativeConfigFiles = []string{"metro.config.js", "metro.config.cjs", "react-native.config.js"}
+)
+
+// Detector implements Electron, Tauri, and React Native detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "appframework"
+}
+
+// Detect scans for app framework dependencies and configuration files. The framework and
+// its platform targets are stored in the "app_framework" property of a virtual component (merged into parent).
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	parser := parsers.NewAppFrameworkParser()
+	packageJSON, dependencies := d.readPackageJSON(files, currentPath, provider)
+
+	info := d.detectTauri(files, currentPath, provider, parser)
+	if info == nil {
+		info = d.detectElectron(files, currentPath, provider, parser, packageJSON, dependencies)
+	}
+	if info == nil {
+		info = d.detectReactNative(files, currentPath, provider, parser, dependencies)
+	}
+	if info == nil {
+		return nil
+	}
+	info.Sort()
+
+	configFile := info.File
+	relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, configFile))
+	info.File = "/" + filepath.ToSlash(relativeFilePath)
+
+	payload := types.NewPayloadWithPath("virtual", info.File)
+	payload.Properties["app_framework"] = info
+	payload.AddTech(info.Framework, "matched file: "+configFile)
+	return []*types.Payload{payload}
+}
+
+// detectElectron detects Electron apps (electron dependency, electron-builder or Forge configuration)
+func (d *Detector) detectElectron(files []types.File, currentPath string, provider types.Provider, parser *parsers.AppFrameworkParser, packageJSON []byte, dependencies map[string]string) *parsers.AppFrameworkInfo {
+	configFile := firstFile(files, electronConfigFiles)
+	version, hasElectron := dependencies["electron"]
+	if configFile == "" && !hasElectron {
+		return nil
+	}
+
+	info := &parsers.AppFrameworkInfo{Framework: parsers.AppFrameworkElectron, File: configFile, Version: version}
+	if configFile == "" {
+		info.File = "package.json"
+	}
+	if packageJSON != nil {
+		parser.ParseElectronPackageJSON(packageJSON, info)
+	}
+	if configFile != "" && filepath.Ext(configFile) != ".js" && filepath.Ext(configFile) != ".ts" {
+		if content, err := provider.ReadFile(filepath.Join(currentPath, configFile)); err == nil {
+			_ = parser.ParseElectronBuilderConfig(content, configFile, info)
+		}
+	}
+	return info
+}
+
+// detectTauri detects Tauri apps (tauri.conf.json, usually in src-tauri). Mobile targets
+// are reported when Tauri 2.x mobile projects were generated (gen/android, gen/apple).
+func (d *Detector) detectTauri(files []types.File, currentPath string, provider types.Provider, parser *parsers.AppFrameworkParser) *parsers.AppFrameworkInfo {
+	configFile := firstFile(files, tauriConfigFiles)
+	if configFile == "" {
+		return nil
+	}
+	content, err := provider.ReadFile(filepath.Join(currentPath, configFile))
+	if err != nil {
+		return nil
+	}
+
+	info := &parsers.AppFrameworkInfo{Framework: parsers.AppFrameworkTauri, File: configFile}
+	if err := parser.ParseTauriConfig(content, info); err != nil {
+		return nil
+	}
+
+	if cargo, err := provider.ReadFile(filepath.Join(currentPath, "Cargo.toml")); err == nil {
+		_, _, deps, _ := parsers.NewRustParser().ParseCargoToml(string(cargo))
+		for _, dep := range deps {
+			if dep.Name == "tauri" {
+				info.Version = dep.Version
+			}
+		}
+	}
+
+	if entries, err := provider.ListDir(filepath.Join(currentPath, "gen")); err == nil {
+		for _, entry := range entries {
+			switch entry.Name {
+			case "android":
+				info.AddTargets(parsers.AppPlatformAndroid)
+			case "apple":
+				info.AddTargets(parsers.AppPlatformIOS)
+			}
+		}
+	}
+	return info
+}
+
+// detectReactNative detects React Native apps (react-native or expo dependency). Platforms come
+// from the native project folders (android, ios) and the Expo configuration (app.json).
+func (d *Detector) detectReactNative(files []types.File, currentPath string, provider types.Provider, parser *parsers.AppFrameworkParser, dependencies map[string]string) *parsers.AppFrameworkInfo {
+	version, hasReactNative := dependencies["react-native"]
+	_, hasExpo := dependencies["expo"]
+	if !hasReactNative && !hasExpo {
+		return nil
+	}
+
+	info := &parsers.AppFrameworkInfo{Framework: parsers.AppFrameworkReactNative, File: firstFile(files, reactNativeConfigFiles), Version: version}
+	if info.File == "" {
+		info.File = "package.json"
+	}
+
+	if hasDir(files, "android") {
+		info.AddTargets(parsers.AppPlatformAndroid)
+	}
+	if hasDir(files, "ios") {
+		info.AddTargets(parsers.AppPlatformIOS)
+	}
+	if firstFile(files, []string{"app.json"}) != "" {
+		if content, err := provider.ReadFile(filepath.Join(currentPath, "app.json")); err == nil {
+			parser.ParseExpoConfig(content, info)
+		}
+	}
+	if _, hasWeb := dependencies["react-native-web"]; hasWeb {
+		info.AddTargets(parsers.AppPlatformWeb)
+	}
+	return info
+}
+
+// readPackageJSON returns the package.json content and its dependencies and devDependencies
+func (d *Detector) readPackageJSON(files []types.File, currentPath string, provider types.Provider) ([]byte, map[string]string) {
+	dependencies := make(map[string]string)
+	if firstFile(files, []string{"package.json"}) == "" {
+		return nil, dependencies
+	}
+	content, err := provider.ReadFile(filepath.Join(currentPath, "package.json"))
+	if err != nil {
+		return nil, dependencies
+	}
+	var packageJSON struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(content, &packageJSON); err != nil {
+		return nil, dependencies
+	}
+	for name, version := range packageJSON.DevDependencies {
+		dependencies[name] = version
+	}
+	for name, version := range packageJSON.Dependencies {
+		dependencies[name] = version
+	}
+	return content, dependencies
+}
+
+// firstFile returns the first of names present as a file
+func firstFile(files []types.File, names []string) string {
+	for _, name := range names {
+		for _, file := range files {
+			if file.Name == name && file.Type != "dir" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+func hasDir(files []types.File, name string) bool {
+	for _, file := range files {
+		if file.Name == name && file.Type == "dir" {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	components.Register(&Detector{})
+}
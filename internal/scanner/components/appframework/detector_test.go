@@ -0,0 +1,171 @@
+package appframework
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	// Derive directory entries from the mock file paths
+	seen := make(map[string]bool)
+	var entries []types.File
+	prefix := strings.TrimSuffix(path, "/") + "/"
+	for filePath := range m.files {
+		if !strings.HasPrefix(filePath, prefix) {
+			continue
+		}
+		name, rest, isDir := strings.Cut(strings.TrimPrefix(filePath, prefix), "/")
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		entryType := "file"
+		if isDir && rest != "" {
+			entryType = "dir"
+		}
+		entries = append(entries, types.File{Name: name, Path: prefix + name, Type: entryType})
+	}
+	return entries, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func detectApp(t *testing.T, files []types.File, currentPath string, provider *MockProvider) (*types.Payload, *parsers.AppFrameworkInfo) {
+	t.Helper()
+	results := (&Detector{}).Detect(files, currentPath, "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.Equal(t, "virtual", results[0].Name)
+	return results[0], results[0].Properties["app_framework"].(*parsers.AppFrameworkInfo)
+}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "appframework", (&Detector{}).Name())
+}
+
+func TestDetector_Detect_Electron(t *testing.T) {
+	provider := &MockProvider{
+		files: map[string]string{
+			"/repo/package.json":         `{"devDependencies": {"electron": "^31.0.0", "electron-builder": "^24.13.0"}}`,
+			"/repo/electron-builder.yml": "appId: com.example.app\nmac:\n  target: dmg\nwin:\n  target: [nsis, portable]\n",
+		},
+	}
+	files := []types.File{{Name: "package.json"}, {Name: "electron-builder.yml"}}
+
+	payload, info := detectApp(t, files, "/repo", provider)
+	assert.Contains(t, payload.Techs, "electron")
+	assert.Equal(t, &parsers.AppFrameworkInfo{
+		Framework: "electron",
+		File:      "/electron-builder.yml",
+		Version:   "^31.0.0",
+		AppID:     "com.example.app",
+		Platforms: []string{"macos", "windows"},
+		Targets:   map[string][]string{"macos": {"dmg"}, "windows": {"nsis", "portable"}},
+	}, info)
+}
+
+func TestDetector_Detect_Tauri(t *testing.T) {
+	provider := &MockProvider{
+		files: map[string]string{
+			"/repo/src-tauri/tauri.conf.json":                  `{"productName": "Notes", "identifier": "com.example.notes", "bundle": {"targets": "all"}}`,
+			"/repo/src-tauri/Cargo.toml":                       "[package]\nname = \"notes\"\n\n[dependencies]\ntauri = { version = \"2.0.1\", features = [] }\n",
+			"/repo/src-tauri/gen/android/app/build.gradle.kts": "",
+		},
+	}
+	files := []types.File{{Name: "tauri.conf.json"}, {Name: "Cargo.toml"}, {Name: "gen", Type: "dir"}}
+
+	payload, info := detectApp(t, files, "/repo/src-tauri", provider)
+	assert.Contains(t, payload.Techs, "tauri")
+	assert.Equal(t, "/src-tauri/tauri.conf.json", info.File)
+	assert.Equal(t, "2.0.1", info.Version)
+	assert.Equal(t, "com.example.notes", info.AppID)
+	assert.Equal(t, []string{"android", "linux", "macos", "windows"}, info.Platforms)
+}
+
+func TestDetector_Detect_ReactNative(t *testing.T) {
+	provider := &MockProvider{
+		files: map[string]string{
+			"/repo/mobile/package.json": `{"dependencies": {"react-native": "0.74.2", "react": "18.2.0"}}`,
+		},
+	}
+	files := []types.File{
+		{Name: "package.json"},
+		{Name: "metro.config.js"},
+		{Name: "android", Type: "dir"},
+		{Name: "ios", Type: "dir"},
+	}
+
+	payload, info := detectApp(t, files, "/repo/mobile", provider)
+	assert.Contains(t, payload.Techs, "reactnative")
+	assert.Equal(t, &parsers.AppFrameworkInfo{
+		Framework: "reactnative",
+		File:      "/mobile/metro.config.js",
+		Version:   "0.74.2",
+		Platforms: []string{"android", "ios"},
+	}, info)
+}
+
+func TestDetector_Detect_Expo(t *testing.T) {
+	provider := &MockProvider{
+		files: map[string]string{
+			"/repo/package.json": `{"dependencies": {"expo": "~51.0.0", "react-native": "0.74.2", "react-native-web": "~0.19.10"}}`,
+			"/repo/app.json":     `{"expo": {"name": "Shop", "ios": {"bundleIdentifier": "com.example.shop"}}}`,
+		},
+	}
+	files := []types.File{{Name: "package.json"}, {Name: "app.json"}}
+
+	_, info := detectApp(t, files, "/repo", provider)
+	assert.Equal(t, "/package.json", info.File)
+	assert.Equal(t, "com.example.shop", info.AppID)
+	assert.Equal(t, []string{"android", "ios", "web"}, info.Platforms)
+}
+
+func TestDetector_Detect_NoApp(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{"/repo/package.json": `{"dependencies": {"react": "^18.0.0"}}`}}
+	results := (&Detector{}).Detect([]types.File{{Name: "package.json"}}, "/repo", "/repo", provider, &MockDependencyDetector{})
+	assert.Empty(t, results)
+}
@@ -0,0 +1,186 @@
+// Package artifacts implements detection of binary artifacts: Java archives (jar, war, ear),
+// Go binaries, .NET assemblies, and ELF and Mach-O executables, inventoried from the metadata
+// they embed.
+package artifacts
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// maxArtifactSize bounds the size of the binaries read
+const maxArtifactSize = 128 << 20
+
+// formatTechs maps artifact formats to the technology of the artifact
+var formatTechs = map[string]string{
+	parsers.ArtifactFormatJar:    "java",
+	parsers.ArtifactFormatWar:    "java",
+	parsers.ArtifactFormatEar:    "java",
+	parsers.ArtifactFormatGo:     "golang",
+	parsers.ArtifactFormatDotnet: "dotnet",
+}
+
+// OS package databases of each scan root (container root file systems), read once
+var (
+	packageIndexMu    sync.Mutex
+	packageIndexCache = make(map[string]*parsers.OSPackageIndex)
+)
+
+// Detector implements binary artifact detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "artifacts"
+}
+
+// Detect reads the jars, wars, ears, .NET assemblies (.dll, .exe), Go binaries, and ELF and
+// Mach-O executables (files without extension, .bin, .out, and .exe for Go) of the current
+// directory. The artifacts are recorded in the "artifacts" property of a virtual component
+// (merged into parent). The modules embedded in applications, the shared libraries required
+// by executables, and library artifacts themselves become dependencies with the "artifact"
+// metadata. A .dll with a .runtimeconfig.json is an application.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	parser := parsers.NewArtifactParser()
+	names := make(map[string]bool, len(files))
+	for _, file := range files {
+		names[file.Name] = true
+	}
+
+	var payload *types.Payload
+	var artifacts []interface{}
+	dependencyNames := make(map[string][]string) // type -> names
+	for _, file := range files {
+		if file.Type == "dir" || file.Size > maxArtifactSize {
+			continue
+		}
+		ext := strings.ToLower(filepath.Ext(file.Name))
+		if !isArtifactCandidate(file.Name, ext) {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			continue
+		}
+		artifact := parseArtifact(parser, content, file.Name, ext, func() *parsers.OSPackageIndex {
+			return packageIndex(basePath, provider)
+		})
+		if artifact == nil {
+			continue
+		}
+		if artifact.Name == "" {
+			artifact.Name = file.Name
+		}
+		if artifact.Format == parsers.ArtifactFormatDotnet && names[strings.TrimSuffix(file.Name, filepath.Ext(file.Name))+".runtimeconfig.json"] {
+			artifact.Application = true
+		}
+		artifact.File = types.CalculateRelativePath(file.Name, currentPath, basePath)
+
+		if payload == nil {
+			payload = types.NewPayloadWithPath("virtual", artifact.File)
+		} else {
+			payload.AddPath(artifact.File)
+		}
+		if tech := formatTechs[artifact.Format]; tech != "" {
+			payload.AddTech(tech, "matched file: "+file.Name)
+		}
+		artifacts = append(artifacts, artifact)
+		for _, dep := range parser.Dependencies(artifact) {
+			payload.AddDependency(dep)
+			dependencyNames[dep.Type] = append(dependencyNames[dep.Type], dep.Name)
+		}
+	}
+	if payload == nil {
+		return nil
+	}
+
+	payload.Properties["artifacts"] = artifacts
+	for depType, depNames := range dependencyNames {
+		for tech, reasons := range depDetector.MatchDependencies(depNames, depType) {
+			for _, reason := range reasons {
+				payload.AddTech(tech, reason)
+			}
+		}
+	}
+	return []*types.Payload{payload}
+}
+
+// isArtifactCandidate reports whether a file may be a binary artifact
+func isArtifactCandidate(name, ext string) bool {
+	switch ext {
+	case ".jar", ".war", ".ear", ".dll", ".exe":
+		return true
+	case "", ".bin", ".out":
+		return !strings.HasPrefix(name, ".")
+	}
+	return false
+}
+
+// parseArtifact reads the metadata of a binary artifact, or returns nil if the file is not one.
+// The shared libraries of native executables (including Go binaries linked with cgo) are
+// mapped to the packages of the OS package database returned by index.
+func parseArtifact(parser *parsers.ArtifactParser, content []byte, fileName, ext string, index func() *parsers.OSPackageIndex) *parsers.Artifact {
+	switch ext {
+	case ".jar", ".war", ".ear":
+		if artifact, err := parser.ParseJavaArchive(content, fileName); err == nil {
+			return artifact
+		}
+		return nil
+	case ".dll", ".exe":
+		if artifact, err := parser.ParseDotnetAssembly(content); err == nil {
+			return artifact
+		}
+	}
+	artifact, _ := parser.ParseGoBinary(content)
+	native, libraries, err := parser.ParseNativeBinary(content)
+	if err != nil {
+		return artifact
+	}
+	if artifact == nil {
+		artifact = native
+	} else {
+		artifact.Arch = native.Arch
+	}
+	parser.AddSharedLibraries(artifact, libraries, index())
+	return artifact
+}
+
+// packageIndex returns the OS package database of the scan root (var/lib/dpkg or
+// lib/apk/db/installed of a container root file system), or nil if it has none
+func packageIndex(basePath string, provider types.Provider) *parsers.OSPackageIndex {
+	packageIndexMu.Lock()
+	defer packageIndexMu.Unlock()
+	if index, ok := packageIndexCache[basePath]; ok {
+		return index
+	}
+
+	var index *parsers.OSPackageIndex
+	parser := parsers.NewOSPackageParser()
+	if content, err := provider.ReadFile(filepath.Join(basePath, "var", "lib", "dpkg", "status")); err == nil {
+		index = parsers.NewOSPackageIndex(parsers.PackageManagerDpkg)
+		index.Versions = parser.ParseDpkgStatus(string(content))
+		infoDir := filepath.Join(basePath, "var", "lib", "dpkg", "info")
+		entries, _ := provider.ListDir(infoDir)
+		for _, entry := range entries {
+			if entry.Type == "dir" || !strings.HasSuffix(entry.Name, ".list") {
+				continue
+			}
+			if list, err := provider.ReadFile(filepath.Join(infoDir, entry.Name)); err == nil {
+				index.AddFiles(parser.DpkgListPackage(entry.Name), strings.Split(string(list), "\n"))
+			}
+		}
+	} else if content, err := provider.ReadFile(filepath.Join(basePath, "lib", "apk", "db", "installed")); err == nil {
+		index = parser.ParseApkInstalled(string(content))
+	}
+	packageIndexCache[basePath] = index
+	return index
+}
+
+func init() {
+	components.Register(&Detector{})
+}
@@ -0,0 +1,169 @@
+package artifacts
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+// ListDir derives the directory entries from the file paths
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	seen := make(map[string]bool)
+	var entries []types.File
+	for filePath := range m.files {
+		rel, err := filepath.Rel(path, filePath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		name, rest, isDir := strings.Cut(rel, string(filepath.Separator))
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		entry := types.File{Name: name, Path: filepath.Join(path, name), Type: "file"}
+		if isDir && rest != "" {
+			entry.Type = "dir"
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return nil, os.ErrNotExist
+	}
+	return entries, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+// buildZip returns an archive with the given entries
+func buildZip(t *testing.T, entries map[string]string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, content := range entries {
+		w, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buf.String()
+}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "artifacts", (&Detector{}).Name())
+}
+
+func TestDetect_Jars(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/lib/guava-31.1-jre.jar": buildZip(t, map[string]string{
+			"META-INF/maven/com.google.guava/guava/pom.properties": "groupId=com.google.guava\nartifactId=guava\nversion=31.1-jre\n",
+		}),
+		"/repo/lib/README":     "Checked-in libraries",
+		"/repo/lib/broken.jar": "not an archive",
+	}}
+	files := []types.File{
+		{Name: "guava-31.1-jre.jar", Type: "file", Size: 100},
+		{Name: "README", Type: "file", Size: 20},
+		{Name: "broken.jar", Type: "file", Size: 14},
+	}
+
+	payloads := (&Detector{}).Detect(files, "/repo/lib", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Equal(t, "virtual", payload.Name)
+	assert.Equal(t, []string{"/lib/guava-31.1-jre.jar"}, payload.Path)
+	assert.Contains(t, payload.Techs, "java")
+	require.Len(t, payload.Dependencies, 1)
+	assert.Equal(t, "maven", payload.Dependencies[0].Type)
+	assert.Equal(t, "com.google.guava:guava", payload.Dependencies[0].Name)
+	assert.Equal(t, "/lib/guava-31.1-jre.jar", payload.Dependencies[0].Metadata["artifact"])
+
+	artifacts, ok := payload.Properties["artifacts"].([]interface{})
+	require.True(t, ok)
+	assert.Len(t, artifacts, 1)
+}
+
+func TestDetect_NoArtifacts(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/Makefile": "all:\n\tgo build\n",
+		"/repo/.envrc":   "use flake",
+	}}
+	files := []types.File{{Name: "Makefile", Type: "file", Size: 16}, {Name: ".envrc", Type: "file", Size: 9}}
+	assert.Nil(t, (&Detector{}).Detect(files, "/repo", "/repo", provider, &MockDependencyDetector{}))
+}
+
+func TestDetect_SkipsLargeFiles(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{"/repo/huge.jar": "PK"}}
+	files := []types.File{{Name: "huge.jar", Type: "file", Size: maxArtifactSize + 1}}
+	assert.Nil(t, (&Detector{}).Detect(files, "/repo", "/repo", provider, &MockDependencyDetector{}))
+}
+
+func TestPackageIndex(t *testing.T) {
+	dpkg := &MockProvider{files: map[string]string{
+		"/rootfs/var/lib/dpkg/status":                   "Package: libc6\nStatus: install ok installed\nVersion: 2.36-9+deb12u4\n",
+		"/rootfs/var/lib/dpkg/info/libc6:amd64.list":    "/.\n/lib/x86_64-linux-gnu/libc.so.6\n",
+		"/rootfs/var/lib/dpkg/info/libc6:amd64.md5sums": "d41d8cd98f00b204e9800998ecf8427e  lib/x86_64-linux-gnu/libc.so.6\n",
+	}}
+	index := packageIndex("/rootfs", dpkg)
+	require.NotNil(t, index)
+	pkg, version, ok := index.Lookup("libc.so.6")
+	assert.True(t, ok)
+	assert.Equal(t, "libc6", pkg)
+	assert.Equal(t, "2.36-9+deb12u4", version)
+
+	apk := &MockProvider{files: map[string]string{
+		"/alpine/lib/apk/db/installed": "P:musl\nV:1.2.4-r2\nF:lib\nR:libc.musl-x86_64.so.1\n",
+	}}
+	index = packageIndex("/alpine", apk)
+	require.NotNil(t, index)
+	assert.Equal(t, "apk", index.Manager)
+
+	assert.Nil(t, packageIndex("/project", &MockProvider{files: map[string]string{}}))
+}
@@ -0,0 +1,264 @@
+// Package blockchain implements detection of smart contract projects: Foundry projects
+// (foundry.toml), Hardhat projects (hardhat.config.*), and Anchor workspaces for Solana
+// programs (Anchor.toml).
+package blockchain
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/git"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// maxSolidityFiles bounds the number of .sol files read per project for pragma extraction
+const maxSolidityFiles = 2000
+
+// hardhatConfigFiles are the Hardhat configuration file names
+var hardhatConfigFiles = []string{
+	"hardhat.config.js", "hardhat.config.ts", "hardhat.config.cjs",
+	"hardhat.config.mjs", "hardhat.config.cts", "hardhat.config.mts",
+}
+
+// Detector implements smart contract project detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "blockchain"
+}
+
+// Detect scans for foundry.toml, Hardhat configurations, and Anchor.toml. Foundry projects
+// become components with their libraries as dependencies. Hardhat projects and Anchor
+// workspaces complement the npm and Cargo components of the same directory, so they are stored
+// in the "hardhat" and "anchor" properties of a virtual component (merged into parent).
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	var results []*types.Payload
+	var virtual *types.Payload
+	parser := parsers.NewBlockchainParser()
+
+	for _, file := range files {
+		if file.Type == "dir" {
+			continue
+		}
+		switch {
+		case file.Name == "foundry.toml":
+			if payload := d.detectFoundry(parser, file, currentPath, basePath, provider, depDetector); payload != nil {
+				results = append(results, payload)
+			}
+		case containsName(hardhatConfigFiles, file.Name):
+			content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+			if err != nil {
+				continue
+			}
+			info := parser.ParseHardhatConfig(string(content))
+			info.File = types.CalculateRelativePath(file.Name, currentPath, basePath)
+			info.ApplyPluginVersions(packageVersions(currentPath, provider))
+			info.SolidityPragmas = collectPragmas(parser, provider, filepath.Join(currentPath, info.Sources))
+			virtual = virtualPayload(virtual, info.File)
+			virtual.AddTech("hardhat", "matched file: "+file.Name)
+			virtual.Properties["hardhat"] = info
+		case file.Name == "Anchor.toml":
+			content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+			if err != nil {
+				continue
+			}
+			info := parser.ParseAnchorToml(string(content))
+			info.File = types.CalculateRelativePath(file.Name, currentPath, basePath)
+			virtual = virtualPayload(virtual, info.File)
+			virtual.AddTech("anchor", "matched file: "+file.Name)
+			virtual.AddTech("solana", "matched file: "+file.Name)
+			virtual.Properties["anchor"] = info
+		}
+	}
+	if virtual != nil {
+		results = append(results, virtual)
+	}
+	return results
+}
+
+// detectFoundry creates a Foundry component with its settings and libraries
+func (d *Detector) detectFoundry(parser *parsers.BlockchainParser, file types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
+	content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+	if err != nil {
+		return nil
+	}
+	config := parser.ParseFoundryToml(string(content))
+
+	payload := types.NewPayloadWithPath(filepath.Base(currentPath), types.CalculateRelativePath(file.Name, currentPath, basePath))
+	payload.SetComponentType("foundry")
+	payload.AddPrimaryTech("solidity")
+	payload.AddTech("foundry", "matched file: "+file.Name)
+
+	foundryInfo := map[string]interface{}{"src": config.Src, "libs": config.Libs}
+	if config.SolcVersion != "" {
+		foundryInfo["solc_version"] = config.SolcVersion
+	}
+	if config.EvmVersion != "" {
+		foundryInfo["evm_version"] = config.EvmVersion
+	}
+	if config.ViaIR {
+		foundryInfo["via_ir"] = true
+	}
+	if len(config.Profiles) > 1 { // Only the default profile otherwise
+		foundryInfo["profiles"] = config.Profiles
+	}
+	if pragmas := collectPragmas(parser, provider, filepath.Join(currentPath, config.Src)); len(pragmas) > 0 {
+		foundryInfo["solidity_pragmas"] = pragmas
+	}
+	payload.SetComponentProperties("foundry", foundryInfo)
+
+	submodules, gitmodulesFile := projectSubmodules(provider, currentPath, basePath)
+	if gitmodulesFile != "" {
+		payload.AddPath(gitmodulesFile)
+	}
+	var lock map[string]parsers.FoundryLockEntry
+	if components.UseLockFiles() {
+		if lockContent, err := provider.ReadFile(filepath.Join(currentPath, "foundry.lock")); err == nil {
+			if lock, err = parser.ParseFoundryLock(string(lockContent)); err == nil {
+				payload.AddPath(types.CalculateRelativePath("foundry.lock", currentPath, basePath))
+			}
+		}
+	}
+
+	var names []string
+	for _, dep := range parser.FoundryDependencies(config, submodules, lock) {
+		payload.AddDependency(dep)
+		names = append(names, dep.Name)
+	}
+	if len(names) > 0 {
+		for tech, reasons := range depDetector.MatchDependencies(names, parsers.DependencyTypeFoundry) {
+			for _, reason := range reasons {
+				payload.AddTech(tech, reason)
+			}
+			depDetector.AddPrimaryTechIfNeeded(payload, tech)
+		}
+	}
+	return payload
+}
+
+// projectSubmodules returns the submodules of the nearest .gitmodules at or above the project
+// directory (within the scanned tree), with paths relative to the project directory and the
+// commits pinned by the repository, and the relative path of the .gitmodules file
+func projectSubmodules(provider types.Provider, currentPath, basePath string) ([]parsers.GitSubmodule, string) {
+	for dir := currentPath; ; dir = filepath.Dir(dir) {
+		if content, err := provider.ReadFile(filepath.Join(dir, ".gitmodules")); err == nil {
+			prefix, err := filepath.Rel(dir, currentPath)
+			if err != nil {
+				return nil, ""
+			}
+			commits := git.SubmoduleCommits(dir)
+			var submodules []parsers.GitSubmodule
+			for _, submodule := range parsers.NewGitmodulesParser().ParseGitmodules(string(content)) {
+				submodule.Commit = commits[submodule.Path]
+				submodule.URL = git.SanitizeRemoteURL(submodule.URL)
+				path := submodule.Path
+				if prefix != "." {
+					var ok bool
+					if path, ok = strings.CutPrefix(path, filepath.ToSlash(prefix)+"/"); !ok {
+						continue
+					}
+				}
+				submodule.Path = path
+				submodules = append(submodules, submodule)
+			}
+			return submodules, types.CalculateRelativePath(".gitmodules", dir, basePath)
+		}
+		if dir == basePath || dir == filepath.Dir(dir) || !strings.HasPrefix(dir, basePath) {
+			return nil, ""
+		}
+	}
+}
+
+// collectPragmas returns the distinct compiler constraints of the .sol files below dir, sorted
+func collectPragmas(parser *parsers.BlockchainParser, provider types.Provider, dir string) []string {
+	seen := make(map[string]bool)
+	count := 0
+	walkSolidityFiles(parser, provider, dir, seen, &count)
+	pragmas := make([]string, 0, len(seen))
+	for pragma := range seen {
+		pragmas = append(pragmas, pragma)
+	}
+	sort.Strings(pragmas)
+	return pragmas
+}
+
+// walkSolidityFiles records the pragma of each .sol file below dir
+func walkSolidityFiles(parser *parsers.BlockchainParser, provider types.Provider, dir string, seen map[string]bool, count *int) {
+	entries, err := provider.ListDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if *count >= maxSolidityFiles {
+			return
+		}
+		path := filepath.Join(dir, entry.Name)
+		if entry.Type == "dir" {
+			if !entry.Symlink && !strings.HasPrefix(entry.Name, ".") && entry.Name != "node_modules" {
+				walkSolidityFiles(parser, provider, path, seen, count)
+			}
+			continue
+		}
+		if filepath.Ext(entry.Name) != ".sol" {
+			continue
+		}
+		*count++
+		content, err := provider.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if pragma := parser.SolidityPragma(string(content)); pragma != "" {
+			seen[pragma] = true
+		}
+	}
+}
+
+// packageVersions returns the declared versions of package.json dependencies in dir
+func packageVersions(dir string, provider types.Provider) map[string]string {
+	content, err := provider.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return nil
+	}
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil
+	}
+	versions := make(map[string]string, len(pkg.Dependencies)+len(pkg.DevDependencies))
+	for name, version := range pkg.DevDependencies {
+		versions[name] = version
+	}
+	for name, version := range pkg.Dependencies {
+		versions[name] = version
+	}
+	return versions
+}
+
+// virtualPayload returns the virtual payload of the directory, creating it for the first file
+func virtualPayload(payload *types.Payload, file string) *types.Payload {
+	if payload == nil {
+		return types.NewPayloadWithPath("virtual", file)
+	}
+	payload.AddPath(file)
+	return payload
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	components.Register(&Detector{})
+}
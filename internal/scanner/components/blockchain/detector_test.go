@@ -0,0 +1,166 @@
+package blockchain
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+// ListDir derives the directory entries from the file paths
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	seen := make(map[string]bool)
+	var entries []types.File
+	for filePath := range m.files {
+		rel, err := filepath.Rel(path, filePath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		name, rest, isDir := strings.Cut(rel, string(filepath.Separator))
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		entry := types.File{Name: name, Path: filepath.Join(path, name), Type: "file"}
+		if isDir && rest != "" {
+			entry.Type = "dir"
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return nil, os.ErrNotExist
+	}
+	return entries, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "blockchain", (&Detector{}).Name())
+}
+
+func TestDetect_FoundryProject(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/.gitmodules": "[submodule \"contracts/lib/forge-std\"]\n\tpath = contracts/lib/forge-std\n\turl = https://github.com/foundry-rs/forge-std\n" +
+			"[submodule \"docs/theme\"]\n\tpath = docs/theme\n\turl = https://github.com/org/theme\n",
+		"/repo/contracts/foundry.toml":            "[profile.default]\nsolc = \"0.8.24\"\n",
+		"/repo/contracts/foundry.lock":            `{"lib/forge-std": {"tag": {"name": "v1.9.4", "rev": "1eea5bae12ae557d589f9f0f0edae2faa47cb262"}}}`,
+		"/repo/contracts/src/Token.sol":           "// SPDX-License-Identifier: MIT\npragma solidity ^0.8.20;\ncontract Token {}\n",
+		"/repo/contracts/src/utils/Math.sol":      "pragma solidity >=0.8.0 <0.9.0;\nlibrary Math {}\n",
+		"/repo/contracts/src/utils/Strings.sol":   "pragma solidity ^0.8.20;\nlibrary Strings {}\n",
+		"/repo/contracts/lib/forge-std/README.md": "",
+	}}
+	files := []types.File{
+		{Name: "foundry.toml", Type: "file"},
+		{Name: "foundry.lock", Type: "file"},
+		{Name: "src", Type: "dir"},
+		{Name: "lib", Type: "dir"},
+	}
+
+	payloads := (&Detector{}).Detect(files, "/repo/contracts", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Equal(t, "contracts", payload.Name)
+	assert.Equal(t, "foundry", payload.ComponentType)
+	assert.Equal(t, []string{"solidity"}, payload.Tech)
+	assert.Contains(t, payload.Techs, "foundry")
+	assert.Equal(t, []string{"/contracts/foundry.toml", "/.gitmodules", "/contracts/foundry.lock"}, payload.Path)
+	assert.Equal(t, map[string]interface{}{
+		"src": "src", "libs": []string{"lib"}, "solc_version": "0.8.24",
+		"solidity_pragmas": []string{">=0.8.0 <0.9.0", "^0.8.20"},
+	}, payload.Properties["foundry"])
+
+	require.Len(t, payload.Dependencies, 1)
+	dep := payload.Dependencies[0]
+	assert.Equal(t, "forge-std", dep.Name)
+	assert.Equal(t, "foundry", dep.Type)
+	assert.Equal(t, "v1.9.4", dep.Version)
+	assert.Equal(t, "foundry.lock", dep.Metadata["source"])
+	assert.Equal(t, "lib/forge-std", dep.Metadata["submodule"])
+}
+
+func TestDetect_HardhatAndAnchor(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/hardhat.config.ts":     "import \"@nomicfoundation/hardhat-toolbox\";\nexport default { solidity: \"0.8.24\" };\n",
+		"/repo/package.json":          `{"devDependencies": {"hardhat": "^2.22.0", "@nomicfoundation/hardhat-toolbox": "^5.0.0"}}`,
+		"/repo/contracts/Vault.sol":   "pragma solidity 0.8.24;\ncontract Vault {}\n",
+		"/repo/Anchor.toml":           "[toolchain]\nanchor_version = \"0.30.1\"\n\n[programs.localnet]\nvault = \"9xQeWvG816bUx9EPjHmaT23yvVM2ZWbrrpZb9PusVFin\"\n",
+		"/repo/programs/vault/lib.rs": "",
+	}}
+	files := []types.File{
+		{Name: "Anchor.toml", Type: "file"},
+		{Name: "contracts", Type: "dir"},
+		{Name: "hardhat.config.ts", Type: "file"},
+		{Name: "package.json", Type: "file"},
+	}
+
+	payloads := (&Detector{}).Detect(files, "/repo", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Equal(t, "virtual", payload.Name)
+	assert.Equal(t, []string{"/Anchor.toml", "/hardhat.config.ts"}, payload.Path)
+	assert.ElementsMatch(t, []string{"anchor", "solana", "hardhat"}, payload.Techs)
+
+	hardhat, ok := payload.Properties["hardhat"].(*parsers.HardhatInfo)
+	require.True(t, ok)
+	assert.Equal(t, "/hardhat.config.ts", hardhat.File)
+	assert.Equal(t, []string{"0.8.24"}, hardhat.SolidityVersions)
+	assert.Equal(t, []parsers.HardhatPlugin{{Name: "@nomicfoundation/hardhat-toolbox", Version: "^5.0.0"}}, hardhat.Plugins)
+	assert.Equal(t, []string{"0.8.24"}, hardhat.SolidityPragmas)
+
+	anchor, ok := payload.Properties["anchor"].(*parsers.AnchorInfo)
+	require.True(t, ok)
+	assert.Equal(t, "0.30.1", anchor.AnchorVersion)
+	assert.Len(t, anchor.Programs, 1)
+}
+
+func TestDetect_NoSmartContractProject(t *testing.T) {
+	payloads := (&Detector{}).Detect([]types.File{{Name: "package.json", Type: "file"}}, "/repo", "/repo", &MockProvider{}, &MockDependencyDetector{})
+	assert.Empty(t, payloads)
+}
@@ -0,0 +1,130 @@
+// Package buf implements detection of Buf protobuf modules: module dependencies from
+// buf.yaml and buf.lock, and the import graph of the modules' .proto files.
+package buf
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// maxProtoFiles bounds the number of .proto files read per buf.yaml
+const maxProtoFiles = 5000
+
+// Detector implements Buf module detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "buf"
+}
+
+// Detect scans for buf.yaml. Module dependencies are added as "buf" dependencies and the
+// configuration with its proto import graph is stored in the "buf" property of a virtual
+// component (merged into parent).
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	if !hasFile(files, "buf.yaml") {
+		return nil
+	}
+
+	parser := parsers.NewBufParser()
+	content, err := provider.ReadFile(filepath.Join(currentPath, "buf.yaml"))
+	if err != nil {
+		return nil
+	}
+	config, err := parser.ParseBufYAML(string(content))
+	if err != nil {
+		return nil
+	}
+
+	var locked []parsers.BufModuleRef
+	if hasFile(files, "buf.lock") {
+		if lockContent, err := provider.ReadFile(filepath.Join(currentPath, "buf.lock")); err == nil {
+			locked, _ = parser.ParseBufLock(string(lockContent))
+		}
+	}
+
+	for _, module := range config.Modules {
+		root := filepath.Join(currentPath, filepath.FromSlash(module.Path))
+		if !isWithin(currentPath, root) {
+			continue
+		}
+		d.collectProtoFiles(root, root, provider, parser, config)
+	}
+	config.ResolveImports()
+
+	relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, "buf.yaml"))
+	config.File = "/" + filepath.ToSlash(relativeFilePath)
+
+	payload := types.NewPayloadWithPath("virtual", config.File)
+	payload.AddTech("buf", "matched file: buf.yaml")
+	payload.Properties["buf"] = []interface{}{config}
+	payload.Dependencies = config.CreateDependencies(locked)
+
+	var depNames []string
+	for _, dep := range payload.Dependencies {
+		depNames = append(depNames, dep.Name)
+	}
+	for tech, reasons := range depDetector.MatchDependencies(depNames, parsers.DependencyTypeBuf) {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+	}
+
+	return []*types.Payload{payload}
+}
+
+// collectProtoFiles recursively parses the .proto files below dir; paths are recorded
+// relative to the module root, as they appear in import statements
+func (d *Detector) collectProtoFiles(root, dir string, provider types.Provider, parser *parsers.BufParser, config *parsers.BufConfig) {
+	entries, err := provider.ListDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if len(config.Files) >= maxProtoFiles {
+			return
+		}
+		path := filepath.Join(dir, entry.Name)
+		if entry.Type == "dir" {
+			if !entry.Symlink && !strings.HasPrefix(entry.Name, ".") && entry.Name != "node_modules" {
+				d.collectProtoFiles(root, path, provider, parser, config)
+			}
+			continue
+		}
+		if filepath.Ext(entry.Name) != ".proto" {
+			continue
+		}
+		content, err := provider.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		protoFile := parser.ParseProtoFile(string(content))
+		rel, _ := filepath.Rel(root, path)
+		protoFile.File = filepath.ToSlash(rel)
+		config.Files = append(config.Files, protoFile)
+	}
+}
+
+// hasFile reports whether files contains a regular file with the given name
+func hasFile(files []types.File, name string) bool {
+	for _, file := range files {
+		if file.Name == name && file.Type != "dir" {
+			return true
+		}
+	}
+	return false
+}
+
+// isWithin reports whether target is base or below it
+func isWithin(base, target string) bool {
+	rel, err := filepath.Rel(base, target)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func init() {
+	components.Register(&Detector{})
+}
@@ -0,0 +1,143 @@
+package buf
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	// Derive directory entries from the mock file paths
+	seen := make(map[string]bool)
+	var entries []types.File
+	prefix := strings.TrimSuffix(path, "/") + "/"
+	for filePath := range m.files {
+		if !strings.HasPrefix(filePath, prefix) {
+			continue
+		}
+		name, rest, isDir := strings.Cut(strings.TrimPrefix(filePath, prefix), "/")
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		entryType := "file"
+		if isDir && rest != "" {
+			entryType = "dir"
+		}
+		entries = append(entries, types.File{Name: name, Path: prefix + name, Type: entryType})
+	}
+	return entries, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "buf", (&Detector{}).Name())
+}
+
+func TestDetector_Detect(t *testing.T) {
+	provider := &MockProvider{
+		files: map[string]string{
+			"/repo/api/buf.yaml": "version: v2\nmodules:\n  - path: proto\ndeps:\n  - buf.build/googleapis/googleapis\n",
+			"/repo/api/buf.lock": "version: v2\ndeps:\n  - name: buf.build/googleapis/googleapis\n    commit: 28151c0d0a1641bf938a7672c500e01d\n",
+			"/repo/api/proto/acme/weather/v1/weather.proto": `syntax = "proto3";
+package acme.weather.v1;
+import "acme/common/v1/types.proto";
+import "google/api/annotations.proto";
+`,
+			"/repo/api/proto/acme/common/v1/types.proto": `syntax = "proto3";
+package acme.common.v1;
+import "google/protobuf/timestamp.proto";
+`,
+			"/repo/api/proto/.cache/ignored.proto": `import "ignored/dep.proto";`,
+			"/repo/api/README.md":                  "",
+		},
+	}
+	files := []types.File{{Name: "buf.yaml", Type: "file"}, {Name: "buf.lock", Type: "file"}, {Name: "proto", Type: "dir"}}
+
+	results := (&Detector{}).Detect(files, "/repo/api", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Equal(t, "virtual", payload.Name)
+	assert.Contains(t, payload.Techs, "buf")
+	require.Len(t, payload.Dependencies, 1)
+	assert.Equal(t, "buf.build/googleapis/googleapis", payload.Dependencies[0].Name)
+	assert.Equal(t, "28151c0d0a1641bf938a7672c500e01d", payload.Dependencies[0].Version)
+
+	configs := payload.Properties["buf"].([]interface{})
+	require.Len(t, configs, 1)
+	config := configs[0].(*parsers.BufConfig)
+	assert.Equal(t, "/api/buf.yaml", config.File)
+	assert.Equal(t, []parsers.ProtoFile{
+		{File: "acme/common/v1/types.proto", Package: "acme.common.v1", Imports: []string{"google/protobuf/timestamp.proto"}},
+		{File: "acme/weather/v1/weather.proto", Package: "acme.weather.v1", Imports: []string{"acme/common/v1/types.proto", "google/api/annotations.proto"}},
+	}, config.Files)
+	assert.Equal(t, []parsers.ProtoImportOwner{
+		{Import: "google/api/annotations.proto", Module: "buf.build/googleapis/googleapis"},
+	}, config.ExternalImports)
+}
+
+func TestDetector_Detect_ModuleOutsideDirectory(t *testing.T) {
+	provider := &MockProvider{
+		files: map[string]string{
+			"/repo/api/buf.yaml":   "version: v2\nmodules:\n  - path: ../shared\n",
+			"/repo/shared/a.proto": `import "b.proto";`,
+		},
+	}
+	files := []types.File{{Name: "buf.yaml", Type: "file"}}
+
+	results := (&Detector{}).Detect(files, "/repo/api", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	config := results[0].Properties["buf"].([]interface{})[0].(*parsers.BufConfig)
+	assert.Empty(t, config.Files)
+}
+
+func TestDetector_Detect_NoBufYAML(t *testing.T) {
+	files := []types.File{{Name: "buf.gen.yaml", Type: "file"}}
+	assert.Empty(t, (&Detector{}).Detect(files, "/repo", "/repo", &MockProvider{}, &MockDependencyDetector{}))
+}
@@ -0,0 +1,101 @@
+// Package cmake implements detection of CMake projects (CMakeLists.txt) and the packages they
+// find with find_package.
+package cmake
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// languageTechs maps CMake language names to tech keys
+var languageTechs = map[string]string{
+	"C":       "c",
+	"CXX":     "cplusplus",
+	"FORTRAN": "fortran",
+	"CUDA":    "cuda",
+	"OBJC":    "objectivec",
+	"OBJCXX":  "objectivec",
+	"SWIFT":   "swift",
+	"CSHARP":  "csharp",
+}
+
+// Detector implements CMake project detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "cmake"
+}
+
+// Detect scans for CMakeLists.txt files declaring a project; scripts added with
+// add_subdirectory belong to the enclosing project. The declared languages become the primary
+// techs (cmake when none are declared), and find_package calls become dependencies, so
+// scientific libraries (BLAS, LAPACK, MPI, HDF5, ...) are matched by the rules.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	for _, file := range files {
+		if file.Name != "CMakeLists.txt" {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			return nil
+		}
+		parser := parsers.NewCMakeParser()
+		project := parser.ParseCMakeLists(string(content))
+		if !project.HasProject {
+			return nil
+		}
+
+		name := project.Name
+		if name == "" {
+			name = filepath.Base(currentPath)
+		}
+		payload := types.NewPayloadWithPath(name, types.CalculateRelativePath(file.Name, currentPath, basePath))
+		payload.SetComponentType("cmake")
+		payload.AddTech("cmake", "matched file: "+file.Name)
+		for _, language := range project.Languages {
+			if tech, ok := languageTechs[strings.ToUpper(language)]; ok {
+				payload.AddPrimaryTech(tech)
+			}
+		}
+		if len(payload.Tech) == 0 {
+			payload.AddPrimaryTech("cmake")
+		}
+
+		cmakeInfo := map[string]interface{}{"project": name}
+		if project.Version != "" {
+			cmakeInfo["version"] = project.Version
+		}
+		if project.MinimumVersion != "" {
+			cmakeInfo["minimum_version"] = project.MinimumVersion
+		}
+		if len(project.Languages) > 0 {
+			cmakeInfo["languages"] = project.Languages
+		}
+		payload.SetComponentProperties("cmake", cmakeInfo)
+
+		var names []string
+		for _, dep := range parser.Dependencies(project) {
+			payload.AddDependency(dep)
+			names = append(names, dep.Name)
+		}
+		if len(names) > 0 {
+			for tech, reasons := range depDetector.MatchDependencies(names, parsers.DependencyTypeCMake) {
+				for _, reason := range reasons {
+					payload.AddTech(tech, reason)
+				}
+				depDetector.AddPrimaryTechIfNeeded(payload, tech)
+			}
+		}
+		return []*types.Payload{payload}
+	}
+	return nil
+}
+
+func init() {
+	components.Register(&Detector{})
+}
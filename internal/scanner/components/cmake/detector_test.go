@@ -0,0 +1,133 @@
+package cmake
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+// ListDir derives the directory entries from the file paths
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	seen := make(map[string]bool)
+	var entries []types.File
+	for filePath := range m.files {
+		rel, err := filepath.Rel(path, filePath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		name, rest, isDir := strings.Cut(rel, string(filepath.Separator))
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		entry := types.File{Name: name, Path: filepath.Join(path, name), Type: "file"}
+		if isDir && rest != "" {
+			entry.Type = "dir"
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return nil, os.ErrNotExist
+	}
+	return entries, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "cmake", (&Detector{}).Name())
+}
+
+func TestDetect_CMakeProject(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/CMakeLists.txt": `cmake_minimum_required(VERSION 3.18)
+project(ocean VERSION 1.2.0 LANGUAGES Fortran CXX)
+find_package(MPI REQUIRED COMPONENTS Fortran)
+find_package(HDF5 1.12 COMPONENTS Fortran)
+add_subdirectory(src)
+`,
+	}}
+
+	payloads := (&Detector{}).Detect([]types.File{{Name: "CMakeLists.txt", Type: "file"}}, "/repo", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Equal(t, "ocean", payload.Name)
+	assert.Equal(t, "cmake", payload.ComponentType)
+	assert.Equal(t, []string{"fortran", "cplusplus"}, payload.Tech)
+	assert.Contains(t, payload.Techs, "cmake")
+	assert.Equal(t, map[string]interface{}{
+		"project": "ocean", "version": "1.2.0", "minimum_version": "3.18",
+		"languages": []string{"Fortran", "CXX"},
+	}, payload.Properties["cmake"])
+
+	require.Len(t, payload.Dependencies, 2)
+	assert.Equal(t, "MPI", payload.Dependencies[0].Name)
+	assert.Equal(t, ">=1.12", payload.Dependencies[1].Version)
+	assert.Equal(t, "cmake", payload.Dependencies[1].Type)
+}
+
+func TestDetect_CMakeProjectWithoutLanguages(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/tools/CMakeLists.txt": "project(${PROJECT_PREFIX}_tools NONE)\n",
+	}}
+
+	payloads := (&Detector{}).Detect([]types.File{{Name: "CMakeLists.txt", Type: "file"}}, "/repo/tools", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	assert.Equal(t, "tools", payloads[0].Name)
+	assert.Equal(t, []string{"cmake"}, payloads[0].Tech)
+	assert.Empty(t, payloads[0].Dependencies)
+}
+
+func TestDetect_CMakeSubdirectoryScript(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/src/CMakeLists.txt": "add_library(core core.f90)\nfind_package(LAPACK REQUIRED)\n",
+	}}
+
+	payloads := (&Detector{}).Detect([]types.File{{Name: "CMakeLists.txt", Type: "file"}}, "/repo/src", "/repo", provider, &MockDependencyDetector{})
+	assert.Nil(t, payloads)
+}
@@ -0,0 +1,182 @@
+// Package codequality implements an inventory of formatters and linters (Prettier, ESLint,
+// RuboCop, Black, Ruff, golangci-lint, ...) with their config files and pinned versions.
+package codequality
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// preCommitConfig is the pre-commit configuration file name
+const preCommitConfig = ".pre-commit-config.yaml"
+
+// Detector implements code quality tooling detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "codequality"
+}
+
+// Detect inventories the formatters and linters of a directory from their config files,
+// manifest dependencies (package.json, pyproject.toml, requirements*.txt, Gemfile,
+// composer.json, go.mod), pre-commit hooks, and GitHub Actions workflows (when the directory
+// has a .github folder). Versions declared by manifests take precedence over pre-commit and CI.
+// Results are stored in the "code_quality" property of a virtual component (merged into parent).
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	parser := parsers.NewCodeQualityParser()
+	inventory := parsers.NewCodeQualityInventory()
+	hasGitHubDir := false
+
+	for _, file := range files {
+		if file.Type == "dir" {
+			hasGitHubDir = hasGitHubDir || file.Name == ".github"
+			continue
+		}
+		if tool := parser.ToolForConfigFile(file.Name); tool != "" {
+			inventory.AddConfigFile(tool, relativePath(basePath, currentPath, file.Name))
+		}
+	}
+
+	for _, file := range files {
+		if file.Type == "dir" {
+			continue
+		}
+		deps, configured, versions := d.readManifest(parser, file.Name, currentPath, provider)
+		source := relativePath(basePath, currentPath, file.Name)
+		for _, tool := range configured {
+			inventory.AddConfigFile(tool, source)
+		}
+		for _, version := range versions {
+			inventory.AddVersion(version.Tool, version.Version, source)
+		}
+		for _, dep := range deps {
+			if tool := parser.ToolForDependency(dep); tool != "" {
+				inventory.AddVersion(tool, dep.Version, source)
+			}
+		}
+	}
+
+	for _, file := range files {
+		if file.Name != preCommitConfig {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			continue
+		}
+		versions, err := parser.ParsePreCommitConfig(string(content))
+		if err != nil {
+			continue
+		}
+		source := relativePath(basePath, currentPath, file.Name)
+		for _, version := range versions {
+			inventory.AddVersion(version.Tool, version.Version, source)
+		}
+	}
+
+	if hasGitHubDir {
+		addWorkflowVersions(inventory, parser, currentPath, basePath, provider)
+	}
+
+	tools := inventory.Tools()
+	if len(tools) == 0 {
+		return nil
+	}
+
+	payload := types.NewPayloadWithPath("virtual", relativePath(basePath, currentPath, ""))
+	properties := make([]interface{}, 0, len(tools))
+	for _, tool := range tools {
+		properties = append(properties, tool)
+		if len(tool.ConfigFiles) > 0 {
+			payload.AddTech(tool.Tech, "code quality config: "+strings.Join(tool.ConfigFiles, ", "))
+		} else {
+			payload.AddTech(tool.Tech, "code quality tool: "+tool.Name+" ("+tool.VersionSource+")")
+		}
+	}
+	payload.Properties["code_quality"] = properties
+	return []*types.Payload{payload}
+}
+
+// readManifest returns the dependencies of a manifest, the tools it configures inline, and
+// tool versions it declares outside the regular dependency lists
+func (d *Detector) readManifest(parser *parsers.CodeQualityParser, name, currentPath string, provider types.Provider) ([]types.Dependency, []string, []parsers.CodeQualityVersion) {
+	isRequirements := strings.HasPrefix(name, "requirements") && strings.HasSuffix(name, ".txt")
+	switch {
+	case name == "package.json", name == "pyproject.toml", name == "Gemfile", name == "composer.json", name == "go.mod", isRequirements:
+	default:
+		return nil, nil, nil
+	}
+	data, err := provider.ReadFile(filepath.Join(currentPath, name))
+	if err != nil {
+		return nil, nil, nil
+	}
+	content := string(data)
+
+	switch {
+	case name == "package.json":
+		nodejsParser := parsers.NewNodeJSParser()
+		pkg, err := nodejsParser.ParsePackageJSON(data)
+		if err != nil {
+			return nil, nil, nil
+		}
+		return nodejsParser.CreateDependencies(pkg, nodejsParser.ExtractDependencies(pkg)), parser.ParsePackageJSONConfig(content), nil
+	case name == "pyproject.toml":
+		configured, versions := parser.ParsePyproject(content)
+		return nil, configured, versions
+	case name == "Gemfile":
+		return parsers.NewRubyParser().ParseGemfile(content), nil, nil
+	case name == "composer.json":
+		_, _, deps := parsers.NewPHPParser().ParseComposerJSON(content)
+		return deps, nil, nil
+	case name == "go.mod":
+		deps, _ := parsers.NewGolangParser().ParseGoModWithInfo(content)
+		return deps, nil, nil
+	default:
+		return parsers.NewPythonParser().ParseRequirementsTxt(content), nil, nil
+	}
+}
+
+// addWorkflowVersions adds the tools run by the GitHub Actions workflows of the directory
+func addWorkflowVersions(inventory *parsers.CodeQualityInventory, parser *parsers.CodeQualityParser, currentPath, basePath string, provider types.Provider) {
+	workflowDir := filepath.Join(currentPath, ".github", "workflows")
+	entries, err := provider.ListDir(workflowDir)
+	if err != nil {
+		return
+	}
+	actionsParser := parsers.NewGitHubActionsParser()
+	for _, entry := range entries {
+		if entry.Type == "dir" || (!strings.HasSuffix(entry.Name, ".yml") && !strings.HasSuffix(entry.Name, ".yaml")) {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(workflowDir, entry.Name))
+		if err != nil {
+			continue
+		}
+		workflow, err := actionsParser.ParseWorkflow(string(content))
+		if err != nil {
+			continue
+		}
+		source := relativePath(basePath, workflowDir, entry.Name)
+		for _, version := range parser.WorkflowToolVersions(workflow) {
+			inventory.AddVersion(version.Tool, version.Version, source)
+		}
+	}
+}
+
+// relativePath computes the relative file path for payload display.
+func relativePath(basePath, currentPath, fileName string) string {
+	relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, fileName))
+	if relativeFilePath == "." {
+		return "/"
+	}
+	return "/" + filepath.ToSlash(relativeFilePath)
+}
+
+func init() {
+	components.Register(&Detector{})
+}
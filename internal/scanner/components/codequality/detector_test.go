@@ -0,0 +1,157 @@
+package codequality
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	// Derive directory entries from the mock file paths
+	seen := make(map[string]bool)
+	var entries []types.File
+	prefix := strings.TrimSuffix(path, "/") + "/"
+	for filePath := range m.files {
+		if !strings.HasPrefix(filePath, prefix) {
+			continue
+		}
+		name, rest, isDir := strings.Cut(strings.TrimPrefix(filePath, prefix), "/")
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		entryType := "file"
+		if isDir && rest != "" {
+			entryType = "dir"
+		}
+		entries = append(entries, types.File{Name: name, Path: prefix + name, Type: entryType})
+	}
+	return entries, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "codequality", (&Detector{}).Name())
+}
+
+func TestDetector_Detect(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/mock/.prettierrc":   "{}",
+		"/mock/package.json":  `{"devDependencies": {"prettier": "3.3.2", "eslint": "^9.0.0", "vite": "^5.0.0"}, "eslintConfig": {}}`,
+		"/mock/.golangci.yml": "linters: {}",
+		"/mock/.pre-commit-config.yaml": `repos:
+  - repo: https://github.com/pre-commit/mirrors-prettier
+    rev: v3.1.0
+    hooks:
+      - id: prettier
+`,
+		"/mock/.github/workflows/lint.yml": `jobs:
+  lint:
+    steps:
+      - uses: golangci/golangci-lint-action@v6
+        with:
+          version: v1.59
+`,
+	}}
+	files := []types.File{
+		{Name: ".prettierrc", Type: "file"},
+		{Name: "package.json", Type: "file"},
+		{Name: ".golangci.yml", Type: "file"},
+		{Name: ".pre-commit-config.yaml", Type: "file"},
+		{Name: ".github", Type: "dir"},
+	}
+
+	results := (&Detector{}).Detect(files, "/mock", "/mock", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Equal(t, []string{"/"}, payload.Path)
+	assert.Contains(t, payload.Techs, "prettier")
+	assert.Contains(t, payload.Techs, "eslint")
+	assert.Contains(t, payload.Techs, "golangcilint")
+	assert.Contains(t, payload.Reason["prettier"], "code quality config: /.prettierrc")
+
+	tools, ok := payload.Properties["code_quality"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, tools, 3)
+
+	eslint := tools[0].(*parsers.CodeQualityTool)
+	assert.Equal(t, "eslint", eslint.Name)
+	assert.Equal(t, []string{"/package.json"}, eslint.ConfigFiles)
+	assert.Equal(t, "^9.0.0", eslint.Version)
+
+	golangci := tools[1].(*parsers.CodeQualityTool)
+	assert.Equal(t, "v1.59", golangci.Version)
+	assert.Equal(t, "/.github/workflows/lint.yml", golangci.VersionSource)
+
+	// The package.json version takes precedence over the pre-commit revision
+	prettier := tools[2].(*parsers.CodeQualityTool)
+	assert.Equal(t, []string{"/.prettierrc"}, prettier.ConfigFiles)
+	assert.Equal(t, "3.3.2", prettier.Version)
+	assert.Equal(t, "/package.json", prettier.VersionSource)
+}
+
+func TestDetector_Detect_DependencyOnly(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/mock/api/requirements-dev.txt": "black==24.4.2\npytest\n",
+	}}
+	files := []types.File{{Name: "requirements-dev.txt", Type: "file"}}
+
+	results := (&Detector{}).Detect(files, "/mock/api", "/mock", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.Contains(t, results[0].Reason["black"], "code quality tool: black (/api/requirements-dev.txt)")
+}
+
+func TestDetector_Detect_NoTools(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/mock/package.json": `{"dependencies": {"react": "^18.0.0"}}`,
+	}}
+	files := []types.File{{Name: "package.json", Type: "file"}}
+
+	assert.Empty(t, (&Detector{}).Detect(files, "/mock", "/mock", provider, &MockDependencyDetector{}))
+}
@@ -0,0 +1,110 @@
+// Package crystal implements detection of Crystal shards (shard.yml, shard.lock) and their
+// dependencies.
+package crystal
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/providers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Detector implements Crystal shard detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "crystal"
+}
+
+// Detect scans for shard.yml. Installed versions (shard.lock) take precedence over the version
+// requirements of shard.yml, and include transitive dependencies. The shard name, version,
+// supported Crystal versions, and targets are stored in the "crystal" properties.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	var hasSpec, hasLock bool
+	for _, file := range files {
+		switch file.Name {
+		case "shard.yml":
+			hasSpec = true
+		case "shard.lock":
+			hasLock = true
+		}
+	}
+	if !hasSpec {
+		return nil
+	}
+	content, err := provider.ReadFile(filepath.Join(currentPath, "shard.yml"))
+	if err != nil {
+		return nil
+	}
+	parser := parsers.NewCrystalParser()
+	shard, err := parser.ParseShardYML(string(content))
+	if err != nil {
+		return nil
+	}
+
+	name := shard.Name
+	if name == "" {
+		name = filepath.Base(currentPath)
+	}
+	payload := types.NewPayloadWithPath(name, types.CalculateRelativePath("shard.yml", currentPath, basePath))
+	payload.SetComponentType("shards")
+	payload.AddPrimaryTech("crystal")
+	payload.AddTech("shards", "matched file: shard.yml")
+
+	crystalInfo := map[string]interface{}{"name": name}
+	if shard.Version != "" {
+		crystalInfo["version"] = shard.Version
+	}
+	if shard.Crystal != "" {
+		crystalInfo["crystal"] = shard.Crystal
+	}
+	if shard.License != "" {
+		crystalInfo["license"] = shard.License
+	}
+	if len(shard.Targets) > 0 {
+		crystalInfo["targets"] = shard.Targets
+	}
+	payload.SetComponentProperties("crystal", crystalInfo)
+
+	var dependencies []types.Dependency
+	if hasLock && components.UseLockFiles() {
+		if lockContent, err := provider.ReadFile(filepath.Join(currentPath, "shard.lock")); err == nil {
+			dependencies = parser.ParseShardLock(string(lockContent), shard)
+			if len(dependencies) > 0 {
+				payload.AddPath(types.CalculateRelativePath("shard.lock", currentPath, basePath))
+			}
+		}
+	}
+	if len(dependencies) == 0 {
+		dependencies = parser.ShardDependencies(shard)
+	}
+
+	var names []string
+	for _, dep := range dependencies {
+		payload.AddDependency(dep)
+		names = append(names, dep.Name)
+	}
+	if len(names) > 0 {
+		for tech, reasons := range depDetector.MatchDependencies(names, parsers.DependencyTypeShards) {
+			for _, reason := range reasons {
+				payload.AddTech(tech, reason)
+			}
+			depDetector.AddPrimaryTechIfNeeded(payload, tech)
+		}
+	}
+
+	return []*types.Payload{payload}
+}
+
+func init() {
+	components.Register(&Detector{})
+
+	// Register shards package provider (shards depend on other shards by name)
+	providers.Register(&providers.PackageProvider{
+		DependencyType:      "shards",
+		ExtractPackageNames: providers.SinglePropertyExtractor("crystal", "name"),
+	})
+}
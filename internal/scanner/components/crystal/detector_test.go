@@ -0,0 +1,126 @@
+package crystal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+// ListDir derives the directory entries from the file paths
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	seen := make(map[string]bool)
+	var entries []types.File
+	for filePath := range m.files {
+		rel, err := filepath.Rel(path, filePath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		name, rest, isDir := strings.Cut(rel, string(filepath.Separator))
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		entry := types.File{Name: name, Path: filepath.Join(path, name), Type: "file"}
+		if isDir && rest != "" {
+			entry.Type = "dir"
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return nil, os.ErrNotExist
+	}
+	return entries, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "crystal", (&Detector{}).Name())
+}
+
+func TestDetect_ShardWithLock(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/shard.yml":  "name: blog\nversion: 0.3.0\ncrystal: \">= 1.9.0\"\ndependencies:\n  kemal:\n    github: kemalcr/kemal\n    version: ~> 1.4\ndevelopment_dependencies:\n  ameba:\n    github: crystal-ameba/ameba\n",
+		"/repo/shard.lock": "version: 2.0\nshards:\n  ameba:\n    git: https://github.com/crystal-ameba/ameba.git\n    version: 1.6.1\n  kemal:\n    git: https://github.com/kemalcr/kemal.git\n    version: 1.4.0\n  radix:\n    git: https://github.com/luislavena/radix.git\n    version: 0.4.1\n",
+	}}
+	files := []types.File{{Name: "shard.yml", Type: "file"}, {Name: "shard.lock", Type: "file"}}
+
+	payloads := (&Detector{}).Detect(files, "/repo", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Equal(t, "blog", payload.Name)
+	assert.Equal(t, "shards", payload.ComponentType)
+	assert.Equal(t, []string{"crystal"}, payload.Tech)
+	assert.Equal(t, []string{"/shard.yml", "/shard.lock"}, payload.Path)
+	assert.Equal(t, map[string]interface{}{"name": "blog", "version": "0.3.0", "crystal": ">= 1.9.0"}, payload.Properties["crystal"])
+
+	require.Len(t, payload.Dependencies, 3)
+	assert.Equal(t, "ameba", payload.Dependencies[0].Name)
+	assert.Equal(t, types.ScopeDev, payload.Dependencies[0].Scope)
+	assert.Equal(t, "1.4.0", payload.Dependencies[1].Version)
+	assert.True(t, payload.Dependencies[1].Direct)
+	assert.False(t, payload.Dependencies[2].Direct)
+}
+
+func TestDetect_ShardWithoutLock(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/libs/util/shard.yml": "dependencies:\n  pg:\n    github: will/crystal-pg\n    version: 0.28.0\n",
+	}}
+
+	payloads := (&Detector{}).Detect([]types.File{{Name: "shard.yml", Type: "file"}}, "/repo/libs/util", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	assert.Equal(t, "util", payloads[0].Name)
+	require.Len(t, payloads[0].Dependencies, 1)
+	assert.Equal(t, "0.28.0", payloads[0].Dependencies[0].Version)
+	assert.Equal(t, "shard.yml", payloads[0].Dependencies[0].Metadata["source"])
+}
+
+func TestDetect_NoShard(t *testing.T) {
+	payloads := (&Detector{}).Detect([]types.File{{Name: "shard.lock", Type: "file"}}, "/repo", "/repo", &MockProvider{}, &MockDependencyDetector{})
+	assert.Empty(t, payloads)
+}
@@ -12,6 +12,17 @@ type Detector interface {
 	Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector DependencyDetector) []*types.Payload
 }
 
+// CacheableDetector is implemented by detectors whose results may be cached between scans. Their
+// results must depend only on the files passed to Detect, what they read through the provider,
+// the rules, and the detection settings. Increment Version whenever the results for the same
+// inputs change.
+type CacheableDetector interface {
+	Detector
+
+	// Version returns the version of the detector's results
+	Version() int
+}
+
 // DependencyDetector interface for matching dependencies
 type DependencyDetector interface {
 	MatchDependencies(dependencies []string, depType string) map[string][]string
@@ -0,0 +1,97 @@
+// Package devenv implements detection of declared development environments: dev containers
+// (devcontainer.json, also used by GitHub Codespaces) and Gitpod workspaces (.gitpod.yml).
+package devenv
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Detector implements dev container and Gitpod detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "devenv"
+}
+
+// Detect scans for .devcontainer/devcontainer.json (including .devcontainer/<name>/ configs),
+// .devcontainer.json, and .gitpod.yml. Configs are stored in the "devcontainer" and "gitpod"
+// properties of a virtual component (merged into parent); base images, features, and tools
+// invoked by Gitpod tasks contribute technologies.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	var results []*types.Payload
+	parser := parsers.NewDevEnvironmentParser()
+
+	for _, file := range files {
+		if file.Type == "dir" {
+			continue
+		}
+		isDevContainer := file.Name == ".devcontainer.json" || (file.Name == "devcontainer.json" && inDevContainerDir(currentPath))
+		isGitpod := file.Name == ".gitpod.yml" || file.Name == ".gitpod.yaml"
+		if !isDevContainer && !isGitpod {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			continue
+		}
+		relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, file.Name))
+		relativeFilePath = "/" + filepath.ToSlash(relativeFilePath)
+		payload := types.NewPayloadWithPath("virtual", relativeFilePath)
+
+		if isDevContainer {
+			container, err := parser.ParseDevContainerJSON(string(content))
+			if err != nil {
+				continue
+			}
+			container.File = relativeFilePath
+			payload.AddTech("devcontainer", "matched file: "+file.Name)
+			for _, feature := range container.Features {
+				if tech := feature.Tech(); tech != "" {
+					payload.AddTech(tech, "devcontainer feature: "+feature.ID)
+				}
+			}
+			addImage(payload, parser, container.Image, parsers.MetadataSourceDevcontainer, depDetector)
+			payload.Properties["devcontainer"] = []interface{}{container}
+		} else {
+			config, err := parser.ParseGitpodYAML(string(content))
+			if err != nil {
+				continue
+			}
+			config.File = relativeFilePath
+			payload.AddTech("gitpod", "matched file: "+file.Name)
+			addImage(payload, parser, config.Image, parsers.MetadataSourceGitpod, depDetector)
+			components.AddScriptToolTechs(payload, config.Usage, "Gitpod task", depDetector)
+			payload.Properties["gitpod"] = config
+		}
+		results = append(results, payload)
+	}
+	return results
+}
+
+// inDevContainerDir reports whether a directory is .devcontainer or one of its subdirectories
+func inDevContainerDir(currentPath string) bool {
+	return filepath.Base(currentPath) == ".devcontainer" || filepath.Base(filepath.Dir(currentPath)) == ".devcontainer"
+}
+
+// addImage adds the base image as a docker dependency and the technologies it matches
+func addImage(payload *types.Payload, parser *parsers.DevEnvironmentParser, image, source string, depDetector components.DependencyDetector) {
+	dependency, ok := parser.CreateImageDependency(image, source)
+	if !ok {
+		return
+	}
+	payload.AddDependency(dependency)
+	for tech, reasons := range depDetector.MatchDependencies([]string{dependency.Name}, parsers.DependencyTypeDocker) {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+	}
+}
+
+func init() {
+	components.Register(&Detector{})
+}
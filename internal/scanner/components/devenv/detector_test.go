@@ -0,0 +1,131 @@
+package devenv
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector matches dependency names against a fixed name -> tech map
+type MockDependencyDetector struct {
+	rules map[string]string
+}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	result := make(map[string][]string)
+	for _, dep := range dependencies {
+		if tech, ok := m.rules[dep]; ok {
+			result[tech] = append(result[tech], "matched dependency: "+dep)
+		}
+	}
+	return result
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func TestDetector_Name(t *testing.T) {
+	detector := &Detector{}
+	assert.Equal(t, "devenv", detector.Name())
+}
+
+func TestDetector_Detect_DevContainer(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{files: map[string]string{
+		"/mock/.devcontainer/devcontainer.json": `{
+  "image": "mcr.microsoft.com/devcontainers/python:3.12",
+  "features": { "ghcr.io/devcontainers/features/terraform:1": {} }
+}`,
+	}}
+	depDetector := &MockDependencyDetector{rules: map[string]string{"mcr.microsoft.com/devcontainers/python": "python"}}
+	files := []types.File{{Name: "devcontainer.json"}}
+
+	results := detector.Detect(files, "/mock/.devcontainer", "/mock", provider, depDetector)
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Equal(t, []string{"/.devcontainer/devcontainer.json"}, payload.Path)
+	assert.Contains(t, payload.Techs, "devcontainer")
+	assert.Contains(t, payload.Techs, "python")
+	assert.Contains(t, payload.Reason["terraform"], "devcontainer feature: ghcr.io/devcontainers/features/terraform")
+	require.Len(t, payload.Dependencies, 1)
+	assert.Equal(t, "mcr.microsoft.com/devcontainers/python", payload.Dependencies[0].Name)
+
+	containers, ok := payload.Properties["devcontainer"].([]interface{})
+	require.True(t, ok)
+	container := containers[0].(*parsers.DevContainer)
+	assert.Equal(t, "/.devcontainer/devcontainer.json", container.File)
+}
+
+func TestDetector_Detect_DevContainerOutsideConfigDir(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{files: map[string]string{
+		"/mock/docs/devcontainer.json": `{"image": "node:20"}`,
+	}}
+	files := []types.File{{Name: "devcontainer.json"}}
+
+	results := detector.Detect(files, "/mock/docs", "/mock", provider, &MockDependencyDetector{})
+	assert.Empty(t, results)
+}
+
+func TestDetector_Detect_Gitpod(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{files: map[string]string{
+		"/mock/.gitpod.yml": "tasks:\n  - name: dev\n    command: npx vite\n",
+	}}
+	depDetector := &MockDependencyDetector{rules: map[string]string{"vite": "vite"}}
+	files := []types.File{{Name: ".gitpod.yml"}}
+
+	results := detector.Detect(files, "/mock", "/mock", provider, depDetector)
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Contains(t, payload.Techs, "gitpod")
+	assert.Contains(t, payload.Techs, "vite")
+	assert.Empty(t, payload.Dependencies)
+
+	config, ok := payload.Properties["gitpod"].(*parsers.GitpodConfig)
+	require.True(t, ok)
+	assert.Equal(t, "/.gitpod.yml", config.File)
+	assert.Equal(t, []string{"dev"}, config.Tasks)
+}
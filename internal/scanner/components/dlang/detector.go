@@ -0,0 +1,118 @@
+// Package dlang implements detection of D packages built with dub (dub.json, dub.sdl,
+// dub.selections.json) and their dependencies.
+package dlang
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/providers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// recipeFiles are the dub package recipes in the order dub looks them up
+var recipeFiles = []string{parsers.MetadataSourceDubJSON, parsers.MetadataSourceDubSDL}
+
+// Detector implements dub package detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "dlang"
+}
+
+// Detect scans for dub package recipes. Selected versions (dub.selections.json) take precedence
+// over the version specifications of the recipe and add the transitive dependencies. The
+// package name, license, target type, and inline sub-packages are stored in the "dub"
+// properties.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	present := make(map[string]bool)
+	for _, file := range files {
+		present[file.Name] = true
+	}
+
+	recipe := ""
+	for _, name := range recipeFiles {
+		if present[name] {
+			recipe = name
+			break
+		}
+	}
+	if recipe == "" {
+		return nil
+	}
+	content, err := provider.ReadFile(filepath.Join(currentPath, recipe))
+	if err != nil {
+		return nil
+	}
+	parser := parsers.NewDubParser()
+	var pkg parsers.DubPackage
+	if recipe == parsers.MetadataSourceDubJSON {
+		pkg, err = parser.ParseDubJSON(string(content))
+	} else {
+		pkg, err = parser.ParseDubSDL(string(content))
+	}
+	if err != nil {
+		return nil
+	}
+
+	name := pkg.Name
+	if name == "" {
+		name = filepath.Base(currentPath)
+	}
+	payload := types.NewPayloadWithPath(name, types.CalculateRelativePath(recipe, currentPath, basePath))
+	payload.SetComponentType("dub")
+	payload.AddPrimaryTech("dlang")
+	payload.AddTech("dub", "matched file: "+recipe)
+
+	dubInfo := map[string]interface{}{"name": name}
+	for key, value := range map[string]string{
+		"version":     pkg.Version,
+		"license":     pkg.License,
+		"target_type": pkg.TargetType,
+	} {
+		if value != "" {
+			dubInfo[key] = value
+		}
+	}
+	if len(pkg.SubPackages) > 0 {
+		dubInfo["sub_packages"] = pkg.SubPackages
+	}
+	payload.SetComponentProperties("dub", dubInfo)
+
+	var selections map[string]parsers.DubSelection
+	if present[parsers.MetadataSourceDubSelections] && components.UseLockFiles() {
+		if selectionsContent, err := provider.ReadFile(filepath.Join(currentPath, parsers.MetadataSourceDubSelections)); err == nil {
+			if selections, err = parser.ParseSelections(string(selectionsContent)); err == nil && len(selections) > 0 {
+				payload.AddPath(types.CalculateRelativePath(parsers.MetadataSourceDubSelections, currentPath, basePath))
+			}
+		}
+	}
+
+	var names []string
+	for _, dep := range parser.Dependencies(pkg, recipe, selections) {
+		payload.AddDependency(dep)
+		names = append(names, dep.Name)
+	}
+	if len(names) > 0 {
+		for tech, reasons := range depDetector.MatchDependencies(names, parsers.DependencyTypeDub) {
+			for _, reason := range reasons {
+				payload.AddTech(tech, reason)
+			}
+			depDetector.AddPrimaryTechIfNeeded(payload, tech)
+		}
+	}
+
+	return []*types.Payload{payload}
+}
+
+func init() {
+	components.Register(&Detector{})
+
+	// Register dub package provider (packages depend on other packages by name)
+	providers.Register(&providers.PackageProvider{
+		DependencyType:      "dub",
+		ExtractPackageNames: providers.SinglePropertyExtractor("dub", "name"),
+	})
+}
@@ -0,0 +1,132 @@
+package dlang
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+// ListDir derives the directory entries from the file paths
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	seen := make(map[string]bool)
+	var entries []types.File
+	for filePath := range m.files {
+		rel, err := filepath.Rel(path, filePath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		name, rest, isDir := strings.Cut(rel, string(filepath.Separator))
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		entry := types.File{Name: name, Path: filepath.Join(path, name), Type: "file"}
+		if isDir && rest != "" {
+			entry.Type = "dir"
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return nil, os.ErrNotExist
+	}
+	return entries, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "dlang", (&Detector{}).Name())
+}
+
+func TestDetect_DubJSONWithSelections(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/dub.json":            `{"name": "webapp", "license": "MIT", "targetType": "executable", "dependencies": {"vibe-d:http": "~>0.9.5"}}`,
+		"/repo/dub.sdl":             `name "ignored"`,
+		"/repo/dub.selections.json": `{"fileVersion": 1, "versions": {"vibe-d": "0.9.7", "vibe-core": "2.8.4"}}`,
+	}}
+	files := []types.File{
+		{Name: "dub.sdl", Type: "file"},
+		{Name: "dub.json", Type: "file"},
+		{Name: "dub.selections.json", Type: "file"},
+	}
+
+	payloads := (&Detector{}).Detect(files, "/repo", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Equal(t, "webapp", payload.Name)
+	assert.Equal(t, "dub", payload.ComponentType)
+	assert.Equal(t, []string{"dlang"}, payload.Tech)
+	assert.Equal(t, []string{"/dub.json", "/dub.selections.json"}, payload.Path)
+	assert.Equal(t, map[string]interface{}{"name": "webapp", "license": "MIT", "target_type": "executable"}, payload.Properties["dub"])
+
+	require.Len(t, payload.Dependencies, 2)
+	assert.Equal(t, "vibe-d:http", payload.Dependencies[0].Name)
+	assert.Equal(t, "0.9.7", payload.Dependencies[0].Version)
+	assert.True(t, payload.Dependencies[0].Direct)
+	assert.Equal(t, "vibe-core", payload.Dependencies[1].Name)
+	assert.False(t, payload.Dependencies[1].Direct)
+}
+
+func TestDetect_DubSDL(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/libs/core/dub.sdl": "dependency \"mir-algorithm\" version=\"~>3.20\"\nsubPackage {\n\tname \"extra\"\n}\n",
+	}}
+
+	payloads := (&Detector{}).Detect([]types.File{{Name: "dub.sdl", Type: "file"}}, "/repo/libs/core", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	assert.Equal(t, "core", payloads[0].Name)
+	assert.Equal(t, []string{"extra"}, payloads[0].Properties["dub"].(map[string]interface{})["sub_packages"])
+	require.Len(t, payloads[0].Dependencies, 1)
+	assert.Equal(t, "~>3.20", payloads[0].Dependencies[0].Version)
+	assert.Equal(t, "dub.sdl", payloads[0].Dependencies[0].Metadata["source"])
+}
+
+func TestDetect_NoRecipe(t *testing.T) {
+	payloads := (&Detector{}).Detect([]types.File{{Name: "dub.selections.json", Type: "file"}}, "/repo", "/repo", &MockProvider{}, &MockDependencyDetector{})
+	assert.Empty(t, payloads)
+}
@@ -1,9 +1,12 @@
 package dotnet
 
 import (
+	"fmt"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
 	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
@@ -13,6 +16,9 @@ import (
 
 type Detector struct{}
 
+// now returns the reference date for support lifecycle checks (overridable in tests)
+var now = time.Now
+
 func (d *Detector) Name() string {
 	return "dotnet"
 }
@@ -24,7 +30,7 @@ func (d *Detector) Detect(files []types.File, currentPath, basePath string, prov
 	centralVersions := d.detectCentralPackageVersions(files, currentPath, provider)
 
 	// Check if there are any .csproj/.vbproj/.fsproj files in this directory
-	dotnetRegex := regexp.MustCompile(`\.(csproj|vbproj|fsproj)$`)
+	dotnetRegex := regexp.MustCompile(`(?i)\.(csproj|vbproj|fsproj)$`)
 	hasDotNetProject := false
 	for _, file := range files {
 		if dotnetRegex.MatchString(file.Name) {
@@ -44,13 +50,140 @@ func (d *Detector) Detect(files []types.File, currentPath, basePath string, prov
 		results = append(results, legacyPayloads...)
 	}
 
+	// Detect solution project graphs (.sln, .slnx)
+	if payload := d.detectSolutions(files, currentPath, basePath, provider); payload != nil {
+		results = append(results, payload)
+	}
+
+	// Detect SDK pins (global.json)
+	if payload := d.detectGlobalJSON(files, currentPath, basePath, provider); payload != nil {
+		results = append(results, payload)
+	}
+
 	return results
 }
 
+// detectGlobalJSON checks for a global.json SDK pin and returns a virtual payload
+// (merged into parent) with the "dotnet_sdk" property
+func (d *Detector) detectGlobalJSON(files []types.File, currentPath, basePath string, provider types.Provider) *types.Payload {
+	for _, file := range files {
+		if !strings.EqualFold(file.Name, "global.json") {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			return nil
+		}
+		sdk := parsers.NewDotNetParser().ParseGlobalJSON(string(content), now())
+		if sdk == nil {
+			return nil
+		}
+
+		relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, file.Name))
+		sdk.File = "/" + relativeFilePath
+
+		payload := types.NewPayloadWithPath("virtual", sdk.File)
+		payload.Properties["dotnet_sdk"] = sdk
+		payload.AddTech("dotnet", "sdk pinned: "+sdk.Version+" (global.json)")
+		if sdk.Support != nil && !sdk.Support.Supported {
+			payload.AddReason(fmt.Sprintf("out of support: %s (SDK %s, end of support %s)", sdk.Support.Product, sdk.Version, sdk.Support.EndOfSupport))
+		}
+		return payload
+	}
+	return nil
+}
+
+// detectSolutions parses .sln/.slnx files and returns a virtual payload (merged into parent)
+// with the "dotnet_solution" property listing the projects and their project references
+func (d *Detector) detectSolutions(files []types.File, currentPath, basePath string, provider types.Provider) *types.Payload {
+	var solutions []interface{}
+	var payload *types.Payload
+	parser := parsers.NewDotNetParser()
+
+	for _, file := range files {
+		ext := strings.ToLower(filepath.Ext(file.Name))
+		if ext != ".sln" && ext != ".slnx" {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			continue
+		}
+
+		var solution *parsers.DotNetSolution
+		if ext == ".slnx" {
+			if solution, err = parser.ParseSolutionX(string(content)); err != nil {
+				continue
+			}
+		} else {
+			solution = parser.ParseSolution(string(content))
+		}
+		relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, file.Name))
+		solution.File = "/" + filepath.ToSlash(relativeFilePath)
+		d.resolveSolutionGraph(solution, currentPath, basePath, provider, parser)
+
+		if payload == nil {
+			payload = types.NewPayloadWithPath("virtual", solution.File)
+		}
+		payload.AddTech("dotnet", fmt.Sprintf("solution: %s (%d projects)", file.Name, len(solution.Projects)))
+		solutions = append(solutions, solution)
+	}
+
+	if payload == nil {
+		return nil
+	}
+	payload.Properties["dotnet_solution"] = solutions
+	return payload
+}
+
+// resolveSolutionGraph reads each project of a solution and records its project references
+// as names of other solution projects. Project files outside the scan root are not read.
+func (d *Detector) resolveSolutionGraph(solution *parsers.DotNetSolution, currentPath, basePath string, provider types.Provider, parser *parsers.DotNetParser) {
+	byPath := make(map[string]string, len(solution.Projects))
+	for _, project := range solution.Projects {
+		byPath[strings.ToLower(project.Path)] = project.Name
+	}
+
+	for i := range solution.Projects {
+		project := &solution.Projects[i]
+		projectFile := filepath.Join(currentPath, filepath.FromSlash(project.Path))
+		if !isWithin(basePath, projectFile) {
+			project.Missing = true
+			continue
+		}
+		content, err := provider.ReadFile(projectFile)
+		if err != nil {
+			project.Missing = true
+			continue
+		}
+
+		parsed := parser.ParseCsproj(string(content), projectFile)
+		project.Framework = parsed.Framework
+		project.PackageCount = len(parsed.Packages)
+
+		projectDir := path.Dir(project.Path)
+		for _, ref := range parsed.ProjectReferences {
+			refPath := path.Join(projectDir, parsers.NormalizeProjectPath(ref))
+			name, ok := byPath[strings.ToLower(refPath)]
+			if !ok {
+				// Referenced project not listed in the solution
+				name = strings.TrimSuffix(path.Base(refPath), path.Ext(refPath))
+			}
+			project.References = append(project.References, name)
+		}
+	}
+}
+
+// isWithin reports whether target is inside base
+func isWithin(base, target string) bool {
+	rel, err := filepath.Rel(base, target)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
 // detectCentralPackageVersions checks for Directory.Packages.props and returns central package versions
 func (d *Detector) detectCentralPackageVersions(files []types.File, currentPath string, provider types.Provider) map[string]string {
 	for _, file := range files {
-		if file.Name == "Directory.Packages.props" {
+		if strings.EqualFold(file.Name, "Directory.Packages.props") {
 			content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
 			if err == nil {
 				dotnetParser := parsers.NewDotNetParser()
@@ -65,7 +198,7 @@ func (d *Detector) detectCentralPackageVersions(files []types.File, currentPath
 // detectProjectFiles handles .csproj, .vbproj, .fsproj files
 func (d *Detector) detectProjectFiles(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector, centralVersions map[string]string) []*types.Payload {
 	var results []*types.Payload
-	dotnetRegex := regexp.MustCompile(`\.(csproj|vbproj|fsproj)$`)
+	dotnetRegex := regexp.MustCompile(`(?i)\.(csproj|vbproj|fsproj)$`)
 
 	for _, file := range files {
 		if dotnetRegex.MatchString(file.Name) {
@@ -87,7 +220,7 @@ func (d *Detector) detectPackagesConfigFiles(files []types.File, currentPath, ba
 	var results []*types.Payload
 
 	for _, file := range files {
-		if file.Name == "packages.config" {
+		if strings.EqualFold(file.Name, "packages.config") {
 			payload := d.detectPackagesConfig(file, currentPath, basePath, provider, depDetector)
 			if payload != nil {
 				results = append(results, payload)
@@ -132,7 +265,7 @@ func (d *Detector) parseDotNetProject(file types.File, files []types.File, curre
 
 func (d *Detector) mergeLegacyPackages(project *parsers.DotNetProject, files []types.File, currentPath string, provider types.Provider, parser *parsers.DotNetParser) {
 	for _, f := range files {
-		if f.Name == "packages.config" {
+		if strings.EqualFold(f.Name, "packages.config") {
 			if pkgContent, err := provider.ReadFile(filepath.Join(currentPath, f.Name)); err == nil {
 				legacyDeps := parser.ParsePackagesConfig(string(pkgContent))
 				for _, dep := range legacyDeps {
@@ -172,6 +305,7 @@ func (d *Detector) createDotNetPayload(project *parsers.DotNetProject, file type
 }
 
 func (d *Detector) getLanguageTech(fileName string) string {
+	fileName = strings.ToLower(fileName)
 	switch {
 	case strings.HasSuffix(fileName, ".csproj"):
 		return "dotnet"
@@ -195,9 +329,33 @@ func (d *Detector) setDotNetProperties(payload *types.Payload, project *parsers.
 	if project.Framework != "" {
 		dotnetInfo["framework"] = project.Framework
 	}
+	if len(project.Frameworks) > 0 {
+		dotnetInfo["frameworks"] = project.Frameworks
+		if support := d.frameworkSupport(payload, project.Frameworks); len(support) > 0 {
+			dotnetInfo["framework_support"] = support
+		}
+	}
 	payload.Properties["dotnet"] = dotnetInfo
 }
 
+// frameworkSupport looks up the support lifecycle of each target framework and
+// records a reason for frameworks that are out of support
+func (d *Detector) frameworkSupport(payload *types.Payload, frameworks []string) []*parsers.DotNetFrameworkSupport {
+	var support []*parsers.DotNetFrameworkSupport
+	asOf := now()
+	for _, framework := range frameworks {
+		status := parsers.LookupDotNetSupport(framework, asOf)
+		if status == nil {
+			continue
+		}
+		support = append(support, status)
+		if !status.Supported {
+			payload.AddReason(fmt.Sprintf("out of support: %s (%s, end of support %s)", framework, status.Product, status.EndOfSupport))
+		}
+	}
+	return support
+}
+
 func (d *Detector) addProjectReferences(payload *types.Payload, projectReferences []string) {
 	for _, projRef := range projectReferences {
 		normalizedPath := strings.ReplaceAll(projRef, "\\", "/")
@@ -208,7 +366,7 @@ func (d *Detector) addProjectReferences(payload *types.Payload, projectReference
 			Type:     "dotnet-ref",
 			Name:     projName,
 			Version:  "",
-			Scope:    "prod",
+			Scope:    types.ScopeProd,
 			Direct:   true,
 			Metadata: map[string]interface{}{"path": normalizedPath},
 		})
@@ -3,7 +3,9 @@ package dotnet
 import (
 	"os"
 	"testing"
+	"time"
 
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -472,3 +474,158 @@ func TestDetector_Detect_NoMatchingDependencies(t *testing.T) {
 	assert.Len(t, payload.Dependencies, 2, "Should have 2 dependencies")
 	assert.Empty(t, payload.Children, "Should have no child components when no matches")
 }
+
+func TestDetector_Detect_FrameworkSupport(t *testing.T) {
+	detector := &Detector{}
+	now = func() time.Time { return time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/Lib.csproj": `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFrameworks>netstandard2.0;net6.0;net8.0</TargetFrameworks>
+  </PropertyGroup>
+</Project>`,
+		},
+	}
+	files := []types.File{{Name: "Lib.csproj", Path: "/project/Lib.csproj"}}
+
+	results := detector.Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+
+	dotnetInfo := results[0].Properties["dotnet"].(map[string]interface{})
+	assert.Equal(t, "netstandard2.0", dotnetInfo["framework"])
+	assert.Equal(t, []string{"netstandard2.0", "net6.0", "net8.0"}, dotnetInfo["frameworks"])
+
+	support := dotnetInfo["framework_support"].([]*parsers.DotNetFrameworkSupport)
+	require.Len(t, support, 2, "netstandard has no support lifecycle")
+	assert.Equal(t, "net6.0", support[0].Framework)
+	assert.False(t, support[0].Supported)
+	assert.Equal(t, "net8.0", support[1].Framework)
+	assert.True(t, support[1].Supported)
+
+	assert.Contains(t, results[0].Reason["_"], "out of support: net6.0 (.NET 6, end of support 2024-11-12)")
+}
+
+func TestDetector_Detect_GlobalJSON(t *testing.T) {
+	detector := &Detector{}
+	now = func() time.Time { return time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/global.json": `{"sdk": {"version": "7.0.410", "rollForward": "latestMinor"}}`,
+		},
+	}
+	files := []types.File{{Name: "global.json", Path: "/project/global.json"}}
+
+	results := detector.Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Equal(t, "virtual", payload.Name)
+	assert.Contains(t, payload.Techs, "dotnet")
+
+	sdk := payload.Properties["dotnet_sdk"].(*parsers.DotNetSDKInfo)
+	assert.Equal(t, "/global.json", sdk.File)
+	assert.Equal(t, "7.0.410", sdk.Version)
+	assert.Equal(t, "latestMinor", sdk.RollForward)
+	require.NotNil(t, sdk.Support)
+	assert.False(t, sdk.Support.Supported)
+	assert.Contains(t, payload.Reason["_"], "out of support: .NET 7 (SDK 7.0.410, end of support 2024-05-14)")
+}
+
+func TestDetector_Detect_GlobalJSONWithoutSDK(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/global.json": `{"msbuild-sdks": {"Microsoft.Build.Traversal": "3.0.3"}}`,
+		},
+	}
+	files := []types.File{{Name: "global.json", Path: "/project/global.json"}}
+
+	results := detector.Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	assert.Empty(t, results)
+}
+
+func TestDetector_Detect_Solution(t *testing.T) {
+	detector := &Detector{}
+
+	provider := &MockProvider{
+		files: map[string]string{
+			"/repo/All.sln": `Project("{9A19103F-16F7-4668-BE54-9A1E7A4F7556}") = "App", "src\App\App.csproj", "{22222222-2222-2222-2222-222222222222}"
+EndProject
+Project("{9A19103F-16F7-4668-BE54-9A1E7A4F7556}") = "Core", "src\Core\Core.csproj", "{33333333-3333-3333-3333-333333333333}"
+EndProject
+Project("{9A19103F-16F7-4668-BE54-9A1E7A4F7556}") = "Gone", "src\Gone\Gone.csproj", "{44444444-4444-4444-4444-444444444444}"
+EndProject
+Project("{9A19103F-16F7-4668-BE54-9A1E7A4F7556}") = "Outside", "..\other\Outside.csproj", "{55555555-5555-5555-5555-555555555555}"
+EndProject`,
+			"/repo/src/App/App.csproj": `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+  </PropertyGroup>
+  <ItemGroup>
+    <ProjectReference Include="..\Core\Core.csproj" />
+    <ProjectReference Include="..\..\lib\Shared\Shared.csproj" />
+    <PackageReference Include="Serilog" Version="3.1.1" />
+  </ItemGroup>
+</Project>`,
+			"/repo/src/Core/Core.csproj": `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+  </PropertyGroup>
+</Project>`,
+			"/other/Outside.csproj": `<Project Sdk="Microsoft.NET.Sdk"></Project>`,
+		},
+	}
+	files := []types.File{{Name: "All.sln", Path: "/repo/All.sln"}}
+
+	results := detector.Detect(files, "/repo", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Equal(t, "virtual", payload.Name)
+	assert.Contains(t, payload.Techs, "dotnet")
+
+	solutions := payload.Properties["dotnet_solution"].([]interface{})
+	require.Len(t, solutions, 1)
+	solution := solutions[0].(*parsers.DotNetSolution)
+	assert.Equal(t, "/All.sln", solution.File)
+	require.Len(t, solution.Projects, 4)
+
+	app := solution.Projects[0]
+	assert.Equal(t, "App", app.Name)
+	assert.Equal(t, "net8.0", app.Framework)
+	assert.Equal(t, 1, app.PackageCount)
+	assert.Equal(t, []string{"Core", "Shared"}, app.References, "references outside the solution are named after the project file")
+
+	assert.Empty(t, solution.Projects[1].References)
+	assert.True(t, solution.Projects[2].Missing, "project file not found")
+	assert.True(t, solution.Projects[3].Missing, "project outside the scan root is not read")
+}
+
+func TestDetector_Detect_FileNameCase(t *testing.T) {
+	// Case-insensitive file systems keep the case tools wrote, e.g. upper-case extensions
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/LEGACY.VBPROJ": `<Project><PropertyGroup><AssemblyName>Legacy</AssemblyName></PropertyGroup></Project>`,
+			"/project/Packages.config": `<packages>
+  <package id="Serilog" version="3.1.1" targetFramework="net48" />
+</packages>`,
+		},
+	}
+	files := []types.File{
+		{Name: "LEGACY.VBPROJ", Path: "/project/LEGACY.VBPROJ"},
+		{Name: "Packages.config", Path: "/project/Packages.config"},
+	}
+
+	results := (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+
+	require.Len(t, results, 1)
+	assert.Equal(t, "Legacy", results[0].Name)
+	assert.Contains(t, results[0].Techs, "vbnet")
+	require.Len(t, results[0].Dependencies, 1)
+	assert.Equal(t, "Serilog", results[0].Dependencies[0].Name)
+}
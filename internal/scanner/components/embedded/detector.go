@@ -0,0 +1,167 @@
+// Package embedded implements detection of embedded firmware projects: PlatformIO projects
+// (platformio.ini) and Arduino libraries (library.properties).
+package embedded
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/providers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// frameworkTechs maps PlatformIO framework names to tech keys
+var frameworkTechs = map[string]string{
+	"arduino":   "arduino",
+	"espidf":    "esp-idf",
+	"zephyr":    "zephyr",
+	"mbed":      "mbed",
+	"stm32cube": "stm32cube",
+}
+
+// Detector implements PlatformIO and Arduino detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "embedded"
+}
+
+// Detect scans for platformio.ini and library.properties files. Target platforms (platform,
+// board, frameworks) are stored per environment in the "platformio" properties.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	var results []*types.Payload
+	parser := parsers.NewEmbeddedParser()
+
+	for _, file := range files {
+		var payload *types.Payload
+		switch file.Name {
+		case "platformio.ini":
+			payload = d.detectPlatformIO(parser, file, currentPath, basePath, provider, depDetector)
+		case "library.properties":
+			payload = d.detectArduinoLibrary(parser, file, currentPath, basePath, provider, depDetector)
+		}
+		if payload != nil {
+			results = append(results, payload)
+		}
+	}
+	return results
+}
+
+// detectPlatformIO creates a PlatformIO component with its environments and dependencies
+func (d *Detector) detectPlatformIO(parser *parsers.EmbeddedParser, file types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
+	content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+	if err != nil {
+		return nil
+	}
+	project := parser.ParsePlatformIO(string(content))
+
+	name := project.Name
+	if name == "" {
+		name = filepath.Base(currentPath)
+	}
+	payload := types.NewComponentPayload(name, file.Name, currentPath, basePath, "platformio")
+	payload.AddTech("platformio", "matched file: "+file.Name)
+
+	var environments []map[string]interface{}
+	for _, env := range project.Environments {
+		envInfo := map[string]interface{}{"name": env.Name}
+		for key, value := range map[string]string{
+			"platform":         env.Platform,
+			"platform_version": env.PlatformVersion,
+			"board":            env.Board,
+		} {
+			if value != "" {
+				envInfo[key] = value
+			}
+		}
+		if len(env.Frameworks) > 0 {
+			envInfo["frameworks"] = env.Frameworks
+		}
+		environments = append(environments, envInfo)
+
+		for _, framework := range env.Frameworks {
+			if tech, ok := frameworkTechs[framework]; ok {
+				payload.AddTech(tech, "framework: "+framework+" (env:"+env.Name+")")
+			}
+		}
+	}
+	platformIOInfo := map[string]interface{}{}
+	if len(environments) > 0 {
+		platformIOInfo["environments"] = environments
+	}
+	if len(project.DefaultEnvs) > 0 {
+		platformIOInfo["default_envs"] = project.DefaultEnvs
+	}
+	if len(platformIOInfo) > 0 {
+		payload.SetComponentProperties("platformio", platformIOInfo)
+	}
+
+	addDependencies(payload, parser.PlatformIODependencies(project), parsers.DependencyTypePlatformIO, depDetector)
+	return payload
+}
+
+// detectArduinoLibrary creates an Arduino library component with the libraries it depends on
+func (d *Detector) detectArduinoLibrary(parser *parsers.EmbeddedParser, file types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
+	content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+	if err != nil {
+		return nil
+	}
+	library, ok := parser.ParseLibraryProperties(string(content))
+	if !ok {
+		return nil
+	}
+
+	payload := types.NewPayloadWithPath(library.Name, types.CalculateRelativePath(file.Name, currentPath, basePath))
+	payload.SetComponentType("arduino-library")
+	payload.AddPrimaryTech("arduino")
+	payload.AddTech("arduino", "matched file: "+file.Name)
+
+	libraryInfo := map[string]interface{}{"name": library.Name}
+	for key, value := range map[string]string{
+		"version":  library.Version,
+		"author":   library.Author,
+		"category": library.Category,
+		"url":      library.URL,
+	} {
+		if value != "" {
+			libraryInfo[key] = value
+		}
+	}
+	if len(library.Architectures) > 0 {
+		libraryInfo["architectures"] = library.Architectures
+	}
+	payload.SetComponentProperties("arduino_library", libraryInfo)
+
+	addDependencies(payload, parser.LibraryDependencies(library), parsers.DependencyTypeArduino, depDetector)
+	return payload
+}
+
+// addDependencies adds dependencies and the technologies they match
+func addDependencies(payload *types.Payload, dependencies []types.Dependency, depType string, depDetector components.DependencyDetector) {
+	var names []string
+	for _, dep := range dependencies {
+		payload.AddDependency(dep)
+		names = append(names, dep.Name)
+	}
+	if len(names) == 0 {
+		return
+	}
+	for tech, reasons := range depDetector.MatchDependencies(names, depType) {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+		depDetector.AddPrimaryTechIfNeeded(payload, tech)
+	}
+}
+
+func init() {
+	components.Register(&Detector{})
+
+	// Register arduino package provider (libraries depend on other libraries by name)
+	providers.Register(&providers.PackageProvider{
+		DependencyType:      "arduino",
+		ExtractPackageNames: providers.SinglePropertyExtractor("arduino_library", "name"),
+	})
+}
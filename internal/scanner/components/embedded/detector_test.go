@@ -0,0 +1,122 @@
+package embedded
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "embedded", (&Detector{}).Name())
+}
+
+func TestDetect_PlatformIOProject(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/firmware/platformio.ini": `[platformio]
+default_envs = esp32dev
+
+[env:esp32dev]
+platform = espressif32@6.4.0
+board = esp32dev
+framework = arduino, espidf
+lib_deps = bblanchon/ArduinoJson@^6.21.3
+
+[env:native]
+platform = native
+`,
+	}}
+
+	payloads := (&Detector{}).Detect([]types.File{{Name: "platformio.ini", Type: "file"}}, "/repo/firmware", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Equal(t, "firmware", payload.Name)
+	assert.Equal(t, "platformio", payload.ComponentType)
+	assert.Equal(t, []string{"/firmware/platformio.ini"}, payload.Path)
+	for _, tech := range []string{"platformio", "arduino", "esp-idf"} {
+		assert.Contains(t, payload.Techs, tech)
+	}
+	assert.Equal(t, map[string]interface{}{
+		"default_envs": []string{"esp32dev"},
+		"environments": []map[string]interface{}{
+			{"name": "esp32dev", "platform": "espressif32", "platform_version": "6.4.0", "board": "esp32dev", "frameworks": []string{"arduino", "espidf"}},
+			{"name": "native", "platform": "native"},
+		},
+	}, payload.Properties["platformio"])
+
+	require.Len(t, payload.Dependencies, 3)
+	assert.Equal(t, "espressif32", payload.Dependencies[0].Name)
+	assert.Equal(t, types.ScopeBuild, payload.Dependencies[0].Scope)
+	assert.Equal(t, "bblanchon/ArduinoJson", payload.Dependencies[1].Name)
+	assert.Equal(t, "platformio", payload.Dependencies[1].Type)
+}
+
+func TestDetect_ArduinoLibrary(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/libraries/WeatherSensors/library.properties": "name=Weather Sensors\nversion=1.3.0\narchitectures=*\ndepends=DHT sensor library (>=1.4.0)\n",
+		"/repo/libraries/Broken/library.properties":         "version=1.0.0\n",
+	}}
+	files := []types.File{{Name: "library.properties", Type: "file"}}
+
+	payloads := (&Detector{}).Detect(files, "/repo/libraries/WeatherSensors", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Equal(t, "Weather Sensors", payload.Name)
+	assert.Equal(t, "arduino-library", payload.ComponentType)
+	assert.Equal(t, []string{"arduino"}, payload.Tech)
+	assert.Equal(t, map[string]interface{}{"name": "Weather Sensors", "version": "1.3.0", "architectures": []string{"*"}}, payload.Properties["arduino_library"])
+	require.Len(t, payload.Dependencies, 1)
+	assert.Equal(t, "DHT sensor library", payload.Dependencies[0].Name)
+	assert.Equal(t, ">=1.4.0", payload.Dependencies[0].Version)
+
+	assert.Empty(t, (&Detector{}).Detect(files, "/repo/libraries/Broken", "/repo", provider, &MockDependencyDetector{}), "Should skip descriptors without a name")
+}
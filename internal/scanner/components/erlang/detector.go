@@ -0,0 +1,123 @@
+// Package erlang implements detection of Erlang/OTP projects built with rebar3 (rebar.config,
+// rebar.lock) and their Hex and git dependencies.
+package erlang
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Detector implements rebar3 project detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "erlang"
+}
+
+// Detect scans for rebar.config. Locked dependencies (rebar.lock) take precedence over the
+// declared deps, and include transitive dependencies; profile deps and plugins always come
+// from rebar.config. The application name and version come from src/<app>.app.src.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	var hasConfig, hasLock bool
+	for _, file := range files {
+		switch file.Name {
+		case "rebar.config":
+			hasConfig = true
+		case "rebar.lock":
+			hasLock = true
+		}
+	}
+	if !hasConfig {
+		return nil
+	}
+	content, err := provider.ReadFile(filepath.Join(currentPath, "rebar.config"))
+	if err != nil {
+		return nil
+	}
+	parser := parsers.NewErlangParser()
+	config := parser.ParseRebarConfig(string(content))
+
+	app, version := d.readAppSrc(parser, currentPath, provider)
+	name := app
+	if name == "" {
+		name = filepath.Base(currentPath)
+	}
+	payload := types.NewPayloadWithPath(name, types.CalculateRelativePath("rebar.config", currentPath, basePath))
+	payload.SetComponentType("rebar")
+	payload.AddPrimaryTech("erlang")
+	payload.AddTech("rebar", "matched file: rebar.config")
+
+	erlangInfo := map[string]interface{}{}
+	if app != "" {
+		erlangInfo["app"] = app
+	}
+	if version != "" {
+		erlangInfo["version"] = version
+	}
+	if config.MinimumOTPVersion != "" {
+		erlangInfo["minimum_otp_version"] = config.MinimumOTPVersion
+	}
+	if len(erlangInfo) > 0 {
+		payload.SetComponentProperties("erlang", erlangInfo)
+	}
+
+	var dependencies []types.Dependency
+	locked := false
+	if hasLock && components.UseLockFiles() {
+		if lockContent, err := provider.ReadFile(filepath.Join(currentPath, "rebar.lock")); err == nil {
+			if lockDeps := parser.ParseRebarLock(string(lockContent)); len(lockDeps) > 0 {
+				dependencies = lockDeps
+				locked = true
+				payload.AddPath(types.CalculateRelativePath("rebar.lock", currentPath, basePath))
+			}
+		}
+	}
+	dependencies = append(dependencies, parser.RebarConfigDependencies(config, locked)...)
+
+	var names []string
+	for _, dep := range dependencies {
+		payload.AddDependency(dep)
+		names = append(names, dep.Name)
+	}
+	if len(names) > 0 {
+		for tech, reasons := range depDetector.MatchDependencies(names, parsers.DependencyTypeHex) {
+			for _, reason := range reasons {
+				payload.AddTech(tech, reason)
+			}
+			depDetector.AddPrimaryTechIfNeeded(payload, tech)
+		}
+	}
+
+	return []*types.Payload{payload}
+}
+
+// readAppSrc returns the application name and version of the first src/*.app.src file
+func (d *Detector) readAppSrc(parser *parsers.ErlangParser, currentPath string, provider types.Provider) (string, string) {
+	srcDir := filepath.Join(currentPath, "src")
+	entries, err := provider.ListDir(srcDir)
+	if err != nil {
+		return "", ""
+	}
+	for _, entry := range entries {
+		if entry.Type == "dir" || !strings.HasSuffix(entry.Name, ".app.src") {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(srcDir, entry.Name))
+		if err != nil {
+			continue
+		}
+		if app, version := parser.ParseAppSrc(string(content)); app != "" {
+			return app, version
+		}
+	}
+	return "", ""
+}
+
+func init() {
+	components.Register(&Detector{})
+}
@@ -0,0 +1,127 @@
+package erlang
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+// ListDir derives the directory entries from the file paths
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	seen := make(map[string]bool)
+	var entries []types.File
+	for filePath := range m.files {
+		rel, err := filepath.Rel(path, filePath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		name, rest, isDir := strings.Cut(rel, string(filepath.Separator))
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		entry := types.File{Name: name, Path: filepath.Join(path, name), Type: "file"}
+		if isDir && rest != "" {
+			entry.Type = "dir"
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return nil, os.ErrNotExist
+	}
+	return entries, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "erlang", (&Detector{}).Name())
+}
+
+func TestDetect_RebarProjectWithLock(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/rebar.config":       "{minimum_otp_vsn, \"25\"}.\n{deps, [cowboy]}.\n{profiles, [{test, [{deps, [proper]}]}]}.\n",
+		"/repo/rebar.lock":         "{\"1.2.0\",\n[{<<\"cowboy\">>,{pkg,<<\"cowboy\">>,<<\"2.10.0\">>},0},\n {<<\"cowlib\">>,{pkg,<<\"cowlib\">>,<<\"2.12.1\">>},1}]}.\n[].\n",
+		"/repo/src/my_app.app.src": "{application, my_app, [{vsn, \"0.4.2\"}]}.\n",
+	}}
+	files := []types.File{
+		{Name: "rebar.config", Type: "file"},
+		{Name: "rebar.lock", Type: "file"},
+		{Name: "src", Type: "dir"},
+	}
+
+	payloads := (&Detector{}).Detect(files, "/repo", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Equal(t, "my_app", payload.Name)
+	assert.Equal(t, "rebar", payload.ComponentType)
+	assert.Equal(t, []string{"erlang"}, payload.Tech)
+	assert.Equal(t, []string{"/rebar.config", "/rebar.lock"}, payload.Path)
+	assert.Equal(t, map[string]interface{}{"app": "my_app", "version": "0.4.2", "minimum_otp_version": "25"}, payload.Properties["erlang"])
+
+	require.Len(t, payload.Dependencies, 3)
+	assert.Equal(t, "2.10.0", payload.Dependencies[0].Version)
+	assert.True(t, payload.Dependencies[0].Direct)
+	assert.False(t, payload.Dependencies[1].Direct)
+	assert.Equal(t, "proper", payload.Dependencies[2].Name)
+	assert.Equal(t, types.ScopeTest, payload.Dependencies[2].Scope)
+}
+
+func TestDetect_RebarProjectWithoutLock(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/apps/api/rebar.config": "{deps, [{jsx, \"3.1.0\"}]}.\n",
+	}}
+
+	payloads := (&Detector{}).Detect([]types.File{{Name: "rebar.config", Type: "file"}}, "/repo/apps/api", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	assert.Equal(t, "api", payloads[0].Name)
+	assert.Nil(t, payloads[0].Properties["erlang"])
+	require.Len(t, payloads[0].Dependencies, 1)
+	assert.Equal(t, "3.1.0", payloads[0].Dependencies[0].Version)
+	assert.Equal(t, "rebar.config", payloads[0].Dependencies[0].Metadata["source"])
+}
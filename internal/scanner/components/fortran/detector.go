@@ -0,0 +1,86 @@
+// Package fortran implements detection of Fortran packages built with the Fortran Package
+// Manager (fpm.toml) and their dependencies.
+package fortran
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/providers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Detector implements fpm package detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "fortran"
+}
+
+// Detect scans for fpm.toml manifests. The package name, version, license, executables, and
+// linked system libraries are stored in the "fpm" properties.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	for _, file := range files {
+		if file.Name != "fpm.toml" {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			return nil
+		}
+		parser := parsers.NewFpmParser()
+		pkg := parser.ParseFpmToml(string(content))
+
+		name := pkg.Name
+		if name == "" {
+			name = filepath.Base(currentPath)
+		}
+		payload := types.NewPayloadWithPath(name, types.CalculateRelativePath(file.Name, currentPath, basePath))
+		payload.SetComponentType("fpm")
+		payload.AddPrimaryTech("fortran")
+		payload.AddTech("fpm", "matched file: "+file.Name)
+
+		fpmInfo := map[string]interface{}{"name": name}
+		if pkg.Version != "" {
+			fpmInfo["version"] = pkg.Version
+		}
+		if pkg.License != "" {
+			fpmInfo["license"] = pkg.License
+		}
+		if len(pkg.Executables) > 0 {
+			fpmInfo["executables"] = pkg.Executables
+		}
+		if len(pkg.Link) > 0 {
+			fpmInfo["link"] = pkg.Link
+		}
+		payload.SetComponentProperties("fpm", fpmInfo)
+
+		var names []string
+		for _, dep := range parser.Dependencies(pkg) {
+			payload.AddDependency(dep)
+			names = append(names, dep.Name)
+		}
+		if len(names) > 0 {
+			for tech, reasons := range depDetector.MatchDependencies(names, parsers.DependencyTypeFpm) {
+				for _, reason := range reasons {
+					payload.AddTech(tech, reason)
+				}
+				depDetector.AddPrimaryTechIfNeeded(payload, tech)
+			}
+		}
+		return []*types.Payload{payload}
+	}
+	return nil
+}
+
+func init() {
+	components.Register(&Detector{})
+
+	// Register fpm package provider (packages depend on other packages by name)
+	providers.Register(&providers.PackageProvider{
+		DependencyType:      "fpm",
+		ExtractPackageNames: providers.SinglePropertyExtractor("fpm", "name"),
+	})
+}
@@ -0,0 +1,142 @@
+package fortran
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+// ListDir derives the directory entries from the file paths
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	seen := make(map[string]bool)
+	var entries []types.File
+	for filePath := range m.files {
+		rel, err := filepath.Rel(path, filePath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		name, rest, isDir := strings.Cut(rel, string(filepath.Separator))
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		entry := types.File{Name: name, Path: filepath.Join(path, name), Type: "file"}
+		if isDir && rest != "" {
+			entry.Type = "dir"
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return nil, os.ErrNotExist
+	}
+	return entries, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "fortran", (&Detector{}).Name())
+}
+
+func TestDetect_FpmPackage(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/fpm.toml": `name = "solver"
+version = "0.3.0"
+license = "MIT"
+
+[build]
+link = ["lapack"]
+
+[dependencies]
+stdlib = "*"
+toml-f.git = "https://github.com/toml-f/toml-f"
+
+[dev-dependencies]
+test-drive = { git = "https://github.com/fortran-lang/test-drive", tag = "v0.5.0" }
+
+[[executable]]
+name = "solve"
+`,
+	}}
+
+	payloads := (&Detector{}).Detect([]types.File{{Name: "fpm.toml", Type: "file"}}, "/repo", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Equal(t, "solver", payload.Name)
+	assert.Equal(t, "fpm", payload.ComponentType)
+	assert.Equal(t, []string{"fortran"}, payload.Tech)
+	assert.Contains(t, payload.Techs, "fpm")
+	assert.Equal(t, map[string]interface{}{
+		"name": "solver", "version": "0.3.0", "license": "MIT",
+		"executables": []string{"solve"}, "link": []string{"lapack"},
+	}, payload.Properties["fpm"])
+
+	require.Len(t, payload.Dependencies, 4)
+	assert.Equal(t, "stdlib", payload.Dependencies[0].Name)
+	assert.Equal(t, "latest", payload.Dependencies[1].Version)
+	assert.Equal(t, types.ScopeDev, payload.Dependencies[2].Scope)
+	assert.Equal(t, "v0.5.0", payload.Dependencies[2].Version)
+	assert.Equal(t, true, payload.Dependencies[3].Metadata["link"])
+}
+
+func TestDetect_FpmPackageWithoutName(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/libs/quadrature/fpm.toml": "[dependencies]\n",
+	}}
+
+	payloads := (&Detector{}).Detect([]types.File{{Name: "fpm.toml", Type: "file"}}, "/repo/libs/quadrature", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	assert.Equal(t, "quadrature", payloads[0].Name)
+	assert.Equal(t, []string{"/libs/quadrature/fpm.toml"}, payloads[0].Path)
+	assert.Empty(t, payloads[0].Dependencies)
+}
+
+func TestDetect_NoFpmManifest(t *testing.T) {
+	payloads := (&Detector{}).Detect([]types.File{{Name: "main.f90", Type: "file"}}, "/repo", "/repo", &MockProvider{}, &MockDependencyDetector{})
+	assert.Nil(t, payloads)
+}
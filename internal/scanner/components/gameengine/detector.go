@@ -0,0 +1,245 @@
+// Package gameengine implements detection of game engine projects: Unity projects
+// (ProjectSettings/ProjectVersion.txt, Packages/manifest.json) and Unreal Engine projects and
+// plugins (.uproject, .uplugin).
+package gameengine
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/providers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// maxPluginDepth limits how deep plugin descriptors are searched below the Plugins directory
+// of an Unreal project (Plugins/<Category>/<Plugin>/<Plugin>.uplugin)
+const maxPluginDepth = 3
+
+// Detector implements Unity and Unreal Engine detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "gameengine"
+}
+
+// Version returns the version of the detector's results; results are cached since the detector
+// searches the plugin descriptors of Unreal projects
+func (d *Detector) Version() int {
+	return 1
+}
+
+// Detect scans for Unity projects (a directory with Assets and ProjectSettings) and Unreal
+// Engine project and plugin descriptors. Engine and plugin versions are stored in the "unity",
+// "unreal", and "unreal_plugin" properties of the components.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	var results []*types.Payload
+	parser := parsers.NewGameEngineParser()
+
+	if payload := d.detectUnity(parser, files, currentPath, basePath, provider, depDetector); payload != nil {
+		results = append(results, payload)
+	}
+
+	for _, file := range files {
+		if file.Type == "dir" {
+			continue
+		}
+		var payload *types.Payload
+		switch strings.ToLower(filepath.Ext(file.Name)) {
+		case ".uproject":
+			payload = d.detectUnrealProject(parser, file, currentPath, basePath, provider, depDetector)
+		case ".uplugin":
+			payload = d.detectUnrealPlugin(parser, file, currentPath, basePath, provider, depDetector)
+		}
+		if payload != nil {
+			results = append(results, payload)
+		}
+	}
+	return results
+}
+
+// detectUnity creates a Unity component from the editor version and the package manifest
+func (d *Detector) detectUnity(parser *parsers.GameEngineParser, files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
+	if !hasDir(files, "Assets") || !hasDir(files, "ProjectSettings") {
+		return nil
+	}
+	versionFile := filepath.Join("ProjectSettings", "ProjectVersion.txt")
+	content, err := provider.ReadFile(filepath.Join(currentPath, versionFile))
+	if err != nil {
+		return nil
+	}
+	version, revision := parser.ParseUnityProjectVersion(string(content))
+
+	name := filepath.Base(currentPath)
+	if settings, err := provider.ReadFile(filepath.Join(currentPath, "ProjectSettings", "ProjectSettings.asset")); err == nil {
+		if productName := parser.ParseUnityProductName(string(settings)); productName != "" {
+			name = productName
+		}
+	}
+
+	payload := types.NewComponentPayload(name, versionFile, currentPath, basePath, "unity")
+	payload.AddTech("unity", "matched file: "+filepath.ToSlash(versionFile))
+	unityInfo := map[string]interface{}{"editor_version": version}
+	if revision != "" {
+		unityInfo["editor_revision"] = revision
+	}
+	payload.SetComponentProperties("unity", unityInfo)
+
+	manifestFile := filepath.Join("Packages", "manifest.json")
+	if manifest, err := provider.ReadFile(filepath.Join(currentPath, manifestFile)); err == nil {
+		if dependencies, err := parser.ParseUnityManifest(string(manifest)); err == nil {
+			payload.AddPath(types.CalculateRelativePath(manifestFile, currentPath, basePath))
+			addDependencies(payload, dependencies, parsers.DependencyTypeUnity, depDetector)
+		}
+	}
+	return payload
+}
+
+// detectUnrealProject creates an Unreal Engine component from a .uproject descriptor
+func (d *Detector) detectUnrealProject(parser *parsers.GameEngineParser, file types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
+	content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+	if err != nil {
+		return nil
+	}
+	project, err := parser.ParseUProject(string(content))
+	if err != nil {
+		return nil
+	}
+
+	name := strings.TrimSuffix(file.Name, filepath.Ext(file.Name))
+	payload := types.NewComponentPayload(name, file.Name, currentPath, basePath, "unreal")
+	payload.AddTech("unreal", "matched file: "+file.Name)
+
+	unrealInfo := map[string]interface{}{"engine_association": project.EngineAssociation}
+	if modules := moduleNames(project.Modules); len(modules) > 0 {
+		unrealInfo["modules"] = modules
+	}
+	if project.Category != "" {
+		unrealInfo["category"] = project.Category
+	}
+	payload.SetComponentProperties("unreal", unrealInfo)
+
+	versions := make(map[string]string)
+	collectPluginVersions(parser, provider, filepath.Join(currentPath, "Plugins"), 0, versions)
+	addDependencies(payload, parser.UnrealPluginDependencies(project.Plugins, parsers.MetadataSourceUProject, versions), parsers.DependencyTypeUnreal, depDetector)
+	return payload
+}
+
+// detectUnrealPlugin creates a component from a .uplugin descriptor, with the plugins it depends on
+func (d *Detector) detectUnrealPlugin(parser *parsers.GameEngineParser, file types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
+	content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+	if err != nil {
+		return nil
+	}
+	plugin, err := parser.ParseUPlugin(string(content))
+	if err != nil {
+		return nil
+	}
+
+	name := strings.TrimSuffix(file.Name, filepath.Ext(file.Name))
+	payload := types.NewPayloadWithPath(name, types.CalculateRelativePath(file.Name, currentPath, basePath))
+	payload.SetComponentType("unreal-plugin")
+	payload.AddPrimaryTech("unreal")
+	payload.AddTech("unreal", "matched file: "+file.Name)
+
+	pluginInfo := map[string]interface{}{"name": name}
+	for key, value := range map[string]string{
+		"friendly_name":  plugin.FriendlyName,
+		"version":        plugin.VersionName,
+		"engine_version": plugin.EngineVersion,
+		"category":       plugin.Category,
+		"created_by":     plugin.CreatedBy,
+	} {
+		if value != "" {
+			pluginInfo[key] = value
+		}
+	}
+	if modules := moduleNames(plugin.Modules); len(modules) > 0 {
+		pluginInfo["modules"] = modules
+	}
+	payload.SetComponentProperties("unreal_plugin", pluginInfo)
+
+	addDependencies(payload, parser.UnrealPluginDependencies(plugin.Plugins, parsers.MetadataSourceUPlugin, nil), parsers.DependencyTypeUnreal, depDetector)
+	return payload
+}
+
+// collectPluginVersions records the version names of the plugins shipped with a project by
+// plugin name, searching the plugin descriptors below dir
+func collectPluginVersions(parser *parsers.GameEngineParser, provider types.Provider, dir string, depth int, versions map[string]string) {
+	if depth >= maxPluginDepth {
+		return
+	}
+	entries, err := provider.ListDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.Type == "dir" {
+			if !entry.Symlink {
+				collectPluginVersions(parser, provider, filepath.Join(dir, entry.Name), depth+1, versions)
+			}
+			continue
+		}
+		if !strings.EqualFold(filepath.Ext(entry.Name), ".uplugin") {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(dir, entry.Name))
+		if err != nil {
+			continue
+		}
+		if plugin, err := parser.ParseUPlugin(string(content)); err == nil && plugin.VersionName != "" {
+			versions[strings.TrimSuffix(entry.Name, filepath.Ext(entry.Name))] = plugin.VersionName
+		}
+	}
+}
+
+// addDependencies adds dependencies and the technologies they match
+func addDependencies(payload *types.Payload, dependencies []types.Dependency, depType string, depDetector components.DependencyDetector) {
+	var names []string
+	for _, dep := range dependencies {
+		payload.AddDependency(dep)
+		names = append(names, dep.Name)
+	}
+	if len(names) == 0 {
+		return
+	}
+	for tech, reasons := range depDetector.MatchDependencies(names, depType) {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+		depDetector.AddPrimaryTechIfNeeded(payload, tech)
+	}
+}
+
+// moduleNames returns the names of code modules
+func moduleNames(modules []parsers.UnrealModule) []string {
+	var names []string
+	for _, module := range modules {
+		if module.Name != "" {
+			names = append(names, module.Name)
+		}
+	}
+	return names
+}
+
+// hasDir reports whether a directory listing contains a subdirectory
+func hasDir(files []types.File, name string) bool {
+	for _, file := range files {
+		if file.Type == "dir" && file.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	components.Register(&Detector{})
+
+	// Register unreal package provider (projects reference plugins by descriptor name)
+	providers.Register(&providers.PackageProvider{
+		DependencyType:      "unreal",
+		ExtractPackageNames: providers.SinglePropertyExtractor("unreal_plugin", "name"),
+	})
+}
@@ -0,0 +1,168 @@
+package gameengine
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+// ListDir derives the directory entries from the file paths
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	seen := make(map[string]bool)
+	var entries []types.File
+	for filePath := range m.files {
+		rel, err := filepath.Rel(path, filePath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		name, rest, isDir := strings.Cut(rel, string(filepath.Separator))
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		entry := types.File{Name: name, Path: filepath.Join(path, name), Type: "file"}
+		if isDir && rest != "" {
+			entry.Type = "dir"
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return nil, os.ErrNotExist
+	}
+	return entries, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct {
+	matchedTechs map[string][]string
+}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return m.matchedTechs
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "gameengine", (&Detector{}).Name())
+}
+
+func TestDetect_UnityProject(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/game/ProjectSettings/ProjectVersion.txt":    "m_EditorVersion: 2022.3.10f1\nm_EditorVersionWithRevision: 2022.3.10f1 (ff3792e53c62)\n",
+		"/repo/game/ProjectSettings/ProjectSettings.asset": "PlayerSettings:\n  productName: Space Shooter\n",
+		"/repo/game/Packages/manifest.json":                `{"dependencies": {"com.unity.inputsystem": "1.7.0", "com.unity.modules.audio": "1.0.0"}}`,
+	}}
+	files := []types.File{
+		{Name: "Assets", Type: "dir"},
+		{Name: "Packages", Type: "dir"},
+		{Name: "ProjectSettings", Type: "dir"},
+	}
+
+	payloads := (&Detector{}).Detect(files, "/repo/game", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Equal(t, "Space Shooter", payload.Name)
+	assert.Equal(t, "unity", payload.ComponentType)
+	assert.Equal(t, []string{"unity"}, payload.Tech)
+	assert.Equal(t, []string{"/game/ProjectSettings/ProjectVersion.txt", "/game/Packages/manifest.json"}, payload.Path)
+	assert.Equal(t, map[string]interface{}{"editor_version": "2022.3.10f1", "editor_revision": "ff3792e53c62"}, payload.Properties["unity"])
+	require.Len(t, payload.Dependencies, 2)
+	assert.Equal(t, "com.unity.inputsystem", payload.Dependencies[0].Name)
+	assert.Equal(t, "unity", payload.Dependencies[0].Type)
+}
+
+func TestDetect_UnityRequiresProjectLayout(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/ProjectSettings/ProjectVersion.txt": "m_EditorVersion: 2022.3.10f1\n",
+	}}
+
+	payloads := (&Detector{}).Detect([]types.File{{Name: "ProjectSettings", Type: "dir"}}, "/repo", "/repo", provider, &MockDependencyDetector{})
+	assert.Empty(t, payloads, "Should require the Assets directory")
+}
+
+func TestDetect_UnrealProjectAndPlugins(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/Shooter.uproject": `{
+	"EngineAssociation": "5.3",
+	"Modules": [{"Name": "Shooter", "Type": "Runtime"}],
+	"Plugins": [
+		{"Name": "EnhancedInput", "Enabled": true},
+		{"Name": "ShooterTools", "Enabled": true},
+		{"Name": "Paper2D", "Enabled": false}
+	]
+}`,
+		"/repo/Plugins/Tools/ShooterTools/ShooterTools.uplugin": `{"VersionName": "1.4", "FriendlyName": "Shooter Tools", "EngineVersion": "5.3.0", "CreatedBy": "Example", "Modules": [{"Name": "ShooterToolsEditor", "Type": "Editor"}], "Plugins": [{"Name": "EditorScriptingUtilities", "Enabled": true}]}`,
+	}}
+	depDetector := &MockDependencyDetector{matchedTechs: map[string][]string{"unreal": {"unreal matched: EnhancedInput"}}}
+
+	payloads := (&Detector{}).Detect([]types.File{{Name: "Shooter.uproject", Type: "file"}, {Name: "Plugins", Type: "dir"}}, "/repo", "/repo", provider, depDetector)
+	require.Len(t, payloads, 1)
+	project := payloads[0]
+
+	assert.Equal(t, "Shooter", project.Name)
+	assert.Equal(t, "unreal", project.ComponentType)
+	assert.Equal(t, []string{"/Shooter.uproject"}, project.Path)
+	assert.Equal(t, map[string]interface{}{"engine_association": "5.3", "modules": []string{"Shooter"}}, project.Properties["unreal"])
+	require.Len(t, project.Dependencies, 2)
+	assert.Equal(t, "EnhancedInput", project.Dependencies[0].Name)
+	assert.Equal(t, "latest", project.Dependencies[0].Version)
+	assert.Equal(t, "1.4", project.Dependencies[1].Version, "Should take the version of the bundled plugin")
+
+	payloads = (&Detector{}).Detect([]types.File{{Name: "ShooterTools.uplugin", Type: "file"}}, "/repo/Plugins/Tools/ShooterTools", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	plugin := payloads[0]
+
+	assert.Equal(t, "ShooterTools", plugin.Name)
+	assert.Equal(t, "unreal-plugin", plugin.ComponentType)
+	assert.Equal(t, []string{"unreal"}, plugin.Tech)
+	assert.Equal(t, map[string]interface{}{
+		"name":           "ShooterTools",
+		"friendly_name":  "Shooter Tools",
+		"version":        "1.4",
+		"engine_version": "5.3.0",
+		"created_by":     "Example",
+		"modules":        []string{"ShooterToolsEditor"},
+	}, plugin.Properties["unreal_plugin"])
+	require.Len(t, plugin.Dependencies, 1)
+	assert.Equal(t, "EditorScriptingUtilities", plugin.Dependencies[0].Name)
+	assert.Equal(t, ".uplugin", plugin.Dependencies[0].Metadata["source"])
+}
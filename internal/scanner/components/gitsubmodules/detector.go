@@ -0,0 +1,66 @@
+// Package gitsubmodules implements detection of repositories vendored as git submodules
+// (.gitmodules).
+package gitsubmodules
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/git"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Detector implements git submodule detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "gitsubmodules"
+}
+
+// Detect scans for .gitmodules files and reports each submodule as a dependency with its
+// repository URL and the commit pinned by the repository (from the index, or the HEAD tree).
+// Returns a virtual component (merged into parent) when submodules are found.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	for _, file := range files {
+		if file.Name != ".gitmodules" || file.Type == "dir" {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			return nil
+		}
+		parser := parsers.NewGitmodulesParser()
+		submodules := parser.ParseGitmodules(string(content))
+		if len(submodules) == 0 {
+			return nil
+		}
+		commits := git.SubmoduleCommits(currentPath)
+		for i := range submodules {
+			submodules[i].Commit = commits[submodules[i].Path]
+			submodules[i].URL = git.SanitizeRemoteURL(submodules[i].URL)
+		}
+
+		payload := types.NewPayloadWithPath("virtual", types.CalculateRelativePath(file.Name, currentPath, basePath))
+		var names []string
+		for _, dep := range parser.Dependencies(submodules) {
+			payload.AddDependency(dep)
+			names = append(names, dep.Name)
+		}
+		if len(names) == 0 {
+			return nil
+		}
+		for tech, reasons := range depDetector.MatchDependencies(names, parsers.DependencyTypeGitSubmodule) {
+			for _, reason := range reasons {
+				payload.AddTech(tech, reason)
+			}
+		}
+		return []*types.Payload{payload}
+	}
+	return nil
+}
+
+func init() {
+	components.Register(&Detector{})
+}
@@ -3,6 +3,7 @@ package golang
 import (
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
@@ -11,12 +12,36 @@ import (
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 )
 
+// maxGoFiles bounds the number of .go files read per go.mod
+const maxGoFiles = 5000
+
 type Detector struct{}
 
+// buildInfo collects the build information of the source files of a module
+type buildInfo struct {
+	files     int
+	cgoFiles  int
+	tags      map[string]bool
+	platforms map[string]bool
+	importers map[string]*importUse // Import path -> files importing it
+}
+
+// importUse records whether an import path is used by platform-specific files only
+type importUse struct {
+	generic   bool            // Imported by a file without platform restriction
+	platforms map[string]bool // Platforms of the restricted files importing it
+}
+
 func (d *Detector) Name() string {
 	return "golang"
 }
 
+// Version returns the version of the detector's results; results are cached since the detector
+// walks the source files of the module
+func (d *Detector) Version() int {
+	return 2
+}
+
 func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
 	var results []*types.Payload
 
@@ -30,6 +55,15 @@ func (d *Detector) Detect(files []types.File, currentPath, basePath string, prov
 		}
 	}
 
+	// Check for go.work (workspace component, unless the directory holds a module as well)
+	for _, file := range files {
+		if file.Name == "go.work" && !hasFile(files, "go.mod") {
+			if payload := d.detectGoWork(file, currentPath, basePath, provider); payload != nil {
+				results = append(results, payload)
+			}
+		}
+	}
+
 	// Check for main.go (component - creates named payload)
 	mainGoRegex := regexp.MustCompile(`^main\.go$`)
 	for _, file := range files {
@@ -76,15 +110,56 @@ func (d *Detector) detectGoMod(file types.File, currentPath, basePath string, pr
 	goParser := parsers.NewGolangParser()
 	dependencies, modInfo := goParser.ParseGoModWithInfo(string(content))
 
-	// Add module info as component properties (following Maven/Docker pattern)
-	if modInfo.ModulePath != "" || modInfo.GoVersion != "" {
-		goInfo := make(map[string]interface{})
-		if modInfo.ModulePath != "" {
-			goInfo["module_path"] = modInfo.ModulePath
+	// With lock files enabled, go.sum adds module hashes, and with --include-transitive the
+	// transitive modules
+	if components.UseLockFiles() {
+		if goSum, err := provider.ReadFile(filepath.Join(currentPath, "go.sum")); err == nil {
+			dependencies = goParser.ParseGoSumWithOptions(string(goSum), string(content), parsers.ParseGoSumOptions{IncludeTransitive: components.IncludeTransitive()})
 		}
-		if modInfo.GoVersion != "" {
-			goInfo["go_version"] = modInfo.GoVersion
+	}
+
+	// Build constraints, cgo, and imports of the module's source files
+	build := &buildInfo{tags: make(map[string]bool), platforms: make(map[string]bool), importers: make(map[string]*importUse)}
+	d.collectSources(currentPath, provider, goParser, build)
+	markPlatformDependencies(dependencies, build)
+
+	// Add module info as component properties (following Maven/Docker pattern)
+	goInfo := make(map[string]interface{})
+	if modInfo.ModulePath != "" {
+		goInfo["module_path"] = modInfo.ModulePath
+	}
+	if modInfo.GoVersion != "" {
+		goInfo["go_version"] = modInfo.GoVersion
+	}
+	if modInfo.Toolchain != "" {
+		goInfo["toolchain"] = modInfo.Toolchain
+	}
+	if len(modInfo.Godebug) > 0 {
+		goInfo["godebug"] = modInfo.Godebug
+	}
+	if build.cgoFiles > 0 {
+		goInfo["cgo"] = true
+		goInfo["cgo_files"] = build.cgoFiles
+	}
+	if len(build.tags) > 0 {
+		goInfo["build_tags"] = setToSortedSlice(build.tags)
+	}
+	if len(build.platforms) > 0 {
+		goInfo["platforms"] = setToSortedSlice(build.platforms)
+	}
+
+	// Workspace the module belongs to (go.work use directive), and members of a go.work next to go.mod
+	if workspace := findWorkspace(currentPath, basePath, provider, goParser); workspace != nil {
+		parsers.ApplyGoWorkReplaces(dependencies, workspace.info.Replace)
+		rel, _ := filepath.Rel(basePath, filepath.Join(workspace.dir, "go.work"))
+		goInfo["workspace"] = "/" + filepath.ToSlash(rel)
+	}
+	if workspace := readWorkspace(currentPath, provider, goParser); workspace != nil {
+		if members := workspaceMembers(workspace, basePath, provider, goParser); len(members) > 0 {
+			goInfo["workspace_members"] = members
 		}
+	}
+	if len(goInfo) > 0 {
 		payload.Properties["golang"] = goInfo
 	}
 
@@ -93,9 +168,12 @@ func (d *Detector) detectGoMod(file types.File, currentPath, basePath string, pr
 		payload.AddDependency(dep)
 	}
 
-	// Extract dependency names for tech matching
+	// Extract dependency names for tech matching (technologies the module uses directly)
 	var depNames []string
 	for _, dep := range dependencies {
+		if !dep.Direct {
+			continue
+		}
 		// Remove version suffix for tech matching
 		name := strings.Split(dep.Name, "@")[0]
 		depNames = append(depNames, name)
@@ -115,6 +193,150 @@ func (d *Detector) detectGoMod(file types.File, currentPath, basePath string, pr
 	return payload
 }
 
+// detectGoWork creates the component of a go.work workspace without a module of its own, named
+// after its directory; the modules of its use directives are separate components
+func (d *Detector) detectGoWork(file types.File, currentPath, basePath string, provider types.Provider) *types.Payload {
+	goParser := parsers.NewGolangParser()
+	workspace := readWorkspace(currentPath, provider, goParser)
+	if workspace == nil {
+		return nil
+	}
+
+	relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, file.Name))
+	payload := types.NewPayloadWithPath(filepath.Base(currentPath), "/"+filepath.ToSlash(relativeFilePath))
+	payload.SetComponentType("golang")
+	payload.AddPrimaryTech("golang")
+
+	goInfo := make(map[string]interface{})
+	if workspace.info.GoVersion != "" {
+		goInfo["go_version"] = workspace.info.GoVersion
+	}
+	if workspace.info.Toolchain != "" {
+		goInfo["toolchain"] = workspace.info.Toolchain
+	}
+	if members := workspaceMembers(workspace, basePath, provider, goParser); len(members) > 0 {
+		goInfo["workspace_members"] = members
+	}
+	if len(goInfo) > 0 {
+		payload.Properties["golang"] = goInfo
+	}
+	return payload
+}
+
+// hasFile reports whether a directory listing holds a file of a name
+func hasFile(files []types.File, name string) bool {
+	for _, file := range files {
+		if file.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// collectSources reads the build information of the .go files below dir, skipping linked, hidden,
+// vendor, and testdata directories and nested modules
+func (d *Detector) collectSources(dir string, provider types.Provider, parser *parsers.GolangParser, build *buildInfo) {
+	entries, err := provider.ListDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if build.files >= maxGoFiles {
+			return
+		}
+		path := filepath.Join(dir, entry.Name)
+		if entry.Type == "dir" {
+			if entry.Symlink || strings.HasPrefix(entry.Name, ".") || strings.HasPrefix(entry.Name, "_") ||
+				entry.Name == "vendor" || entry.Name == "testdata" || entry.Name == "node_modules" {
+				continue
+			}
+			if exists, _ := provider.Exists(filepath.Join(path, "go.mod")); exists {
+				continue
+			}
+			d.collectSources(path, provider, parser, build)
+			continue
+		}
+		if filepath.Ext(entry.Name) != ".go" {
+			continue
+		}
+		content, err := provider.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		build.files++
+
+		source := parser.ParseGoSource(entry.Name, string(content))
+		if source.Cgo {
+			build.cgoFiles++
+		}
+		for _, tag := range source.Tags {
+			build.tags[tag] = true
+		}
+		for _, platform := range source.Platforms {
+			build.platforms[platform] = true
+		}
+		for _, imp := range source.Imports {
+			use, ok := build.importers[imp]
+			if !ok {
+				use = &importUse{platforms: make(map[string]bool)}
+				build.importers[imp] = use
+			}
+			if len(source.Platforms) == 0 {
+				use.generic = true
+			}
+			for _, platform := range source.Platforms {
+				use.platforms[platform] = true
+			}
+		}
+	}
+}
+
+// markPlatformDependencies records the platforms of required modules that are only imported
+// by platform-specific files (e.g., golang.org/x/sys/windows from *_windows.go files)
+func markPlatformDependencies(dependencies []types.Dependency, build *buildInfo) {
+	uses := make(map[int]*importUse) // Dependency index -> merged imports of its packages
+	for imp, use := range build.importers {
+		owner, longest := -1, 0
+		for i, dep := range dependencies {
+			if (imp == dep.Name || strings.HasPrefix(imp, dep.Name+"/")) && len(dep.Name) > longest {
+				owner, longest = i, len(dep.Name)
+			}
+		}
+		if owner < 0 {
+			continue
+		}
+		merged, ok := uses[owner]
+		if !ok {
+			merged = &importUse{platforms: make(map[string]bool)}
+			uses[owner] = merged
+		}
+		merged.generic = merged.generic || use.generic
+		for platform := range use.platforms {
+			merged.platforms[platform] = true
+		}
+	}
+
+	for i, use := range uses {
+		if use.generic || len(use.platforms) == 0 {
+			continue
+		}
+		if dependencies[i].Metadata == nil {
+			dependencies[i].Metadata = make(map[string]interface{})
+		}
+		dependencies[i].Metadata[parsers.MetadataPlatforms] = setToSortedSlice(use.platforms)
+	}
+}
+
+// setToSortedSlice returns the members of a set in order
+func setToSortedSlice(set map[string]bool) []string {
+	values := make([]string, 0, len(set))
+	for value := range set {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
+
 func init() {
 	components.Register(&Detector{})
 
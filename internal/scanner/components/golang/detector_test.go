@@ -4,6 +4,8 @@ import (
 	"os"
 	"testing"
 
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -12,6 +14,7 @@ import (
 // MockProvider implements types.Provider for testing
 type MockProvider struct {
 	files map[string]string
+	dirs  map[string][]types.File
 }
 
 func (m *MockProvider) ReadFile(path string) ([]byte, error) {
@@ -22,7 +25,7 @@ func (m *MockProvider) ReadFile(path string) ([]byte, error) {
 }
 
 func (m *MockProvider) ListDir(path string) ([]types.File, error) {
-	return nil, nil
+	return m.dirs[path], nil
 }
 
 func (m *MockProvider) Open(path string) (string, error) {
@@ -524,3 +527,197 @@ go 1.21`,
 		})
 	}
 }
+
+func TestDetector_Detect_BuildInfo(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/go.mod": `module github.com/example/agent
+
+go 1.22
+
+toolchain go1.22.3
+
+godebug panicnil=1
+
+require (
+	golang.org/x/sys v0.20.0
+	github.com/mattn/go-sqlite3 v1.14.22
+	github.com/spf13/cobra v1.8.0
+	github.com/kardianos/service v1.2.2
+)`,
+			"/project/main.go":                "package main\n\nimport (\n\t\"github.com/spf13/cobra\"\n\t\"golang.org/x/sys/cpu\"\n)\n",
+			"/project/svc/service_windows.go": "package svc\n\nimport (\n\t\"github.com/kardianos/service\"\n\t\"golang.org/x/sys/windows/svc\"\n)\n",
+			"/project/db/db.go":               "//go:build sqlite\n\npackage db\n\n// #cgo LDFLAGS: -lsqlite3\nimport \"C\"\n\nimport _ \"github.com/mattn/go-sqlite3\"\n",
+			"/project/db/db_linux.go":         "package db\n\nimport _ \"github.com/mattn/go-sqlite3\"\n",
+			"/project/tools/go.mod":           "module github.com/example/agent/tools\n",
+			"/project/tools/tools_darwin.go":  "package tools\n",
+			"/project/vendor/x/x_freebsd.go":  "package x\n",
+		},
+		dirs: map[string][]types.File{
+			"/project":        {{Name: "go.mod", Type: "file"}, {Name: "main.go", Type: "file"}, {Name: "svc", Type: "dir"}, {Name: "db", Type: "dir"}, {Name: "tools", Type: "dir"}, {Name: "vendor", Type: "dir"}},
+			"/project/svc":    {{Name: "service_windows.go", Type: "file"}},
+			"/project/db":     {{Name: "db.go", Type: "file"}, {Name: "db_linux.go", Type: "file"}},
+			"/project/tools":  {{Name: "go.mod", Type: "file"}, {Name: "tools_darwin.go", Type: "file"}},
+			"/project/vendor": {{Name: "x", Type: "dir"}},
+		},
+	}
+	depDetector := &MockDependencyDetector{matchedTechs: map[string][]string{}}
+
+	results := detector.Detect([]types.File{{Name: "go.mod", Path: "/project/go.mod"}}, "/project", "/project", provider, depDetector)
+	require.Len(t, results, 1)
+	payload := results[0]
+
+	goInfo, ok := payload.Properties["golang"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "go1.22.3", goInfo["toolchain"])
+	assert.Equal(t, map[string]string{"panicnil": "1"}, goInfo["godebug"])
+	assert.Equal(t, true, goInfo["cgo"])
+	assert.Equal(t, 1, goInfo["cgo_files"])
+	assert.Equal(t, []string{"sqlite"}, goInfo["build_tags"])
+	assert.Equal(t, []string{"linux", "windows"}, goInfo["platforms"], "nested modules and vendor are skipped")
+
+	platforms := make(map[string]interface{})
+	for _, dep := range payload.Dependencies {
+		platforms[dep.Name] = dep.Metadata["platforms"]
+	}
+	assert.Equal(t, []string{"windows"}, platforms["github.com/kardianos/service"])
+	assert.Nil(t, platforms["golang.org/x/sys"], "also imported by a file for every platform")
+	assert.Nil(t, platforms["github.com/spf13/cobra"])
+	assert.Nil(t, platforms["github.com/mattn/go-sqlite3"], "a custom build tag does not restrict the platform")
+}
+
+func TestDetector_Detect_GoSum(t *testing.T) {
+	detector := &Detector{}
+
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/go.mod": `module github.com/example/test-app
+
+go 1.21
+
+require github.com/gin-gonic/gin v1.9.1
+
+require github.com/bytedance/sonic v1.9.1 // indirect
+`,
+			"/project/go.sum": `github.com/bytedance/sonic v1.9.1 h1:6iJ6NqdoxCDr6mbY8h18oSO+cShGSMRGCEo7F2h0x8s=
+github.com/bytedance/sonic v1.9.1/go.mod h1:i736AoUSYt75HyZLoJW9ERYxcy6eaN6h4BZXU064P/U=
+github.com/gin-gonic/gin v1.9.1 h1:4idEAncQnU5cB7BeOkPtxjfCSye0AAm1R0RVIqJ+Jmg=
+github.com/gin-gonic/gin v1.9.1/go.mod h1:hPrL7YrpYKXt5YId3A/Tnip5kqbEAP+KLuI3SUcPTeU=
+`,
+		},
+	}
+	var matched []string
+	depDetector := &recordingDependencyDetector{matched: &matched}
+	files := []types.File{
+		{Name: "go.mod", Path: "/project/go.mod"},
+	}
+
+	results := detector.Detect(files, "/project", "/project", provider, depDetector)
+	require.Len(t, results, 1)
+	require.Len(t, results[0].Dependencies, 1, "direct dependencies only by default")
+	assert.Equal(t, "h1:4idEAncQnU5cB7BeOkPtxjfCSye0AAm1R0RVIqJ+Jmg=", results[0].Dependencies[0].Metadata[parsers.MetadataGoSumHash])
+
+	components.SetIncludeTransitive(true)
+	t.Cleanup(func() { components.SetIncludeTransitive(false) })
+	matched = nil
+	results = detector.Detect(files, "/project", "/project", provider, depDetector)
+	require.Len(t, results, 1)
+
+	deps := results[0].Dependencies
+	require.Len(t, deps, 2)
+	assert.Equal(t, "github.com/gin-gonic/gin", deps[0].Name)
+	assert.True(t, deps[0].Direct)
+	assert.Equal(t, "h1:4idEAncQnU5cB7BeOkPtxjfCSye0AAm1R0RVIqJ+Jmg=", deps[0].Metadata[parsers.MetadataGoSumHash])
+	assert.Equal(t, "github.com/bytedance/sonic", deps[1].Name)
+	assert.False(t, deps[1].Direct)
+	assert.Equal(t, "go.sum", deps[1].SourceFile)
+	assert.Equal(t, []string{"github.com/gin-gonic/gin"}, matched, "technologies are matched on direct dependencies")
+}
+
+// recordingDependencyDetector records the dependency names it matches
+type recordingDependencyDetector struct {
+	MockDependencyDetector
+	matched *[]string
+}
+
+func (r *recordingDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	*r.matched = append(*r.matched, dependencies...)
+	return nil
+}
+
+func TestDetector_Detect_GoWork(t *testing.T) {
+	detector := &Detector{}
+
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/go.work": "go 1.22.0\n\nuse (\n\t./services/api\n\t./libs/shared\n\t./missing\n\t../outside\n)\n\nreplace github.com/acme/legacy => github.com/acme/legacy-fork v1.0.1\n",
+			"/project/services/api/go.mod": `module github.com/acme/api
+
+go 1.22
+
+require (
+	github.com/acme/shared v0.0.0
+	github.com/acme/legacy v1.0.0
+)
+`,
+			"/project/libs/shared/go.mod": "module github.com/acme/shared\n\ngo 1.22\n",
+			"/project/tools/go.mod":       "module github.com/acme/tools\n\ngo 1.22\n",
+		},
+	}
+	depDetector := &MockDependencyDetector{matchedTechs: map[string][]string{}}
+
+	results := detector.Detect([]types.File{{Name: "go.work", Path: "/project/go.work"}}, "/project", "/project", provider, depDetector)
+	require.Len(t, results, 1)
+	workspace := results[0]
+	assert.Equal(t, "project", workspace.Name)
+	assert.Equal(t, []string{"/go.work"}, workspace.Path)
+	assert.Contains(t, workspace.Tech, "golang")
+	golang := workspace.Properties["golang"].(map[string]interface{})
+	assert.Equal(t, "1.22.0", golang["go_version"])
+	assert.Equal(t, []map[string]interface{}{
+		{"path": "/libs/shared", "module": "github.com/acme/shared"},
+		{"path": "/services/api", "module": "github.com/acme/api"},
+	}, golang["workspace_members"], "directories without go.mod and outside the scan root are skipped")
+
+	results = detector.Detect([]types.File{{Name: "go.mod", Path: "/project/services/api/go.mod"}}, "/project/services/api", "/project", provider, depDetector)
+	require.Len(t, results, 1)
+	api := results[0]
+	require.Len(t, api.Dependencies, 2)
+	assert.Equal(t, "/go.work", api.Properties["golang"].(map[string]interface{})["workspace"])
+	for _, dep := range api.Dependencies {
+		if dep.Name == "github.com/acme/legacy" {
+			assert.Equal(t, "github.com/acme/legacy-fork@v1.0.1", dep.Metadata["replaced_by"], "replace of go.work")
+		}
+	}
+
+	results = detector.Detect([]types.File{{Name: "go.mod", Path: "/project/tools/go.mod"}}, "/project/tools", "/project", provider, depDetector)
+	require.Len(t, results, 1)
+	assert.NotContains(t, results[0].Properties["golang"], "workspace", "module not used by the workspace")
+}
+
+func TestDetector_Detect_GoWorkWithRootModule(t *testing.T) {
+	detector := &Detector{}
+
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/go.work":        "go 1.22\n\nuse (\n\t.\n\t./cmd/cli\n)\n",
+			"/project/go.mod":         "module github.com/acme/app\n\ngo 1.22\n",
+			"/project/cmd/cli/go.mod": "module github.com/acme/app/cmd/cli\n\ngo 1.22\n",
+		},
+	}
+	depDetector := &MockDependencyDetector{matchedTechs: map[string][]string{}}
+	files := []types.File{
+		{Name: "go.mod", Path: "/project/go.mod"},
+		{Name: "go.work", Path: "/project/go.work"},
+	}
+
+	results := detector.Detect(files, "/project", "/project", provider, depDetector)
+	require.Len(t, results, 1, "the module of the directory holds the workspace")
+	golang := results[0].Properties["golang"].(map[string]interface{})
+	assert.Equal(t, "/go.work", golang["workspace"])
+	assert.Equal(t, []map[string]interface{}{
+		{"path": "/", "module": "github.com/acme/app"},
+		{"path": "/cmd/cli", "module": "github.com/acme/app/cmd/cli"},
+	}, golang["workspace_members"])
+}
@@ -0,0 +1,83 @@
+package golang
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// goWorkspace is the go.work workspace a module belongs to
+type goWorkspace struct {
+	dir  string // Directory of the go.work file
+	info *parsers.GoWorkInfo
+}
+
+// findWorkspace returns the workspace of a module, like the go command: the nearest go.work in
+// the directory or its parents (up to the scan root), if one of its use directives names the
+// module. Returns nil for modules outside a workspace.
+func findWorkspace(currentPath, basePath string, provider types.Provider, parser *parsers.GolangParser) *goWorkspace {
+	for dir := currentPath; isWithin(basePath, dir); dir = filepath.Dir(dir) {
+		if workspace := readWorkspace(dir, provider, parser); workspace != nil {
+			for _, use := range workspace.info.Use {
+				if filepath.Join(dir, filepath.FromSlash(use)) == currentPath {
+					return workspace
+				}
+			}
+			return nil
+		}
+		if dir == filepath.Dir(dir) {
+			break
+		}
+	}
+	return nil
+}
+
+// readWorkspace parses the go.work of a directory; nil if there is none
+func readWorkspace(dir string, provider types.Provider, parser *parsers.GolangParser) *goWorkspace {
+	content, err := provider.ReadFile(filepath.Join(dir, "go.work"))
+	if err != nil {
+		return nil
+	}
+	if info := parser.ParseGoWork(string(content)); info != nil {
+		return &goWorkspace{dir: dir, info: info}
+	}
+	return nil
+}
+
+// workspaceMembers lists the modules of the use directives of a workspace with their module
+// path and directory relative to the scan root ("/services/api"), sorted by path. Directories
+// outside the scan root and without go.mod are skipped.
+func workspaceMembers(workspace *goWorkspace, basePath string, provider types.Provider, parser *parsers.GolangParser) []map[string]interface{} {
+	var members []map[string]interface{}
+	seen := make(map[string]bool)
+	for _, use := range workspace.info.Use {
+		dir := filepath.Join(workspace.dir, filepath.FromSlash(use))
+		if !isWithin(basePath, dir) || seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		content, err := provider.ReadFile(filepath.Join(dir, "go.mod"))
+		if err != nil {
+			continue
+		}
+		rel, _ := filepath.Rel(basePath, dir)
+		member := map[string]interface{}{"path": "/" + filepath.ToSlash(rel)}
+		if rel == "." {
+			member["path"] = "/"
+		}
+		if _, info := parser.ParseGoModWithInfo(string(content)); info.ModulePath != "" {
+			member["module"] = info.ModulePath
+		}
+		members = append(members, member)
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i]["path"].(string) < members[j]["path"].(string) })
+	return members
+}
+
+// isWithin reports whether path is root or inside it
+func isWithin(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && filepath.IsLocal(rel)
+}
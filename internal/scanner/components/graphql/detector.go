@@ -0,0 +1,86 @@
+// Package graphql implements detection of GraphQL tooling: GraphQL Code Generator and
+// graphql-config configurations, and the schema surface of .graphql documents.
+package graphql
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// maxDocumentSize skips GraphQL documents larger than this (e.g., introspection dumps)
+const maxDocumentSize = 2_000_000
+
+// Detector implements GraphQL configuration and document detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "graphql"
+}
+
+// Detect scans for GraphQL configuration files and documents. They are stored in the
+// "graphql_configs" and "graphql_documents" properties of a virtual component (merged into parent).
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	parser := parsers.NewGraphQLParser()
+	var configs, documents []interface{}
+	var firstFile, reason string
+
+	// Sort by name so the reported file and reason are deterministic
+	sorted := append([]types.File(nil), files...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	for _, file := range sorted {
+		if file.Type == "dir" {
+			continue
+		}
+		_, isConfig := parsers.GraphQLConfigFiles[file.Name]
+		isDocument := parsers.IsGraphQLDocument(file.Name)
+		if !isConfig && !isDocument || file.Size > maxDocumentSize {
+			continue
+		}
+
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			continue
+		}
+		relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, file.Name))
+		relativeFilePath = "/" + filepath.ToSlash(relativeFilePath)
+
+		if isConfig {
+			config, err := parser.ParseConfig(string(content), file.Name)
+			if err != nil {
+				continue
+			}
+			config.File = relativeFilePath
+			configs = append(configs, config)
+		} else {
+			doc := parser.ParseDocument(string(content))
+			doc.File = relativeFilePath
+			documents = append(documents, doc)
+		}
+		if firstFile == "" {
+			firstFile, reason = relativeFilePath, "matched file: "+file.Name
+		}
+	}
+	if firstFile == "" {
+		return nil
+	}
+
+	payload := types.NewPayloadWithPath("virtual", firstFile)
+	payload.AddTech("graphql", reason)
+	if len(configs) > 0 {
+		payload.Properties["graphql_configs"] = configs
+	}
+	if len(documents) > 0 {
+		payload.Properties["graphql_documents"] = documents
+	}
+	return []*types.Payload{payload}
+}
+
+func init() {
+	components.Register(&Detector{})
+}
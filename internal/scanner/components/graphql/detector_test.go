@@ -0,0 +1,98 @@
+package graphql
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "graphql", (&Detector{}).Name())
+}
+
+func TestDetector_Detect(t *testing.T) {
+	provider := &MockProvider{
+		files: map[string]string{
+			"/repo/web/codegen.yml":     "schema: schema.graphql\ndocuments: src/**/*.graphql\ngenerates:\n  src/gql/:\n    preset: client\n",
+			"/repo/web/schema.graphql":  "type Query { me: User }\ntype User { id: ID! }\n",
+			"/repo/web/operations.gql":  "query Me { me { id } }\n",
+			"/repo/web/codegen.yml.bak": "",
+			"/repo/web/large.graphql":   "type Query { big: Int }",
+		},
+	}
+	files := []types.File{
+		{Name: "schema.graphql", Type: "file"},
+		{Name: "codegen.yml", Type: "file"},
+		{Name: "operations.gql", Type: "file"},
+		{Name: "codegen.yml.bak", Type: "file"},
+		{Name: "large.graphql", Type: "file", Size: 5_000_000},
+		{Name: "graphql", Type: "dir"},
+	}
+
+	results := (&Detector{}).Detect(files, "/repo/web", "/repo", provider, nil)
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Equal(t, "virtual", payload.Name)
+	assert.Equal(t, []string{"/web/codegen.yml"}, payload.Path)
+	assert.Contains(t, payload.Techs, "graphql")
+	assert.Equal(t, []interface{}{
+		&parsers.GraphQLConfig{
+			File:      "/web/codegen.yml",
+			Tool:      "codegen",
+			Schema:    []string{"schema.graphql"},
+			Documents: []string{"src/**/*.graphql"},
+			Generates: []string{"src/gql/"},
+			Plugins:   []string{"client"},
+		},
+	}, payload.Properties["graphql_configs"])
+	assert.Equal(t, []interface{}{
+		parsers.GraphQLDocument{File: "/web/operations.gql", Operations: 1},
+		parsers.GraphQLDocument{File: "/web/schema.graphql", Types: 2, Queries: 1},
+	}, payload.Properties["graphql_documents"])
+}
+
+func TestDetector_Detect_NoGraphQL(t *testing.T) {
+	files := []types.File{{Name: "package.json", Type: "file"}, {Name: "schema.json", Type: "file"}}
+	assert.Empty(t, (&Detector{}).Detect(files, "/repo", "/repo", &MockProvider{}, nil))
+}
@@ -0,0 +1,138 @@
+// Package iac implements detection of Pulumi and AWS CDK projects with their provider
+// packages, construct libraries, and cloud targets.
+package iac
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// toolTechs maps infrastructure-as-code tools to their technology
+var toolTechs = map[string]string{
+	parsers.IaCToolPulumi: "pulumi",
+	parsers.IaCToolCDK:    "aws.cdk",
+}
+
+// Detector implements Pulumi and AWS CDK project detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "iac"
+}
+
+// Detect scans for Pulumi.yaml and cdk.json. Projects are stored in the "iac" property of a
+// virtual component (merged into parent); cloud targets are added as technologies.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	parser := parsers.NewIaCProjectParser()
+	var projects []*parsers.IaCProject
+
+	for _, file := range files {
+		if file.Type == "dir" {
+			continue
+		}
+		var project *parsers.IaCProject
+		switch file.Name {
+		case "Pulumi.yaml", "Pulumi.yml":
+			content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+			if err != nil {
+				continue
+			}
+			if project, err = parser.ParsePulumiYAML(string(content)); err != nil {
+				continue
+			}
+			project.Stacks = pulumiStacks(files)
+		case "cdk.json":
+			content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+			if err != nil {
+				continue
+			}
+			if project, err = parser.ParseCDKJSON(string(content)); err != nil {
+				continue
+			}
+		default:
+			continue
+		}
+		relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, file.Name))
+		project.File = "/" + filepath.ToSlash(relativeFilePath)
+		project.ApplyDependencies(manifestDependencies(files, currentPath, provider))
+		projects = append(projects, project)
+	}
+	if len(projects) == 0 {
+		return nil
+	}
+
+	payload := types.NewPayloadWithPath("virtual", projects[0].File)
+	var properties []interface{}
+	for _, project := range projects {
+		properties = append(properties, project)
+		payload.AddTech(toolTechs[project.Tool], "matched file: "+filepath.Base(project.File))
+		for _, cloud := range project.CloudTargets {
+			payload.AddTech(cloud, "cloud target: "+cloud+" ("+project.Tool+")")
+		}
+	}
+	payload.Properties["iac"] = properties
+	return []*types.Payload{payload}
+}
+
+// pulumiStacks returns the stack names of Pulumi.<stack>.yaml files
+func pulumiStacks(files []types.File) []string {
+	var stacks []string
+	for _, file := range files {
+		name := strings.TrimSuffix(strings.TrimSuffix(file.Name, ".yaml"), ".yml")
+		if name == file.Name || !strings.HasPrefix(name, "Pulumi.") {
+			continue
+		}
+		stacks = append(stacks, strings.TrimPrefix(name, "Pulumi."))
+	}
+	sort.Strings(stacks)
+	return stacks
+}
+
+// manifestDependencies reads the dependencies declared in the package manifests of the
+// project directory (package.json, requirements.txt, go.mod, pom.xml, *.csproj)
+func manifestDependencies(files []types.File, currentPath string, provider types.Provider) []types.Dependency {
+	var deps []types.Dependency
+	for _, file := range files {
+		if file.Type == "dir" {
+			continue
+		}
+		read := func() string {
+			content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+			if err != nil {
+				return ""
+			}
+			return string(content)
+		}
+
+		switch {
+		case file.Name == "package.json":
+			nodejsParser := parsers.NewNodeJSParser()
+			if pkg, err := nodejsParser.ParsePackageJSON([]byte(read())); err == nil {
+				deps = append(deps, nodejsParser.CreateDependencies(pkg, nodejsParser.ExtractDependencies(pkg))...)
+			}
+		case file.Name == "requirements.txt":
+			deps = append(deps, parsers.NewPythonParser().ParseRequirementsTxt(read())...)
+		case file.Name == "go.mod":
+			goDeps, _ := parsers.NewGolangParser().ParseGoModWithInfo(read())
+			deps = append(deps, goDeps...)
+		case file.Name == "pom.xml":
+			deps = append(deps, parsers.NewMavenParser().ParsePomXML(read())...)
+		case strings.HasSuffix(file.Name, ".csproj"):
+			project := parsers.NewDotNetParser().ParseCsproj(read(), file.Name)
+			for _, pkg := range project.Packages {
+				deps = append(deps, types.Dependency{Type: parsers.DependencyTypeDotnet, Name: pkg.Name, Version: pkg.Version})
+			}
+		}
+	}
+	return deps
+}
+
+func init() {
+	components.Register(&Detector{})
+}
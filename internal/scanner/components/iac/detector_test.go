@@ -0,0 +1,131 @@
+package iac
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "iac", (&Detector{}).Name())
+}
+
+func TestDetect_Pulumi(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/project/infra/Pulumi.yaml":      "name: infra\nruntime: go\n",
+		"/project/infra/go.mod":           "module example.com/infra\n\ngo 1.22\n\nrequire (\n\tgithub.com/pulumi/pulumi-gcp/sdk/v7 v7.8.0\n\tgithub.com/pulumi/pulumi-kubernetes/sdk/v4 v4.7.1\n\tgithub.com/pulumi/pulumi/sdk/v3 v3.101.1\n)\n",
+		"/project/infra/Pulumi.dev.yaml":  "config: {}\n",
+		"/project/infra/Pulumi.prod.yaml": "config: {}\n",
+	}}
+	files := []types.File{
+		{Name: "Pulumi.yaml", Type: "file"},
+		{Name: "Pulumi.dev.yaml", Type: "file"},
+		{Name: "Pulumi.prod.yaml", Type: "file"},
+		{Name: "go.mod", Type: "file"},
+	}
+
+	payloads := (&Detector{}).Detect(files, "/project/infra", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Equal(t, "virtual", payload.Name)
+	assert.Contains(t, payload.Techs, "pulumi")
+	assert.Contains(t, payload.Techs, "gcp")
+	assert.Contains(t, payload.Techs, "kubernetes")
+
+	projects := payload.Properties["iac"].([]interface{})
+	require.Len(t, projects, 1)
+	project := projects[0].(*parsers.IaCProject)
+	assert.Equal(t, "/infra/Pulumi.yaml", project.File)
+	assert.Equal(t, "go", project.Language)
+	assert.Equal(t, "v3.101.1", project.Version)
+	assert.Equal(t, []string{"dev", "prod"}, project.Stacks)
+	assert.Equal(t, []parsers.IaCPackage{
+		{Name: "gcp", Version: "v7.8.0"},
+		{Name: "kubernetes", Version: "v4.7.1"},
+	}, project.Packages)
+	assert.Equal(t, []string{"gcp", "kubernetes"}, project.CloudTargets)
+}
+
+func TestDetect_CDK(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/project/cdk.json":     `{"app": "npx ts-node --prefer-ts-exts bin/app.ts"}`,
+		"/project/package.json": `{"name": "app", "dependencies": {"aws-cdk-lib": "2.120.0", "constructs": "^10.0.0"}, "devDependencies": {"aws-cdk": "2.120.0"}}`,
+	}}
+	files := []types.File{
+		{Name: "cdk.json", Type: "file"},
+		{Name: "package.json", Type: "file"},
+	}
+
+	payloads := (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Contains(t, payload.Techs, "aws.cdk")
+	assert.Contains(t, payload.Techs, "aws")
+
+	project := payload.Properties["iac"].([]interface{})[0].(*parsers.IaCProject)
+	assert.Equal(t, "/cdk.json", project.File)
+	assert.Equal(t, "typescript", project.Language)
+	assert.Equal(t, "2.120.0", project.Version)
+	assert.Equal(t, []parsers.IaCPackage{
+		{Name: "aws-cdk-lib", Version: "2.120.0"},
+		{Name: "constructs", Version: "^10.0.0"},
+	}, project.Packages)
+}
+
+func TestDetect_NoProjectFiles(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{"/project/package.json": `{"name": "app"}`}}
+	files := []types.File{{Name: "package.json", Type: "file"}}
+
+	assert.Empty(t, (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{}))
+}
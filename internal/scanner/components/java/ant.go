@@ -0,0 +1,234 @@
+package java
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// maxJarFiles limits the jar files collected from the classpath directories of one build file
+const maxJarFiles = 5000
+
+// detectAnt looks for Ant build files (build.xml) and Ivy module descriptors (ivy.xml) of legacy
+// builds that predate Maven and Gradle
+func (d *Detector) detectAnt(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
+	antParser := parsers.NewAntParser()
+	var buildFile, ivyFile string
+	var buildContent, ivyContent string
+
+	for _, file := range files {
+		switch file.Name {
+		case "build.xml":
+			content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+			if err == nil && antParser.IsAntBuild(string(content)) {
+				buildFile, buildContent = file.Name, string(content)
+			}
+		case "ivy.xml":
+			content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+			if err == nil {
+				ivyFile, ivyContent = file.Name, string(content)
+			}
+		}
+	}
+	if buildFile == "" && ivyFile == "" {
+		return nil
+	}
+
+	ivyParser := parsers.NewIvyParser()
+	ivyModule := ivyParser.ParseModule(ivyContent)
+	build := antParser.ParseBuildXML(buildContent)
+
+	projectName := d.formatProjectName(ivyModule.Info.Organisation, ivyModule.Info.Module)
+	if projectName == "" {
+		projectName = build.Name
+	}
+	if projectName == "" {
+		projectName = filepath.Base(currentPath)
+	}
+
+	mainFile := buildFile
+	if mainFile == "" {
+		mainFile = ivyFile
+	}
+	payload := types.NewPayloadWithPath(projectName, relativePath(basePath, currentPath, mainFile))
+	payload.AddPrimaryTech("java")
+
+	if buildFile != "" {
+		payload.SetComponentType("ant")
+		payload.AddTech("ant", "matched file: build.xml")
+		antInfo := map[string]interface{}{"project": build.Name}
+		if len(build.Taskdefs) > 0 {
+			antInfo["taskdefs"] = build.Taskdefs
+		}
+		payload.SetComponentProperties("ant", antInfo)
+	} else {
+		payload.SetComponentType("ivy")
+	}
+
+	if ivyFile != "" {
+		if buildFile != "" {
+			payload.AddPath(relativePath(basePath, currentPath, ivyFile))
+		}
+		payload.AddTech("ivy", "matched file: ivy.xml")
+		if ivyModule.Info.Organisation != "" || ivyModule.Info.Module != "" {
+			payload.SetComponentProperties("ivy", map[string]interface{}{
+				"group_id":    ivyModule.Info.Organisation,
+				"artifact_id": ivyModule.Info.Module,
+				"version":     ivyModule.Info.Revision,
+			})
+		}
+	} else if build.Ivy {
+		payload.AddTech("ivy", "matched Ivy tasks in build.xml")
+	}
+
+	// Ivy dependencies use Maven coordinates, so they are matched against the Maven rules
+	ivyDeps := ivyParser.ParseIvyXML(ivyContent)
+	if len(ivyDeps) > 0 {
+		var depNames []string
+		for _, dep := range ivyDeps {
+			depNames = append(depNames, dep.Name)
+		}
+		for tech, reasons := range depDetector.MatchDependencies(depNames, "maven") {
+			for _, reason := range reasons {
+				payload.AddTech(tech, reason)
+			}
+			depDetector.AddPrimaryTechIfNeeded(payload, tech)
+		}
+	}
+	for _, dep := range ivyDeps {
+		payload.AddDependency(dep)
+	}
+
+	// Jars of taskdef classpaths are build tools, unless they are on a regular classpath too
+	jars, taskdefJars := d.collectJars(build, currentPath, basePath, provider)
+	onClasspath := make(map[string]bool)
+	for _, jar := range jars {
+		onClasspath[jar] = true
+	}
+	var toolJars []string
+	for _, jar := range taskdefJars {
+		if !onClasspath[jar] {
+			toolJars = append(toolJars, jar)
+		}
+	}
+	for _, dep := range antParser.JarDependencies(jars, types.ScopeProd) {
+		payload.AddDependency(dep)
+	}
+	for _, dep := range antParser.JarDependencies(toolJars, types.ScopeBuild) {
+		payload.AddDependency(dep)
+	}
+
+	return payload
+}
+
+// collectJars returns the existing jar files of the classpaths and of the taskdef classpaths of an
+// Ant build, relative to the build file directory: referenced jars and the jars of classpath
+// directories matching their include patterns. Paths leaving the scanned tree are ignored.
+func (d *Detector) collectJars(build parsers.AntBuild, currentPath, basePath string, provider types.Provider) ([]string, []string) {
+	var jars, taskdefJars []string
+	seen := make(map[string]bool)
+	add := func(jar string, taskdef bool) {
+		key := fmt.Sprintf("%t:%s", taskdef, jar)
+		if seen[key] || len(jars)+len(taskdefJars) >= maxJarFiles {
+			return
+		}
+		seen[key] = true
+		if taskdef {
+			taskdefJars = append(taskdefJars, jar)
+		} else {
+			jars = append(jars, jar)
+		}
+	}
+	addExisting := func(referenced []string, taskdef bool) {
+		for _, jar := range referenced {
+			if !insideScan(basePath, currentPath, jar) {
+				continue
+			}
+			if exists, err := provider.Exists(filepath.Join(currentPath, filepath.FromSlash(jar))); err == nil && exists {
+				add(jar, taskdef)
+			}
+		}
+	}
+	addExisting(build.Jars, false)
+	addExisting(build.TaskdefJars, true)
+
+	for _, fileset := range build.Filesets {
+		if !insideScan(basePath, currentPath, fileset.Dir) {
+			continue
+		}
+		includes := fileset.Includes
+		if len(includes) == 0 {
+			includes = []string{"**"}
+		}
+		var found []string
+		walkJars(provider, filepath.Join(currentPath, filepath.FromSlash(fileset.Dir)), "", &found)
+		sort.Strings(found)
+		for _, rel := range found {
+			if antIncluded(rel, includes) {
+				add(path.Join(fileset.Dir, rel), fileset.Taskdef)
+			}
+		}
+	}
+
+	return jars, taskdefJars
+}
+
+// walkJars collects the jar files below dir as slash-separated paths relative to dir
+func walkJars(provider types.Provider, dir, rel string, found *[]string) {
+	entries, err := provider.ListDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if len(*found) >= maxJarFiles {
+			return
+		}
+		entryRel := path.Join(rel, entry.Name)
+		if entry.Type == "dir" {
+			if !entry.Symlink && !strings.HasPrefix(entry.Name, ".") {
+				walkJars(provider, filepath.Join(dir, entry.Name), entryRel, found)
+			}
+			continue
+		}
+		if strings.HasSuffix(strings.ToLower(entry.Name), ".jar") {
+			*found = append(*found, entryRel)
+		}
+	}
+}
+
+// antIncluded reports whether a path matches one of the Ant include patterns
+// (a trailing slash matches everything below the directory)
+func antIncluded(rel string, includes []string) bool {
+	for _, include := range includes {
+		include = strings.ReplaceAll(strings.TrimSpace(include), "\\", "/")
+		if strings.HasSuffix(include, "/") {
+			include += "**"
+		}
+		if matched, err := doublestar.Match(include, rel); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// insideScan reports whether a path relative to the current directory stays within the scanned tree
+func insideScan(basePath, currentPath, rel string) bool {
+	target, err := filepath.Rel(basePath, filepath.Join(currentPath, filepath.FromSlash(rel)))
+	return err == nil && target != ".." && !strings.HasPrefix(target, ".."+string(filepath.Separator))
+}
+
+// relativePath returns the path of a file in the current directory relative to the scan root ("/pom.xml")
+func relativePath(basePath, currentPath, fileName string) string {
+	rel, _ := filepath.Rel(basePath, filepath.Join(currentPath, fileName))
+	if rel == "." {
+		return "/"
+	}
+	return "/" + filepath.ToSlash(rel)
+}
@@ -0,0 +1,103 @@
+package java
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetector_Detect_AntIvyProject(t *testing.T) {
+	detector := &Detector{}
+
+	buildXML := `<project name="legacy-app" default="compile" xmlns:ivy="antlib:org.apache.ivy.ant">
+	<property name="lib.dir" value="lib"/>
+	<path id="compile.classpath">
+		<fileset dir="${lib.dir}" includes="*.jar"/>
+		<pathelement location="tools/ant-contrib-1.0b3.jar"/>
+		<pathelement location="dist/legacy-app.jar"/>
+		<pathelement location="../outside/secret-1.0.jar"/>
+	</path>
+	<taskdef resource="net/sf/antcontrib/antlib.xml" classpath="tools/ant-contrib-1.0b3.jar"/>
+	<taskdef name="checkstyle" classname="com.puppycrawl.tools.checkstyle.CheckStyleTask" classpath="tools/checkstyle-8.45-all.jar"/>
+	<target name="resolve">
+		<ivy:retrieve/>
+	</target>
+	<target name="compile" depends="resolve">
+		<javac srcdir="src" destdir="build" classpathref="compile.classpath"/>
+	</target>
+</project>`
+
+	ivyXML := `<ivy-module version="2.0">
+	<info organisation="com.example" module="legacy-app" revision="1.4"/>
+	<dependencies>
+		<dependency org="org.springframework" name="spring-core" rev="5.3.30"/>
+		<dependency org="junit" name="junit" rev="4.13.2" conf="test->default"/>
+	</dependencies>
+</ivy-module>`
+
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/build.xml":                     buildXML,
+			"/project/ivy.xml":                       ivyXML,
+			"/project/lib/commons-lang-2.6.jar":      "",
+			"/project/lib/log4j-1.2.17.jar":          "",
+			"/project/lib/README.txt":                "",
+			"/project/lib/nested/ignored-1.0.jar":    "",
+			"/project/tools/ant-contrib-1.0b3.jar":   "",
+			"/project/tools/checkstyle-8.45-all.jar": "",
+			"/outside/secret-1.0.jar":                "",
+		},
+	}
+	depDetector := &MockDependencyDetector{
+		matchedTechs: map[string][]string{"spring": {"matched dependency: org.springframework:spring-core"}},
+	}
+	files := []types.File{
+		{Name: "build.xml", Path: "/project/build.xml"},
+		{Name: "ivy.xml", Path: "/project/ivy.xml"},
+	}
+
+	results := detector.Detect(files, "/project", "/project", provider, depDetector)
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Equal(t, "com.example:legacy-app", payload.Name)
+	assert.Equal(t, []string{"/build.xml", "/ivy.xml"}, payload.Path)
+	assert.Contains(t, payload.Tech, "java")
+	assert.Contains(t, payload.Techs, "ant")
+	assert.Contains(t, payload.Techs, "ivy")
+	assert.Contains(t, payload.Techs, "spring", "Should match Ivy dependencies against the Maven rules")
+	assert.Equal(t, map[string]interface{}{"group_id": "com.example", "artifact_id": "legacy-app", "version": "1.4"}, payload.Properties["ivy"])
+	assert.Equal(t, []string{"net/sf/antcontrib/antlib.xml", "checkstyle"}, payload.Properties["ant"].(map[string]interface{})["taskdefs"])
+
+	deps := make(map[string]types.Dependency)
+	for _, dep := range payload.Dependencies {
+		deps[dep.Type+":"+dep.Name] = dep
+	}
+	assert.Len(t, deps, 6)
+	assert.Equal(t, "5.3.30", deps["ivy:org.springframework:spring-core"].Version)
+	assert.Equal(t, types.ScopeDev, deps["ivy:junit:junit"].Scope)
+	assert.Equal(t, "2.6", deps["ant:commons-lang"].Version)
+	assert.Equal(t, "lib/log4j-1.2.17.jar", deps["ant:log4j"].Metadata["path"])
+	assert.Equal(t, "1.0b3", deps["ant:ant-contrib"].Version)
+	assert.Equal(t, types.ScopeProd, deps["ant:ant-contrib"].Scope, "Should keep classpath jars in prod scope")
+	assert.Equal(t, types.ScopeBuild, deps["ant:checkstyle"].Scope, "Should put taskdef-only jars in build scope")
+	assert.NotContains(t, deps, "ant:ignored", "Should apply the include patterns")
+	assert.NotContains(t, deps, "ant:legacy-app", "Should skip jars that do not exist")
+	assert.NotContains(t, deps, "ant:secret", "Should skip jars outside the scanned tree")
+}
+
+func TestDetector_Detect_NonAntBuildXML(t *testing.T) {
+	detector := &Detector{}
+
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/build.xml": `<project name="site" default="build"><target name="build"><phingcall target="x"/></target></project>`,
+		},
+	}
+	files := []types.File{{Name: "build.xml", Path: "/project/build.xml"}}
+
+	results := detector.Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	assert.Empty(t, results, "Should not detect Phing build files")
+}
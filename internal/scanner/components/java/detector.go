@@ -31,6 +31,14 @@ func (d *Detector) Detect(files []types.File, currentPath, basePath string, prov
 		d.addGradleInfoToMaven(payload, files, currentPath, basePath, provider, depDetector)
 	}
 
+	// If neither Maven nor Gradle found, check for Ant and Ivy
+	if payload == nil {
+		payload = d.detectAnt(files, currentPath, basePath, provider, depDetector)
+	}
+
+	// OSGi bundle manifests and Eclipse target platforms add to the build, or stand alone (Eclipse PDE)
+	payload = d.detectOSGi(payload, files, currentPath, basePath, provider, depDetector)
+
 	if payload != nil {
 		results = append(results, payload)
 	}
@@ -61,15 +69,20 @@ func (d *Detector) detectMaven(files []types.File, currentPath, basePath string,
 	return payload
 }
 
-// detectGradleOnly looks for Gradle files when no Maven was found
+// detectGradleOnly looks for Gradle files when no Maven was found, including the included
+// builds of a composite build declared in the settings file
 func (d *Detector) detectGradleOnly(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
 	gradleRegex := regexp.MustCompile(`^build\.gradle(\.kts)?$`)
 	for _, file := range files {
 		if gradleRegex.MatchString(file.Name) {
-			return d.detectGradle(file, currentPath, basePath, provider, depDetector)
+			payload := d.detectGradle(file, currentPath, basePath, provider, depDetector)
+			if _, settings, ok := readGradleSettings(files, currentPath, provider); ok && payload != nil {
+				d.addIncludedBuilds(payload, settings, currentPath, basePath, provider)
+			}
+			return payload
 		}
 	}
-	return nil
+	return d.detectGradleComposite(files, currentPath, basePath, provider)
 }
 
 // addGradleInfoToMaven adds Gradle file paths and dependencies to an existing Maven payload
@@ -131,6 +144,7 @@ func (d *Detector) mergeDependencyList(payload *types.Payload, listFile types.Fi
 			// This is a direct dependency from pom.xml - update its version
 			originalMetadata := payload.Dependencies[idx].Metadata
 			payload.Dependencies[idx].Version = listDep.Version
+			payload.Dependencies[idx].NormalizedVersion = listDep.NormalizedVersion
 
 			// Add source marker to indicate dependency list source
 			if originalMetadata == nil {
@@ -149,7 +163,7 @@ func (d *Detector) detectPomXML(file types.File, currentPath, basePath string, p
 	}
 
 	// Extract project name using parser
-	mavenParser := parsers.NewMavenParser()
+	mavenParser := parsers.NewMavenParserWithProfiles(components.MavenProfiles())
 	projectInfo := mavenParser.ExtractProjectInfo(string(content))
 
 	// Handle inheritance from parent
@@ -204,6 +218,8 @@ func (d *Detector) detectPomXML(file types.File, currentPath, basePath string, p
 	}
 
 	dependencies := mavenParser.ParsePomXMLWithProvider(string(content), currentPath, provider)
+	dependencies = append(dependencies, mavenParser.ParsePlugins(string(content), currentPath, provider)...)
+	parsers.LocateDependencies(dependencies, relativeFilePath, parsers.MavenDeclarationLines(content))
 
 	// Extract dependency names for tech matching
 	var depNames []string
@@ -273,6 +289,7 @@ func (d *Detector) detectGradle(file types.File, currentPath, basePath string, p
 	}
 
 	dependencies := gradleParser.ParseGradle(string(content))
+	dependencies = append(dependencies, gradleParser.ParseGradlePlugins(string(content))...)
 
 	// Extract dependency names for tech matching
 	var depNames []string
@@ -324,4 +341,16 @@ func init() {
 		DependencyType:      "gradle",
 		ExtractPackageNames: providers.GroupArtifactExtractor("gradle"),
 	})
+
+	// Register ivy package provider (organisation and module of ivy.xml)
+	providers.Register(&providers.PackageProvider{
+		DependencyType:      "ivy",
+		ExtractPackageNames: providers.GroupArtifactExtractor("ivy"),
+	})
+
+	// Register osgi package provider (Require-Bundle references the Bundle-SymbolicName)
+	providers.Register(&providers.PackageProvider{
+		DependencyType:      "osgi",
+		ExtractPackageNames: providers.SinglePropertyExtractor("osgi", "symbolic_name"),
+	})
 }
@@ -2,6 +2,8 @@ package java
 
 import (
 	"os"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
@@ -22,8 +24,28 @@ func (m *MockProvider) ReadFile(path string) ([]byte, error) {
 }
 
 func (m *MockProvider) ListDir(path string) ([]types.File, error) {
-	// Simplified mock - not used in current tests
-	return nil, nil
+	// Entries are derived from the file paths below the directory
+	prefix := strings.TrimSuffix(path, "/") + "/"
+	seen := make(map[string]bool)
+	var entries []types.File
+	for file := range m.files {
+		rest, ok := strings.CutPrefix(file, prefix)
+		if !ok {
+			continue
+		}
+		name, _, isDir := strings.Cut(rest, "/")
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		entryType := "file"
+		if isDir {
+			entryType = "dir"
+		}
+		entries = append(entries, types.File{Name: name, Path: prefix + name, Type: entryType})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries, nil
 }
 
 func (m *MockProvider) Open(path string) (string, error) {
@@ -172,6 +194,10 @@ dependencies {
 	assert.Contains(t, payload.Techs, "gradle", "Should detect gradle")
 	assert.Contains(t, payload.Techs, "spring", "Should detect spring from dependencies")
 	assert.NotEmpty(t, payload.Dependencies, "Should have parsed dependencies")
+	assert.Contains(t, payload.Dependencies, types.Dependency{
+		Type: "gradle", Name: "org.springframework.boot", Version: "2.7.0", Scope: types.ScopeBuild, Direct: true,
+		Metadata: map[string]interface{}{"source": "build.gradle", "plugin": true},
+	}, "Should add the Spring Boot plugin as build dependency")
 	// Verify Gradle properties
 	assert.Contains(t, payload.Properties, "gradle", "Should have gradle properties")
 	gradleProps := payload.Properties["gradle"].(map[string]interface{})
@@ -0,0 +1,121 @@
+package java
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+var gradleSettingsRegex = regexp.MustCompile(`^settings\.gradle(\.kts)?$`)
+
+// readGradleSettings parses the settings file of a directory; ok is false if there is none
+func readGradleSettings(files []types.File, currentPath string, provider types.Provider) (string, parsers.GradleSettings, bool) {
+	for _, file := range files {
+		if !gradleSettingsRegex.MatchString(file.Name) {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			return "", parsers.GradleSettings{}, false
+		}
+		return file.Name, parsers.NewGradleParser().ParseGradleSettings(string(content)), true
+	}
+	return "", parsers.GradleSettings{}, false
+}
+
+// detectGradleComposite creates a component for the root of a composite build that only has a
+// settings file, so its included builds are recorded
+func (d *Detector) detectGradleComposite(files []types.File, currentPath, basePath string, provider types.Provider) *types.Payload {
+	settingsFile, settings, ok := readGradleSettings(files, currentPath, provider)
+	if !ok || len(settings.IncludedBuilds) == 0 {
+		return nil
+	}
+
+	projectName := settings.RootProjectName
+	if projectName == "" {
+		projectName = filepath.Base(currentPath)
+	}
+	payload := types.NewPayloadWithPath(projectName, relativePath(basePath, currentPath, settingsFile))
+	payload.SetComponentType("gradle")
+	payload.AddPrimaryTech("java")
+	payload.AddTech("gradle", "matched file: "+settingsFile)
+	payload.SetComponentProperties("gradle", map[string]interface{}{
+		"artifact_id": projectName,
+	})
+
+	d.addIncludedBuilds(payload, settings, currentPath, basePath, provider)
+	return payload
+}
+
+// addIncludedBuilds records the included builds of a composite build in the gradle properties:
+// their path and the modules (group:artifact) they substitute, mapped to the path of the
+// providing project. Included builds outside the scanned tree are skipped.
+func (d *Detector) addIncludedBuilds(payload *types.Payload, settings parsers.GradleSettings, currentPath, basePath string, provider types.Provider) {
+	var includedBuilds []map[string]interface{}
+	for _, build := range settings.IncludedBuilds {
+		if !insideScan(basePath, currentPath, build.Dir) {
+			continue
+		}
+		buildPath := relativePath(basePath, currentPath, filepath.FromSlash(build.Dir))
+		includedBuilds = append(includedBuilds, map[string]interface{}{
+			"path":    buildPath,
+			"modules": includedBuildModules(build, buildPath, filepath.Join(currentPath, filepath.FromSlash(build.Dir)), provider),
+		})
+	}
+	if len(includedBuilds) == 0 {
+		return
+	}
+	payload.SetComponentProperty("gradle", "included_builds", includedBuilds)
+}
+
+// includedBuildModules returns the modules an included build substitutes, mapped to the path of
+// the providing project. Like Gradle, the root project and the subprojects provide group:name,
+// with the group of the root build file and the name from the settings or the directory;
+// explicit dependencySubstitution rules are added.
+func includedBuildModules(build parsers.GradleIncludedBuild, buildPath, buildDir string, provider types.Provider) map[string]string {
+	gradleParser := parsers.NewGradleParser()
+	modules := make(map[string]string)
+
+	var settings parsers.GradleSettings
+	for _, name := range []string{"settings.gradle", "settings.gradle.kts"} {
+		if content, err := provider.ReadFile(filepath.Join(buildDir, name)); err == nil {
+			settings = gradleParser.ParseGradleSettings(string(content))
+			break
+		}
+	}
+	var group string
+	for _, name := range []string{"build.gradle", "build.gradle.kts"} {
+		if content, err := provider.ReadFile(filepath.Join(buildDir, name)); err == nil {
+			group = gradleParser.ParseProjectInfo(string(content)).Group
+			break
+		}
+	}
+
+	if group != "" {
+		rootName := settings.RootProjectName
+		if rootName == "" {
+			rootName = path.Base(build.Dir)
+		}
+		modules[group+":"+rootName] = buildPath
+		for _, project := range settings.Includes {
+			segments := strings.Split(project, ":")
+			modules[group+":"+segments[len(segments)-1]] = projectPath(buildPath, project)
+		}
+	}
+	for _, substitution := range build.Substitutions {
+		modules[substitution.Module] = projectPath(buildPath, substitution.Project)
+	}
+	return modules
+}
+
+// projectPath returns the default directory of a Gradle project path ("services:api") below the build path
+func projectPath(buildPath, project string) string {
+	if project == "" {
+		return buildPath
+	}
+	return path.Join(buildPath, strings.ReplaceAll(project, ":", "/"))
+}
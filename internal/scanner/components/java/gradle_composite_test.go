@@ -0,0 +1,72 @@
+package java
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetector_Detect_GradleCompositeBuild(t *testing.T) {
+	detector := &Detector{}
+
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/settings.gradle.kts": `rootProject.name = "platform"
+includeBuild("app")
+includeBuild("libs/core")
+includeBuild("libs/legacy") {
+	dependencySubstitution {
+		substitute(module("org.legacy:legacy-api")).using(project(":api"))
+	}
+}
+includeBuild("../outside")`,
+			"/project/libs/core/settings.gradle.kts": `rootProject.name = "core"
+include(":model")`,
+			"/project/libs/core/build.gradle.kts": `allprojects {
+	group = "com.example"
+}`,
+			"/project/libs/legacy/settings.gradle": `include 'api'`,
+		},
+	}
+	files := []types.File{
+		{Name: "settings.gradle.kts", Path: "/project/settings.gradle.kts"},
+	}
+
+	results := detector.Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Equal(t, "platform", payload.Name)
+	assert.Equal(t, []string{"/settings.gradle.kts"}, payload.Path)
+	assert.Equal(t, "gradle", payload.ComponentType)
+	assert.Contains(t, payload.Techs, "gradle")
+
+	gradleProps := payload.Properties["gradle"].(map[string]interface{})
+	assert.Equal(t, "platform", gradleProps["artifact_id"])
+	assert.Equal(t, []map[string]interface{}{
+		{"path": "/app", "modules": map[string]string{}},
+		{"path": "/libs/core", "modules": map[string]string{
+			"com.example:core":  "/libs/core",
+			"com.example:model": "/libs/core/model",
+		}},
+		{"path": "/libs/legacy", "modules": map[string]string{
+			"org.legacy:legacy-api": "/libs/legacy/api",
+		}},
+	}, gradleProps["included_builds"], "Should skip included builds outside the scanned tree")
+}
+
+func TestDetector_Detect_GradleSettingsWithoutIncludedBuilds(t *testing.T) {
+	detector := &Detector{}
+
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/settings.gradle": "rootProject.name = 'multi'\ninclude 'core'",
+		},
+	}
+	files := []types.File{{Name: "settings.gradle", Path: "/project/settings.gradle"}}
+
+	results := detector.Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	assert.Empty(t, results, "Should only create a component for the root of a composite build")
+}
@@ -0,0 +1,121 @@
+package java
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// osgiManifestPath is the location of the bundle manifest relative to the project directory
+var osgiManifestPath = filepath.Join("META-INF", "MANIFEST.MF")
+
+// detectOSGi adds the bundle dependencies of an OSGi manifest (META-INF/MANIFEST.MF) and the
+// contents of Eclipse target platforms (.target) to the build component of the directory (Tycho,
+// bnd). Without a build component, bundles (Eclipse PDE projects) and target platform
+// definitions become components of their own. Returns the resulting payload, or the given one.
+func (d *Detector) detectOSGi(payload *types.Payload, files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
+	osgiParser := parsers.NewOSGiParser()
+
+	var manifest parsers.OSGiManifest
+	var isBundle bool
+	var targetFiles []string
+	var targets []parsers.EclipseTarget
+	for _, file := range files {
+		switch {
+		case file.Type == "dir" && file.Name == "META-INF":
+			if content, err := provider.ReadFile(filepath.Join(currentPath, osgiManifestPath)); err == nil {
+				manifest, isBundle = osgiParser.ParseManifest(string(content))
+			}
+		case file.Type != "dir" && strings.HasSuffix(file.Name, ".target"):
+			content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+			if err != nil {
+				continue
+			}
+			if target := osgiParser.ParseTarget(string(content)); target.XMLName.Local != "" {
+				targetFiles = append(targetFiles, file.Name)
+				targets = append(targets, target)
+			}
+		}
+	}
+	if !isBundle && len(targets) == 0 {
+		return payload
+	}
+
+	var osgiFiles []string
+	if isBundle {
+		osgiFiles = append(osgiFiles, osgiManifestPath)
+	}
+	osgiFiles = append(osgiFiles, targetFiles...)
+
+	if payload == nil {
+		name := manifest.SymbolicName
+		if name == "" {
+			name = targets[0].Name
+		}
+		if name == "" {
+			name = filepath.Base(currentPath)
+		}
+		payload = types.NewPayloadWithPath(name, relativePath(basePath, currentPath, osgiFiles[0]))
+		if isBundle {
+			payload.SetComponentType("osgi")
+		} else {
+			payload.SetComponentType("eclipse-target")
+		}
+		payload.AddPrimaryTech("java")
+		osgiFiles = osgiFiles[1:]
+	}
+	for _, file := range osgiFiles {
+		payload.AddPath(relativePath(basePath, currentPath, file))
+	}
+
+	var dependencies []types.Dependency
+	if isBundle {
+		payload.AddTech("osgi", "matched file: "+filepath.ToSlash(osgiManifestPath))
+		bundleInfo := map[string]interface{}{
+			"symbolic_name": manifest.SymbolicName,
+			"version":       manifest.Version,
+		}
+		if manifest.Name != "" {
+			bundleInfo["name"] = manifest.Name
+		}
+		if manifest.Vendor != "" {
+			bundleInfo["vendor"] = manifest.Vendor
+		}
+		if manifest.FragmentHost != "" {
+			bundleInfo["fragment_host"] = manifest.FragmentHost
+		}
+		if len(manifest.ExecutionEnvs) > 0 {
+			bundleInfo["execution_environments"] = manifest.ExecutionEnvs
+		}
+		payload.SetComponentProperties("osgi", bundleInfo)
+		dependencies = append(dependencies, osgiParser.ManifestDependencies(manifest)...)
+	}
+	for i, target := range targets {
+		payload.AddTech("osgi", "matched file: "+targetFiles[i])
+		dependencies = append(dependencies, osgiParser.TargetDependencies(target)...)
+	}
+
+	// Bundles and installable units share the bundle symbolic names, so both use the OSGi rules
+	namesByType := make(map[string][]string)
+	for _, dep := range dependencies {
+		ruleType := dep.Type
+		if ruleType == parsers.DependencyTypeP2 {
+			ruleType = parsers.DependencyTypeOSGi
+		}
+		namesByType[ruleType] = append(namesByType[ruleType], dep.Name)
+		payload.AddDependency(dep)
+	}
+	for ruleType, names := range namesByType {
+		for tech, reasons := range depDetector.MatchDependencies(names, ruleType) {
+			for _, reason := range reasons {
+				payload.AddTech(tech, reason)
+			}
+			depDetector.AddPrimaryTechIfNeeded(payload, tech)
+		}
+	}
+
+	return payload
+}
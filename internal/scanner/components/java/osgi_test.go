@@ -0,0 +1,103 @@
+package java
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBundleManifest = `Manifest-Version: 1.0
+Bundle-ManifestVersion: 2
+Bundle-SymbolicName: com.example.ui;singleton:=true
+Bundle-Version: 1.0.0.qualifier
+Require-Bundle: org.eclipse.ui,
+ org.eclipse.core.runtime
+Import-Package: javax.inject;version="1.0.0"
+`
+
+func TestDetector_Detect_EclipsePluginProject(t *testing.T) {
+	detector := &Detector{}
+
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/bundles/com.example.ui/META-INF/MANIFEST.MF": testBundleManifest,
+		},
+	}
+	depDetector := &MockDependencyDetector{
+		matchedTechs: map[string][]string{"eclipse-rcp": {"eclipse-rcp matched: org.eclipse.ui"}},
+	}
+	files := []types.File{
+		{Name: "META-INF", Path: "/project/bundles/com.example.ui/META-INF", Type: "dir"},
+		{Name: "plugin.xml", Path: "/project/bundles/com.example.ui/plugin.xml", Type: "file"},
+	}
+
+	results := detector.Detect(files, "/project/bundles/com.example.ui", "/project", provider, depDetector)
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Equal(t, "com.example.ui", payload.Name)
+	assert.Equal(t, "osgi", payload.ComponentType)
+	assert.Equal(t, []string{"/bundles/com.example.ui/META-INF/MANIFEST.MF"}, payload.Path)
+	assert.Contains(t, payload.Tech, "java")
+	assert.Contains(t, payload.Techs, "osgi")
+	assert.Contains(t, payload.Techs, "eclipse-rcp")
+	assert.Equal(t, map[string]interface{}{"symbolic_name": "com.example.ui", "version": "1.0.0.qualifier"}, payload.Properties["osgi"])
+
+	require.Len(t, payload.Dependencies, 3)
+	assert.Equal(t, "org.eclipse.ui", payload.Dependencies[0].Name)
+	assert.Equal(t, "osgi", payload.Dependencies[0].Type)
+	assert.Equal(t, "javax.inject", payload.Dependencies[2].Name)
+}
+
+func TestDetector_Detect_TychoBundleAndTargetPlatform(t *testing.T) {
+	detector := &Detector{}
+
+	pomXML := `<project>
+	<groupId>com.example</groupId>
+	<artifactId>com.example.ui</artifactId>
+	<version>1.0.0-SNAPSHOT</version>
+	<packaging>eclipse-plugin</packaging>
+</project>`
+	targetXML := `<target name="platform">
+	<locations>
+		<location type="InstallableUnit">
+			<repository location="https://download.eclipse.org/releases/2023-12"/>
+			<unit id="org.eclipse.e4.rcp.feature.group" version="0.0.0"/>
+		</location>
+	</locations>
+</target>`
+
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/pom.xml":              pomXML,
+			"/project/META-INF/MANIFEST.MF": testBundleManifest,
+			"/project/platform.target":      targetXML,
+			"/project/other.target":         "<project/>",
+		},
+	}
+	files := []types.File{
+		{Name: "pom.xml", Path: "/project/pom.xml", Type: "file"},
+		{Name: "META-INF", Path: "/project/META-INF", Type: "dir"},
+		{Name: "platform.target", Path: "/project/platform.target", Type: "file"},
+		{Name: "other.target", Path: "/project/other.target", Type: "file"},
+	}
+
+	results := detector.Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Equal(t, "maven", payload.ComponentType, "Should add the bundle to the Tycho build")
+	assert.Equal(t, []string{"/pom.xml", "/META-INF/MANIFEST.MF", "/platform.target"}, payload.Path)
+	assert.Contains(t, payload.Techs, "maven")
+	assert.Contains(t, payload.Techs, "osgi")
+	assert.Contains(t, payload.Properties, "osgi")
+
+	deps := make(map[string]types.Dependency)
+	for _, dep := range payload.Dependencies {
+		deps[dep.Type+":"+dep.Name] = dep
+	}
+	assert.Contains(t, deps, "osgi:org.eclipse.ui")
+	assert.Equal(t, "latest", deps["p2:org.eclipse.e4.rcp.feature.group"].Version)
+}
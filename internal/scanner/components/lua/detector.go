@@ -0,0 +1,145 @@
+// Package lua implements detection of LuaRocks packages (.rockspec, luarocks.lock) and their
+// dependencies.
+package lua
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/providers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Detector implements LuaRocks package detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "lua"
+}
+
+// Detect scans for rockspecs. Projects often keep a rockspec per released version next to each
+// other; one component is created per package, using the latest release rockspec (development
+// "scm" and "dev" rockspecs only when there is no release). Pinned versions (luarocks.lock)
+// take precedence over the constraints of the rockspec.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	var rockspecs []string
+	hasLock := false
+	for _, file := range files {
+		switch {
+		case strings.HasSuffix(file.Name, ".rockspec"):
+			rockspecs = append(rockspecs, file.Name)
+		case file.Name == "luarocks.lock":
+			hasLock = true
+		}
+	}
+	if len(rockspecs) == 0 {
+		return nil
+	}
+	sort.Strings(rockspecs)
+
+	parser := parsers.NewLuaParser()
+	type candidate struct {
+		file string
+		spec parsers.Rockspec
+	}
+	selected := make(map[string]candidate)
+	var packages []string
+	for _, fileName := range rockspecs {
+		content, err := provider.ReadFile(filepath.Join(currentPath, fileName))
+		if err != nil {
+			continue
+		}
+		spec, err := parser.ParseRockspec(string(content))
+		if err != nil {
+			continue
+		}
+		if spec.Package == "" {
+			spec.Package = strings.TrimSuffix(fileName, ".rockspec")
+		}
+		current, seen := selected[spec.Package]
+		if !seen {
+			packages = append(packages, spec.Package)
+		}
+		if !seen || isDevRockspec(current.spec) || !isDevRockspec(spec) {
+			selected[spec.Package] = candidate{file: fileName, spec: spec}
+		}
+	}
+
+	var lockContent string
+	if hasLock && components.UseLockFiles() {
+		if content, err := provider.ReadFile(filepath.Join(currentPath, "luarocks.lock")); err == nil {
+			lockContent = string(content)
+		}
+	}
+
+	var results []*types.Payload
+	for _, name := range packages {
+		rockspec := selected[name]
+		spec := rockspec.spec
+
+		payload := types.NewPayloadWithPath(spec.Package, types.CalculateRelativePath(rockspec.file, currentPath, basePath))
+		payload.SetComponentType("luarocks")
+		payload.AddPrimaryTech("lua")
+		payload.AddTech("luarocks", "matched file: "+rockspec.file)
+
+		luaInfo := map[string]interface{}{"package": spec.Package}
+		for key, value := range map[string]string{
+			"version":     spec.Version,
+			"license":     spec.License,
+			"lua_version": spec.LuaVersion,
+			"build_type":  spec.BuildType,
+		} {
+			if value != "" {
+				luaInfo[key] = value
+			}
+		}
+		payload.SetComponentProperties("lua", luaInfo)
+
+		var dependencies []types.Dependency
+		if lockContent != "" {
+			dependencies = parser.ParseLuarocksLock(lockContent, spec)
+			if len(dependencies) > 0 {
+				payload.AddPath(types.CalculateRelativePath("luarocks.lock", currentPath, basePath))
+			}
+		}
+		if len(dependencies) == 0 {
+			dependencies = parser.RockspecDependencies(spec)
+		}
+
+		var names []string
+		for _, dep := range dependencies {
+			payload.AddDependency(dep)
+			names = append(names, dep.Name)
+		}
+		if len(names) > 0 {
+			for tech, reasons := range depDetector.MatchDependencies(names, parsers.DependencyTypeLuarocks) {
+				for _, reason := range reasons {
+					payload.AddTech(tech, reason)
+				}
+				depDetector.AddPrimaryTechIfNeeded(payload, tech)
+			}
+		}
+		results = append(results, payload)
+	}
+	return results
+}
+
+// isDevRockspec reports whether a rockspec builds the development version (scm-1, dev-1)
+func isDevRockspec(spec parsers.Rockspec) bool {
+	version := strings.ToLower(spec.Version)
+	return strings.HasPrefix(version, "scm") || strings.HasPrefix(version, "dev")
+}
+
+func init() {
+	components.Register(&Detector{})
+
+	// Register luarocks package provider (rocks depend on other rocks by name)
+	providers.Register(&providers.PackageProvider{
+		DependencyType:      "luarocks",
+		ExtractPackageNames: providers.SinglePropertyExtractor("lua", "package"),
+	})
+}
@@ -0,0 +1,139 @@
+package lua
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+// ListDir derives the directory entries from the file paths
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	seen := make(map[string]bool)
+	var entries []types.File
+	for filePath := range m.files {
+		rel, err := filepath.Rel(path, filePath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		name, rest, isDir := strings.Cut(rel, string(filepath.Separator))
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		entry := types.File{Name: name, Path: filepath.Join(path, name), Type: "file"}
+		if isDir && rest != "" {
+			entry.Type = "dir"
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return nil, os.ErrNotExist
+	}
+	return entries, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "lua", (&Detector{}).Name())
+}
+
+func TestDetect_RockspecsWithLock(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/kong-plugin-guard-1.0.0-1.rockspec": "package = \"kong-plugin-guard\"\nversion = \"1.0.0-1\"\ndependencies = { \"lua >= 5.1\", \"lua-resty-http ~> 0.16\" }\n",
+		"/repo/kong-plugin-guard-1.1.0-1.rockspec": "package = \"kong-plugin-guard\"\nversion = \"1.1.0-1\"\ndescription = { license = \"MIT\" }\ndependencies = { \"lua >= 5.1\", \"lua-resty-http ~> 0.17\" }\nbuild = { type = \"builtin\" }\n",
+		"/repo/kong-plugin-guard-scm-1.rockspec":   "package = \"kong-plugin-guard\"\nversion = \"scm-1\"\ndependencies = { \"lua-resty-http\" }\n",
+		"/repo/luarocks.lock":                      "return {\n   dependencies = {\n      [\"lua-resty-http\"] = \"0.17.1-0\",\n      lua = \"5.1-1\",\n   },\n}\n",
+	}}
+	files := []types.File{
+		{Name: "kong-plugin-guard-scm-1.rockspec", Type: "file"},
+		{Name: "kong-plugin-guard-1.1.0-1.rockspec", Type: "file"},
+		{Name: "kong-plugin-guard-1.0.0-1.rockspec", Type: "file"},
+		{Name: "luarocks.lock", Type: "file"},
+	}
+
+	payloads := (&Detector{}).Detect(files, "/repo", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Equal(t, "kong-plugin-guard", payload.Name)
+	assert.Equal(t, "luarocks", payload.ComponentType)
+	assert.Equal(t, []string{"lua"}, payload.Tech)
+	assert.Equal(t, []string{"/kong-plugin-guard-1.1.0-1.rockspec", "/luarocks.lock"}, payload.Path)
+	assert.Equal(t, map[string]interface{}{
+		"package":     "kong-plugin-guard",
+		"version":     "1.1.0-1",
+		"license":     "MIT",
+		"lua_version": ">= 5.1",
+		"build_type":  "builtin",
+	}, payload.Properties["lua"])
+
+	require.Len(t, payload.Dependencies, 1)
+	assert.Equal(t, "lua-resty-http", payload.Dependencies[0].Name)
+	assert.Equal(t, "0.17.1-0", payload.Dependencies[0].Version)
+	assert.True(t, payload.Dependencies[0].Direct)
+	assert.Equal(t, "luarocks.lock", payload.Dependencies[0].Metadata["source"])
+}
+
+func TestDetect_DevRockspecOnly(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/rockspecs/mylib-dev-1.rockspec": "package = \"mylib\"\nversion = \"dev-1\"\ntest_dependencies = { \"busted\" }\n",
+	}}
+
+	payloads := (&Detector{}).Detect([]types.File{{Name: "mylib-dev-1.rockspec", Type: "file"}}, "/repo/rockspecs", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	assert.Equal(t, "mylib", payloads[0].Name)
+	require.Len(t, payloads[0].Dependencies, 1)
+	assert.Equal(t, "busted", payloads[0].Dependencies[0].Name)
+	assert.Equal(t, types.ScopeTest, payloads[0].Dependencies[0].Scope)
+	assert.Equal(t, ".rockspec", payloads[0].Dependencies[0].Metadata["source"])
+}
+
+func TestDetect_NoRockspec(t *testing.T) {
+	payloads := (&Detector{}).Detect([]types.File{{Name: "init.lua", Type: "file"}}, "/repo", "/repo", &MockProvider{}, &MockDependencyDetector{})
+	assert.Empty(t, payloads)
+}
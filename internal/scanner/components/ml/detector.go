@@ -0,0 +1,79 @@
+// Package ml implements detection of machine-learning artifacts: model files
+// (ONNX, PyTorch, safetensors, GGUF) and Jupyter notebooks.
+package ml
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// formatTechs maps model formats to the technology implied by the artifact
+var formatTechs = map[string]string{
+	"onnx":    "onnx",
+	"pytorch": "pytorch",
+}
+
+// Detector implements machine-learning artifact detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "ml"
+}
+
+// Detect records model files and notebooks of the current directory in the "ml_models"
+// and "ml_notebooks" properties of a virtual component (merged into parent).
+// ONNX and PyTorch model files and notebooks also add their technology (onnx, pytorch, jupyter).
+// Framework dependencies are detected by rules; the post-scan analysis summarizes both.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	var models, notebooks []interface{}
+	techs := make(map[string]string) // tech -> file name
+	var firstFile string
+	for _, file := range files {
+		if file.Type == "dir" {
+			continue
+		}
+		relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, file.Name))
+		relativeFilePath = "/" + filepath.ToSlash(relativeFilePath)
+
+		if format := parsers.MLModelFormat(file.Name); format != "" {
+			models = append(models, parsers.MLModel{File: relativeFilePath, Format: format, Size: file.Size})
+			if tech, ok := formatTechs[format]; ok && techs[tech] == "" {
+				techs[tech] = file.Name
+			}
+		} else if strings.EqualFold(filepath.Ext(file.Name), parsers.NotebookExtension) {
+			notebooks = append(notebooks, relativeFilePath)
+			if techs["jupyter"] == "" {
+				techs["jupyter"] = file.Name
+			}
+		} else {
+			continue
+		}
+		if firstFile == "" {
+			firstFile = relativeFilePath
+		}
+	}
+	if firstFile == "" {
+		return nil
+	}
+
+	payload := types.NewPayloadWithPath("virtual", firstFile)
+	if len(models) > 0 {
+		payload.Properties["ml_models"] = models
+	}
+	if len(notebooks) > 0 {
+		payload.Properties["ml_notebooks"] = notebooks
+	}
+	for tech, fileName := range techs {
+		payload.AddTech(tech, "matched file: "+fileName)
+	}
+	return []*types.Payload{payload}
+}
+
+func init() {
+	components.Register(&Detector{})
+}
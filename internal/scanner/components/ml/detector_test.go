@@ -0,0 +1,52 @@
+package ml
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "ml", (&Detector{}).Name())
+}
+
+func TestDetector_Detect(t *testing.T) {
+	files := []types.File{
+		{Name: "model.onnx", Type: "file", Size: 1024},
+		{Name: "llama.Q4_K_M.gguf", Type: "file", Size: 4096},
+		{Name: "train.ipynb", Type: "file", Size: 10},
+		{Name: "train.py", Type: "file", Size: 10},
+		{Name: "checkpoints.pt", Type: "dir"},
+	}
+
+	results := (&Detector{}).Detect(files, "/repo/models", "/repo", nil, nil)
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Equal(t, "virtual", payload.Name)
+	assert.Equal(t, []string{"/models/model.onnx"}, payload.Path)
+	assert.Equal(t, []interface{}{
+		parsers.MLModel{File: "/models/model.onnx", Format: "onnx", Size: 1024},
+		parsers.MLModel{File: "/models/llama.Q4_K_M.gguf", Format: "gguf", Size: 4096},
+	}, payload.Properties["ml_models"])
+	assert.Equal(t, []interface{}{"/models/train.ipynb"}, payload.Properties["ml_notebooks"])
+	assert.ElementsMatch(t, []string{"onnx", "jupyter"}, payload.Techs)
+	assert.Equal(t, []string{"matched file: model.onnx"}, payload.Reason["onnx"])
+}
+
+func TestDetector_Detect_NotebooksOnly(t *testing.T) {
+	files := []types.File{{Name: "EDA.IPYNB", Type: "file"}}
+
+	results := (&Detector{}).Detect(files, "/repo", "/repo", nil, nil)
+	require.Len(t, results, 1)
+	assert.Equal(t, []interface{}{"/EDA.IPYNB"}, results[0].Properties["ml_notebooks"])
+	assert.NotContains(t, results[0].Properties, "ml_models")
+}
+
+func TestDetector_Detect_NoArtifacts(t *testing.T) {
+	files := []types.File{{Name: "main.py", Type: "file"}, {Name: "requirements.txt", Type: "file"}}
+	assert.Empty(t, (&Detector{}).Detect(files, "/repo", "/repo", nil, nil))
+}
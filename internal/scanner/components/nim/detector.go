@@ -0,0 +1,85 @@
+// Package nim implements detection of Nim packages (.nimble files) and their Nimble
+// dependencies.
+package nim
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/providers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Detector implements Nimble package detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "nim"
+}
+
+// Detect scans for <name>.nimble package files. The package name, version, license, binaries,
+// and required Nim version are stored in the "nim" properties.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	var results []*types.Payload
+	parser := parsers.NewNimParser()
+
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name, ".nimble") || file.Name == ".nimble" {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			continue
+		}
+		pkg := parser.ParseNimble(string(content), file.Name)
+
+		payload := types.NewPayloadWithPath(pkg.Name, types.CalculateRelativePath(file.Name, currentPath, basePath))
+		payload.SetComponentType("nimble")
+		payload.AddPrimaryTech("nim")
+		payload.AddTech("nimble", "matched file: "+file.Name)
+
+		nimInfo := map[string]interface{}{"name": pkg.Name}
+		if pkg.Version != "" {
+			nimInfo["version"] = pkg.Version
+		}
+		if pkg.License != "" {
+			nimInfo["license"] = pkg.License
+		}
+		if pkg.NimVersion != "" {
+			nimInfo["nim_version"] = pkg.NimVersion
+		}
+		if len(pkg.Bin) > 0 {
+			nimInfo["bin"] = pkg.Bin
+		}
+		payload.SetComponentProperties("nim", nimInfo)
+
+		var names []string
+		for _, dep := range parser.Dependencies(pkg) {
+			payload.AddDependency(dep)
+			names = append(names, dep.Name)
+		}
+		if len(names) > 0 {
+			for tech, reasons := range depDetector.MatchDependencies(names, parsers.DependencyTypeNimble) {
+				for _, reason := range reasons {
+					payload.AddTech(tech, reason)
+				}
+				depDetector.AddPrimaryTechIfNeeded(payload, tech)
+			}
+		}
+		results = append(results, payload)
+	}
+	return results
+}
+
+func init() {
+	components.Register(&Detector{})
+
+	// Register nimble package provider (packages depend on other packages by name)
+	providers.Register(&providers.PackageProvider{
+		DependencyType:      "nimble",
+		ExtractPackageNames: providers.SinglePropertyExtractor("nim", "name"),
+	})
+}
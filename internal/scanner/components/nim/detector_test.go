@@ -0,0 +1,116 @@
+package nim
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+// ListDir derives the directory entries from the file paths
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	seen := make(map[string]bool)
+	var entries []types.File
+	for filePath := range m.files {
+		rel, err := filepath.Rel(path, filePath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		name, rest, isDir := strings.Cut(rel, string(filepath.Separator))
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		entry := types.File{Name: name, Path: filepath.Join(path, name), Type: "file"}
+		if isDir && rest != "" {
+			entry.Type = "dir"
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return nil, os.ErrNotExist
+	}
+	return entries, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "nim", (&Detector{}).Name())
+}
+
+func TestDetect_NimblePackage(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/webapp.nimble": "version = \"0.2.1\"\nlicense = \"MIT\"\nbin = @[\"webapp\"]\n\nrequires \"nim >= 2.0.0\", \"jester >= 0.6.0\"\ntaskRequires \"test\", \"unittest2\"\n",
+	}}
+	files := []types.File{{Name: "webapp.nimble", Type: "file"}, {Name: "README.md", Type: "file"}}
+
+	payloads := (&Detector{}).Detect(files, "/repo", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Equal(t, "webapp", payload.Name)
+	assert.Equal(t, "nimble", payload.ComponentType)
+	assert.Equal(t, []string{"nim"}, payload.Tech)
+	assert.Equal(t, []string{"/webapp.nimble"}, payload.Path)
+	assert.Equal(t, map[string]interface{}{
+		"name":        "webapp",
+		"version":     "0.2.1",
+		"license":     "MIT",
+		"nim_version": ">= 2.0.0",
+		"bin":         []string{"webapp"},
+	}, payload.Properties["nim"])
+
+	require.Len(t, payload.Dependencies, 2)
+	assert.Equal(t, "jester", payload.Dependencies[0].Name)
+	assert.Equal(t, ">= 0.6.0", payload.Dependencies[0].Version)
+	assert.Equal(t, types.ScopeTest, payload.Dependencies[1].Scope)
+}
+
+func TestDetect_NoNimble(t *testing.T) {
+	payloads := (&Detector{}).Detect([]types.File{{Name: "main.nim", Type: "file"}}, "/repo", "/repo", &MockProvider{}, &MockDependencyDetector{})
+	assert.Empty(t, payloads)
+}
@@ -0,0 +1,84 @@
+package nodejs
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// processBuildTargets extracts frontend build targets as component properties:
+// browserslist queries (package.json "browserslist" field or .browserslistrc) and
+// tsconfig.json compiler target/module settings.
+func (d *Detector) processBuildTargets(content []byte, currentPath, basePath string, provider types.Provider, payload *types.Payload) {
+	parser := parsers.NewFrontendConfigParser()
+
+	if browserslist := d.readBrowserslist(content, currentPath, provider, parser); browserslist != nil {
+		payload.Properties["browserslist"] = browserslist
+	}
+
+	if tsconfig := d.readTSConfig(currentPath, basePath, provider, parser); tsconfig != nil {
+		payload.SetComponentProperties("typescript", tsconfig.ToProperties())
+	}
+}
+
+// readBrowserslist reads browserslist targets, preferring the package.json field over .browserslistrc
+func (d *Detector) readBrowserslist(content []byte, currentPath string, provider types.Provider, parser *parsers.FrontendConfigParser) *parsers.BrowserslistConfig {
+	var packageJSON struct {
+		Browserslist json.RawMessage `json:"browserslist"`
+	}
+	if err := json.Unmarshal(content, &packageJSON); err == nil {
+		if config := parser.ParseBrowserslistField(packageJSON.Browserslist); config != nil {
+			return config
+		}
+	}
+
+	rcContent, err := provider.ReadFile(filepath.Join(currentPath, ".browserslistrc"))
+	if err != nil {
+		return nil
+	}
+	config := parser.ParseBrowserslistRC(string(rcContent))
+	if len(config.Queries) == 0 && len(config.Environments) == 0 {
+		return nil
+	}
+	config.File = ".browserslistrc"
+	return config
+}
+
+// readTSConfig reads tsconfig.json and inherits missing settings from a relative "extends" base.
+// The base file is only followed when it resolves inside the scanned project.
+func (d *Detector) readTSConfig(currentPath, basePath string, provider types.Provider, parser *parsers.FrontendConfigParser) *parsers.TSConfigInfo {
+	tsContent, err := provider.ReadFile(filepath.Join(currentPath, "tsconfig.json"))
+	if err != nil {
+		return nil
+	}
+	info, err := parser.ParseTSConfig(string(tsContent))
+	if err != nil {
+		return nil
+	}
+	info.File = "tsconfig.json"
+
+	if strings.HasPrefix(info.Extends, ".") && (info.Target == "" || info.Module == "") {
+		basePathFile := filepath.Clean(filepath.Join(currentPath, info.Extends))
+		if !strings.HasSuffix(basePathFile, ".json") {
+			basePathFile += ".json"
+		}
+		if isWithin(basePath, basePathFile) {
+			if baseContent, err := provider.ReadFile(basePathFile); err == nil {
+				if base, err := parser.ParseTSConfig(string(baseContent)); err == nil {
+					info.InheritFrom(base)
+				}
+			}
+		}
+	}
+
+	return info
+}
+
+// isWithin reports whether path is inside root
+func isWithin(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
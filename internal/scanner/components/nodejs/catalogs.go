@@ -0,0 +1,37 @@
+package nodejs
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// resolveCatalogs replaces the catalog: ranges of dependencies with the versions of the
+// catalogs of the nearest pnpm-workspace.yaml, and of the pnpm-lock.yaml next to it when lock
+// files are enabled
+func (d *Detector) resolveCatalogs(dependencies []types.Dependency, currentPath, basePath string, provider types.Provider) {
+	hasCatalogRanges := false
+	for _, dep := range dependencies {
+		if _, ok := parsers.PnpmCatalogName(dep.Version); ok {
+			hasCatalogRanges = true
+			break
+		}
+	}
+	if !hasCatalogRanges {
+		return
+	}
+
+	dir, content := findUpwards("pnpm-workspace.yaml", currentPath, basePath, provider)
+	if content == nil {
+		return
+	}
+	var locked parsers.PnpmCatalogs
+	if components.UseLockFiles() {
+		if lockContent, err := provider.ReadFile(filepath.Join(dir, "pnpm-lock.yaml")); err == nil {
+			locked = parsers.ParsePnpmLockCatalogs(lockContent)
+		}
+	}
+	parsers.ResolvePnpmCatalogVersions(dependencies, parsers.ParsePnpmWorkspaceCatalogs(content), locked)
+}
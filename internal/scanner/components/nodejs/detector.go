@@ -82,22 +82,57 @@ func (d *Detector) processPackageJSON(file types.File, currentPath, basePath str
 	payload.Properties["nodejs"] = nodejsInfo
 
 	// Process dependencies using priority-based extraction (lock files first)
-	d.processDependenciesWithPriority(currentPath, provider, depDetector, payload)
+	d.processDependenciesWithPriority(currentPath, basePath, provider, depDetector, payload)
+	parsers.LocateDependencies(payload.Dependencies, relativeFilePath, parsers.PackageJSONLines(content))
 
 	// Process license
 	d.processLicense(&packageJSON, payload)
 
+	// Detect build tools invoked from npm scripts
+	d.processScripts(content, payload, depDetector)
+
+	// Extract frontend build targets (browserslist, tsconfig)
+	d.processBuildTargets(content, currentPath, basePath, provider, payload)
+
+	// Check locked peer dependencies and engines (install conflicts)
+	d.processInstallChecks(content, currentPath, basePath, provider, payload)
+
+	// Inventory locally patched packages and version overrides
+	d.processPatches(content, currentPath, provider, payload)
+
 	return payload
 }
 
+// processScripts detects build tools invoked from package.json scripts (e.g., "build": "tsc && vite build")
+// and attributes their technologies even when the tools are not declared as dependencies.
+func (d *Detector) processScripts(content []byte, payload *types.Payload, depDetector components.DependencyDetector) {
+	var packageJSON struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(content, &packageJSON); err != nil || len(packageJSON.Scripts) == 0 {
+		return
+	}
+
+	usage := parsers.NewScriptsParser().DetectTools(packageJSON.Scripts)
+	if len(usage) == 0 {
+		return
+	}
+
+	payload.SetComponentProperty("nodejs", "script_tools", usage.Tools())
+	components.AddScriptToolTechs(payload, usage, "scripts", depDetector)
+}
+
 // processDependenciesWithPriority handles dependency processing using lock file priority system
 // Priority 1: package-lock.json (npm)
 // Priority 2: pnpm-lock.yaml (pnpm)
 // Priority 3: yarn.lock (yarn)
 // Priority 4: package.json (fallback)
-func (d *Detector) processDependenciesWithPriority(currentPath string, provider types.Provider, depDetector components.DependencyDetector, payload *types.Payload) {
+func (d *Detector) processDependenciesWithPriority(currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector, payload *types.Payload) {
 	dependencies := d.extractDependenciesFromLockFiles(currentPath, provider)
 
+	// Resolve pnpm catalog: ranges of package.json to the versions of the workspace catalogs
+	d.resolveCatalogs(dependencies, currentPath, basePath, provider)
+
 	// Add dependencies to payload
 	payload.Dependencies = append(payload.Dependencies, dependencies...)
 
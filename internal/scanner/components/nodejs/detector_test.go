@@ -2,8 +2,10 @@ package nodejs
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -22,7 +24,13 @@ func (m *MockProvider) ReadFile(path string) ([]byte, error) {
 }
 
 func (m *MockProvider) ListDir(path string) ([]types.File, error) {
-	return nil, nil
+	var entries []types.File
+	for name := range m.files {
+		if filepath.Dir(name) == path {
+			entries = append(entries, types.File{Name: filepath.Base(name), Path: name, Type: "file"})
+		}
+	}
+	return entries, nil
 }
 
 func (m *MockProvider) Open(path string) (string, error) {
@@ -447,3 +455,218 @@ func TestDetector_Detect_RelativePathHandling(t *testing.T) {
 	assert.Equal(t, "path-test-app", payload.Name)
 	assert.Equal(t, "/subdir/package.json", payload.Path[0], "Should handle relative paths correctly")
 }
+
+// ruleDependencyDetector matches dependency names against a fixed name -> tech map
+type ruleDependencyDetector struct {
+	rules map[string]string
+}
+
+func (m *ruleDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	result := make(map[string][]string)
+	for _, dep := range dependencies {
+		if tech, ok := m.rules[dep]; ok {
+			result[tech] = append(result[tech], "matched dependency: "+dep)
+		}
+	}
+	return result
+}
+
+func (m *ruleDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func TestDetector_Detect_ScriptTools(t *testing.T) {
+	detector := &Detector{}
+
+	packageJsonContent := `{
+  "name": "script-app",
+  "scripts": {
+    "build": "tsc -p . && vite build",
+    "lint": "npx eslint@8 src",
+    "test:e2e": "cross-env CI=1 playwright test",
+    "start": "node server.js"
+  }
+}`
+
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/package.json": packageJsonContent,
+		},
+	}
+	depDetector := &ruleDependencyDetector{
+		rules: map[string]string{
+			"typescript":       "typescript",
+			"vite":             "vite",
+			"eslint":           "eslint",
+			"@playwright/test": "playwright",
+		},
+	}
+	files := []types.File{
+		{Name: "package.json", Path: "/project/package.json"},
+	}
+
+	results := detector.Detect(files, "/project", "/project", provider, depDetector)
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Empty(t, payload.Dependencies, "Script tools should not be added as dependencies")
+	assert.Contains(t, payload.Techs, "typescript")
+	assert.Contains(t, payload.Techs, "vite")
+	assert.Contains(t, payload.Techs, "eslint")
+	assert.Contains(t, payload.Techs, "playwright")
+	assert.Contains(t, payload.Reason["eslint"], "script command: eslint (scripts: lint)")
+
+	nodejsProps, ok := payload.Properties["nodejs"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, []string{"eslint", "playwright", "tsc", "vite"}, nodejsProps["script_tools"])
+}
+
+func TestDetector_Detect_BuildTargets(t *testing.T) {
+	detector := &Detector{}
+
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/web/package.json":   `{"name": "web", "browserslist": ["> 1%", "not dead"]}`,
+			"/project/web/tsconfig.json":  `{"extends": "../tsconfig.base.json", "compilerOptions": {"module": "ESNext",}}`,
+			"/project/tsconfig.base.json": `{"compilerOptions": {"target": "ES2022", "module": "CommonJS"}}`,
+		},
+	}
+	depDetector := &MockDependencyDetector{matchedTechs: map[string][]string{}}
+	files := []types.File{
+		{Name: "package.json", Path: "/project/web/package.json"},
+	}
+
+	results := detector.Detect(files, "/project/web", "/project", provider, depDetector)
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	browserslist, ok := payload.Properties["browserslist"].(*parsers.BrowserslistConfig)
+	require.True(t, ok)
+	assert.Equal(t, "package.json", browserslist.File)
+	assert.Equal(t, []string{"> 1%", "not dead"}, browserslist.Queries)
+
+	tsconfig, ok := payload.Properties["typescript"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "ES2022", tsconfig["target"], "target should be inherited from the extended config")
+	assert.Equal(t, "ESNext", tsconfig["module"], "own settings take precedence over the extended config")
+	assert.Equal(t, "../tsconfig.base.json", tsconfig["extends"])
+}
+
+func TestDetector_Detect_BrowserslistRC(t *testing.T) {
+	detector := &Detector{}
+
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/package.json":    `{"name": "app"}`,
+			"/project/.browserslistrc": "defaults\n[production]\n> 0.5%\n",
+			"/project/tsconfig.json":   `{"extends": "../../outside/tsconfig.json"}`,
+		},
+	}
+	depDetector := &MockDependencyDetector{matchedTechs: map[string][]string{}}
+	files := []types.File{
+		{Name: "package.json", Path: "/project/package.json"},
+	}
+
+	results := detector.Detect(files, "/project", "/project", provider, depDetector)
+	require.Len(t, results, 1)
+
+	browserslist, ok := results[0].Properties["browserslist"].(*parsers.BrowserslistConfig)
+	require.True(t, ok)
+	assert.Equal(t, ".browserslistrc", browserslist.File)
+	assert.Equal(t, []string{"defaults"}, browserslist.Queries)
+	assert.Equal(t, map[string][]string{"production": {"> 0.5%"}}, browserslist.Environments)
+
+	tsconfig, ok := results[0].Properties["typescript"].(map[string]interface{})
+	require.True(t, ok)
+	assert.NotContains(t, tsconfig, "target", "extends outside the project must not be followed")
+}
+
+func TestDetector_Detect_InstallConflicts(t *testing.T) {
+	detector := &Detector{}
+
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/.nvmrc":                "v16.20.2\n",
+			"/project/.npmrc":                "engine-strict=true\n",
+			"/project/web/package.json":      `{"name": "web", "dependencies": {"react-dom": "^18.2.0"}}`,
+			"/project/web/package-lock.json": `{"name": "web", "lockfileVersion": 3, "packages": {"": {"name": "web"}, "node_modules/react": {"version": "17.0.2"}, "node_modules/react-dom": {"version": "18.2.0", "peerDependencies": {"react": "^18.2.0"}, "engines": {"node": ">=18"}}}}`,
+		},
+	}
+	depDetector := &MockDependencyDetector{matchedTechs: map[string][]string{}}
+	files := []types.File{
+		{Name: "package.json", Path: "/project/web/package.json"},
+	}
+
+	results := detector.Detect(files, "/project/web", "/project", provider, depDetector)
+	require.Len(t, results, 1)
+
+	nodejs, ok := results[0].Properties["nodejs"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "16.20.2", nodejs["node_version"], "nearest .nvmrc inside the project")
+	conflicts, ok := nodejs["install_conflicts"].([]parsers.NPMInstallConflict)
+	require.True(t, ok)
+	require.Len(t, conflicts, 2)
+	assert.Equal(t, parsers.NPMConflictEngine, conflicts[0].Kind)
+	assert.Equal(t, parsers.NPMOutcomeFail, conflicts[0].Outcome, "engine-strict from .npmrc")
+	assert.Equal(t, parsers.NPMConflictPeer, conflicts[1].Kind)
+	assert.Equal(t, "17.0.2", conflicts[1].Resolved)
+}
+
+func TestDetector_Detect_Patches(t *testing.T) {
+	detector := &Detector{}
+
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/package.json": `{"name": "web", "dependencies": {"lodash": "^4.17.21", "express": "^4.18.2"},
+				"overrides": {"semver": "7.5.4"}, "pnpm": {"patchedDependencies": {"express@4.18.2": "patches/express@4.18.2.patch"}}}`,
+			"/project/pnpm-lock.yaml":                   "lockfileVersion: '9.0'\npatchedDependencies:\n  express@4.18.2: 3ahbgdfqt5xkwcvjl6rk2kzjeq\n",
+			"/project/patches/express@4.18.2.patch":     "diff",
+			"/project/patches/lodash+4.17.21.patch":     "diff",
+			"/project/patches/README.md":                "notes",
+			"/project/.yarn/patches/unrelated-file.txt": "notes",
+		},
+	}
+	depDetector := &MockDependencyDetector{matchedTechs: map[string][]string{}}
+	files := []types.File{
+		{Name: "package.json", Path: "/project/package.json"},
+	}
+
+	results := detector.Detect(files, "/project", "/project", provider, depDetector)
+	require.Len(t, results, 1)
+
+	nodejs, ok := results[0].Properties["nodejs"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, []parsers.NPMPatch{
+		{Package: "express", Version: "4.18.2", Tool: parsers.NPMPatchToolPnpm, File: "patches/express@4.18.2.patch"},
+		{Package: "lodash", Version: "4.17.21", Tool: parsers.NPMPatchToolPatchPackage, File: "patches/lodash+4.17.21.patch"},
+	}, nodejs["patches"], "patch file of the lock file entry from package.json, unreferenced patch files from patches/")
+	assert.Equal(t, []parsers.NPMOverride{
+		{Package: "semver", Version: "7.5.4", Field: parsers.NPMOverrideFieldNPM},
+	}, nodejs["overrides"])
+}
+
+func TestDetector_Detect_PnpmCatalogs(t *testing.T) {
+	detector := &Detector{}
+
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/pnpm-workspace.yaml":   "packages:\n  - apps/*\ncatalog:\n  react: ^18.2.0\ncatalogs:\n  tools:\n    typescript: ~5.4.0\n",
+			"/project/pnpm-lock.yaml":        "lockfileVersion: '9.0'\ncatalogs:\n  default:\n    react:\n      specifier: ^18.2.0\n      version: 18.3.1\n",
+			"/project/apps/web/package.json": `{"name": "web", "dependencies": {"react": "catalog:"}, "devDependencies": {"typescript": "catalog:tools"}}`,
+		},
+	}
+	depDetector := &MockDependencyDetector{matchedTechs: map[string][]string{}}
+	files := []types.File{
+		{Name: "package.json", Path: "/project/apps/web/package.json"},
+	}
+
+	results := detector.Detect(files, "/project/apps/web", "/project", provider, depDetector)
+	require.Len(t, results, 1)
+
+	versions := make(map[string]string)
+	for _, dep := range results[0].Dependencies {
+		versions[dep.Name] = dep.Version
+		assert.NotEmpty(t, dep.Metadata[parsers.MetadataPnpmCatalog], dep.Name)
+	}
+	assert.Equal(t, map[string]string{"react": "18.3.1", "typescript": "~5.4.0"}, versions,
+		"catalog versions of the workspace root, locked versions first")
+}
@@ -0,0 +1,77 @@
+package nodejs
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// processInstallChecks cross-checks the peerDependencies and engines of the packages locked in
+// package-lock.json against the locked tree and the Node version of the project, and stores
+// the Node version and the conflicts as component properties
+func (d *Detector) processInstallChecks(content []byte, currentPath, basePath string, provider types.Provider, payload *types.Payload) {
+	if !components.UseLockFiles() {
+		return
+	}
+	lockContent, err := provider.ReadFile(filepath.Join(currentPath, "package-lock.json"))
+	if err != nil || len(lockContent) == 0 {
+		return
+	}
+
+	options := parsers.NPMInstallCheckOptions{NodeVersion: d.readNodeVersion(content, currentPath, basePath, provider)}
+	if npmrc := readUpwards(".npmrc", currentPath, basePath, provider); npmrc != nil {
+		options.EngineStrict = parsers.ParseNpmrcEngineStrict(string(npmrc))
+	}
+	if options.NodeVersion != "" {
+		payload.SetComponentProperty("nodejs", "node_version", options.NodeVersion)
+	}
+	if conflicts := parsers.CheckNPMInstallConflicts(lockContent, options); len(conflicts) > 0 {
+		payload.SetComponentProperty("nodejs", "install_conflicts", conflicts)
+	}
+}
+
+// readNodeVersion returns the Node version the project pins: the volta.node field of
+// package.json, or the nearest .nvmrc or .node-version file inside the scanned project
+func (d *Detector) readNodeVersion(content []byte, currentPath, basePath string, provider types.Provider) string {
+	var packageJSON struct {
+		Volta struct {
+			Node string `json:"node"`
+		} `json:"volta"`
+	}
+	if err := json.Unmarshal(content, &packageJSON); err == nil && packageJSON.Volta.Node != "" {
+		return strings.TrimPrefix(packageJSON.Volta.Node, "v")
+	}
+	for _, name := range []string{".nvmrc", ".node-version"} {
+		if versionFile := readUpwards(name, currentPath, basePath, provider); versionFile != nil {
+			if version := parsers.ParseNodeVersionFile(string(versionFile)); version != "" {
+				return version
+			}
+		}
+	}
+	return ""
+}
+
+// readUpwards reads the nearest file of a name in the directory or its parents up to the root
+// of the scanned project; returns nil if there is none
+func readUpwards(name, currentPath, basePath string, provider types.Provider) []byte {
+	_, content := findUpwards(name, currentPath, basePath, provider)
+	return content
+}
+
+// findUpwards returns the directory and content of the nearest file of a name in the directory
+// or its parents up to the root of the scanned project; returns nil content if there is none
+func findUpwards(name, currentPath, basePath string, provider types.Provider) (string, []byte) {
+	for dir := currentPath; isWithin(basePath, dir); dir = filepath.Dir(dir) {
+		if content, err := provider.ReadFile(filepath.Join(dir, name)); err == nil {
+			return dir, content
+		}
+		if dir == filepath.Dir(dir) {
+			break
+		}
+	}
+	return "", nil
+}
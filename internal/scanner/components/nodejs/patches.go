@@ -0,0 +1,74 @@
+package nodejs
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// patchDirs are the directories holding patch files: patch-package and pnpm patch-commit
+// (patches/), and yarn patch-commit (.yarn/patches/)
+var patchDirs = []string{"patches", ".yarn/patches"}
+
+// processPatches collects the upstream packages the project patches locally (patch: protocol,
+// pnpm.patchedDependencies, patch files) and the versions it overrides (overrides, resolutions,
+// pnpm.overrides), and stores them as component properties
+func (d *Detector) processPatches(content []byte, currentPath string, provider types.Provider, payload *types.Payload) {
+	declared, overrides := parsers.ParsePackageJSONPatches(content)
+
+	// Lock files hold the versions patches apply to, where package.json may hold ranges
+	var patches []parsers.NPMPatch
+	if components.UseLockFiles() {
+		if yarnLock, err := provider.ReadFile(filepath.Join(currentPath, "yarn.lock")); err == nil {
+			patches = append(patches, parsers.ParseYarnLockPatches(yarnLock)...)
+		}
+		if pnpmLock, err := provider.ReadFile(filepath.Join(currentPath, "pnpm-lock.yaml")); err == nil {
+			patches = append(patches, parsers.ParsePnpmLockPatches(pnpmLock)...)
+		}
+	}
+	locked := make(map[string]int)
+	for i, patch := range patches {
+		locked[patch.Tool+"\x00"+patch.Package] = i
+	}
+	for _, patch := range declared {
+		i, ok := locked[patch.Tool+"\x00"+patch.Package]
+		switch {
+		case !ok:
+			patches = append(patches, patch)
+		case patches[i].File == "":
+			patches[i].File = patch.File // pnpm-lock.yaml v9 records only the hash
+		}
+	}
+
+	// Patch files no declaration references (patch-package applies every file of patches/)
+	referenced := make(map[string]bool)
+	for _, patch := range patches {
+		referenced[patch.File] = true
+	}
+	for _, dir := range patchDirs {
+		entries, err := provider.ListDir(filepath.Join(currentPath, filepath.FromSlash(dir)))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			file := path.Join(dir, entry.Name)
+			if entry.Type == "dir" || !strings.HasSuffix(entry.Name, ".patch") || referenced[file] {
+				continue
+			}
+			if patch, ok := parsers.ParsePatchFileName(file); ok {
+				patches = append(patches, patch)
+			}
+		}
+	}
+
+	if patches = parsers.SortNPMPatches(patches); len(patches) > 0 {
+		payload.SetComponentProperty("nodejs", "patches", patches)
+	}
+	if len(overrides) > 0 {
+		payload.SetComponentProperty("nodejs", "overrides", overrides)
+	}
+}
@@ -0,0 +1,137 @@
+// Package ocaml implements detection of OCaml packages from opam files (<name>.opam, opam)
+// and dune-project package stanzas.
+package ocaml
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/providers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Detector implements OCaml package detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "ocaml"
+}
+
+// Detect creates a component for each package of the directory. Packages defined by a
+// dune-project stanza take their dependencies from the stanza (the matching opam file is
+// generated from it); the other packages take them from their opam file.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	parser := parsers.NewOCamlParser()
+
+	var duneProject *parsers.DuneProject
+	var opamFiles []string
+	for _, file := range files {
+		switch {
+		case file.Type == "dir":
+			continue
+		case file.Name == "dune-project":
+			if content, err := provider.ReadFile(filepath.Join(currentPath, file.Name)); err == nil {
+				project := parser.ParseDuneProject(string(content))
+				duneProject = &project
+			}
+		case file.Name == "opam" || strings.HasSuffix(file.Name, ".opam"):
+			opamFiles = append(opamFiles, file.Name)
+		}
+	}
+	sort.Strings(opamFiles)
+
+	var results []*types.Payload
+	stanzas := make(map[string]*types.Payload)
+	if duneProject != nil {
+		for _, pkg := range duneProject.Packages {
+			if pkg.Name == "" {
+				continue
+			}
+			payload := d.createPayload(pkg, "dune-project", currentPath, basePath, parser, depDetector)
+			payload.AddTech("dune", "matched file: dune-project")
+			if duneProject.Lang != "" {
+				payload.SetComponentProperty("opam", "dune_lang", duneProject.Lang)
+			}
+			stanzas[pkg.Name] = payload
+			results = append(results, payload)
+		}
+	}
+
+	for _, name := range opamFiles {
+		pkgName := strings.TrimSuffix(name, ".opam")
+		if payload, ok := stanzas[pkgName]; ok {
+			payload.AddPath(types.CalculateRelativePath(name, currentPath, basePath))
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, name))
+		if err != nil {
+			continue
+		}
+		pkg := parser.ParseOpam(string(content))
+		if name != "opam" {
+			pkg.Name = pkgName
+		}
+		if pkg.Name == "" {
+			pkg.Name = filepath.Base(currentPath)
+		}
+		payload := d.createPayload(pkg, name, currentPath, basePath, parser, depDetector)
+		if duneProject != nil {
+			payload.AddPath(types.CalculateRelativePath("dune-project", currentPath, basePath))
+			payload.AddTech("dune", "matched file: dune-project")
+		}
+		results = append(results, payload)
+	}
+
+	return results
+}
+
+// createPayload creates the component of a package with its dependencies
+func (d *Detector) createPayload(pkg parsers.OpamPackage, fileName, currentPath, basePath string, parser *parsers.OCamlParser, depDetector components.DependencyDetector) *types.Payload {
+	payload := types.NewPayloadWithPath(pkg.Name, types.CalculateRelativePath(fileName, currentPath, basePath))
+	payload.SetComponentType("opam")
+	payload.AddPrimaryTech("ocaml")
+	payload.AddTech("opam", "matched file: "+fileName)
+
+	opamInfo := map[string]interface{}{"name": pkg.Name}
+	if pkg.Version != "" {
+		opamInfo["version"] = pkg.Version
+	}
+	if pkg.Synopsis != "" {
+		opamInfo["synopsis"] = pkg.Synopsis
+	}
+	payload.SetComponentProperties("opam", opamInfo)
+
+	source := parsers.MetadataSourceOpam
+	if fileName == "dune-project" {
+		source = parsers.MetadataSourceDuneProject
+	}
+	dependencies := parser.Dependencies(pkg, source)
+	var names []string
+	for _, dep := range dependencies {
+		payload.AddDependency(dep)
+		names = append(names, dep.Name)
+	}
+	if len(names) > 0 {
+		for tech, reasons := range depDetector.MatchDependencies(names, parsers.DependencyTypeOpam) {
+			for _, reason := range reasons {
+				payload.AddTech(tech, reason)
+			}
+			depDetector.AddPrimaryTechIfNeeded(payload, tech)
+		}
+	}
+	return payload
+}
+
+func init() {
+	components.Register(&Detector{})
+
+	// Register opam package provider (packages of a repository depend on each other by name)
+	providers.Register(&providers.PackageProvider{
+		DependencyType:      "opam",
+		ExtractPackageNames: providers.SinglePropertyExtractor("opam", "name"),
+	})
+}
@@ -0,0 +1,110 @@
+package ocaml
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "ocaml", (&Detector{}).Name())
+}
+
+func TestDetect_DuneProjectWithGeneratedOpamFiles(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/dune-project":     "(lang dune 3.11)\n(version 1.0.0)\n(generate_opam_files)\n(package\n (name server)\n (depends (ocaml (>= 4.14)) dune server-core))\n(package\n (name server-core)\n (depends lwt))\n",
+		"/repo/server.opam":      "depends: [ \"outdated\" ]\n",
+		"/repo/server-core.opam": "depends: [ \"outdated\" ]\n",
+		"/repo/tools.opam":       "version: \"0.1.0\"\ndepends: [ \"cmdliner\" {>= \"1.2\"} ]\n",
+	}}
+	files := []types.File{
+		{Name: "bin", Type: "dir"},
+		{Name: "dune-project", Type: "file"},
+		{Name: "server.opam", Type: "file"},
+		{Name: "server-core.opam", Type: "file"},
+		{Name: "tools.opam", Type: "file"},
+	}
+
+	payloads := (&Detector{}).Detect(files, "/repo", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 3)
+
+	server := payloads[0]
+	assert.Equal(t, "server", server.Name)
+	assert.Equal(t, "opam", server.ComponentType)
+	assert.Equal(t, []string{"ocaml"}, server.Tech)
+	assert.Contains(t, server.Techs, "dune")
+	assert.Equal(t, []string{"/dune-project", "/server.opam"}, server.Path)
+	assert.Equal(t, map[string]interface{}{"name": "server", "version": "1.0.0", "dune_lang": "3.11"}, server.Properties["opam"])
+	require.Len(t, server.Dependencies, 3, "Should take the dependencies of the stanza, not of the generated opam file")
+	assert.Equal(t, "dune-project", server.Dependencies[0].Metadata["source"])
+
+	assert.Equal(t, "server-core", payloads[1].Name)
+
+	tools := payloads[2]
+	assert.Equal(t, "tools", tools.Name)
+	assert.Equal(t, []string{"/tools.opam", "/dune-project"}, tools.Path)
+	require.Len(t, tools.Dependencies, 1)
+	assert.Equal(t, ">=1.2", tools.Dependencies[0].Version)
+	assert.Equal(t, ".opam", tools.Dependencies[0].Metadata["source"])
+}
+
+func TestDetect_LegacyOpamFile(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/lib/opam": "opam-version: \"2.0\"\nname: \"mylib\"\ndepends: [ \"ocamlfind\" {build} ]\n",
+	}}
+
+	payloads := (&Detector{}).Detect([]types.File{{Name: "opam", Type: "file"}}, "/repo/lib", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	assert.Equal(t, "mylib", payloads[0].Name)
+	assert.Equal(t, []string{"/lib/opam"}, payloads[0].Path)
+	require.Len(t, payloads[0].Dependencies, 1)
+	assert.Equal(t, types.ScopeBuild, payloads[0].Dependencies[0].Scope)
+}
@@ -25,11 +25,21 @@ func (d *Detector) Detect(files []types.File, currentPath, basePath string, prov
 		if file.Name == "composer.json" {
 			payload := d.detectComposerJSON(file, currentPath, basePath, provider, depDetector)
 			if payload != nil {
+				d.processFrameworks(files, currentPath, basePath, provider, payload)
 				results = append(results, payload)
 			}
 		}
 	}
 
+	// Frameworks without a named composer component (e.g., a plain WordPress checkout or
+	// a plugin repository) are merged into the parent via a virtual payload
+	if len(results) == 0 && isWordPressCandidate(files, currentPath) {
+		virtual := types.NewPayloadWithPath("virtual", relativePath(basePath, currentPath, ""))
+		if d.processFrameworks(files, currentPath, basePath, provider, virtual) {
+			results = append(results, virtual)
+		}
+	}
+
 	return results
 }
 
@@ -48,6 +58,15 @@ func (d *Detector) detectComposerJSON(file types.File, currentPath, basePath str
 		return nil
 	}
 
+	// Installed versions from composer.lock
+	if components.UseLockFiles() {
+		if lockContent, err := provider.ReadFile(filepath.Join(currentPath, "composer.lock")); err == nil && len(lockContent) > 0 {
+			if locked := phpParser.ParseComposerLockDependencies(string(lockContent), string(content), parsers.ParseComposerLockOptions{}); locked != nil {
+				dependencies = locked
+			}
+		}
+	}
+
 	// Create named payload with specific file path
 	relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, file.Name))
 	if relativeFilePath == "." {
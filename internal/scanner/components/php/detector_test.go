@@ -2,6 +2,7 @@ package php
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
@@ -22,7 +23,26 @@ func (m *MockProvider) ReadFile(path string) ([]byte, error) {
 }
 
 func (m *MockProvider) ListDir(path string) ([]types.File, error) {
-	return nil, nil
+	// Derive directory entries from the mock file paths
+	seen := make(map[string]bool)
+	var entries []types.File
+	prefix := strings.TrimSuffix(path, "/") + "/"
+	for filePath := range m.files {
+		if !strings.HasPrefix(filePath, prefix) {
+			continue
+		}
+		name, rest, isDir := strings.Cut(strings.TrimPrefix(filePath, prefix), "/")
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		entryType := "file"
+		if isDir && rest != "" {
+			entryType = "dir"
+		}
+		entries = append(entries, types.File{Name: name, Path: prefix + name, Type: entryType})
+	}
+	return entries, nil
 }
 
 func (m *MockProvider) Open(path string) (string, error) {
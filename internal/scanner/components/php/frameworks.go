@@ -0,0 +1,179 @@
+package php
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// processFrameworks detects Laravel, Symfony, and WordPress in the component directory and
+// stores framework and plugin inventories as properties. Dependencies are annotated with the
+// licenses and authors recorded in composer.lock. Returns true if a framework was found.
+func (d *Detector) processFrameworks(files []types.File, currentPath, basePath string, provider types.Provider, payload *types.Payload) bool {
+	parser := parsers.NewPHPParser()
+	installed := d.readComposerLock(files, currentPath, provider, parser)
+	addInstalledLicenses(payload, installed)
+	found := false
+
+	if laravel := d.detectLaravel(files, currentPath, basePath, provider, installed); laravel != nil {
+		payload.Properties["laravel"] = laravel
+		payload.AddTech("laravel", "matched file: artisan")
+		found = true
+	}
+
+	if symfony := d.detectSymfony(files, currentPath, basePath, provider, parser, installed); symfony != nil {
+		payload.Properties["symfony"] = symfony
+		payload.AddTech("symfony", "matched file: "+filepath.Base(symfony.File))
+		found = true
+	}
+
+	if wordpress, reason := d.detectWordPress(files, currentPath, basePath, provider, parser, installed); wordpress != nil {
+		payload.Properties["wordpress"] = wordpress
+		payload.AddTech("wordpress", reason)
+		found = true
+	}
+
+	return found
+}
+
+// readComposerLock returns installed package versions from composer.lock (name -> package)
+func (d *Detector) readComposerLock(files []types.File, currentPath string, provider types.Provider, parser *parsers.PHPParser) map[string]parsers.ComposerLockPackage {
+	installed := make(map[string]parsers.ComposerLockPackage)
+	if !hasFile(files, "composer.lock") {
+		return installed
+	}
+	content, err := provider.ReadFile(filepath.Join(currentPath, "composer.lock"))
+	if err != nil {
+		return installed
+	}
+	packages, err := parser.ParseComposerLock(string(content))
+	if err != nil {
+		return installed
+	}
+	for _, pkg := range packages {
+		installed[pkg.Name] = pkg
+	}
+	return installed
+}
+
+// addInstalledLicenses copies the license and authors of installed packages into the
+// metadata of the matching dependencies
+func addInstalledLicenses(payload *types.Payload, installed map[string]parsers.ComposerLockPackage) {
+	for i, dep := range payload.Dependencies {
+		pkg, ok := installed[dep.Name]
+		if !ok || (pkg.License == "" && len(pkg.Authors) == 0) {
+			continue
+		}
+		// Copy the metadata: composer.json dependencies share a single metadata map
+		metadata := make(map[string]interface{}, len(dep.Metadata)+2)
+		for key, value := range dep.Metadata {
+			metadata[key] = value
+		}
+		if pkg.License != "" {
+			metadata[parsers.MetadataLicenseDeclared] = pkg.License
+		}
+		if len(pkg.Authors) > 0 {
+			metadata[parsers.MetadataAuthors] = pkg.Authors
+		}
+		payload.Dependencies[i].Metadata = metadata
+	}
+}
+
+// detectLaravel detects a Laravel application (artisan and config/app.php)
+func (d *Detector) detectLaravel(files []types.File, currentPath, basePath string, provider types.Provider, installed map[string]parsers.ComposerLockPackage) *parsers.LaravelInfo {
+	if !hasFile(files, "artisan") || !hasDir(files, "config") {
+		return nil
+	}
+	if exists, _ := provider.Exists(filepath.Join(currentPath, "config", "app.php")); !exists {
+		return nil
+	}
+
+	info := &parsers.LaravelInfo{File: relativePath(basePath, currentPath, "artisan")}
+	if pkg, ok := installed["laravel/framework"]; ok {
+		info.Version = pkg.Version
+	}
+	for _, name := range sortedPackageNames(installed) {
+		pkg := installed[name]
+		if pkg.Laravel && !pkg.Dev {
+			info.Packages = append(info.Packages, parsers.PHPPackageInfo{Name: pkg.Name, Version: pkg.Version})
+		}
+	}
+	return info
+}
+
+// detectSymfony detects a Symfony application (symfony.lock or config/bundles.php)
+func (d *Detector) detectSymfony(files []types.File, currentPath, basePath string, provider types.Provider, parser *parsers.PHPParser, installed map[string]parsers.ComposerLockPackage) *parsers.SymfonyInfo {
+	var info *parsers.SymfonyInfo
+	var flexVersions map[string]string
+
+	if hasFile(files, "symfony.lock") {
+		info = &parsers.SymfonyInfo{File: relativePath(basePath, currentPath, "symfony.lock")}
+		if content, err := provider.ReadFile(filepath.Join(currentPath, "symfony.lock")); err == nil {
+			flexVersions, _ = parser.ParseSymfonyLock(string(content))
+		}
+	}
+
+	if !hasDir(files, "config") && info == nil {
+		return nil
+	}
+	if content, err := provider.ReadFile(filepath.Join(currentPath, "config", "bundles.php")); err == nil {
+		if info == nil {
+			info = &parsers.SymfonyInfo{File: relativePath(basePath, currentPath, filepath.Join("config", "bundles.php"))}
+		}
+		info.Bundles = parser.ParsePHPClassList(string(content))
+	}
+
+	if info == nil {
+		return nil
+	}
+
+	// Prefer the installed version; fall back to the Flex recipe version
+	for _, name := range []string{"symfony/framework-bundle", "symfony/symfony", "symfony/http-kernel"} {
+		if pkg, ok := installed[name]; ok {
+			info.Version = pkg.Version
+			break
+		}
+		if version := flexVersions[name]; version != "" && info.Version == "" {
+			info.Version = version
+		}
+	}
+	return info
+}
+
+// relativePath computes the relative file path for payload display
+func relativePath(basePath, currentPath, fileName string) string {
+	relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, fileName))
+	if relativeFilePath == "." {
+		return "/"
+	}
+	return "/" + filepath.ToSlash(relativeFilePath)
+}
+
+func hasFile(files []types.File, name string) bool {
+	for _, file := range files {
+		if file.Name == name && file.Type != "dir" {
+			return true
+		}
+	}
+	return false
+}
+
+func hasDir(files []types.File, name string) bool {
+	for _, file := range files {
+		if file.Name == name && file.Type == "dir" {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedPackageNames(installed map[string]parsers.ComposerLockPackage) []string {
+	names := make([]string, 0, len(installed))
+	for name := range installed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,137 @@
+package php
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetector_Detect_Laravel(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/composer.json":  `{"name": "acme/shop", "require": {"laravel/framework": "^11.0"}}`,
+			"/project/artisan":        "#!/usr/bin/env php\n<?php\n",
+			"/project/config/app.php": "<?php return ['name' => env('APP_NAME', 'Laravel')];",
+			"/project/composer.lock": `{
+  "packages": [
+    {"name": "laravel/framework", "version": "v11.9.2", "type": "library"},
+    {"name": "spatie/laravel-permission", "version": "6.7.0", "extra": {"laravel": {"providers": ["Spatie\\Permission\\PermissionServiceProvider"]}}},
+    {"name": "guzzlehttp/guzzle", "version": "7.8.1"}
+  ],
+  "packages-dev": [
+    {"name": "laravel/sail", "version": "v1.29.2", "extra": {"laravel": {"providers": ["Laravel\\Sail\\SailServiceProvider"]}}}
+  ]
+}`,
+		},
+	}
+	files := []types.File{
+		{Name: "composer.json", Type: "file"},
+		{Name: "composer.lock", Type: "file"},
+		{Name: "artisan", Type: "file"},
+		{Name: "config", Type: "dir"},
+	}
+
+	results := detector.Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Equal(t, "acme/shop", payload.Name)
+	assert.Contains(t, payload.Techs, "laravel")
+
+	laravel := payload.Properties["laravel"].(*parsers.LaravelInfo)
+	assert.Equal(t, "/artisan", laravel.File)
+	assert.Equal(t, "11.9.2", laravel.Version)
+	assert.Equal(t, []parsers.PHPPackageInfo{{Name: "spatie/laravel-permission", Version: "6.7.0"}}, laravel.Packages, "dev packages and non-Laravel packages are excluded")
+}
+
+func TestDetector_Detect_ComposerLockLicenses(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/composer.json": `{"name": "acme/api", "require": {"monolog/monolog": "^3.0", "guzzlehttp/guzzle": "^7.8"}}`,
+			"/project/composer.lock": `{
+  "packages": [
+    {"name": "monolog/monolog", "version": "3.6.0", "license": ["MIT"], "authors": [{"name": "Jordi Boggiano"}]},
+    {"name": "guzzlehttp/guzzle", "version": "7.8.1"}
+  ]
+}`,
+		},
+	}
+	files := []types.File{{Name: "composer.json", Type: "file"}, {Name: "composer.lock", Type: "file"}}
+
+	results := detector.Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+
+	deps := make(map[string]types.Dependency)
+	for _, dep := range results[0].Dependencies {
+		deps[dep.Name] = dep
+	}
+	assert.Equal(t, "MIT", deps["monolog/monolog"].Metadata[parsers.MetadataLicenseDeclared])
+	assert.Equal(t, []string{"Jordi Boggiano"}, deps["monolog/monolog"].Metadata[parsers.MetadataAuthors])
+	assert.Equal(t, parsers.MetadataSourceComposerLock, deps["monolog/monolog"].Metadata["source"], "version installed by composer.lock")
+	assert.Equal(t, "3.6.0", deps["monolog/monolog"].Version)
+	assert.NotContains(t, deps["guzzlehttp/guzzle"].Metadata, parsers.MetadataLicenseDeclared, "shared manifest metadata is not modified")
+}
+
+func TestDetector_Detect_LaravelRequiresConfig(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/composer.json": `{"name": "acme/tool"}`,
+			"/project/artisan":       "#!/usr/bin/env php\n",
+		},
+	}
+	files := []types.File{{Name: "composer.json", Type: "file"}, {Name: "artisan", Type: "file"}}
+
+	results := detector.Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.NotContains(t, results[0].Properties, "laravel")
+}
+
+func TestDetector_Detect_Symfony(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/composer.json": `{"name": "acme/api", "require": {"symfony/framework-bundle": "7.1.*"}}`,
+			"/project/symfony.lock": `{
+  "symfony/framework-bundle": {"version": "7.1", "recipe": {"repo": "github.com/symfony/recipes"}},
+  "doctrine/doctrine-bundle": {"version": "2.12"}
+}`,
+			"/project/config/bundles.php": `<?php
+return [
+    Symfony\Bundle\FrameworkBundle\FrameworkBundle::class => ['all' => true],
+    Doctrine\Bundle\DoctrineBundle\DoctrineBundle::class => ['all' => true],
+    Symfony\Bundle\DebugBundle\DebugBundle::class => ['dev' => true],
+];`,
+		},
+	}
+	files := []types.File{
+		{Name: "composer.json", Type: "file"},
+		{Name: "symfony.lock", Type: "file"},
+		{Name: "config", Type: "dir"},
+	}
+
+	results := detector.Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Contains(t, payload.Techs, "symfony")
+
+	symfony := payload.Properties["symfony"].(*parsers.SymfonyInfo)
+	assert.Equal(t, "/symfony.lock", symfony.File)
+	assert.Equal(t, "7.1", symfony.Version, "falls back to the Flex recipe version without composer.lock")
+	assert.Equal(t, []string{"FrameworkBundle", "DoctrineBundle", "DebugBundle"}, symfony.Bundles)
+}
+
+func TestDetector_Detect_NoFramework(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{files: map[string]string{}}
+	files := []types.File{{Name: "index.php", Type: "file"}, {Name: "config", Type: "dir"}}
+
+	results := detector.Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	assert.Empty(t, results)
+}
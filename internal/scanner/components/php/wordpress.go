@@ -0,0 +1,244 @@
+package php
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// WordPress extension sources
+const (
+	wpSourceHeader   = "header"
+	wpSourceComposer = "composer"
+)
+
+// wpCorePackages are Composer packages installing WordPress core (e.g., Bedrock)
+var wpCorePackages = []string{"roots/wordpress", "johnpbloch/wordpress", "roots/wordpress-no-content"}
+
+// detectWordPress detects WordPress installations (wp-content), standalone plugin or theme
+// repositories (file headers), and Composer-managed plugins and themes (composer.lock).
+// Returns the inventory and the detection reason.
+func (d *Detector) detectWordPress(files []types.File, currentPath, basePath string, provider types.Provider, parser *parsers.PHPParser, installed map[string]parsers.ComposerLockPackage) (*parsers.WordPressInfo, string) {
+	var info *parsers.WordPressInfo
+	reason := ""
+
+	if hasDir(files, "wp-content") {
+		contentDir := filepath.Join(currentPath, "wp-content")
+		info = &parsers.WordPressInfo{File: relativePath(basePath, currentPath, "wp-content")}
+		if content, err := provider.ReadFile(filepath.Join(currentPath, "wp-includes", "version.php")); err == nil {
+			info.Version = parser.ParseWordPressVersion(string(content))
+		}
+		info.Plugins = d.scanWordPressPlugins(filepath.Join(contentDir, "plugins"), provider, parser)
+		info.Plugins = append(info.Plugins, d.scanWordPressPlugins(filepath.Join(contentDir, "mu-plugins"), provider, parser)...)
+		info.Themes = d.scanWordPressThemes(filepath.Join(contentDir, "themes"), provider, parser)
+		reason = "matched directory: wp-content"
+	} else if ext, file, isTheme := d.detectWordPressExtension(files, currentPath, provider, parser); ext != nil {
+		info = &parsers.WordPressInfo{File: relativePath(basePath, currentPath, file)}
+		if isTheme {
+			info.Themes = []parsers.WordPressExtension{*ext}
+		} else {
+			info.Plugins = []parsers.WordPressExtension{*ext}
+		}
+		reason = "matched file header: " + file
+	}
+
+	if d.mergeComposerExtensions(&info, basePath, currentPath, installed) && reason == "" {
+		reason = "composer-managed extensions: composer.lock"
+	}
+	if info == nil {
+		return nil, ""
+	}
+
+	parsers.SortWordPressExtensions(info.Plugins)
+	parsers.SortWordPressExtensions(info.Themes)
+	return info, reason
+}
+
+// detectWordPressExtension checks whether the directory itself is a plugin (<dir>.php, or any
+// top-level PHP file next to a WordPress.org readme.txt) or a theme (style.css with a Theme Name header)
+func (d *Detector) detectWordPressExtension(files []types.File, currentPath string, provider types.Provider, parser *parsers.PHPParser) (*parsers.WordPressExtension, string, bool) {
+	slug := filepath.Base(currentPath)
+
+	if hasFile(files, "style.css") {
+		if content, err := provider.ReadFile(filepath.Join(currentPath, "style.css")); err == nil {
+			if theme := parser.ParseWordPressHeader(string(content), parsers.WordPressThemeHeader); theme != nil {
+				theme.Slug = slug
+				theme.Source = wpSourceHeader
+				return theme, "style.css", true
+			}
+		}
+	}
+
+	hasReadme := hasFile(files, "readme.txt")
+	for _, file := range files {
+		if file.Type == "dir" || !strings.HasSuffix(file.Name, ".php") {
+			continue
+		}
+		if file.Name != slug+".php" && !hasReadme {
+			continue
+		}
+		if plugin := d.readPluginFile(filepath.Join(currentPath, file.Name), provider, parser); plugin != nil {
+			plugin.Slug = slug
+			return plugin, file.Name, false
+		}
+	}
+	return nil, "", false
+}
+
+// mergeComposerExtensions adds plugins and themes installed via Composer (wordpress-plugin,
+// wordpress-muplugin, wordpress-theme package types) and the WordPress core version.
+// Extensions already found via file headers keep their header data. Returns true if any were found.
+func (d *Detector) mergeComposerExtensions(info **parsers.WordPressInfo, basePath, currentPath string, installed map[string]parsers.ComposerLockPackage) bool {
+	found := false
+	for _, name := range sortedPackageNames(installed) {
+		pkg := installed[name]
+		isCore := containsString(wpCorePackages, pkg.Name)
+		isTheme := pkg.Type == "wordpress-theme"
+		isPlugin := pkg.Type == "wordpress-plugin" || pkg.Type == "wordpress-muplugin"
+		if !isCore && !isTheme && !isPlugin {
+			continue
+		}
+
+		if *info == nil {
+			*info = &parsers.WordPressInfo{File: relativePath(basePath, currentPath, "composer.lock")}
+		}
+		found = true
+		if isCore {
+			if (*info).Version == "" {
+				(*info).Version = pkg.Version
+			}
+			continue
+		}
+
+		ext := parsers.WordPressExtension{
+			Name:    pkg.Name,
+			Slug:    pkg.Name[strings.Index(pkg.Name, "/")+1:],
+			Version: pkg.Version,
+			Source:  wpSourceComposer,
+		}
+		if isTheme {
+			(*info).Themes = mergeExtension((*info).Themes, ext)
+		} else {
+			(*info).Plugins = mergeExtension((*info).Plugins, ext)
+		}
+	}
+	return found
+}
+
+// mergeExtension adds ext unless an extension with the same slug exists (filling a missing version)
+func mergeExtension(extensions []parsers.WordPressExtension, ext parsers.WordPressExtension) []parsers.WordPressExtension {
+	for i := range extensions {
+		if extensions[i].Slug == ext.Slug {
+			if extensions[i].Version == "" {
+				extensions[i].Version = ext.Version
+			}
+			return extensions
+		}
+	}
+	return append(extensions, ext)
+}
+
+// scanWordPressPlugins reads plugin headers from a plugins directory.
+// Plugins are single PHP files or directories whose main file has a "Plugin Name" header.
+func (d *Detector) scanWordPressPlugins(pluginsDir string, provider types.Provider, parser *parsers.PHPParser) []parsers.WordPressExtension {
+	entries, err := provider.ListDir(pluginsDir)
+	if err != nil {
+		return nil
+	}
+
+	var plugins []parsers.WordPressExtension
+	for _, entry := range entries {
+		var plugin *parsers.WordPressExtension
+		switch {
+		case entry.Type == "dir":
+			plugin = d.readPluginDir(filepath.Join(pluginsDir, entry.Name), provider, parser)
+		case strings.HasSuffix(entry.Name, ".php"):
+			plugin = d.readPluginFile(filepath.Join(pluginsDir, entry.Name), provider, parser)
+		}
+		if plugin == nil {
+			continue
+		}
+		plugin.Slug = strings.TrimSuffix(entry.Name, ".php")
+		plugins = append(plugins, *plugin)
+	}
+	return plugins
+}
+
+// scanWordPressThemes reads theme headers from style.css of each theme directory
+func (d *Detector) scanWordPressThemes(themesDir string, provider types.Provider, parser *parsers.PHPParser) []parsers.WordPressExtension {
+	entries, err := provider.ListDir(themesDir)
+	if err != nil {
+		return nil
+	}
+
+	var themes []parsers.WordPressExtension
+	for _, entry := range entries {
+		if entry.Type != "dir" {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(themesDir, entry.Name, "style.css"))
+		if err != nil {
+			continue
+		}
+		theme := parser.ParseWordPressHeader(string(content), parsers.WordPressThemeHeader)
+		if theme == nil {
+			continue
+		}
+		theme.Slug = entry.Name
+		theme.Source = wpSourceHeader
+		themes = append(themes, *theme)
+	}
+	return themes
+}
+
+// readPluginDir finds the main plugin file of a plugin directory, trying <slug>.php first
+func (d *Detector) readPluginDir(dir string, provider types.Provider, parser *parsers.PHPParser) *parsers.WordPressExtension {
+	if plugin := d.readPluginFile(filepath.Join(dir, filepath.Base(dir)+".php"), provider, parser); plugin != nil {
+		return plugin
+	}
+	entries, err := provider.ListDir(dir)
+	if err != nil {
+		return nil
+	}
+	for _, entry := range entries {
+		if entry.Type == "dir" || !strings.HasSuffix(entry.Name, ".php") {
+			continue
+		}
+		if plugin := d.readPluginFile(filepath.Join(dir, entry.Name), provider, parser); plugin != nil {
+			return plugin
+		}
+	}
+	return nil
+}
+
+func (d *Detector) readPluginFile(path string, provider types.Provider, parser *parsers.PHPParser) *parsers.WordPressExtension {
+	content, err := provider.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	plugin := parser.ParseWordPressHeader(string(content), parsers.WordPressPluginHeader)
+	if plugin != nil {
+		plugin.Source = wpSourceHeader
+	}
+	return plugin
+}
+
+// isWordPressCandidate reports whether a directory without a composer component may contain
+// a WordPress installation, plugin, or theme
+func isWordPressCandidate(files []types.File, currentPath string) bool {
+	return hasDir(files, "wp-content") ||
+		hasFile(files, "style.css") ||
+		hasFile(files, "readme.txt") ||
+		hasFile(files, filepath.Base(currentPath)+".php")
+}
+
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
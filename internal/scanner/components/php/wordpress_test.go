@@ -0,0 +1,158 @@
+package php
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetector_Detect_WordPress(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{
+		files: map[string]string{
+			"/site/wp-includes/version.php": "<?php\n$wp_version = '6.5.3';\n",
+			"/site/wp-content/plugins/akismet/akismet.php": `<?php
+/**
+ * Plugin Name: Akismet Anti-spam: Spam Protection
+ * Version: 5.3.2
+ * Requires at least: 5.8
+ * Requires PHP: 5.6.20
+ */`,
+			"/site/wp-content/plugins/woocommerce/includes/class-wc.php": "<?php\nclass WC {}\n",
+			"/site/wp-content/plugins/woocommerce/plugin.php":            "<?php\n/*\nPlugin Name: WooCommerce\nVersion: 8.9.1\n*/\n",
+			"/site/wp-content/plugins/hello.php":                         "<?php\n/*\nPlugin Name: Hello Dolly\nVersion: 1.7.2\n*/\n",
+			"/site/wp-content/plugins/index.php":                         "<?php\n// Silence is golden.\n",
+			"/site/wp-content/mu-plugins/cache.php":                      "<?php\n/* Plugin Name: Object Cache */\n",
+			"/site/wp-content/themes/twentytwentyfour/style.css":         "/*\nTheme Name: Twenty Twenty-Four\nVersion: 1.1\nRequires at least: 6.4\n*/\n",
+			"/site/wp-content/themes/broken/style.css":                   "body { margin: 0; }",
+		},
+	}
+	files := []types.File{
+		{Name: "wp-config.php", Type: "file"},
+		{Name: "wp-content", Type: "dir"},
+		{Name: "wp-includes", Type: "dir"},
+	}
+
+	results := detector.Detect(files, "/site", "/site", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Equal(t, "virtual", payload.Name, "WordPress without composer.json is merged into the parent")
+	assert.Contains(t, payload.Techs, "wordpress")
+
+	wordpress := payload.Properties["wordpress"].(*parsers.WordPressInfo)
+	assert.Equal(t, "/wp-content", wordpress.File)
+	assert.Equal(t, "6.5.3", wordpress.Version)
+	require.Len(t, wordpress.Plugins, 4, "plugins and mu-plugins")
+
+	assert.Equal(t, parsers.WordPressExtension{
+		Name: "Akismet Anti-spam: Spam Protection", Slug: "akismet", Version: "5.3.2", RequiresWP: "5.8", RequiresPHP: "5.6.20", Source: "header",
+	}, wordpress.Plugins[0])
+	assert.Equal(t, "cache", wordpress.Plugins[1].Slug)
+	assert.Equal(t, "hello", wordpress.Plugins[2].Slug)
+	assert.Equal(t, "1.7.2", wordpress.Plugins[2].Version)
+	assert.Equal(t, "woocommerce", wordpress.Plugins[3].Slug)
+	assert.Equal(t, "8.9.1", wordpress.Plugins[3].Version)
+
+	require.Len(t, wordpress.Themes, 1, "themes without a Theme Name header are skipped")
+	assert.Equal(t, parsers.WordPressExtension{
+		Name: "Twenty Twenty-Four", Slug: "twentytwentyfour", Version: "1.1", RequiresWP: "6.4", Source: "header",
+	}, wordpress.Themes[0])
+}
+
+func TestDetector_Detect_WordPressComposerManaged(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{
+		files: map[string]string{
+			"/site/composer.json": `{"name": "roots/bedrock", "require": {"roots/wordpress": "6.5.3", "wpackagist-plugin/akismet": "^5.3"}}`,
+			"/site/composer.lock": `{
+  "packages": [
+    {"name": "roots/wordpress", "version": "6.5.3", "type": "metapackage"},
+    {"name": "wpackagist-plugin/akismet", "version": "5.3.2", "type": "wordpress-plugin"},
+    {"name": "wpackagist-plugin/wordfence", "version": "7.11.6", "type": "wordpress-plugin"},
+    {"name": "roots/soil", "version": "4.1.1", "type": "wordpress-muplugin"},
+    {"name": "wpackagist-theme/twentytwentyfour", "version": "1.1", "type": "wordpress-theme"},
+    {"name": "vlucas/phpdotenv", "version": "v5.6.0", "type": "library"}
+  ]
+}`,
+		},
+	}
+	files := []types.File{{Name: "composer.json", Type: "file"}, {Name: "composer.lock", Type: "file"}}
+
+	results := detector.Detect(files, "/site", "/site", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Equal(t, "roots/bedrock", payload.Name)
+	assert.Equal(t, []string{"composer-managed extensions: composer.lock"}, payload.Reason["wordpress"])
+
+	wordpress := payload.Properties["wordpress"].(*parsers.WordPressInfo)
+	assert.Equal(t, "/composer.lock", wordpress.File)
+	assert.Equal(t, "6.5.3", wordpress.Version)
+	assert.Equal(t, []parsers.WordPressExtension{
+		{Name: "wpackagist-plugin/akismet", Slug: "akismet", Version: "5.3.2", Source: "composer"},
+		{Name: "roots/soil", Slug: "soil", Version: "4.1.1", Source: "composer"},
+		{Name: "wpackagist-plugin/wordfence", Slug: "wordfence", Version: "7.11.6", Source: "composer"},
+	}, wordpress.Plugins)
+	assert.Equal(t, []parsers.WordPressExtension{
+		{Name: "wpackagist-theme/twentytwentyfour", Slug: "twentytwentyfour", Version: "1.1", Source: "composer"},
+	}, wordpress.Themes)
+}
+
+func TestDetector_Detect_WordPressPluginRepository(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{
+		files: map[string]string{
+			"/repo/my-plugin/my-plugin.php": "<?php\n/**\n * Plugin Name: My Plugin\n * Version: 2.0.1\n * Requires PHP: 8.1\n */\n",
+		},
+	}
+	files := []types.File{{Name: "my-plugin.php", Type: "file"}, {Name: "includes", Type: "dir"}}
+
+	results := detector.Detect(files, "/repo/my-plugin", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Equal(t, "virtual", payload.Name)
+	assert.Equal(t, []string{"matched file header: my-plugin.php"}, payload.Reason["wordpress"])
+
+	wordpress := payload.Properties["wordpress"].(*parsers.WordPressInfo)
+	assert.Equal(t, "/my-plugin/my-plugin.php", wordpress.File)
+	assert.Equal(t, []parsers.WordPressExtension{
+		{Name: "My Plugin", Slug: "my-plugin", Version: "2.0.1", RequiresPHP: "8.1", Source: "header"},
+	}, wordpress.Plugins)
+}
+
+func TestDetector_Detect_WordPressThemeRepository(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{
+		files: map[string]string{
+			"/repo/style.css": "/*\nTheme Name: Acme Theme\nVersion: 3.2.0\n*/\n",
+		},
+	}
+	files := []types.File{{Name: "style.css", Type: "file"}, {Name: "functions.php", Type: "file"}}
+
+	results := detector.Detect(files, "/repo", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+
+	wordpress := results[0].Properties["wordpress"].(*parsers.WordPressInfo)
+	assert.Equal(t, "/style.css", wordpress.File)
+	require.Len(t, wordpress.Themes, 1)
+	assert.Equal(t, "Acme Theme", wordpress.Themes[0].Name)
+	assert.Equal(t, "3.2.0", wordpress.Themes[0].Version)
+}
+
+func TestDetector_Detect_PlainStylesheet(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{
+		files: map[string]string{
+			"/repo/web/style.css": "body { margin: 0; }",
+		},
+	}
+	files := []types.File{{Name: "style.css", Type: "file"}}
+
+	results := detector.Detect(files, "/repo/web", "/repo", provider, &MockDependencyDetector{})
+	assert.Empty(t, results)
+}
@@ -16,6 +16,20 @@ import (
 // Detector implements Python component detection
 type Detector struct{}
 
+// devRequirementFiles are the requirement files of development and test tools, with the scope of
+// their dependencies
+var devRequirementFiles = []struct {
+	name  string
+	scope types.Scope
+}{
+	{"requirements-dev.txt", types.ScopeDev},
+	{"requirements_dev.txt", types.ScopeDev},
+	{"dev-requirements.txt", types.ScopeDev},
+	{"requirements-test.txt", types.ScopeTest},
+	{"requirements_test.txt", types.ScopeTest},
+	{"test-requirements.txt", types.ScopeTest},
+}
+
 // Name returns the detector name
 func (d *Detector) Name() string {
 	return "python"
@@ -23,13 +37,17 @@ func (d *Detector) Name() string {
 
 // Detect scans for Python projects with priority-based detection:
 // Priority 1: pyproject.toml (supports Poetry, uv, and other PEP 518 tools)
-// Priority 2: requirements.txt (PEP 508 compliant dependency parsing)
-// Priority 3: setup.py (basic detection, no dependency parsing)
+// Priority 2: Pipfile (Pipenv, versions pinned by Pipfile.lock)
+// Priority 3: requirements.txt (PEP 508 compliant dependency parsing)
+// Priority 4: setup.py (basic detection, no dependency parsing)
 //
 // If pyproject.toml is found and successfully parsed, lower-priority files are skipped.
+// Development and test requirement files next to them (requirements-dev.txt, ...) add
+// dependencies with the dev or test scope.
 func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
 	// Scan files to determine what's available
 	hasPyprojectToml := false
+	hasPipfile := false
 	hasRequirementsTxt := false
 	hasSetupPy := false
 
@@ -37,6 +55,8 @@ func (d *Detector) Detect(files []types.File, currentPath, basePath string, prov
 		switch file.Name {
 		case "pyproject.toml":
 			hasPyprojectToml = true
+		case "Pipfile":
+			hasPipfile = true
 		case "requirements.txt":
 			hasRequirementsTxt = true
 		case "setup.py":
@@ -46,19 +66,26 @@ func (d *Detector) Detect(files []types.File, currentPath, basePath string, prov
 
 	// Priority 1: pyproject.toml
 	if hasPyprojectToml {
-		if payload := d.detectFromPyprojectToml(currentPath, basePath, provider, depDetector); payload != nil {
+		if payload := d.detectFromPyprojectToml(files, currentPath, basePath, provider, depDetector); payload != nil {
+			return []*types.Payload{payload}
+		}
+	}
+
+	// Priority 2: Pipfile (only if pyproject.toml didn't produce a component)
+	if hasPipfile {
+		if payload := d.detectFromPipfile(files, currentPath, basePath, provider, depDetector); payload != nil {
 			return []*types.Payload{payload}
 		}
 	}
 
-	// Priority 2: requirements.txt (only if pyproject.toml didn't produce a component)
+	// Priority 3: requirements.txt (only if neither pyproject.toml nor Pipfile produced a component)
 	if hasRequirementsTxt {
-		if payload := d.detectFromRequirementsTxt(currentPath, basePath, provider, depDetector); payload != nil {
+		if payload := d.detectFromRequirementsTxt(files, currentPath, basePath, provider, depDetector); payload != nil {
 			return []*types.Payload{payload}
 		}
 	}
 
-	// Priority 3: setup.py (only if neither pyproject.toml nor requirements.txt produced a component)
+	// Priority 4: setup.py (only if no other project file produced a component)
 	if hasSetupPy {
 		if payload := d.detectFromSetupPy(currentPath, basePath); payload != nil {
 			return []*types.Payload{payload}
@@ -70,7 +97,7 @@ func (d *Detector) Detect(files []types.File, currentPath, basePath string, prov
 
 // detectFromPyprojectToml creates a component from pyproject.toml.
 // Falls back to directory name if no [project] or [tool.poetry] name is found.
-func (d *Detector) detectFromPyprojectToml(currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
+func (d *Detector) detectFromPyprojectToml(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
 	content, err := provider.ReadFile(filepath.Join(currentPath, "pyproject.toml"))
 	if err != nil {
 		return nil
@@ -92,6 +119,7 @@ func (d *Detector) detectFromPyprojectToml(currentPath, basePath string, provide
 
 	// Parse dependencies using lock file priority system
 	dependencies := extractDependenciesWithPriority(currentPath, projectName, string(content), provider)
+	dependencies = append(dependencies, devRequirements(files, currentPath, basePath, provider, dependencies)...)
 	d.matchAndAddDependencies(payload, dependencies, depDetector)
 
 	// Detect license
@@ -100,11 +128,43 @@ func (d *Detector) detectFromPyprojectToml(currentPath, basePath string, provide
 	return payload
 }
 
+// detectFromPipfile creates a component from a Pipenv Pipfile, with the versions pinned by
+// Pipfile.lock when lock files are used. Uses the directory name as the component name.
+func (d *Detector) detectFromPipfile(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
+	content, err := provider.ReadFile(filepath.Join(currentPath, "Pipfile"))
+	if err != nil {
+		return nil
+	}
+
+	projectName := dirName(currentPath, basePath)
+	relativeFilePath := relativePath(basePath, currentPath, "Pipfile")
+
+	payload := types.NewPayloadWithPath(projectName, relativeFilePath)
+	payload.SetComponentType("python")
+	payload.AddPrimaryTech("python")
+	payload.SetComponentProperty("python", "package_name", projectName)
+
+	parser := parsers.NewPythonParser()
+	var dependencies []types.Dependency
+	if components.UseLockFiles() {
+		if lockContent, err := provider.ReadFile(filepath.Join(currentPath, "Pipfile.lock")); err == nil && len(lockContent) > 0 {
+			dependencies = parser.ParsePipfileLock(lockContent, string(content), parsers.ParsePipfileLockOptions{})
+		}
+	}
+	if dependencies == nil {
+		dependencies = parser.ParsePipfile(string(content))
+	}
+	parsers.MarkNativePythonPackages(dependencies)
+	dependencies = append(dependencies, devRequirements(files, currentPath, basePath, provider, dependencies)...)
+	d.matchAndAddDependencies(payload, dependencies, depDetector)
+
+	return payload
+}
+
 // detectFromRequirementsTxt creates a component from requirements.txt.
 // Uses the directory name as the component name and parses PEP 508 dependencies.
-func (d *Detector) detectFromRequirementsTxt(currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
-	content, err := provider.ReadFile(filepath.Join(currentPath, "requirements.txt"))
-	if err != nil {
+func (d *Detector) detectFromRequirementsTxt(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
+	if _, err := provider.ReadFile(filepath.Join(currentPath, "requirements.txt")); err != nil {
 		return nil
 	}
 
@@ -116,14 +176,68 @@ func (d *Detector) detectFromRequirementsTxt(currentPath, basePath string, provi
 	payload.AddPrimaryTech("python")
 	payload.SetComponentProperty("python", "package_name", projectName)
 
-	// Parse requirements.txt using the PEP 508 compliant parser
+	// Parse requirements.txt and the files it includes using the PEP 508 compliant parser
 	parser := parsers.NewPythonParser()
-	dependencies := parser.ParseRequirementsTxt(string(content))
+	dependencies := parser.ParseRequirementsFile("requirements.txt", requirementsReader(currentPath, basePath, provider))
+	parsers.MarkNativePythonPackages(dependencies)
+	dependencies = append(dependencies, devRequirements(files, currentPath, basePath, provider, dependencies)...)
 	d.matchAndAddDependencies(payload, dependencies, depDetector)
 
 	return payload
 }
 
+// devRequirements returns the dependencies of the development and test requirement files of a
+// directory, with the dev or test scope. Packages of the main dependencies, and of earlier files,
+// are left out.
+func devRequirements(files []types.File, currentPath, basePath string, provider types.Provider, main []types.Dependency) []types.Dependency {
+	present := make(map[string]bool, len(files))
+	for _, file := range files {
+		present[file.Name] = true
+	}
+	seen := make(map[string]bool, len(main))
+	for _, dep := range main {
+		seen[dep.Name] = true
+	}
+
+	var dependencies []types.Dependency
+	parser := parsers.NewPythonParser()
+	for _, file := range devRequirementFiles {
+		if !present[file.name] {
+			continue
+		}
+		for _, dep := range parser.ParseRequirementsFile(file.name, requirementsReader(currentPath, basePath, provider)) {
+			if seen[dep.Name] {
+				continue
+			}
+			seen[dep.Name] = true
+			dep.Scope = file.scope
+			dep.Metadata["source"] = file.name
+			dependencies = append(dependencies, dep)
+		}
+	}
+	parsers.MarkNativePythonPackages(dependencies)
+	return dependencies
+}
+
+// requirementsReader returns the file reader of parsers.ParseRequirementsFile for the
+// requirement files of a directory: names are relative to the directory, and files outside the
+// directory and the scanned project are not read
+func requirementsReader(currentPath, basePath string, provider types.Provider) func(string) ([]byte, error) {
+	return func(name string) ([]byte, error) {
+		file := filepath.Join(currentPath, filepath.FromSlash(name))
+		if !isWithin(currentPath, file) && !isWithin(basePath, file) {
+			return nil, fmt.Errorf("requirements file %s is outside the scanned project", name)
+		}
+		return provider.ReadFile(file)
+	}
+}
+
+// isWithin reports whether path is inside root
+func isWithin(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
 // detectFromSetupPy creates a basic component from setup.py.
 // Does not parse dependencies (setup.py is executable Python, not statically parseable).
 func (d *Detector) detectFromSetupPy(currentPath, basePath string) *types.Payload {
@@ -220,11 +334,11 @@ func extractProjectName(content string) string {
 	return ""
 }
 
-// parseDependencies parses dependencies from pyproject.toml
+// parseDependencies parses the Poetry dependency tables ([tool.poetry.dependencies]) of
+// pyproject.toml; PEP 621 requirements are read by parsers.ParsePyproject
 func parseDependencies(content string) []types.Dependency {
 	var dependencies []types.Dependency
 	lineReg := regexp.MustCompile(`^([a-zA-Z0-9._-]+)(\s*=\s*(.*))?$`)
-	arrayDepReg := regexp.MustCompile(`^([a-zA-Z0-9._\-\[\]]+)([>=<]+[^"]*)?`)
 
 	lines := strings.Split(content, "\n")
 	state := &dependencyParseState{}
@@ -235,7 +349,7 @@ func parseDependencies(content string) []types.Dependency {
 		state = updateDependencyState(state, line)
 
 		if shouldParseDependency(state, line) {
-			if dep := parseDependencyLine(line, state.inArrayDependencies, lineReg, arrayDepReg); dep != nil {
+			if dep := parseKeyValueDependency(line, lineReg); dep != nil {
 				dependencies = append(dependencies, *dep)
 			}
 		}
@@ -244,6 +358,18 @@ func parseDependencies(content string) []types.Dependency {
 	return dependencies
 }
 
+// pyprojectDependencies returns the dependencies declared in pyproject.toml: the PEP 621
+// requirements followed by the Poetry dependency tables
+func pyprojectDependencies(content string) []types.Dependency {
+	dependencies := parsers.NewPythonParser().ParsePyproject(content)
+	for _, dep := range parseDependencies(content) {
+		dep.SourceFile = "pyproject.toml"
+		dependencies = append(dependencies, dep)
+	}
+	parsers.MarkNativePythonPackages(dependencies)
+	return dependencies
+}
+
 // extractDependenciesWithPriority extracts dependencies using lock file priority system
 // Priority 1: uv.lock (resolved versions)
 // Priority 2: poetry.lock (resolved versions)
@@ -251,11 +377,7 @@ func parseDependencies(content string) []types.Dependency {
 func extractDependenciesWithPriority(currentPath, projectName, pyprojectContent string, provider types.Provider) []types.Dependency {
 	// Check if lock files are enabled
 	if !components.UseLockFiles() {
-		dependencies := parseDependencies(pyprojectContent)
-		for i := range dependencies {
-			dependencies[i].SourceFile = "pyproject.toml"
-		}
-		return dependencies
+		return pyprojectDependencies(pyprojectContent)
 	}
 
 	// Priority 1: Check for uv.lock
@@ -275,42 +397,23 @@ func extractDependenciesWithPriority(currentPath, projectName, pyprojectContent
 	}
 
 	// Priority 3: Fallback to pyproject.toml
-	dependencies := parseDependencies(pyprojectContent)
-
-	// Add source file information
-	for i := range dependencies {
-		dependencies[i].SourceFile = "pyproject.toml"
-	}
-
-	return dependencies
+	return pyprojectDependencies(pyprojectContent)
 }
 
 // dependencyParseState tracks the current parsing state
 type dependencyParseState struct {
-	inProjectSection      bool
 	inDependenciesSection bool
-	inArrayDependencies   bool
-	expectingDependencies bool
 }
 
 // updateDependencyState updates the parsing state based on the current line
 func updateDependencyState(state *dependencyParseState, line string) *dependencyParseState {
 	newState := *state // copy state
 
-	if line == "[project]" {
-		newState.inProjectSection = true
-	} else if line == "[project.dependencies]" {
-		newState.inDependenciesSection = true
-		newState.inArrayDependencies = true
-	} else if line == "[tool.poetry.dependencies]" || line == "[tool.uv.sources]" {
+	if line == "[tool.poetry.dependencies]" || line == "[tool.uv.sources]" {
 		newState.inDependenciesSection = true
-		newState.inArrayDependencies = false
 	} else if strings.HasPrefix(line, "[") {
 		// Reset all state on any other section
 		newState = dependencyParseState{}
-	} else if newState.inProjectSection && strings.HasPrefix(line, "dependencies") {
-		newState.expectingDependencies = true
-		newState.inArrayDependencies = true
 	}
 
 	return &newState
@@ -318,45 +421,9 @@ func updateDependencyState(state *dependencyParseState, line string) *dependency
 
 // shouldParseDependency determines if the current line should be parsed as a dependency
 func shouldParseDependency(state *dependencyParseState, line string) bool {
-	return (state.inDependenciesSection || state.expectingDependencies) &&
+	return state.inDependenciesSection &&
 		line != "" && !strings.HasPrefix(line, "#") && line != "]" && line != "[" &&
-		!strings.HasPrefix(line, "dependencies") && !strings.HasPrefix(line, "[")
-}
-
-// parseDependencyLine parses a single dependency line
-func parseDependencyLine(line string, isArrayFormat bool, lineReg, arrayDepReg *regexp.Regexp) *types.Dependency {
-	line = strings.TrimSpace(line)
-	line = strings.TrimPrefix(line, `"`)
-	line = strings.TrimSuffix(line, `",`)
-	line = strings.TrimSuffix(line, `"`)
-
-	if isArrayFormat {
-		return parseArrayDependency(line, arrayDepReg)
-	}
-	return parseKeyValueDependency(line, lineReg)
-}
-
-// parseArrayDependency parses array format dependencies like "fastapi>=0.104.0"
-func parseArrayDependency(line string, arrayDepReg *regexp.Regexp) *types.Dependency {
-	match := arrayDepReg.FindStringSubmatch(line)
-	if match == nil {
-		return nil
-	}
-
-	name := match[1]
-	version := match[2]
-	if version == "" {
-		version = "latest"
-	} else {
-		// Clean version by stripping operators
-		version = extractVersion(version)
-	}
-
-	return &types.Dependency{
-		Type:    "python",
-		Name:    name,
-		Version: version,
-	}
+		!strings.HasPrefix(line, "[")
 }
 
 // parseKeyValueDependency parses key-value format dependencies like "fastapi = ^0.104.1"
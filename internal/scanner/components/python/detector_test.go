@@ -4,6 +4,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -130,17 +131,19 @@ dev = [
 	assert.True(t, found, "Should detect MIT license")
 
 	// Check dependencies
-	assert.Len(t, payload.Dependencies, 3, "Should have 3 dependencies")
+	assert.Len(t, payload.Dependencies, 7, "Should have 3 dependencies, 2 optional, and 2 build requirements")
 
-	depNames := make(map[string]bool)
+	depScopes := make(map[string]types.Scope)
 	for _, dep := range payload.Dependencies {
-		depNames[dep.Name] = true
+		depScopes[dep.Name] = dep.Scope
 		assert.Equal(t, "python", dep.Type, "All dependencies should be python type")
 	}
 
-	assert.True(t, depNames["flask"], "Should have flask dependency")
-	assert.True(t, depNames["requests"], "Should have requests dependency")
-	assert.True(t, depNames["numpy"], "Should have numpy dependency")
+	assert.Equal(t, map[string]types.Scope{
+		"flask": types.ScopeProd, "requests": types.ScopeProd, "numpy": types.ScopeProd,
+		"pytest": types.ScopeOptional, "black": types.ScopeOptional,
+		"setuptools": types.ScopeBuild, "wheel": types.ScopeBuild,
+	}, depScopes)
 }
 
 func TestDetector_Detect_PoetryFormat(t *testing.T) {
@@ -235,7 +238,7 @@ dependencies = [
 	assert.Equal(t, "project", payload.Name, "Should use directory name when pyproject.toml has no name")
 	assert.Contains(t, payload.Tech, "python", "Should have python as primary tech")
 	assert.Contains(t, payload.Techs, "flask", "Should detect flask from dependencies")
-	assert.Len(t, payload.Dependencies, 1, "Should have 1 dependency")
+	assert.Len(t, payload.Dependencies, 3, "Should have 1 dependency and 2 build requirements")
 }
 
 func TestDetector_Detect_RequirementsTxtOnly(t *testing.T) {
@@ -474,7 +477,10 @@ dev = []
 	payload := results[0]
 	assert.Equal(t, "no-deps-app", payload.Name)
 	assert.Contains(t, payload.Tech, "python", "Should have python as primary tech")
-	assert.Empty(t, payload.Dependencies, "Should have no dependencies")
+	require.Len(t, payload.Dependencies, 2, "Should have the build requirements only")
+	for _, dep := range payload.Dependencies {
+		assert.Equal(t, types.ScopeBuild, dep.Scope)
+	}
 }
 
 func TestDetector_Detect_RelativePathHandling(t *testing.T) {
@@ -591,8 +597,8 @@ dependencies = [
     "numpy",
 ]`,
 			expected: []types.Dependency{
-				{Type: "python", Name: "flask", Version: "2.0.0"},
-				{Type: "python", Name: "requests", Version: "2.26.0"},
+				{Type: "python", Name: "flask", Version: ">=2.0.0"},
+				{Type: "python", Name: "requests", Version: "==2.26.0"},
 				{Type: "python", Name: "numpy", Version: "latest"},
 			},
 		},
@@ -627,7 +633,7 @@ name = "test-app"
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := parseDependencies(tt.content)
+			result := pyprojectDependencies(tt.content)
 			assert.Equal(t, len(tt.expected), len(result), "Should have correct number of dependencies")
 
 			for i, expectedDep := range tt.expected {
@@ -704,3 +710,113 @@ license = "MIT"
 		})
 	}
 }
+
+func TestParseDependencies_EnvironmentMarker(t *testing.T) {
+	deps := pyprojectDependencies(`[project]
+name = "app"
+dependencies = [
+    "pywin32>=306; sys_platform == 'win32'",
+    "requests>=2.31",
+]
+`)
+
+	require.Len(t, deps, 2)
+	assert.Equal(t, "pywin32", deps[0].Name)
+	assert.Equal(t, ">=306", deps[0].Version, "marker is not part of the version")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"kind": "marker", "conditions": []string{"sys_platform == 'win32'"}},
+	}, deps[0].Metadata["activation"])
+	assert.NotContains(t, deps[1].Metadata, "activation")
+}
+
+func TestDetector_Detect_DevRequirements(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/requirements.txt":      "flask==3.0.0\n",
+			"/project/requirements-dev.txt":  "black==24.1.0\nflask==3.0.0\n",
+			"/project/test-requirements.txt": "pytest==8.0.0\nblack==24.1.0\n",
+		},
+	}
+	files := []types.File{
+		{Name: "requirements.txt", Path: "/project/requirements.txt"},
+		{Name: "requirements-dev.txt", Path: "/project/requirements-dev.txt"},
+		{Name: "test-requirements.txt", Path: "/project/test-requirements.txt"},
+	}
+
+	results := detector.Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+
+	scopes := make(map[string]types.Scope)
+	sources := make(map[string]interface{})
+	for _, dep := range results[0].Dependencies {
+		scopes[dep.Name] = dep.Scope
+		sources[dep.Name] = dep.Metadata["source"]
+	}
+	assert.Equal(t, map[string]types.Scope{"flask": types.ScopeProd, "black": types.ScopeDev, "pytest": types.ScopeTest}, scopes,
+		"main dependencies keep their scope, earlier files win")
+	assert.Equal(t, "requirements-dev.txt", sources["black"])
+	assert.Equal(t, "test-requirements.txt", sources["pytest"])
+}
+
+func TestDetector_Detect_RequirementsIncludes(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/app/requirements.txt":     "-r ../requirements/base.txt\n-c ../../outside/constraints.txt\ngunicorn\n",
+			"/project/app/requirements-dev.txt": "-r requirements.txt\nblack==24.1.0\n",
+			"/project/requirements/base.txt":    "flask==3.0.0\n",
+			"/outside/constraints.txt":          "gunicorn==21.2.0\n",
+		},
+	}
+	files := []types.File{
+		{Name: "requirements.txt", Path: "/project/app/requirements.txt"},
+		{Name: "requirements-dev.txt", Path: "/project/app/requirements-dev.txt"},
+	}
+
+	results := detector.Detect(files, "/project/app", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+
+	byName := make(map[string]types.Dependency)
+	for _, dep := range results[0].Dependencies {
+		byName[dep.Name] = dep
+	}
+	require.Len(t, byName, 3)
+	assert.Equal(t, types.ScopeProd, byName["flask"].Scope)
+	assert.Equal(t, "../requirements/base.txt", byName["flask"].Metadata["requirements_file"])
+	assert.Equal(t, "latest", byName["gunicorn"].Version, "constraints outside the scanned project are not read")
+	assert.Equal(t, types.ScopeDev, byName["black"].Scope, "main requirements included by the dev file keep their scope")
+}
+
+func TestDetector_Detect_Pipfile(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/Pipfile":              "[packages]\nrequests = \"*\"\nnumpy = \"*\"\n\n[dev-packages]\npytest = \"*\"\n",
+			"/project/Pipfile.lock":         `{"default": {"requests": {"hashes": ["sha256:58cd"], "version": "==2.31.0"}, "numpy": {"version": "==1.26.4"}}, "develop": {"pytest": {"version": "==8.1.1"}}}`,
+			"/project/requirements.txt":     "requests\n",
+			"/project/requirements-dev.txt": "black==24.1.0\n",
+		},
+	}
+	files := []types.File{
+		{Name: "Pipfile", Path: "/project/Pipfile"},
+		{Name: "Pipfile.lock", Path: "/project/Pipfile.lock"},
+		{Name: "requirements.txt", Path: "/project/requirements.txt"},
+		{Name: "requirements-dev.txt", Path: "/project/requirements-dev.txt"},
+	}
+
+	results := detector.Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.Equal(t, []string{"/Pipfile"}, results[0].Path, "Pipfile takes priority over requirements.txt")
+
+	deps := make(map[string]types.Dependency)
+	for _, dep := range results[0].Dependencies {
+		deps[dep.Name] = dep
+	}
+	require.Len(t, deps, 4)
+	assert.Equal(t, "2.31.0", deps["requests"].Version)
+	assert.Equal(t, []string{"sha256:58cd"}, deps["requests"].Metadata[parsers.MetadataPipfileHashes])
+	assert.Equal(t, true, deps["numpy"].Metadata[parsers.MetadataNative])
+	assert.Equal(t, types.ScopeDev, deps["pytest"].Scope)
+	assert.Equal(t, types.ScopeDev, deps["black"].Scope)
+}
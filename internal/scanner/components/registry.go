@@ -4,9 +4,11 @@ import "sync"
 
 // Global registry for component detectors
 var (
-	detectors    []Detector
-	mu           sync.RWMutex
-	useLockFiles = true // Default to true
+	detectors         []Detector
+	mu                sync.RWMutex
+	useLockFiles      = true // Default to true
+	mavenProfiles     []string
+	includeTransitive bool
 )
 
 // Register adds a component detector to the registry
@@ -36,3 +38,33 @@ func UseLockFiles() bool {
 	defer mu.RUnlock()
 	return useLockFiles
 }
+
+// SetMavenProfiles sets the Maven profiles to consider ("!id" deselects, "*" selects all)
+func SetMavenProfiles(profiles []string) {
+	mu.Lock()
+	defer mu.Unlock()
+	mavenProfiles = profiles
+}
+
+// MavenProfiles returns the Maven profiles to consider (empty = activation conditions only)
+func MavenProfiles() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return mavenProfiles
+}
+
+// SetIncludeTransitive sets whether the lock files listing the full package graph (Cargo.lock,
+// go.sum) add the transitive dependencies
+func SetIncludeTransitive(include bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	includeTransitive = include
+}
+
+// IncludeTransitive returns whether lock files add the transitive dependencies (default false:
+// direct dependencies only)
+func IncludeTransitive() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return includeTransitive
+}
@@ -79,7 +79,12 @@ func (d *Detector) detectGemfile(file types.File, currentPath, basePath string,
 		lockContent, err := provider.ReadFile(filepath.Join(currentPath, "Gemfile.lock"))
 		if err == nil {
 			lockParser := parsers.NewGemfileLockParser()
-			dependencies = lockParser.ParseGemfileLock(string(lockContent))
+			var lockMetadata map[string]interface{}
+			dependencies, lockMetadata = lockParser.ParseGemfileLockWithMetadata(string(lockContent))
+			// Platforms the bundle is resolved for (PLATFORMS), for the platform support matrix
+			if platforms, ok := lockMetadata["platforms"].([]string); ok {
+				payload.SetComponentProperty("ruby", "lock_platforms", platforms)
+			}
 		}
 	}
 
@@ -88,6 +93,7 @@ func (d *Detector) detectGemfile(file types.File, currentPath, basePath string,
 		rubyParser := parsers.NewRubyParser()
 		dependencies = rubyParser.ParseGemfile(string(content))
 	}
+	parsers.LocateDependencies(dependencies, relativeFilePath, parsers.GemfileLines(string(content)))
 
 	// Extract dependency names for tech matching
 	var depNames []string
@@ -41,9 +41,11 @@ func (d *Detector) detectCargoToml(file types.File, currentPath, basePath string
 
 	// Parse Cargo.toml using parser
 	rustParser := parsers.NewRustParser()
-	projectName, license, _, isWorkspace := rustParser.ParseCargoToml(string(content))
+	projectName, license, _, _ := rustParser.ParseCargoToml(string(content))
+	manifest := parsers.ParseCargoManifest(string(content))
+	workspace := findWorkspace(manifest, currentPath, basePath, provider)
 
-	// Create payload (named if not workspace and has package section, virtual otherwise)
+	// Create payload (named for crates and virtual workspaces, virtual otherwise)
 	var payload *types.Payload
 
 	relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, file.Name))
@@ -53,8 +55,9 @@ func (d *Detector) detectCargoToml(file types.File, currentPath, basePath string
 		relativeFilePath = "/" + relativeFilePath
 	}
 
-	if !isWorkspace && projectName != "" {
-		// Named component for projects with [package] section (not workspace)
+	switch {
+	case projectName != "":
+		// Named component for projects with [package] section, including workspace roots
 		payload = types.NewPayloadWithPath(projectName, relativeFilePath)
 		payload.SetComponentType("rust")
 
@@ -63,18 +66,34 @@ func (d *Detector) detectCargoToml(file types.File, currentPath, basePath string
 
 		// Store crate name in properties for inter-component dependency tracking
 		payload.SetComponentProperty("rust", "crate_name", projectName)
-	} else {
-		// Virtual payload for workspace files or files without [package] section
+	case manifest.Workspace != nil:
+		// Virtual workspace manifest: named after its directory, the members are separate components
+		payload = types.NewPayloadWithPath(filepath.Base(currentPath), relativeFilePath)
+		payload.SetComponentType("rust")
+		payload.AddPrimaryTech("rust")
+	default:
+		// Virtual payload for files without [package] section
 		payload = types.NewPayloadWithPath("virtual", relativeFilePath)
 	}
 
-	// Extract dependencies using lock file priority system
-	dependencies := d.extractDependenciesWithPriority(currentPath, string(content), provider)
+	if manifest.Workspace != nil {
+		if members := workspaceMembers(manifest.Workspace, currentPath, basePath, provider); len(members) > 0 {
+			payload.SetComponentProperty("rust", "workspace_members", members)
+		}
+	}
+	if projectName != "" && len(manifest.Features) > 0 {
+		payload.SetComponentProperty("rust", "features", manifest.Features)
+	}
+
+	// Extract dependencies, with versions of the Cargo.lock of the crate or its workspace
+	dependencies := d.extractDependencies(manifest, string(content), workspace, currentPath, provider)
 
-	// Extract dependency names for tech matching
+	// Extract direct dependency names for tech matching
 	var depNames []string
 	for _, dep := range dependencies {
-		depNames = append(depNames, dep.Name)
+		if dep.Direct {
+			depNames = append(depNames, dep.Name)
+		}
 	}
 
 	// Always add cargo tech
@@ -129,37 +148,48 @@ func (d *Detector) detectCargoToml(file types.File, currentPath, basePath string
 	return payload
 }
 
-// extractDependenciesWithPriority extracts dependencies using lock file priority system
-// Priority 1: Cargo.lock (resolved versions)
-// Priority 2: Cargo.toml (version ranges as fallback)
-func (d *Detector) extractDependenciesWithPriority(currentPath, cargoTomlContent string, provider types.Provider) []types.Dependency {
-	// Check if lock files are enabled
-	if !components.UseLockFiles() {
-		rustParser := parsers.NewRustParser()
-		_, _, dependencies, _ := rustParser.ParseCargoToml(cargoTomlContent)
-		for i := range dependencies {
-			dependencies[i].SourceFile = "Cargo.toml"
+// extractDependencies returns the dependencies of a crate, inheriting workspace declarations.
+// Versions are resolved by the Cargo.lock of the crate, else the one of its workspace root, which
+// also adds the transitive dependencies; without lock file the Cargo.toml versions are used.
+func (d *Detector) extractDependencies(manifest *parsers.CargoManifest, cargoToml string, workspace *cargoWorkspace, currentPath string, provider types.Provider) []types.Dependency {
+	var root *parsers.CargoManifest
+	workspaceDir := ""
+	lockDirs := []string{currentPath}
+	if workspace != nil {
+		root = workspace.manifest
+		if rel, err := filepath.Rel(currentPath, workspace.dir); err == nil {
+			workspaceDir = filepath.ToSlash(rel)
+		}
+		if workspace.dir != currentPath {
+			lockDirs = append(lockDirs, workspace.dir)
 		}
-		return dependencies
 	}
 
-	// Priority 1: Check for Cargo.lock
-	if lockContent, err := provider.ReadFile(filepath.Join(currentPath, "Cargo.lock")); err == nil && len(lockContent) > 0 {
-		deps := parsers.ParseCargoLock(lockContent, cargoTomlContent)
-		if len(deps) > 0 {
-			return deps
+	var lockContent []byte
+	if components.UseLockFiles() {
+		for _, dir := range lockDirs {
+			if content, err := provider.ReadFile(filepath.Join(dir, "Cargo.lock")); err == nil && len(content) > 0 {
+				lockContent = content
+				break
+			}
 		}
 	}
-
-	// Priority 2: Fallback to Cargo.toml
-	rustParser := parsers.NewRustParser()
-	_, _, dependencies, _ := rustParser.ParseCargoToml(cargoTomlContent)
-
-	// Add source file information
-	for i := range dependencies {
-		dependencies[i].SourceFile = "Cargo.toml"
+	if lockContent == nil {
+		return parsers.CargoDependencies(manifest, root, workspaceDir, nil)
 	}
 
+	// Direct dependencies keep the declarations of Cargo.toml (features, workspace inheritance)
+	// with the locked versions; with --include-transitive, the packages reachable from them are
+	// added as transitive
+	dependencies := parsers.CargoDependencies(manifest, root, workspaceDir, parsers.CargoLockVersions(lockContent, manifest.PackageName))
+	if !components.IncludeTransitive() {
+		return dependencies
+	}
+	for _, dep := range parsers.ParseCargoLockWithOptions(lockContent, cargoToml, parsers.ParseCargoLockOptions{IncludeTransitive: true}) {
+		if !dep.Direct {
+			dependencies = append(dependencies, dep)
+		}
+	}
 	return dependencies
 }
 
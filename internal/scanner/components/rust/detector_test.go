@@ -4,6 +4,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -12,6 +13,7 @@ import (
 // MockProvider implements types.Provider for testing
 type MockProvider struct {
 	files map[string]string
+	dirs  map[string][]types.File
 }
 
 func (m *MockProvider) ReadFile(path string) ([]byte, error) {
@@ -22,7 +24,7 @@ func (m *MockProvider) ReadFile(path string) ([]byte, error) {
 }
 
 func (m *MockProvider) ListDir(path string) ([]types.File, error) {
-	return nil, nil
+	return m.dirs[path], nil
 }
 
 func (m *MockProvider) Open(path string) (string, error) {
@@ -159,7 +161,9 @@ serde = "1.0"
 	// Setup mock provider
 	provider := &MockProvider{
 		files: map[string]string{
-			"/project/Cargo.toml": cargoContent,
+			"/project/Cargo.toml":              cargoContent,
+			"/project/crates/core/Cargo.toml":  "[package]\nname = \"core\"\n",
+			"/project/crates/utils/Cargo.toml": "[package]\nname = \"utils\"\n",
 		},
 	}
 
@@ -180,10 +184,14 @@ serde = "1.0"
 	require.Len(t, results, 1, "Should detect one Rust workspace")
 
 	payload := results[0]
-	assert.Equal(t, "virtual", payload.Name) // Virtual payload for workspace files
+	assert.Equal(t, "project", payload.Name) // Virtual workspace named after its directory
 	assert.Equal(t, "/Cargo.toml", payload.Path[0])
 	assert.Contains(t, payload.Techs, "cargo", "Should detect cargo from Cargo.toml")
-	assert.Len(t, payload.Dependencies, 2, "Should have 2 workspace dependencies")
+	assert.Empty(t, payload.Dependencies, "Workspace dependencies are attributed to the members using them")
+	assert.Equal(t, []map[string]interface{}{
+		{"name": "core", "path": "/crates/core"},
+		{"name": "utils", "path": "/crates/utils"},
+	}, payload.Properties["rust"].(map[string]interface{})["workspace_members"])
 }
 
 func TestDetector_Detect_CargoTomlWithoutPackage(t *testing.T) {
@@ -502,3 +510,82 @@ version = "0.1.0"
 		})
 	}
 }
+
+func TestDetector_Detect_CargoLockTransitive(t *testing.T) {
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/Cargo.toml": `[package]
+name = "app"
+version = "0.1.0"
+
+[dependencies]
+serde = { version = "1.0", features = ["derive"] }
+
+[dev-dependencies]
+tempfile = "3"
+`,
+			"/project/Cargo.lock": `[[package]]
+name = "app"
+version = "0.1.0"
+dependencies = [
+ "serde",
+ "tempfile",
+]
+
+[[package]]
+name = "serde"
+version = "1.0.195"
+dependencies = [
+ "serde_derive",
+]
+
+[[package]]
+name = "serde_derive"
+version = "1.0.195"
+
+[[package]]
+name = "tempfile"
+version = "3.9.0"
+dependencies = [
+ "fastrand",
+]
+
+[[package]]
+name = "fastrand"
+version = "2.0.1"
+`,
+		},
+	}
+
+	detector := &Detector{}
+	results := detector.Detect([]types.File{{Name: "Cargo.toml"}}, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	require.Len(t, results[0].Dependencies, 2, "direct dependencies only by default")
+
+	components.SetIncludeTransitive(true)
+	t.Cleanup(func() { components.SetIncludeTransitive(false) })
+	results = detector.Detect([]types.File{{Name: "Cargo.toml"}}, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+
+	deps := make(map[string]types.Dependency)
+	for _, dep := range results[0].Dependencies {
+		deps[dep.Name] = dep
+	}
+	require.Len(t, deps, 4)
+
+	serde := deps["serde"]
+	assert.True(t, serde.Direct)
+	assert.Equal(t, "1.0.195", serde.Version)
+	assert.Equal(t, []string{"derive"}, serde.Metadata["features"], "direct dependencies keep the Cargo.toml declaration")
+	assert.True(t, deps["tempfile"].Direct)
+
+	derive := deps["serde_derive"]
+	assert.False(t, derive.Direct)
+	assert.Equal(t, "1.0.195", derive.Version)
+	assert.Equal(t, "Cargo.lock", derive.SourceFile)
+	assert.Equal(t, types.ScopeProd, derive.Scope)
+
+	fastrand := deps["fastrand"]
+	assert.False(t, fastrand.Direct)
+	assert.Equal(t, types.ScopeDev, fastrand.Scope, "scope of the direct dependency it is reached from")
+}
@@ -0,0 +1,121 @@
+package rust
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// cargoWorkspace is the workspace a crate belongs to
+type cargoWorkspace struct {
+	dir      string // Directory of the workspace root
+	manifest *parsers.CargoManifest
+}
+
+// findWorkspace returns the workspace of a crate, like Cargo: the manifest itself if it is a
+// workspace root, the root named by package.workspace, else the first workspace root in the
+// parent directories (up to the scan root) that has the crate as member. Returns nil for crates
+// outside a workspace.
+func findWorkspace(manifest *parsers.CargoManifest, currentPath, basePath string, provider types.Provider) *cargoWorkspace {
+	if manifest.Workspace != nil {
+		return &cargoWorkspace{dir: currentPath, manifest: manifest}
+	}
+	if manifest.PackageWorkspace != "" {
+		dir := filepath.Join(currentPath, filepath.FromSlash(manifest.PackageWorkspace))
+		if root := readManifest(dir, provider); root != nil && root.Workspace != nil {
+			return &cargoWorkspace{dir: dir, manifest: root}
+		}
+		return nil
+	}
+
+	for dir := currentPath; dir != basePath && strings.HasPrefix(dir, basePath); {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+		root := readManifest(dir, provider)
+		if root == nil || root.Workspace == nil {
+			continue
+		}
+		rel, _ := filepath.Rel(dir, currentPath)
+		if !root.Workspace.IsWorkspaceMember(filepath.ToSlash(rel)) {
+			return nil
+		}
+		return &cargoWorkspace{dir: dir, manifest: root}
+	}
+	return nil
+}
+
+// readManifest parses the Cargo.toml of a directory; nil if there is none
+func readManifest(dir string, provider types.Provider) *parsers.CargoManifest {
+	content, err := provider.ReadFile(filepath.Join(dir, "Cargo.toml"))
+	if err != nil {
+		return nil
+	}
+	return parsers.ParseCargoManifest(string(content))
+}
+
+// workspaceMembers lists the member crates of a workspace root with their name and path
+// relative to the scan root ("/crates/core"), sorted by path. Members outside the scan root and
+// directories without Cargo.toml are skipped.
+func workspaceMembers(workspace *parsers.CargoWorkspace, currentPath, basePath string, provider types.Provider) []map[string]interface{} {
+	dirs := make(map[string]bool)
+	for _, member := range workspace.Members {
+		for _, dir := range expandMember(currentPath, strings.Split(path.Clean(member), "/"), provider) {
+			rel, err := filepath.Rel(currentPath, dir)
+			if err != nil || !workspace.IsWorkspaceMember(filepath.ToSlash(rel)) {
+				continue
+			}
+			if rel, err := filepath.Rel(basePath, dir); err != nil || !filepath.IsLocal(rel) && rel != "." {
+				continue
+			}
+			dirs[dir] = true
+		}
+	}
+
+	var members []map[string]interface{}
+	for dir := range dirs {
+		manifest := readManifest(dir, provider)
+		if manifest == nil {
+			continue
+		}
+		rel, _ := filepath.Rel(basePath, dir)
+		member := map[string]interface{}{"path": "/" + filepath.ToSlash(rel)}
+		if manifest.PackageName != "" {
+			member["name"] = manifest.PackageName
+		}
+		members = append(members, member)
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i]["path"].(string) < members[j]["path"].(string) })
+	return members
+}
+
+// expandMember returns the directories a member path matches; segments may be globs ("crates/*")
+func expandMember(dir string, segments []string, provider types.Provider) []string {
+	if len(segments) == 0 {
+		return []string{dir}
+	}
+	segment := segments[0]
+	if !strings.ContainsAny(segment, "*?[") {
+		return expandMember(filepath.Join(dir, segment), segments[1:], provider)
+	}
+	entries, err := provider.ListDir(dir)
+	if err != nil {
+		return nil
+	}
+	var dirs []string
+	for _, entry := range entries {
+		if entry.Type != "dir" {
+			continue
+		}
+		if matched, _ := path.Match(segment, entry.Name); matched {
+			dirs = append(dirs, expandMember(filepath.Join(dir, entry.Name), segments[1:], provider)...)
+		}
+	}
+	return dirs
+}
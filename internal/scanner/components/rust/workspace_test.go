@@ -0,0 +1,112 @@
+package rust
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// workspaceProvider returns a virtual workspace with two member crates and a Cargo.lock at the
+// workspace root
+func workspaceProvider() *MockProvider {
+	return &MockProvider{
+		files: map[string]string{
+			"/repo/Cargo.toml": `[workspace]
+members = ["crates/*"]
+exclude = ["crates/legacy"]
+
+[workspace.dependencies]
+serde = { version = "1.0", features = ["derive"] }
+core = { path = "crates/core" }
+`,
+			"/repo/Cargo.lock": `[[package]]
+name = "serde"
+version = "1.0.195"
+`,
+			"/repo/crates/core/Cargo.toml": `[package]
+name = "core"
+version = "0.1.0"
+`,
+			"/repo/crates/cli/Cargo.toml": `[package]
+name = "cli"
+version = "0.1.0"
+
+[dependencies]
+core.workspace = true
+serde = { workspace = true, features = ["rc"] }
+tokio = { version = "1", optional = true, default-features = false }
+
+[features]
+default = ["async"]
+async = ["dep:tokio", "tokio/rt"]
+`,
+			"/repo/crates/legacy/Cargo.toml": `[package]
+name = "legacy"
+`,
+		},
+		dirs: map[string][]types.File{
+			"/repo/crates": {
+				{Name: "cli", Type: "dir"},
+				{Name: "core", Type: "dir"},
+				{Name: "legacy", Type: "dir"},
+				{Name: "README.md", Type: "file"},
+			},
+		},
+	}
+}
+
+func TestDetector_Detect_WorkspaceMembers(t *testing.T) {
+	detector := &Detector{}
+	results := detector.Detect([]types.File{{Name: "Cargo.toml"}}, "/repo", "/repo", workspaceProvider(), &MockDependencyDetector{})
+
+	require.Len(t, results, 1)
+	assert.Equal(t, "repo", results[0].Name)
+	assert.Equal(t, []map[string]interface{}{
+		{"name": "cli", "path": "/crates/cli"},
+		{"name": "core", "path": "/crates/core"},
+	}, results[0].Properties["rust"].(map[string]interface{})["workspace_members"], "excluded member skipped")
+}
+
+func TestDetector_Detect_WorkspaceMemberDependencies(t *testing.T) {
+	detector := &Detector{}
+	results := detector.Detect([]types.File{{Name: "Cargo.toml"}}, "/repo/crates/cli", "/repo", workspaceProvider(), &MockDependencyDetector{})
+
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.Equal(t, "cli", payload.Name)
+	assert.Equal(t, map[string][]string{"default": {"async"}, "async": {"dep:tokio", "tokio/rt"}},
+		payload.Properties["rust"].(map[string]interface{})["features"])
+
+	deps := make(map[string]types.Dependency)
+	for _, dep := range payload.Dependencies {
+		deps[dep.Name] = dep
+	}
+	require.Len(t, deps, 3)
+
+	core := deps["core"]
+	assert.Equal(t, "path:../../crates/core", core.Version)
+	assert.Equal(t, "../../crates/core", core.Metadata["path"])
+	assert.Equal(t, true, core.Metadata["workspace"])
+
+	serde := deps["serde"]
+	assert.Equal(t, "1.0.195", serde.Version, "version from the Cargo.lock of the workspace root")
+	assert.Equal(t, "Cargo.lock", serde.SourceFile)
+	assert.Equal(t, []string{"derive", "rc"}, serde.Metadata["features"])
+
+	tokio := deps["tokio"]
+	assert.Equal(t, types.ScopeProd, tokio.Scope, "enabled by default features")
+	assert.Equal(t, []string{"rt"}, tokio.Metadata["features"])
+	assert.Equal(t, false, tokio.Metadata["default_features"])
+	assert.Equal(t, []string{"async"}, tokio.Metadata["enabled_by"])
+}
+
+func TestDetector_Detect_ExcludedWorkspaceMember(t *testing.T) {
+	detector := &Detector{}
+	results := detector.Detect([]types.File{{Name: "Cargo.toml"}}, "/repo/crates/legacy", "/repo", workspaceProvider(), &MockDependencyDetector{})
+
+	require.Len(t, results, 1)
+	assert.Equal(t, "legacy", results[0].Name)
+	assert.Empty(t, results[0].Dependencies)
+}
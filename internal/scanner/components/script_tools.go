@@ -0,0 +1,25 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// AddScriptToolTechs attributes technologies for build tools invoked from scripts.
+// Tools are matched against the npm dependency rules, so a tool is detected even when it is
+// installed globally or run via npx and therefore missing from the declared dependencies.
+// source describes where the scripts come from (e.g., "scripts", "Makefile target", "task").
+func AddScriptToolTechs(payload *types.Payload, usage parsers.ScriptToolUsage, source string, depDetector DependencyDetector) {
+	if depDetector == nil {
+		return
+	}
+	for _, tool := range usage.Tools() {
+		matchedTechs := depDetector.MatchDependencies([]string{parsers.ToolPackageName(tool)}, "npm")
+		for tech := range matchedTechs {
+			payload.AddTech(tech, fmt.Sprintf("script command: %s (%s: %s)", tool, source, strings.Join(usage[tool], ", ")))
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Package serverless implements detection of serverless applications defined with the
+// Serverless Framework or AWS SAM/CloudFormation templates, including Lambda runtime
+// deprecation checks.
+package serverless
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// now returns the date used for runtime deprecation checks (overridable in tests)
+var now = time.Now
+
+// serverlessConfigFiles are the Serverless Framework configuration file names
+var serverlessConfigFiles = []string{"serverless.yml", "serverless.yaml"}
+
+// templateFiles are the default AWS SAM template names; other templates are recognized
+// by the suffixes in templateSuffixes
+var (
+	templateFiles    = []string{"template.yaml", "template.yml", "template.json"}
+	templateSuffixes = []string{".template.yaml", ".template.yml", ".template.json", ".cfn.yaml", ".cfn.yml", ".cfn.json"}
+)
+
+// frameworkTechs maps serverless frameworks to their technology
+var frameworkTechs = map[string]string{
+	parsers.ServerlessFrameworkServerless:     "serverless",
+	parsers.ServerlessFrameworkSAM:            "aws.sam",
+	parsers.ServerlessFrameworkCloudFormation: "aws.cloudformation",
+}
+
+// Detector implements serverless application detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "serverless"
+}
+
+// Detect scans for serverless.yml and SAM/CloudFormation templates. Applications are stored
+// in the "serverless" property of a virtual component (merged into parent).
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	parser := parsers.NewServerlessParser()
+	var apps []*parsers.ServerlessInfo
+
+	for _, file := range files {
+		if file.Type == "dir" {
+			continue
+		}
+		var app *parsers.ServerlessInfo
+		switch {
+		case containsName(serverlessConfigFiles, file.Name):
+			content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+			if err != nil {
+				continue
+			}
+			if app, err = parser.ParseServerlessYAML(string(content)); err != nil {
+				continue
+			}
+			app.ApplyPluginVersions(packageVersions(currentPath, provider))
+		case isTemplateFile(file.Name):
+			content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+			if err != nil {
+				continue
+			}
+			app = parser.ParseCloudFormationTemplate(string(content))
+		}
+		if app == nil {
+			continue
+		}
+		relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, file.Name))
+		app.File = "/" + filepath.ToSlash(relativeFilePath)
+		app.CheckRuntimes(now())
+		apps = append(apps, app)
+	}
+	if len(apps) == 0 {
+		return nil
+	}
+	sort.Slice(apps, func(i, j int) bool { return apps[i].File < apps[j].File })
+
+	payload := types.NewPayloadWithPath("virtual", apps[0].File)
+	var properties []interface{}
+	for _, app := range apps {
+		properties = append(properties, app)
+		reason := "matched file: " + filepath.Base(app.File)
+		payload.AddTech(frameworkTechs[app.Framework], reason)
+		if app.Provider == "aws" {
+			payload.AddTech("aws.lambda", reason)
+		}
+		for _, runtime := range app.DeprecatedRuntimes() {
+			payload.AddReason(fmt.Sprintf("deprecated runtime: %s (deprecated %s, %s)", runtime.Runtime, runtime.Deprecation, app.File))
+		}
+	}
+	payload.Properties["serverless"] = properties
+	return []*types.Payload{payload}
+}
+
+// packageVersions returns the declared versions of package.json dependencies in dir
+func packageVersions(dir string, provider types.Provider) map[string]string {
+	content, err := provider.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return nil
+	}
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil
+	}
+	versions := make(map[string]string, len(pkg.Dependencies)+len(pkg.DevDependencies))
+	for name, version := range pkg.DevDependencies {
+		versions[name] = version
+	}
+	for name, version := range pkg.Dependencies {
+		versions[name] = version
+	}
+	return versions
+}
+
+// isTemplateFile reports whether a file name is a SAM or CloudFormation template name
+func isTemplateFile(name string) bool {
+	if containsName(templateFiles, name) {
+		return true
+	}
+	for _, suffix := range templateSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	components.Register(&Detector{})
+}
@@ -0,0 +1,153 @@
+package serverless
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func fixedNow(t *testing.T) {
+	original := now
+	now = func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { now = original })
+}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "serverless", (&Detector{}).Name())
+}
+
+func TestDetect_ServerlessFramework(t *testing.T) {
+	fixedNow(t)
+	provider := &MockProvider{files: map[string]string{
+		"/project/api/serverless.yml": `service: api
+provider:
+  name: aws
+  runtime: nodejs16.x
+functions:
+  hello:
+    handler: handler.hello
+    events:
+      - http: GET hello
+plugins:
+  - serverless-offline
+`,
+		"/project/api/package.json": `{"devDependencies": {"serverless": "^3.38.0", "serverless-offline": "^13.3.0"}}`,
+	}}
+	files := []types.File{
+		{Name: "serverless.yml", Type: "file"},
+		{Name: "package.json", Type: "file"},
+	}
+
+	payloads := (&Detector{}).Detect(files, "/project/api", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Equal(t, "virtual", payload.Name)
+	assert.Contains(t, payload.Techs, "serverless")
+	assert.Contains(t, payload.Techs, "aws.lambda")
+	assert.Contains(t, payload.Reason["_"], "deprecated runtime: nodejs16.x (deprecated 2024-06-12, /api/serverless.yml)")
+
+	apps, ok := payload.Properties["serverless"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, apps, 1)
+	app := apps[0].(*parsers.ServerlessInfo)
+	assert.Equal(t, "/api/serverless.yml", app.File)
+	assert.Equal(t, []parsers.ServerlessPlugin{{Name: "serverless-offline", Version: "^13.3.0"}}, app.Plugins)
+	require.Len(t, app.RuntimeSupport, 1)
+	assert.True(t, app.RuntimeSupport[0].Deprecated)
+}
+
+func TestDetect_SAMTemplate(t *testing.T) {
+	fixedNow(t)
+	provider := &MockProvider{files: map[string]string{
+		"/project/template.yaml": `Transform: AWS::Serverless-2016-10-31
+Resources:
+  Fn:
+    Type: AWS::Serverless::Function
+    Properties:
+      Runtime: python3.12
+      Handler: app.handler
+`,
+		"/project/stack.cfn.json": `{"AWSTemplateFormatVersion": "2010-09-09", "Resources": {"Fn": {"Type": "AWS::Lambda::Function", "Properties": {"Runtime": "go1.x"}}}}`,
+		"/project/chart.yaml":     "name: chart\n",
+	}}
+	files := []types.File{
+		{Name: "template.yaml", Type: "file"},
+		{Name: "stack.cfn.json", Type: "file"},
+		{Name: "chart.yaml", Type: "file"},
+	}
+
+	payloads := (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Contains(t, payload.Techs, "aws.sam")
+	assert.Contains(t, payload.Techs, "aws.cloudformation")
+	assert.Contains(t, payload.Techs, "aws.lambda")
+	assert.Contains(t, payload.Reason["_"], "deprecated runtime: go1.x (deprecated 2024-01-08, /stack.cfn.json)")
+
+	apps := payload.Properties["serverless"].([]interface{})
+	require.Len(t, apps, 2)
+	assert.Equal(t, "/stack.cfn.json", apps[0].(*parsers.ServerlessInfo).File)
+	assert.Equal(t, "/template.yaml", apps[1].(*parsers.ServerlessInfo).File)
+	assert.Empty(t, apps[1].(*parsers.ServerlessInfo).DeprecatedRuntimes())
+}
+
+func TestDetect_NoServerlessFiles(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/project/template.yaml": "apiVersion: v1\nkind: Template\n",
+	}}
+	files := []types.File{{Name: "template.yaml", Type: "file"}}
+
+	assert.Empty(t, (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{}))
+}
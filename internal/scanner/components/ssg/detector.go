@@ -0,0 +1,258 @@
+// Package ssg implements detection of static site generators (Hugo, Jekyll, Gatsby,
+// Astro, Eleventy, Docusaurus) with their versions, themes, and plugins.
+package ssg
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// hugoConfigFiles lists Hugo configuration file names; config.* names are only
+// accepted next to a Hugo site directory (content, layouts, themes, archetypes)
+var (
+	hugoConfigFiles   = []string{"hugo.toml", "hugo.yaml", "hugo.yml", "hugo.json"}
+	legacyHugoConfigs = []string{"config.toml", "config.yaml", "config.yml", "config.json"}
+	hugoSiteDirs      = []string{"content", "layouts", "themes", "archetypes"}
+)
+
+// Detector implements static site generator detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "ssg"
+}
+
+// Detect scans for static site generator configurations. The site is stored in the
+// "static_site" property of a virtual component (merged into parent).
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	parser := parsers.NewStaticSiteParser()
+
+	site := d.detectHugo(files, currentPath, provider, parser)
+	if site == nil {
+		site = d.detectJekyll(files, currentPath, provider, parser)
+	}
+	if site == nil {
+		site = d.detectNPMGenerator(files, currentPath, provider)
+	}
+	if site == nil {
+		return nil
+	}
+
+	configFile := site.File
+	relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, configFile))
+	site.File = "/" + filepath.ToSlash(relativeFilePath)
+
+	payload := types.NewPayloadWithPath("virtual", site.File)
+	payload.Properties["static_site"] = site
+	payload.AddTech(site.Generator, "matched file: "+configFile)
+	return []*types.Payload{payload}
+}
+
+// detectHugo detects a Hugo site from hugo.* or config.* configuration files
+func (d *Detector) detectHugo(files []types.File, currentPath string, provider types.Provider, parser *parsers.StaticSiteParser) *parsers.StaticSiteInfo {
+	configFile := firstFile(files, hugoConfigFiles)
+	if configFile == "" && hasAnyDir(files, hugoSiteDirs) {
+		configFile = firstFile(files, legacyHugoConfigs)
+	}
+	if configFile == "" {
+		return nil
+	}
+
+	content, err := provider.ReadFile(filepath.Join(currentPath, configFile))
+	if err != nil {
+		return nil
+	}
+	config := parser.ParseHugoConfig(string(content), configFile)
+	if config == nil {
+		return nil
+	}
+
+	site := &parsers.StaticSiteInfo{
+		Generator: parsers.SSGHugo,
+		File:      configFile,
+		Version:   config.MinVersion,
+		Themes:    config.Themes,
+		Plugins:   config.Modules,
+	}
+	if len(site.Themes) == 0 {
+		site.Themes = d.listThemeDirs(filepath.Join(currentPath, "themes"), provider)
+	}
+	if netlify, err := provider.ReadFile(filepath.Join(currentPath, "netlify.toml")); err == nil {
+		if version := parser.ParseHugoVersionPin(string(netlify)); version != "" {
+			site.Version = version
+		}
+	}
+	// Hugo modules are commonly themes (e.g., github.com/google/docsy)
+	site.SiteType = parsers.ClassifySiteType(site.Generator, append(append([]string{}, site.Themes...), site.Plugins...))
+	return site
+}
+
+// listThemeDirs returns the theme directories of a Hugo site (themes/<name>)
+func (d *Detector) listThemeDirs(themesDir string, provider types.Provider) []string {
+	entries, err := provider.ListDir(themesDir)
+	if err != nil {
+		return nil
+	}
+	var themes []string
+	for _, entry := range entries {
+		if entry.Type == "dir" {
+			themes = append(themes, entry.Name)
+		}
+	}
+	sort.Strings(themes)
+	return themes
+}
+
+// detectJekyll detects a Jekyll site (_config.yml with a Gemfile)
+func (d *Detector) detectJekyll(files []types.File, currentPath string, provider types.Provider, parser *parsers.StaticSiteParser) *parsers.StaticSiteInfo {
+	configFile := firstFile(files, []string{"_config.yml", "_config.yaml"})
+	if configFile == "" || firstFile(files, []string{"Gemfile"}) == "" {
+		return nil
+	}
+
+	gemfile, err := provider.ReadFile(filepath.Join(currentPath, "Gemfile"))
+	if err != nil {
+		return nil
+	}
+	gems := make(map[string]string)
+	for _, dep := range parsers.NewRubyParser().ParseGemfile(string(gemfile)) {
+		gems[dep.Name] = dep.Version
+	}
+	_, hasJekyll := gems["jekyll"]
+	_, hasPages := gems["github-pages"]
+	if !hasJekyll && !hasPages {
+		return nil
+	}
+	if lock, err := provider.ReadFile(filepath.Join(currentPath, "Gemfile.lock")); err == nil {
+		// Use locked versions; jekyll itself may be installed via github-pages
+		for _, dep := range parsers.NewGemfileLockParser().ParseGemfileLockWithOptions(string(lock), parsers.ParseGemfileLockOptions{IncludeTransitive: true}) {
+			if _, declared := gems[dep.Name]; declared || dep.Name == "jekyll" {
+				gems[dep.Name] = dep.Version
+			}
+		}
+	}
+
+	content, err := provider.ReadFile(filepath.Join(currentPath, configFile))
+	if err != nil {
+		return nil
+	}
+	config, err := parser.ParseJekyllConfig(string(content))
+	if err != nil {
+		return nil
+	}
+
+	site := &parsers.StaticSiteInfo{
+		Generator: parsers.SSGJekyll,
+		File:      configFile,
+		Version:   gems["jekyll"],
+		Themes:    config.Themes(),
+		Plugins:   config.Plugins,
+	}
+	for name := range gems {
+		if strings.HasPrefix(name, "jekyll-") && !containsString(site.Plugins, name) && !containsString(site.Themes, name) {
+			site.Plugins = append(site.Plugins, name)
+		}
+	}
+	sort.Strings(site.Plugins)
+	site.SiteType = parsers.ClassifySiteType(site.Generator, site.Themes)
+	return site
+}
+
+// detectNPMGenerator detects npm-based generators by configuration file or core dependency
+func (d *Detector) detectNPMGenerator(files []types.File, currentPath string, provider types.Provider) *parsers.StaticSiteInfo {
+	dependencies := d.readPackageDependencies(files, currentPath, provider)
+
+	for _, generator := range parsers.NPMSiteGenerators {
+		configFile := firstFile(files, generator.ConfigFiles)
+		version, hasDependency := dependencies[generator.Package]
+		if configFile == "" && !hasDependency {
+			continue
+		}
+		if configFile == "" {
+			configFile = "package.json"
+		}
+
+		site := &parsers.StaticSiteInfo{
+			Generator: generator.Name,
+			File:      configFile,
+			Version:   version,
+			Plugins:   generator.Plugins(dependencies),
+		}
+		for _, plugin := range site.Plugins {
+			if strings.Contains(plugin, "theme") {
+				site.Themes = append(site.Themes, plugin)
+			}
+		}
+		site.SiteType = parsers.ClassifySiteType(site.Generator, site.Plugins)
+		return site
+	}
+	return nil
+}
+
+// readPackageDependencies returns dependencies and devDependencies of package.json
+func (d *Detector) readPackageDependencies(files []types.File, currentPath string, provider types.Provider) map[string]string {
+	dependencies := make(map[string]string)
+	if firstFile(files, []string{"package.json"}) == "" {
+		return dependencies
+	}
+	content, err := provider.ReadFile(filepath.Join(currentPath, "package.json"))
+	if err != nil {
+		return dependencies
+	}
+	var packageJSON struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(content, &packageJSON); err != nil {
+		return dependencies
+	}
+	for name, version := range packageJSON.DevDependencies {
+		dependencies[name] = version
+	}
+	for name, version := range packageJSON.Dependencies {
+		dependencies[name] = version
+	}
+	return dependencies
+}
+
+// firstFile returns the first of names present as a file
+func firstFile(files []types.File, names []string) string {
+	for _, name := range names {
+		for _, file := range files {
+			if file.Name == name && file.Type != "dir" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// hasAnyDir reports whether any of names is present as a directory
+func hasAnyDir(files []types.File, names []string) bool {
+	for _, file := range files {
+		if file.Type == "dir" && containsString(names, file.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	components.Register(&Detector{})
+}
@@ -0,0 +1,233 @@
+package ssg
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	// Derive directory entries from the mock file paths
+	seen := make(map[string]bool)
+	var entries []types.File
+	prefix := strings.TrimSuffix(path, "/") + "/"
+	for filePath := range m.files {
+		if !strings.HasPrefix(filePath, prefix) {
+			continue
+		}
+		name, rest, isDir := strings.Cut(strings.TrimPrefix(filePath, prefix), "/")
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		entryType := "file"
+		if isDir && rest != "" {
+			entryType = "dir"
+		}
+		entries = append(entries, types.File{Name: name, Path: prefix + name, Type: entryType})
+	}
+	return entries, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func detectSite(t *testing.T, files []types.File, currentPath string, provider *MockProvider) (*types.Payload, *parsers.StaticSiteInfo) {
+	t.Helper()
+	results := (&Detector{}).Detect(files, currentPath, "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.Equal(t, "virtual", results[0].Name)
+	return results[0], results[0].Properties["static_site"].(*parsers.StaticSiteInfo)
+}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "ssg", (&Detector{}).Name())
+}
+
+func TestDetector_Detect_Hugo(t *testing.T) {
+	provider := &MockProvider{
+		files: map[string]string{
+			"/repo/docs/hugo.toml":                     "title = \"Docs\"\n[[module.imports]]\n  path = \"github.com/google/docsy\"\n",
+			"/repo/docs/netlify.toml":                  "[build.environment]\n  HUGO_VERSION = \"0.121.2\"\n",
+			"/repo/docs/themes/local-theme/theme.toml": "name = \"Local\"\n",
+		},
+	}
+	files := []types.File{
+		{Name: "hugo.toml", Type: "file"},
+		{Name: "netlify.toml", Type: "file"},
+		{Name: "themes", Type: "dir"},
+	}
+
+	payload, site := detectSite(t, files, "/repo/docs", provider)
+	assert.Contains(t, payload.Techs, "hugo")
+	assert.Equal(t, &parsers.StaticSiteInfo{
+		Generator: "hugo",
+		File:      "/docs/hugo.toml",
+		Version:   "0.121.2",
+		SiteType:  "documentation",
+		Themes:    []string{"local-theme"},
+		Plugins:   []string{"github.com/google/docsy"},
+	}, site)
+}
+
+func TestDetector_Detect_HugoLegacyConfigRequiresSiteDirs(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{"/repo/config.toml": "theme = \"ananke\"\n"}}
+
+	results := (&Detector{}).Detect([]types.File{{Name: "config.toml", Type: "file"}}, "/repo", "/repo", provider, &MockDependencyDetector{})
+	assert.Empty(t, results, "config.toml alone is not a Hugo site")
+
+	files := []types.File{{Name: "config.toml", Type: "file"}, {Name: "content", Type: "dir"}}
+	_, site := detectSite(t, files, "/repo", provider)
+	assert.Equal(t, []string{"ananke"}, site.Themes)
+	assert.Equal(t, "website", site.SiteType)
+}
+
+func TestDetector_Detect_Jekyll(t *testing.T) {
+	provider := &MockProvider{
+		files: map[string]string{
+			"/repo/_config.yml": "remote_theme: just-the-docs/just-the-docs\nplugins:\n  - jekyll-seo-tag\n",
+			"/repo/Gemfile":     "source \"https://rubygems.org\"\ngem \"github-pages\", group: :jekyll_plugins\ngem \"jekyll-feed\"\n",
+			"/repo/Gemfile.lock": `GEM
+  remote: https://rubygems.org/
+  specs:
+    github-pages (231)
+      jekyll (= 3.9.5)
+    jekyll (3.9.5)
+    jekyll-feed (0.17.0)
+
+DEPENDENCIES
+  github-pages
+  jekyll-feed
+`,
+		},
+	}
+	files := []types.File{
+		{Name: "_config.yml", Type: "file"},
+		{Name: "Gemfile", Type: "file"},
+		{Name: "Gemfile.lock", Type: "file"},
+	}
+
+	payload, site := detectSite(t, files, "/repo", provider)
+	assert.Contains(t, payload.Techs, "jekyll")
+	assert.Equal(t, "/_config.yml", site.File)
+	assert.Equal(t, "3.9.5", site.Version, "jekyll version from the lock file via github-pages")
+	assert.Equal(t, []string{"just-the-docs/just-the-docs"}, site.Themes)
+	assert.Equal(t, []string{"jekyll-feed", "jekyll-seo-tag"}, site.Plugins)
+	assert.Equal(t, "documentation", site.SiteType)
+}
+
+func TestDetector_Detect_JekyllRequiresGem(t *testing.T) {
+	provider := &MockProvider{
+		files: map[string]string{
+			"/repo/_config.yml": "title: Not Jekyll\n",
+			"/repo/Gemfile":     "gem \"rails\"\n",
+		},
+	}
+	files := []types.File{{Name: "_config.yml", Type: "file"}, {Name: "Gemfile", Type: "file"}}
+
+	results := (&Detector{}).Detect(files, "/repo", "/repo", provider, &MockDependencyDetector{})
+	assert.Empty(t, results)
+}
+
+func TestDetector_Detect_NPMGenerators(t *testing.T) {
+	tests := []struct {
+		name        string
+		packageJSON string
+		files       []types.File
+		expected    *parsers.StaticSiteInfo
+	}{
+		{
+			name:        "Docusaurus with config",
+			packageJSON: `{"dependencies": {"@docusaurus/core": "3.4.0", "@docusaurus/preset-classic": "3.4.0", "react": "^18.0.0"}}`,
+			files:       []types.File{{Name: "package.json"}, {Name: "docusaurus.config.ts"}},
+			expected: &parsers.StaticSiteInfo{
+				Generator: "docusaurus", File: "/docusaurus.config.ts", Version: "3.4.0", SiteType: "documentation",
+				Plugins: []string{"@docusaurus/preset-classic"},
+			},
+		},
+		{
+			name:        "Astro with Starlight",
+			packageJSON: `{"dependencies": {"astro": "^4.10.0", "@astrojs/starlight": "^0.24.0", "@astrojs/tailwind": "^5.1.0"}}`,
+			files:       []types.File{{Name: "package.json"}, {Name: "astro.config.mjs"}},
+			expected: &parsers.StaticSiteInfo{
+				Generator: "astro", File: "/astro.config.mjs", Version: "^4.10.0", SiteType: "documentation",
+				Plugins: []string{"@astrojs/starlight", "@astrojs/tailwind"},
+			},
+		},
+		{
+			name:        "Gatsby by dependency only",
+			packageJSON: `{"dependencies": {"gatsby": "^5.13.0", "gatsby-theme-blog": "^4.0.0"}}`,
+			files:       []types.File{{Name: "package.json"}},
+			expected: &parsers.StaticSiteInfo{
+				Generator: "gatsby", File: "/package.json", Version: "^5.13.0", SiteType: "website",
+				Themes: []string{"gatsby-theme-blog"}, Plugins: []string{"gatsby-theme-blog"},
+			},
+		},
+		{
+			name:        "Eleventy by config only",
+			packageJSON: `{"name": "site"}`,
+			files:       []types.File{{Name: "package.json"}, {Name: "eleventy.config.js"}},
+			expected: &parsers.StaticSiteInfo{
+				Generator: "eleventy", File: "/eleventy.config.js", SiteType: "website",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &MockProvider{files: map[string]string{"/repo/package.json": tt.packageJSON}}
+			payload, site := detectSite(t, tt.files, "/repo", provider)
+			assert.Equal(t, tt.expected, site)
+			assert.Contains(t, payload.Techs, tt.expected.Generator)
+		})
+	}
+}
+
+func TestDetector_Detect_NoSite(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{"/repo/package.json": `{"dependencies": {"react": "^18.0.0"}}`}}
+	results := (&Detector{}).Detect([]types.File{{Name: "package.json"}}, "/repo", "/repo", provider, &MockDependencyDetector{})
+	assert.Empty(t, results)
+}
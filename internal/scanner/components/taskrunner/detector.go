@@ -0,0 +1,153 @@
+// Package taskrunner implements Makefile and Taskfile detection as a plugin-based component detector.
+// Makefiles and top-level shell scripts are also scanned for invocations of infrastructure tools.
+package taskrunner
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// makefileNames lists the file names GNU make reads by default
+var makefileNames = map[string]bool{
+	"Makefile":    true,
+	"makefile":    true,
+	"GNUmakefile": true,
+}
+
+// taskfileNames lists the file names Task (taskfile.dev) reads by default
+var taskfileNames = map[string]bool{
+	"Taskfile.yml":  true,
+	"Taskfile.yaml": true,
+	"taskfile.yml":  true,
+	"taskfile.yaml": true,
+}
+
+// scriptDirs lists the directories, relative to the scan root, whose shell scripts are scanned
+// for tool invocations
+var scriptDirs = map[string]bool{
+	".":       true,
+	"scripts": true,
+}
+
+// Detector implements Makefile and Taskfile detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "taskrunner"
+}
+
+// Detect scans for Makefiles and Taskfiles, records their targets/tasks in the
+// "make" and "taskfile" properties, and attributes technologies for build tools
+// invoked by their recipes. Invocations of infrastructure tools (docker, kubectl, terraform, ...)
+// in Makefiles and top-level shell scripts are recorded in the "tool_invocations" property.
+// Returns virtual components (merged into parent).
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	var results []*types.Payload
+	parser := parsers.NewScriptsParser()
+
+	for _, file := range files {
+		if isShellScript(file) && isScriptDir(basePath, currentPath) {
+			if payload := detectScriptInvocations(file, currentPath, basePath, provider, parser); payload != nil {
+				results = append(results, payload)
+			}
+			continue
+		}
+		if !makefileNames[file.Name] && !taskfileNames[file.Name] {
+			continue
+		}
+
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			continue
+		}
+
+		relativeFilePath := relativePath(basePath, currentPath, file.Name)
+		payload := types.NewPayloadWithPath("virtual", relativeFilePath)
+
+		if makefileNames[file.Name] {
+			info := parser.ParseMakefile(string(content))
+			if len(info.Targets) == 0 {
+				continue
+			}
+			info.File = relativeFilePath
+			payload.Properties["make"] = info
+			components.AddScriptToolTechs(payload, info.Usage, "Makefile target", depDetector)
+			addToolInvocations(payload, parser.FindToolInvocations(string(content), true), relativeFilePath)
+		} else {
+			info, err := parser.ParseTaskfile(string(content))
+			if err != nil || len(info.Tasks) == 0 {
+				continue
+			}
+			info.File = relativeFilePath
+			payload.Properties["taskfile"] = info
+			payload.AddTech("taskfile", "matched file: "+file.Name)
+			components.AddScriptToolTechs(payload, info.Usage, "task", depDetector)
+		}
+
+		results = append(results, payload)
+	}
+
+	return results
+}
+
+// detectScriptInvocations returns a virtual component with the tool invocations of a shell
+// script, or nil if it invokes none of the known tools
+func detectScriptInvocations(file types.File, currentPath, basePath string, provider types.Provider, parser *parsers.ScriptsParser) *types.Payload {
+	content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+	if err != nil {
+		return nil
+	}
+	invocations := parser.FindToolInvocations(string(content), false)
+	if len(invocations) == 0 {
+		return nil
+	}
+	relativeFilePath := relativePath(basePath, currentPath, file.Name)
+	payload := types.NewPayloadWithPath("virtual", relativeFilePath)
+	addToolInvocations(payload, invocations, relativeFilePath)
+	return payload
+}
+
+// addToolInvocations stores the invocations in the "tool_invocations" property and adds the
+// invoked tools as technologies, with the first invoking line as evidence
+func addToolInvocations(payload *types.Payload, invocations []parsers.ToolInvocation, relativeFilePath string) {
+	if len(invocations) == 0 {
+		return
+	}
+	properties := make([]interface{}, 0, len(invocations))
+	for _, invocation := range invocations {
+		invocation.File = relativeFilePath
+		properties = append(properties, invocation)
+		payload.AddTech(invocation.Tech, "tool invocation: "+invocation.Tool+" ("+relativeFilePath+":"+strconv.Itoa(invocation.Line)+")")
+	}
+	payload.Properties["tool_invocations"] = properties
+}
+
+// isShellScript reports whether a file is a shell script (*.sh, *.bash)
+func isShellScript(file types.File) bool {
+	return file.Type != "dir" && (strings.HasSuffix(file.Name, ".sh") || strings.HasSuffix(file.Name, ".bash"))
+}
+
+// isScriptDir reports whether currentPath is the scan root or one of its script directories
+func isScriptDir(basePath, currentPath string) bool {
+	rel, err := filepath.Rel(basePath, currentPath)
+	return err == nil && scriptDirs[filepath.ToSlash(rel)]
+}
+
+// relativePath computes the relative file path for payload display.
+func relativePath(basePath, currentPath, fileName string) string {
+	relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, fileName))
+	if relativeFilePath == "." {
+		return "/"
+	}
+	return "/" + relativeFilePath
+}
+
+func init() {
+	components.Register(&Detector{})
+}
@@ -0,0 +1,172 @@
+package taskrunner
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector matches dependency names against a fixed name -> tech map
+type MockDependencyDetector struct {
+	rules map[string]string
+}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	result := make(map[string][]string)
+	for _, dep := range dependencies {
+		if tech, ok := m.rules[dep]; ok {
+			result[tech] = append(result[tech], "matched dependency: "+dep)
+		}
+	}
+	return result
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func TestDetector_Name(t *testing.T) {
+	detector := &Detector{}
+	assert.Equal(t, "taskrunner", detector.Name())
+}
+
+func TestDetector_Detect_Makefile(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{files: map[string]string{
+		"/mock/Makefile": "build:\n\tnpx tsc\n\nclean:\n\trm -rf dist\n",
+	}}
+	depDetector := &MockDependencyDetector{rules: map[string]string{"typescript": "typescript"}}
+	files := []types.File{{Name: "Makefile", Path: "/mock/Makefile"}}
+
+	results := detector.Detect(files, "/mock", "/mock", provider, depDetector)
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Equal(t, "virtual", payload.Name)
+	assert.Contains(t, payload.Techs, "typescript")
+	assert.Contains(t, payload.Reason["typescript"], "script command: tsc (Makefile target: build)")
+
+	info, ok := payload.Properties["make"].(*parsers.MakefileInfo)
+	require.True(t, ok)
+	assert.Equal(t, "/Makefile", info.File)
+	assert.Equal(t, []string{"build", "clean"}, info.Targets)
+	assert.Equal(t, []string{"tsc"}, info.Tools)
+}
+
+func TestDetector_Detect_MakefileToolInvocations(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{files: map[string]string{
+		"/mock/Makefile": "deploy:\n\tdocker build -t app .\n\tkubectl apply -f k8s\n",
+	}}
+	files := []types.File{{Name: "Makefile", Path: "/mock/Makefile"}}
+
+	results := detector.Detect(files, "/mock", "/mock", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Contains(t, payload.Techs, "docker")
+	assert.Contains(t, payload.Techs, "kubernetes")
+	assert.Contains(t, payload.Reason["kubernetes"], "tool invocation: kubectl (/Makefile:3)")
+
+	invocations, ok := payload.Properties["tool_invocations"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, invocations, 2)
+	docker := invocations[0].(parsers.ToolInvocation)
+	assert.Equal(t, "/Makefile", docker.File)
+	assert.Equal(t, "docker build -t app .", docker.Command)
+}
+
+func TestDetector_Detect_ShellScripts(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{files: map[string]string{
+		"/mock/deploy.sh":          "#!/bin/sh\ngcloud run deploy api --source .\n",
+		"/mock/scripts/proto.bash": "protoc -I proto --go_out=gen proto/*.proto\n",
+		"/mock/scripts/noop.sh":    "echo done\n",
+		"/mock/app/build.sh":       "docker build .\n",
+	}}
+
+	results := detector.Detect([]types.File{{Name: "deploy.sh"}}, "/mock", "/mock", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.Equal(t, []string{"/deploy.sh"}, results[0].Path)
+	assert.Contains(t, results[0].Techs, "gcp")
+	assert.Contains(t, results[0].Reason["gcp"], "tool invocation: gcloud (/deploy.sh:2)")
+
+	results = detector.Detect([]types.File{{Name: "proto.bash"}, {Name: "noop.sh"}}, "/mock/scripts", "/mock", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.Contains(t, results[0].Techs, "protobuf")
+
+	// Scripts below other directories are not scanned
+	results = detector.Detect([]types.File{{Name: "build.sh"}}, "/mock/app", "/mock", provider, &MockDependencyDetector{})
+	assert.Empty(t, results)
+}
+
+func TestDetector_Detect_Taskfile(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{files: map[string]string{
+		"/mock/web/Taskfile.yml": "version: '3'\ntasks:\n  e2e:\n    cmds:\n      - playwright test\n",
+	}}
+	depDetector := &MockDependencyDetector{rules: map[string]string{"@playwright/test": "playwright"}}
+	files := []types.File{{Name: "Taskfile.yml", Path: "/mock/web/Taskfile.yml"}}
+
+	results := detector.Detect(files, "/mock/web", "/mock", provider, depDetector)
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Contains(t, payload.Techs, "taskfile")
+	assert.Contains(t, payload.Techs, "playwright")
+
+	info, ok := payload.Properties["taskfile"].(*parsers.TaskfileInfo)
+	require.True(t, ok)
+	assert.Equal(t, "/web/Taskfile.yml", info.File)
+	assert.Equal(t, []string{"e2e"}, info.Tasks)
+}
+
+func TestDetector_Detect_EmptyMakefile(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{files: map[string]string{
+		"/mock/Makefile": "# only comments\nCC := gcc\n",
+	}}
+	files := []types.File{{Name: "Makefile", Path: "/mock/Makefile"}}
+
+	results := detector.Detect(files, "/mock", "/mock", provider, &MockDependencyDetector{})
+	assert.Empty(t, results)
+}
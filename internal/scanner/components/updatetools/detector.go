@@ -0,0 +1,81 @@
+// Package updatetools implements detection of automated dependency update tools
+// (Dependabot, Renovate) and records which ecosystems and directories they cover.
+package updatetools
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// renovateFiles lists the configuration file names Renovate looks for
+var renovateFiles = map[string]bool{
+	"renovate.json":     true,
+	"renovate.json5":    true,
+	".renovaterc":       true,
+	".renovaterc.json":  true,
+	".renovaterc.json5": true,
+}
+
+// Detector implements Dependabot and Renovate configuration detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "updatetools"
+}
+
+// Detect scans for .github/dependabot.yml and Renovate configuration files.
+// The parsed coverage is stored in the "dependabot" or "renovate" property of a
+// virtual component (merged into parent).
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	var results []*types.Payload
+	parser := parsers.NewUpdateToolsParser()
+
+	for _, file := range files {
+		isDependabot := (file.Name == "dependabot.yml" || file.Name == "dependabot.yaml") && filepath.Base(currentPath) == ".github"
+		if !isDependabot && !renovateFiles[file.Name] {
+			continue
+		}
+
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			continue
+		}
+
+		var config *parsers.UpdateToolConfig
+		if isDependabot {
+			config, err = parser.ParseDependabot(string(content))
+			if err != nil {
+				continue
+			}
+		} else {
+			config = parser.ParseRenovate(string(content))
+		}
+
+		relativeFilePath := relativePath(basePath, currentPath, file.Name)
+		config.File = relativeFilePath
+
+		payload := types.NewPayloadWithPath("virtual", relativeFilePath)
+		payload.Properties[config.Tool] = config
+		payload.AddTech(config.Tool, "matched file: "+file.Name)
+		results = append(results, payload)
+	}
+
+	return results
+}
+
+// relativePath computes the relative file path for payload display.
+func relativePath(basePath, currentPath, fileName string) string {
+	relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, fileName))
+	if relativeFilePath == "." {
+		return "/"
+	}
+	return "/" + relativeFilePath
+}
+
+func init() {
+	components.Register(&Detector{})
+}
@@ -0,0 +1,107 @@
+package updatetools
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+func TestDetector_Name(t *testing.T) {
+	detector := &Detector{}
+	assert.Equal(t, "updatetools", detector.Name())
+}
+
+func TestDetector_Detect_Dependabot(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{files: map[string]string{
+		"/mock/.github/dependabot.yml": `version: 2
+updates:
+  - package-ecosystem: npm
+    directory: /
+`,
+	}}
+	files := []types.File{{Name: "dependabot.yml", Path: "/mock/.github/dependabot.yml"}}
+
+	results := detector.Detect(files, "/mock/.github", "/mock", provider, nil)
+	require.Len(t, results, 1)
+
+	payload := results[0]
+	assert.Equal(t, "virtual", payload.Name)
+	assert.Contains(t, payload.Techs, "dependabot")
+
+	config, ok := payload.Properties["dependabot"].(*parsers.UpdateToolConfig)
+	require.True(t, ok)
+	assert.Equal(t, "/.github/dependabot.yml", config.File)
+	assert.Equal(t, []string{"npm"}, config.Ecosystems)
+}
+
+func TestDetector_Detect_DependabotOutsideGitHubDir(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{files: map[string]string{
+		"/mock/docs/dependabot.yml": "version: 2\nupdates: []\n",
+	}}
+	files := []types.File{{Name: "dependabot.yml", Path: "/mock/docs/dependabot.yml"}}
+
+	results := detector.Detect(files, "/mock/docs", "/mock", provider, nil)
+	assert.Empty(t, results)
+}
+
+func TestDetector_Detect_Renovate(t *testing.T) {
+	detector := &Detector{}
+	provider := &MockProvider{files: map[string]string{
+		"/mock/renovate.json": `{"extends": ["config:recommended"]}`,
+	}}
+	files := []types.File{
+		{Name: "renovate.json", Path: "/mock/renovate.json"},
+		{Name: "package.json", Path: "/mock/package.json"},
+	}
+
+	results := detector.Detect(files, "/mock", "/mock", provider, nil)
+	require.Len(t, results, 1)
+
+	config, ok := results[0].Properties["renovate"].(*parsers.UpdateToolConfig)
+	require.True(t, ok)
+	assert.Equal(t, "/renovate.json", config.File)
+	assert.Equal(t, []string{parsers.UpdateEcosystemAll}, config.Ecosystems)
+	assert.Contains(t, results[0].Techs, "renovate")
+}
@@ -0,0 +1,99 @@
+// Package vendored implements detection of vendored copies of open-source projects: project
+// source trees in third_party-style directories, and directories added with git subtree.
+package vendored
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/git"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// vendorDirs are the directories holding copies of other projects, one per subdirectory
+var vendorDirs = map[string]bool{
+	"third_party": true, "third-party": true, "thirdparty": true, "3rdparty": true,
+	"vendor": true, "vendored": true, "extern": true, "external": true, "externals": true,
+}
+
+// Subtree directories of each scan root, read once from the git history
+var (
+	subtreeMu    sync.Mutex
+	subtreeCache = make(map[string]map[string]string)
+)
+
+// Detector implements vendored project detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "vendored"
+}
+
+// Detect identifies the project in a subdirectory of a vendor directory (third_party/zlib) or
+// in a git subtree directory, from fingerprint files of well-known projects, or a license file
+// and an optional VERSION file. Returns a virtual component (merged into parent) holding the
+// project as a dependency flagged with "vendored-fork".
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	if currentPath == basePath {
+		return nil
+	}
+	rel, err := filepath.Rel(basePath, currentPath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return nil
+	}
+	rel = filepath.ToSlash(rel)
+	split, isSubtree := subtreeSplits(basePath)[rel]
+	if !isSubtree && !vendorDirs[filepath.Base(filepath.Dir(currentPath))] {
+		return nil
+	}
+
+	parser := parsers.NewVendoredParser()
+	project, ok := parser.IdentifyProject(currentPath, provider)
+	if !ok {
+		if !isSubtree {
+			return nil
+		}
+		project.Name = filepath.Base(currentPath)
+	}
+	project.Dir = rel
+	project.SubtreeSplit = split
+
+	payload := types.NewPayloadWithPath("virtual", types.CalculateRelativePath(project.Evidence, currentPath, basePath))
+	payload.AddDependency(parser.Dependency(project))
+	for tech, reasons := range depDetector.MatchDependencies([]string{project.Name}, parsers.DependencyTypeVendored) {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+	}
+	return []*types.Payload{payload}
+}
+
+// subtreeSplits returns the git subtree directories below the scan root, relative to it,
+// mapped to the upstream commit of their latest merge
+func subtreeSplits(basePath string) map[string]string {
+	subtreeMu.Lock()
+	defer subtreeMu.Unlock()
+	if splits, ok := subtreeCache[basePath]; ok {
+		return splits
+	}
+
+	splits := make(map[string]string)
+	if root := git.FindRepoRoot(basePath); root != "" {
+		for dir, split := range git.SubtreeSplits(root) {
+			rel, err := filepath.Rel(basePath, filepath.Join(root, dir))
+			if err == nil && !strings.HasPrefix(rel, "..") {
+				splits[filepath.ToSlash(rel)] = split
+			}
+		}
+	}
+	subtreeCache[basePath] = splits
+	return splits
+}
+
+func init() {
+	components.Register(&Detector{})
+}
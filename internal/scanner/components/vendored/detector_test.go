@@ -0,0 +1,121 @@
+package vendored
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+// ListDir derives the directory entries from the file paths
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	seen := make(map[string]bool)
+	var entries []types.File
+	for filePath := range m.files {
+		rel, err := filepath.Rel(path, filePath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		name, rest, isDir := strings.Cut(rel, string(filepath.Separator))
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		entry := types.File{Name: name, Path: filepath.Join(path, name), Type: "file"}
+		if isDir && rest != "" {
+			entry.Type = "dir"
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return nil, os.ErrNotExist
+	}
+	return entries, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "vendored", (&Detector{}).Name())
+}
+
+func TestDetect_VendorDirectory(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/third_party/sqlite/sqlite3.h": "#define SQLITE_VERSION        \"3.45.1\"\n",
+	}}
+	depDetector := &MockDependencyDetector{}
+
+	payloads := (&Detector{}).Detect([]types.File{{Name: "sqlite3.h", Type: "file"}}, "/repo/third_party/sqlite", "/repo", provider, depDetector)
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Equal(t, "virtual", payload.Name)
+	assert.Equal(t, []string{"/third_party/sqlite/sqlite3.h"}, payload.Path)
+	require.Len(t, payload.Dependencies, 1)
+	dep := payload.Dependencies[0]
+	assert.Equal(t, "vendored", dep.Type)
+	assert.Equal(t, "sqlite", dep.Name)
+	assert.Equal(t, "3.45.1", dep.Version)
+	assert.Equal(t, true, dep.Metadata["vendored-fork"])
+	assert.Equal(t, "third_party/sqlite", dep.Metadata["path"])
+	assert.Equal(t, "https://sqlite.org", dep.Metadata["upstream"])
+}
+
+func TestDetect_OutsideVendorDirectory(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/src/sqlite/sqlite3.h": "#define SQLITE_VERSION        \"3.45.1\"\n",
+		"/repo/LICENSE":              "MIT License",
+	}}
+	detector := &Detector{}
+
+	assert.Nil(t, detector.Detect([]types.File{{Name: "sqlite3.h", Type: "file"}}, "/repo/src/sqlite", "/repo", provider, &MockDependencyDetector{}))
+	assert.Nil(t, detector.Detect([]types.File{{Name: "LICENSE", Type: "file"}}, "/repo", "/repo", provider, &MockDependencyDetector{}))
+}
+
+func TestDetect_UnidentifiedVendorDirectory(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{"/repo/vendor/github.com/README.md": "modules"}}
+	assert.Nil(t, (&Detector{}).Detect([]types.File{{Name: "README.md", Type: "file"}}, "/repo/vendor/github.com", "/repo", provider, &MockDependencyDetector{}))
+}
@@ -0,0 +1,78 @@
+// Package vlang implements detection of V modules (v.mod) and their VPM dependencies.
+package vlang
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/providers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Detector implements V module detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "vlang"
+}
+
+// Detect scans for v.mod manifests. The module name, version, and license are stored in the
+// "vlang" properties.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	for _, file := range files {
+		if file.Name != "v.mod" {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			return nil
+		}
+		parser := parsers.NewVlangParser()
+		module := parser.ParseVMod(string(content))
+
+		name := module.Name
+		if name == "" {
+			name = filepath.Base(currentPath)
+		}
+		payload := types.NewComponentPayload(name, file.Name, currentPath, basePath, "vlang")
+		payload.AddTech("vlang", "matched file: "+file.Name)
+
+		vlangInfo := map[string]interface{}{"name": name}
+		if module.Version != "" {
+			vlangInfo["version"] = module.Version
+		}
+		if module.License != "" {
+			vlangInfo["license"] = module.License
+		}
+		payload.SetComponentProperties("vlang", vlangInfo)
+
+		dependencies := parser.Dependencies(module)
+		var names []string
+		for _, dep := range dependencies {
+			payload.AddDependency(dep)
+			names = append(names, dep.Name)
+		}
+		if len(names) > 0 {
+			for tech, reasons := range depDetector.MatchDependencies(names, parsers.DependencyTypeVpm) {
+				for _, reason := range reasons {
+					payload.AddTech(tech, reason)
+				}
+				depDetector.AddPrimaryTechIfNeeded(payload, tech)
+			}
+		}
+		return []*types.Payload{payload}
+	}
+	return nil
+}
+
+func init() {
+	components.Register(&Detector{})
+
+	// Register vpm package provider (modules depend on other modules by name)
+	providers.Register(&providers.PackageProvider{
+		DependencyType:      "vpm",
+		ExtractPackageNames: providers.SinglePropertyExtractor("vlang", "name"),
+	})
+}
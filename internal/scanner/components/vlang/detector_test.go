@@ -0,0 +1,76 @@
+package vlang
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "vlang", (&Detector{}).Name())
+}
+
+func TestDetect_VMod(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/v.mod": "Module {\n\tname: 'webapp'\n\tversion: '0.2.0'\n\tlicense: 'MIT'\n\tdependencies: ['vsl']\n}\n",
+	}}
+
+	payloads := (&Detector{}).Detect([]types.File{{Name: "v.mod", Type: "file"}}, "/repo", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Equal(t, "webapp", payload.Name)
+	assert.Equal(t, "vlang", payload.ComponentType)
+	assert.Equal(t, []string{"vlang"}, payload.Tech)
+	assert.Equal(t, map[string]interface{}{"name": "webapp", "version": "0.2.0", "license": "MIT"}, payload.Properties["vlang"])
+	require.Len(t, payload.Dependencies, 1)
+	assert.Equal(t, "vpm", payload.Dependencies[0].Type)
+}
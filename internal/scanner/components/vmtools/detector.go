@@ -0,0 +1,102 @@
+// Package vmtools implements detection of virtual machine tooling: Vagrantfiles with their
+// boxes and providers, and Packer templates with builders, provisioners, and plugins.
+package vmtools
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// legacyPackerTemplate is the conventional name of a legacy JSON Packer template
+const legacyPackerTemplate = "packer.json"
+
+// Detector implements Vagrant and Packer detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "vmtools"
+}
+
+// Detect scans for Vagrantfiles and Packer templates (*.pkr.hcl, *.pkr.json, packer.json).
+// Results are stored in the "vagrant" and "packer" properties of a virtual component (merged
+// into parent); builder platforms and provisioning tools are added as technologies.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	var payload *types.Payload
+	var templates []interface{}
+	packerParser := parsers.NewPackerParser()
+
+	for _, file := range files {
+		if file.Type == "dir" {
+			continue
+		}
+		isPacker := parsers.IsPackerTemplate(file.Name) || file.Name == legacyPackerTemplate
+		if file.Name != "Vagrantfile" && !isPacker {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			continue
+		}
+		relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, file.Name))
+		relativeFilePath = "/" + filepath.ToSlash(relativeFilePath)
+		reason := "matched file: " + file.Name
+
+		if !isPacker {
+			info := parsers.NewVagrantParser().ParseVagrantfile(string(content))
+			if info.IsEmpty() {
+				continue
+			}
+			info.File = relativeFilePath
+			payload = ensurePayload(payload, relativeFilePath)
+			payload.AddTech("vagrant", reason)
+			addTechs(payload, info.Techs(), reason)
+			payload.Properties["vagrant"] = info
+			continue
+		}
+
+		var template *parsers.PackerTemplate
+		if file.Name == legacyPackerTemplate {
+			template = packerParser.ParseLegacyJSON(string(content))
+		} else {
+			template = packerParser.ParseHCL(string(content), file.Name)
+		}
+		if template == nil {
+			continue
+		}
+		template.File = relativeFilePath
+		payload = ensurePayload(payload, relativeFilePath)
+		payload.AddTech("packer", reason)
+		addTechs(payload, template.Techs(), reason)
+		templates = append(templates, template)
+	}
+
+	if payload == nil {
+		return nil
+	}
+	if len(templates) > 0 {
+		payload.Properties["packer"] = templates
+	}
+	return []*types.Payload{payload}
+}
+
+// ensurePayload creates the virtual payload on first use
+func ensurePayload(payload *types.Payload, relativeFilePath string) *types.Payload {
+	if payload != nil {
+		return payload
+	}
+	return types.NewPayloadWithPath("virtual", relativeFilePath)
+}
+
+func addTechs(payload *types.Payload, techs []string, reason string) {
+	for _, tech := range techs {
+		payload.AddTech(tech, reason)
+	}
+}
+
+func init() {
+	components.Register(&Detector{})
+}
@@ -0,0 +1,113 @@
+package vmtools
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "vmtools", (&Detector{}).Name())
+}
+
+func TestDetect_VagrantAndPacker(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/project/infra/Vagrantfile":       "Vagrant.configure(\"2\") do |config|\n  config.vm.box = \"ubuntu/jammy64\"\n  config.vm.provision \"ansible\"\nend\n",
+		"/project/infra/image.pkr.hcl":     "source \"amazon-ebs\" \"base\" {}\nbuild {\n  sources = [\"source.amazon-ebs.base\"]\n}\n",
+		"/project/infra/variables.pkr.hcl": "variable \"region\" {}\n",
+	}}
+	files := []types.File{
+		{Name: "Vagrantfile", Type: "file"},
+		{Name: "image.pkr.hcl", Type: "file"},
+		{Name: "variables.pkr.hcl", Type: "file"},
+	}
+
+	payloads := (&Detector{}).Detect(files, "/project/infra", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Equal(t, "virtual", payload.Name)
+	for _, tech := range []string{"vagrant", "packer", "ansible", "aws"} {
+		assert.Contains(t, payload.Techs, tech)
+	}
+
+	vagrant, ok := payload.Properties["vagrant"].(*parsers.VagrantInfo)
+	require.True(t, ok)
+	assert.Equal(t, "/infra/Vagrantfile", vagrant.File)
+	assert.Equal(t, []parsers.VagrantBox{{Name: "ubuntu/jammy64"}}, vagrant.Boxes)
+
+	templates := payload.Properties["packer"].([]interface{})
+	require.Len(t, templates, 1, "variables-only files are not templates")
+	template := templates[0].(*parsers.PackerTemplate)
+	assert.Equal(t, "/infra/image.pkr.hcl", template.File)
+	assert.Equal(t, []string{"amazon-ebs"}, template.Builders)
+}
+
+func TestDetect_LegacyPackerJSON(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/project/packer.json": `{"builders": [{"type": "docker"}], "provisioners": [{"type": "shell"}]}`,
+	}}
+	files := []types.File{{Name: "packer.json", Type: "file"}}
+
+	payloads := (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	assert.Contains(t, payloads[0].Techs, "packer")
+	assert.Contains(t, payloads[0].Techs, "docker")
+	assert.Nil(t, payloads[0].Properties["vagrant"])
+}
+
+func TestDetect_NoVMTooling(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{"/project/Vagrantfile": "# empty\n"}}
+	files := []types.File{{Name: "Vagrantfile", Type: "file"}, {Name: "main.tf", Type: "file"}}
+
+	assert.Empty(t, (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{}))
+}
@@ -0,0 +1,99 @@
+// Package zig implements detection of Zig packages (build.zig, build.zig.zon) and their
+// dependencies.
+package zig
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/providers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Detector implements Zig package detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "zig"
+}
+
+// Detect scans for build.zig.zon manifests, or build.zig scripts of packages without one.
+// The package name, version, and minimum Zig version are stored in the "zig" properties.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	var hasBuildScript, hasManifest bool
+	for _, file := range files {
+		switch file.Name {
+		case "build.zig":
+			hasBuildScript = true
+		case "build.zig.zon":
+			hasManifest = true
+		}
+	}
+
+	if !hasManifest {
+		if !hasBuildScript {
+			return nil
+		}
+		payload := types.NewComponentPayload(filepath.Base(currentPath), "build.zig", currentPath, basePath, "zig")
+		payload.AddTech("zig", "matched file: build.zig")
+		return []*types.Payload{payload}
+	}
+
+	content, err := provider.ReadFile(filepath.Join(currentPath, "build.zig.zon"))
+	if err != nil {
+		return nil
+	}
+	parser := parsers.NewZigParser()
+	pkg, err := parser.ParseBuildZigZon(string(content))
+	if err != nil {
+		return nil
+	}
+
+	name := pkg.Name
+	if name == "" {
+		name = filepath.Base(currentPath)
+	}
+	payload := types.NewComponentPayload(name, "build.zig.zon", currentPath, basePath, "zig")
+	payload.AddTech("zig", "matched file: build.zig.zon")
+	if hasBuildScript {
+		payload.AddPath(types.CalculateRelativePath("build.zig", currentPath, basePath))
+	}
+
+	zigInfo := map[string]interface{}{"name": name}
+	if pkg.Version != "" {
+		zigInfo["version"] = pkg.Version
+	}
+	if pkg.MinimumZigVersion != "" {
+		zigInfo["minimum_zig_version"] = pkg.MinimumZigVersion
+	}
+	payload.SetComponentProperties("zig", zigInfo)
+
+	dependencies := parser.Dependencies(pkg)
+	var names []string
+	for _, dep := range dependencies {
+		payload.AddDependency(dep)
+		names = append(names, dep.Name)
+	}
+	if len(names) > 0 {
+		for tech, reasons := range depDetector.MatchDependencies(names, parsers.DependencyTypeZig) {
+			for _, reason := range reasons {
+				payload.AddTech(tech, reason)
+			}
+			depDetector.AddPrimaryTechIfNeeded(payload, tech)
+		}
+	}
+
+	return []*types.Payload{payload}
+}
+
+func init() {
+	components.Register(&Detector{})
+
+	// Register zig package provider (dependencies are keyed by package name)
+	providers.Register(&providers.PackageProvider{
+		DependencyType:      "zig",
+		ExtractPackageNames: providers.SinglePropertyExtractor("zig", "name"),
+	})
+}
@@ -0,0 +1,96 @@
+package zig
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string]string
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return []byte(content), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return nil
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "zig", (&Detector{}).Name())
+}
+
+func TestDetect_BuildZigZon(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/repo/server/build.zig.zon": `.{
+    .name = .server,
+    .version = "1.0.0",
+    .minimum_zig_version = "0.14.0",
+    .dependencies = .{
+        .httpz = .{ .url = "git+https://github.com/karlseguin/http.zig#0.14.0", .hash = "httpz-0.0.0-PNVzrJSuBgDFvO7mtd2qDzaq8_hXIu1BqFuL1jwAV8Ac" },
+    },
+    .paths = .{""},
+}`,
+	}}
+	files := []types.File{{Name: "build.zig", Type: "file"}, {Name: "build.zig.zon", Type: "file"}}
+
+	payloads := (&Detector{}).Detect(files, "/repo/server", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+
+	assert.Equal(t, "server", payload.Name)
+	assert.Equal(t, "zig", payload.ComponentType)
+	assert.Equal(t, []string{"/server/build.zig.zon", "/server/build.zig"}, payload.Path)
+	assert.Equal(t, map[string]interface{}{"name": "server", "version": "1.0.0", "minimum_zig_version": "0.14.0"}, payload.Properties["zig"])
+	require.Len(t, payload.Dependencies, 1)
+	assert.Equal(t, "httpz", payload.Dependencies[0].Name)
+	assert.Equal(t, "0.14.0", payload.Dependencies[0].Version)
+}
+
+func TestDetect_BuildZigOnly(t *testing.T) {
+	payloads := (&Detector{}).Detect([]types.File{{Name: "build.zig", Type: "file"}}, "/repo/tool", "/repo", &MockProvider{}, &MockDependencyDetector{})
+	require.Len(t, payloads, 1)
+	assert.Equal(t, "tool", payloads[0].Name)
+	assert.Equal(t, []string{"/tool/build.zig"}, payloads[0].Path)
+	assert.Empty(t, payloads[0].Dependencies)
+
+	assert.Empty(t, (&Detector{}).Detect([]types.File{{Name: "main.zig", Type: "file"}}, "/repo", "/repo", &MockProvider{}, &MockDependencyDetector{}))
+}
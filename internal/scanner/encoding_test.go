@@ -0,0 +1,269 @@
+package scanner
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encodingFixtures are manifests and lock files of the ecosystems, written in each encoding of
+// encodingVariants
+var encodingFixtures = map[string]map[string]string{
+	"nodejs": {
+		"package.json": `{
+  "name": "web",
+  "dependencies": {"react": "^18.2.0"},
+  "devDependencies": {"typescript": "5.3.3"}
+}
+`,
+		"yarn.lock": `# yarn lockfile v1
+
+react@^18.2.0:
+  version "18.2.0"
+  resolved "https://registry.yarnpkg.com/react/-/react-18.2.0.tgz"
+  dependencies:
+    loose-envify "^1.1.0"
+
+loose-envify@^1.1.0:
+  version "1.4.0"
+
+typescript@5.3.3:
+  version "5.3.3"
+`,
+	},
+	"ruby": {
+		"Gemfile": `source "https://rubygems.org"
+
+gem "rails", "~> 7.1"
+group :test do
+  gem "rspec"
+end
+`,
+		"Gemfile.lock": `GEM
+  remote: https://rubygems.org/
+  specs:
+    rails (7.1.2)
+    rspec (3.12.0)
+
+PLATFORMS
+  x86_64-linux
+
+DEPENDENCIES
+  rails (~> 7.1)
+  rspec
+
+BUNDLED WITH
+   2.5.3
+`,
+	},
+	"rust": {
+		"Cargo.toml": `[package]
+name = "cli"
+version = "0.1.0"
+
+[dependencies]
+serde = "1.0"
+`,
+		"Cargo.lock": `version = 3
+
+[[package]]
+name = "cli"
+version = "0.1.0"
+dependencies = [
+ "serde",
+]
+
+[[package]]
+name = "serde"
+version = "1.0.193"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+`,
+	},
+	"python": {
+		"pyproject.toml": `[tool.poetry]
+name = "svc"
+version = "0.1.0"
+
+[tool.poetry.dependencies]
+python = "^3.11"
+fastapi = "^0.109.0"
+`,
+		"poetry.lock": `[[package]]
+name = "fastapi"
+version = "0.109.0"
+description = "FastAPI framework"
+optional = false
+python-versions = ">=3.8"
+`,
+	},
+	"uv": {
+		"pyproject.toml": `[project]
+name = "worker"
+version = "0.1.0"
+dependencies = ["requests>=2.31"]
+`,
+		"uv.lock": `version = 1
+requires-python = ">=3.11"
+
+[[package]]
+name = "requests"
+version = "2.31.0"
+source = { registry = "https://pypi.org/simple" }
+
+[[package]]
+name = "worker"
+version = "0.1.0"
+source = { editable = "." }
+dependencies = [
+    { name = "requests" },
+]
+`,
+	},
+	"cocoapods": {
+		"Podfile": "platform :ios, '15.0'\n\ntarget 'App' do\n  pod 'Alamofire', '~> 5.6'\nend\n",
+		"Podfile.lock": `PODS:
+  - Alamofire (5.6.0)
+
+DEPENDENCIES:
+  - Alamofire (~> 5.6)
+
+COCOAPODS: 1.12.1
+`,
+	},
+	"cmake": {
+		"CMakeLists.txt": `cmake_minimum_required(VERSION 3.20)
+project(Engine
+  VERSION 1.2.0
+  LANGUAGES CXX)
+
+find_package(Boost 1.80 REQUIRED COMPONENTS system)
+find_package(OpenMP)
+`,
+	},
+	"requirements": {
+		"requirements.txt": "flask==3.0.0\nrequests>=2.31.0  # http\n",
+	},
+	"golang": {
+		"go.mod": `module example.com/api
+
+go 1.21
+
+require (
+	github.com/gin-gonic/gin v1.9.1
+	golang.org/x/text v0.14.0 // indirect
+)
+`,
+		"main.go": "package main\n\nimport \"github.com/gin-gonic/gin\"\n\nfunc main() { gin.New() }\n",
+	},
+	"maven": {
+		"pom.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+  <groupId>com.example</groupId>
+  <artifactId>app</artifactId>
+  <version>1.0.0</version>
+  <dependencies>
+    <dependency>
+      <groupId>org.springframework.boot</groupId>
+      <artifactId>spring-boot-starter-web</artifactId>
+      <version>3.2.0</version>
+    </dependency>
+  </dependencies>
+</project>
+`,
+	},
+	"gradle": {
+		"build.gradle": `plugins {
+    id 'java'
+}
+
+dependencies {
+    implementation 'com.google.guava:guava:32.1.3-jre'
+    testImplementation "junit:junit:4.13.2"
+}
+`,
+	},
+	"dotnet": {
+		"App.csproj": `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+  </PropertyGroup>
+  <ItemGroup>
+    <PackageReference Include="Newtonsoft.Json" Version="13.0.3" />
+  </ItemGroup>
+</Project>
+`,
+		"packages.config": `<?xml version="1.0" encoding="utf-8"?>
+<packages>
+  <package id="Serilog" version="3.1.1" targetFramework="net48" />
+</packages>
+`,
+	},
+	"docker": {
+		"Dockerfile":         "FROM node:20-alpine AS build\nRUN npm ci\nFROM nginx:1.25\n",
+		"docker-compose.yml": "services:\n  db:\n    image: postgres:16\n  cache:\n    image: redis:7\n",
+	},
+	"php": {
+		"composer.json": `{
+  "name": "acme/site",
+  "require": {"laravel/framework": "^10.0"}
+}
+`,
+	},
+	"dotenv": {
+		".env.example": "DATABASE_URL=postgres://localhost/app\nSTRIPE_API_KEY=\n",
+	},
+}
+
+// encodingVariants encode LF text as written on other platforms and by other editors
+var encodingVariants = map[string]func(string) []byte{
+	"crlf": func(text string) []byte {
+		return []byte(strings.ReplaceAll(text, "\n", "\r\n"))
+	},
+	"utf8-bom": func(text string) []byte {
+		return append([]byte{0xEF, 0xBB, 0xBF}, text...)
+	},
+	"utf16le-bom-crlf": func(text string) []byte {
+		return encodeUTF16(strings.ReplaceAll(text, "\n", "\r\n"), binary.LittleEndian, []byte{0xFF, 0xFE})
+	},
+	"utf16be-bom": func(text string) []byte {
+		return encodeUTF16(text, binary.BigEndian, []byte{0xFE, 0xFF})
+	},
+}
+
+func encodeUTF16(text string, order binary.AppendByteOrder, bom []byte) []byte {
+	encoded := bom
+	for _, unit := range utf16.Encode([]rune(text)) {
+		encoded = order.AppendUint16(encoded, unit)
+	}
+	return encoded
+}
+
+func TestScanner_Encodings(t *testing.T) {
+	for ecosystem, files := range encodingFixtures {
+		t.Run(ecosystem, func(t *testing.T) {
+			// Fixtures are written to "app", since component names can derive from the directory
+			dir := t.TempDir()
+			plain := make(map[string]string, len(files))
+			for name, content := range files {
+				plain["app/"+name] = content
+			}
+			writeTree(t, dir, plain)
+			expected := componentSummary(scanResult(t, dir))
+			require.NotEmpty(t, expected)
+
+			for variant, encode := range encodingVariants {
+				dir := t.TempDir()
+				encoded := make(map[string]string, len(files))
+				for name, content := range files {
+					encoded["app/"+name] = string(encode(content))
+				}
+				writeTree(t, dir, encoded)
+				assert.Equal(t, expected, componentSummary(scanResult(t, dir)), variant)
+			}
+		})
+	}
+}
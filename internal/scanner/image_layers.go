@@ -0,0 +1,86 @@
+package scanner
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/provider"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// SetImageArchive sets the extracted docker save archive (manifest.json, image configuration,
+// layer tars) of the image whose root file system is scanned. The artifacts and dependencies
+// are then attributed to the layer that introduced their file, with the Dockerfile instruction
+// of the image history. An empty path disables the attribution.
+func (s *Scanner) SetImageArchive(archive string) error {
+	s.imageArchive, s.imageLayers = "", nil
+	if archive == "" {
+		return nil
+	}
+	index, err := parsers.ParseImageLayers(provider.NewFSProvider(archive).ReadFile)
+	if err != nil {
+		return fmt.Errorf("image archive %s: %w", archive, err)
+	}
+	s.imageArchive, s.imageLayers = archive, index
+	return nil
+}
+
+// applyImageLayers records the image layer of the artifacts and dependencies of the payload tree:
+// the layer of the artifact a dependency was read from, else of its manifest. Returns the image
+// for the scan metadata, nil without image archive.
+func (s *Scanner) applyImageLayers(root *types.Payload) *metadata.ImageArchiveInfo {
+	if s.imageLayers == nil {
+		return nil
+	}
+	attributed := 0
+	walkPayloads(root, func(payload *types.Payload) {
+		if artifacts, ok := payload.Properties["artifacts"].([]interface{}); ok {
+			for _, entry := range artifacts {
+				switch artifact := entry.(type) {
+				case *parsers.Artifact:
+					artifact.Layer = s.imageLayers.Lookup(artifact.File)
+				case map[string]interface{}: // Read back from a base result
+					if file, ok := artifact["file"].(string); ok {
+						if layer := s.imageLayers.Lookup(file); layer != nil {
+							artifact[parsers.MetadataLayer] = *layer
+						}
+					}
+				}
+			}
+		}
+		for i := range payload.Dependencies {
+			dep := &payload.Dependencies[i]
+			layer := s.imageLayers.Lookup(dependencyFile(payload, *dep))
+			if layer == nil {
+				continue
+			}
+			if dep.Metadata == nil {
+				dep.Metadata = make(map[string]interface{})
+			}
+			dep.Metadata[parsers.MetadataLayer] = *layer
+			attributed++
+		}
+	})
+	return &metadata.ImageArchiveInfo{Path: s.imageArchive, Layers: len(s.imageLayers.Layers), AttributedDependencies: attributed}
+}
+
+// dependencyFile returns the file a dependency was read from: its artifact, else its manifest
+// (file metadata, else the source file in the component directory)
+func dependencyFile(payload *types.Payload, dep types.Dependency) string {
+	if artifact, ok := dep.Metadata[parsers.MetadataArtifact].(string); ok {
+		return artifact
+	}
+	if file, ok := dep.Metadata[parsers.MetadataFile].(string); ok {
+		return file
+	}
+	source := dep.SourceFile
+	if source == "" {
+		source, _ = dep.Metadata["source"].(string)
+	}
+	if source == "" {
+		return ""
+	}
+	return path.Join(componentDir(payload), source)
+}
@@ -0,0 +1,63 @@
+package scanner
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanner_ImageArchive(t *testing.T) {
+	rootfs := t.TempDir()
+	writeTree(t, rootfs, map[string]string{
+		"etc/os-release":   "ID=debian\n",
+		"app/package.json": `{"name": "web", "dependencies": {"express": "4.18.2"}}`,
+	})
+
+	archive := t.TempDir()
+	layer := func(names ...string) string {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for _, name := range names {
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Typeflag: tar.TypeReg}))
+		}
+		require.NoError(t, tw.Close())
+		return buf.String()
+	}
+	writeTree(t, archive, map[string]string{
+		"manifest.json":     `[{"Config": "blobs/sha256/cfg", "Layers": ["blobs/sha256/base", "blobs/sha256/app"]}]`,
+		"blobs/sha256/cfg":  `{"history": [{"created_by": "/bin/sh -c #(nop) ADD file:4b1 in / "}, {"created_by": "COPY app/ /app/ # buildkit"}]}`,
+		"blobs/sha256/base": layer("etc/os-release"),
+		"blobs/sha256/app":  layer("app/package.json"),
+	})
+
+	s, err := NewScannerWithOptionsAndRootID(rootfs, nil, false, false, false, false, nil, "test-root")
+	require.NoError(t, err)
+	require.NoError(t, s.SetImageArchive(archive))
+	payload, err := s.Scan()
+	require.NoError(t, err)
+
+	var express *types.Dependency
+	walkPayloads(payload, func(p *types.Payload) {
+		for i := range p.Dependencies {
+			if p.Dependencies[i].Name == "express" {
+				express = &p.Dependencies[i]
+			}
+		}
+	})
+	require.NotNil(t, express)
+	assert.Equal(t, parsers.ImageLayer{Index: 1, Digest: "sha256:app", CreatedBy: "COPY app/ /app/"}, express.Metadata[parsers.MetadataLayer])
+	assert.Equal(t, &metadata.ImageArchiveInfo{Path: archive, Layers: 2, AttributedDependencies: 1}, payload.Metadata.(*metadata.ScanMetadata).ImageArchive)
+
+	assert.Error(t, s.SetImageArchive(filepath.Join(archive, "missing")))
+	require.NoError(t, os.Remove(filepath.Join(archive, "blobs", "sha256", "app")))
+	assert.Error(t, s.SetImageArchive(archive))
+	assert.NoError(t, s.SetImageArchive(""))
+}
@@ -0,0 +1,126 @@
+package scanner
+
+import (
+	"path"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// compositeBuild is a Gradle composite build: the directory of its settings file and the
+// builds it includes
+type compositeBuild struct {
+	dir    string
+	builds []includedBuild
+}
+
+// includedBuild is a build of a composite build with the modules it substitutes
+type includedBuild struct {
+	path    string            // Path of the included build ("/libs/core")
+	modules map[string]string // group:artifact -> path of the providing project
+}
+
+// contains reports whether a component directory belongs to the composite build
+func (c compositeBuild) contains(dir string) bool {
+	if isSubPath(c.dir, dir) {
+		return true
+	}
+	for _, build := range c.builds {
+		if isSubPath(build.path, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveIncludedBuilds marks the Gradle dependencies substituted by an included build of a
+// composite build as internal project edges instead of external artifacts: the dependency
+// metadata gets the path of the included build, and the component a reference to the
+// component of the providing project. Substitution applies to all builds of the composite.
+func (s *Scanner) resolveIncludedBuilds(root *types.Payload) {
+	var composites []compositeBuild
+	gradleComponents := make(map[string]*types.Payload) // directory -> component
+	walkPayloads(root, func(payload *types.Payload) {
+		if payload.ComponentType != "gradle" {
+			return
+		}
+		for _, p := range payload.Path {
+			if _, exists := gradleComponents[path.Dir(p)]; !exists {
+				gradleComponents[path.Dir(p)] = payload
+			}
+		}
+		if composite, ok := compositeFromProperties(payload); ok {
+			composites = append(composites, composite)
+		}
+	})
+	if len(composites) == 0 {
+		return
+	}
+
+	walkPayloads(root, func(payload *types.Payload) {
+		if payload.ComponentType != "gradle" || len(payload.Path) == 0 {
+			return
+		}
+		dir := path.Dir(payload.Path[0])
+		for i, dep := range payload.Dependencies {
+			if dep.Type != parsers.DependencyTypeGradle || dep.Metadata[parsers.MetadataPlugin] == true {
+				continue
+			}
+			for _, composite := range composites {
+				if !composite.contains(dir) {
+					continue
+				}
+				for _, build := range composite.builds {
+					projectPath, ok := build.modules[dep.Name]
+					if !ok {
+						continue
+					}
+					if payload.Dependencies[i].Metadata == nil {
+						payload.Dependencies[i].Metadata = make(map[string]interface{})
+					}
+					payload.Dependencies[i].Metadata[parsers.MetadataIncludedBuild] = build.path
+					if target := gradleComponents[projectPath]; target != nil && target != payload {
+						addComponentRef(payload, types.ComponentRef{TargetID: target.ID, PackageName: dep.Name})
+					}
+				}
+			}
+		}
+	})
+}
+
+// compositeFromProperties reads the included builds recorded by the java detector in the
+// gradle properties of a component
+func compositeFromProperties(payload *types.Payload) (compositeBuild, bool) {
+	props, ok := payload.Properties["gradle"].(map[string]interface{})
+	if !ok || len(payload.Path) == 0 {
+		return compositeBuild{}, false
+	}
+	builds, ok := props["included_builds"].([]map[string]interface{})
+	if !ok || len(builds) == 0 {
+		return compositeBuild{}, false
+	}
+
+	composite := compositeBuild{dir: path.Dir(payload.Path[0])}
+	for _, build := range builds {
+		buildPath, _ := build["path"].(string)
+		modules, _ := build["modules"].(map[string]string)
+		if buildPath != "" {
+			composite.builds = append(composite.builds, includedBuild{path: buildPath, modules: modules})
+		}
+	}
+	return composite, true
+}
+
+// isSubPath reports whether a slash-separated path equals dir or lies below it
+func isSubPath(dir, p string) bool {
+	return p == dir || dir == "/" || strings.HasPrefix(p, dir+"/")
+}
+
+// walkPayloads calls fn for a payload and all its descendants
+func walkPayloads(payload *types.Payload, fn func(*types.Payload)) {
+	fn(payload)
+	for _, child := range payload.Children {
+		walkPayloads(child, fn)
+	}
+}
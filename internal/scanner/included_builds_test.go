@@ -0,0 +1,62 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveIncludedBuilds(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	root.ID = "root"
+
+	composite := types.NewPayloadWithPath("platform", "/platform/settings.gradle.kts")
+	composite.ID = "platform"
+	composite.SetComponentType("gradle")
+	composite.SetComponentProperty("gradle", "included_builds", []map[string]interface{}{
+		{"path": "/platform/libs/core", "modules": map[string]string{
+			"com.example:core":  "/platform/libs/core",
+			"com.example:model": "/platform/libs/core/model",
+		}},
+	})
+
+	app := types.NewPayloadWithPath("app", "/platform/app/build.gradle.kts")
+	app.ID = "app"
+	app.SetComponentType("gradle")
+	app.Dependencies = []types.Dependency{
+		{Type: parsers.DependencyTypeGradle, Name: "com.example:core", Version: "1.0.0", Direct: true, Metadata: types.NewMetadata(parsers.MetadataSourceBuildGradle)},
+		{Type: parsers.DependencyTypeGradle, Name: "com.example:model", Version: "latest", Direct: true},
+		{Type: parsers.DependencyTypeGradle, Name: "com.google.guava:guava", Version: "33.0.0-jre", Direct: true},
+	}
+
+	core := types.NewPayloadWithPath("core", "/platform/libs/core/build.gradle.kts")
+	core.ID = "core"
+	core.SetComponentType("gradle")
+	core.SetComponentProperties("gradle", map[string]interface{}{"group_id": "com.example", "artifact_id": "core"})
+
+	// Outside the composite build, the published artifact is used
+	other := types.NewPayloadWithPath("other", "/other/build.gradle")
+	other.ID = "other"
+	other.SetComponentType("gradle")
+	other.Dependencies = []types.Dependency{
+		{Type: parsers.DependencyTypeGradle, Name: "com.example:core", Version: "1.0.0", Direct: true},
+	}
+
+	root.Children = []*types.Payload{composite, app, core, other}
+
+	s := &Scanner{}
+	s.resolveIncludedBuilds(root)
+	s.resolveComponentRefs(root)
+
+	assert.Equal(t, "/platform/libs/core", app.Dependencies[0].Metadata[parsers.MetadataIncludedBuild])
+	assert.Equal(t, "build.gradle", app.Dependencies[0].Metadata["source"])
+	assert.Equal(t, "/platform/libs/core", app.Dependencies[1].Metadata[parsers.MetadataIncludedBuild])
+	assert.NotContains(t, app.Dependencies[2].Metadata, parsers.MetadataIncludedBuild)
+	require.Equal(t, []types.ComponentRef{{TargetID: "core", PackageName: "com.example:core"}}, app.ComponentRefs,
+		"Should reference the included build once, and skip projects without a component")
+
+	assert.NotContains(t, other.Dependencies[0].Metadata, parsers.MetadataIncludedBuild)
+}
@@ -0,0 +1,314 @@
+package scanner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/git"
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/spec"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// ReadBaseResult reads a full scan result (the JSON output of a scan without --aggregate) as
+// base of an incremental scan
+func ReadBaseResult(r io.Reader) (*types.Payload, error) {
+	var payload types.Payload
+	if err := json.NewDecoder(r).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("invalid scan result: %w", err)
+	}
+	meta, _ := payload.Metadata.(map[string]interface{})
+	if format, _ := meta["format"].(string); format != "full" || payload.ID == "" {
+		return nil, fmt.Errorf("not a full scan result (aggregated, query, and telemetry output cannot be merged)")
+	}
+	return &payload, nil
+}
+
+// Rescan re-analyzes the parts of a base result affected by changed files (relative to the
+// scan root) and merges them into the base result, which is modified and returned.
+//
+// Changed files that are manifests or lock files of the base result, or that create new
+// components, select a directory to rescan: the directory of the innermost component
+// containing the file (or of the new component). The components of each selected directory
+// tree are replaced by the components detected in it now. When a selected directory is the
+// scan root, the whole tree is scanned. Languages and code statistics of components outside the
+// rescanned directories are taken from the base result.
+func (s *Scanner) Rescan(base *types.Payload, changed []string) (*types.Payload, error) {
+	basePath := s.provider.GetBasePath()
+	startTime := time.Now()
+
+	dirs := s.rescanDirs(base, changed)
+	info := &metadata.IncrementalInfo{ChangedFiles: len(changed), Rescanned: dirs}
+	if meta, ok := base.Metadata.(map[string]interface{}); ok {
+		info.BaseTimestamp, _ = meta["timestamp"].(string)
+	}
+	if len(dirs) == 1 && dirs[0] == "/" {
+		slog.Debug("Changes affect the scan root, scanning the whole tree")
+		payload, err := s.Scan()
+		if err != nil {
+			return nil, err
+		}
+		payload.Metadata.(*metadata.ScanMetadata).Incremental = info
+		return payload, nil
+	}
+
+	s.progress.ScanStart(basePath, s.excludePatterns)
+	codeStats := make(map[string]interface{})
+	for _, dir := range dirs {
+		slog.Debug("Rescanning directory", "dir", dir)
+		if err := s.rescanDir(base, dir, codeStats); err != nil {
+			return nil, err
+		}
+	}
+	s.followLinks(false)
+
+	base.AssignIDs(base.ID)
+	walkPayloads(base, func(payload *types.Payload) {
+		payload.ComponentRefs = nil
+		if payload.CodeStats == nil {
+			payload.CodeStats = codeStats[payload.ID]
+		}
+	})
+	s.resolveIncludedBuilds(base)
+	validateScopes(base)
+	prodOnly := s.applyProdOnly(base)
+	targetEnv := s.applyTargetEnvironment(base)
+	imageArchive := s.applyImageLayers(base)
+	s.resolveComponentRefs(base)
+	base.Git = git.GetGitInfo(basePath)
+
+	cfg := s.config
+	if cfg == nil {
+		cfg = &config.ScanConfig{}
+	}
+	scanMeta := metadata.NewScanMetadata(basePath, spec.Version)
+	scanMeta.SetDuration(time.Since(startTime))
+	fileCount, componentCount := s.countFilesAndComponents(base)
+	scanMeta.SetFileCounts(fileCount, componentCount)
+	techCount, techsCount := s.countTechs(base)
+	scanMeta.SetLanguageCount(s.countLanguages(base))
+	scanMeta.SetTechCounts(techCount, techsCount)
+	scanMeta.SetProperties(cfg.Properties)
+	scanMeta.SetFormat("full")
+	scanMeta.Incremental = info
+	scanMeta.ProdOnly = prodOnly
+	scanMeta.TargetEnv = targetEnv
+	scanMeta.ImageArchive = imageArchive
+	base.Metadata = scanMeta
+
+	s.progress.ScanComplete(fileCount, componentCount, time.Since(startTime))
+	return base, nil
+}
+
+// rescanDirs returns the directories to rescan for the changed files ("/"-prefixed, sorted,
+// without directories inside other selected directories), or nil if no manifest changed
+func (s *Scanner) rescanDirs(base *types.Payload, changed []string) []string {
+	known := make(map[string]bool)
+	var componentDirs []string
+	walkPayloads(base, func(payload *types.Payload) {
+		for _, p := range payload.Path {
+			known[p] = true
+		}
+		dir := componentDir(payload)
+		for _, dep := range payload.Dependencies {
+			if file, ok := dep.Metadata["file"].(string); ok {
+				known[file] = true
+			}
+			if source, ok := dep.Metadata["source"].(string); ok {
+				known[path.Join(dir, source)] = true
+			}
+		}
+		if payload != base {
+			componentDirs = append(componentDirs, dir)
+		}
+	})
+
+	selected := make(map[string]bool)
+	for _, file := range changed {
+		file = "/" + strings.TrimPrefix(file, "/")
+		dir := path.Dir(file)
+		switch {
+		case known[file]:
+			selected[innermostDir(componentDirs, dir)] = true
+		default:
+			switch s.detectManifest(file) {
+			case manifestComponent:
+				selected[dir] = true
+			case manifestContribution:
+				selected[innermostDir(componentDirs, dir)] = true
+			}
+		}
+	}
+
+	var dirs []string
+	for dir := range selected {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	var result []string
+	for _, dir := range dirs {
+		if len(result) == 0 || !withinDir(dir, result[len(result)-1]) {
+			result = append(result, dir)
+		}
+	}
+	return result
+}
+
+// Kinds of changed files that are not manifests of the base result
+const (
+	manifestNone         = iota
+	manifestComponent    // Creates a component (e.g. a new package.json)
+	manifestContribution // Adds dependencies to the enclosing component (e.g. a new workflow)
+)
+
+// detectManifest runs the component detectors on a single file and reports whether it creates a
+// component or contributes dependencies
+func (s *Scanner) detectManifest(file string) int {
+	absDir := filepath.Join(s.provider.GetBasePath(), filepath.FromSlash(path.Dir(file)))
+	name := path.Base(file)
+	if exists, _ := s.provider.Exists(filepath.Join(absDir, name)); !exists || s.shouldExcludeFileStackBased(name, absDir) {
+		return manifestNone
+	}
+	probe := types.NewPayloadWithPath("main", "/")
+	files := []types.File{{Name: name, Path: filepath.Join(absDir, name), Type: "file"}}
+	s.detectComponents(probe, probe, files, absDir)
+	switch {
+	case len(probe.Children) > 0:
+		return manifestComponent
+	case len(probe.Dependencies) > 0:
+		return manifestContribution
+	default:
+		return manifestNone
+	}
+}
+
+// rescanDir replaces the components of a directory tree of the base result with the components
+// detected in it now. Code stats of the replaced components are collected by ID, so components
+// that are detected again keep them.
+func (s *Scanner) rescanDir(base *types.Payload, dir string, codeStats map[string]interface{}) error {
+	parent := enclosingComponent(base, dir)
+	removed := removeComponents(base, dir, codeStats)
+
+	absDir := filepath.Join(s.provider.GetBasePath(), filepath.FromSlash(dir))
+	if isDir, _ := s.provider.IsDir(absDir); !isDir {
+		return nil // Directory deleted: its components are removed
+	}
+
+	// Load the .gitignore files of the parent directories, as a scan from the root would
+	pushed := 0
+	for _, ancestor := range ancestorDirs(s.provider.GetBasePath(), absDir) {
+		if s.gitignoreStack.LoadAndPushGitignore(ancestor) {
+			pushed++
+		}
+	}
+	defer func() {
+		for i := 0; i < pushed; i++ {
+			s.gitignoreStack.PopGitignore()
+		}
+	}()
+
+	container := types.NewPayloadWithPath("main", dir)
+	if err := s.recurse(container, absDir); err != nil {
+		return err
+	}
+	for _, child := range container.Children {
+		parent.AddChild(child)
+	}
+	container.Children = nil
+	container.Path = nil
+	if removed == 0 {
+		container.Languages = map[string]int{} // Already counted on the parent in the base result
+	}
+	parent.Combine(container)
+	return nil
+}
+
+// enclosingComponent returns the component a scan from the root attaches the components of dir
+// to: the innermost component whose directory contains dir. Of components in the same
+// directory, the outermost is used (components merged into a sibling are not scan contexts).
+func enclosingComponent(base *types.Payload, dir string) *types.Payload {
+	parent := base
+	for {
+		var next *types.Payload
+		for _, child := range parent.Children {
+			childDir := componentDir(child)
+			deeper := parent == base || len(childDir) > len(componentDir(parent))
+			if childDir != dir && withinDir(dir, childDir) && deeper {
+				next = child
+				break
+			}
+		}
+		if next == nil {
+			return parent
+		}
+		parent = next
+	}
+}
+
+// removeComponents removes the components whose directory is within dir from the tree and
+// returns their number; their code stats are collected by ID
+func removeComponents(payload *types.Payload, dir string, codeStats map[string]interface{}) int {
+	removed := 0
+	kept := payload.Children[:0]
+	for _, child := range payload.Children {
+		if !withinDir(componentDir(child), dir) {
+			removed += removeComponents(child, dir, codeStats)
+			kept = append(kept, child)
+			continue
+		}
+		removed++
+		walkPayloads(child, func(c *types.Payload) {
+			if c.CodeStats != nil {
+				codeStats[c.ID] = c.CodeStats
+			}
+		})
+	}
+	payload.Children = kept
+	return removed
+}
+
+// componentDir returns the directory of a component's primary path ("/" for the root)
+func componentDir(payload *types.Payload) string {
+	if len(payload.Path) == 0 || payload.Path[0] == "/" {
+		return "/"
+	}
+	return path.Dir(payload.Path[0])
+}
+
+// innermostDir returns the deepest of the directories containing dir, or "/" if none does
+func innermostDir(dirs []string, dir string) string {
+	innermost := "/"
+	for _, d := range dirs {
+		if withinDir(dir, d) && len(d) > len(innermost) {
+			innermost = d
+		}
+	}
+	return innermost
+}
+
+// withinDir reports whether dir is parent or one of its subdirectories ("/"-prefixed paths)
+func withinDir(dir, parent string) bool {
+	return parent == "/" || dir == parent || strings.HasPrefix(dir, parent+"/")
+}
+
+// ancestorDirs returns the directories from root (inclusive) to dir (exclusive)
+func ancestorDirs(root, dir string) []string {
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || rel == "." {
+		return nil
+	}
+	dirs := []string{root}
+	parts := strings.Split(rel, string(filepath.Separator))
+	for i := 1; i < len(parts); i++ {
+		dirs = append(dirs, filepath.Join(root, filepath.Join(parts[:i]...)))
+	}
+	return dirs
+}
@@ -0,0 +1,111 @@
+package scanner
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if content == "" {
+			require.NoError(t, os.Remove(path))
+			continue
+		}
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+// scanResult scans dir and returns the result as read back from its JSON output
+func scanResult(t *testing.T, dir string) *types.Payload {
+	t.Helper()
+	s, err := NewScannerWithOptionsAndRootID(dir, nil, false, false, false, false, nil, "test-root")
+	require.NoError(t, err)
+	payload, err := s.Scan()
+	require.NoError(t, err)
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+	base, err := ReadBaseResult(bytes.NewReader(data))
+	require.NoError(t, err)
+	return base
+}
+
+// componentSummary maps component IDs to "name path: dependencies"
+func componentSummary(payload *types.Payload) map[string]string {
+	summary := make(map[string]string)
+	walkPayloads(payload, func(p *types.Payload) {
+		var deps []string
+		for _, dep := range p.Dependencies {
+			deps = append(deps, dep.Type+":"+dep.Name+"@"+dep.Version)
+		}
+		sort.Strings(deps)
+		data, _ := json.Marshal(deps)
+		summary[p.ID] = p.Name + " " + p.Path[0] + ": " + string(data)
+	})
+	return summary
+}
+
+func TestScanner_Rescan(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		"package.json":      `{"name": "root", "dependencies": {"lodash": "4.17.21"}}`,
+		"web/package.json":  `{"name": "web", "dependencies": {"react": "^18.0.0"}}`,
+		"api/go.mod":        "module example.com/api\n\ngo 1.21\n\nrequire github.com/gin-gonic/gin v1.9.0\n",
+		"docs/package.json": `{"name": "docs", "dependencies": {"vitepress": "1.0.0"}}`,
+	})
+	base := scanResult(t, dir)
+
+	writeTree(t, dir, map[string]string{
+		"web/package.json":     `{"name": "web", "dependencies": {"react": "^18.2.0", "zod": "3.22.0"}}`,
+		"libs/ui/package.json": `{"name": "ui", "dependencies": {"clsx": "2.0.0"}}`,
+		"docs/package.json":    "",
+		"api/handler.go":       "package api\n",
+	})
+	s, err := NewScannerWithOptionsAndRootID(dir, nil, false, false, false, false, nil, "test-root")
+	require.NoError(t, err)
+	merged, err := s.Rescan(base, []string{"web/package.json", "libs/ui/package.json", "docs/package.json", "api/handler.go"})
+	require.NoError(t, err)
+
+	meta, ok := merged.Metadata.(*metadata.ScanMetadata)
+	require.True(t, ok)
+	require.NotNil(t, meta.Incremental)
+	assert.Equal(t, []string{"/docs", "/libs/ui", "/web"}, meta.Incremental.Rescanned, "source files do not select directories")
+	assert.Equal(t, 4, meta.Incremental.ChangedFiles)
+
+	assert.Equal(t, componentSummary(scanResult(t, dir)), componentSummary(merged), "merged result matches a full scan")
+}
+
+func TestScanner_Rescan_RootManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		"package.json":     `{"name": "root", "dependencies": {"lodash": "4.17.21"}}`,
+		"web/package.json": `{"name": "web", "dependencies": {"react": "^18.0.0"}}`,
+	})
+	base := scanResult(t, dir)
+	writeTree(t, dir, map[string]string{"package.json": `{"name": "root", "dependencies": {"lodash": "4.17.22"}}`})
+
+	s, err := NewScannerWithOptionsAndRootID(dir, nil, false, false, false, false, nil, "test-root")
+	require.NoError(t, err)
+	merged, err := s.Rescan(base, []string{"package.json"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/"}, merged.Metadata.(*metadata.ScanMetadata).Incremental.Rescanned, "root changes scan the whole tree")
+	assert.Equal(t, componentSummary(scanResult(t, dir)), componentSummary(merged))
+}
+
+func TestReadBaseResult_Invalid(t *testing.T) {
+	_, err := ReadBaseResult(bytes.NewReader([]byte(`{"id": "x", "metadata": {"format": "aggregated"}}`)))
+	assert.ErrorContains(t, err, "not a full scan result")
+	_, err = ReadBaseResult(bytes.NewReader([]byte(`not json`)))
+	assert.Error(t, err)
+}
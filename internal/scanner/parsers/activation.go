@@ -0,0 +1,94 @@
+package parsers
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// MetadataActivation lists the conditions under which a dependency is installed. Every entry
+// must hold; dependencies without entries are always installed.
+const MetadataActivation = "activation"
+
+// Fields of an activation entry
+const (
+	ActivationFieldKind       = "kind"       // What the installation depends on (ActivationFeature, ...)
+	ActivationFieldConditions = "conditions" // Names or expressions of the conditions, any of which activates the dependency
+	ActivationFieldDefault    = "default"    // Whether the conditions hold without options (default features, active profiles); omitted when they depend on the environment
+)
+
+// Activation kinds
+const (
+	ActivationOptional = "optional" // npm optionalDependencies: installed unless the platform is unsupported or optional dependencies are omitted
+	ActivationFeature  = "feature"  // Cargo features of the crate enabling an optional dependency
+	ActivationExtra    = "extra"    // Python extras of the project
+	ActivationMarker   = "marker"   // Python environment markers (PEP 508) other than extras
+	ActivationProfile  = "profile"  // Maven profile declaring the dependency
+	ActivationPlatform = "platform" // Gemfile platforms
+	ActivationOS       = "os"       // package-lock.json "os" field: process.platform values, "!win32" excludes one
+	ActivationCPU      = "cpu"      // package-lock.json "cpu" field: process.arch values
+)
+
+// pythonMarkerExtraRegex matches the extra clauses of an environment marker: extra == "docs"
+var pythonMarkerExtraRegex = regexp.MustCompile(`\bextra\s*==\s*["']([^"']+)["']`)
+
+// pythonMarkerVariableRegex matches the environment variables of a marker other than extra
+var pythonMarkerVariableRegex = regexp.MustCompile(`\b(python_version|python_full_version|os_name|sys_platform|platform_release|platform_system|platform_version|platform_machine|platform_python_implementation|implementation_name|implementation_version)\b`)
+
+// AddActivation records that a dependency is only installed under conditions selected by the
+// user (features, extras, profiles), and whether they hold by default
+func AddActivation(dep *types.Dependency, kind string, conditions []string, byDefault bool) {
+	entry := activationEntry(kind, conditions)
+	entry[ActivationFieldDefault] = byDefault
+	appendActivation(dependencyMetadata(dep), entry)
+}
+
+// AddEnvironmentActivation records that a dependency is only installed in some environments
+// (markers, platforms, operating systems and architectures)
+func AddEnvironmentActivation(dep *types.Dependency, kind string, conditions []string) {
+	appendActivation(dependencyMetadata(dep), activationEntry(kind, conditions))
+}
+
+// AddPythonMarkerActivation records the environment marker of a Python requirement: the extras
+// it requires (extra == "docs") and the marker itself when it tests other variables
+func AddPythonMarkerActivation(dep *types.Dependency, marker string) {
+	marker = strings.TrimSpace(marker)
+	if marker == "" {
+		return
+	}
+	var extras []string
+	for _, match := range pythonMarkerExtraRegex.FindAllStringSubmatch(marker, -1) {
+		extras = append(extras, normalizePackageName(match[1]))
+	}
+	if len(extras) > 0 {
+		AddActivation(dep, ActivationExtra, sortedUnique(extras), false)
+	}
+	if pythonMarkerVariableRegex.MatchString(marker) {
+		AddEnvironmentActivation(dep, ActivationMarker, []string{marker})
+	}
+}
+
+// activationEntry returns an activation entry of a kind and its conditions
+func activationEntry(kind string, conditions []string) map[string]interface{} {
+	entry := map[string]interface{}{ActivationFieldKind: kind}
+	if len(conditions) > 0 {
+		entry[ActivationFieldConditions] = conditions
+	}
+	return entry
+}
+
+// appendActivation appends an entry to the activation list of a dependency metadata map. The
+// list is copied, like the origin chain.
+func appendActivation(metadata map[string]interface{}, entry map[string]interface{}) {
+	entries, _ := metadata[MetadataActivation].([]interface{})
+	metadata[MetadataActivation] = append(entries[:len(entries):len(entries)], entry)
+}
+
+// dependencyMetadata returns the metadata map of a dependency, creating it if needed
+func dependencyMetadata(dep *types.Dependency) map[string]interface{} {
+	if dep.Metadata == nil {
+		dep.Metadata = make(map[string]interface{})
+	}
+	return dep.Metadata
+}
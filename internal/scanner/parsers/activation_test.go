@@ -0,0 +1,163 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func activationOf(t *testing.T, deps []types.Dependency, name string) interface{} {
+	t.Helper()
+	for _, dep := range deps {
+		if dep.Name == name {
+			return dep.Metadata[MetadataActivation]
+		}
+	}
+	t.Fatalf("dependency %s not found", name)
+	return nil
+}
+
+func TestAddPythonMarkerActivation(t *testing.T) {
+	tests := []struct {
+		name     string
+		marker   string
+		expected interface{}
+	}{
+		{name: "no marker", marker: "", expected: nil},
+		{
+			name:   "environment marker",
+			marker: `sys_platform == "win32"`,
+			expected: []interface{}{
+				map[string]interface{}{ActivationFieldKind: ActivationMarker, ActivationFieldConditions: []string{`sys_platform == "win32"`}},
+			},
+		},
+		{
+			name:   "extras",
+			marker: `extra == "Docs" or extra == 'test'`,
+			expected: []interface{}{
+				map[string]interface{}{ActivationFieldKind: ActivationExtra, ActivationFieldConditions: []string{"docs", "test"}, ActivationFieldDefault: false},
+			},
+		},
+		{
+			name:   "extra and environment",
+			marker: `python_version < "3.11" and extra == "toml"`,
+			expected: []interface{}{
+				map[string]interface{}{ActivationFieldKind: ActivationExtra, ActivationFieldConditions: []string{"toml"}, ActivationFieldDefault: false},
+				map[string]interface{}{ActivationFieldKind: ActivationMarker, ActivationFieldConditions: []string{`python_version < "3.11" and extra == "toml"`}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dep := types.Dependency{Name: "pkg"}
+			AddPythonMarkerActivation(&dep, tt.marker)
+			assert.Equal(t, tt.expected, dep.Metadata[MetadataActivation])
+		})
+	}
+}
+
+func TestActivation_Ecosystems(t *testing.T) {
+	t.Run("npm optionalDependencies", func(t *testing.T) {
+		deps := ParsePackageJSONEnhanced([]byte(`{"optionalDependencies": {"fsevents": "^2.3.0"}}`))
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{ActivationFieldKind: ActivationOptional, ActivationFieldDefault: true},
+		}, activationOf(t, deps, "fsevents"))
+	})
+
+	t.Run("Cargo features", func(t *testing.T) {
+		crate := ParseCargoManifest(`[package]
+name = "app"
+
+[dependencies]
+serde = { version = "1", optional = true }
+tokio = { version = "1", optional = true }
+
+[features]
+default = ["serde"]
+async = ["dep:tokio"]
+`)
+		deps := CargoDependencies(crate, nil, "", nil)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{ActivationFieldKind: ActivationFeature, ActivationFieldConditions: []string{"default", "serde"}, ActivationFieldDefault: true},
+		}, activationOf(t, deps, "serde"))
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{ActivationFieldKind: ActivationFeature, ActivationFieldConditions: []string{"async"}, ActivationFieldDefault: false},
+		}, activationOf(t, deps, "tokio"))
+	})
+
+	t.Run("Python requirement markers", func(t *testing.T) {
+		deps := NewPythonParser().ParseRequirementsTxt("requests==2.31.0\npywin32>=306; sys_platform == 'win32'\n")
+		assert.Nil(t, activationOf(t, deps, "requests"))
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{ActivationFieldKind: ActivationMarker, ActivationFieldConditions: []string{"sys_platform == 'win32'"}},
+		}, activationOf(t, deps, "pywin32"))
+	})
+
+	t.Run("uv.lock extras", func(t *testing.T) {
+		lock := `version = 1
+
+[[package]]
+name = "app"
+version = "0.1.0"
+source = { editable = "." }
+dependencies = [
+    { name = "httpx" },
+    { name = "colorama", marker = "sys_platform == 'win32'" },
+]
+
+[package.optional-dependencies]
+docs = [
+    { name = "sphinx" },
+    { name = "httpx" },
+]
+
+[[package]]
+name = "httpx"
+version = "0.27.0"
+
+[[package]]
+name = "colorama"
+version = "0.4.6"
+
+[[package]]
+name = "sphinx"
+version = "7.3.7"
+`
+		deps := ParseUvLock([]byte(lock), "app")
+		require.Len(t, deps, 3)
+		assert.Nil(t, activationOf(t, deps, "httpx"), "also a main dependency")
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{ActivationFieldKind: ActivationMarker, ActivationFieldConditions: []string{"sys_platform == 'win32'"}},
+		}, activationOf(t, deps, "colorama"))
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{ActivationFieldKind: ActivationExtra, ActivationFieldConditions: []string{"docs"}, ActivationFieldDefault: false},
+		}, activationOf(t, deps, "sphinx"))
+	})
+
+	t.Run("Maven selected profile", func(t *testing.T) {
+		pom := `<project>
+	<profiles>
+		<profile>
+			<id>release</id>
+			<dependencies>
+				<dependency><groupId>com.example</groupId><artifactId>signer</artifactId><version>1.0</version></dependency>
+			</dependencies>
+		</profile>
+	</profiles>
+</project>`
+		deps := NewMavenParserWithProfiles([]string{"release"}).ParsePomXML(pom)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{ActivationFieldKind: ActivationProfile, ActivationFieldConditions: []string{"release"}, ActivationFieldDefault: false},
+		}, activationOf(t, deps, "com.example:signer"))
+	})
+
+	t.Run("Gemfile platforms", func(t *testing.T) {
+		deps := NewRubyParser().ParseGemfile("gem 'tzinfo-data', platforms: [:windows, :jruby]\n")
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{ActivationFieldKind: ActivationPlatform, ActivationFieldConditions: []string{"windows", "jruby"}},
+		}, activationOf(t, deps, "tzinfo-data"))
+	})
+}
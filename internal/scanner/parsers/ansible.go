@@ -0,0 +1,257 @@
+package parsers
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Ansible requirement kinds
+const (
+	AnsibleKindRole       = "role"
+	AnsibleKindCollection = "collection"
+)
+
+// Ansible requirement source types
+const (
+	AnsibleSourceGalaxy = "galaxy"
+	AnsibleSourceGit    = "git"
+	AnsibleSourceURL    = "url"
+	AnsibleSourceFile   = "file"
+)
+
+// AnsibleParser handles Ansible requirements.yml and galaxy.yml parsing
+type AnsibleParser struct{}
+
+// NewAnsibleParser creates a new Ansible parser
+func NewAnsibleParser() *AnsibleParser {
+	return &AnsibleParser{}
+}
+
+// AnsibleRequirement is a role or collection required by an Ansible project
+type AnsibleRequirement struct {
+	Name    string `json:"name"`
+	Kind    string `json:"kind"` // role or collection
+	Version string `json:"version,omitempty"`
+	Type    string `json:"type"`             // galaxy, git, url, or file (also dir and subdirs)
+	Source  string `json:"source,omitempty"` // Repository URL, archive, path, or custom Galaxy server
+}
+
+// AnsibleCollectionInfo describes a collection defined by galaxy.yml
+type AnsibleCollectionInfo struct {
+	Name         string               `json:"name"` // namespace.name
+	Version      string               `json:"version,omitempty"`
+	Dependencies []AnsibleRequirement `json:"dependencies,omitempty"`
+}
+
+// ParseRequirementsYAML parses an Ansible requirements.yml. Both the legacy format (a plain
+// list of roles) and the roles/collections format are supported.
+func (p *AnsibleParser) ParseRequirementsYAML(content string) ([]AnsibleRequirement, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	var requirements []AnsibleRequirement
+	root := doc.Content[0]
+	switch root.Kind {
+	case yaml.SequenceNode:
+		requirements = ansibleRequirements(root, AnsibleKindRole)
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			switch root.Content[i].Value {
+			case "roles":
+				requirements = append(requirements, ansibleRequirements(root.Content[i+1], AnsibleKindRole)...)
+			case "collections":
+				requirements = append(requirements, ansibleRequirements(root.Content[i+1], AnsibleKindCollection)...)
+			}
+		}
+	}
+	sortAnsibleRequirements(requirements)
+	return requirements, nil
+}
+
+// galaxyYAML represents the subset of a collection's galaxy.yml we use
+type galaxyYAML struct {
+	Namespace    string            `yaml:"namespace"`
+	Name         string            `yaml:"name"`
+	Version      string            `yaml:"version"`
+	Dependencies map[string]string `yaml:"dependencies"`
+}
+
+// ParseGalaxyYAML parses a collection's galaxy.yml and its collection dependencies
+// (name -> version range; "*" means any version)
+func (p *AnsibleParser) ParseGalaxyYAML(content string) (*AnsibleCollectionInfo, error) {
+	var raw galaxyYAML
+	if err := yaml.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, err
+	}
+
+	info := &AnsibleCollectionInfo{Version: raw.Version}
+	if raw.Namespace != "" && raw.Name != "" {
+		info.Name = raw.Namespace + "." + raw.Name
+	}
+	for name, version := range raw.Dependencies {
+		if version == "*" {
+			version = ""
+		}
+		info.Dependencies = append(info.Dependencies, AnsibleRequirement{
+			Name:    name,
+			Kind:    AnsibleKindCollection,
+			Version: version,
+			Type:    AnsibleSourceGalaxy,
+		})
+	}
+	sortAnsibleRequirements(info.Dependencies)
+	return info, nil
+}
+
+// ansibleRequirementEntry is a requirement in map form. Fields are decoded as strings so
+// unquoted versions such as 1.0 keep their original text.
+type ansibleRequirementEntry struct {
+	Name    string `yaml:"name"`
+	Src     string `yaml:"src"`
+	Version string `yaml:"version"`
+	Type    string `yaml:"type"`
+	SCM     string `yaml:"scm"`
+	Source  string `yaml:"source"`
+}
+
+// ansibleRequirements converts the entries (strings or maps) of a requirement list
+func ansibleRequirements(list *yaml.Node, kind string) []AnsibleRequirement {
+	if list.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var requirements []AnsibleRequirement
+	for _, item := range list.Content {
+		var req AnsibleRequirement
+		switch item.Kind {
+		case yaml.ScalarNode:
+			req = parseAnsibleRequirementString(item.Value, kind)
+		case yaml.MappingNode:
+			var entry ansibleRequirementEntry
+			if err := item.Decode(&entry); err != nil {
+				continue
+			}
+			req = parseAnsibleRequirementEntry(entry, kind)
+		default:
+			continue
+		}
+		if req.Name != "" {
+			requirements = append(requirements, req)
+		}
+	}
+	return requirements
+}
+
+// parseAnsibleRequirementString parses the short forms "geerlingguy.java" and the legacy
+// role form "git+https://github.com/org/repo.git,v1.0,name"
+func parseAnsibleRequirementString(value, kind string) AnsibleRequirement {
+	parts := strings.Split(value, ",")
+	req := AnsibleRequirement{Kind: kind}
+	if len(parts) > 1 {
+		req.Version = strings.TrimSpace(parts[1])
+	}
+	applyAnsibleSource(&req, strings.TrimSpace(parts[0]), "", "")
+	if len(parts) > 2 && strings.TrimSpace(parts[2]) != "" {
+		req.Name = strings.TrimSpace(parts[2])
+	}
+	return req
+}
+
+// parseAnsibleRequirementEntry resolves name, type, and source of a requirement map
+func parseAnsibleRequirementEntry(entry ansibleRequirementEntry, kind string) AnsibleRequirement {
+	req := AnsibleRequirement{Kind: kind, Version: entry.Version}
+	sourceType := entry.Type
+	if sourceType == "" {
+		sourceType = entry.SCM
+	}
+
+	switch {
+	case entry.Src != "":
+		applyAnsibleSource(&req, entry.Src, sourceType, entry.Source)
+		if entry.Name != "" {
+			req.Name = entry.Name
+		}
+	case kind == AnsibleKindCollection && sourceType != "" && sourceType != AnsibleSourceGalaxy:
+		// Collections from git, url, and file sources name the location in "name"
+		applyAnsibleSource(&req, entry.Name, sourceType, "")
+	default:
+		applyAnsibleSource(&req, entry.Name, sourceType, entry.Source)
+	}
+	return req
+}
+
+// applyAnsibleSource sets name, type, and source from a requirement location (Galaxy name,
+// repository URL, archive URL, or path). server is a custom Galaxy server URL.
+func applyAnsibleSource(req *AnsibleRequirement, location, sourceType, server string) {
+	location = strings.TrimPrefix(location, "git+")
+	switch {
+	case sourceType == AnsibleSourceGit || strings.HasSuffix(location, ".git") || strings.HasPrefix(location, "git@"):
+		req.Type = AnsibleSourceGit
+	case sourceType == AnsibleSourceFile || sourceType == "dir" || sourceType == "subdirs" || strings.HasPrefix(location, "file://") || strings.HasPrefix(location, "/") || strings.HasPrefix(location, "./"):
+		req.Type = AnsibleSourceFile
+	case sourceType == AnsibleSourceURL || strings.Contains(location, "://"):
+		req.Type = AnsibleSourceURL
+	default:
+		req.Type = AnsibleSourceGalaxy
+		req.Name = location
+		req.Source = server
+		return
+	}
+	req.Source = location
+	req.Name = ansibleNameFromLocation(location)
+}
+
+// ansibleNameFromLocation derives a requirement name from a repository URL, archive, or path
+func ansibleNameFromLocation(location string) string {
+	location = strings.TrimSuffix(location, "/")
+	if idx := strings.LastIndexAny(location, "/:"); idx >= 0 {
+		location = location[idx+1:]
+	}
+	for _, suffix := range []string{".git", ".tar.gz", ".tgz", ".zip"} {
+		location = strings.TrimSuffix(location, suffix)
+	}
+	return location
+}
+
+// sortAnsibleRequirements sorts requirements by kind and name for deterministic output
+func sortAnsibleRequirements(requirements []AnsibleRequirement) {
+	sort.Slice(requirements, func(i, j int) bool {
+		if requirements[i].Kind != requirements[j].Kind {
+			return requirements[i].Kind > requirements[j].Kind // roles before collections
+		}
+		return requirements[i].Name < requirements[j].Name
+	})
+}
+
+// CreateAnsibleDependencies converts requirements to dependencies
+func CreateAnsibleDependencies(requirements []AnsibleRequirement, source string) []types.Dependency {
+	deps := make([]types.Dependency, 0, len(requirements))
+	for _, req := range requirements {
+		version := req.Version
+		if version == "" {
+			version = "latest"
+		}
+		metadata := types.NewMetadata(source)
+		metadata["kind"] = req.Kind
+		metadata["source_type"] = req.Type
+		if req.Source != "" {
+			metadata["source_url"] = req.Source
+		}
+		deps = append(deps, types.Dependency{
+			Type:     DependencyTypeAnsible,
+			Name:     req.Name,
+			Version:  version,
+			Scope:    types.ScopeProd,
+			Direct:   true,
+			Metadata: metadata,
+		})
+	}
+	return deps
+}
@@ -0,0 +1,107 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRequirementsYAML(t *testing.T) {
+	content := `---
+roles:
+  - name: geerlingguy.java
+    version: 2.3.1
+  - src: https://github.com/bennojoy/nginx
+    name: nginx_role
+    version: main
+  - src: git+https://gitlab.example.com/infra/base.git
+    version: v1.0
+  - geerlingguy.docker
+collections:
+  - name: community.general
+    version: ">=7.0.0"
+  - name: my_namespace.internal
+    source: https://galaxy.example.com
+  - name: https://github.com/org/collection_repo.git
+    type: git
+    version: devel
+  - name: ./collections/local
+    type: dir
+  - ansible.posix
+`
+	requirements, err := NewAnsibleParser().ParseRequirementsYAML(content)
+	require.NoError(t, err)
+
+	assert.Equal(t, []AnsibleRequirement{
+		{Name: "base", Kind: "role", Version: "v1.0", Type: "git", Source: "https://gitlab.example.com/infra/base.git"},
+		{Name: "geerlingguy.docker", Kind: "role", Type: "galaxy"},
+		{Name: "geerlingguy.java", Kind: "role", Version: "2.3.1", Type: "galaxy"},
+		{Name: "nginx_role", Kind: "role", Version: "main", Type: "url", Source: "https://github.com/bennojoy/nginx"},
+		{Name: "ansible.posix", Kind: "collection", Type: "galaxy"},
+		{Name: "collection_repo", Kind: "collection", Version: "devel", Type: "git", Source: "https://github.com/org/collection_repo.git"},
+		{Name: "community.general", Kind: "collection", Version: ">=7.0.0", Type: "galaxy"},
+		{Name: "local", Kind: "collection", Type: "file", Source: "./collections/local"},
+		{Name: "my_namespace.internal", Kind: "collection", Type: "galaxy", Source: "https://galaxy.example.com"},
+	}, requirements)
+}
+
+func TestParseRequirementsYAML_LegacyList(t *testing.T) {
+	content := `- src: geerlingguy.apache
+  version: 1.0
+- git+https://github.com/org/ansible-role-ntp.git,2.1.0,ntp
+`
+	requirements, err := NewAnsibleParser().ParseRequirementsYAML(content)
+	require.NoError(t, err)
+
+	require.Len(t, requirements, 2)
+	assert.Equal(t, "geerlingguy.apache", requirements[0].Name)
+	assert.Equal(t, "1.0", requirements[0].Version, "unquoted versions keep their text")
+	assert.Equal(t, "ntp", requirements[1].Name)
+	assert.Equal(t, "2.1.0", requirements[1].Version)
+	assert.Equal(t, "git", requirements[1].Type)
+}
+
+func TestParseRequirementsYAML_NotAnsible(t *testing.T) {
+	requirements, err := NewAnsibleParser().ParseRequirementsYAML("python: 3.12\npackages:\n  - numpy\n")
+	require.NoError(t, err)
+	assert.Empty(t, requirements)
+
+	_, err = NewAnsibleParser().ParseRequirementsYAML("roles: [unclosed")
+	assert.Error(t, err)
+}
+
+func TestParseGalaxyYAML(t *testing.T) {
+	content := `namespace: acme
+name: platform
+version: 1.4.0
+dependencies:
+  community.general: ">=7.0.0"
+  ansible.utils: "*"
+`
+	info, err := NewAnsibleParser().ParseGalaxyYAML(content)
+	require.NoError(t, err)
+
+	assert.Equal(t, "acme.platform", info.Name)
+	assert.Equal(t, "1.4.0", info.Version)
+	assert.Equal(t, []AnsibleRequirement{
+		{Name: "ansible.utils", Kind: "collection", Type: "galaxy"},
+		{Name: "community.general", Kind: "collection", Version: ">=7.0.0", Type: "galaxy"},
+	}, info.Dependencies)
+}
+
+func TestCreateAnsibleDependencies(t *testing.T) {
+	deps := CreateAnsibleDependencies([]AnsibleRequirement{
+		{Name: "geerlingguy.java", Kind: "role", Version: "2.3.1", Type: "galaxy"},
+		{Name: "collection_repo", Kind: "collection", Type: "git", Source: "https://github.com/org/collection_repo.git"},
+	}, MetadataSourceAnsibleRequirements)
+
+	require.Len(t, deps, 2)
+	assert.Equal(t, DependencyTypeAnsible, deps[0].Type)
+	assert.Equal(t, "2.3.1", deps[0].Version)
+	assert.Equal(t, "role", deps[0].Metadata["kind"])
+	assert.Equal(t, "requirements.yml", deps[0].Metadata["source"])
+	assert.Equal(t, "latest", deps[1].Version)
+	assert.Equal(t, "git", deps[1].Metadata["source_type"])
+	assert.Equal(t, "https://github.com/org/collection_repo.git", deps[1].Metadata["source_url"])
+}
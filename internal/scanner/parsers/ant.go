@@ -0,0 +1,265 @@
+package parsers
+
+import (
+	"encoding/xml"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Pre-compiled regexes for Ant parsing
+var (
+	antPropertyRefRegex = regexp.MustCompile(`\$\{([^}]+)\}`)
+	jarFileNameRegex    = regexp.MustCompile(`^(.+?)[-_]v?(\d+(?:\.\d+)*[A-Za-z0-9]*(?:[.\-_][A-Za-z0-9]+)*)$`)
+)
+
+// antIvyNamespace is the antlib URI of the Ivy Ant tasks
+const antIvyNamespace = "antlib:org.apache.ivy.ant"
+
+// antClasspathElements are the elements whose nested paths and filesets form a classpath
+var antClasspathElements = map[string]bool{
+	"path": true, "classpath": true, "taskdef": true, "typedef": true, "bootclasspath": true,
+}
+
+// AntBuild holds the parts of an Ant build file (build.xml) used for dependency detection.
+// Paths are relative to the directory of the build file, with Ant properties resolved.
+type AntBuild struct {
+	Name        string       // Project name
+	Jars        []string     // Jar files of classpaths (pathelement, location, path and classpath attributes)
+	TaskdefJars []string     // Jar files of taskdef and typedef classpaths (build tools)
+	Filesets    []AntFileset // Jar directories of classpaths (fileset in path, classpath, taskdef)
+	Taskdefs    []string     // Custom tasks and types by name, resource, or antlib URI
+	Ivy         bool         // Resolves dependencies with the Ivy Ant tasks
+}
+
+// AntFileset is a directory of a classpath with its include patterns
+type AntFileset struct {
+	Dir      string
+	Includes []string // Ant patterns relative to Dir ("*.jar", "**/*.jar"); empty includes everything
+	Taskdef  bool     // Classpath of a taskdef or typedef (build tools)
+}
+
+// AntParser handles Apache Ant build files (build.xml)
+type AntParser struct{}
+
+// NewAntParser creates a new Ant parser
+func NewAntParser() *AntParser {
+	return &AntParser{}
+}
+
+// IsAntBuild reports whether build.xml content is an Ant project: a <project> root with
+// targets, excluding Phing build files which share the format
+func (p *AntParser) IsAntBuild(content string) bool {
+	return strings.Contains(content, "<project") && strings.Contains(content, "<target") &&
+		!strings.Contains(strings.ToLower(content), "phing")
+}
+
+// ParseBuildXML collects the classpath jars, jar directories, and task definitions of build.xml.
+// Properties follow Ant semantics (the first definition wins); entries with unresolved property
+// references are skipped since they cannot be located statically.
+func (p *AntParser) ParseBuildXML(content string) AntBuild {
+	var build AntBuild
+	properties := map[string]string{"basedir": "."}
+	basedir := "."
+	seenJars := make(map[string]bool)
+	seenTaskdefJars := make(map[string]bool)
+
+	addJars := func(value string, taskdef bool) {
+		for _, entry := range strings.FieldsFunc(value, func(r rune) bool { return r == ':' || r == ';' || r == ',' }) {
+			jar, ok := antPath(entry, basedir, properties)
+			if !ok || !strings.HasSuffix(strings.ToLower(jar), ".jar") {
+				continue
+			}
+			if taskdef && !seenTaskdefJars[jar] {
+				seenTaskdefJars[jar] = true
+				build.TaskdefJars = append(build.TaskdefJars, jar)
+			} else if !taskdef && !seenJars[jar] {
+				seenJars[jar] = true
+				build.Jars = append(build.Jars, jar)
+			}
+		}
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	decoder.Strict = false
+	var stack []string
+	var fileset *AntFileset
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			name := element.Name.Local
+			attrs := make(map[string]string, len(element.Attr))
+			for _, attr := range element.Attr {
+				attrs[attr.Name.Local] = strings.TrimSpace(attr.Value)
+				if attr.Value == antIvyNamespace {
+					build.Ivy = true
+				}
+			}
+			if element.Name.Space == antIvyNamespace {
+				build.Ivy = true
+			}
+			inClasspath := antInClasspath(stack)
+			taskdef := name == "taskdef" || name == "typedef" || antInTaskdef(stack)
+
+			switch name {
+			case "project":
+				if len(stack) == 0 {
+					build.Name = attrs["name"]
+					if dir := attrs["basedir"]; dir != "" {
+						basedir = path.Clean(strings.ReplaceAll(dir, "\\", "/"))
+						properties["basedir"] = basedir
+					}
+				}
+			case "property":
+				value := attrs["value"]
+				if value == "" {
+					value = attrs["location"]
+				}
+				if propName := attrs["name"]; propName != "" && value != "" {
+					if _, exists := properties[propName]; !exists {
+						properties[propName] = value
+					}
+				}
+			case "taskdef", "typedef":
+				for _, key := range []string{"name", "resource", "uri"} {
+					if attrs[key] != "" {
+						build.Taskdefs = append(build.Taskdefs, attrs[key])
+						if strings.HasPrefix(attrs[key], "org/apache/ivy/ant/") || attrs[key] == antIvyNamespace {
+							build.Ivy = true
+						}
+						break
+					}
+				}
+			case "pathelement":
+				addJars(attrs["location"], taskdef)
+				addJars(attrs["path"], taskdef)
+			case "fileset":
+				if inClasspath {
+					if dir, ok := antPath(attrs["dir"], basedir, properties); ok && attrs["dir"] != "" {
+						fileset = &AntFileset{Dir: dir, Taskdef: taskdef}
+						for _, include := range strings.FieldsFunc(attrs["includes"], func(r rune) bool { return r == ',' || r == ' ' }) {
+							fileset.Includes = append(fileset.Includes, include)
+						}
+					}
+				}
+			case "include":
+				if fileset != nil && attrs["name"] != "" {
+					fileset.Includes = append(fileset.Includes, attrs["name"])
+				}
+			}
+
+			if antClasspathElements[name] {
+				addJars(attrs["location"], taskdef)
+				addJars(attrs["path"], taskdef)
+			}
+			addJars(attrs["classpath"], taskdef)
+			stack = append(stack, name)
+
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			if element.Name.Local == "fileset" && fileset != nil {
+				build.Filesets = append(build.Filesets, *fileset)
+				fileset = nil
+			}
+		}
+	}
+
+	return build
+}
+
+// JarDependencies converts jar file paths to dependencies of a scope, named after the jar file
+// ("lib/commons-lang3-3.12.0.jar" is commons-lang3 3.12.0), with the path in the metadata
+func (p *AntParser) JarDependencies(jars []string, scope types.Scope) []types.Dependency {
+	var dependencies []types.Dependency
+	seen := make(map[string]bool)
+	for _, jar := range jars {
+		if seen[jar] {
+			continue
+		}
+		seen[jar] = true
+
+		name, version := ParseJarFileName(path.Base(jar))
+		metadata := types.NewMetadata(MetadataSourceBuildXML)
+		metadata["path"] = jar
+		dependencies = append(dependencies, types.Dependency{
+			Type:     DependencyTypeAnt,
+			Name:     name,
+			Version:  version,
+			Scope:    scope,
+			Direct:   true,
+			Metadata: metadata,
+		})
+	}
+	return dependencies
+}
+
+// ParseJarFileName splits a jar file name into artifact name and version
+// ("guava-31.1-jre.jar" is guava 31.1-jre); jars without a version are "latest"
+func ParseJarFileName(fileName string) (string, string) {
+	base := strings.TrimSuffix(fileName, path.Ext(fileName))
+	if match := jarFileNameRegex.FindStringSubmatch(base); match != nil {
+		return match[1], match[2]
+	}
+	return base, "latest"
+}
+
+// antInClasspath reports whether the element stack is inside a classpath definition
+func antInClasspath(stack []string) bool {
+	for _, name := range stack {
+		if antClasspathElements[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// antInTaskdef reports whether the element stack is inside a taskdef or typedef
+func antInTaskdef(stack []string) bool {
+	for _, name := range stack {
+		if name == "taskdef" || name == "typedef" {
+			return true
+		}
+	}
+	return false
+}
+
+// antPath resolves the property references of a path and makes it relative to the build file
+// directory. It fails for unresolved references and absolute paths.
+func antPath(value, basedir string, properties map[string]string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", false
+	}
+	relativeToBasedir := !strings.HasPrefix(value, "${basedir}")
+
+	for i := 0; i < 10 && strings.Contains(value, "${"); i++ {
+		value = antPropertyRefRegex.ReplaceAllStringFunc(value, func(match string) string {
+			if resolved, ok := properties[match[2:len(match)-1]]; ok {
+				return resolved
+			}
+			return match
+		})
+	}
+	if strings.Contains(value, "${") {
+		return "", false
+	}
+
+	value = strings.ReplaceAll(value, "\\", "/")
+	if path.IsAbs(value) || (len(value) > 1 && value[1] == ':') {
+		return "", false
+	}
+	if relativeToBasedir {
+		value = path.Join(basedir, value)
+	}
+	return path.Clean(value), true
+}
@@ -0,0 +1,97 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAntParser_ParseBuildXML(t *testing.T) {
+	parser := NewAntParser()
+
+	content := `<?xml version="1.0"?>
+<project name="legacy" default="dist" basedir=".">
+	<property name="lib.dir" location="lib"/>
+	<property name="lib.dir" value="ignored"/>
+	<property name="tools.dir" value="${basedir}/tools"/>
+
+	<path id="compile.classpath">
+		<fileset dir="${lib.dir}">
+			<include name="*.jar"/>
+			<include name="runtime/**/*.jar"/>
+		</fileset>
+		<pathelement location="${tools.dir}/junit-4.13.2.jar"/>
+		<pathelement path="extra/a-1.0.jar:extra/b-2.0.jar;classes"/>
+		<pathelement location="${unknown.dir}/c-1.0.jar"/>
+		<pathelement location="/opt/jars/d-1.0.jar"/>
+	</path>
+
+	<taskdef name="checkstyle" classname="com.puppycrawl.tools.checkstyle.CheckStyleTask" classpath="tools/checkstyle-8.45-all.jar"/>
+	<taskdef resource="org/apache/ivy/ant/antlib.xml" uri="antlib:org.apache.ivy.ant"/>
+
+	<target name="dist">
+		<jar destfile="dist/legacy.jar">
+			<fileset dir="build/classes"/>
+		</jar>
+	</target>
+</project>`
+
+	build := parser.ParseBuildXML(content)
+	assert.Equal(t, "legacy", build.Name)
+	assert.Equal(t, []string{"tools/junit-4.13.2.jar", "extra/a-1.0.jar", "extra/b-2.0.jar"}, build.Jars)
+	assert.Equal(t, []string{"tools/checkstyle-8.45-all.jar"}, build.TaskdefJars, "Should keep taskdef jars apart")
+	assert.Equal(t, []AntFileset{{Dir: "lib", Includes: []string{"*.jar", "runtime/**/*.jar"}}}, build.Filesets, "Should only keep classpath filesets")
+	assert.Equal(t, []string{"checkstyle", "org/apache/ivy/ant/antlib.xml"}, build.Taskdefs)
+	assert.True(t, build.Ivy)
+}
+
+func TestAntParser_ParseBuildXML_Basedir(t *testing.T) {
+	build := NewAntParser().ParseBuildXML(`<project name="sub" basedir="..">
+	<path id="cp"><fileset dir="lib" includes="*.jar, ext/*.jar"/></path>
+	<target name="compile"/>
+</project>`)
+
+	require.Len(t, build.Filesets, 1)
+	assert.Equal(t, AntFileset{Dir: "../lib", Includes: []string{"*.jar", "ext/*.jar"}}, build.Filesets[0])
+	assert.False(t, build.Ivy)
+}
+
+func TestAntParser_IsAntBuild(t *testing.T) {
+	parser := NewAntParser()
+	assert.True(t, parser.IsAntBuild(`<project name="a"><target name="b"/></project>`))
+	assert.False(t, parser.IsAntBuild(`<project name="a"><target name="b"><phingcall target="c"/></target></project>`))
+	assert.False(t, parser.IsAntBuild(`<configuration><buildType/></configuration>`))
+}
+
+func TestAntParser_JarDependencies(t *testing.T) {
+	deps := NewAntParser().JarDependencies([]string{"lib/guava-31.1-jre.jar", "lib/guava-31.1-jre.jar", "lib/tools.jar"}, types.ScopeProd)
+	require.Len(t, deps, 2)
+	assert.Equal(t, types.Dependency{
+		Type: DependencyTypeAnt, Name: "guava", Version: "31.1-jre", Scope: types.ScopeProd, Direct: true,
+		Metadata: map[string]interface{}{"source": "build.xml", "path": "lib/guava-31.1-jre.jar"},
+	}, deps[0])
+	assert.Equal(t, "tools", deps[1].Name)
+	assert.Equal(t, "latest", deps[1].Version)
+}
+
+func TestParseJarFileName(t *testing.T) {
+	tests := []struct {
+		fileName string
+		name     string
+		version  string
+	}{
+		{"commons-lang3-3.12.0.jar", "commons-lang3", "3.12.0"},
+		{"log4j-1.2.17.jar", "log4j", "1.2.17"},
+		{"ant-contrib-1.0b3.jar", "ant-contrib", "1.0b3"},
+		{"mysql-connector-java-5.1.49-bin.jar", "mysql-connector-java", "5.1.49-bin"},
+		{"jdom2-2.0.6.jar", "jdom2", "2.0.6"},
+		{"junit.jar", "junit", "latest"},
+	}
+	for _, tt := range tests {
+		name, version := ParseJarFileName(tt.fileName)
+		assert.Equal(t, tt.name, name, tt.fileName)
+		assert.Equal(t, tt.version, version, tt.fileName)
+	}
+}
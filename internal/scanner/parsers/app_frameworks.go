@@ -0,0 +1,317 @@
+package parsers
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// App framework names (match the technology rule names)
+const (
+	AppFrameworkElectron    = "electron"
+	AppFrameworkTauri       = "tauri"
+	AppFrameworkReactNative = "reactnative"
+)
+
+// App platforms
+const (
+	AppPlatformWindows = "windows"
+	AppPlatformMacOS   = "macos"
+	AppPlatformLinux   = "linux"
+	AppPlatformAndroid = "android"
+	AppPlatformIOS     = "ios"
+	AppPlatformWeb     = "web"
+)
+
+// electronBuilderPlatforms maps electron-builder platform keys to platforms
+var electronBuilderPlatforms = map[string]string{
+	"mac":   AppPlatformMacOS,
+	"win":   AppPlatformWindows,
+	"linux": AppPlatformLinux,
+}
+
+// electronBuilderDefaultTargets are the targets electron-builder builds when a platform has none configured
+var electronBuilderDefaultTargets = map[string][]string{
+	AppPlatformMacOS:   {"dmg", "zip"},
+	AppPlatformWindows: {"nsis"},
+	AppPlatformLinux:   {"AppImage", "snap"},
+}
+
+// electronForgeMakers maps Electron Forge makers to the platform and target they build
+var electronForgeMakers = map[string][2]string{
+	"@electron-forge/maker-squirrel": {AppPlatformWindows, "squirrel"},
+	"@electron-forge/maker-wix":      {AppPlatformWindows, "msi"},
+	"@electron-forge/maker-appx":     {AppPlatformWindows, "appx"},
+	"@electron-forge/maker-dmg":      {AppPlatformMacOS, "dmg"},
+	"@electron-forge/maker-pkg":      {AppPlatformMacOS, "pkg"},
+	"@electron-forge/maker-deb":      {AppPlatformLinux, "deb"},
+	"@electron-forge/maker-rpm":      {AppPlatformLinux, "rpm"},
+	"@electron-forge/maker-flatpak":  {AppPlatformLinux, "flatpak"},
+	"@electron-forge/maker-snap":     {AppPlatformLinux, "snap"},
+}
+
+// tauriBundleTargets maps Tauri bundle targets to platforms
+var tauriBundleTargets = map[string]string{
+	"deb":      AppPlatformLinux,
+	"rpm":      AppPlatformLinux,
+	"appimage": AppPlatformLinux,
+	"msi":      AppPlatformWindows,
+	"nsis":     AppPlatformWindows,
+	"app":      AppPlatformMacOS,
+	"dmg":      AppPlatformMacOS,
+}
+
+// AppFrameworkInfo describes a desktop or mobile application and its platform targets
+type AppFrameworkInfo struct {
+	Framework   string              `json:"framework"`
+	File        string              `json:"file"`
+	Version     string              `json:"version,omitempty"` // Framework version or constraint
+	AppID       string              `json:"app_id,omitempty"`  // Bundle identifier / application ID
+	ProductName string              `json:"product_name,omitempty"`
+	Platforms   []string            `json:"platforms"`
+	Targets     map[string][]string `json:"targets,omitempty"` // Packaging targets per platform
+}
+
+// AddTargets records packaging targets for a platform
+func (a *AppFrameworkInfo) AddTargets(platform string, targets ...string) {
+	if !containsString(a.Platforms, platform) {
+		a.Platforms = append(a.Platforms, platform)
+	}
+	if len(targets) == 0 {
+		return
+	}
+	if a.Targets == nil {
+		a.Targets = make(map[string][]string)
+	}
+	for _, target := range targets {
+		if !containsString(a.Targets[platform], target) {
+			a.Targets[platform] = append(a.Targets[platform], target)
+		}
+	}
+}
+
+// Sort sorts platforms and targets for deterministic output
+func (a *AppFrameworkInfo) Sort() {
+	if a.Platforms == nil {
+		a.Platforms = []string{}
+	}
+	sort.Strings(a.Platforms)
+	for _, targets := range a.Targets {
+		sort.Strings(targets)
+	}
+}
+
+// AppFrameworkParser handles Electron, Tauri, and React Native configuration parsing
+type AppFrameworkParser struct{}
+
+// NewAppFrameworkParser creates a new app framework parser
+func NewAppFrameworkParser() *AppFrameworkParser {
+	return &AppFrameworkParser{}
+}
+
+// ParseElectronBuilderConfig parses an electron-builder configuration (YAML or JSON by file name)
+// or the "build" field of package.json, adding app ID and platform targets to info
+func (p *AppFrameworkParser) ParseElectronBuilderConfig(content []byte, fileName string, info *AppFrameworkInfo) error {
+	var raw map[string]interface{}
+	var err error
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".yml", ".yaml":
+		err = yaml.Unmarshal(content, &raw)
+	default:
+		err = json.Unmarshal([]byte(stripJSONComments(string(content))), &raw)
+	}
+	if err != nil {
+		return err
+	}
+	p.applyElectronBuilder(raw, info)
+	return nil
+}
+
+// applyElectronBuilder extracts app ID and per-platform targets from a decoded electron-builder configuration
+func (p *AppFrameworkParser) applyElectronBuilder(raw map[string]interface{}, info *AppFrameworkInfo) {
+	if appID, ok := raw["appId"].(string); ok {
+		info.AppID = appID
+	}
+	if productName, ok := raw["productName"].(string); ok {
+		info.ProductName = productName
+	}
+	for _, key := range []string{"linux", "mac", "win"} {
+		value, ok := raw[key]
+		if !ok {
+			continue
+		}
+		platform := electronBuilderPlatforms[key]
+		targets := electronBuilderTargets(value)
+		if len(targets) == 0 {
+			targets = electronBuilderDefaultTargets[platform]
+		}
+		info.AddTargets(platform, targets...)
+	}
+}
+
+// electronBuilderTargets extracts target names from a platform section
+// (target: "dmg", target: ["dmg", "zip"], or target: [{target: "dmg", arch: [...]}])
+func electronBuilderTargets(section interface{}) []string {
+	m, ok := section.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	var targets []string
+	switch target := m["target"].(type) {
+	case string:
+		targets = append(targets, target)
+	case []interface{}:
+		for _, entry := range target {
+			switch e := entry.(type) {
+			case string:
+				targets = append(targets, e)
+			case map[string]interface{}:
+				if name, ok := e["target"].(string); ok {
+					targets = append(targets, name)
+				}
+			}
+		}
+	case map[string]interface{}:
+		if name, ok := target["target"].(string); ok {
+			targets = append(targets, name)
+		}
+	}
+	return targets
+}
+
+// ParseElectronPackageJSON extracts electron-builder ("build") and Electron Forge (config.forge.makers)
+// settings from package.json
+func (p *AppFrameworkParser) ParseElectronPackageJSON(content []byte, info *AppFrameworkInfo) {
+	var packageJSON struct {
+		Build  map[string]interface{} `json:"build"`
+		Config struct {
+			Forge struct {
+				Makers []struct {
+					Name      string   `json:"name"`
+					Platforms []string `json:"platforms"`
+				} `json:"makers"`
+			} `json:"forge"`
+		} `json:"config"`
+	}
+	if err := json.Unmarshal(content, &packageJSON); err != nil {
+		return
+	}
+	if packageJSON.Build != nil {
+		p.applyElectronBuilder(packageJSON.Build, info)
+	}
+	for _, maker := range packageJSON.Config.Forge.Makers {
+		if mapping, ok := electronForgeMakers[maker.Name]; ok {
+			info.AddTargets(mapping[0], mapping[1])
+			continue
+		}
+		if maker.Name == "@electron-forge/maker-zip" {
+			for _, platform := range maker.Platforms {
+				info.AddTargets(normalizeNodePlatform(platform), "zip")
+			}
+		}
+	}
+}
+
+// normalizeNodePlatform converts a Node.js platform name (darwin, win32) to an app platform
+func normalizeNodePlatform(platform string) string {
+	switch platform {
+	case "darwin", "mas":
+		return AppPlatformMacOS
+	case "win32":
+		return AppPlatformWindows
+	}
+	return platform
+}
+
+// tauriConfig represents the tauri.conf.json settings we use (Tauri 1.x and 2.x layouts)
+type tauriConfig struct {
+	ProductName string `json:"productName"` // 2.x
+	Version     string `json:"version"`     // 2.x
+	Identifier  string `json:"identifier"`  // 2.x
+	Bundle      struct {
+		Targets interface{} `json:"targets"`
+	} `json:"bundle"` // 2.x
+	Package struct {
+		ProductName string `json:"productName"`
+		Version     string `json:"version"`
+	} `json:"package"` // 1.x
+	Tauri struct {
+		Bundle struct {
+			Identifier string      `json:"identifier"`
+			Targets    interface{} `json:"targets"`
+		} `json:"bundle"`
+	} `json:"tauri"` // 1.x
+}
+
+// ParseTauriConfig parses tauri.conf.json and adds app ID and bundle targets to info.
+// "all" (the default) bundles for every desktop platform.
+func (p *AppFrameworkParser) ParseTauriConfig(content []byte, info *AppFrameworkInfo) error {
+	var config tauriConfig
+	if err := json.Unmarshal(content, &config); err != nil {
+		return err
+	}
+
+	info.ProductName = firstNonEmpty(config.ProductName, config.Package.ProductName)
+	info.AppID = firstNonEmpty(config.Identifier, config.Tauri.Bundle.Identifier)
+
+	targets := stringOrList(config.Bundle.Targets)
+	if len(targets) == 0 {
+		targets = stringOrList(config.Tauri.Bundle.Targets)
+	}
+	if len(targets) == 0 || containsString(targets, "all") {
+		info.AddTargets(AppPlatformLinux)
+		info.AddTargets(AppPlatformMacOS)
+		info.AddTargets(AppPlatformWindows)
+		return nil
+	}
+	for _, target := range targets {
+		if platform, ok := tauriBundleTargets[strings.ToLower(target)]; ok {
+			info.AddTargets(platform, target)
+		}
+	}
+	return nil
+}
+
+// ParseExpoConfig extracts the app identifiers and platforms of an Expo app.json
+// (expo.platforms defaults to ios and android)
+func (p *AppFrameworkParser) ParseExpoConfig(content []byte, info *AppFrameworkInfo) bool {
+	var appJSON struct {
+		Expo *struct {
+			Name      string   `json:"name"`
+			Platforms []string `json:"platforms"`
+			IOS       struct {
+				BundleIdentifier string `json:"bundleIdentifier"`
+			} `json:"ios"`
+			Android struct {
+				Package string `json:"package"`
+			} `json:"android"`
+		} `json:"expo"`
+	}
+	if err := json.Unmarshal(content, &appJSON); err != nil || appJSON.Expo == nil {
+		return false
+	}
+
+	expo := appJSON.Expo
+	info.ProductName = expo.Name
+	info.AppID = firstNonEmpty(expo.Android.Package, expo.IOS.BundleIdentifier)
+	platforms := expo.Platforms
+	if len(platforms) == 0 {
+		platforms = []string{AppPlatformIOS, AppPlatformAndroid}
+	}
+	for _, platform := range platforms {
+		info.AddTargets(platform)
+	}
+	return true
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,125 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseElectronBuilderConfig(t *testing.T) {
+	parser := NewAppFrameworkParser()
+
+	tests := []struct {
+		name      string
+		content   string
+		fileName  string
+		platforms []string
+		targets   map[string][]string
+		appID     string
+	}{
+		{
+			name: "YAML with target forms",
+			content: `appId: com.example.app
+productName: Example
+mac:
+  target:
+    - target: dmg
+      arch: [x64, arm64]
+    - zip
+win:
+  target: nsis
+linux:
+  target: [AppImage, deb]
+`,
+			fileName:  "electron-builder.yml",
+			platforms: []string{"linux", "macos", "windows"},
+			targets:   map[string][]string{"linux": {"AppImage", "deb"}, "macos": {"dmg", "zip"}, "windows": {"nsis"}},
+			appID:     "com.example.app",
+		},
+		{
+			name:      "JSON with default targets",
+			content:   `{"appId": "com.example.app", "win": {}, /* comment */ "mac": {"category": "public.app-category.developer-tools"}}`,
+			fileName:  "electron-builder.json",
+			platforms: []string{"macos", "windows"},
+			targets:   map[string][]string{"macos": {"dmg", "zip"}, "windows": {"nsis"}},
+			appID:     "com.example.app",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &AppFrameworkInfo{}
+			require.NoError(t, parser.ParseElectronBuilderConfig([]byte(tt.content), tt.fileName, info))
+			info.Sort()
+			assert.Equal(t, tt.platforms, info.Platforms)
+			assert.Equal(t, tt.targets, info.Targets)
+			assert.Equal(t, tt.appID, info.AppID)
+		})
+	}
+
+	assert.Error(t, parser.ParseElectronBuilderConfig([]byte("mac: ["), "electron-builder.yml", &AppFrameworkInfo{}))
+}
+
+func TestParseElectronPackageJSON(t *testing.T) {
+	parser := NewAppFrameworkParser()
+	content := `{
+  "build": {"appId": "com.example.app", "linux": {"target": "snap"}},
+  "config": {"forge": {"makers": [
+    {"name": "@electron-forge/maker-squirrel"},
+    {"name": "@electron-forge/maker-zip", "platforms": ["darwin"]}
+  ]}}
+}`
+	info := &AppFrameworkInfo{}
+	parser.ParseElectronPackageJSON([]byte(content), info)
+	info.Sort()
+
+	assert.Equal(t, "com.example.app", info.AppID)
+	assert.Equal(t, []string{"linux", "macos", "windows"}, info.Platforms)
+	assert.Equal(t, map[string][]string{"linux": {"snap"}, "macos": {"zip"}, "windows": {"squirrel"}}, info.Targets)
+}
+
+func TestParseTauriConfig(t *testing.T) {
+	parser := NewAppFrameworkParser()
+
+	t.Run("Tauri 2 with targets", func(t *testing.T) {
+		info := &AppFrameworkInfo{}
+		content := `{"productName": "Notes", "version": "1.0.0", "identifier": "com.example.notes", "bundle": {"targets": ["deb", "msi", "dmg"]}}`
+		require.NoError(t, parser.ParseTauriConfig([]byte(content), info))
+		info.Sort()
+		assert.Equal(t, "Notes", info.ProductName)
+		assert.Equal(t, "com.example.notes", info.AppID)
+		assert.Equal(t, []string{"linux", "macos", "windows"}, info.Platforms)
+		assert.Equal(t, map[string][]string{"linux": {"deb"}, "macos": {"dmg"}, "windows": {"msi"}}, info.Targets)
+	})
+
+	t.Run("Tauri 1 with all targets", func(t *testing.T) {
+		info := &AppFrameworkInfo{}
+		content := `{"package": {"productName": "Legacy"}, "tauri": {"bundle": {"identifier": "com.example.legacy", "targets": "all"}}}`
+		require.NoError(t, parser.ParseTauriConfig([]byte(content), info))
+		info.Sort()
+		assert.Equal(t, "Legacy", info.ProductName)
+		assert.Equal(t, "com.example.legacy", info.AppID)
+		assert.Equal(t, []string{"linux", "macos", "windows"}, info.Platforms)
+		assert.Nil(t, info.Targets)
+	})
+
+	assert.Error(t, parser.ParseTauriConfig([]byte("{"), &AppFrameworkInfo{}))
+}
+
+func TestParseExpoConfig(t *testing.T) {
+	parser := NewAppFrameworkParser()
+
+	info := &AppFrameworkInfo{}
+	assert.True(t, parser.ParseExpoConfig([]byte(`{"expo": {"name": "Shop", "android": {"package": "com.example.shop"}}}`), info))
+	info.Sort()
+	assert.Equal(t, "Shop", info.ProductName)
+	assert.Equal(t, "com.example.shop", info.AppID)
+	assert.Equal(t, []string{"android", "ios"}, info.Platforms, "Expo defaults to iOS and Android")
+
+	info = &AppFrameworkInfo{}
+	assert.True(t, parser.ParseExpoConfig([]byte(`{"expo": {"platforms": ["ios", "web"]}}`), info))
+	assert.Equal(t, []string{"ios", "web"}, info.Platforms)
+
+	assert.False(t, parser.ParseExpoConfig([]byte(`{"name": "bare", "displayName": "Bare"}`), &AppFrameworkInfo{}), "bare React Native app.json")
+}
@@ -0,0 +1,125 @@
+package parsers
+
+import (
+	"path"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// MetadataArtifactPlatforms lists the platforms (GOOS/GOARCH: linux/amd64) of the platform
+// specific artifacts locked for a package without a portable one (source distribution, pure
+// wheel, ruby gem); the package installs without building only on these platforms
+const MetadataArtifactPlatforms = "artifact_platforms"
+
+// wheelArchitectures and gemArchitectures map the architectures of wheel platform tags and gem
+// platforms to GOARCH names
+var (
+	wheelArchitectures = map[string][]string{
+		"x86_64": {"amd64"}, "amd64": {"amd64"}, "aarch64": {"arm64"}, "arm64": {"arm64"},
+		"i686": {"386"}, "armv7l": {"arm"}, "ppc64le": {"ppc64le"}, "s390x": {"s390x"},
+		"universal2": {"amd64", "arm64"}, "intel": {"amd64"},
+	}
+	gemArchitectures = map[string][]string{
+		"x86_64": {"amd64"}, "x64": {"amd64"}, "aarch64": {"arm64"}, "arm64": {"arm64"},
+		"x86": {"386"}, "i386": {"386"}, "i686": {"386"}, "arm": {"arm"},
+		"universal": {"amd64", "arm64"},
+	}
+)
+
+// WheelPlatforms returns the platforms of a wheel file name from its platform tags
+// (manylinux_2_17_x86_64.manylinux2014_x86_64, macosx_11_0_arm64, win_amd64), and whether the
+// wheel is portable (any). Tags of other platforms (ios, android) are skipped.
+func WheelPlatforms(fileName string) ([]string, bool) {
+	name := strings.TrimSuffix(path.Base(fileName), ".whl")
+	parts := strings.Split(name, "-")
+	if name == path.Base(fileName) || len(parts) < 5 {
+		return nil, false
+	}
+	var platforms []string
+	for _, tag := range strings.Split(parts[len(parts)-1], ".") {
+		switch {
+		case tag == "any":
+			return nil, true
+		case tag == "win32":
+			platforms = append(platforms, "windows/386")
+		case strings.HasPrefix(tag, "win_"):
+			platforms = append(platforms, platformsOf("windows", wheelArchitectures[strings.TrimPrefix(tag, "win_")])...)
+		case strings.HasPrefix(tag, "macosx_"):
+			platforms = append(platforms, platformsOf("darwin", wheelArchitectures[wheelTagArchitecture(tag)])...)
+		case strings.HasPrefix(tag, "manylinux"), strings.HasPrefix(tag, "musllinux"), strings.HasPrefix(tag, "linux_"):
+			platforms = append(platforms, platformsOf("linux", wheelArchitectures[wheelTagArchitecture(tag)])...)
+		}
+	}
+	return sortedUnique(platforms), false
+}
+
+// wheelTagArchitecture returns the architecture of a platform tag: the known architecture its
+// name ends with (manylinux_2_17_x86_64: x86_64)
+func wheelTagArchitecture(tag string) string {
+	for arch := range wheelArchitectures {
+		if strings.HasSuffix(tag, "_"+arch) {
+			return arch
+		}
+	}
+	return ""
+}
+
+// PythonArtifactPlatforms returns the platforms of the locked wheels of a package, or nil if
+// it has a portable artifact (a source distribution or a pure wheel) or no wheels
+func PythonArtifactPlatforms(wheels []string, sdist bool) []string {
+	if sdist || len(wheels) == 0 {
+		return nil
+	}
+	var platforms []string
+	for _, wheel := range wheels {
+		wheelPlatforms, portable := WheelPlatforms(wheel)
+		if portable {
+			return nil
+		}
+		platforms = append(platforms, wheelPlatforms...)
+	}
+	return sortedUnique(platforms)
+}
+
+// GemPlatforms returns the platforms of a gem platform (x86_64-linux, arm64-darwin,
+// x64-mingw-ucrt), and whether the platform is portable (ruby, java). Platforms of unknown
+// operating systems or architectures return none.
+func GemPlatforms(platform string) ([]string, bool) {
+	platform = strings.ToLower(strings.TrimSpace(platform))
+	switch platform {
+	case "ruby", "java", "jruby", "mri", "truffleruby":
+		return nil, true
+	}
+	arch, system, _ := strings.Cut(platform, "-")
+	var goos string
+	switch {
+	case strings.HasPrefix(system, "linux"):
+		goos = "linux"
+	case strings.HasPrefix(system, "darwin"):
+		goos = "darwin"
+	case strings.HasPrefix(system, "mingw"), strings.HasPrefix(system, "mswin"):
+		goos = "windows"
+	default:
+		return nil, false
+	}
+	return platformsOf(goos, gemArchitectures[arch]), false
+}
+
+// platformsOf returns the platforms of an operating system and architectures
+func platformsOf(goos string, archs []string) []string {
+	platforms := make([]string, 0, len(archs))
+	for _, arch := range archs {
+		platforms = append(platforms, goos+"/"+arch)
+	}
+	return platforms
+}
+
+// SetArtifactPlatforms records the platforms of the platform specific artifacts of a
+// dependency; no platforms is a no-op
+func SetArtifactPlatforms(dep *types.Dependency, platforms []string) {
+	if len(platforms) == 0 {
+		return
+	}
+	dependencyMetadata(dep)[MetadataArtifactPlatforms] = sortedUnique(platforms)
+}
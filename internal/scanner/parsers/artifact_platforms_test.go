@@ -0,0 +1,72 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWheelPlatforms(t *testing.T) {
+	tests := []struct {
+		file      string
+		platforms []string
+		portable  bool
+	}{
+		{"numpy-2.1.0-cp312-cp312-manylinux_2_17_x86_64.manylinux2014_x86_64.whl", []string{"linux/amd64"}, false},
+		{"numpy-2.1.0-cp312-cp312-musllinux_1_2_aarch64.whl", []string{"linux/arm64"}, false},
+		{"numpy-2.1.0-cp312-cp312-macosx_11_0_arm64.whl", []string{"darwin/arm64"}, false},
+		{"orjson-3.10.0-cp312-cp312-macosx_10_15_x86_64.macosx_11_0_arm64.macosx_10_15_universal2.whl", []string{"darwin/amd64", "darwin/arm64"}, false},
+		{"pywin32-306-cp312-cp312-win_amd64.whl", []string{"windows/amd64"}, false},
+		{"pywin32-306-cp312-cp312-win32.whl", []string{"windows/386"}, false},
+		{"https://files.example.org/requests-2.31.0-py3-none-any.whl", nil, true},
+		{"requests-2.31.0.tar.gz", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			platforms, portable := WheelPlatforms(tt.file)
+			assert.Equal(t, tt.platforms, platforms)
+			assert.Equal(t, tt.portable, portable)
+		})
+	}
+}
+
+func TestPythonArtifactPlatforms(t *testing.T) {
+	wheels := []string{
+		"torch-2.4.0-cp312-cp312-manylinux1_x86_64.whl",
+		"torch-2.4.0-cp312-cp312-macosx_11_0_arm64.whl",
+		"torch-2.4.0-cp312-cp312-win_amd64.whl",
+	}
+	assert.Equal(t, []string{"darwin/arm64", "linux/amd64", "windows/amd64"}, PythonArtifactPlatforms(wheels, false))
+	assert.Nil(t, PythonArtifactPlatforms(wheels, true), "source distribution")
+	assert.Nil(t, PythonArtifactPlatforms(append(wheels, "torch-2.4.0-py3-none-any.whl"), false), "pure wheel")
+	assert.Nil(t, PythonArtifactPlatforms(nil, false))
+}
+
+func TestGemPlatforms(t *testing.T) {
+	tests := []struct {
+		platform  string
+		platforms []string
+		portable  bool
+	}{
+		{"x86_64-linux", []string{"linux/amd64"}, false},
+		{"aarch64-linux-gnu", []string{"linux/arm64"}, false},
+		{"arm64-darwin", []string{"darwin/arm64"}, false},
+		{"universal-darwin", []string{"darwin/amd64", "darwin/arm64"}, false},
+		{"x64-mingw-ucrt", []string{"windows/amd64"}, false},
+		{"x86-mingw32", []string{"windows/386"}, false},
+		{"ruby", nil, true},
+		{"java", nil, true},
+		{"x86_64-freebsd", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.platform, func(t *testing.T) {
+			platforms, portable := GemPlatforms(tt.platform)
+			if tt.platforms == nil {
+				assert.Empty(t, platforms)
+			} else {
+				assert.Equal(t, tt.platforms, platforms)
+			}
+			assert.Equal(t, tt.portable, portable)
+		})
+	}
+}
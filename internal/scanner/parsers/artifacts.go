@@ -0,0 +1,322 @@
+package parsers
+
+import (
+	"archive/zip"
+	"bytes"
+	"debug/buildinfo"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Binary artifact formats
+const (
+	ArtifactFormatJar    = "jar"
+	ArtifactFormatWar    = "war"
+	ArtifactFormatEar    = "ear"
+	ArtifactFormatGo     = "go"
+	ArtifactFormatDotnet = "dotnet"
+)
+
+// MaxNestedJarSize bounds the size of a jar embedded in a war, ear, or executable jar that is
+// read for its Maven coordinates
+const MaxNestedJarSize = 64 << 20
+
+// nestedLibDirs are the directories of archives holding embedded jars
+var nestedLibDirs = []string{"WEB-INF/lib/", "BOOT-INF/lib/", "lib/", ""}
+
+// goBuildSettings are the build settings of Go binaries reported in the artifact properties
+var goBuildSettings = map[string]bool{
+	"GOOS": true, "GOARCH": true, "CGO_ENABLED": true, "-trimpath": true, "-tags": true,
+	"vcs": true, "vcs.revision": true, "vcs.time": true, "vcs.modified": true,
+}
+
+// Artifact describes a binary artifact: a Java archive, a Go binary, a .NET assembly, or a
+// native executable.
+// Applications report the modules they embed; libraries are dependencies themselves.
+type Artifact struct {
+	File            string            `json:"file"`
+	Format          string            `json:"format"`
+	Name            string            `json:"name,omitempty"`
+	Version         string            `json:"version,omitempty"`
+	Vendor          string            `json:"vendor,omitempty"`
+	MainClass       string            `json:"main_class,omitempty"`
+	BuildJdk        string            `json:"build_jdk,omitempty"`
+	GoVersion       string            `json:"go_version,omitempty"`
+	TargetFramework string            `json:"target_framework,omitempty"`
+	RuntimeVersion  string            `json:"runtime_version,omitempty"` // CLR metadata version (v4.0.30319)
+	Settings        map[string]string `json:"settings,omitempty"`        // Go build settings (GOOS, GOARCH, vcs.revision)
+	Arch            string            `json:"arch,omitempty"`            // Architecture of native executables
+	Application     bool              `json:"application"`
+	Layer           *ImageLayer       `json:"layer,omitempty"` // Container image layer that introduced the file (--image-archive)
+	Type            string            `json:"-"`               // Dependency type of the artifact itself
+	Modules         []ArtifactModule  `json:"-"`
+}
+
+// ArtifactModule is a module embedded in or referenced by an artifact
+type ArtifactModule struct {
+	Type           string
+	Name           string
+	Version        string
+	Replace        string // Replacement of a Go module (path@version)
+	Package        string // Package providing a shared library
+	PackageManager string // OS package manager of Package (dpkg, apk), empty for known projects
+}
+
+// ArtifactParser reads the metadata of binary artifacts
+type ArtifactParser struct{}
+
+// NewArtifactParser creates a new binary artifact parser
+func NewArtifactParser() *ArtifactParser {
+	return &ArtifactParser{}
+}
+
+// ParseJavaArchive reads a jar, war, or ear: its identity from META-INF/maven pom.properties or
+// the manifest (Implementation-Title and -Version, Bundle-SymbolicName), the main class, and
+// the build JDK. The jars embedded in WEB-INF/lib/, BOOT-INF/lib/, and lib/ and the shaded
+// Maven modules are the modules of the archive. Archives with a main class or embedded jars,
+// wars, and ears are applications.
+func (p *ArtifactParser) ParseJavaArchive(content []byte, fileName string) (*Artifact, error) {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, err
+	}
+
+	format := strings.TrimPrefix(strings.ToLower(path.Ext(fileName)), ".")
+	artifact := &Artifact{Format: format}
+	headers := parseManifestHeaders(readZipEntry(reader, "META-INF/MANIFEST.MF"))
+	artifact.Vendor = headers["Implementation-Vendor"]
+	artifact.MainClass = firstNonEmpty(headers["Start-Class"], headers["Main-Class"]) // Spring Boot launches Start-Class
+	artifact.BuildJdk = firstNonEmpty(headers["Build-Jdk-Spec"], headers["Build-Jdk"])
+
+	identity, poms := jarIdentity(reader, headers, fileName)
+	artifact.Type, artifact.Name, artifact.Version = identity.Type, identity.Name, identity.Version
+
+	for _, file := range reader.File {
+		if !isNestedJar(file.Name, format) || file.UncompressedSize64 > MaxNestedJarSize {
+			continue
+		}
+		nested, err := readZipFile(file)
+		if err != nil {
+			continue
+		}
+		module := ArtifactModule{Type: DependencyTypeJar}
+		module.Name, module.Version = ParseJarFileName(path.Base(file.Name))
+		if nestedReader, err := zip.NewReader(bytes.NewReader(nested), int64(len(nested))); err == nil {
+			module, _ = jarIdentity(nestedReader, parseManifestHeaders(readZipEntry(nestedReader, "META-INF/MANIFEST.MF")), path.Base(file.Name))
+		}
+		artifact.Modules = append(artifact.Modules, module)
+	}
+	for _, pom := range poms { // Shaded modules
+		if pom.Name != identity.Name {
+			artifact.Modules = append(artifact.Modules, pom)
+		}
+	}
+	artifact.Application = format != ArtifactFormatJar || artifact.MainClass != "" || len(artifact.Modules) > 0
+	return artifact, nil
+}
+
+// ParseGoBinary reads the build information embedded in a Go binary: the main module, the Go
+// version, the build settings, and the module dependencies (with their replacements)
+func (p *ArtifactParser) ParseGoBinary(content []byte) (*Artifact, error) {
+	info, err := buildinfo.Read(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	artifact := &Artifact{
+		Format:      ArtifactFormatGo,
+		Name:        firstNonEmpty(info.Main.Path, info.Path),
+		GoVersion:   info.GoVersion,
+		Application: true,
+		Type:        DependencyTypeGolang,
+	}
+	if info.Main.Version != "(devel)" {
+		artifact.Version = info.Main.Version
+	}
+	for _, setting := range info.Settings {
+		if goBuildSettings[setting.Key] {
+			if artifact.Settings == nil {
+				artifact.Settings = make(map[string]string)
+			}
+			artifact.Settings[setting.Key] = setting.Value
+		}
+	}
+	for _, dep := range info.Deps {
+		module := ArtifactModule{Type: DependencyTypeGolang, Name: dep.Path, Version: dep.Version}
+		if dep.Replace != nil {
+			module.Replace = dep.Replace.Path
+			if dep.Replace.Version != "" {
+				module.Replace += "@" + dep.Replace.Version
+			}
+		}
+		artifact.Modules = append(artifact.Modules, module)
+	}
+	return artifact, nil
+}
+
+// AddSharedLibraries adds the shared libraries required by a native executable to its
+// modules. A library installed by the OS package database of the scanned tree takes the
+// package and its installed version; otherwise the version is the ABI version of the soname.
+func (p *ArtifactParser) AddSharedLibraries(artifact *Artifact, libraries []NativeLibrary, index *OSPackageIndex) {
+	for _, library := range libraries {
+		module := ArtifactModule{Type: DependencyTypeSharedLibrary, Name: library.Name, Version: library.Version, Package: library.Package}
+		if index != nil {
+			if pkg, version, ok := index.Lookup(library.Name); ok {
+				module.Package, module.PackageManager = pkg, index.Manager
+				if version != "" {
+					module.Version = version
+				}
+			}
+		}
+		artifact.Modules = append(artifact.Modules, module)
+	}
+}
+
+// Dependencies converts an artifact to dependencies: the embedded modules of an application,
+// or the artifact itself for a library. Versions are the resolved versions recorded in the
+// artifact; the "artifact" metadata records the artifact file, "replace" the replacement of a
+// Go module, and "package" and "package_manager" the package providing a shared library.
+func (p *ArtifactParser) Dependencies(artifact *Artifact) []types.Dependency {
+	modules := artifact.Modules
+	if !artifact.Application {
+		modules = []ArtifactModule{{Type: artifact.Type, Name: artifact.Name, Version: artifact.Version}}
+	}
+
+	var dependencies []types.Dependency
+	for _, module := range modules {
+		if module.Name == "" {
+			continue
+		}
+		metadata := types.NewMetadata(pathBase(artifact.File))
+		metadata[MetadataArtifact] = artifact.File
+		if module.Replace != "" {
+			metadata["replace"] = module.Replace
+		}
+		if module.Package != "" {
+			metadata["package"] = module.Package
+		}
+		if module.PackageManager != "" {
+			metadata["package_manager"] = module.PackageManager
+		}
+		version := module.Version
+		if version == "" {
+			version = "latest"
+		}
+		dependencies = append(dependencies, types.Dependency{
+			Type:     module.Type,
+			Name:     module.Name,
+			Version:  version,
+			Scope:    types.ScopeProd,
+			Direct:   true,
+			Metadata: metadata,
+		})
+	}
+	return dependencies
+}
+
+// jarIdentity returns the identity of a jar: the Maven coordinates of the pom.properties
+// matching the file name (or of the only one), else the OSGi or manifest name and version,
+// else the file name. Also returns the Maven modules of all pom.properties.
+func jarIdentity(reader *zip.Reader, headers map[string]string, fileName string) (ArtifactModule, []ArtifactModule) {
+	baseName, fileVersion := ParseJarFileName(fileName)
+	var poms []ArtifactModule
+	identity := -1
+	for _, file := range reader.File {
+		if !strings.HasPrefix(file.Name, "META-INF/maven/") || !strings.HasSuffix(file.Name, "/pom.properties") {
+			continue
+		}
+		content, err := readZipFile(file)
+		if err != nil {
+			continue
+		}
+		properties := parseProperties(string(content))
+		if properties["groupId"] == "" || properties["artifactId"] == "" {
+			continue
+		}
+		poms = append(poms, ArtifactModule{
+			Type:    DependencyTypeMaven,
+			Name:    properties["groupId"] + ":" + properties["artifactId"],
+			Version: properties["version"],
+		})
+		if properties["artifactId"] == baseName {
+			identity = len(poms) - 1
+		}
+	}
+	if identity < 0 && len(poms) == 1 {
+		identity = 0
+	}
+	if identity >= 0 {
+		module := poms[identity]
+		sort.Slice(poms, func(i, j int) bool { return poms[i].Name < poms[j].Name })
+		return module, poms
+	}
+	sort.Slice(poms, func(i, j int) bool { return poms[i].Name < poms[j].Name })
+
+	name := firstNonEmpty(strings.TrimSpace(strings.Split(headers["Bundle-SymbolicName"], ";")[0]),
+		headers["Automatic-Module-Name"], headers["Implementation-Title"], baseName)
+	version := firstNonEmpty(headers["Implementation-Version"], headers["Bundle-Version"])
+	if version == "" && fileVersion != "latest" {
+		version = fileVersion
+	}
+	return ArtifactModule{Type: DependencyTypeJar, Name: name, Version: version}, poms
+}
+
+// isNestedJar reports whether an archive entry is an embedded jar (lib/ of wars, executable
+// jars, and ears, and the modules at the root of ears)
+func isNestedJar(name, format string) bool {
+	if !strings.HasSuffix(strings.ToLower(name), ".jar") {
+		return false
+	}
+	for _, dir := range nestedLibDirs {
+		if dir == "" && format != ArtifactFormatEar {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(name, dir); ok && !strings.Contains(rest, "/") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseProperties parses a Java properties file of key=value lines
+func parseProperties(content string) map[string]string {
+	properties := make(map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, "="); ok {
+			properties[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return properties
+}
+
+// readZipEntry returns the content of an archive entry, or "" if it is missing
+func readZipEntry(reader *zip.Reader, name string) string {
+	for _, file := range reader.File {
+		if file.Name == name {
+			content, err := readZipFile(file)
+			if err != nil {
+				return ""
+			}
+			return string(content)
+		}
+	}
+	return ""
+}
+
+// readZipFile reads an archive entry
+func readZipFile(file *zip.File) ([]byte, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(io.LimitReader(rc, MaxNestedJarSize))
+}
@@ -0,0 +1,177 @@
+package parsers
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildZip returns an archive with the given entries
+func buildZip(t *testing.T, entries map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, content := range entries {
+		w, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+func TestArtifactParser_ParseJavaArchive_Library(t *testing.T) {
+	content := buildZip(t, map[string][]byte{
+		"META-INF/MANIFEST.MF":                                 []byte("Manifest-Version: 1.0\r\nImplementation-Title: Guava: Google Core Libraries for Java\r\nBundle-SymbolicName: com.google.guava\r\nBuild-Jdk-Spec: 11\r\n"),
+		"META-INF/maven/com.google.guava/guava/pom.properties": []byte("#Created by Apache Maven 3.8.6\ngroupId=com.google.guava\nartifactId=guava\nversion=31.1-jre\n"),
+		"com/google/common/base/Strings.class":                 []byte("class"),
+	})
+
+	artifact, err := NewArtifactParser().ParseJavaArchive(content, "guava-31.1-jre.jar")
+	require.NoError(t, err)
+	assert.Equal(t, ArtifactFormatJar, artifact.Format)
+	assert.Equal(t, "com.google.guava:guava", artifact.Name)
+	assert.Equal(t, "31.1-jre", artifact.Version)
+	assert.Equal(t, "11", artifact.BuildJdk)
+	assert.False(t, artifact.Application)
+
+	artifact.File = "/lib/guava-31.1-jre.jar"
+	deps := NewArtifactParser().Dependencies(artifact)
+	require.Len(t, deps, 1)
+	assert.Equal(t, DependencyTypeMaven, deps[0].Type)
+	assert.Equal(t, "com.google.guava:guava", deps[0].Name)
+	assert.Equal(t, "31.1-jre", deps[0].Version)
+	assert.Equal(t, "guava-31.1-jre.jar", deps[0].Metadata["source"])
+	assert.Equal(t, "/lib/guava-31.1-jre.jar", deps[0].Metadata[MetadataArtifact])
+}
+
+func TestArtifactParser_ParseJavaArchive_ManifestOnly(t *testing.T) {
+	content := buildZip(t, map[string][]byte{
+		"META-INF/MANIFEST.MF": []byte("Manifest-Version: 1.0\nImplementation-Title: legacy-utils\nImplementation-Version: 2.3\nImplementation-Vendor: Example Corp\n"),
+	})
+
+	artifact, err := NewArtifactParser().ParseJavaArchive(content, "legacy.jar")
+	require.NoError(t, err)
+	assert.Equal(t, DependencyTypeJar, artifact.Type)
+	assert.Equal(t, "legacy-utils", artifact.Name)
+	assert.Equal(t, "2.3", artifact.Version)
+	assert.Equal(t, "Example Corp", artifact.Vendor)
+}
+
+func TestArtifactParser_ParseJavaArchive_War(t *testing.T) {
+	springCore := buildZip(t, map[string][]byte{
+		"META-INF/maven/org.springframework/spring-core/pom.properties": []byte("groupId=org.springframework\nartifactId=spring-core\nversion=6.1.4\n"),
+	})
+	content := buildZip(t, map[string][]byte{
+		"META-INF/MANIFEST.MF":                           []byte("Manifest-Version: 1.0\n"),
+		"META-INF/maven/com.example/shop/pom.properties": []byte("groupId=com.example\nartifactId=shop\nversion=1.4.0\n"),
+		"WEB-INF/lib/spring-core-6.1.4.jar":              springCore,
+		"WEB-INF/lib/commons-text-1.11.0.jar":            []byte("not an archive"),
+		"WEB-INF/classes/com/example/Shop.class":         []byte("class"),
+	})
+
+	artifact, err := NewArtifactParser().ParseJavaArchive(content, "shop.war")
+	require.NoError(t, err)
+	assert.Equal(t, ArtifactFormatWar, artifact.Format)
+	assert.Equal(t, "com.example:shop", artifact.Name)
+	assert.Equal(t, "1.4.0", artifact.Version)
+	assert.True(t, artifact.Application)
+	assert.ElementsMatch(t, []ArtifactModule{
+		{Type: DependencyTypeMaven, Name: "org.springframework:spring-core", Version: "6.1.4"},
+		{Type: DependencyTypeJar, Name: "commons-text", Version: "1.11.0"},
+	}, artifact.Modules)
+
+	artifact.File = "/dist/shop.war"
+	deps := NewArtifactParser().Dependencies(artifact)
+	assert.Len(t, deps, 2, "applications list their embedded modules")
+}
+
+func TestArtifactParser_ParseJavaArchive_SpringBootJar(t *testing.T) {
+	content := buildZip(t, map[string][]byte{
+		"META-INF/MANIFEST.MF":                 []byte("Main-Class: org.springframework.boot.loader.launch.JarLauncher\nStart-Class: com.example.Application\n"),
+		"BOOT-INF/lib/jackson-core-2.16.1.jar": []byte("not an archive"),
+	})
+
+	artifact, err := NewArtifactParser().ParseJavaArchive(content, "app-0.0.1-SNAPSHOT.jar")
+	require.NoError(t, err)
+	assert.Equal(t, "com.example.Application", artifact.MainClass)
+	assert.Equal(t, "app", artifact.Name)
+	assert.Equal(t, "0.0.1-SNAPSHOT", artifact.Version)
+	assert.True(t, artifact.Application)
+	assert.Equal(t, []ArtifactModule{{Type: DependencyTypeJar, Name: "jackson-core", Version: "2.16.1"}}, artifact.Modules)
+}
+
+func TestArtifactParser_ParseJavaArchive_NotAnArchive(t *testing.T) {
+	_, err := NewArtifactParser().ParseJavaArchive([]byte("plain text"), "broken.jar")
+	assert.Error(t, err)
+}
+
+func TestArtifactParser_ParseGoBinary(t *testing.T) {
+	executable, err := os.Executable()
+	require.NoError(t, err)
+	content, err := os.ReadFile(executable)
+	require.NoError(t, err)
+
+	artifact, err := NewArtifactParser().ParseGoBinary(content)
+	require.NoError(t, err)
+	assert.Equal(t, ArtifactFormatGo, artifact.Format)
+	assert.True(t, strings.HasPrefix(artifact.GoVersion, "go"))
+	assert.NotEmpty(t, artifact.Name)
+	assert.True(t, artifact.Application)
+	assert.NotEmpty(t, artifact.Settings["GOOS"])
+
+	var names []string
+	for _, module := range artifact.Modules {
+		assert.Equal(t, DependencyTypeGolang, module.Type)
+		names = append(names, module.Name)
+	}
+	assert.Contains(t, names, "github.com/stretchr/testify")
+
+	_, err = NewArtifactParser().ParseGoBinary([]byte("#!/bin/sh\necho hello\n"))
+	assert.Error(t, err)
+}
+
+func TestArtifactParser_Dependencies_GoReplace(t *testing.T) {
+	artifact := &Artifact{File: "/bin/server", Format: ArtifactFormatGo, Application: true, Modules: []ArtifactModule{
+		{Type: DependencyTypeGolang, Name: "github.com/gorilla/mux", Version: "v1.8.1"},
+		{Type: DependencyTypeGolang, Name: "example.com/internal/auth", Version: "v0.0.0", Replace: "../auth"},
+	}}
+
+	deps := NewArtifactParser().Dependencies(artifact)
+	require.Len(t, deps, 2)
+	assert.Equal(t, "v1.8.1", deps[0].Version)
+	assert.Equal(t, "server", deps[0].Metadata["source"])
+	assert.Nil(t, deps[0].Metadata["replace"])
+	assert.Equal(t, "../auth", deps[1].Metadata["replace"])
+}
+
+func TestArtifactParser_AddSharedLibraries(t *testing.T) {
+	index := NewOSPackageIndex(PackageManagerDpkg)
+	index.Versions["libssl3"] = "3.0.11-1~deb12u2"
+	index.AddFiles("libssl3", []string{"/usr/lib/x86_64-linux-gnu/libssl.so.3"})
+
+	artifact := &Artifact{File: "/usr/bin/app", Format: ArtifactFormatELF, Application: true}
+	NewArtifactParser().AddSharedLibraries(artifact, nativeLibraries([]string{"libssl.so.3", "libz.so.1", "libfoo.so"}), index)
+
+	deps := NewArtifactParser().Dependencies(artifact)
+	require.Len(t, deps, 3)
+	assert.Equal(t, DependencyTypeSharedLibrary, deps[0].Type)
+	assert.Equal(t, "libssl.so.3", deps[0].Name)
+	assert.Equal(t, "3.0.11-1~deb12u2", deps[0].Version)
+	assert.Equal(t, "libssl3", deps[0].Metadata["package"])
+	assert.Equal(t, PackageManagerDpkg, deps[0].Metadata["package_manager"])
+
+	// Not installed by a package: the known project and the ABI version
+	assert.Equal(t, "1", deps[1].Version)
+	assert.Equal(t, "zlib", deps[1].Metadata["package"])
+	assert.Nil(t, deps[1].Metadata["package_manager"])
+
+	assert.Equal(t, "latest", deps[2].Version)
+	assert.Nil(t, deps[2].Metadata["package"])
+}
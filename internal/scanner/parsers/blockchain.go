@@ -0,0 +1,568 @@
+package parsers
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Pre-compiled regexes for smart contract project files
+var (
+	solidityPragmaRegex      = regexp.MustCompile(`(?m)^\s*pragma\s+solidity\s+([^;]+);`)
+	hardhatSolidityRegex     = regexp.MustCompile(`\bsolidity\s*:\s*`)
+	hardhatVersionRegex      = regexp.MustCompile(`\bversion\s*:\s*["'\x60]([^"'\x60]+)["'\x60]`)
+	hardhatStringRegex       = regexp.MustCompile(`^["'\x60]([^"'\x60]+)["'\x60]`)
+	hardhatNetworksRegex     = regexp.MustCompile(`\bnetworks\s*:\s*\{`)
+	hardhatPathsRegex        = regexp.MustCompile(`\bpaths\s*:\s*\{`)
+	hardhatSourcesRegex      = regexp.MustCompile(`\bsources\s*:\s*["'\x60]([^"'\x60]+)["'\x60]`)
+	hardhatDefaultNetRegex   = regexp.MustCompile(`\bdefaultNetwork\s*:\s*["'\x60]([^"'\x60]+)["'\x60]`)
+	hardhatPluginsRegex      = regexp.MustCompile(`\bplugins\s*:\s*\[`)
+	jsSideEffectImportRegex  = regexp.MustCompile(`(?m)^\s*import\s+["']([^"']+)["']`)
+	jsSideEffectRequireRegex = regexp.MustCompile(`(?m)^\s*require\(\s*["']([^"']+)["']\s*\)\s*;?\s*$`)
+	jsDefaultImportRegex     = regexp.MustCompile(`(?m)^\s*import\s+(\w+)\s+from\s+["']([^"']+)["']`)
+	jsObjectKeyRegex         = regexp.MustCompile(`(?:^|[{,])\s*["']?([\w-]+)["']?\s*:`)
+	jsIdentifierRegex        = regexp.MustCompile(`\b[A-Za-z_$][\w$]*\b`)
+)
+
+// FoundryConfig represents the default profile of a Foundry project (foundry.toml)
+type FoundryConfig struct {
+	Src          string
+	Test         string
+	Out          string
+	Libs         []string // Library directories holding git submodules (default lib)
+	SolcVersion  string
+	EvmVersion   string
+	ViaIR        bool
+	Profiles     []string
+	Dependencies []FoundryDependency // Soldeer packages ([dependencies])
+}
+
+// FoundryDependency is a Soldeer package of the [dependencies] table: a registry version, an
+// archive URL, or a git repository with rev, tag, or branch
+type FoundryDependency struct {
+	Name    string
+	Version string
+	URL     string
+	Git     string
+	Rev     string
+	Tag     string
+	Branch  string
+}
+
+// FoundryLockEntry is the revision of a library in foundry.lock, with the tag or branch it was
+// installed from
+type FoundryLockEntry struct {
+	Rev    string
+	Tag    string
+	Branch string
+}
+
+// HardhatInfo represents a Hardhat project configuration (hardhat.config.*)
+type HardhatInfo struct {
+	File             string          `json:"file"`
+	SolidityVersions []string        `json:"solidity_versions,omitempty"` // Compiler versions of the solidity setting (including compilers and overrides)
+	DefaultNetwork   string          `json:"default_network,omitempty"`
+	Networks         []string        `json:"networks,omitempty"`
+	Sources          string          `json:"sources,omitempty"` // Contract sources directory (paths.sources)
+	Plugins          []HardhatPlugin `json:"plugins,omitempty"`
+	SolidityPragmas  []string        `json:"solidity_pragmas,omitempty"`
+}
+
+// HardhatPlugin is a plugin package loaded by a Hardhat configuration
+type HardhatPlugin struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// AnchorInfo represents an Anchor workspace for Solana programs (Anchor.toml)
+type AnchorInfo struct {
+	File           string          `json:"file"`
+	AnchorVersion  string          `json:"anchor_version,omitempty"`
+	SolanaVersion  string          `json:"solana_version,omitempty"`
+	PackageManager string          `json:"package_manager,omitempty"`
+	Cluster        string          `json:"cluster,omitempty"`
+	Members        []string        `json:"members,omitempty"`
+	Programs       []AnchorProgram `json:"programs,omitempty"`
+}
+
+// AnchorProgram is a program deployed to a cluster ([programs.<cluster>])
+type AnchorProgram struct {
+	Name    string `json:"name"`
+	Cluster string `json:"cluster"`
+	Address string `json:"address"`
+}
+
+// BlockchainParser handles Foundry, Hardhat, and Anchor project files and Solidity sources
+type BlockchainParser struct{}
+
+// NewBlockchainParser creates a new smart contract project parser
+func NewBlockchainParser() *BlockchainParser {
+	return &BlockchainParser{}
+}
+
+// ParseFoundryToml parses the default profile ([profile.default]) and the Soldeer
+// [dependencies] of foundry.toml. Unset directories take the Foundry defaults (src, lib).
+func (p *BlockchainParser) ParseFoundryToml(content string) FoundryConfig {
+	var config FoundryConfig
+	deps := make(map[string]*FoundryDependency)
+	var order []string
+	dependency := func(name string) *FoundryDependency {
+		if dep, ok := deps[name]; ok {
+			return dep
+		}
+		deps[name] = &FoundryDependency{Name: name}
+		order = append(order, name)
+		return deps[name]
+	}
+
+	for _, entry := range parseTomlEntries(content) {
+		if profile, ok := strings.CutPrefix(entry.section, "profile."); ok && !strings.Contains(profile, ".") {
+			config.Profiles = appendUnique(config.Profiles, profile)
+		}
+		switch {
+		case entry.section == "profile.default" || entry.section == "default":
+			switch entry.key {
+			case "src":
+				config.Src = tomlString(entry.value)
+			case "test":
+				config.Test = tomlString(entry.value)
+			case "out":
+				config.Out = tomlString(entry.value)
+			case "libs":
+				config.Libs = tomlStrings(entry.value)
+			case "solc", "solc_version":
+				config.SolcVersion = tomlString(entry.value)
+			case "evm_version":
+				config.EvmVersion = tomlString(entry.value)
+			case "via_ir":
+				config.ViaIR = entry.value == "true"
+			}
+		case entry.section == "dependencies":
+			dep := dependency(strings.Trim(entry.key, `"'`))
+			if !strings.HasPrefix(entry.value, "{") {
+				dep.Version = tomlString(entry.value)
+				continue
+			}
+			for field, value := range tomlInlineTable(entry.value) {
+				setFoundryField(dep, field, value)
+			}
+		case strings.HasPrefix(entry.section, "dependencies."):
+			name := strings.Trim(strings.TrimPrefix(entry.section, "dependencies."), `"'`)
+			setFoundryField(dependency(name), entry.key, entry.value)
+		}
+	}
+	if config.Src == "" {
+		config.Src = "src"
+	}
+	if len(config.Libs) == 0 {
+		config.Libs = []string{"lib"}
+	}
+	for _, name := range order {
+		config.Dependencies = append(config.Dependencies, *deps[name])
+	}
+	return config
+}
+
+// ParseFoundryLock parses foundry.lock into the locked revisions by library path
+func (p *BlockchainParser) ParseFoundryLock(content string) (map[string]FoundryLockEntry, error) {
+	type namedRev struct {
+		Name string `json:"name"`
+		Rev  string `json:"rev"`
+	}
+	var raw map[string]struct {
+		Rev    string    `json:"rev"`
+		Tag    *namedRev `json:"tag"`
+		Branch *namedRev `json:"branch"`
+	}
+	if err := json.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, err
+	}
+	entries := make(map[string]FoundryLockEntry, len(raw))
+	for path, lock := range raw {
+		entry := FoundryLockEntry{Rev: lock.Rev}
+		if lock.Tag != nil {
+			entry.Tag = lock.Tag.Name
+			entry.Rev = lock.Tag.Rev
+		}
+		if lock.Branch != nil {
+			entry.Branch = lock.Branch.Name
+			entry.Rev = lock.Branch.Rev
+		}
+		entries[path] = entry
+	}
+	return entries, nil
+}
+
+// FoundryDependencies converts the submodules of the library directories and the Soldeer
+// packages to dependencies. Submodule paths are relative to the project directory. Libraries
+// locked in foundry.lock use the locked tag or revision (source foundry.lock); otherwise the
+// commit recorded by git is used, or the branch tracked in .gitmodules. Submodules carry the
+// "git" and "submodule" metadata.
+func (p *BlockchainParser) FoundryDependencies(config FoundryConfig, submodules []GitSubmodule, lock map[string]FoundryLockEntry) []types.Dependency {
+	var dependencies []types.Dependency
+	for _, submodule := range submodules {
+		if !inFoundryLibs(submodule.Path, config.Libs) {
+			continue
+		}
+		metadata := types.NewMetadata(MetadataSourceGitmodules)
+		version := ""
+		if entry, ok := lock[submodule.Path]; ok {
+			metadata = types.NewMetadata(MetadataSourceFoundryLock)
+			version = entry.Tag
+			if version == "" {
+				version = entry.Rev
+			}
+			if entry.Rev != "" {
+				metadata["rev"] = entry.Rev
+			}
+			if entry.Branch != "" {
+				metadata["branch"] = entry.Branch
+			}
+		} else {
+			version = submoduleVersion(submodule, metadata)
+		}
+		if submodule.URL != "" {
+			metadata["git"] = submodule.URL
+		}
+		metadata["submodule"] = submodule.Path
+		dependencies = append(dependencies, types.Dependency{
+			Type:     DependencyTypeFoundry,
+			Name:     pathBase(submodule.Path),
+			Version:  version,
+			Scope:    types.ScopeProd,
+			Direct:   true,
+			Metadata: metadata,
+		})
+	}
+
+	for _, dep := range config.Dependencies {
+		metadata := types.NewMetadata(MetadataSourceFoundryToml)
+		version := dep.Version
+		switch {
+		case dep.Git != "":
+			metadata["git"] = dep.Git
+			switch {
+			case dep.Tag != "":
+				version = dep.Tag
+			case dep.Rev != "":
+				version = dep.Rev
+			case dep.Branch != "":
+				version = dep.Branch
+				metadata["branch"] = dep.Branch
+			}
+		case dep.URL != "":
+			metadata["url"] = dep.URL
+		}
+		if version == "" {
+			version = "latest"
+		}
+		dependencies = append(dependencies, types.Dependency{
+			Type:     DependencyTypeFoundry,
+			Name:     dep.Name,
+			Version:  version,
+			Scope:    types.ScopeProd,
+			Direct:   true,
+			Metadata: metadata,
+		})
+	}
+	return dependencies
+}
+
+// ParseHardhatConfig parses a Hardhat configuration (JavaScript or TypeScript). Compiler
+// versions are read from the solidity setting, networks from the keys of the networks object,
+// and plugins from side-effect imports (import "plugin", require("plugin")) and the imports
+// listed in plugins (Hardhat 3). Unset sources take the Hardhat default (contracts).
+func (p *BlockchainParser) ParseHardhatConfig(content string) *HardhatInfo {
+	content = stripJSComments(content)
+	info := &HardhatInfo{Sources: "contracts"}
+
+	if loc := hardhatSolidityRegex.FindStringIndex(content); loc != nil {
+		rest := content[loc[1]:]
+		if match := hardhatStringRegex.FindStringSubmatch(rest); match != nil {
+			info.SolidityVersions = []string{match[1]}
+		} else if strings.HasPrefix(rest, "{") {
+			for _, match := range hardhatVersionRegex.FindAllStringSubmatch(jsBlock(rest), -1) {
+				info.SolidityVersions = appendUnique(info.SolidityVersions, match[1])
+			}
+		}
+	}
+	if match := hardhatDefaultNetRegex.FindStringSubmatch(content); match != nil {
+		info.DefaultNetwork = match[1]
+	}
+	if loc := hardhatNetworksRegex.FindStringIndex(content); loc != nil {
+		block := jsTopLevel(jsBlock(content[loc[1]-1:]))
+		for _, match := range jsObjectKeyRegex.FindAllStringSubmatch(block, -1) {
+			info.Networks = appendUnique(info.Networks, match[1])
+		}
+	}
+	if loc := hardhatPathsRegex.FindStringIndex(content); loc != nil {
+		if match := hardhatSourcesRegex.FindStringSubmatch(jsBlock(content[loc[1]-1:])); match != nil {
+			info.Sources = strings.TrimPrefix(strings.TrimSuffix(match[1], "/"), "./")
+		}
+	}
+
+	var plugins []string
+	for _, match := range jsSideEffectImportRegex.FindAllStringSubmatch(content, -1) {
+		plugins = appendUnique(plugins, npmImportPackage(match[1]))
+	}
+	for _, match := range jsSideEffectRequireRegex.FindAllStringSubmatch(content, -1) {
+		plugins = appendUnique(plugins, npmImportPackage(match[1]))
+	}
+	if loc := hardhatPluginsRegex.FindStringIndex(content); loc != nil {
+		imports := make(map[string]string)
+		for _, match := range jsDefaultImportRegex.FindAllStringSubmatch(content, -1) {
+			imports[match[1]] = match[2]
+		}
+		for _, identifier := range jsIdentifierRegex.FindAllString(jsBlock(content[loc[1]-1:]), -1) {
+			if spec, ok := imports[identifier]; ok {
+				plugins = appendUnique(plugins, npmImportPackage(spec))
+			}
+		}
+	}
+	for _, name := range plugins {
+		if name != "" && name != "hardhat" {
+			info.Plugins = append(info.Plugins, HardhatPlugin{Name: name})
+		}
+	}
+	return info
+}
+
+// ApplyPluginVersions sets plugin versions from the dependencies declared in package.json
+func (info *HardhatInfo) ApplyPluginVersions(versions map[string]string) {
+	for i := range info.Plugins {
+		if version, ok := versions[info.Plugins[i].Name]; ok {
+			info.Plugins[i].Version = version
+		}
+	}
+}
+
+// ParseAnchorToml parses an Anchor workspace (Anchor.toml): the toolchain versions, the
+// provider cluster, the workspace members, and the program addresses per cluster
+func (p *BlockchainParser) ParseAnchorToml(content string) *AnchorInfo {
+	info := &AnchorInfo{}
+	for _, entry := range parseTomlEntries(content) {
+		switch {
+		case entry.section == "toolchain":
+			switch entry.key {
+			case "anchor_version":
+				info.AnchorVersion = tomlString(entry.value)
+			case "solana_version":
+				info.SolanaVersion = tomlString(entry.value)
+			case "package_manager":
+				info.PackageManager = tomlString(entry.value)
+			}
+		case entry.section == "provider" && entry.key == "cluster":
+			info.Cluster = tomlString(entry.value)
+		case entry.section == "workspace" && entry.key == "members":
+			info.Members = tomlStrings(entry.value)
+		case strings.HasPrefix(entry.section, "programs."):
+			info.Programs = append(info.Programs, AnchorProgram{
+				Name:    strings.Trim(entry.key, `"`),
+				Cluster: strings.TrimPrefix(entry.section, "programs."),
+				Address: tomlString(entry.value),
+			})
+		}
+	}
+	return info
+}
+
+// SolidityPragma returns the compiler version constraint of a Solidity source
+// (pragma solidity ^0.8.20;), with whitespace normalized
+func (p *BlockchainParser) SolidityPragma(content string) string {
+	match := solidityPragmaRegex.FindStringSubmatch(content)
+	if match == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(match[1]), " ")
+}
+
+// setFoundryField sets a field of a Soldeer dependency table
+func setFoundryField(dep *FoundryDependency, key, value string) {
+	value = tomlString(value)
+	switch key {
+	case "version":
+		dep.Version = value
+	case "url":
+		dep.URL = value
+	case "git":
+		dep.Git = value
+	case "rev":
+		dep.Rev = value
+	case "tag":
+		dep.Tag = value
+	case "branch":
+		dep.Branch = value
+	}
+}
+
+// inFoundryLibs reports whether a submodule path lies in one of the library directories
+func inFoundryLibs(path string, libs []string) bool {
+	for _, lib := range libs {
+		lib = strings.TrimSuffix(strings.TrimPrefix(lib, "./"), "/")
+		if strings.HasPrefix(path, lib+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// npmImportPackage returns the package name of an import specifier ("@scope/pkg/sub" gives
+// "@scope/pkg"); relative and node: imports give ""
+func npmImportPackage(spec string) string {
+	if strings.HasPrefix(spec, ".") || strings.HasPrefix(spec, "/") || strings.HasPrefix(spec, "node:") {
+		return ""
+	}
+	parts := strings.Split(spec, "/")
+	if strings.HasPrefix(spec, "@") && len(parts) > 1 {
+		return parts[0] + "/" + parts[1]
+	}
+	return parts[0]
+}
+
+// tomlEntry is a key/value pair of a TOML document with its table
+type tomlEntry struct {
+	section string
+	key     string
+	value   string
+}
+
+// parseTomlEntries splits a TOML document into its key/value pairs. Arrays spanning several
+// lines are joined; array tables ([[name]]) are reported by name.
+func parseTomlEntries(content string) []tomlEntry {
+	var entries []tomlEntry
+	section := ""
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(stripTomlComment(lines[i]))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			section = strings.TrimSpace(strings.Trim(line, "[]"))
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		for strings.HasPrefix(value, "[") && strings.Count(value, "[") > strings.Count(value, "]") && i+1 < len(lines) {
+			i++
+			value += " " + strings.TrimSpace(stripTomlComment(lines[i]))
+		}
+		entries = append(entries, tomlEntry{section: section, key: strings.TrimSpace(key), value: value})
+	}
+	return entries
+}
+
+// stripJSComments removes // and /* */ comments outside of string and template literals
+func stripJSComments(content string) string {
+	var sb strings.Builder
+	var quote byte
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		if quote != 0 {
+			sb.WriteByte(c)
+			if c == '\\' && i+1 < len(content) {
+				i++
+				sb.WriteByte(content[i])
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch {
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case c == '/' && i+1 < len(content) && content[i+1] == '/':
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+			sb.WriteByte('\n')
+			continue
+		case c == '/' && i+1 < len(content) && content[i+1] == '*':
+			end := strings.Index(content[i+2:], "*/")
+			if end < 0 {
+				return sb.String()
+			}
+			i += end + 3
+			continue
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+// jsBlock returns the object or array literal at the start of content, up to the matching
+// closing bracket (or the end of content when unbalanced)
+func jsBlock(content string) string {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'', '`':
+			quote = c
+		case '{', '[', '(':
+			depth++
+		case '}', ']', ')':
+			depth--
+			if depth == 0 {
+				return content[:i+1]
+			}
+		}
+	}
+	return content
+}
+
+// jsTopLevel returns the content of an object literal with its nested literals removed, so
+// that only its own keys remain
+func jsTopLevel(block string) string {
+	var sb strings.Builder
+	depth := 0
+	var quote byte
+	for i := 0; i < len(block); i++ {
+		c := block[i]
+		if quote != 0 {
+			if depth == 1 {
+				sb.WriteByte(c)
+			}
+			if c == '\\' && i+1 < len(block) {
+				i++
+				if depth == 1 {
+					sb.WriteByte(block[i])
+				}
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'', '`':
+			quote = c
+		case '{', '[', '(':
+			depth++
+			if depth == 1 {
+				sb.WriteByte(c)
+			}
+			continue
+		case '}', ']', ')':
+			depth--
+			continue
+		}
+		if depth == 1 {
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
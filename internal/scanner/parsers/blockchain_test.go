@@ -0,0 +1,205 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testFoundryToml = `[profile.default]
+src = "contracts"
+out = "out"
+libs = [
+    "lib",   # git submodules
+    "dependencies",
+]
+solc_version = "0.8.24"
+evm_version = "cancun"
+via_ir = true
+
+[profile.default.fuzz]
+runs = 1000
+
+[profile.ci]
+verbosity = 4
+
+[dependencies]
+forge-std = "1.9.4"
+"@openzeppelin-contracts" = { version = "5.0.2", url = "https://soldeer.example/oz.zip" }
+solady = { version = "0.0.1", git = "https://github.com/Vectorized/solady.git", tag = "v0.0.245" }
+`
+
+func TestBlockchainParser_ParseFoundryToml(t *testing.T) {
+	config := NewBlockchainParser().ParseFoundryToml(testFoundryToml)
+
+	assert.Equal(t, "contracts", config.Src)
+	assert.Equal(t, "out", config.Out)
+	assert.Equal(t, []string{"lib", "dependencies"}, config.Libs)
+	assert.Equal(t, "0.8.24", config.SolcVersion)
+	assert.Equal(t, "cancun", config.EvmVersion)
+	assert.True(t, config.ViaIR)
+	assert.Equal(t, []string{"default", "ci"}, config.Profiles)
+	assert.Equal(t, []FoundryDependency{
+		{Name: "forge-std", Version: "1.9.4"},
+		{Name: "@openzeppelin-contracts", Version: "5.0.2", URL: "https://soldeer.example/oz.zip"},
+		{Name: "solady", Version: "0.0.1", Git: "https://github.com/Vectorized/solady.git", Tag: "v0.0.245"},
+	}, config.Dependencies)
+}
+
+func TestBlockchainParser_ParseFoundryTomlDefaults(t *testing.T) {
+	config := NewBlockchainParser().ParseFoundryToml("[profile.default]\noptimizer = true\n")
+	assert.Equal(t, "src", config.Src)
+	assert.Equal(t, []string{"lib"}, config.Libs)
+	assert.Empty(t, config.Dependencies)
+}
+
+func TestBlockchainParser_ParseFoundryLock(t *testing.T) {
+	content := `{
+  "lib/forge-std": {"tag": {"name": "v1.9.4", "rev": "1eea5bae12ae557d589f9f0f0edae2faa47cb262"}},
+  "lib/solmate": {"rev": "c93f7716c9909175d45f6ef80a34a650e2d24e56"},
+  "lib/openzeppelin-contracts": {"branch": {"name": "release-v5.0", "rev": "dbb6104ce834628e473d2173bbc9d47f81a9eec3"}}
+}`
+	lock, err := NewBlockchainParser().ParseFoundryLock(content)
+	require.NoError(t, err)
+	assert.Equal(t, FoundryLockEntry{Tag: "v1.9.4", Rev: "1eea5bae12ae557d589f9f0f0edae2faa47cb262"}, lock["lib/forge-std"])
+	assert.Equal(t, FoundryLockEntry{Rev: "c93f7716c9909175d45f6ef80a34a650e2d24e56"}, lock["lib/solmate"])
+	assert.Equal(t, "release-v5.0", lock["lib/openzeppelin-contracts"].Branch)
+
+	_, err = NewBlockchainParser().ParseFoundryLock("not json")
+	assert.Error(t, err)
+}
+
+func TestBlockchainParser_FoundryDependencies(t *testing.T) {
+	parser := NewBlockchainParser()
+	config := FoundryConfig{Libs: []string{"lib"}, Dependencies: []FoundryDependency{
+		{Name: "solady", Git: "https://github.com/Vectorized/solady.git", Branch: "main"},
+	}}
+	submodules := []GitSubmodule{
+		{Path: "lib/forge-std", URL: "https://github.com/foundry-rs/forge-std", Commit: "1eea5bae12ae557d589f9f0f0edae2faa47cb262"},
+		{Path: "lib/openzeppelin-contracts", URL: "https://github.com/OpenZeppelin/openzeppelin-contracts", Branch: "release-v5.0"},
+		{Path: "docs/theme", URL: "https://github.com/org/theme"},
+	}
+
+	deps := parser.FoundryDependencies(config, submodules, nil)
+	require.Len(t, deps, 3)
+	assert.Equal(t, "forge-std", deps[0].Name)
+	assert.Equal(t, "1eea5bae12ae557d589f9f0f0edae2faa47cb262", deps[0].Version)
+	assert.Equal(t, MetadataSourceGitmodules, deps[0].Metadata["source"])
+	assert.Equal(t, "lib/forge-std", deps[0].Metadata["submodule"])
+	assert.Equal(t, "https://github.com/foundry-rs/forge-std", deps[0].Metadata["git"])
+	assert.Equal(t, "release-v5.0", deps[1].Version)
+	assert.Equal(t, "release-v5.0", deps[1].Metadata["branch"])
+	assert.Equal(t, "solady", deps[2].Name)
+	assert.Equal(t, "main", deps[2].Version)
+	assert.Equal(t, MetadataSourceFoundryToml, deps[2].Metadata["source"])
+
+	lock := map[string]FoundryLockEntry{"lib/forge-std": {Tag: "v1.9.4", Rev: "1eea5bae"}}
+	deps = parser.FoundryDependencies(config, submodules, lock)
+	assert.Equal(t, "v1.9.4", deps[0].Version)
+	assert.Equal(t, MetadataSourceFoundryLock, deps[0].Metadata["source"])
+	assert.Equal(t, "1eea5bae", deps[0].Metadata["rev"])
+	for _, dep := range deps {
+		assert.Equal(t, DependencyTypeFoundry, dep.Type)
+		assert.True(t, dep.Direct)
+	}
+}
+
+func TestBlockchainParser_ParseHardhatConfig(t *testing.T) {
+	content := `require("@nomicfoundation/hardhat-toolbox");
+require("@openzeppelin/hardhat-upgrades");
+require("dotenv").config();
+// require("hardhat-gas-reporter");
+const { task } = require("hardhat/config");
+
+/** @type import('hardhat/config').HardhatUserConfig */
+module.exports = {
+  solidity: {
+    compilers: [
+      { version: "0.8.24", settings: { optimizer: { enabled: true, runs: 200 } } },
+      { version: '0.6.12' },
+    ],
+    overrides: { "contracts/Legacy.sol": { version: "0.5.17" } },
+  },
+  defaultNetwork: "hardhat",
+  networks: {
+    hardhat: { chainId: 31337 },
+    sepolia: { url: 'https://sepolia.infura.io/v3/' + process.env.KEY, accounts: [process.env.PK] },
+    "arbitrum-one": { url: "https://arb1.arbitrum.io/rpc" },
+  },
+  paths: { sources: "./src/" },
+};
+`
+	info := NewBlockchainParser().ParseHardhatConfig(content)
+	assert.Equal(t, []string{"0.8.24", "0.6.12", "0.5.17"}, info.SolidityVersions)
+	assert.Equal(t, "hardhat", info.DefaultNetwork)
+	assert.Equal(t, []string{"hardhat", "sepolia", "arbitrum-one"}, info.Networks)
+	assert.Equal(t, "src", info.Sources)
+	assert.Equal(t, []HardhatPlugin{{Name: "@nomicfoundation/hardhat-toolbox"}, {Name: "@openzeppelin/hardhat-upgrades"}}, info.Plugins)
+
+	info.ApplyPluginVersions(map[string]string{"@nomicfoundation/hardhat-toolbox": "^5.0.0"})
+	assert.Equal(t, "^5.0.0", info.Plugins[0].Version)
+	assert.Empty(t, info.Plugins[1].Version)
+}
+
+func TestBlockchainParser_ParseHardhatConfigV3(t *testing.T) {
+	content := `import type { HardhatUserConfig } from "hardhat/config";
+import hardhatToolboxViem from "@nomicfoundation/hardhat-toolbox-viem";
+import hardhatKeystore from "@nomicfoundation/hardhat-keystore";
+import { configVariable } from "hardhat/config";
+
+const config: HardhatUserConfig = {
+  plugins: [hardhatToolboxViem, hardhatKeystore],
+  solidity: "0.8.28",
+};
+export default config;
+`
+	info := NewBlockchainParser().ParseHardhatConfig(content)
+	assert.Equal(t, []string{"0.8.28"}, info.SolidityVersions)
+	assert.Equal(t, "contracts", info.Sources)
+	assert.Empty(t, info.Networks)
+	assert.Equal(t, []HardhatPlugin{{Name: "@nomicfoundation/hardhat-toolbox-viem"}, {Name: "@nomicfoundation/hardhat-keystore"}}, info.Plugins)
+}
+
+func TestBlockchainParser_ParseAnchorToml(t *testing.T) {
+	content := `[toolchain]
+anchor_version = "0.30.1"
+solana_version = "1.18.17"
+package_manager = "yarn"
+
+[features]
+resolution = true
+
+[programs.localnet]
+counter = "Fg6PaFpoGXkYsidMpWTK6W2BeZ7FEfcYkg476zPFsLnS"
+
+[programs.devnet]
+counter = "Fg6PaFpoGXkYsidMpWTK6W2BeZ7FEfcYkg476zPFsLnS"
+
+[provider]
+cluster = "Localnet"
+wallet = "~/.config/solana/id.json"
+
+[workspace]
+members = [
+  "programs/counter",
+]
+`
+	info := NewBlockchainParser().ParseAnchorToml(content)
+	assert.Equal(t, "0.30.1", info.AnchorVersion)
+	assert.Equal(t, "1.18.17", info.SolanaVersion)
+	assert.Equal(t, "yarn", info.PackageManager)
+	assert.Equal(t, "Localnet", info.Cluster)
+	assert.Equal(t, []string{"programs/counter"}, info.Members)
+	assert.Equal(t, []AnchorProgram{
+		{Name: "counter", Cluster: "localnet", Address: "Fg6PaFpoGXkYsidMpWTK6W2BeZ7FEfcYkg476zPFsLnS"},
+		{Name: "counter", Cluster: "devnet", Address: "Fg6PaFpoGXkYsidMpWTK6W2BeZ7FEfcYkg476zPFsLnS"},
+	}, info.Programs)
+}
+
+func TestBlockchainParser_SolidityPragma(t *testing.T) {
+	parser := NewBlockchainParser()
+	assert.Equal(t, "^0.8.20", parser.SolidityPragma("// SPDX-License-Identifier: MIT\npragma solidity ^0.8.20;\n\ncontract A {}\n"))
+	assert.Equal(t, ">=0.8.0 <0.9.0", parser.SolidityPragma("pragma solidity  >=0.8.0   <0.9.0 ;"))
+	assert.Empty(t, parser.SolidityPragma("pragma abicoder v2;\ncontract B {}\n"))
+}
@@ -0,0 +1,251 @@
+package parsers
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Compile proto regexes once at package level for performance
+var (
+	protoImportRegex  = regexp.MustCompile(`(?m)^\s*import\s+(?:public\s+|weak\s+)?"([^"]+)"\s*;`)
+	protoPackageRegex = regexp.MustCompile(`(?m)^\s*package\s+([A-Za-z_][\w.]*)\s*;`)
+)
+
+// protoWellKnownPrefix marks imports of the protobuf well-known types bundled with every compiler
+const protoWellKnownPrefix = "google/protobuf/"
+
+// protoImportModules maps import path prefixes of widely used proto definitions to their
+// Buf Schema Registry module, so imports can be attributed without the module sources.
+var protoImportModules = []struct {
+	Prefix string
+	Module string
+}{
+	{"google/api/", "buf.build/googleapis/googleapis"},
+	{"google/type/", "buf.build/googleapis/googleapis"},
+	{"google/rpc/", "buf.build/googleapis/googleapis"},
+	{"google/longrunning/", "buf.build/googleapis/googleapis"},
+	{"google/geo/", "buf.build/googleapis/googleapis"},
+	{"buf/validate/", "buf.build/bufbuild/protovalidate"},
+	{"validate/", "buf.build/envoyproxy/protoc-gen-validate"},
+	{"protoc-gen-openapiv2/options/", "buf.build/grpc-ecosystem/grpc-gateway"},
+	{"grpc/", "buf.build/grpc/grpc"},
+}
+
+// BufParser handles buf.yaml, buf.lock and .proto file parsing
+type BufParser struct{}
+
+// NewBufParser creates a new Buf parser
+func NewBufParser() *BufParser {
+	return &BufParser{}
+}
+
+// BufConfig represents a Buf workspace or module configuration (buf.yaml)
+type BufConfig struct {
+	File            string             `json:"file"`
+	Version         string             `json:"version,omitempty"` // Configuration version (v1beta1, v1, v2)
+	Modules         []BufModule        `json:"modules"`
+	Deps            []BufModuleRef     `json:"-"`
+	Files           []ProtoFile        `json:"proto_files,omitempty"`      // Import graph of the module's .proto files
+	ExternalImports []ProtoImportOwner `json:"external_imports,omitempty"` // Imports not provided by the modules
+}
+
+// BufModule is a module of a Buf workspace (the directory of buf.yaml for v1)
+type BufModule struct {
+	Path string `json:"path"`
+	Name string `json:"name,omitempty"` // Module name on the registry (e.g., buf.build/acme/weather)
+}
+
+// BufModuleRef is a module dependency (buf.build/owner/repository[:ref])
+type BufModuleRef struct {
+	Name   string
+	Ref    string // Label or commit from buf.yaml
+	Commit string // Pinned commit from buf.lock
+}
+
+// ProtoFile holds the package and imports of a .proto file
+type ProtoFile struct {
+	File    string   `json:"file"` // Path relative to the module root (as used in imports)
+	Package string   `json:"package,omitempty"`
+	Imports []string `json:"imports,omitempty"`
+}
+
+// ProtoImportOwner attributes an external import to the module providing it ("" if unknown)
+type ProtoImportOwner struct {
+	Import string `json:"import"`
+	Module string `json:"module,omitempty"`
+}
+
+// bufYAML represents the subset of buf.yaml we use (v1 and v2)
+type bufYAML struct {
+	Version string   `yaml:"version"`
+	Name    string   `yaml:"name"`
+	Deps    []string `yaml:"deps"`
+	Modules []struct {
+		Path string `yaml:"path"`
+		Name string `yaml:"name"`
+	} `yaml:"modules"`
+}
+
+// ParseBufYAML parses buf.yaml. A v1 file describes a single module rooted at its directory;
+// a v2 file lists the workspace modules (defaulting to the directory itself).
+func (p *BufParser) ParseBufYAML(content string) (*BufConfig, error) {
+	var raw bufYAML
+	if err := yaml.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, err
+	}
+
+	config := &BufConfig{Version: raw.Version}
+	for _, m := range raw.Modules {
+		path := strings.Trim(m.Path, "/")
+		if path == "" {
+			path = "."
+		}
+		config.Modules = append(config.Modules, BufModule{Path: path, Name: m.Name})
+	}
+	if len(config.Modules) == 0 {
+		config.Modules = []BufModule{{Path: ".", Name: raw.Name}}
+	}
+
+	for _, dep := range raw.Deps {
+		name, ref, _ := strings.Cut(strings.TrimSpace(dep), ":")
+		if name != "" {
+			config.Deps = append(config.Deps, BufModuleRef{Name: name, Ref: ref})
+		}
+	}
+	return config, nil
+}
+
+// bufLock represents buf.lock (v1 uses remote/owner/repository, v2 uses name)
+type bufLock struct {
+	Deps []struct {
+		Name       string `yaml:"name"`
+		Remote     string `yaml:"remote"`
+		Owner      string `yaml:"owner"`
+		Repository string `yaml:"repository"`
+		Commit     string `yaml:"commit"`
+	} `yaml:"deps"`
+}
+
+// ParseBufLock parses buf.lock and returns the pinned module dependencies
+func (p *BufParser) ParseBufLock(content string) ([]BufModuleRef, error) {
+	var lock bufLock
+	if err := yaml.Unmarshal([]byte(content), &lock); err != nil {
+		return nil, err
+	}
+
+	var refs []BufModuleRef
+	for _, dep := range lock.Deps {
+		name := dep.Name
+		if name == "" && dep.Remote != "" && dep.Owner != "" && dep.Repository != "" {
+			name = dep.Remote + "/" + dep.Owner + "/" + dep.Repository
+		}
+		if name != "" {
+			refs = append(refs, BufModuleRef{Name: name, Commit: dep.Commit})
+		}
+	}
+	return refs, nil
+}
+
+// ParseProtoFile extracts the package and imports of a .proto file
+func (p *BufParser) ParseProtoFile(content string) ProtoFile {
+	var file ProtoFile
+	if match := protoPackageRegex.FindStringSubmatch(content); match != nil {
+		file.Package = match[1]
+	}
+	for _, match := range protoImportRegex.FindAllStringSubmatch(content, -1) {
+		if !containsString(file.Imports, match[1]) {
+			file.Imports = append(file.Imports, match[1])
+		}
+	}
+	sort.Strings(file.Imports)
+	return file
+}
+
+// ResolveImports records the external imports of the config's proto files, i.e. imports that are
+// neither local files nor well-known types, attributed to their registry module where known
+func (c *BufConfig) ResolveImports() {
+	local := make(map[string]bool, len(c.Files))
+	for _, f := range c.Files {
+		local[f.File] = true
+	}
+
+	seen := make(map[string]bool)
+	for _, f := range c.Files {
+		for _, imp := range f.Imports {
+			if local[imp] || seen[imp] || strings.HasPrefix(imp, protoWellKnownPrefix) {
+				continue
+			}
+			seen[imp] = true
+			c.ExternalImports = append(c.ExternalImports, ProtoImportOwner{Import: imp, Module: ProtoImportModule(imp)})
+		}
+	}
+	sort.Slice(c.ExternalImports, func(i, j int) bool {
+		return c.ExternalImports[i].Import < c.ExternalImports[j].Import
+	})
+	sort.Slice(c.Files, func(i, j int) bool {
+		return c.Files[i].File < c.Files[j].File
+	})
+}
+
+// ProtoImportModule returns the registry module known to provide an import path, or ""
+func ProtoImportModule(importPath string) string {
+	for _, m := range protoImportModules {
+		if strings.HasPrefix(importPath, m.Prefix) {
+			return m.Module
+		}
+	}
+	return ""
+}
+
+// CreateDependencies creates buf dependencies from the declared modules, preferring the pinned
+// commits of buf.lock. Modules only referenced through imports are added with version "latest".
+func (c *BufConfig) CreateDependencies(locked []BufModuleRef) []types.Dependency {
+	var deps []types.Dependency
+	added := make(map[string]bool)
+	add := func(name, version, source string) {
+		if added[name] {
+			return
+		}
+		added[name] = true
+		if version == "" {
+			version = "latest"
+		}
+		deps = append(deps, types.Dependency{
+			Type:     DependencyTypeBuf,
+			Name:     name,
+			Version:  version,
+			Scope:    types.ScopeProd,
+			Direct:   true,
+			Metadata: types.NewMetadata(source),
+		})
+	}
+
+	commits := make(map[string]string, len(locked))
+	for _, ref := range locked {
+		commits[ref.Name] = ref.Commit
+	}
+	for _, ref := range c.Deps {
+		if commit, ok := commits[ref.Name]; ok {
+			add(ref.Name, commit, MetadataSourceBufLock)
+		} else {
+			add(ref.Name, ref.Ref, MetadataSourceBufYAML)
+		}
+	}
+	// buf.lock also pins transitive dependencies
+	for _, ref := range locked {
+		if !added[ref.Name] {
+			add(ref.Name, ref.Commit, MetadataSourceBufLock)
+			deps[len(deps)-1].Direct = false
+		}
+	}
+	for _, imp := range c.ExternalImports {
+		if imp.Module != "" {
+			add(imp.Module, "", MetadataSourceProto)
+		}
+	}
+	return deps
+}
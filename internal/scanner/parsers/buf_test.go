@@ -0,0 +1,142 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBufYAML(t *testing.T) {
+	parser := NewBufParser()
+
+	tests := []struct {
+		name    string
+		content string
+		modules []BufModule
+		deps    []BufModuleRef
+	}{
+		{
+			name: "v1 module",
+			content: `version: v1
+name: buf.build/acme/petapis
+deps:
+  - buf.build/googleapis/googleapis
+  - buf.build/acme/paymentapis:6e230f46113f498392c82d12b1a07b70
+`,
+			modules: []BufModule{{Path: ".", Name: "buf.build/acme/petapis"}},
+			deps: []BufModuleRef{
+				{Name: "buf.build/googleapis/googleapis"},
+				{Name: "buf.build/acme/paymentapis", Ref: "6e230f46113f498392c82d12b1a07b70"},
+			},
+		},
+		{
+			name: "v2 workspace",
+			content: `version: v2
+modules:
+  - path: proto
+    name: buf.build/acme/weather
+  - path: vendor/proto/
+deps:
+  - buf.build/bufbuild/protovalidate
+`,
+			modules: []BufModule{{Path: "proto", Name: "buf.build/acme/weather"}, {Path: "vendor/proto"}},
+			deps:    []BufModuleRef{{Name: "buf.build/bufbuild/protovalidate"}},
+		},
+		{
+			name:    "v2 without modules",
+			content: "version: v2\n",
+			modules: []BufModule{{Path: "."}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := parser.ParseBufYAML(tt.content)
+			require.NoError(t, err)
+			assert.Equal(t, tt.modules, config.Modules)
+			assert.Equal(t, tt.deps, config.Deps)
+		})
+	}
+
+	_, err := parser.ParseBufYAML("deps: [")
+	assert.Error(t, err)
+}
+
+func TestParseBufLock(t *testing.T) {
+	parser := NewBufParser()
+
+	v1 := `# Generated by buf. DO NOT EDIT.
+version: v1
+deps:
+  - remote: buf.build
+    owner: googleapis
+    repository: googleapis
+    commit: 28151c0d0a1641bf938a7672c500e01d
+    digest: shake256:abc
+`
+	refs, err := parser.ParseBufLock(v1)
+	require.NoError(t, err)
+	assert.Equal(t, []BufModuleRef{{Name: "buf.build/googleapis/googleapis", Commit: "28151c0d0a1641bf938a7672c500e01d"}}, refs)
+
+	v2 := `version: v2
+deps:
+  - name: buf.build/bufbuild/protovalidate
+    commit: a6c49f84cc0f4e038680d390392e2ab0
+    digest: b5:abc
+`
+	refs, err = parser.ParseBufLock(v2)
+	require.NoError(t, err)
+	assert.Equal(t, []BufModuleRef{{Name: "buf.build/bufbuild/protovalidate", Commit: "a6c49f84cc0f4e038680d390392e2ab0"}}, refs)
+}
+
+func TestParseProtoFile(t *testing.T) {
+	content := `syntax = "proto3";
+
+package acme.weather.v1;
+
+import "google/api/annotations.proto";
+import public "acme/common/v1/types.proto";
+import weak "google/protobuf/timestamp.proto";
+// import "commented/out.proto";
+import "google/api/annotations.proto";
+
+service WeatherService {}
+`
+	file := NewBufParser().ParseProtoFile(content)
+	assert.Equal(t, "acme.weather.v1", file.Package)
+	assert.Equal(t, []string{"acme/common/v1/types.proto", "google/api/annotations.proto", "google/protobuf/timestamp.proto"}, file.Imports)
+}
+
+func TestBufConfig_ResolveImportsAndDependencies(t *testing.T) {
+	config := &BufConfig{
+		Deps: []BufModuleRef{
+			{Name: "buf.build/googleapis/googleapis"},
+			{Name: "buf.build/acme/paymentapis", Ref: "v1.2.0"},
+		},
+		Files: []ProtoFile{
+			{File: "acme/weather/v1/weather.proto", Imports: []string{"acme/common/v1/types.proto", "buf/validate/validate.proto", "google/api/annotations.proto", "google/protobuf/timestamp.proto"}},
+			{File: "acme/common/v1/types.proto", Imports: []string{"acme/payment/v1/payment.proto"}},
+		},
+	}
+	config.ResolveImports()
+
+	assert.Equal(t, "acme/common/v1/types.proto", config.Files[0].File, "files are sorted")
+	assert.Equal(t, []ProtoImportOwner{
+		{Import: "acme/payment/v1/payment.proto"},
+		{Import: "buf/validate/validate.proto", Module: "buf.build/bufbuild/protovalidate"},
+		{Import: "google/api/annotations.proto", Module: "buf.build/googleapis/googleapis"},
+	}, config.ExternalImports)
+
+	deps := config.CreateDependencies([]BufModuleRef{
+		{Name: "buf.build/googleapis/googleapis", Commit: "28151c0d0a1641bf938a7672c500e01d"},
+		{Name: "buf.build/acme/money", Commit: "9f8e7d6c"},
+	})
+	assert.Equal(t, []types.Dependency{
+		{Type: "buf", Name: "buf.build/googleapis/googleapis", Version: "28151c0d0a1641bf938a7672c500e01d", Scope: types.ScopeProd, Direct: true, Metadata: types.NewMetadata("buf.lock")},
+		{Type: "buf", Name: "buf.build/acme/paymentapis", Version: "v1.2.0", Scope: types.ScopeProd, Direct: true, Metadata: types.NewMetadata("buf.yaml")},
+		{Type: "buf", Name: "buf.build/acme/money", Version: "9f8e7d6c", Scope: types.ScopeProd, Direct: false, Metadata: types.NewMetadata("buf.lock")},
+		{Type: "buf", Name: "buf.build/bufbuild/protovalidate", Version: "latest", Scope: types.ScopeProd, Direct: true, Metadata: types.NewMetadata(".proto")},
+	}, deps)
+}
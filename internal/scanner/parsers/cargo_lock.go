@@ -6,170 +6,197 @@ import (
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 )
 
-// ParseCargoLock parses Cargo.lock content and returns direct dependencies with resolved versions
-// Direct dependencies are identified by cross-referencing with Cargo.toml
+// ParseCargoLockOptions contains configuration options for ParseCargoLockWithOptions
+type ParseCargoLockOptions struct {
+	IncludeTransitive bool // Include transitive dependencies (default: false for direct dependencies only)
+}
+
+// cargoLockPackage is a [[package]] entry of a Cargo.lock
+type cargoLockPackage struct {
+	name         string
+	version      string
+	dependencies []string // "name", or "name version" when several versions are locked
+}
+
+// ParseCargoLock parses Cargo.lock content and returns direct dependencies with resolved versions.
+// Direct dependencies are the crates declared in Cargo.toml. Use ParseCargoLockWithOptions to
+// include transitive dependencies.
 func ParseCargoLock(lockContent []byte, cargoTomlContent string) []types.Dependency {
-	// Extract direct dependency names and scopes from Cargo.toml
-	directDeps := extractDirectDepsFromCargoToml(cargoTomlContent)
-	if len(directDeps) == 0 {
+	return ParseCargoLockWithOptions(lockContent, cargoTomlContent, ParseCargoLockOptions{})
+}
+
+// ParseCargoLockWithOptions parses Cargo.lock content with configurable options. Only crates
+// declared in Cargo.toml are direct; their versions are the ones locked for the crate of the
+// Cargo.toml when several versions are locked. Transitive dependencies are the packages reachable
+// from the direct ones, with the scope of the direct dependency they are reached from.
+func ParseCargoLockWithOptions(lockContent []byte, cargoTomlContent string, options ParseCargoLockOptions) []types.Dependency {
+	manifest := ParseCargoManifest(cargoTomlContent)
+	if len(manifest.Dependencies) == 0 {
 		return nil
 	}
+	packages := parseCargoLock(string(lockContent))
+	resolved := cargoLockResolved(packages, manifest.PackageName)
 
-	// Parse Cargo.lock to get resolved versions
-	packages := parseCargoLockPackages(string(lockContent))
-
-	// Build dependency list with resolved versions for direct deps only
+	// Direct dependencies in declaration order, production before build and dev
 	var dependencies []types.Dependency
-	for name, version := range packages {
-		if scope, exists := directDeps[name]; exists {
-			dependencies = append(dependencies, types.Dependency{
-				Type:       "cargo",
-				Name:       name,
-				Version:    version,
-				SourceFile: "Cargo.lock",
+	visited := make(map[*cargoLockPackage]bool)
+	var queue []*cargoLockPackage
+	scopes := make(map[*cargoLockPackage]types.Scope)
+	for _, scope := range []types.Scope{types.ScopeProd, types.ScopeBuild, types.ScopeDev} {
+		for _, declared := range manifest.Dependencies {
+			pkg := resolved[declared.CrateName()]
+			if declared.Scope != scope || pkg == nil || visited[pkg] {
+				continue
+			}
+			visited[pkg] = true
+			queue = append(queue, pkg)
+			scopes[pkg] = scope
+
+			dep := types.Dependency{
+				Type:       DependencyTypeRust,
+				Name:       pkg.name,
+				Version:    pkg.version,
+				SourceFile: MetadataSourceCargoLock,
 				Scope:      scope,
 				Direct:     true,
-			})
+			}
+			RecordLockOrigin(&dep, MetadataSourceCargoToml, MetadataSourceCargoLock, "")
+			dependencies = append(dependencies, dep)
 		}
 	}
+	if !options.IncludeTransitive {
+		return NormalizeDependencyVersions(dependencies)
+	}
 
-	return dependencies
-}
-
-// extractDirectDepsFromCargoToml extracts direct dependency names and scopes from Cargo.toml
-func extractDirectDepsFromCargoToml(content string) map[string]string {
-	deps := make(map[string]string) // name -> scope
-	lines := strings.Split(content, "\n")
-	state := &cargoTomlParseState{}
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		state = updateCargoTomlState(state, trimmed)
-
-		if name := extractCargoDepName(trimmed, state); name != "" {
-			var scope string
-			if state.inDependencies {
-				scope = types.ScopeProd
-			} else if state.inDevDependencies {
-				scope = types.ScopeDev
-			} else if state.inBuildDependencies {
-				scope = types.ScopeBuild
+	// Transitive dependencies, breadth first from the direct ones
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+		for _, ref := range pkg.dependencies {
+			next := cargoLockLookup(packages, ref)
+			if next == nil || visited[next] || next.name == manifest.PackageName {
+				continue
 			}
-			deps[name] = scope
+			visited[next] = true
+			queue = append(queue, next)
+			scopes[next] = scopes[pkg]
+			dependencies = append(dependencies, types.Dependency{
+				Type:       DependencyTypeRust,
+				Name:       next.name,
+				Version:    next.version,
+				SourceFile: MetadataSourceCargoLock,
+				Scope:      scopes[pkg],
+				Direct:     false,
+				Metadata:   types.NewMetadata(MetadataSourceCargoLock),
+			})
 		}
 	}
-
-	return deps
+	return NormalizeDependencyVersions(dependencies)
 }
 
-// cargoTomlParseState tracks the current parsing state for Cargo.toml
-type cargoTomlParseState struct {
-	inDependencies      bool
-	inDevDependencies   bool
-	inBuildDependencies bool
+// CargoLockVersions returns the locked versions of the dependencies of a crate by name: the
+// versions the Cargo.lock records for the crate, else the version of each package by name
+func CargoLockVersions(lockContent []byte, crate string) map[string]string {
+	versions := make(map[string]string)
+	for name, pkg := range cargoLockResolved(parseCargoLock(string(lockContent)), crate) {
+		versions[name] = pkg.version
+	}
+	return versions
 }
 
-func updateCargoTomlState(state *cargoTomlParseState, line string) *cargoTomlParseState {
-	if !strings.HasPrefix(line, "[") || !strings.HasSuffix(line, "]") {
-		return state
+// cargoLockResolved returns the packages of a Cargo.lock by name. Names locked in several versions
+// resolve to the version the dependencies of the crate refer to, else to the last one.
+func cargoLockResolved(packages []cargoLockPackage, crate string) map[string]*cargoLockPackage {
+	resolved := make(map[string]*cargoLockPackage)
+	var root *cargoLockPackage
+	for i := range packages {
+		resolved[packages[i].name] = &packages[i]
+		if crate != "" && packages[i].name == crate {
+			root = &packages[i]
+		}
 	}
-
-	section := strings.Trim(line, "[]")
-	newState := &cargoTomlParseState{}
-
-	switch section {
-	case "dependencies":
-		newState.inDependencies = true
-	case "dev-dependencies":
-		newState.inDevDependencies = true
-	case "build-dependencies":
-		newState.inBuildDependencies = true
+	if root != nil {
+		for _, ref := range root.dependencies {
+			if pkg := cargoLockLookup(packages, ref); pkg != nil {
+				resolved[pkg.name] = pkg
+			}
+		}
 	}
-
-	return newState
+	return resolved
 }
 
-func extractCargoDepName(line string, state *cargoTomlParseState) string {
-	if !state.inDependencies && !state.inDevDependencies && !state.inBuildDependencies {
-		return ""
-	}
-
-	// Skip comments and section headers
-	if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") {
-		return ""
+// cargoLockLookup returns the package a dependency entry of a Cargo.lock refers to: "name",
+// "name version", or "name version (source)"
+func cargoLockLookup(packages []cargoLockPackage, ref string) *cargoLockPackage {
+	fields := strings.Fields(ref)
+	if len(fields) == 0 {
+		return nil
 	}
-
-	// Parse: package-name = "version" or package-name = { version = "..." }
-	if !strings.Contains(line, "=") {
-		return ""
+	var match *cargoLockPackage
+	for i := range packages {
+		if packages[i].name != fields[0] {
+			continue
+		}
+		if len(fields) == 1 || packages[i].version == fields[1] {
+			match = &packages[i]
+		}
 	}
+	return match
+}
 
-	parts := strings.SplitN(line, "=", 2)
-	if len(parts) < 1 {
-		return ""
-	}
+// parseCargoLock extracts the [[package]] entries of a Cargo.lock in file order
+func parseCargoLock(content string) []cargoLockPackage {
+	var packages []cargoLockPackage
+	var current *cargoLockPackage
+	inDependencies := false
 
-	name := strings.TrimSpace(parts[0])
-	if name == "" {
-		return ""
+	flush := func() {
+		if current != nil && current.name != "" && current.version != "" {
+			packages = append(packages, *current)
+		}
+		current = nil
 	}
 
-	return name
-}
-
-// parseCargoLockPackages extracts package name -> version mapping from Cargo.lock
-func parseCargoLockPackages(content string) map[string]string {
-	packages := make(map[string]string)
-	lines := strings.Split(content, "\n")
-	state := &cargoLockParseState{}
-
-	for _, line := range lines {
+	for _, line := range strings.Split(content, "\n") {
 		trimmed := strings.TrimSpace(line)
 
-		if trimmed == "[[package]]" {
-			// Save previous package if valid
-			if state.currentName != "" && state.currentVersion != "" {
-				packages[state.currentName] = state.currentVersion
+		if inDependencies {
+			if trimmed == "]" {
+				inDependencies = false
+			} else if value := strings.Trim(strings.TrimSuffix(trimmed, ","), `"`); value != "" {
+				current.dependencies = append(current.dependencies, value)
 			}
-			state = &cargoLockParseState{}
-			state.inPackage = true
 			continue
 		}
 
-		if !state.inPackage {
+		switch {
+		case trimmed == "[[package]]":
+			flush()
+			current = &cargoLockPackage{}
+		case current == nil:
 			continue
+		case strings.HasPrefix(trimmed, "name = "):
+			current.name = extractCargoLockQuotedValue(trimmed, "name = ")
+		case strings.HasPrefix(trimmed, "version = "):
+			current.version = extractCargoLockQuotedValue(trimmed, "version = ")
+		case strings.HasPrefix(trimmed, "dependencies = ["):
+			// Multi-line array, or inline: dependencies = ["a", "b"]
+			inline := strings.TrimPrefix(trimmed, "dependencies = ")
+			if strings.HasSuffix(inline, "]") {
+				current.dependencies = append(current.dependencies, tomlStrings(inline)...)
+			} else {
+				inDependencies = true
+			}
+		case strings.HasPrefix(trimmed, "["):
+			// End of package section (hit another section)
+			flush()
 		}
-
-		processCargoLockLine(trimmed, state)
-	}
-
-	// Don't forget the last package
-	if state.currentName != "" && state.currentVersion != "" {
-		packages[state.currentName] = state.currentVersion
 	}
+	flush()
 
 	return packages
 }
 
-// cargoLockParseState tracks the current parsing state for Cargo.lock
-type cargoLockParseState struct {
-	inPackage      bool
-	currentName    string
-	currentVersion string
-}
-
-func processCargoLockLine(line string, state *cargoLockParseState) {
-	switch {
-	case strings.HasPrefix(line, "name = "):
-		state.currentName = extractCargoLockQuotedValue(line, "name = ")
-	case strings.HasPrefix(line, "version = "):
-		state.currentVersion = extractCargoLockQuotedValue(line, "version = ")
-	case strings.HasPrefix(line, "[") && line != "[[package]]":
-		// End of package section (hit another section)
-		state.inPackage = false
-	}
-}
-
 func extractCargoLockQuotedValue(line, prefix string) string {
 	rest := line[len(prefix):]
 	if len(rest) >= 2 && rest[0] == '"' {
@@ -2,6 +2,10 @@ package parsers
 
 import (
 	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseCargoLock(t *testing.T) {
@@ -144,3 +148,97 @@ version = "1.0.193"
 		})
 	}
 }
+
+func TestParseCargoLockWithOptions(t *testing.T) {
+	lock := `version = 3
+
+[[package]]
+name = "app"
+version = "0.1.0"
+dependencies = [
+ "json",
+ "rand 0.8.5",
+ "mockall",
+]
+
+[[package]]
+name = "json"
+version = "1.0.108"
+dependencies = ["itoa", "ryu"]
+
+[[package]]
+name = "itoa"
+version = "1.0.9"
+
+[[package]]
+name = "ryu"
+version = "1.0.15"
+
+[[package]]
+name = "rand"
+version = "0.7.3"
+
+[[package]]
+name = "rand"
+version = "0.8.5"
+dependencies = [
+ "rand_core",
+]
+
+[[package]]
+name = "rand_core"
+version = "0.6.4"
+
+[[package]]
+name = "mockall"
+version = "0.12.1"
+dependencies = [
+ "predicates",
+ "itoa",
+]
+
+[[package]]
+name = "predicates"
+version = "3.0.4"
+`
+	toml := `[package]
+name = "app"
+
+[dev-dependencies]
+mockall = "0.12"
+
+[dependencies]
+serde_json = { package = "json", version = "1" }
+rand = "0.8"
+`
+
+	t.Run("default excludes transitive dependencies", func(t *testing.T) {
+		deps := ParseCargoLock([]byte(lock), toml)
+		require.Len(t, deps, 3)
+		for _, dep := range deps {
+			assert.True(t, dep.Direct)
+		}
+		assert.Equal(t, "json", deps[0].Name, "renamed dependency")
+		assert.Equal(t, "rand", deps[1].Name)
+		assert.Equal(t, "0.8.5", deps[1].Version, "version locked for the crate")
+		assert.Equal(t, "mockall", deps[2].Name)
+		assert.Equal(t, types.ScopeDev, deps[2].Scope)
+	})
+
+	t.Run("IncludeTransitive includes reachable packages", func(t *testing.T) {
+		deps := ParseCargoLockWithOptions([]byte(lock), toml, ParseCargoLockOptions{IncludeTransitive: true})
+
+		transitive := make(map[string]types.Dependency)
+		for _, dep := range deps {
+			if !dep.Direct {
+				transitive[dep.Name] = dep
+			}
+		}
+		assert.Len(t, deps, 7)
+		require.Len(t, transitive, 4, "rand 0.7.3 is not reachable")
+		assert.Equal(t, "0.6.4", transitive["rand_core"].Version)
+		assert.Equal(t, types.ScopeProd, transitive["itoa"].Scope, "reached from a production dependency first")
+		assert.Equal(t, types.ScopeDev, transitive["predicates"].Scope)
+		assert.NotContains(t, transitive, "app", "the crate itself")
+	})
+}
@@ -0,0 +1,406 @@
+package parsers
+
+import (
+	"path"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// CargoManifest is the part of a Cargo.toml describing workspaces, dependencies, and features
+type CargoManifest struct {
+	PackageName      string              // [package] name; empty for virtual workspace manifests
+	PackageWorkspace string              // [package] workspace: path of the workspace root, if not a parent directory
+	Workspace        *CargoWorkspace     // [workspace] table; nil if the manifest is not a workspace root
+	Dependencies     []CargoDependency   // [dependencies], [dev-dependencies], [build-dependencies], including target-specific tables
+	Features         map[string][]string // [features] table
+}
+
+// CargoWorkspace is the [workspace] table of a workspace root
+type CargoWorkspace struct {
+	Members      []string                   // Member directories, may contain globs ("crates/*")
+	Exclude      []string                   // Directories excluded from the members
+	Dependencies map[string]CargoDependency // [workspace.dependencies] declarations members inherit
+}
+
+// CargoDependency is a dependency declaration of a Cargo.toml
+type CargoDependency struct {
+	Name                  string // Key of the declaration (the alias of renamed dependencies)
+	Package               string // Crate name of renamed dependencies (package = "...")
+	Scope                 types.Scope
+	Version               string // Version requirement
+	Path                  string // Directory of local dependencies, relative to the manifest
+	Git                   string // Repository of git dependencies
+	Branch                string // Git branch, tag, or revision
+	Tag                   string
+	Rev                   string
+	Features              []string // Features of the dependency enabled by the declaration
+	NoDefaultFeatures     bool     // default-features = false
+	Optional              bool     // Only used when a feature of the crate enables it
+	InheritsFromWorkspace bool     // workspace = true
+}
+
+// CrateName returns the name of the crate a dependency refers to
+func (d CargoDependency) CrateName() string {
+	if d.Package != "" {
+		return d.Package
+	}
+	return d.Name
+}
+
+// cargoDependencyTables maps the dependency tables of a Cargo.toml to their scope
+var cargoDependencyTables = []struct {
+	table string
+	scope types.Scope
+}{
+	{"dependencies", types.ScopeProd},
+	{"dev-dependencies", types.ScopeDev},
+	{"build-dependencies", types.ScopeBuild},
+}
+
+// ParseCargoManifest parses the workspace, dependency, and feature tables of a Cargo.toml.
+// Dependencies are declared as version strings, inline tables, dotted keys
+// (serde.workspace = true), or subtables ([dependencies.serde]); target-specific tables
+// ([target.'cfg(unix)'.dependencies]) are included.
+func ParseCargoManifest(content string) *CargoManifest {
+	manifest := &CargoManifest{Features: make(map[string][]string)}
+	workspaceDeps := make(map[string]*CargoDependency)
+	var workspaceOrder []string
+	deps := make(map[string]*CargoDependency) // By scope and name
+	var order []string
+
+	declare := func(scope types.Scope, name string) *CargoDependency {
+		if scope == "" {
+			if workspaceDeps[name] == nil {
+				workspaceDeps[name] = &CargoDependency{Name: name}
+				workspaceOrder = append(workspaceOrder, name)
+			}
+			return workspaceDeps[name]
+		}
+		key := string(scope) + "\x00" + name
+		if deps[key] == nil {
+			deps[key] = &CargoDependency{Name: name, Scope: scope}
+			order = append(order, key)
+		}
+		return deps[key]
+	}
+
+	for _, entry := range parseTomlEntries(content) {
+		key := tomlKey(entry.key)
+		switch {
+		case entry.section == "package":
+			switch key {
+			case "name":
+				manifest.PackageName = tomlString(entry.value)
+			case "workspace":
+				manifest.PackageWorkspace = tomlString(entry.value)
+			}
+		case entry.section == "workspace":
+			if manifest.Workspace == nil {
+				manifest.Workspace = &CargoWorkspace{}
+			}
+			switch key {
+			case "members":
+				manifest.Workspace.Members = tomlStrings(entry.value)
+			case "exclude":
+				manifest.Workspace.Exclude = tomlStrings(entry.value)
+			}
+		case entry.section == "features":
+			manifest.Features[key] = tomlStrings(entry.value)
+		default:
+			scope, name, ok := cargoDependencySection(entry.section)
+			if !ok {
+				continue
+			}
+			if name != "" {
+				// Subtable of one dependency: [dependencies.serde]
+				declare(scope, name).setField(key, entry.value)
+			} else if depName, field, dotted := strings.Cut(key, "."); dotted {
+				// Dotted key: serde.workspace = true
+				declare(scope, tomlKey(depName)).setField(tomlKey(field), entry.value)
+			} else {
+				declare(scope, key).setValue(entry.value)
+			}
+		}
+	}
+	if manifest.Workspace == nil && hasCargoWorkspaceTable(content) {
+		manifest.Workspace = &CargoWorkspace{} // Empty [workspace] or only [workspace.*] tables
+	}
+
+	if manifest.Workspace != nil {
+		manifest.Workspace.Dependencies = make(map[string]CargoDependency, len(workspaceDeps))
+		for _, name := range workspaceOrder {
+			manifest.Workspace.Dependencies[name] = *workspaceDeps[name]
+		}
+	}
+	for _, key := range order {
+		manifest.Dependencies = append(manifest.Dependencies, *deps[key])
+	}
+	return manifest
+}
+
+// hasCargoWorkspaceTable reports whether a Cargo.toml has a [workspace] or [workspace.*] table
+func hasCargoWorkspaceTable(content string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "[workspace]" || strings.HasPrefix(line, "[workspace.") {
+			return true
+		}
+	}
+	return false
+}
+
+// cargoDependencySection returns the scope of a dependency table (empty for
+// [workspace.dependencies]) and the dependency name of a dependency subtable
+func cargoDependencySection(section string) (scope types.Scope, name string, ok bool) {
+	if section == "workspace.dependencies" {
+		return "", "", true
+	}
+	if rest, found := strings.CutPrefix(section, "workspace.dependencies."); found {
+		return "", tomlKey(rest), true
+	}
+	if strings.HasPrefix(section, "target.") {
+		// [target.'cfg(unix)'.dependencies]: drop the target, which may contain dots
+		for _, table := range cargoDependencyTables {
+			if i := strings.LastIndex(section, "."+table.table); i > 0 {
+				section = section[i+1:]
+				break
+			}
+		}
+	}
+	for _, table := range cargoDependencyTables {
+		if section == table.table {
+			return table.scope, "", true
+		}
+		if rest, found := strings.CutPrefix(section, table.table+"."); found {
+			return table.scope, tomlKey(rest), true
+		}
+	}
+	return "", "", false
+}
+
+// tomlKey removes the quotes of a quoted TOML key
+func tomlKey(key string) string {
+	return tomlString(strings.TrimSpace(key))
+}
+
+// setValue sets a dependency from its declaration: a version string or an inline table
+func (d *CargoDependency) setValue(value string) {
+	if !strings.HasPrefix(value, "{") {
+		d.Version = tomlString(value)
+		return
+	}
+	for field, fieldValue := range tomlInlineTable(value) {
+		d.setField(tomlKey(field), fieldValue)
+	}
+}
+
+// setField sets a field of a dependency declaration
+func (d *CargoDependency) setField(field, value string) {
+	switch field {
+	case "version":
+		d.Version = tomlString(value)
+	case "path":
+		d.Path = tomlString(value)
+	case "git":
+		d.Git = tomlString(value)
+	case "branch":
+		d.Branch = tomlString(value)
+	case "tag":
+		d.Tag = tomlString(value)
+	case "rev":
+		d.Rev = tomlString(value)
+	case "package":
+		d.Package = tomlString(value)
+	case "features":
+		d.Features = tomlStrings(value)
+	case "default-features", "default_features":
+		d.NoDefaultFeatures = strings.TrimSpace(value) == "false"
+	case "optional":
+		d.Optional = strings.TrimSpace(value) == "true"
+	case "workspace":
+		d.InheritsFromWorkspace = strings.TrimSpace(value) == "true"
+	}
+}
+
+// IsWorkspaceMember reports whether a directory (relative to the workspace root, slash-separated)
+// is a member of the workspace: listed by a member path or glob, and not excluded. The root
+// package is a member too.
+func (w *CargoWorkspace) IsWorkspaceMember(dir string) bool {
+	dir = path.Clean(dir)
+	if dir == "." {
+		return true
+	}
+	for _, exclude := range w.Exclude {
+		if path.Clean(exclude) == dir {
+			return false
+		}
+	}
+	for _, member := range w.Members {
+		if matched, _ := path.Match(path.Clean(member), dir); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// CargoDependencies returns the dependencies of a crate. Dependencies inherited from the
+// workspace (workspace = true) take the declaration of the workspace manifest, whose directory
+// is workspaceDir relative to the crate; their features are combined. Versions are taken from
+// lockVersions when the crate is locked (nil without Cargo.lock).
+//
+// The metadata records the features of each dependency the crate enables, including those its
+// default features enable, and whether default features are disabled. Optional dependencies
+// list the crate features enabling them (enabled_by, and a feature activation) and use the
+// optional scope, unless the default features of the crate enable them.
+func CargoDependencies(crate, workspace *CargoManifest, workspaceDir string, lockVersions map[string]string) []types.Dependency {
+	defaults := cargoDefaultFeatures(crate)
+
+	var dependencies []types.Dependency
+	for _, declared := range crate.Dependencies {
+		inherited := false
+		if declared.InheritsFromWorkspace && workspace != nil && workspace.Workspace != nil {
+			if base, ok := workspace.Workspace.Dependencies[declared.Name]; ok {
+				declared = inheritCargoDependency(declared, base, workspaceDir)
+				inherited = true
+			}
+		}
+
+		info := &dependencyInfo{version: declared.Version, path: declared.Path, git: declared.Git, branch: declared.Branch, tag: declared.Tag, rev: declared.Rev}
+		dep := NewRustParser().buildDependency(declared.CrateName(), info)
+		if dep.Name == "" {
+			continue // No version, path, or git repository
+		}
+		dep.Scope = declared.Scope
+		if declared.Optional && !defaults.deps[declared.Name] {
+			dep.Scope = types.ScopeOptional
+		}
+		dep.SourceFile = MetadataSourceCargoToml
+
+		if version, ok := lockVersions[declared.CrateName()]; ok {
+			dep.Version = version
+			dep.SourceFile = MetadataSourceCargoLock
+			dep.Metadata = nil
+			RecordLockOrigin(&dep, MetadataSourceCargoToml, MetadataSourceCargoLock, "")
+		}
+
+		features := append([]string{}, declared.Features...)
+		features = append(features, defaults.features[declared.Name]...)
+		if !declared.Optional || defaults.deps[declared.Name] {
+			features = append(features, defaults.weakFeatures[declared.Name]...)
+		}
+		if features = sortedUnique(features); len(features) > 0 {
+			dep.Metadata[MetadataCargoFeatures] = features
+		}
+		if declared.NoDefaultFeatures {
+			dep.Metadata[MetadataCargoDefaultFeatures] = false
+		}
+		if declared.Optional {
+			dep.Metadata["optional"] = true
+			enabledBy := cargoFeaturesEnabling(crate, declared.Name)
+			if len(enabledBy) > 0 {
+				dep.Metadata[MetadataCargoEnabledBy] = enabledBy
+			}
+			AddActivation(&dep, ActivationFeature, enabledBy, defaults.deps[declared.Name])
+		}
+		if declared.Path != "" {
+			dep.Metadata[MetadataCargoPath] = declared.Path
+		}
+		if inherited {
+			dep.Metadata[MetadataCargoWorkspace] = true
+		}
+		if declared.Package != "" && declared.Package != declared.Name {
+			dep.Metadata[MetadataCargoAlias] = declared.Name
+		}
+		dependencies = append(dependencies, dep)
+	}
+	return NormalizeDependencyVersions(dependencies)
+}
+
+// inheritCargoDependency combines a member declaration (workspace = true) with the workspace
+// declaration: the source comes from the workspace, features are added, and optional stays a
+// member setting
+func inheritCargoDependency(member, base CargoDependency, workspaceDir string) CargoDependency {
+	inherited := base
+	inherited.Name = member.Name
+	inherited.Scope = member.Scope
+	inherited.Optional = member.Optional
+	inherited.Features = append(append([]string{}, base.Features...), member.Features...)
+	if base.Path != "" {
+		inherited.Path = path.Join(workspaceDir, base.Path)
+	}
+	return inherited
+}
+
+// cargoFeatureSet is what the default features of a crate enable
+type cargoFeatureSet struct {
+	deps         map[string]bool     // Optional dependencies enabled
+	features     map[string][]string // Features of dependencies enabled ("serde/derive")
+	weakFeatures map[string][]string // Features of dependencies enabled if the dependency is ("serde?/derive")
+}
+
+// cargoDefaultFeatures resolves the default features of a crate, following features enabling
+// other features
+func cargoDefaultFeatures(crate *CargoManifest) cargoFeatureSet {
+	set := cargoFeatureSet{deps: make(map[string]bool), features: make(map[string][]string), weakFeatures: make(map[string][]string)}
+	visited := make(map[string]bool)
+	queue := append([]string{}, crate.Features["default"]...)
+	for len(queue) > 0 {
+		entry := queue[0]
+		queue = queue[1:]
+		if visited[entry] {
+			continue
+		}
+		visited[entry] = true
+
+		switch {
+		case strings.HasPrefix(entry, "dep:"):
+			set.deps[strings.TrimPrefix(entry, "dep:")] = true
+		case strings.Contains(entry, "?/"):
+			dep, feature, _ := strings.Cut(entry, "?/")
+			set.weakFeatures[dep] = append(set.weakFeatures[dep], feature)
+		case strings.Contains(entry, "/"):
+			dep, feature, _ := strings.Cut(entry, "/")
+			set.deps[dep] = true
+			set.features[dep] = append(set.features[dep], feature)
+		default:
+			if enables, ok := crate.Features[entry]; ok {
+				queue = append(queue, enables...)
+			} else {
+				set.deps[entry] = true // Implicit feature of an optional dependency
+			}
+		}
+	}
+	return set
+}
+
+// cargoFeaturesEnabling returns the features of a crate that enable an optional dependency
+// directly ("dep:serde", "serde/derive", the implicit "serde" feature)
+func cargoFeaturesEnabling(crate *CargoManifest, dep string) []string {
+	var features []string
+	for feature, enables := range crate.Features {
+		for _, entry := range enables {
+			if entry == "dep:"+dep || entry == dep || strings.HasPrefix(entry, dep+"/") {
+				features = append(features, feature)
+				break
+			}
+		}
+	}
+	if _, explicit := crate.Features[dep]; !explicit && !cargoUsesDepPrefix(crate, dep) {
+		features = append(features, dep) // Implicit feature named after the dependency
+	}
+	return sortedUnique(features)
+}
+
+// cargoUsesDepPrefix reports whether a feature refers to the dependency as "dep:name", which
+// removes its implicit feature
+func cargoUsesDepPrefix(crate *CargoManifest, dep string) bool {
+	for _, enables := range crate.Features {
+		for _, entry := range enables {
+			if entry == "dep:"+dep {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCargoManifest(t *testing.T) {
+	manifest := ParseCargoManifest(`[package]
+name = "app"
+workspace = "../.."
+
+[dependencies]
+serde = { version = "1.0", features = ["derive", "rc"], default-features = false }
+json = { package = "serde_json", version = "1" }
+core.workspace = true
+
+[dependencies.tokio]
+version = "1"
+optional = true
+
+[target.'cfg(unix)'.dependencies]
+nix = "0.27"
+
+[dev-dependencies]
+mockall = "0.12"
+
+[features]
+default = ["tokio"]
+`)
+
+	assert.Equal(t, "app", manifest.PackageName)
+	assert.Equal(t, "../..", manifest.PackageWorkspace)
+	assert.Nil(t, manifest.Workspace)
+	assert.Equal(t, map[string][]string{"default": {"tokio"}}, manifest.Features)
+
+	require.Len(t, manifest.Dependencies, 6)
+	deps := make(map[string]CargoDependency)
+	for _, dep := range manifest.Dependencies {
+		deps[dep.Name] = dep
+	}
+	assert.Equal(t, CargoDependency{Name: "serde", Scope: types.ScopeProd, Version: "1.0", Features: []string{"derive", "rc"}, NoDefaultFeatures: true}, deps["serde"])
+	assert.Equal(t, "serde_json", deps["json"].CrateName())
+	assert.True(t, deps["core"].InheritsFromWorkspace)
+	assert.Equal(t, CargoDependency{Name: "tokio", Scope: types.ScopeProd, Version: "1", Optional: true}, deps["tokio"])
+	assert.Equal(t, "0.27", deps["nix"].Version, "target-specific dependency")
+	assert.Equal(t, types.ScopeDev, deps["mockall"].Scope)
+}
+
+func TestParseCargoManifest_Workspace(t *testing.T) {
+	manifest := ParseCargoManifest(`[workspace]
+members = ["crates/*", "tools/cli"]
+exclude = ["crates/legacy"]
+
+[workspace.dependencies]
+serde = { version = "1.0", features = ["derive"] }
+core = { path = "crates/core" }
+`)
+
+	assert.Empty(t, manifest.PackageName)
+	require.NotNil(t, manifest.Workspace)
+	assert.Equal(t, []string{"crates/*", "tools/cli"}, manifest.Workspace.Members)
+	assert.Equal(t, "crates/core", manifest.Workspace.Dependencies["core"].Path)
+	assert.Equal(t, []string{"derive"}, manifest.Workspace.Dependencies["serde"].Features)
+	assert.Empty(t, manifest.Dependencies)
+
+	assert.True(t, manifest.Workspace.IsWorkspaceMember("."))
+	assert.True(t, manifest.Workspace.IsWorkspaceMember("crates/core"))
+	assert.True(t, manifest.Workspace.IsWorkspaceMember("tools/cli"))
+	assert.False(t, manifest.Workspace.IsWorkspaceMember("crates/legacy"))
+	assert.False(t, manifest.Workspace.IsWorkspaceMember("examples/demo"))
+}
+
+func TestCargoDependencies(t *testing.T) {
+	workspace := ParseCargoManifest(`[workspace]
+members = ["crates/*"]
+
+[workspace.dependencies]
+serde = { version = "1.0", features = ["derive"] }
+core = { path = "crates/core" }
+`)
+	crate := ParseCargoManifest(`[package]
+name = "cli"
+
+[dependencies]
+core = { workspace = true }
+serde = { workspace = true, features = ["rc"] }
+tokio = { version = "1", optional = true }
+tracing = { version = "0.1", optional = true }
+log = { version = "0.4", features = ["std"] }
+
+[features]
+default = ["runtime", "log?/serde"]
+runtime = ["dep:tokio", "tokio/rt"]
+trace = ["dep:tracing"]
+`)
+
+	deps := make(map[string]types.Dependency)
+	for _, dep := range CargoDependencies(crate, workspace, "../..", map[string]string{"serde": "1.0.195"}) {
+		deps[dep.Name] = dep
+	}
+	require.Len(t, deps, 5)
+
+	assert.Equal(t, "path:../../crates/core", deps["core"].Version)
+	assert.Equal(t, "../../crates/core", deps["core"].Metadata[MetadataCargoPath])
+	assert.Equal(t, true, deps["core"].Metadata[MetadataCargoWorkspace])
+
+	assert.Equal(t, "1.0.195", deps["serde"].Version)
+	assert.Equal(t, MetadataSourceCargoLock, deps["serde"].SourceFile)
+	assert.Equal(t, []string{"derive", "rc"}, deps["serde"].Metadata[MetadataCargoFeatures])
+
+	assert.Equal(t, types.ScopeProd, deps["tokio"].Scope, "enabled by default features")
+	assert.Equal(t, []string{"rt"}, deps["tokio"].Metadata[MetadataCargoFeatures])
+	assert.Equal(t, []string{"runtime"}, deps["tokio"].Metadata[MetadataCargoEnabledBy])
+
+	assert.Equal(t, types.ScopeOptional, deps["tracing"].Scope, "not enabled by default features")
+	assert.Equal(t, true, deps["tracing"].Metadata["optional"])
+	assert.Equal(t, []string{"trace"}, deps["tracing"].Metadata[MetadataCargoEnabledBy])
+
+	assert.Equal(t, []string{"serde", "std"}, deps["log"].Metadata[MetadataCargoFeatures], "weak feature of a required dependency")
+}
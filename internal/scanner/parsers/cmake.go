@@ -0,0 +1,243 @@
+package parsers
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Pre-compiled regexes for CMake scripts
+var (
+	cmakeCommandRegex        = regexp.MustCompile(`(?i)\b(project|cmake_minimum_required|enable_language|find_package)\s*\(((?:"(?:[^"\\]|\\.)*"|[^()"])*)\)`)
+	cmakeBracketCommentRegex = regexp.MustCompile(`(?s)#\[=*\[.*?\]=*\]`)
+	cmakeArgumentRegex       = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"|[^\s"]+`)
+	cmakeVersionRegex        = regexp.MustCompile(`^\d+(\.\d+)*`)
+)
+
+// cmakeFindPackageKeywords are the options of find_package that end a COMPONENTS list
+var cmakeFindPackageKeywords = map[string]bool{
+	"EXACT": true, "QUIET": true, "MODULE": true, "CONFIG": true, "NO_MODULE": true,
+	"REQUIRED": true, "COMPONENTS": true, "OPTIONAL_COMPONENTS": true, "NO_POLICY_SCOPE": true,
+	"GLOBAL": true, "NAMES": true, "CONFIGS": true, "HINTS": true, "PATHS": true,
+	"PATH_SUFFIXES": true, "REGISTRY_VIEW": true, "BYPASS_PROVIDER": true,
+}
+
+// CMakeProject represents the project declarations and package lookups of a CMakeLists.txt
+type CMakeProject struct {
+	Name           string
+	Version        string
+	HasProject     bool     // True if the script declares a project (top-level CMakeLists.txt)
+	MinimumVersion string   // cmake_minimum_required VERSION
+	Languages      []string // Languages declared by project (LANGUAGES) and enable_language
+	Packages       []CMakePackage
+}
+
+// CMakePackage is a package found by find_package
+type CMakePackage struct {
+	Name       string
+	Version    string
+	Exact      bool
+	Required   bool
+	Components []string
+}
+
+// CMakeParser handles CMake scripts
+type CMakeParser struct{}
+
+// NewCMakeParser creates a new CMake parser
+func NewCMakeParser() *CMakeParser {
+	return &CMakeParser{}
+}
+
+// ParseCMakeLists parses the project, cmake_minimum_required, enable_language, and
+// find_package commands of a CMakeLists.txt. Packages found more than once are merged.
+// Names and versions given by variables are skipped.
+func (p *CMakeParser) ParseCMakeLists(content string) CMakeProject {
+	var project CMakeProject
+	content = stripCMakeComments(content)
+	packageIndex := make(map[string]int)
+
+	for _, match := range cmakeCommandRegex.FindAllStringSubmatch(content, -1) {
+		args := cmakeArguments(match[2])
+		if len(args) == 0 {
+			continue
+		}
+		switch strings.ToLower(match[1]) {
+		case "project":
+			if project.HasProject {
+				continue
+			}
+			project.HasProject = true
+			if !strings.Contains(args[0], "${") {
+				project.Name = args[0]
+			}
+			project.Version, project.Languages = cmakeProjectOptions(args[1:])
+		case "cmake_minimum_required":
+			if len(args) > 1 && strings.EqualFold(args[0], "VERSION") {
+				project.MinimumVersion = args[1]
+			}
+		case "enable_language":
+			for _, language := range args {
+				if !strings.EqualFold(language, "OPTIONAL") {
+					project.Languages = appendUnique(project.Languages, language)
+				}
+			}
+		case "find_package":
+			pkg, ok := parseFindPackage(args)
+			if !ok {
+				continue
+			}
+			if i, seen := packageIndex[pkg.Name]; seen {
+				existing := &project.Packages[i]
+				existing.Required = existing.Required || pkg.Required
+				for _, component := range pkg.Components {
+					existing.Components = appendUnique(existing.Components, component)
+				}
+				if existing.Version == "" {
+					existing.Version, existing.Exact = pkg.Version, pkg.Exact
+				}
+				continue
+			}
+			packageIndex[pkg.Name] = len(project.Packages)
+			project.Packages = append(project.Packages, pkg)
+		}
+	}
+	return project
+}
+
+// Dependencies converts the found packages to dependencies. A find_package version is the
+// minimum compatible version (">=1.10"), or the exact version with EXACT. The "required" and
+// "components" metadata record REQUIRED and the requested components.
+func (p *CMakeParser) Dependencies(project CMakeProject) []types.Dependency {
+	var dependencies []types.Dependency
+	for _, pkg := range project.Packages {
+		metadata := types.NewMetadata(MetadataSourceCMakeLists)
+		if pkg.Required {
+			metadata["required"] = true
+		}
+		if len(pkg.Components) > 0 {
+			metadata["components"] = pkg.Components
+		}
+		version := "latest"
+		switch {
+		case pkg.Version != "" && pkg.Exact:
+			version = pkg.Version
+		case pkg.Version != "":
+			version = ">=" + pkg.Version
+		}
+		dependencies = append(dependencies, types.Dependency{
+			Type:     DependencyTypeCMake,
+			Name:     pkg.Name,
+			Version:  version,
+			Scope:    types.ScopeProd,
+			Direct:   true,
+			Metadata: metadata,
+		})
+	}
+	return dependencies
+}
+
+// cmakeProjectOptions returns the version and languages of project(<name> ...), in both the
+// keyword form (VERSION, LANGUAGES) and the short form (project(name C Fortran))
+func cmakeProjectOptions(args []string) (string, []string) {
+	var version string
+	var languages []string
+	keyword := "LANGUAGES" // Short form lists languages directly
+	for _, arg := range args {
+		switch upper := strings.ToUpper(arg); upper {
+		case "VERSION", "DESCRIPTION", "HOMEPAGE_URL", "LANGUAGES":
+			keyword = upper
+			continue
+		}
+		switch keyword {
+		case "VERSION":
+			if !strings.Contains(arg, "${") {
+				version = arg
+			}
+		case "LANGUAGES":
+			if !strings.EqualFold(arg, "NONE") && !strings.Contains(arg, "${") {
+				languages = appendUnique(languages, arg)
+			}
+		}
+	}
+	return version, languages
+}
+
+// parseFindPackage parses the arguments of find_package
+func parseFindPackage(args []string) (CMakePackage, bool) {
+	if strings.Contains(args[0], "${") {
+		return CMakePackage{}, false
+	}
+	pkg := CMakePackage{Name: args[0]}
+	mode := ""
+	for i, arg := range args[1:] {
+		if i == 0 && cmakeVersionRegex.MatchString(arg) {
+			pkg.Version = arg
+			continue
+		}
+		if cmakeFindPackageKeywords[arg] || strings.HasPrefix(arg, "NO_") {
+			switch arg {
+			case "EXACT":
+				pkg.Exact = true
+			case "REQUIRED":
+				pkg.Required = true
+				mode = "COMPONENTS" // REQUIRED may be followed by the components
+			case "COMPONENTS", "OPTIONAL_COMPONENTS":
+				mode = arg
+			default:
+				mode = ""
+			}
+			continue
+		}
+		if mode == "COMPONENTS" && !strings.Contains(arg, "${") {
+			pkg.Components = appendUnique(pkg.Components, arg)
+		}
+	}
+	return pkg, true
+}
+
+// cmakeArguments splits command arguments on whitespace, unquoting quoted arguments
+func cmakeArguments(args string) []string {
+	var result []string
+	for _, match := range cmakeArgumentRegex.FindAllStringSubmatch(args, -1) {
+		if strings.HasPrefix(match[0], `"`) {
+			result = append(result, match[1])
+		} else {
+			result = append(result, match[0])
+		}
+	}
+	return result
+}
+
+// stripCMakeComments removes bracket comments (#[[ ... ]]) and line comments outside of quoted
+// arguments
+func stripCMakeComments(content string) string {
+	content = cmakeBracketCommentRegex.ReplaceAllString(content, "")
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		inQuote := false
+		for j := 0; j < len(line); j++ {
+			switch line[j] {
+			case '\\':
+				j++
+			case '"':
+				inQuote = !inQuote
+			case '#':
+				if !inQuote {
+					lines[i] = line[:j]
+					j = len(line)
+				}
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// appendUnique appends a value to a list unless it is already present
+func appendUnique(values []string, value string) []string {
+	if containsString(values, value) {
+		return values
+	}
+	return append(values, value)
+}
@@ -0,0 +1,83 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCMakeLists = `cmake_minimum_required(VERSION 3.20)
+#[[ Block comment with find_package(Ignored) ]]
+project(ClimateModel
+  VERSION 2.4.1
+  DESCRIPTION "Regional climate model (find_package in description)"
+  LANGUAGES C Fortran)
+
+enable_language(CUDA OPTIONAL)
+
+# find_package(Commented REQUIRED)
+find_package(MPI REQUIRED COMPONENTS Fortran)
+find_package(HDF5 1.10 REQUIRED COMPONENTS Fortran HL)
+find_package(NetCDF REQUIRED)
+find_package(BLAS)
+find_package(LAPACK 3.9 EXACT QUIET)
+FIND_PACKAGE(OpenMP)
+find_package(MPI COMPONENTS C)
+find_package(${EXTRA_PACKAGE})
+find_package(Boost 1.80 REQUIRED system filesystem)
+
+add_subdirectory(src)
+`
+
+func TestCMakeParser_ParseCMakeLists(t *testing.T) {
+	project := NewCMakeParser().ParseCMakeLists(testCMakeLists)
+
+	assert.True(t, project.HasProject)
+	assert.Equal(t, "ClimateModel", project.Name)
+	assert.Equal(t, "2.4.1", project.Version)
+	assert.Equal(t, "3.20", project.MinimumVersion)
+	assert.Equal(t, []string{"C", "Fortran", "CUDA"}, project.Languages)
+
+	assert.Equal(t, []CMakePackage{
+		{Name: "MPI", Required: true, Components: []string{"Fortran", "C"}},
+		{Name: "HDF5", Version: "1.10", Required: true, Components: []string{"Fortran", "HL"}},
+		{Name: "NetCDF", Required: true},
+		{Name: "BLAS"},
+		{Name: "LAPACK", Version: "3.9", Exact: true},
+		{Name: "OpenMP"},
+		{Name: "Boost", Version: "1.80", Required: true, Components: []string{"system", "filesystem"}},
+	}, project.Packages)
+}
+
+func TestCMakeParser_ParseCMakeListsShortProject(t *testing.T) {
+	project := NewCMakeParser().ParseCMakeLists("project(solver CXX)\nfind_package(Eigen3 3.4 NO_MODULE)\n")
+	assert.Equal(t, "solver", project.Name)
+	assert.Equal(t, []string{"CXX"}, project.Languages)
+	assert.Equal(t, []CMakePackage{{Name: "Eigen3", Version: "3.4"}}, project.Packages)
+}
+
+func TestCMakeParser_ParseCMakeListsWithoutProject(t *testing.T) {
+	project := NewCMakeParser().ParseCMakeLists("add_library(core core.c)\nfind_package(ZLIB REQUIRED)\n")
+	assert.False(t, project.HasProject)
+	assert.Empty(t, project.Languages)
+	assert.Len(t, project.Packages, 1)
+}
+
+func TestCMakeParser_Dependencies(t *testing.T) {
+	parser := NewCMakeParser()
+	deps := parser.Dependencies(parser.ParseCMakeLists(testCMakeLists))
+	require.Len(t, deps, 7)
+
+	for _, dep := range deps {
+		assert.Equal(t, DependencyTypeCMake, dep.Type)
+		assert.True(t, dep.Direct)
+		assert.Equal(t, MetadataSourceCMakeLists, dep.Metadata["source"])
+	}
+	assert.Equal(t, "latest", deps[0].Version)
+	assert.Equal(t, true, deps[0].Metadata["required"])
+	assert.Equal(t, []string{"Fortran", "C"}, deps[0].Metadata["components"])
+	assert.Equal(t, ">=1.10", deps[1].Version)
+	assert.Nil(t, deps[3].Metadata["required"])
+	assert.Equal(t, "3.9", deps[4].Version)
+}
@@ -0,0 +1,444 @@
+package parsers
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Compile code quality regexes once at package level for performance
+var (
+	pyprojectToolSectionRegex = regexp.MustCompile(`^\[tool\.([\w-]+)`)
+	pyprojectQuotedRegex      = regexp.MustCompile(`"([^"]+)"|'([^']+)'`)
+	pyprojectPoetryDepRegex   = regexp.MustCompile(`^([\w.-]+)\s*=\s*(?:["']([^"']+)["']|\{.*version\s*=\s*["']([^"']+)["'])`)
+)
+
+// Code quality tool kinds
+const (
+	CodeQualityKindFormatter = "formatter"
+	CodeQualityKindLinter    = "linter"
+)
+
+// codeQualityToolSpec describes how a formatter or linter is configured and installed
+type codeQualityToolSpec struct {
+	tech        string
+	kinds       []string
+	configFiles []string
+	packages    map[string][]string // Dependency type -> package names
+	hooks       []string            // pre-commit hook IDs
+	actions     []string            // GitHub Actions running the tool
+	configKeys  []string            // package.json keys or pyproject.toml [tool.*] sections holding its configuration
+}
+
+// Kind lists shared by the tool specs
+var (
+	kindsFormatter          = []string{CodeQualityKindFormatter}
+	kindsLinter             = []string{CodeQualityKindLinter}
+	kindsFormatterAndLinter = []string{CodeQualityKindFormatter, CodeQualityKindLinter}
+)
+
+// codeQualityTools lists the inventoried formatters and linters by tool name
+var codeQualityTools = map[string]codeQualityToolSpec{
+	"prettier": {
+		tech:  "prettier",
+		kinds: kindsFormatter,
+		configFiles: []string{".prettierrc", ".prettierrc.json", ".prettierrc.json5", ".prettierrc.yaml", ".prettierrc.yml",
+			".prettierrc.toml", ".prettierrc.js", ".prettierrc.cjs", ".prettierrc.mjs", "prettier.config.js", "prettier.config.cjs", "prettier.config.mjs"},
+		packages:   map[string][]string{DependencyTypeNpm: {"prettier"}},
+		hooks:      []string{"prettier"},
+		configKeys: []string{"prettier"},
+	},
+	"eslint": {
+		tech:  "eslint",
+		kinds: kindsLinter,
+		configFiles: []string{".eslintrc", ".eslintrc.js", ".eslintrc.cjs", ".eslintrc.json", ".eslintrc.yaml", ".eslintrc.yml",
+			"eslint.config.js", "eslint.config.cjs", "eslint.config.mjs", "eslint.config.ts"},
+		packages:   map[string][]string{DependencyTypeNpm: {"eslint"}},
+		hooks:      []string{"eslint"},
+		configKeys: []string{"eslintConfig"},
+	},
+	"stylelint": {
+		tech:  "stylelint",
+		kinds: kindsLinter,
+		configFiles: []string{".stylelintrc", ".stylelintrc.json", ".stylelintrc.yaml", ".stylelintrc.yml", ".stylelintrc.js",
+			".stylelintrc.cjs", "stylelint.config.js", "stylelint.config.cjs", "stylelint.config.mjs"},
+		packages:   map[string][]string{DependencyTypeNpm: {"stylelint"}},
+		hooks:      []string{"stylelint"},
+		configKeys: []string{"stylelint"},
+	},
+	"biome": {
+		tech:        "biomejs",
+		kinds:       kindsFormatterAndLinter,
+		configFiles: []string{"biome.json", "biome.jsonc"},
+		packages:    map[string][]string{DependencyTypeNpm: {"@biomejs/biome"}},
+		hooks:       []string{"biome-check", "biome-format", "biome-lint"},
+		actions:     []string{"biomejs/setup-biome"},
+	},
+	"oxlint": {
+		tech:        "oxlint",
+		kinds:       kindsLinter,
+		configFiles: []string{".oxlintrc.json"},
+		packages:    map[string][]string{DependencyTypeNpm: {"oxlint"}},
+	},
+	"markdownlint": {
+		tech:  "markdownlint",
+		kinds: kindsLinter,
+		configFiles: []string{".markdownlint.json", ".markdownlint.jsonc", ".markdownlint.yaml", ".markdownlint.yml",
+			".markdownlint-cli2.jsonc", ".markdownlint-cli2.yaml"},
+		packages: map[string][]string{DependencyTypeNpm: {"markdownlint-cli", "markdownlint-cli2"}},
+		hooks:    []string{"markdownlint", "markdownlint-cli2"},
+		actions:  []string{"DavidAnson/markdownlint-cli2-action"},
+	},
+	"black": {
+		tech:       "black",
+		kinds:      kindsFormatter,
+		packages:   map[string][]string{DependencyTypePython: {"black"}},
+		hooks:      []string{"black", "black-jupyter"},
+		actions:    []string{"psf/black"},
+		configKeys: []string{"black"},
+	},
+	"ruff": {
+		tech:        "ruff",
+		kinds:       kindsFormatterAndLinter,
+		configFiles: []string{"ruff.toml", ".ruff.toml"},
+		packages:    map[string][]string{DependencyTypePython: {"ruff"}},
+		hooks:       []string{"ruff", "ruff-check", "ruff-format"},
+		actions:     []string{"astral-sh/ruff-action", "chartboost/ruff-action"},
+		configKeys:  []string{"ruff"},
+	},
+	"flake8": {
+		tech:        "flake8",
+		kinds:       kindsLinter,
+		configFiles: []string{".flake8"},
+		packages:    map[string][]string{DependencyTypePython: {"flake8"}},
+		hooks:       []string{"flake8"},
+	},
+	"pylint": {
+		tech:        "pylint",
+		kinds:       kindsLinter,
+		configFiles: []string{".pylintrc", "pylintrc"},
+		packages:    map[string][]string{DependencyTypePython: {"pylint"}},
+		hooks:       []string{"pylint"},
+		configKeys:  []string{"pylint"},
+	},
+	"isort": {
+		tech:        "isort",
+		kinds:       kindsFormatter,
+		configFiles: []string{".isort.cfg"},
+		packages:    map[string][]string{DependencyTypePython: {"isort"}},
+		hooks:       []string{"isort"},
+		actions:     []string{"isort/isort-action"},
+		configKeys:  []string{"isort"},
+	},
+	"mypy": {
+		tech:        "mypy",
+		kinds:       kindsLinter,
+		configFiles: []string{"mypy.ini", ".mypy.ini"},
+		packages:    map[string][]string{DependencyTypePython: {"mypy"}},
+		hooks:       []string{"mypy"},
+		configKeys:  []string{"mypy"},
+	},
+	"rubocop": {
+		tech:        "rubocop",
+		kinds:       kindsFormatterAndLinter,
+		configFiles: []string{".rubocop.yml", ".rubocop.yaml"},
+		packages:    map[string][]string{DependencyTypeRuby: {"rubocop"}},
+		hooks:       []string{"rubocop"},
+	},
+	"golangci-lint": {
+		tech:        "golangcilint",
+		kinds:       kindsLinter,
+		configFiles: []string{".golangci.yml", ".golangci.yaml", ".golangci.toml", ".golangci.json"},
+		packages:    map[string][]string{DependencyTypeGolang: {"github.com/golangci/golangci-lint", "github.com/golangci/golangci-lint/v2"}},
+		hooks:       []string{"golangci-lint", "golangci-lint-full", "golangci-lint-config-verify"},
+		actions:     []string{"golangci/golangci-lint-action"},
+	},
+	"phpstan": {
+		tech:        "phpstan",
+		kinds:       kindsLinter,
+		configFiles: []string{"phpstan.neon", "phpstan.neon.dist", "phpstan.dist.neon"},
+		packages:    map[string][]string{DependencyTypePHP: {"phpstan/phpstan"}},
+	},
+	"php-cs-fixer": {
+		tech:        "phpcsfixer",
+		kinds:       kindsFormatter,
+		configFiles: []string{".php-cs-fixer.php", ".php-cs-fixer.dist.php"},
+		packages:    map[string][]string{DependencyTypePHP: {"friendsofphp/php-cs-fixer"}},
+	},
+	"shellcheck": {
+		tech:        "shellcheck",
+		kinds:       kindsLinter,
+		configFiles: []string{".shellcheckrc"},
+		packages:    map[string][]string{DependencyTypePython: {"shellcheck-py"}},
+		hooks:       []string{"shellcheck"},
+		actions:     []string{"ludeeus/action-shellcheck"},
+	},
+	"hadolint": {
+		tech:        "hadolint",
+		kinds:       kindsLinter,
+		configFiles: []string{".hadolint.yaml", ".hadolint.yml"},
+		hooks:       []string{"hadolint", "hadolint-docker"},
+		actions:     []string{"hadolint/hadolint-action"},
+	},
+	"clang-format": {
+		tech:        "clangformat",
+		kinds:       kindsFormatter,
+		configFiles: []string{".clang-format", "_clang-format"},
+		packages:    map[string][]string{DependencyTypePython: {"clang-format"}},
+		hooks:       []string{"clang-format"},
+	},
+	"swiftlint": {
+		tech:        "swiftlint",
+		kinds:       kindsLinter,
+		configFiles: []string{".swiftlint.yml", ".swiftlint.yaml"},
+		packages:    map[string][]string{DependencyTypeCocoapods: {"SwiftLint"}},
+		hooks:       []string{"swiftlint"},
+	},
+}
+
+// codeQualityIndex holds lookups from config files, packages, hooks, actions, and config keys to tool names
+type codeQualityIndex struct {
+	configFiles map[string]string
+	packages    map[string]string // "type:name" -> tool
+	hooks       map[string]string
+	actions     map[string]string
+	configKeys  map[string]string
+}
+
+var codeQualityLookup = buildCodeQualityIndex()
+
+func buildCodeQualityIndex() codeQualityIndex {
+	index := codeQualityIndex{
+		configFiles: make(map[string]string),
+		packages:    make(map[string]string),
+		hooks:       make(map[string]string),
+		actions:     make(map[string]string),
+		configKeys:  make(map[string]string),
+	}
+	for name, spec := range codeQualityTools {
+		for _, file := range spec.configFiles {
+			index.configFiles[file] = name
+		}
+		for depType, packages := range spec.packages {
+			for _, pkg := range packages {
+				index.packages[depType+":"+pkg] = name
+			}
+		}
+		for _, hook := range spec.hooks {
+			index.hooks[hook] = name
+		}
+		for _, action := range spec.actions {
+			index.actions[strings.ToLower(action)] = name
+		}
+		for _, key := range spec.configKeys {
+			index.configKeys[key] = name
+		}
+	}
+	return index
+}
+
+// CodeQualityParser handles detection of formatter and linter configuration and versions
+type CodeQualityParser struct{}
+
+// NewCodeQualityParser creates a new code quality tooling parser
+func NewCodeQualityParser() *CodeQualityParser {
+	return &CodeQualityParser{}
+}
+
+// CodeQualityTool is an inventoried formatter or linter with its configuration and pinned version
+type CodeQualityTool struct {
+	Name          string   `json:"name"`
+	Tech          string   `json:"tech"`
+	Kinds         []string `json:"kinds"`                  // formatter, linter
+	ConfigFiles   []string `json:"config_files,omitempty"` // Dedicated config files and manifests with embedded configuration
+	Version       string   `json:"version,omitempty"`
+	VersionSource string   `json:"version_source,omitempty"` // Manifest, .pre-commit-config.yaml, or CI workflow declaring the version
+}
+
+// CodeQualityVersion is a tool version declared by a manifest, pre-commit hook, or CI step
+type CodeQualityVersion struct {
+	Tool    string
+	Version string
+}
+
+// ToolForConfigFile returns the tool configured by a file name, or ""
+func (p *CodeQualityParser) ToolForConfigFile(name string) string {
+	return codeQualityLookup.configFiles[name]
+}
+
+// ToolForDependency returns the tool installed by a dependency, or ""
+func (p *CodeQualityParser) ToolForDependency(dep types.Dependency) string {
+	return codeQualityLookup.packages[dep.Type+":"+dep.Name]
+}
+
+// ParsePackageJSONConfig returns the tools configured inline in package.json ("prettier", "eslintConfig")
+func (p *CodeQualityParser) ParsePackageJSONConfig(content string) []string {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(content), &raw); err != nil {
+		return nil
+	}
+	var tools []string
+	for key := range raw {
+		if tool, ok := codeQualityLookup.configKeys[key]; ok {
+			tools = append(tools, tool)
+		}
+	}
+	return sortedUnique(tools)
+}
+
+// ParsePyproject returns the tools configured by [tool.*] sections of a pyproject.toml and
+// the versions of tools declared as dependencies (PEP 621 / PEP 735 lists or Poetry tables)
+func (p *CodeQualityParser) ParsePyproject(content string) ([]string, []CodeQualityVersion) {
+	pythonParser := NewPythonParser()
+	var tools []string
+	var versions []CodeQualityVersion
+	section := ""
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && !strings.HasPrefix(line, "[\"") && !strings.HasPrefix(line, "['") {
+			section = line
+			if match := pyprojectToolSectionRegex.FindStringSubmatch(line); match != nil {
+				if tool, ok := codeQualityLookup.configKeys[match[1]]; ok {
+					tools = append(tools, tool)
+				}
+			}
+			continue
+		}
+		// Poetry: black = "^24.1" or black = { version = "^24.1" } in a dependencies table
+		if strings.Contains(section, "dependencies") {
+			if match := pyprojectPoetryDepRegex.FindStringSubmatch(line); match != nil {
+				name := pythonParser.canonPackageName(match[1])
+				if tool := codeQualityLookup.packages[DependencyTypePython+":"+name]; tool != "" {
+					versions = append(versions, CodeQualityVersion{Tool: tool, Version: match[2] + match[3]})
+					continue
+				}
+			}
+		}
+		// PEP 508 requirement strings: "ruff>=0.5", inline or one per line
+		for _, match := range pyprojectQuotedRegex.FindAllStringSubmatch(line, -1) {
+			dep, err := pythonParser.parsePEP508Dependency(match[1] + match[2])
+			if err != nil {
+				continue
+			}
+			if tool := codeQualityLookup.packages[DependencyTypePython+":"+pythonParser.canonPackageName(dep.Name)]; tool != "" {
+				versions = append(versions, CodeQualityVersion{Tool: tool, Version: dep.Constraint})
+			}
+		}
+	}
+	return sortedUnique(tools), versions
+}
+
+// preCommitConfig represents the subset of .pre-commit-config.yaml we use
+type preCommitConfig struct {
+	Repos []struct {
+		Repo  string `yaml:"repo"`
+		Rev   string `yaml:"rev"`
+		Hooks []struct {
+			ID string `yaml:"id"`
+		} `yaml:"hooks"`
+	} `yaml:"repos"`
+}
+
+// ParsePreCommitConfig returns the tools run by pre-commit hooks with the pinned repository revision
+func (p *CodeQualityParser) ParsePreCommitConfig(content string) ([]CodeQualityVersion, error) {
+	var config preCommitConfig
+	if err := yaml.Unmarshal([]byte(content), &config); err != nil {
+		return nil, err
+	}
+	var versions []CodeQualityVersion
+	for _, repo := range config.Repos {
+		for _, hook := range repo.Hooks {
+			if tool, ok := codeQualityLookup.hooks[hook.ID]; ok {
+				versions = append(versions, CodeQualityVersion{Tool: tool, Version: repo.Rev})
+			}
+		}
+	}
+	return versions, nil
+}
+
+// WorkflowToolVersions returns the tools run by GitHub Actions steps. The version is taken from
+// the step's "version" input, falling back to the action reference (psf/black@24.1.0).
+func (p *CodeQualityParser) WorkflowToolVersions(workflow *GitHubActionsWorkflow) []CodeQualityVersion {
+	var versions []CodeQualityVersion
+	jobNames := make([]string, 0, len(workflow.Jobs))
+	for name := range workflow.Jobs {
+		jobNames = append(jobNames, name)
+	}
+	sort.Strings(jobNames)
+
+	for _, name := range jobNames {
+		for _, step := range workflow.Jobs[name].Steps {
+			action, ref, _ := strings.Cut(step.Uses, "@")
+			tool, ok := codeQualityLookup.actions[strings.ToLower(action)]
+			if !ok {
+				continue
+			}
+			version, _ := step.With["version"].(string)
+			if version == "" && tool == "black" {
+				version = ref // psf/black releases are tagged with the Black version
+			}
+			versions = append(versions, CodeQualityVersion{Tool: tool, Version: version})
+		}
+	}
+	return versions
+}
+
+// CodeQualityInventory collects the formatters and linters found in a directory
+type CodeQualityInventory struct {
+	tools map[string]*CodeQualityTool
+}
+
+// NewCodeQualityInventory creates an empty inventory
+func NewCodeQualityInventory() *CodeQualityInventory {
+	return &CodeQualityInventory{tools: make(map[string]*CodeQualityTool)}
+}
+
+// AddConfigFile records a config file (or manifest with embedded configuration) of a tool
+func (inv *CodeQualityInventory) AddConfigFile(tool, file string) {
+	if entry := inv.entry(tool); entry != nil && !containsString(entry.ConfigFiles, file) {
+		entry.ConfigFiles = append(entry.ConfigFiles, file)
+	}
+}
+
+// AddVersion records the declared version of a tool. The first declared version is kept, so
+// manifests should be added before pre-commit and CI configuration.
+func (inv *CodeQualityInventory) AddVersion(tool, version, source string) {
+	entry := inv.entry(tool)
+	if entry == nil || (entry.Version != "" && version != "") {
+		return
+	}
+	if version != "" || entry.VersionSource == "" {
+		entry.Version = version
+		entry.VersionSource = source
+	}
+}
+
+// Tools returns the collected tools sorted by name
+func (inv *CodeQualityInventory) Tools() []*CodeQualityTool {
+	tools := make([]*CodeQualityTool, 0, len(inv.tools))
+	for _, tool := range inv.tools {
+		sort.Strings(tool.ConfigFiles)
+		tools = append(tools, tool)
+	}
+	sort.Slice(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name })
+	return tools
+}
+
+func (inv *CodeQualityInventory) entry(tool string) *CodeQualityTool {
+	spec, ok := codeQualityTools[tool]
+	if !ok {
+		return nil
+	}
+	if _, exists := inv.tools[tool]; !exists {
+		inv.tools[tool] = &CodeQualityTool{Name: tool, Tech: spec.tech, Kinds: spec.kinds}
+	}
+	return inv.tools[tool]
+}
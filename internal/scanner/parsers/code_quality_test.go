@@ -0,0 +1,130 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeQualityParser_ToolLookups(t *testing.T) {
+	parser := NewCodeQualityParser()
+
+	assert.Equal(t, "prettier", parser.ToolForConfigFile(".prettierrc.yaml"))
+	assert.Equal(t, "golangci-lint", parser.ToolForConfigFile(".golangci.yml"))
+	assert.Equal(t, "", parser.ToolForConfigFile("package.json"))
+
+	assert.Equal(t, "eslint", parser.ToolForDependency(types.Dependency{Type: DependencyTypeNpm, Name: "eslint"}))
+	assert.Equal(t, "rubocop", parser.ToolForDependency(types.Dependency{Type: DependencyTypeRuby, Name: "rubocop"}))
+	assert.Equal(t, "", parser.ToolForDependency(types.Dependency{Type: DependencyTypePython, Name: "eslint"}))
+}
+
+func TestCodeQualityParser_ParsePackageJSONConfig(t *testing.T) {
+	content := `{"name": "web", "prettier": {"semi": false}, "eslintConfig": {"extends": "next"}}`
+	assert.Equal(t, []string{"eslint", "prettier"}, NewCodeQualityParser().ParsePackageJSONConfig(content))
+	assert.Nil(t, NewCodeQualityParser().ParsePackageJSONConfig(`{`))
+}
+
+func TestCodeQualityParser_ParsePyproject(t *testing.T) {
+	content := `[project]
+name = "api"
+dependencies = ["fastapi>=0.110"]
+
+[project.optional-dependencies]
+dev = [
+    "ruff==0.5.0",
+    "mypy>=1.10",
+]
+
+[tool.poetry.group.dev.dependencies]
+black = "^24.1"
+isort = { version = "5.13.2", extras = ["colors"] }
+
+[tool.ruff]
+line-length = 100
+
+[tool.mypy]
+strict = true
+`
+	configured, versions := NewCodeQualityParser().ParsePyproject(content)
+
+	assert.Equal(t, []string{"mypy", "ruff"}, configured)
+	assert.Equal(t, []CodeQualityVersion{
+		{Tool: "ruff", Version: "==0.5.0"},
+		{Tool: "mypy", Version: ">=1.10"},
+		{Tool: "black", Version: "^24.1"},
+		{Tool: "isort", Version: "5.13.2"},
+	}, versions)
+}
+
+func TestCodeQualityParser_ParsePreCommitConfig(t *testing.T) {
+	content := `repos:
+  - repo: https://github.com/astral-sh/ruff-pre-commit
+    rev: v0.5.0
+    hooks:
+      - id: ruff
+      - id: ruff-format
+  - repo: https://github.com/pre-commit/pre-commit-hooks
+    rev: v4.6.0
+    hooks:
+      - id: trailing-whitespace
+  - repo: https://github.com/golangci/golangci-lint
+    rev: v1.59.1
+    hooks:
+      - id: golangci-lint
+`
+	versions, err := NewCodeQualityParser().ParsePreCommitConfig(content)
+	require.NoError(t, err)
+	assert.Equal(t, []CodeQualityVersion{
+		{Tool: "ruff", Version: "v0.5.0"},
+		{Tool: "ruff", Version: "v0.5.0"},
+		{Tool: "golangci-lint", Version: "v1.59.1"},
+	}, versions)
+
+	_, err = NewCodeQualityParser().ParsePreCommitConfig("repos: [")
+	assert.Error(t, err)
+}
+
+func TestCodeQualityParser_WorkflowToolVersions(t *testing.T) {
+	workflow, err := NewGitHubActionsParser().ParseWorkflow(`jobs:
+  lint:
+    steps:
+      - uses: actions/checkout@v4
+      - uses: golangci/golangci-lint-action@v6
+        with:
+          version: v1.59
+      - uses: psf/black@24.4.2
+      - uses: hadolint/hadolint-action@v3.1.0
+`)
+	require.NoError(t, err)
+
+	assert.Equal(t, []CodeQualityVersion{
+		{Tool: "golangci-lint", Version: "v1.59"},
+		{Tool: "black", Version: "24.4.2"},
+		{Tool: "hadolint", Version: ""},
+	}, NewCodeQualityParser().WorkflowToolVersions(workflow))
+}
+
+func TestCodeQualityInventory(t *testing.T) {
+	inventory := NewCodeQualityInventory()
+	inventory.AddConfigFile("ruff", "/ruff.toml")
+	inventory.AddConfigFile("ruff", "/ruff.toml")
+	inventory.AddVersion("ruff", "", "/.github/workflows/ci.yml")
+	inventory.AddVersion("ruff", "==0.5.0", "/pyproject.toml")
+	inventory.AddVersion("ruff", "v0.4.0", "/.pre-commit-config.yaml")
+	inventory.AddVersion("black", "^24.1", "/pyproject.toml")
+	inventory.AddConfigFile("unknown", "/unknown.cfg")
+
+	tools := inventory.Tools()
+	require.Len(t, tools, 2)
+	assert.Equal(t, &CodeQualityTool{Name: "black", Tech: "black", Kinds: []string{CodeQualityKindFormatter}, Version: "^24.1", VersionSource: "/pyproject.toml"}, tools[0])
+	assert.Equal(t, &CodeQualityTool{
+		Name:          "ruff",
+		Tech:          "ruff",
+		Kinds:         []string{CodeQualityKindFormatter, CodeQualityKindLinter},
+		ConfigFiles:   []string{"/ruff.toml"},
+		Version:       "==0.5.0",
+		VersionSource: "/pyproject.toml",
+	}, tools[1])
+}
@@ -0,0 +1,84 @@
+package parsers
+
+import (
+	"sort"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// ParseComposerLockOptions contains configuration options for ParseComposerLockDependencies
+type ParseComposerLockOptions struct {
+	IncludeTransitive bool // Include transitive dependencies (default: false for direct dependencies only)
+}
+
+// ParseComposerLockDependencies returns the dependencies of composer.json with the versions
+// installed by composer.lock. Direct dependencies are the packages of require (prod scope) and
+// require-dev (dev scope); platform requirements (php, ext-*) and packages missing from the lock
+// keep the constraint of composer.json. Transitive dependencies are the other locked packages,
+// with the dev scope for packages-dev. Returns nil if the lock cannot be parsed.
+func (p *PHPParser) ParseComposerLockDependencies(lockContent, composerJSONContent string, options ParseComposerLockOptions) []types.Dependency {
+	packages, err := p.ParseComposerLock(lockContent)
+	if err != nil {
+		return nil
+	}
+	installed := make(map[string]ComposerLockPackage, len(packages))
+	for _, pkg := range packages {
+		installed[pkg.Name] = pkg
+	}
+
+	// Direct dependencies, production before dev, by name
+	_, _, declared := p.ParseComposerJSON(composerJSONContent)
+	sort.SliceStable(declared, func(i, j int) bool {
+		if declared[i].Scope != declared[j].Scope {
+			return declared[i].Scope == types.ScopeProd
+		}
+		return declared[i].Name < declared[j].Name
+	})
+
+	var dependencies []types.Dependency
+	direct := make(map[string]bool, len(declared))
+	for _, dep := range declared {
+		direct[dep.Name] = true
+		pkg, ok := installed[dep.Name]
+		if !ok {
+			dependencies = append(dependencies, dep)
+			continue
+		}
+		locked := composerLockDependency(pkg, dep.Scope, true)
+		RecordLockOrigin(&locked, MetadataSourceComposerJSON, MetadataSourceComposerLock, dep.Version)
+		dependencies = append(dependencies, locked)
+	}
+	if !options.IncludeTransitive {
+		return NormalizeDependencyVersions(dependencies)
+	}
+
+	// Transitive dependencies in lock file order
+	for _, pkg := range packages {
+		if direct[pkg.Name] {
+			continue
+		}
+		scope := types.ScopeProd
+		if pkg.Dev {
+			scope = types.ScopeDev
+		}
+		dependencies = append(dependencies, composerLockDependency(pkg, scope, false))
+	}
+	return NormalizeDependencyVersions(dependencies)
+}
+
+// composerLockDependency returns the dependency of a package installed by composer.lock
+func composerLockDependency(pkg ComposerLockPackage, scope types.Scope, direct bool) types.Dependency {
+	metadata := types.NewMetadata(MetadataSourceComposerLock)
+	if pkg.License != "" {
+		metadata[MetadataLicenseDeclared] = pkg.License
+	}
+	return types.Dependency{
+		Type:       DependencyTypePHP,
+		Name:       pkg.Name,
+		Version:    pkg.Version,
+		SourceFile: MetadataSourceComposerLock,
+		Scope:      scope,
+		Direct:     direct,
+		Metadata:   metadata,
+	}
+}
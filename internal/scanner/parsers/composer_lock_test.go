@@ -0,0 +1,57 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseComposerLockDependencies(t *testing.T) {
+	composerJSON := `{
+	"name": "acme/shop",
+	"require": {"php": "^8.2", "symfony/console": "^7.0", "laravel/framework": "^11.0"},
+	"require-dev": {"phpunit/phpunit": "^10.5"}
+}`
+	lock := `{
+	"packages": [
+		{"name": "laravel/framework", "version": "v11.9.2", "license": ["MIT"]},
+		{"name": "symfony/console", "version": "v7.0.4"},
+		{"name": "symfony/string", "version": "v7.0.4"}
+	],
+	"packages-dev": [
+		{"name": "phpunit/phpunit", "version": "10.5.10", "license": ["BSD-3-Clause"]},
+		{"name": "sebastian/diff", "version": "5.1.0"}
+	]
+}`
+	parser := NewPHPParser()
+
+	deps := parser.ParseComposerLockDependencies(lock, composerJSON, ParseComposerLockOptions{})
+	require.Len(t, deps, 4)
+	assert.Equal(t, []string{"laravel/framework", "php", "symfony/console", "phpunit/phpunit"}, []string{deps[0].Name, deps[1].Name, deps[2].Name, deps[3].Name},
+		"production before dev, by name")
+
+	framework := deps[0]
+	assert.Equal(t, "11.9.2", framework.Version)
+	assert.Equal(t, types.ScopeProd, framework.Scope)
+	assert.True(t, framework.Direct)
+	assert.Equal(t, MetadataSourceComposerLock, framework.Metadata["source"])
+	assert.Equal(t, "MIT", framework.Metadata[MetadataLicenseDeclared])
+	assert.Len(t, framework.Metadata[MetadataOrigin], 4, "range, scope, version, license")
+
+	assert.Equal(t, "^8.2", deps[1].Version, "platform requirement keeps the constraint")
+	assert.Equal(t, MetadataSourceComposerJSON, deps[1].Metadata["source"])
+
+	assert.Equal(t, "10.5.10", deps[3].Version)
+	assert.Equal(t, types.ScopeDev, deps[3].Scope)
+
+	all := parser.ParseComposerLockDependencies(lock, composerJSON, ParseComposerLockOptions{IncludeTransitive: true})
+	require.Len(t, all, 6)
+	assert.Equal(t, types.Dependency{Type: DependencyTypePHP, Name: "symfony/string", Version: "7.0.4", SourceFile: MetadataSourceComposerLock, Scope: types.ScopeProd, Metadata: types.NewMetadata(MetadataSourceComposerLock)}, all[4])
+	assert.Equal(t, "sebastian/diff", all[5].Name)
+	assert.Equal(t, types.ScopeDev, all[5].Scope)
+	assert.False(t, all[5].Direct)
+
+	assert.Nil(t, parser.ParseComposerLockDependencies("not json", composerJSON, ParseComposerLockOptions{}))
+}
@@ -59,7 +59,7 @@ func (p *ConanParser) ExtractDependencies(content string) []types.Dependency {
 }
 
 // parseListDependencies extracts dependencies from list-style declarations (requires = [...])
-func (p *ConanParser) parseListDependencies(content string, listRegex *regexp.Regexp, scope string) []types.Dependency {
+func (p *ConanParser) parseListDependencies(content string, listRegex *regexp.Regexp, scope types.Scope) []types.Dependency {
 	var dependencies []types.Dependency
 	listMatches := listRegex.FindAllStringSubmatch(content, -1)
 	for _, match := range listMatches {
@@ -77,7 +77,7 @@ func (p *ConanParser) parseListDependencies(content string, listRegex *regexp.Re
 }
 
 // ParseConanDependency parses a Conan dependency string in format "name/version" or "name/version/user/channel#build"
-func (p *ConanParser) ParseConanDependency(depString string, scope string) types.Dependency {
+func (p *ConanParser) ParseConanDependency(depString string, scope types.Scope) types.Dependency {
 	parts := strings.Split(depString, "/")
 	if len(parts) >= 2 {
 		name := parts[0]
@@ -26,7 +26,12 @@ const (
 
 	// JVM ecosystem
 	DependencyTypeMaven  = "maven"
+	DependencyTypeJar    = "jar" // Jars without Maven coordinates found in binary artifacts
 	DependencyTypeGradle = "gradle"
+	DependencyTypeIvy    = "ivy"
+	DependencyTypeAnt    = "ant"  // Jar files referenced by Ant build files
+	DependencyTypeOSGi   = "osgi" // Bundles and packages required by OSGi bundle manifests
+	DependencyTypeP2     = "p2"   // Installable units (bundles, features) of Eclipse p2 repositories
 
 	// PHP ecosystem
 	DependencyTypePHP = "php"
@@ -35,7 +40,8 @@ const (
 	DependencyTypeDotnet = "dotnet"
 
 	// C/C++ ecosystem
-	DependencyTypeConan = "conan"
+	DependencyTypeConan         = "conan"
+	DependencyTypeSharedLibrary = "sharedLibrary" // Shared libraries required by native executables (DT_NEEDED, LC_LOAD_DYLIB)
 
 	// iOS/macOS ecosystem
 	DependencyTypeCocoapods = "cocoapods"
@@ -46,9 +52,54 @@ const (
 	// CI/CD
 	DependencyTypeGitHubAction = "githubAction"
 
+	// Version control
+	DependencyTypeGitSubmodule = "gitSubmodule" // Repositories vendored as git submodules (.gitmodules)
+	DependencyTypeVendored     = "vendored"     // Source trees of projects copied into the repository (third_party/, git subtree)
+
 	// Containers
 	DependencyTypeDocker = "docker"
 
+	// Protobuf modules (Buf Schema Registry)
+	DependencyTypeBuf = "buf"
+
+	// Ansible roles and collections (Ansible Galaxy)
+	DependencyTypeAnsible = "ansible"
+
+	// OCaml
+	DependencyTypeOpam = "opam"
+
+	// Erlang
+	DependencyTypeHex = "hex" // Hex packages and git sources of rebar3 projects
+
+	// Fortran and CMake
+	DependencyTypeFpm   = "fpm"   // Fortran Package Manager packages, metapackages, and linked libraries
+	DependencyTypeCMake = "cmake" // Packages found by CMake find_package
+
+	// Smart contracts
+	DependencyTypeFoundry = "foundry" // Foundry libraries (git submodules of lib/) and Soldeer packages
+
+	// D
+	DependencyTypeDub = "dub" // dub packages (dub.json, dub.sdl)
+
+	// Lua
+	DependencyTypeLuarocks = "luarocks" // LuaRocks rocks (.rockspec)
+
+	// Crystal and Nim
+	DependencyTypeShards = "shards" // Crystal shards (shard.yml)
+	DependencyTypeNimble = "nimble" // Nim packages (.nimble)
+
+	// Zig and V
+	DependencyTypeZig = "zig" // Zig packages (build.zig.zon)
+	DependencyTypeVpm = "vpm" // V modules (v.mod)
+
+	// Game engines
+	DependencyTypeUnity  = "unity"  // Unity packages (Packages/manifest.json)
+	DependencyTypeUnreal = "unreal" // Unreal Engine plugins
+
+	// Embedded
+	DependencyTypePlatformIO = "platformio" // PlatformIO libraries, platforms, and platform packages
+	DependencyTypeArduino    = "arduino"    // Arduino libraries (library.properties depends)
+
 	// Other
 	DependencyTypeDelphi = "delphi"
 )
@@ -57,17 +108,20 @@ const (
 // These constants ensure consistency across all parsers and prevent typos.
 const (
 	// JavaScript/TypeScript ecosystem
-	MetadataSourcePackageJSON = "package.json"
-	MetadataSourcePackageLock = "package-lock.json"
-	MetadataSourceYarnLock    = "yarn.lock"
-	MetadataSourcePnpmLock    = "pnpm-lock.yaml"
-	MetadataSourceDenoJSON    = "deno.json"
-	MetadataSourceDenoLock    = "deno.lock"
+	MetadataSourcePackageJSON   = "package.json"
+	MetadataSourcePackageLock   = "package-lock.json"
+	MetadataSourceYarnLock      = "yarn.lock"
+	MetadataSourcePnpmLock      = "pnpm-lock.yaml"
+	MetadataSourcePnpmWorkspace = "pnpm-workspace.yaml"
+	MetadataSourceDenoJSON      = "deno.json"
+	MetadataSourceDenoLock      = "deno.lock"
 
 	// Python ecosystem
 	MetadataSourceRequirementsTxt = "requirements.txt"
 	MetadataSourcePipfile         = "Pipfile"
+	MetadataSourcePipfileLock     = "Pipfile.lock"
 	MetadataSourcePoetryLock      = "poetry.lock"
+	MetadataSourcePyprojectToml   = "pyproject.toml"
 
 	// Ruby ecosystem
 	MetadataSourceGemfile     = "Gemfile"
@@ -84,6 +138,10 @@ const (
 	// JVM ecosystem
 	MetadataSourcePomXML      = "pom.xml"
 	MetadataSourceBuildGradle = "build.gradle"
+	MetadataSourceIvyXML      = "ivy.xml"
+	MetadataSourceBuildXML    = "build.xml"
+	MetadataSourceManifestMF  = "MANIFEST.MF"
+	MetadataSourceTarget      = ".target"
 
 	// PHP ecosystem
 	MetadataSourceComposerJSON = "composer.json"
@@ -113,4 +171,181 @@ const (
 	// Containers
 	MetadataSourceDockerfile    = "Dockerfile"
 	MetadataSourceDockerCompose = "docker-compose.yml"
+
+	// Protobuf
+	MetadataSourceBufYAML = "buf.yaml"
+	MetadataSourceBufLock = "buf.lock"
+	MetadataSourceProto   = ".proto"
+
+	// Ansible
+	MetadataSourceAnsibleRequirements = "requirements.yml"
+	MetadataSourceGalaxyYAML          = "galaxy.yml"
+
+	// Erlang ecosystem
+	MetadataSourceRebarConfig = "rebar.config"
+	MetadataSourceRebarLock   = "rebar.lock"
+
+	// OCaml ecosystem
+	MetadataSourceOpam        = ".opam"
+	MetadataSourceDuneProject = "dune-project"
+
+	// Fortran and CMake
+	MetadataSourceFpmToml    = "fpm.toml"
+	MetadataSourceCMakeLists = "CMakeLists.txt"
+
+	// Smart contracts
+	MetadataSourceFoundryToml = "foundry.toml"
+	MetadataSourceFoundryLock = "foundry.lock"
+	MetadataSourceGitmodules  = ".gitmodules"
+
+	// Vendored projects identified from git history only
+	MetadataSourceGitSubtree = "git-subtree"
+
+	// D ecosystem
+	MetadataSourceDubJSON       = "dub.json"
+	MetadataSourceDubSDL        = "dub.sdl"
+	MetadataSourceDubSelections = "dub.selections.json"
+
+	// Lua ecosystem
+	MetadataSourceRockspec     = ".rockspec"
+	MetadataSourceLuarocksLock = "luarocks.lock"
+
+	// Crystal and Nim
+	MetadataSourceShardYML  = "shard.yml"
+	MetadataSourceShardLock = "shard.lock"
+	MetadataSourceNimble    = ".nimble"
+
+	// Zig and V
+	MetadataSourceBuildZigZon = "build.zig.zon"
+	MetadataSourceVMod        = "v.mod"
+
+	// Game engines
+	MetadataSourceUnityManifest = "Packages/manifest.json"
+	MetadataSourceUProject      = ".uproject"
+	MetadataSourceUPlugin       = ".uplugin"
+
+	// Embedded
+	MetadataSourcePlatformIOIni     = "platformio.ini"
+	MetadataSourceLibraryProperties = "library.properties"
+
+	// Development environments
+	MetadataSourceDevcontainer = "devcontainer.json"
+	MetadataSourceGitpod       = ".gitpod.yml"
+)
+
+// License metadata keys of dependencies, following SPDX declared/concluded semantics
+const (
+	MetadataLicenseDeclared  = "license_declared"  // License declared by the package (package-lock.json, composer.lock)
+	MetadataLicenseConcluded = "license_concluded" // License reported by the package registry for the version
+	MetadataLicenseMismatch  = "license_mismatch"  // True if the declared and concluded licenses differ
+	MetadataAuthors          = "authors"           // Package author names (copyright holders)
+)
+
+// Build plugin metadata keys of dependencies
+const (
+	MetadataPlugin  = "plugin"  // True for build plugins (Maven <build><plugins>, Gradle plugins {} and buildscript classpath)
+	MetadataApplied = "applied" // False for Gradle plugins declared with "apply false"
+)
+
+// Maven profile metadata keys of dependencies
+const (
+	MetadataProfile           = "profile"            // ID of the Maven profile declaring the dependency
+	MetadataProfileActivation = "profile_activation" // Activation conditions of the profile (activeByDefault, jdk, os.family, property, file.exists, ...)
+)
+
+// MetadataOSGiHeader is the OSGi manifest header declaring a dependency (Require-Bundle,
+// Import-Package, Fragment-Host)
+const MetadataOSGiHeader = "header"
+
+// MetadataIncludedBuild is the path of the Gradle included build substituting a dependency in a
+// composite build, making it an internal project edge instead of an external artifact
+const MetadataIncludedBuild = "included_build"
+
+// MetadataArtifact is the binary artifact (jar, war, Go binary, .NET assembly) a dependency
+// was read from
+const MetadataArtifact = "artifact"
+
+// MetadataLayer is the container image layer that introduced the file a dependency was read
+// from (index, digest, and Dockerfile instruction)
+const MetadataLayer = "layer"
+
+// MetadataVendoredFork flags dependencies whose source tree is copied into the repository
+const MetadataVendoredFork = "vendored-fork"
+
+// Pipfile and Pipfile.lock metadata keys of dependencies
+const (
+	MetadataPipfileHashes   = "hashes"   // Hashes of the distributions pinned by Pipfile.lock
+	MetadataPipfileMarkers  = "markers"  // Environment marker of the requirement (PEP 508)
+	MetadataPipfileExtras   = "extras"   // Extras of the package installed
+	MetadataPipfileIndex    = "index"    // Name of the package index ([[source]]) the package is installed from
+	MetadataPipfileGit      = "git"      // Repository of a VCS requirement
+	MetadataPipfileRef      = "ref"      // Revision of a VCS requirement (the commit in Pipfile.lock)
+	MetadataPipfilePath     = "path"     // Directory or file of a local requirement
+	MetadataPipfileEditable = "editable" // True for editable (development mode) installs
+)
+
+// requirements.txt metadata keys of dependencies
+const (
+	MetadataRequirementsMarkers        = "markers"           // Environment marker of the requirement (PEP 508)
+	MetadataRequirementsExtras         = "extras"            // Extras of the package installed
+	MetadataRequirementsEditable       = "editable"          // True for editable (development mode) installs (-e)
+	MetadataRequirementsVCS            = "vcs"               // Version control system of a VCS requirement (git, hg, svn, bzr)
+	MetadataRequirementsURL            = "url"               // Repository of a VCS requirement, or URL of an archive
+	MetadataRequirementsRef            = "ref"               // Revision of a VCS requirement (branch, tag, or commit)
+	MetadataRequirementsPath           = "path"              // Directory or file of a local requirement
+	MetadataRequirementsFile           = "requirements_file" // Included requirements file (-r) declaring the requirement
+	MetadataRequirementsConstraint     = "constraint"        // Specifier of the constraints file (-c) for the package
+	MetadataRequirementsConstraintFile = "constraint_file"   // Constraints file (-c) restricting the version
+)
+
+// pyproject.toml (PEP 621) metadata keys of dependencies
+const (
+	MetadataPyprojectMarkers = "markers" // Environment marker of the requirement (PEP 508)
+	MetadataPyprojectExtras  = "extras"  // Extras of the package installed
+	MetadataPyprojectExtra   = "extra"   // Extra of the project (project.optional-dependencies) requiring the package
+	MetadataPyprojectURL     = "url"     // Direct reference of the requirement (name @ url)
+)
+
+// Cargo metadata keys of dependencies
+const (
+	MetadataCargoFeatures        = "features"         // Features of the dependency the crate enables (declared, inherited from the workspace, enabled by the default features)
+	MetadataCargoDefaultFeatures = "default_features" // False if the default features of the dependency are disabled
+	MetadataCargoEnabledBy       = "enabled_by"       // Features of the crate enabling an optional dependency
+	MetadataCargoPath            = "path"             // Directory of a local (path) dependency, relative to the crate
+	MetadataCargoWorkspace       = "workspace"        // True for dependencies inherited from [workspace.dependencies]
+	MetadataCargoAlias           = "alias"            // Name the crate uses for a renamed dependency (package = "...")
+)
+
+// Install script metadata keys of npm dependencies
+const (
+	MetadataInstallScript = "install_script" // True for packages running scripts when installed (hasInstallScript, requiresBuild)
+	MetadataInstallHooks  = "install_hooks"  // Install lifecycle hooks declared by the version (preinstall, install, postinstall), from registry data
+)
+
+// pnpm metadata keys of npm dependencies
+const (
+	MetadataPnpmCatalog = "catalog" // Catalog of pnpm-workspace.yaml a catalog: range refers to
+)
+
+// Registry metadata keys of dependencies, captured with registry enrichment
+const (
+	MetadataMaintainers = "maintainers" // Number of maintainers of the package (npm maintainers, crates.io and RubyGems owners)
+	MetadataPublisher   = "publisher"   // Publishing organization (npm scope, crates.io team, else repository owner)
+	MetadataRepository  = "repository"  // Source repository URL reported by the registry
+)
+
+// Deprecation metadata keys of dependencies, concluded with registry enrichment
+const (
+	MetadataDeprecated         = "deprecated"          // What is deprecated or yanked: "package" (the latest version) or "version" (the version used)
+	MetadataDeprecationMessage = "deprecation_message" // Registry deprecation message ("yanked" with the reason for yanked releases)
+	DeprecatedPackage          = "package"
+	DeprecatedVersion          = "version"
+)
+
+// Go module metadata keys of dependencies
+const (
+	MetadataGoModule     = "module"        // Module path without the major version suffix (github.com/foo/bar for github.com/foo/bar/v2)
+	MetadataMajorVersion = "major_version" // Major version from the path suffix (/v2, gopkg.in .v3), else from the required version
+	MetadataPlatforms    = "platforms"     // GOOS/GOARCH of the only source files importing the module (*_windows.go, //go:build linux)
+	MetadataGoSumHash    = "hash"          // Hash of the module content recorded in go.sum (h1:...)
 )
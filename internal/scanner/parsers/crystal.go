@@ -0,0 +1,217 @@
+package parsers
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+// shardHosts maps the shorthand resolvers of shard.yml to their repository URL prefix
+var shardHosts = map[string]string{
+	"github":    "https://github.com/",
+	"gitlab":    "https://gitlab.com/",
+	"bitbucket": "https://bitbucket.org/",
+	"codeberg":  "https://codeberg.org/",
+}
+
+// Shard represents a Crystal shard specification (shard.yml)
+type Shard struct {
+	Name                    string
+	Version                 string
+	Crystal                 string // Supported Crystal versions
+	License                 string
+	Targets                 []string
+	Dependencies            []ShardDependency
+	DevelopmentDependencies []ShardDependency
+}
+
+// ShardDependency is a dependency of shard.yml: a repository (github, gitlab, bitbucket, git,
+// hg, fossil) with an optional version requirement or git ref, or a local path
+type ShardDependency struct {
+	Name    string
+	URL     string
+	Path    string
+	Version string
+	Branch  string
+	Tag     string
+	Commit  string
+}
+
+// CrystalParser handles Crystal shard specifications and lock files
+type CrystalParser struct{}
+
+// NewCrystalParser creates a new Crystal parser
+func NewCrystalParser() *CrystalParser {
+	return &CrystalParser{}
+}
+
+// ParseShardYML parses a shard.yml specification
+func (p *CrystalParser) ParseShardYML(content string) (Shard, error) {
+	var spec struct {
+		Name                    string                       `yaml:"name"`
+		Version                 string                       `yaml:"version"`
+		Crystal                 string                       `yaml:"crystal"`
+		License                 string                       `yaml:"license"`
+		Targets                 map[string]interface{}       `yaml:"targets"`
+		Dependencies            map[string]map[string]string `yaml:"dependencies"`
+		DevelopmentDependencies map[string]map[string]string `yaml:"development_dependencies"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &spec); err != nil {
+		return Shard{}, err
+	}
+
+	shard := Shard{
+		Name:                    spec.Name,
+		Version:                 spec.Version,
+		Crystal:                 spec.Crystal,
+		License:                 spec.License,
+		Dependencies:            shardDependencies(spec.Dependencies),
+		DevelopmentDependencies: shardDependencies(spec.DevelopmentDependencies),
+	}
+	for target := range spec.Targets {
+		shard.Targets = append(shard.Targets, target)
+	}
+	sort.Strings(shard.Targets)
+	return shard, nil
+}
+
+// ShardDependencies converts the dependencies of shard.yml to dependencies. The version
+// requirement is used as version; dependencies pinned to a tag, commit, or branch use the ref
+// (with the "git" and, for branches, "branch" metadata). Development dependencies have the
+// dev scope.
+func (p *CrystalParser) ShardDependencies(shard Shard) []types.Dependency {
+	var dependencies []types.Dependency
+	for _, dep := range shard.Dependencies {
+		dependencies = append(dependencies, shardDependency(dep, types.ScopeProd))
+	}
+	for _, dep := range shard.DevelopmentDependencies {
+		dependencies = append(dependencies, shardDependency(dep, types.ScopeDev))
+	}
+	return dependencies
+}
+
+// ParseShardLock parses shard.lock, which holds the installed version of every shard. Shards
+// declared in shard.yml are direct dependencies (development dependencies with the dev scope),
+// the others transitive ones.
+func (p *CrystalParser) ParseShardLock(content string, shard Shard) []types.Dependency {
+	var lock struct {
+		Shards map[string]map[string]string `yaml:"shards"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &lock); err != nil {
+		return nil
+	}
+
+	devDeps := make(map[string]bool)
+	for _, dep := range shard.DevelopmentDependencies {
+		devDeps[dep.Name] = true
+	}
+	directDeps := make(map[string]bool)
+	for _, dep := range shard.Dependencies {
+		directDeps[dep.Name] = true
+	}
+
+	names := make([]string, 0, len(lock.Shards))
+	for name := range lock.Shards {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var dependencies []types.Dependency
+	for _, name := range names {
+		fields := lock.Shards[name]
+		version := fields["version"]
+		if version == "" {
+			version = fields["commit"] // lock format 1.0
+		}
+		if version == "" {
+			version = "latest"
+		}
+		metadata := types.NewMetadata(MetadataSourceShardLock)
+		if path := fields["path"]; path != "" {
+			metadata["path"] = path
+		}
+		scope := types.ScopeProd
+		if devDeps[name] {
+			scope = types.ScopeDev
+		}
+		dependencies = append(dependencies, types.Dependency{
+			Type:     DependencyTypeShards,
+			Name:     name,
+			Version:  version,
+			Scope:    scope,
+			Direct:   directDeps[name] || devDeps[name],
+			Metadata: metadata,
+		})
+	}
+	return dependencies
+}
+
+// shardDependencies converts a dependency table of shard.yml, sorted by name
+func shardDependencies(table map[string]map[string]string) []ShardDependency {
+	names := make([]string, 0, len(table))
+	for name := range table {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var deps []ShardDependency
+	for _, name := range names {
+		fields := table[name]
+		dep := ShardDependency{
+			Name:    name,
+			Path:    fields["path"],
+			Version: fields["version"],
+			Branch:  fields["branch"],
+			Tag:     fields["tag"],
+			Commit:  fields["commit"],
+		}
+		for resolver, prefix := range shardHosts {
+			if repo := fields[resolver]; repo != "" {
+				dep.URL = prefix + strings.TrimSuffix(repo, ".git") + ".git"
+			}
+		}
+		for _, resolver := range []string{"git", "hg", "fossil"} {
+			if url := fields[resolver]; url != "" {
+				dep.URL = url
+			}
+		}
+		deps = append(deps, dep)
+	}
+	return deps
+}
+
+// shardDependency converts a shard.yml declaration to a dependency
+func shardDependency(dep ShardDependency, scope types.Scope) types.Dependency {
+	metadata := types.NewMetadata(MetadataSourceShardYML)
+	version := dep.Version
+	switch {
+	case dep.Path != "":
+		metadata["path"] = dep.Path
+	case dep.Tag != "" || dep.Commit != "" || dep.Branch != "":
+		metadata["git"] = dep.URL
+		switch {
+		case dep.Tag != "":
+			version = dep.Tag
+		case dep.Commit != "":
+			version = dep.Commit
+		default:
+			version = dep.Branch
+			metadata["branch"] = dep.Branch
+		}
+	case dep.URL != "":
+		metadata["url"] = dep.URL
+	}
+	if version == "" || version == "*" {
+		version = "latest"
+	}
+	return types.Dependency{
+		Type:     DependencyTypeShards,
+		Name:     dep.Name,
+		Version:  version,
+		Scope:    scope,
+		Direct:   true,
+		Metadata: metadata,
+	}
+}
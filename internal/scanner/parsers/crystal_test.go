@@ -0,0 +1,154 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testShardYML = `name: blog
+version: 0.3.0
+crystal: ">= 1.9.0"
+license: MIT
+
+targets:
+  blog:
+    main: src/blog.cr
+  worker:
+    main: src/worker.cr
+
+dependencies:
+  kemal:
+    github: kemalcr/kemal
+    version: ~> 1.4
+  pg:
+    github: will/crystal-pg
+    branch: master
+  granite:
+    gitlab: amberframework/granite
+    tag: v0.23.2
+  shared:
+    path: ../shared
+
+development_dependencies:
+  ameba:
+    github: crystal-ameba/ameba
+`
+
+func TestCrystalParser_ParseShardYML(t *testing.T) {
+	parser := NewCrystalParser()
+
+	shard, err := parser.ParseShardYML(testShardYML)
+	require.NoError(t, err)
+	assert.Equal(t, "blog", shard.Name)
+	assert.Equal(t, "0.3.0", shard.Version)
+	assert.Equal(t, ">= 1.9.0", shard.Crystal)
+	assert.Equal(t, "MIT", shard.License)
+	assert.Equal(t, []string{"blog", "worker"}, shard.Targets)
+	require.Len(t, shard.Dependencies, 4)
+	require.Len(t, shard.DevelopmentDependencies, 1)
+
+	assert.Equal(t, "granite", shard.Dependencies[0].Name)
+	assert.Equal(t, "https://gitlab.com/amberframework/granite.git", shard.Dependencies[0].URL)
+	assert.Equal(t, "https://github.com/kemalcr/kemal.git", shard.Dependencies[1].URL)
+}
+
+func TestCrystalParser_ParseShardYMLInvalid(t *testing.T) {
+	_, err := NewCrystalParser().ParseShardYML("name: [unterminated")
+	assert.Error(t, err)
+}
+
+func TestCrystalParser_ShardDependencies(t *testing.T) {
+	parser := NewCrystalParser()
+	shard, err := parser.ParseShardYML(testShardYML)
+	require.NoError(t, err)
+
+	deps := parser.ShardDependencies(shard)
+	require.Len(t, deps, 5)
+	byName := make(map[string]types.Dependency)
+	for _, dep := range deps {
+		assert.Equal(t, DependencyTypeShards, dep.Type)
+		assert.True(t, dep.Direct)
+		assert.Equal(t, MetadataSourceShardYML, dep.Metadata["source"])
+		byName[dep.Name] = dep
+	}
+
+	assert.Equal(t, "~> 1.4", byName["kemal"].Version)
+	assert.Equal(t, "https://github.com/kemalcr/kemal.git", byName["kemal"].Metadata["url"])
+	assert.Equal(t, types.ScopeProd, byName["kemal"].Scope)
+
+	assert.Equal(t, "master", byName["pg"].Version)
+	assert.Equal(t, "master", byName["pg"].Metadata["branch"])
+	assert.Equal(t, "https://github.com/will/crystal-pg.git", byName["pg"].Metadata["git"])
+
+	assert.Equal(t, "v0.23.2", byName["granite"].Version)
+	assert.Nil(t, byName["granite"].Metadata["branch"])
+
+	assert.Equal(t, "latest", byName["shared"].Version)
+	assert.Equal(t, "../shared", byName["shared"].Metadata["path"])
+
+	assert.Equal(t, "latest", byName["ameba"].Version)
+	assert.Equal(t, types.ScopeDev, byName["ameba"].Scope)
+}
+
+func TestCrystalParser_ParseShardLock(t *testing.T) {
+	parser := NewCrystalParser()
+	shard, err := parser.ParseShardYML(testShardYML)
+	require.NoError(t, err)
+
+	lock := `version: 2.0
+shards:
+  ameba:
+    git: https://github.com/crystal-ameba/ameba.git
+    version: 1.6.1
+  db:
+    git: https://github.com/crystal-lang/crystal-db.git
+    version: 0.13.1
+  kemal:
+    git: https://github.com/kemalcr/kemal.git
+    version: 1.4.0
+  pg:
+    git: https://github.com/will/crystal-pg.git
+    version: 0.28.0+git.commit.5bd5c4a
+  shared:
+    path: ../shared
+    version: 0.1.0
+`
+	deps := parser.ParseShardLock(lock, shard)
+	require.Len(t, deps, 5)
+
+	assert.Equal(t, "ameba", deps[0].Name)
+	assert.Equal(t, "1.6.1", deps[0].Version)
+	assert.Equal(t, types.ScopeDev, deps[0].Scope)
+	assert.True(t, deps[0].Direct)
+
+	assert.Equal(t, "db", deps[1].Name)
+	assert.False(t, deps[1].Direct)
+	assert.Equal(t, types.ScopeProd, deps[1].Scope)
+	assert.Equal(t, MetadataSourceShardLock, deps[1].Metadata["source"])
+
+	assert.Equal(t, "1.4.0", deps[2].Version)
+	assert.True(t, deps[2].Direct)
+	assert.Equal(t, "0.28.0+git.commit.5bd5c4a", deps[3].Version)
+	assert.Equal(t, "../shared", deps[4].Metadata["path"])
+}
+
+func TestCrystalParser_ParseShardLockLegacyFormat(t *testing.T) {
+	lock := `version: 1.0
+shards:
+  kemal:
+    github: kemalcr/kemal
+    version: 0.26.1
+  radix:
+    github: luislavena/radix
+    commit: 212c45b3e1e0e0bb6c8c7cb5cdb6ab0ae7f5ab9c
+`
+	deps := NewCrystalParser().ParseShardLock(lock, Shard{Dependencies: []ShardDependency{{Name: "kemal"}}})
+	require.Len(t, deps, 2)
+	assert.Equal(t, "0.26.1", deps[0].Version)
+	assert.True(t, deps[0].Direct)
+	assert.Equal(t, "212c45b3e1e0e0bb6c8c7cb5cdb6ab0ae7f5ab9c", deps[1].Version)
+	assert.False(t, deps[1].Direct)
+}
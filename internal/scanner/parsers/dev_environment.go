@@ -0,0 +1,242 @@
+package parsers
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+// devContainerFeatureTechs maps dev container feature names (last segment of the feature ID)
+// to the technology they install
+var devContainerFeatureTechs = map[string]string{
+	"node":                     "nodejs",
+	"python":                   "python",
+	"go":                       "golang",
+	"java":                     "java",
+	"rust":                     "rust",
+	"ruby":                     "ruby",
+	"php":                      "php",
+	"dotnet":                   "dotnet",
+	"deno":                     "deno",
+	"docker-in-docker":         "docker",
+	"docker-outside-of-docker": "docker",
+	"docker-from-docker":       "docker",
+	"kubectl-helm-minikube":    "kubernetes",
+	"terraform":                "terraform",
+	"aws-cli":                  "aws",
+	"azure-cli":                "azure",
+	"gcloud-cli":               "gcp",
+	"google-cloud-cli":         "gcp",
+	"github-cli":               "github",
+	"git":                      "git",
+}
+
+// DevEnvironmentParser handles dev container (devcontainer.json) and Gitpod (.gitpod.yml) parsing
+type DevEnvironmentParser struct{}
+
+// NewDevEnvironmentParser creates a new development environment parser
+func NewDevEnvironmentParser() *DevEnvironmentParser {
+	return &DevEnvironmentParser{}
+}
+
+// DevContainer describes a development container defined by devcontainer.json
+type DevContainer struct {
+	File         string                `json:"file"`
+	Name         string                `json:"name,omitempty"`
+	Image        string                `json:"image,omitempty"`
+	Dockerfile   string                `json:"dockerfile,omitempty"`
+	ComposeFiles []string              `json:"compose_files,omitempty"`
+	Service      string                `json:"service,omitempty"` // Compose service the editor attaches to
+	Features     []DevContainerFeature `json:"features,omitempty"`
+	Extensions   []string              `json:"extensions,omitempty"` // VS Code extension IDs
+	ForwardPorts []string              `json:"forward_ports,omitempty"`
+	Codespaces   bool                  `json:"codespaces,omitempty"` // Declares GitHub Codespaces customizations
+}
+
+// DevContainerFeature is a dev container feature with its reference tag and tool version option
+type DevContainerFeature struct {
+	ID          string `json:"id"`                     // Feature reference without tag (ghcr.io/devcontainers/features/node)
+	Version     string `json:"version,omitempty"`      // Feature tag (1)
+	ToolVersion string `json:"tool_version,omitempty"` // "version" option of the feature (18, lts)
+}
+
+// devContainerJSON represents the subset of devcontainer.json we use
+type devContainerJSON struct {
+	Name              string                            `json:"name"`
+	Image             string                            `json:"image"`
+	Dockerfile        string                            `json:"dockerFile"`
+	Build             struct{ Dockerfile string }       `json:"build"`
+	DockerComposeFile interface{}                       `json:"dockerComposeFile"`
+	Service           string                            `json:"service"`
+	Features          map[string]interface{}            `json:"features"`
+	Extensions        []string                          `json:"extensions"` // Legacy top-level extensions
+	ForwardPorts      []interface{}                     `json:"forwardPorts"`
+	Customizations    map[string]map[string]interface{} `json:"customizations"`
+}
+
+// ParseDevContainerJSON parses a devcontainer.json (JSON with comments and trailing commas)
+func (p *DevEnvironmentParser) ParseDevContainerJSON(content string) (*DevContainer, error) {
+	var raw devContainerJSON
+	cleaned := trailingCommaRegex.ReplaceAllString(stripJSONComments(content), "$1")
+	if err := json.Unmarshal([]byte(cleaned), &raw); err != nil {
+		return nil, err
+	}
+
+	container := &DevContainer{
+		Name:         raw.Name,
+		Image:        raw.Image,
+		Dockerfile:   raw.Dockerfile,
+		ComposeFiles: stringOrList(raw.DockerComposeFile),
+		Service:      raw.Service,
+	}
+	if raw.Build.Dockerfile != "" {
+		container.Dockerfile = raw.Build.Dockerfile
+	}
+
+	for reference, options := range raw.Features {
+		feature := parseDevContainerFeature(reference)
+		switch o := options.(type) {
+		case map[string]interface{}:
+			feature.ToolVersion = scalarString(o["version"])
+		case string: // Legacy shorthand: "node": "18"
+			feature.ToolVersion = o
+		}
+		container.Features = append(container.Features, feature)
+	}
+	sort.Slice(container.Features, func(i, j int) bool { return container.Features[i].ID < container.Features[j].ID })
+
+	extensions := raw.Extensions
+	if vscode, ok := raw.Customizations["vscode"]; ok {
+		extensions = append(extensions, stringOrList(vscode["extensions"])...)
+	}
+	container.Extensions = sortedUnique(extensions)
+	_, container.Codespaces = raw.Customizations["codespaces"]
+
+	for _, port := range raw.ForwardPorts {
+		container.ForwardPorts = append(container.ForwardPorts, scalarString(port))
+	}
+	return container, nil
+}
+
+// parseDevContainerFeature splits a feature reference into ID and tag. Local features
+// (./feature) and tarball URLs have no tag.
+func parseDevContainerFeature(reference string) DevContainerFeature {
+	if strings.HasPrefix(reference, ".") || strings.Contains(reference, "://") {
+		return DevContainerFeature{ID: reference}
+	}
+	if id, digest, ok := strings.Cut(reference, "@"); ok {
+		return DevContainerFeature{ID: id, Version: digest}
+	}
+	if idx := strings.LastIndex(reference, ":"); idx > strings.LastIndex(reference, "/") {
+		return DevContainerFeature{ID: reference[:idx], Version: reference[idx+1:]}
+	}
+	return DevContainerFeature{ID: reference}
+}
+
+// Tech returns the technology installed by the feature, or "" for features that are not mapped
+func (f DevContainerFeature) Tech() string {
+	return devContainerFeatureTechs[path.Base(f.ID)]
+}
+
+// GitpodConfig describes a Gitpod workspace defined by .gitpod.yml
+type GitpodConfig struct {
+	File       string          `json:"file"`
+	Image      string          `json:"image,omitempty"`
+	Dockerfile string          `json:"dockerfile,omitempty"` // Custom image built from a Dockerfile
+	Tasks      []string        `json:"tasks,omitempty"`
+	Ports      []string        `json:"ports,omitempty"`
+	Extensions []string        `json:"extensions,omitempty"` // VS Code extension IDs
+	Tools      []string        `json:"tools,omitempty"`
+	Usage      ScriptToolUsage `json:"-"`
+}
+
+// gitpodYAML represents the subset of .gitpod.yml we use
+type gitpodYAML struct {
+	Image interface{}              `yaml:"image"`
+	Tasks []map[string]interface{} `yaml:"tasks"`
+	Ports []struct {
+		Port interface{} `yaml:"port"`
+	} `yaml:"ports"`
+	VSCode struct {
+		Extensions []string `yaml:"extensions"`
+	} `yaml:"vscode"`
+}
+
+// gitpodTaskPhases are the task keys holding shell commands, in execution order
+var gitpodTaskPhases = []string{"before", "init", "command"}
+
+// ParseGitpodYAML parses a .gitpod.yml. Tools invoked by the task commands are detected
+// like Taskfile commands; unnamed tasks are called "task 1", "task 2", ...
+func (p *DevEnvironmentParser) ParseGitpodYAML(content string) (*GitpodConfig, error) {
+	var raw gitpodYAML
+	if err := yaml.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, err
+	}
+
+	config := &GitpodConfig{}
+	switch image := raw.Image.(type) {
+	case string:
+		config.Image = image
+	case map[string]interface{}: // image: {file: .gitpod.Dockerfile}
+		config.Dockerfile = scalarString(image["file"])
+	}
+
+	commands := make(map[string]string)
+	for i, task := range raw.Tasks {
+		name := scalarString(task["name"])
+		if name == "" {
+			name = fmt.Sprintf("task %d", i+1)
+		}
+		config.Tasks = append(config.Tasks, name)
+		var lines []string
+		for _, phase := range gitpodTaskPhases {
+			if command := scalarString(task[phase]); command != "" {
+				lines = append(lines, command)
+			}
+		}
+		commands[name] = strings.Join(lines, "\n")
+	}
+	config.Usage = NewScriptsParser().detectToolsInRecipes(commands)
+	config.Tools = config.Usage.Tools()
+
+	for _, port := range raw.Ports {
+		config.Ports = append(config.Ports, scalarString(port.Port))
+	}
+	config.Extensions = sortedUnique(raw.VSCode.Extensions)
+	return config, nil
+}
+
+// CreateImageDependency creates the docker dependency of a development environment's base
+// image. Returns false for images set through variables.
+func (p *DevEnvironmentParser) CreateImageDependency(image, source string) (types.Dependency, bool) {
+	if image == "" || strings.Contains(image, "$") {
+		return types.Dependency{}, false
+	}
+	name, version := NewDockerfileParser().parseImage(image)
+	return types.Dependency{
+		Type:     DependencyTypeDocker,
+		Name:     name,
+		Version:  version,
+		Scope:    types.ScopeDev,
+		Direct:   true,
+		Metadata: types.NewMetadata(source),
+	}, true
+}
+
+// scalarString formats a string or number value; other values yield ""
+func scalarString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int:
+		return fmt.Sprint(v)
+	case float64:
+		return fmt.Sprint(v)
+	}
+	return ""
+}
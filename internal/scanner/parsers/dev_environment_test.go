@@ -0,0 +1,117 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDevEnvironmentParser_ParseDevContainerJSON(t *testing.T) {
+	content := `{
+  // Python development container
+  "name": "api",
+  "image": "mcr.microsoft.com/devcontainers/python:1-3.12-bookworm",
+  "features": {
+    "ghcr.io/devcontainers/features/node:1": { "version": "20" },
+    "ghcr.io/devcontainers/features/docker-in-docker:2": {},
+    "ghcr.io/devcontainers/features/aws-cli@sha256:abc": {},
+    "./local-feature": {},
+  },
+  "forwardPorts": [8000, "db:5432"],
+  "customizations": {
+    "vscode": { "extensions": ["ms-python.python", "charliermarsh.ruff"] },
+    "codespaces": { "openFiles": ["README.md"] }
+  }
+}`
+	container, err := NewDevEnvironmentParser().ParseDevContainerJSON(content)
+	require.NoError(t, err)
+
+	assert.Equal(t, "api", container.Name)
+	assert.Equal(t, "mcr.microsoft.com/devcontainers/python:1-3.12-bookworm", container.Image)
+	assert.Equal(t, []DevContainerFeature{
+		{ID: "./local-feature"},
+		{ID: "ghcr.io/devcontainers/features/aws-cli", Version: "sha256:abc"},
+		{ID: "ghcr.io/devcontainers/features/docker-in-docker", Version: "2"},
+		{ID: "ghcr.io/devcontainers/features/node", Version: "1", ToolVersion: "20"},
+	}, container.Features)
+	assert.Equal(t, []string{"charliermarsh.ruff", "ms-python.python"}, container.Extensions)
+	assert.Equal(t, []string{"8000", "db:5432"}, container.ForwardPorts)
+	assert.True(t, container.Codespaces)
+
+	assert.Equal(t, "", container.Features[0].Tech())
+	assert.Equal(t, "aws", container.Features[1].Tech())
+	assert.Equal(t, "nodejs", container.Features[3].Tech())
+}
+
+func TestDevEnvironmentParser_ParseDevContainerJSON_Compose(t *testing.T) {
+	content := `{
+  "dockerComposeFile": ["../docker-compose.yml", "docker-compose.extend.yml"],
+  "service": "app",
+  "extensions": ["golang.go"]
+}`
+	container, err := NewDevEnvironmentParser().ParseDevContainerJSON(content)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"../docker-compose.yml", "docker-compose.extend.yml"}, container.ComposeFiles)
+	assert.Equal(t, "app", container.Service)
+	assert.Equal(t, []string{"golang.go"}, container.Extensions)
+	assert.False(t, container.Codespaces)
+}
+
+func TestDevEnvironmentParser_ParseDevContainerJSON_Build(t *testing.T) {
+	container, err := NewDevEnvironmentParser().ParseDevContainerJSON(`{"build": {"dockerfile": "Dockerfile", "context": ".."}}`)
+	require.NoError(t, err)
+	assert.Equal(t, "Dockerfile", container.Dockerfile)
+
+	_, err = NewDevEnvironmentParser().ParseDevContainerJSON(`{"name": `)
+	assert.Error(t, err)
+}
+
+func TestDevEnvironmentParser_ParseGitpodYAML(t *testing.T) {
+	content := `image: gitpod/workspace-full:2024-01-01
+tasks:
+  - name: web
+    init: npm ci && npx tsc
+    command: npx vite
+  - command: docker compose up
+    env:
+      DEBUG: "1"
+ports:
+  - port: 5173
+    onOpen: open-preview
+vscode:
+  extensions:
+    - dbaeumer.vscode-eslint
+`
+	config, err := NewDevEnvironmentParser().ParseGitpodYAML(content)
+	require.NoError(t, err)
+
+	assert.Equal(t, "gitpod/workspace-full:2024-01-01", config.Image)
+	assert.Equal(t, []string{"web", "task 2"}, config.Tasks)
+	assert.Equal(t, []string{"5173"}, config.Ports)
+	assert.Equal(t, []string{"dbaeumer.vscode-eslint"}, config.Extensions)
+	assert.Equal(t, []string{"tsc", "vite"}, config.Tools)
+	assert.Equal(t, []string{"web"}, config.Usage["vite"])
+}
+
+func TestDevEnvironmentParser_ParseGitpodYAML_CustomImage(t *testing.T) {
+	config, err := NewDevEnvironmentParser().ParseGitpodYAML("image:\n  file: .gitpod.Dockerfile\n")
+	require.NoError(t, err)
+	assert.Equal(t, "", config.Image)
+	assert.Equal(t, ".gitpod.Dockerfile", config.Dockerfile)
+}
+
+func TestDevEnvironmentParser_CreateImageDependency(t *testing.T) {
+	parser := NewDevEnvironmentParser()
+
+	dep, ok := parser.CreateImageDependency("mcr.microsoft.com/devcontainers/go:1.22", MetadataSourceDevcontainer)
+	require.True(t, ok)
+	assert.Equal(t, DependencyTypeDocker, dep.Type)
+	assert.Equal(t, "mcr.microsoft.com/devcontainers/go", dep.Name)
+	assert.Equal(t, "1.22", dep.Version)
+	assert.Equal(t, MetadataSourceDevcontainer, dep.Metadata["source"])
+
+	_, ok = parser.CreateImageDependency("${localEnv:IMAGE}", MetadataSourceDevcontainer)
+	assert.False(t, ok)
+}
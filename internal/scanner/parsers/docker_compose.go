@@ -24,7 +24,7 @@ type DockerService struct {
 
 // ParseDockerCompose parses docker-compose.yml/yaml and extracts services
 func (p *DockerComposeParser) ParseDockerCompose(content string) []DockerService {
-	lines := strings.Split(content, "\n")
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
 
 	parser := &dockerComposeState{
 		services:           []DockerService{},
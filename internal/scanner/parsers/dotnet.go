@@ -17,6 +17,7 @@ type DotNetProject struct {
 	Name              string
 	PackageId         string // NuGet package ID (defaults to Name/AssemblyName if not specified)
 	Framework         string
+	Frameworks        []string // All target frameworks (<TargetFrameworks> multi-targeting)
 	Packages          []DotNetPackage
 	ProjectReferences []string // Paths to referenced projects
 }
@@ -25,7 +26,7 @@ type DotNetProject struct {
 type DotNetPackage struct {
 	Name     string
 	Version  string
-	Scope    string                 // prod, dev, build
+	Scope    types.Scope            // prod, dev, build
 	Metadata map[string]interface{} // Additional package metadata
 }
 
@@ -38,9 +39,11 @@ type Project struct {
 }
 
 type PropertyGroup struct {
-	TargetFramework string `xml:"TargetFramework"`
-	AssemblyName    string `xml:"AssemblyName"`
-	PackageId       string `xml:"PackageId"`
+	TargetFramework        string `xml:"TargetFramework"`
+	TargetFrameworks       string `xml:"TargetFrameworks"`       // Multi-targeting, semicolon-separated
+	TargetFrameworkVersion string `xml:"TargetFrameworkVersion"` // Legacy .NET Framework projects (e.g., v4.7.2)
+	AssemblyName           string `xml:"AssemblyName"`
+	PackageId              string `xml:"PackageId"`
 }
 
 type ItemGroup struct {
@@ -137,10 +140,8 @@ func (p *DotNetParser) parseModernProject(project Project, content, filePath str
 		if pg.PackageId != "" {
 			result.PackageId = pg.PackageId
 		}
-		if pg.TargetFramework != "" {
-			result.Framework = pg.TargetFramework
-		}
 	}
+	p.applyTargetFrameworks(&result, project.PropertyGroups)
 
 	// Extract packages and project references from ItemGroups
 	for _, ig := range project.ItemGroups {
@@ -186,10 +187,8 @@ func (p *DotNetParser) parseLegacyProject(project LegacyProject, content, filePa
 		if pg.PackageId != "" {
 			result.PackageId = pg.PackageId
 		}
-		if pg.TargetFramework != "" {
-			result.Framework = pg.TargetFramework
-		}
 	}
+	p.applyTargetFrameworks(&result, project.PropertyGroups)
 
 	// Extract packages and project references from ItemGroups
 	for _, ig := range project.ItemGroups {
@@ -223,6 +222,29 @@ func (p *DotNetParser) parseLegacyProject(project LegacyProject, content, filePa
 	return result
 }
 
+// applyTargetFrameworks collects target frameworks from <TargetFramework>, <TargetFrameworks>,
+// and legacy <TargetFrameworkVersion> (converted to its short name, e.g., v4.7.2 -> net472)
+func (p *DotNetParser) applyTargetFrameworks(result *DotNetProject, groups []PropertyGroup) {
+	for _, pg := range groups {
+		if pg.TargetFramework != "" {
+			result.Framework = pg.TargetFramework
+		}
+		if pg.TargetFrameworks != "" {
+			result.Frameworks = SplitTargetFrameworks(pg.TargetFrameworks)
+		}
+		if pg.TargetFrameworkVersion != "" && result.Framework == "" {
+			result.Framework = NormalizeTargetFramework(pg.TargetFrameworkVersion)
+		}
+	}
+
+	if result.Framework != "" && !containsString(result.Frameworks, result.Framework) {
+		result.Frameworks = append([]string{result.Framework}, result.Frameworks...)
+	}
+	if result.Framework == "" && len(result.Frameworks) > 0 {
+		result.Framework = result.Frameworks[0]
+	}
+}
+
 // extractProjectNameFromContent attempts to extract project name from XML content
 func (p *DotNetParser) extractProjectNameFromContent(content, filePath string) string {
 	// Try to match AssemblyName in content (might be in different format)
@@ -264,7 +286,7 @@ func (p *DotNetParser) IsLegacyFramework(framework string) bool {
 
 // determineNuGetScope determines the scope of a NuGet package based on its attributes
 // Aligned with deps.dev patterns: regular (prod), dev, test, build
-func (p *DotNetParser) determineNuGetScope(pr PackageReference) string {
+func (p *DotNetParser) determineNuGetScope(pr PackageReference) types.Scope {
 	// Check for build-time only dependencies (PrivateAssets="All")
 	if pr.PrivateAssets == "All" || pr.PrivateAssets == "all" {
 		return types.ScopeBuild
@@ -306,7 +328,7 @@ func (p *DotNetParser) ParsePackagesConfig(content string) []types.Dependency {
 	var packagesConfig PackagesConfig
 
 	if err := xml.Unmarshal([]byte(content), &packagesConfig); err != nil {
-		return dependencies
+		return NormalizeDependencyVersions(dependencies)
 	}
 
 	for _, pkg := range packagesConfig.Packages {
@@ -336,7 +358,7 @@ func (p *DotNetParser) ParsePackagesConfig(content string) []types.Dependency {
 		})
 	}
 
-	return dependencies
+	return NormalizeDependencyVersions(dependencies)
 }
 
 // ParseDirectoryPackagesProps parses Directory.Packages.props file and returns package versions
@@ -0,0 +1,296 @@
+package parsers
+
+import (
+	"bytes"
+	"debug/pe"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ECMA-335 metadata tables read from .NET assemblies
+const (
+	metadataTableAssembly    = 0x20
+	metadataTableAssemblyRef = 0x23
+	peCLIHeaderDirectory     = 14 // Data directory of the CLI header
+	metadataSignature        = 0x424A5342
+)
+
+var (
+	errNotDotnetAssembly = errors.New("not a .NET assembly")
+	errInvalidMetadata   = errors.New("invalid .NET metadata")
+
+	dotnetTargetFrameworkRegex = regexp.MustCompile(`\.NET(?:CoreApp|Framework|Standard),Version=v[\d.]+`)
+)
+
+// dotnetPlatformAssemblies are assembly references of the .NET platform, not packages
+var dotnetPlatformAssemblies = map[string]bool{
+	"mscorlib": true, "netstandard": true, "System": true, "WindowsBase": true,
+	"PresentationCore": true, "PresentationFramework": true,
+}
+
+// ParseDotnetAssembly reads the metadata of a .NET assembly (a PE file with a CLI header): the
+// assembly name and version, the CLR metadata version, the target framework, and the
+// referenced assemblies (platform assemblies such as System.* are skipped). Assemblies with a
+// managed entry point are applications. Returns an error for native PE files.
+func (p *ArtifactParser) ParseDotnetAssembly(content []byte) (*Artifact, error) {
+	file, err := pe.NewFile(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var directories []pe.DataDirectory
+	switch header := file.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		directories = header.DataDirectory[:min(header.NumberOfRvaAndSizes, 16)]
+	case *pe.OptionalHeader64:
+		directories = header.DataDirectory[:min(header.NumberOfRvaAndSizes, 16)]
+	}
+	if len(directories) <= peCLIHeaderDirectory || directories[peCLIHeaderDirectory].VirtualAddress == 0 {
+		return nil, errNotDotnetAssembly
+	}
+	cliDirectory := directories[peCLIHeaderDirectory]
+	cli := peSectionData(file, cliDirectory.VirtualAddress, max(cliDirectory.Size, 24))
+	if cli == nil {
+		return nil, errInvalidMetadata
+	}
+	metadata := peSectionData(file, binary.LittleEndian.Uint32(cli[8:]), binary.LittleEndian.Uint32(cli[12:]))
+	if metadata == nil {
+		return nil, errInvalidMetadata
+	}
+	const flagsNativeEntryPoint = 0x10
+	entryPoint := binary.LittleEndian.Uint32(cli[20:])
+	flags := binary.LittleEndian.Uint32(cli[16:])
+
+	assembly, err := parseDotnetMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+	assembly.Format = ArtifactFormatDotnet
+	assembly.Type = DependencyTypeDotnet
+	assembly.Application = entryPoint != 0 && flags&flagsNativeEntryPoint == 0
+	assembly.TargetFramework = dotnetTargetFrameworkRegex.FindString(string(metadata))
+	return assembly, nil
+}
+
+// dotnetMetadata holds the streams of the ECMA-335 metadata root
+type dotnetMetadata struct {
+	tables  []byte
+	strings []byte
+	rows    [64]uint32
+	sizes   [metadataTableAssemblyRef + 1]int // Row sizes of the tables up to AssemblyRef
+	offset  int                               // Offset of the first table in the tables stream
+	strIdx  int                               // Size of #Strings indexes
+	blobIdx int                               // Size of #Blob indexes
+}
+
+// parseDotnetMetadata reads the Assembly and AssemblyRef tables of the metadata root
+func parseDotnetMetadata(data []byte) (*Artifact, error) {
+	if len(data) < 20 || binary.LittleEndian.Uint32(data) != metadataSignature {
+		return nil, errInvalidMetadata
+	}
+	versionLength := int(binary.LittleEndian.Uint32(data[12:]))
+	pos := 16 + versionLength
+	if pos+4 > len(data) {
+		return nil, errInvalidMetadata
+	}
+	artifact := &Artifact{RuntimeVersion: strings.TrimRight(string(data[16:pos]), "\x00")}
+
+	md := &dotnetMetadata{}
+	streamCount := int(binary.LittleEndian.Uint16(data[pos+2:]))
+	pos += 4
+	for i := 0; i < streamCount; i++ {
+		if pos+8 > len(data) {
+			return nil, errInvalidMetadata
+		}
+		offset := int(binary.LittleEndian.Uint32(data[pos:]))
+		size := int(binary.LittleEndian.Uint32(data[pos+4:]))
+		end := bytes.IndexByte(data[pos+8:], 0)
+		if end < 0 || offset+size > len(data) {
+			return nil, errInvalidMetadata
+		}
+		name := string(data[pos+8 : pos+8+end])
+		pos += 8 + (end+4)&^3 // Name padded to 4 bytes (including the terminator)
+		switch name {
+		case "#~", "#-":
+			md.tables = data[offset : offset+size]
+		case "#Strings":
+			md.strings = data[offset : offset+size]
+		}
+	}
+	if err := md.readTableHeader(); err != nil {
+		return nil, err
+	}
+
+	if md.rows[metadataTableAssembly] > 0 {
+		row := md.row(metadataTableAssembly, 0)
+		if row == nil {
+			return nil, errInvalidMetadata
+		}
+		artifact.Version = dotnetVersion(row[4:12])
+		artifact.Name = md.string(row, 16+md.blobIdx)
+	}
+	for i := uint32(0); i < md.rows[metadataTableAssemblyRef]; i++ {
+		row := md.row(metadataTableAssemblyRef, i)
+		if row == nil {
+			return nil, errInvalidMetadata
+		}
+		name := md.string(row, 12+md.blobIdx)
+		if name == "" || dotnetPlatformAssemblies[name] || strings.HasPrefix(name, "System.") || strings.HasPrefix(name, "Microsoft.Win32.") {
+			continue
+		}
+		artifact.Modules = append(artifact.Modules, ArtifactModule{Type: DependencyTypeDotnet, Name: name, Version: dotnetVersion(row[0:8])})
+	}
+	return artifact, nil
+}
+
+// readTableHeader reads the row counts and heap index sizes of the tables stream and computes
+// the row sizes of the tables preceding AssemblyRef
+func (md *dotnetMetadata) readTableHeader() error {
+	if len(md.tables) < 24 {
+		return errInvalidMetadata
+	}
+	heapSizes := md.tables[6]
+	valid := binary.LittleEndian.Uint64(md.tables[8:])
+	pos := 24
+	for table := 0; table < 64; table++ {
+		if valid&(1<<table) == 0 {
+			continue
+		}
+		if pos+4 > len(md.tables) {
+			return errInvalidMetadata
+		}
+		md.rows[table] = binary.LittleEndian.Uint32(md.tables[pos:])
+		pos += 4
+	}
+	if heapSizes&0x40 != 0 { // Extra data of uncompressed (#-) streams
+		pos += 4
+	}
+	md.offset = pos
+
+	heapIndex := func(flag byte) int {
+		if heapSizes&flag != 0 {
+			return 4
+		}
+		return 2
+	}
+	md.strIdx, md.blobIdx = heapIndex(0x01), heapIndex(0x04)
+	s, g, b := md.strIdx, heapIndex(0x02), md.blobIdx
+	idx := func(table int) int {
+		if md.rows[table] >= 1<<16 {
+			return 4
+		}
+		return 2
+	}
+	coded := func(tagBits uint, tables ...int) int {
+		for _, table := range tables {
+			if md.rows[table] >= 1<<(16-tagBits) {
+				return 4
+			}
+		}
+		return 2
+	}
+	typeDefOrRef := coded(2, 0x02, 0x01, 0x1B)
+	hasCustomAttribute := coded(5, 0x06, 0x04, 0x01, 0x02, 0x08, 0x09, 0x0A, 0x00, 0x0E, 0x17, 0x14,
+		0x11, 0x1A, 0x1B, 0x20, 0x23, 0x26, 0x27, 0x28, 0x2A, 0x2C, 0x2B)
+	methodDefOrRef := coded(1, 0x06, 0x0A)
+
+	md.sizes = [...]int{
+		0x00: 2 + s + 3*g,                                    // Module
+		0x01: coded(2, 0x00, 0x1A, 0x23, 0x01) + 2*s,         // TypeRef
+		0x02: 4 + 2*s + typeDefOrRef + idx(0x04) + idx(0x06), // TypeDef
+		0x03: idx(0x04),                                      // FieldPtr
+		0x04: 2 + s + b,                                      // Field
+		0x05: idx(0x06),                                      // MethodPtr
+		0x06: 8 + s + b + idx(0x08),                          // MethodDef
+		0x07: idx(0x08),                                      // ParamPtr
+		0x08: 4 + s,                                          // Param
+		0x09: idx(0x02) + typeDefOrRef,                       // InterfaceImpl
+		0x0A: coded(3, 0x02, 0x01, 0x1A, 0x06, 0x1B) + s + b, // MemberRef
+		0x0B: 2 + coded(2, 0x04, 0x08, 0x17) + b,             // Constant
+		0x0C: hasCustomAttribute + coded(3, 0x06, 0x0A) + b,  // CustomAttribute
+		0x0D: coded(1, 0x04, 0x08) + b,                       // FieldMarshal
+		0x0E: 2 + coded(2, 0x02, 0x06, 0x20) + b,             // DeclSecurity
+		0x0F: 6 + idx(0x02),                                  // ClassLayout
+		0x10: 4 + idx(0x04),                                  // FieldLayout
+		0x11: b,                                              // StandAloneSig
+		0x12: idx(0x02) + idx(0x14),                          // EventMap
+		0x13: idx(0x14),                                      // EventPtr
+		0x14: 2 + s + typeDefOrRef,                           // Event
+		0x15: idx(0x02) + idx(0x17),                          // PropertyMap
+		0x16: idx(0x17),                                      // PropertyPtr
+		0x17: 2 + s + b,                                      // Property
+		0x18: 2 + idx(0x06) + coded(1, 0x14, 0x17),           // MethodSemantics
+		0x19: idx(0x02) + 2*methodDefOrRef,                   // MethodImpl
+		0x1A: s,                                              // ModuleRef
+		0x1B: b,                                              // TypeSpec
+		0x1C: 2 + coded(1, 0x04, 0x06) + s + idx(0x1A),       // ImplMap
+		0x1D: 4 + idx(0x04),                                  // FieldRVA
+		0x1E: 8,                                              // EncLog
+		0x1F: 4,                                              // EncMap
+		0x20: 16 + b + 2*s,                                   // Assembly
+		0x21: 4,                                              // AssemblyProcessor
+		0x22: 12,                                             // AssemblyOS
+		0x23: 12 + 2*b + 2*s,                                 // AssemblyRef
+	}
+	return nil
+}
+
+// row returns a row of a table, or nil if it is out of bounds
+func (md *dotnetMetadata) row(table int, index uint32) []byte {
+	offset := md.offset
+	for t := 0; t < table; t++ {
+		offset += int(md.rows[t]) * md.sizes[t]
+	}
+	offset += int(index) * md.sizes[table]
+	if offset+md.sizes[table] > len(md.tables) {
+		return nil
+	}
+	return md.tables[offset : offset+md.sizes[table]]
+}
+
+// string returns the #Strings entry referenced at pos of a row
+func (md *dotnetMetadata) string(row []byte, pos int) string {
+	var index int
+	if md.strIdx == 4 {
+		index = int(binary.LittleEndian.Uint32(row[pos:]))
+	} else {
+		index = int(binary.LittleEndian.Uint16(row[pos:]))
+	}
+	if index >= len(md.strings) {
+		return ""
+	}
+	end := bytes.IndexByte(md.strings[index:], 0)
+	if end < 0 {
+		return ""
+	}
+	return string(md.strings[index : index+end])
+}
+
+// dotnetVersion formats the four 16-bit version numbers of an assembly
+func dotnetVersion(data []byte) string {
+	return fmt.Sprintf("%d.%d.%d.%d", binary.LittleEndian.Uint16(data), binary.LittleEndian.Uint16(data[2:]),
+		binary.LittleEndian.Uint16(data[4:]), binary.LittleEndian.Uint16(data[6:]))
+}
+
+// peSectionData returns the bytes at a relative virtual address of a PE file, or nil
+func peSectionData(file *pe.File, rva, size uint32) []byte {
+	for _, section := range file.Sections {
+		if rva < section.VirtualAddress || rva >= section.VirtualAddress+max(section.VirtualSize, section.Size) {
+			continue
+		}
+		data, err := section.Data()
+		if err != nil {
+			return nil
+		}
+		offset := rva - section.VirtualAddress
+		if uint64(offset)+uint64(size) > uint64(len(data)) {
+			return nil
+		}
+		return data[offset : offset+size]
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package parsers
+
+import (
+	"bytes"
+	"debug/pe"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// padded appends zero bytes up to a multiple of 4
+func padded(data []byte) []byte {
+	for len(data)%4 != 0 {
+		data = append(data, 0)
+	}
+	return data
+}
+
+// buildDotnetMetadata returns an ECMA-335 metadata root with an Assembly row (MyApp 1.2.3.0)
+// and AssemblyRef rows (Newtonsoft.Json 13.0.0.0, System.Runtime 8.0.0.0)
+func buildDotnetMetadata() []byte {
+	le := binary.LittleEndian
+	stringHeap := padded([]byte("\x00MyApp\x00Newtonsoft.Json\x00System.Runtime\x00"))
+	blobHeap := padded(append([]byte{0, 1, 0, 24}, ".NETCoreApp,Version=v8.0"...))
+
+	var tables bytes.Buffer
+	write := func(values ...interface{}) {
+		for _, value := range values {
+			_ = binary.Write(&tables, le, value)
+		}
+	}
+	write(uint32(0), uint8(2), uint8(0), uint8(0), uint8(1), uint64(1<<0x20|1<<0x23), uint64(0), uint32(1), uint32(2))
+	write(uint32(0x8004), uint16(1), uint16(2), uint16(3), uint16(0), uint32(0), uint16(0), uint16(1), uint16(0))
+	write(uint16(13), uint16(0), uint16(0), uint16(0), uint32(0), uint16(0), uint16(7), uint16(0), uint16(0))
+	write(uint16(8), uint16(0), uint16(0), uint16(0), uint32(0), uint16(0), uint16(23), uint16(0), uint16(0))
+	tableStream := padded(tables.Bytes())
+
+	var root bytes.Buffer
+	_ = binary.Write(&root, le, []uint32{0x424A5342, 0x00010001, 0, 12})
+	root.WriteString("v4.0.30319\x00\x00")
+	_ = binary.Write(&root, le, []uint16{0, 3})
+	offset := uint32(root.Len() + 12 + 20 + 16)
+	for _, stream := range []struct {
+		name string
+		data []byte
+	}{{"#~", tableStream}, {"#Strings", stringHeap}, {"#Blob", blobHeap}} {
+		_ = binary.Write(&root, le, []uint32{offset, uint32(len(stream.data))})
+		root.Write(padded(append([]byte(stream.name), 0)))
+		offset += uint32(len(stream.data))
+	}
+	root.Write(tableStream)
+	root.Write(stringHeap)
+	root.Write(blobHeap)
+	return root.Bytes()
+}
+
+// buildPE returns a PE32 file with one section holding a CLI header and the metadata
+func buildPE(t *testing.T, entryPoint uint32, withCLIHeader bool) []byte {
+	t.Helper()
+	le := binary.LittleEndian
+	const sectionRVA, sectionOffset = 0x2000, 0x200
+	metadata := buildDotnetMetadata()
+	var section bytes.Buffer
+	_ = binary.Write(&section, le, []uint32{72, 0x00050002, sectionRVA + 72, uint32(len(metadata)), 1, entryPoint})
+	section.Write(make([]byte, 72-24))
+	section.Write(metadata)
+
+	optional := pe.OptionalHeader32{Magic: 0x10b, SectionAlignment: 0x2000, FileAlignment: 0x200, NumberOfRvaAndSizes: 16}
+	if withCLIHeader {
+		optional.DataDirectory[14] = pe.DataDirectory{VirtualAddress: sectionRVA, Size: 72}
+	}
+	sectionHeader := pe.SectionHeader32{VirtualSize: uint32(section.Len()), VirtualAddress: sectionRVA,
+		SizeOfRawData: uint32(section.Len()), PointerToRawData: sectionOffset}
+	copy(sectionHeader.Name[:], ".text")
+
+	var file bytes.Buffer
+	dos := make([]byte, 64)
+	copy(dos, "MZ")
+	le.PutUint32(dos[0x3c:], 64)
+	file.Write(dos)
+	file.WriteString("PE\x00\x00")
+	require.NoError(t, binary.Write(&file, le, pe.FileHeader{Machine: pe.IMAGE_FILE_MACHINE_I386, NumberOfSections: 1,
+		SizeOfOptionalHeader: uint16(binary.Size(optional)), Characteristics: 0x2102}))
+	require.NoError(t, binary.Write(&file, le, optional))
+	require.NoError(t, binary.Write(&file, le, sectionHeader))
+	file.Write(make([]byte, sectionOffset-file.Len()))
+	file.Write(section.Bytes())
+	return file.Bytes()
+}
+
+func TestArtifactParser_ParseDotnetAssembly(t *testing.T) {
+	artifact, err := NewArtifactParser().ParseDotnetAssembly(buildPE(t, 0, true))
+	require.NoError(t, err)
+	assert.Equal(t, ArtifactFormatDotnet, artifact.Format)
+	assert.Equal(t, "MyApp", artifact.Name)
+	assert.Equal(t, "1.2.3.0", artifact.Version)
+	assert.Equal(t, "v4.0.30319", artifact.RuntimeVersion)
+	assert.Equal(t, ".NETCoreApp,Version=v8.0", artifact.TargetFramework)
+	assert.False(t, artifact.Application, "no entry point")
+	// System.Runtime is a platform assembly
+	assert.Equal(t, []ArtifactModule{{Type: DependencyTypeDotnet, Name: "Newtonsoft.Json", Version: "13.0.0.0"}}, artifact.Modules)
+
+	artifact.File = "/lib/MyApp.dll"
+	deps := NewArtifactParser().Dependencies(artifact)
+	require.Len(t, deps, 1, "a library is a dependency itself")
+	assert.Equal(t, "MyApp", deps[0].Name)
+}
+
+func TestArtifactParser_ParseDotnetAssembly_EntryPoint(t *testing.T) {
+	artifact, err := NewArtifactParser().ParseDotnetAssembly(buildPE(t, 0x06000001, true))
+	require.NoError(t, err)
+	assert.True(t, artifact.Application)
+}
+
+func TestArtifactParser_ParseDotnetAssembly_Native(t *testing.T) {
+	_, err := NewArtifactParser().ParseDotnetAssembly(buildPE(t, 0, false))
+	assert.ErrorIs(t, err, errNotDotnetAssembly)
+
+	_, err = NewArtifactParser().ParseDotnetAssembly([]byte("MZ not a PE file"))
+	assert.Error(t, err)
+}
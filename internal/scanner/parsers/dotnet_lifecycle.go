@@ -0,0 +1,151 @@
+package parsers
+
+import (
+	_ "embed"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed dotnet_lifecycle.yaml
+var dotnetLifecycleData []byte
+
+// Compile .NET version regexes once at package level for performance
+var (
+	legacyFrameworkVersionRegex = regexp.MustCompile(`^v(\d)\.(\d)(?:\.(\d))?$`)
+	sdkVersionRegex             = regexp.MustCompile(`^(\d+)\.(\d+)\.`)
+)
+
+// dotnetLifecycleEntry is a single entry of the embedded lifecycle data
+type dotnetLifecycleEntry struct {
+	Product      string `yaml:"product"`
+	EndOfSupport string `yaml:"end_of_support"`
+}
+
+var (
+	dotnetLifecycleOnce sync.Once
+	dotnetLifecycle     map[string]dotnetLifecycleEntry
+)
+
+// loadDotNetLifecycle parses the embedded lifecycle data once
+func loadDotNetLifecycle() map[string]dotnetLifecycleEntry {
+	dotnetLifecycleOnce.Do(func() {
+		var data struct {
+			Frameworks map[string]dotnetLifecycleEntry `yaml:"frameworks"`
+		}
+		if err := yaml.Unmarshal(dotnetLifecycleData, &data); err != nil {
+			dotnetLifecycle = map[string]dotnetLifecycleEntry{}
+			return
+		}
+		dotnetLifecycle = data.Frameworks
+	})
+	return dotnetLifecycle
+}
+
+// DotNetFrameworkSupport describes the support status of a target framework
+type DotNetFrameworkSupport struct {
+	Framework    string `json:"framework"`
+	Product      string `json:"product"`
+	EndOfSupport string `json:"end_of_support,omitempty"` // YYYY-MM-DD, empty if no end date is announced
+	Supported    bool   `json:"supported"`
+}
+
+// LookupDotNetSupport returns the support status of a target framework moniker (e.g., "net8.0-windows", "net472")
+// as of the given date. Returns nil for unknown frameworks and for netstandard, which is not a runtime.
+func LookupDotNetSupport(framework string, asOf time.Time) *DotNetFrameworkSupport {
+	tfm := NormalizeTargetFramework(framework)
+	entry, ok := loadDotNetLifecycle()[tfm]
+	if !ok {
+		return nil
+	}
+
+	support := &DotNetFrameworkSupport{
+		Framework:    tfm,
+		Product:      entry.Product,
+		EndOfSupport: entry.EndOfSupport,
+		Supported:    true,
+	}
+	if entry.EndOfSupport != "" {
+		if end, err := time.Parse("2006-01-02", entry.EndOfSupport); err == nil && !asOf.Before(end) {
+			support.Supported = false
+		}
+	}
+	return support
+}
+
+// NormalizeTargetFramework converts a framework identifier to its short TFM:
+// strips OS suffixes ("net8.0-windows" -> "net8.0") and converts legacy
+// TargetFrameworkVersion values ("v4.7.2" -> "net472").
+func NormalizeTargetFramework(framework string) string {
+	tfm := strings.ToLower(strings.TrimSpace(framework))
+	if m := legacyFrameworkVersionRegex.FindStringSubmatch(tfm); m != nil {
+		return "net" + m[1] + m[2] + m[3]
+	}
+	if idx := strings.Index(tfm, "-"); idx > 0 {
+		tfm = tfm[:idx]
+	}
+	return tfm
+}
+
+// SplitTargetFrameworks splits a <TargetFrameworks> value ("net6.0;net8.0") into individual frameworks
+func SplitTargetFrameworks(value string) []string {
+	var frameworks []string
+	for _, f := range strings.Split(value, ";") {
+		if f = strings.TrimSpace(f); f != "" {
+			frameworks = append(frameworks, f)
+		}
+	}
+	return frameworks
+}
+
+// DotNetSDKInfo represents the SDK pin of a global.json file
+type DotNetSDKInfo struct {
+	File            string                  `json:"file"`
+	Version         string                  `json:"version"`
+	RollForward     string                  `json:"roll_forward,omitempty"`
+	AllowPrerelease *bool                   `json:"allow_prerelease,omitempty"`
+	Support         *DotNetFrameworkSupport `json:"support,omitempty"` // Lifecycle of the runtime shipped with the SDK
+}
+
+// ParseGlobalJSON parses a global.json file and returns the SDK pin.
+// Returns nil if the file does not pin an SDK version (e.g., only msbuild-sdks).
+func (p *DotNetParser) ParseGlobalJSON(content string, asOf time.Time) *DotNetSDKInfo {
+	var globalJSON struct {
+		SDK struct {
+			Version         string `json:"version"`
+			RollForward     string `json:"rollForward"`
+			AllowPrerelease *bool  `json:"allowPrerelease"`
+		} `json:"sdk"`
+	}
+	cleaned := trailingCommaRegex.ReplaceAllString(stripJSONComments(content), "$1")
+	if err := json.Unmarshal([]byte(cleaned), &globalJSON); err != nil || globalJSON.SDK.Version == "" {
+		return nil
+	}
+
+	info := &DotNetSDKInfo{
+		Version:         globalJSON.SDK.Version,
+		RollForward:     globalJSON.SDK.RollForward,
+		AllowPrerelease: globalJSON.SDK.AllowPrerelease,
+	}
+	if framework := SDKRuntimeFramework(info.Version); framework != "" {
+		info.Support = LookupDotNetSupport(framework, asOf)
+	}
+	return info
+}
+
+// SDKRuntimeFramework returns the target framework matching an SDK version ("8.0.100" -> "net8.0", "3.1.400" -> "netcoreapp3.1")
+func SDKRuntimeFramework(sdkVersion string) string {
+	m := sdkVersionRegex.FindStringSubmatch(sdkVersion)
+	if m == nil {
+		return ""
+	}
+	if major, _ := strconv.Atoi(m[1]); major < 5 {
+		return "netcoreapp" + m[1] + "." + m[2]
+	}
+	return "net" + m[1] + "." + m[2]
+}
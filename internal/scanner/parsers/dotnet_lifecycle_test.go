@@ -0,0 +1,107 @@
+package parsers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTargetFramework(t *testing.T) {
+	tests := []struct {
+		framework string
+		expected  string
+	}{
+		{"net8.0", "net8.0"},
+		{"net8.0-windows", "net8.0"},
+		{"net6.0-android31.0", "net6.0"},
+		{"NET472", "net472"},
+		{"v4.7.2", "net472"},
+		{"v4.8", "net48"},
+		{"v3.5", "net35"},
+		{" netcoreapp3.1 ", "netcoreapp3.1"},
+		{"netstandard2.0", "netstandard2.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.framework, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeTargetFramework(tt.framework))
+		})
+	}
+}
+
+func TestSplitTargetFrameworks(t *testing.T) {
+	assert.Equal(t, []string{"net6.0", "net8.0", "netstandard2.0"}, SplitTargetFrameworks("net6.0; net8.0;;netstandard2.0;"))
+	assert.Nil(t, SplitTargetFrameworks(""))
+}
+
+func TestLookupDotNetSupport(t *testing.T) {
+	asOf := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		framework    string
+		product      string
+		endOfSupport string
+		supported    bool
+	}{
+		{"net8.0", ".NET 8", "2026-11-10", true},
+		{"net8.0-windows", ".NET 8", "2026-11-10", true},
+		{"net6.0", ".NET 6", "2024-11-12", false},
+		{"netcoreapp3.1", ".NET Core 3.1", "2022-12-13", false},
+		{"net461", ".NET Framework 4.6.1", "2022-04-26", false},
+		{"net48", ".NET Framework 4.8", "", true},
+		{"v4.7.2", ".NET Framework 4.7.2", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.framework, func(t *testing.T) {
+			support := LookupDotNetSupport(tt.framework, asOf)
+			require.NotNil(t, support)
+			assert.Equal(t, tt.product, support.Product)
+			assert.Equal(t, tt.endOfSupport, support.EndOfSupport)
+			assert.Equal(t, tt.supported, support.Supported)
+		})
+	}
+
+	assert.Nil(t, LookupDotNetSupport("netstandard2.0", asOf), "netstandard is not a runtime")
+	assert.Nil(t, LookupDotNetSupport("net99.0", asOf))
+
+	// Support ends on the end-of-support date
+	endDate := time.Date(2026, 11, 10, 0, 0, 0, 0, time.UTC)
+	assert.False(t, LookupDotNetSupport("net8.0", endDate).Supported)
+}
+
+func TestSDKRuntimeFramework(t *testing.T) {
+	assert.Equal(t, "net8.0", SDKRuntimeFramework("8.0.100"))
+	assert.Equal(t, "net10.0", SDKRuntimeFramework("10.0.100-rc.1.25451.107"))
+	assert.Equal(t, "netcoreapp3.1", SDKRuntimeFramework("3.1.426"))
+	assert.Equal(t, "", SDKRuntimeFramework("latest"))
+}
+
+func TestParseGlobalJSON(t *testing.T) {
+	parser := NewDotNetParser()
+	asOf := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	content := `{
+  // SDK pin
+  "sdk": {
+    "version": "6.0.428",
+    "rollForward": "latestFeature",
+    "allowPrerelease": false,
+  },
+  "msbuild-sdks": { "Microsoft.Build.Traversal": "3.0.3" }
+}`
+	info := parser.ParseGlobalJSON(content, asOf)
+	require.NotNil(t, info)
+	assert.Equal(t, "6.0.428", info.Version)
+	assert.Equal(t, "latestFeature", info.RollForward)
+	require.NotNil(t, info.AllowPrerelease)
+	assert.False(t, *info.AllowPrerelease)
+	require.NotNil(t, info.Support)
+	assert.Equal(t, "net6.0", info.Support.Framework)
+	assert.False(t, info.Support.Supported)
+
+	assert.Nil(t, parser.ParseGlobalJSON(`{"msbuild-sdks": {"Microsoft.Build.Traversal": "3.0.3"}}`, asOf), "no SDK pin")
+	assert.Nil(t, parser.ParseGlobalJSON(`not json`, asOf))
+}
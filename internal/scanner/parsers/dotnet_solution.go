@@ -0,0 +1,118 @@
+package parsers
+
+import (
+	"bufio"
+	"encoding/xml"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Compile solution parsing regex once at package level for performance
+var slnProjectRegex = regexp.MustCompile(`^Project\("\{([0-9A-Fa-f-]+)\}"\)\s*=\s*"([^"]*)"\s*,\s*"([^"]*)"`)
+
+// slnSolutionFolderType is the project type GUID of solution folders (not buildable projects)
+const slnSolutionFolderType = "2150E333-8FDC-42A3-9474-1A3956D46DE8"
+
+// DotNetSolution represents the project graph of a Visual Studio solution (.sln or .slnx)
+type DotNetSolution struct {
+	File     string                  `json:"file"`
+	Projects []DotNetSolutionProject `json:"projects"`
+}
+
+// DotNetSolutionProject is a project listed in a solution
+type DotNetSolutionProject struct {
+	Name         string   `json:"name"`
+	Path         string   `json:"path"`           // Relative to the solution directory, forward slashes
+	Tech         string   `json:"tech,omitempty"` // Language tech (dotnet, vbnet, fsharp)
+	Framework    string   `json:"framework,omitempty"`
+	References   []string `json:"project_references,omitempty"` // Names of referenced solution projects
+	PackageCount int      `json:"package_count,omitempty"`      // Number of NuGet package references
+	Missing      bool     `json:"missing,omitempty"`            // Project file listed in the solution but not found
+}
+
+// slnxSolution represents the XML solution format (.slnx)
+type slnxSolution struct {
+	XMLName  xml.Name      `xml:"Solution"`
+	Projects []slnxProject `xml:"Project"`
+	Folders  []slnxFolder  `xml:"Folder"`
+}
+
+type slnxFolder struct {
+	Projects []slnxProject `xml:"Project"`
+	Folders  []slnxFolder  `xml:"Folder"`
+}
+
+type slnxProject struct {
+	Path string `xml:"Path,attr"`
+}
+
+// ParseSolution parses a .sln file and returns the listed projects (solution folders are skipped)
+func (p *DotNetParser) ParseSolution(content string) *DotNetSolution {
+	solution := &DotNetSolution{Projects: []DotNetSolutionProject{}}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		match := slnProjectRegex.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if match == nil || strings.EqualFold(match[1], slnSolutionFolderType) {
+			continue
+		}
+		solution.addProject(match[2], match[3])
+	}
+
+	return solution
+}
+
+// ParseSolutionX parses a .slnx file (XML solution format) and returns the listed projects
+func (p *DotNetParser) ParseSolutionX(content string) (*DotNetSolution, error) {
+	var slnx slnxSolution
+	if err := xml.Unmarshal([]byte(content), &slnx); err != nil {
+		return nil, err
+	}
+
+	solution := &DotNetSolution{Projects: []DotNetSolutionProject{}}
+	solution.addSlnxProjects(slnx.Projects, slnx.Folders)
+	return solution, nil
+}
+
+func (s *DotNetSolution) addSlnxProjects(projects []slnxProject, folders []slnxFolder) {
+	for _, project := range projects {
+		name := path.Base(NormalizeProjectPath(project.Path))
+		s.addProject(strings.TrimSuffix(name, path.Ext(name)), project.Path)
+	}
+	for _, folder := range folders {
+		s.addSlnxProjects(folder.Projects, folder.Folders)
+	}
+}
+
+func (s *DotNetSolution) addProject(name, projectPath string) {
+	projectPath = NormalizeProjectPath(projectPath)
+	tech := DotNetProjectTech(projectPath)
+	if tech == "" {
+		// Not an MSBuild project we understand (e.g., web site folders, .vcxproj)
+		return
+	}
+	s.Projects = append(s.Projects, DotNetSolutionProject{
+		Name: name,
+		Path: projectPath,
+		Tech: tech,
+	})
+}
+
+// NormalizeProjectPath converts a Windows-style project path (src\App\App.csproj) to forward slashes
+func NormalizeProjectPath(projectPath string) string {
+	return path.Clean(strings.ReplaceAll(strings.TrimSpace(projectPath), "\\", "/"))
+}
+
+// DotNetProjectTech returns the language tech of a .NET project file, or "" for other files
+func DotNetProjectTech(projectPath string) string {
+	switch strings.ToLower(path.Ext(projectPath)) {
+	case ".csproj":
+		return "dotnet"
+	case ".vbproj":
+		return "vbnet"
+	case ".fsproj":
+		return "fsharp"
+	}
+	return ""
+}
@@ -0,0 +1,70 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSolution(t *testing.T) {
+	parser := NewDotNetParser()
+	content := `
+Microsoft Visual Studio Solution File, Format Version 12.00
+# Visual Studio Version 17
+Project("{2150E333-8FDC-42A3-9474-1A3956D46DE8}") = "src", "src", "{11111111-1111-1111-1111-111111111111}"
+EndProject
+Project("{9A19103F-16F7-4668-BE54-9A1E7A4F7556}") = "App", "src\App\App.csproj", "{22222222-2222-2222-2222-222222222222}"
+EndProject
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "Core", "src\Core\Core.csproj", "{33333333-3333-3333-3333-333333333333}"
+EndProject
+Project("{F2A71F9B-5D33-465A-A702-920D77279786}") = "Scripts", "tools\Scripts.fsproj", "{44444444-4444-4444-4444-444444444444}"
+EndProject
+Project("{8BC9CEB8-8B4A-11D0-8D11-00A0C91BC942}") = "Native", "native\Native.vcxproj", "{55555555-5555-5555-5555-555555555555}"
+EndProject
+Global
+EndGlobal
+`
+	solution := parser.ParseSolution(content)
+	require.Len(t, solution.Projects, 3, "solution folders and non-.NET projects should be skipped")
+
+	assert.Equal(t, DotNetSolutionProject{Name: "App", Path: "src/App/App.csproj", Tech: "dotnet"}, solution.Projects[0])
+	assert.Equal(t, DotNetSolutionProject{Name: "Core", Path: "src/Core/Core.csproj", Tech: "dotnet"}, solution.Projects[1])
+	assert.Equal(t, DotNetSolutionProject{Name: "Scripts", Path: "tools/Scripts.fsproj", Tech: "fsharp"}, solution.Projects[2])
+
+	assert.Empty(t, parser.ParseSolution("").Projects)
+}
+
+func TestParseSolutionX(t *testing.T) {
+	parser := NewDotNetParser()
+	content := `<Solution>
+  <Folder Name="/src/">
+    <Project Path="src/App/App.csproj" />
+    <Folder Name="/src/libs/">
+      <Project Path="src/libs/Core/Core.vbproj" />
+    </Folder>
+  </Folder>
+  <Project Path="tests/App.Tests/App.Tests.csproj" />
+</Solution>`
+
+	solution, err := parser.ParseSolutionX(content)
+	require.NoError(t, err)
+	require.Len(t, solution.Projects, 3)
+
+	names := make(map[string]DotNetSolutionProject)
+	for _, project := range solution.Projects {
+		names[project.Name] = project
+	}
+	assert.Equal(t, "src/App/App.csproj", names["App"].Path)
+	assert.Equal(t, "vbnet", names["Core"].Tech)
+	assert.Equal(t, "tests/App.Tests/App.Tests.csproj", names["App.Tests"].Path)
+
+	_, err = parser.ParseSolutionX("not xml")
+	assert.Error(t, err)
+}
+
+func TestNormalizeProjectPath(t *testing.T) {
+	assert.Equal(t, "src/App/App.csproj", NormalizeProjectPath(`src\App\App.csproj`))
+	assert.Equal(t, "../Core/Core.csproj", NormalizeProjectPath(`..\Core\Core.csproj`))
+	assert.Equal(t, "App.csproj", NormalizeProjectPath(" ./App.csproj "))
+}
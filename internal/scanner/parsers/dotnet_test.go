@@ -187,6 +187,67 @@ func TestParseCsproj(t *testing.T) {
 	}
 }
 
+func TestParseCsproj_TargetFrameworks(t *testing.T) {
+	parser := NewDotNetParser()
+
+	tests := []struct {
+		name               string
+		content            string
+		expectedFramework  string
+		expectedFrameworks []string
+	}{
+		{
+			name: "single target framework",
+			content: `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+  </PropertyGroup>
+</Project>`,
+			expectedFramework:  "net8.0",
+			expectedFrameworks: []string{"net8.0"},
+		},
+		{
+			name: "multi-targeting",
+			content: `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFrameworks>netstandard2.0;net6.0;net8.0-windows</TargetFrameworks>
+  </PropertyGroup>
+</Project>`,
+			expectedFramework:  "netstandard2.0",
+			expectedFrameworks: []string{"netstandard2.0", "net6.0", "net8.0-windows"},
+		},
+		{
+			name: "legacy TargetFrameworkVersion",
+			content: `<Project ToolsVersion="15.0" xmlns="http://schemas.microsoft.com/developer/msbuild/2003">
+  <PropertyGroup>
+    <TargetFrameworkVersion>v4.6.1</TargetFrameworkVersion>
+    <AssemblyName>LegacyApp</AssemblyName>
+  </PropertyGroup>
+</Project>`,
+			expectedFramework:  "net461",
+			expectedFrameworks: []string{"net461"},
+		},
+		{
+			name: "no target framework",
+			content: `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <AssemblyName>App</AssemblyName>
+  </PropertyGroup>
+</Project>`,
+			expectedFramework:  "",
+			expectedFrameworks: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parser.ParseCsproj(tt.content, "test.csproj")
+			assert.Equal(t, tt.expectedFramework, result.Framework)
+			assert.Equal(t, tt.expectedFrameworks, result.Frameworks)
+		})
+	}
+}
+
 func TestGetFrameworkType(t *testing.T) {
 	parser := NewDotNetParser()
 
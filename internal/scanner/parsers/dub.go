@@ -0,0 +1,499 @@
+package parsers
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// DubPackage represents a D package recipe (dub.json or dub.sdl)
+type DubPackage struct {
+	Name         string
+	Version      string
+	Description  string
+	License      string
+	TargetType   string
+	Dependencies []DubDependency
+	SubPackages  []string // Names of inline sub-packages
+}
+
+// DubDependency is a dependency of a package recipe. Names of the form "package:sub" refer to
+// a sub-package. Dependencies of inline sub-packages and build configurations record the
+// declaring sub-package and configuration.
+type DubDependency struct {
+	Name          string
+	Version       string
+	Path          string
+	Repository    string
+	Optional      bool
+	SubPackage    string
+	Configuration string
+}
+
+// DubSelection is the pinned version (or path, or repository and commit) of a package in
+// dub.selections.json
+type DubSelection struct {
+	Version    string
+	Path       string
+	Repository string
+}
+
+// DubParser handles D package recipes and dub.selections.json
+type DubParser struct{}
+
+// NewDubParser creates a new dub parser
+func NewDubParser() *DubParser {
+	return &DubParser{}
+}
+
+// dubRecipe is the JSON form of a package recipe (also used for inline sub-packages and
+// configurations)
+type dubRecipe struct {
+	Name           string                     `json:"name"`
+	Version        string                     `json:"version"`
+	Description    string                     `json:"description"`
+	License        string                     `json:"license"`
+	TargetType     string                     `json:"targetType"`
+	Dependencies   map[string]json.RawMessage `json:"dependencies"`
+	Configurations []dubRecipe                `json:"configurations"`
+	SubPackages    []json.RawMessage          `json:"subPackages"`
+}
+
+// ParseDubJSON parses a dub.json recipe
+func (p *DubParser) ParseDubJSON(content string) (DubPackage, error) {
+	var recipe dubRecipe
+	if err := json.Unmarshal([]byte(content), &recipe); err != nil {
+		return DubPackage{}, err
+	}
+
+	pkg := DubPackage{
+		Name:        recipe.Name,
+		Version:     recipe.Version,
+		Description: recipe.Description,
+		License:     recipe.License,
+		TargetType:  recipe.TargetType,
+	}
+	pkg.Dependencies = dubJSONDependencies(recipe, "")
+	for _, raw := range recipe.SubPackages {
+		var sub dubRecipe
+		if json.Unmarshal(raw, &sub) != nil || sub.Name == "" {
+			continue // Path to a sub-package directory with its own recipe
+		}
+		pkg.SubPackages = append(pkg.SubPackages, sub.Name)
+		pkg.Dependencies = append(pkg.Dependencies, dubJSONDependencies(sub, sub.Name)...)
+	}
+	return pkg, nil
+}
+
+// ParseDubSDL parses a dub.sdl recipe
+func (p *DubParser) ParseDubSDL(content string) (DubPackage, error) {
+	tags, err := parseSDL(content)
+	if err != nil {
+		return DubPackage{}, err
+	}
+
+	var pkg DubPackage
+	for _, tag := range tags {
+		switch tag.name {
+		case "name":
+			pkg.Name = tag.value()
+		case "version":
+			pkg.Version = tag.value()
+		case "description":
+			pkg.Description = tag.value()
+		case "license":
+			pkg.License = tag.value()
+		case "targetType":
+			pkg.TargetType = tag.value()
+		case "subPackage":
+			if len(tag.children) == 0 {
+				continue // Path to a sub-package directory with its own recipe
+			}
+			subName := ""
+			for _, child := range tag.children {
+				if child.name == "name" {
+					subName = child.value()
+				}
+			}
+			if subName == "" {
+				continue
+			}
+			pkg.SubPackages = append(pkg.SubPackages, subName)
+			pkg.Dependencies = append(pkg.Dependencies, dubSDLDependencies(tag.children, subName, "")...)
+		}
+	}
+	pkg.Dependencies = append(dubSDLDependencies(tags, "", ""), pkg.Dependencies...)
+	return pkg, nil
+}
+
+// ParseSelections parses dub.selections.json into the selected package versions
+func (p *DubParser) ParseSelections(content string) (map[string]DubSelection, error) {
+	var file struct {
+		Versions map[string]json.RawMessage `json:"versions"`
+	}
+	if err := json.Unmarshal([]byte(content), &file); err != nil {
+		return nil, err
+	}
+	selections := make(map[string]DubSelection, len(file.Versions))
+	for name, raw := range file.Versions {
+		var version string
+		if json.Unmarshal(raw, &version) == nil {
+			selections[name] = DubSelection{Version: version}
+			continue
+		}
+		var spec struct {
+			Version    string `json:"version"`
+			Path       string `json:"path"`
+			Repository string `json:"repository"`
+		}
+		if json.Unmarshal(raw, &spec) == nil {
+			selections[name] = DubSelection(spec)
+		}
+	}
+	return selections, nil
+}
+
+// Dependencies converts the recipe dependencies to dependencies, with the recipe file name as
+// source. With selections, declared dependencies use the selected version (source
+// dub.selections.json) and the other selected packages are added as transitive dependencies.
+// Dependencies of the "unittest" configuration are test dependencies, optional dependencies
+// are optional. References to the package's own sub-packages are skipped.
+func (p *DubParser) Dependencies(pkg DubPackage, source string, selections map[string]DubSelection) []types.Dependency {
+	var dependencies []types.Dependency
+	declared := make(map[string]bool)
+	for _, dep := range pkg.Dependencies {
+		base, _, _ := strings.Cut(dep.Name, ":")
+		if base == "" || (pkg.Name != "" && base == pkg.Name) {
+			continue
+		}
+		declared[base] = true
+
+		metadata := types.NewMetadata(source)
+		version := dep.Version
+		if selection, ok := selections[base]; ok {
+			metadata = types.NewMetadata(MetadataSourceDubSelections)
+			version = dubSelectionMetadata(selection, metadata)
+		} else {
+			switch {
+			case dep.Path != "":
+				metadata["path"] = dep.Path
+			case dep.Repository != "":
+				metadata["git"] = dep.Repository
+			}
+		}
+		if dep.SubPackage != "" {
+			metadata["sub_package"] = dep.SubPackage
+		}
+		if dep.Configuration != "" {
+			metadata["configuration"] = dep.Configuration
+		}
+
+		scope := types.ScopeProd
+		switch {
+		case dep.Configuration == "unittest":
+			scope = types.ScopeTest
+		case dep.Optional:
+			scope = types.ScopeOptional
+		}
+		if version == "" || version == "*" {
+			version = "latest"
+		}
+		dependencies = append(dependencies, types.Dependency{
+			Type:     DependencyTypeDub,
+			Name:     dep.Name,
+			Version:  version,
+			Scope:    scope,
+			Direct:   true,
+			Metadata: metadata,
+		})
+	}
+
+	names := make([]string, 0, len(selections))
+	for name := range selections {
+		if !declared[name] && name != pkg.Name {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		metadata := types.NewMetadata(MetadataSourceDubSelections)
+		version := dubSelectionMetadata(selections[name], metadata)
+		if version == "" {
+			version = "latest"
+		}
+		dependencies = append(dependencies, types.Dependency{
+			Type:     DependencyTypeDub,
+			Name:     name,
+			Version:  version,
+			Scope:    types.ScopeProd,
+			Direct:   false,
+			Metadata: metadata,
+		})
+	}
+	return dependencies
+}
+
+// dubSelectionMetadata adds the path or repository of a selection and returns its version
+func dubSelectionMetadata(selection DubSelection, metadata map[string]interface{}) string {
+	if selection.Path != "" {
+		metadata["path"] = selection.Path
+	}
+	if selection.Repository != "" {
+		metadata["git"] = selection.Repository
+	}
+	return selection.Version
+}
+
+// dubJSONDependencies returns the dependencies of a JSON recipe and its configurations
+func dubJSONDependencies(recipe dubRecipe, subPackage string) []DubDependency {
+	deps := dubJSONDependencyTable(recipe.Dependencies, subPackage, "")
+	for _, config := range recipe.Configurations {
+		deps = append(deps, dubJSONDependencyTable(config.Dependencies, subPackage, config.Name)...)
+	}
+	return deps
+}
+
+// dubJSONDependencyTable converts a dependencies object, sorted by name. Values are a version
+// specification or an object with version, path, repository, and optional.
+func dubJSONDependencyTable(table map[string]json.RawMessage, subPackage, configuration string) []DubDependency {
+	names := make([]string, 0, len(table))
+	for name := range table {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var deps []DubDependency
+	for _, name := range names {
+		dep := DubDependency{Name: name, SubPackage: subPackage, Configuration: configuration}
+		var version string
+		if json.Unmarshal(table[name], &version) == nil {
+			dep.Version = version
+		} else {
+			var spec struct {
+				Version    string `json:"version"`
+				Path       string `json:"path"`
+				Repository string `json:"repository"`
+				Optional   bool   `json:"optional"`
+			}
+			if json.Unmarshal(table[name], &spec) != nil {
+				continue
+			}
+			dep.Version, dep.Path, dep.Repository, dep.Optional = spec.Version, spec.Path, spec.Repository, spec.Optional
+		}
+		deps = append(deps, dep)
+	}
+	return deps
+}
+
+// dubSDLDependencies returns the dependency tags of an SDL recipe and its configurations
+func dubSDLDependencies(tags []sdlTag, subPackage, configuration string) []DubDependency {
+	var deps []DubDependency
+	for _, tag := range tags {
+		switch tag.name {
+		case "dependency":
+			if tag.value() == "" {
+				continue
+			}
+			deps = append(deps, DubDependency{
+				Name:          tag.value(),
+				Version:       tag.attrs["version"],
+				Path:          tag.attrs["path"],
+				Repository:    tag.attrs["repository"],
+				Optional:      tag.attrs["optional"] == "true",
+				SubPackage:    subPackage,
+				Configuration: configuration,
+			})
+		case "configuration":
+			deps = append(deps, dubSDLDependencies(tag.children, subPackage, tag.value())...)
+		}
+	}
+	return deps
+}
+
+// --- SDLang ---
+
+// sdlTag is a tag of an SDLang document: a name with values, attributes, and child tags
+type sdlTag struct {
+	name     string
+	values   []string
+	attrs    map[string]string
+	children []sdlTag
+}
+
+// value returns the first value of a tag
+func (t sdlTag) value() string {
+	if len(t.values) == 0 {
+		return ""
+	}
+	return t.values[0]
+}
+
+// parseSDL parses an SDLang document into its top-level tags
+func parseSDL(content string) ([]sdlTag, error) {
+	parser := &sdlParser{input: content}
+	tags, err := parser.parseTags()
+	if err != nil {
+		return nil, err
+	}
+	if parser.pos < len(parser.input) {
+		return nil, fmt.Errorf("sdl: unexpected } at offset %d", parser.pos)
+	}
+	return tags, nil
+}
+
+// sdlParser is a recursive descent parser for the SDLang subset used by dub recipes
+type sdlParser struct {
+	input string
+	pos   int
+}
+
+// parseTags parses tags up to the end of input or a closing brace
+func (s *sdlParser) parseTags() ([]sdlTag, error) {
+	var tags []sdlTag
+	for {
+		s.skipSpace(true)
+		if s.pos >= len(s.input) || s.input[s.pos] == '}' {
+			return tags, nil
+		}
+		tag, err := s.parseTag()
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+}
+
+// parseTag parses a tag: [name] values... key=value... [{ children }]
+func (s *sdlParser) parseTag() (sdlTag, error) {
+	tag := sdlTag{attrs: make(map[string]string)}
+	if c := s.input[s.pos]; c != '"' && c != '`' && c != '{' {
+		tag.name = s.parseToken()
+	}
+	for {
+		s.skipSpace(false)
+		if s.pos >= len(s.input) {
+			return tag, nil
+		}
+		switch c := s.input[s.pos]; {
+		case c == '\n' || c == ';':
+			s.pos++
+			return tag, nil
+		case c == '}':
+			return tag, nil
+		case c == '{':
+			s.pos++
+			children, err := s.parseTags()
+			if err != nil {
+				return tag, err
+			}
+			if s.pos >= len(s.input) {
+				return tag, fmt.Errorf("sdl: unterminated block of tag %q", tag.name)
+			}
+			s.pos++
+			tag.children = children
+			return tag, nil
+		case c == '"' || c == '`':
+			value, err := s.parseString()
+			if err != nil {
+				return tag, err
+			}
+			tag.values = append(tag.values, value)
+		default:
+			token := s.parseToken()
+			if token == "" {
+				return tag, fmt.Errorf("sdl: unexpected %q at offset %d", c, s.pos)
+			}
+			if s.pos < len(s.input) && s.input[s.pos] == '=' {
+				s.pos++
+				var value string
+				if s.pos < len(s.input) && (s.input[s.pos] == '"' || s.input[s.pos] == '`') {
+					var err error
+					if value, err = s.parseString(); err != nil {
+						return tag, err
+					}
+				} else {
+					value = s.parseToken()
+				}
+				tag.attrs[token] = value
+			} else {
+				tag.values = append(tag.values, token)
+			}
+		}
+	}
+}
+
+// parseToken parses an identifier, number, or keyword (true, false, null)
+func (s *sdlParser) parseToken() string {
+	start := s.pos
+	for s.pos < len(s.input) && !strings.ContainsRune(" \t\r\n;={}\"`", rune(s.input[s.pos])) {
+		s.pos++
+	}
+	return s.input[start:s.pos]
+}
+
+// parseString parses a double quoted string with escapes or a backquoted raw string
+func (s *sdlParser) parseString() (string, error) {
+	quote := s.input[s.pos]
+	s.pos++
+	var b strings.Builder
+	for s.pos < len(s.input) {
+		c := s.input[s.pos]
+		switch {
+		case c == quote:
+			s.pos++
+			return b.String(), nil
+		case c == '\\' && quote == '"' && s.pos+1 < len(s.input):
+			s.pos++
+			switch e := s.input[s.pos]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(e)
+			}
+		default:
+			b.WriteByte(c)
+		}
+		s.pos++
+	}
+	return "", fmt.Errorf("sdl: unterminated string")
+}
+
+// skipSpace skips whitespace, comments (//, #, --, /* */), and line continuations; newlines
+// end a tag and are only skipped between tags
+func (s *sdlParser) skipSpace(newlines bool) {
+	for s.pos < len(s.input) {
+		rest := s.input[s.pos:]
+		switch c := rest[0]; {
+		case c == ' ' || c == '\t' || c == '\r':
+			s.pos++
+		case c == '\n' || c == ';':
+			if !newlines {
+				return
+			}
+			s.pos++
+		case c == '\\' && strings.HasPrefix(strings.TrimLeft(rest[1:], " \t\r"), "\n"):
+			s.pos += strings.IndexByte(rest, '\n') + 1
+		case strings.HasPrefix(rest, "//") || strings.HasPrefix(rest, "--") || c == '#':
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				s.pos = len(s.input)
+			} else {
+				s.pos += end
+			}
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			if end < 0 {
+				s.pos = len(s.input)
+			} else {
+				s.pos += end + 4
+			}
+		default:
+			return
+		}
+	}
+}
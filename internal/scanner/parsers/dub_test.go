@@ -0,0 +1,188 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDubParser_ParseDubJSON(t *testing.T) {
+	content := `{
+	"name": "webapp",
+	"description": "A vibe.d web application",
+	"license": "BSL-1.0",
+	"targetType": "executable",
+	"dependencies": {
+		"vibe-d:http": "~>0.9.5",
+		"mir-algorithm": ">=3.20.0 <4.0.0",
+		"shared": {"path": "../shared"},
+		"dyaml": {"version": "~>0.9", "optional": true},
+		"forked": {"repository": "git+https://github.com/acme/forked.git", "version": "a1b2c3d"},
+		"webapp:core": "*"
+	},
+	"configurations": [
+		{"name": "application", "targetType": "executable"},
+		{"name": "unittest", "dependencies": {"unit-threaded": "~>2.1"}}
+	],
+	"subPackages": [
+		"./tools/",
+		{"name": "core", "dependencies": {"mir-core": "~>1.5"}}
+	]
+}`
+	pkg, err := NewDubParser().ParseDubJSON(content)
+	require.NoError(t, err)
+
+	assert.Equal(t, "webapp", pkg.Name)
+	assert.Equal(t, "A vibe.d web application", pkg.Description)
+	assert.Equal(t, "BSL-1.0", pkg.License)
+	assert.Equal(t, "executable", pkg.TargetType)
+	assert.Equal(t, []string{"core"}, pkg.SubPackages)
+
+	require.Len(t, pkg.Dependencies, 8)
+	assert.Equal(t, DubDependency{Name: "dyaml", Version: "~>0.9", Optional: true}, pkg.Dependencies[0])
+	assert.Equal(t, DubDependency{Name: "forked", Version: "a1b2c3d", Repository: "git+https://github.com/acme/forked.git"}, pkg.Dependencies[1])
+	assert.Equal(t, DubDependency{Name: "shared", Path: "../shared"}, pkg.Dependencies[3])
+	assert.Equal(t, DubDependency{Name: "vibe-d:http", Version: "~>0.9.5"}, pkg.Dependencies[4])
+	assert.Equal(t, DubDependency{Name: "unit-threaded", Version: "~>2.1", Configuration: "unittest"}, pkg.Dependencies[6])
+	assert.Equal(t, DubDependency{Name: "mir-core", Version: "~>1.5", SubPackage: "core"}, pkg.Dependencies[7])
+}
+
+func TestDubParser_ParseDubSDL(t *testing.T) {
+	content := `name "webapp"
+description "A vibe.d web application"
+license "BSL-1.0"
+targetType "executable"
+
+// Dependencies
+dependency "vibe-d:http" version="~>0.9.5"
+dependency "shared" path="../shared"
+dependency "dyaml" version="~>0.9" optional=true
+dependency "forked" repository="git+https://github.com/acme/forked.git" \
+    version="a1b2c3d"
+
+configuration "application" {
+	targetType "executable"
+}
+configuration "unittest" {
+	dependency "unit-threaded" version="~>2.1" # test runner
+}
+
+subPackage "./tools/"
+subPackage {
+	name "core"
+	/* shared core */
+	dependency "mir-core" version="~>1.5"
+}
+`
+	pkg, err := NewDubParser().ParseDubSDL(content)
+	require.NoError(t, err)
+
+	assert.Equal(t, "webapp", pkg.Name)
+	assert.Equal(t, "A vibe.d web application", pkg.Description)
+	assert.Equal(t, "BSL-1.0", pkg.License)
+	assert.Equal(t, "executable", pkg.TargetType)
+	assert.Equal(t, []string{"core"}, pkg.SubPackages)
+
+	assert.Equal(t, []DubDependency{
+		{Name: "vibe-d:http", Version: "~>0.9.5"},
+		{Name: "shared", Path: "../shared"},
+		{Name: "dyaml", Version: "~>0.9", Optional: true},
+		{Name: "forked", Version: "a1b2c3d", Repository: "git+https://github.com/acme/forked.git"},
+		{Name: "unit-threaded", Version: "~>2.1", Configuration: "unittest"},
+		{Name: "mir-core", Version: "~>1.5", SubPackage: "core"},
+	}, pkg.Dependencies)
+}
+
+func TestDubParser_ParseDubSDLInvalid(t *testing.T) {
+	_, err := NewDubParser().ParseDubSDL("subPackage {\n\tname \"core\"\n")
+	assert.Error(t, err)
+}
+
+func TestDubParser_ParseSelections(t *testing.T) {
+	content := `{
+	"fileVersion": 1,
+	"versions": {
+		"vibe-d": "0.9.7",
+		"shared": {"path": "../shared"},
+		"forked": {"repository": "git+https://github.com/acme/forked.git", "version": "a1b2c3d"}
+	}
+}`
+	selections, err := NewDubParser().ParseSelections(content)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]DubSelection{
+		"vibe-d": {Version: "0.9.7"},
+		"shared": {Path: "../shared"},
+		"forked": {Version: "a1b2c3d", Repository: "git+https://github.com/acme/forked.git"},
+	}, selections)
+}
+
+func TestDubParser_Dependencies(t *testing.T) {
+	pkg := DubPackage{
+		Name: "webapp",
+		Dependencies: []DubDependency{
+			{Name: "vibe-d:http", Version: "~>0.9.5"},
+			{Name: "shared", Path: "../shared"},
+			{Name: "dyaml", Version: "~>0.9", Optional: true},
+			{Name: "forked", Version: "a1b2c3d", Repository: "git+https://github.com/acme/forked.git"},
+			{Name: "unit-threaded", Version: "~>2.1", Configuration: "unittest"},
+			{Name: "mir-core", SubPackage: "core"},
+			{Name: "webapp:core"},
+			{Name: ":core"},
+		},
+	}
+
+	deps := NewDubParser().Dependencies(pkg, MetadataSourceDubJSON, nil)
+	require.Len(t, deps, 6)
+	for _, dep := range deps {
+		assert.Equal(t, DependencyTypeDub, dep.Type)
+		assert.True(t, dep.Direct)
+		assert.Equal(t, MetadataSourceDubJSON, dep.Metadata["source"])
+	}
+	assert.Equal(t, "vibe-d:http", deps[0].Name)
+	assert.Equal(t, "~>0.9.5", deps[0].Version)
+	assert.Equal(t, types.ScopeProd, deps[0].Scope)
+	assert.Equal(t, "latest", deps[1].Version)
+	assert.Equal(t, "../shared", deps[1].Metadata["path"])
+	assert.Equal(t, types.ScopeOptional, deps[2].Scope)
+	assert.Equal(t, "git+https://github.com/acme/forked.git", deps[3].Metadata["git"])
+	assert.Equal(t, types.ScopeTest, deps[4].Scope)
+	assert.Equal(t, "unittest", deps[4].Metadata["configuration"])
+	assert.Equal(t, "core", deps[5].Metadata["sub_package"])
+}
+
+func TestDubParser_DependenciesWithSelections(t *testing.T) {
+	pkg := DubPackage{
+		Name: "webapp",
+		Dependencies: []DubDependency{
+			{Name: "vibe-d:http", Version: "~>0.9.5"},
+			{Name: "dyaml", Version: "~>0.9"},
+		},
+	}
+	selections := map[string]DubSelection{
+		"vibe-d":    {Version: "0.9.7"},
+		"vibe-core": {Version: "2.8.4"},
+		"eventcore": {Version: "0.9.30"},
+		"shared":    {Path: "../shared"},
+	}
+
+	deps := NewDubParser().Dependencies(pkg, MetadataSourceDubSDL, selections)
+	require.Len(t, deps, 5)
+
+	assert.Equal(t, "vibe-d:http", deps[0].Name)
+	assert.Equal(t, "0.9.7", deps[0].Version)
+	assert.Equal(t, MetadataSourceDubSelections, deps[0].Metadata["source"])
+	assert.True(t, deps[0].Direct)
+
+	assert.Equal(t, "~>0.9", deps[1].Version)
+	assert.Equal(t, MetadataSourceDubSDL, deps[1].Metadata["source"])
+
+	assert.Equal(t, "eventcore", deps[2].Name)
+	assert.False(t, deps[2].Direct)
+	assert.Equal(t, "0.9.30", deps[2].Version)
+	assert.Equal(t, "shared", deps[3].Name)
+	assert.Equal(t, "latest", deps[3].Version)
+	assert.Equal(t, "../shared", deps[3].Metadata["path"])
+	assert.Equal(t, "vibe-core", deps[4].Name)
+}
@@ -0,0 +1,368 @@
+package parsers
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// maxInterpolationDepth limits nested ${section.option} references in platformio.ini
+const maxInterpolationDepth = 10
+
+// Pre-compiled regexes for PlatformIO and Arduino files
+var (
+	platformIOInterpolationRegex = regexp.MustCompile(`\$\{([^.}]+)\.([^}]+)\}`)
+	arduinoDependsRegex          = regexp.MustCompile(`^([^(]+?)\s*(?:\(\s*([^)]*?)\s*\))?$`)
+)
+
+// PlatformIOProject represents a PlatformIO project configuration (platformio.ini)
+type PlatformIOProject struct {
+	Name         string
+	DefaultEnvs  []string
+	Environments []PlatformIOEnvironment
+}
+
+// PlatformIOEnvironment is a build environment ([env:<name>]) with its options resolved
+// (inherited [env] options, extends, and ${section.option} interpolation)
+type PlatformIOEnvironment struct {
+	Name             string
+	Platform         string
+	PlatformVersion  string
+	Board            string
+	Frameworks       []string
+	PlatformPackages []string
+	LibDeps          []string
+}
+
+// ArduinoLibrary represents an Arduino library descriptor (library.properties)
+type ArduinoLibrary struct {
+	Name          string
+	Version       string
+	Author        string
+	Category      string
+	URL           string
+	Architectures []string
+	Depends       []string
+}
+
+// platformIOConfig holds the sections of platformio.ini in file order
+type platformIOConfig struct {
+	sections map[string]map[string]string
+	order    []string
+}
+
+// EmbeddedParser handles PlatformIO and Arduino project files
+type EmbeddedParser struct{}
+
+// NewEmbeddedParser creates a new embedded project parser
+func NewEmbeddedParser() *EmbeddedParser {
+	return &EmbeddedParser{}
+}
+
+// ParsePlatformIO parses platformio.ini. Each [env:<name>] section becomes an environment whose
+// options fall back to the sections it extends and to the common [env] section.
+func (p *EmbeddedParser) ParsePlatformIO(content string) PlatformIOProject {
+	config := parsePlatformIOConfig(content)
+
+	project := PlatformIOProject{
+		Name:        config.option("platformio", "name", "", 0),
+		DefaultEnvs: splitPlatformIOList(config.option("platformio", "default_envs", "", 0)),
+	}
+	for _, section := range config.order {
+		envName, ok := strings.CutPrefix(section, "env:")
+		if !ok || envName == "" {
+			continue
+		}
+		platform, platformVersion := splitPlatformIOSpec(config.option(section, "platform", section, 0))
+		project.Environments = append(project.Environments, PlatformIOEnvironment{
+			Name:             envName,
+			Platform:         platform,
+			PlatformVersion:  platformVersion,
+			Board:            config.option(section, "board", section, 0),
+			Frameworks:       splitPlatformIOList(config.option(section, "framework", section, 0)),
+			PlatformPackages: splitPlatformIOList(config.option(section, "platform_packages", section, 0)),
+			LibDeps:          splitPlatformIOList(config.option(section, "lib_deps", section, 0)),
+		})
+	}
+	return project
+}
+
+// PlatformIODependencies converts the platforms, platform packages, and libraries of all
+// environments to dependencies. Platforms and platform packages (toolchains, frameworks) are
+// build dependencies; libraries are prod dependencies. Dependencies shared by several
+// environments are listed once, with the environments in the "envs" metadata.
+func (p *EmbeddedParser) PlatformIODependencies(project PlatformIOProject) []types.Dependency {
+	var dependencies []types.Dependency
+	index := make(map[string]int)
+
+	add := func(spec, kind string, scope types.Scope, env string) {
+		dep, ok := platformIODependency(spec, kind, scope)
+		if !ok {
+			return
+		}
+		key := kind + "|" + dep.Name + "|" + dep.Version
+		if i, exists := index[key]; exists {
+			envs := dependencies[i].Metadata["envs"].([]string)
+			if !containsString(envs, env) {
+				dependencies[i].Metadata["envs"] = append(envs, env)
+			}
+			return
+		}
+		dep.Metadata["envs"] = []string{env}
+		index[key] = len(dependencies)
+		dependencies = append(dependencies, dep)
+	}
+
+	for _, env := range project.Environments {
+		if env.Platform != "" {
+			spec := env.Platform
+			if env.PlatformVersion != "" {
+				spec += "@" + env.PlatformVersion
+			}
+			add(spec, "platform", types.ScopeBuild, env.Name)
+		}
+		for _, pkg := range env.PlatformPackages {
+			add(pkg, "tool", types.ScopeBuild, env.Name)
+		}
+		for _, lib := range env.LibDeps {
+			add(lib, "library", types.ScopeProd, env.Name)
+		}
+	}
+	return dependencies
+}
+
+// ParseLibraryProperties parses an Arduino library.properties file. Returns false when the
+// file has no library name.
+func (p *EmbeddedParser) ParseLibraryProperties(content string) (ArduinoLibrary, bool) {
+	properties := make(map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		properties[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	if properties["name"] == "" {
+		return ArduinoLibrary{}, false
+	}
+
+	library := ArduinoLibrary{
+		Name:     properties["name"],
+		Version:  properties["version"],
+		Author:   properties["author"],
+		Category: properties["category"],
+		URL:      properties["url"],
+	}
+	for _, arch := range strings.Split(properties["architectures"], ",") {
+		if arch = strings.TrimSpace(arch); arch != "" {
+			library.Architectures = append(library.Architectures, arch)
+		}
+	}
+	for _, dep := range strings.Split(properties["depends"], ",") {
+		if dep = strings.TrimSpace(dep); dep != "" {
+			library.Depends = append(library.Depends, dep)
+		}
+	}
+	return library, true
+}
+
+// LibraryDependencies converts the depends entries of an Arduino library ("Name (>=1.2.0)")
+// to dependencies; the version keeps the declared constraint.
+func (p *EmbeddedParser) LibraryDependencies(library ArduinoLibrary) []types.Dependency {
+	var dependencies []types.Dependency
+	for _, entry := range library.Depends {
+		match := arduinoDependsRegex.FindStringSubmatch(entry)
+		if match == nil {
+			continue
+		}
+		version := strings.ReplaceAll(match[2], " ", "")
+		if version == "" {
+			version = "latest"
+		}
+		dependencies = append(dependencies, types.Dependency{
+			Type:     DependencyTypeArduino,
+			Name:     match[1],
+			Version:  version,
+			Scope:    types.ScopeProd,
+			Direct:   true,
+			Metadata: types.NewMetadata(MetadataSourceLibraryProperties),
+		})
+	}
+	return dependencies
+}
+
+// parsePlatformIOConfig reads the sections of an INI file. Indented lines continue the value of
+// the previous option (multi-line lists); lines starting with ; or # are comments.
+func parsePlatformIOConfig(content string) platformIOConfig {
+	config := platformIOConfig{sections: make(map[string]map[string]string)}
+	var section, key string
+	for _, raw := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		line := strings.TrimSpace(stripINIComment(raw))
+		if line == "" {
+			continue
+		}
+		if raw[0] == ' ' || raw[0] == '\t' {
+			if section != "" && key != "" {
+				config.sections[section][key] += "\n" + line
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			key = ""
+			if _, exists := config.sections[section]; !exists {
+				config.sections[section] = make(map[string]string)
+				config.order = append(config.order, section)
+			}
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		if !ok || section == "" {
+			key = ""
+			continue
+		}
+		key = strings.TrimSpace(name)
+		config.sections[section][key] = strings.TrimSpace(value)
+	}
+	return config
+}
+
+// option returns the interpolated value of an option. Environment sections fall back to the
+// sections they extend and to [env]; "this" refers to the environment being resolved.
+func (c platformIOConfig) option(section, key, this string, depth int) string {
+	value, ok := c.lookup(section, key, depth)
+	if !ok {
+		return ""
+	}
+	return c.interpolate(value, this, depth)
+}
+
+// lookup finds the raw value of an option, following extends for environment sections
+func (c platformIOConfig) lookup(section, key string, depth int) (string, bool) {
+	if depth > maxInterpolationDepth {
+		return "", false
+	}
+	if value, ok := c.sections[section][key]; ok {
+		return value, true
+	}
+	if !strings.HasPrefix(section, "env:") {
+		return "", false
+	}
+	for _, base := range splitPlatformIOList(c.sections[section]["extends"]) {
+		if value, ok := c.lookup(base, key, depth+1); ok {
+			return value, true
+		}
+	}
+	value, ok := c.sections["env"][key]
+	return value, ok
+}
+
+// interpolate replaces ${section.option} references; system environment variables (${sysenv.X})
+// are unknown at scan time and resolve to an empty value
+func (c platformIOConfig) interpolate(value, this string, depth int) string {
+	if depth >= maxInterpolationDepth {
+		return value
+	}
+	return platformIOInterpolationRegex.ReplaceAllStringFunc(value, func(ref string) string {
+		match := platformIOInterpolationRegex.FindStringSubmatch(ref)
+		section := match[1]
+		if section == "this" {
+			section = this
+		}
+		return c.option(section, match[2], this, depth+1)
+	})
+}
+
+// stripINIComment removes full-line comments and inline "; comment" suffixes
+func stripINIComment(line string) string {
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, ";") || strings.HasPrefix(trimmed, "#") {
+		return ""
+	}
+	if i := strings.Index(line, " ;"); i >= 0 {
+		return line[:i]
+	}
+	return line
+}
+
+// splitPlatformIOList splits a multi-line or comma separated option value
+func splitPlatformIOList(value string) []string {
+	var items []string
+	for _, line := range strings.Split(value, "\n") {
+		for _, item := range strings.Split(line, ", ") {
+			if item = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(item), ",")); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+// splitPlatformIOSpec splits a package specification ("owner/name @ ^1.2.0") into name and version
+func splitPlatformIOSpec(spec string) (string, string) {
+	name, version, _ := strings.Cut(spec, "@")
+	return strings.TrimSpace(name), strings.TrimSpace(version)
+}
+
+// platformIODependency converts a package specification to a dependency. Specifications are
+// registry packages ("owner/name @ version"), repository URLs ("[Name=]https://...git#tag",
+// "owner/name @ https://..."), or local paths (symlink://, file://).
+func platformIODependency(spec, kind string, scope types.Scope) (types.Dependency, bool) {
+	metadata := types.NewMetadata(MetadataSourcePlatformIOIni)
+	metadata["kind"] = kind
+
+	var name, version string
+	alias, location, hasAlias := strings.Cut(spec, "=")
+	if !hasAlias || strings.Contains(alias, "/") || strings.Contains(alias, "@") {
+		alias, location = "", spec
+	}
+	location = strings.TrimSpace(location)
+	if owner, url, ok := strings.Cut(location, "@"); ok && !strings.Contains(owner, "://") && strings.Contains(url, "://") {
+		alias, location = pathBase(strings.TrimSpace(owner)), strings.TrimSpace(url)
+	}
+
+	switch {
+	case strings.HasPrefix(location, "symlink://") || strings.HasPrefix(location, "file://"):
+		path := strings.TrimPrefix(strings.TrimPrefix(location, "symlink://"), "file://")
+		name = pathBase(path)
+		metadata["path"] = path
+	case strings.Contains(location, "://") || strings.HasPrefix(location, "git@"):
+		url, ref, _ := strings.Cut(location, "#")
+		name = strings.TrimSuffix(pathBase(url), ".git")
+		version = ref
+		metadata["url"] = url
+	default:
+		name, version = splitPlatformIOSpec(location)
+	}
+	if alias = strings.TrimSpace(alias); alias != "" {
+		name = alias
+	}
+	if name == "" {
+		return types.Dependency{}, false
+	}
+	if version == "" {
+		version = "latest"
+	}
+	return types.Dependency{
+		Type:     DependencyTypePlatformIO,
+		Name:     name,
+		Version:  version,
+		Scope:    scope,
+		Direct:   true,
+		Metadata: metadata,
+	}, true
+}
+
+// pathBase returns the last element of a slash separated path or URL
+func pathBase(path string) string {
+	path = strings.TrimRight(path, "/")
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		return path[i+1:]
+	}
+	return path
+}
@@ -0,0 +1,130 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedParser_ParsePlatformIO(t *testing.T) {
+	parser := NewEmbeddedParser()
+
+	content := `; PlatformIO Project Configuration File
+[platformio]
+default_envs = esp32dev
+name = weather-station
+
+[common]
+lib_deps_external =
+    bblanchon/ArduinoJson @ ^6.21.3
+    knolleary/PubSubClient@2.8
+
+[env]
+framework = arduino
+monitor_speed = 115200 ; serial monitor
+
+[env:esp32dev]
+platform = espressif32 @ 6.4.0
+board = esp32dev
+platform_packages =
+    platformio/framework-arduinoespressif32 @ https://github.com/espressif/arduino-esp32.git#2.0.14
+lib_deps =
+    ${common.lib_deps_external}
+    Sensors=https://github.com/example/sensors.git#v1.2.0
+    symlink://../shared/display
+
+[env:uno]
+platform = atmelavr
+board = uno
+lib_deps = ${common.lib_deps_external}, adafruit/DHT sensor library
+
+[env:esp32dev_debug]
+extends = env:esp32dev
+build_type = debug
+`
+
+	project := parser.ParsePlatformIO(content)
+	assert.Equal(t, "weather-station", project.Name)
+	assert.Equal(t, []string{"esp32dev"}, project.DefaultEnvs)
+	require.Len(t, project.Environments, 3)
+
+	esp32 := project.Environments[0]
+	assert.Equal(t, "esp32dev", esp32.Name)
+	assert.Equal(t, "espressif32", esp32.Platform)
+	assert.Equal(t, "6.4.0", esp32.PlatformVersion)
+	assert.Equal(t, "esp32dev", esp32.Board)
+	assert.Equal(t, []string{"arduino"}, esp32.Frameworks, "Should inherit options of [env]")
+	assert.Len(t, esp32.LibDeps, 4, "Should resolve ${section.option} references")
+
+	uno := project.Environments[1]
+	assert.Equal(t, "atmelavr", uno.Platform)
+	assert.Empty(t, uno.PlatformVersion)
+	assert.Equal(t, []string{"bblanchon/ArduinoJson @ ^6.21.3", "knolleary/PubSubClient@2.8", "adafruit/DHT sensor library"}, uno.LibDeps)
+
+	debug := project.Environments[2]
+	assert.Equal(t, "espressif32", debug.Platform, "Should follow extends")
+	assert.Equal(t, esp32.LibDeps, debug.LibDeps)
+
+	deps := make(map[string]types.Dependency)
+	for _, dep := range parser.PlatformIODependencies(project) {
+		deps[dep.Name] = dep
+	}
+	assert.Len(t, deps, 8)
+
+	assert.Equal(t, types.Dependency{
+		Type: DependencyTypePlatformIO, Name: "espressif32", Version: "6.4.0", Scope: types.ScopeBuild, Direct: true,
+		Metadata: map[string]interface{}{"source": "platformio.ini", "kind": "platform", "envs": []string{"esp32dev", "esp32dev_debug"}},
+	}, deps["espressif32"])
+	assert.Equal(t, "latest", deps["atmelavr"].Version)
+
+	tool := deps["framework-arduinoespressif32"]
+	assert.Equal(t, "tool", tool.Metadata["kind"])
+	assert.Equal(t, "2.0.14", tool.Version)
+	assert.Equal(t, "https://github.com/espressif/arduino-esp32.git", tool.Metadata["url"])
+
+	json := deps["bblanchon/ArduinoJson"]
+	assert.Equal(t, "^6.21.3", json.Version)
+	assert.Equal(t, types.ScopeProd, json.Scope)
+	assert.Equal(t, []string{"esp32dev", "uno", "esp32dev_debug"}, json.Metadata["envs"])
+	assert.Equal(t, "2.8", deps["knolleary/PubSubClient"].Version)
+	assert.Equal(t, "latest", deps["adafruit/DHT sensor library"].Version)
+
+	assert.Equal(t, "v1.2.0", deps["Sensors"].Version, "Should use the alias of repository dependencies")
+	assert.Equal(t, "../shared/display", deps["display"].Metadata["path"])
+}
+
+func TestEmbeddedParser_ParseLibraryProperties(t *testing.T) {
+	parser := NewEmbeddedParser()
+
+	content := `name=Weather Sensors
+version=1.3.0
+author=Example <dev@example.com>
+sentence=Read weather sensors.
+category=Sensors
+url=https://github.com/example/weather-sensors
+architectures=esp32, avr
+depends=Adafruit Unified Sensor, DHT sensor library (>=1.4.0), ArduinoJson (>= 6.0.0)
+`
+
+	library, ok := parser.ParseLibraryProperties(content)
+	require.True(t, ok)
+	assert.Equal(t, "Weather Sensors", library.Name)
+	assert.Equal(t, "1.3.0", library.Version)
+	assert.Equal(t, "Sensors", library.Category)
+	assert.Equal(t, []string{"esp32", "avr"}, library.Architectures)
+
+	deps := parser.LibraryDependencies(library)
+	require.Len(t, deps, 3)
+	assert.Equal(t, types.Dependency{
+		Type: DependencyTypeArduino, Name: "Adafruit Unified Sensor", Version: "latest", Scope: types.ScopeProd, Direct: true,
+		Metadata: map[string]interface{}{"source": "library.properties"},
+	}, deps[0])
+	assert.Equal(t, "DHT sensor library", deps[1].Name)
+	assert.Equal(t, ">=1.4.0", deps[1].Version)
+	assert.Equal(t, ">=6.0.0", deps[2].Version)
+
+	_, ok = parser.ParseLibraryProperties("version=1.0.0\n")
+	assert.False(t, ok)
+}
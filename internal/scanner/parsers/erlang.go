@@ -0,0 +1,429 @@
+package parsers
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// RebarConfig represents the dependency related entries of a rebar.config file
+type RebarConfig struct {
+	MinimumOTPVersion string
+	Deps              []RebarDep
+	ProfileDeps       map[string][]RebarDep // Profile name -> deps of the profile
+	Plugins           []RebarDep            // plugins and project_plugins
+}
+
+// RebarDep is a dependency declaration of rebar.config: a Hex package (with an optional
+// version requirement and package name) or a git/hg source
+type RebarDep struct {
+	Name        string
+	Requirement string // Hex version requirement ("~> 1.1", "3.1.0")
+	Package     string // Hex package name when it differs from the application name
+	SourceType  string // git, git_subdir, hg
+	URL         string
+	RefType     string // tag, ref, branch
+	Ref         string
+}
+
+// ErlangParser handles rebar3 build files and OTP application resource files
+type ErlangParser struct{}
+
+// NewErlangParser creates a new Erlang parser
+func NewErlangParser() *ErlangParser {
+	return &ErlangParser{}
+}
+
+// ParseRebarConfig parses the deps, profiles, plugins, and minimum_otp_vsn entries of rebar.config
+func (p *ErlangParser) ParseRebarConfig(content string) RebarConfig {
+	config := RebarConfig{ProfileDeps: make(map[string][]RebarDep)}
+	for _, term := range parseErlangTerms(content) {
+		key, value, ok := term.keyValue()
+		if !ok {
+			continue
+		}
+		switch key {
+		case "deps":
+			config.Deps = parseRebarDeps(value)
+		case "plugins", "project_plugins":
+			config.Plugins = append(config.Plugins, parseRebarDeps(value)...)
+		case "minimum_otp_vsn":
+			config.MinimumOTPVersion = value.value
+		case "profiles":
+			for _, profile := range value.items {
+				name, settings, ok := profile.keyValue()
+				if !ok {
+					continue
+				}
+				for _, setting := range settings.items {
+					if settingKey, deps, ok := setting.keyValue(); ok && settingKey == "deps" {
+						config.ProfileDeps[name] = append(config.ProfileDeps[name], parseRebarDeps(deps)...)
+					}
+				}
+			}
+		}
+	}
+	return config
+}
+
+// RebarConfigDependencies converts the declarations of rebar.config to dependencies. Default
+// deps are prod dependencies; deps of the test profile are test dependencies and deps of other
+// profiles dev dependencies (with the "profile" metadata); plugins are build dependencies.
+// When locked is set, the default deps are skipped (rebar.lock provides them).
+func (p *ErlangParser) RebarConfigDependencies(config RebarConfig, locked bool) []types.Dependency {
+	var dependencies []types.Dependency
+	if !locked {
+		for _, dep := range config.Deps {
+			dependencies = append(dependencies, rebarDependency(dep, types.ScopeProd))
+		}
+	}
+
+	profiles := make([]string, 0, len(config.ProfileDeps))
+	for profile := range config.ProfileDeps {
+		profiles = append(profiles, profile)
+	}
+	sort.Strings(profiles)
+	for _, profile := range profiles {
+		scope := types.ScopeDev
+		if profile == "test" {
+			scope = types.ScopeTest
+		}
+		for _, dep := range config.ProfileDeps[profile] {
+			dependency := rebarDependency(dep, scope)
+			dependency.Metadata["profile"] = profile
+			dependencies = append(dependencies, dependency)
+		}
+	}
+
+	for _, plugin := range config.Plugins {
+		dependency := rebarDependency(plugin, types.ScopeBuild)
+		dependency.Metadata["plugin"] = true
+		dependencies = append(dependencies, dependency)
+	}
+	return NormalizeDependencyVersions(dependencies)
+}
+
+// ParseRebarLock parses rebar.lock. Entries of level 0 are direct dependencies, deeper levels
+// transitive ones. Hex packages have their exact version; git sources their commit ref.
+func (p *ErlangParser) ParseRebarLock(content string) []types.Dependency {
+	terms := parseErlangTerms(content)
+	if len(terms) == 0 {
+		return nil
+	}
+	// rebar3 >= 3.5 wraps the entries in {"1.2.0", [...]}; older versions write the list alone
+	entries := terms[0]
+	if entries.kind == erlTuple && len(entries.items) == 2 {
+		entries = entries.items[1]
+	}
+
+	var dependencies []types.Dependency
+	for _, entry := range entries.items {
+		if entry.kind != erlTuple || len(entry.items) < 3 {
+			continue
+		}
+		name := entry.items[0].value
+		source := entry.items[1]
+		if name == "" || source.kind != erlTuple || len(source.items) < 2 {
+			continue
+		}
+		metadata := types.NewMetadata(MetadataSourceRebarLock)
+		version := "latest"
+		switch source.items[0].value {
+		case "pkg":
+			if pkg := source.items[1].value; pkg != "" && pkg != name {
+				metadata["package"] = pkg
+			}
+			if len(source.items) > 2 && source.items[2].value != "" {
+				version = source.items[2].value
+			}
+		case "git", "git_subdir", "hg":
+			metadata["git"] = source.items[1].value
+			if len(source.items) > 2 && source.items[2].kind == erlTuple && len(source.items[2].items) == 2 {
+				version = source.items[2].items[1].value
+			}
+		default:
+			continue
+		}
+		dependencies = append(dependencies, types.Dependency{
+			Type:     DependencyTypeHex,
+			Name:     name,
+			Version:  version,
+			Scope:    types.ScopeProd,
+			Direct:   entry.items[2].value == "0",
+			Metadata: metadata,
+		})
+	}
+	return NormalizeDependencyVersions(dependencies)
+}
+
+// ParseAppSrc returns the application name and version (vsn) of an OTP application resource
+// file (src/<app>.app.src)
+func (p *ErlangParser) ParseAppSrc(content string) (string, string) {
+	for _, term := range parseErlangTerms(content) {
+		if term.kind != erlTuple || len(term.items) < 3 || term.items[0].value != "application" {
+			continue
+		}
+		var version string
+		for _, property := range term.items[2].items {
+			if key, value, ok := property.keyValue(); ok && key == "vsn" {
+				version = value.value
+			}
+		}
+		return term.items[1].value, version
+	}
+	return "", ""
+}
+
+// parseRebarDeps parses a deps list: name, {name, Vsn}, {name, {pkg, Package}},
+// {name, Source}, or {name, Vsn, Source} with Source {git, Url, {tag|ref|branch, Value}}
+func parseRebarDeps(list erlTerm) []RebarDep {
+	var deps []RebarDep
+	for _, item := range list.items {
+		switch item.kind {
+		case erlAtom:
+			deps = append(deps, RebarDep{Name: item.value})
+		case erlTuple:
+			if len(item.items) == 0 || item.items[0].kind != erlAtom {
+				continue
+			}
+			dep := RebarDep{Name: item.items[0].value}
+			for _, spec := range item.items[1:] {
+				switch {
+				case spec.kind == erlString || spec.kind == erlBinary:
+					dep.Requirement = spec.value
+				case spec.kind == erlTuple && len(spec.items) >= 2 && spec.items[0].value == "pkg":
+					dep.Package = spec.items[1].value
+				case spec.kind == erlTuple && len(spec.items) >= 2:
+					dep.SourceType = spec.items[0].value
+					dep.URL = spec.items[1].value
+					if len(spec.items) > 2 && spec.items[2].kind == erlTuple && len(spec.items[2].items) == 2 {
+						dep.RefType = spec.items[2].items[0].value
+						dep.Ref = spec.items[2].items[1].value
+					}
+				}
+			}
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// rebarDependency converts a rebar.config declaration to a dependency. Legacy ".*" requirements
+// of source deps are ignored; git deps use their tag or commit as version, branch deps the
+// branch name (with the "branch" metadata).
+func rebarDependency(dep RebarDep, scope types.Scope) types.Dependency {
+	metadata := types.NewMetadata(MetadataSourceRebarConfig)
+	version := dep.Requirement
+	if dep.Package != "" && dep.Package != dep.Name {
+		metadata["package"] = dep.Package
+	}
+	if dep.URL != "" {
+		metadata["git"] = dep.URL
+		version = dep.Ref
+		if dep.RefType == "branch" {
+			metadata["branch"] = dep.Ref
+		}
+	}
+	if version == "" || version == ".*" {
+		version = "latest"
+	}
+	return types.Dependency{
+		Type:     DependencyTypeHex,
+		Name:     dep.Name,
+		Version:  version,
+		Scope:    scope,
+		Direct:   true,
+		Metadata: metadata,
+	}
+}
+
+// --- Erlang terms ---
+
+const (
+	erlAtom = iota
+	erlString
+	erlBinary
+	erlNumber
+	erlTuple
+	erlList
+	erlMap
+)
+
+// erlTerm is an Erlang term of a consult file; atoms, strings, binaries, and numbers keep their
+// text in value, tuples, lists, and maps (alternating keys and values) their elements in items
+type erlTerm struct {
+	kind  int
+	value string
+	items []erlTerm
+}
+
+// keyValue returns the key and value of a {key, Value} tuple
+func (t erlTerm) keyValue() (string, erlTerm, bool) {
+	if t.kind != erlTuple || len(t.items) != 2 || t.items[0].kind != erlAtom {
+		return "", erlTerm{}, false
+	}
+	return t.items[0].value, t.items[1], true
+}
+
+// parseErlangTerms parses the dot terminated terms of a file read by file:consult/1
+// (rebar.config, rebar.lock, .app.src). Unparsable terms are skipped.
+func parseErlangTerms(content string) []erlTerm {
+	parser := &erlParser{input: content}
+	var terms []erlTerm
+	for {
+		parser.skipSpace()
+		if parser.pos >= len(parser.input) {
+			return terms
+		}
+		term, ok := parser.parseTerm()
+		parser.skipSpace()
+		if parser.pos >= len(parser.input) {
+			return terms // Truncated term
+		}
+		if ok && parser.input[parser.pos] == '.' {
+			terms = append(terms, term)
+			parser.pos++
+			continue
+		}
+		// Skip to the end of the malformed term
+		end := strings.Index(parser.input[parser.pos:], ".\n")
+		if end < 0 {
+			return terms
+		}
+		parser.pos += end + 2
+	}
+}
+
+type erlParser struct {
+	input string
+	pos   int
+}
+
+// parseTerm parses a single term
+func (e *erlParser) parseTerm() (erlTerm, bool) {
+	e.skipSpace()
+	if e.pos >= len(e.input) {
+		return erlTerm{}, false
+	}
+	switch c := e.input[e.pos]; {
+	case c == '{':
+		e.pos++
+		items, ok := e.parseSequence('}')
+		return erlTerm{kind: erlTuple, items: items}, ok
+	case c == '[':
+		e.pos++
+		items, ok := e.parseSequence(']')
+		return erlTerm{kind: erlList, items: items}, ok
+	case strings.HasPrefix(e.input[e.pos:], "#{"):
+		e.pos += 2
+		items, ok := e.parseSequence('}')
+		return erlTerm{kind: erlMap, items: items}, ok
+	case strings.HasPrefix(e.input[e.pos:], "<<"):
+		e.pos += 2
+		e.skipSpace()
+		var value string
+		if e.pos < len(e.input) && e.input[e.pos] == '"' {
+			var ok bool
+			if value, ok = e.parseQuoted('"'); !ok {
+				return erlTerm{}, false
+			}
+		}
+		e.skipSpace()
+		if !strings.HasPrefix(e.input[e.pos:], ">>") {
+			return erlTerm{}, false
+		}
+		e.pos += 2
+		return erlTerm{kind: erlBinary, value: value}, true
+	case c == '"':
+		value, ok := e.parseQuoted('"')
+		return erlTerm{kind: erlString, value: value}, ok
+	case c == '\'':
+		value, ok := e.parseQuoted('\'')
+		return erlTerm{kind: erlAtom, value: value}, ok
+	case c >= 'a' && c <= 'z':
+		start := e.pos
+		for e.pos < len(e.input) && isErlNameChar(e.input[e.pos]) {
+			e.pos++
+		}
+		return erlTerm{kind: erlAtom, value: e.input[start:e.pos]}, true
+	case c == '-' || (c >= '0' && c <= '9'):
+		start := e.pos
+		e.pos++
+		for e.pos < len(e.input) && (isErlNameChar(e.input[e.pos]) || e.input[e.pos] == '#' || (e.input[e.pos] == '.' && e.pos+1 < len(e.input) && e.input[e.pos+1] >= '0' && e.input[e.pos+1] <= '9')) {
+			e.pos++
+		}
+		return erlTerm{kind: erlNumber, value: e.input[start:e.pos]}, true
+	}
+	return erlTerm{}, false
+}
+
+// parseSequence parses comma separated terms up to the closing character; map associations
+// (Key => Value) are flattened into alternating keys and values
+func (e *erlParser) parseSequence(closing byte) ([]erlTerm, bool) {
+	var items []erlTerm
+	for {
+		e.skipSpace()
+		if e.pos >= len(e.input) {
+			return items, false
+		}
+		if e.input[e.pos] == closing {
+			e.pos++
+			return items, true
+		}
+		term, ok := e.parseTerm()
+		if !ok {
+			return items, false
+		}
+		items = append(items, term)
+		e.skipSpace()
+		switch {
+		case strings.HasPrefix(e.input[e.pos:], "=>"):
+			e.pos += 2
+		case strings.HasPrefix(e.input[e.pos:], ":="):
+			e.pos += 2
+		case e.pos < len(e.input) && (e.input[e.pos] == ',' || e.input[e.pos] == '|'):
+			e.pos++
+		}
+	}
+}
+
+// parseQuoted parses a quoted string or atom with escape sequences; false when the closing
+// quote is missing
+func (e *erlParser) parseQuoted(quote byte) (string, bool) {
+	e.pos++ // opening quote
+	var b strings.Builder
+	for e.pos < len(e.input) && e.input[e.pos] != quote {
+		if e.input[e.pos] == '\\' && e.pos+1 < len(e.input) {
+			e.pos++
+		}
+		b.WriteByte(e.input[e.pos])
+		e.pos++
+	}
+	if e.pos >= len(e.input) {
+		return b.String(), false
+	}
+	e.pos++ // closing quote
+	return b.String(), true
+}
+
+// skipSpace skips whitespace and % comments
+func (e *erlParser) skipSpace() {
+	for e.pos < len(e.input) {
+		switch e.input[e.pos] {
+		case ' ', '\t', '\r', '\n':
+			e.pos++
+		case '%':
+			for e.pos < len(e.input) && e.input[e.pos] != '\n' {
+				e.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// isErlNameChar reports whether a character can continue an unquoted atom or a number
+func isErlNameChar(c byte) bool {
+	return c == '_' || c == '@' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
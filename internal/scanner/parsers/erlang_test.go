@@ -0,0 +1,154 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErlangParser_ParseRebarConfig(t *testing.T) {
+	parser := NewErlangParser()
+
+	content := `%% -*- mode: erlang -*-
+{erl_opts, [debug_info, {i, "include"}]}.
+{minimum_otp_vsn, "25.0"}.
+
+{deps, [
+    cowboy,
+    {jsx, "3.1.0"},
+    {jiffy, "~> 1.1"},
+    {hackney_alias, {pkg, hackney}},
+    {lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}},
+    {meck, ".*", {git, "git://github.com/eproxus/meck.git", {branch, "master"}}},
+    {recon, {git, "https://github.com/ferd/recon.git", {ref, "f7b6c08e6e9e2219db58bfb012e58c178822e01e"}}}
+]}.
+
+{profiles, [
+    {test, [{deps, [proper, {meck, "0.9.2"}]}]},
+    {docs, [{deps, [edown]}, {edoc_opts, [{doclet, edown_doclet}]}]}
+]}.
+
+{project_plugins, [rebar3_format, {rebar3_lint, "3.0.1"}]}.
+{relx, [{release, {myapp, "0.1.0"}, [myapp, sasl]}, {mode, dev}]}.
+`
+
+	config := parser.ParseRebarConfig(content)
+	assert.Equal(t, "25.0", config.MinimumOTPVersion)
+	require.Len(t, config.Deps, 7)
+	assert.Len(t, config.ProfileDeps["test"], 2)
+	assert.Len(t, config.Plugins, 2)
+
+	deps := parser.RebarConfigDependencies(config, false)
+	require.Len(t, deps, 12)
+
+	assert.Equal(t, types.Dependency{
+		Type: DependencyTypeHex, Name: "cowboy", Version: "latest", Scope: types.ScopeProd, Direct: true,
+		Metadata: map[string]interface{}{"source": "rebar.config"},
+	}, deps[0])
+	assert.Equal(t, "3.1.0", deps[1].Version)
+	assert.Equal(t, "~> 1.1", deps[2].Version)
+	assert.Equal(t, "hackney", deps[3].Metadata["package"])
+
+	lager := deps[4]
+	assert.Equal(t, "3.9.2", lager.Version)
+	assert.Equal(t, "https://github.com/erlang-lager/lager.git", lager.Metadata["git"])
+	assert.Equal(t, "master", deps[5].Version, "Should ignore legacy .* requirements")
+	assert.Equal(t, "master", deps[5].Metadata["branch"])
+	assert.Equal(t, "f7b6c08e6e9e2219db58bfb012e58c178822e01e", deps[6].Version)
+
+	assert.Equal(t, "edown", deps[7].Name, "Should sort profiles")
+	assert.Equal(t, types.ScopeDev, deps[7].Scope)
+	assert.Equal(t, "docs", deps[7].Metadata["profile"])
+	assert.Equal(t, types.ScopeTest, deps[8].Scope)
+	assert.Equal(t, "0.9.2", deps[9].Version)
+
+	assert.Equal(t, types.ScopeBuild, deps[10].Scope)
+	assert.Equal(t, true, deps[10].Metadata["plugin"])
+	assert.Equal(t, "3.0.1", deps[11].Version)
+
+	assert.Len(t, parser.RebarConfigDependencies(config, true), 5, "Should leave the default deps to rebar.lock")
+}
+
+func TestErlangParser_ParseRebarLock(t *testing.T) {
+	parser := NewErlangParser()
+
+	content := `{"1.2.0",
+[{<<"cowboy">>,{pkg,<<"cowboy">>,<<"2.10.0">>},0},
+ {<<"cowlib">>,{pkg,<<"cowlib">>,<<"2.12.1">>},1},
+ {<<"hackney_alias">>,{pkg,<<"hackney">>,<<"1.20.1">>},0},
+ {<<"lager">>,
+  {git,"https://github.com/erlang-lager/lager.git",
+       {ref,"459a3b2cdd9eadd29e5a7ce5c43932f5ccd6eb88"}},
+  0}]}.
+[
+{pkg_hash,[
+ {<<"cowboy">>, <<"FF9FF3E1A5FD4C01CE3E8C8F1A1E4C23A7AB8E2F3B4C5D6E7F8A9B0C1D2E3F4A">>}]}
+].
+`
+
+	deps := parser.ParseRebarLock(content)
+	require.Len(t, deps, 4)
+	assert.Equal(t, types.Dependency{
+		Type: DependencyTypeHex, Name: "cowboy", Version: "2.10.0", Scope: types.ScopeProd, Direct: true,
+		Metadata: map[string]interface{}{"source": "rebar.lock"},
+	}, deps[0])
+	assert.False(t, deps[1].Direct, "Level 1 entries are transitive")
+	assert.Equal(t, "hackney", deps[2].Metadata["package"])
+	assert.Equal(t, "459a3b2cdd9eadd29e5a7ce5c43932f5ccd6eb88", deps[3].Version)
+	assert.Equal(t, "https://github.com/erlang-lager/lager.git", deps[3].Metadata["git"])
+
+	legacy := parser.ParseRebarLock(`[{<<"jsx">>,{pkg,<<"jsx">>,<<"2.9.0">>},0}].`)
+	require.Len(t, legacy, 1)
+	assert.Equal(t, "2.9.0", legacy[0].Version)
+}
+
+func TestErlangParser_ParseAppSrc(t *testing.T) {
+	parser := NewErlangParser()
+
+	app, version := parser.ParseAppSrc(`{application, 'my_app',
+ [{description, "An OTP application"},
+  {vsn, "0.4.2"},
+  {registered, []},
+  {mod, {my_app_app, []}},
+  {applications, [kernel, stdlib, cowboy]},
+  {env, #{port => 8080}}
+ ]}.`)
+	assert.Equal(t, "my_app", app)
+	assert.Equal(t, "0.4.2", version)
+}
+
+func TestErlangParser_TruncatedTerms(t *testing.T) {
+	parser := NewErlangParser()
+
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{name: "lone double quote", content: `"`},
+		{name: "lone single quote", content: `'`},
+		{name: "unterminated string", content: `{deps, [{jsx, "3.1.0}]}.`},
+		{name: "unterminated atom", content: `{deps, ['jsx]}.`},
+		{name: "unterminated binary", content: `{deps, [{jsx, <<"3.1.0`},
+		{name: "trailing escape", content: `{deps, [{jsx, "3.1.0\`},
+		{name: "truncated tuple", content: `{deps, [cowboy, {jsx,`},
+		{name: "missing dot", content: `{deps, [cowboy]}`},
+		{name: "truncated after complete term", content: "{deps, [cowboy]}.\n{erl_opts, [\"", expected: []string{"cowboy"}},
+		{name: "malformed term before complete term", content: "{deps, [cowboy}.\n{deps, [jsx]}.\n", expected: []string{"jsx"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			require.NotPanics(t, func() {
+				for _, dep := range parser.ParseRebarConfig(tt.content).Deps {
+					names = append(names, dep.Name)
+				}
+				parser.ParseRebarLock(tt.content)
+				parser.ParseAppSrc(tt.content)
+			})
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
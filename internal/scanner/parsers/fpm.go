@@ -0,0 +1,320 @@
+package parsers
+
+import (
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// fpmMetapackages are the dependencies fpm resolves to system libraries or bundled packages
+// (declared as name = "*")
+var fpmMetapackages = map[string]bool{
+	"openmp":  true,
+	"stdlib":  true,
+	"minpack": true,
+	"mpi":     true,
+	"hdf5":    true,
+	"netcdf":  true,
+	"fftw":    true,
+	"blas":    true,
+}
+
+// FpmPackage represents a Fortran Package Manager manifest (fpm.toml)
+type FpmPackage struct {
+	Name         string
+	Version      string
+	License      string
+	Author       string
+	Link         []string // System libraries linked by the build ([build] link)
+	Executables  []string
+	Dependencies []FpmDependency
+}
+
+// FpmDependency is a dependency of fpm.toml: a git repository (with tag, branch, or rev), a
+// local path, a registry package, or a metapackage. Scope is prod for [dependencies], dev for
+// [dev-dependencies], and test for the dependencies of [[test]] targets.
+type FpmDependency struct {
+	Name      string
+	Version   string
+	Git       string
+	Tag       string
+	Branch    string
+	Rev       string
+	Path      string
+	Namespace string
+	Scope     types.Scope
+}
+
+// FpmParser handles Fortran Package Manager manifests
+type FpmParser struct{}
+
+// NewFpmParser creates a new fpm parser
+func NewFpmParser() *FpmParser {
+	return &FpmParser{}
+}
+
+// ParseFpmToml parses fpm.toml. Dependencies are read from the [dependencies] and
+// [dev-dependencies] tables, their dotted forms ([dependencies.name]), and the dependency
+// tables of [[executable]], [[example]], and [[test]] targets.
+func (p *FpmParser) ParseFpmToml(content string) FpmPackage {
+	var pkg FpmPackage
+	byKey := make(map[string]*FpmDependency)
+	var order []string
+	dependency := func(name string, scope types.Scope) *FpmDependency {
+		key := string(scope) + "/" + name
+		if dep, ok := byKey[key]; ok {
+			return dep
+		}
+		byKey[key] = &FpmDependency{Name: name, Scope: scope}
+		order = append(order, key)
+		return byKey[key]
+	}
+
+	section, target := "", ""
+	for _, rawLine := range strings.Split(content, "\n") {
+		line := strings.TrimSpace(stripTomlComment(rawLine))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			header := strings.TrimSpace(strings.Trim(line, "[]"))
+			if strings.HasPrefix(line, "[[") {
+				target, section = header, header
+				continue
+			}
+			section = header
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		switch {
+		case section == "":
+			switch key {
+			case "name":
+				pkg.Name = tomlString(value)
+			case "version":
+				pkg.Version = tomlString(value)
+			case "license":
+				pkg.License = tomlString(value)
+			case "author":
+				pkg.Author = tomlString(value)
+			}
+		case section == "build" && key == "link":
+			pkg.Link = append(pkg.Link, tomlStrings(value)...)
+		case section == target && key == "name" && target == "executable":
+			pkg.Executables = append(pkg.Executables, tomlString(value))
+		default:
+			scope, name, ok := fpmDependencySection(section, target)
+			if !ok {
+				continue
+			}
+			if name != "" {
+				// Dotted table: [dependencies.name] with one field per line
+				setFpmField(dependency(name, scope), key, value)
+				continue
+			}
+			if field, rest, dotted := strings.Cut(key, "."); dotted {
+				// Dotted key: name.git = "..."
+				setFpmField(dependency(strings.TrimSpace(field), scope), strings.TrimSpace(rest), value)
+				continue
+			}
+			dep := dependency(key, scope)
+			if strings.HasPrefix(value, "{") {
+				for field, fieldValue := range tomlInlineTable(value) {
+					setFpmField(dep, field, fieldValue)
+				}
+			} else {
+				dep.Version = tomlString(value)
+			}
+		}
+	}
+	for _, key := range order {
+		pkg.Dependencies = append(pkg.Dependencies, *byKey[key])
+	}
+	return pkg
+}
+
+// Dependencies converts the manifest dependencies and linked libraries to dependencies. Git
+// dependencies use their tag, rev, or branch as version (with the "git" and, for branches,
+// "branch" metadata); metapackages are flagged with "metapackage"; libraries of [build] link
+// are flagged with "link".
+func (p *FpmParser) Dependencies(pkg FpmPackage) []types.Dependency {
+	var dependencies []types.Dependency
+	for _, dep := range pkg.Dependencies {
+		metadata := types.NewMetadata(MetadataSourceFpmToml)
+		version := dep.Version
+		switch {
+		case dep.Path != "":
+			metadata["path"] = dep.Path
+		case dep.Git != "":
+			metadata["git"] = dep.Git
+			switch {
+			case dep.Tag != "":
+				version = dep.Tag
+			case dep.Rev != "":
+				version = dep.Rev
+			case dep.Branch != "":
+				version = dep.Branch
+				metadata["branch"] = dep.Branch
+			}
+		}
+		if dep.Namespace != "" {
+			metadata["namespace"] = dep.Namespace
+		}
+		if fpmMetapackages[dep.Name] && dep.Version == "*" && dep.Git == "" && dep.Path == "" {
+			metadata["metapackage"] = true
+		}
+		if version == "" || version == "*" {
+			version = "latest"
+		}
+		dependencies = append(dependencies, types.Dependency{
+			Type:     DependencyTypeFpm,
+			Name:     dep.Name,
+			Version:  version,
+			Scope:    dep.Scope,
+			Direct:   true,
+			Metadata: metadata,
+		})
+	}
+	for _, library := range pkg.Link {
+		metadata := types.NewMetadata(MetadataSourceFpmToml)
+		metadata["link"] = true
+		dependencies = append(dependencies, types.Dependency{
+			Type:     DependencyTypeFpm,
+			Name:     library,
+			Version:  "latest",
+			Scope:    types.ScopeProd,
+			Direct:   true,
+			Metadata: metadata,
+		})
+	}
+	return dependencies
+}
+
+// fpmTable is a dependency table of fpm.toml and the scope of its dependencies
+type fpmTable struct {
+	prefix string
+	scope  types.Scope
+}
+
+// fpmTargetTables are the dependency tables of the [[executable]], [[example]], and [[test]] targets
+var fpmTargetTables = map[string]fpmTable{
+	"executable": {"executable.dependencies", types.ScopeProd},
+	"example":    {"example.dependencies", types.ScopeDev},
+	"test":       {"test.dependencies", types.ScopeTest},
+}
+
+// fpmDependencySection returns the scope of a dependency table and, for dotted tables
+// ([dependencies.name]), the dependency name
+func fpmDependencySection(section, target string) (types.Scope, string, bool) {
+	tables := []fpmTable{
+		{"dependencies", types.ScopeProd},
+		{"dev-dependencies", types.ScopeDev},
+	}
+	if table, ok := fpmTargetTables[target]; ok {
+		tables = append(tables, table)
+	}
+	for _, table := range tables {
+		if section == table.prefix {
+			return table.scope, "", true
+		}
+		if name, ok := strings.CutPrefix(section, table.prefix+"."); ok {
+			return table.scope, strings.Trim(name, `"`), true
+		}
+	}
+	return "", "", false
+}
+
+// setFpmField sets a field of a dependency table
+func setFpmField(dep *FpmDependency, key, value string) {
+	value = tomlString(value)
+	switch key {
+	case "git":
+		dep.Git = value
+	case "tag":
+		dep.Tag = value
+	case "branch":
+		dep.Branch = value
+	case "rev":
+		dep.Rev = value
+	case "path":
+		dep.Path = value
+	case "namespace":
+		dep.Namespace = value
+	case "v", "version":
+		dep.Version = value
+	}
+}
+
+// tomlString returns the value of a TOML string (or the raw value of other scalars)
+func tomlString(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// tomlInlineTable splits an inline table ({ key = "value", ... }) into its fields; array and
+// table values are kept as written
+func tomlInlineTable(value string) map[string]string {
+	fields := make(map[string]string)
+	value = strings.TrimSpace(value)
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "{"), "}")
+	var quote byte
+	depth := 0
+	start := 0
+	for i := 0; i <= len(value); i++ {
+		if i < len(value) {
+			c := value[i]
+			if quote != 0 {
+				if c == quote {
+					quote = 0
+				}
+				continue
+			}
+			switch c {
+			case '"', '\'':
+				quote = c
+				continue
+			case '[', '{':
+				depth++
+				continue
+			case ']', '}':
+				depth--
+				continue
+			}
+			if c != ',' || depth > 0 {
+				continue
+			}
+		}
+		if key, fieldValue, ok := strings.Cut(value[start:i], "="); ok {
+			fields[strings.TrimSpace(key)] = strings.TrimSpace(fieldValue)
+		}
+		start = i + 1
+	}
+	return fields
+}
+
+// stripTomlComment removes a trailing # comment outside of strings
+func stripTomlComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
@@ -0,0 +1,99 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testFpmToml = `name = "heat_solver"
+version = "1.3.0"
+license = "BSD-3-Clause"
+author = "Research Group"
+
+[build]
+auto-executables = true
+link = ["lapack", "blas"]
+
+[dependencies]
+stdlib = "*"
+openmp = "*"
+toml-f = { git = "https://github.com/toml-f/toml-f", tag = "v0.4.2" }
+M_CLI2 = { git = "https://github.com/urbanjost/M_CLI2.git", branch = "main" } # CLI parsing
+utils = { path = "../utils" }
+fftpack = { namespace = "fortran-lang", v = "0.2.0" }
+
+[dependencies.json-fortran]
+git = "https://github.com/jacobwilliams/json-fortran.git"
+rev = "3ab8f98209871875325c6985dd0e50085d1c82c2"
+
+[dev-dependencies]
+test-drive.git = "https://github.com/fortran-lang/test-drive"
+test-drive.tag = "v0.5.0"
+
+[[executable]]
+name = "heat"
+main = "main.f90"
+
+[[test]]
+name = "regression"
+[test.dependencies]
+veggies = { git = "https://gitlab.com/everythingfunctional/veggies", tag = "v1.1.3" }
+`
+
+func TestFpmParser_ParseFpmToml(t *testing.T) {
+	pkg := NewFpmParser().ParseFpmToml(testFpmToml)
+
+	assert.Equal(t, "heat_solver", pkg.Name)
+	assert.Equal(t, "1.3.0", pkg.Version)
+	assert.Equal(t, "BSD-3-Clause", pkg.License)
+	assert.Equal(t, "Research Group", pkg.Author)
+	assert.Equal(t, []string{"lapack", "blas"}, pkg.Link)
+	assert.Equal(t, []string{"heat"}, pkg.Executables)
+
+	require.Len(t, pkg.Dependencies, 9)
+	assert.Equal(t, FpmDependency{Name: "stdlib", Version: "*", Scope: types.ScopeProd}, pkg.Dependencies[0])
+	assert.Equal(t, FpmDependency{Name: "toml-f", Git: "https://github.com/toml-f/toml-f", Tag: "v0.4.2", Scope: types.ScopeProd}, pkg.Dependencies[2])
+	assert.Equal(t, FpmDependency{Name: "M_CLI2", Git: "https://github.com/urbanjost/M_CLI2.git", Branch: "main", Scope: types.ScopeProd}, pkg.Dependencies[3])
+	assert.Equal(t, FpmDependency{Name: "utils", Path: "../utils", Scope: types.ScopeProd}, pkg.Dependencies[4])
+	assert.Equal(t, FpmDependency{Name: "fftpack", Version: "0.2.0", Namespace: "fortran-lang", Scope: types.ScopeProd}, pkg.Dependencies[5])
+	assert.Equal(t, FpmDependency{Name: "json-fortran", Git: "https://github.com/jacobwilliams/json-fortran.git", Rev: "3ab8f98209871875325c6985dd0e50085d1c82c2", Scope: types.ScopeProd}, pkg.Dependencies[6])
+	assert.Equal(t, FpmDependency{Name: "test-drive", Git: "https://github.com/fortran-lang/test-drive", Tag: "v0.5.0", Scope: types.ScopeDev}, pkg.Dependencies[7])
+	assert.Equal(t, FpmDependency{Name: "veggies", Git: "https://gitlab.com/everythingfunctional/veggies", Tag: "v1.1.3", Scope: types.ScopeTest}, pkg.Dependencies[8])
+}
+
+func TestFpmParser_Dependencies(t *testing.T) {
+	parser := NewFpmParser()
+	deps := parser.Dependencies(parser.ParseFpmToml(testFpmToml))
+	require.Len(t, deps, 11)
+
+	byName := make(map[string]types.Dependency)
+	for _, dep := range deps {
+		assert.Equal(t, DependencyTypeFpm, dep.Type)
+		assert.True(t, dep.Direct)
+		assert.Equal(t, MetadataSourceFpmToml, dep.Metadata["source"])
+		byName[dep.Name] = dep
+	}
+
+	assert.Equal(t, "latest", byName["openmp"].Version)
+	assert.Equal(t, true, byName["openmp"].Metadata["metapackage"])
+	assert.Equal(t, "v0.4.2", byName["toml-f"].Version)
+	assert.Equal(t, "https://github.com/toml-f/toml-f", byName["toml-f"].Metadata["git"])
+	assert.Equal(t, "main", byName["M_CLI2"].Version)
+	assert.Equal(t, "main", byName["M_CLI2"].Metadata["branch"])
+	assert.Equal(t, "../utils", byName["utils"].Metadata["path"])
+	assert.Equal(t, "0.2.0", byName["fftpack"].Version)
+	assert.Equal(t, "fortran-lang", byName["fftpack"].Metadata["namespace"])
+	assert.Equal(t, "3ab8f98209871875325c6985dd0e50085d1c82c2", byName["json-fortran"].Version)
+	assert.Equal(t, types.ScopeTest, byName["veggies"].Scope)
+	assert.Equal(t, true, byName["lapack"].Metadata["link"])
+	assert.Equal(t, "latest", byName["blas"].Version)
+}
+
+func TestFpmParser_ParseFpmTomlMinimal(t *testing.T) {
+	pkg := NewFpmParser().ParseFpmToml(`name = "hello"`)
+	assert.Equal(t, "hello", pkg.Name)
+	assert.Empty(t, NewFpmParser().Dependencies(pkg))
+}
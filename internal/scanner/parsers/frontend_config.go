@@ -0,0 +1,236 @@
+package parsers
+
+import (
+	"bufio"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Compile JSONC cleanup regex once at package level for performance
+var trailingCommaRegex = regexp.MustCompile(`,(\s*[}\]])`)
+
+// BrowserslistDefaultEnv is the environment name used for queries outside any [env] section
+const BrowserslistDefaultEnv = "defaults"
+
+// FrontendConfigParser handles browserslist and tsconfig parsing
+type FrontendConfigParser struct{}
+
+// NewFrontendConfigParser creates a new frontend configuration parser
+func NewFrontendConfigParser() *FrontendConfigParser {
+	return &FrontendConfigParser{}
+}
+
+// BrowserslistConfig represents browser targets declared via browserslist
+type BrowserslistConfig struct {
+	File         string              `json:"file"`
+	Queries      []string            `json:"queries"`                // Default queries
+	Environments map[string][]string `json:"environments,omitempty"` // Queries per environment (e.g., production, development)
+}
+
+// TSConfigInfo represents the TypeScript compiler settings relevant to build targets
+type TSConfigInfo struct {
+	File             string   `json:"file"`
+	Extends          string   `json:"extends,omitempty"`
+	Target           string   `json:"target,omitempty"`
+	Module           string   `json:"module,omitempty"`
+	ModuleResolution string   `json:"module_resolution,omitempty"`
+	Lib              []string `json:"lib,omitempty"`
+	JSX              string   `json:"jsx,omitempty"`
+	Strict           *bool    `json:"strict,omitempty"`
+}
+
+// ParseBrowserslistRC parses a .browserslistrc file.
+// Queries before any [env] section are the defaults; "[production staging]" sections apply to several environments.
+func (p *FrontendConfigParser) ParseBrowserslistRC(content string) *BrowserslistConfig {
+	config := &BrowserslistConfig{Queries: []string{}}
+	var envs []string
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			envs = strings.Fields(strings.Trim(line, "[]"))
+			continue
+		}
+
+		queries := splitBrowserslistQueries(line)
+		if len(envs) == 0 {
+			config.Queries = append(config.Queries, queries...)
+			continue
+		}
+		for _, env := range envs {
+			config.addEnvironment(env, queries)
+		}
+	}
+
+	return config
+}
+
+// ParseBrowserslistField parses the "browserslist" field of package.json (string, array, or environment map)
+func (p *FrontendConfigParser) ParseBrowserslistField(raw json.RawMessage) *BrowserslistConfig {
+	if len(raw) == 0 {
+		return nil
+	}
+	config := &BrowserslistConfig{File: MetadataSourcePackageJSON, Queries: []string{}}
+
+	var query string
+	if err := json.Unmarshal(raw, &query); err == nil {
+		config.Queries = splitBrowserslistQueries(query)
+		return config
+	}
+
+	var queries []string
+	if err := json.Unmarshal(raw, &queries); err == nil {
+		config.Queries = queries
+		return config
+	}
+
+	var envs map[string]interface{}
+	if err := json.Unmarshal(raw, &envs); err != nil {
+		return nil
+	}
+	for env, value := range envs {
+		var envQueries []string
+		switch v := value.(type) {
+		case string:
+			envQueries = splitBrowserslistQueries(v)
+		case []interface{}:
+			for _, item := range v {
+				if s, ok := item.(string); ok {
+					envQueries = append(envQueries, s)
+				}
+			}
+		}
+		if env == BrowserslistDefaultEnv {
+			config.Queries = append(config.Queries, envQueries...)
+		} else {
+			config.addEnvironment(env, envQueries)
+		}
+	}
+	return config
+}
+
+func (c *BrowserslistConfig) addEnvironment(env string, queries []string) {
+	if c.Environments == nil {
+		c.Environments = make(map[string][]string)
+	}
+	c.Environments[env] = append(c.Environments[env], queries...)
+}
+
+// splitBrowserslistQueries splits a comma-separated query line ("> 1%, last 2 versions")
+func splitBrowserslistQueries(line string) []string {
+	var queries []string
+	for _, q := range strings.Split(line, ",") {
+		if q = strings.TrimSpace(q); q != "" {
+			queries = append(queries, q)
+		}
+	}
+	return queries
+}
+
+// tsconfigFile represents the subset of tsconfig.json we use
+type tsconfigFile struct {
+	Extends         interface{} `json:"extends"`
+	CompilerOptions struct {
+		Target           string   `json:"target"`
+		Module           string   `json:"module"`
+		ModuleResolution string   `json:"moduleResolution"`
+		Lib              []string `json:"lib"`
+		JSX              string   `json:"jsx"`
+		Strict           *bool    `json:"strict"`
+	} `json:"compilerOptions"`
+}
+
+// ParseTSConfig parses a tsconfig.json file (JSON with comments and trailing commas)
+func (p *FrontendConfigParser) ParseTSConfig(content string) (*TSConfigInfo, error) {
+	cleaned := trailingCommaRegex.ReplaceAllString(stripJSONComments(content), "$1")
+
+	var tsconfig tsconfigFile
+	if err := json.Unmarshal([]byte(cleaned), &tsconfig); err != nil {
+		return nil, err
+	}
+
+	info := &TSConfigInfo{
+		Target:           tsconfig.CompilerOptions.Target,
+		Module:           tsconfig.CompilerOptions.Module,
+		ModuleResolution: tsconfig.CompilerOptions.ModuleResolution,
+		Lib:              tsconfig.CompilerOptions.Lib,
+		JSX:              tsconfig.CompilerOptions.JSX,
+		Strict:           tsconfig.CompilerOptions.Strict,
+	}
+
+	// "extends" is a string or (TypeScript 5.0+) an array of strings
+	switch ext := tsconfig.Extends.(type) {
+	case string:
+		info.Extends = ext
+	case []interface{}:
+		var parts []string
+		for _, e := range ext {
+			if s, ok := e.(string); ok {
+				parts = append(parts, s)
+			}
+		}
+		info.Extends = strings.Join(parts, ", ")
+	}
+
+	return info, nil
+}
+
+// InheritFrom fills compiler settings missing in info from a base configuration (extends)
+func (info *TSConfigInfo) InheritFrom(base *TSConfigInfo) {
+	if base == nil {
+		return
+	}
+	if info.Target == "" {
+		info.Target = base.Target
+	}
+	if info.Module == "" {
+		info.Module = base.Module
+	}
+	if info.ModuleResolution == "" {
+		info.ModuleResolution = base.ModuleResolution
+	}
+	if len(info.Lib) == 0 {
+		info.Lib = base.Lib
+	}
+	if info.JSX == "" {
+		info.JSX = base.JSX
+	}
+	if info.Strict == nil {
+		info.Strict = base.Strict
+	}
+}
+
+// ToProperties converts the tsconfig info into a component properties map
+func (info *TSConfigInfo) ToProperties() map[string]interface{} {
+	props := map[string]interface{}{"file": info.File}
+	setIfNotEmpty := func(key, value string) {
+		if value != "" {
+			props[key] = value
+		}
+	}
+	setIfNotEmpty("extends", info.Extends)
+	setIfNotEmpty("target", info.Target)
+	setIfNotEmpty("module", info.Module)
+	setIfNotEmpty("module_resolution", info.ModuleResolution)
+	setIfNotEmpty("jsx", info.JSX)
+	if len(info.Lib) > 0 {
+		lib := append([]string(nil), info.Lib...)
+		sort.Strings(lib)
+		props["lib"] = lib
+	}
+	if info.Strict != nil {
+		props["strict"] = *info.Strict
+	}
+	return props
+}
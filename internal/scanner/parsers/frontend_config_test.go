@@ -0,0 +1,117 @@
+package parsers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrontendConfigParser_ParseBrowserslistRC(t *testing.T) {
+	content := `# Browsers that we support
+> 0.5%, last 2 versions
+not dead
+
+[production staging]
+> 1%
+
+[development]
+last 1 chrome version
+`
+	config := NewFrontendConfigParser().ParseBrowserslistRC(content)
+
+	assert.Equal(t, []string{"> 0.5%", "last 2 versions", "not dead"}, config.Queries)
+	assert.Equal(t, map[string][]string{
+		"production":  {"> 1%"},
+		"staging":     {"> 1%"},
+		"development": {"last 1 chrome version"},
+	}, config.Environments)
+}
+
+func TestFrontendConfigParser_ParseBrowserslistField(t *testing.T) {
+	parser := NewFrontendConfigParser()
+
+	tests := []struct {
+		name         string
+		raw          string
+		queries      []string
+		environments map[string][]string
+	}{
+		{"string", `"> 1%, not dead"`, []string{"> 1%", "not dead"}, nil},
+		{"array", `["defaults", "not IE 11"]`, []string{"defaults", "not IE 11"}, nil},
+		{
+			name:    "environments",
+			raw:     `{"production": [">0.2%", "not dead"], "development": "last 1 firefox version"}`,
+			queries: []string{},
+			environments: map[string][]string{
+				"production":  {">0.2%", "not dead"},
+				"development": {"last 1 firefox version"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := parser.ParseBrowserslistField(json.RawMessage(tt.raw))
+			require.NotNil(t, config)
+			assert.Equal(t, "package.json", config.File)
+			assert.Equal(t, tt.queries, config.Queries)
+			assert.Equal(t, tt.environments, config.Environments)
+		})
+	}
+
+	assert.Nil(t, parser.ParseBrowserslistField(nil))
+	assert.Nil(t, parser.ParseBrowserslistField(json.RawMessage(`42`)))
+}
+
+func TestFrontendConfigParser_ParseTSConfig(t *testing.T) {
+	content := `{
+  // Shared settings
+  "extends": "./tsconfig.base.json",
+  "compilerOptions": {
+    "target": "ES2022",
+    "module": "ESNext",
+    "moduleResolution": "bundler",
+    "lib": ["DOM", "ES2022"], /* browser + modern JS */
+    "jsx": "react-jsx",
+    "strict": true,
+  },
+  "include": ["src"],
+}`
+	info, err := NewFrontendConfigParser().ParseTSConfig(content)
+	require.NoError(t, err)
+
+	assert.Equal(t, "./tsconfig.base.json", info.Extends)
+	assert.Equal(t, "ES2022", info.Target)
+	assert.Equal(t, "ESNext", info.Module)
+	assert.Equal(t, "bundler", info.ModuleResolution)
+	assert.Equal(t, []string{"DOM", "ES2022"}, info.Lib)
+	assert.Equal(t, "react-jsx", info.JSX)
+	require.NotNil(t, info.Strict)
+	assert.True(t, *info.Strict)
+}
+
+func TestFrontendConfigParser_ParseTSConfig_ExtendsArray(t *testing.T) {
+	info, err := NewFrontendConfigParser().ParseTSConfig(`{"extends": ["@tsconfig/strictest", "./base.json"]}`)
+	require.NoError(t, err)
+	assert.Equal(t, "@tsconfig/strictest, ./base.json", info.Extends)
+}
+
+func TestFrontendConfigParser_ParseTSConfig_Invalid(t *testing.T) {
+	_, err := NewFrontendConfigParser().ParseTSConfig(`{"compilerOptions": `)
+	assert.Error(t, err)
+}
+
+func TestTSConfigInfo_InheritFromAndToProperties(t *testing.T) {
+	info := &TSConfigInfo{File: "tsconfig.json", Extends: "./tsconfig.base.json", Module: "CommonJS"}
+	info.InheritFrom(&TSConfigInfo{Target: "ES2020", Module: "ESNext", Lib: []string{"ES2020", "DOM"}})
+
+	assert.Equal(t, map[string]interface{}{
+		"file":    "tsconfig.json",
+		"extends": "./tsconfig.base.json",
+		"target":  "ES2020",
+		"module":  "CommonJS",
+		"lib":     []string{"DOM", "ES2020"},
+	}, info.ToProperties())
+}
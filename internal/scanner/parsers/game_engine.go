@@ -0,0 +1,194 @@
+package parsers
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Pre-compiled regexes for Unity project settings
+var (
+	unityEditorVersionRegex  = regexp.MustCompile(`(?m)^m_EditorVersion:\s*(\S+)`)
+	unityEditorRevisionRegex = regexp.MustCompile(`(?m)^m_EditorVersionWithRevision:\s*\S+\s*\(([0-9a-f]+)\)`)
+	unityProductNameRegex    = regexp.MustCompile(`(?m)^\s*productName:\s*(.+?)\s*$`)
+)
+
+// UnityManifest represents the package manifest of a Unity project (Packages/manifest.json)
+type UnityManifest struct {
+	Dependencies     map[string]string     `json:"dependencies"`
+	ScopedRegistries []UnityScopedRegistry `json:"scopedRegistries"`
+}
+
+// UnityScopedRegistry is a package registry serving the packages of its scopes
+type UnityScopedRegistry struct {
+	Name   string   `json:"name"`
+	URL    string   `json:"url"`
+	Scopes []string `json:"scopes"`
+}
+
+// UnrealProject represents an Unreal Engine project descriptor (.uproject)
+type UnrealProject struct {
+	EngineAssociation string            `json:"EngineAssociation"` // Engine version ("5.3") or the GUID of a source build
+	Category          string            `json:"Category"`
+	Description       string            `json:"Description"`
+	Modules           []UnrealModule    `json:"Modules"`
+	Plugins           []UnrealPluginRef `json:"Plugins"`
+}
+
+// UnrealPlugin represents an Unreal Engine plugin descriptor (.uplugin)
+type UnrealPlugin struct {
+	FriendlyName   string            `json:"FriendlyName"`
+	VersionName    string            `json:"VersionName"`
+	EngineVersion  string            `json:"EngineVersion"`
+	Category       string            `json:"Category"`
+	CreatedBy      string            `json:"CreatedBy"`
+	MarketplaceURL string            `json:"MarketplaceURL"`
+	Modules        []UnrealModule    `json:"Modules"`
+	Plugins        []UnrealPluginRef `json:"Plugins"`
+}
+
+// UnrealModule is a code module of a project or plugin
+type UnrealModule struct {
+	Name string `json:"Name"`
+	Type string `json:"Type"` // Runtime, Editor, ...
+}
+
+// UnrealPluginRef enables or disables a plugin for a project or plugin
+type UnrealPluginRef struct {
+	Name           string `json:"Name"`
+	Enabled        bool   `json:"Enabled"`
+	Optional       bool   `json:"Optional"`
+	MarketplaceURL string `json:"MarketplaceURL"`
+}
+
+// GameEngineParser handles Unity and Unreal Engine project files
+type GameEngineParser struct{}
+
+// NewGameEngineParser creates a new game engine parser
+func NewGameEngineParser() *GameEngineParser {
+	return &GameEngineParser{}
+}
+
+// ParseUnityProjectVersion returns the editor version and revision of ProjectSettings/ProjectVersion.txt
+func (p *GameEngineParser) ParseUnityProjectVersion(content string) (string, string) {
+	var version, revision string
+	if match := unityEditorVersionRegex.FindStringSubmatch(content); match != nil {
+		version = match[1]
+	}
+	if match := unityEditorRevisionRegex.FindStringSubmatch(content); match != nil {
+		revision = match[1]
+	}
+	return version, revision
+}
+
+// ParseUnityProductName returns the product name of ProjectSettings/ProjectSettings.asset
+func (p *GameEngineParser) ParseUnityProductName(content string) string {
+	if match := unityProductNameRegex.FindStringSubmatch(content); match != nil {
+		return strings.Trim(match[1], `"'`)
+	}
+	return ""
+}
+
+// ParseUnityManifest extracts the package dependencies of Packages/manifest.json. Versions are
+// kept as declared (registry versions, git URLs, file: paths); built-in engine modules
+// (com.unity.modules.*) are flagged, and packages of scoped registries get the registry URL.
+func (p *GameEngineParser) ParseUnityManifest(content string) ([]types.Dependency, error) {
+	var manifest UnityManifest
+	if err := json.Unmarshal([]byte(content), &manifest); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(manifest.Dependencies))
+	for name := range manifest.Dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var dependencies []types.Dependency
+	for _, name := range names {
+		version := strings.TrimSpace(manifest.Dependencies[name])
+		if version == "" {
+			version = "latest"
+		}
+		metadata := types.NewMetadata(MetadataSourceUnityManifest)
+		if strings.HasPrefix(name, "com.unity.modules.") {
+			metadata["builtin"] = true
+		}
+		if registry := unityRegistryFor(name, manifest.ScopedRegistries); registry != "" {
+			metadata["registry"] = registry
+		}
+		dependencies = append(dependencies, types.Dependency{
+			Type:     DependencyTypeUnity,
+			Name:     name,
+			Version:  version,
+			Scope:    types.ScopeProd,
+			Direct:   true,
+			Metadata: metadata,
+		})
+	}
+	return dependencies, nil
+}
+
+// ParseUProject parses an Unreal Engine project descriptor
+func (p *GameEngineParser) ParseUProject(content string) (UnrealProject, error) {
+	var project UnrealProject
+	err := json.Unmarshal([]byte(content), &project)
+	return project, err
+}
+
+// ParseUPlugin parses an Unreal Engine plugin descriptor
+func (p *GameEngineParser) ParseUPlugin(content string) (UnrealPlugin, error) {
+	var plugin UnrealPlugin
+	err := json.Unmarshal([]byte(content), &plugin)
+	return plugin, err
+}
+
+// UnrealPluginDependencies converts the enabled plugins of a project or plugin descriptor to
+// dependencies; disabled plugins are skipped. Plugins carry no version in the descriptor, so
+// the version of plugins shipped with the project is looked up by name (versions), else "latest".
+func (p *GameEngineParser) UnrealPluginDependencies(plugins []UnrealPluginRef, source string, versions map[string]string) []types.Dependency {
+	var dependencies []types.Dependency
+	for _, plugin := range plugins {
+		if plugin.Name == "" || !plugin.Enabled {
+			continue
+		}
+		version := versions[plugin.Name]
+		if version == "" {
+			version = "latest"
+		}
+		scope := types.ScopeProd
+		if plugin.Optional {
+			scope = types.ScopeOptional
+		}
+		metadata := types.NewMetadata(source)
+		if plugin.MarketplaceURL != "" {
+			metadata["marketplace_url"] = plugin.MarketplaceURL
+		}
+		dependencies = append(dependencies, types.Dependency{
+			Type:     DependencyTypeUnreal,
+			Name:     plugin.Name,
+			Version:  version,
+			Scope:    scope,
+			Direct:   true,
+			Metadata: metadata,
+		})
+	}
+	return dependencies
+}
+
+// unityRegistryFor returns the URL of the scoped registry serving a package (longest scope wins)
+func unityRegistryFor(name string, registries []UnityScopedRegistry) string {
+	var url string
+	longest := 0
+	for _, registry := range registries {
+		for _, scope := range registry.Scopes {
+			if (name == scope || strings.HasPrefix(name, scope+".")) && len(scope) > longest {
+				url, longest = registry.URL, len(scope)
+			}
+		}
+	}
+	return url
+}
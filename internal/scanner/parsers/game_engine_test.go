@@ -0,0 +1,104 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameEngineParser_ParseUnityProjectVersion(t *testing.T) {
+	parser := NewGameEngineParser()
+
+	version, revision := parser.ParseUnityProjectVersion("m_EditorVersion: 2022.3.10f1\nm_EditorVersionWithRevision: 2022.3.10f1 (ff3792e53c62)\n")
+	assert.Equal(t, "2022.3.10f1", version)
+	assert.Equal(t, "ff3792e53c62", revision)
+
+	version, revision = parser.ParseUnityProjectVersion("m_EditorVersion: 2019.4.40f1\n")
+	assert.Equal(t, "2019.4.40f1", version)
+	assert.Empty(t, revision)
+}
+
+func TestGameEngineParser_ParseUnityProductName(t *testing.T) {
+	parser := NewGameEngineParser()
+
+	content := "%YAML 1.1\n--- !u!129 &1\nPlayerSettings:\n  companyName: Example\n  productName: Space Shooter\n  defaultCursor: {fileID: 0}\n"
+	assert.Equal(t, "Space Shooter", parser.ParseUnityProductName(content))
+	assert.Empty(t, parser.ParseUnityProductName("PlayerSettings:\n  companyName: Example\n"))
+}
+
+func TestGameEngineParser_ParseUnityManifest(t *testing.T) {
+	parser := NewGameEngineParser()
+
+	content := `{
+  "dependencies": {
+    "com.unity.render-pipelines.universal": "14.0.8",
+    "com.unity.modules.physics": "1.0.0",
+    "com.example.tools": "https://github.com/example/tools.git#v1.2.0",
+    "com.cysharp.unitask": "2.5.0",
+    "com.cysharp.unitask.addressables": "2.5.0"
+  },
+  "scopedRegistries": [
+    {"name": "OpenUPM", "url": "https://package.openupm.com", "scopes": ["com.cysharp"]},
+    {"name": "Internal", "url": "https://upm.example.com", "scopes": ["com.cysharp.unitask.addressables"]}
+  ]
+}`
+
+	deps, err := parser.ParseUnityManifest(content)
+	require.NoError(t, err)
+	require.Len(t, deps, 5)
+
+	byName := make(map[string]types.Dependency)
+	for _, dep := range deps {
+		byName[dep.Name] = dep
+	}
+	assert.Equal(t, "com.cysharp.unitask", deps[0].Name, "Should sort by name")
+	assert.Equal(t, types.Dependency{
+		Type: DependencyTypeUnity, Name: "com.unity.render-pipelines.universal", Version: "14.0.8", Scope: types.ScopeProd, Direct: true,
+		Metadata: map[string]interface{}{"source": "Packages/manifest.json"},
+	}, byName["com.unity.render-pipelines.universal"])
+	assert.Equal(t, true, byName["com.unity.modules.physics"].Metadata["builtin"])
+	assert.Equal(t, "https://github.com/example/tools.git#v1.2.0", byName["com.example.tools"].Version)
+	assert.Equal(t, "https://package.openupm.com", byName["com.cysharp.unitask"].Metadata["registry"])
+	assert.Equal(t, "https://upm.example.com", byName["com.cysharp.unitask.addressables"].Metadata["registry"], "Should prefer the most specific scope")
+
+	_, err = parser.ParseUnityManifest("not json")
+	assert.Error(t, err)
+}
+
+func TestGameEngineParser_UnrealDescriptors(t *testing.T) {
+	parser := NewGameEngineParser()
+
+	project, err := parser.ParseUProject(`{
+	"FileVersion": 3,
+	"EngineAssociation": "5.3",
+	"Category": "Games",
+	"Modules": [{"Name": "Shooter", "Type": "Runtime", "LoadingPhase": "Default"}],
+	"Plugins": [
+		{"Name": "EnhancedInput", "Enabled": true},
+		{"Name": "OnlineSubsystemSteam", "Enabled": true, "Optional": true, "MarketplaceURL": "com.epicgames.launcher://ue/marketplace/content/steam"},
+		{"Name": "Paper2D", "Enabled": false},
+		{"Name": "ShooterTools", "Enabled": true}
+	]
+}`)
+	require.NoError(t, err)
+	assert.Equal(t, "5.3", project.EngineAssociation)
+	assert.Equal(t, []UnrealModule{{Name: "Shooter", Type: "Runtime"}}, project.Modules)
+
+	deps := parser.UnrealPluginDependencies(project.Plugins, MetadataSourceUProject, map[string]string{"ShooterTools": "1.4"})
+	require.Len(t, deps, 3, "Should skip disabled plugins")
+	assert.Equal(t, types.Dependency{
+		Type: DependencyTypeUnreal, Name: "EnhancedInput", Version: "latest", Scope: types.ScopeProd, Direct: true,
+		Metadata: map[string]interface{}{"source": ".uproject"},
+	}, deps[0])
+	assert.Equal(t, types.ScopeOptional, deps[1].Scope)
+	assert.Equal(t, "com.epicgames.launcher://ue/marketplace/content/steam", deps[1].Metadata["marketplace_url"])
+	assert.Equal(t, "1.4", deps[2].Version, "Should use the version of plugins shipped with the project")
+
+	plugin, err := parser.ParseUPlugin(`{"FileVersion": 3, "VersionName": "1.4", "FriendlyName": "Shooter Tools", "EngineVersion": "5.3.0", "Plugins": [{"Name": "EditorScriptingUtilities", "Enabled": true}]}`)
+	require.NoError(t, err)
+	assert.Equal(t, "Shooter Tools", plugin.FriendlyName)
+	assert.Equal(t, "5.3.0", plugin.EngineVersion)
+	assert.Len(t, parser.UnrealPluginDependencies(plugin.Plugins, MetadataSourceUPlugin, nil), 1)
+}
@@ -105,7 +105,7 @@ func (p *RubyParser) ParseGemfile(content string) []types.Dependency {
 }
 
 // mapGemfileGroupToScope maps Gemfile groups to dependency scopes
-func (p *RubyParser) mapGemfileGroupToScope(groups []string) string {
+func (p *RubyParser) mapGemfileGroupToScope(groups []string) types.Scope {
 	if len(groups) == 0 {
 		return types.ScopeProd
 	}
@@ -195,6 +195,7 @@ func (p *RubyParser) addPlatformsToMetadata(metadata map[string]interface{}, lin
 		}
 		if len(cleanPlatforms) > 0 {
 			metadata["platforms"] = cleanPlatforms
+			appendActivation(metadata, activationEntry(ActivationPlatform, cleanPlatforms))
 		}
 	}
 }
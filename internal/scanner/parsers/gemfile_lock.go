@@ -96,6 +96,13 @@ func (p *GemfileLockParser) ParseGemfileLockWithOptions(content string, options
 				metadata["direct"] = false
 			}
 
+			// Platform variants of a gem (1.15.5-x86_64-linux) are recorded as locked
+			if _, platform, ok := strings.Cut(version, "-"); ok {
+				if platforms, _ := GemPlatforms(platform); len(platforms) > 0 {
+					metadata[MetadataArtifactPlatforms] = platforms
+				}
+			}
+
 			dependencies = append(dependencies, types.Dependency{
 				Type:     DependencyTypeRuby,
 				Name:     gemName,
@@ -370,6 +370,8 @@ BUNDLED WITH
 		}
 
 		assert.Equal(t, "1.15.5-x86_64-linux", depMap["nokogiri"].Version)
+		assert.Equal(t, []string{"linux/amd64"}, depMap["nokogiri"].Metadata[MetadataArtifactPlatforms], "platform variant")
+		assert.NotContains(t, depMap["rails"].Metadata, MetadataArtifactPlatforms)
 		assert.Equal(t, "7.1.0.rc1", depMap["rails"].Version)
 		assert.Equal(t, "4.9.3.pre", depMap["devise"].Version)
 	})
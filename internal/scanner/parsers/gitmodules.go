@@ -0,0 +1,118 @@
+package parsers
+
+import (
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// GitSubmodule is a submodule declared in .gitmodules, with the commit the repository pins it
+// to (when known)
+type GitSubmodule struct {
+	Name   string
+	Path   string
+	URL    string
+	Branch string
+	Commit string
+}
+
+// GitmodulesParser handles .gitmodules files
+type GitmodulesParser struct{}
+
+// NewGitmodulesParser creates a new .gitmodules parser
+func NewGitmodulesParser() *GitmodulesParser {
+	return &GitmodulesParser{}
+}
+
+// ParseGitmodules parses the submodules of a .gitmodules file
+func (p *GitmodulesParser) ParseGitmodules(content string) []GitSubmodule {
+	var submodules []GitSubmodule
+	var current *GitSubmodule
+	for _, rawLine := range strings.Split(content, "\n") {
+		line := strings.TrimSpace(rawLine)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			current = nil
+			header := strings.TrimSpace(strings.Trim(line, "[]"))
+			if name, ok := strings.CutPrefix(header, "submodule"); ok {
+				submodules = append(submodules, GitSubmodule{Name: strings.Trim(strings.TrimSpace(name), `"`)})
+				current = &submodules[len(submodules)-1]
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+		switch strings.TrimSpace(key) {
+		case "path":
+			current.Path = value
+		case "url":
+			current.URL = value
+		case "branch":
+			current.Branch = value
+		}
+	}
+	return submodules
+}
+
+// Dependencies converts submodules to dependencies named after their repository. The version
+// is the pinned commit, else the tracked branch. The "git" and "submodule" (path) metadata
+// record the repository URL and the checkout path.
+func (p *GitmodulesParser) Dependencies(submodules []GitSubmodule) []types.Dependency {
+	var dependencies []types.Dependency
+	for _, submodule := range submodules {
+		if submodule.Path == "" {
+			continue
+		}
+		metadata := types.NewMetadata(MetadataSourceGitmodules)
+		version := submoduleVersion(submodule, metadata)
+		if submodule.URL != "" {
+			metadata["git"] = submodule.URL
+		}
+		metadata["submodule"] = submodule.Path
+		dependencies = append(dependencies, types.Dependency{
+			Type:     DependencyTypeGitSubmodule,
+			Name:     submoduleRepository(submodule),
+			Version:  version,
+			Scope:    types.ScopeProd,
+			Direct:   true,
+			Metadata: metadata,
+		})
+	}
+	return dependencies
+}
+
+// submoduleVersion returns the pinned commit of a submodule, else its tracked branch (added
+// as "branch" metadata), else "latest"
+func submoduleVersion(submodule GitSubmodule, metadata map[string]interface{}) string {
+	if submodule.Branch != "" {
+		metadata["branch"] = submodule.Branch
+	}
+	switch {
+	case submodule.Commit != "":
+		return submodule.Commit
+	case submodule.Branch != "":
+		return submodule.Branch
+	}
+	return "latest"
+}
+
+// submoduleRepository returns the repository name of a submodule URL
+// ("https://github.com/google/googletest.git" gives "googletest"), or the last path element
+func submoduleRepository(submodule GitSubmodule) string {
+	url := strings.TrimSuffix(strings.TrimRight(submodule.URL, "/"), ".git")
+	if i := strings.LastIndexAny(url, "/:"); i >= 0 {
+		url = url[i+1:]
+	}
+	if url == "" || url == "." || url == ".." {
+		return pathBase(submodule.Path)
+	}
+	return url
+}
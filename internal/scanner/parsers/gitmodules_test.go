@@ -0,0 +1,56 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitmodulesParser_ParseGitmodules(t *testing.T) {
+	content := `[submodule "lib/forge-std"]
+	path = lib/forge-std
+	url = https://github.com/foundry-rs/forge-std
+[submodule "third_party/googletest"]
+	path = third_party/googletest
+	url = "git@github.com:google/googletest.git"
+	branch = v1.14.x
+# local checkout
+[core]
+	bare = false
+`
+	assert.Equal(t, []GitSubmodule{
+		{Name: "lib/forge-std", Path: "lib/forge-std", URL: "https://github.com/foundry-rs/forge-std"},
+		{Name: "third_party/googletest", Path: "third_party/googletest", URL: "git@github.com:google/googletest.git", Branch: "v1.14.x"},
+	}, NewGitmodulesParser().ParseGitmodules(content))
+}
+
+func TestGitmodulesParser_Dependencies(t *testing.T) {
+	submodules := []GitSubmodule{
+		{Path: "third_party/googletest", URL: "https://github.com/google/googletest.git", Commit: "b514bdc898e2951020cbdca1304b75f5950d1f59"},
+		{Path: "vendor/theme", URL: "git@gitlab.com:org/theme.git", Branch: "main"},
+		{Path: "modules/shared", URL: "../shared.git"},
+		{Name: "broken"},
+	}
+
+	deps := NewGitmodulesParser().Dependencies(submodules)
+	require.Len(t, deps, 3)
+	for _, dep := range deps {
+		assert.Equal(t, DependencyTypeGitSubmodule, dep.Type)
+		assert.Equal(t, MetadataSourceGitmodules, dep.Metadata["source"])
+		assert.True(t, dep.Direct)
+	}
+
+	assert.Equal(t, "googletest", deps[0].Name)
+	assert.Equal(t, "b514bdc898e2951020cbdca1304b75f5950d1f59", deps[0].Version)
+	assert.Equal(t, "https://github.com/google/googletest.git", deps[0].Metadata["git"])
+	assert.Equal(t, "third_party/googletest", deps[0].Metadata["submodule"])
+	assert.Nil(t, deps[0].Metadata["branch"])
+
+	assert.Equal(t, "theme", deps[1].Name)
+	assert.Equal(t, "main", deps[1].Version)
+	assert.Equal(t, "main", deps[1].Metadata["branch"])
+
+	assert.Equal(t, "shared", deps[2].Name)
+	assert.Equal(t, "latest", deps[2].Version)
+}
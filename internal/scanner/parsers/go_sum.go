@@ -0,0 +1,125 @@
+package parsers
+
+import (
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// ParseGoSumOptions contains configuration options for ParseGoSumWithOptions
+type ParseGoSumOptions struct {
+	IncludeTransitive bool // Include transitive dependencies (default: false for direct dependencies only)
+}
+
+// ParseGoSum parses go.mod and go.sum content and returns the direct dependencies of go.mod
+// with the module hashes of go.sum. Use ParseGoSumWithOptions to include transitive modules.
+func (p *GolangParser) ParseGoSum(goSumContent, goModContent string) []types.Dependency {
+	return p.ParseGoSumWithOptions(goSumContent, goModContent, ParseGoSumOptions{})
+}
+
+// ParseGoSumWithOptions parses go.mod and go.sum content with configurable options. Direct
+// dependencies are the requirements of go.mod not marked // indirect; go.sum adds the hash of
+// their module content. Transitive dependencies are the // indirect requirements of go.mod and
+// the other modules whose content go.sum records (go.mod files before Go 1.17 list only part of
+// them), at the version go.mod requires, else the highest version go.sum holds content for.
+// Modules whose go.mod file alone is recorded only took part in version selection and are left
+// out.
+func (p *GolangParser) ParseGoSumWithOptions(goSumContent, goModContent string, options ParseGoSumOptions) []types.Dependency {
+	dependencies, _ := p.ParseGoModWithInfo(goModContent)
+	file, err := modfile.Parse("go.mod", []byte(goModContent), nil)
+	if err != nil {
+		return dependencies
+	}
+	modules := parseGoSum(goSumContent)
+
+	replaceMap := make(map[string]string)
+	sumKeys := make(map[string]string) // Module path -> path whose checksums go.sum records
+	replacements := make(map[string]bool)
+	for _, replace := range file.Replace {
+		replaceMap[replace.Old.Path] = replace.New.Path + "@" + replace.New.Version
+		if replace.New.Version != "" {
+			sumKeys[replace.Old.Path] = replace.New.Path
+			replacements[replace.New.Path] = true
+		}
+	}
+	hashOf := func(path, version string) string {
+		if replaced, ok := sumKeys[path]; ok {
+			path, version = replaced, strings.TrimPrefix(replaceMap[path], replaced+"@")
+		}
+		return modules[path][version]
+	}
+
+	direct := make(map[string]bool)
+	for i, dep := range dependencies {
+		direct[dep.Name] = true
+		if hash := hashOf(dep.Name, dep.Version); hash != "" {
+			dependencies[i].Metadata[MetadataGoSumHash] = hash
+		}
+	}
+	if !options.IncludeTransitive {
+		return dependencies
+	}
+
+	// Transitive modules at the version go.mod requires, else the highest with content
+	versions := make(map[string]string)
+	for path, hashes := range modules {
+		for version := range hashes {
+			if semver.Compare(version, versions[path]) > 0 {
+				versions[path] = version
+			}
+		}
+	}
+	for _, req := range file.Require {
+		if req.Indirect {
+			versions[req.Mod.Path] = req.Mod.Version
+		}
+	}
+	paths := make([]string, 0, len(versions))
+	for path := range versions {
+		// Replacement modules are listed under the path they replace
+		if !direct[path] && !replacements[path] && (file.Module == nil || path != file.Module.Mod.Path) {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		version := versions[path]
+		metadata := p.buildGoMetadata(path, version, replaceMap)
+		metadata["source"] = MetadataSourceGoSum
+		if hash := hashOf(path, version); hash != "" {
+			metadata[MetadataGoSumHash] = hash
+		}
+		dependencies = append(dependencies, types.Dependency{
+			Type:       DependencyTypeGolang,
+			Name:       path,
+			Version:    version,
+			SourceFile: MetadataSourceGoSum,
+			Scope:      types.ScopeProd,
+			Direct:     false,
+			Metadata:   metadata,
+		})
+	}
+	return dependencies
+}
+
+// parseGoSum returns the content hashes (h1:...) of the module versions of a go.sum by module
+// path and version; lines for go.mod files only ("v1.2.3/go.mod") record no content
+func parseGoSum(content string) map[string]map[string]string {
+	modules := make(map[string]map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 || strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		if modules[fields[0]] == nil {
+			modules[fields[0]] = make(map[string]string)
+		}
+		modules[fields[0]][fields[1]] = fields[2]
+	}
+	return modules
+}
@@ -0,0 +1,97 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGoMod = `module github.com/example/app
+
+go 1.21
+
+require (
+	github.com/gin-gonic/gin v1.9.1
+	github.com/old/lib v1.0.0
+)
+
+require (
+	github.com/bytedance/sonic v1.9.1 // indirect
+	golang.org/x/net v0.10.0 // indirect
+)
+
+replace github.com/old/lib => github.com/fork/lib v1.0.1
+`
+
+const testGoSum = `github.com/bytedance/sonic v1.9.1 h1:6iJ6NqdoxCDr6mbY8h18oSO+cShGSMRGCEo7F2h0x8s=
+github.com/bytedance/sonic v1.9.1/go.mod h1:i736AoUSYt75HyZLoJW9ERYxcy6eaN6h4BZXU064P/U=
+github.com/fork/lib v1.0.1 h1:forkhash=
+github.com/fork/lib v1.0.1/go.mod h1:forkmodhash=
+github.com/gin-gonic/gin v1.9.1 h1:4idEAncQnU5cB7BeOkPtxjfCSye0AAm1R0RVIqJ+Jmg=
+github.com/gin-gonic/gin v1.9.1/go.mod h1:hPrL7YrpYKXt5YId3A/Tnip5kqbEAP+KLuI3SUcPTeU=
+github.com/go-playground/validator/v10 v10.11.0 h1:validator10110=
+github.com/go-playground/validator/v10 v10.14.0 h1:validator10140=
+github.com/go-playground/validator/v10 v10.14.0/go.mod h1:9iwDLGzpS7v6cYhVFWKSFVVg4dfsrHsgKxK+jq8cvAw=
+github.com/only/gomod v1.0.0/go.mod h1:onlygomod=
+golang.org/x/net v0.9.0 h1:net090=
+golang.org/x/net v0.10.0 h1:net0100=
+golang.org/x/net v0.10.0/go.mod h1:0qNGK6F8kojg2nk9dLZ2mShWaEBan6FAoqfSigmmuDg=
+`
+
+func TestGolangParser_ParseGoSum(t *testing.T) {
+	parser := NewGolangParser()
+
+	deps := parser.ParseGoSum(testGoSum, testGoMod)
+	require.Len(t, deps, 2, "direct dependencies only")
+	assert.Equal(t, "github.com/gin-gonic/gin", deps[0].Name)
+	assert.True(t, deps[0].Direct)
+	assert.Equal(t, "h1:4idEAncQnU5cB7BeOkPtxjfCSye0AAm1R0RVIqJ+Jmg=", deps[0].Metadata[MetadataGoSumHash])
+	assert.Equal(t, MetadataSourceGoMod, deps[0].Metadata["source"])
+	assert.Equal(t, "github.com/old/lib", deps[1].Name)
+	assert.Equal(t, "h1:forkhash=", deps[1].Metadata[MetadataGoSumHash], "hash of the replacement")
+}
+
+func TestGolangParser_ParseGoSumWithOptions_Transitive(t *testing.T) {
+	parser := NewGolangParser()
+
+	deps := parser.ParseGoSumWithOptions(testGoSum, testGoMod, ParseGoSumOptions{IncludeTransitive: true})
+	versions := make(map[string]string)
+	for _, dep := range deps {
+		versions[dep.Name] = dep.Version
+	}
+	assert.Equal(t, map[string]string{
+		"github.com/gin-gonic/gin":               "v1.9.1",
+		"github.com/old/lib":                     "v1.0.0",
+		"github.com/bytedance/sonic":             "v1.9.1",
+		"github.com/go-playground/validator/v10": "v10.14.0",
+		"golang.org/x/net":                       "v0.10.0",
+	}, versions, "replacements and go.mod-only modules are left out")
+
+	require.Len(t, deps, 5)
+	sonic := deps[2]
+	assert.Equal(t, "github.com/bytedance/sonic", sonic.Name)
+	assert.False(t, sonic.Direct)
+	assert.Equal(t, types.ScopeProd, sonic.Scope)
+	assert.Equal(t, MetadataSourceGoSum, sonic.SourceFile)
+	assert.Equal(t, MetadataSourceGoSum, sonic.Metadata["source"])
+	assert.Equal(t, "h1:6iJ6NqdoxCDr6mbY8h18oSO+cShGSMRGCEo7F2h0x8s=", sonic.Metadata[MetadataGoSumHash])
+
+	validator := deps[3]
+	assert.Equal(t, "github.com/go-playground/validator", validator.Metadata[MetadataGoModule])
+	assert.Equal(t, 10, validator.Metadata[MetadataMajorVersion])
+	assert.Equal(t, "h1:validator10140=", validator.Metadata[MetadataGoSumHash], "highest version with content")
+
+	net := deps[4]
+	assert.Equal(t, "h1:net0100=", net.Metadata[MetadataGoSumHash], "version required by go.mod")
+}
+
+func TestGolangParser_ParseGoSum_Invalid(t *testing.T) {
+	parser := NewGolangParser()
+
+	assert.Empty(t, parser.ParseGoSumWithOptions(testGoSum, "not a go.mod {", ParseGoSumOptions{IncludeTransitive: true}))
+	deps := parser.ParseGoSumWithOptions("", testGoMod, ParseGoSumOptions{IncludeTransitive: true})
+	assert.Len(t, deps, 4, "indirect requirements of go.mod without go.sum")
+	assert.NotContains(t, deps[0].Metadata, MetadataGoSumHash)
+}
@@ -0,0 +1,55 @@
+package parsers
+
+import (
+	"path"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// GoWorkInfo contains the directives of a go.work file
+type GoWorkInfo struct {
+	GoVersion string
+	Toolchain string            // toolchain directive (e.g., "go1.22.3")
+	Use       []string          // Module directories of the use directives, relative to the go.work directory
+	Replace   map[string]string // Replaced module path -> replacement (path@version), overriding the replaces of go.mod files
+}
+
+// ParseGoWork parses a go.work file; returns nil if it cannot be parsed
+func (p *GolangParser) ParseGoWork(content string) *GoWorkInfo {
+	file, err := modfile.ParseWork("go.work", []byte(content), nil)
+	if err != nil {
+		return nil
+	}
+
+	info := &GoWorkInfo{Replace: make(map[string]string)}
+	if file.Go != nil {
+		info.GoVersion = file.Go.Version
+	}
+	if file.Toolchain != nil {
+		info.Toolchain = file.Toolchain.Name
+	}
+	for _, use := range file.Use {
+		info.Use = append(info.Use, path.Clean(use.Path))
+	}
+	for _, replace := range file.Replace {
+		info.Replace[replace.Old.Path] = replace.New.Path + "@" + replace.New.Version
+	}
+	return info
+}
+
+// ApplyGoWorkReplaces records the replacements of a go.work on the dependencies of a member
+// module; they take precedence over the replace directives of its go.mod
+func ApplyGoWorkReplaces(dependencies []types.Dependency, replace map[string]string) {
+	for i := range dependencies {
+		replacement, ok := replace[dependencies[i].Name]
+		if !ok {
+			continue
+		}
+		if dependencies[i].Metadata == nil {
+			dependencies[i].Metadata = make(map[string]interface{})
+		}
+		dependencies[i].Metadata["replaced_by"] = replacement
+	}
+}
@@ -0,0 +1,45 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGolangParser_ParseGoWork(t *testing.T) {
+	parser := NewGolangParser()
+
+	info := parser.ParseGoWork(`go 1.22.0
+
+toolchain go1.22.3
+
+use (
+	.
+	./services/api
+	./libs/shared/
+)
+
+use ./tools
+
+replace github.com/acme/legacy v1.0.0 => github.com/acme/legacy-fork v1.0.1
+`)
+	require.NotNil(t, info)
+	assert.Equal(t, "1.22.0", info.GoVersion)
+	assert.Equal(t, "go1.22.3", info.Toolchain)
+	assert.Equal(t, []string{".", "services/api", "libs/shared", "tools"}, info.Use)
+	assert.Equal(t, map[string]string{"github.com/acme/legacy": "github.com/acme/legacy-fork@v1.0.1"}, info.Replace)
+
+	assert.Nil(t, parser.ParseGoWork("use (\n"))
+}
+
+func TestApplyGoWorkReplaces(t *testing.T) {
+	dependencies := []types.Dependency{
+		{Name: "github.com/acme/legacy", Version: "v1.0.0", Metadata: map[string]interface{}{"replaced_by": "github.com/acme/old@v0.9.0"}},
+		{Name: "github.com/gin-gonic/gin", Version: "v1.9.1"},
+	}
+	ApplyGoWorkReplaces(dependencies, map[string]string{"github.com/acme/legacy": "github.com/acme/legacy-fork@v1.0.1"})
+	assert.Equal(t, "github.com/acme/legacy-fork@v1.0.1", dependencies[0].Metadata["replaced_by"], "go.work takes precedence")
+	assert.Nil(t, dependencies[1].Metadata)
+}
@@ -1,7 +1,16 @@
 package parsers
 
 import (
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+
 	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
 
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 )
@@ -13,6 +22,33 @@ type GolangParser struct{}
 type GoModInfo struct {
 	ModulePath string
 	GoVersion  string
+	Toolchain  string            // toolchain directive (e.g., "go1.22.3")
+	Godebug    map[string]string // godebug settings (e.g., "default": "go1.21", "panicnil": "1")
+}
+
+// GoSourceInfo contains the build information of a Go source file
+type GoSourceInfo struct {
+	Cgo       bool     // Imports "C" or requires the cgo build tag
+	Tags      []string // Custom build tags of the build constraint (not platforms, cgo, or Go versions)
+	Platforms []string // GOOS/GOARCH the file is restricted to, from the build constraint or the file name
+	Imports   []string
+}
+
+// goOperatingSystems are the GOOS values recognized in build constraints and file names
+// ("unix" is only valid in build constraints)
+var goOperatingSystems = map[string]bool{
+	"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true, "hurd": true,
+	"illumos": true, "ios": true, "js": true, "linux": true, "nacl": true, "netbsd": true,
+	"openbsd": true, "plan9": true, "solaris": true, "wasip1": true, "windows": true, "zos": true,
+}
+
+// goArchitectures are the GOARCH values recognized in build constraints and file names
+var goArchitectures = map[string]bool{
+	"386": true, "amd64": true, "amd64p32": true, "arm": true, "armbe": true, "arm64": true,
+	"arm64be": true, "loong64": true, "mips": true, "mipsle": true, "mips64": true,
+	"mips64le": true, "mips64p32": true, "mips64p32le": true, "ppc": true, "ppc64": true,
+	"ppc64le": true, "riscv": true, "riscv64": true, "s390": true, "s390x": true,
+	"sparc": true, "sparc64": true, "wasm": true,
 }
 
 // NewGolangParser creates a new Go parser
@@ -20,13 +56,46 @@ func NewGolangParser() *GolangParser {
 	return &GolangParser{}
 }
 
+// SplitGoModulePath splits a module path into the path without its major version suffix and
+// the major version of the suffix: "github.com/foo/bar/v2" -> ("github.com/foo/bar", 2),
+// "gopkg.in/yaml.v3" -> ("gopkg.in/yaml", 3). Paths without a suffix return major 0.
+func SplitGoModulePath(path string) (string, int) {
+	prefix, pathMajor, ok := module.SplitPathVersion(path)
+	if !ok || pathMajor == "" {
+		return path, 0
+	}
+	major, err := strconv.Atoi(strings.TrimLeft(pathMajor, "/.v"))
+	if err != nil {
+		return path, 0
+	}
+	return prefix, major
+}
+
+// GoModuleMajor returns the major version of a required module: the path suffix, else the
+// major of the version (v0, v1, or +incompatible majors), else -1
+func GoModuleMajor(path, version string) int {
+	if _, major := SplitGoModulePath(path); major > 0 {
+		return major
+	}
+	if major, err := strconv.Atoi(strings.TrimPrefix(semver.Major(version), "v")); err == nil {
+		return major
+	}
+	return -1
+}
+
 // buildGoMetadata creates metadata map for Go dependencies
-func (p *GolangParser) buildGoMetadata(depPath string, replaceMap map[string]string) map[string]interface{} {
+func (p *GolangParser) buildGoMetadata(depPath, version string, replaceMap map[string]string) map[string]interface{} {
 	metadata := make(map[string]interface{})
 
 	// Add source file
 	metadata["source"] = MetadataSourceGoMod
 
+	// Logical module and major version, so /v2 and /v3 paths group as majors of one package
+	metadata[MetadataGoModule], _ = SplitGoModulePath(depPath)
+	if major := GoModuleMajor(depPath, version); major >= 0 {
+		metadata[MetadataMajorVersion] = major
+	}
+
 	// Add replace directive if this dependency is replaced
 	if replacement, exists := replaceMap[depPath]; exists {
 		metadata["replaced_by"] = replacement
@@ -57,6 +126,17 @@ func (p *GolangParser) ParseGoModWithInfo(content string) ([]types.Dependency, *
 		info.GoVersion = file.Go.Version
 	}
 
+	// Extract toolchain and godebug settings
+	if file.Toolchain != nil {
+		info.Toolchain = file.Toolchain.Name
+	}
+	for _, godebug := range file.Godebug {
+		if info.Godebug == nil {
+			info.Godebug = make(map[string]string)
+		}
+		info.Godebug[godebug.Key] = godebug.Value
+	}
+
 	// Build replace map for quick lookup
 	replaceMap := make(map[string]string)
 	for _, replace := range file.Replace {
@@ -70,7 +150,7 @@ func (p *GolangParser) ParseGoModWithInfo(content string) ([]types.Dependency, *
 			continue
 		}
 
-		metadata := p.buildGoMetadata(req.Mod.Path, replaceMap)
+		metadata := p.buildGoMetadata(req.Mod.Path, req.Mod.Version, replaceMap)
 
 		dependencies = append(dependencies, types.Dependency{
 			Type:     DependencyTypeGolang,
@@ -84,3 +164,109 @@ func (p *GolangParser) ParseGoModWithInfo(content string) ([]types.Dependency, *
 
 	return dependencies, info
 }
+
+// ParseGoSource reads the build constraint and imports of a Go source file. Platforms are the
+// GOOS/GOARCH values required by the constraint (negated ones like "!windows" do not restrict
+// the file) and of the file name suffix (_linux.go, _windows_amd64.go).
+func (p *GolangParser) ParseGoSource(fileName, content string) GoSourceInfo {
+	info := GoSourceInfo{}
+	tags := make(map[string]bool)
+	platforms := make(map[string]bool)
+
+	for _, platform := range fileNamePlatforms(fileName) {
+		platforms[platform] = true
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
+		if !constraint.IsGoBuild(line) && !constraint.IsPlusBuild(line) {
+			continue
+		}
+		expr, err := constraint.Parse(line)
+		if err != nil {
+			continue
+		}
+		collectConstraintTags(expr, false, tags, platforms, &info)
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), fileName, content, parser.ImportsOnly)
+	if err == nil {
+		for _, spec := range file.Imports {
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			if path == "C" {
+				info.Cgo = true
+				continue
+			}
+			info.Imports = append(info.Imports, path)
+		}
+	}
+
+	info.Tags = sortedKeys(tags)
+	info.Platforms = sortedKeys(platforms)
+	return info
+}
+
+// collectConstraintTags records the tags of a build constraint expression; platforms only count
+// when they are required, not negated
+func collectConstraintTags(expr constraint.Expr, negated bool, tags, platforms map[string]bool, info *GoSourceInfo) {
+	switch e := expr.(type) {
+	case *constraint.NotExpr:
+		collectConstraintTags(e.X, !negated, tags, platforms, info)
+	case *constraint.AndExpr:
+		collectConstraintTags(e.X, negated, tags, platforms, info)
+		collectConstraintTags(e.Y, negated, tags, platforms, info)
+	case *constraint.OrExpr:
+		collectConstraintTags(e.X, negated, tags, platforms, info)
+		collectConstraintTags(e.Y, negated, tags, platforms, info)
+	case *constraint.TagExpr:
+		switch {
+		case e.Tag == "cgo":
+			info.Cgo = info.Cgo || !negated
+		case goOperatingSystems[e.Tag] || goArchitectures[e.Tag] || e.Tag == "unix":
+			if !negated {
+				platforms[e.Tag] = true
+			}
+		case e.Tag == "ignore" || e.Tag == "gc" || e.Tag == "gccgo" || strings.HasPrefix(e.Tag, "go1."):
+			// Toolchain and release tags do not make a file platform-specific
+		default:
+			tags[e.Tag] = true
+		}
+	}
+}
+
+// fileNamePlatforms returns the GOOS and GOARCH of a file name suffix (name_GOOS_GOARCH.go,
+// name_GOOS.go, name_GOARCH.go; _test is ignored)
+func fileNamePlatforms(fileName string) []string {
+	name := strings.TrimSuffix(strings.TrimSuffix(fileName, ".go"), "_test")
+	parts := strings.Split(name, "_")
+	if len(parts) < 2 {
+		return nil
+	}
+	last := parts[len(parts)-1]
+	if len(parts) >= 3 && goOperatingSystems[parts[len(parts)-2]] && goArchitectures[last] {
+		return []string{parts[len(parts)-2], last}
+	}
+	if goOperatingSystems[last] || goArchitectures[last] {
+		return []string{last}
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of a set in order, or nil for an empty set
+func sortedKeys(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -3,6 +3,7 @@ package parsers
 import (
 	"testing"
 
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,7 +22,7 @@ require github.com/gin-gonic/gin v1.9.0`
 		assert.Equal(t, "golang", deps[0].Type)
 		assert.Equal(t, "github.com/gin-gonic/gin", deps[0].Name)
 		assert.Equal(t, "v1.9.0", deps[0].Version)
-		assert.Equal(t, "prod", deps[0].Scope)
+		assert.Equal(t, types.ScopeProd, deps[0].Scope)
 		assert.True(t, deps[0].Direct)
 		assert.Equal(t, "github.com/example/test", info.ModulePath)
 		assert.Equal(t, "1.21", info.GoVersion)
@@ -96,3 +97,128 @@ require (
 		assert.Equal(t, "v1.8.0", deps[1].Version)
 	})
 }
+
+func TestGolangParser_MajorVersions(t *testing.T) {
+	parser := NewGolangParser()
+	content := `module github.com/example/test
+
+go 1.21
+
+require (
+	github.com/foo/bar v1.4.0
+	github.com/foo/bar/v2 v2.1.0
+	gopkg.in/yaml.v3 v3.0.1
+	github.com/docker/docker v24.0.7+incompatible
+	github.com/pkg/errors v0.9.1
+	example.com/pseudo v0.0.0-20240101000000-abcdefabcdef
+)`
+
+	deps, _ := parser.ParseGoModWithInfo(content)
+	assert.Len(t, deps, 6)
+
+	expected := []struct {
+		name   string
+		module string
+		major  int
+	}{
+		{"github.com/foo/bar", "github.com/foo/bar", 1},
+		{"github.com/foo/bar/v2", "github.com/foo/bar", 2},
+		{"gopkg.in/yaml.v3", "gopkg.in/yaml", 3},
+		{"github.com/docker/docker", "github.com/docker/docker", 24},
+		{"github.com/pkg/errors", "github.com/pkg/errors", 0},
+		{"example.com/pseudo", "example.com/pseudo", 0},
+	}
+	for i, e := range expected {
+		assert.Equal(t, e.name, deps[i].Name, "the dependency name stays the module path")
+		assert.Equal(t, e.module, deps[i].Metadata[MetadataGoModule], e.name)
+		assert.Equal(t, e.major, deps[i].Metadata[MetadataMajorVersion], e.name)
+	}
+}
+
+func TestSplitGoModulePath(t *testing.T) {
+	tests := []struct {
+		path  string
+		base  string
+		major int
+	}{
+		{"github.com/foo/bar/v2", "github.com/foo/bar", 2},
+		{"github.com/foo/bar/v12", "github.com/foo/bar", 12},
+		{"github.com/foo/bar", "github.com/foo/bar", 0},
+		{"gopkg.in/yaml.v2", "gopkg.in/yaml", 2},
+		{"gopkg.in/check.v1", "gopkg.in/check", 1},
+		{"github.com/foo/bar/v1", "github.com/foo/bar/v1", 0},
+		{"github.com/foo/v2/pkg", "github.com/foo/v2/pkg", 0},
+	}
+	for _, tt := range tests {
+		base, major := SplitGoModulePath(tt.path)
+		assert.Equal(t, tt.base, base, tt.path)
+		assert.Equal(t, tt.major, major, tt.path)
+	}
+
+	assert.Equal(t, -1, GoModuleMajor("github.com/foo/bar", ""))
+	assert.Equal(t, 3, GoModuleMajor("github.com/foo/bar/v3", ""))
+}
+
+func TestGolangParser_ToolchainAndGodebug(t *testing.T) {
+	parser := NewGolangParser()
+	content := `module github.com/example/test
+
+go 1.22
+
+toolchain go1.22.3
+
+godebug default=go1.21
+
+godebug (
+	panicnil=1
+	http2client=0
+)`
+
+	_, info := parser.ParseGoModWithInfo(content)
+	assert.Equal(t, "go1.22.3", info.Toolchain)
+	assert.Equal(t, map[string]string{"default": "go1.21", "panicnil": "1", "http2client": "0"}, info.Godebug)
+
+	_, info = parser.ParseGoModWithInfo("module x\n\ngo 1.21\n")
+	assert.Empty(t, info.Toolchain)
+	assert.Nil(t, info.Godebug)
+}
+
+func TestGolangParser_ParseGoSource(t *testing.T) {
+	parser := NewGolangParser()
+
+	t.Run("cgo import", func(t *testing.T) {
+		info := parser.ParseGoSource("sqlite.go", "package db\n\n// #include <sqlite3.h>\nimport \"C\"\n\nimport \"fmt\"\n")
+		assert.True(t, info.Cgo)
+		assert.Equal(t, []string{"fmt"}, info.Imports)
+		assert.Empty(t, info.Platforms)
+	})
+
+	t.Run("build constraint", func(t *testing.T) {
+		content := "// Copyright\n\n//go:build (linux || darwin) && !arm && integration\n\npackage x\n\nimport (\n\t\"os\"\n\t\"golang.org/x/sys/unix\"\n)\n"
+		info := parser.ParseGoSource("watch.go", content)
+		assert.Equal(t, []string{"darwin", "linux"}, info.Platforms, "negated platforms do not restrict the file")
+		assert.Equal(t, []string{"integration"}, info.Tags)
+		assert.Equal(t, []string{"os", "golang.org/x/sys/unix"}, info.Imports)
+		assert.False(t, info.Cgo)
+	})
+
+	t.Run("legacy plus build and cgo tag", func(t *testing.T) {
+		info := parser.ParseGoSource("x.go", "// +build cgo,go1.18 !windows\n\npackage x\n")
+		assert.True(t, info.Cgo)
+		assert.Empty(t, info.Tags, "release tags are not custom tags")
+		assert.Empty(t, info.Platforms)
+	})
+
+	t.Run("constraints after the package clause are ignored", func(t *testing.T) {
+		info := parser.ParseGoSource("x.go", "package x\n\n//go:build linux\n")
+		assert.Empty(t, info.Platforms)
+	})
+
+	t.Run("file name suffix", func(t *testing.T) {
+		assert.Equal(t, []string{"windows"}, parser.ParseGoSource("service_windows.go", "package x\n").Platforms)
+		assert.Equal(t, []string{"amd64", "linux"}, parser.ParseGoSource("asm_linux_amd64.go", "package x\n").Platforms)
+		assert.Equal(t, []string{"arm64"}, parser.ParseGoSource("cpu_arm64_test.go", "package x\n").Platforms)
+		assert.Empty(t, parser.ParseGoSource("windows.go", "package x\n").Platforms, "a platform name alone is not a suffix")
+		assert.Empty(t, parser.ParseGoSource("user_service.go", "package x\n").Platforms)
+	})
+}
@@ -1,6 +1,7 @@
 package parsers
 
 import (
+	"path"
 	"regexp"
 	"strings"
 
@@ -11,6 +12,15 @@ import (
 var (
 	gradleDepTypeRegex = regexp.MustCompile(`^\s*(testImplementation|testRuntimeOnly|testCompileOnly|testApi|compileOnly|annotationProcessor|runtimeOnly|implementation|compile|api)`)
 	gradleQuotedRegex  = regexp.MustCompile(`['"]([^'"]+)['"]`)
+
+	gradlePluginsBlockRegex = regexp.MustCompile(`^plugins\s*\{`)
+	gradlePluginRegex       = regexp.MustCompile(`^(id|kotlin)\s*\(?\s*['"]([^'"]+)['"]\s*\)?(?:\s*version\s*\(?\s*['"]([^'"]+)['"]\s*\)?)?`)
+	gradleClasspathRegex    = regexp.MustCompile(`^classpath\s*\(?\s*['"]([^'"]+)['"]`)
+
+	gradleIncludeBuildRegex = regexp.MustCompile(`^includeBuild\s*\(?\s*['"]([^'"]+)['"]\s*\)?`)
+	gradleIncludeRegex      = regexp.MustCompile(`^include(?:\s+|\s*\()`)
+	gradleRootProjectRegex  = regexp.MustCompile(`rootProject\.name\s*=\s*['"]([^'"]+)['"]`)
+	gradleSubstituteRegex   = regexp.MustCompile(`substitute\s*\(?\s*module\s*\(\s*['"]([^'"]+)['"]\s*\)\s*\)?\s*\.?\s*(?:using|with)\s*\(?\s*project\s*\(\s*['"]([^'"]*)['"]`)
 )
 
 // GradleParser handles Gradle-specific file parsing (build.gradle, build.gradle.kts)
@@ -46,7 +56,111 @@ func (p *GradleParser) ParseGradle(content string) []types.Dependency {
 		}
 	}
 
-	return dependencies
+	return NormalizeDependencyVersions(dependencies)
+}
+
+// ParseGradlePlugins extracts the build plugins of build.gradle or build.gradle.kts as build-scope
+// dependencies: plugins {} entries by plugin ID (id("org.springframework.boot") version "3.2.0",
+// kotlin("jvm") as org.jetbrains.kotlin.jvm) and legacy buildscript classpath artifacts. Core
+// plugins (java, application) ship with Gradle and are skipped, as are version catalog aliases.
+func (p *GradleParser) ParseGradlePlugins(content string) []types.Dependency {
+	var plugins []types.Dependency
+	inPlugins := false
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if p.shouldSkipLine(line) {
+			continue
+		}
+
+		if !inPlugins {
+			if match := gradleClasspathRegex.FindStringSubmatch(line); match != nil {
+				if dep := p.parseGradleClasspath(match[1]); dep != nil {
+					plugins = append(plugins, *dep)
+				}
+				continue
+			}
+			if loc := gradlePluginsBlockRegex.FindStringIndex(line); loc != nil {
+				inPlugins = true
+				line = strings.TrimSpace(line[loc[1]:])
+			}
+		}
+		if !inPlugins {
+			continue
+		}
+
+		// The plugins block allows no nested blocks, so the first closing brace ends it
+		if end := strings.Index(line, "}"); end >= 0 {
+			line = strings.TrimSpace(line[:end])
+			inPlugins = false
+		}
+		for _, statement := range strings.Split(line, ";") {
+			if dep := p.parseGradlePlugin(strings.TrimSpace(statement)); dep != nil {
+				plugins = append(plugins, *dep)
+			}
+		}
+	}
+
+	return NormalizeDependencyVersions(plugins)
+}
+
+// parseGradlePlugin parses one statement of the plugins block
+func (p *GradleParser) parseGradlePlugin(statement string) *types.Dependency {
+	match := gradlePluginRegex.FindStringSubmatch(statement)
+	if match == nil {
+		return nil
+	}
+
+	id := match[2]
+	if match[1] == "kotlin" {
+		id = "org.jetbrains.kotlin." + id
+	}
+	if !strings.Contains(id, ".") {
+		return nil // Core plugin
+	}
+	version := match[3]
+	if version == "" {
+		version = "latest"
+	}
+
+	metadata := types.NewMetadata(MetadataSourceBuildGradle)
+	metadata[MetadataPlugin] = true
+	if strings.Contains(statement[len(match[0]):], "apply false") {
+		metadata[MetadataApplied] = false
+	}
+
+	return &types.Dependency{
+		Type:     DependencyTypeGradle,
+		Name:     id,
+		Version:  version,
+		Scope:    types.ScopeBuild,
+		Direct:   true,
+		Metadata: metadata,
+	}
+}
+
+// parseGradleClasspath parses a buildscript classpath artifact (group:artifact:version)
+func (p *GradleParser) parseGradleClasspath(notation string) *types.Dependency {
+	parts := strings.Split(notation, ":")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return nil
+	}
+	version := "latest"
+	if len(parts) >= 3 && parts[2] != "" {
+		version = parts[2]
+	}
+
+	metadata := p.buildGradleMetadata("classpath", "", "")
+	metadata[MetadataPlugin] = true
+
+	return &types.Dependency{
+		Type:     DependencyTypeGradle,
+		Name:     parts[0] + ":" + parts[1],
+		Version:  version,
+		Scope:    types.ScopeBuild,
+		Direct:   true,
+		Metadata: metadata,
+	}
 }
 
 // GradleDependency represents a parsed Gradle dependency
@@ -105,6 +219,101 @@ func (p *GradleParser) ParseProjectInfo(content string) GradleProjectInfo {
 	return info
 }
 
+// GradleSettings holds the project structure declared in settings.gradle or settings.gradle.kts
+type GradleSettings struct {
+	RootProjectName string
+	Includes        []string              // Subprojects by project path without the leading colon ("core", "services:api")
+	IncludedBuilds  []GradleIncludedBuild // Builds of a composite build (includeBuild)
+}
+
+// GradleIncludedBuild is a build included into a composite build
+type GradleIncludedBuild struct {
+	Dir           string               // Directory relative to the settings file, slash-separated
+	Substitutions []GradleSubstitution // Explicit dependencySubstitution rules of the build
+}
+
+// GradleSubstitution replaces a module by a project of an included build
+type GradleSubstitution struct {
+	Module  string // group:artifact
+	Project string // Project path without the leading colon, empty for the root project
+}
+
+// ParseGradleSettings extracts the root project name, the subprojects, and the included builds
+// of a composite build from settings.gradle or settings.gradle.kts. Included builds in absolute
+// directories are skipped.
+func (p *GradleParser) ParseGradleSettings(content string) GradleSettings {
+	var settings GradleSettings
+	var current *GradleIncludedBuild
+	depth := 0
+
+	addSubstitutions := func(line string) {
+		for _, match := range gradleSubstituteRegex.FindAllStringSubmatch(line, -1) {
+			parts := strings.Split(match[1], ":")
+			if len(parts) >= 2 && parts[0] != "" && parts[1] != "" {
+				current.Substitutions = append(current.Substitutions, GradleSubstitution{
+					Module:  parts[0] + ":" + parts[1],
+					Project: strings.TrimPrefix(match[2], ":"),
+				})
+			}
+		}
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if p.shouldSkipLine(line) {
+			continue
+		}
+
+		// Inside the configuration block of an included build
+		if current != nil {
+			addSubstitutions(line)
+			depth += strings.Count(line, "{") - strings.Count(line, "}")
+			if depth <= 0 {
+				current = nil
+			}
+			continue
+		}
+
+		if match := gradleRootProjectRegex.FindStringSubmatch(line); match != nil {
+			settings.RootProjectName = match[1]
+			continue
+		}
+
+		// includeBuild may also appear in pluginManagement {} for builds providing plugins
+		if loc := strings.Index(line, "includeBuild"); loc >= 0 {
+			match := gradleIncludeBuildRegex.FindStringSubmatch(line[loc:])
+			if match == nil {
+				continue
+			}
+			dir := path.Clean(strings.ReplaceAll(match[1], "\\", "/"))
+			if path.IsAbs(dir) || (len(dir) > 1 && dir[1] == ':') {
+				continue
+			}
+			settings.IncludedBuilds = append(settings.IncludedBuilds, GradleIncludedBuild{Dir: dir})
+			rest := line[loc+len(match[0]):]
+			if open := strings.Index(rest, "{"); open >= 0 {
+				current = &settings.IncludedBuilds[len(settings.IncludedBuilds)-1]
+				addSubstitutions(rest)
+				depth = strings.Count(rest, "{") - strings.Count(rest, "}")
+				if depth <= 0 {
+					current = nil
+				}
+			}
+			continue
+		}
+
+		if loc := gradleIncludeRegex.FindStringIndex(line); loc != nil {
+			for _, match := range gradleQuotedRegex.FindAllStringSubmatch(line[loc[1]:], -1) {
+				if project := strings.TrimPrefix(match[1], ":"); project != "" {
+					settings.Includes = append(settings.Includes, project)
+				}
+			}
+		}
+	}
+
+	return settings
+}
+
 // shouldSkipLine checks if a line should be skipped during parsing
 func (p *GradleParser) shouldSkipLine(line string) bool {
 	return line == "" || strings.HasPrefix(line, "//") || strings.HasPrefix(line, "/*") || strings.HasPrefix(line, "*")
@@ -171,7 +380,7 @@ func (p *GradleParser) parseGradleDependency(line string) *types.Dependency {
 	dependencyName := group + ":" + artifact
 
 	// Map Gradle dependency types to scope constants
-	var scope string
+	var scope types.Scope
 	switch depType {
 	case "testImplementation", "testRuntimeOnly", "testCompileOnly", "testApi":
 		scope = types.ScopeDev
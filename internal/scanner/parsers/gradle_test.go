@@ -209,3 +209,120 @@ dependencies {
 	assert.Equal(t, "gradle", gradleDepMap["org.projectlombok:lombok"].Type)
 	assert.Equal(t, "1.18.24", gradleDepMap["org.projectlombok:lombok"].Version)
 }
+
+func TestGradleParser_ParseGradlePlugins(t *testing.T) {
+	parser := NewGradleParser()
+
+	t.Run("Kotlin DSL plugins block", func(t *testing.T) {
+		content := `plugins {
+	java
+	` + "`java-library`" + `
+	id("org.springframework.boot") version "3.2.0"
+	id("io.spring.dependency-management") version "1.1.4" apply false
+	kotlin("jvm") version "1.9.22"
+	alias(libs.plugins.spotless)
+}
+
+dependencies {
+	implementation("org.springframework.boot:spring-boot-starter-web")
+}`
+
+		plugins := parser.ParseGradlePlugins(content)
+		require.Len(t, plugins, 3)
+
+		assert.Equal(t, "org.springframework.boot", plugins[0].Name)
+		assert.Equal(t, "3.2.0", plugins[0].Version)
+		assert.NotContains(t, plugins[0].Metadata, MetadataApplied)
+		assert.Equal(t, "io.spring.dependency-management", plugins[1].Name)
+		assert.Equal(t, false, plugins[1].Metadata[MetadataApplied])
+		assert.Equal(t, "org.jetbrains.kotlin.jvm", plugins[2].Name)
+		assert.Equal(t, "1.9.22", plugins[2].Version)
+
+		for _, plugin := range plugins {
+			assert.Equal(t, DependencyTypeGradle, plugin.Type)
+			assert.Equal(t, types.ScopeBuild, plugin.Scope)
+			assert.Equal(t, true, plugin.Metadata[MetadataPlugin])
+			assert.Equal(t, MetadataSourceBuildGradle, plugin.Metadata["source"])
+		}
+	})
+
+	t.Run("Groovy DSL and buildscript classpath", func(t *testing.T) {
+		content := `buildscript {
+	dependencies {
+		classpath 'com.github.jengelman.gradle.plugins:shadow:6.1.0'
+	}
+}
+
+plugins { id 'java'; id 'com.diffplug.spotless' version '6.25.0' }
+
+apply plugin: 'com.github.johnrengelman.shadow'
+
+dependencies {
+	implementation 'com.google.guava:guava:31.1-jre'
+}`
+
+		plugins := parser.ParseGradlePlugins(content)
+		require.Len(t, plugins, 2)
+
+		assert.Equal(t, "com.github.jengelman.gradle.plugins:shadow", plugins[0].Name)
+		assert.Equal(t, "6.1.0", plugins[0].Version)
+		assert.Equal(t, "classpath", plugins[0].Metadata["configuration"])
+		assert.Equal(t, "com.diffplug.spotless", plugins[1].Name)
+		assert.Equal(t, "6.25.0", plugins[1].Version)
+	})
+
+	t.Run("plugin without version", func(t *testing.T) {
+		plugins := parser.ParseGradlePlugins("plugins {\n\tid 'org.springframework.boot'\n}")
+		require.Len(t, plugins, 1)
+		assert.Equal(t, "latest", plugins[0].Version)
+	})
+}
+
+func TestGradleParser_ParseGradleSettings(t *testing.T) {
+	parser := NewGradleParser()
+
+	t.Run("Kotlin DSL composite build", func(t *testing.T) {
+		content := `pluginManagement {
+	includeBuild("build-logic")
+}
+
+rootProject.name = "platform"
+
+include(":app", ":services:api")
+includeBuild("libs/core")
+includeBuild("../shared") {
+	dependencySubstitution {
+		substitute(module("com.example:shared-utils")).using(project(":utils"))
+		substitute(module("com.example:shared:1.0")).using(project(":"))
+	}
+}
+// includeBuild("disabled")
+includeBuild("/opt/builds/external")`
+
+		settings := parser.ParseGradleSettings(content)
+		assert.Equal(t, "platform", settings.RootProjectName)
+		assert.Equal(t, []string{"app", "services:api"}, settings.Includes)
+		assert.Equal(t, []GradleIncludedBuild{
+			{Dir: "build-logic"},
+			{Dir: "libs/core"},
+			{Dir: "../shared", Substitutions: []GradleSubstitution{
+				{Module: "com.example:shared-utils", Project: "utils"},
+				{Module: "com.example:shared", Project: ""},
+			}},
+		}, settings.IncludedBuilds, "Should skip commented out and absolute included builds")
+	})
+
+	t.Run("Groovy DSL", func(t *testing.T) {
+		content := `rootProject.name = 'legacy'
+include 'core', 'web'
+includeBuild '../plugins'
+includeBuild('lib') { dependencySubstitution { substitute module('org.sample:lib') with project(':') } }`
+
+		settings := parser.ParseGradleSettings(content)
+		assert.Equal(t, "legacy", settings.RootProjectName)
+		assert.Equal(t, []string{"core", "web"}, settings.Includes)
+		require.Len(t, settings.IncludedBuilds, 2)
+		assert.Equal(t, "../plugins", settings.IncludedBuilds[0].Dir)
+		assert.Equal(t, []GradleSubstitution{{Module: "org.sample:lib"}}, settings.IncludedBuilds[1].Substitutions)
+	})
+}
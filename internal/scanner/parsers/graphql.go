@@ -0,0 +1,449 @@
+package parsers
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GraphQL configuration tools
+const (
+	GraphQLToolCodegen = "codegen"
+	GraphQLToolConfig  = "graphql-config"
+)
+
+// GraphQL library roles
+const (
+	GraphQLRoleClient  = "client"
+	GraphQLRoleServer  = "server"
+	GraphQLRoleCodegen = "codegen"
+	GraphQLRoleCore    = "core"
+)
+
+// GraphQLConfigFiles maps GraphQL configuration file names to their tool
+var GraphQLConfigFiles = map[string]string{
+	"codegen.yml":         GraphQLToolCodegen,
+	"codegen.yaml":        GraphQLToolCodegen,
+	"codegen.json":        GraphQLToolCodegen,
+	"codegen.ts":          GraphQLToolCodegen,
+	"codegen.js":          GraphQLToolCodegen,
+	".graphqlrc":          GraphQLToolConfig,
+	".graphqlrc.yml":      GraphQLToolConfig,
+	".graphqlrc.yaml":     GraphQLToolConfig,
+	".graphqlrc.json":     GraphQLToolConfig,
+	".graphqlrc.ts":       GraphQLToolConfig,
+	".graphqlrc.js":       GraphQLToolConfig,
+	"graphql.config.yml":  GraphQLToolConfig,
+	"graphql.config.yaml": GraphQLToolConfig,
+	"graphql.config.json": GraphQLToolConfig,
+	"graphql.config.ts":   GraphQLToolConfig,
+	"graphql.config.js":   GraphQLToolConfig,
+}
+
+// graphQLDocumentExtensions are the file extensions of GraphQL schema and operation documents
+var graphQLDocumentExtensions = map[string]bool{
+	".graphql":  true,
+	".graphqls": true,
+	".gql":      true,
+}
+
+// graphQLLibraries maps dependency type and name to the role of a GraphQL library
+var graphQLLibraries = map[string]map[string]string{
+	DependencyTypeNpm: {
+		"graphql":                       GraphQLRoleCore,
+		"@apollo/client":                GraphQLRoleClient,
+		"apollo-client":                 GraphQLRoleClient,
+		"apollo-angular":                GraphQLRoleClient,
+		"urql":                          GraphQLRoleClient,
+		"@urql/core":                    GraphQLRoleClient,
+		"@urql/vue":                     GraphQLRoleClient,
+		"@urql/svelte":                  GraphQLRoleClient,
+		"relay-runtime":                 GraphQLRoleClient,
+		"react-relay":                   GraphQLRoleClient,
+		"graphql-request":               GraphQLRoleClient,
+		"@apollo/server":                GraphQLRoleServer,
+		"apollo-server":                 GraphQLRoleServer,
+		"apollo-server-express":         GraphQLRoleServer,
+		"graphql-yoga":                  GraphQLRoleServer,
+		"mercurius":                     GraphQLRoleServer,
+		"type-graphql":                  GraphQLRoleServer,
+		"@nestjs/graphql":               GraphQLRoleServer,
+		"@graphql-codegen/cli":          GraphQLRoleCodegen,
+		"relay-compiler":                GraphQLRoleCodegen,
+		"@apollo/rover":                 GraphQLRoleCodegen,
+		"graphql-config":                GraphQLRoleCodegen,
+		"@graphql-eslint/eslint-plugin": GraphQLRoleCodegen,
+	},
+	DependencyTypeMaven: {
+		"com.graphql-java:graphql-java":                            GraphQLRoleServer,
+		"com.graphql-java-kickstart:graphql-spring-boot-starter":   GraphQLRoleServer,
+		"com.netflix.graphql.dgs:graphql-dgs-spring-boot-starter":  GraphQLRoleServer,
+		"org.springframework.boot:spring-boot-starter-graphql":     GraphQLRoleServer,
+		"io.smallrye:smallrye-graphql-client":                      GraphQLRoleClient,
+		"com.apollographql.apollo3:apollo-runtime":                 GraphQLRoleClient,
+		"com.netflix.graphql.dgs.codegen:graphql-dgs-codegen-core": GraphQLRoleCodegen,
+	},
+	DependencyTypeGradle: {
+		"com.graphql-java:graphql-java":                            GraphQLRoleServer,
+		"com.netflix.graphql.dgs:graphql-dgs-spring-boot-starter":  GraphQLRoleServer,
+		"org.springframework.boot:spring-boot-starter-graphql":     GraphQLRoleServer,
+		"com.apollographql.apollo3:apollo-runtime":                 GraphQLRoleClient,
+		"com.apollographql.apollo:apollo-runtime":                  GraphQLRoleClient,
+		"com.netflix.graphql.dgs.codegen:graphql-dgs-codegen-core": GraphQLRoleCodegen,
+	},
+	DependencyTypePython: {
+		"graphql-core":       GraphQLRoleCore,
+		"graphene":           GraphQLRoleServer,
+		"strawberry-graphql": GraphQLRoleServer,
+		"ariadne":            GraphQLRoleServer,
+		"gql":                GraphQLRoleClient,
+		"sgqlc":              GraphQLRoleClient,
+	},
+	DependencyTypeDotnet: {
+		"HotChocolate.AspNetCore": GraphQLRoleServer,
+		"GraphQL.Server.All":      GraphQLRoleServer,
+		"GraphQL":                 GraphQLRoleServer,
+		"GraphQL.Client":          GraphQLRoleClient,
+		"StrawberryShake.Server":  GraphQLRoleClient,
+		"StrawberryShake.Blazor":  GraphQLRoleClient,
+	},
+}
+
+// graphQLCodegenPluginPrefix marks GraphQL Code Generator plugins and presets
+const graphQLCodegenPluginPrefix = "@graphql-codegen/"
+
+// Compile JS/TS configuration regexes once at package level for performance
+var (
+	jsSchemaRegex    = regexp.MustCompile(`\bschema\s*:\s*['"\x60]([^'"\x60]+)['"\x60]`)
+	jsDocumentsRegex = regexp.MustCompile(`\bdocuments\s*:\s*(?:\[([^\]]*)\]|['"\x60]([^'"\x60]+)['"\x60])`)
+	jsPluginsRegex   = regexp.MustCompile(`\bplugins\s*:\s*\[([^\]]*)\]`)
+	jsGeneratesRegex = regexp.MustCompile(`['"\x60]([^'"\x60]+)['"\x60]\s*:\s*\{\s*(?:preset|plugins)\b`)
+	jsStringRegex    = regexp.MustCompile(`['"\x60]([^'"\x60]+)['"\x60]`)
+)
+
+// GraphQLParser handles GraphQL configuration and document parsing
+type GraphQLParser struct{}
+
+// NewGraphQLParser creates a new GraphQL parser
+func NewGraphQLParser() *GraphQLParser {
+	return &GraphQLParser{}
+}
+
+// GraphQLConfig describes a GraphQL Code Generator or graphql-config configuration
+type GraphQLConfig struct {
+	File      string   `json:"file"`
+	Tool      string   `json:"tool"`                // codegen or graphql-config
+	Schema    []string `json:"schema,omitempty"`    // Schema sources (files, globs, or URLs)
+	Documents []string `json:"documents,omitempty"` // Operation document globs
+	Generates []string `json:"generates,omitempty"` // Generated output paths
+	Plugins   []string `json:"plugins,omitempty"`   // Codegen plugins and presets
+	Projects  []string `json:"projects,omitempty"`  // graphql-config project names
+}
+
+// GraphQLDocument summarizes a .graphql file: the schema surface it defines and the operations it contains
+type GraphQLDocument struct {
+	File          string `json:"file"`
+	Types         int    `json:"types,omitempty"`         // Named type definitions (type, input, enum, interface, union, scalar)
+	Queries       int    `json:"queries,omitempty"`       // Fields of the query root type
+	Mutations     int    `json:"mutations,omitempty"`     // Fields of the mutation root type
+	Subscriptions int    `json:"subscriptions,omitempty"` // Fields of the subscription root type
+	Operations    int    `json:"operations,omitempty"`    // Executable definitions (operations and fragments)
+}
+
+// IsGraphQLDocument reports whether a file name has a GraphQL document extension
+func IsGraphQLDocument(fileName string) bool {
+	return graphQLDocumentExtensions[strings.ToLower(path.Ext(fileName))]
+}
+
+// GraphQLLibraryRole returns the role of a GraphQL library dependency, or "" if it is not one
+func GraphQLLibraryRole(depType, name string) string {
+	if role, ok := graphQLLibraries[depType][name]; ok {
+		return role
+	}
+	if depType == DependencyTypeNpm && strings.HasPrefix(name, graphQLCodegenPluginPrefix) {
+		return GraphQLRoleCodegen
+	}
+	return ""
+}
+
+// graphQLConfigFile represents the subset of codegen and graphql-config files we use
+type graphQLConfigFile struct {
+	Schema     interface{}                  `yaml:"schema"`
+	Documents  interface{}                  `yaml:"documents"`
+	Generates  map[string]interface{}       `yaml:"generates"`
+	Extensions map[string]interface{}       `yaml:"extensions"`
+	Projects   map[string]graphQLConfigFile `yaml:"projects"`
+}
+
+// ParseConfig parses a codegen or graphql-config file. YAML and JSON files are fully parsed;
+// for JavaScript and TypeScript files string literals of the common keys are extracted.
+func (p *GraphQLParser) ParseConfig(content, fileName string) (*GraphQLConfig, error) {
+	config := &GraphQLConfig{Tool: GraphQLConfigFiles[fileName]}
+	if ext := path.Ext(fileName); ext == ".ts" || ext == ".js" {
+		p.parseScriptConfig(content, config)
+		config.normalize()
+		return config, nil
+	}
+
+	var raw graphQLConfigFile
+	if err := yaml.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, err
+	}
+	config.addSources(raw)
+	for name, project := range raw.Projects {
+		config.Projects = append(config.Projects, name)
+		config.addSources(project)
+	}
+	config.normalize()
+	return config, nil
+}
+
+// addSources adds schema, documents, and codegen outputs of a configuration (or project)
+func (c *GraphQLConfig) addSources(raw graphQLConfigFile) {
+	c.Schema = append(c.Schema, stringOrKeys(raw.Schema)...)
+	c.Documents = append(c.Documents, stringOrKeys(raw.Documents)...)
+	c.addGenerates(raw.Generates)
+	// graphql-config embeds codegen settings under extensions.codegen
+	if codegen, ok := raw.Extensions["codegen"].(map[string]interface{}); ok {
+		if generates, ok := codegen["generates"].(map[string]interface{}); ok {
+			c.addGenerates(generates)
+		}
+	}
+}
+
+// addGenerates records output paths and the plugins and presets producing them
+func (c *GraphQLConfig) addGenerates(generates map[string]interface{}) {
+	for output, value := range generates {
+		c.Generates = append(c.Generates, output)
+		target, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if preset, ok := target["preset"].(string); ok {
+			c.Plugins = append(c.Plugins, preset)
+		}
+		for _, plugin := range stringOrKeys(target["plugins"]) {
+			c.Plugins = append(c.Plugins, plugin)
+		}
+	}
+}
+
+// parseScriptConfig extracts configuration values from a codegen.ts or graphql.config.js file
+func (p *GraphQLParser) parseScriptConfig(content string, config *GraphQLConfig) {
+	for _, m := range jsSchemaRegex.FindAllStringSubmatch(content, -1) {
+		config.Schema = append(config.Schema, m[1])
+	}
+	for _, m := range jsDocumentsRegex.FindAllStringSubmatch(content, -1) {
+		if m[2] != "" {
+			config.Documents = append(config.Documents, m[2])
+			continue
+		}
+		for _, s := range jsStringRegex.FindAllStringSubmatch(m[1], -1) {
+			config.Documents = append(config.Documents, s[1])
+		}
+	}
+	for _, m := range jsGeneratesRegex.FindAllStringSubmatch(content, -1) {
+		config.Generates = append(config.Generates, m[1])
+	}
+	for _, m := range jsPluginsRegex.FindAllStringSubmatch(content, -1) {
+		for _, s := range jsStringRegex.FindAllStringSubmatch(m[1], -1) {
+			config.Plugins = append(config.Plugins, s[1])
+		}
+	}
+}
+
+// normalize sorts and deduplicates all lists for deterministic output
+func (c *GraphQLConfig) normalize() {
+	c.Schema = sortedUnique(c.Schema)
+	c.Documents = sortedUnique(c.Documents)
+	c.Generates = sortedUnique(c.Generates)
+	c.Plugins = sortedUnique(c.Plugins)
+	c.Projects = sortedUnique(c.Projects)
+}
+
+// stringOrKeys returns a string value, the strings of a list, or the keys of maps
+// (codegen allows `schema: {url: {headers: ...}}` and plugin entries with options)
+func stringOrKeys(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var result []string
+		for _, item := range v {
+			result = append(result, stringOrKeys(item)...)
+		}
+		return result
+	case map[string]interface{}:
+		var result []string
+		for key := range v {
+			result = append(result, key)
+		}
+		return result
+	}
+	return nil
+}
+
+// sortedUnique returns the sorted distinct values of list (nil for an empty list)
+func sortedUnique(list []string) []string {
+	var result []string
+	for _, value := range list {
+		if value != "" && !containsString(result, value) {
+			result = append(result, value)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// ParseDocument counts the type definitions, root operation fields, and executable
+// definitions of a GraphQL document. Root types can be renamed via a schema definition.
+func (p *GraphQLParser) ParseDocument(content string) GraphQLDocument {
+	var doc GraphQLDocument
+	tokens := graphQLTokens(content)
+	roots := map[string]string{"query": "Query", "mutation": "Mutation", "subscription": "Subscription"}
+	fields := make(map[string]int) // object type -> field count
+
+	depth, parens := 0, 0
+	pending := ""     // kind of the definition waiting for its block: type, block, schema, operation
+	pendingName := "" // name of a pending object type
+	current := ""     // object type whose fields are counted
+	inSchema := false
+	extend := false
+
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch tok {
+		case "{":
+			if depth == 0 {
+				switch pending {
+				case "type":
+					current = pendingName
+				case "schema":
+					inSchema = true
+				case "":
+					doc.Operations++ // anonymous query
+				}
+				pending, pendingName = "", ""
+			}
+			depth++
+			continue
+		case "}":
+			if depth--; depth == 0 {
+				current, inSchema = "", false
+			}
+			continue
+		case "(":
+			parens++
+			continue
+		case ")":
+			parens--
+			continue
+		}
+
+		hasNext := i+1 < len(tokens)
+		switch {
+		case depth == 0 && parens == 0:
+			switch tok {
+			case "extend":
+				extend = true
+				continue
+			case "type":
+				if hasNext {
+					pending, pendingName = "type", tokens[i+1]
+					i++
+				}
+				if !extend {
+					doc.Types++
+				}
+			case "interface", "input", "enum", "union", "scalar":
+				pending = "block"
+				if hasNext {
+					i++
+				}
+				if !extend {
+					doc.Types++
+				}
+			case "schema":
+				pending = "schema"
+			case "query", "mutation", "subscription", "fragment":
+				pending = "operation"
+				doc.Operations++
+			}
+			extend = false
+		case depth == 1 && parens == 0 && inSchema:
+			if _, ok := roots[tok]; ok && i+2 < len(tokens) && tokens[i+1] == ":" {
+				roots[tok] = tokens[i+2]
+				i += 2
+			}
+		case depth == 1 && parens == 0 && current != "":
+			if hasNext && (tokens[i+1] == ":" || tokens[i+1] == "(") && (i == 0 || tokens[i-1] != "@") && isGraphQLName(tok) {
+				fields[current]++
+			}
+		}
+	}
+
+	doc.Queries = fields[roots["query"]]
+	doc.Mutations = fields[roots["mutation"]]
+	doc.Subscriptions = fields[roots["subscription"]]
+	return doc
+}
+
+// graphQLTokens splits a GraphQL document into names and the punctuators relevant for
+// structure; comments, strings (including block descriptions), and numbers are dropped
+func graphQLTokens(content string) []string {
+	var tokens []string
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case c == '#':
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(content[i:], `"""`):
+			end := strings.Index(content[i+3:], `"""`)
+			if end < 0 {
+				return tokens
+			}
+			i += end + 6
+		case c == '"':
+			for i++; i < len(content) && content[i] != '"' && content[i] != '\n'; i++ {
+				if content[i] == '\\' {
+					i++
+				}
+			}
+			i++
+		case isGraphQLNameStart(c):
+			j := i + 1
+			for j < len(content) && (isGraphQLNameStart(content[j]) || isDigitByte(content[j])) {
+				j++
+			}
+			tokens = append(tokens, content[i:j])
+			i = j
+		case strings.IndexByte("{}():@", c) >= 0:
+			tokens = append(tokens, string(c))
+			i++
+		case isDigitByte(c):
+			for i < len(content) && (isGraphQLNameStart(content[i]) || isDigitByte(content[i]) || content[i] == '.') {
+				i++
+			}
+		default:
+			i++
+		}
+	}
+	return tokens
+}
+
+func isGraphQLNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigitByte(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isGraphQLName(tok string) bool {
+	return tok != "" && isGraphQLNameStart(tok[0])
+}
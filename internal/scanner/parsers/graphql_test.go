@@ -0,0 +1,213 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphQLParser_ParseConfig(t *testing.T) {
+	parser := NewGraphQLParser()
+
+	tests := []struct {
+		name     string
+		fileName string
+		content  string
+		expected *GraphQLConfig
+	}{
+		{
+			name:     "codegen YAML",
+			fileName: "codegen.yml",
+			content: `overwrite: true
+schema:
+  - "schema.graphql"
+  - https://api.example.com/graphql:
+      headers:
+        Authorization: Bearer token
+documents: "src/**/*.graphql"
+generates:
+  src/gql/:
+    preset: client
+  src/types.ts:
+    plugins:
+      - typescript
+      - typescript-operations:
+          avoidOptionals: true
+`,
+			expected: &GraphQLConfig{
+				Tool:      "codegen",
+				Schema:    []string{"https://api.example.com/graphql", "schema.graphql"},
+				Documents: []string{"src/**/*.graphql"},
+				Generates: []string{"src/gql/", "src/types.ts"},
+				Plugins:   []string{"client", "typescript", "typescript-operations"},
+			},
+		},
+		{
+			name:     "graphql-config with projects and codegen extension",
+			fileName: ".graphqlrc.yml",
+			content: `projects:
+  app:
+    schema: schema.graphql
+    documents: app/**/*.graphql
+    extensions:
+      codegen:
+        generates:
+          app/generated.ts:
+            plugins: [typescript]
+  admin:
+    schema: admin.graphql
+`,
+			expected: &GraphQLConfig{
+				Tool:      "graphql-config",
+				Schema:    []string{"admin.graphql", "schema.graphql"},
+				Documents: []string{"app/**/*.graphql"},
+				Generates: []string{"app/generated.ts"},
+				Plugins:   []string{"typescript"},
+				Projects:  []string{"admin", "app"},
+			},
+		},
+		{
+			name:     "graphqlrc JSON",
+			fileName: ".graphqlrc",
+			content:  `{"schema": "./schema.graphql", "documents": ["src/**/*.tsx"]}`,
+			expected: &GraphQLConfig{
+				Tool:      "graphql-config",
+				Schema:    []string{"./schema.graphql"},
+				Documents: []string{"src/**/*.tsx"},
+			},
+		},
+		{
+			name:     "codegen TypeScript",
+			fileName: "codegen.ts",
+			content: `import type { CodegenConfig } from '@graphql-codegen/cli';
+
+const config: CodegenConfig = {
+  schema: 'http://localhost:4000/graphql',
+  documents: ['src/**/*.tsx', 'src/**/*.ts'],
+  generates: {
+    './src/gql/': {
+      preset: 'client',
+    },
+    'src/resolvers.ts': {
+      plugins: ['typescript', 'typescript-resolvers'],
+    },
+  },
+};
+export default config;
+`,
+			expected: &GraphQLConfig{
+				Tool:      "codegen",
+				Schema:    []string{"http://localhost:4000/graphql"},
+				Documents: []string{"src/**/*.ts", "src/**/*.tsx"},
+				Generates: []string{"./src/gql/", "src/resolvers.ts"},
+				Plugins:   []string{"typescript", "typescript-resolvers"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := parser.ParseConfig(tt.content, tt.fileName)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, config)
+		})
+	}
+
+	_, err := parser.ParseConfig("schema: [", "codegen.yml")
+	assert.Error(t, err)
+}
+
+func TestGraphQLParser_ParseDocument(t *testing.T) {
+	parser := NewGraphQLParser()
+
+	tests := []struct {
+		name     string
+		content  string
+		expected GraphQLDocument
+	}{
+		{
+			name: "schema",
+			content: `"""
+The query root. type Fake { ignored: Int }
+"""
+type Query {
+  "Fetch a user"
+  user(id: ID!, filter: UserFilter = {active: true}): User
+  users(
+    first: Int = 10
+    after: String
+  ): [User!]! @deprecated(reason: "use search")
+  # comment: Int
+}
+
+type Mutation {
+  createUser(input: CreateUserInput!): User
+}
+
+extend type Query {
+  search(term: String!): [User!]!
+}
+
+type User implements Node @key(fields: "id") {
+  id: ID!
+  name: String
+}
+
+interface Node { id: ID! }
+input UserFilter { active: Boolean }
+input CreateUserInput { name: String! }
+enum Role { ADMIN USER }
+union SearchResult = User
+scalar DateTime
+directive @auth(requires: Role = ADMIN) on FIELD_DEFINITION
+`,
+			expected: GraphQLDocument{Types: 9, Queries: 3, Mutations: 1},
+		},
+		{
+			name: "renamed root types",
+			content: `schema { query: RootQuery subscription: RootSubscription }
+type RootQuery { a: Int b: Int }
+type RootSubscription { onEvent: String }
+type Query { ignored: Int }
+`,
+			expected: GraphQLDocument{Types: 3, Queries: 2, Subscriptions: 1},
+		},
+		{
+			name: "operations",
+			content: `query GetUser($id: ID!) {
+  user(id: $id) { ...UserFields }
+}
+
+mutation CreateUser($input: CreateUserInput!) { createUser(input: $input) { id } }
+
+fragment UserFields on User { id name }
+
+{ viewer { id } }
+`,
+			expected: GraphQLDocument{Operations: 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parser.ParseDocument(tt.content))
+		})
+	}
+}
+
+func TestGraphQLLibraryRole(t *testing.T) {
+	assert.Equal(t, "client", GraphQLLibraryRole("npm", "@apollo/client"))
+	assert.Equal(t, "server", GraphQLLibraryRole("maven", "com.graphql-java:graphql-java"))
+	assert.Equal(t, "codegen", GraphQLLibraryRole("npm", "@graphql-codegen/typescript"))
+	assert.Equal(t, "core", GraphQLLibraryRole("npm", "graphql"))
+	assert.Equal(t, "", GraphQLLibraryRole("npm", "react"))
+	assert.Equal(t, "", GraphQLLibraryRole("python", "@apollo/client"))
+}
+
+func TestIsGraphQLDocument(t *testing.T) {
+	assert.True(t, IsGraphQLDocument("schema.graphql"))
+	assert.True(t, IsGraphQLDocument("schema.GRAPHQLS"))
+	assert.True(t, IsGraphQLDocument("queries.gql"))
+	assert.False(t, IsGraphQLDocument("codegen.yml"))
+}
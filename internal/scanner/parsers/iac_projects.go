@@ -0,0 +1,294 @@
+package parsers
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Infrastructure-as-code tools detected from project files
+const (
+	IaCToolPulumi = "pulumi"
+	IaCToolCDK    = "aws-cdk"
+)
+
+// pulumiProviderClouds maps Pulumi provider names to the cloud technology they target
+var pulumiProviderClouds = map[string]string{
+	"aws":           "aws",
+	"awsx":          "aws",
+	"aws-native":    "aws",
+	"eks":           "aws",
+	"azure":         "azure",
+	"azure-native":  "azure",
+	"azuread":       "azure",
+	"gcp":           "gcp",
+	"google-native": "gcp",
+	"kubernetes":    "kubernetes",
+	"cloudflare":    "cloudflare",
+	"digitalocean":  "digitalocean",
+	"alicloud":      "alibabacloud",
+	"hcloud":        "hetzner",
+	"oci":           "oraclecloud",
+	"ibm":           "ibmcloud",
+	"openstack":     "openstack",
+	"scaleway":      "scaleway",
+	"ovh":           "ovh",
+	"vercel":        "vercel",
+}
+
+// pulumiNonProviders are Pulumi packages that are part of the SDK rather than resource providers
+var pulumiNonProviders = map[string]bool{
+	"pulumi":     true,
+	"policy":     true,
+	"automation": true,
+}
+
+// cdkCoreLibraries are the CDK core construct libraries (aws-cdk-lib in v2, core in v1) per ecosystem
+var cdkCoreLibraries = map[string]bool{
+	"aws-cdk-lib":                         true,
+	"@aws-cdk/core":                       true,
+	"aws-cdk-core":                        true,
+	"Amazon.CDK.Lib":                      true,
+	"Amazon.CDK":                          true,
+	"github.com/aws/aws-cdk-go/awscdk/v2": true,
+	"github.com/aws/aws-cdk-go/awscdk":    true,
+	"software.amazon.awscdk:aws-cdk-lib":  true,
+	"software.amazon.awscdk:core":         true,
+}
+
+// cdkLanguages maps the interpreter of a cdk.json "app" command to the project language
+var cdkLanguages = map[string]string{
+	"ts-node":   "typescript",
+	"tsx":       "typescript",
+	"node":      "javascript",
+	"python":    "python",
+	"python3":   "python",
+	"mvn":       "java",
+	"gradle":    "java",
+	"./gradlew": "java",
+	"dotnet":    "dotnet",
+	"go":        "go",
+}
+
+// IaCProjectParser handles Pulumi and AWS CDK project parsing
+type IaCProjectParser struct{}
+
+// NewIaCProjectParser creates a new infrastructure-as-code project parser
+func NewIaCProjectParser() *IaCProjectParser {
+	return &IaCProjectParser{}
+}
+
+// IaCProject describes a Pulumi or AWS CDK project
+type IaCProject struct {
+	Tool         string       `json:"tool"` // pulumi or aws-cdk
+	File         string       `json:"file"`
+	Name         string       `json:"name,omitempty"`
+	Language     string       `json:"language,omitempty"`
+	Version      string       `json:"version,omitempty"`  // Version of the core library (pulumi SDK, aws-cdk-lib)
+	Stacks       []string     `json:"stacks,omitempty"`   // Pulumi stacks with a Pulumi.<stack>.yaml
+	Packages     []IaCPackage `json:"packages,omitempty"` // Pulumi providers or CDK construct libraries
+	CloudTargets []string     `json:"cloud_targets,omitempty"`
+}
+
+// IaCPackage is a Pulumi provider or CDK construct library with its declared version
+type IaCPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// pulumiYAML represents the subset of Pulumi.yaml we use
+type pulumiYAML struct {
+	Name      string                            `yaml:"name"`
+	Runtime   interface{}                       `yaml:"runtime"`
+	Resources map[string]map[string]interface{} `yaml:"resources"`
+}
+
+// ParsePulumiYAML parses a Pulumi.yaml project file. For YAML programs, providers are
+// taken from the resource types (aws:s3:Bucket).
+func (p *IaCProjectParser) ParsePulumiYAML(content string) (*IaCProject, error) {
+	var raw pulumiYAML
+	if err := yaml.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, err
+	}
+
+	project := &IaCProject{Tool: IaCToolPulumi, Name: raw.Name}
+	switch runtime := raw.Runtime.(type) {
+	case string:
+		project.Language = runtime
+	case map[string]interface{}: // runtime: {name: python, options: {...}}
+		project.Language, _ = runtime["name"].(string)
+	}
+
+	for _, resource := range raw.Resources {
+		resourceType, _ := resource["type"].(string)
+		if provider, _, ok := strings.Cut(resourceType, ":"); ok && provider != "pulumi" {
+			project.addPackage(IaCPackage{Name: provider})
+		}
+	}
+	project.normalize()
+	return project, nil
+}
+
+// cdkJSON represents the subset of cdk.json we use
+type cdkJSON struct {
+	App string `json:"app"`
+}
+
+// ParseCDKJSON parses a cdk.json file; the language is derived from the app command
+func (p *IaCProjectParser) ParseCDKJSON(content string) (*IaCProject, error) {
+	var raw cdkJSON
+	if err := json.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, err
+	}
+
+	project := &IaCProject{Tool: IaCToolCDK, Language: cdkAppLanguage(raw.App), CloudTargets: []string{"aws"}}
+	return project, nil
+}
+
+// cdkAppLanguage returns the language of a cdk.json app command ("npx ts-node bin/app.ts")
+func cdkAppLanguage(app string) string {
+	for _, field := range strings.Fields(app) {
+		if language, ok := cdkLanguages[field]; ok {
+			return language
+		}
+		switch {
+		case strings.HasSuffix(field, ".ts"):
+			return "typescript"
+		case strings.HasSuffix(field, ".js"):
+			return "javascript"
+		case strings.HasSuffix(field, ".py"):
+			return "python"
+		}
+	}
+	return ""
+}
+
+// ApplyDependencies adds the Pulumi providers or CDK construct libraries declared in the
+// project's package manifests and sets the core library version
+func (project *IaCProject) ApplyDependencies(deps []types.Dependency) {
+	for _, dep := range deps {
+		switch project.Tool {
+		case IaCToolPulumi:
+			name := PulumiProviderName(dep)
+			switch {
+			case name == "pulumi":
+				project.Version = dep.Version
+			case name != "" && !pulumiNonProviders[name]:
+				project.addPackage(IaCPackage{Name: name, Version: dep.Version})
+			}
+		case IaCToolCDK:
+			if !IsCDKConstructLibrary(dep) {
+				continue
+			}
+			project.addPackage(IaCPackage{Name: dep.Name, Version: dep.Version})
+			if cdkCoreLibraries[dep.Name] && project.Version == "" {
+				project.Version = dep.Version
+			}
+		}
+	}
+	project.normalize()
+}
+
+// PulumiProviderName returns the Pulumi package name of a dependency ("aws" for @pulumi/aws,
+// pulumi-aws, github.com/pulumi/pulumi-aws/sdk/v6, Pulumi.Aws or com.pulumi:aws), "pulumi" for
+// the core SDK, or "" for non-Pulumi dependencies
+func PulumiProviderName(dep types.Dependency) string {
+	name := dep.Name
+	switch dep.Type {
+	case DependencyTypeNpm:
+		if strings.HasPrefix(name, "@pulumi/") {
+			return strings.TrimPrefix(name, "@pulumi/")
+		}
+	case DependencyTypePython:
+		name = strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+		if name == "pulumi" {
+			return name
+		}
+		if strings.HasPrefix(name, "pulumi-") {
+			return strings.TrimPrefix(name, "pulumi-")
+		}
+	case DependencyTypeGolang:
+		if strings.HasPrefix(name, "github.com/pulumi/pulumi/") {
+			return "pulumi"
+		}
+		if rest, ok := strings.CutPrefix(name, "github.com/pulumi/pulumi-"); ok {
+			provider, _, _ := strings.Cut(rest, "/")
+			return provider
+		}
+	case DependencyTypeDotnet:
+		if name == "Pulumi" {
+			return "pulumi"
+		}
+		if rest, ok := strings.CutPrefix(name, "Pulumi."); ok {
+			return kebabCase(rest)
+		}
+	case DependencyTypeMaven, DependencyTypeGradle:
+		if rest, ok := strings.CutPrefix(name, "com.pulumi:"); ok {
+			return rest
+		}
+	}
+	return ""
+}
+
+// IsCDKConstructLibrary reports whether a dependency is an AWS CDK construct library
+// (aws-cdk-lib, v1 @aws-cdk/* modules, alpha modules, or constructs)
+func IsCDKConstructLibrary(dep types.Dependency) bool {
+	name := dep.Name
+	switch dep.Type {
+	case DependencyTypeNpm:
+		return name == "aws-cdk-lib" || name == "constructs" || strings.HasPrefix(name, "@aws-cdk/")
+	case DependencyTypePython:
+		name = strings.ToLower(strings.NewReplacer("_", "-", ".", "-").Replace(name))
+		return name == "constructs" || strings.HasPrefix(name, "aws-cdk-")
+	case DependencyTypeGolang:
+		return strings.HasPrefix(name, "github.com/aws/aws-cdk-go/") || strings.HasPrefix(name, "github.com/aws/constructs-go/")
+	case DependencyTypeDotnet:
+		return name == "Constructs" || strings.HasPrefix(name, "Amazon.CDK")
+	case DependencyTypeMaven, DependencyTypeGradle:
+		return strings.HasPrefix(name, "software.amazon.awscdk:") || name == "software.constructs:constructs"
+	}
+	return false
+}
+
+// kebabCase converts a .NET package suffix to the Pulumi package name (AzureNative -> azure-native)
+func kebabCase(name string) string {
+	var sb strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) && i > 0 {
+			sb.WriteByte('-')
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+	return sb.String()
+}
+
+func (project *IaCProject) addPackage(pkg IaCPackage) {
+	for i, existing := range project.Packages {
+		if existing.Name == pkg.Name {
+			if existing.Version == "" {
+				project.Packages[i].Version = pkg.Version
+			}
+			return
+		}
+	}
+	project.Packages = append(project.Packages, pkg)
+}
+
+// normalize derives Pulumi cloud targets and sorts all lists for deterministic output
+func (project *IaCProject) normalize() {
+	sort.Slice(project.Packages, func(i, j int) bool {
+		return project.Packages[i].Name < project.Packages[j].Name
+	})
+	if project.Tool != IaCToolPulumi {
+		return
+	}
+	var clouds []string
+	for _, pkg := range project.Packages {
+		clouds = append(clouds, pulumiProviderClouds[pkg.Name])
+	}
+	project.CloudTargets = sortedUnique(clouds)
+}
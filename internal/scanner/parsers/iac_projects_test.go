@@ -0,0 +1,142 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePulumiYAML(t *testing.T) {
+	project, err := NewIaCProjectParser().ParsePulumiYAML(`name: infra
+runtime:
+  name: python
+  options:
+    virtualenv: venv
+description: Shared infrastructure
+`)
+	require.NoError(t, err)
+	assert.Equal(t, IaCToolPulumi, project.Tool)
+	assert.Equal(t, "infra", project.Name)
+	assert.Equal(t, "python", project.Language)
+	assert.Empty(t, project.Packages)
+}
+
+func TestParsePulumiYAML_YAMLProgram(t *testing.T) {
+	project, err := NewIaCProjectParser().ParsePulumiYAML(`name: site
+runtime: yaml
+resources:
+  bucket:
+    type: aws:s3:BucketV2
+  record:
+    type: cloudflare:Record
+  provider:
+    type: pulumi:providers:aws
+`)
+	require.NoError(t, err)
+	assert.Equal(t, "yaml", project.Language)
+	assert.Equal(t, []IaCPackage{{Name: "aws"}, {Name: "cloudflare"}}, project.Packages)
+	assert.Equal(t, []string{"aws", "cloudflare"}, project.CloudTargets)
+}
+
+func TestParsePulumiYAML_Invalid(t *testing.T) {
+	_, err := NewIaCProjectParser().ParsePulumiYAML("name: [unclosed")
+	assert.Error(t, err)
+}
+
+func TestIaCProject_ApplyDependencies_Pulumi(t *testing.T) {
+	project := &IaCProject{Tool: IaCToolPulumi}
+	project.ApplyDependencies([]types.Dependency{
+		{Type: "npm", Name: "@pulumi/pulumi", Version: "^3.100.0"},
+		{Type: "npm", Name: "@pulumi/aws", Version: "^6.18.0"},
+		{Type: "npm", Name: "@pulumi/awsx", Version: "^2.4.0"},
+		{Type: "npm", Name: "@pulumi/policy", Version: "^1.9.0"},
+		{Type: "npm", Name: "@pulumi/random", Version: "^4.15.0"},
+		{Type: "npm", Name: "express", Version: "^4.18.0"},
+	})
+
+	assert.Equal(t, "^3.100.0", project.Version)
+	assert.Equal(t, []IaCPackage{
+		{Name: "aws", Version: "^6.18.0"},
+		{Name: "awsx", Version: "^2.4.0"},
+		{Name: "random", Version: "^4.15.0"},
+	}, project.Packages)
+	assert.Equal(t, []string{"aws"}, project.CloudTargets)
+}
+
+func TestPulumiProviderName(t *testing.T) {
+	tests := []struct {
+		dep      types.Dependency
+		expected string
+	}{
+		{types.Dependency{Type: "npm", Name: "@pulumi/gcp"}, "gcp"},
+		{types.Dependency{Type: "python", Name: "pulumi-azure-native"}, "azure-native"},
+		{types.Dependency{Type: "python", Name: "pulumi"}, "pulumi"},
+		{types.Dependency{Type: "golang", Name: "github.com/pulumi/pulumi-kubernetes/sdk/v4"}, "kubernetes"},
+		{types.Dependency{Type: "golang", Name: "github.com/pulumi/pulumi/sdk/v3"}, "pulumi"},
+		{types.Dependency{Type: "dotnet", Name: "Pulumi.AzureNative"}, "azure-native"},
+		{types.Dependency{Type: "dotnet", Name: "Pulumi"}, "pulumi"},
+		{types.Dependency{Type: "maven", Name: "com.pulumi:aws"}, "aws"},
+		{types.Dependency{Type: "npm", Name: "pulumi-like"}, ""},
+		{types.Dependency{Type: "golang", Name: "github.com/aws/aws-sdk-go-v2"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dep.Name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, PulumiProviderName(tt.dep))
+		})
+	}
+}
+
+func TestParseCDKJSON(t *testing.T) {
+	tests := []struct {
+		app      string
+		expected string
+	}{
+		{"npx ts-node --prefer-ts-exts bin/app.ts", "typescript"},
+		{"node bin/app.js", "javascript"},
+		{"python3 app.py", "python"},
+		{"mvn -e -q compile exec:java", "java"},
+		{"dotnet run -p src/Infra/Infra.csproj", "dotnet"},
+		{"go mod download && go run app.go", "go"},
+		{"./run.sh", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.app, func(t *testing.T) {
+			project, err := NewIaCProjectParser().ParseCDKJSON(`{"app": "` + tt.app + `", "context": {}}`)
+			require.NoError(t, err)
+			assert.Equal(t, IaCToolCDK, project.Tool)
+			assert.Equal(t, tt.expected, project.Language)
+			assert.Equal(t, []string{"aws"}, project.CloudTargets)
+		})
+	}
+}
+
+func TestIaCProject_ApplyDependencies_CDK(t *testing.T) {
+	project := &IaCProject{Tool: IaCToolCDK, CloudTargets: []string{"aws"}}
+	project.ApplyDependencies([]types.Dependency{
+		{Type: "python", Name: "aws-cdk-lib", Version: "2.120.0"},
+		{Type: "python", Name: "aws-cdk-aws-lambda-python-alpha", Version: "2.120.0a0"},
+		{Type: "python", Name: "constructs", Version: ">=10.0.0,<11.0.0"},
+		{Type: "python", Name: "boto3", Version: "1.34.0"},
+	})
+
+	assert.Equal(t, "2.120.0", project.Version)
+	assert.Equal(t, []IaCPackage{
+		{Name: "aws-cdk-aws-lambda-python-alpha", Version: "2.120.0a0"},
+		{Name: "aws-cdk-lib", Version: "2.120.0"},
+		{Name: "constructs", Version: ">=10.0.0,<11.0.0"},
+	}, project.Packages)
+	assert.Equal(t, []string{"aws"}, project.CloudTargets)
+}
+
+func TestIsCDKConstructLibrary(t *testing.T) {
+	assert.True(t, IsCDKConstructLibrary(types.Dependency{Type: "npm", Name: "@aws-cdk/aws-s3"}))
+	assert.True(t, IsCDKConstructLibrary(types.Dependency{Type: "golang", Name: "github.com/aws/constructs-go/constructs/v10"}))
+	assert.True(t, IsCDKConstructLibrary(types.Dependency{Type: "dotnet", Name: "Amazon.CDK.Lib"}))
+	assert.True(t, IsCDKConstructLibrary(types.Dependency{Type: "maven", Name: "software.amazon.awscdk:aws-cdk-lib"}))
+	assert.False(t, IsCDKConstructLibrary(types.Dependency{Type: "npm", Name: "aws-cdk"}), "the CLI is not a construct library")
+	assert.False(t, IsCDKConstructLibrary(types.Dependency{Type: "npm", Name: "aws-sdk"}))
+}
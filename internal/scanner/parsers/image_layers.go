@@ -0,0 +1,176 @@
+package parsers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// Whiteout markers of image layers: a file deleted by the layer, all files of a directory
+// replaced by the layer
+const (
+	whiteoutPrefix = ".wh."
+	whiteoutOpaque = ".wh..wh..opq"
+)
+
+// ImageLayer is a layer of a container image
+type ImageLayer struct {
+	Index     int    `json:"index"`                // Position in the image, 0 = base layer
+	Digest    string `json:"digest"`               // Layer file of the archive (blobs/sha256/<digest> or <id>/layer.tar)
+	CreatedBy string `json:"created_by,omitempty"` // Dockerfile instruction of the image history
+}
+
+// ImageLayerIndex maps the files of a container image to the layer that introduced them
+type ImageLayerIndex struct {
+	Layers []ImageLayer
+	files  map[string]int // Absolute path -> layer
+}
+
+// dockerArchiveManifest is an entry of manifest.json of a docker save archive
+type dockerArchiveManifest struct {
+	Config string   `json:"Config"`
+	Layers []string `json:"Layers"`
+}
+
+// imageConfig is the part of the image configuration holding the build history
+type imageConfig struct {
+	History []struct {
+		CreatedBy  string `json:"created_by"`
+		EmptyLayer bool   `json:"empty_layer"`
+	} `json:"history"`
+}
+
+// ParseImageLayers reads an extracted docker save (or podman save) archive: manifest.json, the
+// image configuration, and the layer tars (plain or gzip), read with readFile relative to the
+// archive root. Files deleted by a later layer (whiteouts) are not attributed. The instructions
+// come from the history of the configuration; history entries without layer (ENV, CMD) are
+// skipped. The first image of the archive is read.
+func ParseImageLayers(readFile func(name string) ([]byte, error)) (*ImageLayerIndex, error) {
+	content, err := readFile("manifest.json")
+	if err != nil {
+		return nil, fmt.Errorf("not an image archive: %w", err)
+	}
+	var manifests []dockerArchiveManifest
+	if err := json.Unmarshal(content, &manifests); err != nil {
+		return nil, fmt.Errorf("invalid manifest.json: %w", err)
+	}
+	if len(manifests) == 0 {
+		return nil, fmt.Errorf("manifest.json lists no image")
+	}
+	manifest := manifests[0]
+
+	var instructions []string
+	if content, err := readFile(manifest.Config); err == nil {
+		var config imageConfig
+		if json.Unmarshal(content, &config) == nil {
+			for _, entry := range config.History {
+				if !entry.EmptyLayer {
+					instructions = append(instructions, dockerInstruction(entry.CreatedBy))
+				}
+			}
+		}
+	}
+
+	index := &ImageLayerIndex{files: make(map[string]int)}
+	for i, layerFile := range manifest.Layers {
+		layer := ImageLayer{Index: i, Digest: layerDigest(layerFile)}
+		if len(instructions) == len(manifest.Layers) {
+			layer.CreatedBy = instructions[i]
+		}
+		index.Layers = append(index.Layers, layer)
+
+		content, err := readFile(layerFile)
+		if err != nil {
+			return nil, fmt.Errorf("layer %s: %w", layerFile, err)
+		}
+		if err := index.addLayer(i, content); err != nil {
+			return nil, fmt.Errorf("layer %s: %w", layerFile, err)
+		}
+	}
+	return index, nil
+}
+
+// Lookup returns the layer that introduced a file of the image root file system ("/app/app.jar"),
+// or nil if no layer holds it
+func (i *ImageLayerIndex) Lookup(file string) *ImageLayer {
+	layer, ok := i.files[path.Clean("/"+file)]
+	if !ok {
+		return nil
+	}
+	return &i.Layers[layer]
+}
+
+// addLayer records the files of a layer tar, replacing the files deleted by its whiteouts
+func (i *ImageLayerIndex) addLayer(layer int, content []byte) error {
+	var reader io.Reader = bytes.NewReader(content)
+	if len(content) > 2 && content[0] == 0x1f && content[1] == 0x8b {
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	tr := tar.NewReader(reader)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := path.Clean("/" + header.Name)
+		dir, base := path.Split(name)
+		switch {
+		case base == whiteoutOpaque:
+			i.remove(path.Clean(dir), false, layer)
+		case strings.HasPrefix(base, whiteoutPrefix):
+			i.remove(path.Join(dir, strings.TrimPrefix(base, whiteoutPrefix)), true, layer)
+		case header.Typeflag != tar.TypeDir:
+			i.files[name] = layer
+		}
+	}
+}
+
+// remove forgets the files of the lower layers below a directory (and the path itself if self
+// is set); whiteouts do not apply to the files of their own layer
+func (i *ImageLayerIndex) remove(name string, self bool, layer int) {
+	prefix := strings.TrimSuffix(name, "/") + "/"
+	for file, fileLayer := range i.files {
+		if fileLayer < layer && ((self && file == name) || strings.HasPrefix(file, prefix)) {
+			delete(i.files, file)
+		}
+	}
+}
+
+// layerDigest returns the digest of a layer file of the archive: the blob name of OCI blobs,
+// the layer directory of the legacy layout
+func layerDigest(layerFile string) string {
+	if dir, name := path.Split(layerFile); path.Base(path.Clean(dir)) == "sha256" {
+		return "sha256:" + name
+	} else if name == "layer.tar" {
+		return path.Base(path.Clean(dir))
+	}
+	return layerFile
+}
+
+// dockerInstruction returns the Dockerfile instruction of a history entry: "/bin/sh -c #(nop) COPY
+// file:... in /app" of the legacy builder becomes "COPY file:... in /app", and the " # buildkit"
+// note of BuildKit is removed
+func dockerInstruction(createdBy string) string {
+	instruction := strings.TrimSpace(strings.TrimSuffix(createdBy, " # buildkit"))
+	if rest, ok := strings.CutPrefix(instruction, "/bin/sh -c #(nop) "); ok {
+		return strings.TrimSpace(rest)
+	}
+	if rest, ok := strings.CutPrefix(instruction, "/bin/sh -c "); ok {
+		return "RUN " + rest
+	}
+	return instruction
+}
@@ -0,0 +1,102 @@
+package parsers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// layerTar returns a layer tar holding the named files (directories end with "/")
+func layerTar(t *testing.T, names ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, name := range names {
+		header := &tar.Header{Name: name, Mode: 0o644, Typeflag: tar.TypeReg}
+		if name[len(name)-1] == '/' {
+			header.Typeflag = tar.TypeDir
+			header.Mode = 0o755
+		}
+		require.NoError(t, tw.WriteHeader(header))
+	}
+	require.NoError(t, tw.Close())
+	return buf.Bytes()
+}
+
+func TestParseImageLayers(t *testing.T) {
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	_, err := zw.Write(layerTar(t, "app/", "app/app.jar", "app/lib/old.jar", "tmp/build.log"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	files := map[string][]byte{
+		"manifest.json": []byte(`[{"Config": "blobs/sha256/cfg", "RepoTags": ["app:1.0"], "Layers": ["blobs/sha256/aaa", "blobs/sha256/bbb", "blobs/sha256/ccc"]}]`),
+		"blobs/sha256/cfg": []byte(`{"history": [
+  {"created_by": "/bin/sh -c #(nop) ADD file:4b1 in / "},
+  {"created_by": "/bin/sh -c #(nop)  CMD [\"bash\"]", "empty_layer": true},
+  {"created_by": "COPY build/ /app/ # buildkit"},
+  {"created_by": "RUN /bin/sh -c rm -rf /tmp/* && apt-get install -y curl # buildkit"}
+]}`),
+		"blobs/sha256/aaa": layerTar(t, "usr/", "usr/lib/libssl.so.3", "etc/os-release"),
+		"blobs/sha256/bbb": gz.Bytes(),
+		"blobs/sha256/ccc": layerTar(t, "app/lib/.wh..wh..opq", "app/lib/new.jar", "tmp/.wh.build.log", "usr/bin/curl"),
+	}
+	index, err := ParseImageLayers(func(name string) ([]byte, error) {
+		if content, ok := files[name]; ok {
+			return content, nil
+		}
+		return nil, os.ErrNotExist
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []ImageLayer{
+		{Index: 0, Digest: "sha256:aaa", CreatedBy: "ADD file:4b1 in /"},
+		{Index: 1, Digest: "sha256:bbb", CreatedBy: "COPY build/ /app/"},
+		{Index: 2, Digest: "sha256:ccc", CreatedBy: "RUN /bin/sh -c rm -rf /tmp/* && apt-get install -y curl"},
+	}, index.Layers)
+
+	assert.Equal(t, 0, index.Lookup("/usr/lib/libssl.so.3").Index)
+	assert.Equal(t, 1, index.Lookup("/app/app.jar").Index)
+	assert.Equal(t, 1, index.Lookup("app/app.jar").Index, "relative path")
+	assert.Equal(t, 2, index.Lookup("/app/lib/new.jar").Index)
+	assert.Equal(t, 2, index.Lookup("/usr/bin/curl").Index)
+	assert.Nil(t, index.Lookup("/app/lib/old.jar"), "removed by opaque whiteout")
+	assert.Nil(t, index.Lookup("/tmp/build.log"), "removed by whiteout")
+	assert.Nil(t, index.Lookup("/app"), "directory")
+	assert.Nil(t, index.Lookup("/missing"))
+}
+
+func TestParseImageLayers_LegacyLayout(t *testing.T) {
+	files := map[string][]byte{
+		"manifest.json":   []byte(`[{"Config": "abc.json", "Layers": ["f00/layer.tar"]}]`),
+		"abc.json":        []byte(`{"history": [{"created_by": "/bin/sh -c apk add openssl"}, {"created_by": "/bin/sh -c #(nop) ENV A=1", "empty_layer": true}]}`),
+		"f00/layer.tar":   layerTar(t, "lib/libssl.so.3"),
+		"unreferenced.js": []byte("{}"),
+	}
+	read := func(name string) ([]byte, error) {
+		if content, ok := files[name]; ok {
+			return content, nil
+		}
+		return nil, os.ErrNotExist
+	}
+	index, err := ParseImageLayers(read)
+	require.NoError(t, err)
+	assert.Equal(t, []ImageLayer{{Index: 0, Digest: "f00", CreatedBy: "RUN apk add openssl"}}, index.Layers)
+	assert.Equal(t, &index.Layers[0], index.Lookup("/lib/libssl.so.3"))
+
+	// Missing manifest, layer, or invalid layer
+	_, err = ParseImageLayers(func(string) ([]byte, error) { return nil, os.ErrNotExist })
+	assert.Error(t, err)
+	files["f00/layer.tar"] = []byte("not a tar archive, but long enough to be read as a tar header block")
+	_, err = ParseImageLayers(read)
+	assert.Error(t, err)
+	delete(files, "f00/layer.tar")
+	_, err = ParseImageLayers(read)
+	assert.Error(t, err)
+}
@@ -0,0 +1,119 @@
+package parsers
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// IvyModule represents a parsed ivy.xml module descriptor
+type IvyModule struct {
+	XMLName      xml.Name        `xml:"ivy-module"`
+	Info         IvyInfo         `xml:"info"`
+	Dependencies []IvyDependency `xml:"dependencies>dependency"`
+}
+
+// IvyInfo holds the coordinates of the module
+type IvyInfo struct {
+	Organisation string `xml:"organisation,attr"`
+	Module       string `xml:"module,attr"`
+	Revision     string `xml:"revision,attr"`
+}
+
+// IvyDependency represents a single Ivy dependency declaration
+type IvyDependency struct {
+	Org        string `xml:"org,attr"`
+	Name       string `xml:"name,attr"`
+	Rev        string `xml:"rev,attr"`
+	Conf       string `xml:"conf,attr"`       // Configuration mapping (e.g., "compile->default", "test->*")
+	Transitive string `xml:"transitive,attr"` // "false" disables transitive resolution
+}
+
+// IvyParser handles Apache Ivy module descriptors (ivy.xml)
+type IvyParser struct{}
+
+// NewIvyParser creates a new Ivy parser
+func NewIvyParser() *IvyParser {
+	return &IvyParser{}
+}
+
+// ParseModule parses ivy.xml and returns the module descriptor (zero value if invalid)
+func (p *IvyParser) ParseModule(content string) IvyModule {
+	var module IvyModule
+	if err := xml.Unmarshal([]byte(content), &module); err != nil {
+		return IvyModule{}
+	}
+	return module
+}
+
+// ParseIvyXML extracts the dependencies of ivy.xml. Dependencies without org belong to the
+// organisation of the module; dynamic revisions (latest.integration, 1.+) are kept as declared.
+func (p *IvyParser) ParseIvyXML(content string) []types.Dependency {
+	module := p.ParseModule(content)
+
+	var dependencies []types.Dependency
+	for _, dep := range module.Dependencies {
+		org := strings.TrimSpace(dep.Org)
+		if org == "" {
+			org = strings.TrimSpace(module.Info.Organisation)
+		}
+		name := strings.TrimSpace(dep.Name)
+		if org == "" || name == "" {
+			continue
+		}
+		version := strings.TrimSpace(dep.Rev)
+		if version == "" {
+			version = "latest"
+		}
+
+		metadata := types.NewMetadata(MetadataSourceIvyXML)
+		if conf := strings.TrimSpace(dep.Conf); conf != "" {
+			metadata["conf"] = conf
+		}
+		if strings.EqualFold(strings.TrimSpace(dep.Transitive), "false") {
+			metadata["transitive"] = false
+		}
+
+		dependencies = append(dependencies, types.Dependency{
+			Type:     DependencyTypeIvy,
+			Name:     org + ":" + name,
+			Version:  version,
+			Scope:    ivyScope(dep.Conf),
+			Direct:   true,
+			Metadata: metadata,
+		})
+	}
+
+	return NormalizeDependencyVersions(dependencies)
+}
+
+// ivyScope maps the module configurations of a conf mapping to a scope: dev if all of them are
+// test configurations, build for build-only configurations, else prod
+func ivyScope(conf string) types.Scope {
+	var confs []string
+	for _, mapping := range strings.Split(conf, ";") {
+		master, _, _ := strings.Cut(mapping, "->")
+		for _, name := range strings.Split(master, ",") {
+			if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+				confs = append(confs, name)
+			}
+		}
+	}
+	if len(confs) == 0 {
+		return types.ScopeProd
+	}
+
+	test, build := true, true
+	for _, name := range confs {
+		test = test && strings.Contains(name, "test")
+		build = build && (name == "build" || name == "buildtime" || name == "tools")
+	}
+	switch {
+	case test:
+		return types.ScopeDev
+	case build:
+		return types.ScopeBuild
+	}
+	return types.ScopeProd
+}
@@ -0,0 +1,68 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIvyParser_ParseIvyXML(t *testing.T) {
+	parser := NewIvyParser()
+
+	content := `<?xml version="1.0"?>
+<ivy-module version="2.0">
+	<info organisation="com.example" module="billing" revision="2.1"/>
+	<configurations>
+		<conf name="compile"/>
+		<conf name="test" extends="compile"/>
+	</configurations>
+	<dependencies>
+		<dependency org="commons-lang" name="commons-lang" rev="2.6" conf="compile->default"/>
+		<dependency org="org.slf4j" name="slf4j-api" rev="1.7.+" transitive="false"/>
+		<dependency name="billing-common" rev="latest.integration"/>
+		<dependency org="junit" name="junit" rev="4.13.2" conf="test->default(*)"/>
+		<dependency org="org.projectlombok" name="lombok" conf="build->default"/>
+		<dependency org="broken"/>
+	</dependencies>
+</ivy-module>`
+
+	deps := parser.ParseIvyXML(content)
+	require.Len(t, deps, 5)
+
+	assert.Equal(t, types.Dependency{
+		Type: DependencyTypeIvy, Name: "commons-lang:commons-lang", Version: "2.6", Scope: types.ScopeProd, Direct: true,
+		Metadata: map[string]interface{}{"source": "ivy.xml", "conf": "compile->default"},
+	}, deps[0])
+	assert.Equal(t, "1.7.+", deps[1].Version, "Should keep dynamic revisions")
+	assert.Equal(t, false, deps[1].Metadata["transitive"])
+	assert.Equal(t, "com.example:billing-common", deps[2].Name, "Should default org to the module organisation")
+	assert.Equal(t, types.ScopeDev, deps[3].Scope)
+	assert.Equal(t, types.ScopeBuild, deps[4].Scope)
+	assert.Equal(t, "latest", deps[4].Version)
+
+	module := parser.ParseModule(content)
+	assert.Equal(t, IvyInfo{Organisation: "com.example", Module: "billing", Revision: "2.1"}, module.Info)
+
+	assert.Empty(t, parser.ParseIvyXML("<project/>"))
+	assert.Empty(t, parser.ParseIvyXML("not xml"))
+}
+
+func TestIvyScope(t *testing.T) {
+	tests := []struct {
+		conf     string
+		expected types.Scope
+	}{
+		{"", types.ScopeProd},
+		{"default", types.ScopeProd},
+		{"compile->default", types.ScopeProd},
+		{"test->default", types.ScopeDev},
+		{"test,integration-test->default", types.ScopeDev},
+		{"compile->default;test->default", types.ScopeProd},
+		{"build->*", types.ScopeBuild},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, ivyScope(tt.conf), tt.conf)
+	}
+}
@@ -0,0 +1,169 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Declaration location metadata keys of direct dependencies
+const (
+	MetadataFile = "file" // Manifest declaring the dependency, relative to the scanned directory ("/app/package.json")
+	MetadataLine = "line" // Line of the declaration in the manifest (1-based)
+)
+
+// packageJSONSections are the dependency sections of package.json
+var packageJSONSections = map[string]bool{
+	"dependencies": true, "devDependencies": true, "peerDependencies": true, "optionalDependencies": true,
+}
+
+// LocateDependencies records the manifest and the line declaring each direct dependency
+// found in lines (name -> line). The metadata of located dependencies is copied, since the
+// dependencies of a manifest may share a single metadata map.
+func LocateDependencies(dependencies []types.Dependency, file string, lines map[string]int) {
+	for i, dep := range dependencies {
+		line, ok := lines[dep.Name]
+		if !ok || !dep.Direct {
+			continue
+		}
+		metadata := make(map[string]interface{}, len(dep.Metadata)+2)
+		for key, value := range dep.Metadata {
+			metadata[key] = value
+		}
+		metadata[MetadataFile] = filepath.ToSlash(file)
+		metadata[MetadataLine] = line
+		dependencies[i].Metadata = metadata
+	}
+}
+
+// PackageJSONLines returns the line of the key of each dependency in the dependency sections
+// of package.json; the first declaration of a package wins
+func PackageJSONLines(content []byte) map[string]int {
+	lines := make(map[string]int)
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return lines
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return lines
+		}
+		var section json.RawMessage
+		if err := decoder.Decode(&section); err != nil {
+			return lines
+		}
+		if key, _ := token.(string); !packageJSONSections[key] {
+			continue
+		}
+
+		start := decoder.InputOffset() - int64(len(section))
+		sectionDecoder := json.NewDecoder(bytes.NewReader(section))
+		if token, err := sectionDecoder.Token(); err != nil || token != json.Delim('{') {
+			continue
+		}
+		for sectionDecoder.More() {
+			token, err := sectionDecoder.Token()
+			if err != nil {
+				break
+			}
+			name, _ := token.(string)
+			if _, exists := lines[name]; !exists && name != "" {
+				lines[name] = lineAt(content, start+sectionDecoder.InputOffset())
+			}
+			var value json.RawMessage
+			if err := sectionDecoder.Decode(&value); err != nil {
+				break
+			}
+		}
+	}
+	return lines
+}
+
+// MavenDeclarationLines returns the line of the <dependency> or <plugin> element declaring
+// each groupId:artifactId of a POM (dependencies, dependency management, plugins, and
+// profiles); the first declaration wins. Plugins default to the org.apache.maven.plugins group.
+func MavenDeclarationLines(content []byte) map[string]int {
+	lines := make(map[string]int)
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.Strict = false
+
+	var element string // dependency or plugin being read
+	var line int
+	var field, groupID, artifactID string
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return lines
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch {
+			case t.Name.Local == "dependency" || t.Name.Local == "plugin":
+				element, groupID, artifactID = t.Name.Local, "", ""
+				line, _ = decoder.InputPos()
+			case element != "" && (t.Name.Local == "groupId" || t.Name.Local == "artifactId"):
+				field = t.Name.Local
+			case element != "":
+				field = ""
+				if t.Name.Local == "exclusions" || t.Name.Local == "dependencies" || t.Name.Local == "configuration" || t.Name.Local == "executions" {
+					// Nested declarations (plugin dependencies, exclusions) are not the element's coordinates
+					if err := decoder.Skip(); err != nil {
+						return lines
+					}
+				}
+			}
+		case xml.CharData:
+			switch field {
+			case "groupId":
+				groupID += strings.TrimSpace(string(t))
+			case "artifactId":
+				artifactID += strings.TrimSpace(string(t))
+			}
+		case xml.EndElement:
+			field = ""
+			if t.Name.Local != element {
+				continue
+			}
+			if groupID == "" && element == "plugin" {
+				groupID = "org.apache.maven.plugins"
+			}
+			if name := groupID + ":" + artifactID; groupID != "" && artifactID != "" {
+				if _, exists := lines[name]; !exists {
+					lines[name] = line
+				}
+			}
+			element = ""
+		}
+	}
+}
+
+// GemfileLines returns the line of the gem declaration of each gem of a Gemfile; the first
+// declaration wins
+func GemfileLines(content string) map[string]int {
+	lines := make(map[string]int)
+	for i, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if match := rubyDepRegexNoVersion.FindStringSubmatch(trimmed); match != nil {
+			if _, exists := lines[match[1]]; !exists {
+				lines[match[1]] = i + 1
+			}
+		}
+	}
+	return lines
+}
+
+// lineAt returns the 1-based line of a byte offset of content
+func lineAt(content []byte, offset int64) int {
+	if offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+	return bytes.Count(content[:offset], []byte("\n")) + 1
+}
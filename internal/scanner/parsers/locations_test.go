@@ -0,0 +1,68 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackageJSONLines(t *testing.T) {
+	content := `{
+  "name": "app",
+  "scripts": {"react": "not a dependency"},
+  "dependencies": {
+    "react": "^18.2.0",
+    "@babel/core": "7.24.0"
+  },
+  "devDependencies": {"vitest": "^1.0.0", "react": "^18.2.0"}
+}`
+	assert.Equal(t, map[string]int{"react": 5, "@babel/core": 6, "vitest": 8}, PackageJSONLines([]byte(content)))
+	assert.Empty(t, PackageJSONLines([]byte(`not json`)))
+}
+
+func TestMavenDeclarationLines(t *testing.T) {
+	content := `<project>
+  <dependencies>
+    <dependency>
+      <groupId>junit</groupId>
+      <artifactId>junit</artifactId>
+      <exclusions>
+        <exclusion><groupId>org.hamcrest</groupId><artifactId>hamcrest-core</artifactId></exclusion>
+      </exclusions>
+    </dependency>
+  </dependencies>
+  <build>
+    <plugins>
+      <plugin>
+        <artifactId>maven-compiler-plugin</artifactId>
+      </plugin>
+    </plugins>
+  </build>
+</project>`
+	assert.Equal(t, map[string]int{
+		"junit:junit": 3,
+		"org.apache.maven.plugins:maven-compiler-plugin": 13,
+	}, MavenDeclarationLines([]byte(content)))
+}
+
+func TestGemfileLines(t *testing.T) {
+	content := "source 'https://rubygems.org'\n# gem 'commented'\ngem 'rails', '~> 7.1'\n\ngroup :test do\n  gem \"rspec\"\nend\n"
+	assert.Equal(t, map[string]int{"rails": 3, "rspec": 6}, GemfileLines(content))
+}
+
+func TestLocateDependencies(t *testing.T) {
+	shared := types.NewMetadata(MetadataSourcePackageJSON)
+	deps := []types.Dependency{
+		{Type: "npm", Name: "react", Direct: true, Metadata: shared},
+		{Type: "npm", Name: "scheduler", Direct: false, Metadata: shared},
+		{Type: "npm", Name: "unlisted", Direct: true, Metadata: shared},
+	}
+	LocateDependencies(deps, "/web/package.json", map[string]int{"react": 5, "scheduler": 9})
+
+	assert.Equal(t, "/web/package.json", deps[0].Metadata[MetadataFile])
+	assert.Equal(t, 5, deps[0].Metadata[MetadataLine])
+	assert.NotContains(t, deps[1].Metadata, MetadataLine, "transitive dependencies are not declared")
+	assert.NotContains(t, deps[2].Metadata, MetadataLine)
+	assert.NotContains(t, shared, MetadataLine, "shared metadata is not modified")
+}
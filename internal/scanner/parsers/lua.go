@@ -0,0 +1,481 @@
+package parsers
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// rockDependencyRegex splits a rockspec dependency ("lua-cjson >= 2.1.0", "penlight==1.13.1")
+// into rock name and version constraints
+var rockDependencyRegex = regexp.MustCompile(`^\s*([^\s<>=~!]+)\s*(.*?)\s*$`)
+
+// Rockspec represents a LuaRocks package specification (<package>-<version>.rockspec)
+type Rockspec struct {
+	Package           string
+	Version           string
+	License           string
+	Summary           string
+	SourceURL         string
+	BuildType         string
+	LuaVersion        string // Constraint of the "lua" dependency
+	Dependencies      []RockDependency
+	BuildDependencies []RockDependency
+	TestDependencies  []RockDependency
+}
+
+// RockDependency is a rock required by a rockspec, optionally restricted to a platform
+type RockDependency struct {
+	Name       string
+	Constraint string
+	Platform   string
+}
+
+// LuaParser handles LuaRocks rockspecs and lock files
+type LuaParser struct{}
+
+// NewLuaParser creates a new Lua parser
+func NewLuaParser() *LuaParser {
+	return &LuaParser{}
+}
+
+// ParseRockspec parses the package, version, description, source, build, and dependency tables
+// of a rockspec. Rockspecs are Lua scripts; only literal assignments are evaluated.
+func (p *LuaParser) ParseRockspec(content string) (Rockspec, error) {
+	globals, err := parseLuaAssignments(content)
+	if err != nil {
+		return Rockspec{}, err
+	}
+
+	spec := Rockspec{
+		Package:   luaString(globals["package"]),
+		Version:   luaString(globals["version"]),
+		License:   luaString(luaField(globals["description"], "license")),
+		Summary:   luaString(luaField(globals["description"], "summary")),
+		SourceURL: luaString(luaField(globals["source"], "url")),
+		BuildType: luaString(luaField(globals["build"], "type")),
+	}
+	for _, dep := range rockDependencies(globals["dependencies"]) {
+		if dep.Name == "lua" {
+			if dep.Platform == "" {
+				spec.LuaVersion = dep.Constraint
+			}
+			continue
+		}
+		spec.Dependencies = append(spec.Dependencies, dep)
+	}
+	spec.BuildDependencies = rockDependencies(globals["build_dependencies"])
+	spec.TestDependencies = rockDependencies(globals["test_dependencies"])
+	return spec, nil
+}
+
+// RockspecDependencies converts the dependency tables of a rockspec to dependencies:
+// dependencies are prod (platform specific ones with the "platform" metadata),
+// build_dependencies build, and test_dependencies test dependencies.
+func (p *LuaParser) RockspecDependencies(spec Rockspec) []types.Dependency {
+	var dependencies []types.Dependency
+	for _, group := range []struct {
+		deps  []RockDependency
+		scope types.Scope
+	}{
+		{spec.Dependencies, types.ScopeProd},
+		{spec.BuildDependencies, types.ScopeBuild},
+		{spec.TestDependencies, types.ScopeTest},
+	} {
+		for _, dep := range group.deps {
+			metadata := types.NewMetadata(MetadataSourceRockspec)
+			if dep.Platform != "" {
+				metadata["platform"] = dep.Platform
+			}
+			version := dep.Constraint
+			if version == "" {
+				version = "latest"
+			}
+			dependencies = append(dependencies, types.Dependency{
+				Type:     DependencyTypeLuarocks,
+				Name:     dep.Name,
+				Version:  version,
+				Scope:    group.scope,
+				Direct:   true,
+				Metadata: metadata,
+			})
+		}
+	}
+	return dependencies
+}
+
+// ParseLuarocksLock parses luarocks.lock (written by "luarocks build --pin"), which holds the
+// exact version of every installed rock. Rocks declared by the rockspec are direct
+// dependencies, the others transitive ones; build_dependencies have the build scope.
+func (p *LuaParser) ParseLuarocksLock(content string, spec Rockspec) []types.Dependency {
+	globals, err := parseLuaAssignments(content)
+	if err != nil {
+		return nil
+	}
+	lock, ok := globals["return"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	direct := make(map[string]bool)
+	for _, deps := range [][]RockDependency{spec.Dependencies, spec.BuildDependencies, spec.TestDependencies} {
+		for _, dep := range deps {
+			direct[dep.Name] = true
+		}
+	}
+	testDeps := make(map[string]bool)
+	for _, dep := range spec.TestDependencies {
+		testDeps[dep.Name] = true
+	}
+
+	var dependencies []types.Dependency
+	for _, section := range []struct {
+		key   string
+		scope types.Scope
+	}{
+		{"dependencies", types.ScopeProd},
+		{"build_dependencies", types.ScopeBuild},
+	} {
+		rocks, _ := lock[section.key].(map[string]interface{})
+		names := make([]string, 0, len(rocks))
+		for name := range rocks {
+			if name != "lua" {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			scope := section.scope
+			if testDeps[name] {
+				scope = types.ScopeTest
+			}
+			dependencies = append(dependencies, types.Dependency{
+				Type:     DependencyTypeLuarocks,
+				Name:     name,
+				Version:  luaString(rocks[name]),
+				Scope:    scope,
+				Direct:   direct[name],
+				Metadata: types.NewMetadata(MetadataSourceLuarocksLock),
+			})
+		}
+	}
+	return dependencies
+}
+
+// rockDependencies converts a dependency table: a list of dependency strings, optionally with
+// a platforms table of per platform lists
+func rockDependencies(value interface{}) []RockDependency {
+	var deps []RockDependency
+	for _, item := range luaList(value) {
+		if dep, ok := parseRockDependency(luaString(item)); ok {
+			deps = append(deps, dep)
+		}
+	}
+	platforms, _ := luaField(value, "platforms").(map[string]interface{})
+	names := make([]string, 0, len(platforms))
+	for name := range platforms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, platform := range names {
+		for _, item := range luaList(platforms[platform]) {
+			if dep, ok := parseRockDependency(luaString(item)); ok {
+				dep.Platform = platform
+				deps = append(deps, dep)
+			}
+		}
+	}
+	return deps
+}
+
+// parseRockDependency parses a dependency string into rock name and constraints
+func parseRockDependency(spec string) (RockDependency, bool) {
+	match := rockDependencyRegex.FindStringSubmatch(spec)
+	if match == nil {
+		return RockDependency{}, false
+	}
+	return RockDependency{Name: strings.ToLower(match[1]), Constraint: match[2]}, true
+}
+
+// --- Lua literals ---
+
+// luaString returns the value of a string (or number) literal
+func luaString(value interface{}) string {
+	s, _ := value.(string)
+	return s
+}
+
+// luaField returns a named field of a table
+func luaField(value interface{}, key string) interface{} {
+	if table, ok := value.(map[string]interface{}); ok {
+		return table[key]
+	}
+	return nil
+}
+
+// luaList returns the positional items of a table
+func luaList(value interface{}) []interface{} {
+	items, _ := luaField(value, luaListKey).([]interface{})
+	return items
+}
+
+// luaListKey is the map key holding the positional items of a table
+const luaListKey = "\x00list"
+
+// parseLuaAssignments evaluates the top-level assignments (name = literal) of a Lua script and a
+// trailing "return literal" statement (stored under "return"). Statements that are not literal
+// assignments are skipped.
+func parseLuaAssignments(content string) (map[string]interface{}, error) {
+	parser := &luaParser{input: content}
+	globals := make(map[string]interface{})
+	for {
+		parser.skipSpace()
+		if parser.pos >= len(parser.input) {
+			return globals, nil
+		}
+		name := parser.parseName()
+		if name == "" {
+			parser.skipStatement()
+			continue
+		}
+		parser.skipSpace()
+		if name == "return" {
+			value, err := parser.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			globals[name] = value
+			continue
+		}
+		if parser.pos >= len(parser.input) || parser.input[parser.pos] != '=' || strings.HasPrefix(parser.input[parser.pos:], "==") {
+			parser.skipStatement()
+			continue
+		}
+		parser.pos++
+		value, err := parser.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		globals[name] = value
+	}
+}
+
+// luaParser parses Lua literals: strings, numbers, booleans, and table constructors. Tables
+// become maps of their named fields, with the positional items under luaListKey.
+type luaParser struct {
+	input string
+	pos   int
+}
+
+// parseValue parses a literal; other expressions (function calls, variables) yield nil
+func (l *luaParser) parseValue() (interface{}, error) {
+	l.skipSpace()
+	if l.pos >= len(l.input) {
+		return nil, fmt.Errorf("lua: unexpected end of input")
+	}
+	switch c := l.input[l.pos]; {
+	case c == '{':
+		l.pos++
+		return l.parseTable()
+	case c == '"' || c == '\'':
+		return l.parseString(c)
+	case strings.HasPrefix(l.input[l.pos:], "[[") || strings.HasPrefix(l.input[l.pos:], "[="):
+		return l.parseLongString(), nil
+	default:
+		start := l.pos
+		for l.pos < len(l.input) && !strings.ContainsRune(" \t\r\n,;}", rune(l.input[l.pos])) {
+			if l.input[l.pos] == '(' || l.input[l.pos] == '{' {
+				l.skipBalanced()
+				continue
+			}
+			l.pos++
+		}
+		if l.pos == start {
+			l.pos++ // Operator or closing bracket of an expression
+			return nil, nil
+		}
+		switch token := l.input[start:l.pos]; token {
+		case "true", "false":
+			return token == "true", nil
+		case "nil":
+			return nil, nil
+		default:
+			if (token[0] >= '0' && token[0] <= '9') || token[0] == '-' {
+				return token, nil
+			}
+			return nil, nil
+		}
+	}
+}
+
+// parseTable parses the fields of a table constructor after the opening brace
+func (l *luaParser) parseTable() (interface{}, error) {
+	fields := make(map[string]interface{})
+	var items []interface{}
+	for {
+		l.skipSpace()
+		if l.pos >= len(l.input) {
+			return nil, fmt.Errorf("lua: unterminated table")
+		}
+		if l.input[l.pos] == '}' {
+			l.pos++
+			break
+		}
+		key, err := l.parseFieldKey()
+		if err != nil {
+			return nil, err
+		}
+		value, err := l.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if key != "" {
+			fields[key] = value
+		} else {
+			items = append(items, value)
+		}
+		l.skipSpace()
+		if l.pos < len(l.input) && (l.input[l.pos] == ',' || l.input[l.pos] == ';') {
+			l.pos++
+		}
+	}
+	if len(items) > 0 {
+		fields[luaListKey] = items
+	}
+	return fields, nil
+}
+
+// parseFieldKey consumes "name =" or "[expr] =" and returns the key, or "" for positional items
+func (l *luaParser) parseFieldKey() (string, error) {
+	start := l.pos
+	var key string
+	if l.input[l.pos] == '[' && !strings.HasPrefix(l.input[l.pos:], "[[") && !strings.HasPrefix(l.input[l.pos:], "[=") {
+		l.pos++
+		value, err := l.parseValue()
+		if err != nil {
+			return "", err
+		}
+		l.skipSpace()
+		if l.pos >= len(l.input) || l.input[l.pos] != ']' {
+			return "", fmt.Errorf("lua: expected ] at offset %d", l.pos)
+		}
+		l.pos++
+		key = luaString(value)
+	} else {
+		key = l.parseName()
+	}
+	l.skipSpace()
+	if key == "" || l.pos >= len(l.input) || l.input[l.pos] != '=' || strings.HasPrefix(l.input[l.pos:], "==") {
+		l.pos = start
+		return "", nil
+	}
+	l.pos++
+	return key, nil
+}
+
+// parseName parses an identifier
+func (l *luaParser) parseName() string {
+	start := l.pos
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		if c != '_' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (l.pos == start || c < '0' || c > '9') {
+			break
+		}
+		l.pos++
+	}
+	return l.input[start:l.pos]
+}
+
+// parseString parses a quoted string with escape sequences
+func (l *luaParser) parseString(quote byte) (interface{}, error) {
+	l.pos++
+	var b strings.Builder
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		switch {
+		case c == quote:
+			l.pos++
+			return b.String(), nil
+		case c == '\\' && l.pos+1 < len(l.input):
+			l.pos++
+			switch e := l.input[l.pos]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(e)
+			}
+		default:
+			b.WriteByte(c)
+		}
+		l.pos++
+	}
+	return nil, fmt.Errorf("lua: unterminated string")
+}
+
+// parseLongString parses a long bracket string ([[...]], [==[...]==])
+func (l *luaParser) parseLongString() string {
+	level := 0
+	for l.pos+1+level < len(l.input) && l.input[l.pos+1+level] == '=' {
+		level++
+	}
+	closing := "]" + strings.Repeat("=", level) + "]"
+	start := l.pos + 2 + level
+	end := strings.Index(l.input[start:], closing)
+	if end < 0 {
+		l.pos = len(l.input)
+		return l.input[start:]
+	}
+	l.pos = start + end + len(closing)
+	return strings.TrimPrefix(l.input[start:start+end], "\n")
+}
+
+// skipBalanced skips a parenthesized or braced expression
+func (l *luaParser) skipBalanced() {
+	depth := 0
+	for l.pos < len(l.input) {
+		switch c := l.input[l.pos]; c {
+		case '(', '{':
+			depth++
+		case ')', '}':
+			depth--
+		case '"', '\'':
+			_, _ = l.parseString(c)
+			continue
+		}
+		l.pos++
+		if depth == 0 {
+			return
+		}
+	}
+}
+
+// skipStatement skips the rest of the current line
+func (l *luaParser) skipStatement() {
+	if end := strings.IndexByte(l.input[l.pos:], '\n'); end >= 0 {
+		l.pos += end + 1
+	} else {
+		l.pos = len(l.input)
+	}
+}
+
+// skipSpace skips whitespace and comments (-- line and --[[ block ]])
+func (l *luaParser) skipSpace() {
+	for l.pos < len(l.input) {
+		switch c := l.input[l.pos]; {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == ';':
+			l.pos++
+		case strings.HasPrefix(l.input[l.pos:], "--[[") || strings.HasPrefix(l.input[l.pos:], "--[="):
+			l.pos += 2
+			l.parseLongString()
+		case strings.HasPrefix(l.input[l.pos:], "--"):
+			l.skipStatement()
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRockspec = `-- Kong plugin rockspec
+local plugin_name = "rate-guard"
+package = "kong-plugin-" .. plugin_name
+package = "kong-plugin-rate-guard"
+version = "1.2.0-1"
+rockspec_format = "3.0"
+
+source = {
+   url = "git+https://github.com/acme/kong-plugin-rate-guard.git",
+   tag = "v1.2.0",
+}
+
+description = {
+   summary = "Rate limiting with guard rails",
+   detailed = [[
+      Longer description spanning
+      multiple lines.
+   ]],
+   license = "Apache 2.0",
+}
+
+dependencies = {
+   "lua >= 5.1, < 5.5",
+   "lua-resty-http ~> 0.17",
+   "Penlight == 1.13.1",
+   "lua-cjson",
+   platforms = {
+      windows = { "luawinmulticast" },
+   },
+}
+
+build_dependencies = { "luarocks-build-rust-mlua" }
+
+test_dependencies = {
+   "busted >= 2.0", -- test runner
+}
+
+build = {
+   type = "builtin",
+   modules = {
+      ["kong.plugins.rate-guard.handler"] = "kong/plugins/rate-guard/handler.lua",
+      ["kong.plugins.rate-guard.schema"] = "kong/plugins/rate-guard/schema.lua",
+   },
+}
+`
+
+func TestLuaParser_ParseRockspec(t *testing.T) {
+	spec, err := NewLuaParser().ParseRockspec(testRockspec)
+	require.NoError(t, err)
+
+	assert.Equal(t, "kong-plugin-rate-guard", spec.Package)
+	assert.Equal(t, "1.2.0-1", spec.Version)
+	assert.Equal(t, "Apache 2.0", spec.License)
+	assert.Equal(t, "Rate limiting with guard rails", spec.Summary)
+	assert.Equal(t, "git+https://github.com/acme/kong-plugin-rate-guard.git", spec.SourceURL)
+	assert.Equal(t, "builtin", spec.BuildType)
+	assert.Equal(t, ">= 5.1, < 5.5", spec.LuaVersion)
+
+	assert.Equal(t, []RockDependency{
+		{Name: "lua-resty-http", Constraint: "~> 0.17"},
+		{Name: "penlight", Constraint: "== 1.13.1"},
+		{Name: "lua-cjson"},
+		{Name: "luawinmulticast", Platform: "windows"},
+	}, spec.Dependencies)
+	assert.Equal(t, []RockDependency{{Name: "luarocks-build-rust-mlua"}}, spec.BuildDependencies)
+	assert.Equal(t, []RockDependency{{Name: "busted", Constraint: ">= 2.0"}}, spec.TestDependencies)
+}
+
+func TestLuaParser_ParseRockspecInvalid(t *testing.T) {
+	_, err := NewLuaParser().ParseRockspec(`dependencies = { "lua >= 5.1"`)
+	assert.Error(t, err)
+}
+
+func TestLuaParser_RockspecDependencies(t *testing.T) {
+	parser := NewLuaParser()
+	spec, err := parser.ParseRockspec(testRockspec)
+	require.NoError(t, err)
+
+	deps := parser.RockspecDependencies(spec)
+	require.Len(t, deps, 6)
+	for _, dep := range deps {
+		assert.Equal(t, DependencyTypeLuarocks, dep.Type)
+		assert.True(t, dep.Direct)
+		assert.Equal(t, MetadataSourceRockspec, dep.Metadata["source"])
+	}
+
+	assert.Equal(t, "lua-resty-http", deps[0].Name)
+	assert.Equal(t, "~> 0.17", deps[0].Version)
+	assert.Equal(t, types.ScopeProd, deps[0].Scope)
+	assert.Equal(t, "latest", deps[2].Version)
+	assert.Equal(t, "windows", deps[3].Metadata["platform"])
+	assert.Equal(t, types.ScopeBuild, deps[4].Scope)
+	assert.Equal(t, "busted", deps[5].Name)
+	assert.Equal(t, types.ScopeTest, deps[5].Scope)
+}
+
+func TestLuaParser_ParseLuarocksLock(t *testing.T) {
+	parser := NewLuaParser()
+	spec, err := parser.ParseRockspec(testRockspec)
+	require.NoError(t, err)
+
+	lock := `return {
+   dependencies = {
+      busted = "2.2.0-1",
+      ["lua-cjson"] = "2.1.0.10-1",
+      ["lua-resty-http"] = "0.17.1-0",
+      lua = "5.1-1",
+      luassert = "1.9.0-1",
+      penlight = "1.13.1-1",
+   },
+   build_dependencies = {
+      ["luarocks-build-rust-mlua"] = "0.2.0-1",
+   },
+}
+`
+	deps := parser.ParseLuarocksLock(lock, spec)
+	require.Len(t, deps, 6)
+
+	assert.Equal(t, "busted", deps[0].Name)
+	assert.Equal(t, types.ScopeTest, deps[0].Scope)
+	assert.True(t, deps[0].Direct)
+
+	assert.Equal(t, "lua-cjson", deps[1].Name)
+	assert.Equal(t, "2.1.0.10-1", deps[1].Version)
+	assert.Equal(t, MetadataSourceLuarocksLock, deps[1].Metadata["source"])
+
+	assert.Equal(t, "luassert", deps[3].Name)
+	assert.False(t, deps[3].Direct)
+	assert.Equal(t, types.ScopeProd, deps[3].Scope)
+
+	assert.Equal(t, "luarocks-build-rust-mlua", deps[5].Name)
+	assert.Equal(t, types.ScopeBuild, deps[5].Scope)
+	assert.True(t, deps[5].Direct)
+}
+
+func TestLuaParser_ParseLuarocksLockWithoutReturn(t *testing.T) {
+	assert.Empty(t, NewLuaParser().ParseLuarocksLock(`dependencies = {}`, Rockspec{}))
+}
@@ -84,9 +84,13 @@ type MavenExclusion struct {
 
 // MavenBuild represents the build section
 type MavenBuild struct {
-	Plugins []MavenPlugin `xml:"plugins>plugin"`
+	Plugins          []MavenPlugin `xml:"plugins>plugin"`
+	PluginManagement []MavenPlugin `xml:"pluginManagement>plugins>plugin"`
 }
 
+// DefaultMavenPluginGroupID is the groupId Maven assumes for plugins declared without one
+const DefaultMavenPluginGroupID = "org.apache.maven.plugins"
+
 // MavenPlugin represents a Maven plugin
 type MavenPlugin struct {
 	GroupId      string            `xml:"groupId"`
@@ -109,6 +113,9 @@ type MavenProfile struct {
 	Activation           MavenActivation           `xml:"activation"`
 	Dependencies         MavenDependencies         `xml:"dependencies"`
 	DependencyManagement MavenDependencyManagement `xml:"dependencyManagement"`
+	Build                MavenBuild                `xml:"build"`
+
+	activeWithoutSelection bool // Active without selected profiles (set by getActiveProfiles)
 }
 
 // MavenActivation represents profile activation conditions
@@ -140,14 +147,27 @@ type MavenActivationFile struct {
 	Missing string `xml:"missing"`
 }
 
+// AllMavenProfiles is the profile selection of every profile of a POM
+const AllMavenProfiles = "*"
+
 // MavenParser handles Maven-specific file parsing (pom.xml)
-type MavenParser struct{}
+type MavenParser struct {
+	profiles []string // Selected profile IDs ("!id" deselects, "*" selects all)
+}
 
 // NewMavenParser creates a new Maven parser
 func NewMavenParser() *MavenParser {
 	return &MavenParser{}
 }
 
+// NewMavenParserWithProfiles creates a Maven parser that considers the given profiles like
+// "mvn -P": listed profile IDs are active in addition to the profiles activated by JDK or OS,
+// "!id" deactivates a profile, and "*" selects all profiles. Without a selection, only profiles
+// active by their activation conditions (or by default) are considered.
+func NewMavenParserWithProfiles(profiles []string) *MavenParser {
+	return &MavenParser{profiles: profiles}
+}
+
 // ExtractProjectInfo extracts groupId and artifactId from pom.xml
 func (p *MavenParser) ExtractProjectInfo(content string) MavenProject {
 	var project MavenProject
@@ -171,29 +191,16 @@ func (p *MavenParser) ParsePomXMLWithProvider(content string, pomDir string, pro
 	// Parse the POM structure
 	var project MavenProject
 	if err := xml.Unmarshal([]byte(content), &project); err != nil {
-		return dependencies
+		return NormalizeDependencyVersions(dependencies)
 	}
 
 	// Build properties map: parent properties -> local properties -> project coordinates
-	properties := make(map[string]string)
-
-	// 1. Resolve parent properties (if provider available)
-	if provider != nil && pomDir != "" {
-		parentProps := p.resolveParentProperties(content, pomDir, provider, 0)
-		mergeProperties(properties, parentProps)
-	}
-
-	// 2. Extract local properties (override parent)
-	localProps := p.extractProperties(content)
-	mergeProperties(properties, localProps)
-
-	// 3. Add project coordinates (override all)
-	p.addProjectCoordinates(properties, project.GroupId, project.ArtifactId, project.Version)
+	properties := p.buildProperties(content, project, pomDir, provider)
 
 	// 4. Process profiles and merge active profiles (following deps.dev pattern)
 	activeProfiles := p.getActiveProfiles(project.Profiles)
 	for _, profile := range activeProfiles {
-		// Merge profile dependencies
+		// Merge profile dependencies, tagged with the profile
 		for _, dep := range profile.Dependencies.Dependencies {
 			if dep.GroupId != "" && dep.ArtifactId != "" {
 				dependencies = append(dependencies, types.Dependency{
@@ -202,7 +209,7 @@ func (p *MavenParser) ParsePomXMLWithProvider(content string, pomDir string, pro
 					Version:  p.resolveVersion(dep.Version, properties),
 					Scope:    mapMavenScope(dep.Scope),
 					Direct:   true,
-					Metadata: p.buildMavenMetadata(dep),
+					Metadata: withProfile(p.buildMavenMetadata(dep), profile),
 				})
 			}
 		}
@@ -229,6 +236,9 @@ func (p *MavenParser) ParsePomXMLWithProvider(content string, pomDir string, pro
 	// Process profile dependency management
 	for _, profile := range activeProfiles {
 		profileDepMgmt := p.parseDependencyManagement(profile.DependencyManagement.Dependencies, properties)
+		for i := range profileDepMgmt {
+			profileDepMgmt[i].Metadata = withProfile(profileDepMgmt[i].Metadata, profile)
+		}
 		dependencies = append(dependencies, profileDepMgmt...)
 	}
 
@@ -236,7 +246,97 @@ func (p *MavenParser) ParsePomXMLWithProvider(content string, pomDir string, pro
 	pluginDeps := p.parsePluginDependencies(project.Build.Plugins, properties)
 	dependencies = append(dependencies, pluginDeps...)
 
-	return dependencies
+	for _, profile := range activeProfiles {
+		profilePluginDeps := p.parsePluginDependencies(profile.Build.Plugins, properties)
+		for i := range profilePluginDeps {
+			profilePluginDeps[i].Metadata = withProfile(profilePluginDeps[i].Metadata, profile)
+		}
+		dependencies = append(dependencies, profilePluginDeps...)
+	}
+
+	return NormalizeDependencyVersions(dependencies)
+}
+
+// ParsePlugins extracts the build plugins declared in <build><plugins> as build-scope dependencies,
+// since plugin versions are part of the build supply chain. Plugins without a version take it from
+// <pluginManagement> of the same POM, else "latest". Plugins of active profiles are tagged with the
+// profile. Properties are resolved like in ParsePomXMLWithProvider.
+func (p *MavenParser) ParsePlugins(content string, pomDir string, provider types.Provider) []types.Dependency {
+	var project MavenProject
+	if err := xml.Unmarshal([]byte(content), &project); err != nil {
+		return nil
+	}
+	properties := p.buildProperties(content, project, pomDir, provider)
+
+	managed := make(map[string]string)
+	addManagedPlugins(managed, project.Build.PluginManagement)
+	activeProfiles := p.getActiveProfiles(project.Profiles)
+	for _, profile := range activeProfiles {
+		addManagedPlugins(managed, profile.Build.PluginManagement)
+	}
+
+	plugins := p.buildPlugins(project.Build.Plugins, managed, properties)
+	for _, profile := range activeProfiles {
+		for _, plugin := range p.buildPlugins(profile.Build.Plugins, managed, properties) {
+			plugin.Metadata = withProfile(plugin.Metadata, profile)
+			plugins = append(plugins, plugin)
+		}
+	}
+	return NormalizeDependencyVersions(plugins)
+}
+
+// buildPlugins converts plugins to build-scope dependencies, with versions from pluginManagement
+func (p *MavenParser) buildPlugins(declared []MavenPlugin, managed, properties map[string]string) []types.Dependency {
+	var plugins []types.Dependency
+	for _, plugin := range declared {
+		if plugin.ArtifactId == "" {
+			continue
+		}
+		name := mavenPluginName(plugin)
+		version := plugin.Version
+		if version == "" {
+			version = managed[name]
+		}
+		plugins = append(plugins, types.Dependency{
+			Type:     DependencyTypeMaven,
+			Name:     name,
+			Version:  p.resolveVersion(version, properties),
+			Scope:    types.ScopeBuild,
+			Direct:   true,
+			Metadata: map[string]interface{}{MetadataPlugin: true},
+		})
+	}
+	return plugins
+}
+
+// addManagedPlugins records the versions of pluginManagement entries by plugin name
+func addManagedPlugins(managed map[string]string, plugins []MavenPlugin) {
+	for _, plugin := range plugins {
+		if plugin.ArtifactId != "" && plugin.Version != "" {
+			managed[mavenPluginName(plugin)] = plugin.Version
+		}
+	}
+}
+
+// mavenPluginName returns groupId:artifactId of a plugin, with Maven's default plugin groupId
+func mavenPluginName(plugin MavenPlugin) string {
+	groupID := plugin.GroupId
+	if groupID == "" {
+		groupID = DefaultMavenPluginGroupID
+	}
+	return groupID + ":" + plugin.ArtifactId
+}
+
+// buildProperties collects the properties for version resolution: parent properties (if a provider
+// is available), overridden by local properties, overridden by the project coordinates
+func (p *MavenParser) buildProperties(content string, project MavenProject, pomDir string, provider types.Provider) map[string]string {
+	properties := make(map[string]string)
+	if provider != nil && pomDir != "" {
+		mergeProperties(properties, p.resolveParentProperties(content, pomDir, provider, 0))
+	}
+	mergeProperties(properties, p.extractProperties(content))
+	p.addProjectCoordinates(properties, project.GroupId, project.ArtifactId, project.Version)
+	return properties
 }
 
 // parseDependencyManagement processes dependency management section
@@ -250,7 +350,7 @@ func (p *MavenParser) parseDependencyManagement(deps []MavenDependency, properti
 			// Only include BOM imports (scope=import and type=pom)
 			// Per Maven spec, BOM imports require both scope=import AND type=pom
 			// If type is not specified, it defaults to "jar", not "pom"
-			if dep.Scope == types.ScopeImport && dep.Type == "pom" {
+			if mapMavenScope(dep.Scope) == types.ScopeImport && dep.Type == "pom" {
 				dependencies = append(dependencies, types.Dependency{
 					Type:    DependencyTypeMaven,
 					Name:    dep.GroupId + ":" + dep.ArtifactId,
@@ -329,7 +429,7 @@ func (p *MavenParser) parsePluginDependencies(plugins []MavenPlugin, properties
 }
 
 // mapMavenScope maps Maven scope to our scope constants
-func mapMavenScope(mavenScope string) string {
+func mapMavenScope(mavenScope string) types.Scope {
 	switch mavenScope {
 	case "test":
 		return types.ScopeDev
@@ -492,20 +592,46 @@ func (p *MavenParser) resolveParentPath(pomDir, relativePath string) string {
 }
 
 // getActiveProfiles returns profiles that should be activated
-// Following deps.dev pattern: merge default profiles if no other profile is active
+// Following deps.dev pattern: merge default profiles if no other profile is active.
+// Selected profiles count as active like with "mvn -P", deselected ones are skipped. Profiles
+// that are active without selection are marked, for the activation metadata.
 func (p *MavenParser) getActiveProfiles(profiles []MavenProfile) []MavenProfile {
+	active := p.selectProfiles(profiles)
+	unselected := active
+	if len(p.profiles) > 0 {
+		unselected = (&MavenParser{}).selectProfiles(profiles)
+	}
+	activeWithoutSelection := make(map[string]bool)
+	for _, profile := range unselected {
+		activeWithoutSelection[strings.TrimSpace(profile.ID)] = true
+	}
+	for i := range active {
+		active[i].activeWithoutSelection = activeWithoutSelection[strings.TrimSpace(active[i].ID)]
+	}
+	return active
+}
+
+// selectProfiles returns the active profiles for the selected profiles of the parser
+func (p *MavenParser) selectProfiles(profiles []MavenProfile) []MavenProfile {
 	var activeProfiles []MavenProfile
 	var defaultProfiles []MavenProfile
 
+	selected, deselected, all := p.profileSelection()
 	for _, profile := range profiles {
-		// Check if profile is active by default
-		if strings.ToLower(strings.TrimSpace(profile.Activation.ActiveByDefault)) == "true" {
-			defaultProfiles = append(defaultProfiles, profile)
+		id := strings.TrimSpace(profile.ID)
+		if deselected[id] {
+			continue
 		}
 
-		// Check other activation conditions
-		if p.isProfileActive(profile.Activation) {
+		// Check selection and activation conditions
+		if all || selected[id] || p.isProfileActive(profile.Activation) {
 			activeProfiles = append(activeProfiles, profile)
+			continue
+		}
+
+		// Check if profile is active by default
+		if strings.ToLower(strings.TrimSpace(profile.Activation.ActiveByDefault)) == "true" {
+			defaultProfiles = append(defaultProfiles, profile)
 		}
 	}
 
@@ -517,6 +643,66 @@ func (p *MavenParser) getActiveProfiles(profiles []MavenProfile) []MavenProfile
 	return activeProfiles
 }
 
+// profileSelection splits the selected profiles into selected and deselected ("!id") IDs, and
+// reports whether all profiles are selected ("*")
+func (p *MavenParser) profileSelection() (selected, deselected map[string]bool, all bool) {
+	selected = make(map[string]bool)
+	deselected = make(map[string]bool)
+	for _, id := range p.profiles {
+		id = strings.TrimSpace(id)
+		switch {
+		case id == AllMavenProfiles:
+			all = true
+		case strings.HasPrefix(id, "!"):
+			deselected[strings.TrimSpace(id[1:])] = true
+		case id != "":
+			selected[id] = true
+		}
+	}
+	return selected, deselected, all
+}
+
+// withProfile tags the metadata of a profile dependency with the profile ID and its activation
+// conditions, so profile-scoped dependencies can be told apart from the main ones
+func withProfile(metadata map[string]interface{}, profile MavenProfile) map[string]interface{} {
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	metadata[MetadataProfile] = strings.TrimSpace(profile.ID)
+	if activation := profileActivation(profile.Activation); len(activation) > 0 {
+		metadata[MetadataProfileActivation] = activation
+	}
+	entry := activationEntry(ActivationProfile, []string{strings.TrimSpace(profile.ID)})
+	entry[ActivationFieldDefault] = profile.activeWithoutSelection
+	appendActivation(metadata, entry)
+	return metadata
+}
+
+// profileActivation returns the activation conditions of a profile keyed by their element
+// path (activeByDefault, jdk, os.family, property, file.exists, ...)
+func profileActivation(activation MavenActivation) map[string]string {
+	conditions := make(map[string]string)
+	add := func(key, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			conditions[key] = value
+		}
+	}
+	add("activeByDefault", activation.ActiveByDefault)
+	add("jdk", activation.JDK)
+	add("os.name", activation.OS.Name)
+	add("os.family", activation.OS.Family)
+	add("os.arch", activation.OS.Arch)
+	add("os.version", activation.OS.Version)
+	property := strings.TrimSpace(activation.Property.Name)
+	if value := strings.TrimSpace(activation.Property.Value); property != "" && value != "" {
+		property += "=" + value
+	}
+	add("property", property)
+	add("file.exists", activation.File.Exists)
+	add("file.missing", activation.File.Missing)
+	return conditions
+}
+
 // isProfileActive checks if a profile should be activated based on its activation conditions
 // Following deps.dev pattern: check JDK, OS, property, and file conditions
 // Uses default JDK and OS settings aligned with deps.dev (JDK 11.0.8, Linux/Unix/amd64)
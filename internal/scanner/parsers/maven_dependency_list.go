@@ -97,11 +97,11 @@ func (p *MavenDependencyListParser) ParseDependencyList(content string, includeT
 		dependencies = append(dependencies, dep)
 	}
 
-	return dependencies
+	return NormalizeDependencyVersions(dependencies)
 }
 
 // mapMavenListScope maps Maven scope from dependency list to our scope constants
-func mapMavenListScope(scope string) string {
+func mapMavenListScope(scope string) types.Scope {
 	switch scope {
 	case "test":
 		return types.ScopeDev
@@ -94,7 +94,7 @@ The following files have been resolved:
 func TestMavenDependencyListScopes(t *testing.T) {
 	tests := []struct {
 		mavenScope string
-		expected   string
+		expected   types.Scope
 	}{
 		{"compile", types.ScopeProd},
 		{"test", types.ScopeDev},
@@ -202,3 +202,64 @@ func TestMavenPluginDependenciesWithProperties(t *testing.T) {
 	assert.Equal(t, "2.8.8", result[0].Version, "Should resolve property in plugin dependency version")
 	assert.Equal(t, types.ScopeBuild, result[0].Scope, "Plugin dependencies should have build scope")
 }
+
+func TestMavenParser_ParsePlugins(t *testing.T) {
+	parser := NewMavenParser()
+
+	content := `<?xml version="1.0"?>
+<project>
+	<groupId>com.example</groupId>
+	<artifactId>test-project</artifactId>
+	<version>1.0.0</version>
+
+	<properties>
+		<shade.version>3.5.1</shade.version>
+	</properties>
+
+	<build>
+		<pluginManagement>
+			<plugins>
+				<plugin>
+					<groupId>com.diffplug.spotless</groupId>
+					<artifactId>spotless-maven-plugin</artifactId>
+					<version>2.43.0</version>
+				</plugin>
+			</plugins>
+		</pluginManagement>
+		<plugins>
+			<plugin>
+				<artifactId>maven-shade-plugin</artifactId>
+				<version>${shade.version}</version>
+			</plugin>
+			<plugin>
+				<groupId>com.diffplug.spotless</groupId>
+				<artifactId>spotless-maven-plugin</artifactId>
+			</plugin>
+			<plugin>
+				<groupId>org.springframework.boot</groupId>
+				<artifactId>spring-boot-maven-plugin</artifactId>
+			</plugin>
+		</plugins>
+	</build>
+</project>`
+
+	plugins := parser.ParsePlugins(content, "", nil)
+	require.Len(t, plugins, 3)
+
+	assert.Equal(t, "org.apache.maven.plugins:maven-shade-plugin", plugins[0].Name, "Should default the plugin groupId")
+	assert.Equal(t, "3.5.1", plugins[0].Version, "Should resolve the version property")
+	assert.Equal(t, "com.diffplug.spotless:spotless-maven-plugin", plugins[1].Name)
+	assert.Equal(t, "2.43.0", plugins[1].Version, "Should take the version from pluginManagement")
+	assert.Equal(t, "org.springframework.boot:spring-boot-maven-plugin", plugins[2].Name)
+	assert.Equal(t, "latest", plugins[2].Version)
+
+	for _, plugin := range plugins {
+		assert.Equal(t, DependencyTypeMaven, plugin.Type)
+		assert.Equal(t, types.ScopeBuild, plugin.Scope)
+		assert.True(t, plugin.Direct)
+		assert.Equal(t, true, plugin.Metadata[MetadataPlugin])
+	}
+
+	assert.Empty(t, parser.ParsePlugins("<project><artifactId>x</artifactId></project>", "", nil))
+	assert.Empty(t, parser.ParsePlugins("not xml", "", nil))
+}
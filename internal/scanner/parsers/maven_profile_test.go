@@ -297,3 +297,152 @@ func TestMavenProfileWithScopes(t *testing.T) {
 		}
 	}
 }
+
+func TestMavenProfileMetadata(t *testing.T) {
+	parser := NewMavenParser()
+
+	content := `<?xml version="1.0"?>
+<project>
+	<groupId>com.example</groupId>
+	<artifactId>test-project</artifactId>
+	<version>1.0.0</version>
+
+	<profiles>
+		<profile>
+			<id>default</id>
+			<activation>
+				<activeByDefault>true</activeByDefault>
+			</activation>
+			<dependencies>
+				<dependency>
+					<groupId>com.profile</groupId>
+					<artifactId>default-dep</artifactId>
+					<version>1.0.0</version>
+					<optional>true</optional>
+				</dependency>
+			</dependencies>
+		</profile>
+	</profiles>
+
+	<dependencies>
+		<dependency>
+			<groupId>com.example</groupId>
+			<artifactId>main-dep</artifactId>
+			<version>1.0.0</version>
+		</dependency>
+	</dependencies>
+</project>`
+
+	result := parser.ParsePomXML(content)
+	require.Len(t, result, 2)
+
+	assert.Equal(t, "com.profile:default-dep", result[0].Name)
+	assert.Equal(t, map[string]interface{}{
+		"optional":                true,
+		MetadataProfile:           "default",
+		MetadataProfileActivation: map[string]string{"activeByDefault": "true"},
+		MetadataActivation: []interface{}{
+			map[string]interface{}{ActivationFieldKind: ActivationProfile, ActivationFieldConditions: []string{"default"}, ActivationFieldDefault: true},
+		},
+	}, result[0].Metadata, "Should tag profile dependencies with the profile")
+	assert.Nil(t, result[1].Metadata, "Should not tag main dependencies")
+}
+
+func TestMavenProfileSelection(t *testing.T) {
+	content := `<?xml version="1.0"?>
+<project>
+	<groupId>com.example</groupId>
+	<artifactId>test-project</artifactId>
+	<version>1.0.0</version>
+
+	<profiles>
+		<profile>
+			<id>default</id>
+			<activation>
+				<activeByDefault>true</activeByDefault>
+			</activation>
+			<dependencies>
+				<dependency>
+					<groupId>com.profile</groupId>
+					<artifactId>default-dep</artifactId>
+					<version>1.0.0</version>
+				</dependency>
+			</dependencies>
+		</profile>
+		<profile>
+			<id>linux</id>
+			<activation>
+				<os>
+					<family>unix</family>
+				</os>
+			</activation>
+			<dependencies>
+				<dependency>
+					<groupId>com.profile</groupId>
+					<artifactId>linux-dep</artifactId>
+					<version>1.0.0</version>
+				</dependency>
+			</dependencies>
+		</profile>
+		<profile>
+			<id>dev</id>
+			<activation>
+				<property>
+					<name>env</name>
+					<value>dev</value>
+				</property>
+			</activation>
+			<dependencies>
+				<dependency>
+					<groupId>com.h2database</groupId>
+					<artifactId>h2</artifactId>
+					<version>2.2.224</version>
+				</dependency>
+			</dependencies>
+			<build>
+				<plugins>
+					<plugin>
+						<groupId>org.springframework.boot</groupId>
+						<artifactId>spring-boot-maven-plugin</artifactId>
+						<version>3.2.0</version>
+					</plugin>
+				</plugins>
+			</build>
+		</profile>
+	</profiles>
+</project>`
+
+	names := func(deps []types.Dependency) []string {
+		result := make([]string, 0, len(deps))
+		for _, dep := range deps {
+			result = append(result, dep.Name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		profiles []string
+		expected []string
+	}{
+		{name: "activation conditions only", profiles: nil, expected: []string{"com.profile:linux-dep"}},
+		{name: "selected profile", profiles: []string{"dev"}, expected: []string{"com.profile:linux-dep", "com.h2database:h2"}},
+		{name: "deselected profile", profiles: []string{"!linux"}, expected: []string{"com.profile:default-dep"}},
+		{name: "all profiles", profiles: []string{AllMavenProfiles}, expected: []string{"com.profile:default-dep", "com.profile:linux-dep", "com.h2database:h2"}},
+		{name: "all but one", profiles: []string{"*", "!default"}, expected: []string{"com.profile:linux-dep", "com.h2database:h2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewMavenParserWithProfiles(tt.profiles)
+			assert.Equal(t, tt.expected, names(parser.ParsePomXML(content)))
+		})
+	}
+
+	plugins := NewMavenParserWithProfiles([]string{"dev"}).ParsePlugins(content, "", nil)
+	require.Len(t, plugins, 1)
+	assert.Equal(t, "org.springframework.boot:spring-boot-maven-plugin", plugins[0].Name)
+	assert.Equal(t, "dev", plugins[0].Metadata[MetadataProfile])
+	assert.Equal(t, map[string]string{"property": "env=dev"}, plugins[0].Metadata[MetadataProfileActivation])
+	assert.Empty(t, NewMavenParser().ParsePlugins(content, "", nil), "Should skip plugins of inactive profiles")
+}
@@ -0,0 +1,83 @@
+package parsers
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// NotebookExtension is the file extension of Jupyter notebooks
+const NotebookExtension = ".ipynb"
+
+// mlModelFormats maps model file extensions to their serialization format
+var mlModelFormats = map[string]string{
+	".onnx":        "onnx",
+	".pt":          "pytorch",
+	".pth":         "pytorch",
+	".safetensors": "safetensors",
+	".gguf":        "gguf",
+}
+
+// Compile CUDA version regexes once at package level for performance
+var (
+	cudaLocalVersionRegex = regexp.MustCompile(`\+cu(\d{2,3})\b`)                // torch==2.1.0+cu118
+	cudaPackageRegex      = regexp.MustCompile(`(?:-cu|^cupy-cuda)(\d{2,3})x?$`) // nvidia-cublas-cu12, cupy-cuda12x
+	cudaImageTagRegex     = regexp.MustCompile(`cuda-?(\d+(?:\.\d+)*)`)          // pytorch/pytorch:2.1.0-cuda12.1-cudnn8-runtime
+	leadingVersionRegex   = regexp.MustCompile(`^(\d+(?:\.\d+)*)`)               // nvidia/cuda:12.1.0-runtime-ubuntu22.04
+)
+
+// MLModel describes a model artifact found in the repository
+type MLModel struct {
+	File   string `json:"file"`
+	Format string `json:"format"`
+	Size   int64  `json:"size"`
+}
+
+// CUDARequirement records a CUDA version required by a dependency
+type CUDARequirement struct {
+	Version    string `json:"version"`
+	Type       string `json:"type"`       // Dependency type (python, docker)
+	Dependency string `json:"dependency"` // Dependency declaring the requirement
+}
+
+// MLModelFormat returns the model format for a file name, or "" if it is not a model file
+func MLModelFormat(fileName string) string {
+	return mlModelFormats[strings.ToLower(path.Ext(fileName))]
+}
+
+// CUDARequirementFor returns the CUDA version required by a dependency, or nil.
+// Python wheels carry it in the local version (+cu121) or the package name (nvidia-*-cu12,
+// cupy-cuda12x); Docker images in the nvidia/cuda tag or a "cudaX.Y" tag component.
+func CUDARequirementFor(dep types.Dependency) *CUDARequirement {
+	var version string
+	switch dep.Type {
+	case DependencyTypePython:
+		if m := cudaLocalVersionRegex.FindStringSubmatch(dep.Version); m != nil {
+			version = cudaShortVersion(m[1])
+		} else if m := cudaPackageRegex.FindStringSubmatch(strings.ToLower(dep.Name)); m != nil {
+			version = cudaShortVersion(m[1])
+		}
+	case DependencyTypeDocker:
+		if dep.Name == "nvidia/cuda" {
+			if m := leadingVersionRegex.FindStringSubmatch(dep.Version); m != nil {
+				version = m[1]
+			}
+		} else if m := cudaImageTagRegex.FindStringSubmatch(dep.Version); m != nil {
+			version = m[1]
+		}
+	}
+	if version == "" {
+		return nil
+	}
+	return &CUDARequirement{Version: version, Type: dep.Type, Dependency: dep.Name}
+}
+
+// cudaShortVersion expands a wheel CUDA tag ("118", "121", "12") to a version ("11.8", "12.1", "12")
+func cudaShortVersion(tag string) string {
+	if len(tag) != 3 {
+		return tag
+	}
+	return tag[:2] + "." + tag[2:]
+}
@@ -0,0 +1,57 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMLModelFormat(t *testing.T) {
+	tests := []struct {
+		fileName string
+		expected string
+	}{
+		{"model.onnx", "onnx"},
+		{"weights.pt", "pytorch"},
+		{"checkpoint.PTH", "pytorch"},
+		{"model-00001-of-00002.safetensors", "safetensors"},
+		{"llama-3-8b.Q4_K_M.gguf", "gguf"},
+		{"model.py", ""},
+		{"notebook.ipynb", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MLModelFormat(tt.fileName))
+		})
+	}
+}
+
+func TestCUDARequirementFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		dep      types.Dependency
+		expected string
+	}{
+		{"torch local version", types.Dependency{Type: "python", Name: "torch", Version: "2.1.0+cu118"}, "11.8"},
+		{"nvidia runtime wheel", types.Dependency{Type: "python", Name: "nvidia-cuda-runtime-cu12", Version: "12.1.105"}, "12"},
+		{"cupy wheel", types.Dependency{Type: "python", Name: "cupy-cuda11x", Version: "13.0.0"}, "11"},
+		{"cpu-only torch", types.Dependency{Type: "python", Name: "torch", Version: "2.1.0"}, ""},
+		{"nvidia/cuda image", types.Dependency{Type: "docker", Name: "nvidia/cuda", Version: "12.1.0-runtime-ubuntu22.04"}, "12.1.0"},
+		{"pytorch image", types.Dependency{Type: "docker", Name: "pytorch/pytorch", Version: "2.1.0-cuda12.1-cudnn8-runtime"}, "12.1"},
+		{"plain image", types.Dependency{Type: "docker", Name: "python", Version: "3.12-slim"}, ""},
+		{"npm package", types.Dependency{Type: "npm", Name: "cuda-cu12", Version: "1.0.0"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := CUDARequirementFor(tt.dep)
+			if tt.expected == "" {
+				assert.Nil(t, req)
+				return
+			}
+			assert.Equal(t, &CUDARequirement{Version: tt.expected, Type: tt.dep.Type, Dependency: tt.dep.Name}, req)
+		})
+	}
+}
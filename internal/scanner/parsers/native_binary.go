@@ -0,0 +1,155 @@
+package parsers
+
+import (
+	"bytes"
+	"debug/elf"
+	"debug/macho"
+	"errors"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Native executable formats
+const (
+	ArtifactFormatELF   = "elf"
+	ArtifactFormatMachO = "macho"
+)
+
+var (
+	errNotExecutable = errors.New("not a native executable")
+
+	sonameVersionRegex = regexp.MustCompile(`^(.+?)\.so\.(\d+(?:\.\d+)*)$`)    // libssl.so.3
+	dylibVersionRegex  = regexp.MustCompile(`^(.+?)\.(\d+(?:\.\d+)*)\.dylib$`) // libz.1.dylib
+)
+
+// sharedLibraryPackages maps shared library names (without version) to the project providing
+// them, for binaries scanned without an OS package database
+var sharedLibraryPackages = map[string]string{
+	"libc": "glibc", "libm": "glibc", "libpthread": "glibc", "libdl": "glibc", "librt": "glibc",
+	"libresolv": "glibc", "libutil": "glibc", "ld-linux-x86-64": "glibc", "ld-linux-aarch64": "glibc",
+	"ld-linux": "glibc", "libc.musl-x86_64": "musl", "libc.musl-aarch64": "musl",
+	"libstdc++": "gcc", "libgcc_s": "gcc", "libgomp": "gcc", "libatomic": "gcc", "libgfortran": "gcc",
+	"libssl": "openssl", "libcrypto": "openssl", "libz": "zlib", "libsqlite3": "sqlite",
+	"libpq": "postgresql", "libmysqlclient": "mysql", "libmariadb": "mariadb", "libcurl": "curl",
+	"libxml2": "libxml2", "libxslt": "libxslt", "libpng16": "libpng", "libjpeg": "libjpeg-turbo",
+	"libffi": "libffi", "libyaml-0": "libyaml", "libpcre": "pcre", "libpcre2-8": "pcre2",
+	"libreadline": "readline", "libncursesw": "ncurses", "libtinfo": "ncurses", "libuuid": "util-linux",
+	"libsystemd": "systemd", "libgssapi_krb5": "krb5", "libkrb5": "krb5", "libbz2": "bzip2",
+	"liblzma": "xz", "libzstd": "zstd", "liblz4": "lz4", "libexpat": "expat", "libuv": "libuv",
+	"libevent": "libevent", "libprotobuf": "protobuf", "libjemalloc": "jemalloc", "libopenblas": "openblas",
+	"libblas": "blas", "liblapack": "lapack", "libgmp": "gmp", "libglib-2.0": "glib",
+	"libX11": "libx11", "libGL": "mesa", "libcudart": "cuda", "libnvinfer": "tensorrt",
+}
+
+// NativeLibrary is a shared library required by a native executable
+type NativeLibrary struct {
+	Name    string // DT_NEEDED soname or LC_LOAD_DYLIB install name (base name)
+	Version string // ABI version from the soname (libssl.so.3 is 3)
+	Package string // Project providing the library, when known
+}
+
+// ParseNativeBinary reads the shared libraries required by an ELF executable (DT_NEEDED) or a
+// Mach-O executable (LC_LOAD_DYLIB, first architecture of universal binaries). Shared libraries,
+// object files, and other files return an error.
+func (p *ArtifactParser) ParseNativeBinary(content []byte) (*Artifact, []NativeLibrary, error) {
+	reader := bytes.NewReader(content)
+	if file, err := elf.NewFile(reader); err == nil {
+		defer file.Close()
+		if !isELFExecutable(file) {
+			return nil, nil, errNotExecutable
+		}
+		libraries, err := file.ImportedLibraries()
+		if err != nil {
+			return nil, nil, err
+		}
+		artifact := &Artifact{Format: ArtifactFormatELF, Arch: elfArch(file.Machine), Application: true}
+		return artifact, nativeLibraries(libraries), nil
+	}
+
+	file, err := macho.NewFile(reader)
+	if err != nil {
+		fat, fatErr := macho.NewFatFile(reader)
+		if fatErr != nil || len(fat.Arches) == 0 {
+			return nil, nil, errNotExecutable
+		}
+		defer fat.Close()
+		file = fat.Arches[0].File
+	} else {
+		defer file.Close()
+	}
+	if file.Type != macho.TypeExec {
+		return nil, nil, errNotExecutable
+	}
+	libraries, err := file.ImportedLibraries()
+	if err != nil {
+		return nil, nil, err
+	}
+	artifact := &Artifact{Format: ArtifactFormatMachO, Arch: machoArch(file.Cpu), Application: true}
+	return artifact, nativeLibraries(libraries), nil
+}
+
+// isELFExecutable reports whether an ELF file is an executable: ET_EXEC, or a position
+// independent executable (ET_DYN with a program interpreter)
+func isELFExecutable(file *elf.File) bool {
+	if file.Type == elf.ET_EXEC {
+		return true
+	}
+	if file.Type != elf.ET_DYN {
+		return false
+	}
+	for _, prog := range file.Progs {
+		if prog.Type == elf.PT_INTERP {
+			return true
+		}
+	}
+	return false
+}
+
+// nativeLibraries converts required library names to libraries with their ABI version and
+// providing project
+func nativeLibraries(names []string) []NativeLibrary {
+	libraries := make([]NativeLibrary, 0, len(names))
+	for _, name := range names {
+		library := NativeLibrary{Name: path.Base(name)}
+		base := library.Name
+		if match := sonameVersionRegex.FindStringSubmatch(library.Name); match != nil {
+			base, library.Version = match[1], match[2]
+		} else if match := dylibVersionRegex.FindStringSubmatch(library.Name); match != nil {
+			base, library.Version = match[1], match[2]
+		} else {
+			base = strings.TrimSuffix(strings.TrimSuffix(base, ".dylib"), ".so")
+		}
+		library.Package = sharedLibraryPackages[base]
+		libraries = append(libraries, library)
+	}
+	return libraries
+}
+
+// elfArch returns the architecture name of an ELF machine
+func elfArch(machine elf.Machine) string {
+	switch machine {
+	case elf.EM_X86_64:
+		return "x86_64"
+	case elf.EM_AARCH64:
+		return "arm64"
+	case elf.EM_386:
+		return "x86"
+	case elf.EM_ARM:
+		return "arm"
+	case elf.EM_RISCV:
+		return "riscv"
+	}
+	return strings.ToLower(strings.TrimPrefix(machine.String(), "EM_"))
+}
+
+// machoArch returns the architecture name of a Mach-O CPU type
+func machoArch(cpu macho.Cpu) string {
+	switch cpu {
+	case macho.CpuAmd64:
+		return "x86_64"
+	case macho.CpuArm64:
+		return "arm64"
+	}
+	return strings.ToLower(strings.TrimPrefix(cpu.String(), "Cpu"))
+}
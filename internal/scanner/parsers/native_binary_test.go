@@ -0,0 +1,50 @@
+package parsers
+
+import (
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactParser_ParseNativeBinary(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test binary is a PE file")
+	}
+	executable, err := os.Executable()
+	require.NoError(t, err)
+	content, err := os.ReadFile(executable)
+	require.NoError(t, err)
+
+	artifact, _, err := NewArtifactParser().ParseNativeBinary(content)
+	require.NoError(t, err)
+	assert.Contains(t, []string{ArtifactFormatELF, ArtifactFormatMachO}, artifact.Format)
+	assert.NotEmpty(t, artifact.Arch)
+	assert.True(t, artifact.Application)
+}
+
+func TestArtifactParser_ParseNativeBinary_NotExecutable(t *testing.T) {
+	_, _, err := NewArtifactParser().ParseNativeBinary([]byte("#!/bin/sh\nexec ls\n"))
+	assert.Error(t, err)
+}
+
+func TestNativeLibraries(t *testing.T) {
+	libraries := nativeLibraries([]string{
+		"libssl.so.3",
+		"libc.so.6",
+		"libpcre2-8.so.0",
+		"libfoo.so",
+		"/usr/lib/libz.1.dylib",
+		"/System/Library/Frameworks/Foundation.framework/Versions/C/Foundation",
+	})
+	assert.Equal(t, []NativeLibrary{
+		{Name: "libssl.so.3", Version: "3", Package: "openssl"},
+		{Name: "libc.so.6", Version: "6", Package: "glibc"},
+		{Name: "libpcre2-8.so.0", Version: "0", Package: "pcre2"},
+		{Name: "libfoo.so"},
+		{Name: "libz.1.dylib", Version: "1", Package: "zlib"},
+		{Name: "Foundation"},
+	}, libraries)
+}
@@ -0,0 +1,116 @@
+package parsers
+
+import (
+	"path"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Native code metadata keys of dependencies
+const (
+	MetadataNative         = "native"          // True for packages shipping or building compiled code (Python extension modules, Node addons)
+	MetadataNativeEvidence = "native_evidence" // What flagged the package (platform-wheel, known-package, install-script, requires-build, addon-build)
+)
+
+// Evidence of native code in packages
+const (
+	NativeEvidencePlatformWheel = "platform-wheel" // A locked wheel is platform specific (cp312-manylinux_2_17_x86_64)
+	NativeEvidenceKnownPackage  = "known-package"  // Well-known Python package with compiled extensions
+	NativeEvidenceInstallScript = "install-script" // npm lock entry with hasInstallScript (install scripts, binding.gyp)
+	NativeEvidenceRequiresBuild = "requires-build" // pnpm lock entry with requiresBuild
+	NativeEvidenceAddonBuild    = "addon-build"    // Node package depending on addon build tooling (node-gyp-build, node-addon-api, nan)
+)
+
+// nativePythonPackages are Python packages with compiled extension modules, flagged when no
+// locked wheels tell
+var nativePythonPackages = map[string]bool{
+	"numpy": true, "scipy": true, "pandas": true, "scikit-learn": true, "matplotlib": true,
+	"lxml": true, "psycopg2": true, "psycopg2-binary": true, "asyncpg": true, "mysqlclient": true,
+	"pymssql": true, "pyodbc": true, "cx-oracle": true, "cryptography": true, "bcrypt": true,
+	"cffi": true, "pynacl": true, "pycryptodome": true, "pycryptodomex": true, "pillow": true,
+	"grpcio": true, "grpcio-tools": true, "orjson": true, "ujson": true, "msgpack": true,
+	"pydantic-core": true, "uvloop": true, "httptools": true, "greenlet": true, "gevent": true,
+	"pyzmq": true, "regex": true, "rapidfuzz": true, "zstandard": true, "lz4": true, "brotli": true,
+	"ruamel-yaml-clib": true, "torch": true, "tensorflow": true, "jaxlib": true, "onnxruntime": true,
+	"opencv-python": true, "opencv-python-headless": true, "pyarrow": true, "polars": true,
+	"tokenizers": true, "numba": true, "llvmlite": true, "h5py": true, "netcdf4": true,
+	"shapely": true, "pyproj": true, "rasterio": true, "fiona": true, "gdal": true,
+	"lightgbm": true, "xgboost": true, "faiss-cpu": true,
+}
+
+// nodeAddonBuildPackages are the packages native Node addons depend on to build or load
+// their binaries
+var nodeAddonBuildPackages = map[string]bool{
+	"node-gyp": true, "node-gyp-build": true, "node-addon-api": true, "nan": true, "bindings": true,
+	"prebuild-install": true, "node-pre-gyp": true, "@mapbox/node-pre-gyp": true, "cmake-js": true,
+}
+
+// IsPlatformWheel reports whether a wheel file name has a platform tag (a compiled
+// distribution) rather than "any"
+func IsPlatformWheel(fileName string) bool {
+	name := strings.TrimSuffix(path.Base(fileName), ".whl")
+	if name == path.Base(fileName) {
+		return false
+	}
+	parts := strings.Split(name, "-")
+	return len(parts) >= 5 && parts[len(parts)-1] != "any"
+}
+
+// NativePythonEvidence returns the evidence that a Python package ships compiled extension
+// modules, or "" if none. The locked wheels decide when there are any; otherwise the package
+// is looked up in the list of well-known native packages.
+func NativePythonEvidence(name string, wheels []string) string {
+	for _, wheel := range wheels {
+		if IsPlatformWheel(wheel) {
+			return NativeEvidencePlatformWheel
+		}
+	}
+	if len(wheels) > 0 {
+		return ""
+	}
+	name, _, _ = strings.Cut(name, "[") // Extras
+	if nativePythonPackages[NewPythonParser().canonPackageName(name)] {
+		return NativeEvidenceKnownPackage
+	}
+	return ""
+}
+
+// MarkNativePythonPackages flags the well-known native packages among Python dependencies
+// read without locked wheels (requirements.txt, pyproject.toml)
+func MarkNativePythonPackages(dependencies []types.Dependency) {
+	for i := range dependencies {
+		MarkNative(&dependencies[i], NativePythonEvidence(dependencies[i].Name, nil))
+	}
+}
+
+// MarkNative flags a dependency as native code with its evidence; an empty evidence is a no-op
+func MarkNative(dep *types.Dependency, evidence string) {
+	if evidence == "" {
+		return
+	}
+	if dep.Metadata == nil {
+		dep.Metadata = make(map[string]interface{})
+	}
+	dep.Metadata[MetadataNative] = true
+	dep.Metadata[MetadataNativeEvidence] = evidence
+}
+
+// nativeNodeEvidence returns the evidence that an npm lock entry builds native code, or "" if
+// none: a dependency on addon build tooling, else an install script
+func nativeNodeEvidence(pkg PackageInfo) string {
+	for name := range pkg.Dependencies {
+		if nodeAddonBuildPackages[name] {
+			return NativeEvidenceAddonBuild
+		}
+	}
+	for name := range pkg.Requires {
+		if nodeAddonBuildPackages[name] {
+			return NativeEvidenceAddonBuild
+		}
+	}
+	if pkg.HasInstallScript {
+		return NativeEvidenceInstallScript
+	}
+	return ""
+}
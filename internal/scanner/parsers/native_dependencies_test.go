@@ -0,0 +1,40 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+func TestIsPlatformWheel(t *testing.T) {
+	assert.True(t, IsPlatformWheel("numpy-2.1.0-cp312-cp312-manylinux_2_17_x86_64.manylinux2014_x86_64.whl"))
+	assert.True(t, IsPlatformWheel("cryptography-42.0.5-cp39-abi3-macosx_10_12_universal2.whl"))
+	assert.True(t, IsPlatformWheel("https://files.pythonhosted.org/packages/ab/cd/orjson-3.10.0-1-cp312-cp312-win_amd64.whl"))
+	assert.False(t, IsPlatformWheel("requests-2.31.0-py3-none-any.whl"))
+	assert.False(t, IsPlatformWheel("numpy-2.1.0.tar.gz"))
+}
+
+func TestNativePythonEvidence(t *testing.T) {
+	assert.Equal(t, NativeEvidencePlatformWheel, NativePythonEvidence("orjson", []string{"orjson-3.10.0-cp312-cp312-win_amd64.whl"}))
+	assert.Equal(t, "", NativePythonEvidence("pyyaml", []string{"PyYAML-6.0.1-py3-none-any.whl"}))
+	assert.Equal(t, NativeEvidenceKnownPackage, NativePythonEvidence("Pydantic_Core", nil))
+	assert.Equal(t, NativeEvidenceKnownPackage, NativePythonEvidence("ruamel.yaml.clib", nil))
+	assert.Equal(t, NativeEvidenceKnownPackage, NativePythonEvidence("psycopg2-binary[pool]", nil))
+	assert.Equal(t, "", NativePythonEvidence("requests", nil))
+}
+
+func TestMarkNativePythonPackages(t *testing.T) {
+	deps := []types.Dependency{
+		{Type: "python", Name: "numpy", Version: "1.26.4"},
+		{Type: "python", Name: "flask", Version: "3.0.0"},
+		{Type: "python", Name: "grpcio", Version: "1.62.0", Metadata: map[string]interface{}{"source": "requirements.txt"}},
+	}
+	MarkNativePythonPackages(deps)
+
+	assert.Equal(t, map[string]interface{}{MetadataNative: true, MetadataNativeEvidence: NativeEvidenceKnownPackage}, deps[0].Metadata)
+	assert.Nil(t, deps[1].Metadata)
+	assert.Equal(t, "requirements.txt", deps[2].Metadata["source"])
+	assert.Equal(t, true, deps[2].Metadata[MetadataNative])
+}
@@ -0,0 +1,190 @@
+package parsers
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Pre-compiled regexes for Nimble package files
+var (
+	nimbleFieldRegex   = regexp.MustCompile(`(?m)^(version|author|description|license|srcDir)\s*=\s*"([^"]*)"`)
+	nimbleBinRegex     = regexp.MustCompile(`(?m)^bin\s*=\s*@\[([^\]]*)\]`)
+	nimbleRequireRegex = regexp.MustCompile(`^(requires|taskRequires)\b\s*\(?\s*(".*)$`)
+	nimbleFeatureRegex = regexp.MustCompile(`^feature\s+"([^"]+)"\s*:`)
+	nimbleStringRegex  = regexp.MustCompile(`"([^"]*)"`)
+	nimbleNameRegex    = regexp.MustCompile(`^([^\s<>=~^#@]+)\s*(.*)$`)
+)
+
+// NimblePackage represents a Nimble package file (<name>.nimble)
+type NimblePackage struct {
+	Name         string
+	Version      string
+	Author       string
+	Description  string
+	License      string
+	SrcDir       string
+	Bin          []string
+	NimVersion   string // Requirement on the Nim compiler (requires "nim >= 2.0.0")
+	Requirements []NimbleRequirement
+}
+
+// NimbleRequirement is a package required by a Nimble package file
+type NimbleRequirement struct {
+	Name    string
+	URL     string
+	Version string // Version requirement (>= 1.0, ^= 1.2, == 1.0.1) or VCS ref (#head, #v1.0)
+	Task    string // Task of a taskRequires statement
+	Feature string // Feature block declaring the requirement
+}
+
+// NimParser handles Nimble package files
+type NimParser struct{}
+
+// NewNimParser creates a new Nim parser
+func NewNimParser() *NimParser {
+	return &NimParser{}
+}
+
+// ParseNimble parses a Nimble package file. The package name is the file name without the
+// .nimble extension; requires statements are read at the top level and in feature blocks.
+func (p *NimParser) ParseNimble(content, fileName string) NimblePackage {
+	pkg := NimblePackage{Name: strings.TrimSuffix(fileName, ".nimble")}
+	for _, match := range nimbleFieldRegex.FindAllStringSubmatch(content, -1) {
+		switch match[1] {
+		case "version":
+			pkg.Version = match[2]
+		case "author":
+			pkg.Author = match[2]
+		case "description":
+			pkg.Description = match[2]
+		case "license":
+			pkg.License = match[2]
+		case "srcDir":
+			pkg.SrcDir = match[2]
+		}
+	}
+	if match := nimbleBinRegex.FindStringSubmatch(content); match != nil {
+		for _, bin := range nimbleStringRegex.FindAllStringSubmatch(match[1], -1) {
+			pkg.Bin = append(pkg.Bin, bin[1])
+		}
+	}
+
+	lines := strings.Split(content, "\n")
+	feature, featureIndent := "", 0
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t\r")
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(trimmed)
+		if feature != "" && indent <= featureIndent {
+			feature = ""
+		}
+		if match := nimbleFeatureRegex.FindStringSubmatch(trimmed); match != nil {
+			feature, featureIndent = match[1], indent
+			continue
+		}
+		match := nimbleRequireRegex.FindStringSubmatch(trimmed)
+		if match == nil {
+			continue
+		}
+		// Requirement lists continue on the next line after a trailing comma
+		statement := match[2]
+		for strings.HasSuffix(strings.TrimSpace(statement), ",") && i+1 < len(lines) {
+			i++
+			statement += lines[i]
+		}
+		var specs []string
+		for _, spec := range nimbleStringRegex.FindAllStringSubmatch(statement, -1) {
+			specs = append(specs, spec[1])
+		}
+		task := ""
+		if match[1] == "taskRequires" {
+			if len(specs) < 2 {
+				continue
+			}
+			task, specs = specs[0], specs[1:]
+		}
+		for _, spec := range specs {
+			req, ok := parseNimbleRequirement(spec)
+			if !ok {
+				continue
+			}
+			if strings.EqualFold(req.Name, "nim") {
+				if task == "" && feature == "" {
+					pkg.NimVersion = req.Version
+				}
+				continue
+			}
+			req.Task = task
+			req.Feature = feature
+			pkg.Requirements = append(pkg.Requirements, req)
+		}
+	}
+	return pkg
+}
+
+// Dependencies converts the requirements to dependencies. Requirements of the test task have
+// the test scope and those of other tasks the dev scope (with the "task" metadata); those of
+// feature blocks are optional (with the "feature" metadata).
+func (p *NimParser) Dependencies(pkg NimblePackage) []types.Dependency {
+	var dependencies []types.Dependency
+	for _, req := range pkg.Requirements {
+		metadata := types.NewMetadata(MetadataSourceNimble)
+		if req.URL != "" {
+			metadata["url"] = req.URL
+		}
+		scope := types.ScopeProd
+		switch {
+		case req.Task == "test":
+			scope = types.ScopeTest
+			metadata["task"] = req.Task
+		case req.Task != "":
+			scope = types.ScopeDev
+			metadata["task"] = req.Task
+		case req.Feature != "":
+			scope = types.ScopeOptional
+			metadata["feature"] = req.Feature
+		}
+		version := req.Version
+		if version == "" {
+			version = "latest"
+		}
+		dependencies = append(dependencies, types.Dependency{
+			Type:     DependencyTypeNimble,
+			Name:     req.Name,
+			Version:  version,
+			Scope:    scope,
+			Direct:   true,
+			Metadata: metadata,
+		})
+	}
+	return dependencies
+}
+
+// parseNimbleRequirement splits a requirement ("jester >= 0.6", "karax#head",
+// "https://github.com/owner/repo.git >= 1.0") into name and version
+func parseNimbleRequirement(spec string) (NimbleRequirement, bool) {
+	spec = strings.TrimSpace(spec)
+	var req NimbleRequirement
+	if strings.Contains(spec, "://") {
+		url, rest, _ := strings.Cut(spec, " ")
+		if base, ref, ok := strings.Cut(url, "#"); ok {
+			url, rest = base, "#"+ref
+		}
+		req.URL = url
+		req.Name = strings.TrimSuffix(pathBase(url), ".git")
+		req.Version = strings.TrimSpace(rest)
+		return req, req.Name != ""
+	}
+	match := nimbleNameRegex.FindStringSubmatch(spec)
+	if match == nil {
+		return req, false
+	}
+	req.Name = match[1]
+	req.Version = strings.TrimSpace(strings.TrimPrefix(match[2], "@"))
+	return req, true
+}
@@ -0,0 +1,90 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testNimble = `# Package
+
+version       = "0.2.1"
+author        = "Jane Doe"
+description   = "A small web service"
+license       = "MIT"
+srcDir        = "src"
+bin           = @["webapp", "webctl"]
+
+
+# Dependencies
+
+requires "nim >= 2.0.0"
+requires "jester >= 0.6.0", "karax#head"
+requires "norm ^= 2.8",
+         "https://github.com/owner/chronicles.git#v0.10.3"
+requires("db_connector >= 0.1.0 & < 0.2.0")
+
+taskRequires "test", "unittest2 >= 0.2.1"
+taskRequires "docs", "nimibook"
+
+feature "redis":
+  requires "redis"
+
+when defined(windows):
+  requires "winim"
+`
+
+func TestNimParser_ParseNimble(t *testing.T) {
+	pkg := NewNimParser().ParseNimble(testNimble, "webapp.nimble")
+
+	assert.Equal(t, "webapp", pkg.Name)
+	assert.Equal(t, "0.2.1", pkg.Version)
+	assert.Equal(t, "Jane Doe", pkg.Author)
+	assert.Equal(t, "A small web service", pkg.Description)
+	assert.Equal(t, "MIT", pkg.License)
+	assert.Equal(t, "src", pkg.SrcDir)
+	assert.Equal(t, []string{"webapp", "webctl"}, pkg.Bin)
+	assert.Equal(t, ">= 2.0.0", pkg.NimVersion)
+
+	require.Len(t, pkg.Requirements, 9)
+	assert.Equal(t, NimbleRequirement{Name: "jester", Version: ">= 0.6.0"}, pkg.Requirements[0])
+	assert.Equal(t, NimbleRequirement{Name: "karax", Version: "#head"}, pkg.Requirements[1])
+	assert.Equal(t, NimbleRequirement{Name: "norm", Version: "^= 2.8"}, pkg.Requirements[2])
+	assert.Equal(t, NimbleRequirement{Name: "chronicles", URL: "https://github.com/owner/chronicles.git", Version: "#v0.10.3"}, pkg.Requirements[3])
+	assert.Equal(t, ">= 0.1.0 & < 0.2.0", pkg.Requirements[4].Version)
+	assert.Equal(t, NimbleRequirement{Name: "unittest2", Version: ">= 0.2.1", Task: "test"}, pkg.Requirements[5])
+	assert.Equal(t, "docs", pkg.Requirements[6].Task)
+	assert.Equal(t, NimbleRequirement{Name: "redis", Feature: "redis"}, pkg.Requirements[7])
+	assert.Equal(t, NimbleRequirement{Name: "winim"}, pkg.Requirements[8])
+}
+
+func TestNimParser_Dependencies(t *testing.T) {
+	parser := NewNimParser()
+	deps := parser.Dependencies(parser.ParseNimble(testNimble, "webapp.nimble"))
+	require.Len(t, deps, 9)
+
+	for _, dep := range deps {
+		assert.Equal(t, DependencyTypeNimble, dep.Type)
+		assert.True(t, dep.Direct)
+		assert.Equal(t, MetadataSourceNimble, dep.Metadata["source"])
+	}
+
+	assert.Equal(t, types.ScopeProd, deps[0].Scope)
+	assert.Equal(t, "https://github.com/owner/chronicles.git", deps[3].Metadata["url"])
+	assert.Equal(t, types.ScopeTest, deps[5].Scope)
+	assert.Equal(t, "test", deps[5].Metadata["task"])
+	assert.Equal(t, types.ScopeDev, deps[6].Scope)
+	assert.Equal(t, "latest", deps[6].Version)
+	assert.Equal(t, types.ScopeOptional, deps[7].Scope)
+	assert.Equal(t, "redis", deps[7].Metadata["feature"])
+	assert.Equal(t, types.ScopeProd, deps[8].Scope)
+}
+
+func TestNimParser_ParseNimbleMinimal(t *testing.T) {
+	pkg := NewNimParser().ParseNimble(`version = "1.0.0"`, "tiny.nimble")
+	assert.Equal(t, "tiny", pkg.Name)
+	assert.Empty(t, pkg.Requirements)
+	assert.Empty(t, NewNimParser().Dependencies(pkg))
+}
@@ -70,5 +70,5 @@ func (p *NodeJSParser) CreateDependencies(pkg *PackageJSON, depNames []string) [
 		})
 	}
 
-	return dependencies
+	return NormalizeDependencyVersions(dependencies)
 }
@@ -39,16 +39,16 @@ func NewDependencyFilter(options NPMLockFileOptions) *DependencyFilter {
 }
 
 // AddDirectDependency adds a direct dependency to the filter
-func (f *DependencyFilter) AddDirectDependency(name, scope string) {
+func (f *DependencyFilter) AddDirectDependency(name string, scope types.Scope) {
 	scopeInfo := f.directDeps[name]
 	switch scope {
-	case "prod":
+	case types.ScopeProd:
 		scopeInfo.prod = true
-	case "dev":
+	case types.ScopeDev:
 		scopeInfo.dev = true
-	case "peer":
+	case types.ScopePeer:
 		scopeInfo.peer = true
-	case "optional":
+	case types.ScopeOptional:
 		scopeInfo.optional = true
 	}
 	f.directDeps[name] = scopeInfo
@@ -93,7 +93,7 @@ func (f *DependencyFilter) ShouldInclude(name string) bool {
 }
 
 // GetScope returns the scope for a dependency, or empty string for transitive dependencies
-func (f *DependencyFilter) GetScope(name string) string {
+func (f *DependencyFilter) GetScope(name string) types.Scope {
 	scopeInfo, exists := f.directDeps[name]
 	if !exists {
 		return ""
@@ -125,7 +125,7 @@ func (f *DependencyFilter) CreateDependency(depType, name, version, sourceFile s
 	// Check if this is a direct dependency (in directDeps map)
 	_, isDirect := f.directDeps[name]
 
-	return &types.Dependency{
+	dep := &types.Dependency{
 		Type:       depType,
 		Name:       name,
 		Version:    version,
@@ -133,6 +133,10 @@ func (f *DependencyFilter) CreateDependency(depType, name, version, sourceFile s
 		Scope:      f.GetScope(name),
 		Direct:     isDirect,
 	}
+	if dep.Scope == types.ScopeOptional {
+		AddActivation(dep, ActivationOptional, nil, true)
+	}
+	return dep
 }
 
 // CreateAndAppendDependency creates a dependency and appends it to the slice if it should be included
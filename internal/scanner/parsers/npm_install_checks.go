@@ -0,0 +1,171 @@
+package parsers
+
+import (
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/semver"
+)
+
+// Kinds and outcomes of npm install conflicts
+const (
+	NPMConflictPeer   = "peer"   // A peer dependency is missing or resolved to a version outside its range
+	NPMConflictEngine = "engine" // The Node version is outside the engines.node range of a package
+
+	NPMOutcomeFail = "fail" // npm install fails (ERESOLVE, EBADENGINE with engine-strict)
+	NPMOutcomeWarn = "warn" // npm install warns (missing peer, EBADENGINE)
+)
+
+// NPMInstallConflict is a peerDependencies or engines constraint of a package-lock.json
+// package that the locked tree or the Node version does not satisfy
+type NPMInstallConflict struct {
+	Kind     string `json:"kind"`               // peer or engine
+	Package  string `json:"package"`            // Package declaring the constraint
+	Version  string `json:"version,omitempty"`  // Locked version of the package
+	Path     string `json:"path"`               // Location of the package in the lock file ("" for the project)
+	Requires string `json:"requires"`           // Peer package, or engine (node)
+	Range    string `json:"range"`              // Declared range
+	Resolved string `json:"resolved,omitempty"` // Locked peer version or Node version, empty for a missing peer
+	Outcome  string `json:"outcome"`            // fail or warn
+}
+
+// NPMInstallCheckOptions configures CheckNPMInstallConflicts
+type NPMInstallCheckOptions struct {
+	NodeVersion  string // Node version the project runs on; empty skips the engines checks
+	EngineStrict bool   // engine-strict=true in .npmrc: engine mismatches fail the install
+}
+
+// CheckNPMInstallConflicts cross-checks the peerDependencies and engines.node ranges of the
+// packages of a package-lock.json (v2 and later) against the locked versions and the Node
+// version. A peer resolves like a require() from the package: its own node_modules, then those
+// of its parents. Peers locked at versions outside their range fail the install (ERESOLVE);
+// missing non-optional peers (installed with --legacy-peer-deps) and engine mismatches warn.
+// Returns the conflicts sorted by path and constraint, or nil for legacy lock files.
+func CheckNPMInstallConflicts(content []byte, options NPMInstallCheckOptions) []NPMInstallConflict {
+	var lockfile PackageLockJSON
+	if err := json.Unmarshal(content, &lockfile); err != nil || len(lockfile.Packages) == 0 {
+		return nil
+	}
+
+	engineOutcome := NPMOutcomeWarn
+	if options.EngineStrict {
+		engineOutcome = NPMOutcomeFail
+	}
+	var conflicts []NPMInstallConflict
+	for lockPath, pkg := range lockfile.Packages {
+		if pkg.Link {
+			continue // The target entry holds the package
+		}
+		conflict := NPMInstallConflict{Package: lockPackageName(lockPath, pkg, lockfile.Name), Version: pkg.Version, Path: lockPath}
+
+		for peer, peerRange := range pkg.PeerDependencies {
+			resolved, found := resolveLockPackage(lockfile.Packages, lockPath, peer)
+			peerConflict := conflict
+			peerConflict.Kind, peerConflict.Requires, peerConflict.Range = NPMConflictPeer, peer, peerRange
+			switch {
+			case !found && pkg.PeerDependenciesMeta[peer].Optional:
+				continue
+			case !found:
+				peerConflict.Outcome = NPMOutcomeWarn
+			default:
+				if satisfied, ok := semver.SatisfiesNPMRange(resolved.Version, peerRange); satisfied || !ok {
+					continue
+				}
+				peerConflict.Resolved, peerConflict.Outcome = resolved.Version, NPMOutcomeFail
+			}
+			conflicts = append(conflicts, peerConflict)
+		}
+
+		if nodeRange := pkg.Engines["node"]; nodeRange != "" && options.NodeVersion != "" {
+			if satisfied, ok := semver.SatisfiesNPMRange(nodeReleaseVersion(options.NodeVersion), nodeRange); ok && !satisfied {
+				conflict.Kind, conflict.Requires, conflict.Range = NPMConflictEngine, "node", nodeRange
+				conflict.Resolved, conflict.Outcome = options.NodeVersion, engineOutcome
+				conflicts = append(conflicts, conflict)
+			}
+		}
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Path != conflicts[j].Path {
+			return conflicts[i].Path < conflicts[j].Path
+		}
+		if conflicts[i].Kind != conflicts[j].Kind {
+			return conflicts[i].Kind < conflicts[j].Kind
+		}
+		return conflicts[i].Requires < conflicts[j].Requires
+	})
+	return conflicts
+}
+
+// lockPackageName returns the package name of a package-lock.json entry: its name field, the
+// name of the project for the root, or the name of its node_modules path
+func lockPackageName(lockPath string, pkg PackageInfo, projectName string) string {
+	switch {
+	case pkg.Name != "":
+		return pkg.Name
+	case lockPath == "":
+		return projectName
+	}
+	return extractNameFromNodeModulesPath(lockPath)
+}
+
+// resolveLockPackage resolves a package name from the location of a package-lock.json entry:
+// the node_modules of the entry, then those of its parent directories
+func resolveLockPackage(packages map[string]PackageInfo, from, name string) (PackageInfo, bool) {
+	dir := from
+	for {
+		candidate := path.Join(dir, "node_modules", name)
+		if pkg, ok := packages[candidate]; ok {
+			if pkg.Link && pkg.Resolved != "" {
+				if target, ok := packages[pkg.Resolved]; ok {
+					return target, true
+				}
+			}
+			return pkg, true
+		}
+		if dir == "" || dir == "." {
+			return PackageInfo{}, false
+		}
+		dir = path.Dir(dir)
+	}
+}
+
+// nodeReleaseVersion returns the version a Node version stands for: a partial version (20,
+// 20.11) stands for the latest release of its line, as installed by nvm and version managers
+func nodeReleaseVersion(version string) string {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	for parts := strings.Count(version, ".") + 1; parts < 3; parts++ {
+		version += ".999999"
+	}
+	return version
+}
+
+// ParseNodeVersionFile returns the Node version of a .nvmrc or .node-version file ("v20.11.1",
+// "20"), or empty for aliases (lts/*, node) and files without a version
+func ParseNodeVersionFile(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		version := strings.TrimPrefix(strings.Fields(line)[0], "v")
+		if version == "" || version[0] < '0' || version[0] > '9' {
+			return ""
+		}
+		return version
+	}
+	return ""
+}
+
+// ParseNpmrcEngineStrict reports whether an .npmrc enables engine-strict
+func ParseNpmrcEngineStrict(content string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if ok && strings.TrimSpace(key) == "engine-strict" {
+			return strings.TrimSpace(value) == "true"
+		}
+	}
+	return false
+}
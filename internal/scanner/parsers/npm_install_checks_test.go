@@ -0,0 +1,55 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testInstallCheckLock = `{
+  "name": "web",
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "web", "version": "1.0.0", "dependencies": {"react-dom": "^18.2.0"}, "engines": {"node": ">=16"}},
+    "node_modules/react": {"version": "17.0.2"},
+    "node_modules/react-dom": {"version": "18.2.0", "peerDependencies": {"react": "^18.2.0"}, "engines": ["node >= 0.10"]},
+    "node_modules/legacy": {"version": "1.0.0", "peerDependencies": {"react": "^16 || ^17"}},
+    "node_modules/legacy/node_modules/react": {"version": "16.14.0"},
+    "node_modules/eslint-plugin-x": {"version": "2.0.0", "dev": true, "peerDependencies": {"eslint": ">=8", "typescript": "*"}, "peerDependenciesMeta": {"typescript": {"optional": true}}},
+    "node_modules/vite": {"version": "5.2.0", "dev": true, "engines": {"node": "^18.0.0 || >=20.0.0"}},
+    "node_modules/tagged": {"version": "1.0.0", "peerDependencies": {"react": "latest"}}
+  }
+}`
+
+func TestCheckNPMInstallConflicts(t *testing.T) {
+	conflicts := CheckNPMInstallConflicts([]byte(testInstallCheckLock), NPMInstallCheckOptions{NodeVersion: "16.20.2"})
+	require.Equal(t, []NPMInstallConflict{
+		{Kind: NPMConflictPeer, Package: "eslint-plugin-x", Version: "2.0.0", Path: "node_modules/eslint-plugin-x", Requires: "eslint", Range: ">=8", Outcome: NPMOutcomeWarn},
+		{Kind: NPMConflictPeer, Package: "react-dom", Version: "18.2.0", Path: "node_modules/react-dom", Requires: "react", Range: "^18.2.0", Resolved: "17.0.2", Outcome: NPMOutcomeFail},
+		{Kind: NPMConflictEngine, Package: "vite", Version: "5.2.0", Path: "node_modules/vite", Requires: "node", Range: "^18.0.0 || >=20.0.0", Resolved: "16.20.2", Outcome: NPMOutcomeWarn},
+	}, conflicts, "legacy resolves its nested react; optional and non-semver peers are skipped")
+
+	strict := CheckNPMInstallConflicts([]byte(testInstallCheckLock), NPMInstallCheckOptions{NodeVersion: "16", EngineStrict: true})
+	require.Len(t, strict, 3)
+	assert.Equal(t, NPMOutcomeFail, strict[2].Outcome, "engine-strict")
+
+	assert.Len(t, CheckNPMInstallConflicts([]byte(testInstallCheckLock), NPMInstallCheckOptions{NodeVersion: "20"}), 2, "latest release of the line")
+	assert.Len(t, CheckNPMInstallConflicts([]byte(testInstallCheckLock), NPMInstallCheckOptions{}), 2, "no Node version")
+	assert.Nil(t, CheckNPMInstallConflicts([]byte(`{"lockfileVersion": 1, "dependencies": {"a": {"version": "1.0.0"}}}`), NPMInstallCheckOptions{}))
+	assert.Nil(t, CheckNPMInstallConflicts([]byte("{invalid"), NPMInstallCheckOptions{}))
+}
+
+func TestParseNodeVersionFile(t *testing.T) {
+	assert.Equal(t, "20.11.1", ParseNodeVersionFile("v20.11.1\n"))
+	assert.Equal(t, "18", ParseNodeVersionFile("# comment\n18\n"))
+	assert.Equal(t, "", ParseNodeVersionFile("lts/iron\n"))
+	assert.Equal(t, "", ParseNodeVersionFile("node"))
+	assert.Equal(t, "", ParseNodeVersionFile(""))
+}
+
+func TestParseNpmrcEngineStrict(t *testing.T) {
+	assert.True(t, ParseNpmrcEngineStrict("registry=https://registry.npmjs.org/\nengine-strict = true\n"))
+	assert.False(t, ParseNpmrcEngineStrict("engine-strict=false"))
+	assert.False(t, ParseNpmrcEngineStrict(""))
+}
@@ -20,13 +20,71 @@ type PackageLockJSON struct {
 // PackageInfo represents a package in package-lock.json
 // Enhanced with deps.dev patterns for better dependency classification
 type PackageInfo struct {
-	Version      string                 `json:"version"`
-	Resolved     string                 `json:"resolved,omitempty"`
-	Link         bool                   `json:"link,omitempty"`
-	Dev          bool                   `json:"dev,omitempty"`
-	Optional     bool                   `json:"optional,omitempty"`
-	Bundled      bool                   `json:"bundled,omitempty"`
-	Dependencies map[string]PackageInfo `json:"dependencies,omitempty"`
+	Name             string              `json:"name,omitempty"` // Package name of the root, workspace, and aliased entries
+	Version          string              `json:"version"`
+	Resolved         string              `json:"resolved,omitempty"`
+	Link             bool                `json:"link,omitempty"`
+	Dev              bool                `json:"dev,omitempty"`
+	Optional         bool                `json:"optional,omitempty"`
+	Bundled          bool                `json:"bundled,omitempty"`
+	License          interface{}         `json:"license,omitempty"` // SPDX expression (v2+ packages), legacy {"type": ...} objects
+	HasInstallScript bool                `json:"hasInstallScript,omitempty"`
+	OS               []string            `json:"os,omitempty"`       // Supported platforms (process.platform, "!win32" excludes one)
+	CPU              []string            `json:"cpu,omitempty"`      // Supported architectures (process.arch)
+	Requires         map[string]string   `json:"requires,omitempty"` // Dependency ranges of legacy (v1) entries
+	Dependencies     PackageDependencies `json:"dependencies,omitempty"`
+
+	PeerDependencies     map[string]string                    `json:"peerDependencies,omitempty"`
+	PeerDependenciesMeta map[string]PackagePeerDependencyMeta `json:"peerDependenciesMeta,omitempty"`
+	Engines              PackageEngines                       `json:"engines,omitempty"` // Supported runtime versions (node, npm)
+}
+
+// PackagePeerDependencyMeta holds the peerDependenciesMeta of a peer dependency
+type PackagePeerDependencyMeta struct {
+	Optional bool `json:"optional"`
+}
+
+// PackageEngines holds the engines of a package: runtime names mapped to version ranges
+type PackageEngines map[string]string
+
+// UnmarshalJSON reads the engines object; the legacy array form ("node >= 0.8") and other
+// values are ignored
+func (e *PackageEngines) UnmarshalJSON(data []byte) error {
+	var engines map[string]interface{}
+	if err := json.Unmarshal(data, &engines); err != nil {
+		return nil
+	}
+	*e = make(PackageEngines, len(engines))
+	for name, value := range engines {
+		if versionRange, ok := value.(string); ok {
+			(*e)[name] = versionRange
+		}
+	}
+	return nil
+}
+
+// PackageDependencies holds the dependencies of a package-lock.json entry: nested entries in
+// the legacy "dependencies" tree, version ranges in "packages" entries (kept as the version)
+type PackageDependencies map[string]PackageInfo
+
+// UnmarshalJSON reads nested entries and version ranges
+func (d *PackageDependencies) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*d = make(PackageDependencies, len(raw))
+	for name, value := range raw {
+		var info PackageInfo
+		var versionRange string
+		if err := json.Unmarshal(value, &versionRange); err == nil {
+			info.Version = versionRange
+		} else if err := json.Unmarshal(value, &info); err != nil {
+			return err
+		}
+		(*d)[name] = info
+	}
+	return nil
 }
 
 // ParsePackageLockOptions contains configuration options for ParsePackageLock
@@ -53,15 +111,20 @@ func ParsePackageLockWithOptions(content []byte, packageJSON *PackageJSON, packa
 	scopeMaps := buildDependencyScopeMaps(packageJSON, packageJSONContent)
 
 	// Handle both v2 (dependencies) and v3+ (packages) lockfile formats
+	var dependencies []types.Dependency
 	if len(lockfile.Packages) > 0 {
-		return parsePackagesV3(lockfile.Packages, options, scopeMaps)
+		dependencies = parsePackagesV3(lockfile.Packages, options, scopeMaps)
+	} else if len(lockfile.Dependencies) > 0 {
+		dependencies = parseDependenciesV2Format(lockfile.Dependencies, options, scopeMaps)
 	}
 
-	if len(lockfile.Dependencies) > 0 {
-		return parseDependenciesV2Format(lockfile.Dependencies, options, scopeMaps)
+	// Direct dependencies combine package.json (range, scope) and package-lock.json (version)
+	for i := range dependencies {
+		if dependencies[i].Direct {
+			RecordLockOrigin(&dependencies[i], MetadataSourcePackageJSON, MetadataSourcePackageLock, scopeMaps.ranges[dependencies[i].Name])
+		}
 	}
-
-	return nil
+	return NormalizeDependencyVersions(dependencies)
 }
 
 // buildDependencyScopeMaps builds maps of direct dependency names with their scopes from package.json
@@ -71,26 +134,36 @@ func buildDependencyScopeMaps(packageJSON *PackageJSON, content []byte) dependen
 		devDeps:      make(map[string]bool),
 		peerDeps:     make(map[string]bool),
 		optionalDeps: make(map[string]bool),
+		ranges:       make(map[string]string),
 	}
 
 	if packageJSON == nil {
 		return maps
 	}
 
-	for name := range packageJSON.Dependencies {
+	addRange := func(name, constraint string) {
+		if _, exists := maps.ranges[name]; !exists {
+			maps.ranges[name] = constraint
+		}
+	}
+	for name, constraint := range packageJSON.Dependencies {
 		maps.prodDeps[name] = true
+		addRange(name, constraint)
 	}
-	for name := range packageJSON.DevDependencies {
+	for name, constraint := range packageJSON.DevDependencies {
 		maps.devDeps[name] = true
+		addRange(name, constraint)
 	}
 
 	// Try to detect peer and optional dependencies if enhanced struct is available
 	if enhancedPkg, err := parseEnhancedPackageJSON(content); err == nil {
-		for name := range enhancedPkg.PeerDependencies {
+		for name, constraint := range enhancedPkg.PeerDependencies {
 			maps.peerDeps[name] = true
+			addRange(name, constraint)
 		}
-		for name := range enhancedPkg.OptionalDependencies {
+		for name, constraint := range enhancedPkg.OptionalDependencies {
 			maps.optionalDeps[name] = true
+			addRange(name, constraint)
 		}
 	}
 
@@ -103,6 +176,7 @@ type dependencyScopeMaps struct {
 	devDeps      map[string]bool
 	peerDeps     map[string]bool
 	optionalDeps map[string]bool
+	ranges       map[string]string // Name -> version range declared by package.json
 }
 
 // parsePackagesV3 parses v3+ format with packages field
@@ -287,7 +361,8 @@ func isDirectDependency(name string, prodDeps, devDeps, peerDeps, optionalDeps m
 	return prodDeps[name] || devDeps[name] || peerDeps[name] || optionalDeps[name]
 }
 
-// buildNPMMetadata creates metadata map for NPM dependencies with peer, optional, and bundled flags
+// buildNPMMetadata creates metadata map for NPM dependencies with peer, optional, and bundled flags,
+// the license declared by the package, and the install script and native code flags
 func buildNPMMetadata(name string, pkg PackageInfo, peerDeps, optionalDeps map[string]bool) map[string]interface{} {
 	metadata := make(map[string]interface{})
 
@@ -299,6 +374,16 @@ func buildNPMMetadata(name string, pkg PackageInfo, peerDeps, optionalDeps map[s
 	// Add optional flag if true
 	if optionalDeps[name] || pkg.Optional {
 		metadata["optional"] = true
+		activation := activationEntry(ActivationOptional, nil)
+		activation[ActivationFieldDefault] = true
+		appendActivation(metadata, activation)
+	}
+
+	if len(pkg.OS) > 0 {
+		appendActivation(metadata, activationEntry(ActivationOS, pkg.OS))
+	}
+	if len(pkg.CPU) > 0 {
+		appendActivation(metadata, activationEntry(ActivationCPU, pkg.CPU))
 	}
 
 	// Add bundled flag if true
@@ -306,6 +391,19 @@ func buildNPMMetadata(name string, pkg PackageInfo, peerDeps, optionalDeps map[s
 		metadata["bundled"] = true
 	}
 
+	if license := packageLicense(pkg.License); license != "" {
+		metadata[MetadataLicenseDeclared] = license
+	}
+
+	if pkg.HasInstallScript {
+		metadata[MetadataInstallScript] = true
+	}
+
+	if evidence := nativeNodeEvidence(pkg); evidence != "" {
+		metadata[MetadataNative] = true
+		metadata[MetadataNativeEvidence] = evidence
+	}
+
 	// Return nil if no metadata to add
 	if len(metadata) == 0 {
 		return nil
@@ -314,13 +412,26 @@ func buildNPMMetadata(name string, pkg PackageInfo, peerDeps, optionalDeps map[s
 	return metadata
 }
 
+// packageLicense returns the license of a package-lock.json entry ("MIT" or {"type": "MIT"})
+func packageLicense(license interface{}) string {
+	switch l := license.(type) {
+	case string:
+		return strings.TrimSpace(l)
+	case map[string]interface{}:
+		if licenseType, ok := l["type"].(string); ok {
+			return strings.TrimSpace(licenseType)
+		}
+	}
+	return ""
+}
+
 // determineScopeFromLockfile determines the dependency scope based on package.json and lockfile metadata
 // Enhanced with deps.dev patterns for accurate scope classification
 func determineScopeFromLockfile(
 	name string,
 	pkg PackageInfo,
 	prodDeps, devDeps, peerDeps, optionalDeps map[string]bool,
-) string {
+) types.Scope {
 	// Check if it's a peer dependency
 	if peerDeps[name] {
 		return types.ScopePeer
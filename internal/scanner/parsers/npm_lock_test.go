@@ -106,6 +106,63 @@ func TestParsePackageLock(t *testing.T) {
 	}
 }
 
+func TestParsePackageLock_Licenses(t *testing.T) {
+	content := `{
+		"lockfileVersion": 3,
+		"packages": {
+			"": {"name": "test-project", "version": "1.0.0", "license": "UNLICENSED"},
+			"node_modules/react": {"version": "18.2.0", "license": "MIT"},
+			"node_modules/legacy": {"version": "0.1.0", "license": {"type": "BSD-3-Clause"}},
+			"node_modules/unlicensed": {"version": "1.0.0"}
+		}
+	}`
+
+	licenses := make(map[string]interface{})
+	for _, dep := range ParsePackageLock([]byte(content), nil) {
+		licenses[dep.Name] = dep.Metadata[MetadataLicenseDeclared]
+	}
+
+	expected := map[string]interface{}{"react": "MIT", "legacy": "BSD-3-Clause", "unlicensed": nil}
+	if len(licenses) != len(expected) {
+		t.Fatalf("ParsePackageLock() got %d dependencies, want %d", len(licenses), len(expected))
+	}
+	for name, license := range expected {
+		if licenses[name] != license {
+			t.Errorf("ParsePackageLock() dep %s license = %v, want %v", name, licenses[name], license)
+		}
+	}
+}
+
+func TestParsePackageLock_Native(t *testing.T) {
+	content := `{
+		"lockfileVersion": 3,
+		"packages": {
+			"": {"name": "test-project", "dependencies": {"bcrypt": "^5.1.0", "core-js": "^3.0.0", "react": "^18.2.0"}},
+			"node_modules/bcrypt": {"version": "5.1.1", "hasInstallScript": true, "dependencies": {"@mapbox/node-pre-gyp": "^1.0.11", "node-addon-api": "^5.0.0"}},
+			"node_modules/core-js": {"version": "3.33.0", "hasInstallScript": true},
+			"node_modules/react": {"version": "18.2.0", "dependencies": {"loose-envify": "^1.1.0"}}
+		}
+	}`
+
+	evidence := make(map[string]interface{})
+	for _, dep := range ParsePackageLock([]byte(content), nil) {
+		evidence[dep.Name] = dep.Metadata[MetadataNativeEvidence]
+		if dep.Metadata[MetadataNativeEvidence] != nil && dep.Metadata[MetadataNative] != true {
+			t.Errorf("ParsePackageLock() dep %s not flagged native", dep.Name)
+		}
+	}
+
+	expected := map[string]interface{}{"bcrypt": NativeEvidenceAddonBuild, "core-js": NativeEvidenceInstallScript, "react": nil}
+	if len(evidence) != len(expected) {
+		t.Fatalf("ParsePackageLock() got %d dependencies, want %d", len(evidence), len(expected))
+	}
+	for name, want := range expected {
+		if evidence[name] != want {
+			t.Errorf("ParsePackageLock() dep %s native evidence = %v, want %v", name, evidence[name], want)
+		}
+	}
+}
+
 func TestExtractNameFromNodeModulesPath(t *testing.T) {
 	tests := []struct {
 		path     string
@@ -0,0 +1,305 @@
+package parsers
+
+import (
+	"encoding/json"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Tools applying local patches to npm packages, and the fields overriding their versions
+const (
+	NPMPatchToolYarn         = "yarn"          // patch: protocol (yarn patch-commit), .yarn/patches
+	NPMPatchToolPnpm         = "pnpm"          // pnpm.patchedDependencies (pnpm patch-commit)
+	NPMPatchToolPatchPackage = "patch-package" // patches/ directory applied by patch-package
+
+	NPMOverrideFieldNPM  = "overrides"      // npm overrides
+	NPMOverrideFieldYarn = "resolutions"    // yarn resolutions
+	NPMOverrideFieldPnpm = "pnpm.overrides" // pnpm overrides
+)
+
+// NPMPatch is an upstream npm package the project patches locally
+type NPMPatch struct {
+	Package string `json:"package"`
+	Version string `json:"version,omitempty"` // Patched version; empty patches every version
+	Tool    string `json:"tool"`              // yarn, pnpm, or patch-package
+	File    string `json:"file,omitempty"`    // Patch file, relative to the project
+}
+
+// NPMOverride is a version the project forces on an npm package of its dependency tree
+type NPMOverride struct {
+	Package  string `json:"package"`
+	Selector string `json:"selector,omitempty"` // Key narrowing the override (parent path, version range); empty for all occurrences
+	Version  string `json:"version"`            // Forced version or specifier
+	Field    string `json:"field"`              // overrides, resolutions, or pnpm.overrides
+}
+
+var (
+	// patchPackageFileRegex matches a patch-package file name: name+version[+sequence][+dev].patch,
+	// with the scope separated by + and nested packages by ++
+	patchPackageFileRegex = regexp.MustCompile(`^(?:.*\+\+)?((?:@[^+]+\+)?[^+@]+)\+([0-9][^+]*)(?:\+.*)?\.patch$`)
+	// pnpmPatchFileRegex matches a pnpm patch file name: name@version.patch, with the scope
+	// separated by __
+	pnpmPatchFileRegex = regexp.MustCompile(`^((?:@[^@]+__)?[^@]+)(?:@([0-9][^@]*))?\.patch$`)
+	// yarnPatchFileRegex matches a yarn patch file name: slug-npm-version-hash.patch
+	yarnPatchFileRegex = regexp.MustCompile(`^(.+?)-npm-([0-9][^-]*(?:-[0-9A-Za-z.]+)?)-[0-9a-f]+\.patch$`)
+	// yarnResolutionRegex matches the resolution line of a yarn.lock (Berry) entry
+	yarnResolutionRegex = regexp.MustCompile(`^\s+resolution:\s+"([^"]+)"`)
+)
+
+// ParsePackageJSONPatches returns the patches and overrides a package.json declares: patch:
+// protocol ranges of dependencies and resolutions (yarn), pnpm.patchedDependencies, and the
+// overrides (npm), resolutions (yarn), and pnpm.overrides fields. Nested npm overrides are
+// flattened to pnpm-style selectors (parent>package).
+func ParsePackageJSONPatches(content []byte) ([]NPMPatch, []NPMOverride) {
+	var packageJSON struct {
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+		Resolutions          map[string]string `json:"resolutions"`
+		Overrides            json.RawMessage   `json:"overrides"`
+		Pnpm                 struct {
+			PatchedDependencies map[string]string `json:"patchedDependencies"`
+			Overrides           map[string]string `json:"overrides"`
+		} `json:"pnpm"`
+	}
+	if err := json.Unmarshal(content, &packageJSON); err != nil {
+		return nil, nil
+	}
+
+	var patches []NPMPatch
+	for _, ranges := range []map[string]string{packageJSON.Dependencies, packageJSON.DevDependencies, packageJSON.OptionalDependencies, packageJSON.Resolutions} {
+		for _, spec := range ranges {
+			if patch, ok := parseYarnPatchSpec(spec); ok {
+				patches = append(patches, patch)
+			}
+		}
+	}
+	for key, file := range packageJSON.Pnpm.PatchedDependencies {
+		name, version := splitPnpmPatchKey(key)
+		patches = append(patches, NPMPatch{Package: name, Version: version, Tool: NPMPatchToolPnpm, File: path.Clean(file)})
+	}
+
+	var overrides []NPMOverride
+	for key, version := range packageJSON.Resolutions {
+		if strings.HasPrefix(version, "patch:") {
+			continue // Reported as a patch
+		}
+		name, selector := splitYarnResolutionKey(key)
+		overrides = append(overrides, NPMOverride{Package: name, Selector: selector, Version: version, Field: NPMOverrideFieldYarn})
+	}
+	for key, version := range packageJSON.Pnpm.Overrides {
+		name, selector := splitPnpmOverrideKey(key)
+		overrides = append(overrides, NPMOverride{Package: name, Selector: selector, Version: version, Field: NPMOverrideFieldPnpm})
+	}
+	overrides = appendNPMOverrides(overrides, packageJSON.Overrides, "")
+
+	return SortNPMPatches(patches), sortNPMOverrides(overrides)
+}
+
+// appendNPMOverrides appends the overrides of an npm overrides object: a package maps to a
+// version, or to an object holding its own version (".") and the overrides of its dependencies
+func appendNPMOverrides(overrides []NPMOverride, raw json.RawMessage, parent string) []NPMOverride {
+	var entries map[string]json.RawMessage
+	if len(raw) == 0 || json.Unmarshal(raw, &entries) != nil {
+		return overrides
+	}
+	for key, value := range entries {
+		selector := key
+		if parent != "" {
+			selector = parent + ">" + key
+		}
+		var version string
+		if json.Unmarshal(value, &version) != nil {
+			overrides = appendNPMOverrides(overrides, value, selector)
+			continue
+		}
+		if key == "." {
+			selector = parent // The version of the parent package itself
+		}
+		name, selector := splitPnpmOverrideKey(selector)
+		overrides = append(overrides, NPMOverride{Package: name, Selector: selector, Version: version, Field: NPMOverrideFieldNPM})
+	}
+	return overrides
+}
+
+// npmOverrideSelector returns the selector of an override key: empty when the key is the
+// package name itself
+func npmOverrideSelector(name, key string) string {
+	if key == name {
+		return ""
+	}
+	return key
+}
+
+// splitPnpmOverrideKey splits a pnpm override key (parent>name@range) into the package name
+// and the selector (the key, when it narrows the package)
+func splitPnpmOverrideKey(key string) (string, string) {
+	name := key
+	if i := strings.LastIndex(name, ">"); i >= 0 {
+		name = name[i+1:]
+	}
+	if at := strings.LastIndex(name, "@"); at > 0 {
+		name = name[:at]
+	}
+	return name, npmOverrideSelector(name, key)
+}
+
+// splitYarnResolutionKey splits a yarn resolution key (**/parent/@scope/name) into the package
+// name and the selector (the key, when it narrows the package)
+func splitYarnResolutionKey(key string) (string, string) {
+	segments := strings.Split(key, "/")
+	name := segments[len(segments)-1]
+	if len(segments) > 1 && strings.HasPrefix(segments[len(segments)-2], "@") {
+		name = segments[len(segments)-2] + "/" + name
+	}
+	if at := strings.LastIndex(name, "@"); at > 0 {
+		name = name[:at]
+	}
+	return name, npmOverrideSelector(name, key)
+}
+
+// splitPnpmPatchKey splits a pnpm.patchedDependencies key (name@version) into the package name
+// and version
+func splitPnpmPatchKey(key string) (string, string) {
+	if at := strings.LastIndex(key, "@"); at > 0 {
+		return key[:at], key[at+1:]
+	}
+	return key, ""
+}
+
+// parseYarnPatchSpec parses a yarn patch: protocol range or resolution
+// (patch:lodash@npm%3A4.17.21#~/.yarn/patches/lodash.patch::version=4.17.21&hash=...);
+// yarn's builtin compatibility patches (~builtin<compat/typescript>) are not local patches
+func parseYarnPatchSpec(spec string) (NPMPatch, bool) {
+	spec, ok := strings.CutPrefix(spec, "patch:")
+	if !ok {
+		return NPMPatch{}, false
+	}
+	if decoded, err := url.PathUnescape(spec); err == nil {
+		spec = decoded
+	}
+	descriptor, source, ok := strings.Cut(spec, "#")
+	if !ok || strings.Contains(source, "builtin<") {
+		return NPMPatch{}, false
+	}
+	source, params, _ := strings.Cut(source, "::")
+
+	name, version := descriptor, ""
+	if at := strings.LastIndex(descriptor, "@"); at > 0 {
+		name, version = descriptor[:at], strings.TrimPrefix(descriptor[at+1:], "npm:")
+	}
+	if query, err := url.ParseQuery(params); err == nil && query.Get("version") != "" {
+		version = query.Get("version")
+	}
+	file := strings.TrimPrefix(strings.TrimPrefix(source, "~/"), "./")
+	return NPMPatch{Package: name, Version: version, Tool: NPMPatchToolYarn, File: path.Clean(file)}, true
+}
+
+// ParseYarnLockPatches returns the packages a yarn.lock (Berry) resolves through local patches
+// (patch: protocol resolutions)
+func ParseYarnLockPatches(content []byte) []NPMPatch {
+	var patches []NPMPatch
+	for _, line := range strings.Split(string(content), "\n") {
+		match := yarnResolutionRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		if _, spec, ok := strings.Cut(match[1], "@patch:"); ok {
+			if patch, ok := parseYarnPatchSpec("patch:" + spec); ok {
+				patches = append(patches, patch)
+			}
+		}
+	}
+	return SortNPMPatches(patches)
+}
+
+// ParsePnpmLockPatches returns the patched dependencies of a pnpm-lock.yaml: the
+// patchedDependencies map, holding the patch path and hash (v6 to v8) or the hash (v9+)
+func ParsePnpmLockPatches(content []byte) []NPMPatch {
+	var lockfile struct {
+		PatchedDependencies map[string]yaml.Node `yaml:"patchedDependencies"`
+	}
+	if err := yaml.Unmarshal(content, &lockfile); err != nil {
+		return nil
+	}
+	var patches []NPMPatch
+	for key, node := range lockfile.PatchedDependencies {
+		name, version := splitPnpmPatchKey(key)
+		patch := NPMPatch{Package: name, Version: version, Tool: NPMPatchToolPnpm}
+		var entry struct {
+			Path string `yaml:"path"`
+		}
+		if node.Kind == yaml.MappingNode && node.Decode(&entry) == nil && entry.Path != "" {
+			patch.File = path.Clean(entry.Path)
+		}
+		patches = append(patches, patch)
+	}
+	return SortNPMPatches(patches)
+}
+
+// ParsePatchFileName returns the patch of a patch file found in a patches directory: the
+// patches/ directory of patch-package (lodash+4.17.21.patch, @scope+name+1.0.0.patch) and pnpm
+// (lodash@4.17.21.patch, @scope__name@1.0.0.patch), or .yarn/patches
+// (lodash-npm-4.17.21-6382451519.patch). The file is relative to the project.
+func ParsePatchFileName(file string) (NPMPatch, bool) {
+	base := path.Base(file)
+	if path.Base(path.Dir(file)) == "patches" && path.Base(path.Dir(path.Dir(file))) == ".yarn" {
+		match := yarnPatchFileRegex.FindStringSubmatch(base)
+		if match == nil {
+			return NPMPatch{}, false
+		}
+		name := match[1]
+		if strings.HasPrefix(name, "@") {
+			name = strings.Replace(name, "-", "/", 1) // Scope slugs join scope and name with a dash
+		}
+		return NPMPatch{Package: name, Version: match[2], Tool: NPMPatchToolYarn, File: file}, true
+	}
+	if match := patchPackageFileRegex.FindStringSubmatch(base); match != nil {
+		return NPMPatch{Package: strings.Replace(match[1], "+", "/", 1), Version: match[2], Tool: NPMPatchToolPatchPackage, File: file}, true
+	}
+	if match := pnpmPatchFileRegex.FindStringSubmatch(base); match != nil {
+		return NPMPatch{Package: strings.Replace(match[1], "__", "/", 1), Version: match[2], Tool: NPMPatchToolPnpm, File: file}, true
+	}
+	return NPMPatch{}, false
+}
+
+// SortNPMPatches removes duplicate patches and sorts them by package, version, and file
+func SortNPMPatches(patches []NPMPatch) []NPMPatch {
+	seen := make(map[NPMPatch]bool)
+	var unique []NPMPatch
+	for _, patch := range patches {
+		if !seen[patch] {
+			seen[patch] = true
+			unique = append(unique, patch)
+		}
+	}
+	sort.Slice(unique, func(i, j int) bool {
+		if unique[i].Package != unique[j].Package {
+			return unique[i].Package < unique[j].Package
+		}
+		if unique[i].Version != unique[j].Version {
+			return unique[i].Version < unique[j].Version
+		}
+		return unique[i].File < unique[j].File
+	})
+	return unique
+}
+
+// sortNPMOverrides sorts overrides by package, field, and selector
+func sortNPMOverrides(overrides []NPMOverride) []NPMOverride {
+	sort.Slice(overrides, func(i, j int) bool {
+		if overrides[i].Package != overrides[j].Package {
+			return overrides[i].Package < overrides[j].Package
+		}
+		if overrides[i].Field != overrides[j].Field {
+			return overrides[i].Field < overrides[j].Field
+		}
+		return overrides[i].Selector < overrides[j].Selector
+	})
+	return overrides
+}
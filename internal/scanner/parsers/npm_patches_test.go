@@ -0,0 +1,134 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePackageJSONPatches(t *testing.T) {
+	content := `{
+  "name": "web",
+  "dependencies": {
+    "lodash": "patch:lodash@npm%3A4.17.21#~/.yarn/patches/lodash-npm-4.17.21-6382451519.patch",
+    "react": "^18.2.0"
+  },
+  "devDependencies": {
+    "typescript": "patch:typescript@npm%3A^5.0.0#~builtin<compat/typescript>"
+  },
+  "resolutions": {
+    "**/minimist": "1.2.8",
+    "webpack/@babel/core": "7.24.0",
+    "left-pad": "patch:left-pad@npm%3A1.3.0#./patches/left-pad.patch"
+  },
+  "overrides": {
+    "semver": "7.5.4",
+    "foo": {".": "2.0.0", "bar@<2": "2.1.0"},
+    "@scope/pkg@1.x": "1.2.0"
+  },
+  "pnpm": {
+    "patchedDependencies": {"express@4.18.2": "patches/express@4.18.2.patch"},
+    "overrides": {"webpack>tapable": "2.2.1", "glob@<8": "8.1.0"}
+  }
+}`
+	patches, overrides := ParsePackageJSONPatches([]byte(content))
+	assert.Equal(t, []NPMPatch{
+		{Package: "express", Version: "4.18.2", Tool: NPMPatchToolPnpm, File: "patches/express@4.18.2.patch"},
+		{Package: "left-pad", Version: "1.3.0", Tool: NPMPatchToolYarn, File: "patches/left-pad.patch"},
+		{Package: "lodash", Version: "4.17.21", Tool: NPMPatchToolYarn, File: ".yarn/patches/lodash-npm-4.17.21-6382451519.patch"},
+	}, patches, "builtin compatibility patches are not local patches")
+	assert.Equal(t, []NPMOverride{
+		{Package: "@babel/core", Selector: "webpack/@babel/core", Version: "7.24.0", Field: NPMOverrideFieldYarn},
+		{Package: "@scope/pkg", Selector: "@scope/pkg@1.x", Version: "1.2.0", Field: NPMOverrideFieldNPM},
+		{Package: "bar", Selector: "foo>bar@<2", Version: "2.1.0", Field: NPMOverrideFieldNPM},
+		{Package: "foo", Version: "2.0.0", Field: NPMOverrideFieldNPM},
+		{Package: "glob", Selector: "glob@<8", Version: "8.1.0", Field: NPMOverrideFieldPnpm},
+		{Package: "minimist", Selector: "**/minimist", Version: "1.2.8", Field: NPMOverrideFieldYarn},
+		{Package: "semver", Version: "7.5.4", Field: NPMOverrideFieldNPM},
+		{Package: "tapable", Selector: "webpack>tapable", Version: "2.2.1", Field: NPMOverrideFieldPnpm},
+	}, overrides)
+
+	patches, overrides = ParsePackageJSONPatches([]byte(`{"name": "plain", "dependencies": {"react": "^18.2.0"}}`))
+	assert.Empty(t, patches)
+	assert.Empty(t, overrides)
+	patches, overrides = ParsePackageJSONPatches([]byte("{invalid"))
+	assert.Nil(t, patches)
+	assert.Nil(t, overrides)
+}
+
+func TestParseYarnLockPatches(t *testing.T) {
+	content := `__metadata:
+  version: 8
+
+"lodash@npm:4.17.21":
+  version: 4.17.21
+  resolution: "lodash@npm:4.17.21"
+
+"lodash@patch:lodash@npm%3A4.17.21#~/.yarn/patches/lodash-npm-4.17.21-6382451519.patch":
+  version: 4.17.21
+  resolution: "lodash@patch:lodash@npm%3A4.17.21#~/.yarn/patches/lodash-npm-4.17.21-6382451519.patch::version=4.17.21&hash=2c6e9e&locator=web%40workspace%3A."
+
+"@types/node@patch:@types/node@npm%3A^20.0.0#./patches/types-node.patch":
+  version: 20.11.5
+  resolution: "@types/node@patch:@types/node@npm%3A20.11.5#./patches/types-node.patch::version=20.11.5&hash=abc123"
+
+"typescript@patch:typescript@npm%3A^5.0.0#optional!builtin<compat/typescript>":
+  version: 5.4.5
+  resolution: "typescript@patch:typescript@npm%3A5.4.5#optional!builtin<compat/typescript>::version=5.4.5&hash=5adc0c"
+`
+	assert.Equal(t, []NPMPatch{
+		{Package: "@types/node", Version: "20.11.5", Tool: NPMPatchToolYarn, File: "patches/types-node.patch"},
+		{Package: "lodash", Version: "4.17.21", Tool: NPMPatchToolYarn, File: ".yarn/patches/lodash-npm-4.17.21-6382451519.patch"},
+	}, ParseYarnLockPatches([]byte(content)))
+	assert.Empty(t, ParseYarnLockPatches([]byte(`"react@npm:^18.2.0":`+"\n  version: 18.2.0\n")))
+}
+
+func TestParsePnpmLockPatches(t *testing.T) {
+	v6 := `lockfileVersion: '6.0'
+patchedDependencies:
+  express@4.18.2:
+    hash: 3ahbgdfqt5xkwcvjl6rk2kzjeq
+    path: patches/express@4.18.2.patch
+`
+	assert.Equal(t, []NPMPatch{
+		{Package: "express", Version: "4.18.2", Tool: NPMPatchToolPnpm, File: "patches/express@4.18.2.patch"},
+	}, ParsePnpmLockPatches([]byte(v6)))
+
+	v9 := `lockfileVersion: '9.0'
+patchedDependencies:
+  '@scope/pkg@1.0.0': 3ahbgdfqt5xkwcvjl6rk2kzjeq
+`
+	assert.Equal(t, []NPMPatch{
+		{Package: "@scope/pkg", Version: "1.0.0", Tool: NPMPatchToolPnpm},
+	}, ParsePnpmLockPatches([]byte(v9)), "v9 records only the hash")
+	assert.Empty(t, ParsePnpmLockPatches([]byte("lockfileVersion: '9.0'\n")))
+	assert.Nil(t, ParsePnpmLockPatches([]byte(":\tinvalid")))
+}
+
+func TestParsePatchFileName(t *testing.T) {
+	tests := []struct {
+		file     string
+		expected NPMPatch
+	}{
+		{"patches/lodash+4.17.21.patch", NPMPatch{Package: "lodash", Version: "4.17.21", Tool: NPMPatchToolPatchPackage}},
+		{"patches/@babel+core+7.24.0.patch", NPMPatch{Package: "@babel/core", Version: "7.24.0", Tool: NPMPatchToolPatchPackage}},
+		{"patches/react-native+0.73.0+001+fix-build.patch", NPMPatch{Package: "react-native", Version: "0.73.0", Tool: NPMPatchToolPatchPackage}},
+		{"patches/jest++jest-runtime+29.7.0+dev.patch", NPMPatch{Package: "jest-runtime", Version: "29.7.0", Tool: NPMPatchToolPatchPackage}},
+		{"patches/express@4.18.2.patch", NPMPatch{Package: "express", Version: "4.18.2", Tool: NPMPatchToolPnpm}},
+		{"patches/@scope__pkg@1.0.0.patch", NPMPatch{Package: "@scope/pkg", Version: "1.0.0", Tool: NPMPatchToolPnpm}},
+		{".yarn/patches/lodash-npm-4.17.21-6382451519.patch", NPMPatch{Package: "lodash", Version: "4.17.21", Tool: NPMPatchToolYarn}},
+		{".yarn/patches/@types-node-npm-20.11.5-1a2b3c4d5e.patch", NPMPatch{Package: "@types/node", Version: "20.11.5", Tool: NPMPatchToolYarn}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			patch, ok := ParsePatchFileName(tt.file)
+			require.True(t, ok)
+			tt.expected.File = tt.file
+			assert.Equal(t, tt.expected, patch)
+		})
+	}
+
+	_, ok := ParsePatchFileName(".yarn/patches/notes.patch")
+	assert.False(t, ok)
+}
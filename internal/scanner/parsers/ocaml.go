@@ -0,0 +1,510 @@
+package parsers
+
+import (
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// OpamPackage represents an opam package definition (<name>.opam, opam)
+type OpamPackage struct {
+	Name     string
+	Version  string
+	Synopsis string
+	Depends  []OCamlDependency
+	Depopts  []OCamlDependency
+}
+
+// DuneProject represents a dune-project file
+type DuneProject struct {
+	Lang              string // Version of the dune language ("3.0")
+	Name              string
+	Version           string
+	GenerateOpamFiles bool
+	Packages          []OpamPackage
+}
+
+// OCamlDependency is a package requirement with its version constraint and filters
+type OCamlDependency struct {
+	Name        string
+	Constraint  string // ">=4.14 & <5.0", empty for any version
+	Scope       types.Scope
+	Alternative bool // One of a choice of packages ("a" | "b")
+}
+
+// ocamlFilterScopes maps opam filter variables (and dune :variables) to dependency scopes
+var ocamlFilterScopes = map[string]types.Scope{
+	"with-test":      types.ScopeTest,
+	"with-doc":       types.ScopeDev,
+	"with-dev-setup": types.ScopeDev,
+	"dev":            types.ScopeDev,
+	"build":          types.ScopeBuild,
+}
+
+// OCamlParser handles opam and dune-project files
+type OCamlParser struct{}
+
+// NewOCamlParser creates a new OCaml parser
+func NewOCamlParser() *OCamlParser {
+	return &OCamlParser{}
+}
+
+// ParseOpam parses an opam file: the name, version, synopsis, depends, and depopts fields
+func (p *OCamlParser) ParseOpam(content string) OpamPackage {
+	tokens := tokenizeOpam(content)
+	var pkg OpamPackage
+	for i := 0; i+1 < len(tokens); i++ {
+		if tokens[i].kind != opamIdent || tokens[i+1].text != ":" {
+			continue
+		}
+		field := tokens[i].text
+		i += 2
+		if i >= len(tokens) {
+			break
+		}
+		switch {
+		case tokens[i].kind == opamString:
+			switch field {
+			case "name":
+				pkg.Name = tokens[i].text
+			case "version":
+				pkg.Version = tokens[i].text
+			case "synopsis":
+				pkg.Synopsis = tokens[i].text
+			}
+		case tokens[i].text == "[":
+			end := matchingOpamToken(tokens, i)
+			switch field {
+			case "depends":
+				pkg.Depends = parseOpamDependencies(tokens[i+1 : end])
+			case "depopts":
+				pkg.Depopts = parseOpamDependencies(tokens[i+1 : end])
+			}
+			i = end
+		}
+	}
+	return pkg
+}
+
+// ParseDuneProject parses a dune-project file with its package stanzas
+func (p *OCamlParser) ParseDuneProject(content string) DuneProject {
+	var project DuneProject
+	for _, stanza := range parseSexps(content) {
+		switch stanza.head() {
+		case "lang":
+			if len(stanza.list) > 2 {
+				project.Lang = stanza.list[2].atom
+			}
+		case "name":
+			project.Name = stanza.arg()
+		case "version":
+			project.Version = stanza.arg()
+		case "generate_opam_files":
+			project.GenerateOpamFiles = stanza.arg() == "true" || len(stanza.list) == 1
+		case "package":
+			pkg := OpamPackage{Version: project.Version}
+			for _, field := range stanza.list[1:] {
+				switch field.head() {
+				case "name":
+					pkg.Name = field.arg()
+				case "version":
+					pkg.Version = field.arg()
+				case "synopsis":
+					pkg.Synopsis = field.arg()
+				case "depends":
+					pkg.Depends = duneDependencies(field.list[1:])
+				case "depopts":
+					pkg.Depopts = duneDependencies(field.list[1:])
+				}
+			}
+			project.Packages = append(project.Packages, pkg)
+		}
+	}
+	return project
+}
+
+// Dependencies converts the requirements of a package to dependencies. The version keeps the
+// constraint formula ("latest" without constraint); test, doc, and build filters set the scope,
+// and depopts are optional.
+func (p *OCamlParser) Dependencies(pkg OpamPackage, source string) []types.Dependency {
+	var dependencies []types.Dependency
+	add := func(dep OCamlDependency, optional bool) {
+		version := dep.Constraint
+		if version == "" {
+			version = "latest"
+		}
+		scope := dep.Scope
+		if optional {
+			scope = types.ScopeOptional
+		}
+		metadata := types.NewMetadata(source)
+		if dep.Alternative {
+			metadata["alternative"] = true
+		}
+		dependencies = append(dependencies, types.Dependency{
+			Type:     DependencyTypeOpam,
+			Name:     dep.Name,
+			Version:  version,
+			Scope:    scope,
+			Direct:   true,
+			Metadata: metadata,
+		})
+	}
+	for _, dep := range pkg.Depends {
+		add(dep, false)
+	}
+	for _, dep := range pkg.Depopts {
+		add(dep, true)
+	}
+	return dependencies
+}
+
+// --- opam syntax ---
+
+const (
+	opamIdent = iota
+	opamString
+	opamSymbol
+)
+
+type opamToken struct {
+	kind int
+	text string
+}
+
+// tokenizeOpam splits opam syntax into identifiers, strings, and symbols, dropping comments
+// (# line comments and (* block comments *))
+func tokenizeOpam(content string) []opamToken {
+	var tokens []opamToken
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case c == '#':
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(content[i:], "(*"):
+			end := strings.Index(content[i+2:], "*)")
+			if end < 0 {
+				return tokens
+			}
+			i += end + 4
+		case strings.HasPrefix(content[i:], `"""`):
+			end := strings.Index(content[i+3:], `"""`)
+			if end < 0 {
+				return tokens
+			}
+			tokens = append(tokens, opamToken{opamString, content[i+3 : i+3+end]})
+			i += end + 6
+		case c == '"':
+			var b strings.Builder
+			i++
+			for i < len(content) && content[i] != '"' {
+				if content[i] == '\\' && i+1 < len(content) {
+					i++
+				}
+				b.WriteByte(content[i])
+				i++
+			}
+			tokens = append(tokens, opamToken{opamString, b.String()})
+			i++
+		case strings.ContainsRune("<>!=", rune(c)):
+			if i+1 < len(content) && content[i+1] == '=' {
+				tokens = append(tokens, opamToken{opamSymbol, content[i : i+2]})
+				i += 2
+			} else {
+				tokens = append(tokens, opamToken{opamSymbol, string(c)})
+				i++
+			}
+		case strings.ContainsRune("[]{}():&|?", rune(c)):
+			tokens = append(tokens, opamToken{opamSymbol, string(c)})
+			i++
+		default:
+			start := i
+			for i < len(content) && !strings.ContainsRune(" \t\r\n\"[]{}():&|?<>!=#", rune(content[i])) {
+				i++
+			}
+			tokens = append(tokens, opamToken{opamIdent, content[start:i]})
+		}
+	}
+	return tokens
+}
+
+// matchingOpamToken returns the index of the bracket closing the one at start
+func matchingOpamToken(tokens []opamToken, start int) int {
+	depth := 0
+	for i := start; i < len(tokens); i++ {
+		switch tokens[i].text {
+		case "[", "{", "(":
+			if tokens[i].kind == opamSymbol {
+				depth++
+			}
+		case "]", "}", ")":
+			if tokens[i].kind == opamSymbol {
+				depth--
+				if depth == 0 {
+					return i
+				}
+			}
+		}
+	}
+	return len(tokens)
+}
+
+// parseOpamDependencies parses the items of a depends list: package names with an optional
+// {formula}, grouped alternatives ("a" | "b"), and conjunctions ("a" & "b")
+func parseOpamDependencies(tokens []opamToken) []OCamlDependency {
+	var dependencies []OCamlDependency
+	var parse func(tokens []opamToken, alternative bool)
+	parse = func(tokens []opamToken, alternative bool) {
+		for i := 0; i < len(tokens); i++ {
+			token := tokens[i]
+			switch {
+			case token.kind == opamString:
+				dep := OCamlDependency{Name: token.text, Scope: types.ScopeProd, Alternative: alternative}
+				if i+1 < len(tokens) && tokens[i+1].text == "{" {
+					end := matchingOpamToken(tokens, i+1)
+					dep.Constraint, dep.Scope = opamFormula(tokens[i+2 : min(end, len(tokens))])
+					i = end
+				}
+				dependencies = append(dependencies, dep)
+			case token.text == "(" && token.kind == opamSymbol:
+				end := matchingOpamToken(tokens, i)
+				group := tokens[i+1 : min(end, len(tokens))]
+				parse(group, alternative || containsOpamSymbol(group, "|"))
+				i = end
+			}
+		}
+	}
+	parse(tokens, false)
+	return dependencies
+}
+
+// opamFormula converts a filtered version formula ({>= "1.0" & < "2.0" & with-test}) to a
+// version constraint and a scope
+func opamFormula(tokens []opamToken) (string, types.Scope) {
+	scope := types.ScopeProd
+	var parts []string
+	var joiner string
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		switch {
+		case token.kind == opamIdent:
+			if s, ok := ocamlFilterScopes[token.text]; ok {
+				scope = s
+			}
+		case token.kind == opamSymbol && isOpamOperator(token.text) && i+1 < len(tokens) && tokens[i+1].kind == opamString:
+			if len(parts) > 0 {
+				parts = append(parts, joiner)
+			}
+			parts = append(parts, token.text+tokens[i+1].text)
+			joiner = "&"
+			i++
+		case token.text == "|":
+			joiner = "|"
+		case token.text == "&":
+			joiner = "&"
+		}
+	}
+	return strings.Join(parts, " "), scope
+}
+
+// isOpamOperator reports whether a symbol is a version comparison operator
+func isOpamOperator(symbol string) bool {
+	switch symbol {
+	case "=", "!=", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+// containsOpamSymbol reports whether a token list contains a symbol at its top level
+func containsOpamSymbol(tokens []opamToken, symbol string) bool {
+	depth := 0
+	for _, token := range tokens {
+		if token.kind != opamSymbol {
+			continue
+		}
+		switch token.text {
+		case "(", "{", "[":
+			depth++
+		case ")", "}", "]":
+			depth--
+		case symbol:
+			if depth == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// --- dune s-expressions ---
+
+// sexp is an atom or a list of s-expressions
+type sexp struct {
+	atom   string
+	list   []sexp
+	isList bool
+}
+
+// head returns the first atom of a list
+func (s sexp) head() string {
+	if !s.isList || len(s.list) == 0 {
+		return ""
+	}
+	return s.list[0].atom
+}
+
+// arg returns the second element of a list as an atom
+func (s sexp) arg() string {
+	if !s.isList || len(s.list) < 2 {
+		return ""
+	}
+	return s.list[1].atom
+}
+
+// parseSexps parses the top-level s-expressions of a dune file, skipping ; line comments,
+// #| block comments |#, and #; datum comments
+func parseSexps(content string) []sexp {
+	pos := 0
+	var parse func() (sexp, bool)
+	skip := func() {
+		for pos < len(content) {
+			switch {
+			case strings.ContainsRune(" \t\r\n", rune(content[pos])):
+				pos++
+			case content[pos] == ';':
+				for pos < len(content) && content[pos] != '\n' {
+					pos++
+				}
+			case strings.HasPrefix(content[pos:], "#|"):
+				end := strings.Index(content[pos:], "|#")
+				if end < 0 {
+					pos = len(content)
+				} else {
+					pos += end + 2
+				}
+			case strings.HasPrefix(content[pos:], "#;"):
+				pos += 2
+				parse()
+			default:
+				return
+			}
+		}
+	}
+	parse = func() (sexp, bool) {
+		skip()
+		if pos >= len(content) || content[pos] == ')' {
+			return sexp{}, false
+		}
+		switch content[pos] {
+		case '(':
+			pos++
+			node := sexp{isList: true}
+			for {
+				child, ok := parse()
+				if !ok {
+					break
+				}
+				node.list = append(node.list, child)
+			}
+			if pos < len(content) {
+				pos++ // closing parenthesis
+			}
+			return node, true
+		case '"':
+			var b strings.Builder
+			pos++
+			for pos < len(content) && content[pos] != '"' {
+				if content[pos] == '\\' && pos+1 < len(content) {
+					pos++
+				}
+				b.WriteByte(content[pos])
+				pos++
+			}
+			pos++
+			return sexp{atom: b.String()}, true
+		default:
+			start := pos
+			for pos < len(content) && !strings.ContainsRune(" \t\r\n();\"", rune(content[pos])) {
+				pos++
+			}
+			return sexp{atom: content[start:pos]}, true
+		}
+	}
+
+	var nodes []sexp
+	for pos < len(content) {
+		node, ok := parse()
+		if !ok {
+			if pos < len(content) {
+				pos++ // stray closing parenthesis
+			}
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// duneDependencies converts the entries of a (depends ...) field: package atoms, or lists of
+// a package and its constraint ((lwt (>= 5.6)), (alcotest :with-test)), and (or ...) choices
+func duneDependencies(entries []sexp) []OCamlDependency {
+	var dependencies []OCamlDependency
+	for _, entry := range entries {
+		switch {
+		case !entry.isList:
+			dependencies = append(dependencies, OCamlDependency{Name: entry.atom, Scope: types.ScopeProd})
+		case entry.head() == "or":
+			for _, choice := range duneDependencies(entry.list[1:]) {
+				choice.Alternative = true
+				dependencies = append(dependencies, choice)
+			}
+		case len(entry.list) > 0 && !entry.list[0].isList:
+			dep := OCamlDependency{Name: entry.list[0].atom, Scope: types.ScopeProd}
+			var parts []string
+			for _, constraint := range entry.list[1:] {
+				if part := duneConstraint(constraint, &dep.Scope); part != "" {
+					parts = append(parts, part)
+				}
+			}
+			dep.Constraint = strings.Join(parts, " & ")
+			dependencies = append(dependencies, dep)
+		}
+	}
+	return dependencies
+}
+
+// duneConstraint converts a dune constraint ((>= 1.0), (and ...), (or ...), :with-test) to opam
+// formula syntax; filter variables set the scope
+func duneConstraint(node sexp, scope *types.Scope) string {
+	if !node.isList {
+		if s, ok := ocamlFilterScopes[strings.TrimPrefix(node.atom, ":")]; ok {
+			*scope = s
+		}
+		return ""
+	}
+	switch op := node.head(); op {
+	case "and", "or":
+		var parts []string
+		for _, child := range node.list[1:] {
+			if part := duneConstraint(child, scope); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		joiner := " & "
+		if op == "or" {
+			joiner = " | "
+		}
+		return strings.Join(parts, joiner)
+	default:
+		version := node.arg()
+		if !isOpamOperator(op) || version == "" || strings.HasPrefix(version, ":") {
+			return ""
+		}
+		return op + version
+	}
+}
@@ -0,0 +1,126 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOCamlParser_ParseOpam(t *testing.T) {
+	parser := NewOCamlParser()
+
+	content := `opam-version: "2.0"
+version: "1.4.0"
+synopsis: "HTTP server"
+description: """
+A small HTTP server (with "quotes").
+"""
+# Dependencies
+depends: [
+  "ocaml" {>= "4.14" & < "5.3"}
+  "dune" {>= "3.0"}
+  "lwt" {>= "5.6.0"}
+  "cohttp-lwt-unix" {= "5.3.0"}
+  "alcotest" {with-test}
+  "odoc" {with-doc & >= "2.0"}
+  ("tls-lwt" | "ssl") (* TLS backend *)
+  "conf-pkg-config" {build}
+]
+depopts: [ "graphql" ]
+build: [
+  ["dune" "build" "-p" name "-j" jobs]
+]
+url {
+  src: "https://example.com/server-1.4.0.tbz"
+}
+`
+
+	pkg := parser.ParseOpam(content)
+	assert.Equal(t, "1.4.0", pkg.Version)
+	assert.Equal(t, "HTTP server", pkg.Synopsis)
+
+	deps := make(map[string]types.Dependency)
+	for _, dep := range parser.Dependencies(pkg, MetadataSourceOpam) {
+		deps[dep.Name] = dep
+	}
+	require.Len(t, deps, 10)
+
+	assert.Equal(t, types.Dependency{
+		Type: DependencyTypeOpam, Name: "ocaml", Version: ">=4.14 & <5.3", Scope: types.ScopeProd, Direct: true,
+		Metadata: map[string]interface{}{"source": ".opam"},
+	}, deps["ocaml"])
+	assert.Equal(t, "=5.3.0", deps["cohttp-lwt-unix"].Version)
+	assert.Equal(t, "latest", deps["alcotest"].Version)
+	assert.Equal(t, types.ScopeTest, deps["alcotest"].Scope)
+	assert.Equal(t, ">=2.0", deps["odoc"].Version)
+	assert.Equal(t, types.ScopeDev, deps["odoc"].Scope)
+	assert.Equal(t, true, deps["tls-lwt"].Metadata["alternative"])
+	assert.Equal(t, true, deps["ssl"].Metadata["alternative"])
+	assert.Equal(t, types.ScopeBuild, deps["conf-pkg-config"].Scope)
+	assert.Equal(t, types.ScopeOptional, deps["graphql"].Scope)
+	assert.NotContains(t, deps, "-p", "Should skip other list fields")
+}
+
+func TestOCamlParser_ParseDuneProject(t *testing.T) {
+	parser := NewOCamlParser()
+
+	content := `(lang dune 3.11)
+(name server)
+(version 1.4.0)
+(generate_opam_files true)
+
+; Packages
+(package
+ (name server)
+ (synopsis "HTTP server")
+ (depends
+  (ocaml (>= 4.14))
+  dune
+  (lwt (and (>= 5.6) (< 6.0)))
+  (alcotest :with-test)
+  (odoc (and :with-doc (>= 2.0)))
+  (server-core (= :version))
+  (or tls-lwt ssl)))
+
+#; (package (name ignored))
+
+(package
+ (name server-core)
+ (version 1.5.0)
+ (depopts graphql))
+`
+
+	project := parser.ParseDuneProject(content)
+	assert.Equal(t, "3.11", project.Lang)
+	assert.Equal(t, "server", project.Name)
+	assert.True(t, project.GenerateOpamFiles)
+	require.Len(t, project.Packages, 2)
+
+	server := project.Packages[0]
+	assert.Equal(t, "server", server.Name)
+	assert.Equal(t, "1.4.0", server.Version, "Should inherit the project version")
+	assert.Equal(t, "HTTP server", server.Synopsis)
+
+	deps := parser.Dependencies(server, MetadataSourceDuneProject)
+	require.Len(t, deps, 8)
+	assert.Equal(t, types.Dependency{
+		Type: DependencyTypeOpam, Name: "ocaml", Version: ">=4.14", Scope: types.ScopeProd, Direct: true,
+		Metadata: map[string]interface{}{"source": "dune-project"},
+	}, deps[0])
+	assert.Equal(t, "latest", deps[1].Version)
+	assert.Equal(t, ">=5.6 & <6.0", deps[2].Version)
+	assert.Equal(t, types.ScopeTest, deps[3].Scope)
+	assert.Equal(t, ">=2.0", deps[4].Version)
+	assert.Equal(t, types.ScopeDev, deps[4].Scope)
+	assert.Equal(t, "latest", deps[5].Version, "Should skip variable constraints")
+	assert.Equal(t, "ssl", deps[7].Name)
+	assert.Equal(t, true, deps[7].Metadata["alternative"])
+
+	core := project.Packages[1]
+	assert.Equal(t, "1.5.0", core.Version)
+	coreDeps := parser.Dependencies(core, MetadataSourceDuneProject)
+	require.Len(t, coreDeps, 1)
+	assert.Equal(t, types.ScopeOptional, coreDeps[0].Scope)
+}
@@ -0,0 +1,60 @@
+package parsers
+
+import (
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// MetadataOrigin is the metadata key of the origin chain of dependencies whose record combines
+// several sources (manifest range, lock file version, registry license): a list of entries
+// with the "source" and "field" of each value, and the "value" taken from it
+const MetadataOrigin = "origin"
+
+// Fields of origin chain entries, next to the metadata keys (license_declared, license_concluded)
+const (
+	OriginFieldRange   = "range"   // Version requirement declared by the manifest
+	OriginFieldScope   = "scope"   // Scope derived from the manifest section
+	OriginFieldVersion = "version" // Version of the dependency record
+)
+
+// OriginSourceRegistry is the source of values looked up in package registries
+const OriginSourceRegistry = "registry"
+
+// AppendOrigin appends an entry to the origin chain of a dependency metadata map. The chain
+// is copied, so metadata maps copied from one another do not share it. An empty value is
+// omitted.
+func AppendOrigin(metadata map[string]interface{}, source, field, value string) {
+	entry := map[string]interface{}{"source": source, "field": field}
+	if value != "" {
+		entry["value"] = value
+	}
+	chain, _ := metadata[MetadataOrigin].([]interface{})
+	metadata[MetadataOrigin] = append(chain[:len(chain):len(chain)], entry)
+}
+
+// RecordLockOrigin records the origin chain of a direct dependency read from a lock file and
+// its manifest: the range and scope declared by the manifest, and the version and declared
+// license locked by the lock file
+func RecordLockOrigin(dep *types.Dependency, manifest, lockFile, declaredRange string) {
+	if dep.Metadata == nil {
+		dep.Metadata = make(map[string]interface{})
+	}
+	if declaredRange != "" {
+		AppendOrigin(dep.Metadata, manifest, OriginFieldRange, declaredRange)
+	}
+	if dep.Scope != "" {
+		AppendOrigin(dep.Metadata, manifest, OriginFieldScope, string(dep.Scope))
+	}
+	AppendOrigin(dep.Metadata, lockFile, OriginFieldVersion, dep.Version)
+	if license, _ := dep.Metadata[MetadataLicenseDeclared].(string); license != "" {
+		AppendOrigin(dep.Metadata, lockFile, MetadataLicenseDeclared, license)
+	}
+}
+
+// DependencySource returns the file a dependency was read from: the "source" metadata, else
+// the source file
+func DependencySource(dep types.Dependency) string {
+	if source, _ := dep.Metadata["source"].(string); source != "" {
+		return source
+	}
+	return dep.SourceFile
+}
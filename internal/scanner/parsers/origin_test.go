@@ -0,0 +1,64 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendOrigin(t *testing.T) {
+	metadata := map[string]interface{}{}
+	AppendOrigin(metadata, MetadataSourcePackageLock, OriginFieldVersion, "1.0.0")
+	copied := map[string]interface{}{MetadataOrigin: metadata[MetadataOrigin]}
+	AppendOrigin(copied, OriginSourceRegistry, MetadataInstallHooks, "")
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"source": MetadataSourcePackageLock, "field": OriginFieldVersion, "value": "1.0.0"},
+	}, metadata[MetadataOrigin], "copied metadata does not share the chain")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"source": MetadataSourcePackageLock, "field": OriginFieldVersion, "value": "1.0.0"},
+		map[string]interface{}{"source": OriginSourceRegistry, "field": MetadataInstallHooks},
+	}, copied[MetadataOrigin])
+}
+
+func TestParsePackageLock_Origin(t *testing.T) {
+	lock := `{
+		"lockfileVersion": 3,
+		"packages": {
+			"": {"name": "app"},
+			"node_modules/lodash": {"version": "4.17.21", "license": "MIT"},
+			"node_modules/chalk": {"version": "5.3.0"},
+			"node_modules/left-pad": {"version": "1.3.0"}
+		}
+	}`
+	packageJSON := `{"dependencies": {"lodash": "^4.17.0"}, "devDependencies": {"chalk": "~5.3.0"}}`
+	pkg, err := NewNodeJSParser().ParsePackageJSON([]byte(packageJSON))
+	require.NoError(t, err)
+
+	deps := ParsePackageLockWithOptions([]byte(lock), pkg, []byte(packageJSON), ParsePackageLockOptions{IncludeTransitive: true})
+	byName := make(map[string]types.Dependency)
+	for _, dep := range deps {
+		byName[dep.Name] = dep
+	}
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"source": MetadataSourcePackageJSON, "field": OriginFieldRange, "value": "^4.17.0"},
+		map[string]interface{}{"source": MetadataSourcePackageJSON, "field": OriginFieldScope, "value": string(types.ScopeProd)},
+		map[string]interface{}{"source": MetadataSourcePackageLock, "field": OriginFieldVersion, "value": "4.17.21"},
+		map[string]interface{}{"source": MetadataSourcePackageLock, "field": MetadataLicenseDeclared, "value": "MIT"},
+	}, byName["lodash"].Metadata[MetadataOrigin])
+	assert.Len(t, byName["chalk"].Metadata[MetadataOrigin], 3)
+	assert.NotContains(t, byName["left-pad"].Metadata, MetadataOrigin, "transitive packages come from the lock file only")
+}
+
+func TestParseCargoLock_Origin(t *testing.T) {
+	lock := "[[package]]\nname = \"serde\"\nversion = \"1.0.193\"\n"
+	deps := ParseCargoLock([]byte(lock), "[dependencies]\nserde = \"1.0\"\n")
+	require.Len(t, deps, 1)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"source": MetadataSourceCargoToml, "field": OriginFieldScope, "value": string(types.ScopeProd)},
+		map[string]interface{}{"source": MetadataSourceCargoLock, "field": OriginFieldVersion, "value": "1.0.193"},
+	}, deps[0].Metadata[MetadataOrigin])
+}
@@ -0,0 +1,132 @@
+package parsers
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// OS package managers whose databases map shared libraries to packages
+const (
+	PackageManagerDpkg = "dpkg"
+	PackageManagerApk  = "apk"
+)
+
+var sharedLibraryFileRegex = regexp.MustCompile(`\.so(\.\d+)*$|\.dylib$`)
+
+// OSPackageIndex maps the shared libraries installed by an OS package database (of a container
+// root file system) to their packages
+type OSPackageIndex struct {
+	Manager   string
+	Versions  map[string]string // Package -> installed version
+	Libraries map[string]string // Library file name -> package
+}
+
+// NewOSPackageIndex creates an empty index for a package manager
+func NewOSPackageIndex(manager string) *OSPackageIndex {
+	return &OSPackageIndex{Manager: manager, Versions: make(map[string]string), Libraries: make(map[string]string)}
+}
+
+// AddFiles records the shared libraries among the files installed by a package
+func (i *OSPackageIndex) AddFiles(pkg string, files []string) {
+	for _, file := range files {
+		name := path.Base(strings.TrimSpace(file))
+		if sharedLibraryFileRegex.MatchString(name) {
+			if _, exists := i.Libraries[name]; !exists {
+				i.Libraries[name] = pkg
+			}
+		}
+	}
+}
+
+// Lookup returns the package and installed version of a shared library
+func (i *OSPackageIndex) Lookup(library string) (string, string, bool) {
+	pkg, ok := i.Libraries[library]
+	if !ok {
+		return "", "", false
+	}
+	return pkg, i.Versions[pkg], true
+}
+
+// OSPackageParser handles OS package databases (dpkg, apk)
+type OSPackageParser struct{}
+
+// NewOSPackageParser creates a new OS package database parser
+func NewOSPackageParser() *OSPackageParser {
+	return &OSPackageParser{}
+}
+
+// ParseDpkgStatus returns the versions of the installed packages of a dpkg status file
+// (var/lib/dpkg/status)
+func (p *OSPackageParser) ParseDpkgStatus(content string) map[string]string {
+	versions := make(map[string]string)
+	for _, stanza := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n\n") {
+		var name, version, status string
+		for _, line := range strings.Split(stanza, "\n") {
+			key, value, ok := strings.Cut(line, ":")
+			if !ok || strings.HasPrefix(line, " ") {
+				continue
+			}
+			switch key {
+			case "Package":
+				name = strings.TrimSpace(value)
+			case "Version":
+				version = strings.TrimSpace(value)
+			case "Status":
+				status = strings.TrimSpace(value)
+			}
+		}
+		if name != "" && strings.HasSuffix(status, " installed") {
+			versions[name] = version
+		}
+	}
+	return versions
+}
+
+// DpkgListPackage returns the package of a dpkg file list (var/lib/dpkg/info/<package>.list,
+// or <package>:<arch>.list for multi-arch packages)
+func (p *OSPackageParser) DpkgListPackage(fileName string) string {
+	name := strings.TrimSuffix(fileName, ".list")
+	if pkg, _, ok := strings.Cut(name, ":"); ok {
+		return pkg
+	}
+	return name
+}
+
+// ParseApkInstalled parses an apk database (lib/apk/db/installed): the installed packages
+// with their versions and the shared libraries among their files
+func (p *OSPackageParser) ParseApkInstalled(content string) *OSPackageIndex {
+	index := NewOSPackageIndex(PackageManagerApk)
+	var pkg, folder string
+	var files []string
+	flush := func() {
+		if pkg != "" {
+			index.AddFiles(pkg, files)
+		}
+		pkg, folder, files = "", "", nil
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if line == "" {
+			flush()
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "P":
+			pkg = value
+		case "V":
+			if pkg != "" {
+				index.Versions[pkg] = value
+			}
+		case "F":
+			folder = value
+		case "R":
+			files = append(files, folder+"/"+value)
+		}
+	}
+	flush()
+	return index
+}
@@ -0,0 +1,73 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOSPackageParser_ParseDpkgStatus(t *testing.T) {
+	content := `Package: libssl3
+Status: install ok installed
+Priority: optional
+Architecture: amd64
+Multi-Arch: same
+Version: 3.0.11-1~deb12u2
+Description: Secure Sockets Layer toolkit - shared libraries
+ This package is part of the OpenSSL project's implementation.
+
+Package: libfoo1
+Status: deinstall ok config-files
+Version: 1.0-1
+
+Package: libc6
+Status: install ok installed
+Version: 2.36-9+deb12u4
+`
+	assert.Equal(t, map[string]string{"libssl3": "3.0.11-1~deb12u2", "libc6": "2.36-9+deb12u4"}, NewOSPackageParser().ParseDpkgStatus(content))
+}
+
+func TestOSPackageParser_DpkgListPackage(t *testing.T) {
+	parser := NewOSPackageParser()
+	assert.Equal(t, "libssl3", parser.DpkgListPackage("libssl3:amd64.list"))
+	assert.Equal(t, "tzdata", parser.DpkgListPackage("tzdata.list"))
+}
+
+func TestOSPackageParser_ParseApkInstalled(t *testing.T) {
+	content := `C:Q1abc=
+P:libssl3
+V:3.1.4-r5
+A:x86_64
+F:lib
+R:libssl.so.3
+F:usr/lib
+R:libssl.so
+
+P:musl
+V:1.2.4-r2
+F:lib
+R:ld-musl-x86_64.so.1
+R:libc.musl-x86_64.so.1
+`
+	index := NewOSPackageParser().ParseApkInstalled(content)
+	assert.Equal(t, PackageManagerApk, index.Manager)
+
+	pkg, version, ok := index.Lookup("libssl.so.3")
+	assert.True(t, ok)
+	assert.Equal(t, "libssl3", pkg)
+	assert.Equal(t, "3.1.4-r5", version)
+
+	pkg, version, ok = index.Lookup("libc.musl-x86_64.so.1")
+	assert.True(t, ok)
+	assert.Equal(t, "musl", pkg)
+	assert.Equal(t, "1.2.4-r2", version)
+
+	_, _, ok = index.Lookup("libz.so.1")
+	assert.False(t, ok)
+}
+
+func TestOSPackageIndex_AddFiles(t *testing.T) {
+	index := NewOSPackageIndex(PackageManagerDpkg)
+	index.AddFiles("libc6", []string{"/.", "/lib/x86_64-linux-gnu", "/lib/x86_64-linux-gnu/libc.so.6", "/usr/share/doc/libc6/copyright"})
+	assert.Equal(t, map[string]string{"libc.so.6": "libc6"}, index.Libraries)
+}
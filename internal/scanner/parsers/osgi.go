@@ -0,0 +1,307 @@
+package parsers
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// OSGiManifest holds the bundle headers of an OSGi manifest (META-INF/MANIFEST.MF)
+type OSGiManifest struct {
+	SymbolicName   string   // Bundle-SymbolicName without directives
+	Version        string   // Bundle-Version
+	Name           string   // Bundle-Name
+	Vendor         string   // Bundle-Vendor
+	FragmentHost   string   // Fragment-Host bundle of fragments
+	ExecutionEnvs  []string // Bundle-RequiredExecutionEnvironment (JavaSE-17)
+	ExportPackages []string // Export-Package names
+	Headers        map[string]string
+}
+
+// EclipseTarget represents an Eclipse target platform definition (.target)
+type EclipseTarget struct {
+	XMLName   xml.Name                `xml:"target"`
+	Name      string                  `xml:"name,attr"`
+	Locations []EclipseTargetLocation `xml:"locations>location"`
+}
+
+// EclipseTargetLocation is a location of a target platform: p2 repositories with installable
+// units, Maven artifacts, or local directories and installations
+type EclipseTargetLocation struct {
+	Type         string                    `xml:"type,attr"`
+	Repositories []EclipseTargetRepository `xml:"repository"`
+	Units        []EclipseTargetUnit       `xml:"unit"`
+	Dependencies []EclipseTargetMavenDep   `xml:"dependencies>dependency"`
+	GroupID      string                    `xml:"groupId,attr"` // Maven location before m2e 1.16
+	ArtifactID   string                    `xml:"artifactId,attr"`
+	Version      string                    `xml:"version,attr"`
+}
+
+// EclipseTargetRepository is a p2 repository of an InstallableUnit location
+type EclipseTargetRepository struct {
+	Location string `xml:"location,attr"`
+}
+
+// EclipseTargetUnit is an installable unit (bundle or feature) of a p2 repository
+type EclipseTargetUnit struct {
+	ID      string `xml:"id,attr"`
+	Version string `xml:"version,attr"`
+}
+
+// EclipseTargetMavenDep is an artifact of a Maven location
+type EclipseTargetMavenDep struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+}
+
+// OSGiParser handles OSGi bundle manifests and Eclipse target platform definitions
+type OSGiParser struct{}
+
+// NewOSGiParser creates a new OSGi parser
+func NewOSGiParser() *OSGiParser {
+	return &OSGiParser{}
+}
+
+// ParseManifest parses the main section of a manifest; ok is false if it is not an OSGi bundle
+// (no Bundle-SymbolicName)
+func (p *OSGiParser) ParseManifest(content string) (OSGiManifest, bool) {
+	headers := parseManifestHeaders(content)
+	symbolicName := headers["Bundle-SymbolicName"]
+	if symbolicName == "" {
+		return OSGiManifest{}, false
+	}
+
+	manifest := OSGiManifest{
+		SymbolicName:  clauseNames(symbolicName)[0],
+		Version:       headers["Bundle-Version"],
+		Name:          headers["Bundle-Name"],
+		Vendor:        headers["Bundle-Vendor"],
+		ExecutionEnvs: splitManifestClauses(headers["Bundle-RequiredExecutionEnvironment"]),
+		Headers:       headers,
+	}
+	if host := headers["Fragment-Host"]; host != "" {
+		manifest.FragmentHost = clauseNames(host)[0]
+	}
+	for _, clause := range splitManifestClauses(headers["Export-Package"]) {
+		manifest.ExportPackages = append(manifest.ExportPackages, clauseNames(clause)...)
+	}
+	return manifest, true
+}
+
+// ManifestDependencies returns the bundle dependencies of a manifest: Require-Bundle and
+// Fragment-Host as bundles, Import-Package as packages (except packages of the bundle itself
+// and java.*). Versions are kept as declared ranges ("[3.0.0,4.0.0)"); optional resolution maps
+// to the optional scope.
+func (p *OSGiParser) ManifestDependencies(manifest OSGiManifest) []types.Dependency {
+	var dependencies []types.Dependency
+	seen := make(map[string]bool)
+
+	exported := make(map[string]bool)
+	for _, pkg := range manifest.ExportPackages {
+		exported[pkg] = true
+	}
+
+	add := func(header, versionAttr string, skip func(string) bool) {
+		for _, clause := range splitManifestClauses(manifest.Headers[header]) {
+			attributes := clauseAttributes(clause)
+			version := attributes[versionAttr]
+			if version == "" {
+				version = "latest"
+			}
+			scope := types.ScopeProd
+			if attributes["resolution:"] == "optional" {
+				scope = types.ScopeOptional
+			}
+			for _, name := range clauseNames(clause) {
+				if (skip != nil && skip(name)) || seen[header+":"+name] {
+					continue
+				}
+				seen[header+":"+name] = true
+				metadata := types.NewMetadata(MetadataSourceManifestMF)
+				metadata[MetadataOSGiHeader] = header
+				if attributes["visibility:"] == "reexport" {
+					metadata["reexport"] = true
+				}
+				dependencies = append(dependencies, types.Dependency{
+					Type:     DependencyTypeOSGi,
+					Name:     name,
+					Version:  version,
+					Scope:    scope,
+					Direct:   true,
+					Metadata: metadata,
+				})
+			}
+		}
+	}
+
+	add("Fragment-Host", "bundle-version", nil)
+	add("Require-Bundle", "bundle-version", nil)
+	add("Import-Package", "version", func(name string) bool {
+		return exported[name] || strings.HasPrefix(name, "java.")
+	})
+	return dependencies
+}
+
+// ParseTarget parses an Eclipse target platform definition (zero value if invalid)
+func (p *OSGiParser) ParseTarget(content string) EclipseTarget {
+	var target EclipseTarget
+	if err := xml.Unmarshal([]byte(content), &target); err != nil {
+		return EclipseTarget{}
+	}
+	return target
+}
+
+// TargetDependencies returns the contents of a target platform: installable units of p2
+// repositories as p2 dependencies with the repository in the metadata, and the artifacts of
+// Maven locations as Maven dependencies. Version 0.0.0 selects the latest unit.
+func (p *OSGiParser) TargetDependencies(target EclipseTarget) []types.Dependency {
+	var dependencies []types.Dependency
+	for _, location := range target.Locations {
+		switch location.Type {
+		case "InstallableUnit":
+			var repository string
+			if len(location.Repositories) > 0 {
+				repository = location.Repositories[0].Location
+			}
+			for _, unit := range location.Units {
+				if unit.ID == "" {
+					continue
+				}
+				version := unit.Version
+				if version == "" || version == "0.0.0" {
+					version = "latest"
+				}
+				metadata := types.NewMetadata(MetadataSourceTarget)
+				if repository != "" {
+					metadata["repository"] = repository
+				}
+				dependencies = append(dependencies, types.Dependency{
+					Type:     DependencyTypeP2,
+					Name:     unit.ID,
+					Version:  version,
+					Scope:    types.ScopeProd,
+					Direct:   true,
+					Metadata: metadata,
+				})
+			}
+		case "Maven":
+			mavenDeps := location.Dependencies
+			if location.GroupID != "" {
+				mavenDeps = append(mavenDeps, EclipseTargetMavenDep{GroupID: location.GroupID, ArtifactID: location.ArtifactID, Version: location.Version})
+			}
+			for _, dep := range mavenDeps {
+				if dep.GroupID == "" || dep.ArtifactID == "" {
+					continue
+				}
+				version := strings.TrimSpace(dep.Version)
+				if version == "" {
+					version = "latest"
+				}
+				dependencies = append(dependencies, types.Dependency{
+					Type:     DependencyTypeMaven,
+					Name:     strings.TrimSpace(dep.GroupID) + ":" + strings.TrimSpace(dep.ArtifactID),
+					Version:  version,
+					Scope:    types.ScopeProd,
+					Direct:   true,
+					Metadata: types.NewMetadata(MetadataSourceTarget),
+				})
+			}
+		}
+	}
+	return dependencies
+}
+
+// parseManifestHeaders reads the headers of the main section of a manifest, joining
+// continuation lines (starting with a space)
+func parseManifestHeaders(content string) map[string]string {
+	headers := make(map[string]string)
+	var name string
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(line, " ") {
+			if name != "" {
+				headers[name] += line[1:]
+			}
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			break // End of the main section
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			name = ""
+			continue
+		}
+		name = strings.TrimSpace(key)
+		headers[name] = strings.TrimSpace(value)
+	}
+	for key, value := range headers {
+		headers[key] = strings.TrimSpace(value)
+	}
+	return headers
+}
+
+// splitManifestClauses splits a header value at commas outside of quoted strings
+func splitManifestClauses(value string) []string {
+	var clauses []string
+	var current strings.Builder
+	inQuotes := false
+	for _, r := range value {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == ',' && !inQuotes:
+			if clause := strings.TrimSpace(current.String()); clause != "" {
+				clauses = append(clauses, clause)
+			}
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if clause := strings.TrimSpace(current.String()); clause != "" {
+		clauses = append(clauses, clause)
+	}
+	return clauses
+}
+
+// clauseNames returns the names of a clause: the parts before its attributes and directives
+// ("a;b;version=1.0" names a and b)
+func clauseNames(clause string) []string {
+	var names []string
+	for _, part := range strings.Split(clause, ";") {
+		part = strings.TrimSpace(part)
+		if strings.Contains(part, "=") {
+			break
+		}
+		if part != "" {
+			names = append(names, part)
+		}
+	}
+	if len(names) == 0 {
+		return []string{""}
+	}
+	return names
+}
+
+// clauseAttributes returns the attributes and directives of a clause without quotes; directives
+// keep their colon ("resolution:" for resolution:=optional)
+func clauseAttributes(clause string) map[string]string {
+	attributes := make(map[string]string)
+	inQuotes := false
+	parts := strings.FieldsFunc(clause, func(r rune) bool {
+		if r == '"' {
+			inQuotes = !inQuotes
+		}
+		return r == ';' && !inQuotes
+	})
+	for _, part := range parts {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		attributes[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+	return attributes
+}
@@ -0,0 +1,126 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOSGiParser_ParseManifest(t *testing.T) {
+	parser := NewOSGiParser()
+
+	content := "Manifest-Version: 1.0\r\n" +
+		"Bundle-ManifestVersion: 2\r\n" +
+		"Bundle-Name: Example UI\r\n" +
+		"Bundle-SymbolicName: com.example.ui;singleton:=true\r\n" +
+		"Bundle-Version: 1.2.0.qualifier\r\n" +
+		"Bundle-Vendor: Example\r\n" +
+		"Require-Bundle: org.eclipse.ui;bundle-version=\"[3.200.0,4.0.0)\",\r\n" +
+		" org.eclipse.core.runtime;bundle-version=\"3.26.0\";visibility:=reexport,\r\n" +
+		" org.eclipse.jdt.core;resolution:=optional\r\n" +
+		"Import-Package: com.example.ui.api,\r\n" +
+		" javax.inject;version=\"1.0.0\",\r\n" +
+		" org.osgi.framework;org.osgi.util.tracker;version=\"[1.9,2\r\n" +
+		" )\",\r\n" +
+		" java.util.function\r\n" +
+		"Export-Package: com.example.ui.api;version=\"1.2.0\"\r\n" +
+		"Bundle-RequiredExecutionEnvironment: JavaSE-17\r\n" +
+		"\r\n" +
+		"Name: com/example/Ignored.class\r\n" +
+		"SHA-256-Digest: abc\r\n"
+
+	manifest, ok := parser.ParseManifest(content)
+	require.True(t, ok)
+	assert.Equal(t, "com.example.ui", manifest.SymbolicName)
+	assert.Equal(t, "1.2.0.qualifier", manifest.Version)
+	assert.Equal(t, "Example UI", manifest.Name)
+	assert.Equal(t, []string{"JavaSE-17"}, manifest.ExecutionEnvs)
+	assert.Equal(t, []string{"com.example.ui.api"}, manifest.ExportPackages)
+	assert.NotContains(t, manifest.Headers, "Name", "Should only read the main section")
+
+	deps := make(map[string]types.Dependency)
+	for _, dep := range parser.ManifestDependencies(manifest) {
+		deps[dep.Name] = dep
+	}
+	assert.Len(t, deps, 6)
+
+	ui := deps["org.eclipse.ui"]
+	assert.Equal(t, DependencyTypeOSGi, ui.Type)
+	assert.Equal(t, "[3.200.0,4.0.0)", ui.Version)
+	assert.Equal(t, types.ScopeProd, ui.Scope)
+	assert.Equal(t, map[string]interface{}{"source": "MANIFEST.MF", "header": "Require-Bundle"}, ui.Metadata)
+
+	assert.Equal(t, true, deps["org.eclipse.core.runtime"].Metadata["reexport"])
+	assert.Equal(t, types.ScopeOptional, deps["org.eclipse.jdt.core"].Scope)
+	assert.Equal(t, "latest", deps["org.eclipse.jdt.core"].Version)
+
+	assert.Equal(t, "1.0.0", deps["javax.inject"].Version)
+	assert.Equal(t, "Import-Package", deps["javax.inject"].Metadata["header"])
+	assert.Equal(t, "[1.9,2)", deps["org.osgi.framework"].Version, "Should join continuation lines")
+	assert.Equal(t, "[1.9,2)", deps["org.osgi.util.tracker"].Version, "Should share attributes across the names of a clause")
+	assert.NotContains(t, deps, "com.example.ui.api", "Should skip imports of exported packages")
+	assert.NotContains(t, deps, "java.util.function")
+}
+
+func TestOSGiParser_ParseManifest_Fragment(t *testing.T) {
+	parser := NewOSGiParser()
+
+	manifest, ok := parser.ParseManifest("Bundle-SymbolicName: com.example.ui.tests\nFragment-Host: com.example.ui;bundle-version=\"1.2.0\"\n")
+	require.True(t, ok)
+	assert.Equal(t, "com.example.ui", manifest.FragmentHost)
+
+	deps := parser.ManifestDependencies(manifest)
+	require.Len(t, deps, 1)
+	assert.Equal(t, "com.example.ui", deps[0].Name)
+	assert.Equal(t, "Fragment-Host", deps[0].Metadata["header"])
+
+	_, ok = parser.ParseManifest("Manifest-Version: 1.0\nMain-Class: com.example.Main\n")
+	assert.False(t, ok, "Should reject plain jar manifests")
+}
+
+func TestOSGiParser_TargetDependencies(t *testing.T) {
+	parser := NewOSGiParser()
+
+	content := `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?pde version="3.8"?>
+<target name="Example Platform" sequenceNumber="3">
+	<locations>
+		<location includeAllPlatforms="false" includeMode="planner" type="InstallableUnit">
+			<repository location="https://download.eclipse.org/releases/2023-12"/>
+			<unit id="org.eclipse.e4.rcp.feature.group" version="4.30.0.v20231201-0110"/>
+			<unit id="org.eclipse.equinox.executable.feature.group" version="0.0.0"/>
+		</location>
+		<location includeDependencyDepth="infinite" missingManifest="generate" type="Maven">
+			<dependencies>
+				<dependency>
+					<groupId>com.google.guava</groupId>
+					<artifactId>guava</artifactId>
+					<version>33.0.0-jre</version>
+					<type>jar</type>
+				</dependency>
+			</dependencies>
+		</location>
+		<location groupId="org.slf4j" artifactId="slf4j-api" version="2.0.9" type="Maven"/>
+		<location path="${eclipse_home}" type="Profile"/>
+	</locations>
+</target>`
+
+	target := parser.ParseTarget(content)
+	assert.Equal(t, "Example Platform", target.Name)
+
+	deps := parser.TargetDependencies(target)
+	require.Len(t, deps, 4)
+	assert.Equal(t, types.Dependency{
+		Type: DependencyTypeP2, Name: "org.eclipse.e4.rcp.feature.group", Version: "4.30.0.v20231201-0110", Scope: types.ScopeProd, Direct: true,
+		Metadata: map[string]interface{}{"source": ".target", "repository": "https://download.eclipse.org/releases/2023-12"},
+	}, deps[0])
+	assert.Equal(t, "latest", deps[1].Version)
+	assert.Equal(t, DependencyTypeMaven, deps[2].Type)
+	assert.Equal(t, "com.google.guava:guava", deps[2].Name)
+	assert.Equal(t, "33.0.0-jre", deps[2].Version)
+	assert.Equal(t, "org.slf4j:slf4j-api", deps[3].Name)
+
+	assert.Empty(t, parser.ParseTarget(`<project/>`).XMLName.Local, "Should reject other XML files")
+}
@@ -37,7 +37,7 @@ func ParsePackageJSONEnhanced(content []byte) []types.Dependency {
 			Name:       name,
 			Version:    parseSemanticVersion(version),
 			SourceFile: "package.json",
-			Scope:      "prod",
+			Scope:      types.ScopeProd,
 		})
 	}
 
@@ -48,7 +48,7 @@ func ParsePackageJSONEnhanced(content []byte) []types.Dependency {
 			Name:       name,
 			Version:    parseSemanticVersion(version),
 			SourceFile: "package.json",
-			Scope:      "dev",
+			Scope:      types.ScopeDev,
 		})
 	}
 
@@ -59,22 +59,24 @@ func ParsePackageJSONEnhanced(content []byte) []types.Dependency {
 			Name:       name,
 			Version:    parseSemanticVersion(version),
 			SourceFile: "package.json",
-			Scope:      "peer",
+			Scope:      types.ScopePeer,
 		})
 	}
 
 	// Add optional dependencies with semantic version constraints
 	for name, version := range packageJSON.OptionalDependencies {
-		dependencies = append(dependencies, types.Dependency{
+		dep := types.Dependency{
 			Type:       DependencyTypeNpm,
 			Name:       name,
 			Version:    parseSemanticVersion(version),
 			SourceFile: "package.json",
-			Scope:      "optional",
-		})
+			Scope:      types.ScopeOptional,
+		}
+		AddActivation(&dep, ActivationOptional, nil, true)
+		dependencies = append(dependencies, dep)
 	}
 
-	return dependencies
+	return NormalizeDependencyVersions(dependencies)
 }
 
 // parseSemanticVersion parses and normalizes semantic version strings
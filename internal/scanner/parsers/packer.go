@@ -0,0 +1,249 @@
+package parsers
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// packerBuilderTechs maps Packer builder prefixes to the platform they build images for
+var packerBuilderTechs = map[string]string{
+	"amazon":        "aws",
+	"azure":         "azure",
+	"googlecompute": "gcp",
+	"docker":        "docker",
+	"digitalocean":  "digitalocean",
+	"hcloud":        "hetzner",
+	"oracle":        "oraclecloud",
+	"alicloud":      "alibabacloud",
+	"openstack":     "openstack",
+	"scaleway":      "scaleway",
+}
+
+// provisionerTechs maps Packer and Vagrant provisioners to configuration management tools
+var provisionerTechs = map[string]string{
+	"ansible":       "ansible",
+	"ansible-local": "ansible",
+	"ansible_local": "ansible",
+	"chef-solo":     "chef",
+	"chef-client":   "chef",
+	"chef_solo":     "chef",
+	"chef_zero":     "chef",
+	"chef_client":   "chef",
+}
+
+// PackerParser handles Packer template parsing (HCL2 and legacy JSON)
+type PackerParser struct{}
+
+// NewPackerParser creates a new Packer template parser
+func NewPackerParser() *PackerParser {
+	return &PackerParser{}
+}
+
+// PackerTemplate describes the builders, provisioners, and plugins of a Packer template
+type PackerTemplate struct {
+	File            string         `json:"file"`
+	RequiredVersion string         `json:"required_version,omitempty"`
+	Builders        []string       `json:"builders,omitempty"` // Source/builder types (amazon-ebs, docker)
+	Provisioners    []string       `json:"provisioners,omitempty"`
+	PostProcessors  []string       `json:"post_processors,omitempty"`
+	Plugins         []PackerPlugin `json:"plugins,omitempty"`
+}
+
+// PackerPlugin is a plugin declared in packer.required_plugins
+type PackerPlugin struct {
+	Name    string `json:"name"`
+	Source  string `json:"source,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// IsPackerTemplate reports whether a file name is a Packer HCL2 or JSON template
+func IsPackerTemplate(name string) bool {
+	return strings.HasSuffix(name, ".pkr.hcl") || strings.HasSuffix(name, ".pkr.json")
+}
+
+// ParseHCL parses a Packer HCL2 template (.pkr.hcl, or .pkr.json in HCL JSON syntax).
+// Returns nil if the file declares no sources, builds, or required plugins.
+func (p *PackerParser) ParseHCL(content, fileName string) *PackerTemplate {
+	parser := hclparse.NewParser()
+	var file *hcl.File
+	var diags hcl.Diagnostics
+	if strings.HasSuffix(fileName, ".json") {
+		file, diags = parser.ParseJSON([]byte(content), fileName)
+	} else {
+		file, diags = parser.ParseHCL([]byte(content), fileName)
+	}
+	if diags.HasErrors() || file.Body == nil {
+		return nil
+	}
+
+	body, _, _ := file.Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "packer"},
+			{Type: "source", LabelNames: []string{"type", "name"}},
+			{Type: "build"},
+		},
+	})
+
+	template := &PackerTemplate{}
+	var builders, provisioners, postProcessors []string
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case "packer":
+			template.parsePackerBlock(block.Body)
+		case "source":
+			builders = append(builders, block.Labels[0])
+		case "build":
+			buildProvisioners, buildPostProcessors := parseBuildBlock(block.Body)
+			provisioners = append(provisioners, buildProvisioners...)
+			postProcessors = append(postProcessors, buildPostProcessors...)
+		}
+	}
+	template.Builders = sortedUnique(builders)
+	template.Provisioners = sortedUnique(provisioners)
+	template.PostProcessors = sortedUnique(postProcessors)
+
+	if len(template.Builders) == 0 && len(template.Provisioners) == 0 && len(template.Plugins) == 0 {
+		return nil
+	}
+	return template
+}
+
+// parsePackerBlock reads required_version and required_plugins of the packer block
+func (t *PackerTemplate) parsePackerBlock(body hcl.Body) {
+	content, _, _ := body.PartialContent(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{{Name: "required_version"}},
+		Blocks:     []hcl.BlockHeaderSchema{{Type: "required_plugins"}},
+	})
+	if attr, ok := content.Attributes["required_version"]; ok {
+		t.RequiredVersion = hclString(attr.Expr)
+	}
+	for _, block := range content.Blocks {
+		attrs, _ := block.Body.JustAttributes()
+		for name, attr := range attrs {
+			plugin := PackerPlugin{Name: name}
+			if value, diags := attr.Expr.Value(nil); !diags.HasErrors() && value.Type().IsObjectType() {
+				plugin.Source = ctyObjectString(value, "source")
+				plugin.Version = ctyObjectString(value, "version")
+			}
+			t.Plugins = append(t.Plugins, plugin)
+		}
+	}
+	sort.Slice(t.Plugins, func(i, j int) bool { return t.Plugins[i].Name < t.Plugins[j].Name })
+}
+
+// parseBuildBlock returns the provisioner and post-processor types of a build block
+func parseBuildBlock(body hcl.Body) ([]string, []string) {
+	content, _, _ := body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "provisioner", LabelNames: []string{"type"}},
+			{Type: "post-processor", LabelNames: []string{"type"}},
+			{Type: "post-processors"},
+		},
+	})
+
+	var provisioners, postProcessors []string
+	for _, block := range content.Blocks {
+		switch block.Type {
+		case "provisioner":
+			provisioners = append(provisioners, block.Labels[0])
+		case "post-processor":
+			postProcessors = append(postProcessors, block.Labels[0])
+		case "post-processors":
+			_, nested := parseBuildBlock(block.Body)
+			postProcessors = append(postProcessors, nested...)
+		}
+	}
+	return provisioners, postProcessors
+}
+
+// hclString evaluates a literal string expression; non-literal expressions yield ""
+func hclString(expr hcl.Expression) string {
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() || value.IsNull() || !value.Type().Equals(cty.String) {
+		return ""
+	}
+	return value.AsString()
+}
+
+// ctyObjectString returns a string attribute of an object value, or "" if missing
+func ctyObjectString(value cty.Value, name string) string {
+	if !value.Type().HasAttribute(name) {
+		return ""
+	}
+	attr := value.GetAttr(name)
+	if attr.IsNull() || !attr.IsKnown() || !attr.Type().Equals(cty.String) {
+		return ""
+	}
+	return attr.AsString()
+}
+
+// legacyPackerTemplate represents the legacy JSON template format
+type legacyPackerTemplate struct {
+	MinPackerVersion string            `json:"min_packer_version"`
+	Builders         []packerTypeEntry `json:"builders"`
+	Provisioners     []packerTypeEntry `json:"provisioners"`
+	PostProcessors   []interface{}     `json:"post-processors"`
+}
+
+type packerTypeEntry struct {
+	Type string `json:"type"`
+}
+
+// ParseLegacyJSON parses a legacy JSON Packer template (builders/provisioners arrays).
+// Returns nil if the content has no builders.
+func (p *PackerParser) ParseLegacyJSON(content string) *PackerTemplate {
+	var raw legacyPackerTemplate
+	if err := json.Unmarshal([]byte(content), &raw); err != nil || len(raw.Builders) == 0 {
+		return nil
+	}
+
+	template := &PackerTemplate{RequiredVersion: raw.MinPackerVersion}
+	var builders, provisioners, postProcessors []string
+	for _, builder := range raw.Builders {
+		builders = append(builders, builder.Type)
+	}
+	for _, provisioner := range raw.Provisioners {
+		provisioners = append(provisioners, provisioner.Type)
+	}
+	// Post-processors are a name, an object, or a sequence of names/objects
+	var collect func(value interface{})
+	collect = func(value interface{}) {
+		switch v := value.(type) {
+		case string:
+			postProcessors = append(postProcessors, v)
+		case map[string]interface{}:
+			if t, ok := v["type"].(string); ok {
+				postProcessors = append(postProcessors, t)
+			}
+		case []interface{}:
+			for _, item := range v {
+				collect(item)
+			}
+		}
+	}
+	collect(raw.PostProcessors)
+
+	template.Builders = sortedUnique(builders)
+	template.Provisioners = sortedUnique(provisioners)
+	template.PostProcessors = sortedUnique(postProcessors)
+	return template
+}
+
+// Techs returns the platforms and configuration tools referenced by the template's
+// builders and provisioners
+func (t *PackerTemplate) Techs() []string {
+	var techs []string
+	for _, builder := range t.Builders {
+		prefix, _, _ := strings.Cut(builder, "-")
+		techs = append(techs, packerBuilderTechs[prefix])
+	}
+	for _, provisioner := range t.Provisioners {
+		techs = append(techs, provisionerTechs[provisioner])
+	}
+	return sortedUnique(techs)
+}
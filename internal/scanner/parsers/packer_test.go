@@ -0,0 +1,132 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPackerTemplate(t *testing.T) {
+	assert.True(t, IsPackerTemplate("ubuntu.pkr.hcl"))
+	assert.True(t, IsPackerTemplate("image.pkr.json"))
+	assert.False(t, IsPackerTemplate("main.tf"))
+	assert.False(t, IsPackerTemplate("variables.hcl"))
+}
+
+func TestPackerParseHCL(t *testing.T) {
+	content := `packer {
+  required_version = ">= 1.9.0"
+  required_plugins {
+    amazon = {
+      version = ">= 1.2.8"
+      source  = "github.com/hashicorp/amazon"
+    }
+    ansible = {
+      version = "~> 1"
+      source  = "github.com/hashicorp/ansible"
+    }
+  }
+}
+
+variable "region" {
+  type    = string
+  default = "eu-west-1"
+}
+
+source "amazon-ebs" "ubuntu" {
+  ami_name      = "app-${local.timestamp}"
+  instance_type = "t3.micro"
+  region        = var.region
+}
+
+source "docker" "ubuntu" {
+  image  = "ubuntu:jammy"
+  commit = true
+}
+
+build {
+  sources = ["source.amazon-ebs.ubuntu", "source.docker.ubuntu"]
+
+  provisioner "shell" {
+    inline = ["sudo apt-get update"]
+  }
+
+  provisioner "ansible" {
+    playbook_file = "./playbook.yml"
+  }
+
+  post-processor "manifest" {}
+
+  post-processors {
+    post-processor "docker-tag" {
+      repository = "acme/app"
+    }
+  }
+}
+`
+	template := NewPackerParser().ParseHCL(content, "ubuntu.pkr.hcl")
+	require.NotNil(t, template)
+
+	assert.Equal(t, ">= 1.9.0", template.RequiredVersion)
+	assert.Equal(t, []string{"amazon-ebs", "docker"}, template.Builders)
+	assert.Equal(t, []string{"ansible", "shell"}, template.Provisioners)
+	assert.Equal(t, []string{"docker-tag", "manifest"}, template.PostProcessors)
+	assert.Equal(t, []PackerPlugin{
+		{Name: "amazon", Source: "github.com/hashicorp/amazon", Version: ">= 1.2.8"},
+		{Name: "ansible", Source: "github.com/hashicorp/ansible", Version: "~> 1"},
+	}, template.Plugins)
+	assert.Equal(t, []string{"ansible", "aws", "docker"}, template.Techs())
+}
+
+func TestPackerParseHCL_JSONSyntax(t *testing.T) {
+	content := `{
+  "source": {
+    "googlecompute": {
+      "base": {"project_id": "acme", "zone": "europe-west1-b"}
+    }
+  },
+  "build": {
+    "sources": ["source.googlecompute.base"],
+    "provisioner": {
+      "shell": {"inline": ["echo hi"]}
+    }
+  }
+}`
+	template := NewPackerParser().ParseHCL(content, "base.pkr.json")
+	require.NotNil(t, template)
+	assert.Equal(t, []string{"googlecompute"}, template.Builders)
+	assert.Equal(t, []string{"shell"}, template.Provisioners)
+	assert.Equal(t, []string{"gcp"}, template.Techs())
+}
+
+func TestPackerParseHCL_VariablesOnly(t *testing.T) {
+	content := `variable "region" {
+  type = string
+}
+`
+	assert.Nil(t, NewPackerParser().ParseHCL(content, "variables.pkr.hcl"))
+	assert.Nil(t, NewPackerParser().ParseHCL("source {", "broken.pkr.hcl"))
+}
+
+func TestPackerParseLegacyJSON(t *testing.T) {
+	content := `{
+  "min_packer_version": "1.5.0",
+  "builders": [
+    {"type": "virtualbox-iso", "iso_url": "http://example.com/ubuntu.iso"},
+    {"type": "azure-arm"}
+  ],
+  "provisioners": [{"type": "chef-solo"}],
+  "post-processors": ["compress", [{"type": "vagrant"}, {"type": "vagrant-cloud"}]]
+}`
+	template := NewPackerParser().ParseLegacyJSON(content)
+	require.NotNil(t, template)
+
+	assert.Equal(t, "1.5.0", template.RequiredVersion)
+	assert.Equal(t, []string{"azure-arm", "virtualbox-iso"}, template.Builders)
+	assert.Equal(t, []string{"chef-solo"}, template.Provisioners)
+	assert.Equal(t, []string{"compress", "vagrant", "vagrant-cloud"}, template.PostProcessors)
+	assert.Equal(t, []string{"azure", "chef"}, template.Techs())
+
+	assert.Nil(t, NewPackerParser().ParseLegacyJSON(`{"name": "not-packer"}`))
+}
@@ -69,5 +69,5 @@ func (p *PHPParser) ParseComposerJSON(content string) (string, string, []types.D
 		}
 	}
 
-	return projectName, license, dependencies
+	return projectName, license, NormalizeDependencyVersions(dependencies)
 }
@@ -0,0 +1,211 @@
+package parsers
+
+import (
+	"bufio"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Compile PHP framework regexes once at package level for performance
+var (
+	phpClassConstantRegex = regexp.MustCompile(`([A-Za-z_][A-Za-z0-9_\\]*)::class`)
+	wpVersionRegex        = regexp.MustCompile(`\$wp_version\s*=\s*['"]([^'"]+)['"]`)
+	wpHeaderLineRegex     = regexp.MustCompile(`^[\s/*#@]*([A-Za-z][A-Za-z ]*?)\s*:\s*(.+?)\s*(?:\*/)?$`)
+)
+
+// wpHeaderMaxBytes is the number of bytes WordPress itself reads to find file headers
+const wpHeaderMaxBytes = 8192
+
+// WordPress header names identifying plugins and themes
+const (
+	WordPressPluginHeader = "Plugin Name"
+	WordPressThemeHeader  = "Theme Name"
+)
+
+// ComposerLockPackage is an installed package from composer.lock
+type ComposerLockPackage struct {
+	Name    string
+	Version string
+	Type    string // Composer package type (e.g., library, wordpress-plugin, symfony-bundle)
+	Dev     bool
+	Laravel bool     // Declares extra.laravel (auto-discovered Laravel package)
+	License string   // SPDX expression; multiple licenses are joined with " OR " (Composer semantics)
+	Authors []string // Author names
+}
+
+// PHPPackageInfo is a package name and version reported in framework inventories
+type PHPPackageInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// LaravelInfo describes a Laravel application
+type LaravelInfo struct {
+	File     string           `json:"file"`
+	Version  string           `json:"version,omitempty"`
+	Packages []PHPPackageInfo `json:"packages,omitempty"` // Auto-discovered Laravel packages
+}
+
+// SymfonyInfo describes a Symfony application
+type SymfonyInfo struct {
+	File    string   `json:"file"`
+	Version string   `json:"version,omitempty"`
+	Bundles []string `json:"bundles,omitempty"` // Registered bundles (config/bundles.php)
+}
+
+// WordPressInfo describes a WordPress installation
+type WordPressInfo struct {
+	File    string               `json:"file"`
+	Version string               `json:"version,omitempty"`
+	Plugins []WordPressExtension `json:"plugins,omitempty"`
+	Themes  []WordPressExtension `json:"themes,omitempty"`
+}
+
+// WordPressExtension is a WordPress plugin or theme (from its file header or composer.lock)
+type WordPressExtension struct {
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Version     string `json:"version,omitempty"`
+	RequiresWP  string `json:"requires_wp,omitempty"`
+	RequiresPHP string `json:"requires_php,omitempty"`
+	Source      string `json:"source,omitempty"` // "header" (file header) or "composer" (composer.lock)
+}
+
+// composerLock represents the subset of composer.lock we use
+type composerLock struct {
+	Packages    []composerLockEntry `json:"packages"`
+	PackagesDev []composerLockEntry `json:"packages-dev"`
+}
+
+type composerLockEntry struct {
+	Name    string   `json:"name"`
+	Version string   `json:"version"`
+	Type    string   `json:"type"`
+	License []string `json:"license"`
+	Authors []struct {
+		Name string `json:"name"`
+	} `json:"authors"`
+	Extra struct {
+		Laravel json.RawMessage `json:"laravel"`
+	} `json:"extra"`
+}
+
+// ParseComposerLock parses composer.lock and returns the installed packages
+func (p *PHPParser) ParseComposerLock(content string) ([]ComposerLockPackage, error) {
+	var lock composerLock
+	if err := json.Unmarshal([]byte(content), &lock); err != nil {
+		return nil, err
+	}
+
+	var packages []ComposerLockPackage
+	add := func(entries []composerLockEntry, dev bool) {
+		for _, entry := range entries {
+			if entry.Name == "" {
+				continue
+			}
+			pkg := ComposerLockPackage{
+				Name:    entry.Name,
+				Version: strings.TrimPrefix(entry.Version, "v"),
+				Type:    entry.Type,
+				Dev:     dev,
+				Laravel: len(entry.Extra.Laravel) > 0 && string(entry.Extra.Laravel) != "null",
+				License: strings.Join(entry.License, " OR "),
+			}
+			for _, author := range entry.Authors {
+				if author.Name != "" {
+					pkg.Authors = append(pkg.Authors, author.Name)
+				}
+			}
+			packages = append(packages, pkg)
+		}
+	}
+	add(lock.Packages, false)
+	add(lock.PackagesDev, true)
+	return packages, nil
+}
+
+// ParseSymfonyLock parses symfony.lock (Symfony Flex recipes) and returns package versions
+func (p *PHPParser) ParseSymfonyLock(content string) (map[string]string, error) {
+	var lock map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(content), &lock); err != nil {
+		return nil, err
+	}
+
+	versions := make(map[string]string, len(lock))
+	for name, raw := range lock {
+		var entry struct {
+			Version string `json:"version"`
+		}
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			continue
+		}
+		versions[name] = strings.TrimPrefix(entry.Version, "v")
+	}
+	return versions, nil
+}
+
+// ParsePHPClassList extracts short class names from Foo\Bar::class references,
+// e.g. the bundle list of config/bundles.php. Order is preserved, duplicates removed.
+func (p *PHPParser) ParsePHPClassList(content string) []string {
+	var classes []string
+	for _, match := range phpClassConstantRegex.FindAllStringSubmatch(content, -1) {
+		class := match[1]
+		if idx := strings.LastIndex(class, "\\"); idx >= 0 {
+			class = class[idx+1:]
+		}
+		if class != "" && !containsString(classes, class) {
+			classes = append(classes, class)
+		}
+	}
+	return classes
+}
+
+// ParseWordPressVersion extracts $wp_version from wp-includes/version.php
+func (p *PHPParser) ParseWordPressVersion(content string) string {
+	if match := wpVersionRegex.FindStringSubmatch(content); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// ParseWordPressHeader parses a plugin or theme file header comment.
+// nameHeader is WordPressPluginHeader or WordPressThemeHeader; returns nil if the header is missing.
+// Like WordPress, only the first 8 KB of the file are considered.
+func (p *PHPParser) ParseWordPressHeader(content, nameHeader string) *WordPressExtension {
+	if len(content) > wpHeaderMaxBytes {
+		content = content[:wpHeaderMaxBytes]
+	}
+
+	headers := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		match := wpHeaderLineRegex.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+		key := strings.ToLower(match[1])
+		if _, exists := headers[key]; !exists {
+			headers[key] = match[2]
+		}
+	}
+
+	name := headers[strings.ToLower(nameHeader)]
+	if name == "" {
+		return nil
+	}
+	return &WordPressExtension{
+		Name:        name,
+		Version:     headers["version"],
+		RequiresWP:  headers["requires at least"],
+		RequiresPHP: headers["requires php"],
+	}
+}
+
+// SortWordPressExtensions sorts extensions by slug for deterministic output
+func SortWordPressExtensions(extensions []WordPressExtension) {
+	sort.Slice(extensions, func(i, j int) bool {
+		return extensions[i].Slug < extensions[j].Slug
+	})
+}
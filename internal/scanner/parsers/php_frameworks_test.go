@@ -0,0 +1,114 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseComposerLock(t *testing.T) {
+	parser := NewPHPParser()
+	content := `{
+  "packages": [
+    {"name": "laravel/framework", "version": "v11.9.2", "type": "library", "license": ["MIT"], "authors": [{"name": "Taylor Otwell", "email": "taylor@laravel.com"}]},
+    {"name": "wpackagist-plugin/akismet", "version": "5.3.2", "type": "wordpress-plugin"},
+    {"name": "spatie/laravel-backup", "version": "8.6.0", "extra": {"laravel": {"providers": []}}}
+  ],
+  "packages-dev": [
+    {"name": "phpunit/phpunit", "version": "10.5.20", "license": ["BSD-3-Clause", "MIT"]}
+  ]
+}`
+	packages, err := parser.ParseComposerLock(content)
+	require.NoError(t, err)
+	require.Len(t, packages, 4)
+
+	assert.Equal(t, ComposerLockPackage{Name: "laravel/framework", Version: "11.9.2", Type: "library", License: "MIT", Authors: []string{"Taylor Otwell"}}, packages[0])
+	assert.Equal(t, "wordpress-plugin", packages[1].Type)
+	assert.True(t, packages[2].Laravel)
+	assert.True(t, packages[3].Dev)
+	assert.Equal(t, "BSD-3-Clause OR MIT", packages[3].License, "multiple Composer licenses are alternatives")
+
+	_, err = parser.ParseComposerLock("not json")
+	assert.Error(t, err)
+}
+
+func TestParseSymfonyLock(t *testing.T) {
+	parser := NewPHPParser()
+	versions, err := parser.ParseSymfonyLock(`{"symfony/console": {"version": "v6.4"}, "invalid": "entry"}`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"symfony/console": "6.4"}, versions)
+}
+
+func TestParsePHPClassList(t *testing.T) {
+	parser := NewPHPParser()
+	content := `<?php
+return [
+    App\Providers\AppServiceProvider::class,
+    \Symfony\Bundle\TwigBundle\TwigBundle::class => ['all' => true],
+    App\Providers\AppServiceProvider::class,
+];`
+	assert.Equal(t, []string{"AppServiceProvider", "TwigBundle"}, parser.ParsePHPClassList(content))
+	assert.Empty(t, parser.ParsePHPClassList("<?php return [];"))
+}
+
+func TestParseWordPressVersion(t *testing.T) {
+	parser := NewPHPParser()
+	assert.Equal(t, "6.5.3", parser.ParseWordPressVersion("<?php\n/** The WordPress version string. */\n$wp_version = '6.5.3';\n"))
+	assert.Equal(t, "", parser.ParseWordPressVersion("<?php\n"))
+}
+
+func TestParseWordPressHeader(t *testing.T) {
+	parser := NewPHPParser()
+
+	tests := []struct {
+		name     string
+		content  string
+		header   string
+		expected *WordPressExtension
+	}{
+		{
+			name: "docblock plugin header",
+			content: `<?php
+/**
+ * Plugin Name:       My Plugin
+ * Plugin URI:        https://example.com/my-plugin
+ * Version:           1.2.0
+ * Requires at least: 6.0
+ * Requires PHP:      8.0
+ */`,
+			header:   WordPressPluginHeader,
+			expected: &WordPressExtension{Name: "My Plugin", Version: "1.2.0", RequiresWP: "6.0", RequiresPHP: "8.0"},
+		},
+		{
+			name:     "single-line comment header",
+			content:  "<?php\n/* Plugin Name: Tiny */\n// Version: 0.1\n",
+			header:   WordPressPluginHeader,
+			expected: &WordPressExtension{Name: "Tiny", Version: "0.1"},
+		},
+		{
+			name:     "theme stylesheet header",
+			content:  "/*\nTheme Name: Twenty Twenty-Four\nVersion: 1.1\n*/\nbody { color: red; }",
+			header:   WordPressThemeHeader,
+			expected: &WordPressExtension{Name: "Twenty Twenty-Four", Version: "1.1"},
+		},
+		{
+			name:     "missing name header",
+			content:  "<?php\n// Version: 1.0\n",
+			header:   WordPressPluginHeader,
+			expected: nil,
+		},
+		{
+			name:     "plugin header is not a theme header",
+			content:  "<?php\n/* Plugin Name: Tiny */\n",
+			header:   WordPressThemeHeader,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parser.ParseWordPressHeader(tt.content, tt.header))
+		})
+	}
+}
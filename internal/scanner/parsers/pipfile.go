@@ -0,0 +1,152 @@
+package parsers
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// pipfileMarkerKeys are the PEP 508 marker variables a Pipfile requirement may set as keys
+// (sys_platform = "== 'win32'")
+var pipfileMarkerKeys = []string{
+	"os_name", "sys_platform", "platform_machine", "platform_python_implementation", "platform_release",
+	"platform_system", "platform_version", "python_version", "python_full_version", "implementation_name",
+	"implementation_version",
+}
+
+// ParsePipfile parses the [packages] (prod scope) and [dev-packages] (dev scope) of a Pipfile.
+// Requirements are a version specifier ("*" for any version) or an inline table with version,
+// extras, markers (the markers key and marker variable keys), index, git and ref, or path and
+// editable; these are recorded in the metadata. Other package categories are skipped.
+func (p *PythonParser) ParsePipfile(content string) []types.Dependency {
+	var dependencies []types.Dependency
+	var scope types.Scope
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(stripTomlComment(line))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			switch strings.Trim(line, "[] ") {
+			case "packages":
+				scope = types.ScopeProd
+			case "dev-packages":
+				scope = types.ScopeDev
+			default:
+				scope = ""
+			}
+			continue
+		}
+		if scope == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		if dep, ok := p.pipfileDependency(tomlKey(key), strings.TrimSpace(value), scope); ok {
+			dependencies = append(dependencies, dep)
+		}
+	}
+	sort.SliceStable(dependencies, func(i, j int) bool {
+		if dependencies[i].Scope != dependencies[j].Scope {
+			return dependencies[i].Scope == types.ScopeProd
+		}
+		return dependencies[i].Name < dependencies[j].Name
+	})
+	return NormalizeDependencyVersions(dependencies)
+}
+
+// pipfileDependency returns the dependency of a Pipfile requirement
+func (p *PythonParser) pipfileDependency(name, value string, scope types.Scope) (types.Dependency, bool) {
+	name = p.canonPackageName(name)
+	if name == "" {
+		return types.Dependency{}, false
+	}
+	dep := types.Dependency{
+		Type:       DependencyTypePython,
+		Name:       name,
+		Scope:      scope,
+		Direct:     true,
+		SourceFile: MetadataSourcePipfile,
+		Metadata:   types.NewMetadata(MetadataSourcePipfile),
+	}
+
+	fields := map[string]string{"version": value}
+	if strings.HasPrefix(value, "{") {
+		fields = make(map[string]string)
+		for key, fieldValue := range tomlInlineTable(value) {
+			fields[tomlKey(key)] = fieldValue
+		}
+	}
+	version := tomlString(fields["version"])
+	if version == "*" {
+		version = ""
+	}
+	dep.Version = p.resolveVersion(version)
+
+	var markers []string
+	if marker := tomlString(fields["markers"]); marker != "" {
+		markers = append(markers, marker)
+	}
+	for _, key := range pipfileMarkerKeys {
+		if condition := tomlString(fields[key]); condition != "" {
+			markers = append(markers, key+" "+condition)
+		}
+	}
+	setPipfileSource(&dep, pipfileRequirement{
+		Markers:  strings.Join(markers, " and "),
+		Extras:   tomlStrings(fields["extras"]),
+		Index:    tomlString(fields["index"]),
+		Git:      tomlString(fields["git"]),
+		Ref:      tomlString(fields["ref"]),
+		Path:     tomlString(fields["path"]),
+		Editable: tomlString(fields["editable"]) == "true",
+	})
+	return dep, true
+}
+
+// pipfileRequirement holds the requirement fields shared by Pipfile and Pipfile.lock entries
+type pipfileRequirement struct {
+	Version  string   `json:"version"`
+	Hashes   []string `json:"hashes"`
+	Markers  string   `json:"markers"`
+	Extras   []string `json:"extras"`
+	Index    string   `json:"index"`
+	Git      string   `json:"git"`
+	Ref      string   `json:"ref"`
+	Path     string   `json:"path"`
+	Editable bool     `json:"editable"`
+}
+
+// setPipfileSource records the fields of a requirement in the metadata of a dependency; the
+// markers also become its activation
+func setPipfileSource(dep *types.Dependency, req pipfileRequirement) {
+	metadata := dependencyMetadata(dep)
+	if len(req.Hashes) > 0 {
+		metadata[MetadataPipfileHashes] = req.Hashes
+	}
+	if req.Markers != "" {
+		metadata[MetadataPipfileMarkers] = req.Markers
+		AddPythonMarkerActivation(dep, req.Markers)
+	}
+	if len(req.Extras) > 0 {
+		metadata[MetadataPipfileExtras] = req.Extras
+	}
+	if req.Index != "" {
+		metadata[MetadataPipfileIndex] = req.Index
+	}
+	if req.Git != "" {
+		metadata[MetadataPipfileGit] = req.Git
+	}
+	if req.Ref != "" {
+		metadata[MetadataPipfileRef] = req.Ref
+	}
+	if req.Path != "" {
+		metadata[MetadataPipfilePath] = req.Path
+	}
+	if req.Editable {
+		metadata[MetadataPipfileEditable] = true
+	}
+}
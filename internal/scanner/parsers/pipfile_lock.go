@@ -0,0 +1,93 @@
+package parsers
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// ParsePipfileLockOptions contains configuration options for ParsePipfileLock
+type ParsePipfileLockOptions struct {
+	IncludeTransitive bool // Include transitive dependencies (default: false for direct dependencies only)
+}
+
+// pipfileLock is the structure of Pipfile.lock: the packages of the default (prod) and develop
+// (dev) categories
+type pipfileLock struct {
+	Default map[string]pipfileRequirement `json:"default"`
+	Develop map[string]pipfileRequirement `json:"develop"`
+}
+
+// ParsePipfileLock returns the dependencies of a Pipfile with the versions pinned by
+// Pipfile.lock. Direct dependencies are the packages of the Pipfile, with its scope; packages
+// missing from the lock keep the specifier of the Pipfile. Transitive dependencies are the other
+// locked packages, with the prod scope for the default category and dev for develop-only
+// packages. The hashes, markers, extras, index, and VCS or local source of the lock are
+// recorded in the metadata. Returns nil if the lock cannot be parsed.
+func (p *PythonParser) ParsePipfileLock(lockContent []byte, pipfileContent string, options ParsePipfileLockOptions) []types.Dependency {
+	var lock pipfileLock
+	if err := json.Unmarshal(lockContent, &lock); err != nil {
+		return nil
+	}
+	locked := make(map[string]pipfileRequirement, len(lock.Default)+len(lock.Develop))
+	scopes := make(map[string]types.Scope, len(lock.Default)+len(lock.Develop))
+	for _, category := range []struct {
+		packages map[string]pipfileRequirement
+		scope    types.Scope
+	}{{lock.Develop, types.ScopeDev}, {lock.Default, types.ScopeProd}} {
+		for name, req := range category.packages {
+			name = p.canonPackageName(name)
+			locked[name] = req
+			scopes[name] = category.scope
+		}
+	}
+
+	var dependencies []types.Dependency
+	direct := make(map[string]bool)
+	for _, dep := range p.ParsePipfile(pipfileContent) {
+		direct[dep.Name] = true
+		req, ok := locked[dep.Name]
+		if !ok {
+			dependencies = append(dependencies, dep)
+			continue
+		}
+		lockedDep := p.pipfileLockDependency(dep.Name, req, dep.Scope, true)
+		RecordLockOrigin(&lockedDep, MetadataSourcePipfile, MetadataSourcePipfileLock, dep.Version)
+		dependencies = append(dependencies, lockedDep)
+	}
+
+	if options.IncludeTransitive {
+		names := make([]string, 0, len(locked))
+		for name := range locked {
+			if !direct[name] {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			dependencies = append(dependencies, p.pipfileLockDependency(name, locked[name], scopes[name], false))
+		}
+	}
+	return NormalizeDependencyVersions(dependencies)
+}
+
+// pipfileLockDependency returns the dependency of a package pinned by Pipfile.lock
+func (p *PythonParser) pipfileLockDependency(name string, req pipfileRequirement, scope types.Scope, direct bool) types.Dependency {
+	version := strings.TrimPrefix(strings.TrimPrefix(req.Version, "==="), "==")
+	if version == "" {
+		version = "latest"
+	}
+	dep := types.Dependency{
+		Type:       DependencyTypePython,
+		Name:       name,
+		Version:    version,
+		SourceFile: MetadataSourcePipfileLock,
+		Scope:      scope,
+		Direct:     direct,
+		Metadata:   types.NewMetadata(MetadataSourcePipfileLock),
+	}
+	setPipfileSource(&dep, req)
+	return dep
+}
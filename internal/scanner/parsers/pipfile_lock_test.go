@@ -0,0 +1,87 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPipfileLock = `{
+    "_meta": {"hash": {"sha256": "abc"}, "pipfile-spec": 6, "requires": {"python_version": "3.11"}},
+    "default": {
+        "requests": {"hashes": ["sha256:58cd", "sha256:942c"], "index": "pypi", "markers": "python_version >= '3.7'", "version": "==2.31.0"},
+        "urllib3": {"hashes": ["sha256:55a0"], "markers": "python_version >= '3.8'", "version": "==2.2.1"},
+        "django": {"extras": ["bcrypt"], "hashes": ["sha256:8e0f"], "index": "pypi", "version": "==4.2.11"},
+        "pywin32": {"hashes": ["sha256:06d3"], "markers": "sys_platform == 'win32'", "version": "==306"},
+        "flask-ext": {"git": "https://github.com/org/flask-ext.git", "ref": "5f3a2c1d9e8b7a6f5e4d3c2b1a0f9e8d7c6b5a4f"}
+    },
+    "develop": {
+        "pytest": {"hashes": ["sha256:1733"], "index": "pypi", "version": "==8.1.1"},
+        "pluggy": {"hashes": ["sha256:7db9"], "version": "==1.4.0"},
+        "urllib3": {"hashes": ["sha256:55a0"], "version": "==2.2.1"}
+    }
+}`
+
+const testPipfileForLock = `[packages]
+requests = "*"
+django = {version = ">=4.2", extras = ["bcrypt"]}
+pywin32 = {version = "*", sys_platform = "== 'win32'"}
+flask-ext = {git = "https://github.com/org/flask-ext.git", ref = "main"}
+numpy = "*"
+
+[dev-packages]
+pytest = "*"
+`
+
+func TestParsePipfileLock(t *testing.T) {
+	parser := NewPythonParser()
+	deps := parser.ParsePipfileLock([]byte(testPipfileLock), testPipfileForLock, ParsePipfileLockOptions{})
+	require.Len(t, deps, 6)
+
+	byName := make(map[string]types.Dependency)
+	for _, dep := range deps {
+		byName[dep.Name] = dep
+		assert.True(t, dep.Direct, dep.Name)
+	}
+
+	requests := byName["requests"]
+	assert.Equal(t, "2.31.0", requests.Version)
+	assert.Equal(t, types.ScopeProd, requests.Scope)
+	assert.Equal(t, MetadataSourcePipfileLock, requests.SourceFile)
+	assert.Equal(t, []string{"sha256:58cd", "sha256:942c"}, requests.Metadata[MetadataPipfileHashes])
+	assert.Equal(t, "python_version >= '3.7'", requests.Metadata[MetadataPipfileMarkers])
+	assert.Equal(t, "pypi", requests.Metadata[MetadataPipfileIndex])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"source": MetadataSourcePipfile, "field": "range", "value": "latest"},
+		map[string]interface{}{"source": MetadataSourcePipfile, "field": "scope", "value": string(types.ScopeProd)},
+		map[string]interface{}{"source": MetadataSourcePipfileLock, "field": "version", "value": "2.31.0"},
+	}, requests.Metadata[MetadataOrigin])
+
+	assert.Equal(t, []string{"bcrypt"}, byName["django"].Metadata[MetadataPipfileExtras])
+	assert.False(t, (&TargetEnvironment{OS: "linux"}).Active(byName["pywin32"]))
+	assert.Equal(t, "latest", byName["flask-ext"].Version)
+	assert.Equal(t, "5f3a2c1d9e8b7a6f5e4d3c2b1a0f9e8d7c6b5a4f", byName["flask-ext"].Metadata[MetadataPipfileRef], "locked commit")
+	assert.Equal(t, types.ScopeDev, byName["pytest"].Scope)
+	assert.Equal(t, "8.1.1", byName["pytest"].Version)
+
+	// Declared but not locked: keeps the Pipfile specifier
+	assert.Equal(t, MetadataSourcePipfile, byName["numpy"].SourceFile)
+	assert.Equal(t, "latest", byName["numpy"].Version)
+}
+
+func TestParsePipfileLock_Transitive(t *testing.T) {
+	deps := NewPythonParser().ParsePipfileLock([]byte(testPipfileLock), testPipfileForLock, ParsePipfileLockOptions{IncludeTransitive: true})
+	require.Len(t, deps, 8)
+
+	transitive := make(map[string]types.Scope)
+	for _, dep := range deps[6:] {
+		assert.False(t, dep.Direct)
+		transitive[dep.Name] = dep.Scope
+	}
+	assert.Equal(t, map[string]types.Scope{"pluggy": types.ScopeDev, "urllib3": types.ScopeProd}, transitive, "default category wins")
+	assert.Equal(t, "pluggy", deps[6].Name, "sorted by name")
+
+	assert.Nil(t, NewPythonParser().ParsePipfileLock([]byte("{invalid"), testPipfileForLock, ParsePipfileLockOptions{}))
+}
@@ -0,0 +1,63 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPipfile = `[[source]]
+url = "https://pypi.org/simple"
+verify_ssl = true
+name = "pypi"
+
+[packages]
+requests = "*"
+Django = {version = ">=4.2,<5", extras = ["bcrypt"]}
+pywin32 = {version = "==306", sys_platform = "== 'win32'"}
+"zope.interface" = "==6.1" # quoted key
+mylib = {path = "./libs/mylib", editable = true}
+flask-ext = {git = "https://github.com/org/flask-ext.git", ref = "v1.2.0", markers = "python_version >= '3.9'"}
+
+[dev-packages]
+pytest = ">=7.4"
+
+[docs]
+sphinx = "*"
+
+[requires]
+python_version = "3.11"
+`
+
+func TestParsePipfile(t *testing.T) {
+	deps := NewPythonParser().ParsePipfile(testPipfile)
+	require.Len(t, deps, 7)
+
+	byName := make(map[string]types.Dependency)
+	var names []string
+	for _, dep := range deps {
+		byName[dep.Name] = dep
+		names = append(names, dep.Name)
+		assert.Equal(t, MetadataSourcePipfile, dep.SourceFile)
+		assert.True(t, dep.Direct)
+	}
+	assert.Equal(t, []string{"django", "flask-ext", "mylib", "pywin32", "requests", "zope-interface", "pytest"}, names, "prod before dev, by name")
+
+	assert.Equal(t, "latest", byName["requests"].Version)
+	assert.Equal(t, types.ScopeProd, byName["requests"].Scope)
+	assert.Equal(t, "==6.1", byName["zope-interface"].Version, "specifier as in requirements.txt")
+	assert.Equal(t, []string{"bcrypt"}, byName["django"].Metadata[MetadataPipfileExtras])
+	assert.Equal(t, "sys_platform == 'win32'", byName["pywin32"].Metadata[MetadataPipfileMarkers])
+	assert.False(t, (&TargetEnvironment{OS: "linux"}).Active(byName["pywin32"]), "marker activation")
+	assert.Equal(t, "./libs/mylib", byName["mylib"].Metadata[MetadataPipfilePath])
+	assert.Equal(t, true, byName["mylib"].Metadata[MetadataPipfileEditable])
+	assert.Equal(t, "https://github.com/org/flask-ext.git", byName["flask-ext"].Metadata[MetadataPipfileGit])
+	assert.Equal(t, "v1.2.0", byName["flask-ext"].Metadata[MetadataPipfileRef])
+	assert.Equal(t, "python_version >= '3.9'", byName["flask-ext"].Metadata[MetadataPipfileMarkers])
+	assert.Equal(t, types.ScopeDev, byName["pytest"].Scope)
+	assert.NotContains(t, byName, "sphinx", "other package categories")
+
+	assert.Empty(t, NewPythonParser().ParsePipfile(""))
+}
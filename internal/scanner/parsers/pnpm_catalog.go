@@ -0,0 +1,108 @@
+package parsers
+
+import (
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+// PnpmCatalogDefault is the name of the default catalog (catalog: and catalog:default)
+const PnpmCatalogDefault = "default"
+
+// PnpmCatalogs holds the version of each package of the pnpm catalogs by catalog name
+type PnpmCatalogs map[string]map[string]string
+
+// ParsePnpmWorkspaceCatalogs parses the catalogs of a pnpm-workspace.yaml (pnpm 9.5+): the
+// default catalog (catalog:) and the named catalogs (catalogs:), mapping packages to ranges
+func ParsePnpmWorkspaceCatalogs(content []byte) PnpmCatalogs {
+	var workspace struct {
+		Catalog  map[string]string            `yaml:"catalog"`
+		Catalogs map[string]map[string]string `yaml:"catalogs"`
+	}
+	if err := yaml.Unmarshal(content, &workspace); err != nil {
+		return nil
+	}
+	catalogs := make(PnpmCatalogs)
+	for name, packages := range workspace.Catalogs {
+		catalogs[name] = packages
+	}
+	if len(workspace.Catalog) > 0 {
+		catalogs[PnpmCatalogDefault] = workspace.Catalog
+	}
+	return catalogs
+}
+
+// ParsePnpmLockCatalogs parses the catalogs section of a pnpm-lock.yaml (v9), mapping packages
+// to the versions their catalog ranges resolved to
+func ParsePnpmLockCatalogs(content []byte) PnpmCatalogs {
+	var lockfile struct {
+		Catalogs map[string]map[string]PnpmDependency `yaml:"catalogs"`
+	}
+	if err := yaml.Unmarshal(content, &lockfile); err != nil {
+		return nil
+	}
+	catalogs := make(PnpmCatalogs)
+	for name, packages := range lockfile.Catalogs {
+		catalogs[name] = make(map[string]string)
+		for pkg, entry := range packages {
+			if entry.Version != "" {
+				catalogs[name][pkg] = entry.Version
+			}
+		}
+	}
+	return catalogs
+}
+
+// Lookup returns the version a catalog holds for a package
+func (c PnpmCatalogs) Lookup(catalog, pkg string) (string, bool) {
+	version, ok := c[catalog][pkg]
+	return version, ok && version != ""
+}
+
+// PnpmCatalogName returns the catalog a catalog: protocol range refers to ("catalog:" and
+// "catalog:default" refer to the default catalog), or false for other ranges
+func PnpmCatalogName(version string) (string, bool) {
+	name, ok := strings.CutPrefix(strings.TrimSpace(version), "catalog:")
+	if !ok {
+		return "", false
+	}
+	if name = strings.TrimSpace(name); name == "" {
+		name = PnpmCatalogDefault
+	}
+	return name, true
+}
+
+// ResolvePnpmCatalogVersions replaces the catalog: ranges of package.json dependencies with the
+// versions of the catalogs: the version the catalog resolved to in pnpm-lock.yaml, else the
+// range of pnpm-workspace.yaml. Resolved dependencies record the catalog name and the origin of
+// the version.
+func ResolvePnpmCatalogVersions(dependencies []types.Dependency, workspace, locked PnpmCatalogs) {
+	for i := range dependencies {
+		dep := &dependencies[i]
+		catalog, ok := PnpmCatalogName(dep.Version)
+		if !ok {
+			continue
+		}
+		specifier, inWorkspace := workspace.Lookup(catalog, dep.Name)
+		version, inLock := locked.Lookup(catalog, dep.Name)
+		if !inWorkspace && !inLock {
+			continue
+		}
+
+		if dep.Metadata == nil {
+			dep.Metadata = types.NewMetadata(MetadataSourcePackageJSON)
+		}
+		dep.Metadata[MetadataPnpmCatalog] = catalog
+		AppendOrigin(dep.Metadata, MetadataSourcePackageJSON, OriginFieldRange, dep.Version)
+		if inWorkspace {
+			AppendOrigin(dep.Metadata, MetadataSourcePnpmWorkspace, OriginFieldRange, specifier)
+			dep.Version = specifier
+		}
+		if inLock {
+			AppendOrigin(dep.Metadata, MetadataSourcePnpmLock, OriginFieldVersion, version)
+			dep.Version = version
+		}
+		NormalizeDependencyVersions(dependencies[i : i+1])
+	}
+}
@@ -0,0 +1,103 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPnpmWorkspace = `packages:
+  - apps/*
+catalog:
+  react: ^18.2.0
+  "@types/node": ^20.11.0
+catalogs:
+  legacy:
+    react: ^17.0.2
+`
+
+const testPnpmLockCatalogs = `lockfileVersion: '9.0'
+catalogs:
+  default:
+    react:
+      specifier: ^18.2.0
+      version: 18.3.1
+  legacy:
+    react:
+      specifier: ^17.0.2
+      version: 17.0.2
+importers:
+  .: {}
+`
+
+func TestParsePnpmWorkspaceCatalogs(t *testing.T) {
+	catalogs := ParsePnpmWorkspaceCatalogs([]byte(testPnpmWorkspace))
+	assert.Equal(t, PnpmCatalogs{
+		"default": {"react": "^18.2.0", "@types/node": "^20.11.0"},
+		"legacy":  {"react": "^17.0.2"},
+	}, catalogs)
+
+	assert.Empty(t, ParsePnpmWorkspaceCatalogs([]byte("packages:\n  - apps/*\n")))
+	assert.Nil(t, ParsePnpmWorkspaceCatalogs([]byte(":\tinvalid")))
+}
+
+func TestParsePnpmLockCatalogs(t *testing.T) {
+	assert.Equal(t, PnpmCatalogs{
+		"default": {"react": "18.3.1"},
+		"legacy":  {"react": "17.0.2"},
+	}, ParsePnpmLockCatalogs([]byte(testPnpmLockCatalogs)))
+	assert.Empty(t, ParsePnpmLockCatalogs([]byte("lockfileVersion: '6.0'\n")))
+}
+
+func TestPnpmCatalogName(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected string
+		ok       bool
+	}{
+		{"catalog:", "default", true},
+		{"catalog:default", "default", true},
+		{"catalog:legacy", "legacy", true},
+		{"^18.2.0", "", false},
+		{"workspace:*", "", false},
+	}
+	for _, tt := range tests {
+		name, ok := PnpmCatalogName(tt.version)
+		assert.Equal(t, tt.ok, ok, tt.version)
+		assert.Equal(t, tt.expected, name, tt.version)
+	}
+}
+
+func TestResolvePnpmCatalogVersions(t *testing.T) {
+	newDeps := func() []types.Dependency {
+		return []types.Dependency{
+			{Type: DependencyTypeNpm, Name: "react", Version: "catalog:", Metadata: types.NewMetadata(MetadataSourcePackageJSON)},
+			{Type: DependencyTypeNpm, Name: "@types/node", Version: "catalog:default", Metadata: types.NewMetadata(MetadataSourcePackageJSON)},
+			{Type: DependencyTypeNpm, Name: "react-dom", Version: "catalog:legacy", Metadata: types.NewMetadata(MetadataSourcePackageJSON)},
+			{Type: DependencyTypeNpm, Name: "lodash", Version: "^4.17.21", Metadata: types.NewMetadata(MetadataSourcePackageJSON)},
+		}
+	}
+	workspace := ParsePnpmWorkspaceCatalogs([]byte(testPnpmWorkspace))
+
+	deps := newDeps()
+	ResolvePnpmCatalogVersions(deps, workspace, ParsePnpmLockCatalogs([]byte(testPnpmLockCatalogs)))
+	assert.Equal(t, "18.3.1", deps[0].Version, "version the lock file resolved")
+	assert.Equal(t, "default", deps[0].Metadata[MetadataPnpmCatalog])
+	origin, ok := deps[0].Metadata[MetadataOrigin].([]interface{})
+	require.True(t, ok)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"source": "package.json", "field": "range", "value": "catalog:"},
+		map[string]interface{}{"source": "pnpm-workspace.yaml", "field": "range", "value": "^18.2.0"},
+		map[string]interface{}{"source": "pnpm-lock.yaml", "field": "version", "value": "18.3.1"},
+	}, origin)
+	assert.Equal(t, "^20.11.0", deps[1].Version, "range of the workspace when not locked")
+	assert.Equal(t, "catalog:legacy", deps[2].Version, "package missing from the catalog")
+	assert.Equal(t, "^4.17.21", deps[3].Version)
+	assert.NotContains(t, deps[3].Metadata, MetadataPnpmCatalog)
+
+	deps = newDeps()
+	ResolvePnpmCatalogVersions(deps, workspace, nil)
+	assert.Equal(t, "^18.2.0", deps[0].Version, "without lock file")
+}
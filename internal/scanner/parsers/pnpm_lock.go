@@ -27,11 +27,12 @@ type PnpmImporter struct {
 // PnpmPackage represents a package in pnpm-lock.yaml (v9+ format)
 // Enhanced with deps.dev patterns for workspace and resolution support
 type PnpmPackage struct {
-	Resolution PnpmResolution `yaml:"resolution"`
-	Name       string         `yaml:"name,omitempty"`
-	Version    string         `yaml:"version"`
-	Dev        bool           `yaml:"dev,omitempty"`
-	Optional   bool           `yaml:"optional,omitempty"`
+	Resolution    PnpmResolution `yaml:"resolution"`
+	Name          string         `yaml:"name,omitempty"`
+	Version       string         `yaml:"version"`
+	Dev           bool           `yaml:"dev,omitempty"`
+	Optional      bool           `yaml:"optional,omitempty"`
+	RequiresBuild bool           `yaml:"requiresBuild,omitempty"` // Install scripts or binding.gyp (v6 to v8)
 }
 
 // PnpmResolution represents package resolution information
@@ -71,13 +72,13 @@ func ParsePnpmLockWithOptions(content []byte, options NPMLockFileOptions) []type
 		if exists {
 			// Add direct dependencies to filter
 			for name := range rootImporter.Dependencies {
-				filter.AddDirectDependency(name, "prod")
+				filter.AddDirectDependency(name, types.ScopeProd)
 			}
 			for name := range rootImporter.DevDependencies {
-				filter.AddDirectDependency(name, "dev")
+				filter.AddDirectDependency(name, types.ScopeDev)
 			}
 			for name := range rootImporter.OptionalDependencies {
-				filter.AddDirectDependency(name, "optional")
+				filter.AddDirectDependency(name, types.ScopeOptional)
 			}
 		}
 
@@ -87,9 +88,10 @@ func ParsePnpmLockWithOptions(content []byte, options NPMLockFileOptions) []type
 			if name == "" {
 				continue
 			}
+			_, keyVersion := splitPnpmPackageKey(path)
 
 			// Parse version with semantic version preservation
-			version := parsePnpmVersion(pkg.Version, pkg.Resolution)
+			version := parsePnpmVersion(firstNonEmpty(pkg.Version, keyVersion), pkg.Resolution)
 
 			// Use common filtering to create dependency
 			filter.CreateAndAppendDependency("npm", name, version, "pnpm-lock.yaml", &dependencies)
@@ -103,13 +105,13 @@ func ParsePnpmLockWithOptions(content []byte, options NPMLockFileOptions) []type
 
 		// Add direct dependencies to filter
 		for name := range rootImporter.Dependencies {
-			filter.AddDirectDependency(name, "prod")
+			filter.AddDirectDependency(name, types.ScopeProd)
 		}
 		for name := range rootImporter.DevDependencies {
-			filter.AddDirectDependency(name, "dev")
+			filter.AddDirectDependency(name, types.ScopeDev)
 		}
 		for name := range rootImporter.OptionalDependencies {
-			filter.AddDirectDependency(name, "optional")
+			filter.AddDirectDependency(name, types.ScopeOptional)
 		}
 
 		// Parse production dependencies
@@ -131,7 +133,25 @@ func ParsePnpmLockWithOptions(content []byte, options NPMLockFileOptions) []type
 		}
 	}
 
-	return dependencies
+	markPnpmNativePackages(lockfile.Packages, dependencies)
+	return NormalizeDependencyVersions(dependencies)
+}
+
+// markPnpmNativePackages flags the dependencies whose packages require a build (install scripts
+// or binding.gyp)
+func markPnpmNativePackages(packages map[string]PnpmPackage, dependencies []types.Dependency) {
+	requiresBuild := make(map[string]bool)
+	for path, pkg := range packages {
+		if pkg.RequiresBuild {
+			requiresBuild[extractPackageNameFromPnpmPath(path)] = true
+		}
+	}
+	for i := range dependencies {
+		if requiresBuild[dependencies[i].Name] {
+			MarkNative(&dependencies[i], NativeEvidenceRequiresBuild)
+			dependencies[i].Metadata[MetadataInstallScript] = true
+		}
+	}
 }
 
 // extractPackageNameFromPnpmPath extracts package name from pnpm-lock.yaml path
@@ -150,16 +170,28 @@ func extractPackageNameFromPnpmPath(path string) string {
 	}
 
 	// Handle regular packages
-	parts := strings.Split(path, "/")
-	if len(parts) > 0 {
-		// Handle scoped packages like @babel/core
-		if strings.HasPrefix(parts[0], "@") && len(parts) > 1 {
-			return parts[0] + "/" + parts[1]
+	name, _ := splitPnpmPackageKey(path)
+	return name
+}
+
+// splitPnpmPackageKey splits a packages key into the package name and version:
+// "/name@1.0.0" (v6 to v8), "name@1.0.0" (v9), "/name/1.0.0" (v5), "name" (importer names),
+// scoped like "@babel/core@7.23.0", with the peer dependency suffix "(react@18.2.0)" dropped
+func splitPnpmPackageKey(key string) (string, string) {
+	key = strings.TrimPrefix(key, "/")
+	key, _, _ = strings.Cut(key, "(")
+	start := 0
+	if strings.HasPrefix(key, "@") {
+		slash := strings.Index(key, "/")
+		if slash < 0 {
+			return key, ""
 		}
-		return parts[0]
+		start = slash + 1
 	}
-
-	return ""
+	if i := strings.IndexAny(key[start:], "@/"); i >= 0 {
+		return key[:start+i], key[start+i+1:]
+	}
+	return key, ""
 }
 
 // parsePnpmVersion parses pnpm version with semantic version preservation
@@ -120,3 +120,64 @@ importers:
 		})
 	}
 }
+
+func TestParsePnpmLock_Packages(t *testing.T) {
+	content := `lockfileVersion: '6.0'
+
+importers:
+  .:
+    dependencies:
+      '@babel/core':
+        specifier: ^7.23.0
+        version: 7.23.0
+      sharp:
+        specifier: ^0.33.0
+        version: 0.33.0
+
+packages:
+  /@babel/core@7.23.0:
+    resolution: {integrity: sha512-abc}
+  /sharp@0.33.0:
+    resolution: {integrity: sha512-def}
+    requiresBuild: true
+  /color@4.2.3:
+    resolution: {integrity: sha512-ghi}
+`
+	deps := ParsePnpmLock([]byte(content))
+	if len(deps) != 2 {
+		t.Fatalf("ParsePnpmLock() got %d dependencies, want 2", len(deps))
+	}
+	for _, dep := range deps {
+		switch dep.Name {
+		case "@babel/core":
+			if dep.Version != "7.23.0" || dep.Metadata[MetadataNative] != nil {
+				t.Errorf("ParsePnpmLock() @babel/core = %s %v", dep.Version, dep.Metadata)
+			}
+		case "sharp":
+			if dep.Version != "0.33.0" || dep.Metadata[MetadataNativeEvidence] != NativeEvidenceRequiresBuild {
+				t.Errorf("ParsePnpmLock() sharp = %s %v", dep.Version, dep.Metadata)
+			}
+		default:
+			t.Errorf("ParsePnpmLock() unexpected dependency %s", dep.Name)
+		}
+	}
+}
+
+func TestSplitPnpmPackageKey(t *testing.T) {
+	tests := []struct {
+		key, name, version string
+	}{
+		{"/express@4.18.2", "express", "4.18.2"},
+		{"express@4.18.2", "express", "4.18.2"},
+		{"/express/4.18.2", "express", "4.18.2"},
+		{"@babel/core@7.23.0", "@babel/core", "7.23.0"},
+		{"/@babel/core@7.23.0(supports-color@8.1.1)", "@babel/core", "7.23.0"},
+		{"lodash", "lodash", ""},
+	}
+	for _, tt := range tests {
+		name, version := splitPnpmPackageKey(tt.key)
+		if name != tt.name || version != tt.version {
+			t.Errorf("splitPnpmPackageKey(%q) = %q, %q, want %q, %q", tt.key, name, version, tt.name, tt.version)
+		}
+	}
+}
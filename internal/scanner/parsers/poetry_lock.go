@@ -1,103 +1,244 @@
 package parsers
 
 import (
+	"regexp"
 	"strings"
 
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 )
 
-// ParsePoetryLock parses poetry.lock content and returns direct dependencies with resolved versions
-// Direct dependencies are identified by cross-referencing with pyproject.toml
+var (
+	poetryWheelRegex = regexp.MustCompile(`file = "([^"]+\.whl)"`)
+	poetrySdistRegex = regexp.MustCompile(`file = "[^"]+\.(?:tar\.gz|zip|tar\.bz2)"`)
+)
+
+// poetryGroupRegex matches the dependency tables of Poetry dependency groups
+var poetryGroupRegex = regexp.MustCompile(`^\[tool\.poetry\.group\.[^.\]]+\.dependencies\]$`)
+
+// quotedStringRegex matches the quoted strings of a TOML line
+var quotedStringRegex = regexp.MustCompile(`"([^"]*)"`)
+
+// ParsePoetryLockOptions contains configuration options for ParsePoetryLockWithOptions
+type ParsePoetryLockOptions struct {
+	IncludeTransitive bool // Include transitive dependencies (default: false for direct dependencies only)
+}
+
+// poetryLockPackage is a [[package]] entry of a poetry.lock
+type poetryLockPackage struct {
+	name         string
+	version      string
+	category     string   // "main" or "dev" (lock files of Poetry before 1.5)
+	groups       []string // Dependency groups requiring the package (lock files of Poetry 2)
+	dependencies []string // Normalized names of the [package.dependencies]
+}
+
+// ParsePoetryLock parses poetry.lock content and returns direct dependencies with resolved versions.
+// Direct dependencies are identified by cross-referencing with pyproject.toml. Use
+// ParsePoetryLockWithOptions to include transitive dependencies.
 func ParsePoetryLock(lockContent []byte, pyprojectContent string) []types.Dependency {
+	return ParsePoetryLockWithOptions(lockContent, pyprojectContent, ParsePoetryLockOptions{})
+}
+
+// ParsePoetryLockWithOptions parses poetry.lock content with configurable options. Direct
+// dependencies are the locked packages declared in pyproject.toml: [tool.poetry.dependencies] and
+// the dependencies array of [project] with the prod scope, dev-dependencies, dependency groups
+// and [dependency-groups] with the dev scope, and optional dependencies with the optional scope.
+// They are ordered by scope, then as locked. Transitive dependencies are the other locked
+// packages, with the scope of their category or groups, else of the direct dependency they are
+// reached from.
+func ParsePoetryLockWithOptions(lockContent []byte, pyprojectContent string, options ParsePoetryLockOptions) []types.Dependency {
 	// Extract direct dependency names and scopes from pyproject.toml
 	directDeps := extractDirectDepsFromPyproject(pyprojectContent)
 	if len(directDeps) == 0 {
 		return nil
 	}
 
-	// Parse poetry.lock to get resolved versions
-	packages := parsePoetryPackages(string(lockContent))
+	// Parse poetry.lock to get resolved versions and wheels
+	packages := parsePoetryLockPackages(string(lockContent))
+	wheels, sdists := parsePoetryWheels(string(lockContent))
+	byName := make(map[string]*poetryLockPackage, len(packages))
+	for i := range packages {
+		byName[normalizePackageName(packages[i].name)] = &packages[i]
+	}
 
-	// Build dependency list with resolved versions for direct deps only
+	// Direct dependencies, production before optional and dev
 	var dependencies []types.Dependency
-	for name, version := range packages {
-		// Normalize name for comparison (poetry uses lowercase with hyphens)
-		normalizedName := normalizePackageName(name)
-		if scope, exists := directDeps[normalizedName]; exists {
-			dependencies = append(dependencies, types.Dependency{
-				Type:       "python",
-				Name:       name,
-				Version:    version,
-				SourceFile: "poetry.lock",
+	scopes := make(map[string]types.Scope)
+	var queue []string
+	for _, scope := range []types.Scope{types.ScopeProd, types.ScopeOptional, types.ScopeDev} {
+		for _, pkg := range packages {
+			normalizedName := normalizePackageName(pkg.name)
+			if directDeps[normalizedName] != scope {
+				continue
+			}
+			scopes[normalizedName] = scope
+			queue = append(queue, normalizedName)
+			dep := types.Dependency{
+				Type:       DependencyTypePython,
+				Name:       pkg.name,
+				Version:    pkg.version,
+				SourceFile: MetadataSourcePoetryLock,
 				Scope:      scope,
 				Direct:     true,
-			})
+			}
+			MarkNative(&dep, NativePythonEvidence(pkg.name, wheels[normalizedName]))
+			SetArtifactPlatforms(&dep, PythonArtifactPlatforms(wheels[normalizedName], sdists[normalizedName]))
+			RecordLockOrigin(&dep, MetadataSourcePyprojectToml, MetadataSourcePoetryLock, "")
+			dependencies = append(dependencies, dep)
 		}
 	}
+	if !options.IncludeTransitive {
+		return NormalizeDependencyVersions(dependencies)
+	}
 
-	return dependencies
+	// Scope of the direct dependency each package is reached from, breadth first
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		pkg := byName[name]
+		if pkg == nil {
+			continue
+		}
+		for _, next := range pkg.dependencies {
+			if _, ok := scopes[next]; !ok {
+				scopes[next] = scopes[name]
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	// Transitive dependencies as locked
+	for _, pkg := range packages {
+		normalizedName := normalizePackageName(pkg.name)
+		if _, ok := directDeps[normalizedName]; ok {
+			continue
+		}
+		dep := types.Dependency{
+			Type:       DependencyTypePython,
+			Name:       pkg.name,
+			Version:    pkg.version,
+			SourceFile: MetadataSourcePoetryLock,
+			Scope:      poetryLockScope(pkg, scopes[normalizedName]),
+			Direct:     false,
+			Metadata:   types.NewMetadata(MetadataSourcePoetryLock),
+		}
+		MarkNative(&dep, NativePythonEvidence(pkg.name, wheels[normalizedName]))
+		SetArtifactPlatforms(&dep, PythonArtifactPlatforms(wheels[normalizedName], sdists[normalizedName]))
+		dependencies = append(dependencies, dep)
+	}
+	return NormalizeDependencyVersions(dependencies)
+}
+
+// poetryLockScope returns the scope of a transitive package: prod if its groups or category
+// include main, dev otherwise; packages without groups or category get the scope they are reached
+// from (prod if unreachable)
+func poetryLockScope(pkg poetryLockPackage, reached types.Scope) types.Scope {
+	switch {
+	case len(pkg.groups) > 0:
+		for _, group := range pkg.groups {
+			if group == "main" {
+				return types.ScopeProd
+			}
+		}
+		return types.ScopeDev
+	case pkg.category == "main":
+		return types.ScopeProd
+	case pkg.category != "":
+		return types.ScopeDev
+	case reached != "":
+		return reached
+	}
+	return types.ScopeProd
 }
 
 // pyprojectParseState tracks the current parsing state for pyproject.toml
 type pyprojectParseState struct {
-	inDepsSection    bool
-	inDevDepsSection bool
-	inArrayDeps      bool
+	poetryTable  bool        // Dependencies are the keys of a Poetry dependency table
+	arrayDeps    bool        // Dependencies are the quoted requirements of arrays
+	inProject    bool        // In the [project] table
+	projectArray bool        // In the dependencies array of the [project] table
+	scope        types.Scope // Scope of the dependencies of the current section
 }
 
-// extractDirectDepsFromPyproject extracts direct dependency names and scopes from pyproject.toml
-func extractDirectDepsFromPyproject(content string) map[string]string {
-	deps := make(map[string]string) // name -> scope
-	lines := strings.Split(content, "\n")
+// extractDirectDepsFromPyproject extracts direct dependency names and scopes from pyproject.toml.
+// A dependency declared in several sections keeps the prod scope over the optional and dev ones.
+func extractDirectDepsFromPyproject(content string) map[string]types.Scope {
+	deps := make(map[string]types.Scope) // name -> scope
 	state := &pyprojectParseState{}
 
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(stripTomlComment(line))
 		state = updatePyprojectState(state, trimmed)
 
-		if name := extractDepFromLine(trimmed, state); name != "" {
-			var scope string
-			if state.inDepsSection {
-				scope = types.ScopeProd
-			} else if state.inDevDepsSection {
-				scope = types.ScopeDev
-			} else if state.inArrayDeps {
-				scope = types.ScopeOptional
+		for name, scope := range extractDepsFromLine(trimmed, state) {
+			name = normalizePackageName(name)
+			if existing, ok := deps[name]; !ok || pyprojectScopeRank(scope) < pyprojectScopeRank(existing) {
+				deps[name] = scope
 			}
-			deps[normalizePackageName(name)] = scope
+		}
+		if state.projectArray && closesArray(trimmed) {
+			state.projectArray = false
 		}
 	}
 
 	return deps
 }
 
+// pyprojectScopeRank orders the scopes of declarations: prod before optional before dev
+func pyprojectScopeRank(scope types.Scope) int {
+	switch scope {
+	case types.ScopeProd:
+		return 0
+	case types.ScopeOptional:
+		return 1
+	}
+	return 2
+}
+
 func updatePyprojectState(state *pyprojectParseState, line string) *pyprojectParseState {
 	newState := *state
 
 	switch {
 	case line == "[tool.poetry.dependencies]":
-		newState = pyprojectParseState{inDepsSection: true}
-	case line == "[tool.poetry.dev-dependencies]" || line == "[tool.poetry.group.dev.dependencies]":
-		newState = pyprojectParseState{inDevDepsSection: true}
+		newState = pyprojectParseState{poetryTable: true, scope: types.ScopeProd}
+	case line == "[tool.poetry.dev-dependencies]" || poetryGroupRegex.MatchString(line):
+		newState = pyprojectParseState{poetryTable: true, scope: types.ScopeDev}
 	case line == "[project.dependencies]":
-		newState = pyprojectParseState{inArrayDeps: true}
-	case strings.HasPrefix(line, "[project.optional-dependencies"):
-		newState = pyprojectParseState{inArrayDeps: true}
-	case strings.HasPrefix(line, "[") && !strings.Contains(line, "dependencies"):
+		newState = pyprojectParseState{arrayDeps: true, scope: types.ScopeProd}
+	case line == "[project.optional-dependencies]":
+		newState = pyprojectParseState{arrayDeps: true, scope: types.ScopeOptional}
+	case line == "[dependency-groups]":
+		newState = pyprojectParseState{arrayDeps: true, scope: types.ScopeDev}
+	case line == "[project]":
+		newState = pyprojectParseState{inProject: true}
+	case strings.HasPrefix(line, "["):
 		newState = pyprojectParseState{}
+	case newState.inProject && tomlKey(strings.SplitN(line, "=", 2)[0]) == "dependencies" && strings.Contains(line, "="):
+		newState.projectArray = true
+		newState.scope = types.ScopeProd
 	}
 
 	return &newState
 }
 
-func extractDepFromLine(line string, state *pyprojectParseState) string {
-	if state.inDepsSection || state.inDevDepsSection {
-		return extractPoetryDep(line)
-	}
-	if state.inArrayDeps {
-		return extractArrayDep(line)
+// extractDepsFromLine returns the names and scopes of the dependencies declared on a line
+func extractDepsFromLine(line string, state *pyprojectParseState) map[string]types.Scope {
+	deps := make(map[string]types.Scope)
+	switch {
+	case state.poetryTable:
+		if name := extractPoetryDep(line); name != "" {
+			scope := state.scope
+			if _, value, _ := strings.Cut(line, "="); scope == types.ScopeProd && tomlString(tomlInlineTable(strings.TrimSpace(value))["optional"]) == "true" {
+				scope = types.ScopeOptional
+			}
+			deps[name] = scope
+		}
+	case state.arrayDeps || state.projectArray:
+		for _, name := range extractArrayDeps(line) {
+			deps[name] = state.scope
+		}
 	}
-	return ""
+	return deps
 }
 
 func extractPoetryDep(line string) string {
@@ -108,75 +249,150 @@ func extractPoetryDep(line string) string {
 	if len(parts) < 1 {
 		return ""
 	}
-	name := strings.TrimSpace(parts[0])
+	name := tomlKey(parts[0])
 	if name == "" || name == "python" {
 		return ""
 	}
 	return name
 }
 
-func extractArrayDep(line string) string {
-	if !strings.HasPrefix(line, `"`) {
-		return ""
+// extractArrayDeps returns the package names of the quoted requirements of an array line; the
+// key of the line and inline tables (include-group of dependency groups) are skipped
+func extractArrayDeps(line string) []string {
+	if key, value, ok := strings.Cut(line, "="); ok && !strings.HasPrefix(strings.TrimSpace(key), `"`) {
+		line = value
 	}
-	name := extractPackageNameFromQuoted(line)
-	if name == "" || name == "python" {
-		return ""
+	for strings.Contains(line, "{") {
+		open := strings.Index(line, "{")
+		end := strings.Index(line[open:], "}")
+		if end < 0 {
+			line = line[:open]
+			break
+		}
+		line = line[:open] + line[open+end+1:]
 	}
-	return name
+
+	var names []string
+	for _, match := range quotedStringRegex.FindAllStringSubmatch(line, -1) {
+		name := strings.TrimSpace(extractPackageNameFromQuoted(match[1]))
+		if name != "" && name != "python" {
+			names = append(names, name)
+		}
+	}
+	return names
 }
 
-// parsePoetryPackages extracts package name -> version mapping from poetry.lock
-func parsePoetryPackages(content string) map[string]string {
-	packages := make(map[string]string)
-	lines := strings.Split(content, "\n")
+// closesArray reports whether a line closes an array, outside quoted strings
+func closesArray(line string) bool {
+	return strings.Contains(quotedStringRegex.ReplaceAllString(line, ""), "]")
+}
 
-	var currentName string
-	inPackage := false
+// parsePoetryLockPackages returns the [[package]] entries of a poetry.lock in lock order
+func parsePoetryLockPackages(content string) []poetryLockPackage {
+	var packages []poetryLockPackage
+	var current *poetryLockPackage
+	inDependencies := false
 
-	for _, line := range lines {
+	for _, line := range strings.Split(content, "\n") {
 		trimmed := strings.TrimSpace(line)
-
-		if trimmed == "[[package]]" {
-			inPackage = true
-			currentName = ""
+		switch {
+		case trimmed == "[[package]]":
+			packages = append(packages, poetryLockPackage{})
+			current = &packages[len(packages)-1]
+			inDependencies = false
 			continue
-		}
-
-		if !inPackage {
+		case trimmed == "[package.dependencies]":
+			inDependencies = current != nil
+			continue
+		case strings.HasPrefix(trimmed, "["):
+			inDependencies = false
+			if !strings.HasPrefix(trimmed, "[package.") {
+				current = nil
+			}
 			continue
 		}
-
-		// Parse name = "value"
-		if strings.HasPrefix(trimmed, "name = ") {
-			currentName = extractQuotedValuePoetry(trimmed, "name = ")
+		if current == nil {
 			continue
 		}
 
-		// Parse version = "value"
-		if strings.HasPrefix(trimmed, "version = ") && currentName != "" {
-			version := extractQuotedValuePoetry(trimmed, "version = ")
-			if version != "" {
-				packages[currentName] = version
+		if inDependencies {
+			if key, _, ok := strings.Cut(trimmed, "="); ok {
+				current.dependencies = append(current.dependencies, normalizePackageName(tomlKey(key)))
 			}
-			inPackage = false
 			continue
 		}
+		switch {
+		case strings.HasPrefix(trimmed, "name = ") && current.name == "":
+			current.name = extractQuotedValuePoetry(trimmed, "name = ")
+		case strings.HasPrefix(trimmed, "version = ") && current.version == "":
+			current.version = extractQuotedValuePoetry(trimmed, "version = ")
+		case strings.HasPrefix(trimmed, "category = "):
+			current.category = extractQuotedValuePoetry(trimmed, "category = ")
+		case strings.HasPrefix(trimmed, "groups = "):
+			current.groups = tomlStrings(strings.TrimPrefix(trimmed, "groups = "))
+		}
+	}
+
+	// Entries without name or version cannot be resolved
+	resolved := packages[:0]
+	for _, pkg := range packages {
+		if pkg.name != "" && pkg.version != "" {
+			resolved = append(resolved, pkg)
+		}
+	}
+	return resolved
+}
+
+// parsePoetryWheels extracts the wheel file names of each package (normalized name) from the
+// files of [[package]] entries, or the [metadata.files] table of older lock files, and which
+// packages have a source distribution
+func parsePoetryWheels(content string) (map[string][]string, map[string]bool) {
+	wheels := make(map[string][]string)
+	sdists := make(map[string]bool)
+	var currentName string
+	inFiles := false
+	inMetadataFiles := false
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "[[package]]":
+			currentName, inFiles, inMetadataFiles = "", false, false
+		case trimmed == "[metadata.files]":
+			currentName, inFiles, inMetadataFiles = "", false, true
+		case strings.HasPrefix(trimmed, "["):
+			currentName, inFiles, inMetadataFiles = "", false, false
+		case strings.HasPrefix(trimmed, "name = ") && !inMetadataFiles:
+			currentName = normalizePackageName(extractQuotedValuePoetry(trimmed, "name = "))
+		case strings.HasPrefix(trimmed, "files = ["):
+			inFiles = true
+		case inMetadataFiles && strings.HasSuffix(trimmed, "= ["):
+			currentName = normalizePackageName(strings.Trim(strings.TrimSpace(strings.TrimSuffix(trimmed, "= [")), `"`))
+			inFiles = true
+		}
 
-		// End of package section
-		if strings.HasPrefix(trimmed, "[") && trimmed != "[[package]]" {
-			inPackage = false
+		if inFiles && currentName != "" {
+			for _, match := range poetryWheelRegex.FindAllStringSubmatch(trimmed, -1) {
+				wheels[currentName] = append(wheels[currentName], match[1])
+			}
+			if poetrySdistRegex.MatchString(trimmed) {
+				sdists[currentName] = true
+			}
+		}
+		if inFiles && strings.HasSuffix(trimmed, "]") {
+			inFiles = false
 		}
 	}
 
-	return packages
+	return wheels, sdists
 }
 
 // normalizePackageName normalizes a Python package name for comparison
-// Python package names are case-insensitive and treat hyphens/underscores as equivalent
+// Python package names are case-insensitive and treat hyphens/underscores/periods as equivalent
 func normalizePackageName(name string) string {
 	name = strings.ToLower(name)
 	name = strings.ReplaceAll(name, "_", "-")
+	name = strings.ReplaceAll(name, ".", "-")
 	return name
 }
 
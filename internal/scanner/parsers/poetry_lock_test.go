@@ -2,6 +2,10 @@ package parsers
 
 import (
 	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParsePoetryLock(t *testing.T) {
@@ -146,6 +150,280 @@ version = "2.31.0"
 	}
 }
 
+func TestParsePoetryLock_Native(t *testing.T) {
+	pyproject := `[tool.poetry.dependencies]
+python = "^3.11"
+numpy = "^1.26"
+requests = "^2.31"
+lxml = "^5.0"
+`
+	tests := []struct {
+		name string
+		lock string
+	}{
+		{
+			name: "package files",
+			lock: `[[package]]
+name = "numpy"
+version = "1.26.4"
+optional = false
+files = [
+    {file = "numpy-1.26.4-cp311-cp311-macosx_11_0_arm64.whl", hash = "sha256:1"},
+    {file = "numpy-1.26.4.tar.gz", hash = "sha256:2"},
+]
+
+[[package]]
+name = "requests"
+version = "2.31.0"
+files = [
+    {file = "requests-2.31.0-py3-none-any.whl", hash = "sha256:3"},
+]
+
+[package.dependencies]
+urllib3 = ">=1.21.1,<3"
+
+[[package]]
+name = "lxml"
+version = "5.1.0"
+`,
+		},
+		{
+			name: "metadata files",
+			lock: `[[package]]
+name = "numpy"
+version = "1.26.4"
+
+[[package]]
+name = "requests"
+version = "2.31.0"
+
+[[package]]
+name = "lxml"
+version = "5.1.0"
+
+[metadata]
+lock-version = "1.1"
+
+[metadata.files]
+numpy = [
+    {file = "numpy-1.26.4-cp311-cp311-macosx_11_0_arm64.whl", hash = "sha256:1"},
+]
+requests = [
+    {file = "requests-2.31.0-py3-none-any.whl", hash = "sha256:3"},
+]
+`,
+		},
+	}
+
+	expected := map[string]interface{}{
+		"numpy":    NativeEvidencePlatformWheel,
+		"requests": nil,
+		"lxml":     NativeEvidenceKnownPackage,
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evidence := make(map[string]interface{})
+			for _, dep := range ParsePoetryLock([]byte(tt.lock), pyproject) {
+				evidence[dep.Name] = dep.Metadata[MetadataNativeEvidence]
+			}
+			if len(evidence) != len(expected) {
+				t.Fatalf("ParsePoetryLock() got %d dependencies, want %d", len(evidence), len(expected))
+			}
+			for name, want := range expected {
+				if evidence[name] != want {
+					t.Errorf("ParsePoetryLock() dep %s native evidence = %v, want %v", name, evidence[name], want)
+				}
+			}
+		})
+	}
+}
+
+const testPoetryPyproject = `[tool.poetry]
+name = "app"
+
+[tool.poetry.dependencies]
+python = "^3.11"
+Django = "^4.2"
+"zope.interface" = "^6.1" # quoted key
+boto3 = { version = "^1.34", optional = true }
+
+[tool.poetry.group.dev.dependencies]
+pytest = "^8.0"
+
+[tool.poetry.group.docs.dependencies]
+sphinx = "^7.2"
+
+[tool.poetry.extras]
+aws = ["boto3"]
+`
+
+const testPoetryLock = `[[package]]
+name = "asgiref"
+version = "3.8.1"
+optional = false
+groups = ["main"]
+
+[[package]]
+name = "boto3"
+version = "1.34.0"
+optional = true
+groups = ["main"]
+
+[[package]]
+name = "django"
+version = "4.2.11"
+optional = false
+groups = ["main"]
+
+[package.dependencies]
+asgiref = ">=3.6.0,<4"
+sqlparse = ">=0.3.1"
+
+[package.extras]
+bcrypt = ["bcrypt"]
+
+[[package]]
+name = "iniconfig"
+version = "2.0.0"
+optional = false
+groups = ["dev"]
+
+[[package]]
+name = "pytest"
+version = "8.1.1"
+optional = false
+groups = ["dev"]
+
+[package.dependencies]
+iniconfig = "*"
+
+[[package]]
+name = "sphinx"
+version = "7.2.6"
+optional = false
+groups = ["docs"]
+
+[[package]]
+name = "sqlparse"
+version = "0.5.0"
+
+[[package]]
+name = "zope-interface"
+version = "6.1"
+optional = false
+groups = ["main"]
+
+[metadata]
+lock-version = "2.1"
+`
+
+func TestParsePoetryLock_Scopes(t *testing.T) {
+	deps := ParsePoetryLock([]byte(testPoetryLock), testPoetryPyproject)
+
+	type scoped struct {
+		name, version string
+		scope         types.Scope
+	}
+	var got []scoped
+	for _, dep := range deps {
+		assert.True(t, dep.Direct, dep.Name)
+		got = append(got, scoped{dep.Name, dep.Version, dep.Scope})
+	}
+	assert.Equal(t, []scoped{
+		{"django", "4.2.11", types.ScopeProd},
+		{"zope-interface", "6.1", types.ScopeProd},
+		{"boto3", "1.34.0", types.ScopeOptional},
+		{"pytest", "8.1.1", types.ScopeDev},
+		{"sphinx", "7.2.6", types.ScopeDev},
+	}, got, "prod before optional and dev, as locked")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"source": MetadataSourcePyprojectToml, "field": "scope", "value": string(types.ScopeProd)},
+		map[string]interface{}{"source": MetadataSourcePoetryLock, "field": "version", "value": "4.2.11"},
+	}, deps[0].Metadata[MetadataOrigin])
+}
+
+func TestParsePoetryLock_Transitive(t *testing.T) {
+	deps := ParsePoetryLockWithOptions([]byte(testPoetryLock), testPoetryPyproject, ParsePoetryLockOptions{IncludeTransitive: true})
+	require.Len(t, deps, 8)
+
+	transitive := make(map[string]types.Scope)
+	for _, dep := range deps[5:] {
+		assert.False(t, dep.Direct, dep.Name)
+		assert.Equal(t, MetadataSourcePoetryLock, dep.SourceFile)
+		transitive[dep.Name] = dep.Scope
+	}
+	assert.Equal(t, map[string]types.Scope{
+		"asgiref":   types.ScopeProd, // groups
+		"iniconfig": types.ScopeDev,  // groups
+		"sqlparse":  types.ScopeProd, // reached from django
+	}, transitive)
+	assert.Equal(t, "asgiref", deps[5].Name, "as locked")
+}
+
+func TestParsePoetryLock_LegacyCategory(t *testing.T) {
+	lock := `[[package]]
+name = "requests"
+version = "2.31.0"
+category = "main"
+
+[package.dependencies]
+urllib3 = ">=1.21.1,<3"
+
+[[package]]
+name = "urllib3"
+version = "2.2.1"
+category = "main"
+
+[[package]]
+name = "coverage"
+version = "7.4.4"
+category = "dev"
+`
+	pyproject := `[tool.poetry.dependencies]
+requests = "^2.31"
+
+[tool.poetry.dev-dependencies]
+pytest = "^8.0"
+`
+	deps := ParsePoetryLockWithOptions([]byte(lock), pyproject, ParsePoetryLockOptions{IncludeTransitive: true})
+	require.Len(t, deps, 3, "pytest is not locked")
+	assert.Equal(t, "requests", deps[0].Name)
+	assert.Equal(t, types.ScopeProd, deps[1].Scope)
+	assert.Equal(t, "coverage", deps[2].Name)
+	assert.Equal(t, types.ScopeDev, deps[2].Scope)
+}
+
+func TestExtractDirectDepsFromPyproject_Project(t *testing.T) {
+	pyproject := `[project]
+name = "app"
+classifiers = [
+    "Programming Language :: Python :: 3",
+]
+dependencies = [
+    "requests[socks]>=2.31",
+    "pywin32; sys_platform == 'win32'",
+]
+
+[project.optional-dependencies]
+aws = ["boto3>=1.34"]
+dev = ["requests"]
+
+[dependency-groups]
+test = ["pytest>=8", {include-group = "lint"}]
+lint = ["ruff"]
+`
+	assert.Equal(t, map[string]types.Scope{
+		"requests": types.ScopeProd,
+		"pywin32":  types.ScopeProd,
+		"boto3":    types.ScopeOptional,
+		"pytest":   types.ScopeDev,
+		"ruff":     types.ScopeDev,
+	}, extractDirectDepsFromPyproject(pyproject))
+
+	inline := "[project]\ndependencies = [\"fastapi>=0.110\", \"uvicorn\"]\nreadme = \"README.md\"\n"
+	assert.Equal(t, map[string]types.Scope{"fastapi": types.ScopeProd, "uvicorn": types.ScopeProd}, extractDirectDepsFromPyproject(inline))
+}
+
 func TestNormalizePackageName(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -156,6 +434,7 @@ func TestNormalizePackageName(t *testing.T) {
 		{"my_package", "my-package"},
 		{"My_Package", "my-package"},
 		{"some-package", "some-package"},
+		{"zope.interface", "zope-interface"},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,126 @@
+package parsers
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// pyprojectStringRegex matches the basic ("...") and literal ('...') TOML strings of an array;
+// PEP 508 markers quote their values with the other quote character
+var pyprojectStringRegex = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"|'([^']*)'`)
+
+// pyprojectArray is a requirement array of a pyproject.toml being read
+type pyprojectArray struct {
+	scope types.Scope
+	extra string // Extra of a project.optional-dependencies array
+	value strings.Builder
+}
+
+// ParsePyproject parses the PEP 621 requirements of a pyproject.toml: project.dependencies
+// (prod scope), project.optional-dependencies (optional scope, activated by their extra), and
+// build-system.requires (build scope). Requirements are PEP 508 strings; their extras, markers,
+// and direct URL are recorded in the metadata. Poetry tables are not read.
+func (p *PythonParser) ParsePyproject(content string) []types.Dependency {
+	var dependencies []types.Dependency
+	table := ""
+	var array *pyprojectArray
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(stripTomlComment(line))
+		if array == nil {
+			if strings.HasPrefix(line, "[") {
+				table = strings.Trim(line, "[] ")
+				continue
+			}
+			key, value, ok := strings.Cut(line, "=")
+			value = strings.TrimSpace(value)
+			if !ok || !strings.HasPrefix(value, "[") {
+				continue
+			}
+			scope, extra, ok := pyprojectRequirements(table, tomlKey(key))
+			if !ok {
+				continue
+			}
+			array = &pyprojectArray{scope: scope, extra: extra}
+			line = value
+		}
+		array.value.WriteString(line + "\n")
+		if !pyprojectArrayClosed(array.value.String()) {
+			continue
+		}
+		for _, match := range pyprojectStringRegex.FindAllStringSubmatch(array.value.String(), -1) {
+			requirement := match[1] + match[2]
+			if dep, ok := p.pyprojectDependency(requirement, array.scope, array.extra); ok {
+				dependencies = append(dependencies, dep)
+			}
+		}
+		array = nil
+	}
+	return NormalizeDependencyVersions(dependencies)
+}
+
+// pyprojectRequirements returns the scope and extra of the requirements of a key of a table,
+// and false if the key holds no requirements
+func pyprojectRequirements(table, key string) (types.Scope, string, bool) {
+	switch {
+	case table == "project" && key == "dependencies":
+		return types.ScopeProd, "", true
+	case table == "project" && strings.HasPrefix(key, "optional-dependencies."):
+		return types.ScopeOptional, tomlKey(strings.TrimPrefix(key, "optional-dependencies.")), true
+	case table == "project.optional-dependencies":
+		return types.ScopeOptional, key, true
+	case table == "build-system" && key == "requires":
+		return types.ScopeBuild, "", true
+	}
+	return "", "", false
+}
+
+// pyprojectArrayClosed reports whether the text of an array holds its closing bracket
+func pyprojectArrayClosed(value string) bool {
+	value = pyprojectStringRegex.ReplaceAllString(value, "")
+	return strings.Count(value, "]") >= strings.Count(value, "[")
+}
+
+// pyprojectDependency returns the dependency of a PEP 508 requirement of a pyproject.toml
+func (p *PythonParser) pyprojectDependency(requirement string, scope types.Scope, extra string) (types.Dependency, bool) {
+	req, err := p.parsePEP508Dependency(requirement)
+	if err != nil || req.Name == "" {
+		return types.Dependency{}, false
+	}
+	dep := types.Dependency{
+		Type:       DependencyTypePython,
+		Name:       req.Name,
+		Scope:      scope,
+		Direct:     true,
+		SourceFile: MetadataSourcePyprojectToml,
+		Metadata:   types.NewMetadata(MetadataSourcePyprojectToml),
+	}
+
+	constraint := req.Constraint
+	if url, ok := strings.CutPrefix(constraint, "@"); ok {
+		dep.Metadata[MetadataPyprojectURL] = strings.TrimSpace(url)
+		constraint = ""
+	}
+	dep.Version = p.resolveVersion(constraint)
+
+	if req.Extras != "" {
+		var extras []string
+		for _, name := range strings.Split(req.Extras, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				extras = append(extras, name)
+			}
+		}
+		dep.Metadata[MetadataPyprojectExtras] = extras
+	}
+	if extra != "" {
+		extra = normalizePackageName(extra)
+		dep.Metadata[MetadataPyprojectExtra] = extra
+		AddActivation(&dep, ActivationExtra, []string{extra}, false)
+	}
+	if req.Environment != "" {
+		dep.Metadata[MetadataPyprojectMarkers] = req.Environment
+		AddPythonMarkerActivation(&dep, req.Environment)
+	}
+	return dep, true
+}
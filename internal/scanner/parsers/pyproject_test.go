@@ -0,0 +1,77 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPyproject = `[build-system]
+requires = ["hatchling>=1.18", 'hatch-vcs']
+build-backend = "hatchling.build"
+
+[project]
+name = "app"
+version = "1.0.0"
+dependencies = [
+    "fastapi[standard]>=0.110,<1",   # web framework
+    "pywin32>=306; sys_platform == 'win32'",
+    'Zope.Interface',
+    "mylib @ https://example.com/mylib-1.0.tar.gz",
+]
+optional-dependencies.cli = ["typer"]
+
+[project.optional-dependencies]
+test = [
+    "pytest>=8",
+    "pytest-cov[toml]",
+]
+docs = ["sphinx; python_version >= '3.10'"]
+
+[tool.poetry.dependencies]
+django = "^4.2"
+`
+
+func TestParsePyproject(t *testing.T) {
+	deps := NewPythonParser().ParsePyproject(testPyproject)
+
+	var names []string
+	byName := make(map[string]types.Dependency)
+	for _, dep := range deps {
+		names = append(names, dep.Name)
+		byName[dep.Name] = dep
+		assert.Equal(t, MetadataSourcePyprojectToml, dep.SourceFile)
+		assert.True(t, dep.Direct)
+	}
+	require.Equal(t, []string{"hatchling", "hatch-vcs", "fastapi", "pywin32", "zope-interface", "mylib", "typer", "pytest", "pytest-cov", "sphinx"}, names, "declaration order, Poetry tables skipped")
+
+	assert.Equal(t, types.ScopeBuild, byName["hatchling"].Scope)
+	assert.Equal(t, ">=1.18", byName["hatchling"].Version)
+	assert.Equal(t, types.ScopeBuild, byName["hatch-vcs"].Scope)
+
+	fastapi := byName["fastapi"]
+	assert.Equal(t, types.ScopeProd, fastapi.Scope)
+	assert.Equal(t, ">=0.110,<1", fastapi.Version)
+	assert.Equal(t, []string{"standard"}, fastapi.Metadata[MetadataPyprojectExtras])
+
+	assert.Equal(t, "sys_platform == 'win32'", byName["pywin32"].Metadata[MetadataPyprojectMarkers])
+	assert.False(t, (&TargetEnvironment{OS: "linux"}).Active(byName["pywin32"]), "marker activation")
+	assert.Equal(t, "latest", byName["zope-interface"].Version)
+	assert.Equal(t, "latest", byName["mylib"].Version)
+	assert.Equal(t, "https://example.com/mylib-1.0.tar.gz", byName["mylib"].Metadata[MetadataPyprojectURL])
+
+	pytest := byName["pytest"]
+	assert.Equal(t, types.ScopeOptional, pytest.Scope)
+	assert.Equal(t, "test", pytest.Metadata[MetadataPyprojectExtra])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{ActivationFieldKind: ActivationExtra, ActivationFieldConditions: []string{"test"}, ActivationFieldDefault: false},
+	}, pytest.Metadata[MetadataActivation])
+	assert.Equal(t, []string{"toml"}, byName["pytest-cov"].Metadata[MetadataPyprojectExtras])
+	assert.Equal(t, "cli", byName["typer"].Metadata[MetadataPyprojectExtra], "dotted key of [project]")
+	assert.Equal(t, "docs", byName["sphinx"].Metadata[MetadataPyprojectExtra])
+	assert.Equal(t, "python_version >= '3.10'", byName["sphinx"].Metadata[MetadataPyprojectMarkers])
+
+	assert.Empty(t, NewPythonParser().ParsePyproject("[project]\nname = \"app\"\ndependencies = []\n"))
+}
@@ -17,34 +17,15 @@ func NewPythonParser() *PythonParser {
 	return &PythonParser{}
 }
 
-// ParseRequirementsTxt parses requirements.txt with full PEP 508 compliance
+// ParseRequirementsTxt parses requirements.txt with full PEP 508 compliance. Includes (-r) and
+// constraints (-c) are not followed; ParseRequirementsFile follows them.
 func (p *PythonParser) ParseRequirementsTxt(content string) []types.Dependency {
-	dependencies := make([]types.Dependency, 0)
-
-	for _, line := range strings.Split(content, "\n") {
-		line = strings.TrimSpace(line)
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
-
-		dep, err := p.parsePEP508Dependency(line)
-		if err != nil {
-			continue // Skip invalid lines
-		}
-
-		if dep.Name != "" {
-			dependencies = append(dependencies, types.Dependency{
-				Type:     DependencyTypePython,
-				Name:     p.canonPackageName(dep.Name),
-				Version:  p.resolveVersion(dep.Constraint),
-				Scope:    types.ScopeProd, // requirements.txt defaults to production
-				Direct:   true,
-				Metadata: types.NewMetadata(MetadataSourceRequirementsTxt),
-			})
-		}
+	r := &requirementsReader{parser: p}
+	dependencies := r.parse(MetadataSourceRequirementsTxt, content)
+	if dependencies == nil {
+		return make([]types.Dependency, 0)
 	}
-
-	return dependencies
+	return NormalizeDependencyVersions(dependencies)
 }
 
 // PythonDependency represents a PEP 508 compliant dependency (deps.dev pattern)
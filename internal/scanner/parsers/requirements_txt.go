@@ -0,0 +1,302 @@
+package parsers
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+var (
+	// requirementsCommentRegex matches the comment of a requirements line: a # at the start or
+	// after whitespace (URL fragments like #egg= are not comments)
+	requirementsCommentRegex = regexp.MustCompile(`(^|\s)#.*$`)
+	// requirementsOptionRegex matches the per-requirement options following a requirement
+	// (--hash=sha256:..., --config-settings)
+	requirementsOptionRegex = regexp.MustCompile(`\s--?[a-zA-Z].*$`)
+	// requirementsEggRegex matches the project name of a URL fragment (#egg=name)
+	requirementsEggRegex = regexp.MustCompile(`[#&]egg=([A-Za-z0-9._-]+)`)
+	// requirementsArchiveRegex matches the project name of a wheel or source distribution file
+	requirementsArchiveRegex = regexp.MustCompile(`^([A-Za-z0-9._]+?)-\d[^/]*\.(whl|tar\.gz|tar\.bz2|zip)$`)
+)
+
+// requirementsVCSSchemes are the version control schemes of pip requirement URLs (git+https://)
+var requirementsVCSSchemes = []string{"git", "hg", "svn", "bzr"}
+
+// requirementsReader reads requirement files, following includes when it can read files
+type requirementsReader struct {
+	parser      *PythonParser
+	readFile    func(name string) ([]byte, error) // Nil skips includes and constraints
+	visited     map[string]bool
+	constraints map[string]types.Dependency // Constraints (-c) by package name
+}
+
+// ParseRequirementsFile parses a requirements file and the files it includes: requirement
+// files (-r, --requirement) add their requirements, and constraints files (-c, --constraint)
+// restrict the versions of the requirements they name. File names are relative to the
+// directory of the including file; readFile reads them, relative to the directory of the
+// parsed file. Requirements are PEP 508 strings, archive URLs or paths, and VCS URLs
+// (git+https://...@ref#egg=name), optionally editable (-e). Requirements of included files
+// record the file, constraints their specifier; the first requirement of a package wins.
+func (p *PythonParser) ParseRequirementsFile(name string, readFile func(name string) ([]byte, error)) []types.Dependency {
+	content, err := readFile(name)
+	if err != nil {
+		return nil
+	}
+	r := &requirementsReader{
+		parser:      p,
+		readFile:    readFile,
+		visited:     map[string]bool{path.Clean(name): true},
+		constraints: make(map[string]types.Dependency),
+	}
+	dependencies := r.parse(path.Clean(name), string(content))
+	for i := range dependencies {
+		r.applyConstraint(&dependencies[i])
+	}
+	return NormalizeDependencyVersions(dependencies)
+}
+
+// parse returns the requirements of a requirements file, following its includes
+func (r *requirementsReader) parse(file, content string) []types.Dependency {
+	var dependencies []types.Dependency
+	seen := make(map[string]bool)
+	add := func(deps ...types.Dependency) {
+		for _, dep := range deps {
+			if !seen[dep.Name] {
+				seen[dep.Name] = true
+				dependencies = append(dependencies, dep)
+			}
+		}
+	}
+
+	for _, line := range requirementsLines(content) {
+		option, value := requirementsOption(line)
+		switch option {
+		case "":
+			if dep, ok := r.parser.requirementDependency(requirementsOptionRegex.ReplaceAllString(line, ""), false); ok {
+				add(dep)
+			}
+		case "-e", "--editable":
+			if dep, ok := r.parser.requirementDependency(value, true); ok {
+				add(dep)
+			}
+		case "-r", "--requirement":
+			for _, dep := range r.include(file, value) {
+				if _, ok := dep.Metadata[MetadataRequirementsFile]; !ok {
+					dep.Metadata[MetadataRequirementsFile] = path.Join(path.Dir(file), value)
+				}
+				add(dep)
+			}
+		case "-c", "--constraint":
+			for _, dep := range r.include(file, value) {
+				constraintFile := path.Join(path.Dir(file), value)
+				if existing, ok := r.constraints[dep.Name]; !ok || existing.Version == "latest" {
+					dep.Metadata[MetadataRequirementsConstraintFile] = constraintFile
+					r.constraints[dep.Name] = dep
+				}
+			}
+		}
+	}
+	return dependencies
+}
+
+// include returns the requirements of a file included by another; files already read and
+// files that cannot be read have none
+func (r *requirementsReader) include(from, name string) []types.Dependency {
+	if r.readFile == nil || name == "" {
+		return nil
+	}
+	file := path.Join(path.Dir(from), name)
+	if r.visited[file] {
+		return nil
+	}
+	r.visited[file] = true
+	content, err := r.readFile(file)
+	if err != nil {
+		return nil
+	}
+	return r.parse(file, string(content))
+}
+
+// applyConstraint records the constraint of a requirement and applies its specifier to an
+// unversioned requirement
+func (r *requirementsReader) applyConstraint(dep *types.Dependency) {
+	constraint, ok := r.constraints[dep.Name]
+	if !ok || constraint.Version == "latest" {
+		return
+	}
+	dep.Metadata[MetadataRequirementsConstraint] = constraint.Version
+	dep.Metadata[MetadataRequirementsConstraintFile] = constraint.Metadata[MetadataRequirementsConstraintFile]
+	if dep.Version == "latest" {
+		dep.Version = constraint.Version
+	}
+}
+
+// requirementsLines returns the logical lines of a requirements file: continuation lines
+// (ending with a backslash) joined, comments and blank lines removed
+func requirementsLines(content string) []string {
+	var lines []string
+	var current strings.Builder
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if continued, ok := strings.CutSuffix(line, `\`); ok {
+			current.WriteString(continued + " ")
+			continue
+		}
+		current.WriteString(line)
+		logical := strings.TrimSpace(requirementsCommentRegex.ReplaceAllString(current.String(), ""))
+		current.Reset()
+		if logical != "" {
+			lines = append(lines, logical)
+		}
+	}
+	if logical := strings.TrimSpace(requirementsCommentRegex.ReplaceAllString(current.String(), "")); logical != "" {
+		lines = append(lines, logical)
+	}
+	return lines
+}
+
+// requirementsOption splits an option line into the option (-r, --requirement) and its value
+// ("-rbase.txt", "--requirement=base.txt", "-r base.txt"); a requirement returns no option
+func requirementsOption(line string) (string, string) {
+	if !strings.HasPrefix(line, "-") {
+		return "", ""
+	}
+	if strings.HasPrefix(line, "--") {
+		end := strings.IndexAny(line, "= \t")
+		if end < 0 {
+			return line, ""
+		}
+		return line[:end], strings.TrimSpace(line[end+1:])
+	}
+	if len(line) < 2 {
+		return line, ""
+	}
+	return line[:2], strings.TrimSpace(line[2:])
+}
+
+// requirementDependency returns the dependency of a requirement: a PEP 508 string, or an
+// archive URL, local path, or VCS URL
+func (p *PythonParser) requirementDependency(requirement string, editable bool) (types.Dependency, bool) {
+	requirement = strings.TrimSpace(requirement)
+	if requirement == "" {
+		return types.Dependency{}, false
+	}
+
+	dep := types.Dependency{
+		Type:     DependencyTypePython,
+		Version:  "latest",
+		Scope:    types.ScopeProd, // requirements.txt defaults to production
+		Direct:   true,
+		Metadata: types.NewMetadata(MetadataSourceRequirementsTxt),
+	}
+	if editable {
+		dep.Metadata[MetadataRequirementsEditable] = true
+	}
+
+	var location string
+	if isRequirementLocation(requirement) {
+		location, dep.Name = requirement, requirementLocationName(requirement)
+		if url, marker, ok := strings.Cut(location, "; "); ok {
+			location = url
+			p.setRequirementMarkers(&dep, marker)
+		}
+	} else {
+		req, err := p.parsePEP508Dependency(requirement)
+		if err != nil {
+			return types.Dependency{}, false
+		}
+		dep.Name = req.Name
+		if url, ok := strings.CutPrefix(req.Constraint, "@"); ok {
+			location = strings.TrimSpace(url)
+		} else {
+			dep.Version = p.resolveVersion(req.Constraint)
+		}
+		if req.Extras != "" {
+			var extras []string
+			for _, extra := range strings.Split(req.Extras, ",") {
+				if extra = strings.TrimSpace(extra); extra != "" {
+					extras = append(extras, extra)
+				}
+			}
+			dep.Metadata[MetadataRequirementsExtras] = extras
+		}
+		p.setRequirementMarkers(&dep, req.Environment)
+	}
+
+	dep.Name = p.canonPackageName(dep.Name)
+	if dep.Name == "" || strings.HasPrefix(dep.Name, "-") {
+		return types.Dependency{}, false
+	}
+	if location != "" {
+		setRequirementLocation(&dep, location)
+	}
+	return dep, true
+}
+
+// setRequirementMarkers records the environment marker of a requirement and its activation
+func (p *PythonParser) setRequirementMarkers(dep *types.Dependency, marker string) {
+	if marker = strings.TrimSpace(marker); marker == "" {
+		return
+	}
+	dep.Metadata[MetadataRequirementsMarkers] = marker
+	AddPythonMarkerActivation(dep, marker)
+}
+
+// isRequirementLocation reports whether a requirement is a URL or a local path rather than a
+// PEP 508 string
+func isRequirementLocation(requirement string) bool {
+	if strings.HasPrefix(requirement, ".") || strings.HasPrefix(requirement, "/") {
+		return true
+	}
+	if strings.HasPrefix(requirement, "file:") {
+		return true
+	}
+	scheme, _, ok := strings.Cut(requirement, "://")
+	return ok && !strings.ContainsAny(scheme, " <>=;[@")
+}
+
+// requirementLocationName returns the project name of a URL or path requirement: the #egg=
+// fragment, the name of a wheel or source distribution, or the last path element
+func requirementLocationName(location string) string {
+	if match := requirementsEggRegex.FindStringSubmatch(location); match != nil {
+		return match[1]
+	}
+	location, _, _ = strings.Cut(location, "#")
+	location, _, _ = strings.Cut(location, "; ")
+	base := path.Base(strings.TrimRight(location, "/"))
+	if match := requirementsArchiveRegex.FindStringSubmatch(base); match != nil {
+		return match[1]
+	}
+	if strings.Contains(location, "://") && !strings.HasPrefix(location, "file:") {
+		// VCS URLs name the repository, optionally with a revision (repo.git@v1.0)
+		base, _, _ = strings.Cut(base, "@")
+		return strings.TrimSuffix(base, ".git")
+	}
+	return base
+}
+
+// setRequirementLocation records the source of a URL or path requirement: the repository,
+// VCS, and revision of VCS URLs (git+https://host/repo.git@v1.0), or the archive URL or path
+func setRequirementLocation(dep *types.Dependency, location string) {
+	location, _, _ = strings.Cut(location, "#")
+	for _, vcs := range requirementsVCSSchemes {
+		url, ok := strings.CutPrefix(location, vcs+"+")
+		if !ok {
+			continue
+		}
+		dep.Metadata[MetadataRequirementsVCS] = vcs
+		scheme, rest, _ := strings.Cut(url, "://")
+		if at := strings.LastIndex(rest, "@"); at > strings.LastIndex(rest, "/") {
+			dep.Metadata[MetadataRequirementsRef] = rest[at+1:]
+			rest = rest[:at]
+		}
+		dep.Metadata[MetadataRequirementsURL] = scheme + "://" + rest
+		return
+	}
+	if strings.Contains(location, "://") {
+		dep.Metadata[MetadataRequirementsURL] = location
+		return
+	}
+	dep.Metadata[MetadataRequirementsPath] = strings.TrimPrefix(location, "file:")
+}
@@ -0,0 +1,105 @@
+package parsers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRequirementsFiles(files map[string]string) func(string) ([]byte, error) {
+	return func(name string) ([]byte, error) {
+		content, ok := files[name]
+		if !ok {
+			return nil, fmt.Errorf("%s not found", name)
+		}
+		return []byte(content), nil
+	}
+}
+
+func TestParseRequirementsFile(t *testing.T) {
+	files := testRequirementsFiles(map[string]string{
+		"requirements.txt": `-r requirements/base.txt
+--constraint constraints.txt
+uvicorn[standard]>=0.29   # server
+pywin32==306 ; sys_platform == "win32"
+requests \
+    --hash=sha256:58cd2187c01e70e6e26505bca751777aa9f2ee0b7f4300988b709f44e013003f
+-e ./libs/shared
+-e git+https://github.com/org/tools.git@v1.2.0#egg=org-tools
+django-extras @ git+https://github.com/org/django-extras.git@main
+https://example.com/pkgs/internal_lib-2.0.1-py3-none-any.whl
+--index-url https://pypi.example.com/simple
+-r missing.txt
+-r ../outside.txt
+`,
+		"requirements/base.txt": `-r common.txt
+flask>=2.0
+requests==2.30.0
+`,
+		"requirements/common.txt": "-r ../requirements.txt\nclick\n",
+		"constraints.txt":         "click==8.1.7\nrequests==2.31.0\nunused==1.0\n",
+	})
+
+	deps := NewPythonParser().ParseRequirementsFile("requirements.txt", files)
+
+	var names []string
+	byName := make(map[string]types.Dependency)
+	for _, dep := range deps {
+		names = append(names, dep.Name)
+		byName[dep.Name] = dep
+		assert.Equal(t, types.ScopeProd, dep.Scope)
+		assert.True(t, dep.Direct)
+	}
+	require.Equal(t, []string{"click", "flask", "requests", "uvicorn", "pywin32", "shared", "org-tools", "django-extras", "internal-lib"}, names,
+		"includes in place, first requirement of a package wins, cycles and constraints add none")
+
+	click := byName["click"]
+	assert.Equal(t, "==8.1.7", click.Version, "unversioned requirement takes the constraint")
+	assert.Equal(t, "requirements/common.txt", click.Metadata[MetadataRequirementsFile])
+	assert.Equal(t, "==8.1.7", click.Metadata[MetadataRequirementsConstraint])
+	assert.Equal(t, "constraints.txt", click.Metadata[MetadataRequirementsConstraintFile])
+
+	requests := byName["requests"]
+	assert.Equal(t, "==2.30.0", requests.Version, "declared specifier is kept")
+	assert.Equal(t, "requirements/base.txt", requests.Metadata[MetadataRequirementsFile])
+	assert.Equal(t, "==2.31.0", requests.Metadata[MetadataRequirementsConstraint])
+
+	uvicorn := byName["uvicorn"]
+	assert.Equal(t, ">=0.29", uvicorn.Version)
+	assert.Equal(t, []string{"standard"}, uvicorn.Metadata[MetadataRequirementsExtras])
+	assert.NotContains(t, uvicorn.Metadata, MetadataRequirementsFile)
+
+	assert.Equal(t, `sys_platform == "win32"`, byName["pywin32"].Metadata[MetadataRequirementsMarkers])
+	assert.False(t, (&TargetEnvironment{OS: "linux"}).Active(byName["pywin32"]), "marker activation")
+
+	shared := byName["shared"]
+	assert.Equal(t, "latest", shared.Version)
+	assert.Equal(t, true, shared.Metadata[MetadataRequirementsEditable])
+	assert.Equal(t, "./libs/shared", shared.Metadata[MetadataRequirementsPath])
+
+	tools := byName["org-tools"]
+	assert.Equal(t, true, tools.Metadata[MetadataRequirementsEditable])
+	assert.Equal(t, "git", tools.Metadata[MetadataRequirementsVCS])
+	assert.Equal(t, "https://github.com/org/tools.git", tools.Metadata[MetadataRequirementsURL])
+	assert.Equal(t, "v1.2.0", tools.Metadata[MetadataRequirementsRef])
+
+	extras := byName["django-extras"]
+	assert.Equal(t, "latest", extras.Version)
+	assert.Equal(t, "main", extras.Metadata[MetadataRequirementsRef])
+	assert.NotContains(t, extras.Metadata, MetadataRequirementsEditable)
+
+	assert.Equal(t, "https://example.com/pkgs/internal_lib-2.0.1-py3-none-any.whl", byName["internal-lib"].Metadata[MetadataRequirementsURL])
+
+	assert.Nil(t, NewPythonParser().ParseRequirementsFile("missing.txt", files))
+}
+
+func TestParseRequirementsTxt_Options(t *testing.T) {
+	deps := NewPythonParser().ParseRequirementsTxt("-r base.txt\n-c constraints.txt\n--extra-index-url https://example.com\n-e .\n-e ../lib\nflask\n")
+	require.Len(t, deps, 2, "includes are not followed, option lines and the project itself (-e .) add no packages")
+	assert.Equal(t, "lib", deps[0].Name)
+	assert.Equal(t, "../lib", deps[0].Metadata[MetadataRequirementsPath])
+	assert.Equal(t, "flask", deps[1].Name)
+}
@@ -75,7 +75,7 @@ func (p *RustParser) ParseCargoToml(content string) (string, string, []types.Dep
 		}
 	}
 
-	return projectName, license, dependencies, isWorkspace
+	return projectName, license, NormalizeDependencyVersions(dependencies), isWorkspace
 }
 
 // shouldSkipLine checks if a line should be skipped (empty or comment)
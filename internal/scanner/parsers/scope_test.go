@@ -53,7 +53,7 @@ func TestScopeJSONMarshaling(t *testing.T) {
 	if len(arrMaven) != 6 {
 		t.Errorf("Expected 6 elements for Maven dep, got %d: %v", len(arrMaven), arrMaven)
 	}
-	if arrMaven[3] != types.ScopeDev {
+	if arrMaven[3] != string(types.ScopeDev) {
 		t.Errorf("Expected scope 'dev' at index 3, got '%v'", arrMaven[3])
 	}
 	if arrMaven[4] != true {
@@ -67,7 +67,7 @@ func TestScopeJSONMarshaling(t *testing.T) {
 	if len(arrNPM) != 6 {
 		t.Errorf("Expected 6 elements for NPM dep, got %d: %v", len(arrNPM), arrNPM)
 	}
-	if arrNPM[3] != types.ScopeProd {
+	if arrNPM[3] != string(types.ScopeProd) {
 		t.Errorf("Expected scope 'prod' at index 3, got '%v'", arrNPM[3])
 	}
 	if arrNPM[4] != true {
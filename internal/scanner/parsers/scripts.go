@@ -0,0 +1,433 @@
+package parsers
+
+import (
+	"bufio"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Compile script parsing regexes once at package level for performance
+var (
+	scriptSeparatorRegex = regexp.MustCompile("&&|\\|\\||\\$\\(|[;|&`]") // Command separators, pipes, and command substitutions
+	envAssignmentRegex   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*=`)
+	makeTargetRegex      = regexp.MustCompile(`^([A-Za-z0-9_.\-/%]+(?:\s+[A-Za-z0-9_.\-/%]+)*)\s*:([^=]|$)`)
+)
+
+// scriptToolPackages maps tool commands invoked from scripts to the npm package providing them.
+// Tools are attributed via the npm dependency rules even when installed globally or run via npx.
+var scriptToolPackages = map[string]string{
+	"tsc":            "typescript",
+	"webpack":        "webpack",
+	"webpack-cli":    "webpack",
+	"vite":           "vite",
+	"eslint":         "eslint",
+	"prettier":       "prettier",
+	"playwright":     "@playwright/test",
+	"jest":           "jest",
+	"vitest":         "vitest",
+	"rollup":         "rollup",
+	"esbuild":        "esbuild",
+	"babel":          "@babel/core",
+	"next":           "next",
+	"nuxt":           "nuxt",
+	"ng":             "@angular/cli",
+	"react-scripts":  "react-scripts",
+	"turbo":          "turbo",
+	"nx":             "nx",
+	"storybook":      "storybook",
+	"parcel":         "parcel",
+	"rspack":         "@rspack/core",
+	"swc":            "@swc/core",
+	"cypress":        "cypress",
+	"mocha":          "mocha",
+	"stylelint":      "stylelint",
+	"biome":          "@biomejs/biome",
+	"lerna":          "lerna",
+	"ts-node":        "ts-node",
+	"nodemon":        "nodemon",
+	"tailwindcss":    "tailwindcss",
+	"astro":          "astro",
+	"svelte-kit":     "@sveltejs/kit",
+	"electron":       "electron",
+	"electron-forge": "@electron-forge/cli",
+}
+
+// commandWrappers are launchers whose first non-flag argument is the actual command
+var commandWrappers = map[string]bool{
+	"npx":       true,
+	"pnpx":      true,
+	"bunx":      true,
+	"cross-env": true,
+	"env":       true,
+	"time":      true,
+	"exec":      true,
+	"sudo":      true,
+}
+
+// shellKeywords precede the command of a shell statement ("if docker ps", "do kubectl apply")
+var shellKeywords = map[string]bool{
+	"if":    true,
+	"then":  true,
+	"else":  true,
+	"elif":  true,
+	"do":    true,
+	"while": true,
+	"until": true,
+	"!":     true,
+	"{":     true,
+}
+
+// invokedTools maps commands of well-known infrastructure tools to their technology.
+// They are reported when invoked from Makefiles or shell scripts without being declared in a manifest.
+var invokedTools = map[string]string{
+	"docker":           "docker",
+	"docker-compose":   "docker",
+	"kubectl":          "kubernetes",
+	"helm":             "helm",
+	"terraform":        "terraform",
+	"terragrunt":       "terragrunt",
+	"protoc":           "protobuf",
+	"buf":              "buf",
+	"gcloud":           "gcp",
+	"gsutil":           "gcp",
+	"aws":              "aws",
+	"az":               "azure",
+	"cdk":              "aws.cdk",
+	"pulumi":           "pulumi",
+	"packer":           "packer",
+	"vagrant":          "vagrant",
+	"ansible":          "ansible",
+	"ansible-playbook": "ansible",
+	"ansible-galaxy":   "ansible",
+}
+
+// maxEvidenceLength bounds the length of evidence lines recorded for tool invocations
+const maxEvidenceLength = 120
+
+// packageManagers run a binary when followed by one of the exec subcommands
+var packageManagers = map[string]map[string]bool{
+	"npm":  {"exec": true, "x": true},
+	"pnpm": {"exec": true, "dlx": true},
+	"yarn": {"exec": true, "dlx": true},
+	"bun":  {"x": true},
+}
+
+// ScriptsParser extracts invoked tools from npm scripts, Makefiles, and Taskfiles
+type ScriptsParser struct{}
+
+// NewScriptsParser creates a new scripts parser
+func NewScriptsParser() *ScriptsParser {
+	return &ScriptsParser{}
+}
+
+// ScriptToolUsage maps a tool command to the names of the scripts, targets, or tasks invoking it
+type ScriptToolUsage map[string][]string
+
+// Tools returns the tool commands in sorted order
+func (u ScriptToolUsage) Tools() []string {
+	tools := make([]string, 0, len(u))
+	for tool := range u {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+	return tools
+}
+
+// ToolPackageName returns the npm package providing a tool command (the command itself for scoped packages)
+func ToolPackageName(tool string) string {
+	if pkg, ok := scriptToolPackages[tool]; ok {
+		return pkg
+	}
+	return tool
+}
+
+// DetectTools finds known build tools invoked by the given scripts (script name -> command line)
+func (p *ScriptsParser) DetectTools(scripts map[string]string) ScriptToolUsage {
+	usage := make(ScriptToolUsage)
+	names := make([]string, 0, len(scripts))
+	for name := range scripts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, command := range p.ExtractCommands(scripts[name]) {
+			if !isKnownTool(command) || containsString(usage[command], name) {
+				continue
+			}
+			usage[command] = append(usage[command], name)
+		}
+	}
+	return usage
+}
+
+// ExtractCommands returns the command names invoked by a shell command line.
+// Environment assignments, launchers (npx, cross-env, pnpm exec, ...) and version
+// suffixes (tsc@5) are skipped; paths like ./node_modules/.bin/tsc are reduced to the binary name.
+func (p *ScriptsParser) ExtractCommands(line string) []string {
+	var commands []string
+	for _, segment := range scriptSeparatorRegex.Split(line, -1) {
+		if command := segmentCommand(strings.Fields(segment)); command != "" {
+			commands = append(commands, command)
+		}
+	}
+	return commands
+}
+
+// segmentCommand resolves the effective command of a single shell command
+func segmentCommand(fields []string) string {
+	for i := 0; i < len(fields); i++ {
+		field := strings.Trim(fields[i], "\"'()")
+		switch {
+		case field == "" || strings.HasPrefix(field, "-") || envAssignmentRegex.MatchString(field):
+			continue
+		case commandWrappers[field] || shellKeywords[field]:
+			continue
+		case packageManagers[field] != nil:
+			if i+1 < len(fields) && packageManagers[field][fields[i+1]] {
+				i++
+				continue
+			}
+			return ""
+		}
+		return normalizeCommand(field)
+	}
+	return ""
+}
+
+// normalizeCommand strips paths and version suffixes from a command
+func normalizeCommand(command string) string {
+	if strings.HasPrefix(command, "@") {
+		// Scoped package (npx @biomejs/biome): keep scope/name, drop version
+		if idx := strings.LastIndex(command, "@"); idx > 0 {
+			command = command[:idx]
+		}
+		return command
+	}
+	command = path.Base(command)
+	if idx := strings.Index(command, "@"); idx > 0 {
+		command = command[:idx]
+	}
+	return command
+}
+
+// isKnownTool reports whether a command maps to a known build tool
+func isKnownTool(command string) bool {
+	if _, ok := scriptToolPackages[command]; ok {
+		return true
+	}
+	for _, pkg := range scriptToolPackages {
+		if pkg == command {
+			return true
+		}
+	}
+	return false
+}
+
+// ToolInvocation is the first invocation of a well-known tool in a Makefile or shell script
+type ToolInvocation struct {
+	Tool        string `json:"tool"`
+	Tech        string `json:"tech"`
+	File        string `json:"file"`
+	Line        int    `json:"line"`        // Line number of the first invocation
+	Command     string `json:"command"`     // Evidence: the invoking line (truncated)
+	Occurrences int    `json:"occurrences"` // Number of lines invoking the tool
+}
+
+// FindToolInvocations finds invocations of well-known infrastructure tools (docker, kubectl,
+// terraform, protoc, gcloud, aws, ...). For Makefiles only recipe lines are scanned.
+// Results are ordered by first occurrence.
+func (p *ScriptsParser) FindToolInvocations(content string, makefile bool) []ToolInvocation {
+	var invocations []ToolInvocation
+	index := make(map[string]int)
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		if makefile {
+			if !strings.HasPrefix(line, "\t") {
+				continue
+			}
+			line = strings.TrimLeft(strings.TrimSpace(line), "@-+")
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		seen := make(map[string]bool)
+		for _, command := range p.ExtractCommands(line) {
+			tech, ok := invokedTools[command]
+			if !ok || seen[command] {
+				continue
+			}
+			seen[command] = true
+			if i, exists := index[command]; exists {
+				invocations[i].Occurrences++
+				continue
+			}
+			index[command] = len(invocations)
+			invocations = append(invocations, ToolInvocation{
+				Tool:        command,
+				Tech:        tech,
+				Line:        lineNumber,
+				Command:     truncateEvidence(line),
+				Occurrences: 1,
+			})
+		}
+	}
+	return invocations
+}
+
+// truncateEvidence shortens an evidence line to maxEvidenceLength characters
+func truncateEvidence(line string) string {
+	if len(line) <= maxEvidenceLength {
+		return line
+	}
+	return line[:maxEvidenceLength] + "..."
+}
+
+// MakefileInfo holds targets and tool usage parsed from a Makefile
+type MakefileInfo struct {
+	File    string          `json:"file,omitempty"`
+	Targets []string        `json:"targets"`
+	Tools   []string        `json:"tools,omitempty"`
+	Usage   ScriptToolUsage `json:"-"`
+}
+
+// ParseMakefile extracts target names and the tools invoked by their recipes.
+// Special targets (.PHONY, .DEFAULT, ...) and pattern rules (%.o) are not reported as targets.
+func (p *ScriptsParser) ParseMakefile(content string) *MakefileInfo {
+	info := &MakefileInfo{Targets: []string{}}
+	recipes := make(map[string]string)
+	var current []string
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "\t") {
+			recipe := strings.TrimLeft(strings.TrimSpace(line), "@-+")
+			for _, target := range current {
+				recipes[target] += recipe + "\n"
+			}
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		match := makeTargetRegex.FindStringSubmatch(line)
+		if match == nil {
+			current = nil
+			continue
+		}
+		current = nil
+		for _, target := range strings.Fields(match[1]) {
+			if strings.HasPrefix(target, ".") || strings.Contains(target, "%") {
+				continue
+			}
+			if !containsString(info.Targets, target) {
+				info.Targets = append(info.Targets, target)
+			}
+			current = append(current, target)
+		}
+	}
+
+	info.Usage = p.detectToolsInRecipes(recipes)
+	info.Tools = info.Usage.Tools()
+	return info
+}
+
+// TaskfileInfo holds tasks and tool usage parsed from a Taskfile
+type TaskfileInfo struct {
+	File  string          `json:"file,omitempty"`
+	Tasks []string        `json:"tasks"`
+	Tools []string        `json:"tools,omitempty"`
+	Usage ScriptToolUsage `json:"-"`
+}
+
+// taskfile represents the subset of a Taskfile.yml we use
+type taskfile struct {
+	Tasks map[string]interface{} `yaml:"tasks"`
+}
+
+// ParseTaskfile extracts task names and the tools invoked by their commands
+func (p *ScriptsParser) ParseTaskfile(content string) (*TaskfileInfo, error) {
+	var tf taskfile
+	if err := yaml.Unmarshal([]byte(content), &tf); err != nil {
+		return nil, err
+	}
+
+	info := &TaskfileInfo{Tasks: make([]string, 0, len(tf.Tasks))}
+	commands := make(map[string]string)
+	for name, task := range tf.Tasks {
+		info.Tasks = append(info.Tasks, name)
+		commands[name] = strings.Join(taskCommands(task), "\n")
+	}
+	sort.Strings(info.Tasks)
+
+	info.Usage = p.detectToolsInRecipes(commands)
+	info.Tools = info.Usage.Tools()
+	return info, nil
+}
+
+// taskCommands returns the shell commands of a task (string shorthand, cmds list, or cmd entries)
+func taskCommands(task interface{}) []string {
+	switch t := task.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		return cmdList(t)
+	case map[string]interface{}:
+		var commands []string
+		if cmd, ok := t["cmd"].(string); ok {
+			commands = append(commands, cmd)
+		}
+		if cmds, ok := t["cmds"].([]interface{}); ok {
+			commands = append(commands, cmdList(cmds)...)
+		}
+		return commands
+	}
+	return nil
+}
+
+// cmdList extracts commands from a Taskfile cmds list (strings or {cmd: ...} objects)
+func cmdList(cmds []interface{}) []string {
+	var commands []string
+	for _, entry := range cmds {
+		switch c := entry.(type) {
+		case string:
+			commands = append(commands, c)
+		case map[string]interface{}:
+			if cmd, ok := c["cmd"].(string); ok {
+				commands = append(commands, cmd)
+			}
+		}
+	}
+	return commands
+}
+
+// detectToolsInRecipes detects tools in multi-line recipes (one command per line)
+func (p *ScriptsParser) detectToolsInRecipes(recipes map[string]string) ScriptToolUsage {
+	lines := make(map[string]string, len(recipes))
+	for name, recipe := range recipes {
+		lines[name] = strings.ReplaceAll(recipe, "\n", " ; ")
+	}
+	return p.DetectTools(lines)
+}
+
+// containsString reports whether list contains value
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
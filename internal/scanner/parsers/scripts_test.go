@@ -0,0 +1,154 @@
+package parsers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptsParser_ExtractCommands(t *testing.T) {
+	parser := NewScriptsParser()
+
+	tests := []struct {
+		line     string
+		expected []string
+	}{
+		{"tsc", []string{"tsc"}},
+		{"tsc -p . && vite build", []string{"tsc", "vite"}},
+		{"npx eslint@8 src", []string{"eslint"}},
+		{"npx --yes @biomejs/biome@1.5.0 check", []string{"@biomejs/biome"}},
+		{"cross-env NODE_ENV=production webpack --mode production", []string{"webpack"}},
+		{"NODE_ENV=test jest --coverage", []string{"jest"}},
+		{"./node_modules/.bin/tsc --noEmit", []string{"tsc"}},
+		{"pnpm exec playwright test", []string{"playwright"}},
+		{"yarn dlx prettier --check .", []string{"prettier"}},
+		{"npm run build", nil},
+		{"rimraf dist; rollup -c | tee log", []string{"rimraf", "rollup", "tee"}},
+		{"if docker info >/dev/null; then sudo kubectl apply -f k8s; fi", []string{"docker", "kubectl", "fi"}},
+		{"TOKEN=$(gcloud auth print-access-token)", []string{"gcloud"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parser.ExtractCommands(tt.line))
+		})
+	}
+}
+
+func TestScriptsParser_DetectTools(t *testing.T) {
+	usage := NewScriptsParser().DetectTools(map[string]string{
+		"build":     "tsc && vite build",
+		"typecheck": "tsc --noEmit",
+		"dev":       "vite",
+		"clean":     "rimraf dist",
+	})
+
+	assert.Equal(t, []string{"tsc", "vite"}, usage.Tools())
+	assert.Equal(t, []string{"build", "typecheck"}, usage["tsc"])
+	assert.Equal(t, []string{"build", "dev"}, usage["vite"])
+}
+
+func TestToolPackageName(t *testing.T) {
+	assert.Equal(t, "typescript", ToolPackageName("tsc"))
+	assert.Equal(t, "@playwright/test", ToolPackageName("playwright"))
+	assert.Equal(t, "@biomejs/biome", ToolPackageName("@biomejs/biome"))
+}
+
+func TestScriptsParser_ParseMakefile(t *testing.T) {
+	content := `CC := gcc
+.PHONY: build lint test
+
+# Build the frontend
+build: deps
+	@npx tsc -p frontend
+	cd frontend && npx webpack
+
+lint test:
+	-eslint src
+
+%.o: %.c
+	$(CC) -c $<
+
+deps:
+	npm ci
+`
+	info := NewScriptsParser().ParseMakefile(content)
+
+	assert.Equal(t, []string{"build", "lint", "test", "deps"}, info.Targets)
+	assert.Equal(t, []string{"eslint", "tsc", "webpack"}, info.Tools)
+	assert.Equal(t, []string{"lint", "test"}, info.Usage["eslint"])
+	assert.Equal(t, []string{"build"}, info.Usage["webpack"])
+}
+
+func TestScriptsParser_FindToolInvocations_Makefile(t *testing.T) {
+	content := `# docker is required
+image:
+	@docker build -t app .
+	docker push app
+
+deploy: image
+	-kubectl apply -f k8s/ && helm upgrade app ./chart
+
+proto:
+	protoc --go_out=. api.proto
+`
+	invocations := NewScriptsParser().FindToolInvocations(content, true)
+	require.Len(t, invocations, 4)
+
+	assert.Equal(t, ToolInvocation{Tool: "docker", Tech: "docker", Line: 3, Command: "docker build -t app .", Occurrences: 2}, invocations[0])
+	assert.Equal(t, "kubectl", invocations[1].Tool)
+	assert.Equal(t, "kubernetes", invocations[1].Tech)
+	assert.Equal(t, 7, invocations[1].Line)
+	assert.Equal(t, "helm", invocations[2].Tool)
+	assert.Equal(t, "protobuf", invocations[3].Tech)
+}
+
+func TestScriptsParser_FindToolInvocations_Script(t *testing.T) {
+	content := `#!/usr/bin/env bash
+set -euo pipefail
+# terraform apply is run by CI
+aws s3 cp build/ s3://bucket --recursive
+if ! gsutil ls gs://bucket; then
+  echo "missing bucket"
+fi
+terraform -chdir=infra plan -var "image=` + strings.Repeat("x", 150) + `"
+`
+	invocations := NewScriptsParser().FindToolInvocations(content, false)
+	require.Len(t, invocations, 3)
+
+	assert.Equal(t, "aws", invocations[0].Tool)
+	assert.Equal(t, 4, invocations[0].Line)
+	assert.Equal(t, "gsutil", invocations[1].Tool)
+	assert.Equal(t, "gcp", invocations[1].Tech)
+	assert.Equal(t, "terraform", invocations[2].Tool)
+	assert.Equal(t, 8, invocations[2].Line)
+	assert.Len(t, invocations[2].Command, maxEvidenceLength+len("..."))
+}
+
+func TestScriptsParser_ParseTaskfile(t *testing.T) {
+	content := `version: '3'
+tasks:
+  build:
+    desc: Build the app
+    cmds:
+      - vite build
+      - cmd: tsc --noEmit
+      - task: lint
+  lint: eslint .
+  test:
+    cmd: npx vitest run
+`
+	info, err := NewScriptsParser().ParseTaskfile(content)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"build", "lint", "test"}, info.Tasks)
+	assert.Equal(t, []string{"eslint", "tsc", "vite", "vitest"}, info.Tools)
+	assert.Equal(t, []string{"build"}, info.Usage["tsc"])
+}
+
+func TestScriptsParser_ParseTaskfile_Invalid(t *testing.T) {
+	_, err := NewScriptsParser().ParseTaskfile("tasks: [")
+	assert.Error(t, err)
+}
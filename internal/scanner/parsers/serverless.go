@@ -0,0 +1,309 @@
+package parsers
+
+import (
+	_ "embed"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed lambda_runtimes.yaml
+var lambdaRuntimeData []byte
+
+// Serverless application frameworks
+const (
+	ServerlessFrameworkServerless     = "serverless"
+	ServerlessFrameworkSAM            = "sam"
+	ServerlessFrameworkCloudFormation = "cloudformation"
+)
+
+// lambdaRuntimeRegex matches Lambda runtime identifiers (nodejs20.x, python3.12, provided.al2023);
+// other values are unresolved references such as !Ref parameters
+var lambdaRuntimeRegex = regexp.MustCompile(`^[a-z]+[0-9][a-z0-9.]*$|^provided(\.al2(023)?)?$`)
+
+// samTransform marks a CloudFormation template as an AWS SAM template
+const samTransform = "AWS::Serverless-2016-10-31"
+
+var (
+	lambdaRuntimesOnce sync.Once
+	lambdaRuntimes     map[string]string // runtime -> deprecation date
+)
+
+// loadLambdaRuntimes parses the embedded runtime data once
+func loadLambdaRuntimes() map[string]string {
+	lambdaRuntimesOnce.Do(func() {
+		var data struct {
+			Runtimes map[string]struct {
+				Deprecation string `yaml:"deprecation"`
+			} `yaml:"runtimes"`
+		}
+		lambdaRuntimes = map[string]string{}
+		if err := yaml.Unmarshal(lambdaRuntimeData, &data); err != nil {
+			return
+		}
+		for runtime, entry := range data.Runtimes {
+			lambdaRuntimes[runtime] = entry.Deprecation
+		}
+	})
+	return lambdaRuntimes
+}
+
+// LambdaRuntimeSupport describes the deprecation status of a Lambda runtime
+type LambdaRuntimeSupport struct {
+	Runtime     string `json:"runtime"`
+	Deprecation string `json:"deprecation,omitempty"` // YYYY-MM-DD, empty if no date is announced
+	Deprecated  bool   `json:"deprecated"`
+}
+
+// LookupLambdaRuntime returns the deprecation status of a Lambda runtime identifier
+// (e.g., "nodejs18.x") as of the given date. Returns nil for unknown runtimes.
+func LookupLambdaRuntime(runtime string, asOf time.Time) *LambdaRuntimeSupport {
+	deprecation, ok := loadLambdaRuntimes()[runtime]
+	if !ok {
+		return nil
+	}
+	support := &LambdaRuntimeSupport{Runtime: runtime, Deprecation: deprecation}
+	if deprecation != "" {
+		if date, err := time.Parse("2006-01-02", deprecation); err == nil {
+			support.Deprecated = !asOf.Before(date)
+		}
+	}
+	return support
+}
+
+// ServerlessParser handles Serverless Framework and AWS SAM/CloudFormation template parsing
+type ServerlessParser struct{}
+
+// NewServerlessParser creates a new serverless parser
+func NewServerlessParser() *ServerlessParser {
+	return &ServerlessParser{}
+}
+
+// ServerlessInfo describes a serverless application definition
+type ServerlessInfo struct {
+	Framework        string                 `json:"framework"` // serverless, sam, or cloudformation
+	File             string                 `json:"file"`
+	Service          string                 `json:"service,omitempty"`
+	Provider         string                 `json:"provider,omitempty"`
+	FrameworkVersion string                 `json:"framework_version,omitempty"`
+	Runtimes         []string               `json:"runtimes,omitempty"`
+	Functions        []ServerlessFunction   `json:"functions,omitempty"`
+	EventSources     []string               `json:"event_sources,omitempty"`
+	Plugins          []ServerlessPlugin     `json:"plugins,omitempty"`
+	RuntimeSupport   []LambdaRuntimeSupport `json:"runtime_support,omitempty"`
+}
+
+// ServerlessFunction is a function with its runtime and event sources
+type ServerlessFunction struct {
+	Name    string   `json:"name"`
+	Runtime string   `json:"runtime,omitempty"`
+	Handler string   `json:"handler,omitempty"`
+	Events  []string `json:"events,omitempty"`
+}
+
+// ServerlessPlugin is a Serverless Framework plugin with its declared version
+type ServerlessPlugin struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// serverlessYAML represents the subset of serverless.yml we use
+type serverlessYAML struct {
+	Service          interface{} `yaml:"service"`
+	FrameworkVersion string      `yaml:"frameworkVersion"`
+	Provider         struct {
+		Name    string `yaml:"name"`
+		Runtime string `yaml:"runtime"`
+	} `yaml:"provider"`
+	Functions map[string]struct {
+		Handler string                   `yaml:"handler"`
+		Runtime string                   `yaml:"runtime"`
+		Image   interface{}              `yaml:"image"`
+		Events  []map[string]interface{} `yaml:"events"`
+	} `yaml:"functions"`
+	Plugins interface{} `yaml:"plugins"`
+}
+
+// ParseServerlessYAML parses a Serverless Framework configuration. Functions inherit the
+// provider runtime; values using unresolved variables (${...}) are ignored.
+func (p *ServerlessParser) ParseServerlessYAML(content string) (*ServerlessInfo, error) {
+	var raw serverlessYAML
+	if err := yaml.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, err
+	}
+
+	info := &ServerlessInfo{
+		Framework:        ServerlessFrameworkServerless,
+		Provider:         raw.Provider.Name,
+		FrameworkVersion: raw.FrameworkVersion,
+	}
+	switch service := raw.Service.(type) {
+	case string:
+		info.Service = service
+	case map[string]interface{}: // Legacy form: service: {name: ...}
+		info.Service, _ = service["name"].(string)
+	}
+
+	for name, fn := range raw.Functions {
+		function := ServerlessFunction{Name: name, Handler: fn.Handler, Runtime: resolvedValue(fn.Runtime)}
+		if fn.Runtime == "" && fn.Image == nil {
+			function.Runtime = resolvedValue(raw.Provider.Runtime)
+		}
+		for _, event := range fn.Events {
+			for eventType := range event {
+				function.Events = append(function.Events, eventType)
+			}
+		}
+		info.Functions = append(info.Functions, function)
+	}
+
+	switch plugins := raw.Plugins.(type) {
+	case []interface{}:
+		info.Plugins = serverlessPlugins(plugins)
+	case map[string]interface{}: // plugins: {localPath: ..., modules: [...]}
+		if modules, ok := plugins["modules"].([]interface{}); ok {
+			info.Plugins = serverlessPlugins(modules)
+		}
+	}
+
+	info.normalize()
+	return info, nil
+}
+
+// serverlessPlugins converts a plugin list to plugins (versions are resolved from package.json later)
+func serverlessPlugins(list []interface{}) []ServerlessPlugin {
+	var plugins []ServerlessPlugin
+	for _, item := range list {
+		if name, ok := item.(string); ok && name != "" {
+			plugins = append(plugins, ServerlessPlugin{Name: name})
+		}
+	}
+	return plugins
+}
+
+// cloudFormationTemplate represents the subset of a CloudFormation/SAM template we use
+type cloudFormationTemplate struct {
+	FormatVersion interface{} `yaml:"AWSTemplateFormatVersion"`
+	Transform     interface{} `yaml:"Transform"`
+	Globals       struct {
+		Function struct {
+			Runtime string `yaml:"Runtime"`
+		} `yaml:"Function"`
+	} `yaml:"Globals"`
+	Resources map[string]struct {
+		Type       string `yaml:"Type"`
+		Properties struct {
+			Runtime     string                 `yaml:"Runtime"`
+			Handler     string                 `yaml:"Handler"`
+			PackageType string                 `yaml:"PackageType"`
+			Events      map[string]interface{} `yaml:"Events"`
+		} `yaml:"Properties"`
+	} `yaml:"Resources"`
+}
+
+// ParseCloudFormationTemplate parses an AWS SAM or CloudFormation template (YAML or JSON) and
+// returns its Lambda functions. Returns nil if the content is not a template with functions.
+func (p *ServerlessParser) ParseCloudFormationTemplate(content string) *ServerlessInfo {
+	var raw cloudFormationTemplate
+	if err := yaml.Unmarshal([]byte(content), &raw); err != nil {
+		return nil
+	}
+
+	info := &ServerlessInfo{Framework: ServerlessFrameworkCloudFormation, Provider: "aws"}
+	for _, transform := range stringOrKeys(raw.Transform) {
+		if transform == samTransform {
+			info.Framework = ServerlessFrameworkSAM
+		}
+	}
+	if raw.FormatVersion == nil && info.Framework != ServerlessFrameworkSAM {
+		return nil
+	}
+
+	for name, resource := range raw.Resources {
+		switch resource.Type {
+		case "AWS::Serverless::Function", "AWS::Lambda::Function":
+		default:
+			continue
+		}
+		props := resource.Properties
+		function := ServerlessFunction{Name: name, Handler: props.Handler, Runtime: props.Runtime}
+		if function.Runtime == "" && props.PackageType != "Image" && resource.Type == "AWS::Serverless::Function" {
+			function.Runtime = raw.Globals.Function.Runtime
+		}
+		for _, event := range props.Events {
+			if e, ok := event.(map[string]interface{}); ok {
+				if eventType, ok := e["Type"].(string); ok {
+					function.Events = append(function.Events, strings.ToLower(eventType))
+				}
+			}
+		}
+		info.Functions = append(info.Functions, function)
+	}
+	if len(info.Functions) == 0 {
+		return nil
+	}
+
+	info.normalize()
+	return info
+}
+
+// ApplyPluginVersions sets plugin versions from the dependencies declared in package.json
+func (info *ServerlessInfo) ApplyPluginVersions(versions map[string]string) {
+	for i := range info.Plugins {
+		if version, ok := versions[info.Plugins[i].Name]; ok {
+			info.Plugins[i].Version = version
+		}
+	}
+}
+
+// CheckRuntimes records the deprecation status of every known runtime as of the given date
+func (info *ServerlessInfo) CheckRuntimes(asOf time.Time) {
+	info.RuntimeSupport = nil
+	for _, runtime := range info.Runtimes {
+		if support := LookupLambdaRuntime(runtime, asOf); support != nil {
+			info.RuntimeSupport = append(info.RuntimeSupport, *support)
+		}
+	}
+}
+
+// DeprecatedRuntimes returns the runtimes found to be deprecated by CheckRuntimes
+func (info *ServerlessInfo) DeprecatedRuntimes() []LambdaRuntimeSupport {
+	var deprecated []LambdaRuntimeSupport
+	for _, support := range info.RuntimeSupport {
+		if support.Deprecated {
+			deprecated = append(deprecated, support)
+		}
+	}
+	return deprecated
+}
+
+// normalize drops unresolved runtimes and sorts all lists for deterministic output
+func (info *ServerlessInfo) normalize() {
+	var runtimes, events []string
+	for i := range info.Functions {
+		fn := &info.Functions[i]
+		fn.Events = sortedUnique(fn.Events)
+		if !lambdaRuntimeRegex.MatchString(fn.Runtime) {
+			fn.Runtime = ""
+		}
+		runtimes = append(runtimes, fn.Runtime)
+		events = append(events, fn.Events...)
+	}
+	info.Runtimes = sortedUnique(runtimes)
+	info.EventSources = sortedUnique(events)
+	sort.Slice(info.Functions, func(i, j int) bool {
+		return info.Functions[i].Name < info.Functions[j].Name
+	})
+}
+
+// resolvedValue returns value unless it references an unresolved variable
+func resolvedValue(value string) string {
+	if strings.Contains(value, "${") {
+		return ""
+	}
+	return value
+}
@@ -0,0 +1,192 @@
+package parsers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupLambdaRuntime(t *testing.T) {
+	asOf := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	support := LookupLambdaRuntime("nodejs16.x", asOf)
+	require.NotNil(t, support)
+	assert.Equal(t, "2024-06-12", support.Deprecation)
+	assert.True(t, support.Deprecated)
+
+	support = LookupLambdaRuntime("nodejs20.x", asOf)
+	require.NotNil(t, support)
+	assert.False(t, support.Deprecated)
+
+	support = LookupLambdaRuntime("python3.8", time.Date(2024, 10, 14, 0, 0, 0, 0, time.UTC))
+	require.NotNil(t, support)
+	assert.True(t, support.Deprecated, "deprecated on the deprecation date itself")
+
+	assert.Nil(t, LookupLambdaRuntime("cobol1.0", asOf))
+}
+
+func TestParseServerlessYAML(t *testing.T) {
+	content := `service: orders
+frameworkVersion: "3"
+provider:
+  name: aws
+  runtime: nodejs16.x
+functions:
+  create:
+    handler: src/create.handler
+    events:
+      - httpApi:
+          path: /orders
+          method: post
+  process:
+    handler: src/process.handler
+    runtime: python3.12
+    events:
+      - sqs:
+          arn: arn:aws:sqs:eu-west-1:123456789012:orders
+      - schedule: rate(5 minutes)
+  container:
+    image: orders:latest
+  dynamic:
+    handler: src/dynamic.handler
+    runtime: ${self:custom.runtime}
+plugins:
+  - serverless-offline
+  - serverless-esbuild
+`
+	info, err := NewServerlessParser().ParseServerlessYAML(content)
+	require.NoError(t, err)
+
+	assert.Equal(t, ServerlessFrameworkServerless, info.Framework)
+	assert.Equal(t, "orders", info.Service)
+	assert.Equal(t, "aws", info.Provider)
+	assert.Equal(t, "3", info.FrameworkVersion)
+	assert.Equal(t, []string{"nodejs16.x", "python3.12"}, info.Runtimes)
+	assert.Equal(t, []string{"httpApi", "schedule", "sqs"}, info.EventSources)
+
+	require.Len(t, info.Functions, 4)
+	assert.Equal(t, "container", info.Functions[0].Name)
+	assert.Empty(t, info.Functions[0].Runtime, "image functions do not inherit the provider runtime")
+	assert.Equal(t, "create", info.Functions[1].Name)
+	assert.Equal(t, "nodejs16.x", info.Functions[1].Runtime)
+	assert.Equal(t, "dynamic", info.Functions[2].Name)
+	assert.Empty(t, info.Functions[2].Runtime, "unresolved variables are ignored")
+	assert.Equal(t, []string{"schedule", "sqs"}, info.Functions[3].Events)
+
+	info.ApplyPluginVersions(map[string]string{"serverless-offline": "^13.3.0"})
+	assert.Equal(t, []ServerlessPlugin{
+		{Name: "serverless-offline", Version: "^13.3.0"},
+		{Name: "serverless-esbuild"},
+	}, info.Plugins)
+}
+
+func TestParseServerlessYAML_LegacyForms(t *testing.T) {
+	content := `service:
+  name: legacy
+provider:
+  name: aws
+  runtime: nodejs8.10
+functions:
+  hello:
+    handler: handler.hello
+plugins:
+  localPath: ./plugins
+  modules:
+    - serverless-webpack
+`
+	info, err := NewServerlessParser().ParseServerlessYAML(content)
+	require.NoError(t, err)
+	assert.Equal(t, "legacy", info.Service)
+	assert.Equal(t, []ServerlessPlugin{{Name: "serverless-webpack"}}, info.Plugins)
+
+	info.CheckRuntimes(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	deprecated := info.DeprecatedRuntimes()
+	require.Len(t, deprecated, 1)
+	assert.Equal(t, "nodejs8.10", deprecated[0].Runtime)
+}
+
+func TestParseServerlessYAML_Invalid(t *testing.T) {
+	_, err := NewServerlessParser().ParseServerlessYAML("service: [unclosed")
+	assert.Error(t, err)
+}
+
+func TestParseCloudFormationTemplate_SAM(t *testing.T) {
+	content := `AWSTemplateFormatVersion: '2010-09-09'
+Transform: AWS::Serverless-2016-10-31
+Parameters:
+  Runtime:
+    Type: String
+Globals:
+  Function:
+    Runtime: python3.9
+Resources:
+  ApiFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      Handler: app.handler
+      Events:
+        Api:
+          Type: Api
+          Properties:
+            Path: /items
+            Method: get
+        Nightly:
+          Type: Schedule
+  WorkerFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      Handler: worker.handler
+      Runtime: !Ref Runtime
+      Events:
+        Queue:
+          Type: SQS
+  ImageFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      PackageType: Image
+  Bucket:
+    Type: AWS::S3::Bucket
+`
+	info := NewServerlessParser().ParseCloudFormationTemplate(content)
+	require.NotNil(t, info)
+
+	assert.Equal(t, ServerlessFrameworkSAM, info.Framework)
+	assert.Equal(t, "aws", info.Provider)
+	assert.Equal(t, []string{"python3.9"}, info.Runtimes)
+	assert.Equal(t, []string{"api", "schedule", "sqs"}, info.EventSources)
+
+	require.Len(t, info.Functions, 3)
+	assert.Equal(t, "ApiFunction", info.Functions[0].Name)
+	assert.Equal(t, "python3.9", info.Functions[0].Runtime)
+	assert.Empty(t, info.Functions[1].Runtime, "image functions have no runtime")
+	assert.Empty(t, info.Functions[2].Runtime, "!Ref runtimes are unresolved")
+}
+
+func TestParseCloudFormationTemplate_PlainLambda(t *testing.T) {
+	content := `{
+  "AWSTemplateFormatVersion": "2010-09-09",
+  "Resources": {
+    "Fn": {
+      "Type": "AWS::Lambda::Function",
+      "Properties": {"Runtime": "java8", "Handler": "com.example.Handler"}
+    }
+  }
+}`
+	info := NewServerlessParser().ParseCloudFormationTemplate(content)
+	require.NotNil(t, info)
+	assert.Equal(t, ServerlessFrameworkCloudFormation, info.Framework)
+	assert.Equal(t, []string{"java8"}, info.Runtimes)
+}
+
+func TestParseCloudFormationTemplate_NotATemplate(t *testing.T) {
+	parser := NewServerlessParser()
+
+	assert.Nil(t, parser.ParseCloudFormationTemplate("name: my-chart\nversion: 1.0.0\n"))
+	assert.Nil(t, parser.ParseCloudFormationTemplate(`AWSTemplateFormatVersion: '2010-09-09'
+Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+`), "templates without functions are skipped")
+}
@@ -0,0 +1,276 @@
+package parsers
+
+import (
+	"bufio"
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Static site generator names (match the technology rule names)
+const (
+	SSGHugo       = "hugo"
+	SSGJekyll     = "jekyll"
+	SSGGatsby     = "gatsby"
+	SSGAstro      = "astro"
+	SSGEleventy   = "eleventy"
+	SSGDocusaurus = "docusaurus"
+)
+
+// Static site types
+const (
+	SiteTypeDocumentation = "documentation"
+	SiteTypeWebsite       = "website"
+)
+
+// Compile static site regexes once at package level for performance
+var (
+	tomlKeyValueRegex   = regexp.MustCompile(`^([A-Za-z0-9_]+)\s*=\s*(.+)$`)
+	tomlQuotedRegex     = regexp.MustCompile(`["']([^"']+)["']`)
+	hugoVersionEnvRegex = regexp.MustCompile(`HUGO_VERSION\s*[=:]\s*["']?v?([0-9][0-9.]*)`)
+)
+
+// NPMSiteGenerator describes how an npm-based generator is identified
+type NPMSiteGenerator struct {
+	Name           string
+	Package        string   // Core package providing the version
+	ConfigFiles    []string // Configuration file names
+	PluginPrefixes []string // Dependency name prefixes of plugins, themes, and integrations
+}
+
+// NPMSiteGenerators lists the npm-based static site generators
+var NPMSiteGenerators = []NPMSiteGenerator{
+	{
+		Name:           SSGGatsby,
+		Package:        "gatsby",
+		ConfigFiles:    []string{"gatsby-config.js", "gatsby-config.ts", "gatsby-config.mjs"},
+		PluginPrefixes: []string{"gatsby-plugin-", "gatsby-source-", "gatsby-transformer-", "gatsby-theme-", "@gatsbyjs/"},
+	},
+	{
+		Name:           SSGAstro,
+		Package:        "astro",
+		ConfigFiles:    []string{"astro.config.mjs", "astro.config.js", "astro.config.ts", "astro.config.mts", "astro.config.cjs"},
+		PluginPrefixes: []string{"@astrojs/", "astro-"},
+	},
+	{
+		Name:           SSGEleventy,
+		Package:        "@11ty/eleventy",
+		ConfigFiles:    []string{".eleventy.js", "eleventy.config.js", "eleventy.config.cjs", "eleventy.config.mjs"},
+		PluginPrefixes: []string{"@11ty/eleventy-", "eleventy-plugin-"},
+	},
+	{
+		Name:           SSGDocusaurus,
+		Package:        "@docusaurus/core",
+		ConfigFiles:    []string{"docusaurus.config.js", "docusaurus.config.ts", "docusaurus.config.mjs"},
+		PluginPrefixes: []string{"@docusaurus/plugin-", "@docusaurus/preset-", "@docusaurus/theme-", "docusaurus-plugin-", "docusaurus-theme-"},
+	},
+}
+
+// documentationThemes are theme name fragments indicating a documentation site
+var documentationThemes = []string{"docs", "docsy", "book", "doks", "learn", "geekdoc", "relearn", "starlight", "docsearch"}
+
+// StaticSiteInfo describes a static site generator project
+type StaticSiteInfo struct {
+	Generator string   `json:"generator"`
+	File      string   `json:"file"`
+	Version   string   `json:"version,omitempty"` // Installed version or declared constraint
+	SiteType  string   `json:"site_type"`         // documentation or website
+	Themes    []string `json:"themes,omitempty"`
+	Plugins   []string `json:"plugins,omitempty"`
+}
+
+// HugoConfig represents the Hugo settings we use
+type HugoConfig struct {
+	Themes     []string
+	Modules    []string // module.imports paths
+	MinVersion string   // module.hugoVersion.min
+}
+
+// JekyllConfig represents the _config.yml settings we use
+type JekyllConfig struct {
+	Theme       string   `yaml:"theme"`
+	RemoteTheme string   `yaml:"remote_theme"`
+	Plugins     []string `yaml:"plugins"`
+	Gems        []string `yaml:"gems"` // Jekyll < 3.5 name for plugins
+}
+
+// StaticSiteParser handles static site generator configuration parsing
+type StaticSiteParser struct{}
+
+// NewStaticSiteParser creates a new static site parser
+func NewStaticSiteParser() *StaticSiteParser {
+	return &StaticSiteParser{}
+}
+
+// ParseHugoConfig parses a Hugo configuration file (TOML, YAML, or JSON by file extension)
+func (p *StaticSiteParser) ParseHugoConfig(content, fileName string) *HugoConfig {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".yaml", ".yml":
+		var raw map[string]interface{}
+		if err := yaml.Unmarshal([]byte(content), &raw); err != nil {
+			return nil
+		}
+		return hugoConfigFromMap(raw)
+	case ".json":
+		var raw map[string]interface{}
+		if err := json.Unmarshal([]byte(content), &raw); err != nil {
+			return nil
+		}
+		return hugoConfigFromMap(raw)
+	}
+	return p.parseHugoTOML(content)
+}
+
+// parseHugoTOML extracts theme, module imports, and the minimum Hugo version from TOML (line-based)
+func (p *StaticSiteParser) parseHugoTOML(content string) *HugoConfig {
+	config := &HugoConfig{}
+	section := ""
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			section = strings.Trim(line, "[] ")
+			continue
+		}
+
+		match := tomlKeyValueRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		key, value := match[1], match[2]
+		switch {
+		case section == "" && key == "theme":
+			config.Themes = append(config.Themes, tomlStrings(value)...)
+		case section == "module.imports" && key == "path":
+			config.Modules = append(config.Modules, tomlStrings(value)...)
+		case section == "module.hugoVersion" && key == "min":
+			if values := tomlStrings(value); len(values) > 0 {
+				config.MinVersion = values[0]
+			}
+		}
+	}
+	return config
+}
+
+// tomlStrings extracts the quoted strings of a TOML string or string array value
+func tomlStrings(value string) []string {
+	var values []string
+	for _, match := range tomlQuotedRegex.FindAllStringSubmatch(value, -1) {
+		values = append(values, match[1])
+	}
+	return values
+}
+
+// hugoConfigFromMap extracts Hugo settings from a decoded YAML or JSON configuration
+func hugoConfigFromMap(raw map[string]interface{}) *HugoConfig {
+	config := &HugoConfig{Themes: stringOrList(raw["theme"])}
+	module, _ := raw["module"].(map[string]interface{})
+	if module == nil {
+		return config
+	}
+	if imports, ok := module["imports"].([]interface{}); ok {
+		for _, entry := range imports {
+			if m, ok := entry.(map[string]interface{}); ok {
+				if path, ok := m["path"].(string); ok {
+					config.Modules = append(config.Modules, path)
+				}
+			}
+		}
+	}
+	if version, ok := module["hugoVersion"].(map[string]interface{}); ok {
+		if min, ok := version["min"].(string); ok {
+			config.MinVersion = min
+		}
+	}
+	return config
+}
+
+// stringOrList converts a string or list value to a string slice
+func stringOrList(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// ParseHugoVersionPin extracts a pinned Hugo version from CI or hosting configuration
+// (e.g., HUGO_VERSION = "0.121.0" in netlify.toml)
+func (p *StaticSiteParser) ParseHugoVersionPin(content string) string {
+	if match := hugoVersionEnvRegex.FindStringSubmatch(content); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// ParseJekyllConfig parses a Jekyll _config.yml
+func (p *StaticSiteParser) ParseJekyllConfig(content string) (*JekyllConfig, error) {
+	var config JekyllConfig
+	if err := yaml.Unmarshal([]byte(content), &config); err != nil {
+		return nil, err
+	}
+	config.Plugins = append(config.Plugins, config.Gems...)
+	config.Gems = nil
+	return &config, nil
+}
+
+// Themes returns the configured themes (theme and remote_theme)
+func (c *JekyllConfig) Themes() []string {
+	var themes []string
+	for _, theme := range []string{c.Theme, c.RemoteTheme} {
+		if theme != "" {
+			themes = append(themes, theme)
+		}
+	}
+	return themes
+}
+
+// Plugins returns the dependencies of an npm-based generator that are plugins, themes, or integrations
+func (g NPMSiteGenerator) Plugins(dependencies map[string]string) []string {
+	var plugins []string
+	for name := range dependencies {
+		if name == g.Package {
+			continue
+		}
+		for _, prefix := range g.PluginPrefixes {
+			if strings.HasPrefix(name, prefix) {
+				plugins = append(plugins, name)
+				break
+			}
+		}
+	}
+	sort.Strings(plugins)
+	return plugins
+}
+
+// ClassifySiteType reports whether a site is a documentation site (Docusaurus or a documentation theme) or a website
+func ClassifySiteType(generator string, themes []string) string {
+	if generator == SSGDocusaurus {
+		return SiteTypeDocumentation
+	}
+	for _, theme := range themes {
+		name := strings.ToLower(theme)
+		for _, fragment := range documentationThemes {
+			if strings.Contains(name, fragment) {
+				return SiteTypeDocumentation
+			}
+		}
+	}
+	return SiteTypeWebsite
+}
@@ -0,0 +1,128 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHugoConfig(t *testing.T) {
+	parser := NewStaticSiteParser()
+
+	tests := []struct {
+		name     string
+		content  string
+		fileName string
+		expected *HugoConfig
+	}{
+		{
+			name: "TOML with theme and modules",
+			content: `baseURL = "https://example.org/"
+title = "My Site"
+theme = "PaperMod"
+
+[params]
+theme = "dark"
+
+[module]
+[module.hugoVersion]
+  extended = true
+  min = "0.112.0"
+[[module.imports]]
+  path = "github.com/google/docsy"
+[[module.imports]]
+  path = "github.com/google/docsy/dependencies"
+`,
+			fileName: "hugo.toml",
+			expected: &HugoConfig{
+				Themes:     []string{"PaperMod"},
+				Modules:    []string{"github.com/google/docsy", "github.com/google/docsy/dependencies"},
+				MinVersion: "0.112.0",
+			},
+		},
+		{
+			name:     "TOML theme list",
+			content:  `theme = ["hugo-book", 'shortcodes']`,
+			fileName: "config.toml",
+			expected: &HugoConfig{Themes: []string{"hugo-book", "shortcodes"}},
+		},
+		{
+			name: "YAML",
+			content: `theme: ananke
+module:
+  imports:
+    - path: github.com/theNewDynamic/gohugo-theme-ananke
+`,
+			fileName: "hugo.yaml",
+			expected: &HugoConfig{Themes: []string{"ananke"}, Modules: []string{"github.com/theNewDynamic/gohugo-theme-ananke"}},
+		},
+		{
+			name:     "JSON",
+			content:  `{"theme": ["a", "b"], "module": {"hugoVersion": {"min": "0.120.0"}}}`,
+			fileName: "hugo.json",
+			expected: &HugoConfig{Themes: []string{"a", "b"}, MinVersion: "0.120.0"},
+		},
+		{
+			name:     "invalid YAML",
+			content:  "theme: [",
+			fileName: "hugo.yaml",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parser.ParseHugoConfig(tt.content, tt.fileName))
+		})
+	}
+}
+
+func TestParseHugoVersionPin(t *testing.T) {
+	parser := NewStaticSiteParser()
+	assert.Equal(t, "0.121.0", parser.ParseHugoVersionPin("[build.environment]\n  HUGO_VERSION = \"0.121.0\"\n"))
+	assert.Equal(t, "0.119.0", parser.ParseHugoVersionPin("env:\n  HUGO_VERSION: v0.119.0\n"))
+	assert.Equal(t, "", parser.ParseHugoVersionPin("[build]\n  command = \"hugo\"\n"))
+}
+
+func TestParseJekyllConfig(t *testing.T) {
+	parser := NewStaticSiteParser()
+
+	config, err := parser.ParseJekyllConfig(`title: Docs
+remote_theme: just-the-docs/just-the-docs
+plugins:
+  - jekyll-seo-tag
+gems:
+  - jekyll-feed
+`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"just-the-docs/just-the-docs"}, config.Themes())
+	assert.Equal(t, []string{"jekyll-seo-tag", "jekyll-feed"}, config.Plugins)
+
+	_, err = parser.ParseJekyllConfig("plugins: [")
+	assert.Error(t, err)
+}
+
+func TestNPMSiteGeneratorPlugins(t *testing.T) {
+	var gatsby NPMSiteGenerator
+	for _, generator := range NPMSiteGenerators {
+		if generator.Name == SSGGatsby {
+			gatsby = generator
+		}
+	}
+	deps := map[string]string{
+		"gatsby":                   "^5.13.0",
+		"gatsby-plugin-image":      "^3.13.0",
+		"gatsby-source-filesystem": "^5.13.0",
+		"react":                    "^18.2.0",
+	}
+	assert.Equal(t, []string{"gatsby-plugin-image", "gatsby-source-filesystem"}, gatsby.Plugins(deps))
+}
+
+func TestClassifySiteType(t *testing.T) {
+	assert.Equal(t, SiteTypeDocumentation, ClassifySiteType(SSGDocusaurus, nil))
+	assert.Equal(t, SiteTypeDocumentation, ClassifySiteType(SSGHugo, []string{"github.com/google/docsy"}))
+	assert.Equal(t, SiteTypeDocumentation, ClassifySiteType(SSGAstro, []string{"@astrojs/starlight"}))
+	assert.Equal(t, SiteTypeWebsite, ClassifySiteType(SSGHugo, []string{"PaperMod"}))
+	assert.Equal(t, SiteTypeWebsite, ClassifySiteType(SSGGatsby, nil))
+}
@@ -0,0 +1,500 @@
+package parsers
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/semver"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Keys of a target environment specification ("os=linux,arch=amd64,python=3.11")
+const (
+	TargetKeyOS      = "os"       // Operating system (GOOS names: linux, darwin, windows, ...)
+	TargetKeyArch    = "arch"     // CPU architecture (GOARCH names: amd64, arm64, 386, arm)
+	TargetKeyPython  = "python"   // Python version ("3.11", "3.11.4")
+	TargetKeyNodeEnv = "node_env" // NODE_ENV of npm installs; production leaves out dev dependencies
+)
+
+// TargetKeys lists the keys of a target environment specification
+var TargetKeys = []string{TargetKeyOS, TargetKeyArch, TargetKeyPython, TargetKeyNodeEnv}
+
+// targetOSAliases and targetArchAliases map the names of other ecosystems to GOOS and GOARCH
+var (
+	targetOSAliases = map[string]string{
+		"win32": "windows", "win": "windows", "macos": "darwin", "osx": "darwin", "mac": "darwin",
+	}
+	targetArchAliases = map[string]string{
+		"x86_64": "amd64", "x64": "amd64", "aarch64": "arm64", "ia32": "386", "x86": "386", "i386": "386", "i686": "386",
+	}
+)
+
+// TargetEnvironment is the environment dependencies are installed in. Empty fields are unknown:
+// conditions testing them hold.
+type TargetEnvironment struct {
+	OS      string // GOOS name
+	Arch    string // GOARCH name
+	Python  string // Python version
+	NodeEnv string // NODE_ENV
+}
+
+// ParseTargetEnvironment parses a comma-separated list of key=value pairs (TargetKeys). An empty
+// specification returns nil.
+func ParseTargetEnvironment(spec string) (*TargetEnvironment, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+	env := &TargetEnvironment{}
+	for _, item := range strings.Split(spec, ",") {
+		key, value, ok := strings.Cut(item, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid target environment '%s': expected key=value", strings.TrimSpace(item))
+		}
+		switch key {
+		case TargetKeyOS:
+			env.OS = strings.ToLower(value)
+			if alias, ok := targetOSAliases[env.OS]; ok {
+				env.OS = alias
+			}
+		case TargetKeyArch:
+			env.Arch = strings.ToLower(value)
+			if alias, ok := targetArchAliases[env.Arch]; ok {
+				env.Arch = alias
+			}
+		case TargetKeyPython:
+			if _, err := semver.PyPI.Parse(value); err != nil {
+				return nil, fmt.Errorf("invalid target Python version '%s'", value)
+			}
+			env.Python = value
+		case TargetKeyNodeEnv:
+			env.NodeEnv = value
+		default:
+			return nil, fmt.Errorf("invalid target environment key '%s'. Valid keys: %s", key, strings.Join(TargetKeys, ", "))
+		}
+	}
+	return env, nil
+}
+
+// String returns the specification of the environment, keys in TargetKeys order
+func (e *TargetEnvironment) String() string {
+	var parts []string
+	for _, field := range []struct{ key, value string }{
+		{TargetKeyOS, e.OS}, {TargetKeyArch, e.Arch}, {TargetKeyPython, e.Python}, {TargetKeyNodeEnv, e.NodeEnv},
+	} {
+		if field.value != "" {
+			parts = append(parts, field.key+"="+field.value)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// Active reports whether a dependency is installed in the environment: every activation entry
+// holds, and NODE_ENV=production leaves out npm dev dependencies. Selected conditions (features,
+// extras, profiles, optional dependencies) hold when they hold by default.
+func (e *TargetEnvironment) Active(dep types.Dependency) bool {
+	if e.NodeEnv == "production" && dep.Type == DependencyTypeNpm && dep.Scope == types.ScopeDev {
+		return false
+	}
+	entries, _ := dep.Metadata[MetadataActivation].([]interface{})
+	for _, item := range entries {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if !e.holds(entry) {
+			return false
+		}
+	}
+	return true
+}
+
+// holds reports whether an activation entry holds in the environment
+func (e *TargetEnvironment) holds(entry map[string]interface{}) bool {
+	conditions := activationConditions(entry[ActivationFieldConditions])
+	switch entry[ActivationFieldKind] {
+	case ActivationMarker:
+		for _, marker := range conditions {
+			if !e.markerHolds(marker) {
+				return false
+			}
+		}
+		return true
+	case ActivationPlatform:
+		return e.platformHolds(conditions)
+	case ActivationOS:
+		return npmPlatformHolds(conditions, npmPlatformName(e.OS))
+	case ActivationCPU:
+		return npmPlatformHolds(conditions, npmArchName(e.Arch))
+	}
+	if byDefault, ok := entry[ActivationFieldDefault].(bool); ok {
+		return byDefault
+	}
+	return true
+}
+
+// activationConditions returns the conditions of an activation entry, also when read back from
+// JSON (detector cache)
+func activationConditions(value interface{}) []string {
+	switch conditions := value.(type) {
+	case []string:
+		return conditions
+	case []interface{}:
+		var result []string
+		for _, condition := range conditions {
+			if s, ok := condition.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// platformHolds reports whether one of the Gemfile platforms of a dependency matches the
+// operating system. Windows platforms (windows, mingw, x64_mingw, mswin) match only on Windows,
+// C Ruby platforms (ruby, mri, truffleruby) everywhere else; other platforms (jruby) are unknown.
+func (e *TargetEnvironment) platformHolds(platforms []string) bool {
+	if e.OS == "" || len(platforms) == 0 {
+		return true
+	}
+	for _, platform := range platforms {
+		name := strings.TrimRightFunc(platform, func(r rune) bool { return unicode.IsDigit(r) || r == '_' })
+		switch name {
+		case "windows", "mingw", "x64_mingw", "mswin", "mswin64":
+			if e.OS == "windows" {
+				return true
+			}
+		case "ruby", "mri", "truffleruby":
+			if e.OS != "windows" {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// npmPlatformHolds evaluates the "os" or "cpu" list of a package-lock.json entry: the value must
+// be listed unless the list only excludes values ("!win32"). Unknown values hold.
+func npmPlatformHolds(list []string, value string) bool {
+	if value == "" {
+		return true
+	}
+	allowed := false
+	for _, item := range list {
+		if excluded, ok := strings.CutPrefix(item, "!"); ok {
+			if excluded == value {
+				return false
+			}
+			continue
+		}
+		allowed = true
+		if item == value {
+			return true
+		}
+	}
+	return !allowed
+}
+
+// npmPlatformName returns the process.platform value of a GOOS name
+func npmPlatformName(goos string) string {
+	if goos == "windows" {
+		return "win32"
+	}
+	return goos
+}
+
+// npmArchName returns the process.arch value of a GOARCH name
+func npmArchName(goarch string) string {
+	switch goarch {
+	case "amd64":
+		return "x64"
+	case "386":
+		return "ia32"
+	}
+	return goarch
+}
+
+// markerVariable returns the value of a PEP 508 environment marker variable, or false when the
+// environment does not determine it. Extras are recorded as extra entries and hold here.
+func (e *TargetEnvironment) markerVariable(name string) (string, bool) {
+	switch name {
+	case "sys_platform":
+		if e.OS == "" {
+			return "", false
+		}
+		return npmPlatformName(e.OS), true
+	case "platform_system":
+		switch e.OS {
+		case "":
+			return "", false
+		case "darwin":
+			return "Darwin", true
+		case "windows":
+			return "Windows", true
+		}
+		return strings.ToUpper(e.OS[:1]) + e.OS[1:], true
+	case "os_name":
+		if e.OS == "" {
+			return "", false
+		}
+		if e.OS == "windows" {
+			return "nt", true
+		}
+		return "posix", true
+	case "platform_machine":
+		return e.platformMachine()
+	case "python_version":
+		if e.Python == "" {
+			return "", false
+		}
+		parts := strings.SplitN(e.Python, ".", 3)
+		return strings.Join(parts[:min(len(parts), 2)], "."), true
+	case "python_full_version":
+		return e.Python, e.Python != ""
+	}
+	return "", false
+}
+
+// platformMachine returns platform.machine() of the environment
+func (e *TargetEnvironment) platformMachine() (string, bool) {
+	if e.Arch == "" {
+		return "", false
+	}
+	windows := e.OS == "windows"
+	switch e.Arch {
+	case "amd64":
+		if windows {
+			return "AMD64", true
+		}
+		return "x86_64", true
+	case "arm64":
+		if windows {
+			return "ARM64", true
+		}
+		if e.OS == "darwin" {
+			return "arm64", true
+		}
+		return "aarch64", true
+	case "386":
+		if windows {
+			return "x86", true
+		}
+		return "i686", true
+	}
+	return e.Arch, true
+}
+
+// markerHolds evaluates a PEP 508 environment marker. Comparisons of variables the environment
+// does not determine, and markers that cannot be parsed, hold.
+func (e *TargetEnvironment) markerHolds(marker string) bool {
+	p := &markerParser{env: e, tokens: markerTokens(marker)}
+	result, ok := p.or()
+	if !ok || p.pos != len(p.tokens) {
+		return true
+	}
+	return result
+}
+
+// markerToken is a token of an environment marker; quoted strings keep their quote
+type markerToken struct {
+	text   string
+	quoted bool
+}
+
+// markerTokens splits an environment marker into parentheses, operators, quoted strings, and
+// words (variables, and, or, not, in)
+func markerTokens(marker string) []markerToken {
+	var tokens []markerToken
+	for i := 0; i < len(marker); {
+		c := marker[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, markerToken{text: string(c)})
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(marker[i+1:], c)
+			if end < 0 {
+				return append(tokens, markerToken{text: marker[i:]})
+			}
+			tokens = append(tokens, markerToken{text: marker[i+1 : i+1+end], quoted: true})
+			i += end + 2
+		case strings.ContainsRune("=!<>~", rune(c)):
+			end := i + 1
+			for end < len(marker) && strings.ContainsRune("=!<>~", rune(marker[end])) {
+				end++
+			}
+			tokens = append(tokens, markerToken{text: marker[i:end]})
+			i = end
+		default:
+			end := i + 1
+			for end < len(marker) && !strings.ContainsRune(" \t()\"'=!<>~", rune(marker[end])) {
+				end++
+			}
+			tokens = append(tokens, markerToken{text: marker[i:end]})
+			i = end
+		}
+	}
+	return tokens
+}
+
+// markerParser evaluates the tokens of an environment marker by recursive descent
+type markerParser struct {
+	env    *TargetEnvironment
+	tokens []markerToken
+	pos    int
+}
+
+// peek returns the unquoted text of the next token
+func (p *markerParser) peek() string {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].quoted {
+		return ""
+	}
+	return p.tokens[p.pos].text
+}
+
+func (p *markerParser) or() (bool, bool) {
+	result, ok := p.and()
+	for ok && p.peek() == "or" {
+		p.pos++
+		var next bool
+		next, ok = p.and()
+		result = result || next
+	}
+	return result, ok
+}
+
+func (p *markerParser) and() (bool, bool) {
+	result, ok := p.atom()
+	for ok && p.peek() == "and" {
+		p.pos++
+		var next bool
+		next, ok = p.atom()
+		result = result && next
+	}
+	return result, ok
+}
+
+func (p *markerParser) atom() (bool, bool) {
+	if p.peek() == "(" {
+		p.pos++
+		result, ok := p.or()
+		if !ok || p.peek() != ")" {
+			return false, false
+		}
+		p.pos++
+		return result, true
+	}
+	if p.pos+2 > len(p.tokens) {
+		return false, false
+	}
+	left := p.tokens[p.pos]
+	p.pos++
+	op := p.peek()
+	if op == "not" {
+		p.pos++
+		if p.peek() != "in" {
+			return false, false
+		}
+		op = "not in"
+	}
+	p.pos++
+	if p.pos >= len(p.tokens) {
+		return false, false
+	}
+	right := p.tokens[p.pos]
+	p.pos++
+	return p.compare(left, op, right)
+}
+
+// compare evaluates a comparison of a variable and a string. Versions compare by PEP 440.
+func (p *markerParser) compare(left markerToken, op string, right markerToken) (bool, bool) {
+	lhs, rhs := left.text, right.text
+	variable := ""
+	if !left.quoted {
+		variable = left.text
+	} else if !right.quoted {
+		variable = right.text
+	}
+	if variable != "" {
+		value, known := p.env.markerVariable(variable)
+		if !known {
+			return true, true
+		}
+		if !left.quoted {
+			lhs = value
+		} else {
+			rhs = value
+		}
+	}
+
+	switch op {
+	case "in":
+		return strings.Contains(rhs, lhs), true
+	case "not in":
+		return !strings.Contains(rhs, lhs), true
+	case "==", "!=", "<", "<=", ">", ">=", "~=", "===":
+	default:
+		return false, false
+	}
+	if variable == "python_version" || variable == "python_full_version" {
+		if cmp, ok := comparePythonVersions(lhs, rhs); ok {
+			switch op {
+			case "==", "===":
+				return cmp == 0, true
+			case "!=":
+				return cmp != 0, true
+			case "<":
+				return cmp < 0, true
+			case "<=":
+				return cmp <= 0, true
+			case ">":
+				return cmp > 0, true
+			case ">=":
+				return cmp >= 0, true
+			case "~=":
+				return cmp >= 0 && compatibleRelease(lhs, rhs), true
+			}
+		}
+	}
+	switch op {
+	case "==", "===":
+		return lhs == rhs, true
+	case "!=":
+		return lhs != rhs, true
+	}
+	return true, true
+}
+
+// comparePythonVersions compares two PEP 440 versions
+func comparePythonVersions(a, b string) (int, bool) {
+	va, err := semver.PyPI.Parse(a)
+	if err != nil {
+		return 0, false
+	}
+	vb, err := semver.PyPI.Parse(b)
+	if err != nil {
+		return 0, false
+	}
+	return va.Compare(vb), true
+}
+
+// compatibleRelease reports whether a version matches the release prefix of a ~= clause:
+// 3.11.4 ~= 3.11.2 (3.11.*), not 3.12 ~= 3.11.2
+func compatibleRelease(version, spec string) bool {
+	prefix := strings.Split(spec, ".")
+	if len(prefix) > 1 {
+		prefix = prefix[:len(prefix)-1]
+	}
+	parts := strings.Split(version, ".")
+	if len(parts) < len(prefix) {
+		return false
+	}
+	return strings.Join(parts[:len(prefix)], ".") == strings.Join(prefix, ".")
+}
@@ -0,0 +1,107 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTargetEnvironment(t *testing.T) {
+	env, err := ParseTargetEnvironment("os=Win32, arch=x86_64,python=3.11,node_env=production")
+	require.NoError(t, err)
+	assert.Equal(t, &TargetEnvironment{OS: "windows", Arch: "amd64", Python: "3.11", NodeEnv: "production"}, env)
+	assert.Equal(t, "os=windows,arch=amd64,python=3.11,node_env=production", env.String())
+
+	env, err = ParseTargetEnvironment("")
+	assert.NoError(t, err)
+	assert.Nil(t, env)
+
+	for _, spec := range []string{"linux", "os=", "distro=debian", "python=three"} {
+		_, err := ParseTargetEnvironment(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestTargetEnvironment_MarkerHolds(t *testing.T) {
+	linux := &TargetEnvironment{OS: "linux", Arch: "amd64", Python: "3.11.4"}
+	tests := []struct {
+		marker   string
+		expected bool
+	}{
+		{`sys_platform == "win32"`, false},
+		{`sys_platform != 'win32'`, true},
+		{`platform_system == "Linux" and platform_machine == "x86_64"`, true},
+		{`os_name == "nt" or sys_platform == "darwin"`, false},
+		{`python_version < "3.11"`, false},
+		{`python_version >= "3.8"`, true},
+		{`python_full_version ~= "3.11.2"`, true},
+		{`"linux" in sys_platform`, true},
+		{`sys_platform not in "win32 cygwin"`, true},
+		{`(python_version < "3.9" or sys_platform == "linux") and extra == "test"`, true},
+		{`implementation_name == "pypy"`, true},
+		{`python_version <`, true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, linux.markerHolds(tt.marker), tt.marker)
+	}
+
+	unknown := &TargetEnvironment{OS: "linux"}
+	assert.True(t, unknown.markerHolds(`python_version < "3.8"`), "Python version not set")
+}
+
+func TestTargetEnvironment_Active(t *testing.T) {
+	dep := func(kind string, conditions []string, byDefault *bool) types.Dependency {
+		d := types.Dependency{Type: DependencyTypeNpm, Name: "pkg", Scope: types.ScopeProd}
+		if byDefault != nil {
+			AddActivation(&d, kind, conditions, *byDefault)
+		} else {
+			AddEnvironmentActivation(&d, kind, conditions)
+		}
+		return d
+	}
+	yes, no := true, false
+	linux := &TargetEnvironment{OS: "linux", Arch: "amd64", NodeEnv: "production"}
+
+	assert.True(t, linux.Active(types.Dependency{Type: DependencyTypeNpm, Name: "express", Scope: types.ScopeProd}))
+	assert.False(t, linux.Active(types.Dependency{Type: DependencyTypeNpm, Name: "jest", Scope: types.ScopeDev}), "dev dependency of a production install")
+	assert.True(t, linux.Active(types.Dependency{Type: DependencyTypePython, Name: "pytest", Scope: types.ScopeDev}), "NODE_ENV only applies to npm")
+
+	assert.True(t, linux.Active(dep(ActivationOptional, nil, &yes)))
+	assert.False(t, linux.Active(dep(ActivationFeature, []string{"trace"}, &no)))
+	assert.False(t, linux.Active(dep(ActivationOS, []string{"win32"}, nil)))
+	assert.True(t, linux.Active(dep(ActivationOS, []string{"!win32"}, nil)))
+	assert.True(t, linux.Active(dep(ActivationCPU, []string{"x64", "arm64"}, nil)))
+	assert.False(t, linux.Active(dep(ActivationCPU, []string{"arm64"}, nil)))
+	assert.False(t, linux.Active(dep(ActivationPlatform, []string{"windows", "mingw"}, nil)))
+	assert.True(t, linux.Active(dep(ActivationPlatform, []string{"mri_31"}, nil)))
+	assert.True(t, linux.Active(dep(ActivationPlatform, []string{"jruby"}, nil)), "unknown platform")
+	assert.False(t, (&TargetEnvironment{OS: "windows"}).Active(dep(ActivationPlatform, []string{"ruby"}, nil)))
+
+	// Activation read back from JSON (detector cache)
+	cached := types.Dependency{Type: DependencyTypeNpm, Name: "fsevents", Metadata: map[string]interface{}{
+		MetadataActivation: []interface{}{map[string]interface{}{ActivationFieldKind: ActivationOS, ActivationFieldConditions: []interface{}{"darwin"}}},
+	}}
+	assert.False(t, linux.Active(cached))
+}
+
+func TestTargetEnvironment_PackageLock(t *testing.T) {
+	lock := `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"dependencies": {"esbuild": "^0.20.2"}},
+    "node_modules/esbuild": {"version": "0.20.2", "optionalDependencies": {"@esbuild/linux-x64": "0.20.2", "@esbuild/win32-x64": "0.20.2"}},
+    "node_modules/@esbuild/linux-x64": {"version": "0.20.2", "optional": true, "os": ["linux"], "cpu": ["x64"]},
+    "node_modules/@esbuild/win32-x64": {"version": "0.20.2", "optional": true, "os": ["win32"], "cpu": ["x64"]}
+  }
+}`
+	deps := ParsePackageLockWithOptions([]byte(lock), &PackageJSON{Dependencies: map[string]string{"esbuild": "^0.20.2"}}, nil, ParsePackageLockOptions{IncludeTransitive: true})
+	env := &TargetEnvironment{OS: "linux", Arch: "amd64"}
+
+	active := make(map[string]bool)
+	for _, dep := range deps {
+		active[dep.Name] = env.Active(dep)
+	}
+	assert.Equal(t, map[string]bool{"esbuild": true, "@esbuild/linux-x64": true, "@esbuild/win32-x64": false}, active)
+}
@@ -0,0 +1,303 @@
+package parsers
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Update tool names
+const (
+	UpdateToolDependabot = "dependabot"
+	UpdateToolRenovate   = "renovate"
+)
+
+// UpdateEcosystemAll marks a configuration covering every ecosystem the tool supports
+const UpdateEcosystemAll = "*"
+
+// dependabotEcosystems maps Dependabot package-ecosystem values to dependency types
+var dependabotEcosystems = map[string]string{
+	"npm":            DependencyTypeNpm,
+	"pip":            DependencyTypePython,
+	"uv":             DependencyTypePython,
+	"bundler":        DependencyTypeRuby,
+	"gomod":          DependencyTypeGolang,
+	"cargo":          DependencyTypeRust,
+	"maven":          DependencyTypeMaven,
+	"gradle":         DependencyTypeGradle,
+	"composer":       DependencyTypePHP,
+	"nuget":          DependencyTypeDotnet,
+	"docker":         DependencyTypeDocker,
+	"docker-compose": DependencyTypeDocker,
+	"github-actions": DependencyTypeGitHubAction,
+	"terraform":      DependencyTypeTerraform,
+}
+
+// renovateManagers maps Renovate manager names to dependency types
+var renovateManagers = map[string]string{
+	"npm":              DependencyTypeNpm,
+	"pip_requirements": DependencyTypePython,
+	"pip_setup":        DependencyTypePython,
+	"pipenv":           DependencyTypePython,
+	"poetry":           DependencyTypePython,
+	"pep621":           DependencyTypePython,
+	"setup-cfg":        DependencyTypePython,
+	"bundler":          DependencyTypeRuby,
+	"gomod":            DependencyTypeGolang,
+	"cargo":            DependencyTypeRust,
+	"maven":            DependencyTypeMaven,
+	"gradle":           DependencyTypeGradle,
+	"composer":         DependencyTypePHP,
+	"nuget":            DependencyTypeDotnet,
+	"dockerfile":       DependencyTypeDocker,
+	"docker-compose":   DependencyTypeDocker,
+	"github-actions":   DependencyTypeGitHubAction,
+	"terraform":        DependencyTypeTerraform,
+	"cocoapods":        DependencyTypeCocoapods,
+	"conan":            DependencyTypeConan,
+}
+
+// UpdateToolsParser handles Dependabot and Renovate configuration parsing
+type UpdateToolsParser struct{}
+
+// NewUpdateToolsParser creates a new update tools parser
+func NewUpdateToolsParser() *UpdateToolsParser {
+	return &UpdateToolsParser{}
+}
+
+// UpdateToolConfig describes which ecosystems and directories an automated update tool covers
+type UpdateToolConfig struct {
+	Tool       string            `json:"tool"`
+	File       string            `json:"file,omitempty"`
+	Ecosystems []string          `json:"ecosystems"`        // Covered dependency types ("*" = all supported)
+	Updates    []UpdateToolEntry `json:"updates,omitempty"` // Per-ecosystem directories (Dependabot only)
+	Ignored    []string          `json:"ignore_paths,omitempty"`
+	Unknown    []string          `json:"unknown_ecosystems,omitempty"` // Ecosystems not mapped to a dependency type
+}
+
+// UpdateToolEntry is a single Dependabot update entry
+type UpdateToolEntry struct {
+	Ecosystem   string   `json:"ecosystem"` // Dependency type
+	Directories []string `json:"directories"`
+}
+
+// Covers reports whether the tool keeps dependencies of depType in directory up to date.
+// Renovate discovers manifests automatically, so only the ecosystem is checked;
+// Dependabot requires the directory to be listed (glob patterns are supported).
+func (c *UpdateToolConfig) Covers(depType, directory string) bool {
+	if c == nil {
+		return false
+	}
+	if c.Tool == UpdateToolRenovate {
+		return c.coversEcosystem(depType) && !c.ignores(directory)
+	}
+	for _, entry := range c.Updates {
+		if entry.Ecosystem != depType {
+			continue
+		}
+		for _, dir := range entry.Directories {
+			if matchUpdateDirectory(dir, directory) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *UpdateToolConfig) coversEcosystem(depType string) bool {
+	for _, eco := range c.Ecosystems {
+		if eco == UpdateEcosystemAll || eco == depType {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *UpdateToolConfig) ignores(directory string) bool {
+	dir := strings.Trim(directory, "/")
+	for _, pattern := range c.Ignored {
+		p := strings.Trim(strings.TrimSuffix(pattern, "**"), "/")
+		if p != "" && (dir == p || strings.HasPrefix(dir, p+"/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchUpdateDirectory matches a Dependabot directory pattern ("/", "/web", "/packages/*", "/apps/**")
+func matchUpdateDirectory(pattern, directory string) bool {
+	pattern = "/" + strings.Trim(pattern, "/")
+	directory = "/" + strings.Trim(directory, "/")
+	if pattern == directory {
+		return true
+	}
+	if strings.HasSuffix(pattern, "/**") {
+		prefix := strings.TrimSuffix(pattern, "/**")
+		return prefix == "" || directory == prefix || strings.HasPrefix(directory, prefix+"/")
+	}
+	if strings.Contains(pattern, "*") {
+		patternParts := strings.Split(pattern, "/")
+		dirParts := strings.Split(directory, "/")
+		if len(patternParts) != len(dirParts) {
+			return false
+		}
+		for i := range patternParts {
+			if patternParts[i] != "*" && patternParts[i] != dirParts[i] {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// dependabotConfig represents the .github/dependabot.yml structure
+type dependabotConfig struct {
+	Updates []struct {
+		PackageEcosystem string   `yaml:"package-ecosystem"`
+		Directory        string   `yaml:"directory"`
+		Directories      []string `yaml:"directories"`
+	} `yaml:"updates"`
+}
+
+// ParseDependabot parses a Dependabot configuration file
+func (p *UpdateToolsParser) ParseDependabot(content string) (*UpdateToolConfig, error) {
+	var cfg dependabotConfig
+	if err := yaml.Unmarshal([]byte(content), &cfg); err != nil {
+		return nil, err
+	}
+
+	result := &UpdateToolConfig{Tool: UpdateToolDependabot}
+	entries := make(map[string][]string) // dependency type -> directories
+	unknown := make(map[string]bool)
+	for _, update := range cfg.Updates {
+		depType, ok := dependabotEcosystems[update.PackageEcosystem]
+		if !ok {
+			if update.PackageEcosystem != "" {
+				unknown[update.PackageEcosystem] = true
+			}
+			continue
+		}
+		dirs := update.Directories
+		if update.Directory != "" {
+			dirs = append([]string{update.Directory}, dirs...)
+		}
+		entries[depType] = append(entries[depType], dirs...)
+	}
+
+	for depType, dirs := range entries {
+		result.Ecosystems = append(result.Ecosystems, depType)
+		result.Updates = append(result.Updates, UpdateToolEntry{Ecosystem: depType, Directories: dirs})
+	}
+	for eco := range unknown {
+		result.Unknown = append(result.Unknown, eco)
+	}
+	sortUpdateToolConfig(result)
+	return result, nil
+}
+
+// renovateConfig represents the subset of a Renovate configuration we use
+type renovateConfig struct {
+	Enabled         *bool    `json:"enabled"`
+	EnabledManagers []string `json:"enabledManagers"`
+	IgnorePaths     []string `json:"ignorePaths"`
+}
+
+// ParseRenovate parses a Renovate configuration file.
+// Renovate enables all managers by default; enabledManagers restricts the set.
+// JSON5 files are parsed after stripping comments; if parsing still fails the
+// default (all managers) is assumed, matching Renovate's behavior for an empty config.
+func (p *UpdateToolsParser) ParseRenovate(content string) *UpdateToolConfig {
+	result := &UpdateToolConfig{Tool: UpdateToolRenovate}
+
+	var cfg renovateConfig
+	if err := json.Unmarshal([]byte(stripJSONComments(content)), &cfg); err != nil {
+		result.Ecosystems = []string{UpdateEcosystemAll}
+		return result
+	}
+
+	if cfg.Enabled != nil && !*cfg.Enabled {
+		result.Ecosystems = []string{}
+		return result
+	}
+
+	result.Ignored = cfg.IgnorePaths
+	if len(cfg.EnabledManagers) == 0 {
+		result.Ecosystems = []string{UpdateEcosystemAll}
+		return result
+	}
+
+	seen := make(map[string]bool)
+	unknown := make(map[string]bool)
+	for _, manager := range cfg.EnabledManagers {
+		depType, ok := renovateManagers[manager]
+		if !ok {
+			unknown[manager] = true
+			continue
+		}
+		if !seen[depType] {
+			seen[depType] = true
+			result.Ecosystems = append(result.Ecosystems, depType)
+		}
+	}
+	for manager := range unknown {
+		result.Unknown = append(result.Unknown, manager)
+	}
+	sortUpdateToolConfig(result)
+	return result
+}
+
+// sortUpdateToolConfig sorts all lists for deterministic output
+func sortUpdateToolConfig(c *UpdateToolConfig) {
+	if c.Ecosystems == nil {
+		c.Ecosystems = []string{}
+	}
+	sort.Strings(c.Ecosystems)
+	sort.Strings(c.Unknown)
+	sort.Slice(c.Updates, func(i, j int) bool {
+		return c.Updates[i].Ecosystem < c.Updates[j].Ecosystem
+	})
+}
+
+// stripJSONComments removes // and /* */ comments outside of strings (JSON5 subset)
+func stripJSONComments(content string) string {
+	var sb strings.Builder
+	inString := false
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		if inString {
+			sb.WriteByte(c)
+			if c == '\\' && i+1 < len(content) {
+				i++
+				sb.WriteByte(content[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		if c == '"' {
+			inString = true
+			sb.WriteByte(c)
+			continue
+		}
+		if c == '/' && i+1 < len(content) && content[i+1] == '/' {
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+			sb.WriteByte('\n')
+			continue
+		}
+		if c == '/' && i+1 < len(content) && content[i+1] == '*' {
+			end := strings.Index(content[i+2:], "*/")
+			if end < 0 {
+				break
+			}
+			i += end + 3
+			continue
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
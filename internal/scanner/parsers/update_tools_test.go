@@ -0,0 +1,117 @@
+package parsers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDependabot(t *testing.T) {
+	content := `version: 2
+updates:
+  - package-ecosystem: "npm"
+    directory: "/"
+    schedule:
+      interval: "weekly"
+  - package-ecosystem: "npm"
+    directories:
+      - "/packages/*"
+  - package-ecosystem: "pip"
+    directory: "/api"
+  - package-ecosystem: "github-actions"
+    directory: "/"
+  - package-ecosystem: "elm"
+    directory: "/"
+`
+	config, err := NewUpdateToolsParser().ParseDependabot(content)
+	require.NoError(t, err)
+
+	assert.Equal(t, UpdateToolDependabot, config.Tool)
+	assert.Equal(t, []string{"githubAction", "npm", "python"}, config.Ecosystems)
+	assert.Equal(t, []string{"elm"}, config.Unknown)
+	require.Len(t, config.Updates, 3)
+	assert.Equal(t, UpdateToolEntry{Ecosystem: "npm", Directories: []string{"/", "/packages/*"}}, config.Updates[1])
+
+	assert.True(t, config.Covers("npm", "/"))
+	assert.True(t, config.Covers("npm", "/packages/ui"))
+	assert.False(t, config.Covers("npm", "/packages/ui/nested"))
+	assert.False(t, config.Covers("npm", "/web"))
+	assert.True(t, config.Covers("python", "/api"))
+	assert.False(t, config.Covers("python", "/"))
+	assert.False(t, config.Covers("golang", "/"))
+}
+
+func TestParseDependabot_InvalidYAML(t *testing.T) {
+	_, err := NewUpdateToolsParser().ParseDependabot("updates: [")
+	assert.Error(t, err)
+}
+
+func TestParseRenovate(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		ecosystems []string
+		covers     map[string]bool
+	}{
+		{
+			name:       "default config covers all managers",
+			content:    `{"extends": ["config:recommended"]}`,
+			ecosystems: []string{UpdateEcosystemAll},
+			covers:     map[string]bool{"npm|/": true, "cargo|/crates/a": true},
+		},
+		{
+			name:       "enabledManagers restricts ecosystems",
+			content:    `{"enabledManagers": ["npm", "poetry", "pep621", "custom.regex"]}`,
+			ecosystems: []string{"npm", "python"},
+			covers:     map[string]bool{"npm|/web": true, "python|/": true, "golang|/": false},
+		},
+		{
+			name:       "disabled config covers nothing",
+			content:    `{"enabled": false}`,
+			ecosystems: []string{},
+			covers:     map[string]bool{"npm|/": false},
+		},
+		{
+			name:       "ignorePaths excludes directories",
+			content:    `{"ignorePaths": ["**/examples/**", "legacy/"]}`,
+			ecosystems: []string{UpdateEcosystemAll},
+			covers:     map[string]bool{"npm|/legacy": false, "npm|/legacy/app": false, "npm|/web": true},
+		},
+		{
+			name: "json5 with comments",
+			content: `{
+  // Use the shared preset
+  "extends": ["config:recommended"], /* block */
+  "enabledManagers": ["cargo"]
+}`,
+			ecosystems: []string{"cargo"},
+			covers:     map[string]bool{"cargo|/": true, "npm|/": false},
+		},
+		{
+			name:       "unparsable config falls back to defaults",
+			content:    `{ extends: ['config:recommended'], }`,
+			ecosystems: []string{UpdateEcosystemAll},
+			covers:     map[string]bool{"npm|/": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewUpdateToolsParser().ParseRenovate(tt.content)
+			assert.Equal(t, UpdateToolRenovate, config.Tool)
+			assert.Equal(t, tt.ecosystems, config.Ecosystems)
+			for key, expected := range tt.covers {
+				depType, dir, _ := strings.Cut(key, "|")
+				assert.Equal(t, expected, config.Covers(depType, dir), key)
+			}
+		})
+	}
+}
+
+func TestStripJSONComments(t *testing.T) {
+	assert.Equal(t, "{\"a\": \"http://x\" \n}", stripJSONComments("{\"a\": \"http://x\" // note\n}"))
+	assert.Equal(t, `{"a": 1}`, stripJSONComments(`{/* c */"a": 1}`))
+	assert.Equal(t, `{"a": "/* keep */"}`, stripJSONComments(`{"a": "/* keep */"}`))
+}
@@ -1,9 +1,19 @@
 package parsers
 
 import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 )
 
+var (
+	uvWheelRegex  = regexp.MustCompile(`(?:url|path|filename) = "([^"#]+\.whl)`)
+	uvMarkerRegex = regexp.MustCompile(`marker = "([^"]*)"`)
+)
+
 // UvLockfile represents the structure of uv.lock (TOML format)
 type UvLockfile struct {
 	Version  int         `yaml:"version"`
@@ -17,6 +27,9 @@ type UvPackage struct {
 	Source               UvSource                     `yaml:"source"`
 	Dependencies         []UvDependencyRef            `yaml:"dependencies"`
 	OptionalDependencies map[string][]UvDependencyRef `yaml:"optional-dependencies"`
+	DevDependencies      map[string][]UvDependencyRef `yaml:"dev-dependencies"` // Dependency groups (dev = [...], test = [...])
+	Wheels               []string                     `yaml:"-"`                // Wheel file names
+	Sdist                bool                         `yaml:"-"`                // Whether a source distribution is locked
 }
 
 // UvSource represents the source of a package
@@ -28,8 +41,9 @@ type UvSource struct {
 
 // UvDependencyRef represents a dependency reference
 type UvDependencyRef struct {
-	Name  string `yaml:"name"`
-	Extra string `yaml:"extra"`
+	Name   string `yaml:"name"`
+	Extra  string `yaml:"extra"`
+	Marker string `yaml:"marker"` // Environment marker (PEP 508)
 }
 
 // ParseUvLock parses uv.lock content and returns direct dependencies with resolved versions
@@ -44,26 +58,55 @@ func ParseUvLock(content []byte, projectName string) []types.Dependency {
 		return nil
 	}
 
-	// Build a map of package name -> version for quick lookup
+	// Build maps of package name -> version and wheels for quick lookup
 	packageVersions := make(map[string]string)
+	packageWheels := make(map[string][]string)
+	packageSdists := make(map[string]bool)
 	for _, pkg := range lockfile.Packages {
 		packageVersions[pkg.Name] = pkg.Version
+		packageWheels[pkg.Name] = pkg.Wheels
+		packageSdists[pkg.Name] = pkg.Sdist
 	}
 
-	// Find the project's own package (editable = ".")
-	var directDepNames []string
+	// Find the project's own package (editable = "."): its dependencies are the direct ones,
+	// those only listed in optional dependency groups are installed with these extras
+	var directRefs []UvDependencyRef
+	extras := make(map[string][]string)
+	devScopes := make(map[string]types.Scope)
 	for _, pkg := range lockfile.Packages {
 		if pkg.Source.Editable == "." || pkg.Name == projectName {
-			// This is the project itself - its dependencies are direct deps
-			for _, dep := range pkg.Dependencies {
-				directDepNames = append(directDepNames, dep.Name)
+			directRefs = append(directRefs, pkg.Dependencies...)
+			groups := make([]string, 0, len(pkg.OptionalDependencies))
+			for group := range pkg.OptionalDependencies {
+				groups = append(groups, group)
+			}
+			sort.Strings(groups)
+			for _, group := range groups {
+				for _, dep := range pkg.OptionalDependencies[group] {
+					directRefs = append(directRefs, dep)
+					extras[dep.Name] = append(extras[dep.Name], group)
+				}
 			}
-			// Also include optional dependencies (dev, etc.)
-			for _, deps := range pkg.OptionalDependencies {
-				for _, dep := range deps {
-					directDepNames = append(directDepNames, dep.Name)
+			groups = groups[:0]
+			for group := range pkg.DevDependencies {
+				groups = append(groups, group)
+			}
+			sort.Strings(groups)
+			for _, group := range groups {
+				for _, dep := range pkg.DevDependencies[group] {
+					directRefs = append(directRefs, dep)
+					if _, ok := devScopes[dep.Name]; !ok {
+						devScopes[dep.Name] = uvGroupScope(group)
+					}
 				}
 			}
+			for _, dep := range pkg.Dependencies {
+				delete(extras, dep.Name)
+				delete(devScopes, dep.Name)
+			}
+			for name := range extras {
+				delete(devScopes, name)
+			}
 			break
 		}
 	}
@@ -71,7 +114,8 @@ func ParseUvLock(content []byte, projectName string) []types.Dependency {
 	// Build dependency list with resolved versions
 	var dependencies []types.Dependency
 	seen := make(map[string]bool)
-	for _, name := range directDepNames {
+	for _, ref := range directRefs {
+		name := ref.Name
 		if seen[name] {
 			continue
 		}
@@ -82,16 +126,24 @@ func ParseUvLock(content []byte, projectName string) []types.Dependency {
 			continue
 		}
 
-		dependencies = append(dependencies, types.Dependency{
+		dep := types.Dependency{
 			Type:       "python",
 			Name:       name,
 			Version:    version,
 			SourceFile: "uv.lock",
+			Scope:      devScopes[name],
 			Direct:     true,
-		})
+		}
+		MarkNative(&dep, NativePythonEvidence(name, packageWheels[name]))
+		SetArtifactPlatforms(&dep, PythonArtifactPlatforms(packageWheels[name], packageSdists[name]))
+		if groups := extras[name]; len(groups) > 0 {
+			AddActivation(&dep, ActivationExtra, sortedUnique(groups), false)
+		}
+		AddPythonMarkerActivation(&dep, ref.Marker)
+		dependencies = append(dependencies, dep)
 	}
 
-	return dependencies
+	return NormalizeDependencyVersions(dependencies)
 }
 
 // parseUvLockTOML is a simple TOML parser for uv.lock format
@@ -114,6 +166,8 @@ type uvParseState struct {
 	currentPkg      *UvPackage
 	inDependencies  bool
 	inOptionalDeps  bool
+	inDevDeps       bool
+	inWheels        bool
 	currentOptGroup string
 }
 
@@ -133,6 +187,7 @@ func parseUvPackages(content string) []UvPackage {
 			state = &uvParseState{
 				currentPkg: &UvPackage{
 					OptionalDependencies: make(map[string][]UvDependencyRef),
+					DevDependencies:      make(map[string][]UvDependencyRef),
 				},
 			}
 			continue
@@ -166,17 +221,52 @@ func processUvLine(line string, state *uvParseState) {
 	case line == "dependencies = [":
 		state.inDependencies = true
 		state.inOptionalDeps = false
+		state.inDevDeps = false
+	case hasPrefix(line, "sdist = "):
+		state.currentPkg.Sdist = true
+	case hasPrefix(line, "wheels = ["):
+		state.inWheels = !strings.HasSuffix(line, "]")
+		addUvWheel(line, state)
+	case state.inWheels && hasPrefix(line, "{ url = "), state.inWheels && hasPrefix(line, "{ path = "):
+		addUvWheel(line, state)
 	case line == "[package.optional-dependencies]":
 		state.inOptionalDeps = true
+		state.inDevDeps = false
 		state.inDependencies = false
-	case state.inOptionalDeps && contains(line, " = ["):
+	case line == "[package.dev-dependencies]":
+		state.inDevDeps = true
+		state.inOptionalDeps = false
+		state.inDependencies = false
+	case (state.inOptionalDeps || state.inDevDeps) && contains(line, " = ["):
 		state.currentOptGroup = extractKey(line)
-	case (state.inDependencies || state.inOptionalDeps) && hasPrefix(line, "{ name = "):
+	case strings.HasPrefix(line, "[package."):
+		// Other tables of the package (metadata, ...)
+		state.inOptionalDeps = false
+		state.inDevDeps = false
+		state.inDependencies = false
+	case (state.inDependencies || state.inOptionalDeps || state.inDevDeps) && hasPrefix(line, "{ name = "):
 		addUvDependency(line, state)
 	case line == "]":
 		if state.inDependencies {
 			state.inDependencies = false
 		}
+		state.inWheels = false
+	}
+}
+
+// uvGroupScope returns the scope of the dependencies of a dependency group: test for test groups,
+// else dev
+func uvGroupScope(group string) types.Scope {
+	if scope, ok := types.NormalizeScope(group); ok && scope == types.ScopeTest {
+		return scope
+	}
+	return types.ScopeDev
+}
+
+// addUvWheel records the wheel file names of a wheels line: { url = ".../numpy-2.1.0-cp312-cp312-manylinux_2_17_x86_64.whl", ... }
+func addUvWheel(line string, state *uvParseState) {
+	for _, match := range uvWheelRegex.FindAllStringSubmatch(line, -1) {
+		state.currentPkg.Wheels = append(state.currentPkg.Wheels, path.Base(match[1]))
 	}
 }
 
@@ -187,9 +277,15 @@ func addUvDependency(line string, state *uvParseState) {
 	}
 
 	ref := UvDependencyRef{Name: depName}
+	if match := uvMarkerRegex.FindStringSubmatch(line); match != nil {
+		ref.Marker = match[1]
+	}
 	if state.inOptionalDeps && state.currentOptGroup != "" {
 		state.currentPkg.OptionalDependencies[state.currentOptGroup] = append(
 			state.currentPkg.OptionalDependencies[state.currentOptGroup], ref)
+	} else if state.inDevDeps && state.currentOptGroup != "" {
+		state.currentPkg.DevDependencies[state.currentOptGroup] = append(
+			state.currentPkg.DevDependencies[state.currentOptGroup], ref)
 	} else {
 		state.currentPkg.Dependencies = append(state.currentPkg.Dependencies, ref)
 	}
@@ -2,6 +2,8 @@ package parsers
 
 import (
 	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
 )
 
 func TestParseUvLock(t *testing.T) {
@@ -107,3 +109,167 @@ dev = [
 		})
 	}
 }
+
+func TestParseUvLock_Native(t *testing.T) {
+	content := `version = 1
+
+[[package]]
+name = "numpy"
+version = "2.1.0"
+source = { registry = "https://pypi.org/simple" }
+sdist = { url = "https://files.pythonhosted.org/packages/numpy-2.1.0.tar.gz", hash = "sha256:1" }
+wheels = [
+    { url = "https://files.pythonhosted.org/packages/numpy-2.1.0-cp312-cp312-manylinux_2_17_x86_64.manylinux2014_x86_64.whl", hash = "sha256:2" },
+    { url = "https://files.pythonhosted.org/packages/numpy-2.1.0-cp312-cp312-win_amd64.whl", hash = "sha256:3" },
+]
+
+[[package]]
+name = "requests"
+version = "2.31.0"
+source = { registry = "https://pypi.org/simple" }
+wheels = [
+    { url = "https://files.pythonhosted.org/packages/requests-2.31.0-py3-none-any.whl", hash = "sha256:4" },
+]
+
+[[package]]
+name = "pyyaml-stub"
+version = "1.0.0"
+source = { registry = "https://pypi.org/simple" }
+wheels = [{ url = "https://files.pythonhosted.org/packages/pyyaml_stub-1.0.0-py3-none-any.whl", hash = "sha256:5" }]
+
+[[package]]
+name = "pandas"
+version = "2.2.0"
+source = { git = "https://github.com/pandas-dev/pandas?rev=v2.2.0#abc" }
+
+[[package]]
+name = "my-project"
+source = { editable = "." }
+dependencies = [
+    { name = "numpy" },
+    { name = "pandas" },
+    { name = "pyyaml-stub" },
+    { name = "requests" },
+]
+`
+	evidence := make(map[string]interface{})
+	for _, dep := range ParseUvLock([]byte(content), "my-project") {
+		evidence[dep.Name] = dep.Metadata[MetadataNativeEvidence]
+	}
+
+	expected := map[string]interface{}{
+		"numpy":       NativeEvidencePlatformWheel,
+		"pandas":      NativeEvidenceKnownPackage, // No wheels locked
+		"pyyaml-stub": nil,
+		"requests":    nil,
+	}
+	if len(evidence) != len(expected) {
+		t.Fatalf("ParseUvLock() got %d dependencies, want %d", len(evidence), len(expected))
+	}
+	for name, want := range expected {
+		if evidence[name] != want {
+			t.Errorf("ParseUvLock() dep %s native evidence = %v, want %v", name, evidence[name], want)
+		}
+	}
+}
+
+func TestParseUvLock_ArtifactPlatforms(t *testing.T) {
+	content := `version = 1
+
+[[package]]
+name = "numpy"
+version = "2.1.0"
+sdist = { url = "https://files.pythonhosted.org/packages/numpy-2.1.0.tar.gz", hash = "sha256:1" }
+wheels = [
+    { url = "https://files.pythonhosted.org/packages/numpy-2.1.0-cp312-cp312-manylinux_2_17_x86_64.whl", hash = "sha256:2" },
+]
+
+[[package]]
+name = "torch"
+version = "2.4.0"
+wheels = [
+    { url = "https://download.pytorch.org/whl/torch-2.4.0-cp312-cp312-manylinux1_x86_64.whl", hash = "sha256:3" },
+    { url = "https://download.pytorch.org/whl/torch-2.4.0-cp312-none-macosx_11_0_arm64.whl", hash = "sha256:4" },
+]
+
+[[package]]
+name = "my-project"
+source = { editable = "." }
+dependencies = [
+    { name = "numpy" },
+    { name = "torch" },
+]
+`
+	platforms := make(map[string]interface{})
+	for _, dep := range ParseUvLock([]byte(content), "my-project") {
+		platforms[dep.Name] = dep.Metadata[MetadataArtifactPlatforms]
+	}
+	if platforms["numpy"] != nil {
+		t.Errorf("ParseUvLock() numpy artifact platforms = %v, want none (source distribution)", platforms["numpy"])
+	}
+	if got, ok := platforms["torch"].([]string); !ok || len(got) != 2 || got[0] != "darwin/arm64" || got[1] != "linux/amd64" {
+		t.Errorf("ParseUvLock() torch artifact platforms = %v, want [darwin/arm64 linux/amd64]", platforms["torch"])
+	}
+}
+
+func TestParseUvLock_DependencyGroups(t *testing.T) {
+	content := `version = 1
+
+[[package]]
+name = "my-project"
+version = "0.1.0"
+source = { editable = "." }
+dependencies = [
+    { name = "requests" },
+]
+
+[package.optional-dependencies]
+docs = [
+    { name = "sphinx" },
+]
+
+[package.dev-dependencies]
+dev = [
+    { name = "ruff" },
+    { name = "requests" },
+]
+test = [
+    { name = "pytest" },
+]
+
+[package.metadata]
+requires-dist = [
+    { name = "requests", specifier = ">=2" },
+]
+
+[[package]]
+name = "requests"
+version = "2.31.0"
+
+[[package]]
+name = "sphinx"
+version = "7.3.7"
+
+[[package]]
+name = "ruff"
+version = "0.4.0"
+
+[[package]]
+name = "pytest"
+version = "8.0.0"
+`
+	scopes := make(map[string]types.Scope)
+	for _, dep := range ParseUvLock([]byte(content), "my-project") {
+		scopes[dep.Name] = dep.Scope
+	}
+
+	expected := map[string]types.Scope{"requests": "", "sphinx": "", "ruff": "dev", "pytest": "test"}
+	if len(scopes) != len(expected) {
+		t.Fatalf("ParseUvLock() got %v, want %v", scopes, expected)
+	}
+	for name, want := range expected {
+		if scope, ok := scopes[name]; !ok || scope != want {
+			t.Errorf("ParseUvLock() dep %s scope = %q, want %q", name, scope, want)
+		}
+	}
+}
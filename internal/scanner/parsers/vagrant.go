@@ -0,0 +1,125 @@
+package parsers
+
+import (
+	"bufio"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Compile Vagrantfile regexes once at package level for performance
+var (
+	vagrantBoxRegex         = regexp.MustCompile(`\.vm\.box\s*=\s*["']([^"']+)["']`)
+	vagrantBoxVersionRegex  = regexp.MustCompile(`\.vm\.box_version\s*=\s*["']([^"']+)["']`)
+	vagrantProviderRegex    = regexp.MustCompile(`\.vm\.provider\s*\(?\s*[:"']([\w-]+)`)
+	vagrantProvisionerRegex = regexp.MustCompile(`\.vm\.provision\s*\(?\s*[:"']([\w-]+)`)
+	vagrantDefineRegex      = regexp.MustCompile(`\.vm\.define\s*\(?\s*[:"']([\w.-]+)`)
+	vagrantPluginsRegex     = regexp.MustCompile(`\.vagrant\.plugins\s*=\s*(.+)`)
+	vagrantQuotedRegex      = regexp.MustCompile(`["']([^"']+)["']`)
+)
+
+// vagrantProviderTechs maps Vagrant providers to the platform running the machines
+var vagrantProviderTechs = map[string]string{
+	"docker":        "docker",
+	"aws":           "aws",
+	"azure":         "azure",
+	"google":        "gcp",
+	"digital_ocean": "digitalocean",
+	"hetznercloud":  "hetzner",
+	"openstack":     "openstack",
+}
+
+// VagrantParser handles Vagrantfile parsing
+type VagrantParser struct{}
+
+// NewVagrantParser creates a new Vagrantfile parser
+func NewVagrantParser() *VagrantParser {
+	return &VagrantParser{}
+}
+
+// VagrantInfo describes the machines defined by a Vagrantfile
+type VagrantInfo struct {
+	File         string       `json:"file"`
+	Boxes        []VagrantBox `json:"boxes,omitempty"`
+	Machines     []string     `json:"machines,omitempty"` // Multi-machine names (config.vm.define)
+	Providers    []string     `json:"providers,omitempty"`
+	Provisioners []string     `json:"provisioners,omitempty"`
+	Plugins      []string     `json:"plugins,omitempty"`
+}
+
+// VagrantBox is a base box with its version constraint
+type VagrantBox struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// ParseVagrantfile extracts boxes, providers, provisioners, and plugins from a Vagrantfile.
+// A box_version applies to the box set last before it.
+func (p *VagrantParser) ParseVagrantfile(content string) *VagrantInfo {
+	info := &VagrantInfo{}
+	var providers, provisioners, plugins []string
+	currentBox := -1
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if match := vagrantBoxRegex.FindStringSubmatch(line); match != nil {
+			currentBox = info.addBox(match[1])
+		}
+		if match := vagrantBoxVersionRegex.FindStringSubmatch(line); match != nil && currentBox >= 0 {
+			info.Boxes[currentBox].Version = match[1]
+		}
+		if match := vagrantDefineRegex.FindStringSubmatch(line); match != nil {
+			info.Machines = append(info.Machines, match[1])
+		}
+		if match := vagrantProviderRegex.FindStringSubmatch(line); match != nil {
+			providers = append(providers, match[1])
+		}
+		if match := vagrantProvisionerRegex.FindStringSubmatch(line); match != nil {
+			provisioners = append(provisioners, match[1])
+		}
+		if match := vagrantPluginsRegex.FindStringSubmatch(line); match != nil {
+			for _, plugin := range vagrantQuotedRegex.FindAllStringSubmatch(match[1], -1) {
+				plugins = append(plugins, plugin[1])
+			}
+		}
+	}
+
+	info.Providers = sortedUnique(providers)
+	info.Provisioners = sortedUnique(provisioners)
+	info.Plugins = sortedUnique(plugins)
+	sort.Strings(info.Machines)
+	return info
+}
+
+// IsEmpty reports whether no box, provider, or provisioner was found
+func (info *VagrantInfo) IsEmpty() bool {
+	return len(info.Boxes) == 0 && len(info.Providers) == 0 && len(info.Provisioners) == 0
+}
+
+// Techs returns the platforms and configuration tools referenced by the providers and provisioners
+func (info *VagrantInfo) Techs() []string {
+	var techs []string
+	for _, provider := range info.Providers {
+		techs = append(techs, vagrantProviderTechs[provider])
+	}
+	for _, provisioner := range info.Provisioners {
+		techs = append(techs, provisionerTechs[provisioner])
+	}
+	return sortedUnique(techs)
+}
+
+// addBox adds a box once and returns its index
+func (info *VagrantInfo) addBox(name string) int {
+	for i, box := range info.Boxes {
+		if box.Name == name {
+			return i
+		}
+	}
+	info.Boxes = append(info.Boxes, VagrantBox{Name: name})
+	return len(info.Boxes) - 1
+}
@@ -0,0 +1,51 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVagrantfile(t *testing.T) {
+	content := `# -*- mode: ruby -*-
+Vagrant.configure("2") do |config|
+  config.vagrant.plugins = ["vagrant-vbguest", "vagrant-hostmanager"]
+  config.vm.box = "ubuntu/jammy64"
+  config.vm.box_version = ">= 20240101.0.0"
+
+  config.vm.define "web" do |web|
+    web.vm.box = "generic/debian12"
+    web.vm.box_version = "4.3.12"
+    web.vm.provision :ansible do |ansible|
+      ansible.playbook = "site.yml"
+    end
+  end
+
+  config.vm.define :db
+
+  config.vm.provider "virtualbox" do |vb|
+    vb.memory = 2048
+  end
+  config.vm.provider :docker
+  config.vm.provision "shell", inline: "apt-get update"
+  # config.vm.box = "commented/out"
+end
+`
+	info := NewVagrantParser().ParseVagrantfile(content)
+
+	assert.Equal(t, []VagrantBox{
+		{Name: "ubuntu/jammy64", Version: ">= 20240101.0.0"},
+		{Name: "generic/debian12", Version: "4.3.12"},
+	}, info.Boxes)
+	assert.Equal(t, []string{"db", "web"}, info.Machines)
+	assert.Equal(t, []string{"docker", "virtualbox"}, info.Providers)
+	assert.Equal(t, []string{"ansible", "shell"}, info.Provisioners)
+	assert.Equal(t, []string{"vagrant-hostmanager", "vagrant-vbguest"}, info.Plugins)
+	assert.Equal(t, []string{"ansible", "docker"}, info.Techs())
+	assert.False(t, info.IsEmpty())
+}
+
+func TestParseVagrantfile_Empty(t *testing.T) {
+	info := NewVagrantParser().ParseVagrantfile("# nothing configured\n")
+	assert.True(t, info.IsEmpty())
+}
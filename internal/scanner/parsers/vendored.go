@@ -0,0 +1,164 @@
+package parsers
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// VendoredFingerprint identifies a well-known project from a file of its source tree
+type VendoredFingerprint struct {
+	Name        string         // Dependency name
+	Upstream    string         // Upstream repository
+	Files       []string       // Candidate locations of the identifying file, relative to the project root
+	Marker      string         // Text the identifying file contains
+	Version     *regexp.Regexp // Extracts the version (capture groups joined with ".")
+	VersionFile string         // File holding the version, if not the identifying file
+}
+
+// vendoredFingerprints are the projects recognized in vendored directories
+var vendoredFingerprints = []VendoredFingerprint{
+	{Name: "zlib", Upstream: "https://github.com/madler/zlib", Files: []string{"zlib.h"}, Marker: "Jean-loup Gailly",
+		Version: regexp.MustCompile(`#define\s+ZLIB_VERSION\s+"([^"]+)"`)},
+	{Name: "sqlite", Upstream: "https://sqlite.org", Files: []string{"sqlite3.h"}, Marker: "SQLITE_VERSION",
+		Version: regexp.MustCompile(`#define\s+SQLITE_VERSION\s+"([^"]+)"`)},
+	{Name: "lua", Upstream: "https://www.lua.org", Files: []string{"src/lua.h", "lua.h"}, Marker: "Lua.org, PUC-Rio",
+		Version: regexp.MustCompile(`(?s)LUA_VERSION_MAJOR(?:_N)?\s+"?(\d+)"?.*?LUA_VERSION_MINOR(?:_N)?\s+"?(\d+)"?.*?LUA_VERSION_RELEASE(?:_N)?\s+"?(\d+)"?`)},
+	{Name: "nlohmann_json", Upstream: "https://github.com/nlohmann/json",
+		Files:   []string{"single_include/nlohmann/json.hpp", "include/nlohmann/json.hpp", "nlohmann/json.hpp", "json.hpp"},
+		Marker:  "NLOHMANN_JSON_VERSION_MAJOR",
+		Version: regexp.MustCompile(`(?s)NLOHMANN_JSON_VERSION_MAJOR\s+(\d+).*?NLOHMANN_JSON_VERSION_MINOR\s+(\d+).*?NLOHMANN_JSON_VERSION_PATCH\s+(\d+)`)},
+	{Name: "googletest", Upstream: "https://github.com/google/googletest",
+		Files: []string{"googletest/include/gtest/gtest.h", "include/gtest/gtest.h"}, Marker: "GTEST_H_",
+		Version: regexp.MustCompile(`set\(GOOGLETEST_VERSION\s+([\w.]+)\)`), VersionFile: "CMakeLists.txt"},
+	{Name: "libpng", Upstream: "https://github.com/pnggroup/libpng", Files: []string{"png.h"}, Marker: "PNG_LIBPNG_VER_STRING",
+		Version: regexp.MustCompile(`#define\s+PNG_LIBPNG_VER_STRING\s+"([^"]+)"`)},
+	{Name: "curl", Upstream: "https://github.com/curl/curl", Files: []string{"include/curl/curlver.h"}, Marker: "LIBCURL_VERSION",
+		Version: regexp.MustCompile(`#define\s+LIBCURL_VERSION\s+"([^"]+)"`)},
+	{Name: "openssl", Upstream: "https://github.com/openssl/openssl", Files: []string{"include/openssl/opensslv.h"}, Marker: "OPENSSL_VERSION",
+		Version: regexp.MustCompile(`OPENSSL_VERSION_TEXT\s+"OpenSSL ([\w.]+)`)},
+	{Name: "stb", Upstream: "https://github.com/nothings/stb", Files: []string{"stb_image.h"}, Marker: "stb_image",
+		Version: regexp.MustCompile(`stb_image - v([\d.]+)`)},
+	{Name: "imgui", Upstream: "https://github.com/ocornut/imgui", Files: []string{"imgui.h"}, Marker: "IMGUI_VERSION",
+		Version: regexp.MustCompile(`#define\s+IMGUI_VERSION\s+"([^"]+)"`)},
+	{Name: "catch2", Upstream: "https://github.com/catchorg/Catch2",
+		Files: []string{"single_include/catch2/catch.hpp", "catch2/catch.hpp", "catch.hpp"}, Marker: "Catch v",
+		Version: regexp.MustCompile(`Catch v(\d+\.\d+\.\d+)`)},
+	{Name: "eigen", Upstream: "https://gitlab.com/libeigen/eigen", Files: []string{"Eigen/src/Core/util/Macros.h"}, Marker: "EIGEN_WORLD_VERSION",
+		Version: regexp.MustCompile(`(?s)EIGEN_WORLD_VERSION\s+(\d+).*?EIGEN_MAJOR_VERSION\s+(\d+).*?EIGEN_MINOR_VERSION\s+(\d+)`)},
+	{Name: "pybind11", Upstream: "https://github.com/pybind/pybind11", Files: []string{"include/pybind11/detail/common.h"}, Marker: "PYBIND11_VERSION_MAJOR",
+		Version: regexp.MustCompile(`(?s)PYBIND11_VERSION_MAJOR\s+(\d+).*?PYBIND11_VERSION_MINOR\s+(\d+).*?PYBIND11_VERSION_PATCH\s+(\w+)`)},
+	{Name: "jquery", Upstream: "https://github.com/jquery/jquery",
+		Files: []string{"jquery.js", "jquery.min.js", "dist/jquery.js", "dist/jquery.min.js"}, Marker: "jQuery",
+		Version: regexp.MustCompile(`jQuery (?:JavaScript Library )?v(\d+\.\d+\.\d+)`)},
+}
+
+// vendoredLicenseFiles are the license files marking the root of a vendored project
+var vendoredLicenseFiles = []string{"LICENSE", "LICENSE.txt", "LICENSE.md", "COPYING", "COPYING.txt", "LICENCE"}
+
+// vendoredVersionFiles are the files holding the version of a vendored project
+var vendoredVersionFiles = []string{"VERSION", "VERSION.txt", "version.txt"}
+
+var vendoredVersionRegex = regexp.MustCompile(`^v?\d+(\.\d+)+([-+.]\w+)*$`)
+
+// VendoredProject is a copy of a project's source tree in the repository
+type VendoredProject struct {
+	Name         string
+	Upstream     string // Upstream repository (known projects only)
+	Version      string
+	Dir          string // Project directory, relative to the scan root
+	Evidence     string // File the project was identified from, relative to the project directory
+	SubtreeSplit string // Upstream commit of the latest git subtree merge
+}
+
+// VendoredParser identifies vendored copies of open-source projects
+type VendoredParser struct{}
+
+// NewVendoredParser creates a new vendored project parser
+func NewVendoredParser() *VendoredParser {
+	return &VendoredParser{}
+}
+
+// IdentifyProject identifies the project in dir: a well-known project recognized by a
+// fingerprint file, else a project with a license file named after the directory. The version
+// comes from the fingerprint, else from a VERSION file. Returns false if dir holds neither.
+func (p *VendoredParser) IdentifyProject(dir string, provider types.Provider) (VendoredProject, bool) {
+	for _, fingerprint := range vendoredFingerprints {
+		for _, file := range fingerprint.Files {
+			content, err := provider.ReadFile(filepath.Join(dir, file))
+			if err != nil || !strings.Contains(string(content), fingerprint.Marker) {
+				continue
+			}
+			project := VendoredProject{Name: fingerprint.Name, Upstream: fingerprint.Upstream, Evidence: file}
+			if fingerprint.VersionFile != "" {
+				content, _ = provider.ReadFile(filepath.Join(dir, fingerprint.VersionFile))
+			}
+			if match := fingerprint.Version.FindStringSubmatch(string(content)); match != nil {
+				project.Version = strings.Join(match[1:], ".")
+			}
+			if project.Version == "" {
+				project.Version = p.versionFile(dir, provider)
+			}
+			return project, true
+		}
+	}
+
+	for _, file := range vendoredLicenseFiles {
+		if _, err := provider.ReadFile(filepath.Join(dir, file)); err == nil {
+			return VendoredProject{Name: filepath.Base(dir), Evidence: file, Version: p.versionFile(dir, provider)}, true
+		}
+	}
+	return VendoredProject{}, false
+}
+
+// versionFile returns the version recorded in a VERSION file of dir, if any
+func (p *VendoredParser) versionFile(dir string, provider types.Provider) string {
+	for _, file := range vendoredVersionFiles {
+		content, err := provider.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			continue
+		}
+		version := strings.TrimSpace(strings.SplitN(strings.TrimSpace(string(content)), "\n", 2)[0])
+		if vendoredVersionRegex.MatchString(version) {
+			return version
+		}
+	}
+	return ""
+}
+
+// Dependency converts a vendored project to a dependency flagged with "vendored-fork". The
+// version is the project version, else the git subtree commit, else "latest". The "path"
+// metadata records the project directory, "upstream" the upstream repository, and
+// "subtree_split" the upstream commit of a git subtree.
+func (p *VendoredParser) Dependency(project VendoredProject) types.Dependency {
+	source := MetadataSourceGitSubtree
+	if project.Evidence != "" {
+		source = pathBase(project.Evidence)
+	}
+	metadata := types.NewMetadata(source)
+	metadata[MetadataVendoredFork] = true
+	metadata["path"] = project.Dir
+	if project.Upstream != "" {
+		metadata["upstream"] = project.Upstream
+	}
+	if project.SubtreeSplit != "" {
+		metadata["subtree_split"] = project.SubtreeSplit
+	}
+	version := project.Version
+	if version == "" {
+		version = project.SubtreeSplit
+	}
+	if version == "" {
+		version = "latest"
+	}
+	return types.Dependency{
+		Type:     DependencyTypeVendored,
+		Name:     project.Name,
+		Version:  version,
+		Scope:    types.ScopeProd,
+		Direct:   true,
+		Metadata: metadata,
+	}
+}
@@ -0,0 +1,79 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVendoredParser_IdentifyProject(t *testing.T) {
+	parser := NewVendoredParser()
+	provider := &mockFileProvider{files: map[string]string{
+		"/repo/third_party/zlib/zlib.h": "/* zlib.h -- interface of the 'zlib' general purpose compression library\n  Copyright (C) 1995-2024 Jean-loup Gailly and Mark Adler\n*/\n#define ZLIB_VERSION \"1.3.1\"\n",
+
+		"/repo/third_party/json/single_include/nlohmann/json.hpp": "#define NLOHMANN_JSON_VERSION_MAJOR 3   // NOLINT\n#define NLOHMANN_JSON_VERSION_MINOR 11  // NOLINT\n#define NLOHMANN_JSON_VERSION_PATCH 3   // NOLINT\n",
+
+		"/repo/third_party/googletest/googletest/include/gtest/gtest.h": "#ifndef GOOGLETEST_INCLUDE_GTEST_GTEST_H_\n",
+		"/repo/third_party/googletest/CMakeLists.txt":                   "project(googletest-distribution)\nset(GOOGLETEST_VERSION 1.14.0)\n",
+
+		"/repo/third_party/lua/src/lua.h": "** Lua.org, PUC-Rio, Brazil (www.lua.org)\n#define LUA_VERSION_MAJOR\t\"5\"\n#define LUA_VERSION_MINOR\t\"4\"\n#define LUA_VERSION_RELEASE\t\"6\"\n",
+
+		"/repo/vendor/libfoo/LICENSE": "MIT License",
+		"/repo/vendor/libfoo/VERSION": "2.4.0\n",
+
+		"/repo/vendor/notes/README.md": "Notes",
+	}}
+
+	tests := []struct {
+		dir      string
+		name     string
+		version  string
+		upstream string
+		evidence string
+	}{
+		{"/repo/third_party/zlib", "zlib", "1.3.1", "https://github.com/madler/zlib", "zlib.h"},
+		{"/repo/third_party/json", "nlohmann_json", "3.11.3", "https://github.com/nlohmann/json", "single_include/nlohmann/json.hpp"},
+		{"/repo/third_party/googletest", "googletest", "1.14.0", "https://github.com/google/googletest", "googletest/include/gtest/gtest.h"},
+		{"/repo/third_party/lua", "lua", "5.4.6", "https://www.lua.org", "src/lua.h"},
+		{"/repo/vendor/libfoo", "libfoo", "2.4.0", "", "LICENSE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, ok := parser.IdentifyProject(tt.dir, provider)
+			require.True(t, ok)
+			assert.Equal(t, tt.name, project.Name)
+			assert.Equal(t, tt.version, project.Version)
+			assert.Equal(t, tt.upstream, project.Upstream)
+			assert.Equal(t, tt.evidence, project.Evidence)
+		})
+	}
+
+	_, ok := parser.IdentifyProject("/repo/vendor/notes", provider)
+	assert.False(t, ok, "no fingerprint or license file")
+}
+
+func TestVendoredParser_Dependency(t *testing.T) {
+	parser := NewVendoredParser()
+
+	dep := parser.Dependency(VendoredProject{Name: "zlib", Upstream: "https://github.com/madler/zlib", Version: "1.3.1", Dir: "third_party/zlib", Evidence: "zlib.h"})
+	assert.Equal(t, DependencyTypeVendored, dep.Type)
+	assert.Equal(t, "zlib", dep.Name)
+	assert.Equal(t, "1.3.1", dep.Version)
+	assert.True(t, dep.Direct)
+	assert.Equal(t, "zlib.h", dep.Metadata["source"])
+	assert.Equal(t, true, dep.Metadata[MetadataVendoredFork])
+	assert.Equal(t, "third_party/zlib", dep.Metadata["path"])
+	assert.Equal(t, "https://github.com/madler/zlib", dep.Metadata["upstream"])
+	assert.Nil(t, dep.Metadata["subtree_split"])
+
+	// Git subtree without a recognized project: the upstream commit is the version
+	dep = parser.Dependency(VendoredProject{Name: "theme", Dir: "web/theme", SubtreeSplit: "51b7f2abdade71cd9bb0e7a373ef2610ec6f9daf"})
+	assert.Equal(t, "51b7f2abdade71cd9bb0e7a373ef2610ec6f9daf", dep.Version)
+	assert.Equal(t, MetadataSourceGitSubtree, dep.Metadata["source"])
+	assert.Equal(t, "51b7f2abdade71cd9bb0e7a373ef2610ec6f9daf", dep.Metadata["subtree_split"])
+	assert.Nil(t, dep.Metadata["upstream"])
+
+	dep = parser.Dependency(VendoredProject{Name: "libbar", Dir: "vendor/libbar", Evidence: "COPYING"})
+	assert.Equal(t, "latest", dep.Version)
+}
@@ -0,0 +1,67 @@
+package parsers
+
+import (
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/semver"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// versionSystems maps dependency types to the versioning system of their ecosystem
+var versionSystems = map[string]semver.System{
+	DependencyTypeNpm:    semver.NPM,
+	DependencyTypeRust:   semver.Cargo,
+	DependencyTypeDotnet: semver.NPM, // NuGet versions are semver
+	DependencyTypePHP:    semver.NPM,
+	DependencyTypeGolang: semver.NPM,
+	DependencyTypeHex:    semver.NPM,
+	DependencyTypePython: semver.PyPI,
+	DependencyTypeMaven:  semver.Maven,
+	DependencyTypeGradle: semver.Maven,
+	DependencyTypeIvy:    semver.Maven,
+}
+
+// VersionSystem returns the versioning system of a dependency type, or nil if it has none
+func VersionSystem(depType string) semver.System {
+	return versionSystems[depType]
+}
+
+// NormalizeDependencyVersion returns the canonical form of a concrete dependency version, or ""
+// for ranges, placeholders, unparsable versions, Go modules, and types without a versioning
+// system
+func NormalizeDependencyVersion(depType, version string) string {
+	system := VersionSystem(depType)
+	if system == nil || depType == DependencyTypeGolang || !isConcreteVersion(version) { // Go module versions are canonical (v1.2.3)
+		return ""
+	}
+	parsed, err := system.Parse(strings.TrimLeft(version, "="))
+	if err != nil {
+		return ""
+	}
+	return parsed.Canon(true)
+}
+
+// isConcreteVersion reports whether a version names a single version: it starts with a digit
+// (after an exact "==" or "=" operator and a "v") and has no spaces, wildcards, or range operators
+func isConcreteVersion(version string) bool {
+	v := strings.TrimPrefix(strings.TrimPrefix(strings.TrimLeft(version, "="), "v"), "V")
+	if v == "" || v[0] < '0' || v[0] > '9' {
+		return false
+	}
+	return !strings.ContainsAny(v, " *,|<>^~=[]()$")
+}
+
+// NormalizeDependencyVersions sets the normalized version of the dependencies read by a
+// parser ("1.0" becomes "1.0.0", "v1.2.3" becomes "1.2.3"). It is left empty when the
+// canonical version equals the version read or the version is not concrete. Returns the
+// dependencies.
+func NormalizeDependencyVersions(dependencies []types.Dependency) []types.Dependency {
+	for i, dep := range dependencies {
+		normalized := NormalizeDependencyVersion(dep.Type, dep.Version)
+		if normalized == dep.Version {
+			normalized = ""
+		}
+		dependencies[i].NormalizedVersion = normalized
+	}
+	return dependencies
+}
@@ -0,0 +1,75 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+func TestNormalizeDependencyVersion(t *testing.T) {
+	tests := []struct {
+		depType, version, want string
+	}{
+		{DependencyTypeNpm, "1.0", "1.0.0"},
+		{DependencyTypeNpm, "v1.2.3", "1.2.3"},
+		{DependencyTypeNpm, "=1.2.3", "1.2.3"},
+		{DependencyTypeNpm, "1.2.3", "1.2.3"},
+		{DependencyTypeNpm, "^1.2.3", ""},
+		{DependencyTypeNpm, "1.x", ""},
+		{DependencyTypeNpm, "workspace:*", ""},
+		{DependencyTypePython, "==2.31", "2.31"},
+		{DependencyTypePython, "2.0.0RC1", "2.0.0rc1"},
+		{DependencyTypePython, ">=2.0", ""},
+		{DependencyTypeMaven, "5.3.30.RELEASE", "5.3.30"},
+		{DependencyTypeMaven, "[1.0,2.0)", ""},
+		{DependencyTypeMaven, "${spring.version}", ""},
+		{DependencyTypeRust, "1.0.197", "1.0.197"},
+		{DependencyTypeRust, "=1.2.3", "1.2.3"},
+		{DependencyTypeRust, "1.0", ""},
+		{DependencyTypeRust, "v1.2.3", ""},
+		{DependencyTypeGolang, "v1.2.3", ""},
+		{DependencyTypeRuby, "7.1.0", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, NormalizeDependencyVersion(tt.depType, tt.version), tt.depType+" "+tt.version)
+	}
+}
+
+func TestNormalizeDependencyVersions(t *testing.T) {
+	dependencies := []types.Dependency{
+		{Type: DependencyTypeNpm, Name: "a", Version: "v1.2.3"},
+		{Type: DependencyTypeNpm, Name: "b", Version: "1.2.3"},
+		{Type: DependencyTypeNpm, Name: "c", Version: "1.0"},
+		{Type: DependencyTypeNpm, Name: "d", Version: "^1.0", NormalizedVersion: "1.0.0"},
+	}
+
+	assert.Equal(t, dependencies, NormalizeDependencyVersions(dependencies))
+	assert.Equal(t, "1.2.3", dependencies[0].NormalizedVersion)
+	assert.Empty(t, dependencies[1].NormalizedVersion, "already canonical")
+	assert.Equal(t, "1.0.0", dependencies[2].NormalizedVersion)
+	assert.Empty(t, dependencies[3].NormalizedVersion, "ranges are not normalized")
+}
+
+func TestParsersNormalizeVersions(t *testing.T) {
+	python := NewPythonParser().ParseRequirementsTxt("django==4.2.0RC1\nrequests>=2.31\n")
+	normalized := map[string]string{}
+	for _, dep := range python {
+		normalized[dep.Name] = dep.NormalizedVersion
+	}
+	assert.Equal(t, map[string]string{"django": "4.2.0rc1", "requests": ""}, normalized)
+
+	cargo := ParseCargoLock([]byte("[[package]]\nname = \"app\"\nversion = \"0.1.0\"\ndependencies = [\"serde\"]\n\n[[package]]\nname = \"serde\"\nversion = \"1.0.197\"\n"),
+		"[package]\nname = \"app\"\n\n[dependencies]\nserde = \"1.0\"\n")
+	require.Len(t, cargo, 1)
+	assert.Empty(t, cargo[0].NormalizedVersion, "Cargo.lock versions are canonical")
+
+	_, _, manifest, _ := NewRustParser().ParseCargoToml("[package]\nname = \"app\"\n\n[dependencies]\nserde = \"1.0\"\nrand = \"=0.8.5\"\n")
+	normalized = map[string]string{}
+	for _, dep := range manifest {
+		normalized[dep.Name] = dep.NormalizedVersion
+	}
+	assert.Equal(t, map[string]string{"serde": "", "rand": "0.8.5"}, normalized, "Cargo requirements are not versions, exact ones are")
+}
@@ -0,0 +1,77 @@
+package parsers
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Pre-compiled regexes for V module manifests
+var (
+	vModFieldRegex  = regexp.MustCompile(`(?m)^\s*(name|version|description|license)\s*:\s*['"]([^'"]*)['"]`)
+	vModDepsRegex   = regexp.MustCompile(`(?s)dependencies\s*:\s*\[(.*?)\]`)
+	vModStringRegex = regexp.MustCompile(`['"]([^'"]+)['"]`)
+)
+
+// VModule represents a V module manifest (v.mod)
+type VModule struct {
+	Name         string
+	Version      string
+	Description  string
+	License      string
+	Dependencies []string
+}
+
+// VlangParser handles V module manifests
+type VlangParser struct{}
+
+// NewVlangParser creates a new V parser
+func NewVlangParser() *VlangParser {
+	return &VlangParser{}
+}
+
+// ParseVMod parses a v.mod manifest (Module { name: '...', dependencies: [...] })
+func (p *VlangParser) ParseVMod(content string) VModule {
+	var module VModule
+	for _, match := range vModFieldRegex.FindAllStringSubmatch(content, -1) {
+		switch match[1] {
+		case "name":
+			module.Name = match[2]
+		case "version":
+			module.Version = match[2]
+		case "description":
+			module.Description = match[2]
+		case "license":
+			module.License = match[2]
+		}
+	}
+	if match := vModDepsRegex.FindStringSubmatch(content); match != nil {
+		for _, dep := range vModStringRegex.FindAllStringSubmatch(match[1], -1) {
+			module.Dependencies = append(module.Dependencies, dep[1])
+		}
+	}
+	return module
+}
+
+// Dependencies converts the module dependencies to dependencies. VPM installs the latest
+// version of a module (or the default branch of a repository URL), so no version is declared.
+func (p *VlangParser) Dependencies(module VModule) []types.Dependency {
+	var dependencies []types.Dependency
+	for _, name := range module.Dependencies {
+		metadata := types.NewMetadata(MetadataSourceVMod)
+		if strings.Contains(name, "://") {
+			metadata["url"] = name
+			name = strings.TrimSuffix(pathBase(name), ".git")
+		}
+		dependencies = append(dependencies, types.Dependency{
+			Type:     DependencyTypeVpm,
+			Name:     name,
+			Version:  "latest",
+			Scope:    types.ScopeProd,
+			Direct:   true,
+			Metadata: metadata,
+		})
+	}
+	return dependencies
+}
@@ -0,0 +1,41 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVlangParser_ParseVMod(t *testing.T) {
+	parser := NewVlangParser()
+
+	content := `Module {
+	name: 'webapp'
+	description: 'A small web application'
+	version: '0.2.0'
+	license: 'MIT'
+	dependencies: [
+		'vsl',
+		'https://github.com/example/vtools.git'
+	]
+}`
+
+	module := parser.ParseVMod(content)
+	assert.Equal(t, "webapp", module.Name)
+	assert.Equal(t, "0.2.0", module.Version)
+	assert.Equal(t, "MIT", module.License)
+	assert.Equal(t, []string{"vsl", "https://github.com/example/vtools.git"}, module.Dependencies)
+
+	deps := parser.Dependencies(module)
+	require.Len(t, deps, 2)
+	assert.Equal(t, types.Dependency{
+		Type: DependencyTypeVpm, Name: "vsl", Version: "latest", Scope: types.ScopeProd, Direct: true,
+		Metadata: map[string]interface{}{"source": "v.mod"},
+	}, deps[0])
+	assert.Equal(t, "vtools", deps[1].Name)
+	assert.Equal(t, "https://github.com/example/vtools.git", deps[1].Metadata["url"])
+
+	assert.Empty(t, parser.ParseVMod("Module {\n\tname: 'empty'\n\tdependencies: []\n}").Dependencies)
+}
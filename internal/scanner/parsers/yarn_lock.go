@@ -23,9 +23,9 @@ func ParseYarnLockWithOptions(lockContent []byte, packageJSON *PackageJSON, opti
 	yarnVersion := DetectYarnVersion(lockContent)
 
 	if yarnVersion == "berry" {
-		return parseYarnLockBerryWithOptions(lockContent, packageJSON, options)
+		return NormalizeDependencyVersions(parseYarnLockBerryWithOptions(lockContent, packageJSON, options))
 	} else {
-		return parseYarnLockClassicWithOptions(lockContent, packageJSON, options)
+		return NormalizeDependencyVersions(parseYarnLockClassicWithOptions(lockContent, packageJSON, options))
 	}
 }
 
@@ -0,0 +1,328 @@
+package parsers
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// zigTagRegex extracts the release tag of archive URLs (.../refs/tags/v1.2.0.tar.gz, .../archive/v1.2.0.zip)
+var zigTagRegex = regexp.MustCompile(`/(?:refs/tags|archive(?:/refs/tags)?|releases/download)/([^/]+?)(?:\.tar\.gz|\.tar\.xz|\.tgz|\.zip)?(?:/|$)`)
+
+// ZigPackage represents a Zig package manifest (build.zig.zon)
+type ZigPackage struct {
+	Name              string
+	Version           string
+	MinimumZigVersion string
+	Dependencies      []ZigDependency
+}
+
+// ZigDependency is an entry of the .dependencies table: a remote package (url and content
+// hash) or a local package (path)
+type ZigDependency struct {
+	Name string
+	URL  string
+	Hash string
+	Path string
+	Lazy bool
+}
+
+// ZigParser handles Zig package manifests
+type ZigParser struct{}
+
+// NewZigParser creates a new Zig parser
+func NewZigParser() *ZigParser {
+	return &ZigParser{}
+}
+
+// ParseBuildZigZon parses a build.zig.zon manifest (Zig object notation)
+func (p *ZigParser) ParseBuildZigZon(content string) (ZigPackage, error) {
+	parser := &zonParser{input: content}
+	value, err := parser.parseValue()
+	if err != nil {
+		return ZigPackage{}, err
+	}
+	root, ok := value.(map[string]interface{})
+	if !ok {
+		return ZigPackage{}, fmt.Errorf("build.zig.zon: expected a struct literal")
+	}
+
+	pkg := ZigPackage{
+		Name:              zonString(root["name"]),
+		Version:           zonString(root["version"]),
+		MinimumZigVersion: zonString(root["minimum_zig_version"]),
+	}
+	deps, _ := root["dependencies"].(map[string]interface{})
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fields, ok := deps[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		lazy, _ := fields["lazy"].(bool)
+		pkg.Dependencies = append(pkg.Dependencies, ZigDependency{
+			Name: name,
+			URL:  zonString(fields["url"]),
+			Hash: zonString(fields["hash"]),
+			Path: zonString(fields["path"]),
+			Lazy: lazy,
+		})
+	}
+	return pkg, nil
+}
+
+// Dependencies converts the dependency table to dependencies. Packages carry no version; the
+// git ref or release tag of the URL is used when present, else "latest". The content hash, which
+// pins the exact package contents, is kept in the metadata. Lazy dependencies (only fetched
+// when used by the build) are optional.
+func (p *ZigParser) Dependencies(pkg ZigPackage) []types.Dependency {
+	var dependencies []types.Dependency
+	for _, dep := range pkg.Dependencies {
+		metadata := types.NewMetadata(MetadataSourceBuildZigZon)
+		version := "latest"
+		switch {
+		case dep.Path != "":
+			metadata["path"] = dep.Path
+		case dep.URL != "":
+			metadata["url"] = dep.URL
+			if ref := zigURLRef(dep.URL); ref != "" {
+				version = ref
+			}
+		}
+		if dep.Hash != "" {
+			metadata["hash"] = dep.Hash
+		}
+		scope := types.ScopeProd
+		if dep.Lazy {
+			scope = types.ScopeOptional
+		}
+		dependencies = append(dependencies, types.Dependency{
+			Type:     DependencyTypeZig,
+			Name:     dep.Name,
+			Version:  version,
+			Scope:    scope,
+			Direct:   true,
+			Metadata: metadata,
+		})
+	}
+	return dependencies
+}
+
+// zigURLRef returns the git ref of a git+ URL ("#<ref>") or the release tag of an archive URL
+func zigURLRef(url string) string {
+	if base, ref, ok := strings.Cut(url, "#"); ok {
+		if strings.HasPrefix(base, "git+") {
+			return ref
+		}
+		url = base
+	}
+	if match := zigTagRegex.FindStringSubmatch(url); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// zonString returns the value of a string or enum literal field
+func zonString(value interface{}) string {
+	s, _ := value.(string)
+	return s
+}
+
+// zonParser is a recursive descent parser for Zig object notation. Struct literals with named
+// fields become maps, anonymous list literals become slices, enum literals (.name) become
+// strings, and numbers are kept as their source text.
+type zonParser struct {
+	input string
+	pos   int
+}
+
+// parseValue parses a struct or list literal, string, enum literal, or scalar
+func (z *zonParser) parseValue() (interface{}, error) {
+	z.skipSpace()
+	if z.pos >= len(z.input) {
+		return nil, fmt.Errorf("build.zig.zon: unexpected end of input")
+	}
+	switch c := z.input[z.pos]; {
+	case c == '.':
+		z.pos++
+		z.skipSpace()
+		if z.pos < len(z.input) && z.input[z.pos] == '{' {
+			z.pos++
+			return z.parseContainer()
+		}
+		return z.parseIdentifier()
+	case c == '"':
+		return z.parseString()
+	case c == '\\':
+		return z.parseMultilineString(), nil
+	default:
+		start := z.pos
+		for z.pos < len(z.input) && !strings.ContainsRune(" \t\r\n,}", rune(z.input[z.pos])) {
+			z.pos++
+		}
+		switch token := z.input[start:z.pos]; token {
+		case "":
+			return nil, fmt.Errorf("build.zig.zon: unexpected %q at offset %d", c, start)
+		case "true", "false":
+			return token == "true", nil
+		case "null":
+			return nil, nil
+		default:
+			return token, nil
+		}
+	}
+}
+
+// parseContainer parses the contents of .{ ... } after the opening brace
+func (z *zonParser) parseContainer() (interface{}, error) {
+	fields := make(map[string]interface{})
+	var items []interface{}
+	for {
+		z.skipSpace()
+		if z.pos >= len(z.input) {
+			return nil, fmt.Errorf("build.zig.zon: unterminated struct literal")
+		}
+		if z.input[z.pos] == '}' {
+			z.pos++
+			break
+		}
+		if name, ok, err := z.parseFieldName(); err != nil {
+			return nil, err
+		} else if ok {
+			value, err := z.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			fields[name] = value
+		} else {
+			value, err := z.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+		z.skipSpace()
+		if z.pos < len(z.input) && z.input[z.pos] == ',' {
+			z.pos++
+		}
+	}
+	if len(items) > 0 {
+		return items, nil
+	}
+	return fields, nil
+}
+
+// parseFieldName consumes ".name =" and returns the field name, or false for list items
+func (z *zonParser) parseFieldName() (string, bool, error) {
+	start := z.pos
+	if z.input[z.pos] != '.' {
+		return "", false, nil
+	}
+	z.pos++
+	if z.pos < len(z.input) && z.input[z.pos] == '{' {
+		z.pos = start
+		return "", false, nil
+	}
+	name, err := z.parseIdentifier()
+	if err != nil {
+		return "", false, err
+	}
+	z.skipSpace()
+	if z.pos >= len(z.input) || z.input[z.pos] != '=' {
+		z.pos = start
+		return "", false, nil
+	}
+	z.pos++
+	return name.(string), true, nil
+}
+
+// parseIdentifier parses a bare identifier or a quoted @"identifier"
+func (z *zonParser) parseIdentifier() (interface{}, error) {
+	if strings.HasPrefix(z.input[z.pos:], `@"`) {
+		z.pos++
+		return z.parseString()
+	}
+	start := z.pos
+	for z.pos < len(z.input) {
+		c := z.input[z.pos]
+		if c != '_' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			break
+		}
+		z.pos++
+	}
+	if z.pos == start {
+		return nil, fmt.Errorf("build.zig.zon: expected identifier at offset %d", start)
+	}
+	return z.input[start:z.pos], nil
+}
+
+// parseString parses a double quoted string with escape sequences
+func (z *zonParser) parseString() (interface{}, error) {
+	z.pos++ // opening quote
+	var b strings.Builder
+	for z.pos < len(z.input) {
+		c := z.input[z.pos]
+		switch c {
+		case '"':
+			z.pos++
+			return b.String(), nil
+		case '\\':
+			if z.pos+1 < len(z.input) {
+				z.pos++
+				switch e := z.input[z.pos]; e {
+				case 'n':
+					b.WriteByte('\n')
+				case 't':
+					b.WriteByte('\t')
+				default:
+					b.WriteByte(e)
+				}
+			}
+		default:
+			b.WriteByte(c)
+		}
+		z.pos++
+	}
+	return nil, fmt.Errorf("build.zig.zon: unterminated string")
+}
+
+// parseMultilineString parses consecutive \\ lines
+func (z *zonParser) parseMultilineString() string {
+	var lines []string
+	for strings.HasPrefix(z.input[z.pos:], `\\`) {
+		end := strings.IndexByte(z.input[z.pos:], '\n')
+		if end < 0 {
+			end = len(z.input) - z.pos
+		}
+		lines = append(lines, strings.TrimRight(z.input[z.pos+2:z.pos+end], "\r"))
+		z.pos += end
+		z.skipSpace()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// skipSpace skips whitespace and // comments
+func (z *zonParser) skipSpace() {
+	for z.pos < len(z.input) {
+		switch c := z.input[z.pos]; {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			z.pos++
+		case strings.HasPrefix(z.input[z.pos:], "//"):
+			end := strings.IndexByte(z.input[z.pos:], '\n')
+			if end < 0 {
+				z.pos = len(z.input)
+			} else {
+				z.pos += end
+			}
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZigParser_ParseBuildZigZon(t *testing.T) {
+	parser := NewZigParser()
+
+	content := `.{
+    // Package name (enum literal since Zig 0.14)
+    .name = .webapp,
+    .version = "0.3.1",
+    .fingerprint = 0x9f2b6c4d1e3a5b7c,
+    .minimum_zig_version = "0.14.0",
+    .dependencies = .{
+        .zap = .{
+            .url = "https://github.com/zigzap/zap/archive/refs/tags/v0.9.1.tar.gz",
+            .hash = "1220002b24ad4d4bd0cbf1ee0e5f1e8f2e5c3f0b0e7f3ab1c8d5e7f0b2c4d6e8f0a2",
+        },
+        .@"zig-clap" = .{
+            .url = "git+https://github.com/Hejsil/zig-clap#5289e0753cd274d65344bef1c114284c633536ea",
+            .hash = "clap-0.10.0-oBajB434AQBDh-Ei3YtoKIRxZacVPF1iSwp3IX_ZB8f0",
+            .lazy = true,
+        },
+        .shared = .{ .path = "../shared" },
+    },
+    .paths = .{
+        "build.zig",
+        "build.zig.zon",
+        "src",
+    },
+}`
+
+	pkg, err := parser.ParseBuildZigZon(content)
+	require.NoError(t, err)
+	assert.Equal(t, "webapp", pkg.Name)
+	assert.Equal(t, "0.3.1", pkg.Version)
+	assert.Equal(t, "0.14.0", pkg.MinimumZigVersion)
+	require.Len(t, pkg.Dependencies, 3)
+
+	deps := parser.Dependencies(pkg)
+	require.Len(t, deps, 3)
+
+	assert.Equal(t, "shared", deps[0].Name)
+	assert.Equal(t, "latest", deps[0].Version)
+	assert.Equal(t, "../shared", deps[0].Metadata["path"])
+
+	assert.Equal(t, types.Dependency{
+		Type: DependencyTypeZig, Name: "zap", Version: "v0.9.1", Scope: types.ScopeProd, Direct: true,
+		Metadata: map[string]interface{}{
+			"source": "build.zig.zon",
+			"url":    "https://github.com/zigzap/zap/archive/refs/tags/v0.9.1.tar.gz",
+			"hash":   "1220002b24ad4d4bd0cbf1ee0e5f1e8f2e5c3f0b0e7f3ab1c8d5e7f0b2c4d6e8f0a2",
+		},
+	}, deps[1])
+
+	clap := deps[2]
+	assert.Equal(t, "zig-clap", clap.Name, "Should read quoted identifiers")
+	assert.Equal(t, "5289e0753cd274d65344bef1c114284c633536ea", clap.Version)
+	assert.Equal(t, types.ScopeOptional, clap.Scope, "Lazy dependencies are optional")
+}
+
+func TestZigParser_ParseBuildZigZon_Minimal(t *testing.T) {
+	parser := NewZigParser()
+
+	pkg, err := parser.ParseBuildZigZon(".{ .name = \"tool\", .version = \"0.1.0\", .dependencies = .{}, .paths = .{\"\"} }")
+	require.NoError(t, err)
+	assert.Equal(t, "tool", pkg.Name)
+	assert.Empty(t, parser.Dependencies(pkg))
+
+	_, err = parser.ParseBuildZigZon(".{ .name = \"tool\"")
+	assert.Error(t, err)
+}
+
+func TestZigURLRef(t *testing.T) {
+	assert.Equal(t, "v1.2.0", zigURLRef("https://github.com/org/lib/archive/refs/tags/v1.2.0.tar.gz"))
+	assert.Equal(t, "0.4.0", zigURLRef("https://codeberg.org/org/lib/archive/0.4.0.tar.gz"))
+	assert.Equal(t, "main", zigURLRef("git+https://github.com/org/lib#main"))
+	assert.Equal(t, "v2.0.0", zigURLRef("https://github.com/org/lib/releases/download/v2.0.0/lib.tar.xz"))
+	assert.Empty(t, zigURLRef("https://example.com/lib.tar.gz"))
+}
@@ -0,0 +1,37 @@
+package scanner
+
+import (
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// nonProdScopes are the dependency scopes left out of a production-only inventory
+var nonProdScopes = map[types.Scope]bool{types.ScopeDev: true, types.ScopeTest: true, types.ScopeBuild: true}
+
+// SetProdOnly sets whether the scan reports the runtime dependencies only, leaving out the
+// dependencies with the dev, test, or build scope of all ecosystems (devDependencies,
+// requirements-dev.txt, test groups, build plugins)
+func (s *Scanner) SetProdOnly(prodOnly bool) {
+	s.prodOnly = prodOnly
+}
+
+// applyProdOnly removes the dev, test, and build dependencies of the payload tree. Returns the
+// production-only mode for the scan metadata, nil if disabled.
+func (s *Scanner) applyProdOnly(root *types.Payload) *metadata.ProdOnlyInfo {
+	if !s.prodOnly {
+		return nil
+	}
+	excluded := 0
+	walkPayloads(root, func(payload *types.Payload) {
+		kept := payload.Dependencies[:0]
+		for _, dep := range payload.Dependencies {
+			if nonProdScopes[dep.Scope] {
+				excluded++
+				continue
+			}
+			kept = append(kept, dep)
+		}
+		payload.Dependencies = kept
+	})
+	return &metadata.ProdOnlyInfo{ExcludedDependencies: excluded}
+}
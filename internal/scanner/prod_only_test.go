@@ -0,0 +1,48 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanner_ProdOnly(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		"web/package.json":          `{"name": "web", "dependencies": {"express": "4.18.2"}, "devDependencies": {"jest": "29.7.0"}}`,
+		"api/requirements.txt":      "flask==3.0.0\n",
+		"api/requirements-dev.txt":  "black==24.1.0\n",
+		"api/requirements_test.txt": "pytest==8.0.0\n",
+		"core/Cargo.toml":           "[package]\nname = \"core\"\n\n[dependencies]\nserde = \"1\"\n\n[build-dependencies]\ncc = \"1\"\n",
+		"core/src/lib.rs":           "pub fn f() {}\n",
+	})
+
+	scan := func(prodOnly bool) *types.Payload {
+		s, err := NewScannerWithOptionsAndRootID(dir, nil, false, false, false, false, nil, "test-root")
+		require.NoError(t, err)
+		s.SetProdOnly(prodOnly)
+		payload, err := s.Scan()
+		require.NoError(t, err)
+		return payload
+	}
+	names := func(payload *types.Payload) []string {
+		var result []string
+		walkPayloads(payload, func(p *types.Payload) {
+			for _, dep := range p.Dependencies {
+				result = append(result, dep.Name)
+			}
+		})
+		return result
+	}
+
+	all := scan(false)
+	assert.ElementsMatch(t, []string{"express", "jest", "flask", "black", "pytest", "serde", "cc"}, names(all))
+	assert.Nil(t, all.Metadata.(*metadata.ScanMetadata).ProdOnly)
+
+	prod := scan(true)
+	assert.ElementsMatch(t, []string{"express", "flask", "serde"}, names(prod))
+	assert.Equal(t, &metadata.ProdOnlyInfo{ExcludedDependencies: 4}, prod.Metadata.(*metadata.ScanMetadata).ProdOnly)
+}
@@ -1,19 +1,24 @@
 package scanner
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"log/slog"
 
 	"github.com/bmatcuk/doublestar/v4"
 	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/detectcache"
 	"github.com/petrarca/tech-stack-analyzer/internal/git"
 	"github.com/petrarca/tech-stack-analyzer/internal/license"
 	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/profiling"
 	"github.com/petrarca/tech-stack-analyzer/internal/progress"
 	"github.com/petrarca/tech-stack-analyzer/internal/provider"
 	"github.com/petrarca/tech-stack-analyzer/internal/rules"
@@ -22,23 +27,53 @@ import (
 	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
 	"github.com/petrarca/tech-stack-analyzer/internal/spec"
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/petrarca/tech-stack-analyzer/internal/version"
 
 	// Import component detectors to trigger init() registration
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/ansible"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/appframework"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/artifacts"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/blockchain"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/buf"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/cmake"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/cocoapods"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/codequality"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/cplusplus"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/crystal"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/delphi"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/deno"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/devenv"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/dlang"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/docker"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/dotnet"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/embedded"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/erlang"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/fortran"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/gameengine"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/githubactions"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/gitsubmodules"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/golang"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/graphql"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/iac"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/java"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/lua"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/ml"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/nim"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/nodejs"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/ocaml"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/php"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/python"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/ruby"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/rust"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/serverless"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/ssg"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/taskrunner"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/terraform"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/updatetools"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/vendored"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/vlang"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/vmtools"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/zig"
 )
 
 // Scanner handles the recursive directory scanning and technology detection logic
@@ -54,11 +89,30 @@ type Scanner struct {
 	progress        *progress.Progress
 	codeStats       CodeStatsAnalyzer
 	gitignoreStack  *git.StackBasedLoader
-	gitCache        map[string]*git.GitInfo // Cache git info by repo root path
-	gitRootCache    map[string]string       // Cache path -> repo root mapping
-	rootID          string                  // Override root ID for deterministic scans
-	config          *config.ScanConfig      // Merged configuration for metadata properties
-	useLockFiles    bool                    // Use lock files for dependency resolution
+	gitCache        map[string]*git.GitInfo    // Cache git info by repo root path
+	gitRootCache    map[string]string          // Cache path -> repo root mapping
+	rootID          string                     // Override root ID for deterministic scans
+	config          *config.ScanConfig         // Merged configuration for metadata properties
+	useLockFiles    bool                       // Use lock files for dependency resolution
+	detectorCache   *detectcache.Cache         // Results of cacheable detectors (nil = disabled)
+	phases          *profiling.Phases          // Time per scan phase (nil = not measured)
+	pathWarnings    []PathWarning              // Paths that could not be processed
+	nestedRepos     string                     // Handling of nested git repositories (BoundaryComponent if empty)
+	symlinks        string                     // Handling of links to directories (BoundarySkip if empty)
+	targetEnv       *parsers.TargetEnvironment // Environment dependencies are evaluated for (nil = all dependencies)
+	prodOnly        bool                       // Leave out dev, test, and build dependencies
+	imageArchive    string                     // Extracted docker save archive of the scanned root file system
+	imageLayers     *parsers.ImageLayerIndex   // Layers of the image archive (nil = no attribution)
+	realBase        string                     // Scan root with links resolved (set by linkTarget)
+	links           []dirLink                  // Links to directories to follow after the walk
+	walked          map[string]bool            // Scanned directories ("/src"), if links are followed
+	following       *dirLink                   // Link being followed
+}
+
+// PathWarning is a path the scan could not process (fully), with the reason
+type PathWarning struct {
+	Path   string // Relative to the scanned directory ("/src/app")
+	Reason string
 }
 
 // CodeStatsAnalyzer interface for code statistics collection
@@ -109,9 +163,9 @@ func NewScannerWithOptionsAndRootID(path string, excludePatterns []string, verbo
 
 // NewScannerWithOptionsAndLogger creates a new scanner with all options including logger
 func NewScannerWithOptionsAndLogger(path string, excludePatterns []string, verbose bool, useTreeView bool, traceTimings bool, traceRules bool, codeStats CodeStatsAnalyzer, logger *slog.Logger, rootID string, mergedConfig *config.ScanConfig) (*Scanner, error) {
-	// Create provider for the target path
+	// Create provider for the target path, decoding files with a byte order mark (UTF-8 BOM, UTF-16)
 	tInit := time.Now()
-	provider := provider.NewFSProvider(path)
+	provider := provider.NewTextProvider(provider.NewFSProvider(path))
 
 	// Initialize all scanner components
 	components, err := initializeScannerComponents(provider, path, logger)
@@ -136,6 +190,7 @@ func NewScannerWithOptionsAndLogger(path string, excludePatterns []string, verbo
 
 	// Initialize stack-based gitignore loader
 	gitignoreStack := git.NewStackBasedLoaderWithLogger(prog, logger)
+	gitignoreStack.SetFileReader(provider.ReadFile)
 
 	// Load config excludes to pass to gitignore stack
 	var configExcludes []string
@@ -206,6 +261,17 @@ func NewScannerWithSettings(path string, settings *config.Settings, mergedConfig
 		return nil, err
 	}
 	scanner.useLockFiles = settings.UseLockFiles
+	scanner.SetMavenProfiles(settings.MavenProfiles)
+	scanner.SetIncludeTransitive(settings.IncludeTransitive)
+	scanner.SetNestedRepos(settings.NestedRepos)
+	scanner.SetSymlinks(settings.Symlinks)
+	scanner.SetProdOnly(settings.ProdOnly)
+	if err := scanner.SetTargetEnvironment(settings.TargetEnv); err != nil {
+		return nil, err
+	}
+	if err := scanner.SetImageArchive(settings.ImageArchive); err != nil {
+		return nil, err
+	}
 	return scanner, nil
 }
 
@@ -219,6 +285,99 @@ func (s *Scanner) SetUseLockFiles(use bool) {
 	s.useLockFiles = use
 }
 
+// SetMavenProfiles sets the Maven profiles the detectors consider, like "mvn -P"
+// ("!id" deselects a profile, "*" selects all profiles)
+func (s *Scanner) SetMavenProfiles(profiles []string) {
+	components.SetMavenProfiles(profiles)
+}
+
+// SetIncludeTransitive sets whether the lock files listing the full package graph (Cargo.lock,
+// go.sum) add the transitive dependencies
+func (s *Scanner) SetIncludeTransitive(include bool) {
+	components.SetIncludeTransitive(include)
+}
+
+// EnableDetectorCache caches the results of the detectors implementing
+// components.CacheableDetector in dir between scans. Call after SetMavenProfiles and
+// SetIncludeTransitive and before Scan or ScanFile.
+func (s *Scanner) EnableDetectorCache(dir string) error {
+	context, err := detectcache.Context(version.Version, version.Commit, spec.Version, s.rules, components.UseLockFiles(), components.MavenProfiles(), components.IncludeTransitive())
+	if err != nil {
+		return err
+	}
+	s.detectorCache, err = detectcache.New(dir, context)
+	return err
+}
+
+// DetectorCacheStats returns the numbers of detector results read from and stored in the
+// detector cache (zero if disabled)
+func (s *Scanner) DetectorCacheStats() (hits, stored int) {
+	if s.detectorCache == nil {
+		return 0, 0
+	}
+	return s.detectorCache.Stats()
+}
+
+// MeasurePhases records the time the scan spends per phase in phases. Call before Scan.
+func (s *Scanner) MeasurePhases(phases *profiling.Phases) {
+	s.phases = phases
+}
+
+// RecordReads records the digests of the files the scan reads, for reproducibility manifests.
+// Digests are of the files as stored, before text decoding. Call before Scan or ScanFile.
+func (s *Scanner) RecordReads() {
+	if s.recorder() != nil {
+		return
+	}
+	if text, ok := s.provider.(*provider.TextProvider); ok {
+		s.provider = provider.NewTextProvider(provider.NewRecordingProvider(text.Provider))
+	} else {
+		s.provider = provider.NewRecordingProvider(s.provider)
+	}
+	s.dotenvDetector = parsers.NewDotenvDetector(s.provider, s.rules)
+}
+
+// ReadDigests returns the sha256 digests of the files read by the scan by path relative to the
+// scanned directory, or nil if RecordReads was not called
+func (s *Scanner) ReadDigests() map[string]string {
+	if recorder := s.recorder(); recorder != nil {
+		return recorder.Digests()
+	}
+	return nil
+}
+
+// recorder returns the recording provider of the scanner, if any
+func (s *Scanner) recorder() *provider.RecordingProvider {
+	p := s.provider
+	if text, ok := p.(*provider.TextProvider); ok {
+		p = text.Provider
+	}
+	recorder, _ := p.(*provider.RecordingProvider)
+	return recorder
+}
+
+// PathWarnings returns the paths the scan could not process, in scan order
+func (s *Scanner) PathWarnings() []PathWarning {
+	return s.pathWarnings
+}
+
+// errInvalidUTF8Name is the reason of names that are processed but cannot be represented in JSON
+var errInvalidUTF8Name = errors.New("name is not valid UTF-8, shown with U+FFFD in the output")
+
+// warnPath records a path that could not be processed. The reason omits the path repeated by
+// file system errors.
+func (s *Scanner) warnPath(fullPath string, err error) {
+	relPath := "/"
+	if rel, relErr := filepath.Rel(s.provider.GetBasePath(), fullPath); relErr == nil && rel != "." {
+		relPath = "/" + filepath.ToSlash(rel)
+	}
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		err = pathErr.Err
+	}
+	s.pathWarnings = append(s.pathWarnings, PathWarning{Path: relPath, Reason: err.Error()})
+}
+
 // scannerComponents holds all initialized scanner components
 type scannerComponents struct {
 	rules           []types.Rule
@@ -314,6 +473,7 @@ func (s *Scanner) Scan() (*types.Payload, error) {
 	t1 := time.Now()
 	payload.Git = git.GetGitInfo(basePath)
 	slog.Debug("Retrieved git info", "duration", time.Since(t1))
+	s.phases.Add(profiling.PhaseGit, time.Since(t1))
 
 	// Start recursive directory scanning from base path
 	slog.Debug("Starting directory recursion", "path", basePath)
@@ -321,7 +481,9 @@ func (s *Scanner) Scan() (*types.Payload, error) {
 	if err != nil {
 		return nil, err
 	}
+	s.followLinks(true)
 	slog.Debug("Completed directory recursion")
+	endResolve := s.phases.Start(profiling.PhaseResolve)
 
 	// Set scan duration
 	scanMeta.SetDuration(time.Since(startTime))
@@ -351,15 +513,47 @@ func (s *Scanner) Scan() (*types.Payload, error) {
 	// Assign unique IDs to the entire payload tree
 	payload.AssignIDs(s.resolveRootID(basePath))
 
+	// Mark dependencies substituted by included builds of Gradle composite builds
+	s.resolveIncludedBuilds(payload)
+
+	// Map dependency scopes to the scope constants
+	validateScopes(payload)
+
+	// Keep the runtime dependencies installed in the target environment
+	scanMeta.ProdOnly = s.applyProdOnly(payload)
+	scanMeta.TargetEnv = s.applyTargetEnvironment(payload)
+	scanMeta.ImageArchive = s.applyImageLayers(payload)
+
 	// Resolve inter-component references
 	s.resolveComponentRefs(payload)
 
+	endResolve()
+
 	// Report scan complete
 	s.progress.ScanComplete(fileCount, componentCount, time.Since(startTime))
 
 	return payload, nil
 }
 
+// validateScopes maps the dependency scopes of the payload tree to the scope constants.
+// Unknown scopes are logged once and cleared, so the output only carries known scopes.
+func validateScopes(root *types.Payload) {
+	reported := make(map[types.Scope]bool)
+	walkPayloads(root, func(payload *types.Payload) {
+		for i, dep := range payload.Dependencies {
+			if dep.Scope.IsValid() {
+				continue
+			}
+			scope, ok := types.NormalizeScope(string(dep.Scope))
+			if !ok && !reported[dep.Scope] {
+				reported[dep.Scope] = true
+				slog.Warn("Unknown dependency scope", "scope", dep.Scope, "type", dep.Type, "name", dep.Name)
+			}
+			payload.Dependencies[i].Scope = scope
+		}
+	})
+}
+
 // countFilesAndComponents recursively counts files and components in the payload tree
 func (s *Scanner) countFilesAndComponents(payload *types.Payload) (int, int) {
 	fileCount := 0
@@ -475,6 +669,7 @@ func (s *Scanner) ScanFile(fileName string) (*types.Payload, error) {
 	filePath := filepath.Join(basePath, fileName)
 	content, err := s.provider.ReadFile(filePath)
 	if err != nil {
+		s.warnPath(filePath, err)
 		content = []byte{} // Empty content on error
 	}
 	if lang := s.langDetector.DetectLanguage(fileName, content); lang != "" {
@@ -507,6 +702,12 @@ func (s *Scanner) ScanFile(fileName string) (*types.Payload, error) {
 	// Assign unique IDs to the payload tree
 	payload.AssignIDs(s.resolveRootID(basePath))
 
+	// Map dependency scopes to the scope constants
+	validateScopes(payload)
+	scanMeta.ProdOnly = s.applyProdOnly(payload)
+	scanMeta.TargetEnv = s.applyTargetEnvironment(payload)
+	scanMeta.ImageArchive = s.applyImageLayers(payload)
+
 	return payload, nil
 }
 
@@ -533,7 +734,8 @@ func (s *Scanner) processFile(ctx *types.Payload, dirPath string, fileName strin
 	fileFullPath := filepath.Join(dirPath, fileName)
 	content, err := s.provider.ReadFile(fileFullPath)
 	if err != nil {
-		content = []byte{} // Empty content on error
+		s.warnPath(fileFullPath, err)
+		content = []byte{} // Empty content on error, the language is detected from the name
 	}
 
 	// Detect language from file name
@@ -584,23 +786,42 @@ func (s *Scanner) recurse(payload *types.Payload, filePath string) error {
 	t1 := time.Now()
 	files, err := s.provider.ListDir(filePath)
 	if err != nil {
+		s.warnPath(filePath, err)
+		s.phases.Add(profiling.PhaseWalk, time.Since(tEnter))
 		return err
 	}
 	slog.Debug("Listed directory", "path", filePath, "file_count", len(files), "duration", time.Since(t1))
 
+	nestedRepo := s.isNestedRepo(filePath, files)
+	if nestedRepo && s.nestedRepos == BoundarySkip {
+		slog.Debug("Skipping nested repository", "path", filePath)
+		s.phases.Add(profiling.PhaseWalk, time.Since(tEnter))
+		return nil
+	}
+	s.markWalked(filePath)
+
 	// Filter files to exclude those matching ignore patterns
 	// This ensures rule matching doesn't see excluded files
 	t2 := time.Now()
 	filteredFiles := make([]types.File, 0, len(files))
+	linkTargets := make(map[string]string)
 	for _, file := range files {
 		if file.Type == "file" && s.shouldExcludeFileStackBased(file.Name, filePath) {
 			continue
 		}
+		if file.Symlink {
+			target, ok := s.linkTarget(filePath, file)
+			if !ok {
+				continue // Leaving the scan root
+			}
+			linkTargets[file.Name] = target
+		}
 		filteredFiles = append(filteredFiles, file)
 	}
 	if len(files) != len(filteredFiles) {
 		slog.Debug("Filtered files", "path", filePath, "before", len(files), "after", len(filteredFiles), "duration", time.Since(t2))
 	}
+	s.phases.Add(profiling.PhaseWalk, time.Since(tEnter))
 
 	// Start timing for folder file processing
 	s.progress.FolderFileProcessingStart(filePath)
@@ -612,6 +833,12 @@ func (s *Scanner) recurse(payload *types.Payload, filePath string) error {
 	if time.Since(t3) > 100*time.Millisecond {
 		slog.Debug("Applied rules (slow)", "path", filePath, "duration", time.Since(t3))
 	}
+	if nestedRepo {
+		if ctx == payload {
+			ctx = s.nestedRepoComponent(payload, filePath)
+		}
+		ctx.Git = s.getGitInfo(filePath)
+	}
 
 	// Check if this directory is a git repository and set git info
 	// Only set git info if it's in a different repository than the parent context
@@ -629,6 +856,7 @@ func (s *Scanner) recurse(payload *types.Payload, filePath string) error {
 			}
 		}
 	}
+	s.phases.Add(profiling.PhaseGit, time.Since(tGit))
 	if time.Since(tGit) > 100*time.Millisecond {
 		slog.Debug("Git info retrieval slow", "path", filePath, "duration", time.Since(tGit))
 	}
@@ -637,6 +865,7 @@ func (s *Scanner) recurse(payload *types.Payload, filePath string) error {
 	// This adds file-based license detection (MIT, Apache-2.0, etc.) from LICENSE files
 	tLicense := time.Now()
 	s.licenseDetector.AddLicensesToPayload(ctx, filePath)
+	s.phases.Add(profiling.PhaseDetect, time.Since(tLicense))
 	if time.Since(tLicense) > 100*time.Millisecond {
 		slog.Debug("License detection slow", "path", filePath, "duration", time.Since(tLicense))
 	}
@@ -650,14 +879,34 @@ func (s *Scanner) recurse(payload *types.Payload, filePath string) error {
 
 	// Process each file/directory
 	for _, file := range filteredFiles {
+		if !utf8.ValidString(file.Name) && (file.Type == "file" || !s.shouldIgnoreDirectoryStackBased(file.Name, filePath)) {
+			// Processed, but JSON output replaces the invalid bytes with U+FFFD
+			s.warnPath(filepath.Join(filePath, file.Name), errInvalidUTF8Name)
+		}
+		if file.Type == "file" && linkTargets[file.Name] != "" {
+			continue // Languages and code statistics are counted at the target
+		}
 		if file.Type == "file" {
+			endLanguages := s.phases.Start(profiling.PhaseLanguages)
 			s.processFile(ctx, filePath, file.Name)
+			endLanguages()
 			continue
 		}
 
 		// Skip ignored directories
 		// Use stack-based gitignore checking for proper hierarchy
-		if s.shouldIgnoreDirectoryStackBased(file.Name, filePath) {
+		tIgnore := time.Now()
+		ignored := s.shouldIgnoreDirectoryStackBased(file.Name, filePath)
+		s.phases.Add(profiling.PhaseWalk, time.Since(tIgnore))
+		if ignored {
+			continue
+		}
+
+		// Links to directories are followed after the walk
+		if file.Symlink {
+			if s.symlinks == BoundaryComponent {
+				s.links = append(s.links, dirLink{ctx: ctx, path: filepath.Join(filePath, file.Name), target: linkTargets[file.Name]})
+			}
 			continue
 		}
 
@@ -684,6 +933,8 @@ func (s *Scanner) applyRules(payload *types.Payload, files []types.File, current
 	ctx = s.detectComponents(payload, ctx, files, currentPath)
 
 	// 2. Dotenv detection (matches .env.example variables against rule patterns)
+	endDetect := s.phases.Start(profiling.PhaseDetect)
+	defer endDetect()
 	s.detectDotenv(ctx, files, currentPath)
 
 	// 3. File and extension-based detection (includes JSON schema via content matchers)
@@ -701,7 +952,7 @@ func (s *Scanner) detectComponents(payload, ctx *types.Payload, files []types.Fi
 
 	// Collect all components from all detectors
 	for _, detector := range components.GetDetectors() {
-		detectedComponents := detector.Detect(files, currentPath, s.provider.GetBasePath(), s.provider, s.depDetector)
+		detectedComponents := s.detect(detector, files, currentPath)
 		for _, component := range detectedComponents {
 			// Note: Components should NOT get git info by default
 			// Git info is only added at directory level when component is in a different repository
@@ -734,6 +985,32 @@ func (s *Scanner) detectComponents(payload, ctx *types.Payload, files []types.Fi
 	return ctx
 }
 
+// detect runs a detector on the current directory, using the detector cache for cacheable
+// detectors. Empty results are not cached: detectors find no manifests cheaply.
+func (s *Scanner) detect(detector components.Detector, files []types.File, currentPath string) []*types.Payload {
+	if s.phases != nil {
+		defer s.phases.Start(profiling.PhaseParse + "/" + detector.Name())()
+	}
+	basePath := s.provider.GetBasePath()
+	cacheable, ok := detector.(components.CacheableDetector)
+	if s.detectorCache == nil || !ok {
+		return detector.Detect(files, currentPath, basePath, s.provider, s.depDetector)
+	}
+
+	key := s.detectorCache.Key(detector.Name(), cacheable.Version(), currentPath, files)
+	if cached, ok := s.detectorCache.Get(key, s.provider); ok {
+		return cached
+	}
+	recorder := detectcache.NewRecorder(s.provider)
+	detected := detector.Detect(files, currentPath, basePath, recorder, s.depDetector)
+	if len(detected) > 0 {
+		if err := s.detectorCache.Put(key, recorder, detected); err != nil {
+			slog.Debug("Failed to cache detector results", "detector", detector.Name(), "path", currentPath, "error", err)
+		}
+	}
+	return detected
+}
+
 func (s *Scanner) mergeVirtualPayload(target, virtual *types.Payload, currentPath string) {
 	for _, child := range virtual.Children {
 		target.AddChild(child)
@@ -984,6 +1261,7 @@ func (s *Scanner) shouldExcludeFileStackBased(fileName, currentPath string) bool
 	if err != nil {
 		relPath = fileName // Fallback to just filename
 	}
+	relPath = filepath.ToSlash(relPath) // Patterns use forward slashes on all platforms
 
 	// Check against CLI exclude patterns first (these apply globally)
 	for _, pattern := range s.excludePatterns {
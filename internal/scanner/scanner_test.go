@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 
+	"github.com/petrarca/tech-stack-analyzer/internal/profiling"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -151,6 +155,73 @@ func TestScanner_ScanFile(t *testing.T) {
 	t.Logf("File scan result - Techs: %v", result.Techs)
 }
 
+func TestScanner_RecordReads(t *testing.T) {
+	tempDir := t.TempDir()
+	packageJson := `{"name": "test-app", "dependencies": {"express": "^4.18.0"}}`
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "package.json"), []byte(packageJson), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "src"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "src", "index.js"), []byte("console.log(1)\n"), 0644))
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	assert.Nil(t, scanner.ReadDigests(), "reads are not recorded by default")
+
+	scanner.RecordReads()
+	_, err = scanner.Scan()
+	require.NoError(t, err)
+
+	digests := scanner.ReadDigests()
+	assert.Equal(t, "3879a5d930ae1999b278a3a498f7de3fd83ba8dae59330fcfa2db31c103ac21d", digests["src/index.js"])
+	assert.Contains(t, digests, "package.json")
+}
+
+func TestScanner_DetectorCache(t *testing.T) {
+	tempDir := t.TempDir()
+	cacheDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "go.mod"), []byte("module example.com/app\n\ngo 1.21\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "main.go"), []byte("package main\n"), 0644))
+
+	scan := func() (*types.Payload, int) {
+		s, err := NewScanner(tempDir)
+		require.NoError(t, err)
+		require.NoError(t, s.EnableDetectorCache(cacheDir))
+		payload, err := s.Scan()
+		require.NoError(t, err)
+		hits, _ := s.DetectorCacheStats()
+		return payload, hits
+	}
+
+	first, hits := scan()
+	assert.Equal(t, 0, hits)
+	cached, hits := scan()
+	assert.Equal(t, 1, hits, "golang results of the root directory")
+	assert.Equal(t, first.Children[0].Properties, cached.Children[0].Properties)
+
+	// A cgo source file below the module invalidates the results of the golang detector
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "native"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "native", "lib.go"), []byte("package native\n\nimport \"C\"\n"), 0644))
+	changed, hits := scan()
+	assert.Equal(t, 0, hits)
+	assert.Equal(t, true, changed.Children[0].Properties["golang"].(map[string]interface{})["cgo"])
+}
+
+func TestScanner_MeasurePhases(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "package.json"), []byte(`{"name": "app"}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "index.js"), []byte("console.log(1)\n"), 0644))
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	phases := profiling.NewPhases()
+	scanner.MeasurePhases(phases)
+	_, err = scanner.Scan()
+	require.NoError(t, err)
+
+	for _, phase := range []string{profiling.PhaseWalk, profiling.PhaseParse + "/nodejs", profiling.PhaseDetect, profiling.PhaseLanguages, profiling.PhaseResolve} {
+		assert.NotZero(t, phases.Duration(phase), phase)
+	}
+}
+
 func TestScanner_ScanFile_NonExistentFile(t *testing.T) {
 	// Create temporary directory
 	tempDir, err := os.MkdirTemp("", "scanner-test-file")
@@ -474,3 +545,63 @@ func TestScanner_GetGitInfo_NonGitDirectory(t *testing.T) {
 	assert.True(t, exists, "Path should be cached")
 	assert.Empty(t, cachedRoot, "Cached root should be empty for non-git dir")
 }
+
+func TestValidateScopes(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	child := types.NewPayloadWithPath("app", "/app")
+	child.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "react", Scope: types.ScopePeer},
+		{Type: "maven", Name: "junit:junit", Scope: "Test"},
+		{Type: "maven", Name: "org.slf4j:slf4j-api", Scope: "runtime"},
+		{Type: "npm", Name: "left-pad", Scope: "bogus"},
+		{Type: "golang", Name: "github.com/pkg/errors"},
+	}
+	root.Children = []*types.Payload{child}
+
+	validateScopes(root)
+
+	scopes := make([]types.Scope, 0, len(child.Dependencies))
+	for _, dep := range child.Dependencies {
+		scopes = append(scopes, dep.Scope)
+	}
+	assert.Equal(t, []types.Scope{types.ScopePeer, types.ScopeTest, types.ScopeProd, "", ""}, scopes)
+}
+
+func TestScanner_PathTolerance(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("needs file names that are not valid UTF-8 and paths beyond PATH_MAX")
+	}
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		"bad\xffname/package.json":             `{"name": "web", "dependencies": {"react": "18.2.0"}}`,
+		"we ird [x] *?{a,b}'\"$(echo)/Gemfile": "source \"https://rubygems.org\"\ngem \"rails\"\n",
+	})
+	require.NoError(t, os.Symlink(filepath.Join(dir, "missing"), filepath.Join(dir, "dangling.rb")))
+
+	// Paths beyond PATH_MAX (4096 bytes) can only be created relative to a directory
+	root, err := os.OpenRoot(dir)
+	require.NoError(t, err)
+	defer root.Close()
+	deep := strings.Repeat(strings.Repeat("d", 250)+"/", 20)
+	require.NoError(t, root.MkdirAll(deep, 0755))
+	require.NoError(t, root.WriteFile(deep+"Cargo.toml", []byte("[package]\nname = \"deep\"\nversion = \"0.1.0\"\n\n[dependencies]\nserde = \"1.0\"\n"), 0644))
+	require.NoError(t, root.WriteFile(deep+".gitignore", []byte("generated.py\n"), 0644))
+	require.NoError(t, root.WriteFile(deep+"generated.py", []byte("import os\n"), 0644))
+
+	s, err := NewScannerWithOptionsAndRootID(dir, nil, false, false, false, false, nil, "test-root")
+	require.NoError(t, err)
+	payload, err := s.Scan()
+	require.NoError(t, err)
+
+	paths := make(map[string]string)
+	walkPayloads(payload, func(p *types.Payload) { paths[p.Path[0]] = p.Name })
+	assert.Equal(t, "web", paths["/bad\xffname/package.json"], "name that is not valid UTF-8")
+	assert.Equal(t, "deep", paths["/"+deep+"Cargo.toml"], "path beyond PATH_MAX")
+	assert.Contains(t, paths, "/we ird [x] *?{a,b}'\"$(echo)/Gemfile", "special characters")
+	assert.NotContains(t, payload.Languages, "Python", ".gitignore beyond PATH_MAX")
+
+	assert.Equal(t, []PathWarning{
+		{Path: "/bad\xffname", Reason: errInvalidUTF8Name.Error()},
+		{Path: "/dangling.rb", Reason: "no such file or directory"},
+	}, s.PathWarnings())
+}
@@ -0,0 +1,41 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCargoVersionParsing(t *testing.T) {
+	tests := []struct {
+		version    string
+		canon      string
+		prerelease bool
+	}{
+		{version: "1.2.3", canon: "1.2.3"},
+		{version: "0.4.38", canon: "0.4.38"},
+		{version: "1.0.0-beta.1", canon: "1.0.0-beta.1", prerelease: true},
+		{version: "2.0.0+build.5", canon: "2.0.0+build.5"},
+	}
+	for _, tt := range tests {
+		v, err := Cargo.Parse(tt.version)
+		require.NoError(t, err, tt.version)
+		assert.Equal(t, tt.canon, v.Canon(true), tt.version)
+		assert.Equal(t, tt.prerelease, v.IsPrerelease(), tt.version)
+	}
+
+	for _, invalid := range []string{"", "1", "1.0", "v1.2.3", "=1.2.3", "^1.2.3", "1.2.x", "1.2.3.4"} {
+		_, err := Cargo.Parse(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestCargoVersionCompare(t *testing.T) {
+	older, err := Cargo.Parse("1.0.0-rc.1")
+	require.NoError(t, err)
+	newer, err := Cargo.Parse("1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, -1, older.Compare(newer))
+	assert.Equal(t, 1, newer.Compare(older))
+}
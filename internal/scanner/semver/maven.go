@@ -71,6 +71,18 @@ func (v *MavenVersion) String() string {
 	return v.original
 }
 
+// IsPrerelease reports whether the version has a pre-release qualifier (SNAPSHOT, alpha, beta,
+// milestone, RC) or is a timestamped snapshot build (1.0.0-20131201.121010-1)
+func (v *MavenVersion) IsPrerelease() bool {
+	if v.isRange {
+		return false
+	}
+	if matches := mavenBuildRegex.FindStringSubmatch(v.original); len(matches) >= 3 && matches[2] != "" {
+		return true
+	}
+	return mavenPrereleaseRegex.MatchString(v.original)
+}
+
 // Compare compares this version with another version
 // For Maven, this is a simplified comparison focusing on the canonical form
 func (v *MavenVersion) Compare(other Version) int {
@@ -94,6 +106,9 @@ var (
 
 	// Maven version with build number: 1.0.0-20131201.121010-1
 	mavenBuildRegex = regexp.MustCompile(`^(\d+(?:\.\d+)*)(?:[-.]?(\d{8}\.\d{6})-(\d+))?$`)
+
+	// Maven pre-release qualifiers: 1.0.0-SNAPSHOT, 2.0.0-M1, 3.0.0.RC2, 1.0-beta-1, 1.0.0-alpha1
+	mavenPrereleaseRegex = regexp.MustCompile(`(?i)[-.](snapshot|alpha|beta|a|b|rc|cr|m|milestone|pre|preview|ea|dev)[-.]?\d*(?:[-.]|$)`)
 )
 
 // isMavenVersionRange checks if a version string is a Maven version range
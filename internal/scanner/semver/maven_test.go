@@ -287,3 +287,25 @@ func TestMavenVersionEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestMavenVersion_IsPrerelease(t *testing.T) {
+	tests := map[string]bool{
+		"1.0.0":                   false,
+		"5.3.30.RELEASE":          false,
+		"2.7.1.Final":             false,
+		"1.0.0-SP1":               false,
+		"[1.0,2.0)":               false,
+		"1.0.0-SNAPSHOT":          true,
+		"1.0.0-20131201.121010-1": true,
+		"3.0.0-M1":                true,
+		"6.0.0-RC2":               true,
+		"1.0-beta-1":              true,
+		"2.0.0-alpha1":            true,
+		"7.0.0.CR1":               true,
+	}
+	for version, want := range tests {
+		v, err := Maven.Parse(version)
+		require.NoError(t, err)
+		assert.Equal(t, want, v.IsPrerelease(), version)
+	}
+}
@@ -140,6 +140,11 @@ func (v *NPMVersion) String() string {
 	return v.original
 }
 
+// IsPrerelease reports whether the version has pre-release identifiers (e.g., "-alpha.1")
+func (v *NPMVersion) IsPrerelease() bool {
+	return len(v.prerelease) > 0
+}
+
 // Compare compares this version with another version
 // Following semver 2.0.0 precedence rules
 func (v *NPMVersion) Compare(other Version) int {
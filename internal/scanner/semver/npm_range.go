@@ -0,0 +1,195 @@
+package semver
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// npmOperatorSpaceRegex matches the whitespace between a range operator and its version
+// ("> = 1.2" and ">= 1.2" are ">=1.2")
+var npmOperatorSpaceRegex = regexp.MustCompile(`(~>|>=|<=|[~^<>=])\s+`)
+
+// npmComparator is a primitive comparison of a range: an operator (<, <=, >, >=, =) and a
+// full version
+type npmComparator struct {
+	op      string
+	version *NPMVersion
+}
+
+// SatisfiesNPMRange reports whether a version satisfies an npm range (node-semver: ||
+// alternatives, hyphen ranges, ^ and ~ ranges, x-ranges and partial versions). The second
+// result is false if the version or the range is not a semver range (dist-tags, URLs,
+// aliases, workspace: and file: specifiers). Pre-release versions only satisfy a range with a
+// pre-release of the same major.minor.patch, as with node-semver.
+func SatisfiesNPMRange(version, constraint string) (bool, bool) {
+	v, err := parseNPMVersion(strings.TrimSpace(version))
+	if err != nil {
+		return false, false
+	}
+	satisfied := false
+	for _, alternative := range strings.Split(constraint, "||") {
+		comparators, ok := parseNPMComparatorSet(alternative)
+		if !ok {
+			return false, false
+		}
+		satisfied = satisfied || npmSetSatisfied(v, comparators)
+	}
+	return satisfied, true
+}
+
+// parseNPMComparatorSet returns the primitive comparators of a range without alternatives;
+// none matches any version
+func parseNPMComparatorSet(constraint string) ([]npmComparator, bool) {
+	constraint = strings.TrimSpace(constraint)
+	if from, to, ok := strings.Cut(constraint, " - "); ok {
+		lower, ok := npmComparators(">=", strings.TrimSpace(from))
+		if !ok {
+			return nil, false
+		}
+		upper, ok := npmComparators("<=", strings.TrimSpace(to))
+		if !ok {
+			return nil, false
+		}
+		return append(lower, upper...), true
+	}
+
+	var comparators []npmComparator
+	for _, token := range strings.Fields(npmOperatorSpaceRegex.ReplaceAllString(constraint, "$1")) {
+		op := ""
+		for _, prefix := range []string{"~>", ">=", "<=", "^", "~", ">", "<", "="} {
+			if strings.HasPrefix(token, prefix) {
+				op = prefix
+				break
+			}
+		}
+		primitives, ok := npmComparators(op, strings.TrimPrefix(token, op))
+		if !ok {
+			return nil, false
+		}
+		comparators = append(comparators, primitives...)
+	}
+	return comparators, true
+}
+
+// npmComparators desugars a comparator with a possibly partial version (1, 1.2, 1.x, *) into
+// primitive comparators
+func npmComparators(op, version string) ([]npmComparator, bool) {
+	version = strings.TrimPrefix(strings.TrimPrefix(version, "v"), "=")
+	core, suffix := version, ""
+	if idx := strings.IndexAny(version, "-+"); idx >= 0 {
+		core, suffix = version[:idx], version[idx:]
+	}
+
+	var parts []int
+	for _, part := range strings.Split(core, ".") {
+		if part == "x" || part == "X" || part == "*" || part == "" {
+			break
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false
+		}
+		parts = append(parts, n)
+	}
+	if len(parts) > 3 || (len(parts) < 3 && suffix != "") {
+		return nil, false
+	}
+	if len(parts) == 0 {
+		if op == "<" || op == ">" {
+			return []npmComparator{{op: "<", version: npmVersionOf(0, 0, 0, "")}, {op: ">", version: npmVersionOf(0, 0, 0, "")}}, true // Matches nothing
+		}
+		return nil, true
+	}
+
+	specified := len(parts)
+	for len(parts) < 3 {
+		parts = append(parts, 0)
+	}
+	lower := npmVersionOf(parts[0], parts[1], parts[2], suffix)
+	switch op {
+	case "", "=":
+		if specified == 3 {
+			return []npmComparator{{op: "=", version: lower}}, true
+		}
+		return npmRange(lower, npmBump(parts, specified)), true
+	case "^":
+		switch {
+		case parts[0] > 0 || specified == 1:
+			return npmRange(lower, npmVersionOf(parts[0]+1, 0, 0, "")), true
+		case parts[1] > 0 || specified == 2:
+			return npmRange(lower, npmVersionOf(0, parts[1]+1, 0, "")), true
+		default:
+			return npmRange(lower, npmVersionOf(0, 0, parts[2]+1, "")), true
+		}
+	case "~", "~>":
+		if specified == 1 {
+			return npmRange(lower, npmVersionOf(parts[0]+1, 0, 0, "")), true
+		}
+		return npmRange(lower, npmVersionOf(parts[0], parts[1]+1, 0, "")), true
+	case ">":
+		if specified == 3 {
+			return []npmComparator{{op: ">", version: lower}}, true
+		}
+		return []npmComparator{{op: ">=", version: npmBump(parts, specified)}}, true
+	case "<=":
+		if specified == 3 {
+			return []npmComparator{{op: "<=", version: lower}}, true
+		}
+		return []npmComparator{{op: "<", version: npmBump(parts, specified)}}, true
+	default: // >=, <
+		return []npmComparator{{op: op, version: lower}}, true
+	}
+}
+
+// npmBump returns the first version after a partial version of the given number of parts
+// (1.2: 1.3.0, 1: 2.0.0)
+func npmBump(parts []int, specified int) *NPMVersion {
+	if specified == 2 {
+		return npmVersionOf(parts[0], parts[1]+1, 0, "")
+	}
+	return npmVersionOf(parts[0]+1, 0, 0, "")
+}
+
+// npmRange returns the comparators of the versions from lower (inclusive) to upper (exclusive)
+func npmRange(lower, upper *NPMVersion) []npmComparator {
+	return []npmComparator{{op: ">=", version: lower}, {op: "<", version: upper}}
+}
+
+// npmVersionOf returns a version from its parts and pre-release or build suffix
+func npmVersionOf(major, minor, patch int, suffix string) *NPMVersion {
+	version, _ := parseNPMVersion(strconv.Itoa(major) + "." + strconv.Itoa(minor) + "." + strconv.Itoa(patch) + suffix)
+	return version
+}
+
+// npmSetSatisfied reports whether a version satisfies all comparators of a set
+func npmSetSatisfied(v *NPMVersion, comparators []npmComparator) bool {
+	for _, c := range comparators {
+		cmp := v.Compare(c.version)
+		var ok bool
+		switch c.op {
+		case "=":
+			ok = cmp == 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	if !v.IsPrerelease() {
+		return true
+	}
+	for _, c := range comparators {
+		if c.version.IsPrerelease() && v.compareCoreVersion(c.version) == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestSatisfiesNPMRange(t *testing.T) {
+	tests := []struct {
+		version    string
+		constraint string
+		want       bool
+		ok         bool
+	}{
+		// Exact and primitive comparators
+		{"1.2.3", "1.2.3", true, true},
+		{"1.2.4", "=1.2.3", false, true},
+		{"18.19.0", ">=18", true, true},
+		{"16.20.2", ">=18.0.0", false, true},
+		{"18.19.0", ">=16 <19", true, true},
+		{"20.11.1", ">= 16 < 19", false, true},
+		{"1.3.0", ">1.2", true, true},
+		{"1.2.9", ">1.2", false, true},
+		{"1.2.9", "<=1.2", true, true},
+
+		// Caret and tilde ranges
+		{"18.2.0", "^18.0.0", true, true},
+		{"17.0.2", "^16.8.0 || ^17.0.0 || ^18.0.0", true, true},
+		{"19.0.0", "^16.8.0 || ^17.0.0 || ^18.0.0", false, true},
+		{"0.2.9", "^0.2.3", true, true},
+		{"0.3.0", "^0.2.3", false, true},
+		{"0.0.4", "^0.0.3", false, true},
+		{"1.2.9", "~1.2.3", true, true},
+		{"1.3.0", "~1.2.3", false, true},
+		{"1.9.0", "~1", true, true},
+
+		// X-ranges, partial versions, and hyphen ranges
+		{"4.17.21", "4.x", true, true},
+		{"5.0.0", "4", false, true},
+		{"3.0.0", "*", true, true},
+		{"3.0.0", "", true, true},
+		{"2.3.9", "1.2 - 2.3", true, true},
+		{"2.4.0", "1.2 - 2.3", false, true},
+
+		// Pre-releases
+		{"2.0.0-beta.1", "^1.0.0 || ^2.0.0", false, true},
+		{"2.0.0-beta.2", ">=2.0.0-beta.1", true, true},
+
+		// Not semver ranges
+		{"1.0.0", "latest", false, false},
+		{"1.0.0", "workspace:*", false, false},
+		{"1.0.0", "npm:other@^1.0.0", false, false},
+		{"lts/*", ">=18", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version+" "+tt.constraint, func(t *testing.T) {
+			got, ok := SatisfiesNPMRange(tt.version, tt.constraint)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("SatisfiesNPMRange(%q, %q) = %v, %v, want %v, %v", tt.version, tt.constraint, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -188,3 +188,23 @@ func TestNPMNormalize(t *testing.T) {
 		})
 	}
 }
+
+func TestNPMVersion_IsPrerelease(t *testing.T) {
+	tests := map[string]bool{
+		"1.0.0":             false,
+		"v2.1.0":            false,
+		"1.0.0+build.1":     false,
+		"1.0.0-alpha":       true,
+		"1.0.0-rc.1":        true,
+		"2.0.0-next.3+sha1": true,
+	}
+	for version, want := range tests {
+		v, err := NPM.Parse(version)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", version, err)
+		}
+		if got := v.IsPrerelease(); got != want {
+			t.Errorf("IsPrerelease(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
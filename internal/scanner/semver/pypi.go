@@ -319,6 +319,12 @@ func (v *PyPIVersion) String() string {
 	return v.original
 }
 
+// IsPrerelease reports whether the version is a pre-release or development release
+// (e.g., "1.0a1", "2.0rc1", "1.0.dev3")
+func (v *PyPIVersion) IsPrerelease() bool {
+	return v.pre != nil || v.dev != nil
+}
+
 // Compare compares this version with another version
 func (v *PyPIVersion) Compare(other Version) int {
 	o, ok := other.(*PyPIVersion)
@@ -174,3 +174,25 @@ func TestNormalize(t *testing.T) {
 		})
 	}
 }
+
+func TestPyPIVersion_IsPrerelease(t *testing.T) {
+	tests := map[string]bool{
+		"1.0":          false,
+		"1.0.post1":    false,
+		"1.0+local.1":  false,
+		"1.0a1":        true,
+		"2.0.0b2":      true,
+		"2.0rc1":       true,
+		"1.0.dev3":     true,
+		"1.0.0a1.dev1": true,
+	}
+	for version, want := range tests {
+		v, err := PyPI.Parse(version)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", version, err)
+		}
+		if got := v.IsPrerelease(); got != want {
+			t.Errorf("IsPrerelease(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
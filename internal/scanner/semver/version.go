@@ -18,6 +18,7 @@ package semver
 
 import (
 	"fmt"
+	"strings"
 )
 
 // System represents a versioning system (PyPI, npm, cargo, etc.)
@@ -40,6 +41,9 @@ type Version interface {
 
 	// String returns the original version string
 	String() string
+
+	// IsPrerelease reports whether the version is a pre-release (alpha, beta, rc, dev, snapshot)
+	IsPrerelease() bool
 }
 
 // Common versioning systems
@@ -80,7 +84,9 @@ func Normalize(system System, version string) string {
 	return v.Canon(true)
 }
 
-// cargoSystem is a placeholder for cargo semver support (to be implemented)
+// cargoSystem implements Cargo version parsing: semver 2.0 versions with exactly three
+// numeric components and no prefix. Partial versions ("1.0") are version requirements in
+// Cargo, not versions.
 type cargoSystem struct{}
 
 func (s *cargoSystem) Name() string {
@@ -88,8 +94,24 @@ func (s *cargoSystem) Name() string {
 }
 
 func (s *cargoSystem) Parse(version string) (Version, error) {
-	// TODO: Implement cargo semver parsing
-	return nil, parseError("cargo", version, "not yet implemented")
+	core := version
+	if idx := strings.IndexAny(core, "-+"); idx >= 0 {
+		core = core[:idx]
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return nil, parseError("cargo", version, "expected major.minor.patch")
+	}
+	for _, part := range parts {
+		if part == "" || strings.TrimLeft(part, "0123456789") != "" {
+			return nil, parseError("cargo", version, fmt.Sprintf("invalid version component: %s", part))
+		}
+	}
+	v, err := parseNPMVersion(version)
+	if err != nil {
+		return nil, parseError("cargo", version, err.Error())
+	}
+	return v, nil
 }
 
 // isDigit returns true if the byte is a digit
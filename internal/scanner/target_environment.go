@@ -0,0 +1,45 @@
+package scanner
+
+import (
+	"log/slog"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// SetTargetEnvironment sets the environment the dependencies are evaluated for
+// (parsers.ParseTargetEnvironment: "os=linux,arch=amd64,python=3.11,node_env=production"). The
+// scan then reports the dependencies installed in that environment only. An empty specification
+// reports all dependencies.
+func (s *Scanner) SetTargetEnvironment(spec string) error {
+	env, err := parsers.ParseTargetEnvironment(spec)
+	if err != nil {
+		return err
+	}
+	s.targetEnv = env
+	return nil
+}
+
+// applyTargetEnvironment removes the dependencies of the payload tree that are not installed in
+// the target environment (activation metadata, npm dev dependencies for NODE_ENV=production).
+// Returns the environment for the scan metadata, nil without target environment.
+func (s *Scanner) applyTargetEnvironment(root *types.Payload) *metadata.TargetEnvInfo {
+	if s.targetEnv == nil {
+		return nil
+	}
+	excluded := 0
+	walkPayloads(root, func(payload *types.Payload) {
+		kept := payload.Dependencies[:0]
+		for _, dep := range payload.Dependencies {
+			if s.targetEnv.Active(dep) {
+				kept = append(kept, dep)
+				continue
+			}
+			excluded++
+			slog.Debug("Dependency not installed in target environment", "type", dep.Type, "name", dep.Name, "component", payload.Name)
+		}
+		payload.Dependencies = kept
+	})
+	return &metadata.TargetEnvInfo{Spec: s.targetEnv.String(), ExcludedDependencies: excluded}
+}
@@ -0,0 +1,58 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanner_TargetEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		"package.json": `{"name": "web", "dependencies": {"express": "4.18.2"}, "devDependencies": {"jest": "29.7.0"}, "optionalDependencies": {"fsevents": "2.3.3"}}`,
+		"package-lock.json": `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "web"},
+    "node_modules/express": {"version": "4.18.2"},
+    "node_modules/jest": {"version": "29.7.0", "dev": true},
+    "node_modules/fsevents": {"version": "2.3.3", "optional": true, "os": ["darwin"]}
+  }
+}`,
+	})
+
+	scan := func(spec string) *types.Payload {
+		s, err := NewScannerWithOptionsAndRootID(dir, nil, false, false, false, false, nil, "test-root")
+		require.NoError(t, err)
+		require.NoError(t, s.SetTargetEnvironment(spec))
+		payload, err := s.Scan()
+		require.NoError(t, err)
+		return payload
+	}
+	names := func(payload *types.Payload) []string {
+		var result []string
+		walkPayloads(payload, func(p *types.Payload) {
+			for _, dep := range p.Dependencies {
+				result = append(result, dep.Name)
+			}
+		})
+		return result
+	}
+
+	all := scan("")
+	assert.ElementsMatch(t, []string{"express", "jest", "fsevents"}, names(all))
+	assert.Nil(t, all.Metadata.(*metadata.ScanMetadata).TargetEnv)
+
+	linux := scan("os=linux,arch=amd64,node_env=production")
+	assert.ElementsMatch(t, []string{"express"}, names(linux))
+	assert.Equal(t, &metadata.TargetEnvInfo{Spec: "os=linux,arch=amd64,node_env=production", ExcludedDependencies: 2}, linux.Metadata.(*metadata.ScanMetadata).TargetEnv)
+
+	assert.ElementsMatch(t, []string{"express", "jest", "fsevents"}, names(scan("os=darwin")))
+
+	s, err := NewScannerWithOptionsAndRootID(dir, nil, false, false, false, false, nil, "test-root")
+	require.NoError(t, err)
+	assert.Error(t, s.SetTargetEnvironment("os"))
+}
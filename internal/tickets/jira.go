@@ -0,0 +1,149 @@
+package tickets
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout is the per-request timeout for issue tracker requests
+const DefaultTimeout = 10 * time.Second
+
+// DefaultIssueType is the Jira issue type of new tickets
+const DefaultIssueType = "Task"
+
+// JiraConfig holds the Jira site, project, and credentials
+type JiraConfig struct {
+	URL       string // Site URL (https://acme.atlassian.net or a Data Center base URL)
+	Project   string // Project key
+	IssueType string // Issue type of new tickets (default: Task)
+	User      string // Account email for Jira Cloud API tokens; empty uses the token as a bearer token (Data Center)
+	Token     string // API token or personal access token
+}
+
+// JiraClient implements Tracker with the Jira REST API v2
+type JiraClient struct {
+	config     JiraConfig
+	httpClient *http.Client
+}
+
+// NewJiraClient creates a Jira client
+func NewJiraClient(config JiraConfig, timeout time.Duration) *JiraClient {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	if config.IssueType == "" {
+		config.IssueType = DefaultIssueType
+	}
+	config.URL = strings.TrimSuffix(config.URL, "/")
+	return &JiraClient{config: config, httpClient: &http.Client{Timeout: timeout}}
+}
+
+// jiraSearchResponse is the response of the issue search endpoints
+type jiraSearchResponse struct {
+	Issues []struct {
+		Key    string `json:"key"`
+		Fields struct {
+			Summary string `json:"summary"`
+		} `json:"fields"`
+	} `json:"issues"`
+}
+
+// FindOpen searches the project for an unresolved ticket labeled with the fingerprint. Jira
+// Cloud serves /search/jql, Data Center /search; the latter is used when the former is missing.
+func (c *JiraClient) FindOpen(fingerprint string) (*Ticket, error) {
+	jql := fmt.Sprintf(`project = "%s" AND labels = "%s%s" AND statusCategory != Done`, strings.ReplaceAll(c.config.Project, `"`, `\"`), FingerprintLabel, fingerprint)
+	query := url.Values{"jql": {jql}, "maxResults": {"1"}, "fields": {"summary"}}.Encode()
+
+	var response jiraSearchResponse
+	status, err := c.do(http.MethodGet, "/rest/api/2/search/jql?"+query, nil, &response)
+	if status == http.StatusNotFound {
+		_, err = c.do(http.MethodGet, "/rest/api/2/search?"+query, nil, &response)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Issues) == 0 {
+		return nil, nil
+	}
+	issue := response.Issues[0]
+	return &Ticket{Key: issue.Key, Summary: issue.Fields.Summary}, nil
+}
+
+// Create creates a ticket in the project and returns its key
+func (c *JiraClient) Create(ticket Ticket) (string, error) {
+	request := map[string]interface{}{
+		"fields": map[string]interface{}{
+			"project":     map[string]string{"key": c.config.Project},
+			"issuetype":   map[string]string{"name": c.config.IssueType},
+			"summary":     ticket.Summary,
+			"description": ticket.Description,
+			"labels":      ticket.Labels,
+		},
+	}
+	var response struct {
+		Key string `json:"key"`
+	}
+	if _, err := c.do(http.MethodPost, "/rest/api/2/issue", request, &response); err != nil {
+		return "", err
+	}
+	return response.Key, nil
+}
+
+// UpdateSummary replaces the summary of a ticket
+func (c *JiraClient) UpdateSummary(key, summary string) error {
+	request := map[string]interface{}{"fields": map[string]string{"summary": summary}}
+	_, err := c.do(http.MethodPut, "/rest/api/2/issue/"+url.PathEscape(key), request, nil)
+	return err
+}
+
+// do sends a request to the Jira API and decodes the JSON response into target (if not nil).
+// It returns the status code; errors do not include credentials.
+func (c *JiraClient) do(method, path string, body, target interface{}) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.config.URL+path, reader)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.config.User != "" {
+		req.SetBasicAuth(c.config.User, c.config.Token)
+	} else if c.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return 0, fmt.Errorf("jira request failed: %s %s: %w", method, strings.SplitN(path, "?", 2)[0], err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("jira request failed: %s %s returned %d", method, strings.SplitN(path, "?", 2)[0], resp.StatusCode)
+	}
+	if target == nil || resp.StatusCode == http.StatusNoContent {
+		return resp.StatusCode, nil
+	}
+	return resp.StatusCode, json.NewDecoder(resp.Body).Decode(target)
+}
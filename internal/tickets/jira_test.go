@@ -0,0 +1,90 @@
+package tickets
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJiraClient(t *testing.T) {
+	var created map[string]interface{}
+	var updated map[string]interface{}
+	var searches []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, token, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "bot@acme.com", user)
+		assert.Equal(t, "secret", token)
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/search/jql":
+			http.NotFound(w, r) // Data Center: only /search is available
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/search":
+			searches = append(searches, r.URL.Query().Get("jql"))
+			_, _ = w.Write([]byte(`{"issues":[{"key":"SEC-7","fields":{"summary":"[web] old"}}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue":
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, &created))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"10001","key":"SEC-8"}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/rest/api/2/issue/SEC-7":
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, &updated))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(JiraConfig{URL: server.URL + "/", Project: "SEC", User: "bot@acme.com", Token: "secret"}, 0)
+
+	ticket, err := client.FindOpen("0123456789abcdef")
+	require.NoError(t, err)
+	require.NotNil(t, ticket)
+	assert.Equal(t, "SEC-7", ticket.Key)
+	assert.Equal(t, "[web] old", ticket.Summary)
+	assert.Equal(t, []string{`project = "SEC" AND labels = "stack-analyzer-0123456789abcdef" AND statusCategory != Done`}, searches)
+
+	key, err := client.Create(Ticket{Summary: "[web] new", Description: "details", Labels: []string{Label}})
+	require.NoError(t, err)
+	assert.Equal(t, "SEC-8", key)
+	fields := created["fields"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"key": "SEC"}, fields["project"])
+	assert.Equal(t, map[string]interface{}{"name": DefaultIssueType}, fields["issuetype"])
+	assert.Equal(t, "[web] new", fields["summary"])
+	assert.Equal(t, []interface{}{Label}, fields["labels"])
+
+	require.NoError(t, client.UpdateSummary("SEC-7", "[web] new"))
+	assert.Equal(t, map[string]interface{}{"summary": "[web] new"}, updated["fields"])
+}
+
+func TestJiraClient_BearerTokenAndErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer pat-token", r.Header.Get("Authorization"))
+		if r.URL.Path == "/rest/api/2/search/jql" {
+			_, _ = w.Write([]byte(`{"issues":[]}`))
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(JiraConfig{URL: server.URL, Project: "SEC", Token: "pat-token"}, 0)
+
+	ticket, err := client.FindOpen("0123456789abcdef")
+	require.NoError(t, err)
+	assert.Nil(t, ticket)
+
+	_, err = client.Create(Ticket{Summary: "x"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "returned 401")
+	assert.NotContains(t, err.Error(), "pat-token")
+}
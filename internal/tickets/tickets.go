@@ -0,0 +1,123 @@
+// Package tickets opens issue tracker tickets (Jira) for the findings of a scan. Tickets are
+// labeled with the finding fingerprint (rule, manifest file, and dependency or component), so
+// repeated scans update the open ticket instead of creating duplicates.
+package tickets
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/analysis"
+)
+
+// Labels of the tickets opened by the analyzer
+const (
+	Label            = "stack-analyzer"
+	FingerprintLabel = "stack-analyzer-" // Followed by the finding fingerprint
+)
+
+// maxSummaryLength is the maximum length of a ticket summary (Jira limit)
+const maxSummaryLength = 255
+
+// levelRank orders finding levels by severity
+var levelRank = map[string]int{
+	analysis.LevelNote:    1,
+	analysis.LevelWarning: 2,
+	analysis.LevelError:   3,
+}
+
+// Ticket is an issue tracker ticket for a finding
+type Ticket struct {
+	Key         string
+	Summary     string
+	Description string
+	Labels      []string
+}
+
+// Tracker finds, creates, and updates tickets in an issue tracker (implemented by JiraClient)
+type Tracker interface {
+	// FindOpen returns the open ticket labeled with the fingerprint, or nil if there is none
+	FindOpen(fingerprint string) (*Ticket, error)
+	Create(ticket Ticket) (string, error)
+	UpdateSummary(key, summary string) error
+}
+
+// SyncResult reports the outcome of a sync
+type SyncResult struct {
+	Created   []string // Keys of new tickets
+	Updated   []string // Keys of open tickets whose summary changed
+	Unchanged []string // Keys of open tickets left as they are
+	Failed    int      // Findings whose ticket could not be looked up or written
+}
+
+// Sync opens a ticket for every finding at or above the level that has no open ticket yet.
+// Open tickets of a finding get the current summary (e.g., a new dependency version).
+// Tracker errors are logged and counted; they do not stop the sync.
+func Sync(tracker Tracker, findings []analysis.Finding, minLevel string, logger *slog.Logger) SyncResult {
+	var result SyncResult
+	for _, finding := range findings {
+		if levelRank[finding.Level] < levelRank[minLevel] {
+			continue
+		}
+		fingerprint := finding.Fingerprint()
+		ticket := NewTicket(finding)
+
+		existing, err := tracker.FindOpen(fingerprint)
+		if err != nil {
+			logger.Error("Failed to search tickets", "rule", finding.RuleID, "subject", finding.Subject, "error", err)
+			result.Failed++
+			continue
+		}
+		if existing == nil {
+			key, err := tracker.Create(ticket)
+			if err != nil {
+				logger.Error("Failed to create ticket", "rule", finding.RuleID, "subject", finding.Subject, "error", err)
+				result.Failed++
+				continue
+			}
+			result.Created = append(result.Created, key)
+			continue
+		}
+		if existing.Summary == ticket.Summary {
+			result.Unchanged = append(result.Unchanged, existing.Key)
+			continue
+		}
+		if err := tracker.UpdateSummary(existing.Key, ticket.Summary); err != nil {
+			logger.Error("Failed to update ticket", "key", existing.Key, "error", err)
+			result.Failed++
+			continue
+		}
+		result.Updated = append(result.Updated, existing.Key)
+	}
+	return result
+}
+
+// NewTicket builds the ticket of a finding
+func NewTicket(finding analysis.Finding) Ticket {
+	summary := fmt.Sprintf("[%s] %s", finding.Component, finding.Message)
+	file := finding.File
+	if finding.Line > 0 {
+		file += fmt.Sprintf(":%d", finding.Line)
+	}
+	if len(summary) > maxSummaryLength {
+		summary = summary[:maxSummaryLength-3] + "..."
+	}
+
+	description := strings.Join([]string{
+		finding.Message,
+		"",
+		"* Rule: " + finding.RuleID,
+		"* Level: " + finding.Level,
+		"* Component: " + finding.Component,
+		"* File: " + file,
+		"",
+		"Opened by stack-analyzer. Later scans match this ticket by its " + FingerprintLabel + "* label; close it once the finding is resolved.",
+	}, "\n")
+
+	return Ticket{
+		Summary:     summary,
+		Description: description,
+		Labels:      []string{Label, FingerprintLabel + finding.Fingerprint()},
+	}
+}
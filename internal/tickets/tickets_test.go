@@ -0,0 +1,98 @@
+package tickets
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/analysis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTracker keeps tickets in memory, keyed by fingerprint label
+type fakeTracker struct {
+	tickets map[string]*Ticket
+	created int
+	fail    bool
+}
+
+func newFakeTracker() *fakeTracker {
+	return &fakeTracker{tickets: make(map[string]*Ticket)}
+}
+
+func (f *fakeTracker) FindOpen(fingerprint string) (*Ticket, error) {
+	if f.fail {
+		return nil, errors.New("unavailable")
+	}
+	return f.tickets[FingerprintLabel+fingerprint], nil
+}
+
+func (f *fakeTracker) Create(ticket Ticket) (string, error) {
+	f.created++
+	ticket.Key = "SEC-" + string(rune('0'+f.created))
+	f.tickets[ticket.Labels[1]] = &ticket
+	return ticket.Key, nil
+}
+
+func (f *fakeTracker) UpdateSummary(key, summary string) error {
+	for _, ticket := range f.tickets {
+		if ticket.Key == key {
+			ticket.Summary = summary
+		}
+	}
+	return nil
+}
+
+func testFindings(version string) []analysis.Finding {
+	return []analysis.Finding{
+		{RuleID: analysis.RuleCopyleftDistributed, Level: analysis.LevelError, Component: "web", File: "web/package.json", Subject: "npm:gpl-lib",
+			Message: "gpl-lib " + version + " (npm) is licensed GPL-3.0-only (strong_copyleft) and distributed with the product"},
+		{RuleID: analysis.RuleUnpinnedDependency, Level: analysis.LevelWarning, Component: "web", File: "web/package.json", Subject: "npm:left-pad",
+			Message: "left-pad * (npm) is not pinned (wildcard)"},
+	}
+}
+
+func TestSync(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tracker := newFakeTracker()
+
+	result := Sync(tracker, testFindings("1.0.0"), analysis.LevelError, logger)
+	assert.Equal(t, []string{"SEC-1"}, result.Created, "warnings are below the level")
+
+	result = Sync(tracker, testFindings("1.0.0"), analysis.LevelError, logger)
+	assert.Empty(t, result.Created, "repeated scans do not create duplicates")
+	assert.Equal(t, []string{"SEC-1"}, result.Unchanged)
+
+	result = Sync(tracker, testFindings("1.1.0"), analysis.LevelError, logger)
+	assert.Equal(t, []string{"SEC-1"}, result.Updated)
+	assert.Contains(t, tracker.tickets[FingerprintLabel+testFindings("1.1.0")[0].Fingerprint()].Summary, "gpl-lib 1.1.0")
+
+	result = Sync(tracker, testFindings("1.1.0"), analysis.LevelWarning, logger)
+	assert.Equal(t, []string{"SEC-2"}, result.Created)
+	assert.Equal(t, 2, tracker.created)
+
+	tracker.fail = true
+	result = Sync(tracker, testFindings("1.1.0"), analysis.LevelWarning, logger)
+	assert.Equal(t, 2, result.Failed)
+}
+
+func TestNewTicket(t *testing.T) {
+	finding := testFindings("1.0.0")[0]
+	ticket := NewTicket(finding)
+
+	assert.Equal(t, "[web] "+finding.Message, ticket.Summary)
+	assert.Equal(t, []string{Label, FingerprintLabel + finding.Fingerprint()}, ticket.Labels)
+	assert.Contains(t, ticket.Description, "* Rule: copyleft-distributed")
+	assert.Contains(t, ticket.Description, "* File: web/package.json")
+
+	finding.Line = 12
+	assert.Contains(t, NewTicket(finding).Description, "* File: web/package.json:12")
+
+	finding.Message = strings.Repeat("x", 300)
+	ticket = NewTicket(finding)
+	require.Len(t, ticket.Summary, maxSummaryLength)
+	assert.True(t, strings.HasSuffix(ticket.Summary, "..."))
+}
@@ -0,0 +1,272 @@
+// Package tui implements the interactive terminal browser for scan results. It reads the
+// JSON written by "stack-analyzer scan" and lets users walk ecosystems, search dependencies,
+// and inspect where each dependency is declared.
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// AllEcosystems is the pseudo ecosystem listing every dependency
+const AllEcosystems = "all"
+
+// component is the subset of a scanned payload needed for browsing
+type component struct {
+	ID            string               `json:"id"`
+	Name          string               `json:"name"`
+	Path          []string             `json:"path"`
+	Type          string               `json:"type"`
+	Tech          []string             `json:"tech"`
+	Dependencies  []types.Dependency   `json:"dependencies"`
+	ComponentRefs []types.ComponentRef `json:"component_refs"`
+	Children      []*component         `json:"children"`
+}
+
+// Results holds the dependencies of a scan, deduplicated across components
+type Results struct {
+	Project    string
+	Entries    []*Entry    // Sorted by ecosystem, name, and version
+	Ecosystems []Ecosystem // "all" first, then by dependency count
+}
+
+// Entry is a unique dependency (ecosystem, name, version) with every place it is declared
+type Entry struct {
+	Type      string
+	Name      string
+	Version   string
+	Locations []Location
+}
+
+// Location is one declaration of a dependency in the component tree
+type Location struct {
+	Chain    []string               // Component names from the root to the declaring component
+	Path     string                 // Manifest path of the declaring component
+	Scope    string                 // prod, dev, test, ...
+	Direct   bool                   // Declared in the manifest (true) or transitive (false)
+	Metadata map[string]interface{} // Parser metadata (source, license_declared, ...)
+	LinksTo  string                 // Name of the in-repo component this dependency resolves to
+}
+
+// Ecosystem is a dependency type with the number of unique dependencies
+type Ecosystem struct {
+	Name  string
+	Count int
+}
+
+// Load reads scan results in the full JSON output format. Aggregated output (--aggregate)
+// is rejected because it has no component tree.
+func Load(r io.Reader) (*Results, error) {
+	var root component
+	if err := json.NewDecoder(r).Decode(&root); err != nil {
+		return nil, fmt.Errorf("failed to parse scan results: %w", err)
+	}
+	if root.ID == "" {
+		return nil, errors.New("not a full scan output: run the scan without --aggregate")
+	}
+
+	names := make(map[string]string)
+	indexComponents(&root, names)
+
+	results := &Results{Project: root.Name}
+	index := make(map[string]*Entry)
+	collectEntries(&root, nil, names, index, results)
+
+	sort.Slice(results.Entries, func(i, j int) bool {
+		a, b := results.Entries[i], results.Entries[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Version < b.Version
+	})
+	results.Ecosystems = countEcosystems(results.Entries)
+	return results, nil
+}
+
+// indexComponents maps component IDs to names for resolving component references
+func indexComponents(comp *component, names map[string]string) {
+	names[comp.ID] = comp.Name
+	for _, child := range comp.Children {
+		indexComponents(child, names)
+	}
+}
+
+// collectEntries walks the component tree and records a location for every declared dependency
+func collectEntries(comp *component, parents []string, names map[string]string, index map[string]*Entry, results *Results) {
+	chain := append(append([]string{}, parents...), comp.Name)
+	path := ""
+	if len(comp.Path) > 0 {
+		path = comp.Path[0]
+	}
+
+	links := make(map[string]string)
+	for _, ref := range comp.ComponentRefs {
+		links[ref.PackageName] = names[ref.TargetID]
+	}
+
+	for _, dep := range comp.Dependencies {
+		key := dep.Type + "|" + dep.Name + "|" + dep.Version
+		entry, ok := index[key]
+		if !ok {
+			entry = &Entry{Type: dep.Type, Name: dep.Name, Version: dep.Version}
+			index[key] = entry
+			results.Entries = append(results.Entries, entry)
+		}
+		entry.Locations = append(entry.Locations, Location{
+			Chain:    chain,
+			Path:     path,
+			Scope:    string(dep.Scope),
+			Direct:   dep.Direct,
+			Metadata: dep.Metadata,
+			LinksTo:  links[dep.Name],
+		})
+	}
+
+	for _, child := range comp.Children {
+		collectEntries(child, chain, names, index, results)
+	}
+}
+
+func countEcosystems(entries []*Entry) []Ecosystem {
+	counts := make(map[string]int)
+	for _, entry := range entries {
+		counts[entry.Type]++
+	}
+
+	ecosystems := make([]Ecosystem, 0, len(counts)+1)
+	for name, count := range counts {
+		ecosystems = append(ecosystems, Ecosystem{Name: name, Count: count})
+	}
+	sort.Slice(ecosystems, func(i, j int) bool {
+		if ecosystems[i].Count != ecosystems[j].Count {
+			return ecosystems[i].Count > ecosystems[j].Count
+		}
+		return ecosystems[i].Name < ecosystems[j].Name
+	})
+	return append([]Ecosystem{{Name: AllEcosystems, Count: len(entries)}}, ecosystems...)
+}
+
+// Filter returns the entries of an ecosystem whose name or license contains the query
+// (case-insensitive)
+func (r *Results) Filter(ecosystem, query string) []*Entry {
+	query = strings.ToLower(strings.TrimSpace(query))
+	var matches []*Entry
+	for _, entry := range r.Entries {
+		if ecosystem != AllEcosystems && entry.Type != ecosystem {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(entry.Name), query) &&
+			!strings.Contains(strings.ToLower(entry.License()), query) {
+			continue
+		}
+		matches = append(matches, entry)
+	}
+	return matches
+}
+
+// License returns the concluded license of the first location that has one, else the
+// first declared license
+func (e *Entry) License() string {
+	declared := ""
+	for _, loc := range e.Locations {
+		if concluded := metadataString(loc.Metadata, "license_concluded"); concluded != "" {
+			return concluded
+		}
+		if declared == "" {
+			declared = metadataString(loc.Metadata, "license_declared")
+		}
+	}
+	return declared
+}
+
+// Direct reports whether any component declares the dependency directly
+func (e *Entry) Direct() bool {
+	for _, loc := range e.Locations {
+		if loc.Direct {
+			return true
+		}
+	}
+	return false
+}
+
+// DetailLines renders the detail pane content of an entry
+func (e *Entry) DetailLines() []string {
+	version := e.Version
+	if version == "" {
+		version = "(unversioned)"
+	}
+	lines := []string{
+		e.Name,
+		"",
+		"Ecosystem:  " + e.Type,
+		"Version:    " + version,
+		"License:    " + valueOrNone(e.License()),
+	}
+
+	for i, loc := range e.Locations {
+		lines = append(lines, "", fmt.Sprintf("Declared in (%d/%d):", i+1, len(e.Locations)))
+		lines = append(lines, "  "+strings.Join(loc.Chain, " > "))
+		if loc.Path != "" {
+			lines = append(lines, "  Path:   "+loc.Path)
+		}
+		relation := "transitive"
+		if loc.Direct {
+			relation = "direct"
+		}
+		lines = append(lines, "  Scope:  "+valueOrNone(loc.Scope)+", "+relation)
+		if loc.LinksTo != "" {
+			lines = append(lines, "  Links:  component "+loc.LinksTo)
+		}
+
+		keys := make([]string, 0, len(loc.Metadata))
+		for key := range loc.Metadata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			lines = append(lines, fmt.Sprintf("  %s: %s", key, formatValue(loc.Metadata[key])))
+		}
+	}
+	return lines
+}
+
+func metadataString(metadata map[string]interface{}, key string) string {
+	value, _ := metadata[key].(string)
+	return value
+}
+
+// formatValue renders a metadata value on a single line
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = formatValue(item)
+		}
+		return strings.Join(parts, ", ")
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testResults = `{
+  "id": "root", "name": "shop", "path": ["/"],
+  "dependencies": [["docker", "postgres", "16", "", true, {"source": "docker-compose.yml"}]],
+  "children": [
+    {
+      "id": "web", "name": "web", "path": ["/web/package.json"], "type": "nodejs",
+      "dependencies": [
+        ["npm", "react", "18.2.0", "prod", true, {"source": "package-lock.json", "license_declared": "MIT"}],
+        ["npm", "loose-envify", "1.4.0", "prod", false, {"source": "package-lock.json", "license_declared": "MIT"}],
+        ["npm", "@shop/ui", "1.0.0", "prod", true, {}]
+      ],
+      "component_refs": [{"target_id": "ui", "package_name": "@shop/ui"}],
+      "children": []
+    },
+    {
+      "id": "ui", "name": "@shop/ui", "path": ["/ui/package.json"], "type": "nodejs",
+      "dependencies": [
+        ["npm", "react", "18.2.0", "peer", true, {"source": "package.json", "license_declared": "MIT", "license_concluded": "MIT", "peer": true}],
+        ["npm", "gpl-lib", "2.0.0", "dev", true, {"license_declared": "GPL-3.0-only", "authors": ["Jane", "John"]}]
+      ],
+      "children": []
+    }
+  ]
+}`
+
+func loadTestResults(t *testing.T) *Results {
+	t.Helper()
+	results, err := Load(strings.NewReader(testResults))
+	require.NoError(t, err)
+	return results
+}
+
+func TestLoad(t *testing.T) {
+	results := loadTestResults(t)
+
+	assert.Equal(t, "shop", results.Project)
+	assert.Equal(t, []Ecosystem{{Name: AllEcosystems, Count: 5}, {Name: "npm", Count: 4}, {Name: "docker", Count: 1}}, results.Ecosystems)
+
+	var names []string
+	for _, entry := range results.Entries {
+		names = append(names, entry.Type+":"+entry.Name)
+	}
+	assert.Equal(t, []string{"docker:postgres", "npm:@shop/ui", "npm:gpl-lib", "npm:loose-envify", "npm:react"}, names)
+
+	react := results.Entries[4]
+	require.Len(t, react.Locations, 2, "react is declared by web and ui")
+	assert.Equal(t, []string{"shop", "web"}, react.Locations[0].Chain)
+	assert.Equal(t, "/web/package.json", react.Locations[0].Path)
+	assert.Equal(t, "peer", react.Locations[1].Scope)
+	assert.Equal(t, "@shop/ui", results.Entries[1].Locations[0].LinksTo)
+
+	_, err := Load(strings.NewReader(`{"metadata": {"format": "aggregated"}, "techs": ["react"]}`))
+	assert.ErrorContains(t, err, "--aggregate")
+	_, err = Load(strings.NewReader(`{`))
+	assert.Error(t, err)
+}
+
+func TestResultsFilter(t *testing.T) {
+	results := loadTestResults(t)
+
+	assert.Len(t, results.Filter(AllEcosystems, ""), 5)
+	assert.Len(t, results.Filter("docker", ""), 1)
+	assert.Len(t, results.Filter("npm", "REACT"), 1)
+	assert.Len(t, results.Filter(AllEcosystems, "gpl"), 1, "matches licenses")
+	assert.Empty(t, results.Filter("docker", "react"))
+}
+
+func TestEntryLicenseAndDirect(t *testing.T) {
+	results := loadTestResults(t)
+
+	assert.Equal(t, "MIT", results.Entries[4].License(), "concluded license of the second location wins")
+	assert.Equal(t, "GPL-3.0-only", results.Entries[2].License())
+	assert.Equal(t, "", results.Entries[0].License())
+	assert.True(t, results.Entries[4].Direct())
+	assert.False(t, results.Entries[3].Direct())
+}
+
+func TestEntryDetailLines(t *testing.T) {
+	results := loadTestResults(t)
+
+	lines := results.Entries[2].DetailLines()
+	assert.Equal(t, []string{
+		"gpl-lib",
+		"",
+		"Ecosystem:  npm",
+		"Version:    2.0.0",
+		"License:    GPL-3.0-only",
+		"",
+		"Declared in (1/1):",
+		"  shop > @shop/ui",
+		"  Path:   /ui/package.json",
+		"  Scope:  dev, direct",
+		"  authors: Jane, John",
+		"  license_declared: GPL-3.0-only",
+	}, lines)
+
+	lines = results.Entries[1].DetailLines()
+	assert.Contains(t, lines, "  Links:  component @shop/ui")
+
+	lines = results.Entries[4].DetailLines()
+	assert.Contains(t, lines, "Declared in (2/2):")
+	assert.Contains(t, lines, "  peer: true")
+}
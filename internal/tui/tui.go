@@ -0,0 +1,300 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pane identifies the focused column
+type pane int
+
+const (
+	paneEcosystems pane = iota
+	paneDependencies
+	paneDetail
+	paneCount
+)
+
+var (
+	borderStyle  = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("240"))
+	focusStyle   = borderStyle.BorderForeground(lipgloss.Color("63"))
+	titleStyle   = lipgloss.NewStyle().Bold(true)
+	cursorStyle  = lipgloss.NewStyle().Reverse(true)
+	dimStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	helpText     = "tab: switch pane  up/down: move  /: search  esc: clear search  q: quit"
+	searchPrompt = "/ "
+)
+
+// Model is the bubbletea model of the results browser
+type Model struct {
+	results   *Results
+	visible   []*Entry
+	focus     pane
+	ecoCursor int
+	depCursor int
+	depOffset int
+	detailTop int
+	search    textinput.Model
+	searching bool
+	width     int
+	height    int
+}
+
+// NewModel creates the browser model for loaded results
+func NewModel(results *Results) Model {
+	search := textinput.New()
+	search.Prompt = searchPrompt
+	search.Placeholder = "name or license"
+
+	m := Model{results: results, search: search, focus: paneDependencies, width: 120, height: 30}
+	m.applyFilter()
+	return m
+}
+
+// Run starts the browser in the alternate screen and blocks until the user quits
+func Run(results *Results) error {
+	_, err := tea.NewProgram(NewModel(results), tea.WithAltScreen()).Run()
+	return err
+}
+
+// Init implements tea.Model
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scrollToCursor()
+		return m, nil
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+		return m.updateBrowse(msg)
+	}
+	return m, nil
+}
+
+// updateSearch edits the search query; the list is filtered as the user types
+func (m Model) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.searching = false
+		m.search.Blur()
+		return m, nil
+	case tea.KeyEsc:
+		m.searching = false
+		m.search.Blur()
+		m.search.SetValue("")
+		m.applyFilter()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.search, cmd = m.search.Update(msg)
+	m.applyFilter()
+	return m, cmd
+}
+
+func (m Model) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "/":
+		m.searching = true
+		m.focus = paneDependencies
+		return m, m.search.Focus()
+	case "esc":
+		if m.search.Value() != "" {
+			m.search.SetValue("")
+			m.applyFilter()
+		}
+	case "tab", "right", "l":
+		m.focus = (m.focus + 1) % paneCount
+	case "shift+tab", "left", "h":
+		m.focus = (m.focus + paneCount - 1) % paneCount
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "pgup":
+		m.move(-m.listHeight())
+	case "pgdown":
+		m.move(m.listHeight())
+	case "home", "g":
+		m.move(-len(m.results.Entries) - len(m.results.Ecosystems))
+	case "end", "G":
+		m.move(len(m.results.Entries) + len(m.results.Ecosystems))
+	}
+	return m, nil
+}
+
+// move shifts the cursor of the focused pane by delta, clamped to its bounds
+func (m *Model) move(delta int) {
+	switch m.focus {
+	case paneEcosystems:
+		cursor := clamp(m.ecoCursor+delta, 0, len(m.results.Ecosystems)-1)
+		if cursor != m.ecoCursor {
+			m.ecoCursor = cursor
+			m.applyFilter()
+		}
+	case paneDependencies:
+		m.depCursor = clamp(m.depCursor+delta, 0, len(m.visible)-1)
+		m.detailTop = 0
+		m.scrollToCursor()
+	case paneDetail:
+		m.detailTop = clamp(m.detailTop+delta, 0, len(m.detailLines())-1)
+	}
+}
+
+// applyFilter recomputes the visible dependencies and resets the dependency cursor
+func (m *Model) applyFilter() {
+	m.visible = m.results.Filter(m.Ecosystem(), m.search.Value())
+	m.depCursor, m.depOffset, m.detailTop = 0, 0, 0
+}
+
+// scrollToCursor keeps the dependency cursor inside the visible window
+func (m *Model) scrollToCursor() {
+	height := m.listHeight()
+	if m.depCursor < m.depOffset {
+		m.depOffset = m.depCursor
+	} else if m.depCursor >= m.depOffset+height {
+		m.depOffset = m.depCursor - height + 1
+	}
+}
+
+// Ecosystem returns the selected ecosystem
+func (m Model) Ecosystem() string {
+	if len(m.results.Ecosystems) == 0 {
+		return AllEcosystems
+	}
+	return m.results.Ecosystems[m.ecoCursor].Name
+}
+
+// Selected returns the dependency under the cursor, or nil if the list is empty
+func (m Model) Selected() *Entry {
+	if m.depCursor >= len(m.visible) {
+		return nil
+	}
+	return m.visible[m.depCursor]
+}
+
+func (m Model) detailLines() []string {
+	if entry := m.Selected(); entry != nil {
+		return entry.DetailLines()
+	}
+	return []string{"No dependencies match."}
+}
+
+// listHeight is the number of content rows inside a pane (screen minus header, footer,
+// pane title, and borders)
+func (m Model) listHeight() int {
+	return max(m.height-5, 1)
+}
+
+// View implements tea.Model
+func (m Model) View() string {
+	ecoWidth := max(m.width/5, 18)
+	depWidth := max((m.width-ecoWidth)*2/5, 24)
+	detailWidth := max(m.width-ecoWidth-depWidth, 24)
+
+	header := titleStyle.Render("Stack Analyzer: "+m.results.Project) +
+		dimStyle.Render(fmt.Sprintf("  %d dependencies", len(m.results.Entries)))
+	body := lipgloss.JoinHorizontal(lipgloss.Top,
+		m.renderPane(paneEcosystems, "Ecosystems", m.ecosystemLines(), m.ecoCursor, 0, ecoWidth),
+		m.renderPane(paneDependencies, fmt.Sprintf("Dependencies (%d)", len(m.visible)), m.dependencyLines(), m.depCursor, m.depOffset, depWidth),
+		m.renderPane(paneDetail, "Details", m.detailLines(), -1, m.detailTop, detailWidth),
+	)
+
+	footer := dimStyle.Render(helpText)
+	if m.searching || m.search.Value() != "" {
+		footer = m.search.View()
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, header, body, footer)
+}
+
+// ecosystemLines renders the ecosystems as a tree below "all"
+func (m Model) ecosystemLines() []string {
+	lines := make([]string, len(m.results.Ecosystems))
+	for i, eco := range m.results.Ecosystems {
+		branch := ""
+		switch {
+		case i == 0:
+		case i == len(m.results.Ecosystems)-1:
+			branch = "└ "
+		default:
+			branch = "├ "
+		}
+		lines[i] = fmt.Sprintf("%s%s (%d)", branch, eco.Name, eco.Count)
+	}
+	return lines
+}
+
+func (m Model) dependencyLines() []string {
+	lines := make([]string, len(m.visible))
+	for i, entry := range m.visible {
+		line := entry.Name
+		if entry.Version != "" {
+			line += " " + entry.Version
+		}
+		if m.Ecosystem() == AllEcosystems {
+			line = entry.Type + "  " + line
+		}
+		if !entry.Direct() {
+			line += " (transitive)"
+		}
+		lines[i] = line
+	}
+	return lines
+}
+
+// renderPane draws a bordered column with a title, highlighting the cursor row
+func (m Model) renderPane(id pane, title string, lines []string, cursor, offset, width int) string {
+	inner := width - 2
+	height := m.listHeight()
+
+	rows := []string{titleStyle.Render(truncate(title, inner))}
+	end := min(offset+height, len(lines))
+	for i := offset; i < end; i++ {
+		line := truncate(lines[i], inner)
+		if i == cursor {
+			line = cursorStyle.Render(line + strings.Repeat(" ", max(inner-lipgloss.Width(line), 0)))
+		}
+		rows = append(rows, line)
+	}
+
+	style := borderStyle
+	if id == m.focus {
+		style = focusStyle
+	}
+	return style.Width(inner).Height(height + 1).Render(strings.Join(rows, "\n"))
+}
+
+// truncate shortens a line to width runes, marking the cut with "~"
+func truncate(line string, width int) string {
+	runes := []rune(line)
+	if width <= 0 {
+		return ""
+	}
+	if len(runes) <= width {
+		return line
+	}
+	return string(runes[:width-1]) + "~"
+}
+
+func clamp(value, low, high int) int {
+	if high < low {
+		return low
+	}
+	return min(max(value, low), high)
+}
@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func press(t *testing.T, m Model, keys ...tea.KeyMsg) Model {
+	t.Helper()
+	for _, key := range keys {
+		updated, _ := m.Update(key)
+		m = updated.(Model)
+	}
+	return m
+}
+
+func runes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestModelNavigation(t *testing.T) {
+	m := NewModel(loadTestResults(t))
+	require.Equal(t, "postgres", m.Selected().Name)
+
+	m = press(t, m, runes("j"), tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, "gpl-lib", m.Selected().Name)
+	m = press(t, m, runes("G"))
+	assert.Equal(t, "react", m.Selected().Name)
+
+	m = press(t, m, tea.KeyMsg{Type: tea.KeyShiftTab}, runes("j"))
+	assert.Equal(t, "npm", m.Ecosystem())
+	assert.Len(t, m.visible, 4)
+	assert.Equal(t, "@shop/ui", m.Selected().Name, "changing the ecosystem resets the cursor")
+
+	m = press(t, m, runes("j"), runes("j"), runes("j"))
+	assert.Equal(t, "docker", m.Ecosystem(), "cursor stops at the last ecosystem")
+
+	m = press(t, m, tea.KeyMsg{Type: tea.KeyTab}, tea.KeyMsg{Type: tea.KeyTab}, runes("j"))
+	assert.Equal(t, paneDetail, m.focus)
+	assert.Equal(t, 1, m.detailTop)
+}
+
+func TestModelSearch(t *testing.T) {
+	m := NewModel(loadTestResults(t))
+
+	m = press(t, m, runes("/"), runes("r"), runes("e"), runes("a"))
+	assert.True(t, m.searching)
+	require.Len(t, m.visible, 1)
+	assert.Equal(t, "react", m.Selected().Name)
+
+	m = press(t, m, runes("q"))
+	assert.True(t, m.searching, "q is part of the query while searching")
+	assert.Empty(t, m.visible)
+
+	m = press(t, m, tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, m.searching)
+	assert.Len(t, m.visible, 1, "the filter stays after confirming")
+
+	m = press(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Len(t, m.visible, 5, "esc clears the filter")
+
+	_, cmd := m.Update(runes("q"))
+	require.NotNil(t, cmd)
+	assert.Equal(t, tea.Quit(), cmd())
+}
+
+func TestModelView(t *testing.T) {
+	m := NewModel(loadTestResults(t))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 20})
+	m = updated.(Model)
+
+	view := m.View()
+	assert.Contains(t, view, "Stack Analyzer: shop")
+	assert.Contains(t, view, "npm (4)")
+	assert.Contains(t, view, "loose-envify 1.4.0 (transitive)")
+	assert.Contains(t, view, "Ecosystem:  docker")
+
+	m = press(t, m, runes("/"), runes("zzz"))
+	assert.Contains(t, m.View(), "No dependencies match.")
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "react", truncate("react", 5))
+	assert.Equal(t, "rea~", truncate("react", 4))
+	assert.Equal(t, "", truncate("react", 0))
+}
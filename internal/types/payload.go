@@ -31,6 +31,7 @@ type Payload struct {
 	Edges            []Edge                 `json:"edges"`
 	ComponentRefs    []ComponentRef         `json:"component_refs,omitempty"` // Inter-component references (outgoing - components this component depends on)
 	CodeStats        interface{}            `json:"code_stats,omitempty"`
+	Analysis         interface{}            `json:"analysis,omitempty"` // Project-level analysis results (root payload only)
 }
 
 // Edge represents a relationship between components and technologies
@@ -74,8 +75,26 @@ func (e Edge) MarshalJSON() ([]byte, error) {
 	return json.Marshal(edgeMap)
 }
 
-// NewPayload creates a new payload with a temporary ID (will be finalized by AssignIDs)
+// UnmarshalJSON reads the edge format written by MarshalJSON; the target is a payload holding
+// only the ID
+func (e *Edge) UnmarshalJSON(data []byte) error {
+	var edge struct {
+		Target string `json:"target"`
+	}
+	if err := json.Unmarshal(data, &edge); err != nil {
+		return err
+	}
+	e.Target = &Payload{ID: edge.Target}
+	return nil
+}
+
+// NewPayload creates a new payload with a temporary ID (will be finalized by AssignIDs).
+// Paths are normalized to forward slashes, so output is the same on all platforms.
 func NewPayload(name string, paths []string) *Payload {
+	for i, path := range paths {
+		paths[i] = filepath.ToSlash(path)
+	}
+
 	// Use first path for temporary ID generation
 	var relativePath string
 	if len(paths) > 0 {
@@ -124,7 +143,7 @@ func CalculateRelativePath(fileName, currentPath, basePath string) string {
 	if relativeFilePath == "." {
 		return "/"
 	}
-	return "/" + relativeFilePath
+	return "/" + filepath.ToSlash(relativeFilePath)
 }
 
 // SetComponentProperty sets a property for a component technology.
@@ -250,6 +269,7 @@ func hasOverlappingPath(paths1, paths2 []string) bool {
 
 // AddPath adds a path to the payload, deduplicating entries
 func (p *Payload) AddPath(path string) {
+	path = filepath.ToSlash(path)
 	// Check for duplicate
 	for _, existing := range p.Path {
 		if existing == path {
@@ -372,6 +392,26 @@ func (p *Payload) mergeReasons(reasons map[string][]string) {
 	}
 }
 
+// arrayProperties are properties holding lists that are collected from several directories;
+// they are concatenated when payloads are combined instead of being overwritten
+var arrayProperties = map[string]bool{
+	"docker":            true,
+	"terraform":         true,
+	"dotnet_solution":   true,
+	"ml_models":         true,
+	"ml_notebooks":      true,
+	"buf":               true,
+	"graphql_configs":   true,
+	"graphql_documents": true,
+	"serverless":        true,
+	"iac":               true,
+	"packer":            true,
+	"tool_invocations":  true,
+	"devcontainer":      true,
+	"code_quality":      true,
+	"artifacts":         true,
+}
+
 func (p *Payload) mergeProperties(properties map[string]interface{}) {
 	if len(properties) == 0 {
 		return
@@ -380,8 +420,8 @@ func (p *Payload) mergeProperties(properties map[string]interface{}) {
 		p.Properties = make(map[string]interface{})
 	}
 	for key, value := range properties {
-		// Special handling for array properties (docker, terraform) - merge arrays
-		if key == "docker" || key == "terraform" {
+		// Special handling for array properties - merge arrays
+		if arrayProperties[key] {
 			existing, existsInP := p.Properties[key]
 			newArray, isArray := value.([]interface{})
 
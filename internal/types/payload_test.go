@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -359,3 +360,14 @@ func TestPayload_EdgeCases(t *testing.T) {
 		assert.NotEqual(t, "", payload.String())
 	})
 }
+
+func TestEdge_JSONRoundTrip(t *testing.T) {
+	edge := Edge{Target: &Payload{ID: "abc", Name: "postgres"}}
+	data, err := json.Marshal(edge)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"target":"abc"}`, string(data))
+
+	var read Edge
+	assert.NoError(t, json.Unmarshal(data, &read))
+	assert.Equal(t, "abc", read.Target.ID)
+}
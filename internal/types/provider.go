@@ -30,6 +30,7 @@ type File struct {
 	Type     string `json:"type"` // "file" or "dir"
 	Size     int64  `json:"size"`
 	Modified int64  `json:"modified"`
+	Symlink  bool   `json:"symlink,omitempty"` // Symbolic link; Type, Size, and Modified are of its target
 }
 
 // FileReader defines an interface for reading file content
@@ -1,23 +1,61 @@
 package types
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"regexp"
+	"strings"
 )
 
+// Scope is the scope of a dependency: how the project uses it (prod, dev, test, build, ...).
+// The empty scope means unknown.
+type Scope string
+
 // Dependency scope constants
 const (
-	ScopeProd     = "prod"
-	ScopeDev      = "dev"
-	ScopeTest     = "test"
-	ScopeBuild    = "build"
-	ScopeOptional = "optional"
-	ScopePeer     = "peer"
+	ScopeProd     Scope = "prod"
+	ScopeDev      Scope = "dev"
+	ScopeTest     Scope = "test"
+	ScopeBuild    Scope = "build"
+	ScopeOptional Scope = "optional"
+	ScopePeer     Scope = "peer"
 	// Maven-specific scopes
-	ScopeSystem = "system"
-	ScopeImport = "import"
+	ScopeSystem Scope = "system"
+	ScopeImport Scope = "import"
 )
 
+// scopes are the valid dependency scopes
+var scopes = map[Scope]bool{
+	ScopeProd: true, ScopeDev: true, ScopeTest: true, ScopeBuild: true,
+	ScopeOptional: true, ScopePeer: true, ScopeSystem: true, ScopeImport: true,
+}
+
+// scopeAliases maps the scope names of package managers to the scope constants
+var scopeAliases = map[string]Scope{
+	"production": ScopeProd, "runtime": ScopeProd, "compile": ScopeProd, "provided": ScopeProd, "main": ScopeProd,
+	"development": ScopeDev, "develop": ScopeDev, "testing": ScopeTest, "tests": ScopeTest,
+}
+
+// IsValid reports whether the scope is one of the scope constants or empty (unknown)
+func (s Scope) IsValid() bool {
+	return s == "" || scopes[s]
+}
+
+// NormalizeScope returns the scope constant of a scope name, mapping the scope names of
+// package managers (development, runtime) to their constant. Returns false for unknown scopes.
+func NormalizeScope(scope string) (Scope, bool) {
+	if Scope(scope).IsValid() {
+		return Scope(scope), true
+	}
+	lower := Scope(strings.ToLower(strings.TrimSpace(scope)))
+	if scopes[lower] {
+		return lower, true
+	}
+	alias, ok := scopeAliases[string(lower)]
+	return alias, ok
+}
+
 // NewMetadata creates a new metadata map with the source field set
 // This helper eliminates code duplication across parsers
 func NewMetadata(source string) map[string]interface{} {
@@ -42,15 +80,20 @@ type Rule struct {
 	Content       []ContentRule          `yaml:"content,omitempty" json:"content,omitempty"`
 }
 
+// metadataNormalizedVersion is the metadata key of the normalized version in the array format
+// of dependencies
+const metadataNormalizedVersion = "normalized_version"
+
 // Dependency represents a dependency pattern (struct for YAML, but marshals as array for JSON)
 type Dependency struct {
-	Type       string                 `yaml:"type" json:"type"`
-	Name       string                 `yaml:"name" json:"name"`
-	Version    string                 `yaml:"version,omitempty" json:"version,omitempty"`
-	Scope      string                 `yaml:"scope,omitempty" json:"scope,omitempty"`
-	Direct     bool                   `yaml:"direct" json:"direct"`                               // Direct (true) vs transitive (false) dependency
-	SourceFile string                 `yaml:"source_file,omitempty" json:"source_file,omitempty"` // Deprecated: use metadata.source instead
-	Metadata   map[string]interface{} `yaml:"metadata,omitempty" json:"metadata,omitempty"`       // Package-specific metadata (source, type, classifier, optional, exclusions, peer, etc.)
+	Type              string                 `yaml:"type" json:"type"`
+	Name              string                 `yaml:"name" json:"name"`
+	Version           string                 `yaml:"version,omitempty" json:"version,omitempty"`
+	NormalizedVersion string                 `yaml:"normalized_version,omitempty" json:"normalized_version,omitempty"` // Canonical form of a concrete version, when it differs from Version
+	Scope             Scope                  `yaml:"scope,omitempty" json:"scope,omitempty"`
+	Direct            bool                   `yaml:"direct" json:"direct"`                               // Direct (true) vs transitive (false) dependency
+	SourceFile        string                 `yaml:"source_file,omitempty" json:"source_file,omitempty"` // Deprecated: use metadata.source instead
+	Metadata          map[string]interface{} `yaml:"metadata,omitempty" json:"metadata,omitempty"`       // Package-specific metadata (source, type, classifier, optional, exclusions, peer, etc.)
 }
 
 // MarshalJSON converts Dependency struct to array format [type, name, version, scope, direct, {metadata}]
@@ -58,13 +101,23 @@ type Dependency struct {
 // - [type, name, version, scope, direct, {metadata}]
 // - scope: "prod", "dev", "test", "build", "optional", "peer", etc. (empty string if unknown)
 // - direct: true (declared in manifest) or false (transitive)
-// - metadata: optional object with source, type, classifier, exclusions, peer, optional, bundled, etc.
+// - metadata: optional object with source, type, classifier, exclusions, peer, optional, bundled,
+// normalized_version, etc.
 func (d Dependency) MarshalJSON() ([]byte, error) {
 	// Build metadata object
 	metadata := d.Metadata
 	if metadata == nil {
 		metadata = make(map[string]interface{})
 	}
+	if d.NormalizedVersion != "" {
+		// Copy the metadata: dependencies of a manifest may share a single metadata map
+		withVersion := make(map[string]interface{}, len(metadata)+1)
+		for key, value := range metadata {
+			withVersion[key] = value
+		}
+		withVersion[metadataNormalizedVersion] = d.NormalizedVersion
+		metadata = withVersion
+	}
 
 	// Add source file to metadata if present (migrate from deprecated SourceFile field)
 	if d.SourceFile != "" {
@@ -82,6 +135,38 @@ func (d Dependency) MarshalJSON() ([]byte, error) {
 	return json.Marshal([]interface{}{d.Type, d.Name, d.Version, d.Scope, d.Direct, metadata})
 }
 
+// UnmarshalJSON reads the array format written by MarshalJSON (trailing elements may be
+// omitted) as well as the object format. The normalized_version of the metadata of the
+// array format is read into NormalizedVersion.
+func (d *Dependency) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '[' {
+		type plain Dependency
+		return json.Unmarshal(data, (*plain)(d))
+	}
+
+	var fields []json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	targets := []interface{}{&d.Type, &d.Name, &d.Version, &d.Scope, &d.Direct, &d.Metadata}
+	if len(fields) > len(targets) {
+		return fmt.Errorf("dependency has %d elements, expected at most %d", len(fields), len(targets))
+	}
+	for i, field := range fields {
+		if err := json.Unmarshal(field, targets[i]); err != nil {
+			return fmt.Errorf("dependency element %d: %w", i, err)
+		}
+	}
+	if normalized, ok := d.Metadata[metadataNormalizedVersion].(string); ok {
+		d.NormalizedVersion = normalized
+		delete(d.Metadata, metadataNormalizedVersion)
+	}
+	if len(d.Metadata) == 0 {
+		d.Metadata = nil
+	}
+	return nil
+}
+
 // CompiledDependency is a pre-compiled dependency for performance
 type CompiledDependency struct {
 	Regex *regexp.Regexp
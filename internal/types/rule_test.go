@@ -0,0 +1,76 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependency_UnmarshalJSON(t *testing.T) {
+	original := Dependency{Type: "npm", Name: "react", Version: "18.2.0", Scope: ScopeProd, Direct: true, Metadata: NewMetadata("package.json")}
+	data, err := json.Marshal(original)
+	require.NoError(t, err)
+
+	var decoded Dependency
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, original, decoded, "array format round-trips")
+
+	var short Dependency
+	require.NoError(t, json.Unmarshal([]byte(`["npm","react","^18",""]`), &short))
+	assert.Equal(t, Dependency{Type: "npm", Name: "react", Version: "^18"}, short)
+
+	var empty Dependency
+	require.NoError(t, json.Unmarshal([]byte(`["npm","react","","",false,{}]`), &empty))
+	assert.Nil(t, empty.Metadata)
+
+	var object Dependency
+	require.NoError(t, json.Unmarshal([]byte(`{"type":"maven","name":"junit:junit","scope":"test"}`), &object))
+	assert.Equal(t, Dependency{Type: "maven", Name: "junit:junit", Scope: ScopeTest}, object)
+
+	assert.Error(t, json.Unmarshal([]byte(`["npm","a","","",false,{},"extra"]`), &Dependency{}))
+	assert.Error(t, json.Unmarshal([]byte(`["npm",1]`), &Dependency{}))
+}
+
+func TestDependency_NormalizedVersionJSON(t *testing.T) {
+	shared := NewMetadata("package.json")
+	dep := Dependency{Type: "npm", Name: "react", Version: "v18.2.0", NormalizedVersion: "18.2.0", Scope: ScopeProd, Direct: true, Metadata: shared}
+	data, err := json.Marshal(dep)
+	require.NoError(t, err)
+	assert.JSONEq(t, `["npm","react","v18.2.0","prod",true,{"source":"package.json","normalized_version":"18.2.0"}]`, string(data))
+	assert.NotContains(t, shared, "normalized_version", "shared metadata is not modified")
+
+	var decoded Dependency
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, dep, decoded)
+
+	var bare Dependency
+	require.NoError(t, json.Unmarshal([]byte(`["npm","react","v18.2.0","",false,{"normalized_version":"18.2.0"}]`), &bare))
+	assert.Equal(t, Dependency{Type: "npm", Name: "react", Version: "v18.2.0", NormalizedVersion: "18.2.0"}, bare)
+}
+
+func TestNormalizeScope(t *testing.T) {
+	tests := []struct {
+		scope    string
+		expected Scope
+		valid    bool
+	}{
+		{"peer", ScopePeer, true},
+		{"", "", true},
+		{"Test", ScopeTest, true},
+		{"development", ScopeDev, true},
+		{"runtime", ScopeProd, true},
+		{"compile", ScopeProd, true},
+		{"bogus", "", false},
+	}
+	for _, tt := range tests {
+		scope, ok := NormalizeScope(tt.scope)
+		assert.Equal(t, tt.expected, scope, tt.scope)
+		assert.Equal(t, tt.valid, ok, tt.scope)
+	}
+
+	assert.True(t, ScopeOptional.IsValid())
+	assert.True(t, Scope("").IsValid())
+	assert.False(t, Scope("development").IsValid())
+}
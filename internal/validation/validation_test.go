@@ -111,6 +111,10 @@ func TestValidateJSON_ValidConfig(t *testing.T) {
 			"output_file": "output.json",
 			"pretty":      true,
 			"aggregate":   "tech,dependencies",
+			"complexity_thresholds": map[string]interface{}{
+				"max_direct_dependencies": 50,
+				"max_ecosystems":          3,
+			},
 		},
 	}
 
@@ -162,6 +166,17 @@ func TestValidateJSON_InvalidConfig(t *testing.T) {
 			},
 			expect: "additionalProperties",
 		},
+		{
+			name: "unknown complexity threshold",
+			config: map[string]interface{}{
+				"scan": map[string]interface{}{
+					"complexity_thresholds": map[string]interface{}{
+						"max_dependencies": 50,
+					},
+				},
+			},
+			expect: "additionalProperties",
+		},
 	}
 
 	for _, tt := range tests {